@@ -32,6 +32,8 @@ import (
 // MainAccount main account info
 type MainAccount struct {
 	*apicoreaccount.BaseMainAccount
+	// TenantID 主账号所属租户
+	TenantID string
 }
 
 // Key main account key
@@ -46,6 +48,8 @@ func (a *MainAccount) Key() string {
 // RootAccount root account info
 type RootAccount struct {
 	*apicoreaccount.BaseRootAccount
+	// TenantID 根账号所属租户
+	TenantID string
 }
 
 // Key root account key
@@ -68,8 +72,26 @@ type HcmAccountLister struct {
 	Client *client.ClientSet
 }
 
-// ListAllMainAccount list main account
+// ListAllMainAccount list main account of all tenants
 func (t *HcmAccountLister) ListAllMainAccount(kt *kit.Kit) ([]*MainAccount, error) {
+	tenantResult, err := t.Client.DataService().Global.Tenant.ListIDs(kt)
+	if err != nil {
+		return nil, fmt.Errorf("list tenant ids failed, err %s", err.Error())
+	}
+
+	var retList []*MainAccount
+	for _, tenantID := range tenantResult.IDs {
+		accounts, err := t.listTenantMainAccount(kt.WithTenant(tenantID))
+		if err != nil {
+			return nil, err
+		}
+		retList = append(retList, accounts...)
+	}
+	return retList, nil
+}
+
+// listTenantMainAccount list main account of the kit's tenant
+func (t *HcmAccountLister) listTenantMainAccount(kt *kit.Kit) ([]*MainAccount, error) {
 	result, err := t.Client.DataService().Global.MainAccount.List(kt, &core.ListReq{
 		Filter: tools.AllExpression(),
 		Page: &core.BasePage{
@@ -94,14 +116,33 @@ func (t *HcmAccountLister) ListAllMainAccount(kt *kit.Kit) ([]*MainAccount, erro
 		for _, item := range accountResult.Details {
 			retList = append(retList, &MainAccount{
 				BaseMainAccount: item,
+				TenantID:        kt.TenantID,
 			})
 		}
 	}
 	return retList, nil
 }
 
-// ListAllRootAccount list root account
+// ListAllRootAccount list root account of all tenants
 func (t *HcmAccountLister) ListAllRootAccount(kt *kit.Kit) ([]*RootAccount, error) {
+	tenantResult, err := t.Client.DataService().Global.Tenant.ListIDs(kt)
+	if err != nil {
+		return nil, fmt.Errorf("list tenant ids failed, err %s", err.Error())
+	}
+
+	var retList []*RootAccount
+	for _, tenantID := range tenantResult.IDs {
+		accounts, err := t.listTenantRootAccount(kt.WithTenant(tenantID))
+		if err != nil {
+			return nil, err
+		}
+		retList = append(retList, accounts...)
+	}
+	return retList, nil
+}
+
+// listTenantRootAccount list root account of the kit's tenant
+func (t *HcmAccountLister) listTenantRootAccount(kt *kit.Kit) ([]*RootAccount, error) {
 	listReq := &core.ListReq{Filter: tools.AllExpression(), Page: core.NewCountPage()}
 	result, err := t.Client.DataService().Global.RootAccount.List(kt, listReq)
 	if err != nil {
@@ -123,6 +164,7 @@ func (t *HcmAccountLister) ListAllRootAccount(kt *kit.Kit) ([]*RootAccount, erro
 		for _, item := range accountResult.Details {
 			retList = append(retList, &RootAccount{
 				BaseRootAccount: item,
+				TenantID:        kt.TenantID,
 			})
 		}
 	}
@@ -69,6 +69,7 @@ func NewMainDailySplitController(opt *MainAccountControllerOption) (*MainDailySp
 		ProductID:     opt.ProductID,
 		BkBizID:       opt.BkBizID,
 		Vendor:        opt.Vendor,
+		TenantID:      opt.TenantID,
 
 		RootAccountCloudID: opt.RootAccountCloudID,
 		MainAccountCloudID: opt.MainAccountCloudID,
@@ -83,6 +84,7 @@ type MainDailySplitController struct {
 	ProductID     int64
 	BkBizID       int64
 	Vendor        enumor.Vendor
+	TenantID      string
 	ext           map[string]string
 	kt            *kit.Kit
 
@@ -97,7 +99,7 @@ func (msdc *MainDailySplitController) Start() error {
 	if msdc.kt != nil {
 		return fmt.Errorf("controller already start")
 	}
-	kt := getInternalKit()
+	kt := getInternalKit(msdc.TenantID)
 	cancelFunc := kt.CtxBackgroundWithCancel()
 	msdc.kt = kt
 	msdc.cancelFunc = cancelFunc
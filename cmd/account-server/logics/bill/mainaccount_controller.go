@@ -54,6 +54,8 @@ type MainAccountControllerOption struct {
 	Client              *client.ClientSet
 	AwsSavingPlanOption cc.AwsSavingsPlansOption
 	DefaultCurrency     enumor.CurrencyCode
+	// TenantID 主账号所属租户
+	TenantID string
 }
 
 // NewMainAccountController create new main account controller
@@ -103,6 +105,7 @@ func NewMainAccountController(opt *MainAccountControllerOption) (*MainAccountCon
 		dailySummaryCtrl:    dailySummaryCtrl,
 		AwsSavingPlanOption: opt.AwsSavingPlanOption,
 		DefaultCurrency:     opt.DefaultCurrency,
+		TenantID:            opt.TenantID,
 	}, nil
 }
 
@@ -115,6 +118,7 @@ type MainAccountController struct {
 	BkBizID         int64
 	Vendor          enumor.Vendor
 	DefaultCurrency enumor.CurrencyCode
+	TenantID        string
 
 	RootAccountCloudID string
 	MainAccountCloudID string
@@ -133,7 +137,7 @@ func (mac *MainAccountController) Start() error {
 	if mac.kt != nil {
 		return fmt.Errorf("controller already start")
 	}
-	kt := getInternalKit()
+	kt := getInternalKit(mac.TenantID)
 	cancelFunc := kt.CtxBackgroundWithCancel()
 	mac.kt = kt
 	mac.cancelFunc = cancelFunc
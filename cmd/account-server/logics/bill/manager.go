@@ -26,6 +26,7 @@ import (
 
 	"hcm/pkg/cc"
 	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/logs"
 	"hcm/pkg/serviced"
@@ -77,7 +78,7 @@ func (bm *BillManager) loopOnce() {
 }
 
 func (bm *BillManager) syncRootControllers() error {
-	kt := getInternalKit()
+	kt := getInternalKit(constant.DefaultTenantID)
 	logs.Infof("[bm] start sync root controllers, rid: %s", kt.Rid)
 	rootAccounts, err := bm.AccountList.ListAllRootAccount(kt)
 	if err != nil {
@@ -93,6 +94,7 @@ func (bm *BillManager) syncRootControllers() error {
 				RootAccountCloudID: rootAccount.CloudID,
 				Vendor:             rootAccount.Vendor,
 				Client:             bm.Client,
+				TenantID:           rootAccount.TenantID,
 			}
 			ctrl, err := NewRootAccountController(&opt)
 			if err != nil {
@@ -121,7 +123,7 @@ func (bm *BillManager) syncRootControllers() error {
 
 func (bm *BillManager) syncMainControllers() error {
 	// TODO: 所有主账号 改为 所有未核算完成的主账号
-	kt := getInternalKit()
+	kt := getInternalKit(constant.DefaultTenantID)
 	logs.Infof("[bm] start sync main controllers, rid: %s", kt.Rid)
 	mainAccounts, err := bm.AccountList.ListAllMainAccount(kt)
 	if err != nil {
@@ -141,7 +143,7 @@ func (bm *BillManager) syncMainControllers() error {
 			continue
 		}
 		// 获取root account 信息
-		rootAccount, err := bm.Client.DataService().Global.RootAccount.GetBasicInfo(kt,
+		rootAccount, err := bm.Client.DataService().Global.RootAccount.GetBasicInfo(kt.WithTenant(mainAccount.TenantID),
 			mainAccount.BaseMainAccount.ParentAccountID)
 		if err != nil {
 			logs.Errorf("get root account for main account controller failed, err: %v, rid: %s", err, kt.Rid)
@@ -159,6 +161,7 @@ func (bm *BillManager) syncMainControllers() error {
 			RootAccountCloudID: rootAccount.CloudID,
 			MainAccountCloudID: mainAccount.CloudID,
 			DefaultCurrency:    rootAccount.DefaultCurrency(),
+			TenantID:           mainAccount.TenantID,
 		}
 		ctrl, err := NewMainAccountController(&opt)
 		if err != nil {
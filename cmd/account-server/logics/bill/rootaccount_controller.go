@@ -48,6 +48,8 @@ type RootAccountControllerOption struct {
 	RootAccountCloudID string
 	Vendor             enumor.Vendor
 	Client             *client.ClientSet
+	// TenantID 根账号所属租户
+	TenantID string
 }
 
 // NewRootAccountController create new root account controller
@@ -72,6 +74,7 @@ func NewRootAccountController(opt *RootAccountControllerOption) (*RootAccountCon
 		RootAccountID:      opt.RootAccountID,
 		RootAccountCloudID: opt.RootAccountCloudID,
 		Vendor:             opt.Vendor,
+		TenantID:           opt.TenantID,
 	}, nil
 }
 
@@ -82,6 +85,7 @@ type RootAccountController struct {
 	RootAccountID      string
 	RootAccountCloudID string
 	Vendor             enumor.Vendor
+	TenantID           string
 	// ext extension option for root account
 	ext map[string]string
 
@@ -94,7 +98,7 @@ func (rac *RootAccountController) Start() error {
 	if rac.kt != nil {
 		return fmt.Errorf("controller already start")
 	}
-	kt := getInternalKit()
+	kt := getInternalKit(rac.TenantID)
 	cancelFunc := kt.CtxBackgroundWithCancel()
 	rac.kt = kt
 	rac.cancelFunc = cancelFunc
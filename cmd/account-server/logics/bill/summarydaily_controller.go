@@ -69,6 +69,7 @@ func NewMainSummaryDailyController(opt *MainAccountControllerOption) (*MainSumma
 		BkBizID:         opt.BkBizID,
 		Vendor:          opt.Vendor,
 		DefaultCurrency: opt.DefaultCurrency,
+		TenantID:        opt.TenantID,
 
 		RootAccountCloudID: opt.RootAccountCloudID,
 		MainAccountCloudID: opt.MainAccountCloudID,
@@ -85,6 +86,7 @@ type MainSummaryDailyController struct {
 	BkBizID         int64
 	Vendor          enumor.Vendor
 	DefaultCurrency enumor.CurrencyCode
+	TenantID        string
 
 	RootAccountCloudID string
 	MainAccountCloudID string
@@ -98,7 +100,7 @@ func (msdc *MainSummaryDailyController) Start() error {
 	if msdc.kt != nil {
 		return fmt.Errorf("controller already start")
 	}
-	kt := getInternalKit()
+	kt := getInternalKit(msdc.TenantID)
 	cancelFunc := kt.CtxBackgroundWithCancel()
 	msdc.kt = kt
 	msdc.cancelFunc = cancelFunc
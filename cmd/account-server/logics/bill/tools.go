@@ -35,9 +35,11 @@ const (
 	defaultSleepMillisecond          = 2000
 )
 
-func getInternalKit() *kit.Kit {
+// getInternalKit 生成账单后台任务使用的kit，账单数据按租户隔离，需指定任务所属的租户
+func getInternalKit(tenantID string) *kit.Kit {
 	newKit := kit.New()
 	newKit.User = string(cc.AccountServerName)
 	newKit.AppCode = string(cc.AccountServerName)
+	newKit.TenantID = tenantID
 	return newKit
 }
@@ -42,9 +42,9 @@ import (
 	"hcm/cmd/account-server/service/capability"
 	"hcm/pkg/cc"
 	"hcm/pkg/client"
-	"hcm/pkg/criteria/errf"
 	"hcm/pkg/cryptography"
 	"hcm/pkg/handler"
+	"hcm/pkg/health"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/logs"
 	"hcm/pkg/metrics"
@@ -66,6 +66,8 @@ type Service struct {
 	audit       logicaudit.Interface
 	billManager *bill.BillManager
 	esbClient   esb.Client
+	// dis is used to check the dependent services in readiness probe
+	dis serviced.Discover
 }
 
 // NewService create a service instance.
@@ -115,6 +117,7 @@ func NewService(sd serviced.ServiceDiscover) (*Service, error) {
 	}
 
 	svr := &Service{
+		dis:         sd,
 		clientSet:   apiClientSet,
 		authorizer:  authorizer,
 		audit:       logicaudit.NewAudit(apiClientSet.DataService()),
@@ -137,9 +140,12 @@ func (s *Service) ListenAndServeRest() error {
 	root := http.NewServeMux()
 	root.HandleFunc("/", s.apiSet().ServeHTTP)
 	root.HandleFunc("/healthz", s.Healthz)
+	root.HandleFunc("/readyz", s.Readyz)
 	handler.SetCommonHandler(root)
 
 	network := cc.AccountServer().Network
+	rest.SetBodyLimit(network.BodyLimit)
+	rest.SetAccessLog(network.AccessLog)
 	server := &http.Server{
 		Addr:    net.JoinHostPort(network.BindIP, strconv.FormatUint(uint64(network.Port), 10)),
 		Handler: root,
@@ -219,19 +225,10 @@ func (s *Service) apiSet() *restful.Container {
 
 // Healthz check whether the service is healthy.
 func (s *Service) Healthz(w http.ResponseWriter, r *http.Request) {
-	if shutdown.IsShuttingDown() {
-		logs.Errorf("service healthz check failed, current service is shutting down")
-		w.WriteHeader(http.StatusServiceUnavailable)
-		rest.WriteResp(w, rest.NewBaseResp(errf.UnHealthy, "current service is shutting down"))
-		return
-	}
-
-	if err := serviced.Healthz(r.Context(), cc.AccountServer().Service); err != nil {
-		logs.Errorf("serviced healthz check failed, err: %v", err)
-		rest.WriteResp(w, rest.NewBaseResp(errf.UnHealthy, "serviced healthz error, "+err.Error()))
-		return
-	}
+	health.ServeLiveness(w, r, health.Etcd(cc.AccountServer().Service))
+}
 
-	rest.WriteResp(w, rest.NewBaseResp(errf.OK, "healthy"))
-	return
+// Readyz check whether the service and its dependencies are ready to serve requests.
+func (s *Service) Readyz(w http.ResponseWriter, r *http.Request) {
+	health.ServeReadiness(w, r, health.Readiness(cc.AccountServer().Service, s.dis)...)
 }
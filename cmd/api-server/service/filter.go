@@ -26,7 +26,9 @@ import (
 	"net/http"
 	"regexp"
 
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/gwparser"
 
@@ -39,13 +41,18 @@ func (p *proxy) restFilter() restful.FilterFunction {
 		r, w := req.Request, resp.ResponseWriter
 
 		// parse request
-		kt, err := gwparser.Parse(r.Context(), r.Header)
+		kt, err := p.parseRequest(r)
 		if err != nil {
 			w.WriteHeader(http.StatusForbidden)
 			fmt.Fprintf(w, errf.Error(err).Error())
 			return
 		}
-		req.Request.Header = kt.Header()
+		header := kt.Header()
+		// idempotency key is passed through to the target server, so that retried mutation requests are deduplicated.
+		if key := r.Header.Get(constant.IdempotencyKey); len(key) != 0 {
+			header.Set(constant.IdempotencyKey, key)
+		}
+		req.Request.Header = header
 
 		body, err := peekRequest(r)
 		if err != nil {
@@ -62,6 +69,25 @@ func (p *proxy) restFilter() restful.FilterFunction {
 	}
 }
 
+// parseRequest parse request to context kit, requests with app code and secret are authenticated as the service
+// account of the app if app auth is enabled, other requests are parsed by api gateway jwt.
+func (p *proxy) parseRequest(r *http.Request) (*kit.Kit, error) {
+	if p.appAuth != nil {
+		kt, matched, err := p.appAuth.Parse(r.Context(), r.Header, r.Method, r.URL.Path)
+		if err != nil {
+			logs.Errorf("authenticate app request failed, err: %v, uri: %s, remote addr: %s", err, r.RequestURI,
+				r.RemoteAddr)
+			return nil, err
+		}
+
+		if matched {
+			return kt, nil
+		}
+	}
+
+	return gwparser.Parse(r.Context(), r.Header)
+}
+
 func peekRequest(req *http.Request) (string, error) {
 	if req.Body != nil {
 		byt, err := ioutil.ReadAll(req.Body)
@@ -31,6 +31,7 @@ import (
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/logs"
+	"hcm/pkg/rest"
 	"hcm/pkg/serviced"
 
 	"github.com/emicklei/go-restful/v3"
@@ -40,6 +41,8 @@ import (
 type proxy struct {
 	discovery map[cc.Name]*discovery.APIDiscovery
 	cli       *http.Client
+	// appAuth authenticate third-party system requests by app code and secret, nil if it is disabled.
+	appAuth *rest.AppAuthenticator
 }
 
 // newProxy create new rest proxy.
@@ -56,23 +59,27 @@ func newProxy(dis serviced.Discover, cli *http.Client) (*proxy, error) {
 		cli:       cli,
 	}
 
+	if cc.ApiServer().AppAuth.Enable {
+		p.appAuth = rest.NewAppAuthenticator(cc.ApiServer().AppAuth)
+	}
+
 	return p, nil
 }
 
 func (p *proxy) apiSet() *restful.Container {
-	ws := new(restful.WebService)
-
-	ws.Path("/api/v1")
-	ws.Filter(p.restFilter())
-	ws.Produces(restful.MIME_JSON)
-
-	ws.Route(ws.GET("{.*}").To(p.Do))
-	ws.Route(ws.POST("{.*}").To(p.Do))
-	ws.Route(ws.PUT("{.*}").To(p.Do))
-	ws.Route(ws.DELETE("{.*}").To(p.Do))
-	ws.Route(ws.PATCH("{.*}").To(p.Do))
-
-	return restful.NewContainer().Add(ws)
+	wss := rest.NewWebServices("/api/%s", rest.Version1, rest.Version2)
+	wss.Each(func(ws *restful.WebService) {
+		ws.Filter(p.restFilter())
+		ws.Produces(restful.MIME_JSON)
+
+		ws.Route(ws.GET("{.*}").To(p.Do))
+		ws.Route(ws.POST("{.*}").To(p.Do))
+		ws.Route(ws.PUT("{.*}").To(p.Do))
+		ws.Route(ws.DELETE("{.*}").To(p.Do))
+		ws.Route(ws.PATCH("{.*}").To(p.Do))
+	})
+
+	return wss.Container()
 }
 
 // Do proxy restful request to target server.
@@ -29,10 +29,9 @@ import (
 	"time"
 
 	"hcm/pkg/cc"
-	"hcm/pkg/criteria/errf"
 	"hcm/pkg/handler"
+	"hcm/pkg/health"
 	"hcm/pkg/logs"
-	"hcm/pkg/rest"
 	"hcm/pkg/rest/client"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
@@ -42,6 +41,8 @@ import (
 // Service do all the api server's work
 type Service struct {
 	proxy *proxy
+	// dis is used to check the dependent services in readiness probe
+	dis serviced.Discover
 }
 
 // NewService create a service instance.
@@ -70,6 +71,7 @@ func NewService(dis serviced.Discover) (*Service, error) {
 	}
 
 	return &Service{
+		dis:   dis,
 		proxy: p,
 	}, nil
 }
@@ -80,6 +82,7 @@ func (s *Service) ListenAndServeRest() error {
 	root := http.NewServeMux()
 	root.HandleFunc("/", s.proxy.apiSet().ServeHTTP)
 	root.HandleFunc("/healthz", s.Healthz)
+	root.HandleFunc("/readyz", s.Readyz)
 	handler.SetCommonHandler(root)
 
 	network := cc.ApiServer().Network
@@ -130,21 +133,12 @@ func (s *Service) ListenAndServeRest() error {
 	return nil
 }
 
-// Healthz service health check.
+// Healthz check whether the service is healthy.
 func (s *Service) Healthz(w http.ResponseWriter, r *http.Request) {
-	if shutdown.IsShuttingDown() {
-		logs.Errorf("service healthz check failed, current service is shutting down")
-		w.WriteHeader(http.StatusServiceUnavailable)
-		rest.WriteResp(w, rest.NewBaseResp(errf.UnHealthy, "current service is shutting down"))
-		return
-	}
-
-	if err := serviced.Healthz(r.Context(), cc.ApiServer().Service); err != nil {
-		logs.Errorf("serviced healthz check failed, err: %v", err)
-		rest.WriteResp(w, rest.NewBaseResp(errf.UnHealthy, "serviced healthz error, "+err.Error()))
-		return
-	}
+	health.ServeLiveness(w, r, health.Etcd(cc.ApiServer().Service))
+}
 
-	rest.WriteResp(w, rest.NewBaseResp(errf.OK, "healthy"))
-	return
+// Readyz check whether the service and its dependencies are ready to serve requests.
+func (s *Service) Readyz(w http.ResponseWriter, r *http.Request) {
+	health.ServeReadiness(w, r, health.Readiness(cc.ApiServer().Service, s.dis)...)
 }
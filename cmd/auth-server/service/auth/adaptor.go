@@ -114,6 +114,32 @@ func AdaptAuthOptions(a *meta.ResourceAttribute) (client.ActionID, []client.Reso
 		return genImageResource(a)
 	case meta.TaskManagement:
 		return genTaskManagementResource(a)
+	case meta.CvmTemplate:
+		return genCvmTemplateResource(a)
+	case meta.BizQuota:
+		return genBizQuotaResource(a)
+	case meta.BizResWhitelist:
+		return genBizResWhitelistResource(a)
+	case meta.CvmSchedule:
+		return genCvmScheduleResource(a)
+	case meta.LandingZone:
+		return genLandingZoneResource(a)
+	case meta.IdleResource:
+		return genIdleResourceResource(a)
+	case meta.NamingRule:
+		return genNamingRuleResource(a)
+	case meta.TagPolicy:
+		return genTagPolicyResource(a)
+	case meta.Compliance:
+		return genComplianceResource(a)
+	case meta.TaskCenter:
+		return genTaskCenterResource(a)
+	case meta.AccountGroup:
+		return genAccountGroupResource(a)
+	case meta.Notification:
+		return genNotificationResource(a)
+	case meta.Webhook:
+		return genWebhookResource(a)
 	default:
 		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm auth type: %s", a.Basic.Type)
 	}
@@ -127,3 +153,133 @@ func genApplicationResources(a *meta.ResourceAttribute) (client.ActionID, []clie
 		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
 	}
 }
+
+// genCvmTemplateResource 主机模板由平台管理员维护，复用申请单管理权限
+func genCvmTemplateResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Create, meta.Update, meta.Delete:
+		return sys.ApplicationManage, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
+
+// genBizQuotaResource 业务资源配额由平台管理员维护，复用平台全局配置权限
+func genBizQuotaResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find, meta.Create, meta.Update, meta.Delete:
+		return sys.GlobalConfiguration, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
+
+// genBizResWhitelistResource 业务资源申请白名单由平台管理员维护，复用平台全局配置权限
+func genBizResWhitelistResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find, meta.Create, meta.Update, meta.Delete:
+		return sys.GlobalConfiguration, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
+
+// genLandingZoneResource 网络基线部署一次创建多种网络资源，仅允许平台管理员操作，复用平台全局配置权限
+func genLandingZoneResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find, meta.Create:
+		return sys.GlobalConfiguration, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
+
+// genCvmScheduleResource 主机定时开关机策略由平台管理员维护，复用平台全局配置权限
+func genCvmScheduleResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find, meta.Create, meta.Update, meta.Delete:
+		return sys.GlobalConfiguration, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
+
+// genIdleResourceResource 闲置资源检测结果及一键清理由平台管理员操作，复用平台全局配置权限
+func genIdleResourceResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find, meta.Update, meta.Delete:
+		return sys.GlobalConfiguration, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
+
+// genNamingRuleResource 资源命名规则由平台管理员维护，复用平台全局配置权限
+func genNamingRuleResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find, meta.Create, meta.Update, meta.Delete:
+		return sys.GlobalConfiguration, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
+
+// genTagPolicyResource 标签策略由平台管理员维护，复用平台全局配置权限
+func genTagPolicyResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find, meta.Create, meta.Update, meta.Delete:
+		return sys.GlobalConfiguration, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
+
+// genComplianceResource 平台视角的安全合规检测及豁免由平台管理员操作，复用平台全局配置权限，业务视角使用业务访问权限
+func genComplianceResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find, meta.Create, meta.Update, meta.Delete:
+		return sys.GlobalConfiguration, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
+
+// genTaskCenterResource 任务中心展示平台所有的异步任务，由平台管理员查看及重试、终止，复用平台全局配置权限
+func genTaskCenterResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find, meta.Update:
+		return sys.GlobalConfiguration, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
+
+// genAccountGroupResource 账号组用于划分同步、搜索及费用报表的账号范围，由平台管理员维护，复用平台全局配置权限
+func genAccountGroupResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find, meta.Create, meta.Update, meta.Delete:
+		return sys.GlobalConfiguration, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
+
+// genNotificationResource 通知模板及订阅决定了平台告警的接收方式，由平台管理员维护，复用平台全局配置权限
+func genNotificationResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find, meta.Create, meta.Update, meta.Delete:
+		return sys.GlobalConfiguration, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
+
+// genWebhookResource webhook订阅会将资源变更事件推送到外部系统，由平台管理员维护，复用平台全局配置权限
+func genWebhookResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find, meta.Create, meta.Update, meta.Delete:
+		return sys.GlobalConfiguration, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
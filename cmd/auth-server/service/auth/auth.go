@@ -183,9 +183,79 @@ func (a *Auth) authorizeBatch(kt *kit.Kit, req *authserver.AuthorizeBatchReq, ex
 		index++
 	}
 
+	// resources that are not authorized by iaas resource actions can be authorized by fine-grained actions
+	if err = a.authorizeFineGrained(kt, req, decisions, exact); err != nil {
+		return nil, err
+	}
+
 	return decisions, nil
 }
 
+// authorizeFineGrained authorize the unauthorized resources by their fine-grained actions, fine-grained actions are
+// the resource type and operation level actions of the iaas resource actions, e.g. biz_cvm_create.
+func (a *Auth) authorizeFineGrained(kt *kit.Kit, req *authserver.AuthorizeBatchReq, decisions []meta.Decision,
+	exact bool) error {
+
+	indexes := make([]int, 0)
+	authBatchArr := make([]client.AuthBatch, 0)
+	for index, resource := range req.Resources {
+		if decisions[index].Authorized {
+			continue
+		}
+
+		action, iamResources, err := AdaptAuthOptions(&resource)
+		if err != nil {
+			logs.Errorf("adapt hcm resource to iam failed, err: %s, rid: %s", err, kt.Rid)
+			return err
+		}
+
+		fineGrainedAction, exists := sys.GetFineGrainedAction(resource.Basic.Type, action)
+		if !exists {
+			continue
+		}
+
+		indexes = append(indexes, index)
+		authBatchArr = append(authBatchArr, client.AuthBatch{
+			Action:    client.Action{ID: string(fineGrainedAction)},
+			Resources: iamResources,
+		})
+	}
+
+	if len(authBatchArr) == 0 {
+		return nil
+	}
+
+	opts := &client.AuthBatchOptions{
+		System: sys.SystemIDHCM,
+		Subject: client.Subject{
+			Type: sys.UserSubjectType,
+			ID:   req.User.UserName,
+		},
+		Batch: authBatchArr,
+	}
+
+	var authDecisions []*client.Decision
+	var err error
+	if exact {
+		authDecisions, err = a.auth.AuthorizeBatch(kt.Ctx, opts)
+	} else {
+		authDecisions, err = a.auth.AuthorizeAnyBatch(kt.Ctx, opts)
+	}
+	if err != nil {
+		logs.Errorf("authorize fine-grained batch failed, err: %v, ops: %#v, rid: %s", err, opts, kt.Rid)
+		return err
+	}
+
+	for i, decision := range authDecisions {
+		if i >= len(indexes) {
+			break
+		}
+		decisions[indexes[i]].Authorized = decision.Authorized
+	}
+
+	return nil
+}
+
 func (a *Auth) isWriteOperationDisabled(kt *kit.Kit, resources []meta.ResourceAttribute) error {
 	if !a.disableWriteOpt.IsDisabled {
 		return nil
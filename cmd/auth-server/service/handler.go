@@ -29,12 +29,11 @@ import (
 
 	"hcm/cmd/auth-server/service/capability"
 	"hcm/pkg/cc"
-	"hcm/pkg/criteria/errf"
 	"hcm/pkg/handler"
+	"hcm/pkg/health"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 	"hcm/pkg/runtime/shutdown"
-	"hcm/pkg/serviced"
 	"hcm/pkg/tools/ssl"
 
 	"github.com/emicklei/go-restful/v3"
@@ -46,9 +45,12 @@ func (s *Service) ListenAndServeRest() error {
 	root := http.NewServeMux()
 	root.HandleFunc("/", s.apiSet().ServeHTTP)
 	root.HandleFunc("/healthz", s.Healthz)
+	root.HandleFunc("/readyz", s.Readyz)
 	handler.SetCommonHandler(root)
 
 	network := cc.AuthServer().Network
+	rest.SetBodyLimit(network.BodyLimit)
+	rest.SetAccessLog(network.AccessLog)
 	server := &http.Server{
 		Addr:    net.JoinHostPort(network.BindIP, strconv.FormatUint(uint64(network.Port), 10)),
 		Handler: root,
@@ -117,19 +119,10 @@ func (s *Service) apiSet() *restful.Container {
 
 // Healthz check whether the service is healthy.
 func (s *Service) Healthz(w http.ResponseWriter, r *http.Request) {
-	if shutdown.IsShuttingDown() {
-		logs.Errorf("service healthz check failed, current service is shutting down")
-		w.WriteHeader(http.StatusServiceUnavailable)
-		rest.WriteResp(w, rest.NewBaseResp(errf.UnHealthy, "current service is shutting down"))
-		return
-	}
-
-	if err := serviced.Healthz(r.Context(), cc.AuthServer().Service); err != nil {
-		logs.Errorf("etcd healthz check failed, err: %v", err)
-		rest.WriteResp(w, rest.NewBaseResp(errf.UnHealthy, "etcd healthz error, "+err.Error()))
-		return
-	}
+	health.ServeLiveness(w, r, health.Etcd(cc.AuthServer().Service))
+}
 
-	rest.WriteResp(w, rest.NewBaseResp(errf.OK, "healthy"))
-	return
+// Readyz check whether the service and its dependencies are ready to serve requests.
+func (s *Service) Readyz(w http.ResponseWriter, r *http.Request) {
+	health.ServeReadiness(w, r, health.Readiness(cc.AuthServer().Service, s.dis)...)
 }
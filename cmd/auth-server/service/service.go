@@ -60,6 +60,8 @@ type Service struct {
 	initial *initial.Initial
 	// auth logic module.
 	auth *auth.Auth
+	// dis is used to check the dependent services in readiness probe
+	dis serviced.Discover
 }
 
 // NewService create a service instance.
@@ -77,6 +79,7 @@ func NewService(sd serviced.Discover, iamSettings cc.IAM, esbSettings cc.Esb, di
 	}
 
 	s := &Service{
+		dis:             sd,
 		client:          cli,
 		state:           state,
 		disableAuth:     disableAuth,
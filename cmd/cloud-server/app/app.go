@@ -34,6 +34,7 @@ import (
 	"hcm/pkg/runtime/ctl"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
+	"hcm/pkg/tracing"
 )
 
 // Run start the cloud server.
@@ -72,10 +73,20 @@ func (ds *cloudServer) prepare(opt *options.Option) error {
 
 	logs.Infof("load settings from config file success.")
 
+	// watch settings to reload them once the config file or etcd overlay changed.
+	if conf := cc.CloudServer().ConfigWatch; conf.Enable {
+		if err := cc.WatchSettings(opt.Sys, cc.CloudServer().Service.Etcd, conf); err != nil {
+			return fmt.Errorf("watch settings failed, err: %v", err)
+		}
+	}
+
 	// init metrics
 	network := cc.CloudServer().Network
 	metrics.InitMetrics(net.JoinHostPort(network.BindIP, strconv.Itoa(int(network.Port))))
 
+	// init tracing
+	tracing.Init(cc.CloudServer().Tracing, string(cc.CloudServerName))
+
 	// init service discovery.
 	svcOpt := serviced.NewServiceOption(cc.CloudServerName, cc.CloudServer().Network, opt.Sys)
 	discOpt := serviced.DiscoveryOption{
@@ -0,0 +1,129 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package accountgroup account group logics, resolve the accounts of account group which is used as the scope of
+// sync, search and cost reports.
+package accountgroup
+
+import (
+	"hcm/pkg/api/core"
+	coreaccountgroup "hcm/pkg/api/core/account-group"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// ListAllGroups list all account groups.
+func ListAllGroups(kt *kit.Kit, cli *client.ClientSet) ([]coreaccountgroup.AccountGroup, error) {
+	groups := make([]coreaccountgroup.AccountGroup, 0)
+	req := &core.ListReq{Filter: tools.AllExpression(), Page: core.NewDefaultBasePage()}
+	for {
+		result, err := cli.DataService().Global.AccountGroup.List(kt, req)
+		if err != nil {
+			logs.Errorf("list account group failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		groups = append(groups, result.Details...)
+		if len(result.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+		req.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+
+	return groups, nil
+}
+
+// ListSubGroupIDs list the ids of the group and all its descendant groups.
+func ListSubGroupIDs(groups []coreaccountgroup.AccountGroup, groupID string) []string {
+	children := make(map[string][]string)
+	for _, one := range groups {
+		children[one.ParentID] = append(children[one.ParentID], one.ID)
+	}
+
+	ids := []string{groupID}
+	visited := map[string]struct{}{groupID: {}}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			if _, exists := visited[child]; exists {
+				continue
+			}
+			visited[child] = struct{}{}
+			ids = append(ids, child)
+		}
+	}
+
+	return ids
+}
+
+// ListGroupAccountIDs list the account ids of the given type in the account group, accounts of the descendant
+// groups are included, so that a parent group like "prod accounts" covers all its sub groups.
+func ListGroupAccountIDs(kt *kit.Kit, cli *client.ClientSet, groupID string,
+	accountType enumor.AccountGroupMemberType) ([]string, error) {
+
+	groups, err := ListAllGroups(kt, cli)
+	if err != nil {
+		return nil, err
+	}
+
+	exists := false
+	for _, one := range groups {
+		if one.ID == groupID {
+			exists = true
+			break
+		}
+	}
+	if !exists {
+		return nil, errf.Newf(errf.RecordNotFound, "account group: %s not found", groupID)
+	}
+
+	groupIDs := ListSubGroupIDs(groups, groupID)
+	accountIDs := make([]string, 0)
+	for _, batch := range slice.Split(groupIDs, int(core.DefaultMaxPageLimit)) {
+		req := &core.ListReq{
+			Filter: tools.ExpressionAnd(
+				tools.RuleIn("group_id", batch),
+				tools.RuleEqual("account_type", accountType),
+			),
+			Page:   core.NewDefaultBasePage(),
+			Fields: []string{"account_id"},
+		}
+		for {
+			result, err := cli.DataService().Global.AccountGroup.ListMember(kt, req)
+			if err != nil {
+				logs.Errorf("list account group member failed, err: %v, groups: %v, rid: %s", err, batch, kt.Rid)
+				return nil, err
+			}
+
+			for _, one := range result.Details {
+				accountIDs = append(accountIDs, one.AccountID)
+			}
+			if len(result.Details) < int(core.DefaultMaxPageLimit) {
+				break
+			}
+			req.Page.Start += uint32(core.DefaultMaxPageLimit)
+		}
+	}
+
+	return slice.Unique(accountIDs), nil
+}
@@ -0,0 +1,54 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package accountgroup
+
+import (
+	"reflect"
+	"testing"
+
+	coreaccountgroup "hcm/pkg/api/core/account-group"
+)
+
+func TestListSubGroupIDs(t *testing.T) {
+	groups := []coreaccountgroup.AccountGroup{
+		{ID: "prod"},
+		{ID: "prod-gz", ParentID: "prod"},
+		{ID: "prod-sh", ParentID: "prod"},
+		{ID: "prod-gz-1", ParentID: "prod-gz"},
+		{ID: "test"},
+	}
+
+	// 父账号组包含所有子孙账号组
+	ids := ListSubGroupIDs(groups, "prod")
+	expect := []string{"prod", "prod-gz", "prod-sh", "prod-gz-1"}
+	if !reflect.DeepEqual(ids, expect) {
+		t.Errorf("expect sub group ids %v, but got %v", expect, ids)
+	}
+
+	if ids = ListSubGroupIDs(groups, "test"); !reflect.DeepEqual(ids, []string{"test"}) {
+		t.Errorf("group without children should only contain itself, but got %v", ids)
+	}
+
+	// 账号组层级存在环时不会重复查询
+	groups = append(groups, coreaccountgroup.AccountGroup{ID: "prod", ParentID: "prod-gz-1"})
+	if ids = ListSubGroupIDs(groups, "prod"); len(ids) != len(expect) {
+		t.Errorf("group in cycle should be visited once, but got %v", ids)
+	}
+}
@@ -32,62 +32,134 @@ import (
 	"hcm/cmd/cloud-server/service/sync/tcloud"
 	"hcm/pkg/api/core"
 	protocloud "hcm/pkg/api/data-service/cloud/zone"
+	ts "hcm/pkg/api/task-server"
 	"hcm/pkg/client"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
 	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
-
-	etcd3 "go.etcd.io/etcd/client/v3"
 )
 
-// Sync 账号同步。该操作同一账号不可并行执行，且是异步同步。
+// Sync 账号同步。该操作同一账号不可并行执行，且是异步同步。同步通过异步任务流执行，服务重启后任务流会被重新调度执行。
 func Sync(kt *kit.Kit, cli *client.ClientSet, vendor enumor.Vendor, accountID string) error {
+	_, err := SyncWithFlow(kt, cli, vendor, accountID)
+	return err
+}
 
-	syncer, ok := vendorSyncerMap[vendor]
-	if !ok {
-		return fmt.Errorf("vendor: %s not support", vendor)
-	}
-
-	isNeedSyncPublicResFlag, err := isNeedSyncPublicResource(kt, cli.DataService(), syncer)
-	if err != nil {
-		logs.Errorf("is need sync public resource failed, err: %v, vendor: %s, rid: %s",
-			err, vendor, kt.Rid)
-		return err
+// SyncWithFlow 账号同步，与 Sync 相同，返回执行同步的异步任务流ID。
+func SyncWithFlow(kt *kit.Kit, cli *client.ClientSet, vendor enumor.Vendor, accountID string) (string, error) {
+	if _, ok := vendorSyncerMap[vendor]; !ok {
+		return "", fmt.Errorf("vendor: %s not support", vendor)
 	}
 
+	// 加锁保证检查同步任务流和创建同步任务流的原子性
 	leaseID, err := lock.Manager.TryLock(lock.Key(accountID))
 	if err != nil {
 		if err == lock.ErrLockFailed {
-			return errors.New("synchronization is in progress")
+			return "", errors.New("synchronization is in progress")
 		}
 
-		return err
+		return "", err
 	}
-
-	go func(leaseID etcd3.LeaseID) {
-		defer func() {
-			if err := lock.Manager.UnLock(leaseID); err != nil {
-				// 锁已经超时释放了
-				if strings.Contains(err.Error(), "requested lease not found") {
-					return
-				}
-
-				logs.Errorf("%s: unlock account sync lock failed, err: %v, accountID: %s, leaseID: %d, rid: %s",
-					constant.AccountSyncFailed, err, accountID, leaseID, kt.Rid)
+	defer func() {
+		if err := lock.Manager.UnLock(leaseID); err != nil {
+			// 锁已经超时释放了
+			if strings.Contains(err.Error(), "requested lease not found") {
+				return
 			}
-		}()
 
-		resType, err := syncer.SyncAllResource(kt, cli, accountID, isNeedSyncPublicResFlag)
-		if err != nil {
-			logs.Errorf("[%s] sync account %s failed on %s, err: %v, rid: %s", vendor, accountID, resType, err, kt.Rid)
+			logs.Errorf("%s: unlock account sync lock failed, err: %v, accountID: %s, leaseID: %d, rid: %s",
+				constant.AccountSyncFailed, err, accountID, leaseID, kt.Rid)
 		}
+	}()
 
-	}(leaseID)
+	running, err := isSyncFlowRunning(kt, cli, accountID)
+	if err != nil {
+		return "", err
+	}
+	if running {
+		return "", errors.New("synchronization is in progress")
+	}
+
+	flowReq := &ts.AddCustomFlowReq{
+		Name: enumor.FlowSyncAccount,
+		Memo: accountID,
+		Tasks: []ts.CustomFlowTask{{
+			ActionID:   "1",
+			ActionName: enumor.ActionSyncAccount,
+			Params:     &SyncAccountOption{Vendor: vendor, AccountID: accountID},
+			Retry:      tableasync.NewRetryWithPolicy(syncRetryLimit, 1000, 5000),
+		}},
+	}
+	result, err := cli.TaskServer().CreateCustomFlow(kt, flowReq)
+	if err != nil {
+		logs.Errorf("create sync account flow failed, err: %v, account: %s, rid: %s", err, accountID, kt.Rid)
+		return "", err
+	}
+	logs.Infof("sync account %s by flow %s, rid: %s", accountID, result.ID, kt.Rid)
+
+	return result.ID, nil
+}
+
+// syncRetryLimit 账号同步任务失败后的重试次数，同步支持重入，重试时会重新同步全部资源
+const syncRetryLimit = 3
+
+// isSyncFlowRunning check if there is an unfinished sync flow of the account, the account id is recorded in flow memo.
+func isSyncFlowRunning(kt *kit.Kit, cli *client.ClientSet, accountID string) (bool, error) {
+	req := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("name", enumor.FlowSyncAccount),
+			tools.RuleEqual("memo", accountID),
+			tools.RuleIn("state", []enumor.FlowState{enumor.FlowInit, enumor.FlowPending, enumor.FlowScheduled,
+				enumor.FlowRunning}),
+		),
+		Page: core.NewCountPage(),
+	}
+	result, err := cli.TaskServer().ListFlow(kt, req)
+	if err != nil {
+		logs.Errorf("list account sync flow failed, err: %v, account: %s, rid: %s", err, accountID, kt.Rid)
+		return false, err
+	}
+
+	return result.Count > 0, nil
+}
+
+// SyncAccountOption define sync account flow task option.
+type SyncAccountOption struct {
+	Vendor    enumor.Vendor `json:"vendor" validate:"required"`
+	AccountID string        `json:"account_id" validate:"required"`
+}
+
+// Validate SyncAccountOption.
+func (opt *SyncAccountOption) Validate() error {
+	if _, ok := vendorSyncerMap[opt.Vendor]; !ok {
+		return fmt.Errorf("vendor: %s not support", opt.Vendor)
+	}
+
+	return validator.Validate.Struct(opt)
+}
+
+// SyncAllResource sync all resources of the account, it is executed by the sync account flow task.
+func SyncAllResource(kt *kit.Kit, cli *client.ClientSet, vendor enumor.Vendor, accountID string) (
+	enumor.CloudResourceType, error) {
+
+	syncer, ok := vendorSyncerMap[vendor]
+	if !ok {
+		return "", fmt.Errorf("vendor: %s not support", vendor)
+	}
+
+	isNeedSyncPublicResFlag, err := isNeedSyncPublicResource(kt, cli.DataService(), syncer)
+	if err != nil {
+		logs.Errorf("is need sync public resource failed, err: %v, vendor: %s, rid: %s",
+			err, vendor, kt.Rid)
+		return "", err
+	}
 
-	return nil
+	return syncer.SyncAllResource(kt, cli, accountID, isNeedSyncPublicResFlag)
 }
 
 // check is there any tree types of public resources, if one of that type does not exist, we sync all public resources
@@ -0,0 +1,51 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+)
+
+func TestSyncAccountOptionValidate(t *testing.T) {
+	opt := &SyncAccountOption{Vendor: enumor.TCloud, AccountID: "00000001"}
+	if err := opt.Validate(); err != nil {
+		t.Errorf("validate sync account option failed, err: %v", err)
+	}
+
+	// 不支持同步的云厂商
+	opt = &SyncAccountOption{Vendor: enumor.Zenlayer, AccountID: "00000001"}
+	if err := opt.Validate(); err == nil {
+		t.Errorf("vendor %s should not be supported", opt.Vendor)
+	}
+
+	opt = &SyncAccountOption{Vendor: enumor.Aws}
+	if err := opt.Validate(); err == nil {
+		t.Errorf("account id is required")
+	}
+}
+
+func TestSyncAllResourceUnsupportedVendor(t *testing.T) {
+	if _, err := SyncAllResource(kit.New(), nil, enumor.Zenlayer, "00000001"); err == nil {
+		t.Errorf("sync account of vendor %s should be failed", enumor.Zenlayer)
+	}
+}
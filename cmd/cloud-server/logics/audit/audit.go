@@ -43,6 +43,8 @@ type Interface interface {
 	ResBizAssignAudit(kt *kit.Kit, resType enumor.AuditResourceType, resIDs []string, bizID int64) error
 	// ResDeliverAudit 资源交付到业务审计
 	ResDeliverAudit(kt *kit.Kit, resType enumor.AuditResourceType, resIDs []string, bizID int64) error
+	// ResBizTransferAudit 资源在业务间转移审计
+	ResBizTransferAudit(kt *kit.Kit, resType enumor.AuditResourceType, resIDs []string, targetBizID int64) error
 	// ResCloudAreaBindAudit 资源绑定云区域审计
 	ResCloudAreaBindAudit(kt *kit.Kit, resType enumor.AuditResourceType, opt []ResCloudAreaBindOption) error
 	// ResBaseOperationAudit 资源基础操作审计，开机，关机等。
@@ -111,6 +113,30 @@ func (a audit) ResDeliverAudit(kt *kit.Kit, resType enumor.AuditResourceType, re
 	return nil
 }
 
+// ResBizTransferAudit resource transfer between biz audit, audit is recorded under the source biz.
+func (a audit) ResBizTransferAudit(kt *kit.Kit, resType enumor.AuditResourceType, resIDs []string,
+	targetBizID int64) error {
+
+	req := &protoaudit.CloudResourceAssignAuditReq{
+		Assigns: make([]protoaudit.CloudResourceAssignInfo, 0, len(resIDs)),
+	}
+
+	for _, resID := range resIDs {
+		req.Assigns = append(req.Assigns, protoaudit.CloudResourceAssignInfo{
+			ResType:         resType,
+			ResID:           resID,
+			AssignedResType: enumor.TransferAssignedResType,
+			AssignedResID:   targetBizID,
+		})
+	}
+	if err := a.dataCli.Global.Audit.CloudResourceAssignAudit(kt.Ctx, kt.Header(), req); err != nil {
+		logs.Errorf("request dataservice CloudResourceAssignAudit failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
 // ResCloudAreaBindOption resource bind cloud area option.
 type ResCloudAreaBindOption struct {
 	ResID   string
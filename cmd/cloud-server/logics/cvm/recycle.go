@@ -0,0 +1,232 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"errors"
+	"fmt"
+
+	proto "hcm/pkg/api/cloud-server/cvm"
+	"hcm/pkg/api/cloud-server/recycle"
+	corerecord "hcm/pkg/api/core/recycle-record"
+	protoaudit "hcm/pkg/api/data-service/audit"
+	dsrecord "hcm/pkg/api/data-service/recycle-record"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/maps"
+	"hcm/pkg/tools/slice"
+)
+
+// RecycleCvm 主机标记回收，包含预检、审计、解绑不随主机回收的磁盘和eip，创建回收任务，返回回收任务ID
+func (c *cvm) RecycleCvm(kt *kit.Kit, infos []proto.CvmRecycleInfo,
+	basicInfoMap map[string]types.CloudResourceBasicInfo) (string, error) {
+
+	// 1. 预检，有一个失败则全部失败，且不进审计
+	if err := c.RecyclePreCheck(kt, basicInfoMap); err != nil {
+		logs.Errorf("recycle precheck fail, err: %v, rid: %s", err, kt.Rid)
+		return "", err
+	}
+
+	cvmStatus := make(map[string]*recycle.CvmDetail, len(infos))
+	for _, cvmRecycleReq := range infos {
+		cvmStatus[cvmRecycleReq.ID] = &recycle.CvmDetail{
+			Vendor:           basicInfoMap[cvmRecycleReq.ID].Vendor,
+			AccountID:        basicInfoMap[cvmRecycleReq.ID].AccountID,
+			CvmID:            cvmRecycleReq.ID,
+			CvmRecycleDetail: corerecord.CvmRecycleDetail{CvmRecycleOptions: cvmRecycleReq.CvmRecycleOptions},
+		}
+	}
+
+	auditInfos := slice.Map(infos, func(info proto.CvmRecycleInfo) protoaudit.CloudResRecycleAuditInfo {
+		return protoaudit.CloudResRecycleAuditInfo{ResID: info.ID, Data: info.CvmRecycleOptions}
+	})
+	// create recycle audit
+	auditReq := &protoaudit.CloudResourceRecycleAuditReq{ResType: enumor.CvmAuditResType, Action: protoaudit.Recycle,
+		Infos: auditInfos,
+	}
+	if err := c.audit.ResRecycleAudit(kt, auditReq); err != nil {
+		logs.Errorf("create recycle audit failed, err: %v, rid: %s", err, kt.Rid)
+		return "", err
+	}
+
+	defer func() {
+		if err := c.recycleCleanUp(kt, cvmStatus); err != nil {
+			logs.Errorf("failed to cleanup recycle, err: %v, rid: %s", err, kt.Rid)
+		}
+	}()
+
+	return c.recycleCvm(kt, infos, cvmStatus)
+}
+
+// recycleCvm  回收核心逻辑（创建recycle record）
+// 1. 获取磁盘信息
+// 2. 解绑不随主机回收磁盘
+// 3. 获取eip信息
+// 4. 解绑不随主机回收eip
+// 5. 标记磁盘和eip为被动回收
+// 6. 回收主机 (仅回收前置步骤成功的）
+func (c *cvm) recycleCvm(kt *kit.Kit, infos []proto.CvmRecycleInfo,
+	cvmStatus map[string]*recycle.CvmDetail) (taskID string, err error) {
+	// 获取磁盘信息
+	if err := c.disk.BatchGetDiskInfo(kt, cvmStatus); err != nil {
+		logs.Errorf("failed to get disk info of cvm, err: %v, rid: %s", err, kt.Rid)
+		return "", err
+	}
+	// 过滤出不随主机回收的磁盘，并解绑
+	failed, err := c.disk.BatchDetach(kt,
+		maps.FilterByValue(cvmStatus, func(c *recycle.CvmDetail) bool { return !c.WithDisk }))
+	if err != nil {
+		logs.Errorf("failed to detach some disks of cvm(%v), err: %v, rid: %s", failed, err, kt.Rid)
+	}
+
+	// 获取eip信息
+	if err := c.eip.BatchGetEipInfo(kt, cvmStatus); err != nil {
+		logs.Errorf("failed to get eip info of cvm, err: %v, rid: %s", err, kt.Rid)
+	}
+
+	// 过滤出不随主机回收的Eip，并解绑
+	failed, err = c.eip.BatchUnbind(kt,
+		maps.FilterByValue(cvmStatus, func(c *recycle.CvmDetail) bool { return !c.WithEip }))
+	if err != nil {
+		logs.Errorf("failed to unbind eip of cvm(%v), err: %v, rid: %s", failed, err, kt.Rid)
+	}
+
+	// 标记磁盘和eip为回收(修改disk表和eip表中的recycle_status字段为recycling)
+	err = c.markRelatedRecycleStatus(kt, cvmStatus)
+	if err != nil {
+		return "", err
+	}
+
+	// 创建回收任务
+	opt := &dsrecord.BatchRecycleReq{
+		ResType:            enumor.CvmCloudResType,
+		DefaultRecycleTime: cc.CloudServer().Recycle.AutoDeleteTime,
+	}
+	for _, info := range infos {
+		// 过滤掉已经失败的id
+		if recCvm := cvmStatus[info.ID]; recCvm != nil && recCvm.FailedAt == "" {
+			opt.Infos = append(opt.Infos,
+				dsrecord.RecycleReq{ID: info.ID, Detail: cvmStatus[info.ID].CvmRecycleDetail})
+		}
+	}
+	if len(opt.Infos) == 0 {
+		return "", errors.New("all cvm recycle failed")
+	}
+
+	// 创建回收记录
+	taskID, err = c.client.DataService().Global.RecycleRecord.BatchRecycleCloudRes(kt, opt)
+	if err != nil {
+		logs.Errorf("fail to recycle cvm, err: %v, rid: %s", err, kt.Rid)
+		for _, info := range opt.Infos {
+			cvmStatus[info.ID].FailedAt = enumor.CvmCloudResType
+		}
+		return "", err
+	}
+
+	return taskID, nil
+}
+
+// recycleCleanUp 处理回收失败需要尝试重新绑定的eip、disk
+func (c *cvm) recycleCleanUp(kt *kit.Kit, cvmStatus map[string]*recycle.CvmDetail) error {
+
+	eipRebind := make(map[string]*recycle.CvmDetail, len(cvmStatus))
+	diskRebind := make(map[string]*recycle.CvmDetail, len(cvmStatus))
+
+	for cvmId, detail := range cvmStatus {
+		switch detail.FailedAt {
+		case "":
+			continue
+		case enumor.DiskCloudResType:
+			continue
+		case enumor.EipCloudResType:
+			diskRebind[cvmId] = detail
+		case enumor.CvmCloudResType:
+			// 	重新挂载磁盘和绑定eip
+			eipRebind[cvmId] = detail
+			diskRebind[cvmId] = detail
+		default:
+			return fmt.Errorf("unknown failed type: %v", detail.FailedAt)
+		}
+	}
+	// 	尝试重新挂载磁盘
+	err := c.eip.BatchRebind(kt, eipRebind)
+	if err != nil {
+		return err
+	}
+	err = c.disk.BatchReattachDisk(kt, diskRebind)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// markRelatedRecycleStatus 将关联资源标记为回收状态, 创建关联回收任务
+func (c *cvm) markRelatedRecycleStatus(kt *kit.Kit, cvmStatus map[string]*recycle.CvmDetail) error {
+	var diskReqs []dsrecord.RecycleReq
+	var eipIds []string
+	for _, recCvm := range cvmStatus {
+		// 过滤掉已经失败的id
+		if recCvm.FailedAt != "" {
+			continue
+		}
+		if recCvm.WithDisk {
+			diskReqs = slice.Map(recCvm.DiskList, func(d corerecord.DiskAttachInfo) dsrecord.RecycleReq {
+				return dsrecord.RecycleReq{ID: d.DiskID, Detail: corerecord.DiskRelatedRecycleOpt{CvmID: recCvm.CvmID}}
+			})
+		}
+		if recCvm.WithEip {
+			eipIds = slice.Map(recCvm.EipList, func(e corerecord.EipBindInfo) string { return e.EipID })
+		}
+	}
+
+	if len(diskReqs) > 0 {
+		// 创建disk回收任务 RecycleTypeRelated
+		opt := &dsrecord.BatchRecycleReq{
+			ResType:            enumor.DiskCloudResType,
+			RecycleType:        enumor.RecycleTypeRelated,
+			DefaultRecycleTime: cc.CloudServer().Recycle.AutoDeleteTime,
+			Infos:              diskReqs,
+		}
+		_, err := c.client.DataService().Global.RecycleRecord.BatchRecycleCloudRes(kt, opt)
+		if err != nil {
+			logs.Errorf("fail to create related disk recycle record, err: %v, disk infos: %v, rid: %s",
+				err, diskReqs, kt.Rid)
+			return err
+		}
+
+	}
+	if len(eipIds) > 0 {
+		// 标记eip为回收状态
+		err := c.client.DataService().Global.RecycleRecord.BatchUpdateRecycleStatus(kt,
+			&dsrecord.BatchUpdateRecycleStatusReq{
+				ResType:       enumor.EipCloudResType,
+				IDs:           eipIds,
+				RecycleStatus: enumor.RecycleStatus,
+			})
+		if err != nil {
+			logs.Errorf("fail to mark eip recycling status, err: %v, eip ids: %v, rid: %s", err, eipIds, kt.Rid)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"hcm/pkg/api/core"
+	protoaudit "hcm/pkg/api/data-service/audit"
+	hcprotocvm "hcm/pkg/api/hc-service/cvm"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/classifier"
+)
+
+// BatchStartCvm batch start cvm.
+func (c *cvm) BatchStartCvm(kt *kit.Kit, basicInfoMap map[string]types.CloudResourceBasicInfo) (
+	result *core.BatchOperateAllResult, err error) {
+
+	result = &core.BatchOperateAllResult{}
+	if len(basicInfoMap) == 0 {
+		return result, nil
+	}
+
+	ids := make([]string, 0, len(basicInfoMap))
+	for id := range basicInfoMap {
+		ids = append(ids, id)
+	}
+
+	if err := c.audit.ResBaseOperationAudit(kt, enumor.CvmAuditResType, protoaudit.Start, ids); err != nil {
+		logs.Errorf("create operation audit failed, err: %v, rid: %s", err, kt.Rid)
+		for _, cvmId := range ids {
+			result.Failed = append(result.Failed, core.FailedInfo{ID: cvmId, Error: err})
+		}
+		return result, err
+	}
+
+	cvmVendorMap := classifier.ClassifyBasicInfoByVendor(basicInfoMap)
+	for vendor, infos := range cvmVendorMap {
+		switch vendor {
+		case enumor.TCloud, enumor.Aws, enumor.HuaWei:
+			// 支持batch
+			batchStartRes := c.batchStartCvm(kt, vendor, infos)
+			result.Succeeded = append(result.Succeeded, batchStartRes.Succeeded...)
+			result.Failed = append(result.Failed, batchStartRes.Failed...)
+
+		case enumor.Gcp:
+			for _, cvmInfo := range infos {
+				if err := c.client.HCService().Gcp.Cvm.StartCvm(kt, cvmInfo.ID); err != nil {
+					result.Failed = append(result.Failed, core.FailedInfo{ID: cvmInfo.ID, Error: err})
+				} else {
+					result.Succeeded = append(result.Succeeded, cvmInfo.ID)
+				}
+			}
+		case enumor.Azure:
+			for _, cvmInfo := range infos {
+				if err := c.client.HCService().Azure.Cvm.StartCvm(kt, cvmInfo.ID); err != nil {
+					result.Failed = append(result.Failed, core.FailedInfo{ID: cvmInfo.ID, Error: err})
+				} else {
+					result.Succeeded = append(result.Succeeded, cvmInfo.ID)
+				}
+			}
+		default:
+			err := errf.Newf(errf.Unknown, "vendor: %s not support", vendor)
+			for _, cvmInfo := range infos {
+				result.Failed = append(result.Failed, core.FailedInfo{ID: cvmInfo.ID, Error: err})
+			}
+		}
+	}
+	return result, err
+}
+
+// batchStartCvm start cvm.
+func (c *cvm) batchStartCvm(kt *kit.Kit, vendor enumor.Vendor, infoMap []types.CloudResourceBasicInfo) (
+	result *core.BatchOperateAllResult) {
+
+	result = &core.BatchOperateAllResult{}
+	cvmMap := classifier.ClassifyBasicInfoByAccount(infoMap)
+	markFail := func(err error, ids ...string) {
+		for _, id := range ids {
+			result.Failed = append(result.Failed, core.FailedInfo{ID: id, Error: err})
+		}
+	}
+	for accountID, reginMap := range cvmMap {
+		for region, ids := range reginMap {
+			var err error
+			switch vendor {
+			case enumor.TCloud:
+				req := &hcprotocvm.TCloudBatchStartReq{AccountID: accountID, Region: region, IDs: ids}
+				err = c.client.HCService().TCloud.Cvm.BatchStartCvm(kt, req)
+			case enumor.Aws:
+				req := &hcprotocvm.AwsBatchStartReq{AccountID: accountID, Region: region, IDs: ids}
+				err = c.client.HCService().Aws.Cvm.BatchStartCvm(kt, req)
+			case enumor.HuaWei:
+				req := &hcprotocvm.HuaWeiBatchStartReq{AccountID: accountID, Region: region, IDs: ids}
+				err = c.client.HCService().HuaWei.Cvm.BatchStartCvm(kt, req)
+			default:
+				err = errf.Newf(errf.Unknown, "vendor: %s not support", vendor)
+			}
+			if err != nil {
+				markFail(err, ids...)
+				continue
+			}
+
+			result.Succeeded = append(result.Succeeded, ids...)
+		}
+	}
+
+	return result
+}
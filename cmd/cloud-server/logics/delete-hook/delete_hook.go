@@ -0,0 +1,180 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package deletehook pre delete hook logics, call the configured hooks before deleting resources, so that custom
+// safety checks can reject or annotate the deletion.
+package deletehook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// Interface define pre delete hook interface.
+type Interface interface {
+	// PreDelete 删除资源前依次调用该资源类型配置的钩子，任一钩子拒绝时返回错误，钩子放行时返回的备注信息记录到日志
+	PreDelete(kt *kit.Kit, resType enumor.CloudResourceType, resources []Resource) error
+}
+
+// Resource define the resource to be deleted which is sent to the hook.
+type Resource struct {
+	ID        string        `json:"id"`
+	Vendor    enumor.Vendor `json:"vendor"`
+	AccountID string        `json:"account_id"`
+	BkBizID   int64         `json:"bk_biz_id"`
+	Region    string        `json:"region,omitempty"`
+	// ParentID 子资源所属的资源ID，如安全组规则所属的安全组ID
+	ParentID string `json:"parent_id,omitempty"`
+}
+
+// NewResource new hook resource by resource basic info.
+func NewResource(info *types.CloudResourceBasicInfo) Resource {
+	return Resource{
+		ID:        info.ID,
+		Vendor:    info.Vendor,
+		AccountID: info.AccountID,
+		BkBizID:   info.BkBizID,
+		Region:    info.Region,
+	}
+}
+
+// hookReq is the request body sent to the hook.
+type hookReq struct {
+	Hook      string                   `json:"hook"`
+	ResType   enumor.CloudResourceType `json:"res_type"`
+	Resources []Resource               `json:"resources"`
+	Operator  string                   `json:"operator"`
+	Rid       string                   `json:"rid"`
+}
+
+// hookResp is the response body returned by the hook.
+type hookResp struct {
+	Allow bool `json:"allow"`
+	// Message 拒绝时为拒绝原因，放行时为备注信息
+	Message string `json:"message"`
+}
+
+type deleteHook struct {
+	hooks []cc.PreDeleteHook
+	http  *http.Client
+}
+
+// NewDeleteHook new pre delete hook logics.
+func NewDeleteHook(hooks []cc.PreDeleteHook) Interface {
+	return &deleteHook{
+		hooks: hooks,
+		http:  new(http.Client),
+	}
+}
+
+// PreDelete call pre delete hooks of the resource type in order.
+func (d *deleteHook) PreDelete(kt *kit.Kit, resType enumor.CloudResourceType, resources []Resource) error {
+	if len(resources) == 0 {
+		return nil
+	}
+
+	for _, hook := range d.hooks {
+		if !slice.IsItemInSlice(hook.ResTypes, string(resType)) {
+			continue
+		}
+
+		resp, err := d.call(kt, hook, resType, resources)
+		if err != nil {
+			logs.Errorf("call pre delete hook %s failed, err: %v, res_type: %s, rid: %s", hook.Name, err, resType,
+				kt.Rid)
+			if hook.FailOpen {
+				continue
+			}
+			return errf.Newf(errf.Aborted, "call pre delete hook %s failed, err: %v", hook.Name, err)
+		}
+
+		if !resp.Allow {
+			logs.Warnf("pre delete hook %s rejected deleting %s, message: %s, operator: %s, rid: %s", hook.Name,
+				resType, resp.Message, kt.User, kt.Rid)
+			return errf.Newf(errf.PreDeleteHookRejected, "pre delete hook %s rejected the deletion, %s", hook.Name,
+				resp.Message)
+		}
+
+		if len(resp.Message) != 0 {
+			logs.Infof("pre delete hook %s annotated deleting %s, message: %s, operator: %s, rid: %s", hook.Name,
+				resType, resp.Message, kt.User, kt.Rid)
+		}
+	}
+
+	return nil
+}
+
+func (d *deleteHook) call(kt *kit.Kit, hook cc.PreDeleteHook, resType enumor.CloudResourceType,
+	resources []Resource) (*hookResp, error) {
+
+	body, err := json.Marshal(&hookReq{
+		Hook:      hook.Name,
+		ResType:   resType,
+		Resources: resources,
+		Operator:  kt.User,
+		Rid:       kt.Rid,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(kt.Ctx, time.Duration(hook.TimeoutSec)*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := d.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("hook responded with status %d", httpResp.StatusCode)
+	}
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(hookResp)
+	if err = json.Unmarshal(respBody, resp); err != nil {
+		return nil, fmt.Errorf("unmarshal hook response failed, err: %v", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,93 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package deletehook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreDelete(t *testing.T) {
+	var received *hookReq
+	newServer := func(status int, resp *hookResp) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = new(hookReq)
+			_ = json.NewDecoder(r.Body).Decode(received)
+			w.WriteHeader(status)
+			_ = json.NewEncoder(w).Encode(resp)
+		}))
+	}
+
+	allowSvr := newServer(http.StatusOK, &hookResp{Allow: true, Message: "still in lb pool"})
+	defer allowSvr.Close()
+	rejectSvr := newServer(http.StatusOK, &hookResp{Allow: false, Message: "host is still in use"})
+	defer rejectSvr.Close()
+	errSvr := newServer(http.StatusInternalServerError, &hookResp{})
+	defer errSvr.Close()
+
+	resources := []Resource{{ID: "00000001", Vendor: enumor.TCloud, AccountID: "00000002", BkBizID: 1}}
+	hook := func(name, url string, failOpen bool) cc.PreDeleteHook {
+		return cc.PreDeleteHook{Name: name, URL: url, ResTypes: []string{"cvm"}, TimeoutSec: 5, FailOpen: failOpen}
+	}
+
+	t.Run("allowed with annotation", func(t *testing.T) {
+		received = nil
+		err := NewDeleteHook([]cc.PreDeleteHook{hook("allow", allowSvr.URL, false)}).
+			PreDelete(kit.New(), enumor.CvmCloudResType, resources)
+		assert.NoError(t, err)
+		assert.NotNil(t, received)
+		assert.Equal(t, "allow", received.Hook)
+		assert.Equal(t, resources, received.Resources)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		err := NewDeleteHook([]cc.PreDeleteHook{hook("allow", allowSvr.URL, false),
+			hook("reject", rejectSvr.URL, false)}).PreDelete(kit.New(), enumor.CvmCloudResType, resources)
+		assert.Error(t, err)
+		assert.Equal(t, errf.PreDeleteHookRejected, errf.Error(err).Code)
+	})
+
+	t.Run("res type not matched", func(t *testing.T) {
+		received = nil
+		err := NewDeleteHook([]cc.PreDeleteHook{hook("reject", rejectSvr.URL, false)}).
+			PreDelete(kit.New(), enumor.SecurityGroupRuleCloudResType, resources)
+		assert.NoError(t, err)
+		assert.Nil(t, received)
+	})
+
+	t.Run("call failed", func(t *testing.T) {
+		err := NewDeleteHook([]cc.PreDeleteHook{hook("error", errSvr.URL, false)}).
+			PreDelete(kit.New(), enumor.CvmCloudResType, resources)
+		assert.Error(t, err)
+
+		err = NewDeleteHook([]cc.PreDeleteHook{hook("error", errSvr.URL, true)}).
+			PreDelete(kit.New(), enumor.CvmCloudResType, resources)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,150 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package diff compare the configuration of two resources field by field.
+package diff
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	csdiff "hcm/pkg/api/cloud-server/diff"
+)
+
+// Compare compare the flattened fields of source and target, nested objects are compared field by field, arrays
+// are compared as sets so that the order of elements (e.g. security group rules) does not matter.
+func Compare(source, target map[string]interface{}) []csdiff.FieldDiff {
+	sourceFields, targetFields := make(map[string]interface{}), make(map[string]interface{})
+	flatten("", source, sourceFields)
+	flatten("", target, targetFields)
+
+	fields := make([]string, 0, len(sourceFields)+len(targetFields))
+	for field := range sourceFields {
+		fields = append(fields, field)
+	}
+	for field := range targetFields {
+		if _, exists := sourceFields[field]; !exists {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	diffs := make([]csdiff.FieldDiff, 0)
+	for _, field := range fields {
+		sourceVal, sourceExists := sourceFields[field]
+		targetVal, targetExists := targetFields[field]
+
+		sourceArr, sourceIsArr := sourceVal.([]interface{})
+		targetArr, targetIsArr := targetVal.([]interface{})
+		if (sourceIsArr || !sourceExists) && (targetIsArr || !targetExists) {
+			diffs = append(diffs, compareSet(field, sourceArr, targetArr)...)
+			continue
+		}
+
+		switch {
+		case !targetExists:
+			diffs = append(diffs, csdiff.FieldDiff{Field: field, Type: csdiff.Removed, Source: sourceVal})
+		case !sourceExists:
+			diffs = append(diffs, csdiff.FieldDiff{Field: field, Type: csdiff.Added, Target: targetVal})
+		case !reflect.DeepEqual(sourceVal, targetVal):
+			diffs = append(diffs, csdiff.FieldDiff{Field: field, Type: csdiff.Changed, Source: sourceVal,
+				Target: targetVal})
+		}
+	}
+
+	return diffs
+}
+
+// flatten flatten nested objects into dot joined fields, null fields and empty objects are ignored.
+func flatten(prefix string, obj map[string]interface{}, fields map[string]interface{}) {
+	for key, val := range obj {
+		field := key
+		if len(prefix) != 0 {
+			field = prefix + "." + key
+		}
+
+		switch v := val.(type) {
+		case nil:
+			continue
+		case map[string]interface{}:
+			flatten(field, v, fields)
+		default:
+			fields[field] = v
+		}
+	}
+}
+
+// compareSet compare array elements as a multiset, elements are identified by their json encoding, in which
+// object keys are sorted.
+func compareSet(field string, source, target []interface{}) []csdiff.FieldDiff {
+	targetCount := make(map[string]int, len(target))
+	for _, one := range target {
+		targetCount[elementKey(one)]++
+	}
+
+	diffs := make([]csdiff.FieldDiff, 0)
+	sourceCount := make(map[string]int, len(source))
+	for _, one := range source {
+		key := elementKey(one)
+		if targetCount[key] > 0 {
+			targetCount[key]--
+			sourceCount[key]++
+			continue
+		}
+		diffs = append(diffs, csdiff.FieldDiff{Field: field, Type: csdiff.Removed, Source: one})
+	}
+
+	for _, one := range target {
+		key := elementKey(one)
+		if sourceCount[key] > 0 {
+			sourceCount[key]--
+			continue
+		}
+		diffs = append(diffs, csdiff.FieldDiff{Field: field, Type: csdiff.Added, Target: one})
+	}
+
+	return diffs
+}
+
+func elementKey(element interface{}) string {
+	key, err := json.Marshal(element)
+	if err != nil {
+		return ""
+	}
+	return string(key)
+}
+
+// toMap convert resource into a generic json object.
+func toMap(res interface{}) (map[string]interface{}, error) {
+	obj := make(map[string]interface{})
+	if err := convert(res, &obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// convert convert value into generic json value by json encoding.
+func convert(val interface{}, result interface{}) error {
+	raw, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, result)
+}
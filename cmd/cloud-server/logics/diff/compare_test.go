@@ -0,0 +1,69 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package diff
+
+import (
+	"testing"
+
+	csdiff "hcm/pkg/api/cloud-server/diff"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	source := map[string]interface{}{
+		"name":   "web",
+		"region": "ap-guangzhou",
+		"memo":   nil,
+		"extension": map[string]interface{}{
+			"vpc_id": "vpc-1",
+			"tags":   map[string]interface{}{"env": "prod"},
+		},
+		"rules": []interface{}{
+			map[string]interface{}{"port": "80", "action": "ACCEPT"},
+			map[string]interface{}{"port": "22", "action": "ACCEPT"},
+		},
+		"cloud_security_group_ids": []interface{}{"sg-1", "sg-2"},
+	}
+	target := map[string]interface{}{
+		"name":   "web",
+		"region": "ap-shanghai",
+		"extension": map[string]interface{}{
+			"vpc_id": "vpc-1",
+			"tags":   map[string]interface{}{"env": "prod", "owner": "jim"},
+		},
+		"rules": []interface{}{
+			map[string]interface{}{"action": "ACCEPT", "port": "80"},
+			map[string]interface{}{"port": "443", "action": "ACCEPT"},
+		},
+		"cloud_security_group_ids": []interface{}{"sg-2", "sg-1"},
+		"memo":                     "new",
+	}
+
+	expected := []csdiff.FieldDiff{
+		{Field: "extension.tags.owner", Type: csdiff.Added, Target: "jim"},
+		{Field: "memo", Type: csdiff.Added, Target: "new"},
+		{Field: "region", Type: csdiff.Changed, Source: "ap-guangzhou", Target: "ap-shanghai"},
+		{Field: "rules", Type: csdiff.Removed, Source: map[string]interface{}{"port": "22", "action": "ACCEPT"}},
+		{Field: "rules", Type: csdiff.Added, Target: map[string]interface{}{"port": "443", "action": "ACCEPT"}},
+	}
+	assert.Equal(t, expected, Compare(source, target))
+	assert.Empty(t, Compare(source, source))
+}
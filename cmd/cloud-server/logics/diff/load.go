@@ -0,0 +1,287 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package diff
+
+import (
+	"fmt"
+
+	csdiff "hcm/pkg/api/cloud-server/diff"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+)
+
+// ignoredFields 资源标识及元数据字段，不参与对比
+var ignoredFields = []string{"id", "cloud_id", "creator", "reviser", "created_at", "updated_at",
+	"cloud_created_time", "cloud_update_time", "cloud_launched_time", "recycle_status"}
+
+// ruleIgnoredFields 安全组规则的标识及所属安全组字段，不参与对比
+var ruleIgnoredFields = []string{"id", "cloud_id", "cloud_policy_index", "version", "security_group_id",
+	"cloud_security_group_id", "region", "account_id", "creator", "reviser", "created_at", "updated_at"}
+
+// templateFields 主机与主机模板对比时，主机中可以由模板定义的字段
+var templateFields = []string{"vendor", "account_id", "region", "zone", "cloud_image_id", "instance_type",
+	"cloud_security_group_ids"}
+
+// Load load the configuration of resource as a generic json object, identity and revision fields are removed.
+// security group rules are loaded into the rules field.
+func Load(kt *kit.Kit, cli *dataservice.Client, res csdiff.DiffResource, vendor enumor.Vendor) (
+	map[string]interface{}, error) {
+
+	var obj map[string]interface{}
+	var err error
+	switch res.ResType {
+	case enumor.CvmCloudResType:
+		obj, err = loadCvm(kt, cli, res.ID, vendor)
+	case enumor.SecurityGroupCloudResType:
+		obj, err = loadSecurityGroup(kt, cli, res.ID, vendor)
+	case enumor.VpcCloudResType:
+		obj, err = loadVpc(kt, cli, res.ID, vendor)
+	case enumor.SubnetCloudResType:
+		obj, err = loadSubnet(kt, cli, res.ID, vendor)
+	case csdiff.CvmTemplateResType:
+		obj, err = loadCvmTemplate(kt, cli, res.ID)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "resource type %s does not support diff", res.ResType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range ignoredFields {
+		delete(obj, field)
+	}
+	return obj, nil
+}
+
+// TemplateView pick the fields of cvm which can be defined by cvm template, so that a cvm can be compared with
+// a cvm template.
+func TemplateView(obj map[string]interface{}, resType enumor.CloudResourceType) map[string]interface{} {
+	if resType == enumor.CvmCloudResType {
+		obj["instance_type"] = obj["machine_type"]
+		if ext, ok := obj["extension"].(map[string]interface{}); ok {
+			obj["cloud_security_group_ids"] = ext["cloud_security_group_ids"]
+		}
+	}
+
+	view := make(map[string]interface{}, len(templateFields))
+	for _, field := range templateFields {
+		view[field] = obj[field]
+	}
+	return view
+}
+
+func loadCvm(kt *kit.Kit, cli *dataservice.Client, id string, vendor enumor.Vendor) (map[string]interface{},
+	error) {
+
+	var cvm interface{}
+	var err error
+	switch vendor {
+	case enumor.TCloud:
+		cvm, err = cli.TCloud.Cvm.GetCvm(kt.Ctx, kt.Header(), id)
+	case enumor.Aws:
+		cvm, err = cli.Aws.Cvm.GetCvm(kt.Ctx, kt.Header(), id)
+	case enumor.Gcp:
+		cvm, err = cli.Gcp.Cvm.GetCvm(kt.Ctx, kt.Header(), id)
+	case enumor.HuaWei:
+		cvm, err = cli.HuaWei.Cvm.GetCvm(kt.Ctx, kt.Header(), id)
+	case enumor.Azure:
+		cvm, err = cli.Azure.Cvm.GetCvm(kt.Ctx, kt.Header(), id)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "cvm: %s vendor: %s not support", id, vendor)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return toMap(cvm)
+}
+
+func loadVpc(kt *kit.Kit, cli *dataservice.Client, id string, vendor enumor.Vendor) (map[string]interface{},
+	error) {
+
+	var vpc interface{}
+	var err error
+	switch vendor {
+	case enumor.TCloud:
+		vpc, err = cli.TCloud.Vpc.Get(kt.Ctx, kt.Header(), id)
+	case enumor.Aws:
+		vpc, err = cli.Aws.Vpc.Get(kt.Ctx, kt.Header(), id)
+	case enumor.Gcp:
+		vpc, err = cli.Gcp.Vpc.Get(kt.Ctx, kt.Header(), id)
+	case enumor.HuaWei:
+		vpc, err = cli.HuaWei.Vpc.Get(kt.Ctx, kt.Header(), id)
+	case enumor.Azure:
+		vpc, err = cli.Azure.Vpc.Get(kt, id)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vpc: %s vendor: %s not support", id, vendor)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return toMap(vpc)
+}
+
+func loadSubnet(kt *kit.Kit, cli *dataservice.Client, id string, vendor enumor.Vendor) (map[string]interface{},
+	error) {
+
+	var subnet interface{}
+	var err error
+	switch vendor {
+	case enumor.TCloud:
+		subnet, err = cli.TCloud.Subnet.Get(kt.Ctx, kt.Header(), id)
+	case enumor.Aws:
+		subnet, err = cli.Aws.Subnet.Get(kt.Ctx, kt.Header(), id)
+	case enumor.Gcp:
+		subnet, err = cli.Gcp.Subnet.Get(kt.Ctx, kt.Header(), id)
+	case enumor.HuaWei:
+		subnet, err = cli.HuaWei.Subnet.Get(kt.Ctx, kt.Header(), id)
+	case enumor.Azure:
+		subnet, err = cli.Azure.Subnet.Get(kt.Ctx, kt.Header(), id)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "subnet: %s vendor: %s not support", id, vendor)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return toMap(subnet)
+}
+
+func loadSecurityGroup(kt *kit.Kit, cli *dataservice.Client, id string, vendor enumor.Vendor) (
+	map[string]interface{}, error) {
+
+	ruleFilter := tools.EqualExpression("security_group_id", id)
+	var sg, rules interface{}
+	var err error
+	switch vendor {
+	case enumor.TCloud:
+		if sg, err = cli.TCloud.SecurityGroup.GetSecurityGroup(kt.Ctx, kt.Header(), id); err != nil {
+			return nil, err
+		}
+		rules, err = listRules(id, func(page *core.BasePage) ([]corecloud.TCloudSecurityGroupRule, error) {
+			req := &protocloud.TCloudSGRuleListReq{Filter: ruleFilter, Page: page}
+			result, err := cli.TCloud.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(), req, id)
+			if err != nil {
+				return nil, err
+			}
+			return result.Details, nil
+		})
+	case enumor.Aws:
+		if sg, err = cli.Aws.SecurityGroup.GetSecurityGroup(kt.Ctx, kt.Header(), id); err != nil {
+			return nil, err
+		}
+		rules, err = listRules(id, func(page *core.BasePage) ([]corecloud.AwsSecurityGroupRule, error) {
+			req := &protocloud.AwsSGRuleListReq{Filter: ruleFilter, Page: page}
+			result, err := cli.Aws.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(), req, id)
+			if err != nil {
+				return nil, err
+			}
+			return result.Details, nil
+		})
+	case enumor.HuaWei:
+		if sg, err = cli.HuaWei.SecurityGroup.GetSecurityGroup(kt.Ctx, kt.Header(), id); err != nil {
+			return nil, err
+		}
+		rules, err = listRules(id, func(page *core.BasePage) ([]corecloud.HuaWeiSecurityGroupRule, error) {
+			req := &protocloud.HuaWeiSGRuleListReq{Filter: ruleFilter, Page: page}
+			result, err := cli.HuaWei.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(), req, id)
+			if err != nil {
+				return nil, err
+			}
+			return result.Details, nil
+		})
+	case enumor.Azure:
+		if sg, err = cli.Azure.SecurityGroup.GetSecurityGroup(kt.Ctx, kt.Header(), id); err != nil {
+			return nil, err
+		}
+		rules, err = listRules(id, func(page *core.BasePage) ([]corecloud.AzureSecurityGroupRule, error) {
+			req := &protocloud.AzureSGRuleListReq{Filter: ruleFilter, Page: page}
+			result, err := cli.Azure.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(), req, id)
+			if err != nil {
+				return nil, err
+			}
+			return result.Details, nil
+		})
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "security group: %s vendor: %s not support", id, vendor)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := toMap(sg)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleObjs := make([]interface{}, 0)
+	if err = convert(rules, &ruleObjs); err != nil {
+		return nil, err
+	}
+	for _, one := range ruleObjs {
+		if rule, ok := one.(map[string]interface{}); ok {
+			for _, field := range ruleIgnoredFields {
+				delete(rule, field)
+			}
+		}
+	}
+	obj["rules"] = ruleObjs
+
+	return obj, nil
+}
+
+// listRules list all rules of security group page by page.
+func listRules[T any](sgID string, list func(page *core.BasePage) ([]T, error)) ([]T, error) {
+	page := core.NewDefaultBasePage()
+	rules := make([]T, 0)
+	for {
+		details, err := list(page)
+		if err != nil {
+			return nil, fmt.Errorf("list security group: %s rules failed, err: %v", sgID, err)
+		}
+		rules = append(rules, details...)
+
+		if uint(len(details)) < page.Limit {
+			return rules, nil
+		}
+		page.Start += uint32(page.Limit)
+	}
+}
+
+func loadCvmTemplate(kt *kit.Kit, cli *dataservice.Client, id string) (map[string]interface{}, error) {
+	listReq := &core.ListReq{Filter: tools.EqualExpression("id", id), Page: core.NewDefaultBasePage()}
+	result, err := cli.Global.Cvm.ListCvmTemplate(kt, listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "cvm template: %s not found", id)
+	}
+
+	return toMap(result.Details[0])
+}
@@ -37,6 +37,7 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
@@ -50,6 +51,8 @@ type Interface interface {
 	DisassociateEip(kt *kit.Kit, vendor enumor.Vendor, eipID, cvmID, nicID, accountID string) error
 
 	DeleteEip(kt *kit.Kit, vendor enumor.Vendor, eipId string) error
+	DeleteRecycledEip(kt *kit.Kit, basicInfoMap map[string]types.CloudResourceBasicInfo) (
+		*core.BatchOperateResult, error)
 	BatchGetEipInfo(kt *kit.Kit, cvmStatus map[string]*recycle.CvmDetail) error
 	BatchUnbind(kt *kit.Kit, cvmStatus map[string]*recycle.CvmDetail) (failed []string, err error)
 	BatchRebind(kt *kit.Kit, cvmRecycleMap map[string]*recycle.CvmDetail) error
@@ -416,3 +419,48 @@ func (e *eip) DeleteEip(kt *kit.Kit, vendor enumor.Vendor, eipId string) (err er
 	}
 	return err
 }
+
+// DeleteRecycledEip 删除回收站中的eip，eip需要已经解绑
+func (e *eip) DeleteRecycledEip(kt *kit.Kit, basicInfoMap map[string]types.CloudResourceBasicInfo) (
+	*core.BatchOperateResult, error) {
+
+	if len(basicInfoMap) == 0 {
+		return nil, nil
+	}
+
+	if len(basicInfoMap) > constant.BatchOperationMaxLimit {
+		return nil, errf.Newf(errf.InvalidParameter, "eip length should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	ids := make([]string, 0, len(basicInfoMap))
+	for id := range basicInfoMap {
+		ids = append(ids, id)
+	}
+
+	// check if eips are all disassociated
+	relReq := &core.ListReq{
+		Filter: tools.ContainersExpression("eip_id", ids),
+		Page:   &core.BasePage{Count: true},
+	}
+	relRes, err := e.client.DataService().Global.ListEipCvmRel(kt, relReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if converter.PtrToVal(relRes.Count) > 0 {
+		logs.Errorf("some recycled eips(ids: %+v) are associated, cannot be deleted, rid: %s", ids, kt.Rid)
+		return nil, errf.New(errf.InvalidParameter, "recycled eip is associated, cannot be deleted")
+	}
+
+	res := new(core.BatchOperateResult)
+	for _, id := range ids {
+		info := basicInfoMap[id]
+		if err = e.DeleteEip(kt, info.Vendor, id); err != nil {
+			res.Failed = &core.FailedInfo{ID: id, Error: err}
+			return res, err
+		}
+		res.Succeeded = append(res.Succeeded, id)
+	}
+
+	return res, nil
+}
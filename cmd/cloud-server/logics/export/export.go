@@ -0,0 +1,215 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package export resource list export logics, list resources page by page and stream the selected columns into csv
+// or xlsx file.
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	proto "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"github.com/tidwall/gjson"
+)
+
+const (
+	// maxExportRows 单次导出的最大资源数，超过时需要缩小查询条件
+	maxExportRows = uint64(50000)
+)
+
+// PageLister list resources by page, only count is returned when page.Count is true.
+type PageLister func(kt *kit.Kit, page *core.BasePage) (*core.ListResultT[any], error)
+
+// ExtLister list resources with extension of the vendor by ids, return nil if the vendor has no extension.
+type ExtLister func(kt *kit.Kit, vendor enumor.Vendor, ids []string) (any, error)
+
+// Option define resource export option.
+type Option struct {
+	// Name 导出文件名前缀
+	Name    string
+	Format  enumor.ExportFormat
+	Columns []string
+}
+
+// Export list resources page by page and write the selected columns into a temp file, the file is deleted after
+// download. column is the json path of resource, e.g. name, extension.zone_id.
+func Export(kt *kit.Kit, opt *Option, list PageLister) (*proto.ResourceExportFile, error) {
+	countResult, err := list(kt, core.NewCountPage())
+	if err != nil {
+		logs.Errorf("count %s to export failed, err: %v, rid: %s", opt.Name, err, kt.Rid)
+		return nil, err
+	}
+	if countResult.Count > maxExportRows {
+		return nil, errf.Newf(errf.InvalidParameter, "%s count %d exceeds export limit %d, please narrow the "+
+			"filter", opt.Name, countResult.Count, maxExportRows)
+	}
+
+	file, err := os.CreateTemp("", fmt.Sprintf("%s-export-*.%s", opt.Name, opt.Format))
+	if err != nil {
+		logs.Errorf("create %s export file failed, err: %v, rid: %s", opt.Name, err, kt.Rid)
+		return nil, err
+	}
+	defer file.Close()
+
+	exportFile := &proto.ResourceExportFile{
+		FileName: fmt.Sprintf("%s_%s.%s", opt.Name, time.Now().Format("20060102150405"), opt.Format),
+		FilePath: file.Name(),
+		Format:   opt.Format,
+	}
+
+	if err = writeRows(kt, file, opt, list); err != nil {
+		if rmErr := os.Remove(file.Name()); rmErr != nil {
+			logs.Errorf("remove %s export file failed, err: %v, rid: %s", opt.Name, rmErr, kt.Rid)
+		}
+		return nil, err
+	}
+
+	return exportFile, nil
+}
+
+func writeRows(kt *kit.Kit, file *os.File, opt *Option, list PageLister) error {
+	writer, err := newRowWriter(opt.Format, file)
+	if err != nil {
+		logs.Errorf("new %s export writer failed, err: %v, rid: %s", opt.Format, err, kt.Rid)
+		return err
+	}
+
+	if err = writer.WriteRow(opt.Columns); err != nil {
+		return err
+	}
+
+	page := &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit, Sort: "id", Order: core.Ascending}
+	for {
+		result, err := list(kt, page)
+		if err != nil {
+			logs.Errorf("list %s to export failed, err: %v, start: %d, rid: %s", opt.Name, err, page.Start, kt.Rid)
+			return err
+		}
+
+		for _, one := range result.Details {
+			row, err := toRow(one, opt.Columns)
+			if err != nil {
+				logs.Errorf("convert %s to export row failed, err: %v, rid: %s", opt.Name, err, kt.Rid)
+				return err
+			}
+
+			if err = writer.WriteRow(row); err != nil {
+				return err
+			}
+		}
+
+		if uint(len(result.Details)) < page.Limit {
+			break
+		}
+		page.Start += uint32(page.Limit)
+	}
+
+	return writer.Close()
+}
+
+// toRow 按列的 json 路径取值，非标量的值以 json 格式输出
+func toRow(record any, columns []string) ([]string, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+
+	row := make([]string, 0, len(columns))
+	for _, column := range columns {
+		value := gjson.GetBytes(data, column)
+		if value.IsObject() || value.IsArray() {
+			row = append(row, value.Raw)
+			continue
+		}
+		row = append(row, value.String())
+	}
+
+	return row, nil
+}
+
+// ToRecords convert resource details to export records.
+func ToRecords[T any](details []T) []any {
+	records := make([]any, 0, len(details))
+	for _, one := range details {
+		records = append(records, one)
+	}
+
+	return records
+}
+
+// MergeExtension group records by vendor and replace them with the records with extension, records whose vendor
+// has no extension are kept as they are, the order of records is not changed.
+func MergeExtension(kt *kit.Kit, records []any, list ExtLister) ([]any, error) {
+	rawRecords := make([][]byte, 0, len(records))
+	vendorIDs := make(map[enumor.Vendor][]string)
+	for _, one := range records {
+		data, err := json.Marshal(one)
+		if err != nil {
+			return nil, err
+		}
+		rawRecords = append(rawRecords, data)
+
+		vendor := enumor.Vendor(gjson.GetBytes(data, "vendor").String())
+		vendorIDs[vendor] = append(vendorIDs[vendor], gjson.GetBytes(data, "id").String())
+	}
+
+	extMap := make(map[string]json.RawMessage, len(records))
+	for vendor, ids := range vendorIDs {
+		details, err := list(kt, vendor, ids)
+		if err != nil {
+			logs.Errorf("list %s resource extension failed, err: %v, ids: %v, rid: %s", vendor, err, ids, kt.Rid)
+			return nil, err
+		}
+		if details == nil {
+			continue
+		}
+
+		data, err := json.Marshal(details)
+		if err != nil {
+			return nil, err
+		}
+		exts := make([]json.RawMessage, 0, len(ids))
+		if err = json.Unmarshal(data, &exts); err != nil {
+			return nil, err
+		}
+		for _, ext := range exts {
+			extMap[gjson.GetBytes(ext, "id").String()] = ext
+		}
+	}
+
+	merged := make([]any, 0, len(records))
+	for _, data := range rawRecords {
+		if ext, exists := extMap[gjson.GetBytes(data, "id").String()]; exists {
+			merged = append(merged, ext)
+			continue
+		}
+		merged = append(merged, json.RawMessage(data))
+	}
+
+	return merged, nil
+}
@@ -0,0 +1,87 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package export
+
+import (
+	"encoding/json"
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testRecord struct {
+	ID        string         `json:"id"`
+	Vendor    enumor.Vendor  `json:"vendor"`
+	Name      string         `json:"name"`
+	Tags      []string       `json:"tags"`
+	Extension map[string]any `json:"extension,omitempty"`
+}
+
+func Test_toRow(t *testing.T) {
+	record := testRecord{ID: "00000001", Vendor: enumor.TCloud, Name: "test", Tags: []string{"a", "b"},
+		Extension: map[string]any{"zone": "ap-guangzhou-1"}}
+
+	row, err := toRow(record, []string{"id", "name", "tags", "extension.zone", "not_exists"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"00000001", "test", `["a","b"]`, "ap-guangzhou-1", ""}, row)
+}
+
+func TestMergeExtension(t *testing.T) {
+	records := ToRecords([]testRecord{
+		{ID: "00000001", Vendor: enumor.TCloud, Name: "tcloud"},
+		{ID: "00000002", Vendor: enumor.Gcp, Name: "gcp"},
+		{ID: "00000003", Vendor: enumor.TCloud, Name: "tcloud2"},
+	})
+
+	list := func(kt *kit.Kit, vendor enumor.Vendor, ids []string) (any, error) {
+		if vendor != enumor.TCloud {
+			return nil, nil
+		}
+
+		exts := make([]testRecord, 0, len(ids))
+		for _, id := range ids {
+			exts = append(exts, testRecord{ID: id, Vendor: vendor, Extension: map[string]any{"zone": id}})
+		}
+		return exts, nil
+	}
+
+	merged, err := MergeExtension(kit.New(), records, list)
+	assert.NoError(t, err)
+	assert.Len(t, merged, 3)
+
+	ids := make([]string, 0, len(merged))
+	for _, one := range merged {
+		record := new(testRecord)
+		data, err := json.Marshal(one)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(data, record))
+		ids = append(ids, record.ID)
+
+		if record.Vendor == enumor.TCloud {
+			assert.Equal(t, record.ID, record.Extension["zone"])
+		} else {
+			assert.Nil(t, record.Extension)
+		}
+	}
+	assert.Equal(t, []string{"00000001", "00000002", "00000003"}, ids)
+}
@@ -0,0 +1,125 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var (
+	// bomHeader 兼容windows excel打开csv文件时中文乱码
+	bomHeader = []byte{0xEF, 0xBB, 0xBF}
+)
+
+const (
+	defaultSheetName = "Sheet1"
+)
+
+// rowWriter write rows into export file.
+type rowWriter interface {
+	WriteRow(row []string) error
+	// Close flush the buffered rows into file.
+	Close() error
+}
+
+func newRowWriter(format enumor.ExportFormat, w io.Writer) (rowWriter, error) {
+	switch format {
+	case enumor.CsvExportFormat:
+		return newCsvWriter(w)
+	case enumor.XlsxExportFormat:
+		return newXlsxWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCsvWriter(w io.Writer) (*csvWriter, error) {
+	if _, err := w.Write(bomHeader); err != nil {
+		return nil, err
+	}
+
+	return &csvWriter{writer: csv.NewWriter(w)}, nil
+}
+
+// WriteRow ...
+func (c *csvWriter) WriteRow(row []string) error {
+	return c.writer.Write(row)
+}
+
+// Close ...
+func (c *csvWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// xlsxWriter 使用流式写入，行数据由 excelize 缓存在临时文件中，避免大量数据占用内存
+type xlsxWriter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	rowIdx int
+}
+
+func newXlsxWriter(w io.Writer) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter(defaultSheetName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &xlsxWriter{w: w, file: file, stream: stream}, nil
+}
+
+// WriteRow ...
+func (x *xlsxWriter) WriteRow(row []string) error {
+	x.rowIdx++
+	cell, err := excelize.CoordinatesToCellName(1, x.rowIdx)
+	if err != nil {
+		return err
+	}
+
+	values := make([]interface{}, 0, len(row))
+	for _, one := range row {
+		values = append(values, one)
+	}
+
+	return x.stream.SetRow(cell, values)
+}
+
+// Close ...
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+
+	return x.file.Write(x.w)
+}
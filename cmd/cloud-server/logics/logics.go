@@ -23,18 +23,31 @@ package logics
 import (
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/logics/cvm"
+	deletehook "hcm/cmd/cloud-server/logics/delete-hook"
 	"hcm/cmd/cloud-server/logics/disk"
 	"hcm/cmd/cloud-server/logics/eip"
+	maintenancewindow "hcm/cmd/cloud-server/logics/maintenance-window"
+	"hcm/cmd/cloud-server/logics/naming"
+	"hcm/cmd/cloud-server/logics/price"
+	"hcm/cmd/cloud-server/logics/quota"
+	tagpolicy "hcm/cmd/cloud-server/logics/tag-policy"
+	"hcm/pkg/cc"
 	"hcm/pkg/client"
 	"hcm/pkg/thirdparty/esb"
 )
 
 // Logics defines cloud-server common logics.
 type Logics struct {
-	Audit audit.Interface
-	Disk  disk.Interface
-	Cvm   cvm.Interface
-	Eip   eip.Interface
+	Audit             audit.Interface
+	Disk              disk.Interface
+	Cvm               cvm.Interface
+	Eip               eip.Interface
+	Quota             quota.Interface
+	Naming            naming.Interface
+	TagPolicy         tagpolicy.Interface
+	DeleteHook        deletehook.Interface
+	MaintenanceWindow maintenancewindow.Interface
+	Price             price.Interface
 }
 
 // NewLogics create a new cloud server logics.
@@ -43,9 +56,15 @@ func NewLogics(c *client.ClientSet, esbClient esb.Client) *Logics {
 	eipLogics := eip.NewEip(c, auditLogics)
 	diskLogics := disk.NewDisk(c, auditLogics)
 	return &Logics{
-		Audit: auditLogics,
-		Disk:  disk.NewDisk(c, auditLogics),
-		Cvm:   cvm.NewCvm(c, auditLogics, eipLogics, diskLogics, esbClient),
-		Eip:   eip.NewEip(c, auditLogics),
+		Audit:             auditLogics,
+		Disk:              disk.NewDisk(c, auditLogics),
+		Cvm:               cvm.NewCvm(c, auditLogics, eipLogics, diskLogics, esbClient),
+		Eip:               eip.NewEip(c, auditLogics),
+		Quota:             quota.NewQuota(c),
+		Naming:            naming.NewNaming(c),
+		TagPolicy:         tagpolicy.NewTagPolicy(c),
+		DeleteHook:        deletehook.NewDeleteHook(cc.CloudServer().PreDeleteHooks),
+		MaintenanceWindow: maintenancewindow.NewMaintenanceWindow(c),
+		Price:             price.NewPrice(c, cc.CloudServer().PriceEstimate),
 	}
 }
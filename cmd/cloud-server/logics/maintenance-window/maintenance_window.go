@@ -0,0 +1,151 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package maintenancewindow maintenance window logics, check whether the disruptive actions of biz resources are
+// in the maintenance windows of the biz.
+package maintenancewindow
+
+import (
+	"time"
+
+	"hcm/pkg/api/core"
+	coremw "hcm/pkg/api/core/maintenance-window"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// Interface define maintenance window interface.
+type Interface interface {
+	// CheckAllowed 检查资源所属业务当前是否在维护窗口内，存在不在维护窗口内的业务时返回错误，用于拒绝用户发起的操作
+	CheckAllowed(kt *kit.Kit, action enumor.MaintenanceAction,
+		basicInfoMap map[string]types.CloudResourceBasicInfo) error
+	// ListOutOfWindowBiz 返回在指定时间不在维护窗口内的业务，用于定时任务推迟执行，未配置维护窗口的业务不受限制
+	ListOutOfWindowBiz(kt *kit.Kit, action enumor.MaintenanceAction, bizIDs []int64, now time.Time) ([]int64,
+		error)
+}
+
+type maintenanceWindow struct {
+	client *client.ClientSet
+}
+
+// NewMaintenanceWindow new maintenance window logics.
+func NewMaintenanceWindow(client *client.ClientSet) Interface {
+	return &maintenanceWindow{
+		client: client,
+	}
+}
+
+// CheckAllowed check whether the action of the resources is allowed now.
+func (m *maintenanceWindow) CheckAllowed(kt *kit.Kit, action enumor.MaintenanceAction,
+	basicInfoMap map[string]types.CloudResourceBasicInfo) error {
+
+	bizIDs := make([]int64, 0)
+	for _, info := range basicInfoMap {
+		bizIDs = append(bizIDs, info.BkBizID)
+	}
+
+	outOfWindow, err := m.ListOutOfWindowBiz(kt, action, bizIDs, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if len(outOfWindow) != 0 {
+		return errf.Newf(errf.OutOfMaintenanceWindow, "%s is not allowed now, biz %v is out of maintenance window",
+			action, outOfWindow)
+	}
+
+	return nil
+}
+
+// ListOutOfWindowBiz list biz which has enabled maintenance windows restricting the action but none of them
+// contains the time.
+func (m *maintenanceWindow) ListOutOfWindowBiz(kt *kit.Kit, action enumor.MaintenanceAction, bizIDs []int64,
+	now time.Time) ([]int64, error) {
+
+	bizIDs = slice.Filter(slice.Unique(bizIDs), func(bizID int64) bool { return bizID > 0 })
+	if len(bizIDs) == 0 {
+		return make([]int64, 0), nil
+	}
+
+	windows, err := m.listEnabled(kt, bizIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// restricted 配置了限制该操作的维护窗口的业务，inWindow 当前在维护窗口内的业务
+	restricted, inWindow := make(map[int64]struct{}), make(map[int64]struct{})
+	for i := range windows {
+		if !windows[i].Restricts(action) {
+			continue
+		}
+		restricted[windows[i].BkBizID] = struct{}{}
+
+		contains, err := windows[i].Contains(now)
+		if err != nil {
+			logs.Errorf("check maintenance window failed, err: %v, id: %s, rid: %s", err, windows[i].ID, kt.Rid)
+			continue
+		}
+		if contains {
+			inWindow[windows[i].BkBizID] = struct{}{}
+		}
+	}
+
+	outOfWindow := make([]int64, 0)
+	for bizID := range restricted {
+		if _, exists := inWindow[bizID]; !exists {
+			outOfWindow = append(outOfWindow, bizID)
+		}
+	}
+
+	return outOfWindow, nil
+}
+
+func (m *maintenanceWindow) listEnabled(kt *kit.Kit, bizIDs []int64) ([]coremw.MaintenanceWindow, error) {
+	windows := make([]coremw.MaintenanceWindow, 0)
+	for _, batch := range slice.Split(bizIDs, int(core.DefaultMaxPageLimit)) {
+		listReq := &core.ListReq{
+			Filter: tools.ExpressionAnd(
+				tools.RuleIn("bk_biz_id", batch),
+				tools.RuleEqual("enabled", true),
+			),
+			Page: core.NewDefaultBasePage(),
+		}
+		for {
+			result, err := m.client.DataService().Global.MaintenanceWindow.List(kt, listReq)
+			if err != nil {
+				logs.Errorf("list maintenance window failed, err: %v, biz: %v, rid: %s", err, batch, kt.Rid)
+				return nil, err
+			}
+			windows = append(windows, result.Details...)
+
+			if uint(len(result.Details)) < listReq.Page.Limit {
+				break
+			}
+			listReq.Page.Start += uint32(listReq.Page.Limit)
+		}
+	}
+
+	return windows, nil
+}
@@ -0,0 +1,87 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package naming resource naming rule logics, check whether the name of resource to be created conforms to the
+// naming rule of the biz.
+package naming
+
+import (
+	"hcm/pkg/api/core"
+	corenaming "hcm/pkg/api/core/naming"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// Interface define resource naming rule interface.
+type Interface interface {
+	CheckName(kt *kit.Kit, bizID int64, resType enumor.CloudResourceType, name string) error
+}
+
+type naming struct {
+	client *client.ClientSet
+}
+
+// NewNaming new resource naming rule logics.
+func NewNaming(client *client.ClientSet) Interface {
+	return &naming{
+		client: client,
+	}
+}
+
+// CheckName check whether the name conforms to the naming rule of the biz and resource type, the default naming
+// rule is used if the biz has no rule of the resource type, and no check if neither is configured.
+func (n *naming) CheckName(kt *kit.Kit, bizID int64, resType enumor.CloudResourceType, name string) error {
+	if err := enumor.ValidateNamingRuleResType(resType); err != nil {
+		return nil
+	}
+
+	if bizID <= 0 {
+		bizID = constant.UnassignedBiz
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleIn("bk_biz_id", []int64{bizID, constant.UnassignedBiz}),
+			tools.RuleEqual("res_type", resType),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := n.client.DataService().Global.NamingRule.List(kt, listReq)
+	if err != nil {
+		logs.Errorf("list naming rule failed, err: %v, biz: %d, res_type: %s, rid: %s", err, bizID, resType, kt.Rid)
+		return err
+	}
+
+	rule := corenaming.MatchRule(result.Details, bizID, resType)
+	if rule == nil {
+		return nil
+	}
+
+	if err = rule.Check(name); err != nil {
+		return errf.Newf(errf.NamingRuleViolated, "%s name does not conform to the naming rule of biz %d, %v",
+			resType, bizID, err)
+	}
+
+	return nil
+}
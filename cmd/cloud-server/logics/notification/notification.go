@@ -0,0 +1,280 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package notification notification logics, dispatch events such as sync failures, credential expiry, budget alerts
+// and approval events to the subscribers by email, wecom or webhook.
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+	"time"
+
+	"hcm/pkg/api/core"
+	corenotification "hcm/pkg/api/core/notification"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/thirdparty/api-gateway/cmsi"
+	"hcm/pkg/tools/slice"
+)
+
+// Interface define notification interface.
+type Interface interface {
+	// Notify 将事件通知给订阅了该事件的接收人，通知失败只记录日志，不影响调用方的业务流程
+	Notify(kt *kit.Kit, event *Event)
+	// NotifySubscription 使用指定订阅发送通知，用于测试订阅配置，返回各渠道的发送结果
+	NotifySubscription(kt *kit.Kit, sub *corenotification.Subscription, event *Event) map[enumor.NotificationChannel]error
+}
+
+// Event define notification event.
+type Event struct {
+	Type enumor.NotificationEventType
+	// AccountID 事件关联的账号，用于匹配订阅的账号范围
+	AccountID string
+	// BkBizIDs 事件关联的业务，用于匹配订阅的业务范围
+	BkBizIDs []int64
+	// RelatedUsers 事件相关人员，如单据申请人，订阅开启 notify_related 时一并通知
+	RelatedUsers []string
+	// Data 事件数据，用于渲染通知模板
+	Data map[string]interface{}
+}
+
+type notification struct {
+	client  *client.ClientSet
+	cmsiCli cmsi.Client
+	http    *http.Client
+}
+
+// NewNotification new notification logics.
+func NewNotification(client *client.ClientSet, cmsiCli cmsi.Client) Interface {
+	return &notification{
+		client:  client,
+		cmsiCli: cmsiCli,
+		http:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Notify send notification of the event to all enabled subscriptions which match the event.
+func (n *notification) Notify(kt *kit.Kit, event *Event) {
+	if event == nil {
+		return
+	}
+
+	subs, err := n.listSubscriptions(kt, event.Type)
+	if err != nil {
+		logs.Errorf("list %s notification subscription failed, err: %v, rid: %s", event.Type, err, kt.Rid)
+		return
+	}
+
+	for i := range subs {
+		if !matchScope(&subs[i], event) {
+			continue
+		}
+
+		for channel, sendErr := range n.NotifySubscription(kt, &subs[i], event) {
+			if sendErr != nil {
+				logs.Errorf("send %s notification by %s failed, err: %v, subscription: %s, rid: %s", event.Type,
+					channel, sendErr, subs[i].ID, kt.Rid)
+			}
+		}
+	}
+}
+
+// NotifySubscription send notification of the event to the subscription by all its channels.
+func (n *notification) NotifySubscription(kt *kit.Kit, sub *corenotification.Subscription,
+	event *Event) map[enumor.NotificationChannel]error {
+
+	templates, err := n.getTemplates(kt, sub.TemplateIDs)
+	if err != nil {
+		logs.Errorf("get notification templates failed, err: %v, ids: %v, rid: %s", err, sub.TemplateIDs, kt.Rid)
+	}
+
+	receivers := sub.Receivers
+	if sub.NotifyRelated {
+		receivers = slice.Unique(append(append([]string{}, receivers...), event.RelatedUsers...))
+	}
+
+	results := make(map[enumor.NotificationChannel]error, len(sub.Channels))
+	for _, channel := range sub.Channels {
+		title, content := render(kt, templates[channel], event, channel)
+
+		switch channel {
+		case enumor.NotificationEmail:
+			results[channel] = n.sendMail(kt, receivers, title, content)
+		case enumor.NotificationWecom:
+			results[channel] = n.sendWecom(kt, receivers, title, content)
+		case enumor.NotificationWebhook:
+			results[channel] = n.sendWebhook(kt, sub.WebhookUrl, event, title, content)
+		default:
+			results[channel] = fmt.Errorf("unsupported notification channel: %s", channel)
+		}
+	}
+
+	return results
+}
+
+func (n *notification) listSubscriptions(kt *kit.Kit, eventType enumor.NotificationEventType) (
+	[]corenotification.Subscription, error) {
+
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("event_type", eventType),
+			tools.RuleEqual("enabled", true),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+
+	subs := make([]corenotification.Subscription, 0)
+	for {
+		result, err := n.client.DataService().Global.Notification.ListSubscription(kt, listReq)
+		if err != nil {
+			return nil, err
+		}
+
+		subs = append(subs, result.Details...)
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return subs, nil
+}
+
+// getTemplates get the templates of the subscription, key is channel.
+func (n *notification) getTemplates(kt *kit.Kit, ids []string) (
+	map[enumor.NotificationChannel]*corenotification.Template, error) {
+
+	templates := make(map[enumor.NotificationChannel]*corenotification.Template)
+	if len(ids) == 0 {
+		return templates, nil
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := n.client.DataService().Global.Notification.ListTemplate(kt, listReq)
+	if err != nil {
+		return templates, err
+	}
+
+	for i := range result.Details {
+		templates[result.Details[i].Channel] = &result.Details[i]
+	}
+
+	return templates, nil
+}
+
+// matchScope 订阅的账号、业务范围为空表示不限制，否则事件需关联到范围内的账号或业务
+func matchScope(sub *corenotification.Subscription, event *Event) bool {
+	if len(sub.AccountIDs) > 0 && !slice.IsItemInSlice(sub.AccountIDs, event.AccountID) {
+		return false
+	}
+
+	if len(sub.BkBizIDs) > 0 {
+		for _, bizID := range event.BkBizIDs {
+			if slice.IsItemInSlice(sub.BkBizIDs, bizID) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+func (n *notification) sendMail(kt *kit.Kit, receivers []string, title, content string) error {
+	if len(receivers) == 0 {
+		return fmt.Errorf("no receiver to send mail")
+	}
+
+	mail := &cmsi.CmsiMail{
+		ReceiverUserName: strings.Join(receivers, ","),
+		Title:            title,
+		Content:          strings.ReplaceAll(html.EscapeString(content), "\n", "<br/>"),
+	}
+	return n.cmsiCli.SendMail(kt, mail)
+}
+
+func (n *notification) sendWecom(kt *kit.Kit, receivers []string, title, content string) error {
+	if len(receivers) == 0 {
+		return fmt.Errorf("no receiver to send wecom message")
+	}
+
+	msg := &cmsi.CmsiWeixin{
+		ReceiverUserName: strings.Join(receivers, ","),
+		Data: cmsi.CmsiWeixinData{
+			Heading: title,
+			Message: content,
+		},
+	}
+	return n.cmsiCli.SendWeixin(kt, msg)
+}
+
+// webhookPayload 通用webhook渠道发送的消息体
+type webhookPayload struct {
+	EventType  enumor.NotificationEventType `json:"event_type"`
+	Title      string                       `json:"title"`
+	Content    string                       `json:"content"`
+	AccountID  string                       `json:"account_id,omitempty"`
+	BkBizIDs   []int64                      `json:"bk_biz_ids,omitempty"`
+	Data       map[string]interface{}       `json:"data"`
+	OccurredAt string                       `json:"occurred_at"`
+	Rid        string                       `json:"rid"`
+}
+
+func (n *notification) sendWebhook(kt *kit.Kit, url string, event *Event, title, content string) error {
+	if len(url) == 0 {
+		return fmt.Errorf("webhook url is empty")
+	}
+
+	body, err := json.Marshal(&webhookPayload{
+		EventType:  event.Type,
+		Title:      title,
+		Content:    content,
+		AccountID:  event.AccountID,
+		BkBizIDs:   event.BkBizIDs,
+		Data:       event.Data,
+		OccurredAt: time.Now().Format(time.RFC3339),
+		Rid:        kt.Rid,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := n.http.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook %s responded with status %d", url, resp.StatusCode)
+	}
+
+	return nil
+}
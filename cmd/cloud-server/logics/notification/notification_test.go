@@ -0,0 +1,143 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package notification
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	corenotification "hcm/pkg/api/core/notification"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/thirdparty/api-gateway/cmsi"
+)
+
+type fakeCmsi struct {
+	mails []*cmsi.CmsiMail
+}
+
+// SendMail ...
+func (f *fakeCmsi) SendMail(_ *kit.Kit, m *cmsi.CmsiMail) error {
+	f.mails = append(f.mails, m)
+	return nil
+}
+
+// SendWeixin ...
+func (f *fakeCmsi) SendWeixin(_ *kit.Kit, _ *cmsi.CmsiWeixin) error {
+	return nil
+}
+
+func TestRender(t *testing.T) {
+	event := &Event{Type: enumor.NotificationSyncFailed, Data: map[string]interface{}{"account_name": "prod"}}
+
+	kt := kit.New()
+	title, _ := render(kt, nil, event, enumor.NotificationEmail)
+	if title != "【HCM】云资源同步失败：prod" {
+		t.Errorf("unexpected chinese default title: %s", title)
+	}
+
+	kt.Language = constant.English
+	title, _ = render(kt, nil, event, enumor.NotificationEmail)
+	if title != "[HCM] Cloud resource sync failed: prod" {
+		t.Errorf("unexpected english default title: %s", title)
+	}
+
+	tpl := &corenotification.Template{ID: "tpl-1", Title: "sync {{.account_name}}", Content: "{{.account_name}} failed"}
+	title, content := render(kt, tpl, event, enumor.NotificationEmail)
+	if title != "sync prod" || content != "prod failed" {
+		t.Errorf("unexpected custom template result: %s, %s", title, content)
+	}
+
+	// 自定义模板渲染失败时回退到默认模板
+	tpl.Title = "{{.account_name"
+	if title, _ = render(kt, tpl, event, enumor.NotificationEmail); !strings.HasPrefix(title, "[HCM]") {
+		t.Errorf("invalid custom template should fall back to default template, but got: %s", title)
+	}
+}
+
+func TestMatchScope(t *testing.T) {
+	event := &Event{AccountID: "account-1", BkBizIDs: []int64{1, 2}}
+
+	cases := []struct {
+		sub   corenotification.Subscription
+		match bool
+	}{
+		{sub: corenotification.Subscription{}, match: true},
+		{sub: corenotification.Subscription{AccountIDs: []string{"account-1"}, BkBizIDs: []int64{2}}, match: true},
+		{sub: corenotification.Subscription{AccountIDs: []string{"account-2"}}},
+		{sub: corenotification.Subscription{BkBizIDs: []int64{3}}},
+	}
+	for idx, c := range cases {
+		if matchScope(&c.sub, event) != c.match {
+			t.Errorf("case %d: expect match %v", idx, c.match)
+		}
+	}
+}
+
+func TestNotifySubscription(t *testing.T) {
+	var payload webhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	cmsiCli := new(fakeCmsi)
+	n := &notification{cmsiCli: cmsiCli, http: server.Client()}
+	sub := &corenotification.Subscription{
+		ID:            "sub-1",
+		Channels:      []enumor.NotificationChannel{enumor.NotificationEmail, enumor.NotificationWebhook},
+		Receivers:     []string{"admin"},
+		NotifyRelated: true,
+		WebhookUrl:    server.URL,
+	}
+	event := &Event{
+		Type:         enumor.NotificationApplicationStatus,
+		AccountID:    "account-1",
+		RelatedUsers: []string{"alice", "admin"},
+		Data:         map[string]interface{}{"application_sn": "sn-1", "status": "pass"},
+	}
+
+	results := n.NotifySubscription(kit.New(), sub, event)
+	for channel, err := range results {
+		if err != nil {
+			t.Errorf("send notification by %s failed, err: %v", channel, err)
+		}
+	}
+
+	// 开启通知相关人员时，接收人包含去重后的单据申请人
+	if len(cmsiCli.mails) != 1 || cmsiCli.mails[0].ReceiverUserName != "admin,alice" {
+		t.Errorf("unexpected mails: %+v", cmsiCli.mails)
+	}
+	if payload.EventType != enumor.NotificationApplicationStatus || payload.AccountID != "account-1" ||
+		!strings.Contains(payload.Title, "sn-1") {
+		t.Errorf("unexpected webhook payload: %+v", payload)
+	}
+
+	sub.WebhookUrl = ""
+	if err := n.NotifySubscription(kit.New(), sub, event)[enumor.NotificationWebhook]; err == nil {
+		t.Errorf("webhook without url should fail")
+	}
+}
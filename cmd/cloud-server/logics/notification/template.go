@@ -0,0 +1,203 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package notification
+
+import (
+	"bytes"
+	"text/template"
+
+	corenotification "hcm/pkg/api/core/notification"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// defaultTemplate 订阅未配置某个渠道的模板时使用的默认模板，模板使用 go text/template 语法，通过 {{.key}} 引用事件数据
+type defaultTemplate struct {
+	title   string
+	content string
+}
+
+// defaultTemplates 默认模板，按语言区分，不支持的语言使用中文模板
+var defaultTemplates = map[constant.Language]map[enumor.NotificationEventType]defaultTemplate{
+	constant.Chinese: zhDefaultTemplates,
+	constant.English: enDefaultTemplates,
+}
+
+var zhDefaultTemplates = map[enumor.NotificationEventType]defaultTemplate{
+	// 事件数据：vendor、account_id、account_name、resource、error
+	enumor.NotificationSyncFailed: {
+		title: "【HCM】云资源同步失败：{{.account_name}}",
+		content: `账号【{{.account_name}}】({{.account_id}}) 同步云资源失败，请及时处理。
+云厂商：{{.vendor}}
+失败资源：{{.resource}}
+失败原因：{{.error}}`,
+	},
+	// 事件数据：vendor、account_id、account_name、error
+	enumor.NotificationCredentialInvalid: {
+		title: "【HCM】云账号密钥失效：{{.account_name}}",
+		content: `账号【{{.account_name}}】({{.account_id}}) 的密钥已失效，可能已过期、被禁用或被删除，该账号的资源同步将持续失败，请及时更新账号密钥。
+云厂商：{{.vendor}}
+错误信息：{{.error}}`,
+	},
+	// 事件数据：budget_name、scope、period、cost、amount、currency、percent、threshold
+	enumor.NotificationBudgetAlert: {
+		title: "【HCM】云账单预算告警：{{.budget_name}}",
+		content: `云账单预算【{{.budget_name}}】费用已超过告警阈值，请及时关注。
+预算范围：{{.scope}}
+账期：{{.period}}
+本月已产生费用：{{.cost}} {{.currency}}
+月度预算金额：{{.amount}} {{.currency}}
+预算使用比例：{{.percent}}%，已超过告警阈值 {{.threshold}}%`,
+	},
+	// 事件数据：application_id、application_sn、application_type、applicant、status
+	enumor.NotificationApplicationStatus: {
+		title:   "【HCM】单据状态变更：{{.application_sn}}",
+		content: `{{.applicant}} 提交的{{.application_type}}单据【{{.application_sn}}】状态已变更为：{{.status}}`,
+	},
+}
+
+var enDefaultTemplates = map[enumor.NotificationEventType]defaultTemplate{
+	enumor.NotificationSyncFailed: {
+		title: "[HCM] Cloud resource sync failed: {{.account_name}}",
+		content: `Account [{{.account_name}}] ({{.account_id}}) failed to sync cloud resources, ` +
+			`please handle it in time.
+Vendor: {{.vendor}}
+Failed resource: {{.resource}}
+Reason: {{.error}}`,
+	},
+	enumor.NotificationCredentialInvalid: {
+		title: "[HCM] Cloud account credential invalid: {{.account_name}}",
+		content: `The credential of account [{{.account_name}}] ({{.account_id}}) is invalid, it may be expired, ` +
+			`disabled or deleted. The resource sync of the account will keep failing, ` +
+			`please update the credential in time.
+Vendor: {{.vendor}}
+Error: {{.error}}`,
+	},
+	enumor.NotificationBudgetAlert: {
+		title: "[HCM] Cloud bill budget alert: {{.budget_name}}",
+		content: `The cost of budget [{{.budget_name}}] exceeds the alert threshold, please pay attention to it.
+Scope: {{.scope}}
+Period: {{.period}}
+Cost of this month: {{.cost}} {{.currency}}
+Monthly budget: {{.amount}} {{.currency}}
+Budget used: {{.percent}}%, exceeds the alert threshold {{.threshold}}%`,
+	},
+	enumor.NotificationApplicationStatus: {
+		title: "[HCM] Application status changed: {{.application_sn}}",
+		content: `The status of {{.application_type}} application [{{.application_sn}}] submitted by ` +
+			`{{.applicant}} is changed to: {{.status}}`,
+	},
+}
+
+// render 渲染通知标题和内容，自定义模板渲染失败时回退到请求语言的默认模板
+func render(kt *kit.Kit, tpl *corenotification.Template, event *Event,
+	channel enumor.NotificationChannel) (string, string) {
+
+	if tpl != nil {
+		title, titleErr := execute(tpl.Title, event.Data)
+		content, contentErr := execute(tpl.Content, event.Data)
+		if titleErr == nil && contentErr == nil {
+			return title, content
+		}
+		logs.Errorf("render %s notification template %s failed, title err: %v, content err: %v, rid: %s",
+			channel, tpl.ID, titleErr, contentErr, kt.Rid)
+	}
+
+	templates, ok := defaultTemplates[kt.Language]
+	if !ok {
+		templates = defaultTemplates[constant.Chinese]
+	}
+	def := templates[event.Type]
+	title, err := execute(def.title, event.Data)
+	if err != nil {
+		logs.Errorf("render default %s notification title failed, err: %v, rid: %s", event.Type, err, kt.Rid)
+		title = string(event.Type)
+	}
+	content, err := execute(def.content, event.Data)
+	if err != nil {
+		logs.Errorf("render default %s notification content failed, err: %v, rid: %s", event.Type, err, kt.Rid)
+	}
+
+	return title, content
+}
+
+func execute(text string, data map[string]interface{}) (string, error) {
+	tpl, err := template.New("notification").Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	buf := new(bytes.Buffer)
+	if err = tpl.Execute(buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// ValidateTemplate validate whether the text can be parsed as notification template.
+func ValidateTemplate(text string) error {
+	_, err := template.New("notification").Parse(text)
+	return err
+}
+
+// SampleEventData return the sample data of the event type, which is used to send test notification.
+func SampleEventData(eventType enumor.NotificationEventType) map[string]interface{} {
+	switch eventType {
+	case enumor.NotificationSyncFailed:
+		return map[string]interface{}{
+			"vendor":       enumor.TCloud,
+			"account_id":   "00000001",
+			"account_name": "test-account",
+			"resource":     enumor.CvmCloudResType,
+			"error":        "this is a test notification",
+		}
+	case enumor.NotificationCredentialInvalid:
+		return map[string]interface{}{
+			"vendor":       enumor.TCloud,
+			"account_id":   "00000001",
+			"account_name": "test-account",
+			"error":        "this is a test notification",
+		}
+	case enumor.NotificationBudgetAlert:
+		return map[string]interface{}{
+			"budget_name": "test-budget",
+			"scope":       "test",
+			"period":      "2025-05",
+			"cost":        "800.00",
+			"amount":      "1000.00",
+			"currency":    enumor.CurrencyRMB,
+			"percent":     "80.00",
+			"threshold":   80,
+		}
+	case enumor.NotificationApplicationStatus:
+		return map[string]interface{}{
+			"application_id":   "00000001",
+			"application_sn":   "TEST-00000001",
+			"application_type": "test",
+			"applicant":        "test",
+			"status":           "this is a test notification",
+		}
+	default:
+		return make(map[string]interface{})
+	}
+}
@@ -0,0 +1,271 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package price estimate the monthly cost of resource applications before submitted.
+package price
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	csapplication "hcm/pkg/api/cloud-server/application"
+	hcproto "hcm/pkg/api/hc-service/instance-type"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+)
+
+// hoursPerMonth 按小时计费的价格折算为月价格时使用的每月小时数
+const hoursPerMonth = 730
+
+// Interface define price estimate interface.
+type Interface interface {
+	EstimateCvm(kt *kit.Kit, opt *CvmEstimateOption) (*csapplication.CvmPriceEstimate, error)
+}
+
+type price struct {
+	client *client.ClientSet
+	conf   cc.PriceEstimate
+}
+
+// NewPrice new price estimate logics.
+func NewPrice(client *client.ClientSet, conf cc.PriceEstimate) Interface {
+	return &price{
+		client: client,
+		conf:   conf,
+	}
+}
+
+// CvmEstimateOption 申请主机时用于预估费用的参数，与各云厂商创建主机的申请参数字段一致
+type CvmEstimateOption struct {
+	Vendor             enumor.Vendor  `json:"vendor"`
+	AccountID          string         `json:"account_id"`
+	Region             string         `json:"region"`
+	Zone               string         `json:"zone"`
+	InstanceType       string         `json:"instance_type"`
+	InstanceChargeType string         `json:"instance_charge_type"`
+	PublicIPAssigned   bool           `json:"public_ip_assigned"`
+	RequiredCount      int64          `json:"required_count"`
+	SystemDisk         EstimateDisk   `json:"system_disk"`
+	DataDisk           []EstimateDisk `json:"data_disk"`
+}
+
+// EstimateDisk 申请主机的硬盘，系统盘没有数量
+type EstimateDisk struct {
+	DiskSizeGB int64 `json:"disk_size_gb"`
+	DiskCount  int64 `json:"disk_count"`
+}
+
+// instanceSpec 机型规格及云上价格，cloudMonthly 为nil表示云上未返回价格
+type instanceSpec struct {
+	cpu          int64
+	memoryMB     int64
+	cloudMonthly *decimal.Decimal
+}
+
+// EstimateCvm estimate the monthly cost of the applied cvms.
+func (p *price) EstimateCvm(kt *kit.Kit, opt *CvmEstimateOption) (*csapplication.CvmPriceEstimate, error) {
+	if opt == nil || len(opt.InstanceType) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "instance_type is required")
+	}
+
+	if opt.RequiredCount <= 0 {
+		return nil, errf.New(errf.InvalidParameter, "required_count should > 0")
+	}
+
+	instanceItem, err := p.estimateInstance(kt, opt)
+	if err != nil {
+		return nil, err
+	}
+	items := []csapplication.PriceItem{*instanceItem}
+
+	diskGB := decimal.NewFromFloat(p.conf.DiskGBMonthly)
+	if opt.SystemDisk.DiskSizeGB > 0 {
+		items = append(items, newItem(csapplication.SystemDiskPriceItem, csapplication.ConfigPriceSource,
+			opt.SystemDisk.DiskSizeGB, diskGB))
+	}
+
+	var dataDiskSize int64
+	for _, disk := range opt.DataDisk {
+		dataDiskSize += disk.DiskSizeGB * disk.DiskCount
+	}
+	if dataDiskSize > 0 {
+		items = append(items, newItem(csapplication.DataDiskPriceItem, csapplication.ConfigPriceSource,
+			dataDiskSize, diskGB))
+	}
+
+	if opt.PublicIPAssigned {
+		items = append(items, newItem(csapplication.EipPriceItem, csapplication.ConfigPriceSource, 1,
+			decimal.NewFromFloat(p.conf.EipMonthly)))
+	}
+
+	unitMonthly := decimal.Zero
+	for _, item := range items {
+		unitMonthly = unitMonthly.Add(item.Monthly)
+	}
+
+	return &csapplication.CvmPriceEstimate{
+		Currency:      p.conf.Currency,
+		RequiredCount: opt.RequiredCount,
+		Items:         items,
+		UnitMonthly:   unitMonthly,
+		TotalMonthly:  unitMonthly.Mul(decimal.NewFromInt(opt.RequiredCount)),
+	}, nil
+}
+
+// estimateInstance 机型价格优先使用配置的机型月单价，其次使用云上机型列表返回的价格，均没有时按vCPU核数及内存计算
+func (p *price) estimateInstance(kt *kit.Kit, opt *CvmEstimateOption) (*csapplication.PriceItem, error) {
+	if monthly, exists := p.conf.InstanceTypeMonthly[opt.InstanceType]; exists {
+		item := newItem(csapplication.InstancePriceItem, csapplication.ConfigPriceSource, 1,
+			decimal.NewFromFloat(monthly))
+		return &item, nil
+	}
+
+	spec, err := p.getInstanceSpec(kt, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	if spec.cloudMonthly != nil {
+		item := newItem(csapplication.InstancePriceItem, csapplication.CloudPriceSource, 1, *spec.cloudMonthly)
+		return &item, nil
+	}
+
+	monthly := decimal.NewFromFloat(p.conf.CvmCpuCoreMonthly).Mul(decimal.NewFromInt(spec.cpu)).
+		Add(decimal.NewFromFloat(p.conf.CvmMemoryGBMonthly).Mul(decimal.NewFromInt(spec.memoryMB)).
+			Div(decimal.NewFromInt(1024)))
+	item := newItem(csapplication.InstancePriceItem, csapplication.ConfigPriceSource, 1, monthly)
+	return &item, nil
+}
+
+func newItem(itemType csapplication.PriceItemType, source csapplication.PriceSource, quantity int64,
+	unitMonthly decimal.Decimal) csapplication.PriceItem {
+
+	return csapplication.PriceItem{
+		Type:        itemType,
+		Source:      source,
+		Quantity:    quantity,
+		UnitMonthly: unitMonthly.Round(2),
+		Monthly:     unitMonthly.Mul(decimal.NewFromInt(quantity)).Round(2),
+	}
+}
+
+// getInstanceSpec 查询机型的规格，腾讯云机型列表同时返回机型价格
+func (p *price) getInstanceSpec(kt *kit.Kit, opt *CvmEstimateOption) (*instanceSpec, error) {
+	var spec *instanceSpec
+	var err error
+
+	switch opt.Vendor {
+	case enumor.TCloud:
+		listReq := &hcproto.TCloudInstanceTypeListReq{AccountID: opt.AccountID, Region: opt.Region, Zone: opt.Zone,
+			InstanceChargeType: opt.InstanceChargeType}
+		list, lErr := p.client.HCService().TCloud.InstanceType.List(kt, listReq)
+		err = lErr
+		for _, one := range list {
+			if one.InstanceType == opt.InstanceType {
+				spec = &instanceSpec{cpu: one.CPU, memoryMB: one.Memory,
+					cloudMonthly: tcloudMonthly(opt.InstanceChargeType, one)}
+				break
+			}
+		}
+	case enumor.Aws:
+		listReq := &hcproto.AwsInstanceTypeListReq{AccountID: opt.AccountID, Region: opt.Region}
+		list, lErr := p.client.HCService().Aws.InstanceType.List(kt, listReq)
+		err = lErr
+		for _, one := range list {
+			if one.InstanceType == opt.InstanceType {
+				spec = &instanceSpec{cpu: one.CPU, memoryMB: one.Memory}
+				break
+			}
+		}
+	case enumor.HuaWei:
+		listReq := &hcproto.HuaWeiInstanceTypeListReq{AccountID: opt.AccountID, Region: opt.Region, Zone: opt.Zone}
+		list, lErr := p.client.HCService().HuaWei.InstanceType.List(kt, listReq)
+		err = lErr
+		for _, one := range list {
+			if one.InstanceType == opt.InstanceType {
+				spec = &instanceSpec{cpu: one.CPU, memoryMB: one.Memory}
+				break
+			}
+		}
+	case enumor.Gcp:
+		listReq := &hcproto.GcpInstanceTypeListReq{AccountID: opt.AccountID, Zone: opt.Zone}
+		list, lErr := p.client.HCService().Gcp.InstanceType.List(kt, listReq)
+		err = lErr
+		for _, one := range list {
+			if one.InstanceType == opt.InstanceType {
+				spec = &instanceSpec{cpu: one.CPU, memoryMB: one.Memory}
+				break
+			}
+		}
+	case enumor.Azure:
+		listReq := &hcproto.AzureInstanceTypeListReq{AccountID: opt.AccountID, Region: opt.Region}
+		list, lErr := p.client.HCService().Azure.InstanceType.List(kt, listReq)
+		err = lErr
+		for _, one := range list {
+			if one.InstanceType == opt.InstanceType {
+				spec = &instanceSpec{cpu: one.CPU, memoryMB: one.Memory}
+				break
+			}
+		}
+	default:
+		return nil, fmt.Errorf("vendor: %s not support", opt.Vendor)
+	}
+	if err != nil {
+		logs.Errorf("list %s instance type failed, err: %v, account: %s, region: %s, rid: %s", opt.Vendor, err,
+			opt.AccountID, opt.Region, kt.Rid)
+		return nil, err
+	}
+
+	if spec == nil {
+		return nil, errf.Newf(errf.InvalidParameter, "instance type %s not found in %s", opt.InstanceType, opt.Region)
+	}
+
+	return spec, nil
+}
+
+// tcloudMonthly 腾讯云机型价格折算为每月价格，包年包月取每月折扣价，按量计费按每小时折扣价折算
+func tcloudMonthly(chargeType string, one *hcproto.TCloudInstanceTypeResp) *decimal.Decimal {
+	var monthly float64
+	if typecvm.TCloudInstanceChargeType(chargeType) == typecvm.Prepaid {
+		monthly = converter.PtrToVal(one.Price.DiscountPrice)
+		if monthly == 0 {
+			monthly = converter.PtrToVal(one.Price.OriginalPrice)
+		}
+	} else {
+		hourly := converter.PtrToVal(one.Price.UnitPriceDiscount)
+		if hourly == 0 {
+			hourly = converter.PtrToVal(one.Price.UnitPrice)
+		}
+		monthly = hourly * hoursPerMonth
+	}
+
+	if monthly <= 0 {
+		return nil
+	}
+
+	result := decimal.NewFromFloat(monthly)
+	return &result
+}
@@ -0,0 +1,78 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package price
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	csapplication "hcm/pkg/api/cloud-server/application"
+	hcproto "hcm/pkg/api/hc-service/instance-type"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+)
+
+func TestEstimateCvmWithConfiguredInstancePrice(t *testing.T) {
+	p := &price{conf: cc.PriceEstimate{
+		Currency:            "CNY",
+		DiskGBMonthly:       0.5,
+		EipMonthly:          20,
+		InstanceTypeMonthly: map[string]float64{"S5.MEDIUM2": 100},
+	}}
+
+	opt := &CvmEstimateOption{
+		Vendor:           enumor.TCloud,
+		InstanceType:     "S5.MEDIUM2",
+		PublicIPAssigned: true,
+		RequiredCount:    3,
+		SystemDisk:       EstimateDisk{DiskSizeGB: 50},
+		DataDisk:         []EstimateDisk{{DiskSizeGB: 100, DiskCount: 2}},
+	}
+
+	result, err := p.EstimateCvm(kit.New(), opt)
+	assert.NoError(t, err)
+	assert.Equal(t, "CNY", result.Currency)
+	assert.Len(t, result.Items, 4)
+	assert.Equal(t, csapplication.ConfigPriceSource, result.Items[0].Source)
+	// 100 + 50*0.5 + 200*0.5 + 20
+	assert.True(t, decimal.NewFromInt(245).Equal(result.UnitMonthly), result.UnitMonthly.String())
+	assert.True(t, decimal.NewFromInt(735).Equal(result.TotalMonthly), result.TotalMonthly.String())
+}
+
+func TestTCloudMonthly(t *testing.T) {
+	one := &hcproto.TCloudInstanceTypeResp{}
+	one.Price.UnitPrice = converter.ValToPtr(0.2)
+	one.Price.UnitPriceDiscount = converter.ValToPtr(0.1)
+	one.Price.OriginalPrice = converter.ValToPtr(120.0)
+
+	hourly := tcloudMonthly("POSTPAID_BY_HOUR", one)
+	assert.NotNil(t, hourly)
+	assert.True(t, decimal.NewFromInt(73).Equal(*hourly), hourly.String())
+
+	prepaid := tcloudMonthly("PREPAID", one)
+	assert.NotNil(t, prepaid)
+	assert.True(t, decimal.NewFromInt(120).Equal(*prepaid), prepaid.String())
+
+	assert.Nil(t, tcloudMonthly("PREPAID", &hcproto.TCloudInstanceTypeResp{}))
+}
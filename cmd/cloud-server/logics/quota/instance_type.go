@@ -0,0 +1,132 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package quota
+
+import (
+	"fmt"
+	"strings"
+
+	hcproto "hcm/pkg/api/hc-service/instance-type"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// instanceTypeCpuLister 查询云厂商指定地域下的机型列表，返回机型到vCPU核数的映射
+type instanceTypeCpuLister func(kt *kit.Kit, cli *client.ClientSet, opt *CvmApplyOption) (map[string]int64, error)
+
+// instanceTypeCpuListers 各云厂商查询机型vCPU核数的方法
+var instanceTypeCpuListers = map[enumor.Vendor]instanceTypeCpuLister{
+	enumor.TCloud: func(kt *kit.Kit, cli *client.ClientSet, opt *CvmApplyOption) (map[string]int64, error) {
+		req := &hcproto.TCloudInstanceTypeListReq{AccountID: opt.AccountID, Region: opt.Region, Zone: opt.Zone,
+			InstanceChargeType: opt.InstanceChargeType}
+		list, err := cli.HCService().TCloud.InstanceType.List(kt, req)
+		return toCpuMap(list, err, func(one *hcproto.TCloudInstanceTypeResp) (string, int64) {
+			return one.InstanceType, one.CPU
+		})
+	},
+	enumor.Aws: func(kt *kit.Kit, cli *client.ClientSet, opt *CvmApplyOption) (map[string]int64, error) {
+		req := &hcproto.AwsInstanceTypeListReq{AccountID: opt.AccountID, Region: opt.Region}
+		list, err := cli.HCService().Aws.InstanceType.List(kt, req)
+		return toCpuMap(list, err, func(one *hcproto.AwsInstanceTypeResp) (string, int64) {
+			return one.InstanceType, one.CPU
+		})
+	},
+	enumor.HuaWei: func(kt *kit.Kit, cli *client.ClientSet, opt *CvmApplyOption) (map[string]int64, error) {
+		req := &hcproto.HuaWeiInstanceTypeListReq{AccountID: opt.AccountID, Region: opt.Region, Zone: opt.Zone}
+		list, err := cli.HCService().HuaWei.InstanceType.List(kt, req)
+		return toCpuMap(list, err, func(one *hcproto.HuaWeiInstanceTypeResp) (string, int64) {
+			return one.InstanceType, one.CPU
+		})
+	},
+	enumor.Gcp: func(kt *kit.Kit, cli *client.ClientSet, opt *CvmApplyOption) (map[string]int64, error) {
+		req := &hcproto.GcpInstanceTypeListReq{AccountID: opt.AccountID, Zone: opt.Zone}
+		list, err := cli.HCService().Gcp.InstanceType.List(kt, req)
+		return toCpuMap(list, err, func(one *hcproto.GcpInstanceTypeResp) (string, int64) {
+			return one.InstanceType, one.CPU
+		})
+	},
+	enumor.Azure: func(kt *kit.Kit, cli *client.ClientSet, opt *CvmApplyOption) (map[string]int64, error) {
+		req := &hcproto.AzureInstanceTypeListReq{AccountID: opt.AccountID, Region: opt.Region}
+		list, err := cli.HCService().Azure.InstanceType.List(kt, req)
+		return toCpuMap(list, err, func(one *hcproto.AzureInstanceTypeResp) (string, int64) {
+			return one.InstanceType, one.CPU
+		})
+	},
+}
+
+func toCpuMap[T any](list []T, err error, get func(one T) (string, int64)) (map[string]int64, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	cpuMap := make(map[string]int64, len(list))
+	for _, one := range list {
+		instanceType, cpu := get(one)
+		cpuMap[instanceType] = cpu
+	}
+	return cpuMap, nil
+}
+
+// cpuResolver 查询机型的vCPU核数，相同云厂商、账号及地域的机型列表只查询一次
+type cpuResolver struct {
+	client  *client.ClientSet
+	listers map[enumor.Vendor]instanceTypeCpuLister
+	cache   map[string]map[string]int64
+}
+
+func (q *quota) newCpuResolver() *cpuResolver {
+	return &cpuResolver{
+		client:  q.client,
+		listers: instanceTypeCpuListers,
+		cache:   make(map[string]map[string]int64),
+	}
+}
+
+// cpuOf 查询机型的vCPU核数
+func (r *cpuResolver) cpuOf(kt *kit.Kit, opt *CvmApplyOption) (int64, error) {
+	lister, exists := r.listers[opt.Vendor]
+	if !exists {
+		return 0, fmt.Errorf("vendor: %s not support", opt.Vendor)
+	}
+
+	key := strings.Join([]string{string(opt.Vendor), opt.AccountID, opt.Region, opt.Zone, opt.InstanceChargeType},
+		"/")
+	cpuMap, exists := r.cache[key]
+	if !exists {
+		var err error
+		cpuMap, err = lister(kt, r.client, opt)
+		if err != nil {
+			logs.Errorf("list %s instance type failed, err: %v, account: %s, region: %s, rid: %s", opt.Vendor, err,
+				opt.AccountID, opt.Region, kt.Rid)
+			return 0, err
+		}
+		r.cache[key] = cpuMap
+	}
+
+	cpu, exists := cpuMap[opt.InstanceType]
+	if !exists {
+		return 0, errf.Newf(errf.InvalidParameter, "instance type %s not found in %s", opt.InstanceType, opt.Region)
+	}
+
+	return cpu, nil
+}
@@ -0,0 +1,199 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package quota biz resource quota logics, check whether the applied resources exceed the biz quota.
+package quota
+
+import (
+	"hcm/pkg/api/core"
+	corequota "hcm/pkg/api/core/quota"
+	dataquota "hcm/pkg/api/data-service/quota"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	tablequota "hcm/pkg/dal/table/quota"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// Interface define biz quota interface.
+type Interface interface {
+	GetBizQuota(kt *kit.Kit, bizID int64) (*corequota.BizQuota, error)
+	CheckCvmQuota(kt *kit.Kit, opt *CvmApplyOption) error
+	CheckCvmQuotaOnDeliver(kt *kit.Kit, applicationID string, opt *CvmApplyOption) error
+	CheckEipQuota(kt *kit.Kit, bizID int64, count int64) error
+	LockBiz(bizID int64) func()
+	GetBizResWhitelist(kt *kit.Kit, bizID int64, vendor enumor.Vendor) (*corequota.BizResWhitelist, error)
+	CheckCvmWhitelist(kt *kit.Kit, opt *CvmApplyOption) error
+}
+
+type quota struct {
+	client   *client.ClientSet
+	bizLocks *bizLocker
+}
+
+// NewQuota new biz quota logics.
+func NewQuota(client *client.ClientSet) Interface {
+	return &quota{
+		client:   client,
+		bizLocks: newBizLocker(),
+	}
+}
+
+// CvmApplyOption 申请主机时用于校验配额及资源白名单的参数
+type CvmApplyOption struct {
+	BkBizID      int64         `json:"bk_biz_id"`
+	Vendor       enumor.Vendor `json:"vendor"`
+	AccountID    string        `json:"account_id"`
+	Region       string        `json:"region"`
+	Zone         string        `json:"zone"`
+	InstanceType string        `json:"instance_type"`
+	CloudImageID string        `json:"cloud_image_id"`
+	// InstanceChargeType 计费模式，腾讯云查询机型时需要
+	InstanceChargeType string `json:"instance_charge_type"`
+	// RequiredCount 申请的主机数量
+	RequiredCount int64 `json:"required_count"`
+}
+
+// GetBizQuota get biz quota, returns nil if the biz has no quota, which means unlimited.
+func (q *quota) GetBizQuota(kt *kit.Kit, bizID int64) (*corequota.BizQuota, error) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("bk_biz_id", bizID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := q.client.DataService().Global.BizQuota.List(kt, listReq)
+	if err != nil {
+		logs.Errorf("list biz quota failed, err: %v, biz: %d, rid: %s", err, bizID, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, nil
+	}
+
+	return &result.Details[0], nil
+}
+
+// getBizUsage get biz resource usage counted from inventory.
+func (q *quota) getBizUsage(kt *kit.Kit, bizID int64) (*corequota.BizResUsage, error) {
+	listReq := &dataquota.BizResUsageListReq{BkBizIDs: []int64{bizID}}
+	result, err := q.client.DataService().Global.BizQuota.ListUsage(kt, listReq)
+	if err != nil {
+		logs.Errorf("list biz resource usage failed, err: %v, biz: %d, rid: %s", err, bizID, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return &corequota.BizResUsage{BkBizID: bizID}, nil
+	}
+
+	return &result.Details[0], nil
+}
+
+// CheckCvmQuota check whether the applied cvm count and vcpu exceed the biz quota, the resources applied by the
+// in-flight cvm applications of the biz are reserved and counted as used.
+func (q *quota) CheckCvmQuota(kt *kit.Kit, opt *CvmApplyOption) error {
+	return q.checkCvmQuota(kt, opt, "")
+}
+
+// CheckCvmQuotaOnDeliver re-check the cvm quota before the application is delivered, the reservation of the
+// application itself is excluded, so that quota lowered or resources delivered during approval are taken into account.
+func (q *quota) CheckCvmQuotaOnDeliver(kt *kit.Kit, applicationID string, opt *CvmApplyOption) error {
+	return q.checkCvmQuota(kt, opt, applicationID)
+}
+
+func (q *quota) checkCvmQuota(kt *kit.Kit, opt *CvmApplyOption, excludeAppID string) error {
+	if opt == nil || opt.BkBizID <= 0 || opt.RequiredCount <= 0 {
+		return nil
+	}
+
+	bizQuota, err := q.GetBizQuota(kt, opt.BkBizID)
+	if err != nil {
+		return err
+	}
+
+	if bizQuota == nil || (bizQuota.MaxCvm == tablequota.UnlimitedQuota &&
+		bizQuota.MaxCpuCore == tablequota.UnlimitedQuota) {
+		return nil
+	}
+
+	usage, err := q.getBizUsage(kt, opt.BkBizID)
+	if err != nil {
+		return err
+	}
+
+	reserved, err := q.listCvmReservations(kt, opt.BkBizID, excludeAppID)
+	if err != nil {
+		return err
+	}
+
+	// 机型核数按云厂商查询，同一次校验中相同地域的机型列表只查询一次
+	needCpu := bizQuota.MaxCpuCore != tablequota.UnlimitedQuota
+	resolver := q.newCpuResolver()
+
+	applied := cvmAmount{count: opt.RequiredCount}
+	if needCpu {
+		cpu, err := resolver.cpuOf(kt, opt)
+		if err != nil {
+			return err
+		}
+		applied.cpu = cpu * opt.RequiredCount
+	}
+
+	reservedAmount, err := sumReservations(kt, reserved, needCpu, resolver.cpuOf)
+	if err != nil {
+		return err
+	}
+
+	used := cvmAmount{count: usage.CvmCount, cpu: usage.CpuCore}
+	return checkCvmLimit(opt.BkBizID, bizQuota, used, reservedAmount, applied)
+}
+
+// CheckEipQuota check whether the applied eip count exceed the biz quota.
+func (q *quota) CheckEipQuota(kt *kit.Kit, bizID int64, count int64) error {
+	if bizID <= 0 || count <= 0 {
+		return nil
+	}
+
+	bizQuota, err := q.GetBizQuota(kt, bizID)
+	if err != nil {
+		return err
+	}
+
+	if bizQuota == nil || bizQuota.MaxEip == tablequota.UnlimitedQuota {
+		return nil
+	}
+
+	usage, err := q.getBizUsage(kt, bizID)
+	if err != nil {
+		return err
+	}
+
+	if exceeded(bizQuota.MaxEip, usage.EipCount, count) {
+		return errf.Newf(errf.QuotaExceeded, "eip quota of biz %d exceeded, quota: %d, used: %d, applied: %d",
+			bizID, bizQuota.MaxEip, usage.EipCount, count)
+	}
+
+	return nil
+}
+
+func exceeded(limit, used, applied int64) bool {
+	return limit != tablequota.UnlimitedQuota && used+applied > limit
+}
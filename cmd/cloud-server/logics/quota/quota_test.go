@@ -0,0 +1,164 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package quota
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	corequota "hcm/pkg/api/core/quota"
+	dataproto "hcm/pkg/api/data-service"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	tablequota "hcm/pkg/dal/table/quota"
+	"hcm/pkg/kit"
+)
+
+func TestInstanceTypeCpuListersCoverVendors(t *testing.T) {
+	for _, vendor := range []enumor.Vendor{enumor.TCloud, enumor.Aws, enumor.HuaWei, enumor.Gcp, enumor.Azure} {
+		assert.Contains(t, instanceTypeCpuListers, vendor)
+	}
+}
+
+func TestCpuResolverListOncePerRegion(t *testing.T) {
+	calls := 0
+	resolver := &cpuResolver{
+		listers: map[enumor.Vendor]instanceTypeCpuLister{
+			enumor.TCloud: func(kt *kit.Kit, cli *client.ClientSet, opt *CvmApplyOption) (map[string]int64, error) {
+				calls++
+				if opt.Region == "bad" {
+					return nil, errors.New("list failed")
+				}
+				return map[string]int64{"S5.MEDIUM2": 2, "S5.LARGE8": 4}, nil
+			},
+		},
+		cache: make(map[string]map[string]int64),
+	}
+
+	kt := kit.New()
+	cpu, err := resolver.cpuOf(kt, &CvmApplyOption{Vendor: enumor.TCloud, Region: "gz", InstanceType: "S5.MEDIUM2"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), cpu)
+
+	cpu, err = resolver.cpuOf(kt, &CvmApplyOption{Vendor: enumor.TCloud, Region: "gz", InstanceType: "S5.LARGE8"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), cpu)
+	assert.Equal(t, 1, calls)
+
+	_, err = resolver.cpuOf(kt, &CvmApplyOption{Vendor: enumor.TCloud, Region: "gz", InstanceType: "S6.HUGE"})
+	assert.Equal(t, errf.InvalidParameter, errf.Error(err).Code)
+
+	_, err = resolver.cpuOf(kt, &CvmApplyOption{Vendor: enumor.TCloud, Region: "bad", InstanceType: "S5.MEDIUM2"})
+	assert.Error(t, err)
+	assert.Equal(t, 2, calls)
+
+	_, err = resolver.cpuOf(kt, &CvmApplyOption{Vendor: enumor.Aws, Region: "gz", InstanceType: "t2.micro"})
+	assert.Error(t, err)
+}
+
+func TestSumReservations(t *testing.T) {
+	apps := []*dataproto.ApplicationResp{
+		{ID: "1", Content: `{"bk_biz_id":1,"vendor":"tcloud","instance_type":"S5.MEDIUM2","required_count":2}`},
+		{ID: "2", Content: `{"bk_biz_id":1,"vendor":"tcloud","instance_type":"S5.LARGE8","required_count":3}`},
+		{ID: "3", Content: `{"bk_biz_id":1,"vendor":"tcloud","instance_type":"S5.LARGE8","required_count":0}`},
+	}
+	opts, err := parseCvmReservations(apps)
+	assert.NoError(t, err)
+	assert.Len(t, opts, 3)
+
+	cpuOf := func(kt *kit.Kit, opt *CvmApplyOption) (int64, error) {
+		return map[string]int64{"S5.MEDIUM2": 2, "S5.LARGE8": 4}[opt.InstanceType], nil
+	}
+
+	sum, err := sumReservations(kit.New(), opts, true, cpuOf)
+	assert.NoError(t, err)
+	assert.Equal(t, cvmAmount{count: 5, cpu: 16}, sum)
+
+	sum, err = sumReservations(kit.New(), opts, false, func(*kit.Kit, *CvmApplyOption) (int64, error) {
+		return 0, errors.New("should not query cpu")
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, cvmAmount{count: 5}, sum)
+
+	_, err = parseCvmReservations([]*dataproto.ApplicationResp{{ID: "4", Content: "{"}})
+	assert.Error(t, err)
+}
+
+func TestCheckCvmLimit(t *testing.T) {
+	bizQuota := &corequota.BizQuota{MaxCvm: 10, MaxCpuCore: 40}
+
+	// 库存 4 台，预留 4 台，再申请 2 台刚好用满
+	err := checkCvmLimit(1, bizQuota, cvmAmount{count: 4, cpu: 16}, cvmAmount{count: 4, cpu: 16},
+		cvmAmount{count: 2, cpu: 8})
+	assert.NoError(t, err)
+
+	// 预留计入已用，只看库存时不会超出
+	err = checkCvmLimit(1, bizQuota, cvmAmount{count: 4, cpu: 16}, cvmAmount{count: 5, cpu: 8},
+		cvmAmount{count: 2, cpu: 8})
+	assert.Equal(t, errf.QuotaExceeded, errf.Error(err).Code)
+
+	err = checkCvmLimit(1, bizQuota, cvmAmount{count: 4, cpu: 16}, cvmAmount{count: 4, cpu: 20},
+		cvmAmount{count: 1, cpu: 8})
+	assert.Equal(t, errf.QuotaExceeded, errf.Error(err).Code)
+
+	unlimited := &corequota.BizQuota{MaxCvm: tablequota.UnlimitedQuota, MaxCpuCore: tablequota.UnlimitedQuota}
+	err = checkCvmLimit(1, unlimited, cvmAmount{count: 100, cpu: 400}, cvmAmount{count: 100},
+		cvmAmount{count: 100})
+	assert.NoError(t, err)
+}
+
+func TestBizLockerSerializeSameBiz(t *testing.T) {
+	locker := newBizLocker()
+
+	unlock := locker.lock(1)
+	acquired := make(chan struct{})
+	go func() {
+		defer close(acquired)
+		locker.lock(1)()
+	}()
+
+	// 其他业务不受影响
+	locker.lock(2)()
+
+	select {
+	case <-acquired:
+		t.Fatal("lock of the same biz acquired before unlock")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock()
+	<-acquired
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			locker.lock(1)()
+		}()
+	}
+	wg.Wait()
+	assert.Empty(t, locker.locks)
+}
@@ -0,0 +1,177 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package quota
+
+import (
+	"sync"
+
+	"hcm/pkg/api/core"
+	corequota "hcm/pkg/api/core/quota"
+	dataproto "hcm/pkg/api/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/json"
+)
+
+// reservingStatuses 处于这些状态的主机申请单会预留配额，驳回、撤销、交付失败后预留自动释放，交付完成后计入库存
+var reservingStatuses = []enumor.ApplicationStatus{enumor.Pending, enumor.Pass, enumor.Delivering}
+
+// cvmAmount 主机数量及vCPU核数
+type cvmAmount struct {
+	count int64
+	cpu   int64
+}
+
+// listCvmReservations 查询业务下预留配额的主机申请单，excludeAppID 不为空时排除该申请单自身的预留
+func (q *quota) listCvmReservations(kt *kit.Kit, bizID int64, excludeAppID string) ([]*CvmApplyOption, error) {
+	rules := []*filter.AtomRule{
+		tools.RuleEqual("type", enumor.CreateCvm),
+		tools.RuleIn("status", reservingStatuses),
+		tools.RuleJSONContains("bk_biz_ids", bizID),
+	}
+	if excludeAppID != "" {
+		rules = append(rules, tools.RuleNotEqual("id", excludeAppID))
+	}
+
+	req := &dataproto.ApplicationListReq{
+		Filter: tools.ExpressionAnd(rules...),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	apps := make([]*dataproto.ApplicationResp, 0)
+	for {
+		result, err := q.client.DataService().Global.Application.ListApplication(kt, req)
+		if err != nil {
+			logs.Errorf("list cvm applications reserving quota failed, err: %v, biz: %d, rid: %s", err, bizID,
+				kt.Rid)
+			return nil, err
+		}
+
+		apps = append(apps, result.Details...)
+		if uint(len(result.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return parseCvmReservations(apps)
+}
+
+// parseCvmReservations 从申请单内容中解析预留的主机参数，申请单内容即提交时的创建请求
+func parseCvmReservations(apps []*dataproto.ApplicationResp) ([]*CvmApplyOption, error) {
+	opts := make([]*CvmApplyOption, 0, len(apps))
+	for _, app := range apps {
+		opt := new(CvmApplyOption)
+		if err := json.UnmarshalFromString(app.Content, opt); err != nil {
+			return nil, errf.Newf(errf.Aborted, "parse content of application %s failed, err: %v", app.ID, err)
+		}
+		opts = append(opts, opt)
+	}
+
+	return opts, nil
+}
+
+// sumReservations 汇总预留的主机数量，needCpu 为 true 时按机型核数汇总vCPU
+func sumReservations(kt *kit.Kit, opts []*CvmApplyOption, needCpu bool,
+	cpuOf func(kt *kit.Kit, opt *CvmApplyOption) (int64, error)) (cvmAmount, error) {
+
+	sum := cvmAmount{}
+	for _, opt := range opts {
+		if opt.RequiredCount <= 0 {
+			continue
+		}
+
+		sum.count += opt.RequiredCount
+		if !needCpu {
+			continue
+		}
+
+		cpu, err := cpuOf(kt, opt)
+		if err != nil {
+			return cvmAmount{}, err
+		}
+		sum.cpu += cpu * opt.RequiredCount
+	}
+
+	return sum, nil
+}
+
+// checkCvmLimit 校验库存、预留及本次申请的主机数量和vCPU核数之和是否超出业务配额
+func checkCvmLimit(bizID int64, bizQuota *corequota.BizQuota, used, reserved, applied cvmAmount) error {
+	if exceeded(bizQuota.MaxCvm, used.count+reserved.count, applied.count) {
+		return errf.Newf(errf.QuotaExceeded, "cvm quota of biz %d exceeded, quota: %d, used: %d, reserved: %d, "+
+			"applied: %d", bizID, bizQuota.MaxCvm, used.count, reserved.count, applied.count)
+	}
+
+	if exceeded(bizQuota.MaxCpuCore, used.cpu+reserved.cpu, applied.cpu) {
+		return errf.Newf(errf.QuotaExceeded, "vcpu quota of biz %d exceeded, quota: %d, used: %d, reserved: %d, "+
+			"applied: %d", bizID, bizQuota.MaxCpuCore, used.cpu, reserved.cpu, applied.cpu)
+	}
+
+	return nil
+}
+
+// LockBiz lock the biz quota in process, the caller should hold it from checking the quota to persisting the
+// application or resource, so that concurrent applies of the biz can see each other. returns the unlock func.
+func (q *quota) LockBiz(bizID int64) func() {
+	return q.bizLocks.lock(bizID)
+}
+
+// bizLocker 按业务加锁，锁不再被使用时释放
+type bizLocker struct {
+	mu    sync.Mutex
+	locks map[int64]*bizLock
+}
+
+type bizLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+func newBizLocker() *bizLocker {
+	return &bizLocker{locks: make(map[int64]*bizLock)}
+}
+
+func (l *bizLocker) lock(bizID int64) func() {
+	l.mu.Lock()
+	one, exists := l.locks[bizID]
+	if !exists {
+		one = new(bizLock)
+		l.locks[bizID] = one
+	}
+	one.refs++
+	l.mu.Unlock()
+
+	one.mu.Lock()
+	return func() {
+		one.mu.Unlock()
+
+		l.mu.Lock()
+		one.refs--
+		if one.refs == 0 {
+			delete(l.locks, bizID)
+		}
+		l.mu.Unlock()
+	}
+}
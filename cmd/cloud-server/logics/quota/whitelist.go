@@ -0,0 +1,87 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package quota
+
+import (
+	"hcm/pkg/api/core"
+	corequota "hcm/pkg/api/core/quota"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// GetBizResWhitelist get biz resource whitelist of the vendor, returns nil if the biz has no whitelist of the
+// vendor, which means unlimited.
+func (q *quota) GetBizResWhitelist(kt *kit.Kit, bizID int64, vendor enumor.Vendor) (*corequota.BizResWhitelist,
+	error) {
+
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(tools.RuleEqual("bk_biz_id", bizID), tools.RuleEqual("vendor", vendor)),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := q.client.DataService().Global.BizResWhitelist.List(kt, listReq)
+	if err != nil {
+		logs.Errorf("list biz resource whitelist failed, err: %v, biz: %d, vendor: %s, rid: %s", err, bizID,
+			vendor, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, nil
+	}
+
+	return &result.Details[0], nil
+}
+
+// CheckCvmWhitelist check whether the applied region, instance type and image are allowed by the biz resource
+// whitelist of the vendor.
+func (q *quota) CheckCvmWhitelist(kt *kit.Kit, opt *CvmApplyOption) error {
+	if opt == nil || opt.BkBizID <= 0 {
+		return nil
+	}
+
+	whitelist, err := q.GetBizResWhitelist(kt, opt.BkBizID, opt.Vendor)
+	if err != nil {
+		return err
+	}
+
+	if whitelist == nil {
+		return nil
+	}
+
+	if !whitelist.AllowRegion(opt.Region) {
+		return errf.Newf(errf.ResWhitelistViolated, "region %s is not allowed for biz %d, allowed regions: %v",
+			opt.Region, opt.BkBizID, whitelist.Regions)
+	}
+
+	if !whitelist.AllowInstanceType(opt.InstanceType) {
+		return errf.Newf(errf.ResWhitelistViolated, "instance type %s is not allowed for biz %d, "+
+			"allowed instance families: %v", opt.InstanceType, opt.BkBizID, whitelist.InstanceFamilies)
+	}
+
+	if !whitelist.AllowImage(opt.CloudImageID) {
+		return errf.Newf(errf.ResWhitelistViolated, "image %s is not allowed for biz %d, allowed images: %v",
+			opt.CloudImageID, opt.BkBizID, whitelist.CloudImageIDs)
+	}
+
+	return nil
+}
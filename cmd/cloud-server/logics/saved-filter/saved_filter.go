@@ -0,0 +1,133 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package savedfilter saved filter logics, resolve the saved filter referenced by resource list request and merge it
+// into the request filter.
+package savedfilter
+
+import (
+	"hcm/pkg/api/core"
+	coresavedfilter "hcm/pkg/api/core/saved-filter"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+)
+
+// Interface define saved filter logics interface.
+type Interface interface {
+	GetVisible(kt *kit.Kit, id string) (*coresavedfilter.SavedFilter, error)
+	MergeFilter(kt *kit.Kit, id string, resType enumor.CloudResourceType, expr *filter.Expression) (
+		*filter.Expression, error)
+}
+
+type savedFilter struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// NewSavedFilter new saved filter logics.
+func NewSavedFilter(client *client.ClientSet, authorizer auth.Authorizer) Interface {
+	return &savedFilter{
+		client:     client,
+		authorizer: authorizer,
+	}
+}
+
+// GetVisible get saved filter which is visible to current user, filter is visible to its creator, and to the users
+// who can access the biz it shared with.
+func (s *savedFilter) GetVisible(kt *kit.Kit, id string) (*coresavedfilter.SavedFilter, error) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := s.client.DataService().Global.SavedFilter.List(kt, listReq)
+	if err != nil {
+		logs.Errorf("get saved filter failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "saved filter: %s not found", id)
+	}
+	one := result.Details[0]
+
+	if one.Creator == kt.User {
+		return &one, nil
+	}
+
+	if one.Scope != enumor.SavedFilterBizScope {
+		return nil, errf.Newf(errf.PermissionDenied, "saved filter: %s is not shared", id)
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Biz, Action: meta.Access}, BizID: one.BkBizID}
+	if err = s.authorizer.AuthorizeWithPerm(kt, authRes); err != nil {
+		return nil, err
+	}
+
+	return &one, nil
+}
+
+// MergeFilter merge the saved filter into the given filter with 'and' operation, return the given filter directly
+// if saved filter id is not set.
+func (s *savedFilter) MergeFilter(kt *kit.Kit, id string, resType enumor.CloudResourceType,
+	expr *filter.Expression) (*filter.Expression, error) {
+
+	if len(id) == 0 {
+		return expr, nil
+	}
+
+	saved, err := s.GetVisible(kt, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeFilter(kt, saved, resType, expr)
+}
+
+// mergeFilter 将保存的过滤条件与请求的过滤条件取交集，保存的过滤条件需与请求的资源类型一致
+func mergeFilter(kt *kit.Kit, saved *coresavedfilter.SavedFilter, resType enumor.CloudResourceType,
+	expr *filter.Expression) (*filter.Expression, error) {
+
+	if saved.ResType != resType {
+		return nil, errf.Newf(errf.InvalidParameter, "saved filter: %s is for %s, not %s", saved.ID, saved.ResType,
+			resType)
+	}
+
+	if saved.Filter == nil {
+		return expr, nil
+	}
+
+	if expr.IsEmpty() {
+		return saved.Filter, nil
+	}
+
+	merged, err := tools.And(saved.Filter, expr)
+	if err != nil {
+		logs.Errorf("merge saved filter failed, err: %v, id: %s, rid: %s", err, saved.ID, kt.Rid)
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return merged, nil
+}
@@ -0,0 +1,68 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package savedfilter
+
+import (
+	"testing"
+
+	coresavedfilter "hcm/pkg/api/core/saved-filter"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/runtime/filter"
+)
+
+func TestMergeFilter(t *testing.T) {
+	saved := &coresavedfilter.SavedFilter{
+		ID:      "filter-1",
+		ResType: enumor.CvmCloudResType,
+		Filter:  tools.EqualExpression("vendor", enumor.TCloud),
+	}
+
+	// 请求未设置过滤条件时直接使用保存的过滤条件
+	merged, err := mergeFilter(kit.New(), saved, enumor.CvmCloudResType, nil)
+	if err != nil {
+		t.Fatalf("merge saved filter failed, err: %v", err)
+	}
+	if merged != saved.Filter {
+		t.Errorf("saved filter should be used directly, but got %v", merged)
+	}
+
+	// 保存的过滤条件与请求的过滤条件取交集
+	merged, err = mergeFilter(kit.New(), saved, enumor.CvmCloudResType,
+		tools.ExpressionAnd(tools.RuleEqual("region", "ap-guangzhou"), tools.RuleEqual("bk_biz_id", 1)))
+	if err != nil {
+		t.Fatalf("merge saved filter failed, err: %v", err)
+	}
+	if merged.Op != filter.And || len(merged.Rules) != 3 {
+		t.Errorf("saved filter should be merged with and operation, but got %v", merged)
+	}
+
+	if _, err = mergeFilter(kit.New(), saved, enumor.DiskCloudResType, nil); err == nil {
+		t.Errorf("saved filter of other resource type should not be applied")
+	}
+
+	// 未保存过滤条件时使用请求的过滤条件
+	expr := tools.EqualExpression("region", "ap-guangzhou")
+	saved.Filter = nil
+	if merged, err = mergeFilter(kit.New(), saved, enumor.CvmCloudResType, expr); err != nil || merged != expr {
+		t.Errorf("request filter should be used, but got %v, err: %v", merged, err)
+	}
+}
@@ -0,0 +1,104 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tagpolicy tag policy logics, check whether the tags of resource to be created conform to the tag policies
+// of the biz.
+package tagpolicy
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/pkg/api/core"
+	coretagpolicy "hcm/pkg/api/core/tag-policy"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// Interface define tag policy interface.
+type Interface interface {
+	CheckTags(kt *kit.Kit, bizID int64, resType enumor.CloudResourceType, tags []core.TagPair) error
+}
+
+type tagPolicy struct {
+	client *client.ClientSet
+}
+
+// NewTagPolicy new tag policy logics.
+func NewTagPolicy(client *client.ClientSet) Interface {
+	return &tagPolicy{
+		client: client,
+	}
+}
+
+// CheckTags check whether the tags conform to the tag policies of the biz and resource type, the default policy of
+// the same tag key is used if the biz has no policy of the tag key.
+func (t *tagPolicy) CheckTags(kt *kit.Kit, bizID int64, resType enumor.CloudResourceType,
+	tags []core.TagPair) error {
+
+	if err := enumor.ValidateTagPolicyResType(resType); err != nil {
+		return nil
+	}
+
+	if bizID <= 0 {
+		bizID = constant.UnassignedBiz
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleIn("bk_biz_id", []int64{bizID, constant.UnassignedBiz}),
+			tools.RuleEqual("res_type", resType),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := t.client.DataService().Global.TagPolicy.List(kt, listReq)
+	if err != nil {
+		logs.Errorf("list tag policy failed, err: %v, biz: %d, res_type: %s, rid: %s", err, bizID, resType, kt.Rid)
+		return err
+	}
+
+	tagMap := core.NewTagMap(tags...)
+	reasons := make([]string, 0)
+	for _, policy := range coretagpolicy.MatchPolicies(result.Details, bizID, resType) {
+		reason, value, ok := policy.Check(tagMap)
+		if ok {
+			continue
+		}
+
+		switch reason {
+		case enumor.TagMissing:
+			reasons = append(reasons, fmt.Sprintf("tag %s is required", policy.TagKey))
+		case enumor.TagMistagged:
+			reasons = append(reasons, fmt.Sprintf("tag %s value %s should be one of %v", policy.TagKey, value,
+				policy.AllowedValues))
+		}
+	}
+
+	if len(reasons) != 0 {
+		return errf.Newf(errf.TagPolicyViolated, "%s tags do not conform to the tag policy of biz %d, %s", resType,
+			bizID, strings.Join(reasons, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tenant tenant logics, run the background jobs tenant by tenant.
+package tenant
+
+import (
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// ForEach 依次以各租户的kit执行后台任务，未开启多租户时仅执行默认租户。
+// 后台任务没有请求方的租户，必须按租户分别读写数据，避免跨租户处理资源。
+func ForEach(kt *kit.Kit, dataCli *dataservice.Client, do func(kt *kit.Kit)) error {
+	result, err := dataCli.Global.Tenant.ListIDs(kt)
+	if err != nil {
+		logs.Errorf("list tenant ids failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	for _, tenantID := range result.IDs {
+		do(kt.WithTenant(tenantID))
+	}
+
+	return nil
+}
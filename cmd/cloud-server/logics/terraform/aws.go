@@ -0,0 +1,177 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package terraform
+
+import (
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+)
+
+// awsAllProtocol aws protocol value which means all protocols, ports should not be set.
+const awsAllProtocol = "-1"
+
+type awsExporter struct{}
+
+func (awsExporter) provider() (string, string) {
+	return "aws", "hashicorp/aws"
+}
+
+func (e awsExporter) export(kt *kit.Kit, cli *dataservice.Client, opt *ExportOption) ([]resource, error) {
+	resources := make([]resource, 0)
+	// vpcAddr cloud vpc id -> terraform address of the exported vpc
+	vpcAddr := make(map[string]string)
+
+	if len(opt.VpcIDs) != 0 {
+		listReq := &core.ListReq{Filter: resourceExpr(opt.Vendor, opt.VpcIDs), Page: core.NewDefaultBasePage()}
+		result, err := cli.Aws.Vpc.ListVpcExt(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, vpc := range result.Details {
+			block := NewBlock("resource", "aws_vpc", resourceName(vpc.CloudID))
+			if vpc.Extension != nil {
+				for _, cidr := range vpc.Extension.Cidr {
+					if cidr.Type == enumor.Ipv4 {
+						block.Attr("cidr_block", cidr.Cidr)
+						break
+					}
+				}
+				block.Attr("instance_tenancy", vpc.Extension.InstanceTenancy).
+					Attr("enable_dns_support", vpc.Extension.EnableDnsSupport).
+					Attr("enable_dns_hostnames", vpc.Extension.EnableDnsHostnames)
+			}
+			block.Attr("tags", nameTags(vpc.Name))
+
+			one := resource{block: block, region: vpc.Region, importID: vpc.CloudID}
+			vpcAddr[vpc.CloudID] = one.address()
+			resources = append(resources, one)
+		}
+	}
+
+	if len(opt.SubnetIDs) != 0 {
+		listReq := &core.ListReq{Filter: resourceExpr(opt.Vendor, opt.SubnetIDs), Page: core.NewDefaultBasePage()}
+		result, err := cli.Global.Subnet.List(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, subnet := range result.Details {
+			block := NewBlock("resource", "aws_subnet", resourceName(subnet.CloudID))
+			setVpcID(block, vpcAddr, subnet.CloudVpcID)
+			block.Attr("availability_zone", subnet.Zone)
+			if len(subnet.Ipv4Cidr) != 0 {
+				block.Attr("cidr_block", subnet.Ipv4Cidr[0])
+			}
+			if len(subnet.Ipv6Cidr) != 0 {
+				block.Attr("ipv6_cidr_block", subnet.Ipv6Cidr[0])
+			}
+			block.Attr("tags", nameTags(subnet.Name))
+
+			resources = append(resources, resource{block: block, region: subnet.Region, importID: subnet.CloudID})
+		}
+	}
+
+	if len(opt.SecurityGroupIDs) != 0 {
+		sgResources, err := e.exportSecurityGroup(kt, cli, opt, vpcAddr)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, sgResources...)
+	}
+
+	return resources, nil
+}
+
+// exportSecurityGroup export security groups, each rule is exported as one ingress or egress rule resource.
+func (e awsExporter) exportSecurityGroup(kt *kit.Kit, cli *dataservice.Client, opt *ExportOption,
+	vpcAddr map[string]string) ([]resource, error) {
+
+	listReq := &core.ListReq{Filter: resourceExpr(opt.Vendor, opt.SecurityGroupIDs), Page: core.NewDefaultBasePage()}
+	result, err := cli.Aws.SecurityGroup.ListSecurityGroupExt(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]resource, 0)
+	for _, sg := range result.Details {
+		sgBlock := NewBlock("resource", "aws_security_group", resourceName(sg.CloudID)).
+			Attr("name", sg.Name).Attr("description", sg.Memo)
+		if sg.Extension != nil && sg.Extension.CloudVpcID != nil {
+			setVpcID(sgBlock, vpcAddr, *sg.Extension.CloudVpcID)
+		}
+		sgBlock.Attr("tags", map[string]string(sg.Tags))
+		sgRes := resource{block: sgBlock, region: sg.Region, importID: sg.CloudID}
+		resources = append(resources, sgRes)
+
+		rules, err := listRules(sg.ID, func(page *core.BasePage) ([]corecloud.AwsSecurityGroupRule, error) {
+			ruleReq := &protocloud.AwsSGRuleListReq{Filter: tools.EqualExpression("security_group_id", sg.ID),
+				Page: page}
+			ruleResult, err := cli.Aws.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(), ruleReq, sg.ID)
+			if err != nil {
+				return nil, err
+			}
+			return ruleResult.Details, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rule := range rules {
+			block := NewBlock("resource", "aws_vpc_security_group_"+string(rule.Type)+"_rule",
+				resourceName(rule.CloudID)).
+				Ref("security_group_id", sgRes.address()+".id").
+				Attr("ip_protocol", rule.Protocol)
+			if converter.PtrToVal(rule.Protocol) != awsAllProtocol {
+				block.Attr("from_port", rule.FromPort).Attr("to_port", rule.ToPort)
+			}
+			block.Attr("cidr_ipv4", rule.IPv4Cidr).
+				Attr("cidr_ipv6", rule.IPv6Cidr).
+				Attr("prefix_list_id", rule.CloudPrefixListID).
+				Attr("referenced_security_group_id", rule.CloudTargetSecurityGroupID).
+				Attr("description", rule.Memo)
+
+			resources = append(resources, resource{block: block, region: sg.Region, importID: rule.CloudID})
+		}
+	}
+
+	return resources, nil
+}
+
+func setVpcID(block *Block, vpcAddr map[string]string, cloudVpcID string) {
+	if addr, exists := vpcAddr[cloudVpcID]; exists {
+		block.Ref("vpc_id", addr+".id")
+		return
+	}
+	block.Attr("vpc_id", cloudVpcID)
+}
+
+func nameTags(name string) map[string]string {
+	if len(name) == 0 {
+		return nil
+	}
+	return map[string]string{"Name": name}
+}
@@ -0,0 +1,199 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package terraform
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Block define hcl block, such as resource, import and provider.
+type Block struct {
+	Type   string
+	Labels []string
+	attrs  []attr
+	blocks []*Block
+}
+
+type attr struct {
+	name string
+	expr string
+}
+
+// NewBlock create a hcl block.
+func NewBlock(typ string, labels ...string) *Block {
+	return &Block{Type: typ, Labels: labels}
+}
+
+// Attr set attribute of literal value, empty string, nil pointer, empty slice and map are ignored.
+func (b *Block) Attr(name string, value any) *Block {
+	expr, ok := literalExpr(value)
+	if ok {
+		b.attrs = append(b.attrs, attr{name: name, expr: expr})
+	}
+	return b
+}
+
+// Ref set attribute of reference expression, such as tencentcloud_vpc.vpc_xxx.id.
+func (b *Block) Ref(name string, ref string) *Block {
+	b.attrs = append(b.attrs, attr{name: name, expr: ref})
+	return b
+}
+
+// Child append a nested block and return it.
+func (b *Block) Child(typ string, labels ...string) *Block {
+	child := NewBlock(typ, labels...)
+	b.blocks = append(b.blocks, child)
+	return child
+}
+
+// Render hcl block.
+func (b *Block) Render(sb *strings.Builder) {
+	b.render(sb, 0)
+}
+
+func (b *Block) render(sb *strings.Builder, depth int) {
+	indent := strings.Repeat("  ", depth)
+	sb.WriteString(indent + b.Type)
+	for _, label := range b.Labels {
+		sb.WriteString(" " + quote(label))
+	}
+	sb.WriteString(" {\n")
+
+	width := 0
+	for _, one := range b.attrs {
+		if len(one.name) > width {
+			width = len(one.name)
+		}
+	}
+	for _, one := range b.attrs {
+		sb.WriteString(fmt.Sprintf("%s  %-*s = %s\n", indent, width, one.name, one.expr))
+	}
+
+	for i, child := range b.blocks {
+		if i != 0 || len(b.attrs) != 0 {
+			sb.WriteString("\n")
+		}
+		child.render(sb, depth+1)
+	}
+	sb.WriteString(indent + "}\n")
+}
+
+func literalExpr(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return quote(v), len(v) != 0
+	case *string:
+		if v == nil {
+			return "", false
+		}
+		return literalExpr(*v)
+	case bool:
+		return strconv.FormatBool(v), true
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case *int64:
+		if v == nil {
+			return "", false
+		}
+		return strconv.FormatInt(*v, 10), true
+	case []string:
+		if len(v) == 0 {
+			return "", false
+		}
+		items := make([]string, len(v))
+		for i, one := range v {
+			items[i] = quote(one)
+		}
+		return "[" + strings.Join(items, ", ") + "]", true
+	case map[string]string:
+		if len(v) == 0 {
+			return "", false
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		items := make([]string, len(keys))
+		for i, key := range keys {
+			name := key
+			if !identifierRegexp.MatchString(key) {
+				name = quote(key)
+			}
+			items[i] = name + " = " + quote(v[key])
+		}
+		return "{ " + strings.Join(items, ", ") + " }", true
+	default:
+		return quote(fmt.Sprintf("%v", v)), true
+	}
+}
+
+// quote quote string as hcl string literal, template sequences are escaped.
+func quote(s string) string {
+	sb := strings.Builder{}
+	sb.WriteByte('"')
+	for i, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '$', '%':
+			sb.WriteRune(r)
+			if i+1 < len(s) && s[i+1] == '{' {
+				sb.WriteRune(r)
+			}
+		default:
+			if r < 0x20 {
+				sb.WriteString(fmt.Sprintf(`\u%04x`, r))
+				continue
+			}
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+var (
+	identifierRegexp   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+	invalidNameCharReg = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+)
+
+// resourceName convert cloud id to terraform resource name.
+func resourceName(cloudID string) string {
+	name := invalidNameCharReg.ReplaceAllString(cloudID, "_")
+	if !identifierRegexp.MatchString(name) {
+		name = "r_" + name
+	}
+	return name
+}
@@ -0,0 +1,68 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package terraform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockRender(t *testing.T) {
+	memo := "allow ${var} 100%{x}"
+	block := NewBlock("resource", "tencentcloud_vpc", resourceName("vpc-123")).
+		Attr("name", "test\"vpc").
+		Attr("description", &memo).
+		Attr("empty", "").
+		Attr("dns_servers", []string{"1.1.1.1"}).
+		Attr("tags", map[string]string{"env": "prod", "a.b": "c"}).
+		Attr("is_multicast", false)
+	block.Child("ingress").Attr("port", "80")
+
+	sb := new(strings.Builder)
+	block.Render(sb)
+	expected := `resource "tencentcloud_vpc" "vpc-123" {
+  name         = "test\"vpc"
+  description  = "allow $${var} 100%%{x}"
+  dns_servers  = ["1.1.1.1"]
+  tags         = { "a.b" = "c", env = "prod" }
+  is_multicast = false
+
+  ingress {
+    port = "80"
+  }
+}
+`
+	assert.Equal(t, expected, sb.String())
+}
+
+func Test_resourceName(t *testing.T) {
+	assert.Equal(t, "sg-abc", resourceName("sg-abc"))
+	assert.Equal(t, "r_123", resourceName("123"))
+	assert.Equal(t, "projects_p_networks_n", resourceName("projects/p/networks/n"))
+}
+
+func Test_stateResourceAddress(t *testing.T) {
+	res := stateResource{Module: "module.net", Type: "aws_vpc", Name: "main"}
+	assert.Equal(t, "module.net.aws_vpc.main", res.address(stateInstance{}))
+	assert.Equal(t, `module.net.aws_vpc.main["a"]`, res.address(stateInstance{IndexKey: "a"}))
+	assert.Equal(t, "module.net.aws_vpc.main[0]", res.address(stateInstance{IndexKey: float64(0)}))
+}
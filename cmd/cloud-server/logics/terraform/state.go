@@ -0,0 +1,324 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+
+	csterraform "hcm/pkg/api/cloud-server/terraform"
+	"hcm/pkg/api/core"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+// supportedStateVersion is the terraform state format version supported.
+const supportedStateVersion = 4
+
+// stateFile define terraform state file, only the fields used are defined.
+type stateFile struct {
+	Version   int             `json:"version"`
+	Resources []stateResource `json:"resources"`
+}
+
+type stateResource struct {
+	Module    string          `json:"module"`
+	Mode      string          `json:"mode"`
+	Type      string          `json:"type"`
+	Name      string          `json:"name"`
+	Instances []stateInstance `json:"instances"`
+}
+
+type stateInstance struct {
+	IndexKey   any            `json:"index_key"`
+	Attributes map[string]any `json:"attributes"`
+}
+
+// address return terraform address of the resource instance, such as module.net.aws_vpc.main["a"].
+func (r stateResource) address(ins stateInstance) string {
+	addr := r.Type + "." + r.Name
+	if len(r.Module) != 0 {
+		addr = r.Module + "." + addr
+	}
+
+	switch key := ins.IndexKey.(type) {
+	case nil:
+	case string:
+		addr += "[" + quote(key) + "]"
+	default:
+		addr += fmt.Sprintf("[%v]", key)
+	}
+	return addr
+}
+
+// stateTypeMapping define how terraform resource type maps onto hcm resource type.
+type stateTypeMapping struct {
+	vendor  enumor.Vendor
+	resType enumor.CloudResourceType
+	// idAttr is the attribute of cloud id in terraform state.
+	idAttr string
+}
+
+var stateTypeMappings = map[string]stateTypeMapping{
+	"tencentcloud_vpc":            {vendor: enumor.TCloud, resType: enumor.VpcCloudResType, idAttr: "id"},
+	"tencentcloud_subnet":         {vendor: enumor.TCloud, resType: enumor.SubnetCloudResType, idAttr: "id"},
+	"tencentcloud_security_group": {vendor: enumor.TCloud, resType: enumor.SecurityGroupCloudResType, idAttr: "id"},
+	// rule set 管理安全组下的全部规则，对应到安全组
+	"tencentcloud_security_group_rule_set": {vendor: enumor.TCloud, resType: enumor.SecurityGroupCloudResType,
+		idAttr: "security_group_id"},
+	"aws_vpc":            {vendor: enumor.Aws, resType: enumor.VpcCloudResType, idAttr: "id"},
+	"aws_subnet":         {vendor: enumor.Aws, resType: enumor.SubnetCloudResType, idAttr: "id"},
+	"aws_security_group": {vendor: enumor.Aws, resType: enumor.SecurityGroupCloudResType, idAttr: "id"},
+	"aws_vpc_security_group_ingress_rule": {vendor: enumor.Aws, resType: enumor.SecurityGroupRuleCloudResType,
+		idAttr: "id"},
+	"aws_vpc_security_group_egress_rule": {vendor: enumor.Aws, resType: enumor.SecurityGroupRuleCloudResType,
+		idAttr: "id"},
+}
+
+// AuthFilter return the authorized filter of the resource type, noPerm is true when no resource is authorized.
+type AuthFilter func(resType enumor.CloudResourceType) (expr *filter.Expression, noPerm bool, err error)
+
+// matchedRes define hcm resource matched by cloud id.
+type matchedRes struct {
+	id        string
+	accountID string
+	bkBizID   int64
+}
+
+type resKey struct {
+	vendor  enumor.Vendor
+	resType enumor.CloudResourceType
+}
+
+// MapState map managed resources of terraform state onto hcm resources by cloud id, rules of aws are looked up
+// under the security group they belong to. Resources can not be viewed by current user are treated as not found.
+func MapState(kt *kit.Kit, cli *dataservice.Client, raw []byte, authFilter AuthFilter) (
+	[]csterraform.StateMapping, error) {
+
+	state := new(stateFile)
+	if err := json.Unmarshal(raw, state); err != nil {
+		return nil, errf.Newf(errf.InvalidParameter, "unmarshal terraform state failed, err: %v", err)
+	}
+	if state.Version != supportedStateVersion {
+		return nil, errf.Newf(errf.InvalidParameter, "terraform state version %d is not supported, only %d is "+
+			"supported", state.Version, supportedStateVersion)
+	}
+
+	result := make([]csterraform.StateMapping, 0)
+	// pending resource key -> cloud id -> index of result
+	pending := make(map[resKey]map[string][]int)
+	// ruleSG cloud security group rule id -> cloud security group id
+	ruleSG := make(map[string]string)
+	for _, res := range state.Resources {
+		if res.Mode != "managed" {
+			continue
+		}
+
+		mapping, supported := stateTypeMappings[res.Type]
+		for _, ins := range res.Instances {
+			one := csterraform.StateMapping{Address: res.address(ins), Type: res.Type}
+			if !supported {
+				one.Status = enumor.TerraformMappingUnsupported
+				result = append(result, one)
+				continue
+			}
+
+			one.Vendor, one.ResType = mapping.vendor, mapping.resType
+			one.CloudID, _ = ins.Attributes[mapping.idAttr].(string)
+			if len(one.CloudID) == 0 {
+				one.Status, one.Reason = enumor.TerraformMappingNotFound, mapping.idAttr+" is not set in state"
+				result = append(result, one)
+				continue
+			}
+
+			key := resKey{vendor: mapping.vendor, resType: mapping.resType}
+			if mapping.resType == enumor.SecurityGroupRuleCloudResType {
+				sgCloudID, _ := ins.Attributes["security_group_id"].(string)
+				ruleSG[one.CloudID] = sgCloudID
+			}
+			if _, exists := pending[key]; !exists {
+				pending[key] = make(map[string][]int)
+			}
+			pending[key][one.CloudID] = append(pending[key][one.CloudID], len(result))
+			result = append(result, one)
+		}
+	}
+
+	for key, cloudIDMap := range pending {
+		matched, err := matchResources(kt, cli, key, cloudIDMap, ruleSG, authFilter)
+		if err != nil {
+			logs.Errorf("match %s %s by cloud id failed, err: %v, rid: %s", key.vendor, key.resType, err, kt.Rid)
+			return nil, err
+		}
+
+		for cloudID, indexes := range cloudIDMap {
+			res, exists := matched[cloudID]
+			for _, idx := range indexes {
+				if !exists {
+					result[idx].Status = enumor.TerraformMappingNotFound
+					continue
+				}
+				result[idx].ResID, result[idx].AccountID, result[idx].BkBizID = res.id, res.accountID, res.bkBizID
+				result[idx].Status = enumor.TerraformMappingMatched
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// matchResources return cloud id -> matched hcm resource.
+func matchResources(kt *kit.Kit, cli *dataservice.Client, key resKey, cloudIDMap map[string][]int,
+	ruleSG map[string]string, authFilter AuthFilter) (map[string]matchedRes, error) {
+
+	cloudIDs := make([]string, 0, len(cloudIDMap))
+	for cloudID := range cloudIDMap {
+		cloudIDs = append(cloudIDs, cloudID)
+	}
+
+	if key.resType == enumor.SecurityGroupRuleCloudResType {
+		return matchAwsRules(kt, cli, cloudIDs, ruleSG, authFilter)
+	}
+
+	authExpr, noPerm, err := authFilter(key.resType)
+	if err != nil {
+		return nil, err
+	}
+	if noPerm {
+		return make(map[string]matchedRes), nil
+	}
+
+	return listByCloudIDs(kt, cli, key, authExpr, cloudIDs)
+}
+
+// listByCloudIDs list hcm resources of the vendor and resource type by cloud ids.
+func listByCloudIDs(kt *kit.Kit, cli *dataservice.Client, key resKey, authExpr *filter.Expression,
+	cloudIDs []string) (map[string]matchedRes, error) {
+
+	result := make(map[string]matchedRes, len(cloudIDs))
+	for _, batch := range slice.Split(cloudIDs, int(core.DefaultMaxPageLimit)) {
+		expr, err := tools.And(authExpr, tools.RuleEqual("vendor", key.vendor), tools.RuleIn("cloud_id", batch))
+		if err != nil {
+			return nil, err
+		}
+		listReq := &core.ListReq{Filter: expr, Page: core.NewDefaultBasePage(),
+			Fields: []string{"id", "cloud_id", "account_id", "bk_biz_id"}}
+
+		switch key.resType {
+		case enumor.VpcCloudResType:
+			resp, err := cli.Global.Vpc.List(kt.Ctx, kt.Header(), listReq)
+			if err != nil {
+				return nil, err
+			}
+			for _, one := range resp.Details {
+				result[one.CloudID] = matchedRes{id: one.ID, accountID: one.AccountID, bkBizID: one.BkBizID}
+			}
+		case enumor.SubnetCloudResType:
+			resp, err := cli.Global.Subnet.List(kt.Ctx, kt.Header(), listReq)
+			if err != nil {
+				return nil, err
+			}
+			for _, one := range resp.Details {
+				result[one.CloudID] = matchedRes{id: one.ID, accountID: one.AccountID, bkBizID: one.BkBizID}
+			}
+		case enumor.SecurityGroupCloudResType:
+			sgReq := &protocloud.SecurityGroupListReq{Field: listReq.Fields, Filter: listReq.Filter,
+				Page: listReq.Page}
+			resp, err := cli.Global.SecurityGroup.ListSecurityGroup(kt.Ctx, kt.Header(), sgReq)
+			if err != nil {
+				return nil, err
+			}
+			for _, one := range resp.Details {
+				result[one.CloudID] = matchedRes{id: one.ID, accountID: one.AccountID, bkBizID: one.BkBizID}
+			}
+		default:
+			return nil, fmt.Errorf("unsupported resource type: %s", key.resType)
+		}
+	}
+
+	return result, nil
+}
+
+// matchAwsRules match aws security group rules under the security groups they belong to, rules are authorized
+// by their security groups.
+func matchAwsRules(kt *kit.Kit, cli *dataservice.Client, ruleCloudIDs []string, ruleSG map[string]string,
+	authFilter AuthFilter) (map[string]matchedRes, error) {
+
+	result := make(map[string]matchedRes, len(ruleCloudIDs))
+	authExpr, noPerm, err := authFilter(enumor.SecurityGroupCloudResType)
+	if err != nil {
+		return nil, err
+	}
+	if noPerm {
+		return result, nil
+	}
+
+	// sgRules cloud security group id -> cloud rule ids
+	sgRules := make(map[string][]string)
+	for _, ruleID := range ruleCloudIDs {
+		if sgCloudID := ruleSG[ruleID]; len(sgCloudID) != 0 {
+			sgRules[sgCloudID] = append(sgRules[sgCloudID], ruleID)
+		}
+	}
+	if len(sgRules) == 0 {
+		return result, nil
+	}
+
+	sgCloudIDs := make([]string, 0, len(sgRules))
+	for sgCloudID := range sgRules {
+		sgCloudIDs = append(sgCloudIDs, sgCloudID)
+	}
+	sgMap, err := listByCloudIDs(kt, cli, resKey{vendor: enumor.Aws, resType: enumor.SecurityGroupCloudResType},
+		authExpr, sgCloudIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for sgCloudID, ruleIDs := range sgRules {
+		sg, exists := sgMap[sgCloudID]
+		if !exists {
+			continue
+		}
+
+		for _, batch := range slice.Split(ruleIDs, int(core.DefaultMaxPageLimit)) {
+			listReq := &protocloud.AwsSGRuleListReq{
+				Filter: tools.ExpressionAnd(tools.RuleEqual("security_group_id", sg.id),
+					tools.RuleIn("cloud_id", batch)),
+				Page: core.NewDefaultBasePage(),
+			}
+			resp, err := cli.Aws.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(), listReq, sg.id)
+			if err != nil {
+				return nil, err
+			}
+			for _, one := range resp.Details {
+				result[one.CloudID] = matchedRes{id: one.ID, accountID: one.AccountID, bkBizID: sg.bkBizID}
+			}
+		}
+	}
+
+	return result, nil
+}
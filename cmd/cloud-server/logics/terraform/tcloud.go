@@ -0,0 +1,157 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package terraform
+
+import (
+	"sort"
+
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+)
+
+type tcloudExporter struct{}
+
+func (tcloudExporter) provider() (string, string) {
+	return "tencentcloud", "tencentcloudstack/tencentcloud"
+}
+
+func (e tcloudExporter) export(kt *kit.Kit, cli *dataservice.Client, opt *ExportOption) ([]resource, error) {
+	resources := make([]resource, 0)
+	// vpcAddr cloud vpc id -> terraform address of the exported vpc
+	vpcAddr := make(map[string]string)
+
+	if len(opt.VpcIDs) != 0 {
+		listReq := &core.ListReq{Filter: resourceExpr(opt.Vendor, opt.VpcIDs), Page: core.NewDefaultBasePage()}
+		result, err := cli.TCloud.Vpc.ListVpcExt(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, vpc := range result.Details {
+			block := NewBlock("resource", "tencentcloud_vpc", resourceName(vpc.CloudID)).Attr("name", vpc.Name)
+			if vpc.Extension != nil {
+				for _, cidr := range vpc.Extension.Cidr {
+					if cidr.Type == enumor.Ipv4 && cidr.Category == enumor.MasterTCloudCidr {
+						block.Attr("cidr_block", cidr.Cidr)
+						break
+					}
+				}
+				block.Attr("dns_servers", vpc.Extension.DnsServerSet).
+					Attr("is_multicast", vpc.Extension.EnableMulticast)
+			}
+
+			one := resource{block: block, region: vpc.Region, importID: vpc.CloudID}
+			vpcAddr[vpc.CloudID] = one.address()
+			resources = append(resources, one)
+		}
+	}
+
+	if len(opt.SubnetIDs) != 0 {
+		listReq := &core.ListReq{Filter: resourceExpr(opt.Vendor, opt.SubnetIDs), Page: core.NewDefaultBasePage()}
+		result, err := cli.Global.Subnet.List(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, subnet := range result.Details {
+			block := NewBlock("resource", "tencentcloud_subnet", resourceName(subnet.CloudID))
+			setVpcID(block, vpcAddr, subnet.CloudVpcID)
+			block.Attr("name", subnet.Name).Attr("availability_zone", subnet.Zone)
+			if len(subnet.Ipv4Cidr) != 0 {
+				block.Attr("cidr_block", subnet.Ipv4Cidr[0])
+			}
+
+			resources = append(resources, resource{block: block, region: subnet.Region, importID: subnet.CloudID})
+		}
+	}
+
+	if len(opt.SecurityGroupIDs) != 0 {
+		sgResources, err := e.exportSecurityGroup(kt, cli, opt)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, sgResources...)
+	}
+
+	return resources, nil
+}
+
+// exportSecurityGroup export security groups, all rules of one security group are exported as one rule set.
+func (e tcloudExporter) exportSecurityGroup(kt *kit.Kit, cli *dataservice.Client, opt *ExportOption) (
+	[]resource, error) {
+
+	listReq := &protocloud.SecurityGroupListReq{Filter: resourceExpr(opt.Vendor, opt.SecurityGroupIDs),
+		Page: core.NewDefaultBasePage()}
+	result, err := cli.Global.SecurityGroup.ListSecurityGroup(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]resource, 0, len(result.Details)*2)
+	for _, sg := range result.Details {
+		sgBlock := NewBlock("resource", "tencentcloud_security_group", resourceName(sg.CloudID)).
+			Attr("name", sg.Name).Attr("description", sg.Memo).Attr("tags", map[string]string(sg.Tags))
+		sgRes := resource{block: sgBlock, region: sg.Region, importID: sg.CloudID}
+		resources = append(resources, sgRes)
+
+		rules, err := listRules(sg.ID, func(page *core.BasePage) ([]corecloud.TCloudSecurityGroupRule, error) {
+			ruleReq := &protocloud.TCloudSGRuleListReq{Filter: tools.EqualExpression("security_group_id", sg.ID),
+				Page: page}
+			ruleResult, err := cli.TCloud.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(), ruleReq, sg.ID)
+			if err != nil {
+				return nil, err
+			}
+			return ruleResult.Details, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(rules) == 0 {
+			continue
+		}
+
+		// 规则按照云上的策略顺序输出
+		sort.Slice(rules, func(i, j int) bool { return rules[i].CloudPolicyIndex < rules[j].CloudPolicyIndex })
+		ruleSet := NewBlock("resource", "tencentcloud_security_group_rule_set", resourceName(sg.CloudID)).
+			Ref("security_group_id", sgRes.address()+".id")
+		for _, rule := range rules {
+			ruleSet.Child(string(rule.Type)).
+				Attr("action", rule.Action).
+				Attr("protocol", rule.Protocol).
+				Attr("port", rule.Port).
+				Attr("cidr_block", rule.IPv4Cidr).
+				Attr("ipv6_cidr_block", rule.IPv6Cidr).
+				Attr("source_security_id", rule.CloudTargetSecurityGroupID).
+				Attr("address_template_id", rule.CloudAddressID).
+				Attr("address_template_group", rule.CloudAddressGroupID).
+				Attr("service_template_id", rule.CloudServiceID).
+				Attr("service_template_group", rule.CloudServiceGroupID).
+				Attr("description", rule.Memo)
+		}
+		resources = append(resources, resource{block: ruleSet, region: sg.Region, importID: sg.CloudID})
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,159 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package terraform render hcm resources as terraform configuration, and map terraform state onto hcm resources.
+package terraform
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+)
+
+// ExportOption define terraform export option, resources should belong to the vendor.
+type ExportOption struct {
+	Vendor           enumor.Vendor
+	VpcIDs           []string
+	SubnetIDs        []string
+	SecurityGroupIDs []string
+}
+
+// resource define one terraform resource rendered from hcm resource.
+type resource struct {
+	block  *Block
+	region string
+	// importID is the id used by terraform import block.
+	importID string
+}
+
+func (r resource) address() string {
+	return r.block.Labels[0] + "." + r.block.Labels[1]
+}
+
+// exporter render hcm resources of one vendor as terraform resources.
+type exporter interface {
+	// provider return terraform provider local name and source.
+	provider() (string, string)
+	export(kt *kit.Kit, cli *dataservice.Client, opt *ExportOption) ([]resource, error)
+}
+
+var exporters = map[enumor.Vendor]exporter{
+	enumor.TCloud: tcloudExporter{},
+	enumor.Aws:    awsExporter{},
+}
+
+// IsVendorSupported return if the vendor supports terraform export and state import.
+func IsVendorSupported(vendor enumor.Vendor) bool {
+	_, exists := exporters[vendor]
+	return exists
+}
+
+// Export render resources as terraform configuration with resource blocks and import blocks, the import blocks
+// need terraform v1.5+. Resources of different regions use provider aliases named by region.
+func Export(kt *kit.Kit, cli *dataservice.Client, opt *ExportOption) (string, error) {
+	exp, exists := exporters[opt.Vendor]
+	if !exists {
+		return "", fmt.Errorf("vendor: %s does not support terraform export", opt.Vendor)
+	}
+
+	resources, err := exp.export(kt, cli, opt)
+	if err != nil {
+		logs.Errorf("export %s terraform resources failed, err: %v, rid: %s", opt.Vendor, err, kt.Rid)
+		return "", err
+	}
+
+	providerName, providerSource := exp.provider()
+	regionSet := make(map[string]struct{})
+	for _, one := range resources {
+		regionSet[one.region] = struct{}{}
+	}
+	regions := make([]string, 0, len(regionSet))
+	for region := range regionSet {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+
+	sb := new(strings.Builder)
+	sb.WriteString("# Generated by HCM, run terraform plan to check the difference before apply.\n\n")
+
+	tf := NewBlock("terraform")
+	tf.Child("required_providers").Attr(providerName, map[string]string{"source": providerSource})
+	tf.Render(sb)
+
+	multiRegion := len(regions) > 1
+	for _, region := range regions {
+		sb.WriteString("\n")
+		p := NewBlock("provider", providerName)
+		if multiRegion {
+			p.Attr("alias", regionAlias(region))
+		}
+		p.Attr("region", region).Render(sb)
+	}
+
+	for _, one := range resources {
+		imp := NewBlock("import").Ref("to", one.address()).Attr("id", one.importID)
+		if multiRegion {
+			providerRef := providerName + "." + regionAlias(one.region)
+			one.block.Ref("provider", providerRef)
+			imp.Ref("provider", providerRef)
+		}
+
+		sb.WriteString("\n")
+		imp.Render(sb)
+		sb.WriteString("\n")
+		one.block.Render(sb)
+	}
+
+	return sb.String(), nil
+}
+
+func regionAlias(region string) string {
+	return strings.ReplaceAll(resourceName(region), "-", "_")
+}
+
+// resourceExpr return the filter of resources of the vendor by ids.
+func resourceExpr(vendor enumor.Vendor, ids []string) *filter.Expression {
+	return tools.ExpressionAnd(tools.RuleEqual("vendor", vendor), tools.RuleIn("id", ids))
+}
+
+// listRules list all security group rules of the security group page by page.
+func listRules[T any](sgID string, list func(page *core.BasePage) ([]T, error)) ([]T, error) {
+	page := core.NewDefaultBasePage()
+	rules := make([]T, 0)
+	for {
+		details, err := list(page)
+		if err != nil {
+			return nil, fmt.Errorf("list security group: %s rules failed, err: %v", sgID, err)
+		}
+		rules = append(rules, details...)
+
+		if uint(len(details)) < page.Limit {
+			return rules, nil
+		}
+		page.Start += uint32(page.Limit)
+	}
+}
@@ -0,0 +1,317 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package webhook webhook delivery logics, pull the resource change events of each webhook subscription, record the
+// matched events as deliveries, and deliver them to the subscription url with hmac signature, failed deliveries are
+// retried with exponential backoff.
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"hcm/pkg/api/core"
+	corewebhook "hcm/pkg/api/core/webhook"
+	dataevent "hcm/pkg/api/data-service/event"
+	datawebhook "hcm/pkg/api/data-service/webhook"
+	"hcm/pkg/cc"
+	"hcm/pkg/cdc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/cryptography"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+const (
+	// SignatureHeader 请求体签名，格式为 sha256=hex(hmac_sha256(secret, timestamp + "." + body))
+	SignatureHeader = "X-HCM-Signature"
+	// TimestampHeader 签名时间戳，unix秒，订阅方可据此拒绝过期的请求，防止重放
+	TimestampHeader = "X-HCM-Timestamp"
+	// EventHeader 事件类型，格式为 资源类型.操作类型，如 cvm.create
+	EventHeader = "X-HCM-Event"
+	// DeliveryHeader 投递记录ID，重试时不变，订阅方可据此对事件去重
+	DeliveryHeader = "X-HCM-Delivery"
+)
+
+const (
+	// pullBatches 每轮每个topic最多拉取的批次，避免积压较多时单轮执行时间过长
+	pullBatches = 10
+	// baseBackoff 首次重试的等待时间，之后每次翻倍
+	baseBackoff = 30 * time.Second
+	// maxBackoff 重试等待时间的上限
+	maxBackoff = time.Hour
+	// maxReasonLen 投递失败原因的最大长度
+	maxReasonLen = 1024
+)
+
+// Sign 计算请求体的签名，签名内容包含时间戳，避免请求被截获后重放
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff 返回第attempts次投递失败后到下次重试的等待时间，按指数退避
+func Backoff(attempts uint) time.Duration {
+	backoff := baseBackoff
+	for i := uint(1); i < attempts; i++ {
+		backoff *= 2
+		if backoff >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return backoff
+}
+
+// Dispatcher dispatch resource change events to webhook subscriptions.
+type Dispatcher struct {
+	client      *client.ClientSet
+	cipher      cryptography.Crypto
+	http        *http.Client
+	maxAttempts uint
+}
+
+// NewDispatcher new webhook dispatcher.
+func NewDispatcher(client *client.ClientSet, cipher cryptography.Crypto, opt cc.Webhook) *Dispatcher {
+	return &Dispatcher{
+		client:      client,
+		cipher:      cipher,
+		http:        &http.Client{Timeout: time.Duration(opt.TimeoutSec) * time.Second},
+		maxAttempts: opt.MaxAttempts,
+	}
+}
+
+// Dispatch collect the new events of all enabled subscriptions and deliver the pending deliveries.
+func (d *Dispatcher) Dispatch(kt *kit.Kit) error {
+	subs, err := d.listEnabledSubscriptions(kt)
+	if err != nil {
+		logs.Errorf("list enabled webhook subscription failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	if len(subs) == 0 {
+		return nil
+	}
+
+	for i := range subs {
+		for _, resType := range subs[i].ResTypes {
+			if err = d.collect(kt, &subs[i], resType); err != nil {
+				logs.Errorf("collect webhook %s events of %s failed, err: %v, rid: %s", subs[i].ID, resType, err,
+					kt.Rid)
+			}
+		}
+	}
+
+	return d.deliverPending(kt, subs)
+}
+
+func (d *Dispatcher) listEnabledSubscriptions(kt *kit.Kit) ([]corewebhook.Subscription, error) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("enabled", true),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	subs := make([]corewebhook.Subscription, 0)
+	for {
+		result, err := d.client.DataService().Global.Webhook.ListSubscription(kt, listReq)
+		if err != nil {
+			return nil, err
+		}
+
+		subs = append(subs, result.Details...)
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return subs, nil
+}
+
+// collect 拉取订阅在该topic上的新事件，满足过滤条件的事件记录为待投递后再提交位点，保证事件至少投递一次
+func (d *Dispatcher) collect(kt *kit.Kit, sub *corewebhook.Subscription, resType enumor.AuditResourceType) error {
+	consumer := datawebhook.ConsumerName(sub.ID)
+
+	for i := 0; i < pullBatches; i++ {
+		pullReq := &dataevent.PullReq{Consumer: consumer, Topic: resType, Limit: dataevent.MaxPullLimit}
+		result, err := d.client.DataService().Global.Event.Pull(kt, pullReq)
+		if err != nil {
+			return err
+		}
+
+		if len(result.Details) == 0 {
+			return nil
+		}
+
+		matched := make([]cdc.Event, 0, len(result.Details))
+		for _, event := range result.Details {
+			if sub.Match(&event) {
+				matched = append(matched, event)
+			}
+		}
+
+		if len(matched) != 0 {
+			createReq := &datawebhook.DeliveryBatchCreateReq{SubscriptionID: sub.ID, Events: matched}
+			if err = d.client.DataService().Global.Webhook.BatchCreateDelivery(kt, createReq); err != nil {
+				return err
+			}
+		}
+
+		commitReq := &dataevent.OffsetCommitReq{Consumer: consumer, Topic: resType, Offset: &result.NextOffset}
+		if err = d.client.DataService().Global.Event.CommitOffset(kt, commitReq); err != nil {
+			return err
+		}
+
+		if len(result.Details) < dataevent.MaxPullLimit {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// deliverPending 按事件顺序投递到期的待投递记录，订阅地址无法访问时跳过该订阅本轮剩余的记录
+func (d *Dispatcher) deliverPending(kt *kit.Kit, subs []corewebhook.Subscription) error {
+	subMap := make(map[string]*corewebhook.Subscription, len(subs))
+	subIDs := make([]string, 0, len(subs))
+	for i := range subs {
+		subMap[subs[i].ID] = &subs[i]
+		subIDs = append(subIDs, subs[i].ID)
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleIn("subscription_id", subIDs),
+			tools.RuleEqual("state", enumor.WebhookDeliveryPending),
+			tools.RuleLessThanEqual("next_retry_at", time.Now().Format(constant.TimeStdFormat)),
+		),
+		Page: &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit, Sort: "event_offset", Order: core.Ascending},
+	}
+	result, err := d.client.DataService().Global.Webhook.ListDelivery(kt, listReq)
+	if err != nil {
+		logs.Errorf("list pending webhook delivery failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	unreachable := make(map[string]struct{})
+	for i := range result.Details {
+		delivery := &result.Details[i]
+		if _, exists := unreachable[delivery.SubscriptionID]; exists {
+			continue
+		}
+
+		sub, exists := subMap[delivery.SubscriptionID]
+		if !exists {
+			continue
+		}
+
+		statusCode, sendErr := d.Deliver(kt, sub, delivery)
+		if err = d.updateResult(kt, delivery, statusCode, sendErr); err != nil {
+			logs.Errorf("update webhook delivery %s result failed, err: %v, rid: %s", delivery.ID, err, kt.Rid)
+		}
+
+		if sendErr != nil && statusCode == 0 {
+			unreachable[delivery.SubscriptionID] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// Deliver send the delivery payload to the subscription url, returns the response status code, which is 0 if
+// the request is not responded.
+func (d *Dispatcher) Deliver(kt *kit.Kit, sub *corewebhook.Subscription, delivery *corewebhook.Delivery) (int,
+	error) {
+
+	secret, err := d.cipher.DecryptFromBase64(sub.Secret)
+	if err != nil {
+		return 0, fmt.Errorf("decrypt webhook secret failed, err: %v", err)
+	}
+
+	body, err := json.Marshal(delivery.Payload)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(kt.Ctx, http.MethodPost, sub.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(secret, timestamp, body))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(EventHeader, fmt.Sprintf("%s.%s", delivery.ResType, delivery.Action))
+	req.Header.Set(DeliveryHeader, delivery.ID)
+
+	resp, err := d.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (d *Dispatcher) updateResult(kt *kit.Kit, delivery *corewebhook.Delivery, statusCode int, sendErr error) error {
+	updateReq := &datawebhook.DeliveryUpdateReq{
+		ID:          delivery.ID,
+		State:       enumor.WebhookDeliverySuccess,
+		Attempts:    delivery.Attempts + 1,
+		StatusCode:  statusCode,
+		NextRetryAt: time.Now(),
+	}
+
+	if sendErr != nil {
+		updateReq.Reason = sendErr.Error()
+		if len(updateReq.Reason) > maxReasonLen {
+			updateReq.Reason = updateReq.Reason[:maxReasonLen]
+		}
+
+		if updateReq.Attempts >= d.maxAttempts {
+			updateReq.State = enumor.WebhookDeliveryFailed
+			logs.Errorf("webhook delivery %s failed after %d attempts, subscription: %s, err: %v, rid: %s",
+				delivery.ID, updateReq.Attempts, delivery.SubscriptionID, sendErr, kt.Rid)
+		} else {
+			updateReq.State = enumor.WebhookDeliveryPending
+			updateReq.NextRetryAt = time.Now().Add(Backoff(updateReq.Attempts))
+		}
+	}
+
+	return d.client.DataService().Global.Webhook.UpdateDelivery(kt, updateReq)
+}
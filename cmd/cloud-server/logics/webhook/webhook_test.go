@@ -0,0 +1,102 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	corewebhook "hcm/pkg/api/core/webhook"
+	"hcm/pkg/cc"
+	"hcm/pkg/cdc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/cryptography"
+	"hcm/pkg/kit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, Backoff(1))
+	assert.Equal(t, time.Minute, Backoff(2))
+	assert.Equal(t, 4*time.Minute, Backoff(4))
+	assert.Equal(t, time.Hour, Backoff(10))
+}
+
+func TestDeliver(t *testing.T) {
+	cipher, err := cryptography.NewAESGcm([]byte("0123456789abcdef0123456789abcdef"), []byte("0123456789ab"))
+	assert.NoError(t, err)
+
+	const secret = "webhook-secret-for-test"
+	var header http.Header
+	var body []byte
+	status := http.StatusOK
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer svr.Close()
+
+	d := NewDispatcher(nil, cipher, cc.Webhook{TimeoutSec: 5, MaxAttempts: 3})
+	sub := &corewebhook.Subscription{ID: "00000001", Url: svr.URL, Secret: cipher.EncryptToBase64(secret)}
+	delivery := &corewebhook.Delivery{
+		ID:      "00000002",
+		ResType: enumor.CvmAuditResType,
+		Action:  enumor.Create,
+		Payload: &cdc.Event{Offset: 10, ResType: enumor.CvmAuditResType, ResID: "00000003", Action: enumor.Create},
+	}
+
+	code, err := d.Deliver(kit.New(), sub, delivery)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "cvm.create", header.Get(EventHeader))
+	assert.Equal(t, "00000002", header.Get(DeliveryHeader))
+
+	// 订阅方使用相同的密钥和时间戳计算签名进行校验
+	timestamp, err := strconv.ParseInt(header.Get(TimestampHeader), 10, 64)
+	assert.NoError(t, err)
+	assert.Equal(t, Sign(secret, timestamp, body), header.Get(SignatureHeader))
+	assert.NotEqual(t, Sign("other-secret", timestamp, body), header.Get(SignatureHeader))
+
+	status = http.StatusInternalServerError
+	code, err = d.Deliver(kit.New(), sub, delivery)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, code)
+}
+
+func TestSubscriptionMatch(t *testing.T) {
+	sub := &corewebhook.Subscription{
+		ResTypes: []enumor.AuditResourceType{enumor.CvmAuditResType},
+		Actions:  []enumor.AuditAction{enumor.Create, enumor.Delete},
+	}
+
+	assert.True(t, sub.Match(&cdc.Event{ResType: enumor.CvmAuditResType, Action: enumor.Delete, BkBizID: 2}))
+	assert.False(t, sub.Match(&cdc.Event{ResType: enumor.CvmAuditResType, Action: enumor.Update}))
+	assert.False(t, sub.Match(&cdc.Event{ResType: enumor.DiskAuditResType, Action: enumor.Create}))
+
+	sub.BkBizIDs = []int64{1}
+	assert.False(t, sub.Match(&cdc.Event{ResType: enumor.CvmAuditResType, Action: enumor.Delete, BkBizID: 2}))
+	assert.True(t, sub.Match(&cdc.Event{ResType: enumor.CvmAuditResType, Action: enumor.Delete, BkBizID: 1}))
+}
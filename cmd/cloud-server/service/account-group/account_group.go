@@ -0,0 +1,238 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package accountgroup account group service, account group is used as the scope of sync, search and cost reports.
+package accountgroup
+
+import (
+	"fmt"
+	"net/http"
+
+	logicsaccountgroup "hcm/cmd/cloud-server/logics/account-group"
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/api/core"
+	coreaccountgroup "hcm/pkg/api/core/account-group"
+	dataaccountgroup "hcm/pkg/api/data-service/account-group"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the account group service.
+func InitService(c *capability.Capability) {
+	svc := &accountGroupSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateAccountGroup", http.MethodPost, "/account_groups/create", svc.CreateAccountGroup)
+	h.Add("UpdateAccountGroup", http.MethodPatch, "/account_groups/{id}", svc.UpdateAccountGroup)
+	h.Add("ListAccountGroup", http.MethodPost, "/account_groups/list", svc.ListAccountGroup)
+	h.Add("BatchDeleteAccountGroup", http.MethodDelete, "/account_groups/batch", svc.BatchDeleteAccountGroup)
+
+	h.Add("AddAccountGroupMember", http.MethodPost, "/account_groups/members/add", svc.AddAccountGroupMember)
+	h.Add("RemoveAccountGroupMember", http.MethodPost, "/account_groups/members/remove",
+		svc.RemoveAccountGroupMember)
+	h.Add("ListAccountGroupMember", http.MethodPost, "/account_groups/members/list", svc.ListAccountGroupMember)
+
+	h.Add("SyncAccountGroup", http.MethodPost, "/account_groups/{id}/sync", svc.SyncAccountGroup)
+
+	h.Load(c.WebService)
+}
+
+type accountGroupSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// CreateAccountGroup create account group, parent_id is used to build the hierarchy of account groups.
+func (svc *accountGroupSvc) CreateAccountGroup(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataaccountgroup.AccountGroupCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Create); err != nil {
+		return nil, err
+	}
+
+	if len(req.ParentID) != 0 {
+		groups, err := logicsaccountgroup.ListAllGroups(cts.Kit, svc.client)
+		if err != nil {
+			return nil, err
+		}
+		if !isGroupExists(groups, req.ParentID) {
+			return nil, errf.Newf(errf.InvalidParameter, "parent account group: %s not found", req.ParentID)
+		}
+	}
+
+	result, err := svc.client.DataService().Global.AccountGroup.Create(cts.Kit, req)
+	if err != nil {
+		logs.Errorf("create account group failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateAccountGroup update account group, the parent can not be the group itself or its descendant groups.
+func (svc *accountGroupSvc) UpdateAccountGroup(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(dataaccountgroup.AccountGroupUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	if req.ParentID != nil && len(*req.ParentID) != 0 {
+		groups, err := logicsaccountgroup.ListAllGroups(cts.Kit, svc.client)
+		if err != nil {
+			return nil, err
+		}
+		if !isGroupExists(groups, *req.ParentID) {
+			return nil, errf.Newf(errf.InvalidParameter, "parent account group: %s not found", *req.ParentID)
+		}
+		for _, subID := range logicsaccountgroup.ListSubGroupIDs(groups, id) {
+			if subID == *req.ParentID {
+				return nil, errf.New(errf.InvalidParameter,
+					"parent account group can not be the group itself or its sub group")
+			}
+		}
+	}
+
+	if err := svc.client.DataService().Global.AccountGroup.Update(cts.Kit, id, req); err != nil {
+		logs.Errorf("update account group failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListAccountGroup list account group.
+func (svc *accountGroupSvc) ListAccountGroup(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.AccountGroup.List(cts.Kit, req)
+}
+
+// BatchDeleteAccountGroup batch delete account group and its members, the group which has sub groups can not be
+// deleted unless its sub groups are deleted together.
+func (svc *accountGroupSvc) BatchDeleteAccountGroup(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	groups, err := logicsaccountgroup.ListAllGroups(cts.Kit, svc.client)
+	if err != nil {
+		return nil, err
+	}
+
+	deleteIDs := make(map[string]struct{}, len(req.IDs))
+	for _, id := range req.IDs {
+		deleteIDs[id] = struct{}{}
+	}
+	for _, one := range groups {
+		if _, exists := deleteIDs[one.ParentID]; !exists {
+			continue
+		}
+		if _, exists := deleteIDs[one.ID]; !exists {
+			return nil, errf.Newf(errf.InvalidParameter, "account group: %s has sub group: %s, can not delete",
+				one.ParentID, one.ID)
+		}
+	}
+
+	if err = svc.client.DataService().Global.AccountGroup.BatchDelete(cts.Kit, req); err != nil {
+		logs.Errorf("delete account group failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (svc *accountGroupSvc) authorize(cts *rest.Contexts, action meta.Action) error {
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountGroup, Action: action}}
+	return svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes)
+}
+
+func isGroupExists(groups []coreaccountgroup.AccountGroup, id string) bool {
+	for _, one := range groups {
+		if one.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (svc *accountGroupSvc) checkGroupExists(cts *rest.Contexts, id string) error {
+	result, err := svc.client.DataService().Global.AccountGroup.List(cts.Kit, &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewCountPage(),
+	})
+	if err != nil {
+		logs.Errorf("count account group failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return err
+	}
+
+	if result.Count == 0 {
+		return errf.New(errf.RecordNotFound, fmt.Sprintf("account group: %s not found", id))
+	}
+
+	return nil
+}
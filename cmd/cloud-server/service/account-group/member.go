@@ -0,0 +1,159 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package accountgroup
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	dataaccountgroup "hcm/pkg/api/data-service/account-group"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/slice"
+)
+
+// AddAccountGroupMember add resource accounts or main accounts into account group.
+func (svc *accountGroupSvc) AddAccountGroupMember(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataaccountgroup.AccountGroupMemberReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	if err := svc.checkGroupExists(cts, req.GroupID); err != nil {
+		return nil, err
+	}
+
+	typeAccountIDs := make(map[enumor.AccountGroupMemberType][]string)
+	for _, one := range req.Members {
+		typeAccountIDs[one.AccountType] = append(typeAccountIDs[one.AccountType], one.AccountID)
+	}
+	for accountType, accountIDs := range typeAccountIDs {
+		if err := svc.checkMemberExists(cts.Kit, accountType, slice.Unique(accountIDs)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := svc.client.DataService().Global.AccountGroup.AddMember(cts.Kit, req); err != nil {
+		logs.Errorf("add account group member failed, err: %v, group: %s, rid: %s", err, req.GroupID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// RemoveAccountGroupMember remove members from account group.
+func (svc *accountGroupSvc) RemoveAccountGroupMember(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataaccountgroup.AccountGroupMemberReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.AccountGroup.RemoveMember(cts.Kit, req); err != nil {
+		logs.Errorf("remove account group member failed, err: %v, group: %s, rid: %s", err, req.GroupID,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListAccountGroupMember list account group member, only the direct members of the group are returned.
+func (svc *accountGroupSvc) ListAccountGroupMember(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.AccountGroup.ListMember(cts.Kit, req)
+}
+
+// checkMemberExists check whether the resource accounts or main accounts exist.
+func (svc *accountGroupSvc) checkMemberExists(kt *kit.Kit, accountType enumor.AccountGroupMemberType,
+	accountIDs []string) error {
+
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("id", accountIDs),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id"},
+	}
+
+	existIDs := make(map[string]struct{}, len(accountIDs))
+	switch accountType {
+	case enumor.AccountGroupMemberAccount:
+		result, err := svc.client.DataService().Global.Account.List(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list account failed, err: %v, ids: %v, rid: %s", err, accountIDs, kt.Rid)
+			return err
+		}
+		for _, one := range result.Details {
+			existIDs[one.ID] = struct{}{}
+		}
+
+	case enumor.AccountGroupMemberMainAccount:
+		result, err := svc.client.DataService().Global.MainAccount.List(kt, listReq)
+		if err != nil {
+			logs.Errorf("list main account failed, err: %v, ids: %v, rid: %s", err, accountIDs, kt.Rid)
+			return err
+		}
+		for _, one := range result.Details {
+			existIDs[one.ID] = struct{}{}
+		}
+
+	default:
+		return errf.Newf(errf.InvalidParameter, "unsupported account group member type: %s", accountType)
+	}
+
+	for _, id := range accountIDs {
+		if _, exists := existIDs[id]; !exists {
+			return errf.New(errf.InvalidParameter, fmt.Sprintf("%s: %s not found", accountType, id))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package accountgroup
+
+import (
+	logicsaccount "hcm/cmd/cloud-server/logics/account"
+	logicsaccountgroup "hcm/cmd/cloud-server/logics/account-group"
+	csaccountgroup "hcm/pkg/api/cloud-server/account-group"
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncAccountGroup sync cloud resources of all resource accounts in account group and its sub groups, the user
+// should have the update permission of all these accounts. Accounts which are syncing are returned as failed.
+func (svc *accountGroupSvc) SyncAccountGroup(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	accountIDs, err := logicsaccountgroup.ListGroupAccountIDs(cts.Kit, svc.client, id,
+		enumor.AccountGroupMemberAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &csaccountgroup.AccountGroupSyncResult{
+		Succeeded: make([]string, 0),
+		Failed:    make([]csaccountgroup.AccountGroupSyncFailure, 0),
+	}
+	if len(accountIDs) == 0 {
+		return result, nil
+	}
+
+	authRes := make([]meta.ResourceAttribute, 0, len(accountIDs))
+	for _, accountID := range accountIDs {
+		authRes = append(authRes, meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Account,
+			Action: meta.Update, ResourceID: accountID}})
+	}
+	if err = svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes...); err != nil {
+		return nil, err
+	}
+
+	for _, batch := range slice.Split(accountIDs, int(core.DefaultMaxPageLimit)) {
+		listReq := &core.ListReq{
+			Filter: tools.ContainersExpression("id", batch),
+			Page:   core.NewDefaultBasePage(),
+			Fields: []string{"id", "vendor"},
+		}
+		accounts, err := svc.client.DataService().Global.Account.List(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list account failed, err: %v, ids: %v, rid: %s", err, batch, cts.Kit.Rid)
+			return nil, err
+		}
+
+		for _, one := range accounts.Details {
+			if err = logicsaccount.Sync(cts.Kit, svc.client, one.Vendor, one.ID); err != nil {
+				logs.Errorf("sync account of group failed, err: %v, group: %s, account: %s, rid: %s", err, id,
+					one.ID, cts.Kit.Rid)
+				result.Failed = append(result.Failed, csaccountgroup.AccountGroupSyncFailure{AccountID: one.ID,
+					Reason: err.Error()})
+				continue
+			}
+			result.Succeeded = append(result.Succeeded, one.ID)
+		}
+	}
+
+	return result, nil
+}
@@ -25,6 +25,8 @@ import (
 
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/service/capability"
+	proto "hcm/pkg/api/cloud-server/account"
+	dataproto "hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/client"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/iam/auth"
@@ -42,18 +44,22 @@ func InitAccountService(c *capability.Capability) {
 
 	h := rest.NewHandler()
 	// 兼容登记账号校验，过渡方案，后期去除
-	h.Add("CheckAccount", http.MethodPost, "/accounts/check", svc.CheckAccount)
+	h.Add("CheckAccount", http.MethodPost, "/accounts/check", svc.CheckAccount).Reads(proto.AccountCheckReq{})
 
 	h.Add("GetResCountBySecret", http.MethodPost, "/vendors/{vendor}/accounts/res_counts/by_secrets",
 		svc.GetResCountBySecret)
 	h.Add("GetAccountBySecret", http.MethodPost, "/vendors/{vendor}/accounts/secret", svc.GetAccountBySecret)
 	h.Add("CheckByID", http.MethodPost, "/accounts/{account_id}/check", svc.CheckByID)
-	h.Add("ListAccount", http.MethodPost, "/accounts/list", svc.ListAccount)
-	h.Add("ResourceList", http.MethodPost, "/accounts/resources/accounts/list", svc.ResourceList)
+	h.Add("ListAccount", http.MethodPost, "/accounts/list", svc.ListAccount).Reads(proto.AccountListReq{}).
+		Writes(dataproto.AccountListResult{})
+	h.Add("ResourceList", http.MethodPost, "/accounts/resources/accounts/list", svc.ResourceList).
+		Reads(proto.AccountListResourceReq{})
 	h.Add("GetAccount", http.MethodGet, "/accounts/{account_id}", svc.GetAccount)
 	h.Add("GetSyncDetail", http.MethodGet, "/accounts/sync_details/{account_id}", svc.GetSyncDetail)
-	h.Add("UpdateAccount", http.MethodPatch, "/accounts/{account_id}", svc.UpdateAccount)
-	h.Add("SyncCloudResource", http.MethodPost, "/accounts/{account_id}/sync", svc.SyncCloudResource)
+	h.Add("UpdateAccount", http.MethodPatch, "/accounts/{account_id}", svc.UpdateAccount).Reads(proto.AccountUpdateReq{})
+	// v2 返回同步任务流ID，v1 保持不返回数据
+	h.AddVersion(rest.Version2, "SyncCloudResource", http.MethodPost, "/accounts/{account_id}/sync",
+		svc.SyncCloudResource, rest.VersionConverter{Version: rest.Version1, Response: discardSyncResult})
 	h.Add("DeleteAccount", http.MethodDelete, "/accounts/{account_id}", svc.DeleteAccount)
 	h.Add("DeleteValidate", http.MethodPost, "/accounts/{account_id}/delete/validate", svc.DeleteValidate)
 
@@ -92,7 +98,7 @@ func InitAccountService(c *capability.Capability) {
 	h.Add("GetTCloudNetworkAccountType", http.MethodGet, "/vendors/tcloud/accounts/{account_id}/network_type",
 		svc.GetTCloudNetworkAccountType)
 
-	h.Load(c.WebService)
+	h.LoadVersions(c.WebServices)
 }
 
 type accountSvc struct {
@@ -37,6 +37,7 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 	"hcm/pkg/runtime/filter"
@@ -45,7 +46,7 @@ import (
 	etcd3 "go.etcd.io/etcd/client/v3"
 )
 
-// SyncCloudResource ...
+// SyncCloudResource sync account cloud resource, returns the sync flow id since v2.
 func (a *accountSvc) SyncCloudResource(cts *rest.Contexts) (interface{}, error) {
 	accountID := cts.PathParameter("account_id").String()
 
@@ -60,10 +61,16 @@ func (a *accountSvc) SyncCloudResource(cts *rest.Contexts) (interface{}, error)
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	if err = account.Sync(cts.Kit, a.client, baseInfo.Vendor, accountID); err != nil {
+	flowID, err := account.SyncWithFlow(cts.Kit, a.client, baseInfo.Vendor, accountID)
+	if err != nil {
 		return nil, err
 	}
 
+	return &cloudaccount.AccountSyncResult{FlowID: flowID}, nil
+}
+
+// discardSyncResult converts the v2 account sync result to v1, which has no data.
+func discardSyncResult(_ *kit.Kit, _ interface{}) (interface{}, error) {
 	return nil, nil
 }
 
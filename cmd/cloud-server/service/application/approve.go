@@ -23,6 +23,8 @@ import (
 	"errors"
 	"fmt"
 
+	"hcm/cmd/cloud-server/logics/notification"
+	"hcm/cmd/cloud-server/logics/quota"
 	"hcm/cmd/cloud-server/service/application/handlers"
 	accounthandler "hcm/cmd/cloud-server/service/application/handlers/account"
 	awscvmhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/aws"
@@ -38,15 +40,19 @@ import (
 	"hcm/cmd/cloud-server/service/application/handlers/load_balancer/tcloud"
 	createmainaccount "hcm/cmd/cloud-server/service/application/handlers/main-account/create-main-account"
 	updatemainaccount "hcm/cmd/cloud-server/service/application/handlers/main-account/update-main-account"
+	tcloudsghandler "hcm/cmd/cloud-server/service/application/handlers/security-group/tcloud"
+	transferres "hcm/cmd/cloud-server/service/application/handlers/transfer-resource"
 	awsvpchandler "hcm/cmd/cloud-server/service/application/handlers/vpc/aws"
 	azurevpchandler "hcm/cmd/cloud-server/service/application/handlers/vpc/azure"
 	gcpvpchandler "hcm/cmd/cloud-server/service/application/handlers/vpc/gcp"
 	huaweivpchandler "hcm/cmd/cloud-server/service/application/handlers/vpc/huawei"
 	tcloudvpchandler "hcm/cmd/cloud-server/service/application/handlers/vpc/tcloud"
+	cloudserver "hcm/pkg/api/cloud-server"
 	proto "hcm/pkg/api/cloud-server/application"
 	cscvm "hcm/pkg/api/cloud-server/cvm"
 	csdisk "hcm/pkg/api/cloud-server/disk"
 	csvpc "hcm/pkg/api/cloud-server/vpc"
+	"hcm/pkg/api/core"
 	dataproto "hcm/pkg/api/data-service"
 	hclb "hcm/pkg/api/hc-service/load-balancer"
 	"hcm/pkg/criteria/constant"
@@ -125,6 +131,21 @@ func (a *applicationSvc) approve(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
+	// 单据状态变更通知异步发送，使用独立的kit避免回调请求结束后被取消
+	notifyKt := core.NewBackendKit().NewSubKitWithRid(cts.Kit.Rid)
+	go a.notifyLgc.Notify(notifyKt, &notification.Event{
+		Type:         enumor.NotificationApplicationStatus,
+		BkBizIDs:     application.BkBizIDs,
+		RelatedUsers: []string{application.Applicant},
+		Data: map[string]interface{}{
+			"application_id":   application.ID,
+			"application_sn":   application.SN,
+			"application_type": application.Type,
+			"applicant":        application.Applicant,
+			"status":           nextStatus,
+		},
+	})
+
 	// 通过后需要进行资源交付
 	if status == enumor.Pass {
 		// TODO: 需要引入异步任务框架，这里先暂时用goroutine异步执行，无法记录状态等的，包括可能被kill等异常情况都无法处理和记录
@@ -134,6 +155,18 @@ func (a *applicationSvc) approve(cts *rest.Contexts) (interface{}, error) {
 	return nil, nil
 }
 
+// checkCvmQuotaOnDeliver 交付主机申请单前重新校验业务主机配额
+func (a *applicationSvc) checkCvmQuotaOnDeliver(cts *rest.Contexts, application *dataproto.ApplicationResp) error {
+	opt, err := parseReqFromApplicationContent[quota.CvmApplyOption](application.Content)
+	if err != nil {
+		return err
+	}
+
+	unlock := a.quotaLgc.LockBiz(opt.BkBizID)
+	defer unlock()
+	return a.quotaLgc.CheckCvmQuotaOnDeliver(cts.Kit, application.ID, opt)
+}
+
 func parseReqFromApplicationContent[T any](content string) (*T, error) {
 	// 解析申请单内容
 	req := new(T)
@@ -277,6 +310,22 @@ func (a *applicationSvc) getHandlerOfCreateLoadBalancer(opt *handlers.HandlerOpt
 	}
 }
 
+func (a *applicationSvc) getHandlerOfCreateSGRule(opt *handlers.HandlerOption, vendor enumor.Vendor,
+	application *dataproto.ApplicationResp) (handlers.ApplicationHandler, error) {
+
+	switch vendor {
+	case enumor.TCloud:
+		req, err := parseReqFromApplicationContent[proto.SGRuleCreateReq[cloudserver.TCloudSecurityGroupRule]](
+			application.Content)
+		if err != nil {
+			return nil, err
+		}
+		return tcloudsghandler.NewApplicationOfCreateTCloudSGRule(opt, req), nil
+	default:
+		return nil, fmt.Errorf("not support handler of create %s security group rule", vendor)
+	}
+}
+
 func (a *applicationSvc) getHandlerByApplication(cts *rest.Contexts, application *dataproto.ApplicationResp) (
 	handlers.ApplicationHandler, error) {
 
@@ -307,6 +356,14 @@ func (a *applicationSvc) getHandlerByApplication(cts *rest.Contexts, application
 		return a.getHandlerOfCreateDisk(opt, vendor, application)
 	case enumor.CreateLoadBalancer:
 		return a.getHandlerOfCreateLoadBalancer(opt, vendor, application)
+	case enumor.CreateSecurityGroupRule:
+		return a.getHandlerOfCreateSGRule(opt, vendor, application)
+	case enumor.TransferResource:
+		req, err := parseReqFromApplicationContent[proto.ResourceTransferReq](application.Content)
+		if err != nil {
+			return nil, err
+		}
+		return transferres.NewApplicationOfTransferResource(opt, req), nil
 	case enumor.CreateMainAccount:
 		req, err := parseReqFromApplicationContent[proto.MainAccountCreateReq](application.Content)
 		if err != nil {
@@ -366,6 +423,16 @@ func (a *applicationSvc) deliver(cts *rest.Contexts, application *dataproto.Appl
 		return
 	}
 
+	// 审批期间业务配额可能被调低或其他单据已交付，交付前扣除本单据的预留后重新校验主机配额
+	if application.Type == enumor.CreateCvm {
+		if err = a.checkCvmQuotaOnDeliver(cts, application); err != nil {
+			logs.Errorf("execute application[id=%s] delivery of %s failed, check quota err: %s, rid: %s",
+				application.ID, application.Type, err, cts.Kit.Rid)
+			deliveryDetailStr = fmt.Sprintf(`{"error": "check quota failed, err: %v"}`, err)
+			return
+		}
+	}
+
 	// 执行交付
 	deliverStatus, deliveryDetail, err := handler.Deliver()
 	// Note: 排查需要，这里无论失败还是成功，都记录日志，因为没有异步框架可以记录这些信息
@@ -22,12 +22,24 @@ package application
 import (
 	"fmt"
 
+	proto "hcm/pkg/api/cloud-server/application"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+	"hcm/pkg/tools/slice"
+
+	"github.com/tidwall/gjson"
 )
 
-// CancelApplication ...
+// CancelApplication 撤销单据，审批中的单据撤销ITSM单据，交付失败或部分交付的单据会先清理已创建的资源
 func (a *applicationSvc) CancelApplication(cts *rest.Contexts) (interface{}, error) {
 	applicationID := cts.PathParameter("application_id").String()
 	application, err := a.client.DataService().Global.Application.GetApplication(
@@ -43,17 +55,156 @@ func (a *applicationSvc) CancelApplication(cts *rest.Contexts) (interface{}, err
 		)
 	}
 
-	// 根据SN调用ITSM接口撤销单据
-	err = a.itsmCli.WithdrawTicket(cts.Kit, application.SN, cts.Kit.User)
+	if !isCancelable(application) {
+		return nil, errf.Newf(errf.InvalidParameter, "application in %s status can not be cancelled",
+			application.Status)
+	}
+
+	if application.Status == enumor.Pending {
+		// 根据SN调用ITSM接口撤销单据
+		err = a.itsmCli.WithdrawTicket(cts.Kit, application.SN, cts.Kit.User)
+		if err != nil {
+			return nil, fmt.Errorf("call itsm cancel ticket api failed, err: %v", err)
+		}
+
+		// 更新状态
+		err = a.updateStatusWithDetail(cts, applicationID, enumor.Cancelled, "")
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	}
+
+	// 清理交付失败时已创建的资源，清理失败时单据保持原状态，可以再次撤销
+	deletedCloudIDs, err := a.cleanupDeliveredResource(cts.Kit, application)
 	if err != nil {
-		return nil, fmt.Errorf("call itsm cancel ticket api failed, err: %v", err)
+		logs.Errorf("cleanup delivered resource of application %s failed, err: %v, rid: %s", applicationID, err,
+			cts.Kit.Rid)
+		return nil, err
 	}
 
-	// 更新状态
-	err = a.updateStatusWithDetail(cts, applicationID, enumor.Cancelled, "")
+	detail := make(map[string]interface{})
+	if len(application.DeliveryDetail) != 0 {
+		if err = json.UnmarshalFromString(application.DeliveryDetail, &detail); err != nil {
+			logs.Errorf("unmarshal delivery detail failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
+		}
+	}
+	detail["deleted_cloud_ids"] = deletedCloudIDs
+	detailStr, err := json.MarshalToString(detail)
 	if err != nil {
 		return nil, err
 	}
 
-	return nil, nil
+	if err = a.updateStatusWithDetail(cts, applicationID, enumor.Cancelled, detailStr); err != nil {
+		return nil, err
+	}
+
+	return &proto.ApplicationCancelResult{DeletedCloudIDs: deletedCloudIDs}, nil
+}
+
+// isCancelable 审批中、交付失败及部分交付的单据可以撤销
+func isCancelable(application *dataproto.ApplicationResp) bool {
+	switch application.Status {
+	case enumor.Pending, enumor.DeliverError, enumor.DeliverPartial:
+		return true
+	default:
+		return false
+	}
+}
+
+// cleanupDeliveredResource 删除交付失败时已创建的资源，目前只有主机和硬盘申请会部分创建资源，返回已删除资源的云ID
+func (a *applicationSvc) cleanupDeliveredResource(kt *kit.Kit, application *dataproto.ApplicationResp) (
+	[]string, error) {
+
+	cloudIDs := make([]string, 0)
+	for _, one := range gjson.Get(application.DeliveryDetail, "result.success_cloud_ids").Array() {
+		cloudIDs = append(cloudIDs, one.String())
+	}
+	if len(cloudIDs) == 0 {
+		return cloudIDs, nil
+	}
+
+	vendor := enumor.Vendor(gjson.Get(application.Content, "vendor").String())
+	accountID := gjson.Get(application.Content, "account_id").String()
+
+	switch application.Type {
+	case enumor.CreateCvm:
+		for _, batch := range slice.Split(cloudIDs, constant.BatchOperationMaxLimit) {
+			if err := a.deleteCvmByCloudIDs(kt, vendor, accountID, batch); err != nil {
+				return nil, err
+			}
+		}
+	case enumor.CreateDisk:
+		for _, batch := range slice.Split(cloudIDs, constant.BatchOperationMaxLimit) {
+			if err := a.deleteDiskByCloudIDs(kt, vendor, accountID, batch); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("cleanup resource of %s application is not supported", application.Type)
+	}
+
+	return cloudIDs, nil
+}
+
+func (a *applicationSvc) deleteCvmByCloudIDs(kt *kit.Kit, vendor enumor.Vendor, accountID string,
+	cloudIDs []string) error {
+
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(tools.RuleEqual("vendor", vendor), tools.RuleEqual("account_id", accountID),
+			tools.RuleIn("cloud_id", cloudIDs)),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id"},
+	}
+	result, err := a.client.DataService().Global.Cvm.ListCvm(kt, listReq)
+	if err != nil {
+		return err
+	}
+	// 主机已被删除或尚未同步时无需清理
+	if len(result.Details) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(result.Details))
+	for _, one := range result.Details {
+		ids = append(ids, one.ID)
+	}
+	basicInfoMap, err := a.client.DataService().Global.Cloud.ListResBasicInfo(kt,
+		protocloud.ListResourceBasicInfoReq{ResourceType: enumor.CvmCloudResType, IDs: ids})
+	if err != nil {
+		return err
+	}
+
+	if _, err = a.cvmLgc.BatchDeleteCvm(kt, basicInfoMap); err != nil {
+		logs.Errorf("delete cvm failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+func (a *applicationSvc) deleteDiskByCloudIDs(kt *kit.Kit, vendor enumor.Vendor, accountID string,
+	cloudIDs []string) error {
+
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(tools.RuleEqual("vendor", vendor), tools.RuleEqual("account_id", accountID),
+			tools.RuleIn("cloud_id", cloudIDs)),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id"},
+	}
+	result, err := a.client.DataService().Global.ListDisk(kt, listReq)
+	if err != nil {
+		return err
+	}
+
+	for _, one := range result.Details {
+		if err = a.diskLgc.DeleteDisk(kt, vendor, one.ID); err != nil {
+			logs.Errorf("delete disk failed, err: %v, id: %s, rid: %s", err, one.ID, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
 }
@@ -22,6 +22,8 @@ package application
 import (
 	"fmt"
 
+	logicscvm "hcm/cmd/cloud-server/logics/cvm"
+	"hcm/cmd/cloud-server/logics/quota"
 	"hcm/cmd/cloud-server/service/application/handlers"
 	accounthandler "hcm/cmd/cloud-server/service/application/handlers/account"
 	awscvmhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/aws"
@@ -37,15 +39,20 @@ import (
 	lbtcloud "hcm/cmd/cloud-server/service/application/handlers/load_balancer/tcloud"
 	createmainaccount "hcm/cmd/cloud-server/service/application/handlers/main-account/create-main-account"
 	updatemainaccount "hcm/cmd/cloud-server/service/application/handlers/main-account/update-main-account"
+	tcloudsghandler "hcm/cmd/cloud-server/service/application/handlers/security-group/tcloud"
+	transferres "hcm/cmd/cloud-server/service/application/handlers/transfer-resource"
 	awsvpchandler "hcm/cmd/cloud-server/service/application/handlers/vpc/aws"
 	azurevpchandler "hcm/cmd/cloud-server/service/application/handlers/vpc/azure"
 	gcpvpchandler "hcm/cmd/cloud-server/service/application/handlers/vpc/gcp"
 	huaweivpchandler "hcm/cmd/cloud-server/service/application/handlers/vpc/huawei"
 	tcloudvpchandler "hcm/cmd/cloud-server/service/application/handlers/vpc/tcloud"
+	"hcm/cmd/cloud-server/service/common"
+	cloudserver "hcm/pkg/api/cloud-server"
 	proto "hcm/pkg/api/cloud-server/application"
 	cscvm "hcm/pkg/api/cloud-server/cvm"
 	csdisk "hcm/pkg/api/cloud-server/disk"
 	csvpc "hcm/pkg/api/cloud-server/vpc"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
 	dataproto "hcm/pkg/api/data-service"
 	hclb "hcm/pkg/api/hc-service/load-balancer"
 	"hcm/pkg/criteria/enumor"
@@ -143,10 +150,11 @@ func (a *applicationSvc) create(cts *rest.Contexts, req *proto.CreateCommonReq,
 		)
 	}
 
-	// 主机、硬盘、VPC、负载均衡需要记录业务ID
+	// 主机、硬盘、VPC、负载均衡、安全组规则、资源转移需要记录业务ID
 	var bkBizIDs = make([]int64, 0)
 	if applicationType == enumor.CreateCvm || applicationType == enumor.CreateDisk ||
-		applicationType == enumor.CreateVpc || applicationType == enumor.CreateLoadBalancer {
+		applicationType == enumor.CreateVpc || applicationType == enumor.CreateLoadBalancer ||
+		applicationType == enumor.CreateSecurityGroupRule || applicationType == enumor.TransferResource {
 		bkBizIDs = handler.GetBkBizIDs()
 	}
 
@@ -172,6 +180,24 @@ func (a *applicationSvc) create(cts *rest.Contexts, req *proto.CreateCommonReq,
 	return result, nil
 }
 
+// applyNameOption 申请单中用于校验命名规则的参数
+type applyNameOption struct {
+	BkBizID  int64  `json:"bk_biz_id"`
+	Name     string `json:"name"`
+	DiskName string `json:"disk_name"`
+}
+
+// checkApplyName 申请单提交时校验资源名称是否符合业务的命名规则，部分云厂商申请硬盘时不支持指定名称，此时不校验
+func (a *applicationSvc) checkApplyName(cts *rest.Contexts, resType enumor.CloudResourceType,
+	opt *applyNameOption) error {
+
+	if len(opt.Name) == 0 {
+		return nil
+	}
+
+	return a.namingLgc.CheckName(cts.Kit, opt.BkBizID, resType, opt.Name)
+}
+
 func parseReqFromRequestBody[T any](cts *rest.Contexts) (*T, error) {
 	req := new(T)
 	if err := cts.DecodeInto(req); err != nil {
@@ -180,6 +206,14 @@ func parseReqFromRequestBody[T any](cts *rest.Contexts) (*T, error) {
 	return req, nil
 }
 
+func parseReqFromBytes[T any](body []byte) (*T, error) {
+	req := new(T)
+	if err := json.Unmarshal(body, req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	return req, nil
+}
+
 // CreateForAddAccount ...
 func (a *applicationSvc) CreateForAddAccount(cts *rest.Contexts) (interface{}, error) {
 	commReq, err := decodeCommonReqAndValidate(cts)
@@ -219,39 +253,76 @@ func (a *applicationSvc) CreateForCreateCvm(cts *rest.Contexts) (interface{}, er
 		return nil, err
 	}
 
+	body, err := cts.RequestBody()
+	if err != nil {
+		logs.Errorf("get request body failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return a.createForCreateCvm(cts, vendor, commReq, body)
+}
+
+// createForCreateCvm 根据云厂商解析创建主机请求并创建申请单
+func (a *applicationSvc) createForCreateCvm(cts *rest.Contexts, vendor enumor.Vendor, commReq *proto.CreateCommonReq,
+	body []byte) (interface{}, error) {
+
+	// 申请单提交时校验业务资源申请白名单及资源配额
+	quotaOpt, err := parseReqFromBytes[quota.CvmApplyOption](body)
+	if err != nil {
+		return nil, err
+	}
+	quotaOpt.Vendor = vendor
+	if err = a.quotaLgc.CheckCvmWhitelist(cts.Kit, quotaOpt); err != nil {
+		return nil, err
+	}
+	// 提交的申请单会预留配额，校验配额到申请单落库期间加锁，避免同业务并发提交互相看不到对方的预留
+	unlock := a.quotaLgc.LockBiz(quotaOpt.BkBizID)
+	defer unlock()
+	if err = a.quotaLgc.CheckCvmQuota(cts.Kit, quotaOpt); err != nil {
+		return nil, err
+	}
+
+	nameOpt, err := parseReqFromBytes[applyNameOption](body)
+	if err != nil {
+		return nil, err
+	}
+	if err = a.checkApplyName(cts, enumor.CvmCloudResType, nameOpt); err != nil {
+		return nil, err
+	}
+
 	opt := a.getHandlerOption(cts)
 
 	switch vendor {
 	case enumor.TCloud:
-		req, err := parseReqFromRequestBody[cscvm.TCloudCvmCreateReq](cts)
+		req, err := parseReqFromBytes[cscvm.TCloudCvmCreateReq](body)
 		if err != nil {
 			return nil, err
 		}
 		handler := tcloudcvmhandler.NewApplicationOfCreateTCloudCvm(opt, req)
 		return a.create(cts, commReq, handler)
 	case enumor.Aws:
-		req, err := parseReqFromRequestBody[cscvm.AwsCvmCreateReq](cts)
+		req, err := parseReqFromBytes[cscvm.AwsCvmCreateReq](body)
 		if err != nil {
 			return nil, err
 		}
 		handler := awscvmhandler.NewApplicationOfCreateAwsCvm(opt, req)
 		return a.create(cts, commReq, handler)
 	case enumor.HuaWei:
-		req, err := parseReqFromRequestBody[cscvm.HuaWeiCvmCreateReq](cts)
+		req, err := parseReqFromBytes[cscvm.HuaWeiCvmCreateReq](body)
 		if err != nil {
 			return nil, err
 		}
 		handler := huaweicvmhandler.NewApplicationOfCreateHuaWeiCvm(opt, req)
 		return a.create(cts, commReq, handler)
 	case enumor.Gcp:
-		req, err := parseReqFromRequestBody[cscvm.GcpCvmCreateReq](cts)
+		req, err := parseReqFromBytes[cscvm.GcpCvmCreateReq](body)
 		if err != nil {
 			return nil, err
 		}
 		handler := gcpcvmhandler.NewApplicationOfCreateGcpCvm(opt, req)
 		return a.create(cts, commReq, handler)
 	case enumor.Azure:
-		req, err := parseReqFromRequestBody[cscvm.AzureCvmCreateReq](cts)
+		req, err := parseReqFromBytes[cscvm.AzureCvmCreateReq](body)
 		if err != nil {
 			return nil, err
 		}
@@ -262,6 +333,74 @@ func (a *applicationSvc) CreateForCreateCvm(cts *rest.Contexts) (interface{}, er
 	return nil, nil
 }
 
+// CreateForCreateCvmByTemplate 基于主机模板创建申请主机的申请单，模板与申请参数合并后走对应云厂商的主机申请流程
+func (a *applicationSvc) CreateForCreateCvmByTemplate(cts *rest.Contexts) (interface{}, error) {
+	templateID := cts.PathParameter("template_id").String()
+	if len(templateID) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "template id is required")
+	}
+
+	req, err := parseReqFromRequestBody[cscvm.CvmTemplateApplyReq](cts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err = a.checkApplyResPermission(cts, meta.Cvm); err != nil {
+		return nil, err
+	}
+
+	tpl, err := logicscvm.GetTemplate(cts.Kit, a.client.DataService(), templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := genCvmCreateReqFromTemplate(tpl, req)
+	if err != nil {
+		logs.Errorf("gen create cvm request from template failed, err: %v, template: %s, rid: %s", err,
+			templateID, cts.Kit.Rid)
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return a.createForCreateCvm(cts, tpl.Vendor, &proto.CreateCommonReq{Remark: req.Remark}, body)
+}
+
+// genCvmCreateReqFromTemplate 以模板的差异化参数为基础，依次覆盖模板的通用参数和申请参数，生成云厂商的主机创建请求
+func genCvmCreateReqFromTemplate(tpl *corecvm.Template, req *cscvm.CvmTemplateApplyReq) ([]byte, error) {
+	merged := make(map[string]interface{})
+	if !tpl.Extension.IsEmpty() {
+		if err := json.UnmarshalFromString(string(tpl.Extension), &merged); err != nil {
+			return nil, fmt.Errorf("unmarshal template extension failed, err: %v", err)
+		}
+	}
+
+	merged["account_id"] = tpl.AccountID
+	merged["region"] = tpl.Region
+	merged["zone"] = tpl.Zone
+	merged["cloud_image_id"] = tpl.CloudImageID
+	merged["instance_type"] = tpl.InstanceType
+	merged["cloud_security_group_ids"] = tpl.CloudSecurityGroupIDs
+	merged["system_disk"] = tpl.SystemDisk
+	merged["data_disk"] = tpl.DataDisk
+	if len(tpl.UserData) != 0 {
+		merged["user_data"] = tpl.UserData
+	}
+
+	merged["bk_biz_id"] = req.BkBizID
+	merged["name"] = req.Name
+	merged["password"] = req.Password
+	merged["confirmed_password"] = req.ConfirmedPassword
+	merged["required_count"] = req.RequiredCount
+	if req.Memo != nil {
+		merged["memo"] = req.Memo
+	}
+
+	return json.Marshal(merged)
+}
+
 // CreateForCreateVpc ...
 func (a *applicationSvc) CreateForCreateVpc(cts *rest.Contexts) (interface{}, error) {
 
@@ -279,6 +418,14 @@ func (a *applicationSvc) CreateForCreateVpc(cts *rest.Contexts) (interface{}, er
 		return nil, err
 	}
 
+	nameOpt := new(applyNameOption)
+	if err = cts.ReDecodeInto(nameOpt); err != nil {
+		return nil, err
+	}
+	if err = a.checkApplyName(cts, enumor.VpcCloudResType, nameOpt); err != nil {
+		return nil, err
+	}
+
 	opt := a.getHandlerOption(cts)
 
 	switch vendor {
@@ -338,6 +485,15 @@ func (a *applicationSvc) CreateForCreateDisk(cts *rest.Contexts) (interface{}, e
 		return nil, err
 	}
 
+	nameOpt := new(applyNameOption)
+	if err = cts.ReDecodeInto(nameOpt); err != nil {
+		return nil, err
+	}
+	nameOpt.Name = nameOpt.DiskName
+	if err = a.checkApplyName(cts, enumor.DiskCloudResType, nameOpt); err != nil {
+		return nil, err
+	}
+
 	opt := a.getHandlerOption(cts)
 
 	switch vendor {
@@ -405,6 +561,9 @@ func (a *applicationSvc) CreateForCreateLB(cts *rest.Contexts) (interface{}, err
 		if err != nil {
 			return nil, err
 		}
+		if err = a.tagLgc.CheckTags(cts.Kit, req.BkBizID, enumor.LoadBalancerCloudResType, req.Tags); err != nil {
+			return nil, err
+		}
 		handler := lbtcloud.NewApplicationOfCreateTCloudLB(opt, req)
 		return a.create(cts, commReq, handler)
 	}
@@ -412,6 +571,63 @@ func (a *applicationSvc) CreateForCreateLB(cts *rest.Contexts) (interface{}, err
 	return nil, nil
 }
 
+// CreateForCreateSGRule 创建新增安全组规则申请单
+func (a *applicationSvc) CreateForCreateSGRule(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.Request.PathParameter("vendor"))
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	commReq, err := decodeCommonReqAndValidate(cts)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := a.checkApplyResPermission(cts, meta.SecurityGroup); err != nil {
+		return nil, err
+	}
+
+	opt := a.getHandlerOption(cts)
+
+	switch vendor {
+	case enumor.TCloud:
+		req, err := parseReqFromRequestBody[proto.SGRuleCreateReq[cloudserver.TCloudSecurityGroupRule]](cts)
+		if err != nil {
+			return nil, err
+		}
+		handler := tcloudsghandler.NewApplicationOfCreateTCloudSGRule(opt, req)
+		return a.create(cts, commReq, handler)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "create security group rule application of %s is not supported",
+			vendor)
+	}
+}
+
+// CreateForTransferResource 创建资源业务间转移申请单，需要同时拥有原业务和目标业务下该类资源的编辑权限
+func (a *applicationSvc) CreateForTransferResource(cts *rest.Contexts) (interface{}, error) {
+	commReq, err := decodeCommonReqAndValidate(cts)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req, err := parseReqFromRequestBody[proto.ResourceTransferReq](cts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err = common.AuthorizeBizTransfer(cts.Kit, a.authorizer, req.ResType, req.BkBizID, req.TargetBkBizID)
+	if err != nil {
+		return nil, err
+	}
+
+	handler := transferres.NewApplicationOfTransferResource(a.getHandlerOption(cts), req)
+	return a.create(cts, commReq, handler)
+}
+
 // CreateForCreateMainAccount ...
 func (a *applicationSvc) CreateForCreateMainAccount(cts *rest.Contexts) (interface{}, error) {
 	req, err := parseReqFromRequestBody[proto.MainAccountCreateReq](cts)
@@ -0,0 +1,109 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package application
+
+import (
+	"reflect"
+	"testing"
+
+	cscvm "hcm/pkg/api/cloud-server/cvm"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/json"
+)
+
+func TestGenCvmCreateReqFromTemplate(t *testing.T) {
+	tpl := &corecvm.Template{
+		ID:                    "00000001",
+		Vendor:                enumor.TCloud,
+		AccountID:             "account-1",
+		Region:                "ap-guangzhou",
+		Zone:                  "ap-guangzhou-3",
+		CloudImageID:          "img-1",
+		InstanceType:          "S5.MEDIUM2",
+		CloudSecurityGroupIDs: []string{"sg-1"},
+		SystemDisk:            `{"disk_type":"CLOUD_SSD","disk_size_gb":50}`,
+		DataDisk:              `[{"disk_type":"CLOUD_SSD","disk_size_gb":100,"disk_count":1}]`,
+		// 模板通用参数应覆盖差异化参数中的同名字段
+		Extension: `{"cloud_vpc_id":"vpc-1","region":"ap-shanghai","name":"tpl-name"}`,
+	}
+	req := &cscvm.CvmTemplateApplyReq{
+		BkBizID:           2,
+		Name:              "cvm",
+		Password:          "Passw0rd!",
+		ConfirmedPassword: "Passw0rd!",
+		RequiredCount:     3,
+		Memo:              converter.ValToPtr("memo"),
+	}
+
+	body, err := genCvmCreateReqFromTemplate(tpl, req)
+	if err != nil {
+		t.Fatalf("gen cvm create req from template failed, err: %v", err)
+	}
+
+	merged := make(map[string]interface{})
+	if err = json.Unmarshal(body, &merged); err != nil {
+		t.Fatalf("unmarshal merged req failed, err: %v", err)
+	}
+
+	expects := map[string]interface{}{
+		"cloud_vpc_id":             "vpc-1",
+		"account_id":               "account-1",
+		"region":                   "ap-guangzhou",
+		"zone":                     "ap-guangzhou-3",
+		"cloud_image_id":           "img-1",
+		"instance_type":            "S5.MEDIUM2",
+		"cloud_security_group_ids": []interface{}{"sg-1"},
+		"system_disk":              map[string]interface{}{"disk_type": "CLOUD_SSD", "disk_size_gb": float64(50)},
+		"bk_biz_id":                float64(2),
+		"name":                     "cvm",
+		"password":                 "Passw0rd!",
+		"confirmed_password":       "Passw0rd!",
+		"required_count":           float64(3),
+		"memo":                     "memo",
+	}
+	for key, expect := range expects {
+		if !reflect.DeepEqual(merged[key], expect) {
+			t.Errorf("field %s should be %v, but got %v", key, expect, merged[key])
+		}
+	}
+
+	if _, exists := merged["user_data"]; exists {
+		t.Errorf("empty user data should not be set")
+	}
+
+	createReq := new(cscvm.TCloudCvmCreateReq)
+	if err = json.Unmarshal(body, createReq); err != nil {
+		t.Fatalf("merged req should be decoded as tcloud create req, err: %v", err)
+	}
+	if len(createReq.DataDisk) != 1 || createReq.DataDisk[0].DiskSizeGB != 100 {
+		t.Errorf("data disk not merged, got: %+v", createReq.DataDisk)
+	}
+}
+
+func TestGenCvmCreateReqFromTemplateInvalidExtension(t *testing.T) {
+	tpl := &corecvm.Template{Vendor: enumor.TCloud, Extension: "not json"}
+	req := &cscvm.CvmTemplateApplyReq{BkBizID: 1, Name: "cvm", RequiredCount: 1}
+
+	if _, err := genCvmCreateReqFromTemplate(tpl, req); err == nil {
+		t.Errorf("invalid template extension should fail")
+	}
+}
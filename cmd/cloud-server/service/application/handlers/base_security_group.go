@@ -20,6 +20,8 @@
 package handlers
 
 import (
+	"fmt"
+
 	"hcm/pkg/api/core"
 	corecloud "hcm/pkg/api/core/cloud"
 	dataproto "hcm/pkg/api/data-service/cloud"
@@ -54,3 +56,33 @@ func (a *BaseApplicationHandler) ListSecurityGroup(
 
 	return resp.Details, nil
 }
+
+// GetSecurityGroup 根据ID查询安全组
+func (a *BaseApplicationHandler) GetSecurityGroup(vendor enumor.Vendor, id string) (*corecloud.BaseSecurityGroup,
+	error) {
+
+	reqFilter := &filter.Expression{
+		Op: filter.And,
+		Rules: []filter.RuleFactory{
+			filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+			filter.AtomRule{Field: "id", Op: filter.Equal.Factory(), Value: id},
+		},
+	}
+	// 查询
+	resp, err := a.Client.DataService().Global.SecurityGroup.ListSecurityGroup(
+		a.Cts.Kit.Ctx,
+		a.Cts.Kit.Header(),
+		&dataproto.SecurityGroupListReq{
+			Filter: reqFilter,
+			Page:   a.getPageOfOneLimit(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Details) == 0 {
+		return nil, fmt.Errorf("not found %s security group by id(%s)", vendor, id)
+	}
+
+	return &resp.Details[0], nil
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/tidwall/gjson"
 
+	"hcm/cmd/cloud-server/logics/tenant"
 	actioncvm "hcm/cmd/task-server/logics/action/cvm"
 	"hcm/pkg/api/core"
 	coreasync "hcm/pkg/api/core/async"
@@ -47,10 +48,11 @@ func TimingHandleDeliverApplication(cliSet *client.ClientSet, interval time.Dura
 	for {
 		time.Sleep(2 * time.Second)
 
-		kt := core.NewBackendKit()
-		if err := WaitAndHandleDeliverCvm(kt, cliSet.DataService(), cliSet.TaskServer()); err != nil {
-			logs.Errorf("WaitAndHandleDeliverCvm err: %v, rid: %s", err, kt.Rid)
-		}
+		_ = tenant.ForEach(core.NewBackendKit(), cliSet.DataService(), func(kt *kit.Kit) {
+			if err := WaitAndHandleDeliverCvm(kt, cliSet.DataService(), cliSet.TaskServer()); err != nil {
+				logs.Errorf("WaitAndHandleDeliverCvm err: %v, tenant: %s, rid: %s", err, kt.TenantID, kt.Rid)
+			}
+		})
 	}
 }
 
@@ -104,8 +106,10 @@ func handleDeliverCvm(kt *kit.Kit, dsCli *dataservice.Client, tsCli *taskserver.
 	app := flowAppMap[flowID]
 
 	state := enumor.Completed
+	// 保留flow_id，交付失败后可以从失败的任务重试
 	detail := map[string]interface{}{
-		"result": result,
+		"flow_id": flowID,
+		"result":  result,
 	}
 	if len(result.SuccessCloudIDs) != 0 {
 		req := &core.ListReq{
@@ -126,17 +130,22 @@ func handleDeliverCvm(kt *kit.Kit, dsCli *dataservice.Client, tsCli *taskserver.
 		}
 
 		task := listResult.Details[0]
-		assignResult := new(actioncvm.AssignCvmResult)
-		if err = json.UnmarshalFromString(string(task.Result), &assignResult); err != nil {
-			logs.Errorf("unmarshal task result failed, err: %v, result: %s, rid: %s", err, assignResult, kt.Rid)
-			return err
-		}
-
-		detail["cvm_ids"] = assignResult.IDs
 		requiredCount := gjson.Get(app.Content, "required_count").Int()
 		if len(result.SuccessCloudIDs) != int(requiredCount) {
 			state = enumor.DeliverPartial
 		}
+
+		// 创建主机的任务失败时分配任务不会执行，此时只有部分主机被创建
+		if task.State == enumor.TaskSuccess {
+			assignResult := new(actioncvm.AssignCvmResult)
+			if err = json.UnmarshalFromString(string(task.Result), &assignResult); err != nil {
+				logs.Errorf("unmarshal task result failed, err: %v, result: %s, rid: %s", err, assignResult, kt.Rid)
+				return err
+			}
+			detail["cvm_ids"] = assignResult.IDs
+		} else {
+			state = enumor.DeliverPartial
+		}
 	} else {
 		state = enumor.DeliverError
 	}
@@ -204,16 +213,21 @@ func queryAndParseEndStateFlowByFlowID(kt *kit.Kit, cli *taskserver.Client, flow
 
 	flowResultMap := make(map[string]*hccvm.BatchCreateResult)
 	for _, task := range tasks {
+		if _, exist := flowResultMap[task.FlowID]; !exist {
+			flowResultMap[task.FlowID] = new(hccvm.BatchCreateResult)
+		}
+
+		// 执行失败的任务可能没有结果
+		if len(task.Result) == 0 {
+			continue
+		}
+
 		tmp := new(hccvm.BatchCreateResult)
 		if err := json.UnmarshalFromString(string(task.Result), tmp); err != nil {
 			logs.Errorf("unmarshal tasks result failed, err: %v, rid: %s", err, kt.Rid)
 			return nil, err
 		}
 
-		if _, exist := flowResultMap[task.FlowID]; !exist {
-			flowResultMap[task.FlowID] = new(hccvm.BatchCreateResult)
-		}
-
 		result := flowResultMap[task.FlowID]
 		result.SuccessCloudIDs = append(result.SuccessCloudIDs, tmp.SuccessCloudIDs...)
 		result.FailedCloudIDs = append(result.FailedCloudIDs, tmp.FailedCloudIDs...)
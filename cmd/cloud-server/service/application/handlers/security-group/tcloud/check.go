@@ -0,0 +1,50 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+
+	logicsaccount "hcm/cmd/cloud-server/logics/account"
+)
+
+// CheckReq 检查申请单的数据是否正确
+func (a *ApplicationOfCreateTCloudSGRule) CheckReq() error {
+	if err := a.req.Validate(); err != nil {
+		return err
+	}
+
+	sg, err := a.GetSecurityGroup(a.Vendor(), a.req.SecurityGroupID)
+	if err != nil {
+		return err
+	}
+
+	// 只能申请在业务下的安全组新增规则
+	if sg.BkBizID != a.req.BkBizID {
+		return fmt.Errorf("security group %s is not in biz %d", sg.ID, a.req.BkBizID)
+	}
+
+	if err = logicsaccount.IsResourceAccount(a.Cts.Kit, a.Client.DataService(), sg.AccountID); err != nil {
+		return err
+	}
+
+	a.sg = sg
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+	"strings"
+
+	proto "hcm/pkg/api/cloud-server"
+	cvt "hcm/pkg/tools/converter"
+)
+
+type formItem struct {
+	Label string
+	Value string
+}
+
+// RenderItsmTitle 渲染ITSM单据标题
+func (a *ApplicationOfCreateTCloudSGRule) RenderItsmTitle() (string, error) {
+	return fmt.Sprintf("申请新增[%s]安全组[%s]规则", a.Vendor().GetNameZh(), a.sg.Name), nil
+}
+
+// RenderItsmForm 渲染ITSM表单
+func (a *ApplicationOfCreateTCloudSGRule) RenderItsmForm() (string, error) {
+	formItems := make([]formItem, 0)
+
+	// 基本通用信息
+	baseInfoFormItems, err := a.renderBaseInfo()
+	if err != nil {
+		return "", err
+	}
+	formItems = append(formItems, baseInfoFormItems...)
+
+	// 规则
+	formItems = append(formItems, a.renderRules("出站规则", a.req.EgressRuleSet)...)
+	formItems = append(formItems, a.renderRules("入站规则", a.req.IngressRuleSet)...)
+
+	// 转换为ITSM表单内容数据
+	content := make([]string, 0, len(formItems))
+	for _, i := range formItems {
+		content = append(content, fmt.Sprintf("%s: %s", i.Label, i.Value))
+	}
+	return strings.Join(content, "\n"), nil
+}
+
+func (a *ApplicationOfCreateTCloudSGRule) renderBaseInfo() ([]formItem, error) {
+	formItems := make([]formItem, 0)
+
+	// 业务
+	bizName, err := a.GetBizName(a.req.BkBizID)
+	if err != nil {
+		return formItems, err
+	}
+	formItems = append(formItems, formItem{Label: "业务", Value: bizName})
+
+	// 云账号
+	accountInfo, err := a.GetAccount(a.sg.AccountID)
+	if err != nil {
+		return formItems, err
+	}
+	formItems = append(formItems, formItem{Label: "云账号", Value: accountInfo.Name})
+
+	// 云厂商
+	formItems = append(formItems, formItem{Label: "云厂商", Value: a.Vendor().GetNameZh()})
+
+	// 云地域
+	regionInfo, err := a.GetTCloudRegion(a.sg.Region)
+	if err != nil {
+		return formItems, err
+	}
+	formItems = append(formItems, formItem{Label: "云地域", Value: regionInfo.RegionName})
+
+	// 安全组
+	formItems = append(formItems, formItem{Label: "安全组", Value: fmt.Sprintf("%s(%s)", a.sg.CloudID, a.sg.Name)})
+
+	return formItems, nil
+}
+
+func (a *ApplicationOfCreateTCloudSGRule) renderRules(label string, rules []proto.TCloudSecurityGroupRule) []formItem {
+	formItems := make([]formItem, 0, len(rules))
+	for idx, rule := range rules {
+		port := cvt.PtrToVal(rule.Protocol) + ":" + cvt.PtrToVal(rule.Port)
+		if rule.CloudServiceID != nil {
+			port = *rule.CloudServiceID
+		}
+		if rule.CloudServiceGroupID != nil {
+			port = *rule.CloudServiceGroupID
+		}
+
+		source := ""
+		for _, one := range []*string{rule.IPv4Cidr, rule.IPv6Cidr, rule.CloudAddressID, rule.CloudAddressGroupID,
+			rule.CloudTargetSecurityGroupID} {
+			if one != nil {
+				source = *one
+				break
+			}
+		}
+
+		value := fmt.Sprintf("协议端口[%s], 源地址[%s], 策略[%s]", port, source, rule.Action)
+		if memo := cvt.PtrToVal(rule.Memo); len(memo) != 0 {
+			value += fmt.Sprintf(", 备注[%s]", memo)
+		}
+		formItems = append(formItems, formItem{Label: fmt.Sprintf("%s%d", label, idx+1), Value: value})
+	}
+
+	return formItems
+}
@@ -0,0 +1,48 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package transferres
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CheckReq 检查申请单的数据是否正确，预览转移计划，存在冲突的资源时不允许申请
+func (a *ApplicationOfTransferResource) CheckReq() error {
+	if err := a.req.Validate(); err != nil {
+		return err
+	}
+
+	plan, err := a.Client.DataService().Global.Cloud.PreviewTransferResourceBiz(a.Cts.Kit, a.transferReq())
+	if err != nil {
+		return err
+	}
+
+	if len(plan.Conflicts) != 0 {
+		reasons := make([]string, 0, len(plan.Conflicts))
+		for _, one := range plan.Conflicts {
+			reasons = append(reasons, fmt.Sprintf("%s(%s): %s", one.ResType, one.ID, one.Reason))
+		}
+		return fmt.Errorf("resources can not be transferred, %s", strings.Join(reasons, "; "))
+	}
+
+	a.plan = plan
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package transferres
+
+import (
+	"fmt"
+	"strings"
+)
+
+type formItem struct {
+	Label string
+	Value string
+}
+
+// RenderItsmTitle 渲染ITSM单据标题
+func (a *ApplicationOfTransferResource) RenderItsmTitle() (string, error) {
+	targetBizName, err := a.GetBizName(a.req.TargetBkBizID)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("申请转移[%s]资源到业务[%s]", a.req.ResType, targetBizName), nil
+}
+
+// RenderItsmForm 渲染ITSM表单
+func (a *ApplicationOfTransferResource) RenderItsmForm() (string, error) {
+	formItems := make([]formItem, 0)
+
+	sourceBizName, err := a.GetBizName(a.req.BkBizID)
+	if err != nil {
+		return "", err
+	}
+	formItems = append(formItems, formItem{Label: "原业务", Value: sourceBizName})
+
+	targetBizName, err := a.GetBizName(a.req.TargetBkBizID)
+	if err != nil {
+		return "", err
+	}
+	formItems = append(formItems, formItem{Label: "目标业务", Value: targetBizName})
+
+	formItems = append(formItems, formItem{Label: "资源类型", Value: string(a.req.ResType)})
+	formItems = append(formItems, formItem{Label: "资源ID", Value: strings.Join(a.req.IDs, ",")})
+
+	// 依赖资源，如主机的硬盘、EIP、网络接口
+	depends := make([]string, 0)
+	for _, one := range a.plan.Resources {
+		if len(one.DependOnID) != 0 {
+			depends = append(depends, fmt.Sprintf("%s(%s)", one.ResType, one.ID))
+		}
+	}
+	if len(depends) != 0 {
+		formItems = append(formItems, formItem{Label: "依赖资源", Value: strings.Join(depends, ",")})
+	}
+
+	// 转换为ITSM表单内容数据
+	content := make([]string, 0, len(formItems))
+	for _, i := range formItems {
+		content = append(content, fmt.Sprintf("%s: %s", i.Label, i.Value))
+	}
+	return strings.Join(content, "\n"), nil
+}
@@ -0,0 +1,34 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package transferres
+
+import (
+	"hcm/pkg/criteria/enumor"
+)
+
+// Deliver 执行资源交付，将资源及其依赖资源转移到目标业务
+func (a *ApplicationOfTransferResource) Deliver() (enumor.ApplicationStatus, map[string]interface{}, error) {
+	result, err := a.Client.DataService().Global.Cloud.TransferResourceBiz(a.Cts.Kit, a.transferReq())
+	if err != nil {
+		return enumor.DeliverError, map[string]interface{}{"error": err.Error()}, err
+	}
+
+	return enumor.Completed, map[string]interface{}{"resources": result.Resources}, nil
+}
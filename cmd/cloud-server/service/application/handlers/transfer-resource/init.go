@@ -0,0 +1,56 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package transferres ...
+package transferres
+
+import (
+	"hcm/cmd/cloud-server/service/application/handlers"
+	proto "hcm/pkg/api/cloud-server/application"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+)
+
+// ApplicationOfTransferResource ...
+type ApplicationOfTransferResource struct {
+	handlers.BaseApplicationHandler
+
+	req *proto.ResourceTransferReq
+	// plan 转移计划，校验申请单时预览生成
+	plan *protocloud.AssignResourcePreviewResult
+}
+
+// NewApplicationOfTransferResource ...
+func NewApplicationOfTransferResource(opt *handlers.HandlerOption,
+	req *proto.ResourceTransferReq) *ApplicationOfTransferResource {
+
+	return &ApplicationOfTransferResource{
+		BaseApplicationHandler: handlers.NewBaseApplicationHandler(opt, enumor.TransferResource, ""),
+		req:                    req,
+	}
+}
+
+func (a *ApplicationOfTransferResource) transferReq() *protocloud.TransferResourceBizReq {
+	return &protocloud.TransferResourceBizReq{
+		SourceBkBizID: a.req.BkBizID,
+		TargetBkBizID: a.req.TargetBkBizID,
+		ResType:       a.req.ResType,
+		IDs:           a.req.IDs,
+	}
+}
@@ -0,0 +1,59 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package transferres
+
+import (
+	"hcm/pkg/thirdparty/api-gateway/itsm"
+)
+
+// PrepareReq 预处理请求参数
+func (a *ApplicationOfTransferResource) PrepareReq() error {
+	return nil
+}
+
+// GenerateApplicationContent 获取预处理过的数据，以interface格式
+func (a *ApplicationOfTransferResource) GenerateApplicationContent() interface{} {
+	return a.req
+}
+
+// PrepareReqFromContent 预处理请求参数，转移申请不包含敏感数据，无需处理
+func (a *ApplicationOfTransferResource) PrepareReqFromContent() error {
+	return nil
+}
+
+// GetItsmApprover 获取itsm审批人，资源属于同一账号时需要账号负责人审批
+func (a *ApplicationOfTransferResource) GetItsmApprover(managers []string) []itsm.VariableApprover {
+	accountIDs := a.plan.AccountIDs()
+	if len(accountIDs) == 1 {
+		return a.GetItsmPlatformAndAccountApprover(managers, accountIDs[0])
+	}
+
+	return []itsm.VariableApprover{
+		{
+			Variable:  "platform_manager",
+			Approvers: managers,
+		},
+	}
+}
+
+// GetBkBizIDs 获取当前的业务IDs，包括原业务和目标业务
+func (a *ApplicationOfTransferResource) GetBkBizIDs() []int64 {
+	return []int64{a.req.BkBizID, a.req.TargetBkBizID}
+}
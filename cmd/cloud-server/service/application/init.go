@@ -27,6 +27,13 @@ import (
 	"github.com/tidwall/gjson"
 
 	"hcm/cmd/cloud-server/logics/audit"
+	logicscvm "hcm/cmd/cloud-server/logics/cvm"
+	logicsdisk "hcm/cmd/cloud-server/logics/disk"
+	"hcm/cmd/cloud-server/logics/naming"
+	"hcm/cmd/cloud-server/logics/notification"
+	"hcm/cmd/cloud-server/logics/price"
+	"hcm/cmd/cloud-server/logics/quota"
+	tagpolicy "hcm/cmd/cloud-server/logics/tag-policy"
 	"hcm/cmd/cloud-server/service/application/handlers"
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/pkg/api/core"
@@ -56,19 +63,39 @@ func InitApplicationService(c *capability.Capability, bkHcmUrl string) {
 		esbCli:     c.EsbClient,
 		bkHcmUrl:   bkHcmUrl,
 		cmsiCli:    c.CmsiCli,
+		quotaLgc:   c.Logics.Quota,
+		priceLgc:   c.Logics.Price,
+		namingLgc:  c.Logics.Naming,
+		tagLgc:     c.Logics.TagPolicy,
+		cvmLgc:     c.Logics.Cvm,
+		diskLgc:    c.Logics.Disk,
+		notifyLgc:  notification.NewNotification(c.ApiClient, c.CmsiCli),
 	}
 	h := rest.NewHandler()
 	h.Add("ListApplications", "POST", "/applications/list", svc.ListApplications)
+	h.Add("ListMyApplications", "POST", "/applications/mine/list", svc.ListMyApplications)
+	h.Add("ListMyApprovals", "POST", "/applications/my_approvals/list", svc.ListMyApprovals)
 	h.Add("GetApplication", "GET", "/applications/{application_id}", svc.GetApplication)
 	h.Add("CancelApplication", "PATCH", "/applications/{application_id}/cancel", svc.CancelApplication)
+	h.Add("RetryApplication", "POST", "/applications/{application_id}/retry", svc.RetryApplication)
+	h.Add("ListApplicationSteps", "GET", "/applications/{application_id}/steps", svc.ListApplicationSteps)
 	h.Add("ApproveApplication", "POST", "/applications/approve", svc.ApproveApplication)
 
 	h.Add("CreateForAddAccount", "POST", "/applications/types/add_account", svc.CreateForAddAccount)
 	h.Add("CreateForCreateCvm", "POST", "/vendors/{vendor}/applications/types/create_cvm", svc.CreateForCreateCvm)
+	h.Add("QuoteForCreateCvm", "POST", "/vendors/{vendor}/applications/types/create_cvm/quote",
+		svc.QuoteForCreateCvm)
+	h.Add("CreateForCreateCvmByTemplate", "POST", "/applications/types/create_cvm/cvm_templates/{template_id}",
+		svc.CreateForCreateCvmByTemplate)
 	h.Add("CreateForCreateVpc", "POST", "/vendors/{vendor}/applications/types/create_vpc", svc.CreateForCreateVpc)
 	h.Add("CreateForCreateDisk", "POST", "/vendors/{vendor}/applications/types/create_disk", svc.CreateForCreateDisk)
 	h.Add("CreateForCreateLB", "POST",
 		"/vendors/{vendor}/applications/types/create_load_balancer", svc.CreateForCreateLB)
+	h.Add("CreateForCreateSGRule", "POST",
+		"/vendors/{vendor}/applications/types/create_security_group_rule", svc.CreateForCreateSGRule)
+
+	h.Add("CreateForTransferResource", "POST", "/applications/types/transfer_resource",
+		svc.CreateForTransferResource)
 
 	h.Add("CreateForCreateMainAccount", "POST",
 		"/applications/types/create_main_account", svc.CreateForCreateMainAccount)
@@ -99,6 +126,13 @@ type applicationSvc struct {
 	esbCli     esb.Client
 	bkHcmUrl   string
 	cmsiCli    cmsi.Client
+	quotaLgc   quota.Interface
+	priceLgc   price.Interface
+	namingLgc  naming.Interface
+	tagLgc     tagpolicy.Interface
+	notifyLgc  notification.Interface
+	cvmLgc     logicscvm.Interface
+	diskLgc    logicsdisk.Interface
 }
 
 func (a *applicationSvc) getCallbackUrl() string {
@@ -0,0 +1,210 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package application
+
+import (
+	proto "hcm/pkg/api/cloud-server/application"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/thirdparty/api-gateway/itsm"
+)
+
+// ListMyApplications list the applications submitted by current user with status transitions.
+func (a *applicationSvc) ListMyApplications(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.MyApplicationListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	rules := []filter.RuleFactory{tools.RuleEqual("applicant", cts.Kit.User)}
+	if len(req.Statuses) != 0 {
+		rules = append(rules, tools.RuleIn("status", req.Statuses))
+	}
+	if len(req.Types) != 0 {
+		rules = append(rules, tools.RuleIn("type", req.Types))
+	}
+
+	resp, err := a.client.DataService().Global.Application.ListApplication(cts.Kit,
+		&dataproto.ApplicationListReq{Filter: &filter.Expression{Op: filter.And, Rules: rules}, Page: req.Page})
+	if err != nil {
+		logs.Errorf("list my applications failed, err: %v, user: %s, rid: %s", err, cts.Kit.User, cts.Kit.Rid)
+		return nil, err
+	}
+
+	result := &proto.MyApplicationListResult{Count: resp.Count, Details: make([]proto.MyApplication, 0)}
+	for _, one := range resp.Details {
+		result.Details = append(result.Details, *convMyApplication(cts.Kit, one))
+	}
+
+	return result, nil
+}
+
+func convMyApplication(kt *kit.Kit, app *dataproto.ApplicationResp) *proto.MyApplication {
+	one := &proto.MyApplication{
+		ApplicationResp: *app,
+		NextStatuses:    app.Status.NextStatuses(),
+		Cancelable:      isCancelable(app) && app.Applicant == kt.User,
+		Retryable:       isRetryable(app) && app.Applicant == kt.User,
+	}
+	one.Content = RemoveSenseField(one.Content)
+
+	return one
+}
+
+// ListMyApprovals list the itsm tickets of hcm waiting for current user to approve, the tickets are approved by
+// the ticket approve api with the sn and state id.
+func (a *applicationSvc) ListMyApprovals(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.MyApprovalListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	if !req.Page.Count && req.Page.Limit == 0 {
+		return nil, errf.New(errf.InvalidParameter, "page.limit is required")
+	}
+
+	serviceIDs, err := a.listApprovalServiceIDs(cts.Kit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &proto.MyApprovalListResult{Details: make([]proto.MyApproval, 0)}
+	for _, serviceID := range serviceIDs {
+		getReq := &itsm.GetTicketsByUserReq{
+			ServiceID: serviceID,
+			User:      cts.Kit.User,
+			ViewType:  itsm.MyApproval,
+			Page:      1,
+			PageSize:  1,
+		}
+		if !req.Page.Count {
+			getReq.Page = int64(req.Page.Start)/int64(req.Page.Limit) + 1
+			getReq.PageSize = int64(req.Page.Limit)
+		}
+
+		resp, err := a.itsmCli.GetTicketsByUser(cts.Kit, getReq)
+		if err != nil {
+			logs.Errorf("request itsm get tickets by user failed, err: %v, req: %v, rid: %s", err, getReq,
+				cts.Kit.Rid)
+			return nil, err
+		}
+
+		result.Count += resp.Count
+		if req.Page.Count {
+			continue
+		}
+
+		for _, ticket := range resp.Items {
+			approval := proto.MyApproval{
+				SN:            ticket.Sn,
+				Title:         ticket.Title,
+				ServiceName:   ticket.ServiceName,
+				CurrentStatus: ticket.CurrentStatus,
+				Creator:       ticket.Creator,
+				CreateAt:      ticket.CreateAt,
+			}
+			if len(ticket.CurrentSteps) != 0 {
+				approval.StateID = ticket.CurrentSteps[0].StateID
+			}
+			result.Details = append(result.Details, approval)
+		}
+	}
+
+	if req.Page.Count {
+		result.Details = nil
+		return result, nil
+	}
+
+	if err = a.fillApprovalApplications(cts.Kit, result.Details); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// fillApprovalApplications 根据单据号关联hcm申请单
+func (a *applicationSvc) fillApprovalApplications(kt *kit.Kit, approvals []proto.MyApproval) error {
+	if len(approvals) == 0 {
+		return nil
+	}
+
+	sns := make([]string, 0, len(approvals))
+	for _, one := range approvals {
+		sns = append(sns, one.SN)
+	}
+
+	resp, err := a.client.DataService().Global.Application.ListApplication(kt, &dataproto.ApplicationListReq{
+		Filter: tools.ContainersExpression("sn", sns),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		logs.Errorf("list application by sn failed, err: %v, sns: %v, rid: %s", err, sns, kt.Rid)
+		return err
+	}
+
+	appMap := make(map[string]*dataproto.ApplicationResp, len(resp.Details))
+	for _, one := range resp.Details {
+		appMap[one.SN] = one
+	}
+
+	for i := range approvals {
+		if app, exists := appMap[approvals[i].SN]; exists {
+			approvals[i].Application = convMyApplication(kt, app)
+		}
+	}
+
+	return nil
+}
+
+// listApprovalServiceIDs 获取hcm itsm单据流程所在的服务目录ID列表
+func (a *applicationSvc) listApprovalServiceIDs(kt *kit.Kit) ([]int64, error) {
+	req := &dataproto.ApprovalProcessListReq{
+		Filter: tools.AllExpression(),
+		// itsm 单据流程不可能超过500个，所以就不分页去查了。
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := a.client.DataService().Global.ApprovalProcess.ListApprovalProcesses(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("list approval process failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	serviceIDMap := make(map[int64]struct{})
+	serviceIDs := make([]int64, 0)
+	for _, one := range result.Details {
+		if _, exists := serviceIDMap[one.ServiceID]; !exists {
+			serviceIDMap[one.ServiceID] = struct{}{}
+			serviceIDs = append(serviceIDs, one.ServiceID)
+		}
+	}
+
+	return serviceIDs, nil
+}
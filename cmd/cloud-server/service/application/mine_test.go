@@ -0,0 +1,72 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package application
+
+import (
+	"testing"
+
+	dataproto "hcm/pkg/api/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/json"
+)
+
+func TestConvMyApplication(t *testing.T) {
+	kt := kit.New()
+	kt.User = "alice"
+
+	app := &dataproto.ApplicationResp{
+		ID:        "00000001",
+		Status:    enumor.Pending,
+		Applicant: "alice",
+		Content:   `{"name":"cvm-1","password":"123456"}`,
+	}
+	one := convMyApplication(kt, app)
+	if !one.Cancelable {
+		t.Errorf("pending application should be cancelable by the applicant")
+	}
+	if len(one.NextStatuses) == 0 {
+		t.Errorf("pending application should have next statuses")
+	}
+
+	content := make(map[string]interface{})
+	if err := json.UnmarshalFromString(one.Content, &content); err != nil {
+		t.Fatalf("unmarshal content failed, err: %v", err)
+	}
+	if _, exists := content["password"]; exists || content["name"] != "cvm-1" {
+		t.Errorf("password should be removed from content, but got %s", one.Content)
+	}
+	if app.Content != `{"name":"cvm-1","password":"123456"}` {
+		t.Errorf("origin application should not be changed")
+	}
+
+	// 非申请人不能撤销单据
+	kt.User = "bob"
+	if convMyApplication(kt, app).Cancelable {
+		t.Errorf("application should not be cancelable by others")
+	}
+
+	app.Status = enumor.Completed
+	kt.User = "alice"
+	one = convMyApplication(kt, app)
+	if one.Cancelable || len(one.NextStatuses) != 0 {
+		t.Errorf("completed application should be final, but got %+v", one)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package application
+
+import (
+	"hcm/cmd/cloud-server/logics/price"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// QuoteForCreateCvm estimate the monthly cost of the cvm application before submitted, the request body is the
+// same as creating cvm application.
+func (a *applicationSvc) QuoteForCreateCvm(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.Request.PathParameter("vendor"))
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := a.checkApplyResPermission(cts, meta.Cvm); err != nil {
+		return nil, err
+	}
+
+	body, err := cts.RequestBody()
+	if err != nil {
+		logs.Errorf("get request body failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	opt, err := parseReqFromBytes[price.CvmEstimateOption](body)
+	if err != nil {
+		return nil, err
+	}
+	opt.Vendor = vendor
+
+	result, err := a.priceLgc.EstimateCvm(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("estimate cvm application price failed, err: %v, vendor: %s, rid: %s", err, vendor,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,228 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package application
+
+import (
+	"fmt"
+	"sort"
+
+	proto "hcm/pkg/api/cloud-server/application"
+	"hcm/pkg/api/core"
+	coreasync "hcm/pkg/api/core/async"
+	dataproto "hcm/pkg/api/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/tidwall/gjson"
+)
+
+// RetryApplication 从失败的步骤重试交付失败或部分交付的单据，仅支持通过异步任务交付的单据，已成功的步骤不会重复执行
+func (a *applicationSvc) RetryApplication(cts *rest.Contexts) (interface{}, error) {
+	applicationID := cts.PathParameter("application_id").String()
+
+	req := new(proto.ApplicationRetryReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	application, err := a.client.DataService().Global.Application.GetApplication(
+		cts.Kit.Ctx, cts.Kit.Header(), applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if application.Applicant != cts.Kit.User {
+		return nil, errf.NewFromErr(
+			errf.PermissionDenied, fmt.Errorf("you can not operate other people's application"),
+		)
+	}
+
+	if !isRetryable(application) {
+		return nil, errf.Newf(errf.InvalidParameter, "%s application in %s status can not be retried",
+			application.Type, application.Status)
+	}
+
+	flowID := gjson.Get(application.DeliveryDetail, "flow_id").String()
+	flow, err := a.client.TaskServer().GetFlow(cts.Kit, flowID)
+	if err != nil {
+		logs.Errorf("get flow %s failed, err: %v, rid: %s", flowID, err, cts.Kit.Rid)
+		return nil, err
+	}
+	if flow.State != enumor.FlowFailed {
+		return nil, errf.Newf(errf.InvalidParameter, "delivery flow is %s, only failed flow can be retried",
+			flow.State)
+	}
+
+	tasks, err := a.listFlowTasks(cts.Kit, flowID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 未指定步骤时重试第一个失败的步骤
+	stepID := req.StepID
+	if len(stepID) == 0 {
+		for _, task := range tasks {
+			if task.State == enumor.TaskFailed {
+				stepID = task.ID
+				break
+			}
+		}
+	}
+	if !hasFailedTask(tasks, stepID) {
+		return nil, errf.Newf(errf.InvalidParameter, "failed step %s not found in delivery flow", stepID)
+	}
+
+	if err = a.client.TaskServer().RetryTask(cts.Kit, flowID, stepID); err != nil {
+		logs.Errorf("retry task %s of flow %s failed, err: %v, rid: %s", stepID, flowID, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// 重试后单据重新进入交付中，交付结果由交付任务结束后重新生成
+	detail, err := json.MarshalToString(map[string]interface{}{"flow_id": flowID})
+	if err != nil {
+		return nil, err
+	}
+	if err = a.updateStatusWithDetail(cts, applicationID, enumor.Delivering, detail); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// isRetryable 交付失败或部分交付，且通过异步任务交付的单据可以重试
+func isRetryable(application *dataproto.ApplicationResp) bool {
+	if application.Status != enumor.DeliverError && application.Status != enumor.DeliverPartial {
+		return false
+	}
+
+	return len(gjson.Get(application.DeliveryDetail, "flow_id").String()) != 0
+}
+
+func hasFailedTask(tasks []coreasync.AsyncFlowTask, taskID string) bool {
+	for _, task := range tasks {
+		if task.ID == taskID && task.State == enumor.TaskFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// ListApplicationSteps 查询单据的交付步骤及其状态，步骤状态来自交付的异步任务
+func (a *applicationSvc) ListApplicationSteps(cts *rest.Contexts) (interface{}, error) {
+	applicationID := cts.PathParameter("application_id").String()
+
+	application, err := a.client.DataService().Global.Application.GetApplication(
+		cts.Kit.Ctx, cts.Kit.Header(), applicationID)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if application.Applicant != cts.Kit.User {
+		_, authorized, err := a.authorizer.Authorize(cts.Kit, meta.ResourceAttribute{Basic: &meta.Basic{
+			Type:   meta.Application,
+			Action: meta.Find,
+		}})
+		if err != nil {
+			return nil, err
+		}
+		// 没有单据管理权限的用户只能查询自己的申请单
+		if !authorized {
+			return nil, errf.NewFromErr(errf.PermissionDenied,
+				fmt.Errorf("you can not view other people's application"))
+		}
+	}
+
+	result := &proto.ApplicationStepListResult{Details: make([]proto.ApplicationStep, 0)}
+	// 未通过异步任务交付的单据没有交付步骤
+	flowID := gjson.Get(application.DeliveryDetail, "flow_id").String()
+	if len(flowID) == 0 {
+		return result, nil
+	}
+
+	flow, err := a.client.TaskServer().GetFlow(cts.Kit, flowID)
+	if err != nil {
+		logs.Errorf("get flow %s failed, err: %v, rid: %s", flowID, err, cts.Kit.Rid)
+		return nil, err
+	}
+	result.FlowID = flowID
+	result.FlowState = flow.State
+
+	tasks, err := a.listFlowTasks(cts.Kit, flowID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 任务参数中可能包含主机密码等敏感信息，只返回步骤状态
+	for _, task := range tasks {
+		step := proto.ApplicationStep{
+			ID:         task.ID,
+			ActionID:   task.ActionID,
+			ActionName: task.ActionName,
+			DependOn:   task.DependOn,
+			State:      task.State,
+			CreatedAt:  task.CreatedAt,
+			UpdatedAt:  task.UpdatedAt,
+		}
+		if task.Reason != nil {
+			step.Reason = task.Reason.Message
+		}
+		result.Details = append(result.Details, step)
+	}
+
+	return result, nil
+}
+
+// listFlowTasks list all tasks of flow, sorted by id which is the creation order of tasks.
+func (a *applicationSvc) listFlowTasks(kt *kit.Kit, flowID string) ([]coreasync.AsyncFlowTask, error) {
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("flow_id", flowID),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id", "action_id", "action_name", "depend_on", "state", "reason", "created_at",
+			"updated_at"},
+	}
+	tasks := make([]coreasync.AsyncFlowTask, 0)
+	for {
+		result, err := a.client.TaskServer().ListTask(kt, req)
+		if err != nil {
+			logs.Errorf("list task of flow %s failed, err: %v, rid: %s", flowID, err, kt.Rid)
+			return nil, err
+		}
+
+		tasks = append(tasks, result.Details...)
+
+		if len(result.Details) < int(req.Page.Limit) {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+
+	return tasks, nil
+}
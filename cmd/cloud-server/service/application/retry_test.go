@@ -0,0 +1,98 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package application
+
+import (
+	"testing"
+
+	coreasync "hcm/pkg/api/core/async"
+	dataproto "hcm/pkg/api/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+)
+
+func TestIsRetryable(t *testing.T) {
+	cases := []struct {
+		status enumor.ApplicationStatus
+		detail string
+		expect bool
+	}{
+		{status: enumor.DeliverError, detail: `{"flow_id":"flow-1"}`, expect: true},
+		{status: enumor.DeliverPartial, detail: `{"flow_id":"flow-1"}`, expect: true},
+		// 未通过异步任务交付的单据不能重试
+		{status: enumor.DeliverError, detail: `{"error":"create failed"}`, expect: false},
+		{status: enumor.Delivering, detail: `{"flow_id":"flow-1"}`, expect: false},
+		{status: enumor.Completed, detail: `{"flow_id":"flow-1"}`, expect: false},
+	}
+	for _, c := range cases {
+		app := &dataproto.ApplicationResp{Status: c.status, DeliveryDetail: c.detail}
+		if got := isRetryable(app); got != c.expect {
+			t.Errorf("status %s, detail %s expect retryable %v, but got %v", c.status, c.detail, c.expect, got)
+		}
+	}
+}
+
+func TestHasFailedTask(t *testing.T) {
+	tasks := []coreasync.AsyncFlowTask{
+		{ID: "task-1", State: enumor.TaskSuccess},
+		{ID: "task-2", State: enumor.TaskFailed},
+	}
+	if !hasFailedTask(tasks, "task-2") {
+		t.Errorf("task-2 is failed")
+	}
+	// 已成功的步骤不能重试
+	if hasFailedTask(tasks, "task-1") {
+		t.Errorf("task-1 is not failed")
+	}
+	if hasFailedTask(tasks, "task-3") {
+		t.Errorf("task-3 is not exists")
+	}
+}
+
+func TestIsCancelable(t *testing.T) {
+	for _, status := range []enumor.ApplicationStatus{enumor.Pending, enumor.DeliverError, enumor.DeliverPartial} {
+		if !isCancelable(&dataproto.ApplicationResp{Status: status}) {
+			t.Errorf("application in %s status should be cancelable", status)
+		}
+	}
+	for _, status := range []enumor.ApplicationStatus{enumor.Delivering, enumor.Completed, enumor.Cancelled} {
+		if isCancelable(&dataproto.ApplicationResp{Status: status}) {
+			t.Errorf("application in %s status should not be cancelable", status)
+		}
+	}
+}
+
+func TestCleanupDeliveredResource(t *testing.T) {
+	svc := new(applicationSvc)
+
+	// 没有创建成功的资源时无需清理
+	app := &dataproto.ApplicationResp{Type: enumor.CreateCvm, DeliveryDetail: `{"error":"create failed"}`}
+	cloudIDs, err := svc.cleanupDeliveredResource(kit.New(), app)
+	if err != nil || len(cloudIDs) != 0 {
+		t.Errorf("expect no resource cleaned up, but got %v, err: %v", cloudIDs, err)
+	}
+
+	// 不支持清理资源的单据类型
+	app = &dataproto.ApplicationResp{Type: enumor.CreateVpc,
+		DeliveryDetail: `{"result":{"success_cloud_ids":["vpc-1"]}}`}
+	if _, err = svc.cleanupDeliveredResource(kit.New(), app); err == nil {
+		t.Errorf("cleanup resource of create vpc application should be failed")
+	}
+}
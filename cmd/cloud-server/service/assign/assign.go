@@ -25,6 +25,7 @@ import (
 
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/service/capability"
+	"hcm/cmd/cloud-server/service/common"
 	proto "hcm/pkg/api/cloud-server/assign"
 	"hcm/pkg/api/core"
 	"hcm/pkg/api/data-service/cloud"
@@ -34,6 +35,7 @@ import (
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 	"hcm/pkg/runtime/filter"
@@ -51,8 +53,18 @@ func InitService(c *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("AssignResourceToBiz", http.MethodPost, "/resources/assign/bizs", s.AssignResourceToBiz)
+	h.Add("PreviewAssignResourceToBiz", http.MethodPost, "/resources/assign/bizs/preview",
+		s.PreviewAssignResourceToBiz)
+	h.Add("BatchAssignResourceToBiz", http.MethodPost, "/resources/assign/bizs/batch", s.BatchAssignResourceToBiz)
 
 	h.Load(c.WebService)
+
+	bizH := rest.NewHandler()
+	bizH.Path("/bizs/{bk_biz_id}")
+	bizH.Add("PreviewTransferResourceBiz", http.MethodPost, "/resources/transfer/preview",
+		s.PreviewTransferResourceBiz)
+	bizH.Add("TransferResourceBiz", http.MethodPost, "/resources/transfer", s.TransferResourceBiz)
+	bizH.Load(c.WebService)
 }
 
 type svc struct {
@@ -140,3 +152,138 @@ func (svc *svc) AssignResourceToBiz(cts *rest.Contexts) (interface{}, error) {
 
 	return nil, nil
 }
+
+// PreviewAssignResourceToBiz preview the filtered unassigned resources and their dependent resources to be assigned.
+func (svc *svc) PreviewAssignResourceToBiz(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.BatchAssignResourceToBizReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	plan, err := svc.previewAndAuth(cts.Kit, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// BatchAssignResourceToBiz assign the filtered unassigned resources and their dependent resources to biz.
+func (svc *svc) BatchAssignResourceToBiz(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.BatchAssignResourceToBizReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	plan, err := svc.previewAndAuth(cts.Kit, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(plan.Conflicts) != 0 {
+		return plan, errf.Newf(errf.InvalidParameter, "%d resources can not be assigned to biz(%d)",
+			len(plan.Conflicts), req.BkBizID)
+	}
+
+	assignReq := &cloud.BatchAssignResourceToBizReq{BkBizID: req.BkBizID, ResType: req.ResType, Filter: req.Filter}
+	result, err := svc.client.DataService().Global.Cloud.BatchAssignResourceToBiz(cts.Kit, assignReq)
+	if err != nil {
+		logs.Errorf("batch assign resource to biz failed, err: %v, req: %+v, rid: %s", err, assignReq, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// previewAndAuth 预览分配计划，并校验待分配资源所属账号的分配权限
+func (svc *svc) previewAndAuth(kt *kit.Kit, req *proto.BatchAssignResourceToBizReq) (
+	*cloud.AssignResourcePreviewResult, error) {
+
+	previewReq := &cloud.BatchAssignResourceToBizReq{BkBizID: req.BkBizID, ResType: req.ResType, Filter: req.Filter}
+	plan, err := svc.client.DataService().Global.Cloud.PreviewAssignResourceToBiz(kt, previewReq)
+	if err != nil {
+		logs.Errorf("preview assign resource to biz failed, err: %v, req: %+v, rid: %s", err, previewReq, kt.Rid)
+		return nil, err
+	}
+
+	accountIDs := plan.AccountIDs()
+	if len(accountIDs) == 0 {
+		return plan, nil
+	}
+
+	authRes := make([]meta.ResourceAttribute, 0, len(accountIDs))
+	for _, accountID := range accountIDs {
+		authRes = append(authRes, meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.CloudResource,
+			Action: meta.Assign, ResourceID: accountID}, BizID: req.BkBizID})
+	}
+	if err = svc.authorizer.AuthorizeWithPerm(kt, authRes...); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// PreviewTransferResourceBiz preview the resources to be transferred from url biz to target biz.
+func (svc *svc) PreviewTransferResourceBiz(cts *rest.Contexts) (interface{}, error) {
+	req, err := svc.decodeTransferAndAuth(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := svc.client.DataService().Global.Cloud.PreviewTransferResourceBiz(cts.Kit, req)
+	if err != nil {
+		logs.Errorf("preview transfer resource biz failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// TransferResourceBiz transfer resources and its dependent resource from url biz to target biz directly,
+// use transfer_resource application if approval is required.
+func (svc *svc) TransferResourceBiz(cts *rest.Contexts) (interface{}, error) {
+	req, err := svc.decodeTransferAndAuth(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := svc.client.DataService().Global.Cloud.TransferResourceBiz(cts.Kit, req)
+	if err != nil {
+		logs.Errorf("transfer resource biz failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return result, err
+	}
+
+	return result, nil
+}
+
+// decodeTransferAndAuth 解析业务间转移请求，并校验原业务和目标业务下的权限
+func (svc *svc) decodeTransferAndAuth(cts *rest.Contexts) (*cloud.TransferResourceBizReq, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(proto.TransferResourceBizReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err = common.AuthorizeBizTransfer(cts.Kit, svc.authorizer, req.ResType, bizID, req.TargetBkBizID); err != nil {
+		return nil, err
+	}
+
+	return &cloud.TransferResourceBizReq{SourceBkBizID: bizID, TargetBkBizID: req.TargetBkBizID,
+		ResType: req.ResType, IDs: req.IDs}, nil
+}
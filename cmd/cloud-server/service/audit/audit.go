@@ -51,12 +51,16 @@ func InitService(c *capability.Capability) {
 
 	h.Add("GetAudit", http.MethodGet, "/audits/{id}", svc.GetAudit)
 	h.Add("ListAudit", http.MethodPost, "/audits/list", svc.ListAudit)
+	h.Add("QueryAudit", http.MethodPost, "/audits/query", svc.QueryAudit)
+	h.Add("ExportAudit", http.MethodPost, "/audits/export", svc.ExportAudit)
 	h.Add("ListAuditAsyncFlow", http.MethodPost, "/audits/async_flow/list", svc.ListAuditAsyncFlow)
 	h.Add("ListAuditAsyncTask", http.MethodPost, "/audits/async_task/list", svc.ListAuditAsyncTask)
 
 	// biz audit apis
 	h.Add("GetBizAudit", http.MethodGet, "/bizs/{bk_biz_id}/audits/{id}", svc.GetBizAudit)
 	h.Add("ListBizAudit", http.MethodPost, "/bizs/{bk_biz_id}/audits/list", svc.ListBizAudit)
+	h.Add("QueryBizAudit", http.MethodPost, "/bizs/{bk_biz_id}/audits/query", svc.QueryBizAudit)
+	h.Add("ExportBizAudit", http.MethodPost, "/bizs/{bk_biz_id}/audits/export", svc.ExportBizAudit)
 	h.Add("ListBizAuditAsyncFlow", http.MethodPost, "/bizs/{bk_biz_id}/audits/async_flow/list",
 		svc.ListBizAuditAsyncFlow)
 	h.Add("ListBizAuditAsyncTask", http.MethodPost, "/bizs/{bk_biz_id}/audits/async_task/list",
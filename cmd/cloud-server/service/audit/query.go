@@ -0,0 +1,244 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	proto "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	coreaudit "hcm/pkg/api/core/audit"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+const (
+	// defaultQueryLimit 游标查询默认每页条数
+	defaultQueryLimit = uint(100)
+	// maxExportRows 单次导出的最大审计记录数，超过时需要缩小查询条件
+	maxExportRows = uint64(50000)
+)
+
+var (
+	// bomHeader 兼容windows excel打开csv文件时中文乱码
+	bomHeader = []byte{0xEF, 0xBB, 0xBF}
+
+	exportHeader = []string{"id", "res_type", "res_id", "cloud_res_id", "res_name", "action", "bk_biz_id", "vendor",
+		"account_id", "operator", "source", "rid", "app_code", "created_at"}
+)
+
+// QueryAudit query audit with compound conditions and cursor pagination.
+func (svc svc) QueryAudit(cts *rest.Contexts) (interface{}, error) {
+	return svc.queryAudit(cts, handler.ListResourceAuthRes)
+}
+
+// QueryBizAudit query biz audit with compound conditions and cursor pagination.
+func (svc svc) QueryBizAudit(cts *rest.Contexts) (interface{}, error) {
+	return svc.queryAudit(cts, handler.ListBizAuthRes)
+}
+
+func (svc svc) queryAudit(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (interface{}, error) {
+	req := new(proto.AuditQueryReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result := &proto.AuditQueryResult[coreaudit.Audit]{Details: make([]coreaudit.Audit, 0)}
+	expr, noPermFlag, err := svc.authQueryExpr(cts, authHandler, &req.AuditQueryCondition)
+	if err != nil {
+		return nil, err
+	}
+	if noPermFlag {
+		return result, nil
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultQueryLimit
+	}
+
+	details, err := svc.listAuditByCursor(cts.Kit, expr, req.Cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Details = details
+	if len(details) != 0 {
+		result.NextCursor = details[len(details)-1].ID
+	}
+	result.HasMore = uint(len(details)) == limit
+
+	return result, nil
+}
+
+// ExportAudit export audit with compound conditions as csv file.
+func (svc svc) ExportAudit(cts *rest.Contexts) (interface{}, error) {
+	return svc.exportAudit(cts, handler.ListResourceAuthRes)
+}
+
+// ExportBizAudit export biz audit with compound conditions as csv file.
+func (svc svc) ExportBizAudit(cts *rest.Contexts) (interface{}, error) {
+	return svc.exportAudit(cts, handler.ListBizAuthRes)
+}
+
+func (svc svc) exportAudit(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (interface{}, error) {
+	req := new(proto.AuditExportReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	expr, noPermFlag, err := svc.authQueryExpr(cts, authHandler, &req.AuditQueryCondition)
+	if err != nil {
+		return nil, err
+	}
+	if noPermFlag {
+		return nil, errf.New(errf.PermissionDenied, "no permission to export audit")
+	}
+
+	countReq := &core.ListReq{Filter: expr, Page: core.NewCountPage()}
+	countResult, err := svc.client.DataService().Global.Audit.ListAudit(cts.Kit.Ctx, cts.Kit.Header(), countReq)
+	if err != nil {
+		logs.Errorf("count audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+	if countResult.Count > maxExportRows {
+		return nil, errf.Newf(errf.InvalidParameter, "audit count %d exceeds export limit %d, please narrow the "+
+			"query conditions", countResult.Count, maxExportRows)
+	}
+
+	return svc.writeExportFile(cts.Kit, expr)
+}
+
+// writeExportFile 按游标分页查询审计记录并写入临时csv文件，文件在下载完成后删除
+func (svc svc) writeExportFile(kt *kit.Kit, expr *filter.Expression) (*proto.AuditExportFile, error) {
+	file, err := os.CreateTemp("", "audit-export-*.csv")
+	if err != nil {
+		logs.Errorf("create audit export file failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+	defer file.Close()
+
+	exportFile := &proto.AuditExportFile{
+		FileName: fmt.Sprintf("audit_%s.csv", time.Now().Format("20060102150405")),
+		FilePath: file.Name(),
+	}
+
+	if err = svc.writeExportRows(kt, file, expr); err != nil {
+		if rmErr := os.Remove(file.Name()); rmErr != nil {
+			logs.Errorf("remove audit export file failed, err: %v, rid: %s", rmErr, kt.Rid)
+		}
+		return nil, err
+	}
+
+	return exportFile, nil
+}
+
+func (svc svc) writeExportRows(kt *kit.Kit, file *os.File, expr *filter.Expression) error {
+	if _, err := file.Write(bomHeader); err != nil {
+		logs.Errorf("write BOM failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(exportHeader); err != nil {
+		return err
+	}
+
+	cursor := uint64(0)
+	for {
+		details, err := svc.listAuditByCursor(kt, expr, cursor, core.DefaultMaxPageLimit)
+		if err != nil {
+			return err
+		}
+
+		for _, one := range details {
+			row := []string{strconv.FormatUint(one.ID, 10), string(one.ResType), one.ResID, one.CloudResID,
+				one.ResName, string(one.Action), strconv.FormatInt(one.BkBizID, 10), string(one.Vendor), one.AccountID,
+				one.Operator, string(one.Source), one.Rid, one.AppCode, one.CreatedAt}
+			if err = writer.Write(row); err != nil {
+				return err
+			}
+		}
+
+		if uint(len(details)) < core.DefaultMaxPageLimit {
+			break
+		}
+		cursor = details[len(details)-1].ID
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// authQueryExpr 将查询条件转换为filter，并合并鉴权条件
+func (svc svc) authQueryExpr(cts *rest.Contexts, authHandler handler.ListAuthResHandler,
+	cond *proto.AuditQueryCondition) (*filter.Expression, bool, error) {
+
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.Audit, Action: meta.Find, Filter: cond.Expression()})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return expr, noPermFlag, nil
+}
+
+// listAuditByCursor 查询 id 小于游标的审计记录，按 id 倒序返回，cursor 为0时从最新的记录开始查询
+func (svc svc) listAuditByCursor(kt *kit.Kit, expr *filter.Expression, cursor uint64, limit uint) (
+	[]coreaudit.Audit, error) {
+
+	if cursor != 0 {
+		var err error
+		expr, err = tools.And(expr, &filter.AtomRule{Field: "id", Op: filter.LessThan.Factory(), Value: cursor})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   &core.BasePage{Limit: limit, Sort: "id", Order: core.Descending},
+	}
+	result, err := svc.client.DataService().Global.Audit.ListAudit(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list audit by cursor failed, err: %v, cursor: %d, rid: %s", err, cursor, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
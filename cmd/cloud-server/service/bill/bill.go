@@ -50,6 +50,20 @@ func InitBillService(c *capability.Capability) {
 	h.Add("ListBills", "POST", "/vendors/{vendor}/bills/list", svc.ListBills)
 	h.Add("ListBillsConfig", "POST", "/bills/config/list", svc.ListBillsConfig)
 
+	// 费用看板
+	h.Add("SummaryBillCost", "POST", "/bills/costs/summary", svc.SummaryBillCost)
+	h.Add("ListBillCostTrend", "POST", "/bills/costs/trend", svc.ListBillCostTrend)
+
+	// 账单预算
+	h.Add("CreateBillBudget", "POST", "/bills/budgets/create", svc.CreateBillBudget)
+	h.Add("UpdateBillBudget", "PATCH", "/bills/budgets/{id}", svc.UpdateBillBudget)
+	h.Add("ListBillBudget", "POST", "/bills/budgets/list", svc.ListBillBudget)
+	h.Add("BatchDeleteBillBudget", "DELETE", "/bills/budgets/batch", svc.BatchDeleteBillBudget)
+
+	// 账单对账
+	h.Add("RunBillReconciliation", "POST", "/bills/reconciliations/run", svc.RunBillReconciliation)
+	h.Add("ListBillReconciliation", "POST", "/bills/reconciliations/list", svc.ListBillReconciliation)
+
 	h.Load(c.WebService)
 }
 
@@ -33,41 +33,46 @@ import (
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
-	"hcm/pkg/serviced"
+	"hcm/pkg/scheduler"
 	"hcm/pkg/tools/retry"
 )
 
-// CloudBillConfigCreate 定时生成云账单配置
-func CloudBillConfigCreate(intervalMin time.Duration, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
-	logs.Infof("account cloud bill config pipeline enable && start, syncIntervalMin: %v", intervalMin)
+// CloudBillConfigCreateJobName is the scheduler job name of cloud bill config create.
+const CloudBillConfigCreateJobName = "cloud_bill_config_create"
 
-	for {
-		time.Sleep(intervalMin)
+// CloudBillConfigCreateJob 定时生成云账单配置
+func CloudBillConfigCreateJob(interval time.Duration, cliSet *client.ClientSet) scheduler.Job {
+	logs.Infof("account cloud bill config pipeline enable, syncIntervalMin: %v", interval)
 
-		if !sd.IsMaster() {
-			continue
-		}
-
-		kt := core.NewBackendKit()
+	return scheduler.Job{
+		Name:     CloudBillConfigCreateJobName,
+		Interval: interval,
+		Run: func(kt *kit.Kit) error {
+			CloudBillConfigCreate(kt, cliSet)
+			return nil
+		},
+	}
+}
 
-		start := time.Now()
-		logs.Infof("account cloud bill config pipeline start, time: %v, rid: %s", start, kt.Rid)
+// CloudBillConfigCreate 生成全部账号的云账单配置
+func CloudBillConfigCreate(kt *kit.Kit, cliSet *client.ClientSet) {
+	start := time.Now()
+	logs.Infof("account cloud bill config pipeline start, time: %v, rid: %s", start, kt.Rid)
 
-		waitGroup := new(sync.WaitGroup)
+	waitGroup := new(sync.WaitGroup)
 
-		vendors := []enumor.Vendor{enumor.Aws}
-		waitGroup.Add(len(vendors))
-		for _, vendor := range vendors {
-			go func(vendor enumor.Vendor) {
-				allAccountBillConfig(kt, cliSet, vendor)
-				waitGroup.Done()
-			}(vendor)
-		}
+	vendors := []enumor.Vendor{enumor.Aws}
+	waitGroup.Add(len(vendors))
+	for _, vendor := range vendors {
+		go func(vendor enumor.Vendor) {
+			allAccountBillConfig(kt, cliSet, vendor)
+			waitGroup.Done()
+		}(vendor)
+	}
 
-		waitGroup.Wait()
+	waitGroup.Wait()
 
-		logs.Infof("cloud resource all sync end, time: %v, rid: %s", start, kt.Rid)
-	}
+	logs.Infof("account cloud bill config pipeline end, time: %v, rid: %s", start, kt.Rid)
 }
 
 // allAccountBillConfig all account bill config.
@@ -0,0 +1,156 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	csbill "hcm/pkg/api/cloud-server/bill"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service"
+	dsbill "hcm/pkg/api/data-service/bill"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	cvt "hcm/pkg/tools/converter"
+)
+
+// CreateBillBudget create bill budget.
+func (b *billSvc) CreateBillBudget(cts *rest.Contexts) (interface{}, error) {
+	req := new(csbill.BudgetCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := b.checkPermission(cts, meta.CostManage, meta.Find); err != nil {
+		return nil, err
+	}
+
+	if req.ScopeType == enumor.BudgetScopeMainAccount {
+		if _, err := getMainAccountVendor(cts.Kit, b.client.DataService(), req.MainAccountID); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.client.DataService().Global.Bill.CreateBillBudget(cts.Kit, req)
+}
+
+// UpdateBillBudget update bill budget, alert state is reset when alert related fields updated.
+func (b *billSvc) UpdateBillBudget(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(csbill.BudgetUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := b.checkPermission(cts, meta.CostManage, meta.Find); err != nil {
+		return nil, err
+	}
+
+	updateReq := &dsbill.BudgetUpdateReq{
+		ID:          id,
+		Name:        req.Name,
+		Currency:    req.Currency,
+		Amount:      req.Amount,
+		Thresholds:  req.Thresholds,
+		NoticeUsers: req.NoticeUsers,
+		Memo:        req.Memo,
+	}
+	if req.AlertRelated() {
+		updateReq.AlertMonth = cvt.ValToPtr(0)
+		updateReq.AlertThreshold = cvt.ValToPtr(int64(0))
+	}
+	if err := b.client.DataService().Global.Bill.UpdateBillBudget(cts.Kit, updateReq); err != nil {
+		logs.Errorf("update bill budget failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ListBillBudget list bill budget.
+func (b *billSvc) ListBillBudget(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := b.checkPermission(cts, meta.CostManage, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return b.client.DataService().Global.Bill.ListBillBudget(cts.Kit, req)
+}
+
+// BatchDeleteBillBudget batch delete bill budget.
+func (b *billSvc) BatchDeleteBillBudget(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if len(req.IDs) == 0 || len(req.IDs) > int(core.DefaultMaxPageLimit) {
+		return nil, errf.Newf(errf.InvalidParameter, "ids count should between 1 and %d",
+			core.DefaultMaxPageLimit)
+	}
+
+	if err := b.checkPermission(cts, meta.CostManage, meta.Find); err != nil {
+		return nil, err
+	}
+
+	delReq := &dataproto.BatchDeleteReq{Filter: tools.ContainersExpression("id", req.IDs)}
+	if err := b.client.DataService().Global.Bill.BatchDeleteBillBudget(cts.Kit, delReq); err != nil {
+		logs.Errorf("delete bill budget failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// getMainAccountVendor get vendor of main account, return error if main account not exists.
+func getMainAccountVendor(kt *kit.Kit, dataCli *dataservice.Client, mainAccountID string) (enumor.Vendor, error) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", mainAccountID),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id", "vendor"},
+	}
+	result, err := dataCli.Global.MainAccount.List(kt, listReq)
+	if err != nil {
+		logs.Errorf("list main account failed, err: %v, id: %s, rid: %s", err, mainAccountID, kt.Rid)
+		return "", err
+	}
+	if len(result.Details) == 0 {
+		return "", errf.Newf(errf.RecordNotFound, "main account %s not found", mainAccountID)
+	}
+	return result.Details[0].Vendor, nil
+}
@@ -0,0 +1,283 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"hcm/cmd/cloud-server/logics/notification"
+	"hcm/cmd/cloud-server/logics/tenant"
+	csbill "hcm/pkg/api/cloud-server/bill"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/bill"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/client"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/serviced"
+	"hcm/pkg/thirdparty/api-gateway/cmsi"
+	cvt "hcm/pkg/tools/converter"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	budgetAlertMailTitle = "【HCM】云账单预算告警：%s"
+	// budgetAlertMailContent 预算名称、预算范围、账期、已使用金额、预算金额、使用比例、触发阈值
+	budgetAlertMailContent = `<p>您关注的云账单预算【%s】费用已超过告警阈值，请及时关注。</p>
+<p>预算范围：%s</p>
+<p>账期：%d-%02d</p>
+<p>本月已产生费用：%s %s</p>
+<p>月度预算金额：%s %s</p>
+<p>预算使用比例：%s%%，已超过告警阈值 %d%%</p>`
+)
+
+// BudgetAlertTiming 定时评估账单预算，当月费用超过预算告警阈值时发送邮件通知，同一账期内每个阈值只告警一次
+func BudgetAlertTiming(interval time.Duration, sd serviced.ServiceDiscover, cliSet *client.ClientSet,
+	cmsiCli cmsi.Client) {
+
+	logs.Infof("bill budget alert enable && start, interval: %v", interval)
+	notifier := notification.NewNotification(cliSet, cmsiCli)
+
+	for {
+		time.Sleep(interval)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		_ = tenant.ForEach(core.NewBackendKit(), cliSet.DataService(), func(kt *kit.Kit) {
+			start := time.Now()
+			logs.Infof("tenant %s bill budget alert start, time: %v, rid: %s", kt.TenantID, start, kt.Rid)
+
+			evaluator := &budgetEvaluator{
+				dataCli:  cliSet.DataService(),
+				cmsiCli:  cmsiCli,
+				notifier: notifier,
+				year:     start.Year(),
+				month:    int(start.Month()),
+				rates:    make(map[enumor.CurrencyCode]*decimal.Decimal),
+			}
+			evaluator.evaluateAll(kt)
+
+			logs.Infof("tenant %s bill budget alert end, cost: %v, rid: %s", kt.TenantID, time.Since(start), kt.Rid)
+		})
+	}
+}
+
+type budgetEvaluator struct {
+	dataCli  *dataservice.Client
+	cmsiCli  cmsi.Client
+	notifier notification.Interface
+	year     int
+	month    int
+	// rates 当前账期的汇率缓存，key为原币种与目标币种的组合
+	rates map[enumor.CurrencyCode]*decimal.Decimal
+}
+
+func (e *budgetEvaluator) evaluateAll(kt *kit.Kit) {
+	listReq := &core.ListReq{
+		Filter: tools.AllExpression(),
+		Page:   core.NewDefaultBasePage(),
+	}
+	for {
+		result, err := e.dataCli.Global.Bill.ListBillBudget(kt, listReq)
+		if err != nil {
+			logs.Errorf("list bill budget failed, err: %v, rid: %s", err, kt.Rid)
+			return
+		}
+
+		for _, budget := range result.Details {
+			if err = e.evaluate(kt, budget); err != nil {
+				logs.Errorf("evaluate bill budget %s(%s) failed, err: %v, rid: %s", budget.Name, budget.ID, err,
+					kt.Rid)
+			}
+		}
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			return
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+}
+
+// evaluate 计算预算当月费用，命中比上次告警更高的阈值时发送告警并记录告警状态
+func (e *budgetEvaluator) evaluate(kt *kit.Kit, budget bill.Budget) error {
+	if !budget.Amount.IsPositive() || len(budget.Thresholds) == 0 {
+		return nil
+	}
+
+	cost, err := e.getBudgetCost(kt, budget)
+	if err != nil {
+		return err
+	}
+
+	period := e.year*100 + e.month
+	percent, hit := budgetAlertThreshold(budget, cost, period)
+	if hit == 0 {
+		return nil
+	}
+
+	mail := &cmsi.CmsiMail{
+		ReceiverUserName: strings.Join(budget.NoticeUsers, ","),
+		Title:            fmt.Sprintf(budgetAlertMailTitle, budget.Name),
+		Content: fmt.Sprintf(budgetAlertMailContent, budget.Name, budgetScopeDesc(budget), e.year, e.month,
+			cost.StringFixed(2), budget.Currency, budget.Amount.StringFixed(2), budget.Currency,
+			percent.StringFixed(2), hit),
+	}
+	if err = e.cmsiCli.SendMail(kt, mail); err != nil {
+		return fmt.Errorf("send budget alert mail failed, err: %v", err)
+	}
+	logs.Infof("bill budget %s(%s) alert sent, period: %d, percent: %s, threshold: %d, rid: %s", budget.Name,
+		budget.ID, period, percent.StringFixed(2), hit, kt.Rid)
+
+	event := &notification.Event{
+		Type:         enumor.NotificationBudgetAlert,
+		RelatedUsers: budget.NoticeUsers,
+		Data: map[string]interface{}{
+			"budget_name": budget.Name,
+			"scope":       budgetScopeDesc(budget),
+			"period":      fmt.Sprintf("%d-%02d", e.year, e.month),
+			"cost":        cost.StringFixed(2),
+			"amount":      budget.Amount.StringFixed(2),
+			"currency":    budget.Currency,
+			"percent":     percent.StringFixed(2),
+			"threshold":   hit,
+		},
+	}
+	if budget.ScopeType == enumor.BudgetScopeBiz {
+		event.BkBizIDs = []int64{budget.BkBizID}
+	}
+	e.notifier.Notify(kt, event)
+
+	updateReq := &dsbill.BudgetUpdateReq{
+		ID:             budget.ID,
+		AlertMonth:     cvt.ValToPtr(period),
+		AlertThreshold: cvt.ValToPtr(hit),
+	}
+	return e.dataCli.Global.Bill.UpdateBillBudget(kt, updateReq)
+}
+
+// budgetAlertThreshold 计算预算使用比例及需要告警的阈值，未命中阈值或该账期内已告警过不低于该阈值时返回0
+func budgetAlertThreshold(budget bill.Budget, cost decimal.Decimal, period int) (decimal.Decimal, int64) {
+	percent := cost.Div(budget.Amount).Mul(decimal.NewFromInt(100))
+	var hit int64
+	for _, threshold := range budget.Thresholds {
+		if percent.GreaterThanOrEqual(decimal.NewFromInt(threshold)) && threshold > hit {
+			hit = threshold
+		}
+	}
+	if hit == 0 {
+		return percent, 0
+	}
+
+	if budget.AlertMonth == period && budget.AlertThreshold >= hit {
+		return percent, 0
+	}
+	return percent, hit
+}
+
+// getBudgetCost 获取预算范围内当月费用，其他币种的费用按当月汇率转换为预算币种
+func (e *budgetEvaluator) getBudgetCost(kt *kit.Kit, budget bill.Budget) (decimal.Decimal, error) {
+	cond := new(csbill.BillCostCondition)
+	switch budget.ScopeType {
+	case enumor.BudgetScopeBiz:
+		cond.BkBizIDs = []int64{budget.BkBizID}
+	case enumor.BudgetScopeMainAccount:
+		vendor, err := getMainAccountVendor(kt, e.dataCli, budget.MainAccountID)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		cond.Vendors = []enumor.Vendor{vendor}
+		cond.MainAccountIDs = []string{budget.MainAccountID}
+	default:
+		return decimal.Zero, fmt.Errorf("unsupported budget scope type: %s", budget.ScopeType)
+	}
+
+	costs, err := sumBillCost(kt, e.dataCli, cond, nil, e.year, e.month)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	total := decimal.Zero
+	for key, cost := range costs {
+		if key.Currency == budget.Currency {
+			total = total.Add(cost)
+			continue
+		}
+		rate, err := e.getExchangeRate(kt, key.Currency, budget.Currency)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		if rate == nil {
+			logs.Warnf("exchange rate from %s to %s of %d-%02d not found, skip cost %s of budget %s, rid: %s",
+				key.Currency, budget.Currency, e.year, e.month, cost, budget.ID, kt.Rid)
+			continue
+		}
+		total = total.Add(cost.Mul(*rate))
+	}
+	return total, nil
+}
+
+// getExchangeRate 获取当月汇率，未配置汇率时返回nil
+func (e *budgetEvaluator) getExchangeRate(kt *kit.Kit, from, to enumor.CurrencyCode) (*decimal.Decimal, error) {
+	key := from + "-" + to
+	if rate, exists := e.rates[key]; exists {
+		return rate, nil
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("from_currency", from),
+			tools.RuleEqual("to_currency", to),
+			tools.RuleEqual("year", e.year),
+			tools.RuleEqual("month", e.month),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := e.dataCli.Global.Bill.ListExchangeRate(kt, listReq)
+	if err != nil {
+		logs.Errorf("list exchange rate failed, err: %v, from: %s, to: %s, rid: %s", err, from, to, kt.Rid)
+		return nil, err
+	}
+
+	var rate *decimal.Decimal
+	if len(result.Details) > 0 {
+		rate = result.Details[0].ExchangeRate
+	}
+	e.rates[key] = rate
+	return rate, nil
+}
+
+func budgetScopeDesc(budget bill.Budget) string {
+	switch budget.ScopeType {
+	case enumor.BudgetScopeBiz:
+		return fmt.Sprintf("业务(%d)", budget.BkBizID)
+	case enumor.BudgetScopeMainAccount:
+		return fmt.Sprintf("二级账号(%s)", budget.MainAccountID)
+	default:
+		return string(budget.ScopeType)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"testing"
+
+	"hcm/pkg/api/core/bill"
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestBudgetAlertThreshold(t *testing.T) {
+	budget := bill.Budget{
+		Amount:     decimal.NewFromInt(1000),
+		Thresholds: []int64{50, 80, 100},
+	}
+
+	cases := []struct {
+		name           string
+		cost           int64
+		alertMonth     int
+		alertThreshold int64
+		expectPercent  string
+		expectHit      int64
+	}{
+		{name: "below all thresholds", cost: 400, expectPercent: "40.00"},
+		{name: "hit the highest reached threshold", cost: 850, expectPercent: "85.00", expectHit: 80},
+		{name: "exactly reach threshold", cost: 1000, expectPercent: "100.00", expectHit: 100},
+		{name: "already alerted in period", cost: 850, alertMonth: 202403, alertThreshold: 80,
+			expectPercent: "85.00"},
+		{name: "higher threshold than alerted", cost: 1200, alertMonth: 202403, alertThreshold: 80,
+			expectPercent: "120.00", expectHit: 100},
+		{name: "alerted in last period", cost: 600, alertMonth: 202402, alertThreshold: 100,
+			expectPercent: "60.00", expectHit: 50},
+	}
+	for _, c := range cases {
+		budget.AlertMonth = c.alertMonth
+		budget.AlertThreshold = c.alertThreshold
+		percent, hit := budgetAlertThreshold(budget, decimal.NewFromInt(c.cost), 202403)
+		if percent.StringFixed(2) != c.expectPercent || hit != c.expectHit {
+			t.Errorf("%s: expect percent %s and hit %d, but got %s and %d", c.name, c.expectPercent,
+				c.expectHit, percent.StringFixed(2), hit)
+		}
+	}
+}
+
+func TestBudgetScopeDesc(t *testing.T) {
+	if desc := budgetScopeDesc(bill.Budget{ScopeType: enumor.BudgetScopeBiz, BkBizID: 3}); desc != "业务(3)" {
+		t.Errorf("unexpected biz budget scope desc: %s", desc)
+	}
+
+	desc := budgetScopeDesc(bill.Budget{ScopeType: enumor.BudgetScopeMainAccount, MainAccountID: "00000001"})
+	if desc != "二级账号(00000001)" {
+		t.Errorf("unexpected main account budget scope desc: %s", desc)
+	}
+}
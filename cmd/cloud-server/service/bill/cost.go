@@ -0,0 +1,267 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"sort"
+
+	logicsaccountgroup "hcm/cmd/cloud-server/logics/account-group"
+	csbill "hcm/pkg/api/cloud-server/bill"
+	dsbill "hcm/pkg/api/data-service/bill"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/shopspring/decimal"
+)
+
+// billCostVendors 未指定云厂商时参与费用汇总的云厂商
+var billCostVendors = []enumor.Vendor{enumor.TCloud, enumor.Aws, enumor.HuaWei, enumor.Gcp, enumor.Azure,
+	enumor.Zenlayer, enumor.Kaopu}
+
+// costKey 费用汇总维度，不参与汇总的维度为零值
+type costKey struct {
+	BkBizID       int64
+	MainAccountID string
+	Vendor        enumor.Vendor
+	Region        string
+	HcProductCode string
+	Currency      enumor.CurrencyCode
+}
+
+// SummaryBillCost summary bill cost of month by dimensions, and compare with last month.
+func (b *billSvc) SummaryBillCost(cts *rest.Contexts) (interface{}, error) {
+	req := new(csbill.BillCostSummaryReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := b.checkPermission(cts, meta.CostManage, meta.Find); err != nil {
+		return nil, err
+	}
+
+	noAccount, err := b.resolveAccountGroupScope(cts.Kit, req.BillCostCondition)
+	if err != nil {
+		return nil, err
+	}
+	if noAccount {
+		return &csbill.BillCostSummaryResult{Details: make([]csbill.BillCostSummary, 0)}, nil
+	}
+
+	dataCli := b.client.DataService()
+	current, err := sumBillCost(cts.Kit, dataCli, req.BillCostCondition, req.GroupBy, req.BillYear, req.BillMonth)
+	if err != nil {
+		return nil, err
+	}
+	lastYear, lastMonth := req.BillYear, req.BillMonth-1
+	if lastMonth == 0 {
+		lastYear, lastMonth = lastYear-1, 12
+	}
+	last, err := sumBillCost(cts.Kit, dataCli, req.BillCostCondition, req.GroupBy, lastYear, lastMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	// 上月有费用而本月没有的维度同样需要返回，以体现费用下降
+	for key := range last {
+		if _, exists := current[key]; !exists {
+			current[key] = decimal.Zero
+		}
+	}
+
+	details := make([]csbill.BillCostSummary, 0, len(current))
+	for key, cost := range current {
+		summary := csbill.BillCostSummary{
+			BkBizID:       key.BkBizID,
+			MainAccountID: key.MainAccountID,
+			Vendor:        key.Vendor,
+			Region:        key.Region,
+			HcProductCode: key.HcProductCode,
+			Currency:      key.Currency,
+			Cost:          cost,
+			LastMonthCost: last[key],
+		}
+		if !summary.LastMonthCost.IsZero() {
+			value, _ := cost.Sub(summary.LastMonthCost).Div(summary.LastMonthCost).Round(4).Float64()
+			summary.MonthOnMonthValue = &value
+		}
+		details = append(details, summary)
+	}
+	sort.Slice(details, func(i, j int) bool {
+		return details[i].Cost.GreaterThan(details[j].Cost)
+	})
+
+	return &csbill.BillCostSummaryResult{Details: details}, nil
+}
+
+// ListBillCostTrend list bill cost of recent months by dimensions.
+func (b *billSvc) ListBillCostTrend(cts *rest.Contexts) (interface{}, error) {
+	req := new(csbill.BillCostTrendReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := b.checkPermission(cts, meta.CostManage, meta.Find); err != nil {
+		return nil, err
+	}
+
+	noAccount, err := b.resolveAccountGroupScope(cts.Kit, req.BillCostCondition)
+	if err != nil {
+		return nil, err
+	}
+	if noAccount {
+		return &csbill.BillCostTrendResult{Details: make([]csbill.BillCostTrend, 0)}, nil
+	}
+
+	dataCli := b.client.DataService()
+	details := make([]csbill.BillCostTrend, 0)
+	year, month := req.BillYear, req.BillMonth
+	for i := 0; i < req.Months; i++ {
+		costs, err := sumBillCost(cts.Kit, dataCli, req.BillCostCondition, req.GroupBy, year, month)
+		if err != nil {
+			return nil, err
+		}
+
+		monthDetails := make([]csbill.BillCostTrend, 0, len(costs))
+		for key, cost := range costs {
+			monthDetails = append(monthDetails, csbill.BillCostTrend{
+				BillYear:      year,
+				BillMonth:     month,
+				BkBizID:       key.BkBizID,
+				MainAccountID: key.MainAccountID,
+				Vendor:        key.Vendor,
+				Region:        key.Region,
+				HcProductCode: key.HcProductCode,
+				Currency:      key.Currency,
+				Cost:          cost,
+			})
+		}
+		sort.Slice(monthDetails, func(i, j int) bool {
+			return monthDetails[i].Cost.GreaterThan(monthDetails[j].Cost)
+		})
+		// 按月份升序返回
+		details = append(monthDetails, details...)
+
+		month--
+		if month == 0 {
+			year, month = year-1, 12
+		}
+	}
+
+	return &csbill.BillCostTrendResult{Details: details}, nil
+}
+
+// resolveAccountGroupScope replace the account group of condition with the main accounts of the group and its sub
+// groups, main accounts are intersected with the given main_account_ids. returns true if no main account matched.
+func (b *billSvc) resolveAccountGroupScope(kt *kit.Kit, cond *csbill.BillCostCondition) (bool, error) {
+	if cond == nil || len(cond.AccountGroupID) == 0 {
+		return false, nil
+	}
+
+	accountIDs, err := logicsaccountgroup.ListGroupAccountIDs(kt, b.client, cond.AccountGroupID,
+		enumor.AccountGroupMemberMainAccount)
+	if err != nil {
+		return false, err
+	}
+
+	if len(cond.MainAccountIDs) > 0 {
+		accountIDs = slice.Intersection(accountIDs, cond.MainAccountIDs)
+	}
+	if len(accountIDs) == 0 {
+		return true, nil
+	}
+	if len(accountIDs) > int(filter.DefaultMaxInLimit) {
+		return false, errf.Newf(errf.InvalidParameter, "account group has more than %d main accounts",
+			filter.DefaultMaxInLimit)
+	}
+
+	cond.MainAccountIDs = accountIDs
+	return false, nil
+}
+
+// sumBillCost sum bill item cost of all vendors in the month, costs of different vendors are merged by currency
+// unless group by vendor.
+func sumBillCost(kt *kit.Kit, dataCli *dataservice.Client, cond *csbill.BillCostCondition,
+	groupBy []enumor.BillCostDimension, year, month int) (map[costKey]decimal.Decimal, error) {
+
+	vendors := billCostVendors
+	rules := []*filter.AtomRule{
+		tools.RuleEqual("bill_year", year),
+		tools.RuleEqual("bill_month", month),
+	}
+	if cond != nil {
+		if len(cond.Vendors) > 0 {
+			vendors = slice.Unique(cond.Vendors)
+		}
+		if len(cond.BkBizIDs) > 0 {
+			rules = append(rules, tools.RuleIn("bk_biz_id", cond.BkBizIDs))
+		}
+		if len(cond.MainAccountIDs) > 0 {
+			rules = append(rules, tools.RuleIn("main_account_id", cond.MainAccountIDs))
+		}
+		if len(cond.HcProductCodes) > 0 {
+			rules = append(rules, tools.RuleIn("hc_product_code", cond.HcProductCodes))
+		}
+	}
+	groupByVendor := slice.IsItemInSlice(groupBy, enumor.BillCostDimVendor)
+
+	costs := make(map[costKey]decimal.Decimal)
+	for _, vendor := range vendors {
+		req := &dsbill.BillItemCostSumReq{
+			ItemCommonOpt: &dsbill.ItemCommonOpt{Vendor: vendor, Year: year, Month: month},
+			Filter:        tools.ExpressionAnd(rules...),
+			GroupBy:       groupBy,
+		}
+		result, err := dataCli.Global.Bill.SumBillItemCost(kt, req)
+		if err != nil {
+			logs.Errorf("sum %s bill item cost of %d-%02d failed, err: %v, rid: %s", vendor, year, month, err,
+				kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			key := costKey{
+				BkBizID:       one.BkBizID,
+				MainAccountID: one.MainAccountID,
+				Region:        one.Region,
+				HcProductCode: one.HcProductCode,
+				Currency:      one.Currency,
+			}
+			if groupByVendor {
+				key.Vendor = one.Vendor
+			}
+			costs[key] = costs[key].Add(one.Cost.Decimal)
+		}
+	}
+	return costs, nil
+}
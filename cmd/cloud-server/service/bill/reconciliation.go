@@ -0,0 +1,76 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	csbill "hcm/pkg/api/cloud-server/bill"
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// RunBillReconciliation run bill reconciliation asynchronously, the result can be queried by list api.
+func (b *billSvc) RunBillReconciliation(cts *rest.Contexts) (interface{}, error) {
+	req := new(csbill.BillReconcileReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := b.checkPermission(cts, meta.CostManage, meta.Find); err != nil {
+		return nil, err
+	}
+
+	if !reconcileRunning.CompareAndSwap(false, true) {
+		return nil, errf.New(errf.Aborted, "bill reconciliation is already running, please try again later")
+	}
+
+	// 对账耗时较长，使用独立的kit异步执行，避免请求结束后上下文被取消
+	kt := core.NewBackendKit()
+	logs.Infof("%s run bill reconciliation, bill month: %d-%02d, reconcile rid: %s, rid: %s", cts.Kit.User,
+		req.BillYear, req.BillMonth, kt.Rid, cts.Kit.Rid)
+	go func() {
+		defer reconcileRunning.Store(false)
+		reconcileBills(kt, b.client.DataService(), req)
+	}()
+
+	return nil, nil
+}
+
+// ListBillReconciliation list bill reconciliation results.
+func (b *billSvc) ListBillReconciliation(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := b.checkPermission(cts, meta.CostManage, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return b.client.DataService().Global.Bill.ListBillReconciliation(cts.Kit, req)
+}
@@ -0,0 +1,389 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"hcm/cmd/cloud-server/logics/tenant"
+	csbill "hcm/pkg/api/cloud-server/bill"
+	"hcm/pkg/api/core"
+	accountset "hcm/pkg/api/core/account-set"
+	"hcm/pkg/api/core/bill"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/client"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/serviced"
+	"hcm/pkg/tools/json"
+	"hcm/pkg/tools/slice"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	huaweiVmResourceType     = "hws.resource.type.vm"
+	huaweiVolumeResourceType = "hws.resource.type.volume"
+	huaweiIpResourceType     = "hws.resource.type.ip"
+)
+
+// reconcileRunning 同一时间只允许一个对账任务执行，避免定时任务与手动触发的任务互相覆盖对账结果
+var reconcileRunning atomic.Bool
+
+// BillReconcileTiming 定时对上月账单进行对账，找出账单中产生费用但在HCM中未纳管或已回收的资源
+func BillReconcileTiming(interval time.Duration, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	logs.Infof("bill reconcile enable && start, interval: %v", interval)
+
+	for {
+		time.Sleep(interval)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+		now := time.Now()
+		lastMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -1, 0)
+		req := &csbill.BillReconcileReq{
+			BillYear:  lastMonth.Year(),
+			BillMonth: int(lastMonth.Month()),
+		}
+		if !reconcileRunning.CompareAndSwap(false, true) {
+			logs.Infof("bill reconcile is already running, skip this round, rid: %s", kt.Rid)
+			continue
+		}
+		_ = tenant.ForEach(kt, cliSet.DataService(), func(kt *kit.Kit) {
+			reconcileBills(kt, cliSet.DataService(), req)
+		})
+		reconcileRunning.Store(false)
+	}
+}
+
+// reconcileBills 执行账单对账，调用方需保证同一时间只有一个对账任务执行
+func reconcileBills(kt *kit.Kit, dataCli *dataservice.Client, req *csbill.BillReconcileReq) {
+	start := time.Now()
+	logs.Infof("bill reconcile start, bill month: %d-%02d, time: %v, rid: %s", req.BillYear, req.BillMonth, start,
+		kt.Rid)
+
+	reconciler := &billReconciler{
+		dataCli: dataCli,
+		year:    req.BillYear,
+		month:   req.BillMonth,
+	}
+	if err := reconciler.reconcileAll(kt, req.Vendors, req.MainAccountIDs); err != nil {
+		logs.Errorf("bill reconcile failed, err: %v, rid: %s", err, kt.Rid)
+	}
+
+	logs.Infof("bill reconcile end, bill month: %d-%02d, cost: %v, rid: %s", req.BillYear, req.BillMonth,
+		time.Since(start), kt.Rid)
+}
+
+type billReconciler struct {
+	dataCli *dataservice.Client
+	year    int
+	month   int
+}
+
+// reconcileKey 对账聚合维度
+type reconcileKey struct {
+	resType  enumor.CloudResourceType
+	cloudID  string
+	currency enumor.CurrencyCode
+}
+
+// reconcileCharge 资源在账期内的费用汇总
+type reconcileCharge struct {
+	productCode string
+	cost        decimal.Decimal
+	amount      decimal.Decimal
+	amountUnit  string
+}
+
+func (r *billReconciler) reconcileAll(kt *kit.Kit, vendors []enumor.Vendor, mainAccountIDs []string) error {
+	if len(vendors) == 0 {
+		vendors = csbill.ReconcileSupportedVendors
+	}
+
+	rules := []*filter.AtomRule{tools.RuleIn("vendor", vendors)}
+	if len(mainAccountIDs) > 0 {
+		rules = append(rules, tools.RuleIn("id", mainAccountIDs))
+	}
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(rules...),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id", "vendor", "parent_account_id"},
+	}
+	for {
+		result, err := r.dataCli.Global.MainAccount.List(kt, listReq)
+		if err != nil {
+			logs.Errorf("list main account failed, err: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+
+		for _, account := range result.Details {
+			if err = r.reconcileMainAccount(kt, account); err != nil {
+				logs.Errorf("reconcile main account %s bill failed, err: %v, rid: %s", account.ID, err, kt.Rid)
+			}
+		}
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			return nil
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+}
+
+// reconcileMainAccount 对账单个二级账号的账单，并覆盖该账号在账期内的对账结果
+func (r *billReconciler) reconcileMainAccount(kt *kit.Kit, account *accountset.BaseMainAccount) error {
+	charges, err := r.collectCharges(kt, account)
+	if err != nil {
+		return err
+	}
+
+	items, err := r.matchInventory(kt, account.Vendor, charges)
+	if err != nil {
+		return err
+	}
+
+	replaceReq := &dsbill.ReconciliationReplaceReq{
+		Vendor:        account.Vendor,
+		RootAccountID: account.ParentAccountID,
+		MainAccountID: account.ID,
+		BillYear:      r.year,
+		BillMonth:     r.month,
+		Items:         items,
+	}
+	if err = r.dataCli.Global.Bill.ReplaceBillReconciliation(kt, replaceReq); err != nil {
+		logs.Errorf("replace bill reconciliation failed, err: %v, main account: %s, rid: %s", err, account.ID,
+			kt.Rid)
+		return err
+	}
+	return nil
+}
+
+// collectCharges 按资源汇总二级账号在账期内的费用
+func (r *billReconciler) collectCharges(kt *kit.Kit, account *accountset.BaseMainAccount) (
+	map[reconcileKey]*reconcileCharge, error) {
+
+	listReq := &dsbill.BillItemListReq{
+		ItemCommonOpt: &dsbill.ItemCommonOpt{Vendor: account.Vendor, Year: r.year, Month: r.month},
+		ListReq: &core.ListReq{
+			Filter: tools.EqualExpression("main_account_id", account.ID),
+			Page:   core.NewDefaultBasePage(),
+		},
+	}
+
+	charges := make(map[reconcileKey]*reconcileCharge)
+	for {
+		result, err := r.dataCli.Global.Bill.ListBillItemRaw(kt, listReq)
+		if err != nil {
+			logs.Errorf("list bill item failed, err: %v, main account: %s, rid: %s", err, account.ID, kt.Rid)
+			return nil, err
+		}
+
+		for _, item := range result.Details {
+			resType, cloudID, err := extractBillResource(account.Vendor, item)
+			if err != nil {
+				logs.Errorf("extract bill item %s resource failed, err: %v, rid: %s", item.ID, err, kt.Rid)
+				return nil, err
+			}
+			if len(cloudID) == 0 {
+				continue
+			}
+
+			key := reconcileKey{resType: resType, cloudID: cloudID, currency: item.Currency}
+			charge, exists := charges[key]
+			if !exists {
+				charge = &reconcileCharge{productCode: item.HcProductCode, amountUnit: item.ResAmountUnit}
+				charges[key] = charge
+			}
+			charge.cost = charge.cost.Add(item.Cost)
+			charge.amount = charge.amount.Add(item.ResAmount)
+		}
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			return charges, nil
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+}
+
+// extractBillResource 从账单明细的原始数据中解析资源类型及资源云ID，无法识别的资源返回空
+func extractBillResource(vendor enumor.Vendor, item *bill.BillItemRaw) (enumor.CloudResourceType, string, error) {
+	if len(item.Extension) == 0 {
+		return "", "", nil
+	}
+
+	switch vendor {
+	case enumor.Aws:
+		ext := new(bill.AwsRawBillItem)
+		if err := json.Unmarshal(item.Extension, ext); err != nil {
+			return "", "", err
+		}
+		switch {
+		case strings.HasPrefix(ext.LineItemResourceId, "i-"):
+			return enumor.CvmCloudResType, ext.LineItemResourceId, nil
+		case strings.HasPrefix(ext.LineItemResourceId, "vol-"):
+			return enumor.DiskCloudResType, ext.LineItemResourceId, nil
+		}
+
+	case enumor.HuaWei:
+		ext := new(bill.HuaweiBillItemExtension)
+		if err := json.Unmarshal(item.Extension, ext); err != nil {
+			return "", "", err
+		}
+		if ext.ResFeeRecordV2 == nil || ext.ResourceType == nil || ext.ResourceId == nil {
+			return "", "", nil
+		}
+		switch *ext.ResourceType {
+		case huaweiVmResourceType:
+			return enumor.CvmCloudResType, *ext.ResourceId, nil
+		case huaweiVolumeResourceType:
+			return enumor.DiskCloudResType, *ext.ResourceId, nil
+		case huaweiIpResourceType:
+			return enumor.EipCloudResType, *ext.ResourceId, nil
+		}
+
+	case enumor.Gcp:
+		ext := new(bill.GcpRawBillItem)
+		if err := json.Unmarshal(item.Extension, ext); err != nil {
+			return "", "", err
+		}
+		if ext.ResourceGlobalName == nil {
+			return "", "", nil
+		}
+		name := *ext.ResourceGlobalName
+		cloudID := name[strings.LastIndex(name, "/")+1:]
+		switch {
+		case strings.Contains(name, "/instances/"):
+			return enumor.CvmCloudResType, cloudID, nil
+		case strings.Contains(name, "/disks/"):
+			return enumor.DiskCloudResType, cloudID, nil
+		}
+
+	default:
+		return "", "", fmt.Errorf("vendor %s does not support bill reconciliation", vendor)
+	}
+
+	return "", "", nil
+}
+
+// matchInventory 将账单中的资源与HCM资源进行比对，返回未纳管或已回收但仍产生费用的资源
+func (r *billReconciler) matchInventory(kt *kit.Kit, vendor enumor.Vendor,
+	charges map[reconcileKey]*reconcileCharge) ([]dsbill.ReconciliationCreateItem, error) {
+
+	cloudIDs := make(map[enumor.CloudResourceType][]string)
+	for key := range charges {
+		cloudIDs[key.resType] = append(cloudIDs[key.resType], key.cloudID)
+	}
+
+	recycleStatus := make(map[enumor.CloudResourceType]map[string]string)
+	for resType, ids := range cloudIDs {
+		statusMap, err := r.listRecycleStatus(kt, vendor, resType, slice.Unique(ids))
+		if err != nil {
+			return nil, err
+		}
+		recycleStatus[resType] = statusMap
+	}
+
+	items := make([]dsbill.ReconciliationCreateItem, 0)
+	for key, charge := range charges {
+		status, exists := recycleStatus[key.resType][key.cloudID]
+		var reason enumor.BillReconcileReason
+		switch {
+		case !exists:
+			reason = enumor.BillReconcileUnmanaged
+		case status == enumor.RecycleStatus:
+			reason = enumor.BillReconcileRecycled
+		default:
+			continue
+		}
+
+		items = append(items, dsbill.ReconciliationCreateItem{
+			ResType:       key.resType,
+			ResCloudID:    key.cloudID,
+			HcProductCode: charge.productCode,
+			Currency:      key.currency,
+			Cost:          charge.cost,
+			ResAmount:     charge.amount,
+			ResAmountUnit: charge.amountUnit,
+			Reason:        reason,
+		})
+	}
+	return items, nil
+}
+
+// listRecycleStatus 查询HCM中已纳管资源的回收状态，key为资源云ID
+func (r *billReconciler) listRecycleStatus(kt *kit.Kit, vendor enumor.Vendor, resType enumor.CloudResourceType,
+	cloudIDs []string) (map[string]string, error) {
+
+	statusMap := make(map[string]string, len(cloudIDs))
+	for _, ids := range slice.Split(cloudIDs, int(filter.DefaultMaxInLimit)) {
+		listReq := &core.ListReq{
+			Filter: tools.ExpressionAnd(tools.RuleEqual("vendor", vendor), tools.RuleIn("cloud_id", ids)),
+			Page:   core.NewDefaultBasePage(),
+			Fields: []string{"cloud_id", "recycle_status"},
+		}
+
+		switch resType {
+		case enumor.CvmCloudResType:
+			result, err := r.dataCli.Global.Cvm.ListCvm(kt, listReq)
+			if err != nil {
+				logs.Errorf("list cvm failed, err: %v, rid: %s", err, kt.Rid)
+				return nil, err
+			}
+			for _, one := range result.Details {
+				statusMap[one.CloudID] = one.RecycleStatus
+			}
+
+		case enumor.DiskCloudResType:
+			result, err := r.dataCli.Global.ListDisk(kt, listReq)
+			if err != nil {
+				logs.Errorf("list disk failed, err: %v, rid: %s", err, kt.Rid)
+				return nil, err
+			}
+			for _, one := range result.Details {
+				statusMap[one.CloudID] = one.RecycleStatus
+			}
+
+		case enumor.EipCloudResType:
+			result, err := r.dataCli.Global.ListEip(kt, listReq)
+			if err != nil {
+				logs.Errorf("list eip failed, err: %v, rid: %s", err, kt.Rid)
+				return nil, err
+			}
+			for _, one := range result.Details {
+				statusMap[one.CloudID] = one.RecycleStatus
+			}
+
+		default:
+			return nil, fmt.Errorf("resource type %s does not support bill reconciliation", resType)
+		}
+	}
+
+	return statusMap, nil
+}
@@ -0,0 +1,69 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"testing"
+
+	"hcm/pkg/api/core/bill"
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestExtractBillResource(t *testing.T) {
+	cases := []struct {
+		name      string
+		vendor    enumor.Vendor
+		extension string
+		resType   enumor.CloudResourceType
+		cloudID   string
+	}{
+		{name: "aws cvm", vendor: enumor.Aws, extension: `{"line_item_resource_id":"i-0abc"}`,
+			resType: enumor.CvmCloudResType, cloudID: "i-0abc"},
+		{name: "aws disk", vendor: enumor.Aws, extension: `{"line_item_resource_id":"vol-0abc"}`,
+			resType: enumor.DiskCloudResType, cloudID: "vol-0abc"},
+		{name: "aws unknown resource", vendor: enumor.Aws, extension: `{"line_item_resource_id":"arn:aws:s3:::b"}`},
+		{name: "huawei eip", vendor: enumor.HuaWei,
+			extension: `{"resource_type":"hws.resource.type.ip","resource_id":"eip-1"}`,
+			resType:   enumor.EipCloudResType, cloudID: "eip-1"},
+		{name: "huawei without resource id", vendor: enumor.HuaWei,
+			extension: `{"resource_type":"hws.resource.type.vm"}`},
+		{name: "gcp cvm", vendor: enumor.Gcp,
+			extension: `{"resource_global_name":"//compute.googleapis.com/projects/p/zones/z/instances/123"}`,
+			resType:   enumor.CvmCloudResType, cloudID: "123"},
+		{name: "empty extension", vendor: enumor.Gcp},
+	}
+
+	for _, c := range cases {
+		item := &bill.BillItemRaw{Extension: []byte(c.extension)}
+		resType, cloudID, err := extractBillResource(c.vendor, item)
+		if err != nil {
+			t.Errorf("%s: extract bill resource failed, err: %v", c.name, err)
+			continue
+		}
+		if resType != c.resType || cloudID != c.cloudID {
+			t.Errorf("%s: expect %s %s, but got %s %s", c.name, c.resType, c.cloudID, resType, cloudID)
+		}
+	}
+
+	// 账单明细中不包含资源ID的云厂商不支持对账
+	if _, _, err := extractBillResource(enumor.TCloud, &bill.BillItemRaw{Extension: []byte(`{}`)}); err == nil {
+		t.Errorf("tcloud should not support bill reconciliation")
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"hcm/pkg/client"
 	"hcm/pkg/cryptography"
 	"hcm/pkg/iam/auth"
+	"hcm/pkg/rest"
 	"hcm/pkg/thirdparty/api-gateway/bkbase"
 	"hcm/pkg/thirdparty/api-gateway/cmsi"
 	"hcm/pkg/thirdparty/api-gateway/itsm"
@@ -37,13 +38,15 @@ import (
 // Capability defines the service's capability
 type Capability struct {
 	WebService *restful.WebService
-	ApiClient  *client.ClientSet
-	Authorizer auth.Authorizer
-	Audit      audit.Interface
-	Cipher     cryptography.Crypto
-	EsbClient  esb.Client
-	Logics     *logics.Logics
-	ItsmCli    itsm.Client
-	BKBaseCli  bkbase.Client
-	CmsiCli    cmsi.Client
+	// WebServices is the web services of all api versions, WebService is the one of rest.Version1.
+	WebServices *rest.WebServices
+	ApiClient   *client.ClientSet
+	Authorizer  auth.Authorizer
+	Audit       audit.Interface
+	Cipher      cryptography.Crypto
+	EsbClient   esb.Client
+	Logics      *logics.Logics
+	ItsmCli     itsm.Client
+	BKBaseCli   bkbase.Client
+	CmsiCli     cmsi.Client
 }
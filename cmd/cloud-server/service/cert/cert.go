@@ -24,6 +24,7 @@ import (
 	"net/http"
 
 	"hcm/cmd/cloud-server/logics/audit"
+	logicssavedfilter "hcm/cmd/cloud-server/logics/saved-filter"
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
@@ -33,9 +34,10 @@ import (
 // InitCertService initialize the cvm service.
 func InitCertService(c *capability.Capability) {
 	svc := &certSvc{
-		client:     c.ApiClient,
-		authorizer: c.Authorizer,
-		audit:      c.Audit,
+		client:         c.ApiClient,
+		authorizer:     c.Authorizer,
+		audit:          c.Audit,
+		savedFilterLgc: logicssavedfilter.NewSavedFilter(c.ApiClient, c.Authorizer),
 	}
 
 	h := rest.NewHandler()
@@ -55,7 +57,8 @@ func InitCertService(c *capability.Capability) {
 }
 
 type certSvc struct {
-	client     *client.ClientSet
-	authorizer auth.Authorizer
-	audit      audit.Interface
+	client         *client.ClientSet
+	authorizer     auth.Authorizer
+	audit          audit.Interface
+	savedFilterLgc logicssavedfilter.Interface
 }
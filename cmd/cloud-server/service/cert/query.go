@@ -23,6 +23,7 @@ package cert
 import (
 	proto "hcm/pkg/api/cloud-server"
 	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/iam/meta"
 	"hcm/pkg/logs"
@@ -50,6 +51,12 @@ func (svc *certSvc) listCert(cts *rest.Contexts, authHandler handler.ListAuthRes
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	filterExpr, err := svc.savedFilterLgc.MergeFilter(cts.Kit, req.SavedFilterID, enumor.CertCloudResType, req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	req.Filter = filterExpr
+
 	// list authorized instances
 	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
 		ResType: meta.Cert, Action: meta.Find, Filter: req.Filter})
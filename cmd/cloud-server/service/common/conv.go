@@ -23,9 +23,11 @@ import (
 	"fmt"
 
 	typecvm "hcm/pkg/adaptor/types/cvm"
+	csproto "hcm/pkg/api/cloud-server"
 	cscvm "hcm/pkg/api/cloud-server/cvm"
 	cloudserver "hcm/pkg/api/cloud-server/disk"
 	csvpc "hcm/pkg/api/cloud-server/vpc"
+	hcsgproto "hcm/pkg/api/hc-service"
 	hcproto "hcm/pkg/api/hc-service/cvm"
 	hcprotodisk "hcm/pkg/api/hc-service/disk"
 	"hcm/pkg/api/hc-service/subnet"
@@ -80,6 +82,7 @@ func ConvTCloudCvmCreateReq(req *cscvm.TCloudCvmCreateReq) *hcproto.TCloudBatchC
 		InternetMaxBandwidthOut: req.InternetMaxBandwidthOut,
 		InternetChargeType:      req.InternetChargeType,
 		BandwidthPackageID:      req.BandwidthPackageID,
+		UserData:                req.UserData,
 	}
 
 	return createReq
@@ -505,3 +508,43 @@ func ConvTCloudVpcCreateReq(req *csvpc.TCloudVpcCreateReq) *hcprotovpc.VpcCreate
 		},
 	}
 }
+
+// ConvTCloudSGRuleCreateReq conv tcloud security group rule create req.
+func ConvTCloudSGRuleCreateReq(accountID string,
+	req *csproto.SecurityGroupRuleCreateReq[csproto.TCloudSecurityGroupRule]) *hcsgproto.TCloudSGRuleCreateReq {
+
+	createReq := &hcsgproto.TCloudSGRuleCreateReq{
+		AccountID: accountID,
+	}
+	if len(req.EgressRuleSet) != 0 {
+		createReq.EgressRuleSet = make([]hcsgproto.TCloudSGRuleCreate, 0, len(req.EgressRuleSet))
+		for _, one := range req.EgressRuleSet {
+			createReq.EgressRuleSet = append(createReq.EgressRuleSet, convTCloudSGRuleCreate(one))
+		}
+	}
+
+	if len(req.IngressRuleSet) != 0 {
+		createReq.IngressRuleSet = make([]hcsgproto.TCloudSGRuleCreate, 0, len(req.IngressRuleSet))
+		for _, one := range req.IngressRuleSet {
+			createReq.IngressRuleSet = append(createReq.IngressRuleSet, convTCloudSGRuleCreate(one))
+		}
+	}
+
+	return createReq
+}
+
+func convTCloudSGRuleCreate(one csproto.TCloudSecurityGroupRule) hcsgproto.TCloudSGRuleCreate {
+	return hcsgproto.TCloudSGRuleCreate{
+		Protocol:                   one.Protocol,
+		Port:                       one.Port,
+		CloudServiceID:             one.CloudServiceID,
+		CloudServiceGroupID:        one.CloudServiceGroupID,
+		IPv4Cidr:                   one.IPv4Cidr,
+		IPv6Cidr:                   one.IPv6Cidr,
+		CloudAddressID:             one.CloudAddressID,
+		CloudAddressGroupID:        one.CloudAddressGroupID,
+		CloudTargetSecurityGroupID: one.CloudTargetSecurityGroupID,
+		Action:                     one.Action,
+		Memo:                       one.Memo,
+	}
+}
@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+)
+
+// transferResMetaTypes 支持业务间转移的资源类型对应的鉴权资源类型
+var transferResMetaTypes = map[enumor.CloudResourceType]meta.ResourceType{
+	enumor.CvmCloudResType:              meta.Cvm,
+	enumor.DiskCloudResType:             meta.Disk,
+	enumor.EipCloudResType:              meta.Eip,
+	enumor.NetworkInterfaceCloudResType: meta.NetworkInterface,
+}
+
+// AuthorizeBizTransfer 校验资源在业务间转移的权限，需要同时拥有原业务和目标业务下该类资源的编辑权限
+func AuthorizeBizTransfer(kt *kit.Kit, authorizer auth.Authorizer, resType enumor.CloudResourceType,
+	sourceBizID, targetBizID int64) error {
+
+	metaType, exists := transferResMetaTypes[resType]
+	if !exists {
+		return errf.Newf(errf.InvalidParameter, "resource type %s cannot be transferred", resType)
+	}
+
+	authRes := []meta.ResourceAttribute{
+		{Basic: &meta.Basic{Type: metaType, Action: meta.Update}, BizID: sourceBizID},
+		{Basic: &meta.Basic{Type: metaType, Action: meta.Update}, BizID: targetBizID},
+	}
+	return authorizer.AuthorizeWithPerm(kt, authRes...)
+}
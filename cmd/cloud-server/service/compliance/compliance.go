@@ -0,0 +1,313 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package compliance security compliance service.
+package compliance
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	cscompliance "hcm/pkg/api/cloud-server/compliance"
+	"hcm/pkg/api/core"
+	corecompliance "hcm/pkg/api/core/compliance"
+	datacompliance "hcm/pkg/api/data-service/compliance"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the security compliance service.
+func InitService(c *capability.Capability) {
+	svc := &complianceSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("DetectCompliance", http.MethodPost, "/compliance/detect", svc.DetectCompliance)
+	h.Add("ListComplianceFinding", http.MethodPost, "/compliance/findings/list", svc.ListComplianceFinding)
+	h.Add("CreateComplianceExemption", http.MethodPost, "/compliance/exemptions/create",
+		svc.CreateComplianceExemption)
+	h.Add("ListComplianceExemption", http.MethodPost, "/compliance/exemptions/list", svc.ListComplianceExemption)
+	h.Add("BatchDeleteComplianceExemption", http.MethodDelete, "/compliance/exemptions/batch",
+		svc.BatchDeleteComplianceExemption)
+
+	// compliance apis in biz
+	h.Add("ListBizComplianceFinding", http.MethodPost, "/bizs/{bk_biz_id}/compliance/findings/list",
+		svc.ListBizComplianceFinding)
+	h.Add("CreateBizComplianceExemption", http.MethodPost, "/bizs/{bk_biz_id}/compliance/exemptions/create",
+		svc.CreateBizComplianceExemption)
+	h.Add("ListBizComplianceExemption", http.MethodPost, "/bizs/{bk_biz_id}/compliance/exemptions/list",
+		svc.ListBizComplianceExemption)
+	h.Add("BatchDeleteBizComplianceExemption", http.MethodDelete, "/bizs/{bk_biz_id}/compliance/exemptions/batch",
+		svc.BatchDeleteBizComplianceExemption)
+
+	h.Load(c.WebService)
+}
+
+type complianceSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// DetectCompliance detect compliance finding immediately with the configured rules.
+func (svc *complianceSvc) DetectCompliance(cts *rest.Contexts) (interface{}, error) {
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.Compliance.Detect(cts.Kit, NewDetectReq(cc.CloudServer().Compliance))
+}
+
+// ListComplianceFinding list compliance finding.
+func (svc *complianceSvc) ListComplianceFinding(cts *rest.Contexts) (interface{}, error) {
+	return svc.listFinding(cts, 0, meta.Find)
+}
+
+// ListBizComplianceFinding list compliance finding of biz.
+func (svc *complianceSvc) ListBizComplianceFinding(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := svc.authorizeBiz(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.listFinding(cts, bizID, "")
+}
+
+func (svc *complianceSvc) listFinding(cts *rest.Contexts, bizID int64, action meta.Action) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(action) != 0 {
+		if err := svc.authorize(cts, action); err != nil {
+			return nil, err
+		}
+	}
+
+	if bizID > 0 {
+		expr, err := tools.And(req.Filter, tools.RuleEqual("bk_biz_id", bizID))
+		if err != nil {
+			return nil, err
+		}
+		req.Filter = expr
+	}
+
+	return svc.client.DataService().Global.Compliance.ListFinding(cts.Kit, req)
+}
+
+// CreateComplianceExemption create compliance exemption for the finding.
+func (svc *complianceSvc) CreateComplianceExemption(cts *rest.Contexts) (interface{}, error) {
+	if err := svc.authorize(cts, meta.Create); err != nil {
+		return nil, err
+	}
+
+	return svc.createExemption(cts, 0)
+}
+
+// CreateBizComplianceExemption create compliance exemption for the finding of biz.
+func (svc *complianceSvc) CreateBizComplianceExemption(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := svc.authorizeBiz(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.createExemption(cts, bizID)
+}
+
+func (svc *complianceSvc) createExemption(cts *rest.Contexts, bizID int64) (interface{}, error) {
+	req := new(cscompliance.ExemptionCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", req.FindingID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	findings, err := svc.client.DataService().Global.Compliance.ListFinding(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list compliance finding failed, err: %v, id: %s, rid: %s", err, req.FindingID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(findings.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "compliance finding %s not found", req.FindingID)
+	}
+	finding := findings.Details[0]
+
+	if bizID > 0 && finding.BkBizID != bizID {
+		return nil, errf.Newf(errf.InvalidParameter, "compliance finding %s not belongs to biz %d", finding.ID,
+			bizID)
+	}
+
+	createReq := &datacompliance.ExemptionCreateReq{
+		Rule:      finding.Rule,
+		ResID:     finding.ResID,
+		BkBizID:   finding.BkBizID,
+		Reason:    req.Reason,
+		ExpiredAt: req.ExpiredAt,
+	}
+	return svc.client.DataService().Global.Compliance.CreateExemption(cts.Kit, createReq)
+}
+
+// ListComplianceExemption list compliance exemption.
+func (svc *complianceSvc) ListComplianceExemption(cts *rest.Contexts) (interface{}, error) {
+	return svc.listExemption(cts, 0, meta.Find)
+}
+
+// ListBizComplianceExemption list compliance exemption of biz.
+func (svc *complianceSvc) ListBizComplianceExemption(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := svc.authorizeBiz(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.listExemption(cts, bizID, "")
+}
+
+func (svc *complianceSvc) listExemption(cts *rest.Contexts, bizID int64, action meta.Action) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(action) != 0 {
+		if err := svc.authorize(cts, action); err != nil {
+			return nil, err
+		}
+	}
+
+	if bizID > 0 {
+		expr, err := tools.And(req.Filter, tools.RuleEqual("bk_biz_id", bizID))
+		if err != nil {
+			return nil, err
+		}
+		req.Filter = expr
+	}
+
+	return svc.client.DataService().Global.Compliance.ListExemption(cts.Kit, req)
+}
+
+// BatchDeleteComplianceExemption batch delete compliance exemption.
+func (svc *complianceSvc) BatchDeleteComplianceExemption(cts *rest.Contexts) (interface{}, error) {
+	if err := svc.authorize(cts, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	return svc.deleteExemption(cts, 0)
+}
+
+// BatchDeleteBizComplianceExemption batch delete compliance exemption of biz.
+func (svc *complianceSvc) BatchDeleteBizComplianceExemption(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := svc.authorizeBiz(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.deleteExemption(cts, bizID)
+}
+
+func (svc *complianceSvc) deleteExemption(cts *rest.Contexts, bizID int64) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if bizID > 0 {
+		listReq := &core.ListReq{
+			Filter: tools.ContainersExpression("id", req.IDs),
+			Page:   core.NewDefaultBasePage(),
+		}
+		exemptions, err := svc.client.DataService().Global.Compliance.ListExemption(cts.Kit, listReq)
+		if err != nil {
+			logs.Errorf("list compliance exemption failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+			return nil, err
+		}
+
+		if err = validateExemptionBiz(exemptions.Details, bizID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := svc.client.DataService().Global.Compliance.BatchDeleteExemption(cts.Kit, req); err != nil {
+		logs.Errorf("delete compliance exemption failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func validateExemptionBiz(exemptions []corecompliance.Exemption, bizID int64) error {
+	for _, one := range exemptions {
+		if one.BkBizID != bizID {
+			return errf.Newf(errf.InvalidParameter, "compliance exemption %s not belongs to biz %d", one.ID, bizID)
+		}
+	}
+
+	return nil
+}
+
+func (svc *complianceSvc) authorize(cts *rest.Contexts, action meta.Action) error {
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Compliance, Action: action}}
+	return svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes)
+}
+
+// authorizeBiz authorize biz access permission and return the biz id.
+func (svc *complianceSvc) authorizeBiz(cts *rest.Contexts) (int64, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return 0, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if bizID <= 0 {
+		return 0, errf.New(errf.InvalidParameter, "biz id is invalid")
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Biz, Action: meta.Access}, BizID: bizID}
+	if err = svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return 0, err
+	}
+
+	return bizID, nil
+}
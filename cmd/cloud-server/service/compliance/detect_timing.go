@@ -0,0 +1,79 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package compliance
+
+import (
+	"time"
+
+	"hcm/cmd/cloud-server/logics/tenant"
+	"hcm/pkg/api/core"
+	datacompliance "hcm/pkg/api/data-service/compliance"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/serviced"
+	"hcm/pkg/tools/slice"
+)
+
+// ComplianceDetectTiming 定时按内置规则检测安全组对任意来源开放管理端口、未加密硬盘等不合规资源
+func ComplianceDetectTiming(interval time.Duration, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	logs.Infof("compliance detect enable && start, interval: %v", interval)
+
+	for {
+		time.Sleep(interval)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		_ = tenant.ForEach(core.NewBackendKit(), cliSet.DataService(), func(kt *kit.Kit) {
+			start := time.Now()
+			result, err := cliSet.DataService().Global.Compliance.Detect(kt, NewDetectReq(cc.CloudServer().Compliance))
+			if err != nil {
+				logs.Errorf("detect tenant %s compliance failed, err: %v, rid: %s", kt.TenantID, err, kt.Rid)
+				return
+			}
+			logs.Infof("detect tenant %s compliance end, counts: %v, cost: %v, rid: %s", kt.TenantID, result.Counts,
+				time.Since(start), kt.Rid)
+		})
+	}
+}
+
+// NewDetectReq build compliance detect request from config.
+func NewDetectReq(conf cc.Compliance) *datacompliance.DetectReq {
+	req := &datacompliance.DetectReq{
+		Rules:      make([]datacompliance.RuleSetting, 0),
+		AdminPorts: conf.AdminPorts,
+	}
+	for rule, severity := range enumor.ComplianceRuleDefaultSeverity {
+		if slice.IsItemInSlice(conf.DisabledRules, string(rule)) {
+			continue
+		}
+
+		if configured, exists := conf.Severity[string(rule)]; exists {
+			severity = enumor.ComplianceSeverity(configured)
+		}
+		req.Rules = append(req.Rules, datacompliance.RuleSetting{Rule: rule, Severity: severity})
+	}
+
+	return req
+}
@@ -0,0 +1,121 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	cscvm "hcm/pkg/api/cloud-server/cvm"
+	"hcm/pkg/api/core"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncCvmToCmdb sync cvms to cmdb hosts in resource view.
+func (svc *cvmSvc) SyncCvmToCmdb(cts *rest.Contexts) (interface{}, error) {
+	return svc.syncCvmToCmdb(cts, handler.ResOperateAuth)
+}
+
+// SyncBizCvmToCmdb sync cvms to cmdb hosts in biz view.
+func (svc *cvmSvc) SyncBizCvmToCmdb(cts *rest.Contexts) (interface{}, error) {
+	return svc.syncCvmToCmdb(cts, handler.BizOperateAuth)
+}
+
+func (svc *cvmSvc) syncCvmToCmdb(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{},
+	error) {
+
+	req := new(cscvm.CvmCmdbSyncReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	ids := slice.Unique(req.IDs)
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.CvmCloudResType,
+		IDs:          ids,
+		Fields:       types.CommonBasicInfoFields,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Cvm,
+		Action: meta.Update, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	syncReq := &dataproto.CvmCmdbSyncReq{CvmIDs: ids, BkModuleID: req.BkModuleID}
+	result, err := svc.client.DataService().Global.Cvm.SyncCvmToCmdbHost(cts.Kit, syncReq)
+	if err != nil {
+		logs.Errorf("sync cvm to cmdb host failed, err: %v, ids: %v, rid: %s", err, ids, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListCvmCmdbHostRel list cvm and cmdb host relations in resource view.
+func (svc *cvmSvc) ListCvmCmdbHostRel(cts *rest.Contexts) (interface{}, error) {
+	return svc.listCvmCmdbHostRel(cts, handler.ListResourceAuthRes)
+}
+
+// ListBizCvmCmdbHostRel list cvm and cmdb host relations in biz view.
+func (svc *cvmSvc) ListBizCvmCmdbHostRel(cts *rest.Contexts) (interface{}, error) {
+	return svc.listCvmCmdbHostRel(cts, handler.ListBizAuthRes)
+}
+
+func (svc *cvmSvc) listCvmCmdbHostRel(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (interface{},
+	error) {
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.Cvm, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return &core.ListResultT[corecvm.CmdbHostRel]{Details: make([]corecvm.CmdbHostRel, 0)}, nil
+	}
+
+	req.Filter = expr
+	return svc.client.DataService().Global.Cvm.ListCmdbHostRel(cts.Kit, req)
+}
@@ -0,0 +1,101 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"time"
+
+	"hcm/cmd/cloud-server/logics/tenant"
+	"hcm/pkg/api/core"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/serviced"
+	"hcm/pkg/tools/slice"
+)
+
+// CmdbHostSyncTiming 定时将已分配业务的主机同步到cmdb对应业务下，并记录主机与cmdb主机的映射关系，无需单独部署采集器即可保持cmdb数据最新
+func CmdbHostSyncTiming(interval time.Duration, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	logs.Infof("cmdb host sync enable && start, interval: %v", interval)
+
+	for {
+		time.Sleep(interval)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		_ = tenant.ForEach(core.NewBackendKit(), cliSet.DataService(), func(kt *kit.Kit) {
+			start := time.Now()
+			logs.Infof("tenant %s cmdb host sync start, rid: %s", kt.TenantID, kt.Rid)
+			syncAllCmdbHosts(kt, cliSet)
+			logs.Infof("tenant %s cmdb host sync end, cost: %v, rid: %s", kt.TenantID, time.Since(start), kt.Rid)
+		})
+	}
+}
+
+func syncAllCmdbHosts(kt *kit.Kit, cliSet *client.ClientSet) {
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(tools.RuleNotEqual("bk_biz_id", constant.UnassignedBiz)),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id"},
+	}
+	total, failed := 0, 0
+	for {
+		result, err := cliSet.DataService().Global.Cvm.ListCvm(kt, listReq)
+		if err != nil {
+			logs.Errorf("list cvm failed, err: %v, rid: %s", err, kt.Rid)
+			return
+		}
+
+		ids := make([]string, 0, len(result.Details))
+		for _, one := range result.Details {
+			ids = append(ids, one.ID)
+		}
+
+		for _, batch := range slice.Split(ids, constant.BatchOperationMaxLimit) {
+			syncReq := &protocloud.CvmCmdbSyncReq{CvmIDs: batch}
+			syncResult, err := cliSet.DataService().Global.Cvm.SyncCvmToCmdbHost(kt, syncReq)
+			if err != nil {
+				logs.Errorf("[%s] sync cvm to cmdb host failed, err: %v, ids: %v, rid: %s", constant.CmdbSyncFailed,
+					err, batch, kt.Rid)
+				failed += len(batch)
+				continue
+			}
+
+			for _, one := range syncResult.Failed {
+				logs.Errorf("[%s] sync cvm to cmdb host failed, id: %s, reason: %s, rid: %s", constant.CmdbSyncFailed,
+					one.ID, one.Reason, kt.Rid)
+			}
+			failed += len(syncResult.Failed)
+		}
+		total += len(ids)
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	logs.Infof("cmdb host sync finished, total: %d, failed: %d, rid: %s", total, failed, kt.Rid)
+}
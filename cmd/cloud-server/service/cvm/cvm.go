@@ -25,8 +25,11 @@ import (
 
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/logics/cvm"
+	deletehook "hcm/cmd/cloud-server/logics/delete-hook"
 	"hcm/cmd/cloud-server/logics/disk"
 	"hcm/cmd/cloud-server/logics/eip"
+	logicsmw "hcm/cmd/cloud-server/logics/maintenance-window"
+	logicssavedfilter "hcm/cmd/cloud-server/logics/saved-filter"
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
@@ -36,18 +39,22 @@ import (
 // InitCvmService initialize the cvm service.
 func InitCvmService(c *capability.Capability) {
 	svc := &cvmSvc{
-		client:     c.ApiClient,
-		authorizer: c.Authorizer,
-		audit:      c.Audit,
-		diskLgc:    c.Logics.Disk,
-		cvmLgc:     c.Logics.Cvm,
-		eipLgc:     c.Logics.Eip,
+		client:         c.ApiClient,
+		authorizer:     c.Authorizer,
+		audit:          c.Audit,
+		diskLgc:        c.Logics.Disk,
+		cvmLgc:         c.Logics.Cvm,
+		eipLgc:         c.Logics.Eip,
+		savedFilterLgc: logicssavedfilter.NewSavedFilter(c.ApiClient, c.Authorizer),
+		deleteHookLgc:  c.Logics.DeleteHook,
+		mwLgc:          c.Logics.MaintenanceWindow,
 	}
 
 	h := rest.NewHandler()
 
 	h.Add("GetCvm", http.MethodGet, "/cvms/{id}", svc.GetCvm)
 	h.Add("ListCvmExt", http.MethodPost, "/cvms/list", svc.ListCvm)
+	h.Add("ExportCvm", http.MethodPost, "/cvms/export", svc.ExportCvm)
 	h.Add("CreateCvm", http.MethodPost, "/cvms/create", svc.CreateCvm)
 	h.Add("InquiryPriceCvm", http.MethodPost, "/cvms/prices/inquiry", svc.InquiryPriceCvm)
 	h.Add("BatchDeleteCvm", http.MethodDelete, "/cvms/batch", svc.BatchDeleteCvm)
@@ -56,6 +63,11 @@ func InitCvmService(c *capability.Capability) {
 	h.Add("BatchStopCvm", http.MethodPost, "/cvms/batch/stop", svc.BatchStopCvm)
 	h.Add("BatchRebootCvm", http.MethodPost, "/cvms/batch/reboot", svc.BatchRebootCvm)
 	h.Add("QueryCvmRelatedRes", http.MethodPost, "/cvms/rel_res/batch", svc.QueryCvmRelatedRes)
+	h.Add("ImportCvmOnboardingPreview", http.MethodPost, "/cvms/onboarding/import/preview",
+		svc.ImportCvmOnboardingPreview)
+	h.Add("SubmitCvmOnboarding", http.MethodPost, "/cvms/onboarding/import/submit", svc.SubmitCvmOnboarding)
+	h.Add("SyncCvmToCmdb", http.MethodPost, "/cvms/cmdb/sync", svc.SyncCvmToCmdb)
+	h.Add("ListCvmCmdbHostRel", http.MethodPost, "/cvms/cmdb/host_rels/list", svc.ListCvmCmdbHostRel)
 
 	// 资源下回收相关接口
 	h.Add("RecycleCvm", http.MethodPost, "/cvms/recycle", svc.RecycleCvm)
@@ -66,11 +78,15 @@ func InitCvmService(c *capability.Capability) {
 	// cvm apis in biz
 	h.Add("GetBizCvm", http.MethodGet, "/bizs/{bk_biz_id}/cvms/{id}", svc.GetBizCvm)
 	h.Add("ListBizCvmExt", http.MethodPost, "/bizs/{bk_biz_id}/cvms/list", svc.ListBizCvm)
+	h.Add("ExportBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/cvms/export", svc.ExportBizCvm)
 	h.Add("BatchDeleteBizCvm", http.MethodDelete, "/bizs/{bk_biz_id}/cvms/batch", svc.BatchDeleteBizCvm)
 	h.Add("BatchStartBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/cvms/batch/start", svc.BatchStartBizCvm)
 	h.Add("BatchStopBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/cvms/batch/stop", svc.BatchStopBizCvm)
 	h.Add("BatchRebootBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/cvms/batch/reboot", svc.BatchRebootBizCvm)
 	h.Add("QueryBizCvmRelatedRes", http.MethodPost, "/bizs/{bk_biz_id}/cvms/rel_res/batch", svc.QueryBizCvmRelatedRes)
+	h.Add("SyncBizCvmToCmdb", http.MethodPost, "/bizs/{bk_biz_id}/cvms/cmdb/sync", svc.SyncBizCvmToCmdb)
+	h.Add("ListBizCvmCmdbHostRel", http.MethodPost, "/bizs/{bk_biz_id}/cvms/cmdb/host_rels/list",
+		svc.ListBizCvmCmdbHostRel)
 	h.Add("ListCvmSecurityGroupRules", http.MethodPost,
 		"/bizs/{bk_biz_id}/cvms/{cvm_id}/security_groups/{security_group_id}/rules/list", svc.ListCvmSecurityGroupRules)
 
@@ -91,16 +107,33 @@ func InitCvmService(c *capability.Capability) {
 	h.Add("BizBatchAssociateSecurityGroups", http.MethodPost,
 		"/bizs/{bk_biz_id}/cvms/{cvm_id}/security_groups/batch_associate", svc.BizBatchAssociateSecurityGroups)
 
+	// 主机模板相关接口
+	h.Add("CreateCvmTemplate", http.MethodPost, "/cvm_templates/create", svc.CreateCvmTemplate)
+	h.Add("UpdateCvmTemplate", http.MethodPatch, "/cvm_templates/{id}", svc.UpdateCvmTemplate)
+	h.Add("ListCvmTemplate", http.MethodPost, "/cvm_templates/list", svc.ListCvmTemplate)
+	h.Add("BatchDeleteCvmTemplate", http.MethodDelete, "/cvm_templates/batch", svc.BatchDeleteCvmTemplate)
+
+	// 主机定时开关机策略相关接口
+	h.Add("CreateCvmSchedule", http.MethodPost, "/cvm_schedules/create", svc.CreateCvmSchedule)
+	h.Add("UpdateCvmSchedule", http.MethodPatch, "/cvm_schedules/{id}", svc.UpdateCvmSchedule)
+	h.Add("ListCvmSchedule", http.MethodPost, "/cvm_schedules/list", svc.ListCvmSchedule)
+	h.Add("BatchDeleteCvmSchedule", http.MethodDelete, "/cvm_schedules/batch", svc.BatchDeleteCvmSchedule)
+	h.Add("ListCvmScheduleRecord", http.MethodPost, "/cvm_schedules/records/list", svc.ListCvmScheduleRecord)
+	h.Add("GetCvmScheduleSavings", http.MethodPost, "/cvm_schedules/{id}/savings", svc.GetCvmScheduleSavings)
+
 	initCvmServiceHooks(svc, h)
 
 	h.Load(c.WebService)
 }
 
 type cvmSvc struct {
-	client     *client.ClientSet
-	authorizer auth.Authorizer
-	audit      audit.Interface
-	diskLgc    disk.Interface
-	cvmLgc     cvm.Interface
-	eipLgc     eip.Interface
+	client         *client.ClientSet
+	authorizer     auth.Authorizer
+	audit          audit.Interface
+	diskLgc        disk.Interface
+	cvmLgc         cvm.Interface
+	eipLgc         eip.Interface
+	savedFilterLgc logicssavedfilter.Interface
+	deleteHookLgc  deletehook.Interface
+	mwLgc          logicsmw.Interface
 }
@@ -21,6 +21,7 @@ package cvm
 
 import (
 	"hcm/cmd/cloud-server/logics/async"
+	deletehook "hcm/cmd/cloud-server/logics/delete-hook"
 	proto "hcm/pkg/api/cloud-server"
 	dataproto "hcm/pkg/api/data-service/cloud"
 	ts "hcm/pkg/api/task-server"
@@ -72,6 +73,14 @@ func (svc *cvmSvc) batchDeleteCvmSvc(cts *rest.Contexts, validHandler handler.Va
 		return nil, err
 	}
 
+	hookResources := make([]deletehook.Resource, 0, len(basicInfoMap))
+	for _, info := range basicInfoMap {
+		hookResources = append(hookResources, deletehook.NewResource(&info))
+	}
+	if err = svc.deleteHookLgc.PreDelete(cts.Kit, enumor.CvmCloudResType, hookResources); err != nil {
+		return nil, err
+	}
+
 	if err = svc.audit.ResDeleteAudit(cts.Kit, enumor.CvmAuditResType, req.IDs); err != nil {
 		logs.Errorf("create operation audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
 		return nil, err
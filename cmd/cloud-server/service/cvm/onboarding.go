@@ -0,0 +1,431 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"hcm/cmd/cloud-server/logics/cvm"
+	cscvm "hcm/pkg/api/cloud-server/cvm"
+	"hcm/pkg/api/core"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+	"hcm/pkg/tools/slice"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// onboardingExcelColumnCount 存量主机导入excel列数：主机标识、业务ID、负责人、标签
+const onboardingExcelColumnCount = 4
+
+// ImportCvmOnboardingPreview 上传存量主机与业务对应关系的excel, 与已同步的主机进行匹配并返回匹配结果
+func (svc *cvmSvc) ImportCvmOnboardingPreview(cts *rest.Contexts) (interface{}, error) {
+	file, _, err := cts.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	rows, err := parseOnboardingExcel(file)
+	if err != nil {
+		logs.Errorf("parse cvm onboarding excel failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, errf.Newf(errf.InvalidParameter, "parse excel failed, err: %v", err)
+	}
+	if len(rows) > constant.ExcelImportRowLimit {
+		return nil, errf.Newf(errf.InvalidParameter, "rows count should less than %d", constant.ExcelImportRowLimit)
+	}
+
+	authExpr, noPerm, err := handler.ListResourceAuthRes(cts, &handler.ListAuthResOption{
+		Authorizer: svc.authorizer, ResType: meta.Cvm, Action: meta.Find, Filter: tools.AllExpression()})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]cscvm.CvmOnboardingPreviewItem, len(rows))
+	identifiers := make([]string, 0, len(rows))
+	identifierRows := make(map[string]int, len(rows))
+	for i, row := range rows {
+		items[i].CvmOnboardingRow = row.CvmOnboardingRow
+		if len(row.invalidReason) != 0 {
+			items[i].Status, items[i].Reason = enumor.CvmOnboardingMismatch, row.invalidReason
+			continue
+		}
+
+		if firstRow, exists := identifierRows[row.Identifier]; exists {
+			items[i].Status = enumor.CvmOnboardingMismatch
+			items[i].Reason = fmt.Sprintf("identifier is duplicated with row %d", items[firstRow].RowNum)
+			continue
+		}
+		identifierRows[row.Identifier] = i
+		identifiers = append(identifiers, row.Identifier)
+	}
+
+	if noPerm {
+		for i := range items {
+			if len(items[i].Status) == 0 {
+				items[i].Status, items[i].Reason = enumor.CvmOnboardingMismatch, "cvm not found"
+			}
+		}
+		return &cscvm.CvmOnboardingPreviewResult{Details: items}, nil
+	}
+
+	cvmMap, err := svc.listCvmByIdentifier(cts.Kit, authExpr, identifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	matchedRows := make(map[string]int)
+	for _, identifier := range identifiers {
+		idx := identifierRows[identifier]
+		matchCvmOnboardingItem(&items[idx], cvmMap[identifier])
+		if len(items[idx].CvmID) == 0 || items[idx].Status == enumor.CvmOnboardingMismatch {
+			continue
+		}
+
+		if firstRow, exists := matchedRows[items[idx].CvmID]; exists {
+			items[idx].Status = enumor.CvmOnboardingMismatch
+			items[idx].Reason = fmt.Sprintf("cvm is duplicated with row %d", items[firstRow].RowNum)
+			continue
+		}
+		matchedRows[items[idx].CvmID] = idx
+	}
+
+	return &cscvm.CvmOnboardingPreviewResult{Details: items}, nil
+}
+
+// matchCvmOnboardingItem 根据匹配到的主机设置导入行的状态
+func matchCvmOnboardingItem(item *cscvm.CvmOnboardingPreviewItem, cvms []corecvm.BaseCvm) {
+	switch len(cvms) {
+	case 0:
+		item.Status, item.Reason = enumor.CvmOnboardingMismatch, "cvm not found"
+		return
+	case 1:
+	default:
+		item.Status = enumor.CvmOnboardingMismatch
+		item.Reason = fmt.Sprintf("multiple cvms matched, cloud ids: %v",
+			slice.Map(cvms, func(one corecvm.BaseCvm) string { return one.CloudID }))
+		return
+	}
+
+	one := cvms[0]
+	item.CvmID, item.CloudID, item.CurBkBizID = one.ID, one.CloudID, one.BkBizID
+	switch one.BkBizID {
+	case item.BkBizID:
+		item.Status = enumor.CvmOnboardingAssigned
+	case constant.UnassignedBiz:
+		if one.BkCloudID == constant.UnbindBkCloudID {
+			item.Status, item.Reason = enumor.CvmOnboardingMismatch, "cvm not bind cloud area"
+			return
+		}
+		item.Status = enumor.CvmOnboardingMatched
+	default:
+		item.Status = enumor.CvmOnboardingMismatch
+		item.Reason = fmt.Sprintf("cvm already assigned to biz %d", one.BkBizID)
+	}
+}
+
+// listCvmByIdentifier 根据云ID、内网IPv4或公网IPv4查询主机，返回 标识 -> 主机列表 的映射
+func (svc *cvmSvc) listCvmByIdentifier(kt *kit.Kit, authExpr *filter.Expression, identifiers []string) (
+	map[string][]corecvm.BaseCvm, error) {
+
+	result := make(map[string][]corecvm.BaseCvm, len(identifiers))
+	for _, batch := range slice.Split(identifiers, int(core.DefaultMaxPageLimit)) {
+		matchExpr := tools.ExpressionOr(
+			tools.RuleIn("cloud_id", batch),
+			tools.RuleJsonOverlaps("private_ipv4_addresses", batch),
+			tools.RuleJsonOverlaps("public_ipv4_addresses", batch),
+		)
+		expr, err := tools.And(authExpr, matchExpr)
+		if err != nil {
+			return nil, err
+		}
+
+		batchSet := make(map[string]struct{}, len(batch))
+		for _, one := range batch {
+			batchSet[one] = struct{}{}
+		}
+
+		listReq := &core.ListReq{
+			Fields: []string{"id", "cloud_id", "bk_biz_id", "bk_cloud_id", "account_id", "private_ipv4_addresses",
+				"public_ipv4_addresses"},
+			Filter: expr,
+			Page:   core.NewDefaultBasePage(),
+		}
+		for {
+			resp, err := svc.client.DataService().Global.Cvm.ListCvm(kt, listReq)
+			if err != nil {
+				logs.Errorf("list cvm by identifier failed, err: %v, rid: %s", err, kt.Rid)
+				return nil, err
+			}
+
+			for _, one := range resp.Details {
+				keys := append([]string{one.CloudID}, one.PrivateIPv4Addresses...)
+				keys = append(keys, one.PublicIPv4Addresses...)
+				for _, key := range slice.Unique(keys) {
+					if _, exists := batchSet[key]; exists {
+						result[key] = append(result[key], one)
+					}
+				}
+			}
+
+			if uint(len(resp.Details)) < listReq.Page.Limit {
+				break
+			}
+			listReq.Page.Start += uint32(listReq.Page.Limit)
+		}
+	}
+
+	return result, nil
+}
+
+type onboardingExcelRow struct {
+	cscvm.CvmOnboardingRow
+	invalidReason string
+}
+
+// parseOnboardingExcel 解析存量主机导入excel，第一行为表头，列依次为：主机标识、业务ID、负责人、标签(k1=v1;k2=v2)
+func parseOnboardingExcel(reader io.Reader) ([]onboardingExcelRow, error) {
+	excel, err := excelize.OpenReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	defer excel.Close()
+
+	rows, err := excel.Rows(excel.GetSheetName(0))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// 跳过表头
+	rows.Next()
+	result := make([]onboardingExcelRow, 0)
+	for rowNum := 2; rows.Next(); rowNum++ {
+		columns, err := rows.Columns()
+		if err != nil {
+			return nil, err
+		}
+		if len(strings.TrimSpace(strings.Join(columns, ""))) == 0 {
+			continue
+		}
+		if len(result) >= constant.ExcelImportRowLimit {
+			return nil, fmt.Errorf("rows count should less than %d", constant.ExcelImportRowLimit)
+		}
+
+		result = append(result, parseOnboardingExcelRow(rowNum, columns))
+	}
+
+	return result, nil
+}
+
+func parseOnboardingExcelRow(rowNum int, columns []string) onboardingExcelRow {
+	for len(columns) < onboardingExcelColumnCount {
+		columns = append(columns, "")
+	}
+
+	row := onboardingExcelRow{CvmOnboardingRow: cscvm.CvmOnboardingRow{
+		RowNum:     rowNum,
+		Identifier: strings.TrimSpace(columns[0]),
+		Owner:      strings.TrimSpace(columns[2]),
+	}}
+
+	if len(row.Identifier) == 0 {
+		row.invalidReason = "identifier is required"
+		return row
+	}
+
+	bizID, err := strconv.ParseInt(strings.TrimSpace(columns[1]), 10, 64)
+	if err != nil || bizID <= 0 {
+		row.invalidReason = fmt.Sprintf("invalid bk_biz_id: %s", columns[1])
+		return row
+	}
+	row.BkBizID = bizID
+
+	if len(row.Owner) > 64 {
+		row.invalidReason = "owner length should <= 64"
+		return row
+	}
+
+	labels, err := parseOnboardingLabels(columns[3])
+	if err != nil {
+		row.invalidReason = err.Error()
+		return row
+	}
+	row.Labels = labels
+
+	return row
+}
+
+// parseOnboardingLabels 解析 k1=v1;k2=v2 格式的标签
+func parseOnboardingLabels(raw string) (map[string]string, error) {
+	labels := make(map[string]string)
+	for _, pair := range strings.Split(raw, ";") {
+		pair = strings.TrimSpace(pair)
+		if len(pair) == 0 {
+			continue
+		}
+
+		key, value, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid label: %s, should be key=value", pair)
+		}
+		labels[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	if err := cscvm.ValidateLabels(labels); err != nil {
+		return nil, err
+	}
+
+	return labels, nil
+}
+
+// SubmitCvmOnboarding 提交存量主机导入预览中匹配的主机，批量分配业务并设置负责人和标签
+func (svc *cvmSvc) SubmitCvmOnboarding(cts *rest.Contexts) (interface{}, error) {
+	req := new(cscvm.CvmOnboardingSubmitReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvmIDs := slice.Map(req.Details, func(one cscvm.CvmOnboardingItem) string { return one.CvmID })
+	cvmMap, err := svc.listCvmMapByIDs(cts.Kit, cvmIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 校验主机状态并鉴权，未分配的主机需要分配权限，已在目标业务下的主机需要编辑权限
+	authRes := make([]meta.ResourceAttribute, 0, len(req.Details))
+	for _, one := range req.Details {
+		cvmInfo, exists := cvmMap[one.CvmID]
+		if !exists {
+			return nil, errf.Newf(errf.RecordNotFound, "cvm: %s not found", one.CvmID)
+		}
+
+		action := meta.Update
+		switch cvmInfo.BkBizID {
+		case one.BkBizID:
+		case constant.UnassignedBiz:
+			action = meta.Assign
+		default:
+			return nil, errf.Newf(errf.InvalidParameter, "cvm: %s already assigned to biz %d", one.CvmID,
+				cvmInfo.BkBizID)
+		}
+		authRes = append(authRes, meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Cvm, Action: action,
+			ResourceID: cvmInfo.AccountID}, BizID: one.BkBizID})
+	}
+	if err = svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes...); err != nil {
+		return nil, err
+	}
+
+	result := &cscvm.CvmOnboardingSubmitResult{SucceededIDs: make([]string, 0),
+		Failed: make([]cscvm.CvmOnboardingFailedItem, 0)}
+
+	// 按业务分组分配未分配的主机
+	bizAssignIDs := make(map[int64][]string)
+	succeeded := make([]cscvm.CvmOnboardingItem, 0, len(req.Details))
+	for _, one := range req.Details {
+		if cvmMap[one.CvmID].BkBizID == constant.UnassignedBiz {
+			bizAssignIDs[one.BkBizID] = append(bizAssignIDs[one.BkBizID], one.CvmID)
+			continue
+		}
+		succeeded = append(succeeded, one)
+	}
+
+	assignedIDs := make(map[string]struct{})
+	for bizID, ids := range bizAssignIDs {
+		for _, batch := range slice.Split(ids, constant.BatchOperationMaxLimit) {
+			if err = cvm.Assign(cts.Kit, svc.client.DataService(), batch, bizID); err != nil {
+				logs.Errorf("assign cvm to biz failed, err: %v, ids: %v, biz: %d, rid: %s", err, batch, bizID,
+					cts.Kit.Rid)
+				result.Failed = append(result.Failed, cscvm.CvmOnboardingFailedItem{CvmIDs: batch,
+					Reason: err.Error()})
+				continue
+			}
+			for _, id := range batch {
+				assignedIDs[id] = struct{}{}
+			}
+		}
+	}
+	for _, one := range req.Details {
+		if _, exists := assignedIDs[one.CvmID]; exists {
+			succeeded = append(succeeded, one)
+		}
+	}
+
+	// 设置负责人和标签
+	ownerInfos := make([]dataproto.CvmOwnerInfo, 0, len(succeeded))
+	for _, one := range succeeded {
+		if len(one.Owner) == 0 && len(one.Labels) == 0 {
+			result.SucceededIDs = append(result.SucceededIDs, one.CvmID)
+			continue
+		}
+		ownerInfos = append(ownerInfos, dataproto.CvmOwnerInfo{ID: one.CvmID, Owner: one.Owner, Labels: one.Labels})
+	}
+
+	for _, batch := range slice.Split(ownerInfos, constant.BatchOperationMaxLimit) {
+		ids := slice.Map(batch, func(one dataproto.CvmOwnerInfo) string { return one.ID })
+		updateReq := &dataproto.CvmOwnerInfoBatchUpdateReq{Cvms: batch}
+		if err = svc.client.DataService().Global.Cvm.BatchUpdateCvmOwnerInfo(cts.Kit, updateReq); err != nil {
+			logs.Errorf("batch update cvm owner info failed, err: %v, ids: %v, rid: %s", err, ids, cts.Kit.Rid)
+			result.Failed = append(result.Failed, cscvm.CvmOnboardingFailedItem{CvmIDs: ids, Reason: err.Error()})
+			continue
+		}
+		result.SucceededIDs = append(result.SucceededIDs, ids...)
+	}
+
+	return result, nil
+}
+
+func (svc *cvmSvc) listCvmMapByIDs(kt *kit.Kit, ids []string) (map[string]corecvm.BaseCvm, error) {
+	result := make(map[string]corecvm.BaseCvm, len(ids))
+	for _, batch := range slice.Split(ids, int(core.DefaultMaxPageLimit)) {
+		listReq := &core.ListReq{
+			Fields: []string{"id", "bk_biz_id", "account_id"},
+			Filter: tools.ContainersExpression("id", batch),
+			Page:   core.NewDefaultBasePage(),
+		}
+		resp, err := svc.client.DataService().Global.Cvm.ListCvm(kt, listReq)
+		if err != nil {
+			logs.Errorf("list cvm failed, err: %v, ids: %v, rid: %s", err, batch, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range resp.Details {
+			result[one.ID] = one
+		}
+	}
+
+	return result, nil
+}
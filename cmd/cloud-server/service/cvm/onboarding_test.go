@@ -0,0 +1,109 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	cscvm "hcm/pkg/api/cloud-server/cvm"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestParseOnboardingExcel(t *testing.T) {
+	excel := excelize.NewFile()
+	sheet := excel.GetSheetName(0)
+	rows := [][]interface{}{
+		{"identifier", "bk_biz_id", "owner", "labels"},
+		{"ins-1", "100", "alice", "env=prod; app = web"},
+		{},
+		{"10.0.0.1", "abc"},
+		{"", "100"},
+		{"ins-2", "100", "", "env"},
+	}
+	for idx, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, idx+1)
+		if err := excel.SetSheetRow(sheet, cell, &row); err != nil {
+			t.Fatalf("set excel row failed, err: %v", err)
+		}
+	}
+	buf, err := excel.WriteToBuffer()
+	if err != nil {
+		t.Fatalf("write excel failed, err: %v", err)
+	}
+
+	result, err := parseOnboardingExcel(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("parse onboarding excel failed, err: %v", err)
+	}
+
+	// 空行被跳过，行号与excel中的行号一致
+	if len(result) != 4 {
+		t.Fatalf("expect 4 rows, but got %+v", result)
+	}
+	first := result[0]
+	if first.RowNum != 2 || first.Identifier != "ins-1" || first.BkBizID != 100 || first.Owner != "alice" ||
+		!reflect.DeepEqual(first.Labels, map[string]string{"env": "prod", "app": "web"}) ||
+		len(first.invalidReason) != 0 {
+		t.Errorf("unexpected first row: %+v", first)
+	}
+	for _, row := range result[1:] {
+		if len(row.invalidReason) == 0 {
+			t.Errorf("row %d should be invalid", row.RowNum)
+		}
+	}
+	if result[1].RowNum != 4 {
+		t.Errorf("empty row should be skipped but counted, got row num %d", result[1].RowNum)
+	}
+}
+
+func TestMatchCvmOnboardingItem(t *testing.T) {
+	cases := []struct {
+		name   string
+		cvms   []corecvm.BaseCvm
+		status enumor.CvmOnboardingStatus
+	}{
+		{name: "not found", status: enumor.CvmOnboardingMismatch},
+		{name: "multiple cvms", cvms: []corecvm.BaseCvm{{ID: "1"}, {ID: "2"}}, status: enumor.CvmOnboardingMismatch},
+		{name: "unassigned", cvms: []corecvm.BaseCvm{{ID: "1", BkBizID: constant.UnassignedBiz}},
+			status: enumor.CvmOnboardingMatched},
+		{name: "unassigned without cloud area", cvms: []corecvm.BaseCvm{{ID: "1", BkBizID: constant.UnassignedBiz,
+			BkCloudID: constant.UnbindBkCloudID}}, status: enumor.CvmOnboardingMismatch},
+		{name: "already in target biz", cvms: []corecvm.BaseCvm{{ID: "1", BkBizID: 100}},
+			status: enumor.CvmOnboardingAssigned},
+		{name: "in other biz", cvms: []corecvm.BaseCvm{{ID: "1", BkBizID: 200}}, status: enumor.CvmOnboardingMismatch},
+	}
+
+	for _, c := range cases {
+		item := &cscvm.CvmOnboardingPreviewItem{CvmOnboardingRow: cscvm.CvmOnboardingRow{BkBizID: 100}}
+		matchCvmOnboardingItem(item, c.cvms)
+		if item.Status != c.status {
+			t.Errorf("%s: expect status %s, but got %s(%s)", c.name, c.status, item.Status, item.Reason)
+		}
+		if c.status != enumor.CvmOnboardingMismatch && item.CvmID != "1" {
+			t.Errorf("%s: matched cvm id should be set", c.name)
+		}
+	}
+}
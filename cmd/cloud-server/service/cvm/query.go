@@ -62,6 +62,12 @@ func (svc *cvmSvc) listCvm(cts *rest.Contexts, authHandler handler.ListAuthResHa
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	filterExpr, err := svc.savedFilterLgc.MergeFilter(cts.Kit, req.SavedFilterID, enumor.CvmCloudResType, req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	req.Filter = filterExpr
+
 	// list authorized instances
 	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
 		ResType: meta.Cvm, Action: meta.Find, Filter: req.Filter})
@@ -0,0 +1,304 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"time"
+
+	cscvm "hcm/pkg/api/cloud-server/cvm"
+	"hcm/pkg/api/core"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/times"
+)
+
+// CreateCvmSchedule create cvm schedule.
+func (svc *cvmSvc) CreateCvmSchedule(cts *rest.Contexts) (interface{}, error) {
+	req := new(cscvm.CvmScheduleCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.CvmSchedule, Action: meta.Create}}
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return nil, err
+	}
+
+	createReq := &protocloud.CvmScheduleCreateReq{
+		Name:      req.Name,
+		Filter:    req.Filter,
+		StartCron: req.StartCron,
+		StopCron:  req.StopCron,
+		Timezone:  req.Timezone,
+		Enabled:   req.Enabled,
+		Memo:      req.Memo,
+	}
+	if len(createReq.Timezone) == 0 {
+		createReq.Timezone = cscvm.DefaultScheduleTimezone
+	}
+
+	result, err := svc.client.DataService().Global.Cvm.CreateCvmSchedule(cts.Kit, createReq)
+	if err != nil {
+		logs.Errorf("create cvm schedule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateCvmSchedule update cvm schedule.
+func (svc *cvmSvc) UpdateCvmSchedule(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(cscvm.CvmScheduleUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.CvmSchedule, Action: meta.Update, ResourceID: id}}
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return nil, err
+	}
+
+	updateReq := &protocloud.CvmScheduleUpdateReq{
+		Name:      req.Name,
+		Filter:    req.Filter,
+		StartCron: req.StartCron,
+		StopCron:  req.StopCron,
+		Timezone:  req.Timezone,
+		Enabled:   req.Enabled,
+		Memo:      req.Memo,
+	}
+	if err := svc.client.DataService().Global.Cvm.UpdateCvmSchedule(cts.Kit, id, updateReq); err != nil {
+		logs.Errorf("update cvm schedule failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListCvmSchedule list cvm schedule.
+func (svc *cvmSvc) ListCvmSchedule(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.CvmSchedule, Action: meta.Find}}
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.Cvm.ListCvmSchedule(cts.Kit, req)
+}
+
+// BatchDeleteCvmSchedule batch delete cvm schedule.
+func (svc *cvmSvc) BatchDeleteCvmSchedule(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.CvmSchedule, Action: meta.Delete}}
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.Cvm.BatchDeleteCvmSchedule(cts.Kit, req); err != nil {
+		logs.Errorf("batch delete cvm schedule failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListCvmScheduleRecord list cvm schedule execution record.
+func (svc *cvmSvc) ListCvmScheduleRecord(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.CvmSchedule, Action: meta.Find}}
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.Cvm.ListCvmScheduleRecord(cts.Kit, req)
+}
+
+// GetCvmScheduleSavings get the savings of cvm schedule in the time range, the savings is calculated by the
+// duration between stop record and the next start record.
+func (svc *cvmSvc) GetCvmScheduleSavings(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(cscvm.CvmScheduleSavingsReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.CvmSchedule, Action: meta.Find, ResourceID: id}}
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return nil, err
+	}
+
+	start, end, err := req.ParseTimeRange()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 统计开始前的最后一条记录，用于判断统计开始时主机是否处于关机状态
+	prevRecords, err := svc.listScheduleRecords(cts.Kit, id, time.Time{}, start, true)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := svc.listScheduleRecords(cts.Kit, id, start, end, false)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &cscvm.CvmScheduleSavingsResult{ScheduleID: id}
+	var stopped *corecvm.ScheduleRecord
+	var stoppedAt time.Time
+	if len(prevRecords) != 0 && prevRecords[0].Action == enumor.CvmScheduleStop {
+		stopped, stoppedAt = &prevRecords[0], start
+	}
+
+	for i := range records {
+		record := &records[i]
+		createdAt, err := time.Parse(constant.TimeStdFormat, record.CreatedAt)
+		if err != nil {
+			logs.Errorf("parse cvm schedule record created time failed, err: %v, record: %s, rid: %s", err,
+				record.ID, cts.Kit.Rid)
+			return nil, err
+		}
+
+		// 处于关机状态时，遇到下一条开机或关机记录即结算上一段关机时长
+		if stopped != nil {
+			accumulateSavings(result, stopped, createdAt.Sub(stoppedAt))
+			stopped = nil
+		}
+
+		switch record.Action {
+		case enumor.CvmScheduleStop:
+			result.StopCount++
+			stopped, stoppedAt = record, createdAt
+		case enumor.CvmScheduleStart:
+			result.StartCount++
+		}
+	}
+
+	if stopped != nil {
+		accumulateSavings(result, stopped, end.Sub(stoppedAt))
+	}
+
+	return result, nil
+}
+
+func accumulateSavings(result *cscvm.CvmScheduleSavingsResult, record *corecvm.ScheduleRecord,
+	duration time.Duration) {
+
+	hours := duration.Hours()
+	result.SavedCvmHours += hours * float64(len(record.CvmIDs))
+	result.SavedCpuCoreHours += hours * float64(record.CpuCore)
+}
+
+// listScheduleRecords list schedule records created in [start, end) order by created time, if onlyLatest is true,
+// only the latest record is returned.
+func (svc *cvmSvc) listScheduleRecords(kt *kit.Kit, scheduleID string, start, end time.Time, onlyLatest bool) (
+	[]corecvm.ScheduleRecord, error) {
+
+	rules := []filter.RuleFactory{
+		tools.RuleEqual("schedule_id", scheduleID),
+		&filter.AtomRule{Field: "created_at", Op: filter.LessThan.Factory(), Value: times.ConvStdTimeFormat(end)},
+	}
+	if !start.IsZero() {
+		rules = append(rules, tools.RuleGreaterThanEqual("created_at", times.ConvStdTimeFormat(start)))
+	}
+	expr, err := tools.And(rules...)
+	if err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit, Sort: "created_at", Order: core.Ascending},
+	}
+	if onlyLatest {
+		listReq.Page = &core.BasePage{Start: 0, Limit: 1, Sort: "created_at", Order: core.Descending}
+	}
+
+	records := make([]corecvm.ScheduleRecord, 0)
+	for {
+		result, err := svc.client.DataService().Global.Cvm.ListCvmScheduleRecord(kt, listReq)
+		if err != nil {
+			logs.Errorf("list cvm schedule record failed, err: %v, schedule: %s, rid: %s", err, scheduleID, kt.Rid)
+			return nil, err
+		}
+		records = append(records, result.Details...)
+
+		if onlyLatest || uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,342 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"time"
+
+	logicscvm "hcm/cmd/cloud-server/logics/cvm"
+	logicsmw "hcm/cmd/cloud-server/logics/maintenance-window"
+	"hcm/cmd/cloud-server/logics/tenant"
+	"hcm/pkg/api/core"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/serviced"
+	"hcm/pkg/tools/cron"
+	"hcm/pkg/tools/slice"
+)
+
+// CvmScheduleTiming 定时检查主机定时开关机策略，在cron表达式触发时对匹配的主机执行开机或关机，并记录执行结果用于统计节省情况。
+// 所属业务不在维护窗口内的主机推迟到维护窗口开始后再关机
+func CvmScheduleTiming(interval time.Duration, sd serviced.ServiceDiscover, cliSet *client.ClientSet,
+	cvmLgc logicscvm.Interface, mwLgc logicsmw.Interface) {
+
+	logs.Infof("cvm schedule enable && start, interval: %v", interval)
+
+	executor := &scheduleExecutor{
+		client:   cliSet,
+		cvmLgc:   cvmLgc,
+		mwLgc:    mwLgc,
+		deferred: make(map[string]*deferredAction),
+	}
+	lastCheck := time.Now()
+	for {
+		time.Sleep(interval)
+
+		now := time.Now()
+		// 非主节点也需要推进检查时间，避免切换为主节点后重复执行已过去的策略
+		from := lastCheck
+		lastCheck = now
+		if !sd.IsMaster() {
+			continue
+		}
+
+		_ = tenant.ForEach(core.NewBackendKit(), cliSet.DataService(), func(kt *kit.Kit) {
+			logs.Infof("tenant %s cvm schedule check start, from: %v, to: %v, rid: %s", kt.TenantID, from, now,
+				kt.Rid)
+			executor.retryDeferred(kt, now)
+			executor.checkAll(kt, from, now)
+			logs.Infof("tenant %s cvm schedule check end, cost: %v, rid: %s", kt.TenantID, time.Since(now), kt.Rid)
+		})
+	}
+}
+
+type scheduleExecutor struct {
+	client *client.ClientSet
+	cvmLgc logicscvm.Interface
+	mwLgc  logicsmw.Interface
+	// deferred schedule id -> 因不在维护窗口内推迟执行的操作
+	deferred map[string]*deferredAction
+}
+
+// deferredAction is the schedule action deferred because the biz of the cvms is out of maintenance window.
+type deferredAction struct {
+	// tenantID 定时策略所属租户，推迟的操作只能在所属租户下执行
+	tenantID     string
+	schedule     corecvm.Schedule
+	action       enumor.CvmScheduleAction
+	basicInfoMap map[string]types.CloudResourceBasicInfo
+}
+
+// scheduleMaintenanceActions 受维护窗口限制的定时开关机操作
+var scheduleMaintenanceActions = map[enumor.CvmScheduleAction]enumor.MaintenanceAction{
+	enumor.CvmScheduleStop: enumor.MaintenanceStopCvm,
+}
+
+func (e *scheduleExecutor) checkAll(kt *kit.Kit, from, to time.Time) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("enabled", true),
+		Page:   core.NewDefaultBasePage(),
+	}
+	for {
+		result, err := e.client.DataService().Global.Cvm.ListCvmSchedule(kt, listReq)
+		if err != nil {
+			logs.Errorf("list cvm schedule failed, err: %v, rid: %s", err, kt.Rid)
+			return
+		}
+
+		for _, schedule := range result.Details {
+			e.check(kt, schedule, from, to)
+		}
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			return
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+}
+
+// check 判断策略在(from, to]内是否触发开机或关机，同时触发时按触发时间先后执行
+func (e *scheduleExecutor) check(kt *kit.Kit, schedule corecvm.Schedule, from, to time.Time) {
+	loc, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		logs.Errorf("load cvm schedule timezone failed, err: %v, schedule: %s, rid: %s", err, schedule.ID, kt.Rid)
+		return
+	}
+	from = from.In(loc)
+
+	type trigger struct {
+		action enumor.CvmScheduleAction
+		at     time.Time
+	}
+	triggers := make([]trigger, 0, 2)
+	specs := map[enumor.CvmScheduleAction]string{
+		enumor.CvmScheduleStart: schedule.StartCron,
+		enumor.CvmScheduleStop:  schedule.StopCron,
+	}
+	for action, spec := range specs {
+		if len(spec) == 0 {
+			continue
+		}
+
+		sched, err := cron.Parse(spec)
+		if err != nil {
+			logs.Errorf("parse cvm schedule cron failed, err: %v, schedule: %s, cron: %s, rid: %s", err, schedule.ID,
+				spec, kt.Rid)
+			continue
+		}
+
+		if sched.Between(from, to) {
+			triggers = append(triggers, trigger{action: action, at: sched.Next(from)})
+		}
+	}
+
+	if len(triggers) == 2 && triggers[1].at.Before(triggers[0].at) {
+		triggers[0], triggers[1] = triggers[1], triggers[0]
+	}
+
+	for _, one := range triggers {
+		e.execute(kt, schedule, one.action)
+	}
+}
+
+// execute 对策略匹配的主机执行开机或关机并记录结果，新触发的操作覆盖该策略之前推迟执行的操作
+func (e *scheduleExecutor) execute(kt *kit.Kit, schedule corecvm.Schedule, action enumor.CvmScheduleAction) {
+	basicInfoMap, err := e.listScheduleCvm(kt, schedule)
+	if err != nil {
+		return
+	}
+
+	logs.Infof("cvm schedule triggered, schedule: %s, action: %s, cvm count: %d, rid: %s", schedule.ID, action,
+		len(basicInfoMap), kt.Rid)
+
+	delete(e.deferred, schedule.ID)
+	basicInfoMap = e.deferOutOfWindow(kt, &deferredAction{tenantID: kt.GetTenantID(), schedule: schedule,
+		action: action, basicInfoMap: basicInfoMap}, time.Now())
+	if _, exists := e.deferred[schedule.ID]; exists && len(basicInfoMap) == 0 {
+		return
+	}
+	e.run(kt, schedule, action, basicInfoMap)
+}
+
+// retryDeferred 执行所属业务已进入维护窗口的推迟操作
+func (e *scheduleExecutor) retryDeferred(kt *kit.Kit, now time.Time) {
+	deferred := e.deferred
+	e.deferred = make(map[string]*deferredAction)
+	for id, one := range deferred {
+		if one.tenantID != kt.GetTenantID() {
+			e.deferred[id] = one
+			continue
+		}
+
+		basicInfoMap := e.deferOutOfWindow(kt, one, now)
+		if len(basicInfoMap) == 0 {
+			continue
+		}
+
+		logs.Infof("cvm schedule deferred action is in maintenance window now, schedule: %s, action: %s, "+
+			"cvm count: %d, rid: %s", id, one.action, len(basicInfoMap), kt.Rid)
+		e.run(kt, one.schedule, one.action, basicInfoMap)
+	}
+}
+
+// deferOutOfWindow 推迟执行所属业务不在维护窗口内的主机，返回可以立即执行的主机
+func (e *scheduleExecutor) deferOutOfWindow(kt *kit.Kit, one *deferredAction,
+	now time.Time) map[string]types.CloudResourceBasicInfo {
+
+	mwAction, restricted := scheduleMaintenanceActions[one.action]
+	if !restricted || len(one.basicInfoMap) == 0 {
+		return one.basicInfoMap
+	}
+
+	bizIDs := make([]int64, 0, len(one.basicInfoMap))
+	for _, info := range one.basicInfoMap {
+		bizIDs = append(bizIDs, info.BkBizID)
+	}
+	outOfWindow, err := e.mwLgc.ListOutOfWindowBiz(kt, mwAction, bizIDs, now)
+	if err != nil {
+		// 查询维护窗口失败时推迟执行，避免在维护窗口外中断业务
+		logs.Errorf("list out of maintenance window biz failed, err: %v, schedule: %s, rid: %s", err,
+			one.schedule.ID, kt.Rid)
+		e.deferred[one.schedule.ID] = one
+		return nil
+	}
+	if len(outOfWindow) == 0 {
+		return one.basicInfoMap
+	}
+
+	allowed := make(map[string]types.CloudResourceBasicInfo)
+	deferred := make(map[string]types.CloudResourceBasicInfo)
+	for id, info := range one.basicInfoMap {
+		if slice.IsItemInSlice(outOfWindow, info.BkBizID) {
+			deferred[id] = info
+			continue
+		}
+		allowed[id] = info
+	}
+
+	logs.Infof("cvm schedule action deferred for out of maintenance window, schedule: %s, action: %s, biz: %v, "+
+		"cvm count: %d, rid: %s", one.schedule.ID, one.action, outOfWindow, len(deferred), kt.Rid)
+	e.deferred[one.schedule.ID] = &deferredAction{tenantID: one.tenantID, schedule: one.schedule, action: one.action,
+		basicInfoMap: deferred}
+	return allowed
+}
+
+// run 执行开机或关机并记录结果
+func (e *scheduleExecutor) run(kt *kit.Kit, schedule corecvm.Schedule, action enumor.CvmScheduleAction,
+	basicInfoMap map[string]types.CloudResourceBasicInfo) {
+
+	var err error
+	succeeded, failed := make([]string, 0), make([]string, 0)
+	infos := make([]types.CloudResourceBasicInfo, 0, len(basicInfoMap))
+	for _, info := range basicInfoMap {
+		infos = append(infos, info)
+	}
+	for _, batch := range slice.Split(infos, constant.BatchOperationMaxLimit) {
+		batchMap := make(map[string]types.CloudResourceBasicInfo, len(batch))
+		for _, info := range batch {
+			batchMap[info.ID] = info
+		}
+
+		var result *core.BatchOperateAllResult
+		switch action {
+		case enumor.CvmScheduleStart:
+			result, err = e.cvmLgc.BatchStartCvm(kt, batchMap)
+		case enumor.CvmScheduleStop:
+			result, err = e.cvmLgc.BatchStopCvm(kt, batchMap)
+		}
+		if err != nil {
+			logs.Errorf("cvm schedule %s cvm failed, err: %v, schedule: %s, rid: %s", action, err, schedule.ID, kt.Rid)
+		}
+		if result == nil {
+			continue
+		}
+
+		succeeded = append(succeeded, result.Succeeded...)
+		for _, one := range result.Failed {
+			failed = append(failed, one.ID)
+		}
+	}
+
+	recordReq := &protocloud.CvmScheduleRecordCreateReq{
+		ScheduleID:   schedule.ID,
+		Action:       action,
+		CvmIDs:       succeeded,
+		FailedCvmIDs: failed,
+	}
+	if _, err = e.client.DataService().Global.Cvm.CreateCvmScheduleRecord(kt, recordReq); err != nil {
+		logs.Errorf("create cvm schedule record failed, err: %v, schedule: %s, rid: %s", err, schedule.ID, kt.Rid)
+	}
+}
+
+// listScheduleCvm list cvm matched the schedule filter, cvm in recycling is excluded.
+func (e *scheduleExecutor) listScheduleCvm(kt *kit.Kit, schedule corecvm.Schedule) (
+	map[string]types.CloudResourceBasicInfo, error) {
+
+	if schedule.Filter == nil {
+		return make(map[string]types.CloudResourceBasicInfo), nil
+	}
+
+	expr, err := tools.And(schedule.Filter, tools.RuleNotEqual("recycle_status", enumor.RecycleStatus))
+	if err != nil {
+		logs.Errorf("build cvm schedule filter failed, err: %v, schedule: %s, rid: %s", err, schedule.ID, kt.Rid)
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id", "vendor", "account_id", "bk_biz_id", "region"},
+	}
+	basicInfoMap := make(map[string]types.CloudResourceBasicInfo)
+	for {
+		result, err := e.client.DataService().Global.Cvm.ListCvm(kt, listReq)
+		if err != nil {
+			logs.Errorf("list cvm by schedule filter failed, err: %v, schedule: %s, rid: %s", err, schedule.ID,
+				kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			basicInfoMap[one.ID] = types.CloudResourceBasicInfo{
+				ResType:   enumor.CvmCloudResType,
+				ID:        one.ID,
+				Vendor:    one.Vendor,
+				AccountID: one.AccountID,
+				BkBizID:   one.BkBizID,
+				Region:    one.Region,
+			}
+		}
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return basicInfoMap, nil
+}
@@ -0,0 +1,162 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	logicscvm "hcm/cmd/cloud-server/logics/cvm"
+	cscvm "hcm/pkg/api/cloud-server/cvm"
+	"hcm/pkg/api/core"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateCvmTemplate create cvm template.
+func (svc *cvmSvc) CreateCvmTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(cscvm.CvmTemplateCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.CvmTemplate, Action: meta.Create}}
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return nil, err
+	}
+
+	createReq := &protocloud.CvmTemplateCreateReq{
+		Name:                  req.Name,
+		Vendor:                req.Vendor,
+		AccountID:             req.AccountID,
+		Region:                req.Region,
+		Zone:                  req.Zone,
+		CloudImageID:          req.CloudImageID,
+		InstanceType:          req.InstanceType,
+		CloudSecurityGroupIDs: req.CloudSecurityGroupIDs,
+		SystemDisk:            req.SystemDisk,
+		DataDisk:              req.DataDisk,
+		UserData:              req.UserData,
+		Extension:             req.Extension,
+		Memo:                  req.Memo,
+	}
+	result, err := svc.client.DataService().Global.Cvm.CreateCvmTemplate(cts.Kit, createReq)
+	if err != nil {
+		logs.Errorf("create cvm template failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateCvmTemplate update cvm template.
+func (svc *cvmSvc) UpdateCvmTemplate(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(cscvm.CvmTemplateUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.CvmTemplate, Action: meta.Update, ResourceID: id}}
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return nil, err
+	}
+
+	if req.UserData != nil {
+		tpl, err := logicscvm.GetTemplate(cts.Kit, svc.client.DataService(), id)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = cscvm.ValidateUserData(tpl.Vendor, *req.UserData); err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+	}
+
+	updateReq := &protocloud.CvmTemplateUpdateReq{
+		Name:                  req.Name,
+		Region:                req.Region,
+		Zone:                  req.Zone,
+		CloudImageID:          req.CloudImageID,
+		InstanceType:          req.InstanceType,
+		CloudSecurityGroupIDs: req.CloudSecurityGroupIDs,
+		SystemDisk:            req.SystemDisk,
+		DataDisk:              req.DataDisk,
+		UserData:              req.UserData,
+		Extension:             req.Extension,
+		Memo:                  req.Memo,
+	}
+	if err := svc.client.DataService().Global.Cvm.UpdateCvmTemplate(cts.Kit, id, updateReq); err != nil {
+		logs.Errorf("update cvm template failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListCvmTemplate list cvm template, templates are visible to all users who can apply cvm.
+func (svc *cvmSvc) ListCvmTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.client.DataService().Global.Cvm.ListCvmTemplate(cts.Kit, req)
+}
+
+// BatchDeleteCvmTemplate batch delete cvm template.
+func (svc *cvmSvc) BatchDeleteCvmTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.CvmTemplate, Action: meta.Delete}}
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.Cvm.BatchDeleteCvmTemplate(cts.Kit, req); err != nil {
+		logs.Errorf("batch delete cvm template failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,131 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package diff resource configuration diff service, compare two resources of the same type (or a cvm with a cvm
+// template) field by field for review.
+package diff
+
+import (
+	"net/http"
+
+	logicsdiff "hcm/cmd/cloud-server/logics/diff"
+	"hcm/cmd/cloud-server/service/capability"
+	csdiff "hcm/pkg/api/cloud-server/diff"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// InitService initialize the resource diff service.
+func InitService(c *capability.Capability) {
+	svc := &diffSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("DiffResource", http.MethodPost, "/resources/diff", svc.DiffResource)
+	h.Add("DiffBizResource", http.MethodPost, "/bizs/{bk_biz_id}/resources/diff", svc.DiffBizResource)
+
+	h.Load(c.WebService)
+}
+
+type diffSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// DiffResource compare the configuration of two resources in resource view.
+func (svc *diffSvc) DiffResource(cts *rest.Contexts) (interface{}, error) {
+	return svc.diff(cts, handler.ResOperateAuth)
+}
+
+// DiffBizResource compare the configuration of two resources in biz view.
+func (svc *diffSvc) DiffBizResource(cts *rest.Contexts) (interface{}, error) {
+	return svc.diff(cts, handler.BizOperateAuth)
+}
+
+func (svc *diffSvc) diff(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{}, error) {
+	req := new(csdiff.ResourceDiffReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	objs := make([]map[string]interface{}, 0, 2)
+	for _, res := range []csdiff.DiffResource{req.Source, req.Target} {
+		vendor, err := svc.authorize(cts, validHandler, res)
+		if err != nil {
+			return nil, err
+		}
+
+		obj, err := logicsdiff.Load(cts.Kit, svc.client.DataService(), res, vendor)
+		if err != nil {
+			logs.Errorf("load %s: %s for diff failed, err: %v, rid: %s", res.ResType, res.ID, err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		if req.IsCvmWithTemplate() {
+			obj = logicsdiff.TemplateView(obj, res.ResType)
+		}
+		objs = append(objs, obj)
+	}
+
+	fields := logicsdiff.Compare(objs[0], objs[1])
+	return &csdiff.ResourceDiffResult{Identical: len(fields) == 0, Fields: fields}, nil
+}
+
+// authorize authorize view of the resource and return its vendor, cvm templates are visible to all users who can
+// apply cvm, so they are not authorized.
+func (svc *diffSvc) authorize(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	res csdiff.DiffResource) (enumor.Vendor, error) {
+
+	if res.ResType == csdiff.CvmTemplateResType {
+		return "", nil
+	}
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit, res.ResType, res.ID)
+	if err != nil {
+		return "", err
+	}
+
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer,
+		ResType: authResTypes[res.ResType], Action: meta.Find, BasicInfo: basicInfo})
+	if err != nil {
+		return "", err
+	}
+
+	return basicInfo.Vendor, nil
+}
+
+var authResTypes = map[enumor.CloudResourceType]meta.ResourceType{
+	enumor.CvmCloudResType:           meta.Cvm,
+	enumor.SecurityGroupCloudResType: meta.SecurityGroup,
+	enumor.VpcCloudResType:           meta.Vpc,
+	enumor.SubnetCloudResType:        meta.Subnet,
+}
@@ -20,6 +20,7 @@ package disk
 import (
 	"net/http"
 
+	logicssavedfilter "hcm/cmd/cloud-server/logics/saved-filter"
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/pkg/rest"
 )
@@ -27,10 +28,11 @@ import (
 // InitDiskService initialize the disk service.
 func InitDiskService(c *capability.Capability) {
 	svc := &diskSvc{
-		client:     c.ApiClient,
-		authorizer: c.Authorizer,
-		audit:      c.Audit,
-		diskLgc:    c.Logics.Disk,
+		client:         c.ApiClient,
+		authorizer:     c.Authorizer,
+		audit:          c.Audit,
+		diskLgc:        c.Logics.Disk,
+		savedFilterLgc: logicssavedfilter.NewSavedFilter(c.ApiClient, c.Authorizer),
 	}
 
 	h := rest.NewHandler()
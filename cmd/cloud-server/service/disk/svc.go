@@ -27,6 +27,7 @@ import (
 
 	"hcm/cmd/cloud-server/logics/audit"
 	disklgc "hcm/cmd/cloud-server/logics/disk"
+	logicssavedfilter "hcm/cmd/cloud-server/logics/saved-filter"
 	cloudproto "hcm/pkg/api/cloud-server/disk"
 	"hcm/pkg/api/core"
 	"hcm/pkg/api/data-service/cloud"
@@ -46,10 +47,11 @@ import (
 )
 
 type diskSvc struct {
-	client     *client.ClientSet
-	authorizer auth.Authorizer
-	audit      audit.Interface
-	diskLgc    disklgc.Interface
+	client         *client.ClientSet
+	authorizer     auth.Authorizer
+	audit          audit.Interface
+	diskLgc        disklgc.Interface
+	savedFilterLgc logicssavedfilter.Interface
 }
 
 // ListDisk list disk.
@@ -71,6 +73,12 @@ func (svc *diskSvc) listDisk(cts *rest.Contexts, authHandler handler.ListAuthRes
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	filterExpr, err := svc.savedFilterLgc.MergeFilter(cts.Kit, req.SavedFilterID, enumor.DiskCloudResType, req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	req.Filter = filterExpr
+
 	// list authorized instances
 	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{
 		Authorizer: svc.authorizer, ResType: meta.Disk, Action: meta.Find, Filter: req.Filter})
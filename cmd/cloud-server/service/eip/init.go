@@ -22,6 +22,7 @@ package eip
 import (
 	"net/http"
 
+	logicssavedfilter "hcm/cmd/cloud-server/logics/saved-filter"
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/cmd/cloud-server/service/eip/aws"
 	"hcm/cmd/cloud-server/service/eip/azure"
@@ -34,20 +35,23 @@ import (
 // InitEipService initialize the eip service.
 func InitEipService(c *capability.Capability) {
 	svc := &eipSvc{
-		client:     c.ApiClient,
-		authorizer: c.Authorizer,
-		audit:      c.Audit,
-		tcloud:     tcloud.NewTCloud(c.ApiClient, c.Authorizer, c.Audit),
-		aws:        aws.NewAws(c.ApiClient, c.Authorizer, c.Audit),
-		azure:      azure.NewAzure(c.ApiClient, c.Authorizer, c.Audit),
-		gcp:        gcp.NewGcp(c.ApiClient, c.Authorizer, c.Audit),
-		huawei:     huawei.NewHuaWei(c.ApiClient, c.Authorizer, c.Audit),
-		eip:        c.Logics.Eip,
+		client:         c.ApiClient,
+		authorizer:     c.Authorizer,
+		audit:          c.Audit,
+		tcloud:         tcloud.NewTCloud(c.ApiClient, c.Authorizer, c.Audit),
+		aws:            aws.NewAws(c.ApiClient, c.Authorizer, c.Audit),
+		azure:          azure.NewAzure(c.ApiClient, c.Authorizer, c.Audit),
+		gcp:            gcp.NewGcp(c.ApiClient, c.Authorizer, c.Audit),
+		huawei:         huawei.NewHuaWei(c.ApiClient, c.Authorizer, c.Audit),
+		eip:            c.Logics.Eip,
+		quotaLgc:       c.Logics.Quota,
+		savedFilterLgc: logicssavedfilter.NewSavedFilter(c.ApiClient, c.Authorizer),
 	}
 
 	h := rest.NewHandler()
 
 	h.Add("ListEip", http.MethodPost, "/eips/list", svc.ListEip)
+	h.Add("ExportEip", http.MethodPost, "/eips/export", svc.ExportEip)
 	h.Add("RetrieveEip", http.MethodGet, "/eips/{id}", svc.RetrieveEip)
 	h.Add("AssignEip", http.MethodPost, "/eips/assign/bizs", svc.AssignEip)
 	h.Add("BatchDeleteEip", http.MethodDelete, "/eips/batch", svc.BatchDeleteEip)
@@ -58,8 +62,14 @@ func InitEipService(c *capability.Capability) {
 	h.Add("DisassociateEip", http.MethodPost, "/eips/disassociate", svc.DisassociateEip)
 	h.Add("CreateEip", http.MethodPost, "/eips/create", svc.CreateEip)
 
+	// eip recycle apis
+	h.Add("RecycleEip", http.MethodPost, "/eips/recycle", svc.RecycleEip)
+	h.Add("RecoverEip", http.MethodPost, "/eips/recover", svc.RecoverEip)
+	h.Add("BatchDeleteRecycledEip", http.MethodDelete, "/recycled/eips/batch", svc.BatchDeleteRecycledEip)
+
 	// eip apis in biz
 	h.Add("ListBizEip", http.MethodPost, "/bizs/{bk_biz_id}/eips/list", svc.ListBizEip)
+	h.Add("ExportBizEip", http.MethodPost, "/bizs/{bk_biz_id}/eips/export", svc.ExportBizEip)
 	h.Add("ListBizEipExtByCvmID", http.MethodGet, "/bizs/{bk_biz_id}/vendors/{vendor}/eips/cvms/{cvm_id}",
 		svc.ListBizEipExtByCvmID)
 	h.Add("ListBizRelEipWithoutCvm", http.MethodPost, "/bizs/{bk_biz_id}/eip_cvm_rels/with/eips/without/cvm/list",
@@ -70,5 +80,11 @@ func InitEipService(c *capability.Capability) {
 	h.Add("DisassociateBizEip", http.MethodPost, "/bizs/{bk_biz_id}/eips/disassociate", svc.DisassociateBizEip)
 	h.Add("CreateBizEip", http.MethodPost, "/bizs/{bk_biz_id}/eips/create", svc.CreateBizEip)
 
+	// eip recycle apis in biz
+	h.Add("RecycleBizEip", http.MethodPost, "/bizs/{bk_biz_id}/eips/recycle", svc.RecycleBizEip)
+	h.Add("RecoverBizEip", http.MethodPost, "/bizs/{bk_biz_id}/eips/recover", svc.RecoverBizEip)
+	h.Add("BatchDeleteBizRecycledEip", http.MethodDelete, "/bizs/{bk_biz_id}/recycled/eips/batch",
+		svc.BatchDeleteBizRecycledEip)
+
 	h.Load(c.WebService)
 }
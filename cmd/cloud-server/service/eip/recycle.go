@@ -0,0 +1,326 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package eip
+
+import (
+	"fmt"
+
+	"hcm/cmd/cloud-server/logics/recycle"
+	cseip "hcm/pkg/api/cloud-server/eip"
+	csrecycle "hcm/pkg/api/cloud-server/recycle"
+	"hcm/pkg/api/core"
+	corerr "hcm/pkg/api/core/recycle-record"
+	protoaudit "hcm/pkg/api/data-service/audit"
+	"hcm/pkg/api/data-service/cloud"
+	dsrr "hcm/pkg/api/data-service/recycle-record"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// RecycleEip recycle eip.
+func (svc *eipSvc) RecycleEip(cts *rest.Contexts) (interface{}, error) {
+	return svc.recycleEipSvc(cts, handler.ResOperateAuth)
+}
+
+// RecycleBizEip recycle biz eip.
+func (svc *eipSvc) RecycleBizEip(cts *rest.Contexts) (interface{}, error) {
+	return svc.recycleEipSvc(cts, handler.BizOperateAuth)
+}
+
+func (svc *eipSvc) recycleEipSvc(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{}, error) {
+	req := new(cseip.EipRecycleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	ids := make([]string, 0, len(req.Infos))
+	auditInfos := make([]protoaudit.CloudResRecycleAuditInfo, 0, len(req.Infos))
+	for _, info := range req.Infos {
+		ids = append(ids, info.ID)
+		auditInfos = append(auditInfos,
+			protoaudit.CloudResRecycleAuditInfo{ResID: info.ID, Data: info.EipRecycleOptions})
+	}
+
+	basicInfoReq := cloud.ListResourceBasicInfoReq{
+		ResourceType: enumor.EipCloudResType,
+		IDs:          ids,
+		Fields:       append(types.CommonBasicInfoFields, "recycle_status"),
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Eip,
+		Action: meta.Recycle, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	// 绑定中的eip需要先解绑才能回收，避免回收站中的eip仍在提供服务
+	relRes, err := svc.client.DataService().Global.ListEipCvmRel(cts.Kit, &core.ListReq{
+		Filter: tools.ContainersExpression("eip_id", ids),
+		Page:   &core.BasePage{Count: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if converter.PtrToVal(relRes.Count) > 0 {
+		return nil, errf.New(errf.InvalidParameter, "associated eip can not be recycled, please disassociate it first")
+	}
+
+	// create recycle audit
+	auditReq := &protoaudit.CloudResourceRecycleAuditReq{
+		ResType: enumor.EipAuditResType,
+		Action:  protoaudit.Recycle,
+		Infos:   auditInfos,
+	}
+	if err = svc.audit.ResRecycleAudit(cts.Kit, auditReq); err != nil {
+		logs.Errorf("create recycle audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// create recycle record, the eip is deleted from cloud by recycle timing task after the retention time
+	opt := &dsrr.BatchRecycleReq{
+		ResType:            enumor.EipCloudResType,
+		DefaultRecycleTime: cc.CloudServer().Recycle.AutoDeleteTime,
+		Infos:              make([]dsrr.RecycleReq, 0, len(req.Infos)),
+	}
+	for _, info := range req.Infos {
+		opt.Infos = append(opt.Infos, dsrr.RecycleReq{
+			ID:     info.ID,
+			Detail: info.EipRecycleOptions,
+		})
+	}
+
+	taskID, err := svc.client.DataService().Global.RecycleRecord.BatchRecycleCloudRes(cts.Kit, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &csrecycle.RecycleResult{TaskID: taskID}, nil
+}
+
+// validateRecycleRecord 只能批量处理处于同一个回收任务的且是等待回收的记录。
+func (svc *eipSvc) validateRecycleRecord(records *dsrr.ListResult) error {
+	taskID := ""
+	for _, one := range records.Details {
+		if len(taskID) == 0 {
+			taskID = one.TaskID
+		} else if taskID != one.TaskID {
+			return fmt.Errorf("only eips in one task can be reclaimed at the same time")
+		}
+
+		if one.Status != enumor.WaitingRecycleRecordStatus {
+			return fmt.Errorf("record: %s not is wait_recycle status", one.ID)
+		}
+
+		if one.ResType != enumor.EipCloudResType {
+			return fmt.Errorf("record: %s not is eip recycle record", one.ID)
+		}
+		if one.RecycleType == enumor.RecycleTypeRelated {
+			return fmt.Errorf("related recycled eip(%s) can not be operated", one.ResID)
+		}
+	}
+
+	return nil
+}
+
+func (svc *eipSvc) listRecycleRecords(cts *rest.Contexts, recordIDs []string) (*dsrr.ListResult, error) {
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("id", recordIDs),
+		Page:   &core.BasePage{Limit: constant.BatchOperationMaxLimit},
+	}
+	records, err := svc.client.DataService().Global.RecycleRecord.ListRecycleRecord(cts.Kit, listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records.Details) != len(recordIDs) {
+		return nil, errf.New(errf.InvalidParameter, "some record_ids are not in recycle bin")
+	}
+
+	if err = svc.validateRecycleRecord(records); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return records, nil
+}
+
+// RecoverEip recover eip.
+func (svc *eipSvc) RecoverEip(cts *rest.Contexts) (interface{}, error) {
+	return svc.recoverEip(cts, handler.ResOperateAuth)
+}
+
+// RecoverBizEip recover biz eip.
+func (svc *eipSvc) RecoverBizEip(cts *rest.Contexts) (interface{}, error) {
+	return svc.recoverEip(cts, handler.BizOperateAuth)
+}
+
+func (svc *eipSvc) recoverEip(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{}, error) {
+	req := new(cseip.EipRecoverReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	records, err := svc.listRecycleRecords(cts, req.RecordIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	eipIDs := make([]string, 0, len(records.Details))
+	auditInfos := make([]protoaudit.CloudResRecycleAuditInfo, 0, len(records.Details))
+	for _, record := range records.Details {
+		eipIDs = append(eipIDs, record.ResID)
+		auditInfos = append(auditInfos, protoaudit.CloudResRecycleAuditInfo{ResID: record.ResID, Data: record.Detail})
+	}
+
+	basicInfoReq := cloud.ListResourceBasicInfoReq{
+		ResourceType: enumor.EipCloudResType,
+		IDs:          eipIDs,
+		Fields:       append(types.CommonBasicInfoFields, "recycle_status"),
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Eip,
+		Action: meta.Recover, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	// create recover audit
+	auditReq := &protoaudit.CloudResourceRecycleAuditReq{
+		ResType: enumor.EipAuditResType,
+		Action:  protoaudit.Recover,
+		Infos:   auditInfos,
+	}
+	if err = svc.audit.ResRecycleAudit(cts.Kit, auditReq); err != nil {
+		logs.Errorf("create recycle audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	opt := &dsrr.BatchRecoverReq{
+		ResType:   enumor.EipCloudResType,
+		RecordIDs: req.RecordIDs,
+	}
+	if err = svc.client.DataService().Global.RecycleRecord.BatchRecoverCloudResource(cts.Kit, opt); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchDeleteRecycledEip batch delete recycled eips.
+func (svc *eipSvc) BatchDeleteRecycledEip(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteRecycledEip(cts, handler.ResOperateAuth)
+}
+
+// BatchDeleteBizRecycledEip batch delete biz recycled eips.
+func (svc *eipSvc) BatchDeleteBizRecycledEip(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteRecycledEip(cts, handler.BizOperateAuth)
+}
+
+func (svc *eipSvc) batchDeleteRecycledEip(cts *rest.Contexts,
+	validHandler handler.ValidWithAuthHandler) (interface{}, error) {
+
+	req := new(cseip.EipDeleteRecycleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	records, err := svc.listRecycleRecords(cts, req.RecordIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	opRet := new(core.BatchOperateResult)
+	var recycleErr error
+	for _, record := range records.Details {
+		recycleErr = svc.destroyOneRecord(cts, validHandler, record)
+		if recycleErr != nil {
+			logs.Errorf("fail to destroy eip recycle record(%s), err: %v, rid:%s", record.ID, recycleErr, cts.Kit.Rid)
+
+			opRet.Failed = &core.FailedInfo{ID: record.ID, Error: recycleErr}
+			if ef := errf.Error(recycleErr); ef != nil && ef.Code == errf.RecordNotFound {
+				logicsrecycle.MarkRecordFailed(cts.Kit, svc.client.DataService(), recycleErr, []string{record.ID})
+			}
+			break
+		}
+		opRet.Succeeded = append(opRet.Succeeded, record.ID)
+	}
+
+	if len(opRet.Succeeded) > 0 {
+		logicsrecycle.MarkRecordSuccess(cts.Kit, svc.client.DataService(), opRet.Succeeded)
+	}
+	return opRet, recycleErr
+}
+
+func (svc *eipSvc) destroyOneRecord(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	record corerr.RecycleRecord) error {
+
+	basicInfoReq := cloud.ListResourceBasicInfoReq{
+		ResourceType: enumor.EipCloudResType,
+		IDs:          []string{record.ResID},
+		Fields:       append(types.CommonBasicInfoFields, "recycle_status"),
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Eip,
+		Action: meta.Destroy, BasicInfos: basicInfoMap})
+	if err != nil {
+		return err
+	}
+
+	if _, err = svc.eip.DeleteRecycledEip(cts.Kit, basicInfoMap); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -25,6 +25,8 @@ import (
 	"hcm/cmd/cloud-server/logics/async"
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/logics/eip"
+	"hcm/cmd/cloud-server/logics/quota"
+	logicssavedfilter "hcm/cmd/cloud-server/logics/saved-filter"
 	"hcm/cmd/cloud-server/service/common"
 	"hcm/cmd/cloud-server/service/eip/aws"
 	"hcm/cmd/cloud-server/service/eip/azure"
@@ -54,15 +56,17 @@ import (
 )
 
 type eipSvc struct {
-	client     *client.ClientSet
-	authorizer auth.Authorizer
-	audit      audit.Interface
-	tcloud     *tcloud.TCloud
-	aws        *aws.Aws
-	azure      *azure.Azure
-	gcp        *gcp.Gcp
-	huawei     *huawei.HuaWei
-	eip        eip.Interface
+	client         *client.ClientSet
+	authorizer     auth.Authorizer
+	audit          audit.Interface
+	tcloud         *tcloud.TCloud
+	aws            *aws.Aws
+	azure          *azure.Azure
+	gcp            *gcp.Gcp
+	huawei         *huawei.HuaWei
+	eip            eip.Interface
+	quotaLgc       quota.Interface
+	savedFilterLgc logicssavedfilter.Interface
 }
 
 // ListEip list eip.
@@ -85,6 +89,12 @@ func (svc *eipSvc) listEip(cts *rest.Contexts, authHandler handler.ListAuthResHa
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	filterExpr, err := svc.savedFilterLgc.MergeFilter(cts.Kit, req.SavedFilterID, enumor.EipCloudResType, req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	req.Filter = filterExpr
+
 	// list authorized instances
 	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{
 		Authorizer: svc.authorizer,
@@ -342,6 +352,15 @@ func (svc *eipSvc) createEip(cts *rest.Contexts, bizID int64,
 		return nil, err
 	}
 
+	// 业务下申请弹性IP时校验业务资源配额，校验到创建完成期间加锁，避免同业务并发申请超出配额
+	if bizID != constant.UnassignedBiz {
+		unlock := svc.quotaLgc.LockBiz(bizID)
+		defer unlock()
+		if err = svc.checkEipQuota(cts, bizID); err != nil {
+			return nil, err
+		}
+	}
+
 	// 查询该账号对应的Vendor
 	baseInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit,
 		enumor.AccountCloudResType, accountID)
@@ -365,6 +384,23 @@ func (svc *eipSvc) createEip(cts *rest.Contexts, bizID int64,
 	}
 }
 
+// checkEipQuota 校验申请的弹性IP数量是否超出业务配额，未指定 eip_count 的云厂商每次申请一个
+func (svc *eipSvc) checkEipQuota(cts *rest.Contexts, bizID int64) error {
+	req := new(struct {
+		EipCount *int64 `json:"eip_count"`
+	})
+	if err := cts.ReDecodeInto(req); err != nil {
+		return errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	count := int64(1)
+	if req.EipCount != nil {
+		count = *req.EipCount
+	}
+
+	return svc.quotaLgc.CheckEipQuota(cts.Kit, bizID, count)
+}
+
 func (svc *eipSvc) authorizeEipAssignOp(kt *kit.Kit, ids []string) error {
 	basicInfoReq := cloud.ListResourceBasicInfoReq{
 		ResourceType: enumor.EipCloudResType,
@@ -0,0 +1,47 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package errorcode error code catalog service, the frontend and integrators branch on the error codes and the cloud
+// vendor sub codes published here instead of parsing the error messages.
+package errorcode
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the error code catalog service.
+func InitService(c *capability.Capability) {
+	h := rest.NewHandler()
+
+	h.Add("ListErrorCode", http.MethodGet, "/error_codes", ListErrorCode)
+
+	h.Load(c.WebService)
+}
+
+// ListErrorCode list all the error codes with the description in the language of the request, the catalog is
+// not sensitive, so no permission is required.
+func ListErrorCode(cts *rest.Contexts) (interface{}, error) {
+	codes := errf.Catalog(cts.Kit.Language)
+	return &core.ListResultT[errf.CodeInfo]{Count: uint64(len(codes)), Details: codes}, nil
+}
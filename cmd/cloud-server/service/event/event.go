@@ -0,0 +1,148 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package event resource change event service, integrators pull the change events of a resource type and commit the
+// consumed offset, the events are delivered at least once.
+package event
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	csevent "hcm/pkg/api/cloud-server/event"
+	"hcm/pkg/api/core"
+	dataevent "hcm/pkg/api/data-service/event"
+	"hcm/pkg/cdc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// InitService initialize the resource change event service.
+func InitService(c *capability.Capability) {
+	svc := &eventSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("PullEvent", http.MethodPost, "/events/pull", svc.PullEvent)
+	h.Add("CommitEventOffset", http.MethodPost, "/events/offsets/commit", svc.CommitEventOffset)
+	h.Add("ListEventOffset", http.MethodPost, "/events/offsets/list", svc.ListEventOffset)
+
+	h.Load(c.WebService)
+}
+
+type eventSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// PullEvent pull resource change events, only the events of the accounts that the user has permission to view the
+// operation records are returned.
+func (svc *eventSvc) PullEvent(cts *rest.Contexts) (interface{}, error) {
+	req := new(csevent.PullReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	expr, err := svc.authFilter(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	pullReq := &dataevent.PullReq{
+		Consumer: req.Consumer,
+		Topic:    req.Topic,
+		Offset:   req.Offset,
+		Limit:    req.Limit,
+		Filter:   expr,
+	}
+	result, err := svc.client.DataService().Global.Event.Pull(cts.Kit, pullReq)
+	if err != nil {
+		return nil, err
+	}
+	if result.Details == nil {
+		result.Details = make([]cdc.Event, 0)
+	}
+
+	return result, nil
+}
+
+// CommitEventOffset commit the consumed offset, events after the offset are returned in the next pull.
+func (svc *eventSvc) CommitEventOffset(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataevent.OffsetCommitReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if _, err := svc.authFilter(cts); err != nil {
+		return nil, err
+	}
+
+	return nil, svc.client.DataService().Global.Event.CommitOffset(cts.Kit, req)
+}
+
+// ListEventOffset list the committed offsets of consumers.
+func (svc *eventSvc) ListEventOffset(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if _, err := svc.authFilter(cts); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.Event.ListOffset(cts.Kit, req)
+}
+
+// authFilter events are the operation records of resources, return the filter of the operation records that the
+// user has permission to view.
+func (svc *eventSvc) authFilter(cts *rest.Contexts) (*filter.Expression, error) {
+	expr, noPerm, err := handler.ListResourceAuthRes(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.Audit, Action: meta.Find, Filter: tools.AllExpression()})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPerm {
+		return nil, errf.New(errf.PermissionDenied, "no permission to view operation records")
+	}
+
+	return expr, nil
+}
@@ -0,0 +1,74 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package idleresource
+
+import (
+	"time"
+
+	"hcm/cmd/cloud-server/logics/tenant"
+	"hcm/pkg/api/core"
+	dataidle "hcm/pkg/api/data-service/idle-resource"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/serviced"
+
+	"github.com/shopspring/decimal"
+)
+
+// IdleResourceDetectTiming 定时检测未挂载硬盘、未绑定弹性IP、长期关机主机及未使用的安全组，并预估每月费用
+func IdleResourceDetectTiming(interval time.Duration, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	logs.Infof("idle resource detect enable && start, interval: %v", interval)
+
+	for {
+		time.Sleep(interval)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		_ = tenant.ForEach(core.NewBackendKit(), cliSet.DataService(), func(kt *kit.Kit) {
+			start := time.Now()
+			req := NewDetectReq(cc.CloudServer().IdleResource)
+			result, err := cliSet.DataService().Global.IdleResource.Detect(kt, req)
+			if err != nil {
+				logs.Errorf("detect tenant %s idle resource failed, err: %v, rid: %s", kt.TenantID, err, kt.Rid)
+				return
+			}
+			logs.Infof("detect tenant %s idle resource end, counts: %v, cost: %v, rid: %s", kt.TenantID,
+				result.Counts, time.Since(start), kt.Rid)
+		})
+	}
+}
+
+// NewDetectReq build idle resource detect request from config.
+func NewDetectReq(conf cc.IdleResource) *dataidle.IdleDetectReq {
+	return &dataidle.IdleDetectReq{
+		StoppedCvmDays: conf.StoppedCvmDays,
+		Currency:       enumor.CurrencyCode(conf.Currency),
+		Price: dataidle.IdlePrice{
+			DiskGBMonthly:     decimal.NewFromFloat(conf.Price.DiskGBMonthly),
+			EipMonthly:        decimal.NewFromFloat(conf.Price.EipMonthly),
+			CvmCpuCoreMonthly: decimal.NewFromFloat(conf.Price.CvmCpuCoreMonthly),
+		},
+	}
+}
@@ -0,0 +1,151 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package idleresource idle resource detection and remediation service.
+package idleresource
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	logicscvm "hcm/cmd/cloud-server/logics/cvm"
+	"hcm/cmd/cloud-server/service/capability"
+	csidle "hcm/pkg/api/cloud-server/idle-resource"
+	"hcm/pkg/api/core"
+	coreidle "hcm/pkg/api/core/idle-resource"
+	dataidle "hcm/pkg/api/data-service/idle-resource"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the idle resource service.
+func InitService(c *capability.Capability) {
+	svc := &idleSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		audit:      c.Audit,
+		cvmLgc:     c.Logics.Cvm,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListIdleResource", http.MethodPost, "/idle_resources/list", svc.ListIdleResource)
+	h.Add("DetectIdleResource", http.MethodPost, "/idle_resources/detect", svc.DetectIdleResource)
+	h.Add("BatchUpdateIdleResourceStatus", http.MethodPatch, "/idle_resources/status/batch",
+		svc.BatchUpdateIdleResourceStatus)
+	h.Add("RemediateIdleResource", http.MethodPost, "/idle_resources/remediate", svc.RemediateIdleResource)
+
+	h.Load(c.WebService)
+}
+
+type idleSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	audit      audit.Interface
+	cvmLgc     logicscvm.Interface
+}
+
+// ListIdleResource list idle resource.
+func (svc *idleSvc) ListIdleResource(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.IdleResource.List(cts.Kit, req)
+}
+
+// DetectIdleResource detect idle resource immediately with the configured price.
+func (svc *idleSvc) DetectIdleResource(cts *rest.Contexts) (interface{}, error) {
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.IdleResource.Detect(cts.Kit, NewDetectReq(cc.CloudServer().IdleResource))
+}
+
+// BatchUpdateIdleResourceStatus ignore idle resource or restore it to pending.
+func (svc *idleSvc) BatchUpdateIdleResourceStatus(cts *rest.Contexts) (interface{}, error) {
+	req := new(csidle.IdleStatusUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	updateReq := &dataidle.IdleStatusUpdateReq{IDs: req.IDs, Status: req.Status}
+	if err := svc.client.DataService().Global.IdleResource.BatchUpdateStatus(cts.Kit, updateReq); err != nil {
+		logs.Errorf("update idle resource status failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (svc *idleSvc) authorize(cts *rest.Contexts, action meta.Action) error {
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.IdleResource, Action: action}}
+	return svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes)
+}
+
+// listIdleResource list idle resource by ids.
+func (svc *idleSvc) listIdleResource(cts *rest.Contexts, ids []string) ([]coreidle.IdleResource, error) {
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.client.DataService().Global.IdleResource.List(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list idle resource failed, err: %v, ids: %v, rid: %s", err, ids, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) != len(ids) {
+		return nil, errf.New(errf.InvalidParameter, "some idle resources not found, please detect again")
+	}
+
+	for _, one := range result.Details {
+		if one.Status != enumor.IdleResourcePending {
+			return nil, errf.Newf(errf.InvalidParameter, "idle resource %s is %s, only pending one can be remediated",
+				one.ID, one.Status)
+		}
+	}
+
+	return result.Details, nil
+}
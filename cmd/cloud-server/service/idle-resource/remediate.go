@@ -0,0 +1,222 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package idleresource
+
+import (
+	actionsg "hcm/cmd/task-server/logics/action/security-group"
+	proto "hcm/pkg/api/cloud-server/cvm"
+	csidle "hcm/pkg/api/cloud-server/idle-resource"
+	coreidle "hcm/pkg/api/core/idle-resource"
+	corerr "hcm/pkg/api/core/recycle-record"
+	protoaudit "hcm/pkg/api/data-service/audit"
+	"hcm/pkg/api/data-service/cloud"
+	dataidle "hcm/pkg/api/data-service/idle-resource"
+	dsrr "hcm/pkg/api/data-service/recycle-record"
+	ts "hcm/pkg/api/task-server"
+	"hcm/pkg/async/action"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/counter"
+	"hcm/pkg/tools/slice"
+)
+
+// RemediateIdleResource 一键清理闲置资源，硬盘、弹性IP、主机通过回收站回收，安全组通过异步任务删除，提交成功的闲置资源标记为清理中
+func (svc *idleSvc) RemediateIdleResource(cts *rest.Contexts) (interface{}, error) {
+	req := new(csidle.IdleRemediateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	idles, err := svc.listIdleResource(cts, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resTypeIdles := make(map[enumor.CloudResourceType][]coreidle.IdleResource)
+	for _, one := range idles {
+		resTypeIdles[one.ResType] = append(resTypeIdles[one.ResType], one)
+	}
+
+	result := &csidle.IdleRemediateResult{
+		RecycleTaskIDs: make([]string, 0),
+		FlowIDs:        make([]string, 0),
+		Succeeded:      make([]string, 0),
+		Failed:         make([]csidle.IdleRemediateFailed, 0),
+	}
+	for _, resType := range []enumor.CloudResourceType{enumor.DiskCloudResType, enumor.EipCloudResType,
+		enumor.CvmCloudResType, enumor.SecurityGroupCloudResType} {
+
+		group, exists := resTypeIdles[resType]
+		if !exists {
+			continue
+		}
+
+		resIDs := slice.Map(group, func(one coreidle.IdleResource) string { return one.ResID })
+		var taskID string
+		switch resType {
+		case enumor.DiskCloudResType, enumor.EipCloudResType:
+			taskID, err = svc.recycleRes(cts.Kit, resType, resIDs)
+		case enumor.CvmCloudResType:
+			taskID, err = svc.recycleCvm(cts.Kit, resIDs)
+		case enumor.SecurityGroupCloudResType:
+			taskID, err = svc.deleteSecurityGroup(cts.Kit, resIDs)
+		}
+
+		ids := slice.Map(group, func(one coreidle.IdleResource) string { return one.ID })
+		if err != nil {
+			logs.Errorf("remediate idle resource failed, err: %v, res_type: %s, res_ids: %v, rid: %s", err, resType,
+				resIDs, cts.Kit.Rid)
+			for _, id := range ids {
+				result.Failed = append(result.Failed, csidle.IdleRemediateFailed{ID: id, Error: err.Error()})
+			}
+			continue
+		}
+
+		if resType == enumor.SecurityGroupCloudResType {
+			result.FlowIDs = append(result.FlowIDs, taskID)
+		} else {
+			result.RecycleTaskIDs = append(result.RecycleTaskIDs, taskID)
+		}
+		result.Succeeded = append(result.Succeeded, ids...)
+	}
+
+	if len(result.Succeeded) == 0 {
+		return result, nil
+	}
+
+	updateReq := &dataidle.IdleStatusUpdateReq{IDs: result.Succeeded, Status: enumor.IdleResourceRemediating}
+	if err = svc.client.DataService().Global.IdleResource.BatchUpdateStatus(cts.Kit, updateReq); err != nil {
+		// 资源已提交清理，状态更新失败不影响清理结果，下次检测时会同步
+		logs.Errorf("update idle resource status to remediating failed, err: %v, ids: %v, rid: %s", err,
+			result.Succeeded, cts.Kit.Rid)
+	}
+
+	return result, nil
+}
+
+// recycleRes recycle unattached disk or unbound eip to recycle bin, returns recycle task id.
+func (svc *idleSvc) recycleRes(kt *kit.Kit, resType enumor.CloudResourceType, ids []string) (string, error) {
+	var auditResType enumor.AuditResourceType
+	var detail interface{}
+	switch resType {
+	case enumor.DiskCloudResType:
+		auditResType, detail = enumor.DiskAuditResType, &corerr.DiskRecycleOptions{}
+	case enumor.EipCloudResType:
+		auditResType, detail = enumor.EipAuditResType, &corerr.EipRecycleOptions{}
+	default:
+		return "", errf.Newf(errf.InvalidParameter, "unsupported recycle resource type: %s", resType)
+	}
+
+	auditReq := &protoaudit.CloudResourceRecycleAuditReq{
+		ResType: auditResType,
+		Action:  protoaudit.Recycle,
+		Infos: slice.Map(ids, func(id string) protoaudit.CloudResRecycleAuditInfo {
+			return protoaudit.CloudResRecycleAuditInfo{ResID: id, Data: detail}
+		}),
+	}
+	if err := svc.audit.ResRecycleAudit(kt, auditReq); err != nil {
+		logs.Errorf("create recycle audit failed, err: %v, rid: %s", err, kt.Rid)
+		return "", err
+	}
+
+	opt := &dsrr.BatchRecycleReq{
+		ResType:            resType,
+		DefaultRecycleTime: cc.CloudServer().Recycle.AutoDeleteTime,
+		Infos: slice.Map(ids, func(id string) dsrr.RecycleReq {
+			return dsrr.RecycleReq{ID: id, Detail: detail}
+		}),
+	}
+	return svc.client.DataService().Global.RecycleRecord.BatchRecycleCloudRes(kt, opt)
+}
+
+// recycleCvm recycle long stopped cvm to recycle bin with its data disks, the eip is kept and will be detected as
+// unbound eip later.
+func (svc *idleSvc) recycleCvm(kt *kit.Kit, ids []string) (string, error) {
+	basicInfoReq := cloud.ListResourceBasicInfoReq{
+		ResourceType: enumor.CvmCloudResType,
+		IDs:          ids,
+		Fields:       append(types.CommonBasicInfoFields, "region", "recycle_status"),
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(kt, basicInfoReq)
+	if err != nil {
+		return "", err
+	}
+
+	infos := slice.Map(ids, func(id string) proto.CvmRecycleInfo {
+		return proto.CvmRecycleInfo{ID: id, CvmRecycleOptions: corerr.CvmRecycleOptions{WithDisk: true}}
+	})
+	return svc.cvmLgc.RecycleCvm(kt, infos, basicInfoMap)
+}
+
+// deleteSecurityGroup delete unused security group by async flow, returns flow id.
+func (svc *idleSvc) deleteSecurityGroup(kt *kit.Kit, ids []string) (string, error) {
+	basicInfoReq := cloud.ListResourceBasicInfoReq{
+		ResourceType: enumor.SecurityGroupCloudResType,
+		IDs:          ids,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(kt, basicInfoReq)
+	if err != nil {
+		return "", err
+	}
+
+	if err = svc.audit.ResDeleteAudit(kt, enumor.SecurityGroupAuditResType, ids); err != nil {
+		logs.Errorf("create delete audit failed, err: %v, rid: %s", err, kt.Rid)
+		return "", err
+	}
+
+	tasks := make([]ts.CustomFlowTask, 0, len(basicInfoMap))
+	nextID := counter.NewNumStringCounter(1, 10)
+	for _, info := range basicInfoMap {
+		tasks = append(tasks, ts.CustomFlowTask{
+			ActionID:   action.ActIDType(nextID()),
+			ActionName: enumor.ActionDeleteSecurityGroup,
+			Params: actionsg.DeleteSGOption{
+				Vendor: info.Vendor,
+				ID:     info.ID,
+			},
+		})
+	}
+	flowReq := &ts.AddCustomFlowReq{
+		Name:  enumor.FlowDeleteSecurityGroup,
+		Tasks: tasks,
+	}
+
+	result, err := svc.client.TaskServer().CreateCustomFlow(kt, flowReq)
+	if err != nil {
+		logs.Errorf("call taskserver to create custom flow failed, err: %v, rid: %s", err, kt.Rid)
+		return "", err
+	}
+
+	return result.ID, nil
+}
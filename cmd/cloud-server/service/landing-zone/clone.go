@@ -0,0 +1,391 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package landingzone
+
+import (
+	"fmt"
+
+	cslz "hcm/pkg/api/cloud-server/landing-zone"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	corelz "hcm/pkg/api/core/landing-zone"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/cidr"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/hooks/handler"
+	"hcm/pkg/tools/slice"
+)
+
+const (
+	// tcloudUserRoute 用户自定义路由，系统下发的云联网等路由不参与克隆
+	tcloudUserRoute = "USER"
+	// tcloudNatGateway 下一跳为NAT网关的路由类型
+	tcloudNatGateway = "NAT"
+	defaultRouteCidr = "0.0.0.0/0"
+)
+
+// CloneVpcLayout clone the layout (subnets, route tables and security groups with rules) of a vpc into another
+// region or account, the cloned layout is provisioned as a landing zone deployment.
+func (svc *landingZoneSvc) CloneVpcLayout(cts *rest.Contexts) (interface{}, error) {
+	req := new(cslz.CloneReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeClone(cts, req); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.buildCloneSpec(cts.Kit, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = result.Spec.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("cloned spec is invalid, err: %v", err))
+	}
+
+	if req.DryRun {
+		return result, nil
+	}
+
+	createReq := &cslz.DeploymentCreateReq{
+		Name:      req.Name,
+		AccountID: req.TargetAccountID,
+		BkBizID:   req.TargetBkBizID,
+		Region:    req.TargetRegion,
+		Spec:      result.Spec,
+		Memo:      req.Memo,
+	}
+	createResult, err := svc.createDeployment(cts.Kit, createReq)
+	if err != nil {
+		return nil, err
+	}
+	result.ID = createResult.ID
+	result.FlowID = createResult.FlowID
+
+	return result, nil
+}
+
+// authorizeClone authorize view of the source vpc and security groups, and create of the target landing zone.
+func (svc *landingZoneSvc) authorizeClone(cts *rest.Contexts, req *cslz.CloneReq) error {
+	vpcInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit, enumor.VpcCloudResType, req.VpcID)
+	if err != nil {
+		return err
+	}
+
+	if vpcInfo.Vendor != enumor.TCloud {
+		return errf.Newf(errf.InvalidParameter, "vendor: %s does not support vpc clone", vpcInfo.Vendor)
+	}
+
+	err = handler.ResOperateAuth(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Vpc,
+		Action: meta.Find, BasicInfo: vpcInfo})
+	if err != nil {
+		return err
+	}
+
+	if len(req.SecurityGroupIDs) != 0 {
+		sgInfos, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit,
+			protocloud.ListResourceBasicInfoReq{ResourceType: enumor.SecurityGroupCloudResType,
+				IDs: slice.Unique(req.SecurityGroupIDs)})
+		if err != nil {
+			return err
+		}
+
+		for id, info := range sgInfos {
+			if info.Vendor != enumor.TCloud {
+				return errf.Newf(errf.InvalidParameter, "security group: %s is not a tcloud security group", id)
+			}
+		}
+
+		err = handler.ResOperateAuth(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer,
+			ResType: meta.SecurityGroup, Action: meta.Find, BasicInfos: sgInfos})
+		if err != nil {
+			return err
+		}
+	}
+
+	authRes := []meta.ResourceAttribute{
+		{Basic: &meta.Basic{Type: meta.LandingZone, Action: meta.Create}},
+		{Basic: &meta.Basic{Type: meta.Vpc, Action: meta.Create, ResourceID: req.TargetAccountID}},
+	}
+	return svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes...)
+}
+
+// buildCloneSpec build the landing zone deployment spec from the source vpc layout.
+func (svc *landingZoneSvc) buildCloneSpec(kt *kit.Kit, req *cslz.CloneReq) (*cslz.CloneResult, error) {
+	vpc, err := svc.client.DataService().TCloud.Vpc.Get(kt.Ctx, kt.Header(), req.VpcID)
+	if err != nil {
+		logs.Errorf("get tcloud vpc failed, err: %v, id: %s, rid: %s", err, req.VpcID, kt.Rid)
+		return nil, err
+	}
+
+	vpcCidr := ""
+	if vpc.Extension != nil {
+		for _, one := range vpc.Extension.Cidr {
+			if one.Type == enumor.Ipv4 && one.Category == enumor.MasterTCloudCidr {
+				vpcCidr = one.Cidr
+				break
+			}
+		}
+	}
+	if len(vpcCidr) == 0 {
+		return nil, errf.Newf(errf.InvalidParameter, "vpc: %s has no ipv4 cidr", req.VpcID)
+	}
+
+	result := &cslz.CloneResult{
+		Spec: &corelz.DeploymentSpec{
+			VpcName:   vpc.Name,
+			BkCloudID: req.BkCloudID,
+		},
+		Skipped: make([]string, 0),
+	}
+	if len(req.VpcName) != 0 {
+		result.Spec.VpcName = req.VpcName
+	}
+	if result.Spec.VpcCidr, err = remapCidr(vpcCidr, req.CidrMappings); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err = svc.cloneSubnets(kt, req, result); err != nil {
+		return nil, err
+	}
+
+	for _, subnet := range result.Spec.Subnets {
+		if !subnet.Private {
+			continue
+		}
+		result.Spec.NatGateway = req.NatGateway
+		if result.Spec.NatGateway == nil {
+			result.Spec.NatGateway = &corelz.NatGatewaySpec{Name: result.Spec.VpcName + "-nat", AddressCount: 1}
+		}
+		break
+	}
+
+	if err = svc.cloneSecurityGroups(kt, req, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// cloneSubnets clone subnets of the source vpc, subnets whose route table routes the internet traffic to a nat
+// gateway are cloned as private subnets, other user routes can not be cloned and are recorded as skipped.
+func (svc *landingZoneSvc) cloneSubnets(kt *kit.Kit, req *cslz.CloneReq, result *cslz.CloneResult) error {
+	listReq := &core.ListReq{Filter: tools.EqualExpression("vpc_id", req.VpcID), Page: core.NewDefaultBasePage()}
+	subnets, err := svc.client.DataService().Global.Subnet.List(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list subnet of vpc %s failed, err: %v, rid: %s", req.VpcID, err, kt.Rid)
+		return err
+	}
+
+	if len(subnets.Details) == 0 {
+		return errf.Newf(errf.InvalidParameter, "vpc: %s has no subnet", req.VpcID)
+	}
+
+	// natRouteTables route table id -> if the route table routes the internet traffic to a nat gateway
+	natRouteTables := make(map[string]bool)
+	for _, subnet := range subnets.Details {
+		if len(subnet.RouteTableID) == 0 {
+			continue
+		}
+		if _, exists := natRouteTables[subnet.RouteTableID]; exists {
+			continue
+		}
+
+		natRouteTables[subnet.RouteTableID], err = svc.cloneRouteTable(kt, subnet.RouteTableID, result)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, subnet := range subnets.Details {
+		zone, exists := req.ZoneMappings[subnet.Zone]
+		if !exists {
+			return errf.Newf(errf.InvalidParameter, "zone: %s of subnet: %s has no mapping", subnet.Zone,
+				subnet.Name)
+		}
+
+		if len(subnet.Ipv4Cidr) == 0 {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("subnet %s has no ipv4 cidr", subnet.Name))
+			continue
+		}
+
+		subnetCidr, err := remapCidr(subnet.Ipv4Cidr[0], req.CidrMappings)
+		if err != nil {
+			return errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		result.Spec.Subnets = append(result.Spec.Subnets, corelz.SubnetSpec{
+			Name:     subnet.Name,
+			Zone:     zone,
+			IPv4Cidr: subnetCidr,
+			Private:  natRouteTables[subnet.RouteTableID],
+		})
+	}
+
+	return nil
+}
+
+// cloneRouteTable return if the route table routes the internet traffic to a nat gateway.
+func (svc *landingZoneSvc) cloneRouteTable(kt *kit.Kit, routeTableID string, result *cslz.CloneResult) (bool,
+	error) {
+
+	listReq := &core.ListReq{Filter: tools.AllExpression(), Page: core.NewDefaultBasePage()}
+	routes, err := svc.client.DataService().TCloud.RouteTable.ListRoute(kt.Ctx, kt.Header(), routeTableID, listReq)
+	if err != nil {
+		logs.Errorf("list route of route table %s failed, err: %v, rid: %s", routeTableID, err, kt.Rid)
+		return false, err
+	}
+
+	isNat := false
+	for _, route := range routes.Details {
+		if !route.Enabled || route.RouteType != tcloudUserRoute {
+			continue
+		}
+
+		if route.GatewayType == tcloudNatGateway && route.DestinationCidrBlock == defaultRouteCidr {
+			isNat = true
+			continue
+		}
+
+		result.Skipped = append(result.Skipped, fmt.Sprintf("route table %s route %s -> %s(%s)",
+			route.CloudRouteTableID, route.DestinationCidrBlock, route.CloudGatewayID, route.GatewayType))
+	}
+
+	return isNat, nil
+}
+
+// cloneSecurityGroups clone security groups with their ipv4 cidr rules, rules referring to parameter templates,
+// security groups or ipv6 cidr can not be cloned and are recorded as skipped.
+func (svc *landingZoneSvc) cloneSecurityGroups(kt *kit.Kit, req *cslz.CloneReq, result *cslz.CloneResult) error {
+	ids := slice.Unique(req.SecurityGroupIDs)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	listReq := &protocloud.SecurityGroupListReq{Filter: tools.ContainersExpression("id", ids),
+		Page: core.NewDefaultBasePage()}
+	sgs, err := svc.client.DataService().Global.SecurityGroup.ListSecurityGroup(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list security group failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return err
+	}
+
+	for _, sg := range sgs.Details {
+		rules, err := svc.listSecurityGroupRules(kt, sg.ID)
+		if err != nil {
+			return err
+		}
+
+		sgSpec := corelz.SecurityGroupSpec{Name: sg.Name, Memo: sg.Memo}
+		for _, rule := range rules {
+			ruleSpec, skipped := cloneSecurityGroupRule(rule, req.CidrMappings)
+			if len(skipped) != 0 {
+				result.Skipped = append(result.Skipped, fmt.Sprintf("security group %s %s rule %d: %s",
+					sg.Name, rule.Type, rule.CloudPolicyIndex, skipped))
+				continue
+			}
+
+			switch rule.Type {
+			case enumor.Ingress:
+				sgSpec.IngressRules = append(sgSpec.IngressRules, *ruleSpec)
+			case enumor.Egress:
+				sgSpec.EgressRules = append(sgSpec.EgressRules, *ruleSpec)
+			}
+		}
+
+		result.Spec.SecurityGroups = append(result.Spec.SecurityGroups, sgSpec)
+	}
+
+	return nil
+}
+
+func (svc *landingZoneSvc) listSecurityGroupRules(kt *kit.Kit, sgID string) ([]corecloud.TCloudSecurityGroupRule,
+	error) {
+
+	rules := make([]corecloud.TCloudSecurityGroupRule, 0)
+	page := core.NewDefaultBasePage()
+	for {
+		listReq := &protocloud.TCloudSGRuleListReq{Filter: tools.EqualExpression("security_group_id", sgID),
+			Page: page}
+		result, err := svc.client.DataService().TCloud.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(),
+			listReq, sgID)
+		if err != nil {
+			logs.Errorf("list security group %s rules failed, err: %v, rid: %s", sgID, err, kt.Rid)
+			return nil, err
+		}
+		rules = append(rules, result.Details...)
+
+		if uint(len(result.Details)) < page.Limit {
+			return rules, nil
+		}
+		page.Start += uint32(page.Limit)
+	}
+}
+
+// cloneSecurityGroupRule return the cloned rule, or the reason why the rule can not be cloned.
+func cloneSecurityGroupRule(rule corecloud.TCloudSecurityGroupRule, mappings []cslz.CidrMapping) (
+	*corelz.SecurityGroupRuleSpec, string) {
+
+	if len(converter.PtrToVal(rule.IPv4Cidr)) == 0 {
+		return nil, "source or destination is not an ipv4 cidr"
+	}
+
+	if len(converter.PtrToVal(rule.Protocol)) == 0 || len(converter.PtrToVal(rule.Port)) == 0 {
+		return nil, "protocol and port refer to service template"
+	}
+
+	ruleCidr, err := remapCidr(converter.PtrToVal(rule.IPv4Cidr), mappings)
+	if err != nil {
+		return nil, err.Error()
+	}
+
+	return &corelz.SecurityGroupRuleSpec{
+		Protocol: converter.PtrToVal(rule.Protocol),
+		Port:     converter.PtrToVal(rule.Port),
+		IPv4Cidr: ruleCidr,
+		Action:   rule.Action,
+		Memo:     rule.Memo,
+	}, ""
+}
+
+// remapCidr remap the cidr by the first mapping whose source contains it, the cidr is kept if no mapping matches.
+func remapCidr(origin string, mappings []cslz.CidrMapping) (string, error) {
+	for _, mapping := range mappings {
+		if cidr.IsSubnetContained(mapping.Source, origin) != nil {
+			continue
+		}
+		return cidr.Remap(origin, mapping.Source, mapping.Target)
+	}
+
+	return origin, nil
+}
@@ -0,0 +1,179 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package landingzone
+
+import (
+	"fmt"
+	"strconv"
+
+	actionlz "hcm/cmd/task-server/logics/action/landing-zone"
+	actionflow "hcm/cmd/task-server/logics/flow"
+	cslz "hcm/pkg/api/cloud-server/landing-zone"
+	datalz "hcm/pkg/api/data-service/landing-zone"
+	ts "hcm/pkg/api/task-server"
+	"hcm/pkg/async/action"
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// natRetryLimit 创建NAT网关的任务重试次数，每次执行最多等待NAT网关可用90秒
+const natRetryLimit = 10
+
+// startDeploy create the deploy flow and its watch flow, return the deploy flow id.
+func (svc *landingZoneSvc) startDeploy(kt *kit.Kit, deploymentID string, req *cslz.DeploymentCreateReq) (string,
+	error) {
+
+	tasks := buildDeployTasks(req)
+	flowReq := &ts.AddCustomFlowReq{
+		Name: enumor.FlowLandingZoneDeploy,
+		ShareData: tableasync.NewShareData(map[string]string{
+			actionlz.DeploymentIDKey: deploymentID,
+		}),
+		Tasks: tasks,
+	}
+	flowResult, err := svc.client.TaskServer().CreateCustomFlow(kt, flowReq)
+	if err != nil {
+		logs.Errorf("create landing zone deploy flow failed, err: %v, deployment: %s, rid: %s", err, deploymentID,
+			kt.Rid)
+		return "", fmt.Errorf("create deploy flow failed, err: %v", err)
+	}
+	flowID := flowResult.ID
+
+	updateReq := &datalz.DeploymentUpdateReq{FlowID: flowID}
+	if err = svc.client.DataService().Global.LandingZoneDeployment.Update(kt, deploymentID, updateReq); err != nil {
+		logs.Errorf("update landing zone deployment %s flow id failed, err: %v, rid: %s", deploymentID, err, kt.Rid)
+		return "", err
+	}
+
+	watchReq := &ts.AddTemplateFlowReq{
+		Name: enumor.FlowLandingZoneDeployWatch,
+		Tasks: []ts.TemplateFlowTask{{
+			ActionID: "1",
+			Params: &actionflow.LandingZoneDeployWatchOption{
+				DeploymentID: deploymentID,
+				FlowID:       flowID,
+			},
+		}},
+	}
+	if _, err = svc.client.TaskServer().CreateTemplateFlow(kt, watchReq); err != nil {
+		logs.Errorf("create landing zone deploy watch flow failed, err: %v, deployment: %s, rid: %s", err,
+			deploymentID, kt.Rid)
+		return "", err
+	}
+
+	return flowID, nil
+}
+
+// buildDeployTasks build the deploy flow tasks, subnets are created after the vpc, private subnets are created after
+// the route table which routes to the nat gateway, and security groups are not bound to vpc in tencent cloud, they
+// are created in parallel.
+func buildDeployTasks(req *cslz.DeploymentCreateReq) []ts.CustomFlowTask {
+	spec := req.Spec
+	actionID := 0
+	nextID := func() action.ActIDType {
+		actionID++
+		return action.ActIDType(strconv.Itoa(actionID))
+	}
+
+	tasks := make([]ts.CustomFlowTask, 0)
+	vpcActionID := nextID()
+	tasks = append(tasks, ts.CustomFlowTask{
+		ActionID:   vpcActionID,
+		ActionName: enumor.ActionLandingZoneCreateVpc,
+		Params: &actionlz.CreateVpcOption{
+			AccountID: req.AccountID,
+			BkBizID:   req.BkBizID,
+			Region:    req.Region,
+			Name:      spec.VpcName,
+			IPv4Cidr:  spec.VpcCidr,
+			BkCloudID: spec.BkCloudID,
+			Memo:      req.Memo,
+		},
+	})
+
+	var routeTableActionID action.ActIDType
+	if spec.NatGateway != nil {
+		natActionID := nextID()
+		tasks = append(tasks, ts.CustomFlowTask{
+			ActionID:   natActionID,
+			ActionName: enumor.ActionLandingZoneCreateNatGateway,
+			Params: &actionlz.CreateNatGatewayOption{
+				AccountID:      req.AccountID,
+				Region:         req.Region,
+				NatGatewaySpec: *spec.NatGateway,
+			},
+			DependOn: []action.ActIDType{vpcActionID},
+			Retry: &tableasync.Retry{
+				Enable: true,
+				Policy: &tableasync.RetryPolicy{
+					Count:        natRetryLimit,
+					SleepRangeMS: [2]uint{1000, 2000},
+				},
+			},
+		})
+
+		routeTableActionID = nextID()
+		tasks = append(tasks, ts.CustomFlowTask{
+			ActionID:   routeTableActionID,
+			ActionName: enumor.ActionLandingZoneCreateRouteTable,
+			Params: &actionlz.CreateRouteTableOption{
+				AccountID: req.AccountID,
+				Region:    req.Region,
+				Name:      spec.VpcName + "-nat",
+			},
+			DependOn: []action.ActIDType{natActionID},
+		})
+	}
+
+	for _, subnet := range spec.Subnets {
+		dependOn := vpcActionID
+		if subnet.Private {
+			dependOn = routeTableActionID
+		}
+		tasks = append(tasks, ts.CustomFlowTask{
+			ActionID:   nextID(),
+			ActionName: enumor.ActionLandingZoneCreateSubnet,
+			Params: &actionlz.CreateSubnetOption{
+				AccountID:  req.AccountID,
+				BkBizID:    req.BkBizID,
+				Region:     req.Region,
+				SubnetSpec: subnet,
+			},
+			DependOn: []action.ActIDType{dependOn},
+		})
+	}
+
+	for _, sg := range spec.SecurityGroups {
+		tasks = append(tasks, ts.CustomFlowTask{
+			ActionID:   nextID(),
+			ActionName: enumor.ActionLandingZoneCreateSecurityGroup,
+			Params: &actionlz.CreateSecurityGroupOption{
+				AccountID:         req.AccountID,
+				BkBizID:           req.BkBizID,
+				Region:            req.Region,
+				SecurityGroupSpec: sg,
+			},
+		})
+	}
+
+	return tasks
+}
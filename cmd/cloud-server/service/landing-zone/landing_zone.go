@@ -0,0 +1,181 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package landingzone landing zone service, provision a network baseline (vpc, subnets, nat gateway, route table and
+// security groups) by one async flow, and roll back the created resources if any step of the flow failed.
+package landingzone
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	cslz "hcm/pkg/api/cloud-server/landing-zone"
+	"hcm/pkg/api/core"
+	datalz "hcm/pkg/api/data-service/landing-zone"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+)
+
+// InitService initialize the landing zone service.
+func InitService(c *capability.Capability) {
+	svc := &landingZoneSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateLandingZoneDeployment", http.MethodPost, "/landing_zones/deployments/create",
+		svc.CreateLandingZoneDeployment)
+	h.Add("ListLandingZoneDeployment", http.MethodPost, "/landing_zones/deployments/list",
+		svc.ListLandingZoneDeployment)
+	h.Add("GetLandingZoneDeployment", http.MethodGet, "/landing_zones/deployments/{id}",
+		svc.GetLandingZoneDeployment)
+	h.Add("CloneVpcLayout", http.MethodPost, "/landing_zones/clone", svc.CloneVpcLayout)
+
+	h.Load(c.WebService)
+}
+
+type landingZoneSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// CreateLandingZoneDeployment create landing zone deployment and start the deploy flow.
+func (svc *landingZoneSvc) CreateLandingZoneDeployment(cts *rest.Contexts) (interface{}, error) {
+	req := new(cslz.DeploymentCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRes := []meta.ResourceAttribute{
+		{Basic: &meta.Basic{Type: meta.LandingZone, Action: meta.Create}},
+		{Basic: &meta.Basic{Type: meta.Vpc, Action: meta.Create, ResourceID: req.AccountID}},
+	}
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes...); err != nil {
+		return nil, err
+	}
+
+	return svc.createDeployment(cts.Kit, req)
+}
+
+// createDeployment create landing zone deployment and start the deploy flow, the caller should authorize first.
+func (svc *landingZoneSvc) createDeployment(kt *kit.Kit, req *cslz.DeploymentCreateReq) (
+	*cslz.DeploymentCreateResult, error) {
+
+	info, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(kt, enumor.AccountCloudResType, req.AccountID)
+	if err != nil {
+		logs.Errorf("get account basic info failed, err: %v, account: %s, rid: %s", err, req.AccountID, kt.Rid)
+		return nil, err
+	}
+
+	if info.Vendor != enumor.TCloud {
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s does not support landing zone", info.Vendor)
+	}
+
+	createReq := &datalz.DeploymentCreateReq{
+		Name:      req.Name,
+		Vendor:    info.Vendor,
+		AccountID: req.AccountID,
+		BkBizID:   req.BkBizID,
+		Region:    req.Region,
+		Spec:      req.Spec,
+		Memo:      req.Memo,
+	}
+	result, err := svc.client.DataService().Global.LandingZoneDeployment.Create(kt, createReq)
+	if err != nil {
+		logs.Errorf("create landing zone deployment failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	flowID, err := svc.startDeploy(kt, result.ID, req)
+	if err != nil {
+		// 任务流未创建成功时没有创建任何资源，直接置为已回滚
+		updateReq := &datalz.DeploymentUpdateReq{
+			State:  enumor.LandingZoneRolledBack,
+			Reason: converter.ValToPtr(err.Error()),
+		}
+		if updateErr := svc.client.DataService().Global.LandingZoneDeployment.Update(kt, result.ID,
+			updateReq); updateErr != nil {
+
+			logs.Errorf("update landing zone deployment %s state failed, err: %v, rid: %s", result.ID, updateErr,
+				kt.Rid)
+		}
+		return nil, err
+	}
+
+	return &cslz.DeploymentCreateResult{ID: result.ID, FlowID: flowID}, nil
+}
+
+// ListLandingZoneDeployment list landing zone deployment.
+func (svc *landingZoneSvc) ListLandingZoneDeployment(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.LandingZone, Action: meta.Find}}
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.LandingZoneDeployment.List(cts.Kit, req)
+}
+
+// GetLandingZoneDeployment get landing zone deployment, including the created resources and the rollback state.
+func (svc *landingZoneSvc) GetLandingZoneDeployment(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.LandingZone, Action: meta.Find}}
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{Filter: tools.EqualExpression("id", id), Page: core.NewDefaultBasePage()}
+	result, err := svc.client.DataService().Global.LandingZoneDeployment.List(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list landing zone deployment failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "landing zone deployment: %s not found", id)
+	}
+
+	return result.Details[0], nil
+}
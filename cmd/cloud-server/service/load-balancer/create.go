@@ -86,6 +86,9 @@ func (svc *lbSvc) batchCreateTCloudLB(kt *kit.Kit, rawReq json.RawMessage) (any,
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 	req.BkBizID = constant.UnassignedBiz
+	if err := svc.tagLgc.CheckTags(kt, req.BkBizID, enumor.LoadBalancerCloudResType, req.Tags); err != nil {
+		return nil, err
+	}
 	return svc.client.HCService().TCloud.Clb.BatchCreate(kt, req)
 }
 
@@ -45,7 +45,7 @@ func (svc *lbSvc) ImportPreview(cts *rest.Contexts) (interface{}, error) {
 	operationType := cts.PathParameter("operation_type").String()
 	bizID, err := cts.PathParameter("bk_biz_id").Int64()
 	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
-	file, _, err := cts.Request.Request.FormFile("file")
+	file, _, err := cts.FormFile("file")
 	if err != nil {
 		return nil, err
 	}
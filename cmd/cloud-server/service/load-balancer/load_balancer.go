@@ -27,6 +27,8 @@ import (
 	"hcm/cmd/cloud-server/logics/cvm"
 	"hcm/cmd/cloud-server/logics/disk"
 	"hcm/cmd/cloud-server/logics/eip"
+	logicssavedfilter "hcm/cmd/cloud-server/logics/saved-filter"
+	tagpolicy "hcm/cmd/cloud-server/logics/tag-policy"
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
@@ -36,9 +38,11 @@ import (
 // InitService initialize the load balancer service.
 func InitService(c *capability.Capability) {
 	svc := &lbSvc{
-		client:     c.ApiClient,
-		authorizer: c.Authorizer,
-		audit:      c.Audit,
+		client:         c.ApiClient,
+		authorizer:     c.Authorizer,
+		audit:          c.Audit,
+		tagLgc:         c.Logics.TagPolicy,
+		savedFilterLgc: logicssavedfilter.NewSavedFilter(c.ApiClient, c.Authorizer),
 	}
 
 	h := rest.NewHandler()
@@ -177,10 +181,12 @@ func bizSopService(h *rest.Handler, svc *lbSvc) {
 }
 
 type lbSvc struct {
-	client     *client.ClientSet
-	authorizer auth.Authorizer
-	audit      audit.Interface
-	diskLgc    disk.Interface
-	cvmLgc     cvm.Interface
-	eipLgc     eip.Interface
+	client         *client.ClientSet
+	authorizer     auth.Authorizer
+	audit          audit.Interface
+	diskLgc        disk.Interface
+	cvmLgc         cvm.Interface
+	eipLgc         eip.Interface
+	tagLgc         tagpolicy.Interface
+	savedFilterLgc logicssavedfilter.Interface
 }
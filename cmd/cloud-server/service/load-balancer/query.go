@@ -62,6 +62,13 @@ func (svc *lbSvc) listLoadBalancer(cts *rest.Contexts, authHandler handler.ListA
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	filterExpr, err := svc.savedFilterLgc.MergeFilter(cts.Kit, req.SavedFilterID, enumor.LoadBalancerCloudResType,
+		req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	req.Filter = filterExpr
+
 	// list authorized instances
 	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{
 		Authorizer: svc.authorizer,
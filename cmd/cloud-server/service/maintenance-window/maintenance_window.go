@@ -0,0 +1,222 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package maintenancewindow biz maintenance window service, reboot and stop of the biz cvms can only be executed in
+// the maintenance windows of the biz, and the scheduled stop is deferred until the maintenance window begins.
+package maintenancewindow
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/api/core"
+	datamw "hcm/pkg/api/data-service/maintenance-window"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+// InitService initialize the maintenance window service.
+func InitService(c *capability.Capability) {
+	svc := &maintenanceWindowSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateMaintenanceWindow", http.MethodPost, "/bizs/{bk_biz_id}/maintenance_windows/create",
+		svc.CreateMaintenanceWindow)
+	h.Add("UpdateMaintenanceWindow", http.MethodPatch, "/bizs/{bk_biz_id}/maintenance_windows/{id}",
+		svc.UpdateMaintenanceWindow)
+	h.Add("ListMaintenanceWindow", http.MethodPost, "/bizs/{bk_biz_id}/maintenance_windows/list",
+		svc.ListMaintenanceWindow)
+	h.Add("BatchDeleteMaintenanceWindow", http.MethodDelete, "/bizs/{bk_biz_id}/maintenance_windows/batch",
+		svc.BatchDeleteMaintenanceWindow)
+
+	h.Load(c.WebService)
+}
+
+type maintenanceWindowSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// CreateMaintenanceWindow create biz maintenance window.
+func (svc *maintenanceWindowSvc) CreateMaintenanceWindow(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := svc.authorizeBiz(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(datamw.MaintenanceWindowCreateReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	req.BkBizID = bizID
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := svc.client.DataService().Global.MaintenanceWindow.Create(cts.Kit, req)
+	if err != nil {
+		logs.Errorf("create maintenance window failed, err: %v, biz: %d, rid: %s", err, bizID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateMaintenanceWindow update biz maintenance window.
+func (svc *maintenanceWindowSvc) UpdateMaintenanceWindow(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := svc.authorizeBiz(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(datamw.MaintenanceWindowUpdateReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err = svc.checkBelongToBiz(cts.Kit, bizID, []string{id}); err != nil {
+		return nil, err
+	}
+
+	if err = svc.client.DataService().Global.MaintenanceWindow.Update(cts.Kit, id, req); err != nil {
+		logs.Errorf("update maintenance window failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListMaintenanceWindow list biz maintenance window.
+func (svc *maintenanceWindowSvc) ListMaintenanceWindow(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := svc.authorizeBiz(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(core.ListReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req.Filter, err = tools.And(req.Filter, tools.RuleEqual("bk_biz_id", bizID))
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.client.DataService().Global.MaintenanceWindow.List(cts.Kit, req)
+}
+
+// BatchDeleteMaintenanceWindow batch delete biz maintenance window.
+func (svc *maintenanceWindowSvc) BatchDeleteMaintenanceWindow(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := svc.authorizeBiz(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(core.BatchDeleteReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err = svc.checkBelongToBiz(cts.Kit, bizID, req.IDs); err != nil {
+		return nil, err
+	}
+
+	if err = svc.client.DataService().Global.MaintenanceWindow.BatchDelete(cts.Kit, req); err != nil {
+		logs.Errorf("delete maintenance window failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// checkBelongToBiz check all maintenance windows exist and belong to the biz.
+func (svc *maintenanceWindowSvc) checkBelongToBiz(kt *kit.Kit, bizID int64, ids []string) error {
+	ids = slice.Unique(ids)
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				tools.RuleIn("id", ids),
+				tools.RuleEqual("bk_biz_id", bizID),
+			},
+		},
+		Page: core.NewCountPage(),
+	}
+	result, err := svc.client.DataService().Global.MaintenanceWindow.List(kt, listReq)
+	if err != nil {
+		logs.Errorf("count maintenance window failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return err
+	}
+
+	if result.Count != uint64(len(ids)) {
+		return errf.Newf(errf.RecordNotFound, "some of maintenance windows: %v not found in biz %d", ids, bizID)
+	}
+
+	return nil
+}
+
+// authorizeBiz authorize biz access permission and return the biz id.
+func (svc *maintenanceWindowSvc) authorizeBiz(cts *rest.Contexts) (int64, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return 0, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if bizID <= 0 {
+		return 0, errf.New(errf.InvalidParameter, "biz id is invalid")
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Biz, Action: meta.Access}, BizID: bizID}
+	if err = svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return 0, err
+	}
+
+	return bizID, nil
+}
@@ -0,0 +1,216 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package monitor
+
+import (
+	"time"
+
+	"hcm/pkg/api/core"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/thirdparty/api-gateway/bkmonitor"
+	"hcm/pkg/tools/converter"
+)
+
+const (
+	// resourceCountMetric 各云厂商各类资源的数量，维度：vendor、res_type
+	resourceCountMetric = "hcm_resource_count"
+	// syncLagMetric 账号下最久未同步的资源距今的秒数，维度：vendor、account_id
+	syncLagMetric = "hcm_account_sync_lag_seconds"
+	// syncFailedMetric 账号下最近一次同步失败的资源类型数，维度：vendor、account_id
+	syncFailedMetric = "hcm_account_sync_failed_count"
+	// failedFlowMetric 上报周期内失败的异步任务数，维度：flow_name
+	failedFlowMetric = "hcm_failed_flow_count"
+)
+
+var reportVendors = []enumor.Vendor{enumor.TCloud, enumor.Aws, enumor.HuaWei, enumor.Gcp, enumor.Azure}
+
+// collectResourceCount collect resource counts of each vendor.
+func (r *reporter) collectResourceCount(kt *kit.Kit, now time.Time) ([]bkmonitor.MetricData, error) {
+	global := r.client.DataService().Global
+	counters := map[enumor.CloudResourceType]func(expr *filter.Expression) (uint64, error){
+		enumor.CvmCloudResType: func(expr *filter.Expression) (uint64, error) {
+			result, err := global.Cvm.ListCvm(kt, &core.ListReq{Filter: expr, Page: core.NewCountPage()})
+			if err != nil {
+				return 0, err
+			}
+			return result.Count, nil
+		},
+		enumor.DiskCloudResType: func(expr *filter.Expression) (uint64, error) {
+			result, err := global.ListDisk(kt, &core.ListReq{Filter: expr, Page: core.NewCountPage()})
+			if err != nil {
+				return 0, err
+			}
+			return result.Count, nil
+		},
+		enumor.EipCloudResType: func(expr *filter.Expression) (uint64, error) {
+			result, err := global.ListEip(kt, &core.ListReq{Filter: expr, Page: core.NewCountPage()})
+			if err != nil {
+				return 0, err
+			}
+			return converter.PtrToVal(result.Count), nil
+		},
+		enumor.VpcCloudResType: func(expr *filter.Expression) (uint64, error) {
+			result, err := global.Vpc.List(kt.Ctx, kt.Header(), &core.ListReq{Filter: expr, Page: core.NewCountPage()})
+			if err != nil {
+				return 0, err
+			}
+			return result.Count, nil
+		},
+		enumor.SubnetCloudResType: func(expr *filter.Expression) (uint64, error) {
+			result, err := global.Subnet.List(kt.Ctx, kt.Header(),
+				&core.ListReq{Filter: expr, Page: core.NewCountPage()})
+			if err != nil {
+				return 0, err
+			}
+			return result.Count, nil
+		},
+		enumor.SecurityGroupCloudResType: func(expr *filter.Expression) (uint64, error) {
+			result, err := global.SecurityGroup.ListSecurityGroup(kt.Ctx, kt.Header(),
+				&protocloud.SecurityGroupListReq{Filter: expr, Page: core.NewCountPage()})
+			if err != nil {
+				return 0, err
+			}
+			return result.Count, nil
+		},
+		enumor.LoadBalancerCloudResType: func(expr *filter.Expression) (uint64, error) {
+			result, err := global.LoadBalancer.ListLoadBalancer(kt,
+				&core.ListReq{Filter: expr, Page: core.NewCountPage()})
+			if err != nil {
+				return 0, err
+			}
+			return result.Count, nil
+		},
+	}
+
+	data := make([]bkmonitor.MetricData, 0, len(reportVendors)*len(counters))
+	for _, vendor := range reportVendors {
+		for resType, count := range counters {
+			total, err := count(tools.EqualExpression("vendor", vendor))
+			if err != nil {
+				logs.Errorf("count %s %s failed, err: %v, rid: %s", vendor, resType, err, kt.Rid)
+				return nil, err
+			}
+
+			dimension := map[string]string{"vendor": string(vendor), "res_type": string(resType)}
+			data = append(data, newMetric(resourceCountMetric, float64(total), dimension, now))
+		}
+	}
+
+	return data, nil
+}
+
+// collectSyncLag collect sync lag and sync failed resource count of each account. the lag of an account is the
+// duration since the end time of its least recently synced resource.
+func (r *reporter) collectSyncLag(kt *kit.Kit, now time.Time) ([]bkmonitor.MetricData, error) {
+	type accountSync struct {
+		vendor     enumor.Vendor
+		oldestEnd  time.Time
+		failedResN int
+	}
+
+	accountMap := make(map[string]*accountSync)
+	listReq := &core.ListReq{Filter: tools.AllExpression(), Page: core.NewDefaultBasePage()}
+	for {
+		result, err := r.client.DataService().Global.AccountSyncDetail.List(kt, listReq)
+		if err != nil {
+			logs.Errorf("list account sync detail failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			endTime, err := time.Parse(constant.TimeStdFormat, one.ResEndTime)
+			if err != nil {
+				// resource has never been synced
+				continue
+			}
+
+			account, exists := accountMap[one.AccountID]
+			if !exists {
+				account = &accountSync{vendor: one.Vendor, oldestEnd: endTime}
+				accountMap[one.AccountID] = account
+			}
+			if endTime.Before(account.oldestEnd) {
+				account.oldestEnd = endTime
+			}
+			if one.ResStatus == string(enumor.SyncFailed) {
+				account.failedResN++
+			}
+		}
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	data := make([]bkmonitor.MetricData, 0, len(accountMap)*2)
+	for accountID, account := range accountMap {
+		dimension := map[string]string{"vendor": string(account.vendor), "account_id": accountID}
+		data = append(data, newMetric(syncLagMetric, now.Sub(account.oldestEnd).Seconds(), dimension, now),
+			newMetric(syncFailedMetric, float64(account.failedResN), dimension, now))
+	}
+
+	return data, nil
+}
+
+// collectFailedFlow collect the number of async flows failed in the last report interval by flow name.
+func (r *reporter) collectFailedFlow(kt *kit.Kit, now time.Time) ([]bkmonitor.MetricData, error) {
+	since := now.Add(-r.interval)
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("state", enumor.FlowFailed),
+			tools.RuleGreaterThanEqual("updated_at", since.Format(constant.TimeStdFormat)),
+		),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id", "name"},
+	}
+
+	failedMap := make(map[enumor.FlowName]int)
+	for {
+		result, err := r.client.TaskServer().ListFlow(kt, listReq)
+		if err != nil {
+			logs.Errorf("list failed async flow failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			failedMap[one.Name]++
+		}
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	data := make([]bkmonitor.MetricData, 0, len(failedMap))
+	for name, count := range failedMap {
+		data = append(data, newMetric(failedFlowMetric, float64(count), map[string]string{"flow_name": string(name)},
+			now))
+	}
+
+	return data, nil
+}
@@ -0,0 +1,118 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package monitor report platform business metrics to bk monitor.
+package monitor
+
+import (
+	"time"
+
+	"hcm/cmd/cloud-server/logics/tenant"
+	"hcm/pkg/api/core"
+	"hcm/pkg/client"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/serviced"
+	"hcm/pkg/thirdparty/api-gateway/bkmonitor"
+)
+
+// reportTarget is the target of metrics reported to bk monitor.
+const reportTarget = "hcm"
+
+// tenantDimension is the dimension of the tenant which the tenant scoped metrics belong to.
+const tenantDimension = "tenant_id"
+
+// MetricsReportTiming 定时采集各云厂商资源数量、账号同步延迟、失败的异步任务数等平台业务指标，上报到蓝鲸监控用于配置仪表盘及告警
+func MetricsReportTiming(interval time.Duration, sd serviced.ServiceDiscover, cliSet *client.ClientSet,
+	monitorCli bkmonitor.Client) {
+
+	logs.Infof("bk monitor metrics report enable && start, interval: %v", interval)
+
+	r := &reporter{client: cliSet, interval: interval}
+	for {
+		time.Sleep(interval)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+		start := time.Now()
+		data := r.collect(kt, start)
+		if err := monitorCli.ReportMetrics(kt, data); err != nil {
+			logs.Errorf("report metrics to bk monitor failed, err: %v, rid: %s", err, kt.Rid)
+			continue
+		}
+		logs.Infof("report metrics to bk monitor end, count: %d, cost: %v, rid: %s", len(data), time.Since(start),
+			kt.Rid)
+	}
+}
+
+type reporter struct {
+	client   *client.ClientSet
+	interval time.Duration
+}
+
+type collector func(kt *kit.Kit, now time.Time) ([]bkmonitor.MetricData, error)
+
+// collect all metrics, metrics of the failed collector are skipped so that the others can still be reported.
+func (r *reporter) collect(kt *kit.Kit, now time.Time) []bkmonitor.MetricData {
+	// 异步任务属于平台数据，仅采集一次
+	data := runCollectors(kt, now, map[string]collector{"failed flow": r.collectFailedFlow})
+
+	// 资源数量、同步延迟属于租户数据，按租户分别采集，并以租户ID作为维度区分
+	tenantCollectors := map[string]collector{
+		"resource count": r.collectResourceCount,
+		"sync lag":       r.collectSyncLag,
+	}
+	err := tenant.ForEach(kt, r.client.DataService(), func(kt *kit.Kit) {
+		for _, one := range runCollectors(kt, now, tenantCollectors) {
+			one.Dimension[tenantDimension] = kt.TenantID
+			data = append(data, one)
+		}
+	})
+	if err != nil {
+		logs.Errorf("collect tenant metrics failed, err: %v, rid: %s", err, kt.Rid)
+	}
+
+	return data
+}
+
+func runCollectors(kt *kit.Kit, now time.Time, collectors map[string]collector) []bkmonitor.MetricData {
+	data := make([]bkmonitor.MetricData, 0)
+	for name, collect := range collectors {
+		one, err := collect(kt, now)
+		if err != nil {
+			logs.Errorf("collect %s metrics failed, err: %v, rid: %s", name, err, kt.Rid)
+			continue
+		}
+		data = append(data, one...)
+	}
+
+	return data
+}
+
+func newMetric(name string, value float64, dimension map[string]string, now time.Time) bkmonitor.MetricData {
+	return bkmonitor.MetricData{
+		Metrics:   map[string]float64{name: value},
+		Target:    reportTarget,
+		Dimension: dimension,
+		Timestamp: now.UnixMilli(),
+	}
+}
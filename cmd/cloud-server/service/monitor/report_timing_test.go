@@ -0,0 +1,58 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package monitor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"hcm/pkg/kit"
+	"hcm/pkg/thirdparty/api-gateway/bkmonitor"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMetric(t *testing.T) {
+	now := time.Unix(100, 0)
+	metric := newMetric(resourceCountMetric, 3, map[string]string{"vendor": "tcloud"}, now)
+
+	assert.Equal(t, map[string]float64{resourceCountMetric: 3}, metric.Metrics)
+	assert.Equal(t, reportTarget, metric.Target)
+	assert.Equal(t, map[string]string{"vendor": "tcloud"}, metric.Dimension)
+	assert.Equal(t, int64(100000), metric.Timestamp)
+}
+
+func TestRunCollectors(t *testing.T) {
+	now := time.Now()
+	collectors := map[string]collector{
+		"ok": func(kt *kit.Kit, now time.Time) ([]bkmonitor.MetricData, error) {
+			return []bkmonitor.MetricData{newMetric(failedFlowMetric, 1, map[string]string{}, now)}, nil
+		},
+		"failed": func(kt *kit.Kit, now time.Time) ([]bkmonitor.MetricData, error) {
+			return nil, errors.New("collect failed")
+		},
+	}
+
+	// 采集失败的指标被跳过，其他指标仍然上报
+	data := runCollectors(kit.New(), now, collectors)
+	assert.Len(t, data, 1)
+	assert.Contains(t, data[0].Metrics, failedFlowMetric)
+}
@@ -0,0 +1,175 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package naming resource naming rule admin service.
+package naming
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/api/core"
+	datanaming "hcm/pkg/api/data-service/naming"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the resource naming rule service.
+func InitService(c *capability.Capability) {
+	svc := &namingSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateNamingRule", http.MethodPost, "/naming_rules/create", svc.CreateNamingRule)
+	h.Add("UpdateNamingRule", http.MethodPatch, "/naming_rules/{id}", svc.UpdateNamingRule)
+	h.Add("ListNamingRule", http.MethodPost, "/naming_rules/list", svc.ListNamingRule)
+	h.Add("BatchDeleteNamingRule", http.MethodDelete, "/naming_rules/batch", svc.BatchDeleteNamingRule)
+	h.Add("ListNamingViolation", http.MethodPost, "/naming_violations/list", svc.ListNamingViolation)
+
+	h.Load(c.WebService)
+}
+
+type namingSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// CreateNamingRule create naming rule, bk_biz_id -1 means the default rule for biz without its own rule.
+func (svc *namingSvc) CreateNamingRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(datanaming.NamingRuleCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Create); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.client.DataService().Global.NamingRule.Create(cts.Kit, req)
+	if err != nil {
+		logs.Errorf("create naming rule failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateNamingRule update naming rule.
+func (svc *namingSvc) UpdateNamingRule(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(datanaming.NamingRuleUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.NamingRule.Update(cts.Kit, id, req); err != nil {
+		logs.Errorf("update naming rule failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListNamingRule list naming rule.
+func (svc *namingSvc) ListNamingRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.NamingRule.List(cts.Kit, req)
+}
+
+// BatchDeleteNamingRule batch delete naming rule.
+func (svc *namingSvc) BatchDeleteNamingRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.NamingRule.BatchDelete(cts.Kit, req); err != nil {
+		logs.Errorf("delete naming rule failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListNamingViolation list cloud resources whose name does not conform to the naming rule, which are detected
+// after cloud resource sync.
+func (svc *namingSvc) ListNamingViolation(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.NamingRule.ListViolation(cts.Kit, req)
+}
+
+func (svc *namingSvc) authorize(cts *rest.Contexts, action meta.Action) error {
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.NamingRule, Action: action}}
+	return svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes)
+}
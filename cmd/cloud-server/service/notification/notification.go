@@ -0,0 +1,344 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package notification notification service, manage the notification templates and subscriptions which decide who
+// will be notified by which channels when sync failures, credential expiry, budget alerts and approval events occur.
+package notification
+
+import (
+	"net/http"
+
+	logicsnotification "hcm/cmd/cloud-server/logics/notification"
+	"hcm/cmd/cloud-server/service/capability"
+	csnotification "hcm/pkg/api/cloud-server/notification"
+	"hcm/pkg/api/core"
+	datanotification "hcm/pkg/api/data-service/notification"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the notification service.
+func InitService(c *capability.Capability) {
+	svc := &notificationSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		notifier:   logicsnotification.NewNotification(c.ApiClient, c.CmsiCli),
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateNotificationTemplate", http.MethodPost, "/notifications/templates/create",
+		svc.CreateNotificationTemplate)
+	h.Add("UpdateNotificationTemplate", http.MethodPatch, "/notifications/templates/{id}",
+		svc.UpdateNotificationTemplate)
+	h.Add("ListNotificationTemplate", http.MethodPost, "/notifications/templates/list", svc.ListNotificationTemplate)
+	h.Add("BatchDeleteNotificationTemplate", http.MethodDelete, "/notifications/templates/batch",
+		svc.BatchDeleteNotificationTemplate)
+
+	h.Add("CreateNotificationSubscription", http.MethodPost, "/notifications/subscriptions/create",
+		svc.CreateNotificationSubscription)
+	h.Add("UpdateNotificationSubscription", http.MethodPatch, "/notifications/subscriptions/{id}",
+		svc.UpdateNotificationSubscription)
+	h.Add("ListNotificationSubscription", http.MethodPost, "/notifications/subscriptions/list",
+		svc.ListNotificationSubscription)
+	h.Add("BatchDeleteNotificationSubscription", http.MethodDelete, "/notifications/subscriptions/batch",
+		svc.BatchDeleteNotificationSubscription)
+	h.Add("TestNotificationSubscription", http.MethodPost, "/notifications/subscriptions/{id}/test",
+		svc.TestNotificationSubscription)
+
+	h.Load(c.WebService)
+}
+
+type notificationSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	notifier   logicsnotification.Interface
+}
+
+// CreateNotificationTemplate create notification template.
+func (svc *notificationSvc) CreateNotificationTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(datanotification.TemplateCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := validateTemplateText(req.Title, req.Content); err != nil {
+		return nil, err
+	}
+
+	if err := svc.authorize(cts, meta.Create); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.client.DataService().Global.Notification.CreateTemplate(cts.Kit, req)
+	if err != nil {
+		logs.Errorf("create notification template failed, err: %v, name: %s, rid: %s", err, req.Name, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateNotificationTemplate update notification template.
+func (svc *notificationSvc) UpdateNotificationTemplate(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(datanotification.TemplateUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	var title, content string
+	if req.Title != nil {
+		title = *req.Title
+	}
+	if req.Content != nil {
+		content = *req.Content
+	}
+	if err := validateTemplateText(title, content); err != nil {
+		return nil, err
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.Notification.UpdateTemplate(cts.Kit, id, req); err != nil {
+		logs.Errorf("update notification template failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListNotificationTemplate list notification template.
+func (svc *notificationSvc) ListNotificationTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.Notification.ListTemplate(cts.Kit, req)
+}
+
+// BatchDeleteNotificationTemplate batch delete notification template, template used by subscriptions can not be
+// deleted.
+func (svc *notificationSvc) BatchDeleteNotificationTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.Notification.BatchDeleteTemplate(cts.Kit, req); err != nil {
+		logs.Errorf("delete notification template failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// CreateNotificationSubscription create notification subscription.
+func (svc *notificationSvc) CreateNotificationSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(datanotification.SubscriptionCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Create); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.client.DataService().Global.Notification.CreateSubscription(cts.Kit, req)
+	if err != nil {
+		logs.Errorf("create notification subscription failed, err: %v, name: %s, rid: %s", err, req.Name,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateNotificationSubscription update notification subscription.
+func (svc *notificationSvc) UpdateNotificationSubscription(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(datanotification.SubscriptionUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.Notification.UpdateSubscription(cts.Kit, id, req); err != nil {
+		logs.Errorf("update notification subscription failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListNotificationSubscription list notification subscription.
+func (svc *notificationSvc) ListNotificationSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.Notification.ListSubscription(cts.Kit, req)
+}
+
+// BatchDeleteNotificationSubscription batch delete notification subscription.
+func (svc *notificationSvc) BatchDeleteNotificationSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.Notification.BatchDeleteSubscription(cts.Kit, req); err != nil {
+		logs.Errorf("delete notification subscription failed, err: %v, ids: %v, rid: %s", err, req.IDs,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// TestNotificationSubscription send a test notification with sample event data by all channels of the
+// subscription, regardless of whether the subscription is enabled, so that the receivers and templates can be
+// verified before the real events occur.
+func (svc *notificationSvc) TestNotificationSubscription(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.client.DataService().Global.Notification.ListSubscription(cts.Kit, &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		logs.Errorf("get notification subscription failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "notification subscription: %s not found", id)
+	}
+	sub := result.Details[0]
+
+	event := &logicsnotification.Event{
+		Type:         sub.EventType,
+		RelatedUsers: []string{cts.Kit.User},
+		Data:         logicsnotification.SampleEventData(sub.EventType),
+	}
+	sendResults := svc.notifier.NotifySubscription(cts.Kit, &sub, event)
+
+	details := make([]csnotification.SubscriptionTestChannelResult, 0, len(sub.Channels))
+	for _, channel := range sub.Channels {
+		one := csnotification.SubscriptionTestChannelResult{Channel: channel, Success: true}
+		if sendErr := sendResults[channel]; sendErr != nil {
+			one.Success = false
+			one.Reason = sendErr.Error()
+		}
+		details = append(details, one)
+	}
+
+	return &csnotification.SubscriptionTestResult{Details: details}, nil
+}
+
+func (svc *notificationSvc) authorize(cts *rest.Contexts, action meta.Action) error {
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Notification, Action: action}}
+	return svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes)
+}
+
+func validateTemplateText(title, content string) error {
+	if err := logicsnotification.ValidateTemplate(title); err != nil {
+		return errf.Newf(errf.InvalidParameter, "title is not a valid template, err: %v", err)
+	}
+
+	if err := logicsnotification.ValidateTemplate(content); err != nil {
+		return errf.Newf(errf.InvalidParameter, "content is not a valid template, err: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package quota
+
+import (
+	csquota "hcm/pkg/api/cloud-server/quota"
+	"hcm/pkg/api/core"
+	dataquota "hcm/pkg/api/data-service/quota"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/slice"
+)
+
+// SetBizResWhitelist set biz resource whitelist of the vendor, create it if the biz has no whitelist of the vendor.
+func (svc *quotaSvc) SetBizResWhitelist(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil || bizID <= 0 {
+		return nil, errf.New(errf.InvalidParameter, "bk_biz_id is invalid")
+	}
+
+	req := new(csquota.BizResWhitelistSetReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.BizResWhitelist, Action: meta.Update}}
+	if err = svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return nil, err
+	}
+
+	// 整体覆盖白名单，未传的列表置空，表示不限制
+	regions := nonNilUnique(req.Regions)
+	families := nonNilUnique(req.InstanceFamilies)
+	imageIDs := nonNilUnique(req.CloudImageIDs)
+
+	whitelist, err := svc.quotaLgc.GetBizResWhitelist(cts.Kit, bizID, req.Vendor)
+	if err != nil {
+		return nil, err
+	}
+
+	if whitelist == nil {
+		createReq := &dataquota.BizResWhitelistCreateReq{
+			BkBizID:          bizID,
+			Vendor:           req.Vendor,
+			Regions:          regions,
+			InstanceFamilies: families,
+			CloudImageIDs:    imageIDs,
+			Memo:             req.Memo,
+		}
+		result, err := svc.client.DataService().Global.BizResWhitelist.Create(cts.Kit, createReq)
+		if err != nil {
+			logs.Errorf("create biz resource whitelist failed, err: %v, biz: %d, vendor: %s, rid: %s", err, bizID,
+				req.Vendor, cts.Kit.Rid)
+			return nil, err
+		}
+		return result, nil
+	}
+
+	updateReq := &dataquota.BizResWhitelistUpdateReq{
+		Regions:          regions,
+		InstanceFamilies: families,
+		CloudImageIDs:    imageIDs,
+		Memo:             req.Memo,
+	}
+	if err = svc.client.DataService().Global.BizResWhitelist.Update(cts.Kit, whitelist.ID, updateReq); err != nil {
+		logs.Errorf("update biz resource whitelist failed, err: %v, biz: %d, vendor: %s, rid: %s", err, bizID,
+			req.Vendor, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: whitelist.ID}, nil
+}
+
+func nonNilUnique(values []string) []string {
+	if len(values) == 0 {
+		return make([]string, 0)
+	}
+	return slice.Unique(values)
+}
+
+// DeleteBizResWhitelist delete biz resource whitelist of the vendor, the biz is unlimited after deleted.
+func (svc *quotaSvc) DeleteBizResWhitelist(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil || bizID <= 0 {
+		return nil, errf.New(errf.InvalidParameter, "bk_biz_id is invalid")
+	}
+
+	req := new(csquota.BizResWhitelistDeleteReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.BizResWhitelist, Action: meta.Delete}}
+	if err = svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return nil, err
+	}
+
+	whitelist, err := svc.quotaLgc.GetBizResWhitelist(cts.Kit, bizID, req.Vendor)
+	if err != nil {
+		return nil, err
+	}
+
+	if whitelist == nil {
+		return nil, nil
+	}
+
+	deleteReq := &core.BatchDeleteReq{IDs: []string{whitelist.ID}}
+	if err = svc.client.DataService().Global.BizResWhitelist.BatchDelete(cts.Kit, deleteReq); err != nil {
+		logs.Errorf("delete biz resource whitelist failed, err: %v, biz: %d, vendor: %s, rid: %s", err, bizID,
+			req.Vendor, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListBizResWhitelist list biz resource whitelist.
+func (svc *quotaSvc) ListBizResWhitelist(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.BizResWhitelist, Action: meta.Find}}
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.BizResWhitelist.List(cts.Kit, req)
+}
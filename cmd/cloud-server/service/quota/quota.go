@@ -0,0 +1,229 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package quota biz resource quota admin service.
+package quota
+
+import (
+	"net/http"
+
+	logicsquota "hcm/cmd/cloud-server/logics/quota"
+	"hcm/cmd/cloud-server/service/capability"
+	csquota "hcm/pkg/api/cloud-server/quota"
+	"hcm/pkg/api/core"
+	corequota "hcm/pkg/api/core/quota"
+	dataquota "hcm/pkg/api/data-service/quota"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	tablequota "hcm/pkg/dal/table/quota"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the biz quota service.
+func InitService(c *capability.Capability) {
+	svc := &quotaSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		quotaLgc:   c.Logics.Quota,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("SetBizQuota", http.MethodPut, "/biz_quotas/{bk_biz_id}", svc.SetBizQuota)
+	h.Add("DeleteBizQuota", http.MethodDelete, "/biz_quotas/{bk_biz_id}", svc.DeleteBizQuota)
+	h.Add("ListBizQuota", http.MethodPost, "/biz_quotas/list", svc.ListBizQuota)
+	h.Add("ListBizQuotaUsage", http.MethodPost, "/biz_quotas/usages/list", svc.ListBizQuotaUsage)
+
+	h.Add("SetBizResWhitelist", http.MethodPut, "/biz_res_whitelists/{bk_biz_id}", svc.SetBizResWhitelist)
+	h.Add("DeleteBizResWhitelist", http.MethodDelete, "/biz_res_whitelists/{bk_biz_id}", svc.DeleteBizResWhitelist)
+	h.Add("ListBizResWhitelist", http.MethodPost, "/biz_res_whitelists/list", svc.ListBizResWhitelist)
+
+	h.Load(c.WebService)
+}
+
+type quotaSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	quotaLgc   logicsquota.Interface
+}
+
+// SetBizQuota set biz quota, create it if the biz has no quota.
+func (svc *quotaSvc) SetBizQuota(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil || bizID <= 0 {
+		return nil, errf.New(errf.InvalidParameter, "bk_biz_id is invalid")
+	}
+
+	req := new(csquota.BizQuotaSetReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.BizQuota, Action: meta.Update}}
+	if err = svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return nil, err
+	}
+
+	bizQuota, err := svc.quotaLgc.GetBizQuota(cts.Kit, bizID)
+	if err != nil {
+		return nil, err
+	}
+
+	if bizQuota == nil {
+		createReq := &dataquota.BizQuotaCreateReq{
+			BkBizID:    bizID,
+			MaxCvm:     defaultUnlimited(req.MaxCvm),
+			MaxCpuCore: defaultUnlimited(req.MaxCpuCore),
+			MaxEip:     defaultUnlimited(req.MaxEip),
+			Memo:       req.Memo,
+		}
+		result, err := svc.client.DataService().Global.BizQuota.Create(cts.Kit, createReq)
+		if err != nil {
+			logs.Errorf("create biz quota failed, err: %v, biz: %d, rid: %s", err, bizID, cts.Kit.Rid)
+			return nil, err
+		}
+		return result, nil
+	}
+
+	updateReq := &dataquota.BizQuotaUpdateReq{
+		MaxCvm:     req.MaxCvm,
+		MaxCpuCore: req.MaxCpuCore,
+		MaxEip:     req.MaxEip,
+		Memo:       req.Memo,
+	}
+	if err = svc.client.DataService().Global.BizQuota.Update(cts.Kit, bizQuota.ID, updateReq); err != nil {
+		logs.Errorf("update biz quota failed, err: %v, biz: %d, rid: %s", err, bizID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: bizQuota.ID}, nil
+}
+
+func defaultUnlimited(value *int64) int64 {
+	if value == nil {
+		return tablequota.UnlimitedQuota
+	}
+	return *value
+}
+
+// DeleteBizQuota delete biz quota, the biz resources are unlimited after deleted.
+func (svc *quotaSvc) DeleteBizQuota(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil || bizID <= 0 {
+		return nil, errf.New(errf.InvalidParameter, "bk_biz_id is invalid")
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.BizQuota, Action: meta.Delete}}
+	if err = svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return nil, err
+	}
+
+	bizQuota, err := svc.quotaLgc.GetBizQuota(cts.Kit, bizID)
+	if err != nil {
+		return nil, err
+	}
+
+	if bizQuota == nil {
+		return nil, nil
+	}
+
+	deleteReq := &core.BatchDeleteReq{IDs: []string{bizQuota.ID}}
+	if err = svc.client.DataService().Global.BizQuota.BatchDelete(cts.Kit, deleteReq); err != nil {
+		logs.Errorf("delete biz quota failed, err: %v, biz: %d, rid: %s", err, bizID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListBizQuota list biz quota.
+func (svc *quotaSvc) ListBizQuota(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.BizQuota, Action: meta.Find}}
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.BizQuota.List(cts.Kit, req)
+}
+
+// ListBizQuotaUsage list biz quota and current usage counted from inventory.
+func (svc *quotaSvc) ListBizQuotaUsage(cts *rest.Contexts) (interface{}, error) {
+	req := new(csquota.BizQuotaUsageListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.BizQuota, Action: meta.Find}}
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("bk_biz_id", req.BkBizIDs),
+		Page:   core.NewDefaultBasePage(),
+	}
+	quotas, err := svc.client.DataService().Global.BizQuota.List(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list biz quota failed, err: %v, bizs: %v, rid: %s", err, req.BkBizIDs, cts.Kit.Rid)
+		return nil, err
+	}
+	quotaMap := make(map[int64]corequota.BizQuota, len(quotas.Details))
+	for _, one := range quotas.Details {
+		quotaMap[one.BkBizID] = one
+	}
+
+	usageReq := &dataquota.BizResUsageListReq{BkBizIDs: req.BkBizIDs}
+	usages, err := svc.client.DataService().Global.BizQuota.ListUsage(cts.Kit, usageReq)
+	if err != nil {
+		logs.Errorf("list biz resource usage failed, err: %v, bizs: %v, rid: %s", err, req.BkBizIDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]csquota.BizQuotaUsage, 0, len(usages.Details))
+	for _, usage := range usages.Details {
+		detail := csquota.BizQuotaUsage{BkBizID: usage.BkBizID, Usage: usage}
+		if bizQuota, exists := quotaMap[usage.BkBizID]; exists {
+			detail.Quota = &bizQuota
+		}
+		details = append(details, detail)
+	}
+
+	return &core.ListResultT[csquota.BizQuotaUsage]{Details: details}, nil
+}
@@ -24,6 +24,7 @@ import (
 
 	"hcm/cmd/cloud-server/logics"
 	"hcm/cmd/cloud-server/logics/recycle"
+	"hcm/cmd/cloud-server/logics/tenant"
 	"hcm/pkg/api/core"
 	recyclerecord "hcm/pkg/api/core/recycle-record"
 	dataproto "hcm/pkg/api/data-service/cloud"
@@ -59,88 +60,103 @@ func RecycleTiming(c *client.ClientSet, state serviced.State, conf cc.Recycle, e
 
 	go r.recycleTiming(enumor.DiskCloudResType, r.recycleDiskWorker, conf)
 	go r.recycleTiming(enumor.CvmCloudResType, r.recycleCvmWorker, conf)
+	go r.recycleTiming(enumor.EipCloudResType, r.recycleEipWorker, conf)
 }
 
 type recycleWorker func(kt *kit.Kit, info *types.CloudResourceBasicInfo) error
 
 func (r *recycle) recycleTiming(resType enumor.CloudResourceType, worker recycleWorker, conf cc.Recycle) {
 	for {
-		kt := core.NewBackendKit()
-
 		if !r.state.IsMaster() {
 			logs.Infof("recycle %s, but is not master, skip", resType)
 			time.Sleep(time.Minute)
 			continue
 		}
 
-		logs.Infof("start recycle %s, rid: %s", resType, kt.Rid)
-		// get need recycled resource records
-		expr, err := tools.And(tools.EqualWithOpExpression(filter.And,
-			map[string]interface{}{"res_type": resType, "status": enumor.WaitingRecycleRecordStatus}),
-			&filter.AtomRule{Field: "recycled_at", Op: filter.LessThanEqual.Factory(),
-				Value: times.ConvStdTimeFormat(time.Now())},
-			// 不处理关联资源回收任务
-			&filter.AtomRule{Field: "recycle_type", Op: filter.NotEqual.Factory(), Value: enumor.RecycleTypeRelated},
-		)
-		if err != nil {
-			time.Sleep(time.Minute)
-			continue
-		}
-		listReq := &core.ListReq{
-			Filter: expr,
-			Page:   core.NewDefaultBasePage(),
-			Fields: []string{"id", "res_id", "bk_biz_id"},
-		}
-		recordRes, err := r.client.DataService().Global.RecycleRecord.ListRecycleRecord(kt, listReq)
+		// 取各租户中最短的等待时间，有租户处理了回收记录时立即开始下一轮
+		wait := noRecordWait
+		err := tenant.ForEach(core.NewBackendKit(), r.client.DataService(), func(kt *kit.Kit) {
+			if one := r.recycleTenant(kt, resType, worker); one < wait {
+				wait = one
+			}
+		})
 		if err != nil {
-			logs.Errorf("list %s resource recycle record failed, err: %v, rid: %s", resType, err, kt.Rid)
-			time.Sleep(time.Minute)
-			continue
+			wait = time.Minute
 		}
 
-		// sleep for a while if no resource needs recycling
-		if len(recordRes.Details) == 0 {
-			time.Sleep(time.Minute * 10)
-			continue
-		}
+		time.Sleep(wait)
+	}
+}
 
-		// get need recycled resource basic info
-		ids := make([]string, 0, len(recordRes.Details))
-		for _, record := range recordRes.Details {
-			ids = append(ids, record.ResID)
-		}
+// noRecordWait 没有待回收的记录时，下一轮回收的等待时间
+const noRecordWait = 10 * time.Minute
+
+// recycleTenant 回收kit所属租户下一批到期的资源，返回下一轮回收前需要等待的时间
+func (r *recycle) recycleTenant(kt *kit.Kit, resType enumor.CloudResourceType, worker recycleWorker) time.Duration {
+	logs.Infof("start recycle tenant %s %s, rid: %s", kt.TenantID, resType, kt.Rid)
+	// get need recycled resource records
+	expr, err := tools.And(tools.EqualWithOpExpression(filter.And,
+		map[string]interface{}{"res_type": resType, "status": enumor.WaitingRecycleRecordStatus}),
+		&filter.AtomRule{Field: "recycled_at", Op: filter.LessThanEqual.Factory(),
+			Value: times.ConvStdTimeFormat(time.Now())},
+		// 不处理关联资源回收任务
+		&filter.AtomRule{Field: "recycle_type", Op: filter.NotEqual.Factory(), Value: enumor.RecycleTypeRelated},
+	)
+	if err != nil {
+		return time.Minute
+	}
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id", "res_id", "bk_biz_id"},
+	}
+	recordRes, err := r.client.DataService().Global.RecycleRecord.ListRecycleRecord(kt, listReq)
+	if err != nil {
+		logs.Errorf("list %s resource recycle record failed, err: %v, rid: %s", resType, err, kt.Rid)
+		return time.Minute
+	}
 
-		infoReq := dataproto.ListResourceBasicInfoReq{
-			ResourceType: resType,
-			IDs:          ids,
-			Fields:       append(types.CommonBasicInfoFields, "region", "recycle_status"),
-		}
-		basicInfoMap, err := r.client.DataService().Global.Cloud.ListResBasicInfo(kt, infoReq)
-		if err != nil {
-			if ef := errf.Error(err); ef.Code == errf.RecordNotFound {
-				recordIDs := slice.Map(recordRes.Details, func(r recyclerecord.RecycleRecord) string { return r.ID })
-				logs.Errorf("recycle %s res(ids: %+v) all don't exist, mark all as fail, reason: %v, rid: %s",
-					resType, ids, err, kt.Rid)
-				logicsrecycle.MarkRecordFailed(kt, r.client.DataService(), err, recordIDs)
-				continue
-			}
-			logs.Errorf("get recycle %s resource detail failed, err: %v, ids: %+v, rid: %s", resType, err, ids, kt.Rid)
-			time.Sleep(time.Minute)
-			continue
-		}
+	// sleep for a while if no resource needs recycling
+	if len(recordRes.Details) == 0 {
+		return noRecordWait
+	}
 
-		// recycle resources one by one
-		for _, record := range recordRes.Details {
-			if !r.state.IsMaster() {
-				logs.Infof("recycle %s res(id: %s), but is not master, skip, rid: %s", resType, record.ResID, kt.Rid)
-				time.Sleep(time.Minute)
-				break
-			}
-			r.execWorker(kt, worker, record, basicInfoMap)
+	ids := make([]string, 0, len(recordRes.Details))
+	for _, record := range recordRes.Details {
+		ids = append(ids, record.ResID)
+	}
+
+	// get need recycled resource basic info
+	infoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: resType,
+		IDs:          ids,
+		Fields:       append(types.CommonBasicInfoFields, "region", "recycle_status"),
+	}
+	basicInfoMap, err := r.client.DataService().Global.Cloud.ListResBasicInfo(kt, infoReq)
+	if err != nil {
+		if ef := errf.Error(err); ef.Code == errf.RecordNotFound {
+			recordIDs := slice.Map(recordRes.Details, func(r recyclerecord.RecycleRecord) string { return r.ID })
+			logs.Errorf("recycle %s res(ids: %+v) all don't exist, mark all as fail, reason: %v, rid: %s",
+				resType, ids, err, kt.Rid)
+			logicsrecycle.MarkRecordFailed(kt, r.client.DataService(), err, recordIDs)
+			return 0
 		}
+		logs.Errorf("get recycle %s resource detail failed, err: %v, ids: %+v, rid: %s", resType, err, ids, kt.Rid)
+		return time.Minute
+	}
 
-		logs.Infof("finished recycle %s, count: %d, rid: %s", resType, len(recordRes.Details), kt.Rid)
+	// recycle resources one by one
+	for _, record := range recordRes.Details {
+		if !r.state.IsMaster() {
+			logs.Infof("recycle %s res(id: %s), but is not master, skip, rid: %s", resType, record.ResID, kt.Rid)
+			return time.Minute
+		}
+		r.execWorker(kt, worker, record, basicInfoMap)
 	}
+
+	logs.Infof("finished recycle tenant %s %s, count: %d, rid: %s", kt.TenantID, resType, len(recordRes.Details),
+		kt.Rid)
+	return 0
 }
 
 const maxRetryCount = 3
@@ -186,6 +202,15 @@ func (r *recycle) recycleDiskWorker(kt *kit.Kit, info *types.CloudResourceBasicI
 	return nil
 }
 
+func (r *recycle) recycleEipWorker(kt *kit.Kit, info *types.CloudResourceBasicInfo) error {
+	res, err := r.logics.Eip.DeleteRecycledEip(kt, map[string]types.CloudResourceBasicInfo{info.ID: *info})
+	if err != nil {
+		logs.Errorf("delete eip failed, err: %v, res: %+v, eip: %s, rid: %s", err, res, info.ID, kt.Rid)
+		return err
+	}
+	return nil
+}
+
 func (r *recycle) recycleCvmWorker(kt *kit.Kit, info *types.CloudResourceBasicInfo) error {
 	// 实际销毁CVM
 	res, err := r.logics.Cvm.DestroyRecycledCvm(kt, map[string]types.CloudResourceBasicInfo{info.ID: *info}, nil)
@@ -0,0 +1,239 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package savedfilter saved filter service, save the named filter expressions of resource list for current user or
+// share them with the users of a biz, then apply them by id from the resource list apis.
+package savedfilter
+
+import (
+	"net/http"
+
+	logicssavedfilter "hcm/cmd/cloud-server/logics/saved-filter"
+	"hcm/cmd/cloud-server/service/capability"
+	cssavedfilter "hcm/pkg/api/cloud-server/saved-filter"
+	"hcm/pkg/api/core"
+	coresavedfilter "hcm/pkg/api/core/saved-filter"
+	datasavedfilter "hcm/pkg/api/data-service/saved-filter"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+// InitService initialize the saved filter service.
+func InitService(c *capability.Capability) {
+	svc := &savedFilterSvc{
+		client:         c.ApiClient,
+		authorizer:     c.Authorizer,
+		savedFilterLgc: logicssavedfilter.NewSavedFilter(c.ApiClient, c.Authorizer),
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateSavedFilter", http.MethodPost, "/saved_filters/create", svc.CreateSavedFilter)
+	h.Add("UpdateSavedFilter", http.MethodPatch, "/saved_filters/{id}", svc.UpdateSavedFilter)
+	h.Add("GetSavedFilter", http.MethodGet, "/saved_filters/{id}", svc.GetSavedFilter)
+	h.Add("ListSavedFilter", http.MethodPost, "/saved_filters/list", svc.ListSavedFilter)
+	h.Add("BatchDeleteSavedFilter", http.MethodDelete, "/saved_filters/batch", svc.BatchDeleteSavedFilter)
+
+	h.Load(c.WebService)
+}
+
+type savedFilterSvc struct {
+	client         *client.ClientSet
+	authorizer     auth.Authorizer
+	savedFilterLgc logicssavedfilter.Interface
+}
+
+// CreateSavedFilter create saved filter.
+func (svc *savedFilterSvc) CreateSavedFilter(cts *rest.Contexts) (interface{}, error) {
+	req := new(datasavedfilter.SavedFilterCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeScope(cts.Kit, req.Scope, req.BkBizID); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.client.DataService().Global.SavedFilter.Create(cts.Kit, req)
+	if err != nil {
+		logs.Errorf("create saved filter failed, err: %v, name: %s, rid: %s", err, req.Name, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateSavedFilter update saved filter, only the creator can update it.
+func (svc *savedFilterSvc) UpdateSavedFilter(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(datasavedfilter.SavedFilterUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if _, err := svc.listOwned(cts.Kit, []string{id}); err != nil {
+		return nil, err
+	}
+
+	if len(req.Scope) != 0 {
+		if err := svc.authorizeScope(cts.Kit, req.Scope, req.BkBizID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := svc.client.DataService().Global.SavedFilter.Update(cts.Kit, id, req); err != nil {
+		logs.Errorf("update saved filter failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// GetSavedFilter get saved filter visible to current user.
+func (svc *savedFilterSvc) GetSavedFilter(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	return svc.savedFilterLgc.GetVisible(cts.Kit, id)
+}
+
+// ListSavedFilter list saved filters of the resource type created by current user, and the ones shared with the
+// given biz.
+func (svc *savedFilterSvc) ListSavedFilter(cts *rest.Contexts) (interface{}, error) {
+	req := new(cssavedfilter.ListSavedFilterReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	visible := &filter.Expression{
+		Op:    filter.Or,
+		Rules: []filter.RuleFactory{tools.RuleEqual("creator", cts.Kit.User)},
+	}
+	if req.BkBizID > 0 {
+		if err := svc.authorizeScope(cts.Kit, enumor.SavedFilterBizScope, req.BkBizID); err != nil {
+			return nil, err
+		}
+
+		visible.Rules = append(visible.Rules, tools.ExpressionAnd(
+			tools.RuleEqual("scope", enumor.SavedFilterBizScope),
+			tools.RuleEqual("bk_biz_id", req.BkBizID),
+		))
+	}
+
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op:    filter.And,
+			Rules: []filter.RuleFactory{tools.RuleEqual("res_type", req.ResType), visible},
+		},
+		Page: req.Page,
+	}
+	result, err := svc.client.DataService().Global.SavedFilter.List(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list saved filter failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// BatchDeleteSavedFilter batch delete saved filter, only the creator can delete it.
+func (svc *savedFilterSvc) BatchDeleteSavedFilter(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if _, err := svc.listOwned(cts.Kit, req.IDs); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.SavedFilter.BatchDelete(cts.Kit, req); err != nil {
+		logs.Errorf("delete saved filter failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// listOwned list saved filters by ids, and check that all of them exist and are created by current user.
+func (svc *savedFilterSvc) listOwned(kt *kit.Kit, ids []string) ([]coresavedfilter.SavedFilter, error) {
+	ids = slice.Unique(ids)
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.client.DataService().Global.SavedFilter.List(kt, listReq)
+	if err != nil {
+		logs.Errorf("list saved filter failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) != len(ids) {
+		return nil, errf.Newf(errf.RecordNotFound, "some of saved filters: %v not found", ids)
+	}
+
+	for _, one := range result.Details {
+		if one.Creator != kt.User {
+			return nil, errf.Newf(errf.PermissionDenied, "saved filter: %s is not created by you", one.ID)
+		}
+	}
+
+	return result.Details, nil
+}
+
+// authorizeScope 共享到业务的过滤条件需要有该业务的访问权限
+func (svc *savedFilterSvc) authorizeScope(kt *kit.Kit, scope enumor.SavedFilterScope, bizID int64) error {
+	if scope != enumor.SavedFilterBizScope {
+		return nil
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Biz, Action: meta.Access}, BizID: bizID}
+	return svc.authorizer.AuthorizeWithPerm(kt, authRes)
+}
@@ -0,0 +1,374 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package search cross account resource search service.
+package search
+
+import (
+	"net/http"
+	"sync"
+
+	logicsaccountgroup "hcm/cmd/cloud-server/logics/account-group"
+	"hcm/cmd/cloud-server/service/capability"
+	cssearch "hcm/pkg/api/cloud-server/search"
+	"hcm/pkg/api/core"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/concurrence"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// InitService initialize the resource search service.
+func InitService(c *capability.Capability) {
+	svc := &searchSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+	h.Add("SearchResource", http.MethodPost, "/resources/search", svc.SearchResource)
+	h.Add("SearchBizResource", http.MethodPost, "/bizs/{bk_biz_id}/resources/search", svc.SearchBizResource)
+
+	h.Load(c.WebService)
+}
+
+type searchSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// SearchResource search resources of all vendors and accounts in resource view.
+func (svc *searchSvc) SearchResource(cts *rest.Contexts) (interface{}, error) {
+	return svc.search(cts, handler.ListResourceAuthRes)
+}
+
+// SearchBizResource search resources of all vendors and accounts in biz view.
+func (svc *searchSvc) SearchBizResource(cts *rest.Contexts) (interface{}, error) {
+	return svc.search(cts, handler.ListBizAuthRes)
+}
+
+// searchFunc search resources of one resource type by the filter, the filter contains authorized condition.
+type searchFunc func(kt *kit.Kit, expr *filter.Expression, page *core.BasePage) ([]cssearch.SearchResource, error)
+
+// searchResType define how to search resources of one resource type.
+type searchResType struct {
+	authType meta.ResourceType
+	// keywordRules 关键字匹配规则，多个规则之间为或的关系
+	keywordRules func(keyword string) []*filter.AtomRule
+	search       searchFunc
+}
+
+func (svc *searchSvc) search(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (interface{}, error) {
+	req := new(cssearch.ResourceSearchReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	resTypes := req.ResTypes
+	if len(resTypes) == 0 {
+		resTypes = cssearch.SupportedResTypes
+	}
+	resTypes = uniqueResTypes(resTypes)
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = cssearch.DefaultSearchLimit
+	}
+
+	var groupAccountIDs []string
+	if len(req.AccountGroupID) != 0 {
+		accountIDs, err := logicsaccountgroup.ListGroupAccountIDs(cts.Kit, svc.client, req.AccountGroupID,
+			enumor.AccountGroupMemberAccount)
+		if err != nil {
+			return nil, err
+		}
+		if len(accountIDs) > int(filter.DefaultMaxInLimit) {
+			return nil, errf.Newf(errf.InvalidParameter, "account group has more than %d accounts",
+				filter.DefaultMaxInLimit)
+		}
+		groupAccountIDs = accountIDs
+	}
+
+	searchers := svc.searchers()
+	resultMap := make(map[enumor.CloudResourceType][]cssearch.SearchResource, len(resTypes))
+	lock := sync.Mutex{}
+	err := concurrence.BaseExec(len(resTypes), resTypes, func(resType enumor.CloudResourceType) error {
+		searcher := searchers[resType]
+		keywordExpr := tools.ExpressionOr(searcher.keywordRules(req.Keyword)...)
+		if groupAccountIDs != nil {
+			// 账号组下没有资源账号时不返回任何资源
+			if len(groupAccountIDs) == 0 {
+				lock.Lock()
+				resultMap[resType] = make([]cssearch.SearchResource, 0)
+				lock.Unlock()
+				return nil
+			}
+
+			var err error
+			keywordExpr, err = tools.And(keywordExpr, tools.RuleIn("account_id", groupAccountIDs))
+			if err != nil {
+				return err
+			}
+		}
+
+		expr, noPerm, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+			ResType: searcher.authType, Action: meta.Find, Filter: keywordExpr})
+		if err != nil {
+			logs.Errorf("list %s authorized resource failed, err: %v, rid: %s", resType, err, cts.Kit.Rid)
+			return err
+		}
+
+		// 无该资源类型的查看权限时不返回该类型的资源
+		details := make([]cssearch.SearchResource, 0)
+		if !noPerm {
+			details, err = searcher.search(cts.Kit, expr, &core.BasePage{Limit: limit})
+			if err != nil {
+				logs.Errorf("search %s failed, err: %v, keyword: %s, rid: %s", resType, err, req.Keyword,
+					cts.Kit.Rid)
+				return err
+			}
+		}
+
+		lock.Lock()
+		resultMap[resType] = details
+		lock.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &cssearch.ResourceSearchResult{Details: make([]cssearch.ResTypeSearchResult, 0, len(resTypes))}
+	for _, resType := range resTypes {
+		result.Details = append(result.Details, cssearch.ResTypeSearchResult{
+			ResType: resType,
+			Details: resultMap[resType],
+		})
+	}
+
+	return result, nil
+}
+
+func uniqueResTypes(resTypes []enumor.CloudResourceType) []enumor.CloudResourceType {
+	exists := make(map[enumor.CloudResourceType]struct{}, len(resTypes))
+	unique := make([]enumor.CloudResourceType, 0, len(resTypes))
+	for _, one := range resTypes {
+		if _, ok := exists[one]; ok {
+			continue
+		}
+		exists[one] = struct{}{}
+		unique = append(unique, one)
+	}
+	return unique
+}
+
+// nameOrCloudIDRules 按云资源ID精确匹配或按名称模糊匹配
+func nameOrCloudIDRules(keyword string) []*filter.AtomRule {
+	return []*filter.AtomRule{
+		tools.RuleEqual("cloud_id", keyword),
+		{Field: "name", Op: filter.ContainsInsensitive.Factory(), Value: keyword},
+	}
+}
+
+func (svc *searchSvc) searchers() map[enumor.CloudResourceType]searchResType {
+	return map[enumor.CloudResourceType]searchResType{
+		enumor.CvmCloudResType: {
+			authType: meta.Cvm,
+			keywordRules: func(keyword string) []*filter.AtomRule {
+				return append(nameOrCloudIDRules(keyword),
+					tools.RuleJSONContains("private_ipv4_addresses", keyword),
+					tools.RuleJSONContains("private_ipv6_addresses", keyword),
+					tools.RuleJSONContains("public_ipv4_addresses", keyword),
+					tools.RuleJSONContains("public_ipv6_addresses", keyword),
+				)
+			},
+			search: svc.searchCvm,
+		},
+		enumor.VpcCloudResType: {
+			authType:     meta.Vpc,
+			keywordRules: nameOrCloudIDRules,
+			search:       svc.searchVpc,
+		},
+		enumor.SecurityGroupCloudResType: {
+			authType:     meta.SecurityGroup,
+			keywordRules: nameOrCloudIDRules,
+			search:       svc.searchSecurityGroup,
+		},
+		enumor.EipCloudResType: {
+			authType: meta.Eip,
+			keywordRules: func(keyword string) []*filter.AtomRule {
+				return append(nameOrCloudIDRules(keyword),
+					tools.RuleEqual("public_ip", keyword),
+					tools.RuleEqual("private_ip", keyword),
+				)
+			},
+			search: svc.searchEip,
+		},
+		enumor.DiskCloudResType: {
+			authType:     meta.Disk,
+			keywordRules: nameOrCloudIDRules,
+			search:       svc.searchDisk,
+		},
+	}
+}
+
+func (svc *searchSvc) searchCvm(kt *kit.Kit, expr *filter.Expression, page *core.BasePage) (
+	[]cssearch.SearchResource, error) {
+
+	result, err := svc.client.DataService().Global.Cvm.ListCvm(kt, &core.ListReq{Filter: expr, Page: page})
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]cssearch.SearchResource, 0, len(result.Details))
+	for _, one := range result.Details {
+		ips := make([]string, 0)
+		ips = append(ips, one.PrivateIPv4Addresses...)
+		ips = append(ips, one.PrivateIPv6Addresses...)
+		ips = append(ips, one.PublicIPv4Addresses...)
+		ips = append(ips, one.PublicIPv6Addresses...)
+		details = append(details, cssearch.SearchResource{
+			ID:        one.ID,
+			CloudID:   one.CloudID,
+			Name:      one.Name,
+			Vendor:    one.Vendor,
+			AccountID: one.AccountID,
+			BkBizID:   one.BkBizID,
+			Region:    one.Region,
+			IPs:       ips,
+		})
+	}
+	return details, nil
+}
+
+func (svc *searchSvc) searchVpc(kt *kit.Kit, expr *filter.Expression, page *core.BasePage) (
+	[]cssearch.SearchResource, error) {
+
+	result, err := svc.client.DataService().Global.Vpc.List(kt.Ctx, kt.Header(),
+		&core.ListReq{Filter: expr, Page: page})
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]cssearch.SearchResource, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, cssearch.SearchResource{
+			ID:        one.ID,
+			CloudID:   one.CloudID,
+			Name:      one.Name,
+			Vendor:    one.Vendor,
+			AccountID: one.AccountID,
+			BkBizID:   one.BkBizID,
+			Region:    one.Region,
+		})
+	}
+	return details, nil
+}
+
+func (svc *searchSvc) searchSecurityGroup(kt *kit.Kit, expr *filter.Expression, page *core.BasePage) (
+	[]cssearch.SearchResource, error) {
+
+	result, err := svc.client.DataService().Global.SecurityGroup.ListSecurityGroup(kt.Ctx, kt.Header(),
+		&protocloud.SecurityGroupListReq{Filter: expr, Page: page})
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]cssearch.SearchResource, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, cssearch.SearchResource{
+			ID:        one.ID,
+			CloudID:   one.CloudID,
+			Name:      one.Name,
+			Vendor:    one.Vendor,
+			AccountID: one.AccountID,
+			BkBizID:   one.BkBizID,
+			Region:    one.Region,
+		})
+	}
+	return details, nil
+}
+
+func (svc *searchSvc) searchEip(kt *kit.Kit, expr *filter.Expression, page *core.BasePage) (
+	[]cssearch.SearchResource, error) {
+
+	result, err := svc.client.DataService().Global.ListEip(kt, &core.ListReq{Filter: expr, Page: page})
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]cssearch.SearchResource, 0, len(result.Details))
+	for _, one := range result.Details {
+		ips := make([]string, 0)
+		if len(one.PublicIp) != 0 {
+			ips = append(ips, one.PublicIp)
+		}
+		if len(one.PrivateIp) != 0 {
+			ips = append(ips, one.PrivateIp)
+		}
+		details = append(details, cssearch.SearchResource{
+			ID:        one.ID,
+			CloudID:   one.CloudID,
+			Name:      converter.PtrToVal(one.Name),
+			Vendor:    enumor.Vendor(one.Vendor),
+			AccountID: one.AccountID,
+			BkBizID:   one.BkBizID,
+			Region:    one.Region,
+			IPs:       ips,
+		})
+	}
+	return details, nil
+}
+
+func (svc *searchSvc) searchDisk(kt *kit.Kit, expr *filter.Expression, page *core.BasePage) (
+	[]cssearch.SearchResource, error) {
+
+	result, err := svc.client.DataService().Global.ListDisk(kt, &core.ListReq{Filter: expr, Page: page})
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]cssearch.SearchResource, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, cssearch.SearchResource{
+			ID:        one.ID,
+			CloudID:   one.CloudID,
+			Name:      one.Name,
+			Vendor:    enumor.Vendor(one.Vendor),
+			AccountID: one.AccountID,
+			BkBizID:   one.BkBizID,
+			Region:    one.Region,
+		})
+	}
+	return details, nil
+}
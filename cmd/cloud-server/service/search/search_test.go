@@ -0,0 +1,66 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	cssearch "hcm/pkg/api/cloud-server/search"
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestUniqueResTypes(t *testing.T) {
+	unique := uniqueResTypes([]enumor.CloudResourceType{enumor.CvmCloudResType, enumor.EipCloudResType,
+		enumor.CvmCloudResType})
+	expect := []enumor.CloudResourceType{enumor.CvmCloudResType, enumor.EipCloudResType}
+	if !reflect.DeepEqual(unique, expect) {
+		t.Errorf("expect %v, but got %v", expect, unique)
+	}
+}
+
+func TestSearchers(t *testing.T) {
+	searchers := new(searchSvc).searchers()
+
+	for _, resType := range cssearch.SupportedResTypes {
+		if _, exists := searchers[resType]; !exists {
+			t.Errorf("supported resource type %s has no searcher", resType)
+		}
+	}
+
+	cases := map[enumor.CloudResourceType][]string{
+		enumor.CvmCloudResType: {"cloud_id", "name", "private_ipv4_addresses", "private_ipv6_addresses",
+			"public_ipv4_addresses", "public_ipv6_addresses"},
+		enumor.EipCloudResType: {"cloud_id", "name", "public_ip", "private_ip"},
+		enumor.VpcCloudResType: {"cloud_id", "name"},
+	}
+	for resType, expect := range cases {
+		fields := make([]string, 0)
+		for _, rule := range searchers[resType].keywordRules("10.0.0.1") {
+			if rule.Value == nil {
+				t.Errorf("%s keyword rule of %s should match the keyword", resType, rule.Field)
+			}
+			fields = append(fields, rule.Field)
+		}
+		if !reflect.DeepEqual(fields, expect) {
+			t.Errorf("%s should be searched by %v, but got %v", resType, expect, fields)
+		}
+	}
+}
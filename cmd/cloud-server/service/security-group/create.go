@@ -67,6 +67,10 @@ func (svc *securityGroupSvc) createSecurityGroup(cts *rest.Contexts, bizID int64
 		return nil, err
 	}
 
+	if err = svc.namingLgc.CheckName(cts.Kit, bizID, enumor.SecurityGroupCloudResType, req.Name); err != nil {
+		return nil, err
+	}
+
 	switch req.Vendor {
 	case enumor.TCloud:
 		return svc.createTCloudSecurityGroup(cts, bizID, req)
@@ -21,6 +21,7 @@ package securitygroup
 
 import (
 	"hcm/cmd/cloud-server/logics/async"
+	"hcm/cmd/cloud-server/service/common"
 	actionsg "hcm/cmd/task-server/logics/action/security-group"
 	proto "hcm/pkg/api/cloud-server"
 	hcproto "hcm/pkg/api/hc-service"
@@ -104,47 +105,7 @@ func (svc *securityGroupSvc) createTCloudSGRule(cts *rest.Contexts, sgBaseInfo *
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	createReq := &hcproto.TCloudSGRuleCreateReq{
-		AccountID: sgBaseInfo.AccountID,
-	}
-	if len(req.EgressRuleSet) != 0 {
-		createReq.EgressRuleSet = make([]hcproto.TCloudSGRuleCreate, 0, len(req.EgressRuleSet))
-		for _, one := range req.EgressRuleSet {
-			createReq.EgressRuleSet = append(createReq.EgressRuleSet, hcproto.TCloudSGRuleCreate{
-				Protocol:                   one.Protocol,
-				Port:                       one.Port,
-				CloudServiceID:             one.CloudServiceID,
-				CloudServiceGroupID:        one.CloudServiceGroupID,
-				IPv4Cidr:                   one.IPv4Cidr,
-				IPv6Cidr:                   one.IPv6Cidr,
-				CloudAddressID:             one.CloudAddressID,
-				CloudAddressGroupID:        one.CloudAddressGroupID,
-				CloudTargetSecurityGroupID: one.CloudTargetSecurityGroupID,
-				Action:                     one.Action,
-				Memo:                       one.Memo,
-			})
-		}
-	}
-
-	if len(req.IngressRuleSet) != 0 {
-		createReq.IngressRuleSet = make([]hcproto.TCloudSGRuleCreate, 0, len(req.IngressRuleSet))
-		for _, one := range req.IngressRuleSet {
-			createReq.IngressRuleSet = append(createReq.IngressRuleSet, hcproto.TCloudSGRuleCreate{
-				Protocol:                   one.Protocol,
-				Port:                       one.Port,
-				CloudServiceID:             one.CloudServiceID,
-				CloudServiceGroupID:        one.CloudServiceGroupID,
-				IPv4Cidr:                   one.IPv4Cidr,
-				IPv6Cidr:                   one.IPv6Cidr,
-				CloudAddressID:             one.CloudAddressID,
-				CloudAddressGroupID:        one.CloudAddressGroupID,
-				CloudTargetSecurityGroupID: one.CloudTargetSecurityGroupID,
-				Action:                     one.Action,
-				Memo:                       one.Memo,
-			})
-		}
-	}
-
+	createReq := common.ConvTCloudSGRuleCreateReq(sgBaseInfo.AccountID, req)
 	result, err := svc.client.HCService().TCloud.SecurityGroup.BatchCreateSecurityGroupRule(cts.Kit.Ctx,
 		cts.Kit.Header(), sgBaseInfo.ID, createReq)
 	if err != nil {
@@ -20,6 +20,7 @@
 package securitygroup
 
 import (
+	deletehook "hcm/cmd/cloud-server/logics/delete-hook"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/iam/meta"
@@ -69,6 +70,13 @@ func (svc *securityGroupSvc) deleteSGRule(cts *rest.Contexts, validHandler handl
 		return nil, err
 	}
 
+	hookRes := deletehook.NewResource(basicInfo)
+	hookRes.ID, hookRes.ParentID = id, sgID
+	err = svc.deleteHookLgc.PreDelete(cts.Kit, enumor.SecurityGroupRuleCloudResType, []deletehook.Resource{hookRes})
+	if err != nil {
+		return nil, err
+	}
+
 	// create delete audit.
 	err = svc.audit.ChildResDeleteAudit(cts.Kit, enumor.SecurityGroupRuleAuditResType, sgID, []string{id})
 	if err != nil {
@@ -0,0 +1,124 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"hcm/cmd/cloud-server/logics/export"
+	proto "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// ExportSecurityGroup export security group list as csv or xlsx file.
+func (svc *securityGroupSvc) ExportSecurityGroup(cts *rest.Contexts) (interface{}, error) {
+	return svc.exportSecurityGroup(cts, handler.ListResourceAuthRes)
+}
+
+// ExportBizSecurityGroup export biz security group list as csv or xlsx file.
+func (svc *securityGroupSvc) ExportBizSecurityGroup(cts *rest.Contexts) (interface{}, error) {
+	return svc.exportSecurityGroup(cts, handler.ListBizAuthRes)
+}
+
+func (svc *securityGroupSvc) exportSecurityGroup(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (
+	interface{}, error) {
+
+	req := new(proto.ResourceExportReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.SecurityGroup, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+	if noPermFlag {
+		return nil, errf.New(errf.PermissionDenied, "no permission to export security group")
+	}
+
+	opt := &export.Option{Name: "security_group", Format: req.Format, Columns: req.Columns}
+	return export.Export(cts.Kit, opt, func(kt *kit.Kit, page *core.BasePage) (*core.ListResultT[any], error) {
+		listReq := &dataproto.SecurityGroupListReq{Filter: expr, Page: page}
+		result, err := svc.client.DataService().Global.SecurityGroup.ListSecurityGroup(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			return nil, err
+		}
+
+		records := export.ToRecords(result.Details)
+		if req.NeedExtension() && len(records) != 0 {
+			if records, err = export.MergeExtension(kt, records, svc.listSGExtForExport); err != nil {
+				return nil, err
+			}
+		}
+
+		return &core.ListResultT[any]{Count: result.Count, Details: records}, nil
+	})
+}
+
+// listSGExtForExport list security group with extension of the vendor by ids, gcp has no security group extension.
+func (svc *securityGroupSvc) listSGExtForExport(kt *kit.Kit, vendor enumor.Vendor, ids []string) (any, error) {
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		result, err := svc.client.DataService().TCloud.SecurityGroup.ListSecurityGroupExt(kt.Ctx, kt.Header(),
+			listReq)
+		if err != nil {
+			return nil, err
+		}
+		return result.Details, nil
+	case enumor.Aws:
+		result, err := svc.client.DataService().Aws.SecurityGroup.ListSecurityGroupExt(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			return nil, err
+		}
+		return result.Details, nil
+	case enumor.Azure:
+		result, err := svc.client.DataService().Azure.SecurityGroup.ListSecurityGroupExt(kt.Ctx, kt.Header(),
+			listReq)
+		if err != nil {
+			return nil, err
+		}
+		return result.Details, nil
+	case enumor.HuaWei:
+		result, err := svc.client.DataService().HuaWei.SecurityGroup.ListSecurityGroupExt(kt.Ctx, kt.Header(),
+			listReq)
+		if err != nil {
+			return nil, err
+		}
+		return result.Details, nil
+	default:
+		return nil, nil
+	}
+}
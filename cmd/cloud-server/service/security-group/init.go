@@ -23,6 +23,9 @@ import (
 	"net/http"
 
 	"hcm/cmd/cloud-server/logics/audit"
+	deletehook "hcm/cmd/cloud-server/logics/delete-hook"
+	"hcm/cmd/cloud-server/logics/naming"
+	logicssavedfilter "hcm/cmd/cloud-server/logics/saved-filter"
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
@@ -32,9 +35,12 @@ import (
 // InitSecurityGroupService initial the security group service
 func InitSecurityGroupService(c *capability.Capability) {
 	svc := &securityGroupSvc{
-		client:     c.ApiClient,
-		authorizer: c.Authorizer,
-		audit:      c.Audit,
+		client:         c.ApiClient,
+		authorizer:     c.Authorizer,
+		audit:          c.Audit,
+		namingLgc:      c.Logics.Naming,
+		savedFilterLgc: logicssavedfilter.NewSavedFilter(c.ApiClient, c.Authorizer),
+		deleteHookLgc:  c.Logics.DeleteHook,
 	}
 
 	h := rest.NewHandler()
@@ -45,6 +51,7 @@ func InitSecurityGroupService(c *capability.Capability) {
 	h.Add("BatchUpdateSecurityGroup", http.MethodPatch, "/security_groups/{id}", svc.UpdateSecurityGroup)
 	h.Add("BatchDeleteSecurityGroup", http.MethodDelete, "/security_groups/batch", svc.BatchDeleteSecurityGroup)
 	h.Add("ListSecurityGroup", http.MethodPost, "/security_groups/list", svc.ListSecurityGroup)
+	h.Add("ExportSecurityGroup", http.MethodPost, "/security_groups/export", svc.ExportSecurityGroup)
 	h.Add("ListSecurityGroupsByCvmID", http.MethodGet, "/security_groups/cvms/{cvm_id}", svc.ListSecurityGroupsByCvmID)
 	h.Add("AssignSecurityGroupToBiz", http.MethodPost, "/security_groups/assign/bizs", svc.AssignSecurityGroupToBiz)
 	h.Add("AssociateCvm", http.MethodPost, "/security_groups/associate/cvms", svc.AssociateCvm)
@@ -89,6 +96,8 @@ func bizService(h *rest.Handler, svc *securityGroupSvc) {
 	h.Add("BatchDeleteBizSecurityGroup", http.MethodDelete, "/bizs/{bk_biz_id}/security_groups/batch",
 		svc.BatchDeleteBizSecurityGroup)
 	h.Add("ListBizSecurityGroup", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/list", svc.ListBizSecurityGroup)
+	h.Add("ExportBizSecurityGroup", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/export",
+		svc.ExportBizSecurityGroup)
 	h.Add("ListBizSecurityGroupsByCvmID", http.MethodGet, "/bizs/{bk_biz_id}/security_groups/cvms/{cvm_id}",
 		svc.ListBizSecurityGroupsByCvmID)
 	h.Add("AssociateBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/associate/cvms", svc.AssociateBizCvm)
@@ -127,7 +136,10 @@ func bizService(h *rest.Handler, svc *securityGroupSvc) {
 }
 
 type securityGroupSvc struct {
-	client     *client.ClientSet
-	authorizer auth.Authorizer
-	audit      audit.Interface
+	client         *client.ClientSet
+	authorizer     auth.Authorizer
+	audit          audit.Interface
+	namingLgc      naming.Interface
+	savedFilterLgc logicssavedfilter.Interface
+	deleteHookLgc  deletehook.Interface
 }
@@ -201,6 +201,13 @@ func (svc *securityGroupSvc) listSecurityGroup(cts *rest.Contexts, authHandler h
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	filterExpr, err := svc.savedFilterLgc.MergeFilter(cts.Kit, req.SavedFilterID, enumor.SecurityGroupCloudResType,
+		req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	req.Filter = filterExpr
+
 	// list authorized instances
 	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
 		ResType: meta.SecurityGroup, Action: meta.Find, Filter: req.Filter})
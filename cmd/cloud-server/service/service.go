@@ -30,7 +30,9 @@ import (
 
 	"hcm/cmd/cloud-server/logics"
 	logicaudit "hcm/cmd/cloud-server/logics/audit"
+	logicsnotification "hcm/cmd/cloud-server/logics/notification"
 	"hcm/cmd/cloud-server/service/account"
+	accountgroup "hcm/cmd/cloud-server/service/account-group"
 	"hcm/cmd/cloud-server/service/application"
 	appcvm "hcm/cmd/cloud-server/service/application/handlers/cvm"
 	approvalprocess "hcm/cmd/cloud-server/service/approval_process"
@@ -43,46 +45,67 @@ import (
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/cmd/cloud-server/service/cert"
 	cloudselection "hcm/cmd/cloud-server/service/cloud-selection"
+	"hcm/cmd/cloud-server/service/compliance"
 	"hcm/cmd/cloud-server/service/cvm"
+	"hcm/cmd/cloud-server/service/diff"
 	"hcm/cmd/cloud-server/service/disk"
 	"hcm/cmd/cloud-server/service/eip"
+	errorcode "hcm/cmd/cloud-server/service/error-code"
+	"hcm/cmd/cloud-server/service/event"
 	"hcm/cmd/cloud-server/service/firewall"
+	idleresource "hcm/cmd/cloud-server/service/idle-resource"
 	"hcm/cmd/cloud-server/service/image"
 	instancetype "hcm/cmd/cloud-server/service/instance-type"
+	landingzone "hcm/cmd/cloud-server/service/landing-zone"
 	loadbalancer "hcm/cmd/cloud-server/service/load-balancer"
+	maintenancewindow "hcm/cmd/cloud-server/service/maintenance-window"
+	"hcm/cmd/cloud-server/service/monitor"
+	"hcm/cmd/cloud-server/service/naming"
 	networkinterface "hcm/cmd/cloud-server/service/network-interface"
+	"hcm/cmd/cloud-server/service/notification"
+	"hcm/cmd/cloud-server/service/quota"
 	"hcm/cmd/cloud-server/service/recycle"
 	"hcm/cmd/cloud-server/service/region"
 	resourcegroup "hcm/cmd/cloud-server/service/resource-group"
 	routetable "hcm/cmd/cloud-server/service/route-table"
+	savedfilter "hcm/cmd/cloud-server/service/saved-filter"
+	"hcm/cmd/cloud-server/service/search"
 	securitygroup "hcm/cmd/cloud-server/service/security-group"
 	subaccount "hcm/cmd/cloud-server/service/sub-account"
 	"hcm/cmd/cloud-server/service/subnet"
 	"hcm/cmd/cloud-server/service/sync"
 	"hcm/cmd/cloud-server/service/sync/lock"
+	tagpolicy "hcm/cmd/cloud-server/service/tag-policy"
 	"hcm/cmd/cloud-server/service/task"
+	taskcenter "hcm/cmd/cloud-server/service/task-center"
+	"hcm/cmd/cloud-server/service/terraform"
 	"hcm/cmd/cloud-server/service/user"
 	"hcm/cmd/cloud-server/service/vpc"
+	"hcm/cmd/cloud-server/service/webhook"
 	"hcm/cmd/cloud-server/service/zone"
 	"hcm/pkg/cc"
 	"hcm/pkg/client"
-	"hcm/pkg/criteria/errf"
 	"hcm/pkg/cryptography"
+	"hcm/pkg/featureflag"
 	"hcm/pkg/handler"
+	"hcm/pkg/health"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/logs"
 	"hcm/pkg/metrics"
 	"hcm/pkg/rest"
 	restcli "hcm/pkg/rest/client"
 	"hcm/pkg/runtime/shutdown"
+	"hcm/pkg/scheduler"
 	"hcm/pkg/serviced"
 	"hcm/pkg/thirdparty/api-gateway/bkbase"
+	"hcm/pkg/thirdparty/api-gateway/bkmonitor"
 	"hcm/pkg/thirdparty/api-gateway/cmsi"
 	"hcm/pkg/thirdparty/api-gateway/itsm"
 	"hcm/pkg/thirdparty/esb"
 	"hcm/pkg/tools/ssl"
 
 	"github.com/emicklei/go-restful/v3"
+	etcd3 "go.etcd.io/etcd/client/v3"
 )
 
 // Service do all the cloud server's work
@@ -98,6 +121,8 @@ type Service struct {
 	itsmCli   itsm.Client
 	bkBaseCli bkbase.Client
 	cmsiCli   cmsi.Client
+	// dis is used to check the dependent services in readiness probe
+	dis serviced.Discover
 }
 
 // NewService create a service instance.
@@ -107,6 +132,8 @@ func NewService(sd serviced.ServiceDiscover) (*Service, error) {
 		return nil, err
 	}
 
+	featureflag.Init(func() cc.FeatureFlags { return cc.CloudServer().FeatureFlags })
+
 	etcdCfg, err := cc.CloudServer().Service.Etcd.ToConfig()
 	if err != nil {
 		return nil, err
@@ -116,14 +143,58 @@ func NewService(sd serviced.ServiceDiscover) (*Service, error) {
 		return nil, err
 	}
 
-	if cc.CloudServer().CloudResource.Sync.Enable {
-		interval := time.Duration(cc.CloudServer().CloudResource.Sync.SyncIntervalMin) * time.Minute
-		go sync.CloudResourceSync(interval, sd, apiClientSet)
+	etcdCli, err := etcd3.New(etcdCfg)
+	if err != nil {
+		return nil, fmt.Errorf("new etcd client failed, err: %v", err)
 	}
 
-	if cc.CloudServer().BillConfig.Enable {
-		interval := time.Duration(cc.CloudServer().BillConfig.SyncIntervalMin) * time.Minute
-		go bill.CloudBillConfigCreate(interval, sd, apiClientSet)
+	if err = startScheduler(etcdCli, sd, apiClientSet, svr); err != nil {
+		return nil, err
+	}
+
+	// 变更请求的幂等记录保存在etcd中，由所有cloud-server实例共享
+	rest.EnableIdempotency(rest.NewEtcdIdempotencyStore(etcdCli, idempotencyStorePrefix),
+		time.Duration(cc.CloudServer().Idempotency.TTLMin)*time.Minute)
+
+	if cc.CloudServer().BudgetAlert.Enable {
+		interval := time.Duration(cc.CloudServer().BudgetAlert.IntervalMin) * time.Minute
+		go bill.BudgetAlertTiming(interval, sd, apiClientSet, svr.cmsiCli)
+	}
+
+	if cc.CloudServer().BillReconcile.Enable {
+		interval := time.Duration(cc.CloudServer().BillReconcile.IntervalMin) * time.Minute
+		go bill.BillReconcileTiming(interval, sd, apiClientSet)
+	}
+
+	if cc.CloudServer().CvmSchedule.Enable {
+		interval := time.Duration(cc.CloudServer().CvmSchedule.IntervalMin) * time.Minute
+		lgc := logics.NewLogics(apiClientSet, esbClient)
+		go cvm.CvmScheduleTiming(interval, sd, apiClientSet, lgc.Cvm, lgc.MaintenanceWindow)
+	}
+
+	if cc.CloudServer().CmdbHostSync.Enable {
+		interval := time.Duration(cc.CloudServer().CmdbHostSync.IntervalMin) * time.Minute
+		go cvm.CmdbHostSyncTiming(interval, sd, apiClientSet)
+	}
+
+	if cc.CloudServer().BkMonitor.Enable {
+		monitorCfg := cc.CloudServer().BkMonitor
+		monitorCli, err := bkmonitor.NewClient(&monitorCfg, metrics.Register())
+		if err != nil {
+			return nil, err
+		}
+		interval := time.Duration(monitorCfg.IntervalMin) * time.Minute
+		go monitor.MetricsReportTiming(interval, sd, apiClientSet, monitorCli)
+	}
+
+	if cc.CloudServer().IdleResource.Enable {
+		interval := time.Duration(cc.CloudServer().IdleResource.IntervalMin) * time.Minute
+		go idleresource.IdleResourceDetectTiming(interval, sd, apiClientSet)
+	}
+
+	if cc.CloudServer().Compliance.Enable {
+		interval := time.Duration(cc.CloudServer().Compliance.IntervalMin) * time.Minute
+		go compliance.ComplianceDetectTiming(interval, sd, apiClientSet)
 	}
 
 	recycle.RecycleTiming(apiClientSet, sd, cc.CloudServer().Recycle, esbClient)
@@ -135,6 +206,63 @@ func NewService(sd serviced.ServiceDiscover) (*Service, error) {
 	return svr, nil
 }
 
+// startScheduler register periodic jobs that should be run exactly once across all cloud-server replicas to the
+// scheduler, and start the scheduler.
+func startScheduler(etcdCli *etcd3.Client, sd serviced.ServiceDiscover, apiClientSet *client.ClientSet,
+	svr *Service) error {
+
+	sch := scheduler.New(sd, scheduler.NewEtcdStore(etcdCli, schedulerStorePrefix))
+
+	jobs := make([]scheduler.Job, 0)
+	if cc.CloudServer().CloudResource.Sync.Enable {
+		interval := time.Duration(cc.CloudServer().CloudResource.Sync.SyncIntervalMin) * time.Minute
+		notifier := logicsnotification.NewNotification(apiClientSet, svr.cmsiCli)
+		jobs = append(jobs, sync.CloudResourceSyncJob(interval, apiClientSet, notifier))
+	}
+
+	if cc.CloudServer().BillConfig.Enable {
+		interval := time.Duration(cc.CloudServer().BillConfig.SyncIntervalMin) * time.Minute
+		jobs = append(jobs, bill.CloudBillConfigCreateJob(interval, apiClientSet))
+	}
+
+	if cc.CloudServer().Webhook.Enable {
+		jobs = append(jobs, webhook.WebhookDeliveryJob(cc.CloudServer().Webhook, apiClientSet, svr.cipher))
+	}
+
+	for _, job := range jobs {
+		if err := sch.Register(job); err != nil {
+			return err
+		}
+	}
+	sch.Start()
+
+	// 配置热更新后同步更新定时任务的执行间隔
+	cc.OnChange(func() {
+		intervals := map[string]uint64{
+			sync.CloudResourceSyncJobName:     cc.CloudServer().CloudResource.Sync.SyncIntervalMin,
+			bill.CloudBillConfigCreateJobName: cc.CloudServer().BillConfig.SyncIntervalMin,
+		}
+		for _, job := range jobs {
+			intervalMin, exists := intervals[job.Name]
+			if !exists {
+				continue
+			}
+			if err := sch.UpdateInterval(job.Name, time.Duration(intervalMin)*time.Minute); err != nil {
+				logs.Errorf("update scheduler job %s interval failed, err: %v", job.Name, err)
+			}
+		}
+	})
+
+	return nil
+}
+
+const (
+	// schedulerStorePrefix is the etcd key prefix of cloud-server scheduler job run records.
+	schedulerStorePrefix = "/hcm/scheduler/cloud-server"
+	// idempotencyStorePrefix is the etcd key prefix of cloud-server idempotent request records.
+	idempotencyStorePrefix = "/hcm/idempotency/cloud-server"
+)
+
 func getCloudClientSvr(sd serviced.ServiceDiscover) (*client.ClientSet, esb.Client, *Service, error) {
 	tls := cc.CloudServer().Network.TLS
 	var tlsConfig *ssl.TLSConfig
@@ -194,6 +322,7 @@ func getCloudClientSvr(sd serviced.ServiceDiscover) (*client.ClientSet, esb.Clie
 	}
 
 	svr := &Service{
+		dis:        sd,
 		client:     apiClientSet,
 		authorizer: authorizer,
 		audit:      logicaudit.NewAudit(apiClientSet.DataService()),
@@ -219,9 +348,12 @@ func (s *Service) ListenAndServeRest() error {
 	root := http.NewServeMux()
 	root.HandleFunc("/", s.apiSet(cc.CloudServer().BkHcmUrl).ServeHTTP)
 	root.HandleFunc("/healthz", s.Healthz)
+	root.HandleFunc("/readyz", s.Readyz)
 	handler.SetCommonHandler(root)
 
 	network := cc.CloudServer().Network
+	rest.SetBodyLimit(network.BodyLimit)
+	rest.SetAccessLog(network.AccessLog)
 	server := &http.Server{
 		Addr:    net.JoinHostPort(network.BindIP, strconv.FormatUint(uint64(network.Port), 10)),
 		Handler: root,
@@ -272,21 +404,23 @@ func (s *Service) ListenAndServeRest() error {
 }
 
 func (s *Service) apiSet(bkHcmUrl string) *restful.Container {
-	ws := new(restful.WebService)
-	ws.Path("/api/v1/cloud")
-	ws.Produces(restful.MIME_JSON)
+	wss := rest.NewWebServices("/api/%s/cloud", rest.Version1, rest.Version2)
+	wss.Each(func(ws *restful.WebService) {
+		ws.Produces(restful.MIME_JSON)
+	})
 
 	c := &capability.Capability{
-		WebService: ws,
-		ApiClient:  s.client,
-		Authorizer: s.authorizer,
-		Audit:      s.audit,
-		Cipher:     s.cipher,
-		EsbClient:  s.esbClient,
-		Logics:     logics.NewLogics(s.client, s.esbClient),
-		ItsmCli:    s.itsmCli,
-		BKBaseCli:  s.bkBaseCli,
-		CmsiCli:    s.cmsiCli,
+		WebService:  wss.Get(rest.Version1),
+		WebServices: wss,
+		ApiClient:   s.client,
+		Authorizer:  s.authorizer,
+		Audit:       s.audit,
+		Cipher:      s.cipher,
+		EsbClient:   s.esbClient,
+		Logics:      logics.NewLogics(s.client, s.esbClient),
+		ItsmCli:     s.itsmCli,
+		BKBaseCli:   s.bkBaseCli,
+		CmsiCli:     s.cmsiCli,
 	}
 
 	account.InitAccountService(c)
@@ -322,27 +456,35 @@ func (s *Service) apiSet(bkHcmUrl string) *restful.Container {
 	asynctask.InitService(c)
 
 	bandwidthpackage.InitService(c)
+	quota.InitService(c)
+	idleresource.InitService(c)
+	naming.InitService(c)
+	tagpolicy.InitService(c)
+	accountgroup.InitService(c)
+	notification.InitService(c)
+	savedfilter.InitService(c)
+	compliance.InitService(c)
+	taskcenter.InitService(c)
+	search.InitService(c)
+	terraform.InitService(c)
+	maintenancewindow.InitService(c)
+	landingzone.InitService(c)
+	diff.InitService(c)
+	event.InitService(c)
+	webhook.InitService(c)
+	errorcode.InitService(c)
 
 	task.InitService(c)
 
-	return restful.NewContainer().Add(c.WebService)
+	return wss.Container()
 }
 
 // Healthz check whether the service is healthy.
 func (s *Service) Healthz(w http.ResponseWriter, r *http.Request) {
-	if shutdown.IsShuttingDown() {
-		logs.Errorf("service healthz check failed, current service is shutting down")
-		w.WriteHeader(http.StatusServiceUnavailable)
-		rest.WriteResp(w, rest.NewBaseResp(errf.UnHealthy, "current service is shutting down"))
-		return
-	}
-
-	if err := serviced.Healthz(r.Context(), cc.CloudServer().Service); err != nil {
-		logs.Errorf("serviced healthz check failed, err: %v", err)
-		rest.WriteResp(w, rest.NewBaseResp(errf.UnHealthy, "serviced healthz error, "+err.Error()))
-		return
-	}
+	health.ServeLiveness(w, r, health.Etcd(cc.CloudServer().Service))
+}
 
-	rest.WriteResp(w, rest.NewBaseResp(errf.OK, "healthy"))
-	return
+// Readyz check whether the service and its dependencies are ready to serve requests.
+func (s *Service) Readyz(w http.ResponseWriter, r *http.Request) {
+	health.ServeReadiness(w, r, health.Readiness(cc.CloudServer().Service, s.dis)...)
 }
@@ -25,6 +25,7 @@ import (
 
 	"hcm/cmd/cloud-server/logics/async"
 	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/cmd/cloud-server/logics/naming"
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/cmd/cloud-server/service/common"
 	actionsubnet "hcm/cmd/task-server/logics/action/subnet"
@@ -57,6 +58,7 @@ func InitSubnetService(c *capability.Capability) {
 		client:     c.ApiClient,
 		authorizer: c.Authorizer,
 		audit:      c.Audit,
+		namingLgc:  c.Logics.Naming,
 	}
 
 	h := rest.NewHandler()
@@ -88,6 +90,7 @@ type subnetSvc struct {
 	client     *client.ClientSet
 	authorizer auth.Authorizer
 	audit      audit.Interface
+	namingLgc  naming.Interface
 }
 
 // CreateSubnet create subnet.
@@ -120,6 +123,16 @@ func (svc *subnetSvc) createSubnet(cts *rest.Contexts, bizID int64,
 		return nil, err
 	}
 
+	nameReq := new(struct {
+		Name string `json:"name"`
+	})
+	if err = cts.ReDecodeInto(nameReq); err != nil {
+		return nil, err
+	}
+	if err = svc.namingLgc.CheckName(cts.Kit, bizID, enumor.SubnetCloudResType, nameReq.Name); err != nil {
+		return nil, err
+	}
+
 	req := new(cloudserver.RawCreateReq)
 	if err := cts.DecodeInto(req); err != nil {
 		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
@@ -21,60 +21,84 @@ package sync
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"hcm/cmd/cloud-server/logics/account"
+	"hcm/cmd/cloud-server/logics/notification"
+	"hcm/cmd/cloud-server/logics/tenant"
 	"hcm/cmd/cloud-server/service/sync/detail"
 	"hcm/pkg/api/core"
 	corecloud "hcm/pkg/api/core/cloud"
 	protocloud "hcm/pkg/api/data-service/cloud"
+	datanaming "hcm/pkg/api/data-service/naming"
+	datatagpolicy "hcm/pkg/api/data-service/tag-policy"
+	"hcm/pkg/cc"
 	"hcm/pkg/client"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
-	"hcm/pkg/serviced"
+	"hcm/pkg/scheduler"
 	"hcm/pkg/tools/retry"
 )
 
-// CloudResourceSync 定时同步云资源
-func CloudResourceSync(intervalMin time.Duration, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
-	logs.Infof("cloud resource sync enable, syncIntervalMin: %v", intervalMin)
+// CloudResourceSyncJobName is the scheduler job name of cloud resource sync.
+const CloudResourceSyncJobName = "cloud_resource_sync"
 
-	for {
-		time.Sleep(intervalMin)
+// CloudResourceSyncJob 定时同步云资源，账号同步失败时发送通知
+func CloudResourceSyncJob(interval time.Duration, cliSet *client.ClientSet,
+	notifier notification.Interface) scheduler.Job {
 
-		if !sd.IsMaster() {
-			continue
-		}
+	logs.Infof("cloud resource sync enable, syncIntervalMin: %v", interval)
 
-		start := time.Now()
-		logs.Infof("cloud resource all sync start, time: %v", start)
-
-		waitGroup := new(sync.WaitGroup)
-		syncers := account.GetAvailableVendorSyncers()
-
-		waitGroup.Add(len(syncers))
-		for _, vendorSyncer := range syncers {
-			go func(vendor account.VendorSyncer) {
-				kt := core.NewBackendKit()
-				// for retry
-				kt.RequestSource = enumor.AsynchronousTasks
-				allAccountSync(kt, cliSet, vendor)
-				waitGroup.Done()
-			}(vendorSyncer)
-		}
+	return scheduler.Job{
+		Name:     CloudResourceSyncJobName,
+		Interval: interval,
+		Run: func(kt *kit.Kit) error {
+			CloudResourceSync(kt, cliSet, notifier)
+			return nil
+		},
+	}
+}
+
+// CloudResourceSync 按租户依次同步全部账号的云资源
+func CloudResourceSync(kt *kit.Kit, cliSet *client.ClientSet, notifier notification.Interface) {
+	err := tenant.ForEach(kt, cliSet.DataService(), func(kt *kit.Kit) {
+		tenantResourceSync(kt, cliSet, notifier)
+	})
+	if err != nil {
+		logs.Errorf("cloud resource sync failed, err: %v, rid: %s", err, kt.Rid)
+	}
+}
 
-		waitGroup.Wait()
+// tenantResourceSync 同步kit所属租户下全部账号的云资源
+func tenantResourceSync(kt *kit.Kit, cliSet *client.ClientSet, notifier notification.Interface) {
+	start := time.Now()
+	logs.Infof("tenant %s cloud resource all sync start, time: %v, rid: %s", kt.TenantID, start, kt.Rid)
 
-		logs.Infof("cloud resource all sync end, time: %v", start)
+	waitGroup := new(sync.WaitGroup)
+	syncers := account.GetAvailableVendorSyncers()
+
+	waitGroup.Add(len(syncers))
+	for _, vendorSyncer := range syncers {
+		go func(vendor account.VendorSyncer) {
+			// for retry
+			allAccountSync(kt.NewSubKit().WithAsyncSource(), cliSet, vendor, notifier)
+			waitGroup.Done()
+		}(vendorSyncer)
 	}
+
+	waitGroup.Wait()
+
+	logs.Infof("tenant %s cloud resource all sync end, time: %v, rid: %s", kt.TenantID, start, kt.Rid)
 }
 
 // allAccountSync all account sync.
-func allAccountSync(kt *kit.Kit, cliSet *client.ClientSet, syncer account.VendorSyncer) {
+func allAccountSync(kt *kit.Kit, cliSet *client.ClientSet, syncer account.VendorSyncer,
+	notifier notification.Interface) {
 
 	startTime := time.Now()
 	logs.Infof("%s start sync all cloud resource, time: %v, rid: %s", syncer.Vendor(), startTime, kt.Rid)
@@ -117,12 +141,20 @@ func allAccountSync(kt *kit.Kit, cliSet *client.ClientSet, syncer account.Vendor
 				}
 				logs.Errorf("sync %s all resource failed, err: %v, accountID: %s, rid: %s",
 					syncer.Vendor(), err, acc.ID, kt.Rid)
+				notifier.Notify(kt, newSyncFailedEvent(acc, resName, err))
 				// 跳过当前账号
 				continue
 			}
 
 			// 公共资源仅需要同步一次即可
 			syncPublicResource = false
+
+			if cc.CloudServer().NamingPolicy.CheckOnSync {
+				checkNamingViolation(kt, cliSet, acc.ID)
+			}
+			if cc.CloudServer().TagPolicy.CheckOnSync {
+				checkTagViolation(kt, cliSet, acc.ID)
+			}
 		}
 		if len(accounts) < int(core.DefaultMaxPageLimit) {
 			break
@@ -131,6 +163,77 @@ func allAccountSync(kt *kit.Kit, cliSet *client.ClientSet, syncer account.Vendor
 	}
 }
 
+// checkNamingViolation 同步完成后检测账号下资源的命名是否符合命名规则，检测失败不影响同步结果
+func checkNamingViolation(kt *kit.Kit, cliSet *client.ClientSet, accountID string) {
+	req := &datanaming.NamingViolationCheckReq{AccountID: accountID}
+	result, err := cliSet.DataService().Global.NamingRule.CheckViolation(kt, req)
+	if err != nil {
+		logs.Errorf("check naming violation failed, err: %v, accountID: %s, rid: %s", err, accountID, kt.Rid)
+		return
+	}
+
+	if result.Count > 0 {
+		logs.Infof("found %d naming violation resources, accountID: %s, rid: %s", result.Count, accountID, kt.Rid)
+	}
+}
+
+// checkTagViolation 同步完成后检测账号下资源的标签是否符合标签策略，检测失败不影响同步结果
+func checkTagViolation(kt *kit.Kit, cliSet *client.ClientSet, accountID string) {
+	req := &datatagpolicy.TagViolationCheckReq{AccountID: accountID}
+	result, err := cliSet.DataService().Global.TagPolicy.CheckViolation(kt, req)
+	if err != nil {
+		logs.Errorf("check tag violation failed, err: %v, accountID: %s, rid: %s", err, accountID, kt.Rid)
+		return
+	}
+
+	if result.Count > 0 {
+		logs.Infof("found %d tag violations, accountID: %s, rid: %s", result.Count, accountID, kt.Rid)
+	}
+}
+
+// credentialInvalidPatterns 云厂商返回的鉴权失败错误特征，命中时认为账号密钥已过期、被禁用或被删除
+var credentialInvalidPatterns = []string{
+	// tcloud
+	"AuthFailure",
+	// aws
+	"InvalidClientTokenId", "UnrecognizedClientException", "SignatureDoesNotMatch",
+	// azure，AADSTS7000215：密钥错误，AADSTS7000222：密钥已过期
+	"AADSTS7000215", "AADSTS7000222", "invalid_client",
+	// huawei
+	"APIGW.0301", "IAM.0001",
+	// gcp
+	"invalid_grant", "unauthorized_client",
+}
+
+// newSyncFailedEvent 根据同步失败原因生成通知事件，鉴权失败时生成账号密钥失效事件
+func newSyncFailedEvent(acc *corecloud.BaseAccount, resName enumor.CloudResourceType,
+	syncErr error) *notification.Event {
+
+	event := &notification.Event{
+		Type:         enumor.NotificationSyncFailed,
+		AccountID:    acc.ID,
+		BkBizIDs:     acc.BkBizIDs,
+		RelatedUsers: acc.Managers,
+		Data: map[string]interface{}{
+			"vendor":       acc.Vendor,
+			"account_id":   acc.ID,
+			"account_name": acc.Name,
+			"resource":     resName,
+			"error":        syncErr.Error(),
+		},
+	}
+
+	for _, pattern := range credentialInvalidPatterns {
+		if strings.Contains(syncErr.Error(), pattern) {
+			event.Type = enumor.NotificationCredentialInvalid
+			delete(event.Data, "resource")
+			break
+		}
+	}
+
+	return event
+}
+
 const maxRetryCount = 3
 
 // listAccountWithRetry 查询账号列表，最多重试3次，每次等待
@@ -0,0 +1,216 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tagpolicy tag policy admin service.
+package tagpolicy
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/api/core"
+	datatagpolicy "hcm/pkg/api/data-service/tag-policy"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the tag policy service.
+func InitService(c *capability.Capability) {
+	svc := &tagPolicySvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateTagPolicy", http.MethodPost, "/tag_policies/create", svc.CreateTagPolicy)
+	h.Add("UpdateTagPolicy", http.MethodPatch, "/tag_policies/{id}", svc.UpdateTagPolicy)
+	h.Add("ListTagPolicy", http.MethodPost, "/tag_policies/list", svc.ListTagPolicy)
+	h.Add("BatchDeleteTagPolicy", http.MethodDelete, "/tag_policies/batch", svc.BatchDeleteTagPolicy)
+	h.Add("CheckTagViolation", http.MethodPost, "/tag_violations/check", svc.CheckTagViolation)
+	h.Add("ListTagViolation", http.MethodPost, "/tag_violations/list", svc.ListTagViolation)
+	h.Add("GetTagComplianceReport", http.MethodPost, "/tag_violations/compliance_report",
+		svc.GetTagComplianceReport)
+
+	h.Load(c.WebService)
+}
+
+type tagPolicySvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// CreateTagPolicy create tag policy, bk_biz_id -1 means the default policy for all biz, the
+// policy of biz takes precedence over the default one with the same tag key.
+func (svc *tagPolicySvc) CreateTagPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(datatagpolicy.TagPolicyCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Create); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.client.DataService().Global.TagPolicy.Create(cts.Kit, req)
+	if err != nil {
+		logs.Errorf("create tag policy failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateTagPolicy update tag policy.
+func (svc *tagPolicySvc) UpdateTagPolicy(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(datatagpolicy.TagPolicyUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.TagPolicy.Update(cts.Kit, id, req); err != nil {
+		logs.Errorf("update tag policy failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListTagPolicy list tag policy.
+func (svc *tagPolicySvc) ListTagPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.TagPolicy.List(cts.Kit, req)
+}
+
+// BatchDeleteTagPolicy batch delete tag policy.
+func (svc *tagPolicySvc) BatchDeleteTagPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.TagPolicy.BatchDelete(cts.Kit, req); err != nil {
+		logs.Errorf("delete tag policy failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListTagViolation list cloud resources which are untagged or mistagged, which are detected after cloud resource
+// sync or by manual check.
+func (svc *tagPolicySvc) ListTagViolation(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.TagPolicy.ListViolation(cts.Kit, req)
+}
+
+// CheckTagViolation check the tags of account resources against the tag policies immediately.
+func (svc *tagPolicySvc) CheckTagViolation(cts *rest.Contexts) (interface{}, error) {
+	req := new(datatagpolicy.TagViolationCheckReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.TagPolicy.CheckViolation(cts.Kit, req)
+}
+
+// GetTagComplianceReport get the tag compliance report of accounts, which counts the untagged and mistagged
+// resources of each account.
+func (svc *tagPolicySvc) GetTagComplianceReport(cts *rest.Contexts) (interface{}, error) {
+	req := new(datatagpolicy.TagComplianceReportReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.TagPolicy.GetComplianceReport(cts.Kit, req)
+}
+
+func (svc *tagPolicySvc) authorize(cts *rest.Contexts, action meta.Action) error {
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.TagPolicy, Action: action}}
+	return svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes)
+}
@@ -0,0 +1,330 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package taskcenter task center service, lists all async jobs of the platform with state and sub task breakdown,
+// and supports retry and cancel.
+package taskcenter
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/logics/account"
+	"hcm/cmd/cloud-server/service/capability"
+	cstaskcenter "hcm/pkg/api/cloud-server/task-center"
+	"hcm/pkg/api/core"
+	coreasync "hcm/pkg/api/core/async"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/i18n"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the task center service.
+func InitService(c *capability.Capability) {
+	svc := &taskCenterSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListAsyncJob", http.MethodPost, "/task_center/jobs/list", svc.ListAsyncJob)
+	h.Add("ListAsyncJobSubTask", http.MethodPost, "/task_center/jobs/sub_tasks/list", svc.ListAsyncJobSubTask)
+	h.Add("RetryAsyncJob", http.MethodPost, "/task_center/jobs/retry", svc.RetryAsyncJob)
+	h.Add("CancelAsyncJob", http.MethodPost, "/task_center/jobs/cancel", svc.CancelAsyncJob)
+	h.Add("WatchAsyncJob", http.MethodGet, "/task_center/jobs/{kind}/{id}/events", svc.WatchAsyncJob)
+
+	h.Load(c.WebService)
+}
+
+type taskCenterSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// ListAsyncJob list async jobs, including async flows and account syncs.
+func (svc *taskCenterSvc) ListAsyncJob(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.AsyncJob.List(cts.Kit, req)
+}
+
+// ListAsyncJobSubTask list sub tasks of async job.
+func (svc *taskCenterSvc) ListAsyncJobSubTask(cts *rest.Contexts) (interface{}, error) {
+	req := new(cstaskcenter.SubTaskListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	job, err := svc.getJob(cts.Kit, req.Kind, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch job.Kind {
+	case enumor.AsyncJobFlow:
+		return svc.listFlowSubTask(cts.Kit, job.ID, req.Page)
+	case enumor.AsyncJobSync:
+		return svc.listSyncSubTask(cts.Kit, job, req.Page)
+	default:
+		return nil, errf.NewLocalized(errf.InvalidParameter, i18n.AsyncJobKindUnsupported, job.Kind)
+	}
+}
+
+func (svc *taskCenterSvc) listFlowSubTask(kt *kit.Kit, flowID string, page *core.BasePage) (
+	*core.ListResultT[cstaskcenter.SubTask], error) {
+
+	listReq := &core.ListReq{Filter: tools.EqualExpression("flow_id", flowID), Page: page}
+	result, err := svc.client.TaskServer().ListTask(kt, listReq)
+	if err != nil {
+		logs.Errorf("list flow task failed, err: %v, flow: %s, rid: %s", err, flowID, kt.Rid)
+		return nil, err
+	}
+
+	if page.Count {
+		return &core.ListResultT[cstaskcenter.SubTask]{Count: result.Count}, nil
+	}
+
+	details := make([]cstaskcenter.SubTask, 0, len(result.Details))
+	for _, one := range result.Details {
+		subTask := cstaskcenter.SubTask{
+			ID:        one.ID,
+			Name:      string(one.ActionName),
+			State:     string(one.State),
+			CreatedAt: one.CreatedAt,
+			UpdatedAt: one.UpdatedAt,
+		}
+		if one.Reason != nil {
+			subTask.Reason = one.Reason.Message
+		}
+		details = append(details, subTask)
+	}
+
+	return &core.ListResultT[cstaskcenter.SubTask]{Details: details}, nil
+}
+
+// syncStateMap 资源同步状态到异步任务状态的映射，与 async_job 视图中的映射保持一致
+var syncStateMap = map[string]enumor.FlowState{
+	string(enumor.SyncSuccess): enumor.FlowSuccess,
+	string(enumor.SyncFailed):  enumor.FlowFailed,
+	string(enumor.Syncing):     enumor.FlowRunning,
+	string(enumor.NotSync):     enumor.FlowPending,
+}
+
+func (svc *taskCenterSvc) listSyncSubTask(kt *kit.Kit, job *coreasync.AsyncJob, page *core.BasePage) (
+	*core.ListResultT[cstaskcenter.SubTask], error) {
+
+	listReq := &core.ListReq{
+		Filter: tools.EqualWithOpExpression("and", map[string]interface{}{"account_id": job.AccountID,
+			"vendor": job.Vendor}),
+		Page: page,
+	}
+	result, err := svc.client.DataService().Global.AccountSyncDetail.List(kt, listReq)
+	if err != nil {
+		logs.Errorf("list account sync detail failed, err: %v, account: %s, rid: %s", err, job.AccountID, kt.Rid)
+		return nil, err
+	}
+
+	if page.Count {
+		return &core.ListResultT[cstaskcenter.SubTask]{Count: result.Count}, nil
+	}
+
+	details := make([]cstaskcenter.SubTask, 0, len(result.Details))
+	for _, one := range result.Details {
+		state, ok := syncStateMap[one.ResStatus]
+		if !ok {
+			state = enumor.FlowState(one.ResStatus)
+		}
+		details = append(details, cstaskcenter.SubTask{
+			ID:        one.ID,
+			Name:      one.ResName,
+			State:     string(state),
+			Reason:    string(one.ResFailedReason),
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		})
+	}
+
+	return &core.ListResultT[cstaskcenter.SubTask]{Details: details}, nil
+}
+
+// RetryAsyncJob retry failed async job. the failed flow retries the given failed task or the first failed task,
+// the account sync job triggers a new sync of the account.
+func (svc *taskCenterSvc) RetryAsyncJob(cts *rest.Contexts) (interface{}, error) {
+	req := new(cstaskcenter.JobRetryReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	job, err := svc.getJob(cts.Kit, req.Kind, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch job.Kind {
+	case enumor.AsyncJobFlow:
+		return svc.retryFlow(cts.Kit, job, req.TaskID)
+	case enumor.AsyncJobSync:
+		if job.State == enumor.FlowRunning {
+			return nil, errf.NewLocalized(errf.InvalidParameter, i18n.AccountSyncingNoRetry)
+		}
+
+		if err = account.Sync(cts.Kit, svc.client, job.Vendor, job.AccountID); err != nil {
+			logs.Errorf("retry account sync failed, err: %v, account: %s, rid: %s", err, job.AccountID,
+				cts.Kit.Rid)
+			return nil, err
+		}
+		return new(cstaskcenter.JobRetryResult), nil
+	default:
+		return nil, errf.NewLocalized(errf.InvalidParameter, i18n.AsyncJobKindUnsupported, job.Kind)
+	}
+}
+
+func (svc *taskCenterSvc) retryFlow(kt *kit.Kit, job *coreasync.AsyncJob, taskID string) (
+	*cstaskcenter.JobRetryResult, error) {
+
+	if job.State != enumor.FlowFailed {
+		return nil, errf.NewLocalized(errf.InvalidParameter, i18n.FlowRetryStateInvalid, job.State)
+	}
+
+	if len(taskID) == 0 {
+		listReq := &core.ListReq{
+			Filter: tools.EqualWithOpExpression("and", map[string]interface{}{"flow_id": job.ID,
+				"state": enumor.TaskFailed}),
+			Page: &core.BasePage{Limit: 1, Sort: "created_at", Order: core.Ascending},
+		}
+		tasks, err := svc.client.TaskServer().ListTask(kt, listReq)
+		if err != nil {
+			logs.Errorf("list failed flow task failed, err: %v, flow: %s, rid: %s", err, job.ID, kt.Rid)
+			return nil, err
+		}
+
+		if len(tasks.Details) == 0 {
+			return nil, errf.NewLocalized(errf.InvalidParameter, i18n.FlowNoFailedTask, job.ID)
+		}
+		taskID = tasks.Details[0].ID
+	}
+
+	if err := svc.client.TaskServer().RetryTask(kt, job.ID, taskID); err != nil {
+		logs.Errorf("retry flow task failed, err: %v, flow: %s, task: %s, rid: %s", err, job.ID, taskID, kt.Rid)
+		return nil, err
+	}
+
+	return &cstaskcenter.JobRetryResult{TaskID: taskID}, nil
+}
+
+// cancelableFlowStates 可以终止的异步任务流状态
+var cancelableFlowStates = map[enumor.FlowState]struct{}{
+	enumor.FlowInit:      {},
+	enumor.FlowPending:   {},
+	enumor.FlowScheduled: {},
+	enumor.FlowRunning:   {},
+}
+
+// CancelAsyncJob cancel unfinished async flow, account sync job can not be canceled.
+func (svc *taskCenterSvc) CancelAsyncJob(cts *rest.Contexts) (interface{}, error) {
+	req := new(cstaskcenter.JobReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	if req.Kind != enumor.AsyncJobFlow {
+		return nil, errf.NewLocalized(errf.InvalidParameter, i18n.AsyncJobNotCancelable, req.Kind)
+	}
+
+	job, err := svc.getJob(cts.Kit, req.Kind, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := cancelableFlowStates[job.State]; !ok {
+		return nil, errf.NewLocalized(errf.InvalidParameter, i18n.FlowCancelStateInvalid, job.State)
+	}
+
+	if err = svc.client.TaskServer().CancelFlow(cts.Kit, job.ID); err != nil {
+		logs.Errorf("cancel flow failed, err: %v, flow: %s, rid: %s", err, job.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (svc *taskCenterSvc) getJob(kt *kit.Kit, kind enumor.AsyncJobKind, id string) (*coreasync.AsyncJob, error) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualWithOpExpression("and", map[string]interface{}{"kind": kind, "id": id}),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.client.DataService().Global.AsyncJob.List(kt, listReq)
+	if err != nil {
+		logs.Errorf("get async job failed, err: %v, kind: %s, id: %s, rid: %s", err, kind, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.NewLocalized(errf.RecordNotFound, i18n.AsyncJobNotFound, kind, id)
+	}
+
+	return &result.Details[0], nil
+}
+
+func (svc *taskCenterSvc) authorize(cts *rest.Contexts, action meta.Action) error {
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.TaskCenter, Action: action}}
+	return svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes)
+}
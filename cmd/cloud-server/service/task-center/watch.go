@@ -0,0 +1,170 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package taskcenter
+
+import (
+	"time"
+
+	cstaskcenter "hcm/pkg/api/cloud-server/task-center"
+	"hcm/pkg/api/core"
+	coreasync "hcm/pkg/api/core/async"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/i18n"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+const (
+	// watchInterval 服务端查询异步任务状态的间隔
+	watchInterval = 2 * time.Second
+	// watchKeepAliveInterval 没有状态变更时发送保活消息的间隔，避免代理关闭空闲连接
+	watchKeepAliveInterval = 15 * time.Second
+	// watchMaxDuration 单个事件流的最长时间，超过后正常结束，由客户端重新连接
+	watchMaxDuration = 30 * time.Minute
+)
+
+// finalFlowStates 异步任务的终态，进入终态后不会再发生变更
+var finalFlowStates = map[enumor.FlowState]struct{}{
+	enumor.FlowSuccess: {},
+	enumor.FlowFailed:  {},
+	enumor.FlowCancel:  {},
+}
+
+// WatchAsyncJob push the state changes of async job and its sub tasks to client by server-sent events, so
+// that the client needs not to poll the job. the first events of each connection are the full snapshot of
+// the job and its sub tasks, then only the changes are pushed, the stream ends when the job is finished.
+func (svc *taskCenterSvc) WatchAsyncJob(cts *rest.Contexts) (interface{}, error) {
+	req := &cstaskcenter.JobReq{
+		Kind: enumor.AsyncJobKind(cts.PathParameter("kind").String()),
+		ID:   cts.PathParameter("id").String(),
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	// 建立事件流前先确认任务存在，不存在时以普通响应返回错误
+	job, err := svc.getJob(cts.Kit, req.Kind, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return rest.EventStreamFunc(func(w *rest.EventWriter) error {
+		return svc.watchJob(cts.Kit, job, w)
+	}), nil
+}
+
+func (svc *taskCenterSvc) watchJob(kt *kit.Kit, job *coreasync.AsyncJob, w *rest.EventWriter) error {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	deadline := time.Now().Add(watchMaxDuration)
+
+	var lastJob *coreasync.AsyncJob
+	lastSubTasks := make(map[string]cstaskcenter.SubTask)
+	lastSentAt := time.Now()
+	for {
+		changed := false
+		if lastJob == nil || lastJob.State != job.State || lastJob.Reason != job.Reason ||
+			lastJob.UpdatedAt != job.UpdatedAt {
+
+			if err := w.Send(cstaskcenter.JobEventJob, "", job); err != nil {
+				return err
+			}
+			lastJob, changed = job, true
+		}
+
+		subTasks, err := svc.listAllSubTask(kt, job)
+		if err != nil {
+			return err
+		}
+
+		changedTasks := make([]cstaskcenter.SubTask, 0)
+		for _, one := range subTasks {
+			if last, ok := lastSubTasks[one.ID]; ok && last == one {
+				continue
+			}
+			lastSubTasks[one.ID] = one
+			changedTasks = append(changedTasks, one)
+		}
+		if len(changedTasks) != 0 {
+			if err = w.Send(cstaskcenter.JobEventSubTask, "", changedTasks); err != nil {
+				return err
+			}
+			changed = true
+		}
+
+		// 子任务在任务进入终态前已完成更新，因此任务终态的子任务推送完成后即可结束
+		if _, ok := finalFlowStates[job.State]; ok || time.Now().After(deadline) {
+			return nil
+		}
+
+		if changed {
+			lastSentAt = time.Now()
+		} else if time.Since(lastSentAt) >= watchKeepAliveInterval {
+			if err = w.KeepAlive(); err != nil {
+				return err
+			}
+			lastSentAt = time.Now()
+		}
+
+		select {
+		case <-kt.Ctx.Done():
+			return kt.Ctx.Err()
+		case <-ticker.C:
+		}
+
+		if job, err = svc.getJob(kt, job.Kind, job.ID); err != nil {
+			return err
+		}
+	}
+}
+
+// listAllSubTask list all sub tasks of async job page by page.
+func (svc *taskCenterSvc) listAllSubTask(kt *kit.Kit, job *coreasync.AsyncJob) ([]cstaskcenter.SubTask, error) {
+	all := make([]cstaskcenter.SubTask, 0)
+	for start := uint32(0); ; start += uint32(core.DefaultMaxPageLimit) {
+		page := &core.BasePage{Start: start, Limit: core.DefaultMaxPageLimit, Sort: "created_at",
+			Order: core.Ascending}
+
+		var result *core.ListResultT[cstaskcenter.SubTask]
+		var err error
+		switch job.Kind {
+		case enumor.AsyncJobFlow:
+			result, err = svc.listFlowSubTask(kt, job.ID, page)
+		case enumor.AsyncJobSync:
+			result, err = svc.listSyncSubTask(kt, job, page)
+		default:
+			return nil, errf.NewLocalized(errf.InvalidParameter, i18n.AsyncJobKindUnsupported, job.Kind)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, result.Details...)
+		if uint(len(result.Details)) < core.DefaultMaxPageLimit {
+			return all, nil
+		}
+	}
+}
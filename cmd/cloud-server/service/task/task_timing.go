@@ -22,6 +22,7 @@ package task
 import (
 	"time"
 
+	"hcm/cmd/cloud-server/logics/tenant"
 	"hcm/pkg/api/core"
 	coretask "hcm/pkg/api/core/task"
 	datatask "hcm/pkg/api/data-service/task"
@@ -43,24 +44,30 @@ func TimingHandleTaskMgmtState(c *client.ClientSet, sd serviced.State, interval
 			continue
 		}
 
-		kt := core.NewBackendKit()
-		listReq := &core.ListReq{
-			Filter: tools.EqualExpression("state", enumor.TaskManagementRunning),
-			Fields: []string{"id", "state", "flow_ids"},
-			Page:   core.NewDefaultBasePage(),
-		}
-		list, err := c.DataService().Global.TaskManagement.List(kt, listReq)
-		if err != nil {
-			logs.Errorf("list task management failed, err: %v, req: %+v, rid: %s", err, listReq, kt.Rid)
-			continue
-		}
+		_ = tenant.ForEach(core.NewBackendKit(), c.DataService(), func(kt *kit.Kit) {
+			refreshRunningTaskMgmtState(kt, c)
+		})
+	}
+}
 
-		for _, management := range list.Details {
-			if _, err = refreshTaskMgmtState(kt, c, management); err != nil {
-				logs.Errorf("refresh task management state failed, err: %v, data: %+v, rid: %s", err, management,
-					kt.Rid)
-				continue
-			}
+// refreshRunningTaskMgmtState 刷新kit所属租户下处于running状态的任务管理数据状态
+func refreshRunningTaskMgmtState(kt *kit.Kit, c *client.ClientSet) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("state", enumor.TaskManagementRunning),
+		Fields: []string{"id", "state", "flow_ids"},
+		Page:   core.NewDefaultBasePage(),
+	}
+	list, err := c.DataService().Global.TaskManagement.List(kt, listReq)
+	if err != nil {
+		logs.Errorf("list task management failed, err: %v, req: %+v, rid: %s", err, listReq, kt.Rid)
+		return
+	}
+
+	for _, management := range list.Details {
+		if _, err = refreshTaskMgmtState(kt, c, management); err != nil {
+			logs.Errorf("refresh task management state failed, err: %v, data: %+v, rid: %s", err, management,
+				kt.Rid)
+			continue
 		}
 	}
 }
@@ -0,0 +1,209 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package terraform terraform interoperability service, render hcm managed network resources as terraform
+// configuration with import blocks, and map the resources of an existing terraform state onto hcm resources.
+package terraform
+
+import (
+	"net/http"
+
+	logicsterraform "hcm/cmd/cloud-server/logics/terraform"
+	"hcm/cmd/cloud-server/service/capability"
+	csterraform "hcm/pkg/api/cloud-server/terraform"
+	"hcm/pkg/api/core"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+	"hcm/pkg/tools/slice"
+)
+
+// InitService initialize the terraform service.
+func InitService(c *capability.Capability) {
+	svc := &terraformSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ExportTerraform", http.MethodPost, "/terraform/export", svc.ExportTerraform)
+	h.Add("ImportTerraformState", http.MethodPost, "/terraform/state/import", svc.ImportTerraformState)
+
+	h.Add("ExportBizTerraform", http.MethodPost, "/bizs/{bk_biz_id}/terraform/export", svc.ExportBizTerraform)
+	h.Add("ImportBizTerraformState", http.MethodPost, "/bizs/{bk_biz_id}/terraform/state/import",
+		svc.ImportBizTerraformState)
+
+	h.Load(c.WebService)
+}
+
+type terraformSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// ExportTerraform export resources as terraform configuration in resource view.
+func (svc *terraformSvc) ExportTerraform(cts *rest.Contexts) (interface{}, error) {
+	return svc.export(cts, handler.ListResourceAuthRes)
+}
+
+// ExportBizTerraform export resources as terraform configuration in biz view.
+func (svc *terraformSvc) ExportBizTerraform(cts *rest.Contexts) (interface{}, error) {
+	return svc.export(cts, handler.ListBizAuthRes)
+}
+
+func (svc *terraformSvc) export(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (interface{}, error) {
+	req := new(csterraform.ExportReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	if !logicsterraform.IsVendorSupported(req.Vendor) {
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s does not support terraform export", req.Vendor)
+	}
+
+	opt := &logicsterraform.ExportOption{
+		Vendor:           req.Vendor,
+		VpcIDs:           slice.Unique(req.VpcIDs),
+		SubnetIDs:        slice.Unique(req.SubnetIDs),
+		SecurityGroupIDs: slice.Unique(req.SecurityGroupIDs),
+	}
+
+	// 校验资源均存在且有查看权限
+	checks := []struct {
+		resType enumor.CloudResourceType
+		ids     []string
+	}{
+		{resType: enumor.VpcCloudResType, ids: opt.VpcIDs},
+		{resType: enumor.SubnetCloudResType, ids: opt.SubnetIDs},
+		{resType: enumor.SecurityGroupCloudResType, ids: opt.SecurityGroupIDs},
+	}
+	for _, check := range checks {
+		if len(check.ids) == 0 {
+			continue
+		}
+		resFilter := tools.ExpressionAnd(tools.RuleEqual("vendor", req.Vendor), tools.RuleIn("id", check.ids))
+		if err := svc.checkResources(cts, authHandler, check.resType, resFilter, len(check.ids)); err != nil {
+			return nil, err
+		}
+	}
+
+	content, err := logicsterraform.Export(cts.Kit, svc.client.DataService(), opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &csterraform.ExportResult{Content: content}, nil
+}
+
+// checkResources check all resources matched by the filter are authorized.
+func (svc *terraformSvc) checkResources(cts *rest.Contexts, authHandler handler.ListAuthResHandler,
+	resType enumor.CloudResourceType, resFilter *filter.Expression, expected int) error {
+
+	expr, noPerm, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: authResTypes[resType], Action: meta.Find, Filter: resFilter})
+	if err != nil {
+		return err
+	}
+	if noPerm {
+		return errf.Newf(errf.PermissionDenied, "no permission to view %s", resType)
+	}
+
+	listReq := &core.ListReq{Filter: expr, Page: core.NewCountPage()}
+	var count uint64
+	switch resType {
+	case enumor.VpcCloudResType:
+		result, err := svc.client.DataService().Global.Vpc.List(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+		if err != nil {
+			return err
+		}
+		count = result.Count
+	case enumor.SubnetCloudResType:
+		result, err := svc.client.DataService().Global.Subnet.List(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+		if err != nil {
+			return err
+		}
+		count = result.Count
+	case enumor.SecurityGroupCloudResType:
+		result, err := svc.client.DataService().Global.SecurityGroup.ListSecurityGroup(cts.Kit.Ctx,
+			cts.Kit.Header(), &protocloud.SecurityGroupListReq{Filter: expr, Page: core.NewCountPage()})
+		if err != nil {
+			return err
+		}
+		count = result.Count
+	}
+
+	if count != uint64(expected) {
+		logs.Errorf("some %s not found or not authorized, expected: %d, actual: %d, rid: %s", resType, expected,
+			count, cts.Kit.Rid)
+		return errf.Newf(errf.InvalidParameter, "some %s not found or no permission", resType)
+	}
+
+	return nil
+}
+
+// ImportTerraformState map terraform state onto hcm resources in resource view.
+func (svc *terraformSvc) ImportTerraformState(cts *rest.Contexts) (interface{}, error) {
+	return svc.importState(cts, handler.ListResourceAuthRes)
+}
+
+// ImportBizTerraformState map terraform state onto hcm resources in biz view.
+func (svc *terraformSvc) ImportBizTerraformState(cts *rest.Contexts) (interface{}, error) {
+	return svc.importState(cts, handler.ListBizAuthRes)
+}
+
+func (svc *terraformSvc) importState(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (
+	interface{}, error) {
+
+	req := new(csterraform.StateImportReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authFilter := func(resType enumor.CloudResourceType) (*filter.Expression, bool, error) {
+		return authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+			ResType: authResTypes[resType], Action: meta.Find, Filter: tools.AllExpression()})
+	}
+	details, err := logicsterraform.MapState(cts.Kit, svc.client.DataService(), req.State, authFilter)
+	if err != nil {
+		logs.Errorf("map terraform state failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &csterraform.StateImportResult{Details: details}, nil
+}
+
+var authResTypes = map[enumor.CloudResourceType]meta.ResourceType{
+	enumor.VpcCloudResType:           meta.Vpc,
+	enumor.SubnetCloudResType:        meta.Subnet,
+	enumor.SecurityGroupCloudResType: meta.SecurityGroup,
+}
@@ -0,0 +1,104 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package user
+
+import (
+	csuser "hcm/pkg/api/cloud-server/user"
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dsuser "hcm/pkg/api/data-service/user"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// RecordRecentView record the resource viewed by current user.
+func (svc *service) RecordRecentView(cts *rest.Contexts) (interface{}, error) {
+	req := new(csuser.RecordRecentViewReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	recordReq := &dsuser.UserRecentViewRecordReq{
+		ResType: req.ResType,
+		ResID:   req.ResID,
+	}
+	if err := svc.client.DataService().Global.UserRecentView.Record(cts.Kit, recordReq); err != nil {
+		logs.Errorf("record recent view failed, err: %v, req: %+v, rid: %s", err, recordReq, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListRecentView list the recently viewed resources of current user, the latest viewed resource comes first.
+func (svc *service) ListRecentView(cts *rest.Contexts) (interface{}, error) {
+	resType := enumor.UserCollectionResType(cts.PathParameter("res_type").String())
+	if err := resType.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("res_type", resType),
+			tools.RuleEqual("user", cts.Kit.User),
+		),
+		Page: &core.BasePage{
+			Limit: dsuser.MaxRecentViewCount,
+			Sort:  "updated_at",
+			Order: core.Descending,
+		},
+	}
+	result, err := svc.client.DataService().Global.UserRecentView.List(cts.Kit, req)
+	if err != nil {
+		logs.Errorf("list user recent view failed, err: %v, res_type: %s, rid: %s", err, resType, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+// ClearRecentView clear the recently viewed resources of current user.
+func (svc *service) ClearRecentView(cts *rest.Contexts) (interface{}, error) {
+	resType := enumor.UserCollectionResType(cts.PathParameter("res_type").String())
+	if err := resType.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 只能清理自己的访问记录
+	delReq := &dataservice.BatchDeleteReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("res_type", resType),
+			tools.RuleEqual("user", cts.Kit.User),
+		),
+	}
+	if err := svc.client.DataService().Global.UserRecentView.BatchDelete(cts.Kit, delReq); err != nil {
+		logs.Errorf("clear user recent view failed, err: %v, res_type: %s, rid: %s", err, resType, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
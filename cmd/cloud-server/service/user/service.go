@@ -46,6 +46,11 @@ func InitService(c *capability.Capability) {
 	h.Add("DeleteCollection", http.MethodDelete, "/collections/{id}", svc.DeleteCollection)
 	h.Add("ListResourceCollection", http.MethodGet, "/collections/{res_type}/list", svc.ListResourceCollection)
 
+	// 最近访问
+	h.Add("RecordRecentView", http.MethodPost, "/recent_views/record", svc.RecordRecentView)
+	h.Add("ListRecentView", http.MethodGet, "/recent_views/{res_type}/list", svc.ListRecentView)
+	h.Add("ClearRecentView", http.MethodDelete, "/recent_views/{res_type}", svc.ClearRecentView)
+
 	h.Load(c.WebService)
 }
 
@@ -58,7 +58,9 @@ func InitVpcService(c *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("GetVpc", "GET", "/vpcs/{id}", svc.GetVpc)
+	h.Add("GetVpcTopology", "GET", "/vpcs/{id}/topology", svc.GetVpcTopology)
 	h.Add("ListVpc", "POST", "/vpcs/list", svc.ListVpc)
+	h.Add("ExportVpc", "POST", "/vpcs/export", svc.ExportVpc)
 	h.Add("CreateVpc", "POST", "/vpcs/create", svc.CreateVpc)
 	h.Add("UpdateVpc", "PATCH", "/vpcs/{id}", svc.UpdateVpc)
 	h.Add("DeleteVpc", "DELETE", "/vpcs/{id}", svc.DeleteVpc)
@@ -68,7 +70,9 @@ func InitVpcService(c *capability.Capability) {
 
 	// vpc apis in biz
 	h.Add("GetBizVpc", "GET", "/bizs/{bk_biz_id}/vpcs/{id}", svc.GetBizVpc)
+	h.Add("GetBizVpcTopology", "GET", "/bizs/{bk_biz_id}/vpcs/{id}/topology", svc.GetBizVpcTopology)
 	h.Add("ListBizVpc", "POST", "/bizs/{bk_biz_id}/vpcs/list", svc.ListBizVpc)
+	h.Add("ExportBizVpc", "POST", "/bizs/{bk_biz_id}/vpcs/export", svc.ExportBizVpc)
 	h.Add("ListBizVpcExt", "POST", "/bizs/{bk_biz_id}/vendors/{vendor}/vpcs/list", svc.ListBizVpcExt)
 	h.Add("UpdateBizVpc", "PATCH", "/bizs/{bk_biz_id}/vpcs/{id}", svc.UpdateBizVpc)
 	h.Add("DeleteBizVpc", "DELETE", "/bizs/{bk_biz_id}/vpcs/{id}", svc.DeleteBizVpc)
@@ -0,0 +1,262 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package webhook webhook service, manage the webhook subscriptions which push resource change events such as cvm
+// creation and deletion to external systems, and query the delivery logs of the events.
+package webhook
+
+import (
+	"net/http"
+	"time"
+
+	"hcm/cmd/cloud-server/service/capability"
+	cswebhook "hcm/pkg/api/cloud-server/webhook"
+	"hcm/pkg/api/core"
+	datawebhook "hcm/pkg/api/data-service/webhook"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/cryptography"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the webhook service.
+func InitService(c *capability.Capability) {
+	svc := &webhookSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		cipher:     c.Cipher,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateWebhookSubscription", http.MethodPost, "/webhooks/subscriptions/create",
+		svc.CreateWebhookSubscription)
+	h.Add("UpdateWebhookSubscription", http.MethodPatch, "/webhooks/subscriptions/{id}",
+		svc.UpdateWebhookSubscription)
+	h.Add("ListWebhookSubscription", http.MethodPost, "/webhooks/subscriptions/list", svc.ListWebhookSubscription)
+	h.Add("BatchDeleteWebhookSubscription", http.MethodDelete, "/webhooks/subscriptions/batch",
+		svc.BatchDeleteWebhookSubscription)
+
+	h.Add("ListWebhookDelivery", http.MethodPost, "/webhooks/deliveries/list", svc.ListWebhookDelivery)
+	h.Add("RedeliverWebhookDelivery", http.MethodPost, "/webhooks/deliveries/{id}/redeliver",
+		svc.RedeliverWebhookDelivery)
+
+	h.Load(c.WebService)
+}
+
+type webhookSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	cipher     cryptography.Crypto
+}
+
+// CreateWebhookSubscription create webhook subscription, the secret is stored encrypted.
+func (svc *webhookSvc) CreateWebhookSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(cswebhook.SubscriptionCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Create); err != nil {
+		return nil, err
+	}
+
+	createReq := &datawebhook.SubscriptionCreateReq{
+		Name:     req.Name,
+		Url:      req.Url,
+		Secret:   svc.cipher.EncryptToBase64(req.Secret),
+		ResTypes: req.ResTypes,
+		Actions:  req.Actions,
+		BkBizIDs: req.BkBizIDs,
+		Enabled:  req.Enabled,
+		Memo:     req.Memo,
+	}
+	result, err := svc.client.DataService().Global.Webhook.CreateSubscription(cts.Kit, createReq)
+	if err != nil {
+		logs.Errorf("create webhook subscription failed, err: %v, name: %s, rid: %s", err, req.Name, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateWebhookSubscription update webhook subscription.
+func (svc *webhookSvc) UpdateWebhookSubscription(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(cswebhook.SubscriptionUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	updateReq := &datawebhook.SubscriptionUpdateReq{
+		Name:     req.Name,
+		Url:      req.Url,
+		ResTypes: req.ResTypes,
+		Actions:  req.Actions,
+		BkBizIDs: req.BkBizIDs,
+		Enabled:  req.Enabled,
+		Memo:     req.Memo,
+	}
+	if req.Secret != nil {
+		secret := svc.cipher.EncryptToBase64(*req.Secret)
+		updateReq.Secret = &secret
+	}
+
+	if err := svc.client.DataService().Global.Webhook.UpdateSubscription(cts.Kit, id, updateReq); err != nil {
+		logs.Errorf("update webhook subscription failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListWebhookSubscription list webhook subscription, the secret is not returned.
+func (svc *webhookSvc) ListWebhookSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.client.DataService().Global.Webhook.ListSubscription(cts.Kit, req)
+	if err != nil {
+		logs.Errorf("list webhook subscription failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	for i := range result.Details {
+		result.Details[i].Secret = ""
+	}
+
+	return result, nil
+}
+
+// BatchDeleteWebhookSubscription batch delete webhook subscription and its delivery logs.
+func (svc *webhookSvc) BatchDeleteWebhookSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.Webhook.BatchDeleteSubscription(cts.Kit, req); err != nil {
+		logs.Errorf("delete webhook subscription failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListWebhookDelivery list webhook delivery logs.
+func (svc *webhookSvc) ListWebhookDelivery(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.Webhook.ListDelivery(cts.Kit, req)
+}
+
+// RedeliverWebhookDelivery reset the delivery to pending with attempts cleared, so that it is delivered again in
+// the next dispatch round, used to redeliver the failed events after the subscriber is recovered.
+func (svc *webhookSvc) RedeliverWebhookDelivery(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.client.DataService().Global.Webhook.ListDelivery(cts.Kit, &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		logs.Errorf("get webhook delivery failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "webhook delivery: %s not found", id)
+	}
+	delivery := result.Details[0]
+
+	updateReq := &datawebhook.DeliveryUpdateReq{
+		ID:          id,
+		State:       enumor.WebhookDeliveryPending,
+		StatusCode:  delivery.StatusCode,
+		Reason:      delivery.Reason,
+		NextRetryAt: time.Now(),
+	}
+	if err = svc.client.DataService().Global.Webhook.UpdateDelivery(cts.Kit, updateReq); err != nil {
+		logs.Errorf("redeliver webhook delivery failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (svc *webhookSvc) authorize(cts *rest.Contexts, action meta.Action) error {
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Webhook, Action: action}}
+	return svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes)
+}
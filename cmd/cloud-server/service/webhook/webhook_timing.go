@@ -0,0 +1,46 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package webhook
+
+import (
+	"time"
+
+	logicswebhook "hcm/cmd/cloud-server/logics/webhook"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/cryptography"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/scheduler"
+)
+
+// WebhookDeliveryJob 定时拉取资源变更事件并投递到webhook订阅
+func WebhookDeliveryJob(opt cc.Webhook, cliSet *client.ClientSet, cipher cryptography.Crypto) scheduler.Job {
+	logs.Infof("webhook delivery enable, intervalSec: %d, maxAttempts: %d", opt.IntervalSec, opt.MaxAttempts)
+
+	dispatcher := logicswebhook.NewDispatcher(cliSet, cipher, opt)
+	return scheduler.Job{
+		Name:     "webhook_delivery",
+		Interval: time.Duration(opt.IntervalSec) * time.Second,
+		Run: func(kt *kit.Kit) error {
+			return dispatcher.Dispatch(kt)
+		},
+	}
+}
@@ -0,0 +1,410 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package zone
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	cloudproto "hcm/pkg/api/cloud-server/zone"
+	"hcm/pkg/api/core"
+	corezone "hcm/pkg/api/core/cloud/zone"
+	dataproto "hcm/pkg/api/data-service/cloud/zone"
+	hcproto "hcm/pkg/api/hc-service/instance-type"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/hooks/handler"
+	"hcm/pkg/tools/slice"
+)
+
+// GetCapabilityMatrix get the instance families and features supported by each region and zone of the vendor,
+// the matrix is built from the synced zone capabilities, so that the apply page can hide unsupported combinations.
+func (dSvc *ZoneSvc) GetCapabilityMatrix(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(cloudproto.CapabilityMatrixReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	rules := []filter.RuleFactory{tools.RuleEqual("vendor", vendor)}
+	if len(req.Regions) != 0 {
+		rules = append(rules, tools.RuleIn("region", req.Regions))
+	}
+	expr := &filter.Expression{Op: filter.And, Rules: rules}
+
+	regionZones, err := dSvc.listRegionZones(cts.Kit, expr)
+	if err != nil {
+		return nil, err
+	}
+
+	capabilities, err := dSvc.listCapabilities(cts.Kit, expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildCapabilityMatrix(vendor, regionZones, capabilities), nil
+}
+
+// listRegionZones list zones grouped by region.
+func (dSvc *ZoneSvc) listRegionZones(kt *kit.Kit, expr *filter.Expression) (map[string][]string, error) {
+	regionZones := make(map[string][]string)
+	page := core.NewDefaultBasePage()
+	for {
+		result, err := dSvc.client.DataService().Global.Zone.ListZone(kt.Ctx, kt.Header(),
+			&dataproto.ZoneListReq{Filter: expr, Page: page, Field: []string{"region", "name"}})
+		if err != nil {
+			logs.Errorf("list zone failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			regionZones[one.Region] = append(regionZones[one.Region], one.Name)
+		}
+
+		if len(result.Details) < int(page.Limit) {
+			break
+		}
+		page.Start += uint32(page.Limit)
+	}
+
+	return regionZones, nil
+}
+
+// listCapabilities list all zone capabilities matched by the filter.
+func (dSvc *ZoneSvc) listCapabilities(kt *kit.Kit, expr *filter.Expression) ([]corezone.ZoneCapability, error) {
+	capabilities := make([]corezone.ZoneCapability, 0)
+	page := core.NewDefaultBasePage()
+	for {
+		result, err := dSvc.client.DataService().Global.Zone.ListZoneCapability(kt,
+			&core.ListReq{Filter: expr, Page: page})
+		if err != nil {
+			logs.Errorf("list zone capability failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		capabilities = append(capabilities, result.Details...)
+
+		if len(result.Details) < int(page.Limit) {
+			break
+		}
+		page.Start += uint32(page.Limit)
+	}
+
+	return capabilities, nil
+}
+
+// buildCapabilityMatrix merge region level capabilities into zones, and aggregate zone capabilities to region.
+func buildCapabilityMatrix(vendor enumor.Vendor, regionZones map[string][]string,
+	capabilities []corezone.ZoneCapability) *cloudproto.CapabilityMatrixResult {
+
+	regionCaps := make(map[string]corezone.ZoneCapability)
+	zoneCaps := make(map[string]map[string]corezone.ZoneCapability)
+	for _, one := range capabilities {
+		if len(one.Zone) == 0 {
+			regionCaps[one.Region] = one
+			continue
+		}
+
+		if _, exists := zoneCaps[one.Region]; !exists {
+			zoneCaps[one.Region] = make(map[string]corezone.ZoneCapability)
+		}
+		zoneCaps[one.Region][one.Zone] = one
+		// 未同步到本地的可用区也需要展示其能力
+		if !slice.IsItemInSlice(regionZones[one.Region], one.Zone) {
+			regionZones[one.Region] = append(regionZones[one.Region], one.Zone)
+		}
+	}
+	for region := range regionCaps {
+		if _, exists := regionZones[region]; !exists {
+			regionZones[region] = make([]string, 0)
+		}
+	}
+
+	result := &cloudproto.CapabilityMatrixResult{Vendor: vendor, Regions: make([]cloudproto.RegionCapability, 0)}
+	for region, zones := range regionZones {
+		regionCap, regionSynced := regionCaps[region]
+		regionFamilies := newStringSet(regionCap.InstanceFamilies...)
+		regionFeatures := newStringSet(featuresToStrings(regionCap.Features)...)
+
+		regionResult := cloudproto.RegionCapability{Region: region, Zones: make([]cloudproto.ZoneCapability, 0)}
+		sort.Strings(zones)
+		for _, zone := range zones {
+			zoneCap, zoneSynced := zoneCaps[region][zone]
+			families := newStringSet(regionCap.InstanceFamilies...).add(zoneCap.InstanceFamilies...)
+			features := newStringSet(featuresToStrings(regionCap.Features)...).
+				add(featuresToStrings(zoneCap.Features)...)
+
+			regionFamilies.add(zoneCap.InstanceFamilies...)
+			regionFeatures.add(featuresToStrings(zoneCap.Features)...)
+
+			regionResult.Zones = append(regionResult.Zones, cloudproto.ZoneCapability{
+				Zone:             zone,
+				InstanceFamilies: families.sorted(),
+				Features:         stringsToFeatures(features.sorted()),
+				Synced:           regionSynced || zoneSynced,
+			})
+		}
+
+		regionResult.InstanceFamilies = regionFamilies.sorted()
+		regionResult.Features = stringsToFeatures(regionFeatures.sorted())
+		result.Regions = append(result.Regions, regionResult)
+	}
+
+	sort.Slice(result.Regions, func(i, j int) bool { return result.Regions[i].Region < result.Regions[j].Region })
+
+	return result
+}
+
+// RefreshCapability refresh zone capabilities of the given regions from the instance type catalog of the account.
+func (dSvc *ZoneSvc) RefreshCapability(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(cloudproto.CapabilityRefreshReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := handler.ResOperateAuth(cts, &handler.ValidWithAuthOption{Authorizer: dSvc.authorizer,
+		ResType: meta.InstanceType, Action: meta.Find, DisableBizIDEqual: true,
+		BasicInfo: &types.CloudResourceBasicInfo{AccountID: req.AccountID}})
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := dSvc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit, enumor.AccountCloudResType,
+		req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Vendor != vendor {
+		return nil, errf.Newf(errf.InvalidParameter, "account %s is not %s account", req.AccountID, vendor)
+	}
+
+	capabilities := make([]dataproto.ZoneCapabilityCreate, 0)
+	for _, region := range req.Regions {
+		regionCaps, err := dSvc.collectCapabilities(cts.Kit, vendor, req.AccountID, region)
+		if err != nil {
+			logs.Errorf("collect zone capability failed, err: %v, vendor: %s, region: %s, rid: %s", err, vendor,
+				region, cts.Kit.Rid)
+			return nil, err
+		}
+		capabilities = append(capabilities, regionCaps...)
+	}
+
+	replaceReq := &dataproto.ZoneCapabilityReplaceReq{Vendor: vendor, Regions: req.Regions,
+		Capabilities: capabilities}
+	if err = dSvc.client.DataService().Global.Zone.ReplaceZoneCapability(cts.Kit, replaceReq); err != nil {
+		logs.Errorf("replace zone capability failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// instanceTypeInfo is the vendor independent instance type info used to build zone capability.
+type instanceTypeInfo struct {
+	family string
+	gpu    int64
+	fpga   int64
+}
+
+// collectCapabilities collect zone capabilities of region, the instance types of aws and azure can only be queried
+// by region, so region level capability is collected for them.
+func (dSvc *ZoneSvc) collectCapabilities(kt *kit.Kit, vendor enumor.Vendor, accountID, region string) (
+	[]dataproto.ZoneCapabilityCreate, error) {
+
+	switch vendor {
+	case enumor.Aws:
+		list, err := dSvc.client.HCService().Aws.InstanceType.List(kt,
+			&hcproto.AwsInstanceTypeListReq{AccountID: accountID, Region: region})
+		if err != nil {
+			return nil, err
+		}
+		infos := make([]instanceTypeInfo, 0, len(list))
+		for _, one := range list {
+			infos = append(infos, instanceTypeInfo{family: one.InstanceFamily, gpu: one.GPU, fpga: one.FPGA})
+		}
+		return []dataproto.ZoneCapabilityCreate{buildCapability(region, "", infos)}, nil
+
+	case enumor.Azure:
+		list, err := dSvc.client.HCService().Azure.InstanceType.List(kt,
+			&hcproto.AzureInstanceTypeListReq{AccountID: accountID, Region: region})
+		if err != nil {
+			// 未开通计算资源的地域没有可用机型
+			if strings.Contains(err.Error(), "No registered resource provider found for location") {
+				return []dataproto.ZoneCapabilityCreate{buildCapability(region, "", nil)}, nil
+			}
+			return nil, err
+		}
+		infos := make([]instanceTypeInfo, 0, len(list))
+		for _, one := range list {
+			infos = append(infos, instanceTypeInfo{family: one.InstanceFamily, gpu: one.GPU, fpga: one.FPGA})
+		}
+		return []dataproto.ZoneCapabilityCreate{buildCapability(region, "", infos)}, nil
+	}
+
+	regionZones, err := dSvc.listRegionZones(kt, tools.ExpressionAnd(tools.RuleEqual("vendor", vendor),
+		tools.RuleEqual("region", region)))
+	if err != nil {
+		return nil, err
+	}
+
+	capabilities := make([]dataproto.ZoneCapabilityCreate, 0, len(regionZones[region]))
+	for _, zone := range regionZones[region] {
+		infos, err := dSvc.listZoneInstanceTypes(kt, vendor, accountID, region, zone)
+		if err != nil {
+			return nil, err
+		}
+		capabilities = append(capabilities, buildCapability(region, zone, infos))
+	}
+
+	return capabilities, nil
+}
+
+// listZoneInstanceTypes list instance types of zone for vendors which support querying instance types by zone.
+func (dSvc *ZoneSvc) listZoneInstanceTypes(kt *kit.Kit, vendor enumor.Vendor, accountID, region, zone string) (
+	[]instanceTypeInfo, error) {
+
+	infos := make([]instanceTypeInfo, 0)
+	switch vendor {
+	case enumor.TCloud:
+		list, err := dSvc.client.HCService().TCloud.InstanceType.List(kt, &hcproto.TCloudInstanceTypeListReq{
+			AccountID:          accountID,
+			Region:             region,
+			Zone:               zone,
+			InstanceChargeType: string(typecvm.PostpaidByHour),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, one := range list {
+			infos = append(infos, instanceTypeInfo{family: one.InstanceFamily, gpu: one.GPU, fpga: one.FPGA})
+		}
+
+	case enumor.HuaWei:
+		list, err := dSvc.client.HCService().HuaWei.InstanceType.List(kt,
+			&hcproto.HuaWeiInstanceTypeListReq{AccountID: accountID, Region: region, Zone: zone})
+		if err != nil {
+			return nil, err
+		}
+		for _, one := range list {
+			infos = append(infos, instanceTypeInfo{family: one.InstanceFamily, gpu: one.GPU, fpga: one.FPGA})
+		}
+
+	case enumor.Gcp:
+		list, err := dSvc.client.HCService().Gcp.InstanceType.List(kt,
+			&hcproto.GcpInstanceTypeListReq{AccountID: accountID, Zone: zone})
+		if err != nil {
+			return nil, err
+		}
+		for _, one := range list {
+			infos = append(infos, instanceTypeInfo{family: one.InstanceFamily, gpu: one.GPU, fpga: one.FPGA})
+		}
+
+	default:
+		return nil, fmt.Errorf("vendor: %s does not support zone capability", vendor)
+	}
+
+	return infos, nil
+}
+
+// buildCapability build zone capability from instance types.
+func buildCapability(region, zone string, infos []instanceTypeInfo) dataproto.ZoneCapabilityCreate {
+	families := newStringSet()
+	features := newStringSet()
+	for _, one := range infos {
+		if len(one.family) != 0 {
+			families.add(one.family)
+		}
+		if one.gpu > 0 {
+			features.add(string(enumor.ZoneFeatureGpu))
+		}
+		if one.fpga > 0 {
+			features.add(string(enumor.ZoneFeatureFpga))
+		}
+	}
+
+	return dataproto.ZoneCapabilityCreate{
+		Region:           region,
+		Zone:             zone,
+		InstanceFamilies: families.sorted(),
+		Features:         stringsToFeatures(features.sorted()),
+	}
+}
+
+type stringSet map[string]struct{}
+
+func newStringSet(items ...string) stringSet {
+	return make(stringSet).add(items...)
+}
+
+func (s stringSet) add(items ...string) stringSet {
+	for _, item := range items {
+		s[item] = struct{}{}
+	}
+	return s
+}
+
+func (s stringSet) sorted() []string {
+	items := converter.MapKeyToStringSlice(s)
+	sort.Strings(items)
+	return items
+}
+
+func featuresToStrings(features []enumor.ZoneFeature) []string {
+	result := make([]string, 0, len(features))
+	for _, one := range features {
+		result = append(result, string(one))
+	}
+	return result
+}
+
+func stringsToFeatures(items []string) []enumor.ZoneFeature {
+	result := make([]enumor.ZoneFeature, 0, len(items))
+	for _, one := range items {
+		result = append(result, enumor.ZoneFeature(one))
+	}
+	return result
+}
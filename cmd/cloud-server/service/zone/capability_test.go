@@ -0,0 +1,64 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package zone
+
+import (
+	"testing"
+
+	corezone "hcm/pkg/api/core/cloud/zone"
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCapabilityMatrix(t *testing.T) {
+	regionZones := map[string][]string{
+		"ap-guangzhou": {"ap-guangzhou-4", "ap-guangzhou-3"},
+		"ap-shanghai":  {"ap-shanghai-2"},
+	}
+	capabilities := []corezone.ZoneCapability{
+		{Region: "ap-guangzhou", Zone: "ap-guangzhou-3", InstanceFamilies: []string{"S5", "GN7"},
+			Features: []enumor.ZoneFeature{enumor.ZoneFeatureGpu}},
+		{Region: "ap-guangzhou", Zone: "ap-guangzhou-4", InstanceFamilies: []string{"SA2"}},
+		{Region: "us-east-1", InstanceFamilies: []string{"m5"}},
+	}
+
+	result := buildCapabilityMatrix(enumor.TCloud, regionZones, capabilities)
+	assert.Len(t, result.Regions, 3)
+
+	gz := result.Regions[0]
+	assert.Equal(t, "ap-guangzhou", gz.Region)
+	assert.Equal(t, []string{"GN7", "S5", "SA2"}, gz.InstanceFamilies)
+	assert.Equal(t, []enumor.ZoneFeature{enumor.ZoneFeatureGpu}, gz.Features)
+	assert.Equal(t, "ap-guangzhou-3", gz.Zones[0].Zone)
+	assert.True(t, gz.Zones[0].Synced)
+	assert.Equal(t, []string{"SA2"}, gz.Zones[1].InstanceFamilies)
+	assert.Empty(t, gz.Zones[1].Features)
+
+	sh := result.Regions[1]
+	assert.Equal(t, "ap-shanghai", sh.Region)
+	assert.False(t, sh.Zones[0].Synced)
+	assert.Empty(t, sh.InstanceFamilies)
+
+	// 地域级别的能力没有可用区
+	us := result.Regions[2]
+	assert.Equal(t, []string{"m5"}, us.InstanceFamilies)
+	assert.Empty(t, us.Zones)
+}
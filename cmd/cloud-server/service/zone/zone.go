@@ -65,6 +65,10 @@ func InitZoneService(c *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("ListZone", http.MethodPost, "/vendors/{vendor}/regions/{region}/zones/list", svc.ListZone)
+	h.Add("GetCapabilityMatrix", http.MethodPost, "/vendors/{vendor}/zones/capabilities/matrix",
+		svc.GetCapabilityMatrix)
+	h.Add("RefreshCapability", http.MethodPost, "/vendors/{vendor}/zones/capabilities/refresh",
+		svc.RefreshCapability)
 
 	h.Load(c.WebService)
 }
@@ -33,6 +33,7 @@ import (
 	"hcm/pkg/runtime/ctl"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
+	"hcm/pkg/tracing"
 )
 
 // Run start the data service.
@@ -71,10 +72,20 @@ func (ds *dataService) prepare(opt *options.Option) error {
 
 	logs.Infof("load settings from config file success.")
 
+	// watch settings to reload them once the config file or etcd overlay changed.
+	if conf := cc.DataService().ConfigWatch; conf.Enable {
+		if err := cc.WatchSettings(opt.Sys, cc.DataService().Service.Etcd, conf); err != nil {
+			return fmt.Errorf("watch settings failed, err: %v", err)
+		}
+	}
+
 	// init metrics
 	network := cc.DataService().Network
 	metrics.InitMetrics(net.JoinHostPort(network.BindIP, strconv.Itoa(int(network.Port))))
 
+	// init tracing
+	tracing.Init(cc.DataService().Tracing, string(cc.DataServiceName))
+
 	svc, err := service.NewService()
 	if err != nil {
 		return fmt.Errorf("initialize service failed, err: %v", err)
@@ -90,6 +101,9 @@ func (ds *dataService) prepare(opt *options.Option) error {
 
 	ds.sd = sd
 
+	ds.svc.BindService(sd)
+	ds.svc.RunTimingJobs(sd)
+
 	// init hcm control tool
 	if err := ctl.LoadCtl(ctl.WithBasics(sd)...); err != nil {
 		return fmt.Errorf("load control tool failed, err: %v", err)
@@ -0,0 +1,197 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package accountgroup account group service
+package accountgroup
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	coreaccountgroup "hcm/pkg/api/core/account-group"
+	dataaccountgroup "hcm/pkg/api/data-service/account-group"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableaccountgroup "hcm/pkg/dal/table/account-group"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InitService initial the service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateAccountGroup", http.MethodPost, "/account_groups/create", svc.CreateAccountGroup)
+	h.Add("UpdateAccountGroup", http.MethodPatch, "/account_groups/{id}", svc.UpdateAccountGroup)
+	h.Add("ListAccountGroup", http.MethodPost, "/account_groups/list", svc.ListAccountGroup)
+	h.Add("BatchDeleteAccountGroup", http.MethodDelete, "/account_groups/batch", svc.BatchDeleteAccountGroup)
+
+	h.Add("AddAccountGroupMember", http.MethodPost, "/account_groups/members/add", svc.AddAccountGroupMember)
+	h.Add("RemoveAccountGroupMember", http.MethodPost, "/account_groups/members/remove",
+		svc.RemoveAccountGroupMember)
+	h.Add("ListAccountGroupMember", http.MethodPost, "/account_groups/members/list", svc.ListAccountGroupMember)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
+
+// CreateAccountGroup create account group.
+func (svc *service) CreateAccountGroup(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataaccountgroup.AccountGroupCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tableaccountgroup.AccountGroupTable{
+		Name:     req.Name,
+		ParentID: &req.ParentID,
+		Memo:     req.Memo,
+		Creator:  cts.Kit.User,
+		Reviser:  cts.Kit.User,
+	}
+
+	id, err := svc.dao.AccountGroup().Create(cts.Kit, model)
+	if err != nil {
+		logs.Errorf("create account group failed, err: %v, name: %s, rid: %s", err, req.Name, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// UpdateAccountGroup update account group.
+func (svc *service) UpdateAccountGroup(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(dataaccountgroup.AccountGroupUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tableaccountgroup.AccountGroupTable{
+		ParentID: req.ParentID,
+		Memo:     req.Memo,
+		Reviser:  cts.Kit.User,
+	}
+	if req.Name != nil {
+		model.Name = *req.Name
+	}
+
+	if err := svc.dao.AccountGroup().UpdateByID(cts.Kit, id, model); err != nil {
+		logs.Errorf("update account group failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListAccountGroup list account group.
+func (svc *service) ListAccountGroup(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.AccountGroup().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list account group failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]coreaccountgroup.AccountGroup, 0, len(result.Details))
+	for _, one := range result.Details {
+		group := coreaccountgroup.AccountGroup{
+			ID:   one.ID,
+			Name: one.Name,
+			Memo: one.Memo,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		}
+		if one.ParentID != nil {
+			group.ParentID = *one.ParentID
+		}
+		details = append(details, group)
+	}
+
+	return &core.ListResultT[coreaccountgroup.AccountGroup]{Count: result.Count, Details: details}, nil
+}
+
+// BatchDeleteAccountGroup batch delete account group and its members.
+func (svc *service) BatchDeleteAccountGroup(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		relExpr := tools.ContainersExpression("group_id", req.IDs)
+		if err := svc.dao.AccountGroupRel().DeleteWithTx(cts.Kit, txn, relExpr); err != nil {
+			return nil, err
+		}
+
+		return nil, svc.dao.AccountGroup().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", req.IDs))
+	})
+	if err != nil {
+		logs.Errorf("delete account group failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,191 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package accountgroup
+
+import (
+	"hcm/pkg/api/core"
+	coreaccountgroup "hcm/pkg/api/core/account-group"
+	dataaccountgroup "hcm/pkg/api/data-service/account-group"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableaccountgroup "hcm/pkg/dal/table/account-group"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// AddAccountGroupMember add account group members, members already in the group are ignored.
+func (svc *service) AddAccountGroupMember(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataaccountgroup.AccountGroupMemberReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	existMembers, err := svc.listGroupMember(cts.Kit, req.GroupID, groupMemberIDs(req.Members))
+	if err != nil {
+		return nil, err
+	}
+
+	rels := make([]tableaccountgroup.AccountGroupRelTable, 0, len(req.Members))
+	for _, one := range req.Members {
+		if _, exists := existMembers[one]; exists {
+			continue
+		}
+		existMembers[one] = struct{}{}
+
+		rels = append(rels, tableaccountgroup.AccountGroupRelTable{
+			GroupID:     req.GroupID,
+			AccountType: one.AccountType,
+			AccountID:   one.AccountID,
+			Creator:     cts.Kit.User,
+		})
+	}
+
+	if len(rels) == 0 {
+		return nil, nil
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.AccountGroupRel().BatchCreateWithTx(cts.Kit, txn, rels)
+	})
+	if err != nil {
+		logs.Errorf("add account group member failed, err: %v, group: %s, rid: %s", err, req.GroupID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// RemoveAccountGroupMember remove account group members.
+func (svc *service) RemoveAccountGroupMember(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataaccountgroup.AccountGroupMemberReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	typeAccountIDs := make(map[enumor.AccountGroupMemberType][]string)
+	for _, one := range req.Members {
+		typeAccountIDs[one.AccountType] = append(typeAccountIDs[one.AccountType], one.AccountID)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for accountType, accountIDs := range typeAccountIDs {
+			expr := tools.ExpressionAnd(
+				tools.RuleEqual("group_id", req.GroupID),
+				tools.RuleEqual("account_type", accountType),
+				tools.RuleIn("account_id", accountIDs),
+			)
+			if err := svc.dao.AccountGroupRel().DeleteWithTx(cts.Kit, txn, expr); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("remove account group member failed, err: %v, group: %s, rid: %s", err, req.GroupID,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListAccountGroupMember list account group member.
+func (svc *service) ListAccountGroupMember(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.AccountGroupRel().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list account group member failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]coreaccountgroup.AccountGroupMember, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, coreaccountgroup.AccountGroupMember{
+			GroupID:     one.GroupID,
+			AccountType: one.AccountType,
+			AccountID:   one.AccountID,
+			Creator:     one.Creator,
+			CreatedAt:   one.CreatedAt.String(),
+		})
+	}
+
+	return &core.ListResultT[coreaccountgroup.AccountGroupMember]{Count: result.Count, Details: details}, nil
+}
+
+func groupMemberIDs(members []dataaccountgroup.AccountGroupMember) []string {
+	ids := make([]string, 0, len(members))
+	for _, one := range members {
+		ids = append(ids, one.AccountID)
+	}
+	return ids
+}
+
+// listGroupMember list members of the group whose account id is in the given ids.
+func (svc *service) listGroupMember(kt *kit.Kit, groupID string, accountIDs []string) (
+	map[dataaccountgroup.AccountGroupMember]struct{}, error) {
+
+	opt := &types.ListOption{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("group_id", groupID),
+			tools.RuleIn("account_id", accountIDs),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.dao.AccountGroupRel().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list account group member failed, err: %v, group: %s, rid: %s", err, groupID, kt.Rid)
+		return nil, err
+	}
+
+	members := make(map[dataaccountgroup.AccountGroupMember]struct{}, len(result.Details))
+	for _, one := range result.Details {
+		members[dataaccountgroup.AccountGroupMember{AccountType: one.AccountType, AccountID: one.AccountID}] =
+			struct{}{}
+	}
+
+	return members, nil
+}
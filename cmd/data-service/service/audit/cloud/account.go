@@ -20,9 +20,12 @@
 package cloud
 
 import (
+	"fmt"
+
 	"hcm/pkg/api/core"
 	protoaudit "hcm/pkg/api/data-service/audit"
 	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	tableaudit "hcm/pkg/dal/table/audit"
@@ -107,3 +110,54 @@ func (ad Audit) listAccount(kt *kit.Kit, ids []string) (map[string]tablecloud.Ac
 
 	return result, nil
 }
+
+func (ad Audit) accountOperationAuditBuild(kt *kit.Kit, ops []protoaudit.CloudResourceOperationInfo) (
+	[]*tableaudit.AuditTable, error) {
+
+	ids := make([]string, 0, len(ops))
+	for _, one := range ops {
+		if one.Action != protoaudit.AccessSecret {
+			return nil, fmt.Errorf("audit action: %s not support", one.Action)
+		}
+		ids = append(ids, one.ResID)
+	}
+	idAccountMap, err := ad.listAccount(kt, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	audits := make([]*tableaudit.AuditTable, 0, len(ops))
+	for _, one := range ops {
+		account, exist := idAccountMap[one.ResID]
+		if !exist {
+			return nil, errf.Newf(errf.RecordNotFound, "account: %s not found", one.ResID)
+		}
+
+		action, err := one.Action.ConvAuditAction()
+		if err != nil {
+			return nil, err
+		}
+
+		// remove secret key from account info
+		extension := tools.AccountExtensionRemoveSecretKey(string(account.Extension))
+		account.Extension = tabletype.JsonField(extension)
+
+		audits = append(audits, &tableaudit.AuditTable{
+			ResID:     one.ResID,
+			ResName:   account.Name,
+			ResType:   enumor.AccountAuditResType,
+			Action:    action,
+			Vendor:    enumor.Vendor(account.Vendor),
+			AccountID: account.ID,
+			Operator:  kt.User,
+			Source:    kt.GetRequestSource(),
+			Rid:       kt.Rid,
+			AppCode:   kt.AppCode,
+			Detail: &tableaudit.BasicDetail{
+				Data: account,
+			},
+		})
+	}
+
+	return audits, nil
+}
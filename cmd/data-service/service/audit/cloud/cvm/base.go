@@ -175,6 +175,8 @@ func (c *Cvm) CvmAssignAuditBuild(kt *kit.Kit, assigns []protoaudit.CloudResourc
 			audit.Action = enumor.Assign
 		case enumor.DeliverAssignedResType:
 			audit.Action = enumor.Deliver
+		case enumor.TransferAssignedResType:
+			audit.Action = enumor.Transfer
 		default:
 			return nil, errf.New(errf.InvalidParameter, "assigned resource type is invalid")
 		}
@@ -61,6 +61,8 @@ func (ad Audit) diskAssignAuditBuild(kt *kit.Kit, assigns []protoaudit.CloudReso
 			action = enumor.Assign
 		case enumor.DeliverAssignedResType:
 			action = enumor.Deliver
+		case enumor.TransferAssignedResType:
+			action = enumor.Transfer
 		default:
 			return nil, errf.New(errf.InvalidParameter, "assigned resource type is invalid")
 		}
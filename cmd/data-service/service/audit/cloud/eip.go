@@ -57,18 +57,24 @@ func (ad Audit) eipAssignAuditBuild(kt *kit.Kit, assigns []protoaudit.CloudResou
 			continue
 		}
 
-		changed := make(map[string]interface{})
-		if one.AssignedResType != enumor.BizAuditAssignedResType {
+		var action enumor.AuditAction
+		switch one.AssignedResType {
+		case enumor.BizAuditAssignedResType:
+			action = enumor.Assign
+		case enumor.TransferAssignedResType:
+			action = enumor.Transfer
+		default:
 			return nil, errf.New(errf.InvalidParameter, "assigned resource type is invalid")
 		}
-		changed["bk_biz_id"] = one.AssignedResID
+
+		changed := map[string]interface{}{"bk_biz_id": one.AssignedResID}
 
 		audits = append(audits, &tableaudit.AuditTable{
 			ResID:      one.ResID,
 			CloudResID: eipData.CloudID,
 			ResName:    converter.PtrToVal(eipData.Name),
 			ResType:    enumor.EipAuditResType,
-			Action:     enumor.Assign,
+			Action:     action,
 			BkBizID:    eipData.BkBizID,
 			Vendor:     enumor.Vendor(eipData.Vendor),
 			AccountID:  eipData.AccountID,
@@ -156,6 +156,8 @@ func (n *NetworkInterface) NetworkInterfaceAssignAuditBuild(kt *kit.Kit, assigns
 			action = enumor.Assign
 		case enumor.DeliverAssignedResType:
 			action = enumor.Deliver
+		case enumor.TransferAssignedResType:
+			action = enumor.Transfer
 		default:
 			return nil, errf.New(errf.InvalidParameter, "assigned resource type is invalid")
 		}
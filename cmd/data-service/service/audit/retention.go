@@ -0,0 +1,68 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/cc"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/serviced"
+)
+
+// RetentionTiming 定时清理超过保留天数的审计记录，只在主节点执行
+func RetentionTiming(dao dao.Set, sd serviced.State, conf cc.AuditRetention) {
+	for {
+		time.Sleep(time.Duration(conf.IntervalMin) * time.Minute)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+		before := time.Now().AddDate(0, 0, -int(conf.Days))
+		deleted, err := DeleteExpired(kt, dao, before, conf.BatchSize)
+		if err != nil {
+			logs.Errorf("clean expired audit failed, deleted: %d, err: %v, rid: %s", deleted, err, kt.Rid)
+			continue
+		}
+
+		logs.Infof("clean expired audit success, before: %v, deleted: %d, rid: %s", before, deleted, kt.Rid)
+	}
+}
+
+// DeleteExpired 分批删除创建时间早于 before 的审计记录，返回删除的总数
+func DeleteExpired(kt *kit.Kit, dao dao.Set, before time.Time, batchSize uint) (int64, error) {
+	var total int64
+	for {
+		deleted, err := dao.Audit().DeleteBefore(kt, before, batchSize)
+		if err != nil {
+			return total, err
+		}
+
+		total += deleted
+		if deleted < int64(batchSize) {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,87 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"hcm/pkg/dal/dao"
+	daoaudit "hcm/pkg/dal/dao/audit"
+	"hcm/pkg/kit"
+)
+
+type fakeSet struct {
+	dao.Set
+	audit *fakeAudit
+}
+
+// Audit ...
+func (f fakeSet) Audit() daoaudit.Interface {
+	return f.audit
+}
+
+// fakeAudit deletes the remaining audits batch by batch.
+type fakeAudit struct {
+	daoaudit.Interface
+	remaining int64
+	calls     int
+	err       error
+}
+
+// DeleteBefore ...
+func (f *fakeAudit) DeleteBefore(_ *kit.Kit, _ time.Time, limit uint) (int64, error) {
+	f.calls++
+	if f.err != nil {
+		return 0, f.err
+	}
+
+	deleted := f.remaining
+	if deleted > int64(limit) {
+		deleted = int64(limit)
+	}
+	f.remaining -= deleted
+	return deleted, nil
+}
+
+func TestDeleteExpired(t *testing.T) {
+	audit := &fakeAudit{remaining: 2500}
+	total, err := DeleteExpired(kit.New(), fakeSet{audit: audit}, time.Now(), 1000)
+	if err != nil {
+		t.Fatalf("delete expired audit failed, err: %v", err)
+	}
+
+	// 最后一批不足批次大小时结束
+	if total != 2500 || audit.calls != 3 {
+		t.Errorf("expect 2500 audits deleted in 3 batches, but got %d in %d", total, audit.calls)
+	}
+
+	audit = &fakeAudit{remaining: 2000}
+	if total, _ = DeleteExpired(kit.New(), fakeSet{audit: audit}, time.Now(), 1000); total != 2000 ||
+		audit.calls != 3 {
+		t.Errorf("expect 2000 audits deleted in 3 batches, but got %d in %d", total, audit.calls)
+	}
+
+	audit = &fakeAudit{err: errors.New("db error")}
+	if _, err = DeleteExpired(kit.New(), fakeSet{audit: audit}, time.Now(), 1000); err == nil {
+		t.Errorf("delete error should be returned")
+	}
+}
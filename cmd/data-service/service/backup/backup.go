@@ -0,0 +1,120 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package backup inventory data logical backup and restore service
+package backup
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	databackup "hcm/pkg/api/data-service/backup"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	daobackup "hcm/pkg/dal/dao/backup"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ExportBackup", http.MethodPost, "/backups/export", svc.ExportBackup)
+	h.Add("ImportBackup", http.MethodPost, "/backups/import", svc.ImportBackup)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
+
+// ExportBackup export a consistent snapshot of the tables in newline-delimited json stream, each line is a
+// record with table name and row, which can be imported into another environment by ImportBackup. the last
+// line is the manifest of the exported data.
+func (svc *service) ExportBackup(cts *rest.Contexts) (interface{}, error) {
+	req := new(databackup.ExportReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &daobackup.ExportOption{
+		Tables:     req.Tables,
+		AccountIDs: req.AccountIDs,
+		BkBizIDs:   req.BkBizIDs,
+	}
+	if len(opt.Tables) == 0 {
+		opt.Tables = daobackup.BackupTables()
+	}
+
+	return rest.StreamFunc(func(w *rest.StreamWriter) error {
+		manifest, err := svc.dao.Backup().Export(cts.Kit, opt, func(record *daobackup.Record) error {
+			return w.Write(record)
+		})
+		if err != nil {
+			return err
+		}
+
+		// 清单作为最后一行记录，恢复前用于确认目标环境持有账号密钥的主密钥
+		if err = w.Write(&daobackup.Record{Manifest: manifest}); err != nil {
+			return err
+		}
+
+		logs.Infof("export backup success, tenant: %s, tables: %v, key ids: %v, count: %d, operator: %s, rid: %s",
+			manifest.TenantID, opt.Tables, manifest.KeyIDs, w.Count(), cts.Kit.User, cts.Kit.Rid)
+		return nil
+	}), nil
+}
+
+// ImportBackup import the records exported by ExportBackup.
+func (svc *service) ImportBackup(cts *rest.Contexts) (interface{}, error) {
+	req := new(databackup.ImportReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	mode := req.Mode
+	if len(mode) == 0 {
+		mode = daobackup.SkipExisting
+	}
+
+	count, err := svc.dao.Backup().Import(cts.Kit, req.Records, mode)
+	if err != nil {
+		logs.Errorf("import backup failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	logs.Infof("import backup success, mode: %s, count: %d, operator: %s, rid: %s", mode, count, cts.Kit.User,
+		cts.Kit.Rid)
+
+	return &databackup.ImportResult{Count: count}, nil
+}
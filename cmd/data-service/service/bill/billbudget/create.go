@@ -0,0 +1,77 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package billbudget
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	tablebill "hcm/pkg/dal/table/bill"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CreateBillBudget create bill budget
+func (svc *service) CreateBillBudget(cts *rest.Contexts) (any, error) {
+	req := new(dsbill.BudgetCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	budget := tablebill.AccountBillBudget{
+		Name:          req.Name,
+		ScopeType:     req.ScopeType,
+		BkBizID:       req.BkBizID,
+		MainAccountID: req.MainAccountID,
+		Currency:      req.Currency,
+		Amount:        &types.Decimal{Decimal: req.Amount},
+		Thresholds:    req.Thresholds,
+		NoticeUsers:   req.NoticeUsers,
+		Memo:          req.Memo,
+		Creator:       cts.Kit.User,
+		Reviser:       cts.Kit.User,
+	}
+	ids, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := svc.dao.AccountBillBudget().CreateWithTx(cts.Kit, txn, []tablebill.AccountBillBudget{budget})
+		if err != nil {
+			logs.Errorf("create bill budget failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, fmt.Errorf("create bill budget failed, err: %v", err)
+		}
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	idList, ok := ids.([]string)
+	if !ok || len(idList) != 1 {
+		return nil, fmt.Errorf("create bill budget but return ids invalid, ids: %v", ids)
+	}
+	return &core.CreateResult{ID: idList[0]}, nil
+}
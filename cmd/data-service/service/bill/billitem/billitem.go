@@ -38,6 +38,7 @@ func InitService(cap *capability.Capability) {
 	h.Add("ListBillItemExt", http.MethodPost, "/vendors/{vendor}/bills/items/list", svc.ListBillItemExt)
 	h.Add("ListBillItem", http.MethodPost, "/bills/items/list", svc.ListBillItem)
 	h.Add("ListBillItemRaw", http.MethodPost, "/bills/items/list_with_extension", svc.ListBillItemRaw)
+	h.Add("SumBillItemCost", http.MethodPost, "/bills/items/cost/sum", svc.SumBillItemCost)
 
 	h.Add("CreateBillItem", http.MethodPost, "/vendors/{vendor}/bills/items/create", svc.CreateBillItem)
 	h.Add("CreateBillItemRaw", http.MethodPost, "/vendors/{vendor}/bills/rawitems/create", svc.CreateBillItemRaw)
@@ -29,6 +29,7 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/types"
+	typesbill "hcm/pkg/dal/dao/types/bill"
 	tablebill "hcm/pkg/dal/table/bill"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
@@ -193,3 +194,27 @@ func convBillItem(m *tablebill.AccountBillItem) *bill.BaseBillItem {
 		},
 	}
 }
+
+// SumBillItemCost sum bill item cost group by dimensions
+func (svc *service) SumBillItemCost(cts *rest.Contexts) (any, error) {
+	req := new(dataproto.BillItemCostSumReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &typesbill.ItemCostSumOption{
+		Filter:  req.Filter,
+		GroupBy: req.GroupBy,
+	}
+	details, err := svc.dao.AccountBillItem().SumCostGroupBy(cts.Kit, req.ItemCommonOpt, opt)
+	if err != nil {
+		logs.Errorf("sum bill item cost failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &dataproto.BillItemCostSumResult{Details: details}, nil
+}
@@ -0,0 +1,172 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package billreconciliation ...
+package billreconciliation
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/bill"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablebill "hcm/pkg/dal/table/bill"
+	tabletypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InitService initialize the bill reconciliation service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+	h := rest.NewHandler()
+	h.Add("ReplaceBillReconciliation", http.MethodPost, "/bills/reconciliations/replace",
+		svc.ReplaceBillReconciliation)
+	h.Add("ListBillReconciliation", http.MethodPost, "/bills/reconciliations/list", svc.ListBillReconciliation)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
+
+// ReplaceBillReconciliation replace the reconciliation results of main account in the month, so that the results
+// of the latest reconciliation are kept only.
+func (svc *service) ReplaceBillReconciliation(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbill.ReconciliationReplaceReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	models := make([]tablebill.AccountBillReconciliation, 0, len(req.Items))
+	for _, one := range req.Items {
+		models = append(models, tablebill.AccountBillReconciliation{
+			Vendor:        req.Vendor,
+			RootAccountID: req.RootAccountID,
+			MainAccountID: req.MainAccountID,
+			BillYear:      req.BillYear,
+			BillMonth:     req.BillMonth,
+			ResType:       one.ResType,
+			ResCloudID:    one.ResCloudID,
+			HcProductCode: one.HcProductCode,
+			Currency:      one.Currency,
+			Cost:          &tabletypes.Decimal{Decimal: one.Cost},
+			ResAmount:     &tabletypes.Decimal{Decimal: one.ResAmount},
+			ResAmountUnit: one.ResAmountUnit,
+			Reason:        one.Reason,
+			Creator:       cts.Kit.User,
+			Reviser:       cts.Kit.User,
+		})
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		delExpr := tools.ExpressionAnd(
+			tools.RuleEqual("vendor", req.Vendor),
+			tools.RuleEqual("main_account_id", req.MainAccountID),
+			tools.RuleEqual("bill_year", req.BillYear),
+			tools.RuleEqual("bill_month", req.BillMonth),
+		)
+		if err := svc.dao.AccountBillReconciliation().DeleteWithTx(cts.Kit, txn, delExpr); err != nil {
+			return nil, err
+		}
+
+		for _, batch := range slice.Split(models, int(core.DefaultMaxPageLimit)) {
+			if _, err := svc.dao.AccountBillReconciliation().CreateWithTx(cts.Kit, txn, batch); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("replace bill reconciliation failed, err: %v, main account: %s, period: %d-%02d, rid: %s",
+			err, req.MainAccountID, req.BillYear, req.BillMonth, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListBillReconciliation list bill reconciliation
+func (svc *service) ListBillReconciliation(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	data, err := svc.dao.AccountBillReconciliation().List(cts.Kit, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dsbill.ReconciliationListResult{Details: slice.Map(data.Details, convReconciliation),
+		Count: data.Count}, nil
+}
+
+func convReconciliation(r tablebill.AccountBillReconciliation) bill.Reconciliation {
+	result := bill.Reconciliation{
+		ID:            r.ID,
+		Vendor:        r.Vendor,
+		RootAccountID: r.RootAccountID,
+		MainAccountID: r.MainAccountID,
+		BillYear:      r.BillYear,
+		BillMonth:     r.BillMonth,
+		ResType:       r.ResType,
+		ResCloudID:    r.ResCloudID,
+		HcProductCode: r.HcProductCode,
+		Currency:      r.Currency,
+		ResAmountUnit: r.ResAmountUnit,
+		Reason:        r.Reason,
+		Revision: &core.Revision{
+			Creator:   r.Creator,
+			Reviser:   r.Reviser,
+			CreatedAt: r.CreatedAt.String(),
+			UpdatedAt: r.UpdatedAt.String(),
+		},
+	}
+	if r.Cost != nil {
+		result.Cost = r.Cost.Decimal
+	}
+	if r.ResAmount != nil {
+		result.ResAmount = r.ResAmount.Decimal
+	}
+	return result
+}
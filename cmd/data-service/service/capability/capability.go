@@ -27,6 +27,7 @@ import (
 	"hcm/pkg/thirdparty/esb"
 
 	"github.com/emicklei/go-restful/v3"
+	"google.golang.org/grpc"
 )
 
 // Capability defines the service's capability
@@ -36,4 +37,6 @@ type Capability struct {
 	Cipher      cryptography.Crypto
 	EsbClient   esb.Client
 	ObjectStore objectstore.Storage
+	// GrpcServer is the grpc server to register grpc api, it is nil if grpc is not enabled.
+	GrpcServer *grpc.Server
 }
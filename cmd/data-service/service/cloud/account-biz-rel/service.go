@@ -21,6 +21,8 @@ package accountbizrel
 
 import (
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/cache"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -33,7 +35,8 @@ func InitService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("UpdateAccountBizRel", "PUT", "/account_biz_rels/accounts/{account_id}", svc.UpdateAccountBizRel)
+	h.Add("UpdateAccountBizRel", "PUT", "/account_biz_rels/accounts/{account_id}",
+		cache.Invalidating(enumor.AccountCloudResType, svc.UpdateAccountBizRel))
 	h.Add("ListAccountBizRel", "POST", "/account_biz_rels/list", svc.ListAccountBizRel)
 	h.Add("ListWithAccount", "POST", "/account_biz_rels/with/accounts/list", svc.ListWithAccount)
 
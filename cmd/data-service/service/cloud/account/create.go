@@ -59,8 +59,8 @@ func (svc *service) CreateAccount(cts *rest.Contexts) (interface{}, error) {
 	}
 }
 
-func createAccount[T protocloud.AccountExtensionCreateReq, PT protocloud.SecretEncryptor[T]](vendor enumor.Vendor,
-	svc *service, cts *rest.Contexts) (interface{}, error) {
+func createAccount[T protocloud.AccountExtensionCreateReq](vendor enumor.Vendor, svc *service,
+	cts *rest.Contexts) (interface{}, error) {
 
 	req := new(protocloud.AccountCreateReq[T])
 	if err := cts.DecodeInto(req); err != nil {
@@ -71,13 +71,6 @@ func createAccount[T protocloud.AccountExtensionCreateReq, PT protocloud.SecretE
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	// 将参数里的SecretKey加密
-	if req.Extension != nil {
-		p := PT(req.Extension)
-		// 加密密钥
-		p.EncryptSecretKey(svc.cipher)
-	}
-
 	accountID, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
 		extensionJson, err := json.MarshalToString(req.Extension)
 		if err != nil {
@@ -39,22 +39,20 @@ import (
 	"hcm/pkg/tools/json"
 )
 
-func convertToAccountResult[T protocloud.AccountExtensionGetResp, PT protocloud.SecretDecryptor[T]](
+func convertToAccountResult[T protocloud.AccountExtensionGetResp](
 	baseAccount *protocore.BaseAccount, dbExtension tabletype.JsonField, svc *service,
 ) (*protocloud.AccountGetResult[T], error) {
-	extension := new(T)
-	err := json.UnmarshalFromString(string(dbExtension), extension)
-	if err != nil {
-		return nil, fmt.Errorf("UnmarshalFromString db extension failed, err: %v", err)
-	}
-
 	// 解密密钥
-	p := PT(extension)
-	err = p.DecryptSecretKey(svc.cipher)
+	dbExtension, err := svc.dao.Account().DecryptExtension(dbExtension)
 	if err != nil {
 		return nil, fmt.Errorf("decrypt secret key of extension failed, err: %v", err)
 	}
 
+	extension := new(T)
+	if err = json.UnmarshalFromString(string(dbExtension), extension); err != nil {
+		return nil, fmt.Errorf("UnmarshalFromString db extension failed, err: %v", err)
+	}
+
 	return &protocloud.AccountGetResult[T]{
 		BaseAccount: *baseAccount,
 		Extension:   extension,
@@ -194,7 +192,7 @@ func (svc *service) ListAccount(cts *rest.Contexts) (interface{}, error) {
 		one.BkBizIDs = accountBizMap[one.ID]
 	}
 
-	return &protocloud.AccountListResult{Details: details}, nil
+	return &protocloud.AccountListResult{Count: daoAccountResp.Count, Details: details}, nil
 }
 
 // getAccountBizMap 获取账号和业务的映射关系
@@ -300,22 +298,20 @@ func (svc *service) ListAccountWithBiz(kt *kit.Kit, ids []string) ([]types.Accou
 	return accounts, nil
 }
 
-func convertToAccountExtension[T protocloud.AccountExtensionGetResp, PT protocloud.SecretDecryptor[T]](
+func convertToAccountExtension[T protocloud.AccountExtensionGetResp](
 	dbExtension tabletype.JsonField, svc *service) (map[string]interface{}, error) {
 
-	extension := new(T)
-	err := json.UnmarshalFromString(string(dbExtension), extension)
-	if err != nil {
-		return nil, fmt.Errorf("UnmarshalFromString db extension failed, err: %v", err)
-	}
-
 	// 解密密钥
-	p := PT(extension)
-	err = p.DecryptSecretKey(svc.cipher)
+	dbExtension, err := svc.dao.Account().DecryptExtension(dbExtension)
 	if err != nil {
 		return nil, fmt.Errorf("decrypt secret key of extension failed, err: %v", err)
 	}
 
+	extension := new(T)
+	if err = json.UnmarshalFromString(string(dbExtension), extension); err != nil {
+		return nil, fmt.Errorf("UnmarshalFromString db extension failed, err: %v", err)
+	}
+
 	return converter.StructToMap(extension)
 }
 
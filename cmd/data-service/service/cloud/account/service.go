@@ -21,7 +21,8 @@ package account
 
 import (
 	"hcm/cmd/data-service/service/capability"
-	"hcm/pkg/cryptography"
+	"hcm/pkg/cache"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -29,24 +30,25 @@ import (
 // InitService initial the account service
 func InitService(cap *capability.Capability) {
 	svc := &service{
-		dao:    cap.Dao,
-		cipher: cap.Cipher,
+		dao: cap.Dao,
 	}
 
 	h := rest.NewHandler()
 
-	h.Add("CreateAccount", "POST", "/vendors/{vendor}/accounts/create", svc.CreateAccount)
-	h.Add("UpdateAccount", "PATCH", "/vendors/{vendor}/accounts/{account_id}", svc.UpdateAccount)
+	h.Add("CreateAccount", "POST", "/vendors/{vendor}/accounts/create",
+		cache.Invalidating(enumor.AccountCloudResType, svc.CreateAccount))
+	h.Add("UpdateAccount", "PATCH", "/vendors/{vendor}/accounts/{account_id}",
+		cache.Invalidating(enumor.AccountCloudResType, svc.UpdateAccount))
 	h.Add("GetAccount", "GET", "/vendors/{vendor}/accounts/{account_id}", svc.GetAccount)
-	h.Add("ListAccount", "POST", "/accounts/list", svc.ListAccount)
+	h.Add("ListAccount", "POST", "/accounts/list", cache.Cached(enumor.AccountCloudResType, svc.ListAccount))
 	h.Add("ListAccountWithExtension", "POST", "/accounts/extensions/list", svc.ListAccountWithExtension)
-	h.Add("DeleteAccount", "DELETE", "/accounts", svc.DeleteAccount)
+	h.Add("DeleteAccount", "DELETE", "/accounts", cache.Invalidating(enumor.AccountCloudResType, svc.DeleteAccount))
 	h.Add("DeleteValidate", "POST", "/accounts/{account_id}/delete/validate", svc.DeleteValidate)
+	h.Add("RotateAccountSecret", "POST", "/accounts/secrets/rotate", svc.RotateAccountSecret)
 
 	h.Load(cap.WebService)
 }
 
 type service struct {
-	dao    dao.Set
-	cipher cryptography.Crypto
+	dao dao.Set
 }
@@ -29,10 +29,8 @@ import (
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	tablecloud "hcm/pkg/dal/table/cloud"
-	tabletype "hcm/pkg/dal/table/types"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
-	"hcm/pkg/tools/json"
 )
 
 // UpdateAccount account with filter.
@@ -79,8 +77,8 @@ func getAccountFromTable(accountID string, svc *service, cts *rest.Contexts) (*t
 	return details[0], nil
 }
 
-func updateAccount[T protocloud.AccountExtensionUpdateReq, PT protocloud.SecretEncryptor[T]](accountID string,
-	svc *service, cts *rest.Contexts) (interface{}, error) {
+func updateAccount[T protocloud.AccountExtensionUpdateReq](accountID string, svc *service,
+	cts *rest.Contexts) (interface{}, error) {
 
 	req := new(protocloud.AccountUpdateReq[T])
 
@@ -104,23 +102,14 @@ func updateAccount[T protocloud.AccountExtensionUpdateReq, PT protocloud.SecretE
 
 	// 只有提供了Extension才进行更新
 	if req.Extension != nil {
-		// 将参数里的SecretKey加密
-		p := PT(req.Extension)
-		p.EncryptSecretKey(svc.cipher)
-
-		// 查询账号
-		dbAccount, err := getAccountFromTable(accountID, svc, cts)
+		// 加密参数里的SecretKey后合并覆盖dbExtension
+		updatedExtension, err := svc.dao.Account().MergeExtension(cts.Kit, accountID, req.Extension)
 		if err != nil {
+			logs.Errorf("merge account extension failed, err: %v, id: %s, rid: %s", err, accountID, cts.Kit.Rid)
 			return nil, err
 		}
 
-		// 合并覆盖dbExtension
-		updatedExtension, err := json.UpdateMerge(req.Extension, string(dbAccount.Extension))
-		if err != nil {
-			return nil, fmt.Errorf("json UpdateMerge extension failed, err: %v", err)
-		}
-
-		account.Extension = tabletype.JsonField(updatedExtension)
+		account.Extension = updatedExtension
 	}
 
 	err := svc.dao.Account().Update(cts.Kit, tools.EqualExpression("id", accountID), account)
@@ -131,3 +120,16 @@ func updateAccount[T protocloud.AccountExtensionUpdateReq, PT protocloud.SecretE
 
 	return nil, nil
 }
+
+// RotateAccountSecret 使用当前主密钥重新加密账号密钥，用于主密钥轮换及历史AesGcm密文迁移到信封加密
+func (svc *service) RotateAccountSecret(cts *rest.Contexts) (interface{}, error) {
+	count, err := svc.dao.Account().RotateSecret(cts.Kit)
+	if err != nil {
+		logs.Errorf("rotate account secret failed, err: %v, rotated: %d, rid: %s", err, count, cts.Kit.Rid)
+		return nil, err
+	}
+
+	logs.Infof("rotate account secret success, count: %d, operator: %s, rid: %s", count, cts.Kit.User, cts.Kit.Rid)
+
+	return &protocloud.AccountSecretRotateResult{Count: count}, nil
+}
@@ -0,0 +1,339 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"fmt"
+
+	"hcm/cmd/data-service/service/cloud/cvm"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/data-service/audit"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablecloud "hcm/pkg/dal/table/cloud"
+	tablecvm "hcm/pkg/dal/table/cloud/cvm"
+	tabledisk "hcm/pkg/dal/table/cloud/disk"
+	tableeip "hcm/pkg/dal/table/cloud/eip"
+	tableni "hcm/pkg/dal/table/cloud/network-interface"
+	routetable "hcm/pkg/dal/table/cloud/route-table"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// batchAssignResColumnTypes 支持批量分配的资源类型及其可用于过滤的字段
+var batchAssignResColumnTypes = map[enumor.CloudResourceType]map[string]enumor.ColumnType{
+	enumor.CvmCloudResType:              tablecvm.TableColumns.ColumnTypes(),
+	enumor.DiskCloudResType:             tabledisk.DiskColumns.ColumnTypes(),
+	enumor.EipCloudResType:              tableeip.EipColumns.ColumnTypes(),
+	enumor.NetworkInterfaceCloudResType: tableni.NetworkInterfaceColumns.ColumnTypes(),
+	enumor.SecurityGroupCloudResType:    tablecloud.SecurityGroupColumns.ColumnTypes(),
+	enumor.GcpFirewallRuleCloudResType:  tablecloud.GcpFirewallRuleColumns.ColumnTypes(),
+	enumor.VpcCloudResType:              tablecloud.VpcColumns.ColumnTypes(),
+	enumor.SubnetCloudResType:           tablecloud.SubnetColumns.ColumnTypes(),
+	enumor.RouteTableCloudResType:       routetable.RouteTableColumns.ColumnTypes(),
+}
+
+// PreviewAssignResourceToBiz preview the resources to be assigned by BatchAssignResourceToBiz.
+func (svc cloudSvc) PreviewAssignResourceToBiz(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocloud.BatchAssignResourceToBizReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.genAssignPlan(cts.Kit, req)
+}
+
+// BatchAssignResourceToBiz assign filtered unassigned resource and its dependent resource to biz in one transaction.
+func (svc cloudSvc) BatchAssignResourceToBiz(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocloud.BatchAssignResourceToBizReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	plan, err := svc.genAssignPlan(cts.Kit, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(plan.Conflicts) != 0 {
+		return plan, errf.Newf(errf.InvalidParameter, "%d resources can not be assigned to biz(%d)",
+			len(plan.Conflicts), req.BkBizID)
+	}
+
+	if len(plan.Resources) == 0 {
+		return plan, nil
+	}
+
+	idsByType := make(map[enumor.CloudResourceType][]string)
+	cvmAccountIDs := make([]string, 0)
+	for _, one := range plan.Resources {
+		idsByType[one.ResType] = append(idsByType[one.ResType], one.ID)
+		if one.ResType == enumor.CvmCloudResType {
+			cvmAccountIDs = append(cvmAccountIDs, one.AccountID)
+		}
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		auditOpts := make([]audit.CloudResourceAssignInfo, 0, len(plan.Resources))
+		for resType, ids := range idsByType {
+			for _, batch := range slice.Split(ids, int(core.DefaultMaxPageLimit)) {
+				// 只分配仍处于未分配状态的资源，避免覆盖预览后被其他操作分配的资源
+				assignFilter := tools.ExpressionAnd(tools.RuleIn("id", batch),
+					tools.RuleEqual("bk_biz_id", constant.UnassignedBiz))
+				err := svc.dao.Cloud().AssignResourceToBiz(cts.Kit, txn, resType, assignFilter, req.BkBizID)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			for _, id := range ids {
+				auditOpts = append(auditOpts, audit.CloudResourceAssignInfo{
+					ResType:         assignResAuditTypeMap[resType],
+					ResID:           id,
+					AssignedResType: enumor.BizAuditAssignedResType,
+					AssignedResID:   req.BkBizID,
+				})
+			}
+		}
+
+		if err := svc.createAudit(cts.Kit, txn, auditOpts); err != nil {
+			return nil, err
+		}
+
+		for _, accountID := range slice.Unique(cvmAccountIDs) {
+			if err := cvm.SyncCvmToCmdb(cts.Kit, accountID, req.BkBizID); err != nil {
+				logs.Errorf("sync cvm to cmdb failed, err: %v, accountID: %s, bkBizID: %d, rid: %s", err,
+					accountID, req.BkBizID, cts.Kit.Rid)
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// genAssignPlan 生成分配计划，过滤出的资源只包括未分配的资源，主机的硬盘、EIP、网络接口、安全组作为依赖资源一起分配
+func (svc cloudSvc) genAssignPlan(kt *kit.Kit, req *protocloud.BatchAssignResourceToBizReq) (
+	*protocloud.AssignResourcePreviewResult, error) {
+
+	columnTypes, exists := batchAssignResColumnTypes[req.ResType]
+	if !exists {
+		return nil, errf.Newf(errf.InvalidParameter, "resource type %s cannot be batch assigned", req.ResType)
+	}
+
+	if err := req.Filter.Validate(filter.NewExprOption(filter.RuleFields(columnTypes))); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	expr, err := tools.And(req.Filter, tools.RuleEqual("bk_biz_id", constant.UnassignedBiz))
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	ids, err := svc.dao.Cloud().ListResourceIDs(kt, req.ResType, expr)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) > int(core.DefaultMaxPageLimit) {
+		return nil, errf.Newf(errf.InvalidParameter, "%d resources matched, exceed limit %d, please narrow the filter",
+			len(ids), core.DefaultMaxPageLimit)
+	}
+
+	plan := &protocloud.AssignResourcePreviewResult{
+		Resources: make([]protocloud.AssignResourceItem, 0),
+		Conflicts: make([]protocloud.AssignResourceConflict, 0),
+	}
+	if len(ids) == 0 {
+		return plan, nil
+	}
+
+	fields := types.CommonBasicInfoFields
+	if req.ResType == enumor.CvmCloudResType || req.ResType == enumor.VpcCloudResType {
+		fields = append([]string{"bk_cloud_id"}, types.CommonBasicInfoFields...)
+	}
+	infos, err := svc.dao.Cloud().ListResourceBasicInfo(kt, req.ResType, ids, fields...)
+	if err != nil {
+		return nil, err
+	}
+
+	cvmIDs := make([]string, 0)
+	for _, info := range infos {
+		switch {
+		case req.ResType == enumor.CvmCloudResType && info.BkCloudID == constant.UnbindBkCloudID:
+			plan.Conflicts = append(plan.Conflicts, protocloud.AssignResourceConflict{ResType: req.ResType,
+				ID: info.ID, Reason: "cvm not bind cloud area"})
+			continue
+		case req.ResType == enumor.VpcCloudResType && info.BkCloudID <= 0:
+			plan.Conflicts = append(plan.Conflicts, protocloud.AssignResourceConflict{ResType: req.ResType,
+				ID: info.ID, Reason: "vpc not bind cloud area"})
+			continue
+		}
+
+		plan.Resources = append(plan.Resources, protocloud.AssignResourceItem{ResType: req.ResType, ID: info.ID,
+			AccountID: info.AccountID})
+		if req.ResType == enumor.CvmCloudResType {
+			cvmIDs = append(cvmIDs, info.ID)
+		}
+	}
+
+	if len(cvmIDs) == 0 {
+		return plan, nil
+	}
+
+	if err = svc.fillCvmDependentRes(kt, cvmIDs, req.BkBizID, plan); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// fillCvmDependentRes 将主机依赖的资源加入分配计划，已属于目标业务的依赖资源无需分配，属于其他业务的依赖资源视为冲突
+func (svc cloudSvc) fillCvmDependentRes(kt *kit.Kit, cvmIDs []string, bizID int64,
+	plan *protocloud.AssignResourcePreviewResult) error {
+
+	depMap, err := svc.listCvmDependentRes(kt, cvmIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, resType := range []enumor.CloudResourceType{enumor.DiskCloudResType, enumor.EipCloudResType,
+		enumor.NetworkInterfaceCloudResType, enumor.SecurityGroupCloudResType} {
+
+		resCvmMap := depMap[resType]
+		if len(resCvmMap) == 0 {
+			continue
+		}
+
+		resIDs := make([]string, 0, len(resCvmMap))
+		for id := range resCvmMap {
+			resIDs = append(resIDs, id)
+		}
+
+		for _, batch := range slice.Split(resIDs, int(core.DefaultMaxPageLimit)) {
+			infos, err := svc.dao.Cloud().ListResourceBasicInfo(kt, resType, batch)
+			if err != nil {
+				return err
+			}
+
+			for _, info := range infos {
+				switch info.BkBizID {
+				case bizID:
+					continue
+				case constant.UnassignedBiz:
+					plan.Resources = append(plan.Resources, protocloud.AssignResourceItem{ResType: resType,
+						ID: info.ID, AccountID: info.AccountID, DependOnID: resCvmMap[info.ID]})
+				default:
+					plan.Conflicts = append(plan.Conflicts, protocloud.AssignResourceConflict{ResType: resType,
+						ID: info.ID, Reason: fmt.Sprintf("%s depended by cvm(%s) is already assigned to biz(%d)",
+							resType, resCvmMap[info.ID], info.BkBizID)})
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// listCvmDependentRes 查询主机关联的资源，返回 资源类型 -> 资源ID -> 主机ID 的映射
+func (svc cloudSvc) listCvmDependentRes(kt *kit.Kit, cvmIDs []string) (
+	map[enumor.CloudResourceType]map[string]string, error) {
+
+	depMap := map[enumor.CloudResourceType]map[string]string{
+		enumor.DiskCloudResType:             make(map[string]string),
+		enumor.EipCloudResType:              make(map[string]string),
+		enumor.NetworkInterfaceCloudResType: make(map[string]string),
+		enumor.SecurityGroupCloudResType:    make(map[string]string),
+	}
+
+	for _, ids := range slice.Split(cvmIDs, int(core.DefaultMaxPageLimit)) {
+		opt := &types.ListOption{Filter: tools.ContainersExpression("cvm_id", ids), Page: core.NewDefaultBasePage()}
+		diskRels, err := svc.dao.DiskCvmRel().List(kt, opt)
+		if err != nil {
+			logs.Errorf("list disk cvm rel failed, err: %v, cvmIDs: %v, rid: %s", err, ids, kt.Rid)
+			return nil, err
+		}
+		for _, rel := range diskRels.Details {
+			depMap[enumor.DiskCloudResType][rel.DiskID] = rel.CvmID
+		}
+
+		eipRels, err := svc.dao.EipCvmRel().List(kt, opt)
+		if err != nil {
+			logs.Errorf("list eip cvm rel failed, err: %v, cvmIDs: %v, rid: %s", err, ids, kt.Rid)
+			return nil, err
+		}
+		for _, rel := range eipRels.Details {
+			depMap[enumor.EipCloudResType][rel.EipID] = rel.CvmID
+		}
+
+		niRels, err := svc.dao.NiCvmRel().List(kt, opt)
+		if err != nil {
+			logs.Errorf("list network interface cvm rel failed, err: %v, cvmIDs: %v, rid: %s", err, ids, kt.Rid)
+			return nil, err
+		}
+		for _, rel := range niRels.Details {
+			depMap[enumor.NetworkInterfaceCloudResType][rel.NetworkInterfaceID] = rel.CvmID
+		}
+
+		// 主机关联的安全组数量可能超过单页上限，需要分页查询
+		sgOpt := &types.ListOption{Filter: tools.ContainersExpression("cvm_id", ids),
+			Page: core.NewDefaultBasePage()}
+		for {
+			sgRels, err := svc.dao.SGCvmRel().List(kt, sgOpt)
+			if err != nil {
+				logs.Errorf("list security group cvm rel failed, err: %v, cvmIDs: %v, rid: %s", err, ids, kt.Rid)
+				return nil, err
+			}
+			for _, rel := range sgRels.Details {
+				depMap[enumor.SecurityGroupCloudResType][rel.SecurityGroupID] = rel.CvmID
+			}
+
+			if uint(len(sgRels.Details)) < sgOpt.Page.Limit {
+				break
+			}
+			sgOpt.Page.Start += uint32(sgOpt.Page.Limit)
+		}
+	}
+
+	return depMap, nil
+}
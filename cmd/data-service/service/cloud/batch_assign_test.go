@@ -0,0 +1,204 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"testing"
+
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao"
+	daocloud "hcm/pkg/dal/dao/cloud"
+	diskcvmrel "hcm/pkg/dal/dao/cloud/disk-cvm-rel"
+	eipcvmrel "hcm/pkg/dal/dao/cloud/eip-cvm-rel"
+	nicvmrel "hcm/pkg/dal/dao/cloud/network-interface-cvm-rel"
+	sgcvmrel "hcm/pkg/dal/dao/cloud/security-group-cvm-rel"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	typescloud "hcm/pkg/dal/dao/types/cloud"
+	tablecloud "hcm/pkg/dal/table/cloud"
+	"hcm/pkg/kit"
+	"hcm/pkg/runtime/filter"
+)
+
+// fakeAssignSet serves the resources and relations of the assign plan from memory.
+type fakeAssignSet struct {
+	dao.Set
+	cloud *fakeCloud
+}
+
+// Cloud ...
+func (f fakeAssignSet) Cloud() daocloud.Cloud {
+	return f.cloud
+}
+
+// DiskCvmRel ...
+func (f fakeAssignSet) DiskCvmRel() diskcvmrel.DiskCvmRel {
+	return fakeDiskRel{}
+}
+
+// EipCvmRel ...
+func (f fakeAssignSet) EipCvmRel() eipcvmrel.EipCvmRel {
+	return fakeEipRel{}
+}
+
+// NiCvmRel ...
+func (f fakeAssignSet) NiCvmRel() nicvmrel.NiCvmRel {
+	return fakeNiRel{}
+}
+
+// SGCvmRel ...
+func (f fakeAssignSet) SGCvmRel() sgcvmrel.Interface {
+	return fakeSGRel{}
+}
+
+type fakeCloud struct {
+	daocloud.Cloud
+	infos map[enumor.CloudResourceType][]types.CloudResourceBasicInfo
+}
+
+// ListResourceIDs ...
+func (f *fakeCloud) ListResourceIDs(_ *kit.Kit, resType enumor.CloudResourceType, _ *filter.Expression) (
+	[]string, error) {
+
+	ids := make([]string, 0)
+	for _, info := range f.infos[resType] {
+		if info.BkBizID == constant.UnassignedBiz {
+			ids = append(ids, info.ID)
+		}
+	}
+	return ids, nil
+}
+
+// ListResourceBasicInfo ...
+func (f *fakeCloud) ListResourceBasicInfo(_ *kit.Kit, resType enumor.CloudResourceType, ids []string,
+	_ ...string) ([]types.CloudResourceBasicInfo, error) {
+
+	idMap := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		idMap[id] = struct{}{}
+	}
+
+	infos := make([]types.CloudResourceBasicInfo, 0)
+	for _, info := range f.infos[resType] {
+		if _, exists := idMap[info.ID]; exists {
+			infos = append(infos, info)
+		}
+	}
+	return infos, nil
+}
+
+type fakeDiskRel struct {
+	diskcvmrel.DiskCvmRel
+}
+
+// List ...
+func (fakeDiskRel) List(_ *kit.Kit, _ *types.ListOption) (*typescloud.DiskCvmRelListResult, error) {
+	return &typescloud.DiskCvmRelListResult{Details: []*tablecloud.DiskCvmRelModel{
+		{DiskID: "disk-1", CvmID: "cvm-1"},
+	}}, nil
+}
+
+type fakeEipRel struct {
+	eipcvmrel.EipCvmRel
+}
+
+// List ...
+func (fakeEipRel) List(_ *kit.Kit, _ *types.ListOption) (*typescloud.EipCvmRelListResult, error) {
+	return &typescloud.EipCvmRelListResult{Details: []*tablecloud.EipCvmRelModel{
+		{EipID: "eip-1", CvmID: "cvm-1"},
+	}}, nil
+}
+
+type fakeNiRel struct {
+	nicvmrel.NiCvmRel
+}
+
+// List ...
+func (fakeNiRel) List(_ *kit.Kit, _ *types.ListOption) (*types.ListNetworkInterfaceCvmRelDetails, error) {
+	return &types.ListNetworkInterfaceCvmRelDetails{}, nil
+}
+
+type fakeSGRel struct {
+	sgcvmrel.Interface
+}
+
+// List ...
+func (fakeSGRel) List(_ *kit.Kit, _ *types.ListOption) (*types.ListSecurityGroupCvmRelDetails, error) {
+	return &types.ListSecurityGroupCvmRelDetails{Details: []tablecloud.SecurityGroupCvmRelTable{
+		{SecurityGroupID: "sg-1", CvmID: "cvm-1"},
+	}}, nil
+}
+
+func TestGenAssignPlan(t *testing.T) {
+	const bizID = 100
+	cloud := &fakeCloud{infos: map[enumor.CloudResourceType][]types.CloudResourceBasicInfo{
+		enumor.CvmCloudResType: {
+			{ID: "cvm-1", AccountID: "account-1", BkBizID: constant.UnassignedBiz, BkCloudID: 0},
+			{ID: "cvm-2", AccountID: "account-1", BkBizID: constant.UnassignedBiz,
+				BkCloudID: constant.UnbindBkCloudID},
+			{ID: "cvm-3", AccountID: "account-1", BkBizID: 200},
+		},
+		enumor.DiskCloudResType: {{ID: "disk-1", AccountID: "account-1", BkBizID: constant.UnassignedBiz}},
+		enumor.EipCloudResType:  {{ID: "eip-1", AccountID: "account-1", BkBizID: bizID}},
+		enumor.SecurityGroupCloudResType: {
+			{ID: "sg-1", AccountID: "account-1", BkBizID: 200},
+		},
+	}}
+	svc := cloudSvc{dao: fakeAssignSet{cloud: cloud}}
+
+	plan, err := svc.genAssignPlan(kit.New(), &protocloud.BatchAssignResourceToBizReq{BkBizID: bizID,
+		ResType: enumor.CvmCloudResType, Filter: tools.EqualExpression("account_id", "account-1")})
+	if err != nil {
+		t.Fatalf("generate assign plan failed, err: %v", err)
+	}
+
+	// the unassigned disk is assigned with its cvm, and the eip already in the biz is skipped.
+	expectRes := []protocloud.AssignResourceItem{
+		{ResType: enumor.CvmCloudResType, ID: "cvm-1", AccountID: "account-1"},
+		{ResType: enumor.DiskCloudResType, ID: "disk-1", AccountID: "account-1", DependOnID: "cvm-1"},
+	}
+	if len(plan.Resources) != len(expectRes) {
+		t.Fatalf("unexpected resources to assign: %+v", plan.Resources)
+	}
+	for idx, one := range expectRes {
+		if plan.Resources[idx] != one {
+			t.Errorf("resource %d should be %+v, got: %+v", idx, one, plan.Resources[idx])
+		}
+	}
+
+	// the cvm without cloud area and the dependent resource in the other biz are conflicts.
+	conflicts := make(map[string]enumor.CloudResourceType)
+	for _, one := range plan.Conflicts {
+		conflicts[one.ID] = one.ResType
+	}
+	if len(conflicts) != 2 || conflicts["cvm-2"] != enumor.CvmCloudResType ||
+		conflicts["sg-1"] != enumor.SecurityGroupCloudResType {
+
+		t.Errorf("unexpected conflicts: %+v", plan.Conflicts)
+	}
+
+	_, err = svc.genAssignPlan(kit.New(), &protocloud.BatchAssignResourceToBizReq{BkBizID: bizID,
+		ResType: enumor.LoadBalancerCloudResType, Filter: tools.AllExpression()})
+	if err == nil {
+		t.Errorf("resource type not supported should be rejected")
+	}
+}
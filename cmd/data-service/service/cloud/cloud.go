@@ -60,6 +60,13 @@ func InitCloudService(cap *capability.Capability) {
 	h.Add("BatchListResBasicInfo", http.MethodPost, "/cloud/resources/basics/batch/list",
 		svc.BatchListResourceBasicInfo)
 	h.Add("AssignResourceToBiz", http.MethodPost, "/cloud/resources/assign/bizs", svc.AssignResourceToBiz)
+	h.Add("PreviewAssignResourceToBiz", http.MethodPost, "/cloud/resources/assign/bizs/preview",
+		svc.PreviewAssignResourceToBiz)
+	h.Add("BatchAssignResourceToBiz", http.MethodPost, "/cloud/resources/assign/bizs/batch",
+		svc.BatchAssignResourceToBiz)
+	h.Add("PreviewTransferResourceBiz", http.MethodPost, "/cloud/resources/transfer/bizs/preview",
+		svc.PreviewTransferResourceBiz)
+	h.Add("TransferResourceBiz", http.MethodPost, "/cloud/resources/transfer/bizs", svc.TransferResourceBiz)
 
 	h.Load(cap.WebService)
 }
@@ -21,18 +21,23 @@ package cvm
 
 import (
 	"hcm/cmd/data-service/service/cloud/logics/cmdb"
+	"hcm/pkg/api/core"
 	corecvm "hcm/pkg/api/core/cloud/cvm"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table/cloud/cvm"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
 )
 
 // upsertCmdbHosts upsert cmdb hosts. TODO add previous hosts params to transfer across biz when supported.
 func upsertCmdbHosts[T corecvm.Extension](svc *cvmSvc, kt *kit.Kit, vendor enumor.Vendor, models []*cvm.Table) error {
 	bizHostMap := make(map[int64][]corecvm.Cvm[T])
+	bizModelMap := make(map[int64][]*cvm.Table)
 	for _, model := range models {
 		if model.BkBizID == constant.UnassignedBiz {
 			// ignore unassigned host. TODO delete unassigned host from cmdb when transfer back to resource supported.
@@ -46,6 +51,7 @@ func upsertCmdbHosts[T corecvm.Extension](svc *cvmSvc, kt *kit.Kit, vendor enumo
 			return err
 		}
 		bizHostMap[model.BkBizID] = append(bizHostMap[model.BkBizID], converter.PtrToVal(host))
+		bizModelMap[model.BkBizID] = append(bizModelMap[model.BkBizID], model)
 	}
 
 	for bizID, hosts := range bizHostMap {
@@ -55,6 +61,11 @@ func upsertCmdbHosts[T corecvm.Extension](svc *cvmSvc, kt *kit.Kit, vendor enumo
 				addCmdbReq, kt.Rid)
 			return err
 		}
+
+		// cmdb hosts have been synced, the relation will be fixed by the next sync if save failed.
+		if _, err := saveCmdbHostRels(svc, kt, bizID, 0, bizModelMap[bizID], nil); err != nil {
+			logs.Errorf("save cmdb host rels failed, err: %v, biz: %d, rid: %s", err, bizID, kt.Rid)
+		}
 	}
 
 	return nil
@@ -64,6 +75,7 @@ func upsertCmdbHosts[T corecvm.Extension](svc *cvmSvc, kt *kit.Kit, vendor enumo
 // TODO add previous hosts params to transfer across biz when supported.
 func upsertBaseCmdbHosts(svc *cvmSvc, kt *kit.Kit, models []*cvm.Table) error {
 	bizHostMap := make(map[int64][]corecvm.BaseCvm)
+	bizModelMap := make(map[int64][]*cvm.Table)
 	for _, model := range models {
 		if model.BkBizID == constant.UnassignedBiz {
 			// ignore unassigned host. TODO delete unassigned host from cmdb when transfer back to resource supported.
@@ -71,6 +83,7 @@ func upsertBaseCmdbHosts(svc *cvmSvc, kt *kit.Kit, models []*cvm.Table) error {
 		}
 
 		bizHostMap[model.BkBizID] = append(bizHostMap[model.BkBizID], converter.PtrToVal(convTableToBaseCvm(model)))
+		bizModelMap[model.BkBizID] = append(bizModelMap[model.BkBizID], model)
 	}
 
 	for bizID, hosts := range bizHostMap {
@@ -80,6 +93,11 @@ func upsertBaseCmdbHosts(svc *cvmSvc, kt *kit.Kit, models []*cvm.Table) error {
 				err, addCmdbReq, kt.Rid)
 			return err
 		}
+
+		// cmdb hosts have been synced, the relation will be fixed by the next sync if save failed.
+		if _, err := saveCmdbHostRels(svc, kt, bizID, 0, bizModelMap[bizID], nil); err != nil {
+			logs.Errorf("save cmdb host rels failed, err: %v, biz: %d, rid: %s", err, bizID, kt.Rid)
+		}
 	}
 
 	return nil
@@ -111,3 +129,98 @@ func deleteCmdbHosts(svc *cvmSvc, kt *kit.Kit, models []cvm.Table) error {
 
 	return nil
 }
+
+// TransferCmdbHosts 主机在业务间转移后，将主机从原业务的cmdb中删除，再添加到目标业务下
+func TransferCmdbHosts(kt *kit.Kit, ids []string, sourceBizID, targetBizID int64) error {
+	opt := &types.ListOption{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	list, err := svc.dao.Cvm().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list cvm failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return err
+	}
+
+	for idx := range list.Details {
+		list.Details[idx].BkBizID = sourceBizID
+	}
+	if err = deleteCmdbHosts(svc, kt, list.Details); err != nil {
+		return err
+	}
+
+	for idx := range list.Details {
+		list.Details[idx].BkBizID = targetBizID
+	}
+	return upsertBaseCmdbHosts(svc, kt, converter.SliceToPtr(list.Details))
+}
+
+// saveCmdbHostRels save the results of syncing cvms to cmdb biz as cmdb host relations. when sync succeeded, the cmdb
+// host ids are queried from cmdb by cloud ids, and moduleID is the module that hosts have been transferred to.
+func saveCmdbHostRels(svc *cvmSvc, kt *kit.Kit, bizID, moduleID int64, models []*cvm.Table, syncErr error) (
+	[]cvm.CmdbHostRelTable, error) {
+
+	saved := make([]cvm.CmdbHostRelTable, 0, len(models))
+	for _, batch := range slice.Split(models, cmdbHostRelBatchSize) {
+		hostIDMap := make(map[enumor.Vendor]map[string]int64)
+		if syncErr == nil {
+			vendorCloudIDs := make(map[enumor.Vendor][]string)
+			for _, model := range batch {
+				vendorCloudIDs[model.Vendor] = append(vendorCloudIDs[model.Vendor], model.CloudID)
+			}
+
+			var err error
+			hostIDMap, err = svc.cmdbLogics.ListCloudHostIDs(kt, bizID, vendorCloudIDs)
+			if err != nil {
+				logs.Errorf("list cmdb cloud host ids failed, err: %v, biz: %d, rid: %s", err, bizID, kt.Rid)
+				return nil, err
+			}
+		}
+
+		rels := make([]cvm.CmdbHostRelTable, 0, len(batch))
+		for _, model := range batch {
+			rel := cvm.CmdbHostRelTable{
+				CvmID:      model.ID,
+				Vendor:     model.Vendor,
+				AccountID:  model.AccountID,
+				CloudID:    model.CloudID,
+				BkBizID:    bizID,
+				SyncStatus: enumor.CmdbHostSyncFailed,
+			}
+
+			hostID, exists := hostIDMap[model.Vendor][model.CloudID]
+			switch {
+			case syncErr != nil:
+				rel.SyncMessage = truncateSyncMessage(syncErr.Error())
+			case !exists:
+				rel.SyncMessage = "host is not found in cmdb biz after sync"
+			default:
+				rel.SyncStatus = enumor.CmdbHostSyncSuccess
+				rel.BkHostID = hostID
+				rel.BkModuleID = moduleID
+			}
+			rels = append(rels, rel)
+		}
+
+		if err := svc.dao.CmdbHostRel().BatchUpsert(kt, rels); err != nil {
+			return nil, err
+		}
+		saved = append(saved, rels...)
+	}
+
+	return saved, nil
+}
+
+// cmdbHostRelBatchSize is the max number of cvms to query cmdb host ids at one time.
+const cmdbHostRelBatchSize = 500
+
+// maxSyncMessageLen is the max length of cmdb host rel sync message.
+const maxSyncMessageLen = 1024
+
+func truncateSyncMessage(msg string) string {
+	runes := []rune(msg)
+	if len(runes) <= maxSyncMessageLen {
+		return msg
+	}
+	return string(runes[:maxSyncMessageLen])
+}
@@ -0,0 +1,202 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"errors"
+
+	"hcm/cmd/data-service/service/cloud/logics/cmdb"
+	"hcm/pkg/api/core"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablecvm "hcm/pkg/dal/table/cloud/cvm"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+)
+
+// SyncCvmToCmdbHost push cvms to the cmdb hosts of their biz, transfer the hosts to the module if specified, and
+// record the cmdb host relations of the cvms.
+func (svc *cvmSvc) SyncCvmToCmdbHost(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocloud.CvmCmdbSyncReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: tools.ContainersExpression("id", req.CvmIDs),
+		Page:   core.NewDefaultBasePage(),
+	}
+	list, err := svc.dao.Cvm().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list cvm failed, err: %v, ids: %v, rid: %s", err, req.CvmIDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	result := &protocloud.CvmCmdbSyncResult{
+		Succeeded: make([]string, 0),
+		Failed:    make([]protocloud.CvmCmdbSyncFailed, 0),
+	}
+	existIDs := make(map[string]struct{}, len(list.Details))
+	bizModelMap := make(map[int64][]*tablecvm.Table)
+	for index, one := range list.Details {
+		existIDs[one.ID] = struct{}{}
+		if one.BkBizID == constant.UnassignedBiz {
+			result.Failed = append(result.Failed, protocloud.CvmCmdbSyncFailed{ID: one.ID,
+				Reason: "cvm is not assigned to biz"})
+			continue
+		}
+		bizModelMap[one.BkBizID] = append(bizModelMap[one.BkBizID], &list.Details[index])
+	}
+	for _, id := range req.CvmIDs {
+		if _, exists := existIDs[id]; !exists {
+			result.Failed = append(result.Failed, protocloud.CvmCmdbSyncFailed{ID: id, Reason: "cvm is not found"})
+		}
+	}
+
+	for bizID, models := range bizModelMap {
+		syncErr := svc.syncBizCmdbHosts(cts.Kit, bizID, req.BkModuleID, models)
+		moduleID := req.BkModuleID
+		if syncErr != nil {
+			moduleID = 0
+		}
+
+		rels, err := saveCmdbHostRels(svc, cts.Kit, bizID, moduleID, models, syncErr)
+		if err != nil {
+			logs.Errorf("save cmdb host rels failed, err: %v, biz: %d, rid: %s", err, bizID, cts.Kit.Rid)
+			for _, model := range models {
+				result.Failed = append(result.Failed, protocloud.CvmCmdbSyncFailed{ID: model.ID, Reason: err.Error()})
+			}
+			continue
+		}
+
+		for _, rel := range rels {
+			if rel.SyncStatus == enumor.CmdbHostSyncSuccess {
+				result.Succeeded = append(result.Succeeded, rel.CvmID)
+				continue
+			}
+			result.Failed = append(result.Failed, protocloud.CvmCmdbSyncFailed{ID: rel.CvmID, Reason: rel.SyncMessage})
+		}
+	}
+
+	return result, nil
+}
+
+// syncBizCmdbHosts add or update the cmdb hosts of cvms in the biz, then transfer the hosts to the module.
+func (svc *cvmSvc) syncBizCmdbHosts(kt *kit.Kit, bizID, moduleID int64, models []*tablecvm.Table) error {
+	hosts := make([]corecvm.BaseCvm, 0, len(models))
+	vendorCloudIDs := make(map[enumor.Vendor][]string)
+	for _, model := range models {
+		hosts = append(hosts, converter.PtrToVal(convTableToBaseCvm(model)))
+		vendorCloudIDs[model.Vendor] = append(vendorCloudIDs[model.Vendor], model.CloudID)
+	}
+
+	addCmdbReq := &cmdb.AddBaseCloudHostToBizReq{BizID: bizID, Hosts: hosts}
+	if err := cmdb.AddBaseCloudHostToBiz(svc.cmdbLogics, kt, addCmdbReq); err != nil {
+		logs.Errorf("[%s] add cmdb base cloud hosts failed, err: %v, biz: %d, rid: %s", constant.CmdbSyncFailed, err,
+			bizID, kt.Rid)
+		return err
+	}
+
+	if moduleID == 0 {
+		return nil
+	}
+
+	hostIDMap, err := svc.cmdbLogics.ListCloudHostIDs(kt, bizID, vendorCloudIDs)
+	if err != nil {
+		logs.Errorf("list cmdb cloud host ids failed, err: %v, biz: %d, rid: %s", err, bizID, kt.Rid)
+		return err
+	}
+
+	hostIDs := make([]int64, 0, len(models))
+	for _, cloudHostIDs := range hostIDMap {
+		for _, hostID := range cloudHostIDs {
+			hostIDs = append(hostIDs, hostID)
+		}
+	}
+	if len(hostIDs) == 0 {
+		return errors.New("hosts are not found in cmdb biz after sync")
+	}
+
+	if err = svc.cmdbLogics.TransferHostToModule(kt, bizID, hostIDs, moduleID); err != nil {
+		logs.Errorf("[%s] transfer cmdb hosts to module failed, err: %v, biz: %d, module: %d, rid: %s",
+			constant.CmdbSyncFailed, err, bizID, moduleID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// ListCmdbHostRel list cvm and cmdb host relations.
+func (svc *cvmSvc) ListCmdbHostRel(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.CmdbHostRel().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list cmdb host rel failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]corecvm.CmdbHostRel, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corecvm.CmdbHostRel{
+			ID:          one.ID,
+			CvmID:       one.CvmID,
+			Vendor:      one.Vendor,
+			AccountID:   one.AccountID,
+			CloudID:     one.CloudID,
+			BkBizID:     one.BkBizID,
+			BkHostID:    one.BkHostID,
+			BkModuleID:  one.BkModuleID,
+			SyncStatus:  one.SyncStatus,
+			SyncMessage: one.SyncMessage,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &core.ListResultT[corecvm.CmdbHostRel]{Count: result.Count, Details: details}, nil
+}
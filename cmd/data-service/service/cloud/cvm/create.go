@@ -32,6 +32,7 @@ import (
 	"hcm/pkg/dal/dao/orm"
 	tablecvm "hcm/pkg/dal/table/cloud/cvm"
 	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 	"hcm/pkg/tools/json"
@@ -68,11 +69,18 @@ func batchCreateCvm[T corecvm.Extension](cts *rest.Contexts, svc *cvmSvc, vendor
 		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
 	}
 
+	return createCvm(cts.Kit, svc, vendor, req)
+}
+
+// createCvm create cvm, it is shared by the restful and grpc api.
+func createCvm[T corecvm.Extension](kt *kit.Kit, svc *cvmSvc, vendor enumor.Vendor,
+	req *protocloud.CvmBatchCreateReq[T]) (*core.BatchCreateResult, error) {
+
 	if err := req.Validate(); err != nil {
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+	result, err := svc.dao.Txn().AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
 		models := make([]*tablecvm.Table, 0, len(req.Cvms))
 		for _, one := range req.Cvms {
 			extension, err := json.MarshalToString(one.Extension)
@@ -107,21 +115,21 @@ func batchCreateCvm[T corecvm.Extension](cts *rest.Contexts, svc *cvmSvc, vendor
 				CloudCreatedTime:     one.CloudCreatedTime,
 				CloudLaunchedTime:    one.CloudLaunchedTime,
 				CloudExpiredTime:     one.CloudExpiredTime,
-				Creator:              cts.Kit.User,
-				Reviser:              cts.Kit.User,
+				Creator:              kt.User,
+				Reviser:              kt.User,
 			})
 		}
 
-		ids, err := svc.dao.Cvm().BatchCreateWithTx(cts.Kit, txn, models)
+		ids, err := svc.dao.Cvm().BatchCreateWithTx(kt, txn, models)
 		if err != nil {
 			return nil, fmt.Errorf("batch create cvm failed, err: %v", err)
 		}
 
 		// create cmdb cloud hosts
 		// 如果主机同步Cmdb失败，但写入HCM成功，忽略该错误。
-		err = upsertCmdbHosts[T](svc, cts.Kit, vendor, models)
+		err = upsertCmdbHosts[T](svc, kt, vendor, models)
 		if err != nil {
-			logs.Errorf("[%s] upsert cmdb hosts failed, err: %v, rid: %s", constant.CmdbSyncFailed, err, cts.Kit.Rid)
+			logs.Errorf("[%s] upsert cmdb hosts failed, err: %v, rid: %s", constant.CmdbSyncFailed, err, kt.Rid)
 			return nil, nil
 		}
 
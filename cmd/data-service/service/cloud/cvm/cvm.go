@@ -25,6 +25,7 @@ import (
 
 	"hcm/cmd/data-service/service/capability"
 	"hcm/cmd/data-service/service/cloud/logics/cmdb"
+	"hcm/pkg/api/data-service/grpc/pb"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -45,11 +46,31 @@ func InitService(cap *capability.Capability) {
 	h.Add("BatchUpdateCvm", http.MethodPatch, "/vendors/{vendor}/cvms/batch/update", svc.BatchUpdateCvm)
 	h.Add("GetCvm", http.MethodGet, "/vendors/{vendor}/cvms/{id}", svc.GetCvm)
 	h.Add("ListCvm", http.MethodPost, "/cvms/list", svc.ListCvm)
+	h.Add("StreamListCvm", http.MethodPost, "/cvms/list/stream", svc.StreamListCvm)
 	h.Add("ListCvmExt", http.MethodPost, "/vendors/{vendor}/cvms/list", svc.ListCvmExt)
 	h.Add("BatchDeleteCvm", http.MethodDelete, "/cvms/batch", svc.BatchDeleteCvm)
 	h.Add("BatchUpdateCvmCommonInfo", http.MethodPatch, "/cvms/common/info/batch/update", svc.BatchUpdateCvmCommonInfo)
+	h.Add("BatchUpdateCvmOwnerInfo", http.MethodPatch, "/cvms/owner/info/batch/update", svc.BatchUpdateCvmOwnerInfo)
+	h.Add("SyncCvmToCmdbHost", http.MethodPost, "/cvms/cmdb/sync", svc.SyncCvmToCmdbHost)
+	h.Add("ListCmdbHostRel", http.MethodPost, "/cvms/cmdb/host_rels/list", svc.ListCmdbHostRel)
+
+	h.Add("CreateCvmTemplate", http.MethodPost, "/cvm_templates/create", svc.CreateCvmTemplate)
+	h.Add("UpdateCvmTemplate", http.MethodPatch, "/cvm_templates/{id}", svc.UpdateCvmTemplate)
+	h.Add("ListCvmTemplate", http.MethodPost, "/cvm_templates/list", svc.ListCvmTemplate)
+	h.Add("BatchDeleteCvmTemplate", http.MethodDelete, "/cvm_templates/batch", svc.BatchDeleteCvmTemplate)
+
+	h.Add("CreateCvmSchedule", http.MethodPost, "/cvm_schedules/create", svc.CreateCvmSchedule)
+	h.Add("UpdateCvmSchedule", http.MethodPatch, "/cvm_schedules/{id}", svc.UpdateCvmSchedule)
+	h.Add("ListCvmSchedule", http.MethodPost, "/cvm_schedules/list", svc.ListCvmSchedule)
+	h.Add("BatchDeleteCvmSchedule", http.MethodDelete, "/cvm_schedules/batch", svc.BatchDeleteCvmSchedule)
+	h.Add("CreateCvmScheduleRecord", http.MethodPost, "/cvm_schedules/records/create", svc.CreateCvmScheduleRecord)
+	h.Add("ListCvmScheduleRecord", http.MethodPost, "/cvm_schedules/records/list", svc.ListCvmScheduleRecord)
 
 	h.Load(cap.WebService)
+
+	if cap.GrpcServer != nil {
+		pb.RegisterCvmServiceServer(cap.GrpcServer, &grpcSvc{svc: svc})
+	}
 }
 
 type cvmSvc struct {
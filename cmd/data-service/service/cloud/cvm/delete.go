@@ -86,6 +86,11 @@ func (svc *cvmSvc) BatchDeleteCvm(cts *rest.Contexts) (interface{}, error) {
 			return nil, err
 		}
 
+		relFilter := tools.ContainersExpression("cvm_id", delIDs)
+		if err := svc.dao.CmdbHostRel().DeleteWithTx(cts.Kit, txn, relFilter); err != nil {
+			return nil, err
+		}
+
 		// delete cmdb cloud hosts
 		if err = deleteCmdbHosts(svc, cts.Kit, listResp.Details); err != nil {
 			logs.Errorf("delete cmdb hosts failed, err: %v, rid: %s", err, cts.Kit.Rid)
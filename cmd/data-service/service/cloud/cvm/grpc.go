@@ -0,0 +1,202 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"context"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/api/data-service/grpc/pb"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	tablecvm "hcm/pkg/dal/table/cloud/cvm"
+	"hcm/pkg/kit"
+	"hcm/pkg/rpc"
+)
+
+// grpcSvc is the grpc api of cvm hot paths, the requests are handled by the same logics with the restful api.
+type grpcSvc struct {
+	pb.UnimplementedCvmServiceServer
+	svc *cvmSvc
+}
+
+// BatchCreateCvm batch create cvm.
+func (g *grpcSvc) BatchCreateCvm(ctx context.Context, in *pb.CvmBatchCreateReq) (*pb.BatchCreateResult, error) {
+	kt, err := rpc.Kit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	vendor := enumor.Vendor(in.Vendor)
+	if err = vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	var result *core.BatchCreateResult
+	switch vendor {
+	case enumor.TCloud:
+		result, err = grpcCreateCvm[corecvm.TCloudCvmExtension](kt, g.svc, vendor, in)
+	case enumor.Aws:
+		result, err = grpcCreateCvm[corecvm.AwsCvmExtension](kt, g.svc, vendor, in)
+	case enumor.HuaWei:
+		result, err = grpcCreateCvm[corecvm.HuaWeiCvmExtension](kt, g.svc, vendor, in)
+	case enumor.Azure:
+		result, err = grpcCreateCvm[corecvm.AzureCvmExtension](kt, g.svc, vendor, in)
+	case enumor.Gcp:
+		result, err = grpcCreateCvm[corecvm.GcpCvmExtension](kt, g.svc, vendor, in)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.BatchCreateResult{Ids: result.IDs}, nil
+}
+
+func grpcCreateCvm[T corecvm.Extension](kt *kit.Kit, svc *cvmSvc, vendor enumor.Vendor,
+	in *pb.CvmBatchCreateReq) (*core.BatchCreateResult, error) {
+
+	req, err := protocloud.CvmBatchCreateReqFromPb[T](in)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	return createCvm(kt, svc, vendor, req)
+}
+
+// BatchUpdateCvm batch update cvm.
+func (g *grpcSvc) BatchUpdateCvm(ctx context.Context, in *pb.CvmBatchUpdateReq) (*pb.BatchUpdateResult, error) {
+	kt, err := rpc.Kit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	vendor := enumor.Vendor(in.Vendor)
+	if err = vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		err = grpcUpdateCvm[corecvm.TCloudCvmExtension](kt, g.svc, vendor, in)
+	case enumor.Aws:
+		err = grpcUpdateCvm[corecvm.AwsCvmExtension](kt, g.svc, vendor, in)
+	case enumor.HuaWei:
+		err = grpcUpdateCvm[corecvm.HuaWeiCvmExtension](kt, g.svc, vendor, in)
+	case enumor.Azure:
+		err = grpcUpdateCvm[corecvm.AzureCvmExtension](kt, g.svc, vendor, in)
+	case enumor.Gcp:
+		err = grpcUpdateCvm[corecvm.GcpCvmExtension](kt, g.svc, vendor, in)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.BatchUpdateResult{}, nil
+}
+
+func grpcUpdateCvm[T corecvm.Extension](kt *kit.Kit, svc *cvmSvc, vendor enumor.Vendor,
+	in *pb.CvmBatchUpdateReq) error {
+
+	req, err := protocloud.CvmBatchUpdateReqFromPb[T](in)
+	if err != nil {
+		return errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	return updateCvm(kt, svc, vendor, req)
+}
+
+// ListCvmExt list cvm with extension, the extension is returned as the json stored in db without decoding.
+func (g *grpcSvc) ListCvmExt(ctx context.Context, in *pb.ListReq) (*pb.CvmListResult, error) {
+	kt, err := rpc.Kit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = enumor.Vendor(in.Vendor).Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req, err := protocloud.CvmExtListReqFromPb(in)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	result, err := g.svc.listCvmExt(kt, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Page.Count {
+		return &pb.CvmListResult{Count: result.Count}, nil
+	}
+
+	details := make([]*pb.Cvm, 0, len(result.Details))
+	for i := range result.Details {
+		details = append(details, convTableToPbCvm(&result.Details[i]))
+	}
+
+	return &pb.CvmListResult{Details: details}, nil
+}
+
+func convTableToPbCvm(one *tablecvm.Table) *pb.Cvm {
+	return &pb.Cvm{
+		Id:                   one.ID,
+		CloudId:              one.CloudID,
+		Name:                 one.Name,
+		Vendor:               string(one.Vendor),
+		BkBizId:              one.BkBizID,
+		BkCloudId:            one.BkCloudID,
+		AccountId:            one.AccountID,
+		Region:               one.Region,
+		Zone:                 one.Zone,
+		CloudVpcIds:          one.CloudVpcIDs,
+		VpcIds:               one.VpcIDs,
+		CloudSubnetIds:       one.CloudSubnetIDs,
+		SubnetIds:            one.SubnetIDs,
+		CloudImageId:         one.CloudImageID,
+		ImageId:              one.ImageID,
+		OsName:               one.OsName,
+		Memo:                 one.Memo,
+		Status:               one.Status,
+		RecycleStatus:        one.RecycleStatus,
+		PrivateIpv4Addresses: one.PrivateIPv4Addresses,
+		PrivateIpv6Addresses: one.PrivateIPv6Addresses,
+		PublicIpv4Addresses:  one.PublicIPv4Addresses,
+		PublicIpv6Addresses:  one.PublicIPv6Addresses,
+		MachineType:          one.MachineType,
+		CloudCreatedTime:     one.CloudCreatedTime,
+		CloudLaunchedTime:    one.CloudLaunchedTime,
+		CloudExpiredTime:     one.CloudExpiredTime,
+		Owner:                one.Owner,
+		Labels:               one.Labels,
+		Creator:              one.Creator,
+		Reviser:              one.Reviser,
+		CreatedAt:            one.CreatedAt.String(),
+		UpdatedAt:            one.UpdatedAt.String(),
+		Extension:            []byte(one.Extension),
+	}
+}
@@ -68,7 +68,30 @@ func (svc *cvmSvc) ListCvm(cts *rest.Contexts) (interface{}, error) {
 		details = append(details, *convTableToBaseCvm(&one))
 	}
 
-	return &protocloud.CvmListResult{Details: details}, nil
+	return &protocloud.CvmListResult{Count: result.Count, Details: details}, nil
+}
+
+// StreamListCvm list cvm in newline-delimited json stream, which is used to export huge amount of cvms.
+func (svc *cvmSvc) StreamListCvm(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocloud.CvmStreamListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.StreamListOption{
+		Fields: req.Field,
+		Filter: req.Filter,
+	}
+
+	return rest.StreamFunc(func(w *rest.StreamWriter) error {
+		return svc.dao.Cvm().ListStream(cts.Kit, opt, func(one *tablecvm.Table) error {
+			return w.Write(convTableToBaseCvm(one))
+		})
+	}), nil
 }
 
 // GetCvm cvm.
@@ -154,6 +177,8 @@ func convTableToBaseCvm(one *tablecvm.Table) *corecvm.BaseCvm {
 		CloudCreatedTime:     one.CloudCreatedTime,
 		CloudLaunchedTime:    one.CloudLaunchedTime,
 		CloudExpiredTime:     one.CloudExpiredTime,
+		Owner:                one.Owner,
+		Labels:               one.Labels,
 		Revision: &core.Revision{
 			Creator:   one.Creator,
 			Reviser:   one.Reviser,
@@ -195,19 +220,9 @@ func (svc *cvmSvc) ListCvmExt(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	if err := req.Validate(); err != nil {
-		return nil, errf.NewFromErr(errf.InvalidParameter, err)
-	}
-
-	opt := &types.ListOption{
-		Fields: req.Field,
-		Filter: req.Filter,
-		Page:   req.Page,
-	}
-	result, err := svc.dao.Cvm().List(cts.Kit, opt)
+	result, err := svc.listCvmExt(cts.Kit, req)
 	if err != nil {
-		logs.Errorf("list cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
-		return nil, fmt.Errorf("list cvm failed, err: %v", err)
+		return nil, err
 	}
 
 	if req.Page.Count {
@@ -231,6 +246,26 @@ func (svc *cvmSvc) ListCvmExt(cts *rest.Contexts) (interface{}, error) {
 	}
 }
 
+// listCvmExt list cvm with extension, it is shared by the restful and grpc api.
+func (svc *cvmSvc) listCvmExt(kt *kit.Kit, req *protocloud.CvmExtListReq) (*types.ListCvmDetails, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Field,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.Cvm().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list cvm failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, fmt.Errorf("list cvm failed, err: %v", err)
+	}
+
+	return result, nil
+}
+
 func convCvmListResult[T corecvm.Extension](tables []tablecvm.Table) (*protocloud.CvmExtListResult[T], error) {
 
 	details := make([]corecvm.Cvm[T], 0, len(tables))
@@ -0,0 +1,268 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"hcm/pkg/api/core"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablecvm "hcm/pkg/dal/table/cloud/cvm"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/json"
+)
+
+// CreateCvmSchedule create cvm schedule.
+func (svc *cvmSvc) CreateCvmSchedule(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocloud.CvmScheduleCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	filterJson, err := json.MarshalToString(req.Filter)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablecvm.ScheduleTable{
+		Name:      req.Name,
+		Filter:    tabletype.JsonField(filterJson),
+		StartCron: converter.ValToPtr(req.StartCron),
+		StopCron:  converter.ValToPtr(req.StopCron),
+		Timezone:  req.Timezone,
+		Enabled:   converter.ValToPtr(req.Enabled),
+		Memo:      req.Memo,
+		Creator:   cts.Kit.User,
+		Reviser:   cts.Kit.User,
+	}
+
+	id, err := svc.dao.CvmSchedule().Create(cts.Kit, model)
+	if err != nil {
+		logs.Errorf("create cvm schedule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// UpdateCvmSchedule update cvm schedule.
+func (svc *cvmSvc) UpdateCvmSchedule(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(protocloud.CvmScheduleUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablecvm.ScheduleTable{
+		Name:      req.Name,
+		StartCron: req.StartCron,
+		StopCron:  req.StopCron,
+		Timezone:  req.Timezone,
+		Enabled:   req.Enabled,
+		Memo:      req.Memo,
+		Reviser:   cts.Kit.User,
+	}
+	if req.Filter != nil {
+		filterJson, err := json.MarshalToString(req.Filter)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+		model.Filter = tabletype.JsonField(filterJson)
+	}
+
+	if err := svc.dao.CvmSchedule().UpdateByID(cts.Kit, id, model); err != nil {
+		logs.Errorf("update cvm schedule failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListCvmSchedule list cvm schedule.
+func (svc *cvmSvc) ListCvmSchedule(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.CvmSchedule().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list cvm schedule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]corecvm.Schedule, 0, len(result.Details))
+	for _, one := range result.Details {
+		var expr *filter.Expression
+		if len(one.Filter) != 0 {
+			expr = new(filter.Expression)
+			if err = json.UnmarshalFromString(string(one.Filter), expr); err != nil {
+				logs.Errorf("unmarshal cvm schedule filter failed, err: %v, id: %s, rid: %s", err, one.ID,
+					cts.Kit.Rid)
+				return nil, err
+			}
+		}
+
+		details = append(details, corecvm.Schedule{
+			ID:        one.ID,
+			Name:      one.Name,
+			Filter:    expr,
+			StartCron: converter.PtrToVal(one.StartCron),
+			StopCron:  converter.PtrToVal(one.StopCron),
+			Timezone:  one.Timezone,
+			Enabled:   converter.PtrToVal(one.Enabled),
+			Memo:      one.Memo,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &core.ListResultT[corecvm.Schedule]{Count: result.Count, Details: details}, nil
+}
+
+// BatchDeleteCvmSchedule batch delete cvm schedule.
+func (svc *cvmSvc) BatchDeleteCvmSchedule(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.dao.CvmSchedule().Delete(cts.Kit, tools.ContainersExpression("id", req.IDs)); err != nil {
+		logs.Errorf("delete cvm schedule failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// CreateCvmScheduleRecord create cvm schedule execution record.
+func (svc *cvmSvc) CreateCvmScheduleRecord(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocloud.CvmScheduleRecordCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cpuCore, err := svc.dao.CvmSchedule().SumCpuCore(cts.Kit, req.CvmIDs)
+	if err != nil {
+		logs.Errorf("sum cvm cpu core failed, err: %v, schedule: %s, rid: %s", err, req.ScheduleID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	model := &tablecvm.ScheduleRecordTable{
+		ScheduleID:   req.ScheduleID,
+		Action:       req.Action,
+		CvmIDs:       req.CvmIDs,
+		FailedCvmIDs: req.FailedCvmIDs,
+		CpuCore:      cpuCore,
+		Creator:      cts.Kit.User,
+	}
+	if model.CvmIDs == nil {
+		model.CvmIDs = make([]string, 0)
+	}
+	if model.FailedCvmIDs == nil {
+		model.FailedCvmIDs = make([]string, 0)
+	}
+
+	id, err := svc.dao.CvmSchedule().CreateRecord(cts.Kit, model)
+	if err != nil {
+		logs.Errorf("create cvm schedule record failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// ListCvmScheduleRecord list cvm schedule execution record.
+func (svc *cvmSvc) ListCvmScheduleRecord(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.CvmSchedule().ListRecord(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list cvm schedule record failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]corecvm.ScheduleRecord, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corecvm.ScheduleRecord{
+			ID:           one.ID,
+			ScheduleID:   one.ScheduleID,
+			Action:       one.Action,
+			CvmIDs:       one.CvmIDs,
+			FailedCvmIDs: one.FailedCvmIDs,
+			CpuCore:      one.CpuCore,
+			Creator:      one.Creator,
+			CreatedAt:    one.CreatedAt.String(),
+		})
+	}
+
+	return &core.ListResultT[corecvm.ScheduleRecord]{Count: result.Count, Details: details}, nil
+}
@@ -0,0 +1,192 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"hcm/pkg/api/core"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablecvm "hcm/pkg/dal/table/cloud/cvm"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateCvmTemplate create cvm template.
+func (svc *cvmSvc) CreateCvmTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocloud.CvmTemplateCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablecvm.TemplateTable{
+		Name:                  req.Name,
+		Vendor:                req.Vendor,
+		AccountID:             req.AccountID,
+		Region:                req.Region,
+		Zone:                  req.Zone,
+		CloudImageID:          req.CloudImageID,
+		InstanceType:          req.InstanceType,
+		CloudSecurityGroupIDs: req.CloudSecurityGroupIDs,
+		SystemDisk:            defaultJsonField(req.SystemDisk, "{}"),
+		DataDisk:              defaultJsonField(req.DataDisk, "[]"),
+		UserData:              req.UserData,
+		Extension:             defaultJsonField(req.Extension, "{}"),
+		Memo:                  req.Memo,
+		Creator:               cts.Kit.User,
+		Reviser:               cts.Kit.User,
+	}
+	if model.CloudSecurityGroupIDs == nil {
+		model.CloudSecurityGroupIDs = make([]string, 0)
+	}
+
+	id, err := svc.dao.CvmTemplate().Create(cts.Kit, model)
+	if err != nil {
+		logs.Errorf("create cvm template failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// UpdateCvmTemplate update cvm template.
+func (svc *cvmSvc) UpdateCvmTemplate(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(protocloud.CvmTemplateUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablecvm.TemplateTable{
+		Name:                  req.Name,
+		Region:                req.Region,
+		Zone:                  req.Zone,
+		CloudImageID:          req.CloudImageID,
+		InstanceType:          req.InstanceType,
+		CloudSecurityGroupIDs: req.CloudSecurityGroupIDs,
+		SystemDisk:            req.SystemDisk,
+		DataDisk:              req.DataDisk,
+		Extension:             req.Extension,
+		Memo:                  req.Memo,
+		Reviser:               cts.Kit.User,
+	}
+	if req.UserData != nil {
+		model.UserData = *req.UserData
+	}
+
+	if err := svc.dao.CvmTemplate().UpdateByID(cts.Kit, id, model); err != nil {
+		logs.Errorf("update cvm template failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListCvmTemplate list cvm template.
+func (svc *cvmSvc) ListCvmTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.CvmTemplate().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list cvm template failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]corecvm.Template, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corecvm.Template{
+			ID:                    one.ID,
+			Name:                  one.Name,
+			Vendor:                one.Vendor,
+			AccountID:             one.AccountID,
+			Region:                one.Region,
+			Zone:                  one.Zone,
+			CloudImageID:          one.CloudImageID,
+			InstanceType:          one.InstanceType,
+			CloudSecurityGroupIDs: one.CloudSecurityGroupIDs,
+			SystemDisk:            one.SystemDisk,
+			DataDisk:              one.DataDisk,
+			UserData:              one.UserData,
+			Extension:             one.Extension,
+			Memo:                  one.Memo,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &core.ListResultT[corecvm.Template]{Count: result.Count, Details: details}, nil
+}
+
+// BatchDeleteCvmTemplate batch delete cvm template.
+func (svc *cvmSvc) BatchDeleteCvmTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.dao.CvmTemplate().Delete(cts.Kit, tools.ContainersExpression("id", req.IDs)); err != nil {
+		logs.Errorf("delete cvm template failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func defaultJsonField(field tabletype.JsonField, def string) tabletype.JsonField {
+	if len(field) == 0 {
+		return tabletype.JsonField(def)
+	}
+	return field
+}
@@ -32,6 +32,7 @@ import (
 	"hcm/pkg/dal/dao/types"
 	tablecvm "hcm/pkg/dal/table/cloud/cvm"
 	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 	"hcm/pkg/tools/converter"
@@ -70,8 +71,15 @@ func batchUpdateCvm[T corecvm.Extension](cts *rest.Contexts, svc *cvmSvc, vendor
 		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
 	}
 
+	return nil, updateCvm(cts.Kit, svc, vendor, req)
+}
+
+// updateCvm update cvm, it is shared by the restful and grpc api.
+func updateCvm[T corecvm.Extension](kt *kit.Kit, svc *cvmSvc, vendor enumor.Vendor,
+	req *protocloud.CvmBatchUpdateReq[T]) error {
+
 	if err := req.Validate(); err != nil {
-		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		return errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
 	ids := make([]string, 0, len(req.Cvms))
@@ -79,12 +87,12 @@ func batchUpdateCvm[T corecvm.Extension](cts *rest.Contexts, svc *cvmSvc, vendor
 		ids = append(ids, one.ID)
 	}
 	// TODO list extension and cloud id
-	existCvmMap, err := listCvmInfo(cts, svc, ids)
+	existCvmMap, err := listCvmInfo(kt, svc, ids)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+	_, err = svc.dao.Txn().AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
 		models := make([]*tablecvm.Table, 0, len(req.Cvms))
 
 		for _, one := range req.Cvms {
@@ -104,7 +112,7 @@ func batchUpdateCvm[T corecvm.Extension](cts *rest.Contexts, svc *cvmSvc, vendor
 				PublicIPv6Addresses:  one.PublicIPv6Addresses,
 				CloudLaunchedTime:    one.CloudLaunchedTime,
 				CloudExpiredTime:     one.CloudExpiredTime,
-				Reviser:              cts.Kit.User,
+				Reviser:              kt.User,
 			}
 
 			existCvm, exist := existCvmMap[one.ID]
@@ -120,8 +128,8 @@ func batchUpdateCvm[T corecvm.Extension](cts *rest.Contexts, svc *cvmSvc, vendor
 				update.Extension = tabletype.JsonField(merge)
 			}
 
-			if err := svc.dao.Cvm().UpdateByIDWithTx(cts.Kit, txn, one.ID, update); err != nil {
-				logs.Errorf("update cvm by id failed, err: %v, id: %s, rid: %s", err, one.ID, cts.Kit.Rid)
+			if err := svc.dao.Cvm().UpdateByIDWithTx(kt, txn, one.ID, update); err != nil {
+				logs.Errorf("update cvm by id failed, err: %v, id: %s, rid: %s", err, one.ID, kt.Rid)
 				return nil, fmt.Errorf("update cvm failed, err: %v", err)
 			}
 
@@ -151,22 +159,22 @@ func batchUpdateCvm[T corecvm.Extension](cts *rest.Contexts, svc *cvmSvc, vendor
 		}
 
 		// upsert cmdb cloud hosts
-		err = upsertCmdbHosts[T](svc, cts.Kit, vendor, models)
+		err = upsertCmdbHosts[T](svc, kt, vendor, models)
 		if err != nil {
-			logs.Errorf("upsert cmdb hosts failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			logs.Errorf("upsert cmdb hosts failed, err: %v, rid: %s", err, kt.Rid)
 			return nil, nil
 		}
 
 		return nil, nil
 	})
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return nil, nil
+	return nil
 }
 
-func listCvmInfo(cts *rest.Contexts, svc *cvmSvc, ids []string) (map[string]tablecvm.Table, error) {
+func listCvmInfo(kt *kit.Kit, svc *cvmSvc, ids []string) (map[string]tablecvm.Table, error) {
 	opt := &types.ListOption{
 		Filter: tools.ContainersExpression("id", ids),
 		Page: &core.BasePage{
@@ -174,7 +182,7 @@ func listCvmInfo(cts *rest.Contexts, svc *cvmSvc, ids []string) (map[string]tabl
 			Limit: core.DefaultMaxPageLimit,
 		},
 	}
-	list, err := svc.dao.Cvm().List(cts.Kit, opt)
+	list, err := svc.dao.Cvm().List(kt, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -229,3 +237,36 @@ func (svc *cvmSvc) BatchUpdateCvmCommonInfo(cts *rest.Contexts) (interface{}, er
 
 	return nil, nil
 }
+
+// BatchUpdateCvmOwnerInfo batch update cvm owner and labels.
+func (svc *cvmSvc) BatchUpdateCvmOwnerInfo(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocloud.CvmOwnerInfoBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.Cvms {
+			update := &tablecvm.Table{
+				Owner:   one.Owner,
+				Labels:  one.Labels,
+				Reviser: cts.Kit.User,
+			}
+			if err := svc.dao.Cvm().UpdateByIDWithTx(cts.Kit, txn, one.ID, update); err != nil {
+				logs.Errorf("update cvm owner info failed, err: %v, id: %s, rid: %s", err, one.ID, cts.Kit.Rid)
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
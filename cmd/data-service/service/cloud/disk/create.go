@@ -29,6 +29,7 @@ import (
 	"hcm/pkg/dal/dao/orm"
 	tablecloud "hcm/pkg/dal/table/cloud/disk"
 	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
 	"hcm/pkg/rest"
 	"hcm/pkg/tools/converter"
 	"hcm/pkg/tools/json"
@@ -72,37 +73,12 @@ func batchCreateDiskExt[T coredisk.Extension](
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	diskIDs, err := dSvc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
-		disks := make([]*tablecloud.DiskModel, len(*req))
-		for indx, diskReq := range *req {
-			extensionJson, err := json.MarshalToString(diskReq.Extension)
-			if err != nil {
-				return nil, errf.NewFromErr(errf.InvalidParameter, err)
-			}
-
-			bkBizID := diskReq.BkBizID
-			if bkBizID == 0 {
-				bkBizID = constant.UnassignedBiz
-			}
+	disks, err := toDiskModels(cts.Kit, vendor, *req)
+	if err != nil {
+		return nil, err
+	}
 
-			disks[indx] = &tablecloud.DiskModel{
-				Vendor:       string(vendor),
-				AccountID:    diskReq.AccountID,
-				CloudID:      diskReq.CloudID,
-				BkBizID:      bkBizID,
-				Name:         diskReq.Name,
-				Region:       diskReq.Region,
-				Zone:         diskReq.Zone,
-				DiskSize:     diskReq.DiskSize,
-				DiskType:     diskReq.DiskType,
-				Status:       diskReq.Status,
-				IsSystemDisk: converter.ValToPtr(diskReq.IsSystemDisk),
-				Memo:         diskReq.Memo,
-				Extension:    tabletype.JsonField(extensionJson),
-				Creator:      cts.Kit.User,
-				Reviser:      cts.Kit.User,
-			}
-		}
+	diskIDs, err := dSvc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
 		return dSvc.dao.Disk().BatchCreateWithTx(cts.Kit, txn, disks)
 	})
 	if err != nil {
@@ -111,3 +87,41 @@ func batchCreateDiskExt[T coredisk.Extension](
 
 	return &core.BatchCreateResult{IDs: diskIDs.([]string)}, nil
 }
+
+// toDiskModels 将创建请求转换为云盘表数据
+func toDiskModels[T coredisk.Extension](kt *kit.Kit, vendor enumor.Vendor, reqs []*dataproto.DiskExtCreateReq[T]) (
+	[]*tablecloud.DiskModel, error) {
+
+	disks := make([]*tablecloud.DiskModel, len(reqs))
+	for indx, diskReq := range reqs {
+		extensionJson, err := json.MarshalToString(diskReq.Extension)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		bkBizID := diskReq.BkBizID
+		if bkBizID == 0 {
+			bkBizID = constant.UnassignedBiz
+		}
+
+		disks[indx] = &tablecloud.DiskModel{
+			Vendor:       string(vendor),
+			AccountID:    diskReq.AccountID,
+			CloudID:      diskReq.CloudID,
+			BkBizID:      bkBizID,
+			Name:         diskReq.Name,
+			Region:       diskReq.Region,
+			Zone:         diskReq.Zone,
+			DiskSize:     diskReq.DiskSize,
+			DiskType:     diskReq.DiskType,
+			Status:       diskReq.Status,
+			IsSystemDisk: converter.ValToPtr(diskReq.IsSystemDisk),
+			Memo:         diskReq.Memo,
+			Extension:    tabletype.JsonField(extensionJson),
+			Creator:      kt.User,
+			Reviser:      kt.User,
+		}
+	}
+
+	return disks, nil
+}
@@ -37,6 +37,8 @@ func InitService(cap *capability.Capability) {
 
 	// 批量创建云盘(支持 extension 字段)
 	h.Add("BatchCreateDiskExt", http.MethodPost, "/vendors/{vendor}/disks/batch/create", svc.BatchCreateDiskExt)
+	// 批量删除、更新和创建云盘(支持 extension 字段)，用于同步时减少请求次数
+	h.Add("BatchUpsertDiskExt", http.MethodPost, "/vendors/{vendor}/disks/batch/upsert", svc.BatchUpsertDiskExt)
 	// 获取单个云盘
 	h.Add("RetrieveDiskExt", http.MethodGet, "/vendors/{vendor}/disks/{id}", svc.RetrieveDiskExt)
 	// 查询云盘列表 (不带 extension 字段)
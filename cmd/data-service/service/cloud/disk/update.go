@@ -32,6 +32,7 @@ import (
 	"hcm/pkg/dal/dao/types"
 	tablecloud "hcm/pkg/dal/table/cloud/disk"
 	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
 	"hcm/pkg/rest"
 	"hcm/pkg/runtime/filter"
 	"hcm/pkg/tools/json"
@@ -104,33 +105,7 @@ func batchUpdateDiskExt[T coredisk.Extension](cts *rest.Contexts,
 	}
 
 	_, err = dSvc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
-		for _, diskReq := range *req {
-			updateData := &tablecloud.DiskModel{
-				Name:         diskReq.Name,
-				Region:       diskReq.Region,
-				BkBizID:      int64(diskReq.BkBizID),
-				Status:       diskReq.Status,
-				IsSystemDisk: diskReq.IsSystemDisk,
-				Memo:         diskReq.Memo,
-			}
-
-			if diskReq.Extension != nil {
-				rawExtension, exist := rawExtensions[diskReq.ID]
-				if !exist {
-					return nil, fmt.Errorf("disk id (%s) not exit", diskReq.ID)
-				}
-				mergedExtension, err := json.UpdateMerge(diskReq.Extension, string(rawExtension))
-				if err != nil {
-					return nil, fmt.Errorf("disk id (%s) merge extension failed, err: %v", diskReq.ID, err)
-				}
-				updateData.Extension = tabletype.JsonField(mergedExtension)
-			}
-
-			if err := dSvc.dao.Disk().UpdateByIDWithTx(cts.Kit, txn, diskReq.ID, updateData); err != nil {
-				return nil, fmt.Errorf("update disk failed, err: %v", err)
-			}
-		}
-		return nil, nil
+		return nil, updateDiskExtWithTx(cts.Kit, dSvc, txn, *req, rawExtensions)
 	})
 	if err != nil {
 		return nil, err
@@ -139,6 +114,40 @@ func batchUpdateDiskExt[T coredisk.Extension](cts *rest.Contexts,
 	return nil, nil
 }
 
+// updateDiskExtWithTx 在事务中更新云盘，rawExtensions 为更新前的 extension 字段，用于合并更新
+func updateDiskExtWithTx[T coredisk.Extension](kt *kit.Kit, dSvc *diskSvc, txn *sqlx.Tx,
+	reqs []*dataproto.DiskExtUpdateReq[T], rawExtensions map[string]tabletype.JsonField) error {
+
+	for _, diskReq := range reqs {
+		updateData := &tablecloud.DiskModel{
+			Name:         diskReq.Name,
+			Region:       diskReq.Region,
+			BkBizID:      int64(diskReq.BkBizID),
+			Status:       diskReq.Status,
+			IsSystemDisk: diskReq.IsSystemDisk,
+			Memo:         diskReq.Memo,
+		}
+
+		if diskReq.Extension != nil {
+			rawExtension, exist := rawExtensions[diskReq.ID]
+			if !exist {
+				return fmt.Errorf("disk id (%s) not exit", diskReq.ID)
+			}
+			mergedExtension, err := json.UpdateMerge(diskReq.Extension, string(rawExtension))
+			if err != nil {
+				return fmt.Errorf("disk id (%s) merge extension failed, err: %v", diskReq.ID, err)
+			}
+			updateData.Extension = tabletype.JsonField(mergedExtension)
+		}
+
+		if err := dSvc.dao.Disk().UpdateByIDWithTx(kt, txn, diskReq.ID, updateData); err != nil {
+			return fmt.Errorf("update disk failed, err: %v", err)
+		}
+	}
+
+	return nil
+}
+
 // rawExtensions 根据条件查询原始的 extension 字段, 返回字典结构 {"云盘 ID": "原始的 extension 字段"}
 func (dSvc *diskSvc) rawExtensions(
 	cts *rest.Contexts,
@@ -0,0 +1,151 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disk
+
+import (
+	"hcm/pkg/api/core"
+	coredisk "hcm/pkg/api/core/cloud/disk"
+	dataproto "hcm/pkg/api/data-service/cloud/disk"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchUpsertDiskExt 在一个事务中批量删除、更新和创建云盘(支持 extension 字段)
+func (dSvc *diskSvc) BatchUpsertDiskExt(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.Request.PathParameter("vendor"))
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	switch vendor {
+	case enumor.TCloud:
+		return batchUpsertDiskExt[coredisk.TCloudExtension](cts, dSvc, vendor)
+	case enumor.Aws:
+		return batchUpsertDiskExt[coredisk.AwsExtension](cts, dSvc, vendor)
+	case enumor.Gcp:
+		return batchUpsertDiskExt[coredisk.GcpExtension](cts, dSvc, vendor)
+	case enumor.Azure:
+		return batchUpsertDiskExt[coredisk.AzureExtension](cts, dSvc, vendor)
+	case enumor.HuaWei:
+		return batchUpsertDiskExt[coredisk.HuaWeiExtension](cts, dSvc, vendor)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
+	}
+}
+
+func batchUpsertDiskExt[T coredisk.Extension](cts *rest.Contexts, dSvc *diskSvc, vendor enumor.Vendor) (
+	interface{}, error) {
+
+	req := new(dataproto.DiskExtBatchUpsertReq[T])
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	delIDs, err := dSvc.listDeleteDiskIDs(cts, vendor, req.AccountID, req.DeleteCloudIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	rawExtensions := make(map[string]tabletype.JsonField)
+	if len(req.Updates) != 0 {
+		updateIDs := make([]string, len(req.Updates))
+		for indx, diskReq := range req.Updates {
+			updateIDs[indx] = diskReq.ID
+		}
+		rawExtensions, err = dSvc.rawExtensions(cts, tools.ContainersExpression("id", updateIDs))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	disks, err := toDiskModels(cts.Kit, vendor, req.Creates)
+	if err != nil {
+		return nil, err
+	}
+
+	diskIDs, err := dSvc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if len(delIDs) != 0 {
+			if err := dSvc.dao.Disk().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", delIDs)); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := updateDiskExtWithTx(cts.Kit, dSvc, txn, req.Updates, rawExtensions); err != nil {
+			return nil, err
+		}
+
+		if len(disks) == 0 {
+			return []string{}, nil
+		}
+		return dSvc.dao.Disk().BatchCreateWithTx(cts.Kit, txn, disks)
+	})
+	if err != nil {
+		logs.Errorf("batch upsert disk failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.BatchCreateResult{IDs: diskIDs.([]string)}, nil
+}
+
+// listDeleteDiskIDs 查询账号下需要删除的云盘ID
+func (dSvc *diskSvc) listDeleteDiskIDs(cts *rest.Contexts, vendor enumor.Vendor, accountID string,
+	cloudIDs []string) ([]string, error) {
+
+	if len(cloudIDs) == 0 {
+		return nil, nil
+	}
+
+	opt := &types.ListOption{
+		Fields: []string{"id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+				filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: cloudIDs},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	listResp, err := dSvc.dao.Disk().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list disk to delete failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	delIDs := make([]string, len(listResp.Details))
+	for index, one := range listResp.Details {
+		delIDs[index] = one.ID
+	}
+
+	return delIDs, nil
+}
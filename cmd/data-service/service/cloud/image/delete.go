@@ -20,9 +20,16 @@
 package image
 
 import (
+	"fmt"
+
+	"hcm/cmd/data-service/service/cloud/logics"
+	"hcm/pkg/api/core"
 	dataproto "hcm/pkg/api/data-service/cloud/image"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 
 	"github.com/jmoiron/sqlx"
@@ -39,8 +46,38 @@ func (svc *imageSvc) BatchDeleteImage(cts *rest.Contexts) (interface{}, error) {
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
-		return nil, svc.dao.Image().DeleteWithTx(cts.Kit, txn, req.Filter)
+	opt := &types.ListOption{
+		Fields: []string{"id"},
+		Filter: req.Filter,
+		Page:   core.NewDefaultBasePage(),
+	}
+	listResp, err := svc.dao.Image().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list image failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list image failed, err: %v", err)
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, nil
+	}
+
+	delIDs := make([]string, len(listResp.Details))
+	for index, one := range listResp.Details {
+		delIDs[index] = one.ID
+	}
+
+	if !req.SkipInUseCheck {
+		blockers, err := logics.CheckImageInUse(cts.Kit, svc.dao, delIDs)
+		if err != nil {
+			return nil, err
+		}
+		if len(blockers) > 0 {
+			return logics.NewInUseError(blockers)
+		}
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.Image().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", delIDs))
 	})
 	if err != nil {
 		return nil, err
@@ -24,6 +24,8 @@ import (
 	"net/http"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/cache"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
@@ -36,12 +38,16 @@ func InitService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("BatchCreateImageExt", http.MethodPost, "/vendors/{vendor}/images/batch/create", pSvc.BatchCreateImageExt)
+	h.Add("BatchCreateImageExt", http.MethodPost, "/vendors/{vendor}/images/batch/create",
+		cache.Invalidating(enumor.ImageCloudResType, pSvc.BatchCreateImageExt))
 	h.Add("GetImageExt", http.MethodGet, "/vendors/{vendor}/images/{id}", pSvc.GetImageExt)
-	h.Add("ListImage", http.MethodPost, "/images/list", pSvc.ListImage)
-	h.Add("ListImageExt", http.MethodPost, "/vendors/{vendor}/images/list", pSvc.ListImageExt)
-	h.Add("BatchUpdateImageExt", http.MethodPatch, "/vendors/{vendor}/images", pSvc.BatchUpdateImageExt)
-	h.Add("BatchDeleteImage", http.MethodDelete, "/images/batch", pSvc.BatchDeleteImage)
+	h.Add("ListImage", http.MethodPost, "/images/list", cache.Cached(enumor.ImageCloudResType, pSvc.ListImage))
+	h.Add("ListImageExt", http.MethodPost, "/vendors/{vendor}/images/list",
+		cache.Cached(enumor.ImageCloudResType, pSvc.ListImageExt))
+	h.Add("BatchUpdateImageExt", http.MethodPatch, "/vendors/{vendor}/images",
+		cache.Invalidating(enumor.ImageCloudResType, pSvc.BatchUpdateImageExt))
+	h.Add("BatchDeleteImage", http.MethodDelete, "/images/batch",
+		cache.Invalidating(enumor.ImageCloudResType, pSvc.BatchDeleteImage))
 
 	h.Load(cap.WebService)
 }
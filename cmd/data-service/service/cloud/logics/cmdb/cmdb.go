@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"hcm/pkg/api/core/cloud/cvm"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/thirdparty/esb/cmdb"
@@ -139,35 +140,11 @@ func (c *CmdbLogics) DeleteCloudHostFromBiz(kt *kit.Kit, req *DeleteCloudHostFro
 	}
 
 	// get cmdb host ids
-	rules := make([]cmdb.Rule, 0)
-	for vendor, cloudIDs := range req.VendorCloudIDs {
-		rules = append(rules, &cmdb.CombinedRule{
-			Condition: "AND",
-			Rules: []cmdb.Rule{
-				&cmdb.AtomRule{
-					Field:    "bk_cloud_vendor",
-					Operator: cmdb.OperatorEqual,
-					Value:    cmdb.HcmCmdbVendorMap[vendor],
-				},
-				&cmdb.AtomRule{
-					Field:    "bk_cloud_inst_id",
-					Operator: cmdb.OperatorIn,
-					Value:    cloudIDs,
-				},
-			},
-		})
-	}
-
 	listParams := &cmdb.ListBizHostParams{
-		BizID:  req.BizID,
-		Fields: []string{"bk_host_id"},
-		Page:   cmdb.BasePage{Limit: 500},
-		HostPropertyFilter: &cmdb.QueryFilter{
-			Rule: &cmdb.CombinedRule{
-				Condition: "OR",
-				Rules:     rules,
-			},
-		},
+		BizID:              req.BizID,
+		Fields:             []string{"bk_host_id"},
+		Page:               cmdb.BasePage{Limit: 500},
+		HostPropertyFilter: cloudHostFilter(req.VendorCloudIDs),
 	}
 	hosts, err := c.client.ListBizHost(kt, listParams)
 	if err != nil {
@@ -195,3 +172,86 @@ func (c *CmdbLogics) DeleteCloudHostFromBiz(kt *kit.Kit, req *DeleteCloudHostFro
 
 	return nil
 }
+
+// ListCloudHostIDs list cmdb host ids of cloud hosts in biz, returns vendor -> cloud id -> cmdb host id. cloud host
+// that is not found in cmdb will not be returned.
+func (c *CmdbLogics) ListCloudHostIDs(kt *kit.Kit, bizID int64, vendorCloudIDs map[enumor.Vendor][]string) (
+	map[enumor.Vendor]map[string]int64, error) {
+
+	idLen := 0
+	for _, ids := range vendorCloudIDs {
+		idLen += len(ids)
+	}
+	if bizID <= 0 || idLen == 0 || idLen > 500 {
+		return nil, errf.New(errf.InvalidParameter, "biz id or list cmdb cloud ids length is invalid")
+	}
+
+	listParams := &cmdb.ListBizHostParams{
+		BizID:              bizID,
+		Fields:             []string{"bk_host_id", "bk_cloud_vendor", "bk_cloud_inst_id"},
+		Page:               cmdb.BasePage{Limit: 500},
+		HostPropertyFilter: cloudHostFilter(vendorCloudIDs),
+	}
+	hosts, err := c.client.ListBizHost(kt, listParams)
+	if err != nil {
+		return nil, err
+	}
+
+	hostIDMap := make(map[enumor.Vendor]map[string]int64)
+	for _, host := range hosts.Info {
+		vendor, exists := cmdb.CmdbHcmVendorMap[host.BkCloudVendor]
+		if !exists {
+			continue
+		}
+		if _, exists = hostIDMap[vendor]; !exists {
+			hostIDMap[vendor] = make(map[string]int64)
+		}
+		hostIDMap[vendor][host.BkCloudInstID] = host.BkHostID
+	}
+
+	return hostIDMap, nil
+}
+
+// TransferHostToModule transfer cmdb hosts to the module of the biz, the previous modules are replaced.
+func (c *CmdbLogics) TransferHostToModule(kt *kit.Kit, bizID int64, hostIDs []int64, moduleID int64) error {
+	if bizID <= 0 || moduleID <= 0 || len(hostIDs) == 0 {
+		return errf.New(errf.InvalidParameter, "biz id, module id and host ids are required")
+	}
+
+	params := &cmdb.TransferHostModuleParams{
+		BizID:       bizID,
+		HostIDs:     hostIDs,
+		ModuleIDs:   []int64{moduleID},
+		IsIncrement: false,
+	}
+	return c.client.TransferHostModule(kt, params)
+}
+
+// cloudHostFilter return the cmdb host filter of cloud hosts by vendor and cloud ids.
+func cloudHostFilter(vendorCloudIDs map[enumor.Vendor][]string) *cmdb.QueryFilter {
+	rules := make([]cmdb.Rule, 0)
+	for vendor, cloudIDs := range vendorCloudIDs {
+		rules = append(rules, &cmdb.CombinedRule{
+			Condition: "AND",
+			Rules: []cmdb.Rule{
+				&cmdb.AtomRule{
+					Field:    "bk_cloud_vendor",
+					Operator: cmdb.OperatorEqual,
+					Value:    cmdb.HcmCmdbVendorMap[vendor],
+				},
+				&cmdb.AtomRule{
+					Field:    "bk_cloud_inst_id",
+					Operator: cmdb.OperatorIn,
+					Value:    cloudIDs,
+				},
+			},
+		})
+	}
+
+	return &cmdb.QueryFilter{
+		Rule: &cmdb.CombinedRule{
+			Condition: "OR",
+			Rules:     rules,
+		},
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cmdb
+
+import (
+	"reflect"
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/thirdparty/esb/cmdb"
+)
+
+type fakeCmdbClient struct {
+	cmdb.Client
+	hosts       []cmdb.Host
+	listParams  *cmdb.ListBizHostParams
+	transferred *cmdb.TransferHostModuleParams
+}
+
+// ListBizHost ...
+func (f *fakeCmdbClient) ListBizHost(_ *kit.Kit, params *cmdb.ListBizHostParams) (*cmdb.ListBizHostResult, error) {
+	f.listParams = params
+	return &cmdb.ListBizHostResult{Count: int64(len(f.hosts)), Info: f.hosts}, nil
+}
+
+// TransferHostModule ...
+func (f *fakeCmdbClient) TransferHostModule(_ *kit.Kit, params *cmdb.TransferHostModuleParams) error {
+	f.transferred = params
+	return nil
+}
+
+func TestListCloudHostIDs(t *testing.T) {
+	client := &fakeCmdbClient{hosts: []cmdb.Host{
+		{BkHostID: 1, BkCloudVendor: cmdb.TCloudCloudVendor, BkCloudInstID: "ins-1"},
+		{BkHostID: 2, BkCloudVendor: cmdb.AwsCloudVendor, BkCloudInstID: "i-1"},
+		{BkHostID: 3, BkCloudVendor: "unknown", BkCloudInstID: "x-1"},
+	}}
+	logics := NewCmdbLogics(client)
+
+	vendorCloudIDs := map[enumor.Vendor][]string{enumor.TCloud: {"ins-1", "ins-2"}, enumor.Aws: {"i-1"}}
+	hostIDs, err := logics.ListCloudHostIDs(kit.New(), 100, vendorCloudIDs)
+	if err != nil {
+		t.Fatalf("list cloud host ids failed, err: %v", err)
+	}
+
+	// 未在cmdb中找到的主机和未知云厂商的主机不返回
+	expect := map[enumor.Vendor]map[string]int64{enumor.TCloud: {"ins-1": 1}, enumor.Aws: {"i-1": 2}}
+	if !reflect.DeepEqual(hostIDs, expect) {
+		t.Errorf("expect host ids %v, but got %v", expect, hostIDs)
+	}
+	rule, ok := client.listParams.HostPropertyFilter.Rule.(*cmdb.CombinedRule)
+	if client.listParams.BizID != 100 || !ok || len(rule.Rules) != 2 {
+		t.Errorf("hosts should be listed by vendor and cloud ids in biz, params: %+v", client.listParams)
+	}
+
+	if _, err = logics.ListCloudHostIDs(kit.New(), 0, vendorCloudIDs); err == nil {
+		t.Errorf("biz id is required")
+	}
+	if _, err = logics.ListCloudHostIDs(kit.New(), 100, map[enumor.Vendor][]string{}); err == nil {
+		t.Errorf("cloud ids are required")
+	}
+}
+
+func TestTransferHostToModule(t *testing.T) {
+	client := new(fakeCmdbClient)
+	logics := NewCmdbLogics(client)
+
+	if err := logics.TransferHostToModule(kit.New(), 100, []int64{1, 2}, 10); err != nil {
+		t.Fatalf("transfer host to module failed, err: %v", err)
+	}
+
+	// 主机转移到模块时覆盖原有模块
+	expect := &cmdb.TransferHostModuleParams{BizID: 100, HostIDs: []int64{1, 2}, ModuleIDs: []int64{10}}
+	if !reflect.DeepEqual(client.transferred, expect) {
+		t.Errorf("expect transfer params %+v, but got %+v", expect, client.transferred)
+	}
+
+	if err := logics.TransferHostToModule(kit.New(), 100, []int64{1}, 0); err == nil {
+		t.Errorf("module id is required")
+	}
+}
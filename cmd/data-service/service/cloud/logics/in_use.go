@@ -0,0 +1,154 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package logics
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// NewInUseError 返回资源正在被使用的错误，blockers 作为响应数据返回给调用方
+func NewInUseError(blockers []dataservice.InUseBlocker) (*dataservice.InUseResult, error) {
+	return &dataservice.InUseResult{Blockers: blockers},
+		errf.Newf(errf.ResourceInUse, "%d resources are in use, can not be deleted", len(blockers))
+}
+
+// CheckSubnetInUse 检查子网是否被主机使用，返回正在被使用的子网的阻塞项
+func CheckSubnetInUse(kt *kit.Kit, dao dao.Set, subnetIDs []string) ([]dataservice.InUseBlocker, error) {
+	refMap := make(map[string][]string)
+	for _, ids := range slice.Split(subnetIDs, int(core.DefaultMaxPageLimit)) {
+		opt := &types.ListOption{
+			Fields: []string{"id", "subnet_ids"},
+			Filter: tools.ExpressionAnd(tools.RuleJsonOverlaps("subnet_ids", ids)),
+			Page:   core.NewDefaultBasePage(),
+		}
+		for {
+			cvmResp, err := dao.Cvm().List(kt, opt)
+			if err != nil {
+				logs.Errorf("list cvm by subnet ids failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+				return nil, fmt.Errorf("list cvm by subnet ids failed, err: %v", err)
+			}
+
+			for _, cvm := range cvmResp.Details {
+				for _, subnetID := range cvm.SubnetIDs {
+					refMap[subnetID] = append(refMap[subnetID], cvm.ID)
+				}
+			}
+
+			if uint(len(cvmResp.Details)) < opt.Page.Limit {
+				break
+			}
+			opt.Page.Start += uint32(opt.Page.Limit)
+		}
+	}
+
+	return genInUseBlockers(enumor.SubnetCloudResType, subnetIDs, enumor.CvmCloudResType, refMap), nil
+}
+
+// CheckSecurityGroupInUse 检查安全组是否绑定了主机，返回正在被使用的安全组的阻塞项
+func CheckSecurityGroupInUse(kt *kit.Kit, dao dao.Set, sgIDs []string) ([]dataservice.InUseBlocker, error) {
+	refMap := make(map[string][]string)
+	for _, ids := range slice.Split(sgIDs, int(core.DefaultMaxPageLimit)) {
+		opt := &types.ListOption{
+			Filter: tools.ContainersExpression("security_group_id", ids),
+			Page:   core.NewDefaultBasePage(),
+		}
+		for {
+			relResp, err := dao.SGCvmRel().List(kt, opt)
+			if err != nil {
+				logs.Errorf("list security group cvm rel failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+				return nil, fmt.Errorf("list security group cvm rel failed, err: %v", err)
+			}
+
+			for _, rel := range relResp.Details {
+				refMap[rel.SecurityGroupID] = append(refMap[rel.SecurityGroupID], rel.CvmID)
+			}
+
+			if uint(len(relResp.Details)) < opt.Page.Limit {
+				break
+			}
+			opt.Page.Start += uint32(opt.Page.Limit)
+		}
+	}
+
+	return genInUseBlockers(enumor.SecurityGroupCloudResType, sgIDs, enumor.CvmCloudResType, refMap), nil
+}
+
+// CheckImageInUse 检查镜像是否被主机使用，返回正在被使用的镜像的阻塞项
+func CheckImageInUse(kt *kit.Kit, dao dao.Set, imageIDs []string) ([]dataservice.InUseBlocker, error) {
+	refMap := make(map[string][]string)
+	for _, ids := range slice.Split(imageIDs, int(core.DefaultMaxPageLimit)) {
+		opt := &types.ListOption{
+			Fields: []string{"id", "image_id"},
+			Filter: tools.ContainersExpression("image_id", ids),
+			Page:   core.NewDefaultBasePage(),
+		}
+		for {
+			cvmResp, err := dao.Cvm().List(kt, opt)
+			if err != nil {
+				logs.Errorf("list cvm by image ids failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+				return nil, fmt.Errorf("list cvm by image ids failed, err: %v", err)
+			}
+
+			for _, cvm := range cvmResp.Details {
+				refMap[cvm.ImageID] = append(refMap[cvm.ImageID], cvm.ID)
+			}
+
+			if uint(len(cvmResp.Details)) < opt.Page.Limit {
+				break
+			}
+			opt.Page.Start += uint32(opt.Page.Limit)
+		}
+	}
+
+	return genInUseBlockers(enumor.ImageCloudResType, imageIDs, enumor.CvmCloudResType, refMap), nil
+}
+
+// genInUseBlockers 根据资源ID与引用资源ID的映射生成阻塞项，只返回 ids 中被引用的资源
+func genInUseBlockers(resType enumor.CloudResourceType, ids []string, refResType enumor.CloudResourceType,
+	refMap map[string][]string) []dataservice.InUseBlocker {
+
+	blockers := make([]dataservice.InUseBlocker, 0)
+	for _, id := range ids {
+		refIDs, exist := refMap[id]
+		if !exist || len(refIDs) == 0 {
+			continue
+		}
+
+		blockers = append(blockers, dataservice.InUseBlocker{
+			ResType:    resType,
+			ResID:      id,
+			RefResType: refResType,
+			RefResIDs:  refIDs,
+		})
+	}
+
+	return blockers
+}
@@ -0,0 +1,147 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package logics
+
+import (
+	"reflect"
+	"testing"
+
+	dataservice "hcm/pkg/api/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/cloud/cvm"
+	sgcvmrel "hcm/pkg/dal/dao/cloud/security-group-cvm-rel"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table/cloud"
+	tablecvm "hcm/pkg/dal/table/cloud/cvm"
+	tabletypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+)
+
+type fakeDaoSet struct {
+	dao.Set
+	cvm   *fakeCvmDao
+	sgRel *fakeSGCvmRelDao
+}
+
+func (s *fakeDaoSet) Cvm() cvm.Interface {
+	return s.cvm
+}
+
+func (s *fakeDaoSet) SGCvmRel() sgcvmrel.Interface {
+	return s.sgRel
+}
+
+type fakeCvmDao struct {
+	cvm.Interface
+	cvms  []tablecvm.Table
+	calls int
+}
+
+// List 按分页返回主机，用于验证分页查询逻辑
+func (d *fakeCvmDao) List(_ *kit.Kit, opt *types.ListOption) (*types.ListCvmDetails, error) {
+	d.calls++
+	start := int(opt.Page.Start)
+	if start >= len(d.cvms) {
+		return &types.ListCvmDetails{}, nil
+	}
+	end := start + int(opt.Page.Limit)
+	if end > len(d.cvms) {
+		end = len(d.cvms)
+	}
+	return &types.ListCvmDetails{Details: d.cvms[start:end]}, nil
+}
+
+type fakeSGCvmRelDao struct {
+	sgcvmrel.Interface
+	rels []cloud.SecurityGroupCvmRelTable
+}
+
+func (d *fakeSGCvmRelDao) List(_ *kit.Kit, _ *types.ListOption) (*types.ListSecurityGroupCvmRelDetails, error) {
+	return &types.ListSecurityGroupCvmRelDetails{Details: d.rels}, nil
+}
+
+func TestCheckSubnetInUse(t *testing.T) {
+	// 超过一页的主机，验证会继续查询下一页
+	cvms := make([]tablecvm.Table, 0)
+	for i := 0; i < 500; i++ {
+		cvms = append(cvms, tablecvm.Table{ID: "cvm-x", SubnetIDs: tabletypes.StringArray{"subnet-other"}})
+	}
+	cvms = append(cvms, tablecvm.Table{ID: "cvm-1", SubnetIDs: tabletypes.StringArray{"subnet-1", "subnet-other"}})
+	set := &fakeDaoSet{cvm: &fakeCvmDao{cvms: cvms}}
+
+	blockers, err := CheckSubnetInUse(kit.New(), set, []string{"subnet-1", "subnet-2"})
+	if err != nil {
+		t.Fatalf("check subnet in use failed, err: %v", err)
+	}
+	if set.cvm.calls != 2 {
+		t.Errorf("expect list cvm 2 pages, but got %d", set.cvm.calls)
+	}
+	if len(blockers) != 1 {
+		t.Fatalf("only subnet in the request should be returned, but got %+v", blockers)
+	}
+	expect := dataservice.InUseBlocker{ResType: enumor.SubnetCloudResType, ResID: "subnet-1",
+		RefResType: enumor.CvmCloudResType, RefResIDs: []string{"cvm-1"}}
+	if !reflect.DeepEqual(blockers[0], expect) {
+		t.Errorf("expect blocker: %+v, but got %+v", expect, blockers[0])
+	}
+}
+
+func TestCheckSecurityGroupInUse(t *testing.T) {
+	set := &fakeDaoSet{sgRel: &fakeSGCvmRelDao{rels: []cloud.SecurityGroupCvmRelTable{
+		{SecurityGroupID: "sg-1", CvmID: "cvm-1"},
+		{SecurityGroupID: "sg-1", CvmID: "cvm-2"},
+	}}}
+
+	blockers, err := CheckSecurityGroupInUse(kit.New(), set, []string{"sg-1", "sg-2"})
+	if err != nil {
+		t.Fatalf("check security group in use failed, err: %v", err)
+	}
+	if len(blockers) != 1 || blockers[0].ResID != "sg-1" ||
+		!reflect.DeepEqual(blockers[0].RefResIDs, []string{"cvm-1", "cvm-2"}) {
+		t.Errorf("unexpected blockers: %+v", blockers)
+	}
+}
+
+func TestCheckImageInUse(t *testing.T) {
+	set := &fakeDaoSet{cvm: &fakeCvmDao{cvms: []tablecvm.Table{{ID: "cvm-1", ImageID: "image-2"}}}}
+
+	blockers, err := CheckImageInUse(kit.New(), set, []string{"image-1"})
+	if err != nil {
+		t.Fatalf("check image in use failed, err: %v", err)
+	}
+	if len(blockers) != 0 {
+		t.Errorf("unused image should not be blocked, but got %+v", blockers)
+	}
+}
+
+func TestNewInUseError(t *testing.T) {
+	result, err := NewInUseError([]dataservice.InUseBlocker{{ResID: "subnet-1"}})
+	if err == nil {
+		t.Fatalf("in use error should not be nil")
+	}
+	if ef := errf.Error(err); ef.Code != errf.ResourceInUse {
+		t.Errorf("expect code %d, but got %d", errf.ResourceInUse, ef.Code)
+	}
+	if len(result.Blockers) != 1 {
+		t.Errorf("blockers should be returned, but got %+v", result)
+	}
+}
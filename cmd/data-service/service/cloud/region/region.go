@@ -22,6 +22,7 @@ package region
 
 import (
 	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/cache"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao"
@@ -35,10 +36,14 @@ func InitRegionService(cap *capability.Capability) {
 	}
 
 	h := rest.NewHandler()
-	h.Add("BatchCreateRegion", "POST", "/vendors/{vendor}/regions/batch/create", svc.BatchCreateRegion)
-	h.Add("BatchUpdateRegion", "PATCH", "/vendors/{vendor}/regions/batch", svc.BatchUpdateRegion)
-	h.Add("ListRegion", "POST", "/vendors/{vendor}/regions/list", svc.ListRegion)
-	h.Add("BatchDeleteRegion", "DELETE", "/vendors/{vendor}/regions/batch", svc.BatchDeleteRegion)
+	h.Add("BatchCreateRegion", "POST", "/vendors/{vendor}/regions/batch/create",
+		cache.Invalidating(enumor.RegionCloudResType, svc.BatchCreateRegion))
+	h.Add("BatchUpdateRegion", "PATCH", "/vendors/{vendor}/regions/batch",
+		cache.Invalidating(enumor.RegionCloudResType, svc.BatchUpdateRegion))
+	h.Add("ListRegion", "POST", "/vendors/{vendor}/regions/list",
+		cache.Cached(enumor.RegionCloudResType, svc.ListRegion))
+	h.Add("BatchDeleteRegion", "DELETE", "/vendors/{vendor}/regions/batch",
+		cache.Invalidating(enumor.RegionCloudResType, svc.BatchDeleteRegion))
 
 	h.Load(cap.WebService)
 }
@@ -25,6 +25,7 @@ import (
 	"reflect"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/cmd/data-service/service/cloud/logics"
 	"hcm/pkg/api/core"
 	corecloud "hcm/pkg/api/core/cloud"
 	protocloud "hcm/pkg/api/data-service/cloud"
@@ -171,7 +172,7 @@ func (svc *securityGroupSvc) ListSecurityGroup(cts *rest.Contexts) (interface{},
 		})
 	}
 
-	return &protocloud.SecurityGroupListResult{Details: details}, nil
+	return &protocloud.SecurityGroupListResult{Count: result.Count, Details: details}, nil
 }
 
 // BatchDeleteSecurityGroup delete security group.
@@ -205,11 +206,18 @@ func (svc *securityGroupSvc) BatchDeleteSecurityGroup(cts *rest.Contexts) (inter
 		delIDs[index] = one.ID
 	}
 
-	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
-		if err := svc.deleteSecurityGroupRule(cts.Kit, txn, listResp.Details); err != nil {
+	if !req.SkipInUseCheck {
+		blockers, err := logics.CheckSecurityGroupInUse(cts.Kit, svc.dao, delIDs)
+		if err != nil {
 			return nil, err
 		}
+		if len(blockers) > 0 {
+			return logics.NewInUseError(blockers)
+		}
+	}
 
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		// security group rules and relations are cascade deleted by dao in the same transaction.
 		delFilter := tools.ContainersExpression("id", delIDs)
 		if err := svc.dao.SecurityGroup().DeleteWithTx(cts.Kit, txn, delFilter); err != nil {
 			return nil, err
@@ -225,40 +233,6 @@ func (svc *securityGroupSvc) BatchDeleteSecurityGroup(cts *rest.Contexts) (inter
 	return nil, nil
 }
 
-func (svc *securityGroupSvc) deleteSecurityGroupRule(kt *kit.Kit, txn *sqlx.Tx,
-	details []tablecloud.SecurityGroupTable) error {
-
-	vendorSGMap := make(map[enumor.Vendor][]string)
-	for _, one := range details {
-		if _, exist := vendorSGMap[one.Vendor]; !exist {
-			vendorSGMap[one.Vendor] = make([]string, 0)
-		}
-
-		vendorSGMap[one.Vendor] = append(vendorSGMap[one.Vendor], one.ID)
-	}
-
-	var err error
-	for vendor, sgIDs := range vendorSGMap {
-		switch vendor {
-		case enumor.TCloud:
-			err = svc.dao.TCloudSGRule().DeleteWithTx(kt, txn, tools.ContainersExpression("security_group_id", sgIDs))
-		case enumor.Aws:
-			err = svc.dao.AwsSGRule().DeleteWithTx(kt, txn, tools.ContainersExpression("security_group_id", sgIDs))
-		case enumor.HuaWei:
-			err = svc.dao.HuaWeiSGRule().DeleteWithTx(kt, txn, tools.ContainersExpression("security_group_id", sgIDs))
-		case enumor.Azure:
-			err = svc.dao.AzureSGRule().DeleteWithTx(kt, txn, tools.ContainersExpression("security_group_id", sgIDs))
-		default:
-			return fmt.Errorf("vendor: %s not support", vendor)
-		}
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 // GetSecurityGroup get security group detail.
 func (svc *securityGroupSvc) GetSecurityGroup(cts *rest.Contexts) (interface{}, error) {
 	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
@@ -468,7 +442,7 @@ func batchCreateSecurityGroup[T corecloud.SecurityGroupExtension](vendor enumor.
 
 		ids, err := svc.dao.SecurityGroup().BatchCreateWithTx(cts.Kit, txn, sgs)
 		if err != nil {
-			return nil, fmt.Errorf("create security group failed, err: %v", err)
+			return nil, fmt.Errorf("create security group failed, err: %w", err)
 		}
 
 		return ids, nil
@@ -24,9 +24,9 @@ import (
 	"reflect"
 
 	"hcm/cmd/data-service/service/capability"
+	"hcm/cmd/data-service/service/cloud/logics"
 	"hcm/pkg/api/core"
 	protocore "hcm/pkg/api/core/cloud"
-	dataservice "hcm/pkg/api/data-service"
 	protocloud "hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
@@ -413,7 +413,7 @@ func (svc *subnetSvc) ListSubnet(cts *rest.Contexts) (interface{}, error) {
 		details = append(details, converter.PtrToVal(convertBaseSubnet(&subnet)))
 	}
 
-	return &protocloud.SubnetListResult{Details: details}, nil
+	return &protocloud.SubnetListResult{Count: daoSubnetResp.Count, Details: details}, nil
 }
 
 func convertBaseSubnet(dbSubnet *tablecloud.SubnetTable) *protocore.BaseSubnet {
@@ -448,7 +448,7 @@ func convertBaseSubnet(dbSubnet *tablecloud.SubnetTable) *protocore.BaseSubnet {
 
 // BatchDeleteSubnet batch delete subnets.
 func (svc *subnetSvc) BatchDeleteSubnet(cts *rest.Contexts) (interface{}, error) {
-	req := new(dataservice.BatchDeleteReq)
+	req := new(protocloud.SubnetBatchDeleteReq)
 	if err := cts.DecodeInto(req); err != nil {
 		return nil, err
 	}
@@ -479,6 +479,16 @@ func (svc *subnetSvc) BatchDeleteSubnet(cts *rest.Contexts) (interface{}, error)
 		delSubnetIDs[index] = one.ID
 	}
 
+	if !req.SkipInUseCheck {
+		blockers, err := logics.CheckSubnetInUse(cts.Kit, svc.dao, delSubnetIDs)
+		if err != nil {
+			return nil, err
+		}
+		if len(blockers) > 0 {
+			return logics.NewInUseError(blockers)
+		}
+	}
+
 	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
 		delSubnetFilter := tools.ContainersExpression("id", delSubnetIDs)
 		if err := svc.dao.Subnet().BatchDeleteWithTx(cts.Kit, txn, delSubnetFilter); err != nil {
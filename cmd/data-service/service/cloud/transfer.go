@@ -0,0 +1,293 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"fmt"
+
+	"hcm/cmd/data-service/service/cloud/cvm"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/data-service/audit"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// transferResRecycleField 支持业务间转移的资源类型，value表示该资源是否有回收状态
+var transferResRecycleField = map[enumor.CloudResourceType]bool{
+	enumor.CvmCloudResType:              true,
+	enumor.DiskCloudResType:             true,
+	enumor.EipCloudResType:              true,
+	enumor.NetworkInterfaceCloudResType: false,
+}
+
+// PreviewTransferResourceBiz preview the resources to be transferred by TransferResourceBiz.
+func (svc cloudSvc) PreviewTransferResourceBiz(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocloud.TransferResourceBizReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.genTransferPlan(cts.Kit, req)
+}
+
+// TransferResourceBiz transfer resource and its dependent resource from source biz to target biz in one transaction.
+func (svc cloudSvc) TransferResourceBiz(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocloud.TransferResourceBizReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	plan, err := svc.genTransferPlan(cts.Kit, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(plan.Conflicts) != 0 {
+		return plan, errf.Newf(errf.InvalidParameter, "%d resources can not be transferred to biz(%d)",
+			len(plan.Conflicts), req.TargetBkBizID)
+	}
+
+	idsByType := make(map[enumor.CloudResourceType][]string)
+	for _, one := range plan.Resources {
+		idsByType[one.ResType] = append(idsByType[one.ResType], one.ID)
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		auditOpts := make([]audit.CloudResourceAssignInfo, 0, len(plan.Resources))
+		for resType, ids := range idsByType {
+			// 只转移仍属于原业务的资源，避免覆盖预览后被其他操作变更的资源
+			transferFilter := tools.ExpressionAnd(tools.RuleIn("id", ids),
+				tools.RuleEqual("bk_biz_id", req.SourceBkBizID))
+			err := svc.dao.Cloud().AssignResourceToBiz(cts.Kit, txn, resType, transferFilter, req.TargetBkBizID)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, id := range ids {
+				auditOpts = append(auditOpts, audit.CloudResourceAssignInfo{
+					ResType:         assignResAuditTypeMap[resType],
+					ResID:           id,
+					AssignedResType: enumor.TransferAssignedResType,
+					AssignedResID:   req.TargetBkBizID,
+				})
+			}
+		}
+
+		if err := svc.createAudit(cts.Kit, txn, auditOpts); err != nil {
+			return nil, err
+		}
+
+		if cvmIDs := idsByType[enumor.CvmCloudResType]; len(cvmIDs) != 0 {
+			if err := cvm.TransferCmdbHosts(cts.Kit, cvmIDs, req.SourceBkBizID, req.TargetBkBizID); err != nil {
+				logs.Errorf("transfer cmdb hosts failed, err: %v, ids: %v, source biz: %d, target biz: %d, rid: %s",
+					err, cvmIDs, req.SourceBkBizID, req.TargetBkBizID, cts.Kit.Rid)
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// genTransferPlan 生成转移计划，主机的硬盘、EIP、网络接口作为依赖资源一起转移，安全组可能被多个业务的资源共用，不随主机转移
+func (svc cloudSvc) genTransferPlan(kt *kit.Kit, req *protocloud.TransferResourceBizReq) (
+	*protocloud.AssignResourcePreviewResult, error) {
+
+	hasRecycle, exists := transferResRecycleField[req.ResType]
+	if !exists {
+		return nil, errf.Newf(errf.InvalidParameter, "resource type %s cannot be transferred", req.ResType)
+	}
+
+	fields := types.CommonBasicInfoFields
+	if hasRecycle {
+		fields = append([]string{"recycle_status"}, types.CommonBasicInfoFields...)
+	}
+	infos, err := svc.dao.Cloud().ListResourceBasicInfo(kt, req.ResType, req.IDs, fields...)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &protocloud.AssignResourcePreviewResult{
+		Resources: make([]protocloud.AssignResourceItem, 0),
+		Conflicts: make([]protocloud.AssignResourceConflict, 0),
+	}
+
+	infoMap := make(map[string]types.CloudResourceBasicInfo, len(infos))
+	for _, info := range infos {
+		infoMap[info.ID] = info
+	}
+
+	// 硬盘、EIP、网络接口绑定了主机时只能随主机一起转移
+	boundCvmMap := make(map[string]string)
+	if req.ResType != enumor.CvmCloudResType {
+		if boundCvmMap, err = svc.listBoundCvm(kt, req.ResType, req.IDs); err != nil {
+			return nil, err
+		}
+	}
+
+	cvmIDs := make([]string, 0)
+	for _, id := range req.IDs {
+		info, exists := infoMap[id]
+		reason := ""
+		switch {
+		case !exists:
+			reason = "resource not found"
+		case info.BkBizID != req.SourceBkBizID:
+			reason = fmt.Sprintf("resource belongs to biz(%d), not source biz", info.BkBizID)
+		case info.RecycleStatus == enumor.RecycleStatus:
+			reason = "resource is in recycle bin"
+		case len(boundCvmMap[id]) != 0:
+			reason = fmt.Sprintf("resource is bound to cvm(%s), please transfer the cvm", boundCvmMap[id])
+		}
+
+		if len(reason) != 0 {
+			plan.Conflicts = append(plan.Conflicts, protocloud.AssignResourceConflict{ResType: req.ResType,
+				ID: id, Reason: reason})
+			continue
+		}
+
+		plan.Resources = append(plan.Resources, protocloud.AssignResourceItem{ResType: req.ResType, ID: id,
+			AccountID: info.AccountID})
+		if req.ResType == enumor.CvmCloudResType {
+			cvmIDs = append(cvmIDs, id)
+		}
+	}
+
+	if len(cvmIDs) == 0 {
+		return plan, nil
+	}
+
+	if err = svc.fillCvmTransferDependentRes(kt, cvmIDs, req, plan); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// fillCvmTransferDependentRes 将主机依赖的资源加入转移计划，已属于目标业务的依赖资源无需转移，属于其他业务的依赖资源视为冲突
+func (svc cloudSvc) fillCvmTransferDependentRes(kt *kit.Kit, cvmIDs []string, req *protocloud.TransferResourceBizReq,
+	plan *protocloud.AssignResourcePreviewResult) error {
+
+	depMap, err := svc.listCvmDependentRes(kt, cvmIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, resType := range []enumor.CloudResourceType{enumor.DiskCloudResType, enumor.EipCloudResType,
+		enumor.NetworkInterfaceCloudResType} {
+
+		resCvmMap := depMap[resType]
+		if len(resCvmMap) == 0 {
+			continue
+		}
+
+		resIDs := make([]string, 0, len(resCvmMap))
+		for id := range resCvmMap {
+			resIDs = append(resIDs, id)
+		}
+
+		infos, err := svc.dao.Cloud().ListResourceBasicInfo(kt, resType, resIDs)
+		if err != nil {
+			return err
+		}
+
+		for _, info := range infos {
+			switch info.BkBizID {
+			case req.TargetBkBizID:
+				continue
+			case req.SourceBkBizID:
+				plan.Resources = append(plan.Resources, protocloud.AssignResourceItem{ResType: resType,
+					ID: info.ID, AccountID: info.AccountID, DependOnID: resCvmMap[info.ID]})
+			default:
+				plan.Conflicts = append(plan.Conflicts, protocloud.AssignResourceConflict{ResType: resType,
+					ID: info.ID, Reason: fmt.Sprintf("%s depended by cvm(%s) belongs to biz(%d), not source biz",
+						resType, resCvmMap[info.ID], info.BkBizID)})
+			}
+		}
+	}
+
+	return nil
+}
+
+// listBoundCvm 查询硬盘、EIP、网络接口绑定的主机，返回 资源ID -> 主机ID 的映射
+func (svc cloudSvc) listBoundCvm(kt *kit.Kit, resType enumor.CloudResourceType, ids []string) (
+	map[string]string, error) {
+
+	boundMap := make(map[string]string)
+	switch resType {
+	case enumor.DiskCloudResType:
+		opt := &types.ListOption{Filter: tools.ContainersExpression("disk_id", ids), Page: core.NewDefaultBasePage()}
+		rels, err := svc.dao.DiskCvmRel().List(kt, opt)
+		if err != nil {
+			logs.Errorf("list disk cvm rel failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+			return nil, err
+		}
+		for _, rel := range rels.Details {
+			boundMap[rel.DiskID] = rel.CvmID
+		}
+
+	case enumor.EipCloudResType:
+		opt := &types.ListOption{Filter: tools.ContainersExpression("eip_id", ids), Page: core.NewDefaultBasePage()}
+		rels, err := svc.dao.EipCvmRel().List(kt, opt)
+		if err != nil {
+			logs.Errorf("list eip cvm rel failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+			return nil, err
+		}
+		for _, rel := range rels.Details {
+			boundMap[rel.EipID] = rel.CvmID
+		}
+
+	case enumor.NetworkInterfaceCloudResType:
+		opt := &types.ListOption{Filter: tools.ContainersExpression("network_interface_id", ids),
+			Page: core.NewDefaultBasePage()}
+		rels, err := svc.dao.NiCvmRel().List(kt, opt)
+		if err != nil {
+			logs.Errorf("list network interface cvm rel failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+			return nil, err
+		}
+		for _, rel := range rels.Details {
+			boundMap[rel.NetworkInterfaceID] = rel.CvmID
+		}
+	}
+
+	return boundMap, nil
+}
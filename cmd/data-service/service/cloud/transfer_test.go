@@ -0,0 +1,94 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"testing"
+
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+)
+
+func TestGenTransferPlan(t *testing.T) {
+	const sourceBizID, targetBizID = 100, 200
+	cloud := &fakeCloud{infos: map[enumor.CloudResourceType][]types.CloudResourceBasicInfo{
+		enumor.CvmCloudResType: {
+			{ID: "cvm-1", AccountID: "account-1", BkBizID: sourceBizID},
+			{ID: "cvm-2", AccountID: "account-1", BkBizID: 300},
+		},
+		enumor.DiskCloudResType: {{ID: "disk-1", AccountID: "account-1", BkBizID: sourceBizID}},
+		enumor.EipCloudResType:  {{ID: "eip-1", AccountID: "account-1", BkBizID: targetBizID}},
+	}}
+	svc := cloudSvc{dao: fakeAssignSet{cloud: cloud}}
+
+	plan, err := svc.genTransferPlan(kit.New(), &protocloud.TransferResourceBizReq{SourceBkBizID: sourceBizID,
+		TargetBkBizID: targetBizID, ResType: enumor.CvmCloudResType, IDs: []string{"cvm-1", "cvm-2", "cvm-3"}})
+	if err != nil {
+		t.Fatalf("generate transfer plan failed, err: %v", err)
+	}
+
+	// 硬盘随主机转移，已在目标业务的EIP无需转移，安全组不随主机转移
+	expectRes := []protocloud.AssignResourceItem{
+		{ResType: enumor.CvmCloudResType, ID: "cvm-1", AccountID: "account-1"},
+		{ResType: enumor.DiskCloudResType, ID: "disk-1", AccountID: "account-1", DependOnID: "cvm-1"},
+	}
+	if len(plan.Resources) != len(expectRes) {
+		t.Fatalf("unexpected resources to transfer: %+v", plan.Resources)
+	}
+	for idx, one := range expectRes {
+		if plan.Resources[idx] != one {
+			t.Errorf("resource %d should be %+v, got: %+v", idx, one, plan.Resources[idx])
+		}
+	}
+
+	// 不属于原业务的主机和不存在的主机为冲突项
+	if len(plan.Conflicts) != 2 || plan.Conflicts[0].ID != "cvm-2" || plan.Conflicts[1].ID != "cvm-3" {
+		t.Errorf("unexpected conflicts: %+v", plan.Conflicts)
+	}
+}
+
+func TestGenTransferPlanBoundResource(t *testing.T) {
+	cloud := &fakeCloud{infos: map[enumor.CloudResourceType][]types.CloudResourceBasicInfo{
+		enumor.DiskCloudResType: {
+			{ID: "disk-1", AccountID: "account-1", BkBizID: 100},
+			{ID: "disk-2", AccountID: "account-1", BkBizID: 100, RecycleStatus: enumor.RecycleStatus},
+		},
+	}}
+	svc := cloudSvc{dao: fakeAssignSet{cloud: cloud}}
+
+	plan, err := svc.genTransferPlan(kit.New(), &protocloud.TransferResourceBizReq{SourceBkBizID: 100,
+		TargetBkBizID: 200, ResType: enumor.DiskCloudResType, IDs: []string{"disk-1", "disk-2"}})
+	if err != nil {
+		t.Fatalf("generate transfer plan failed, err: %v", err)
+	}
+
+	// 绑定了主机的硬盘只能随主机转移，回收站中的硬盘不能转移
+	if len(plan.Resources) != 0 || len(plan.Conflicts) != 2 {
+		t.Errorf("bound and recycled disks should be conflicts, plan: %+v", plan)
+	}
+
+	_, err = svc.genTransferPlan(kit.New(), &protocloud.TransferResourceBizReq{SourceBkBizID: 100,
+		TargetBkBizID: 200, ResType: enumor.SecurityGroupCloudResType, IDs: []string{"sg-1"}})
+	if err == nil {
+		t.Errorf("security group should not be transferred")
+	}
+}
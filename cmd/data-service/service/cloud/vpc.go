@@ -419,7 +419,7 @@ func (svc *vpcSvc) ListVpc(cts *rest.Contexts) (interface{}, error) {
 		details = append(details, converter.PtrToVal(convertBaseVpc(&vpc)))
 	}
 
-	return &protocloud.VpcListResult{Details: details}, nil
+	return &protocloud.VpcListResult{Count: daoVpcResp.Count, Details: details}, nil
 }
 
 func convertBaseVpc(dbVpc *tablecloud.VpcTable) *protocore.BaseVpc {
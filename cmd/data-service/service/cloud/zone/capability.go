@@ -0,0 +1,130 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package zone
+
+import (
+	"hcm/pkg/api/core"
+	corezone "hcm/pkg/api/core/cloud/zone"
+	protocloud "hcm/pkg/api/data-service/cloud/zone"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablezone "hcm/pkg/dal/table/cloud/zone"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ListZoneCapability list zone capability.
+func (svc *zoneSvc) ListZoneCapability(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.ZoneCapability().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list zone capability failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]corezone.ZoneCapability, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corezone.ZoneCapability{
+			ID:               one.ID,
+			Vendor:           one.Vendor,
+			Region:           one.Region,
+			Zone:             one.Zone,
+			InstanceFamilies: one.InstanceFamilies,
+			Features: slice.Map(one.Features, func(f string) enumor.ZoneFeature {
+				return enumor.ZoneFeature(f)
+			}),
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &core.ListResultT[corezone.ZoneCapability]{Count: result.Count, Details: details}, nil
+}
+
+// ReplaceZoneCapability replace all zone capabilities of the given regions, the capabilities are rebuilt from the
+// latest cloud catalog each time, so the old ones are deleted rather than updated.
+func (svc *zoneSvc) ReplaceZoneCapability(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocloud.ZoneCapabilityReplaceReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	models := make([]tablezone.ZoneCapabilityTable, 0, len(req.Capabilities))
+	for _, one := range req.Capabilities {
+		models = append(models, tablezone.ZoneCapabilityTable{
+			Vendor:           req.Vendor,
+			Region:           one.Region,
+			Zone:             one.Zone,
+			InstanceFamilies: tabletype.StringArray(slice.Unique(one.InstanceFamilies)),
+			Features: slice.Map(slice.Unique(one.Features), func(f enumor.ZoneFeature) string {
+				return string(f)
+			}),
+			Creator: cts.Kit.User,
+			Reviser: cts.Kit.User,
+		})
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		delFilter := tools.ExpressionAnd(tools.RuleEqual("vendor", req.Vendor), tools.RuleIn("region", req.Regions))
+		if err := svc.dao.ZoneCapability().DeleteWithTx(cts.Kit, txn, delFilter); err != nil {
+			return nil, err
+		}
+
+		if len(models) == 0 {
+			return nil, nil
+		}
+		return svc.dao.ZoneCapability().BatchCreateWithTx(cts.Kit, txn, models)
+	})
+	if err != nil {
+		logs.Errorf("replace zone capability failed, err: %v, vendor: %s, regions: %v, rid: %s", err, req.Vendor,
+			req.Regions, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
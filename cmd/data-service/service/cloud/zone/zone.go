@@ -28,6 +28,7 @@ import (
 	"hcm/pkg/api/core"
 	"hcm/pkg/api/core/cloud/zone"
 	protocloud "hcm/pkg/api/data-service/cloud/zone"
+	"hcm/pkg/cache"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao"
@@ -50,13 +51,19 @@ func InitZoneService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
-	h.Add("BatchCreateZone", http.MethodPost, "/vendors/{vendor}/zones/batch/create", svc.BatchCreateZone)
+	h.Add("BatchCreateZone", http.MethodPost, "/vendors/{vendor}/zones/batch/create",
+		cache.Invalidating(enumor.ZoneCloudResType, svc.BatchCreateZone))
 
-	h.Add("BatchUpdateZone", http.MethodPatch, "/vendors/{vendor}/zones/batch/update", svc.BatchUpdateZone)
+	h.Add("BatchUpdateZone", http.MethodPatch, "/vendors/{vendor}/zones/batch/update",
+		cache.Invalidating(enumor.ZoneCloudResType, svc.BatchUpdateZone))
 
-	h.Add("ListZone", http.MethodPost, "/zones/list", svc.ListZone)
+	h.Add("ListZone", http.MethodPost, "/zones/list", cache.Cached(enumor.ZoneCloudResType, svc.ListZone))
 
-	h.Add("BatchDeleteZone", http.MethodDelete, "/zones/batch", svc.BatchDeleteZone)
+	h.Add("BatchDeleteZone", http.MethodDelete, "/zones/batch",
+		cache.Invalidating(enumor.ZoneCloudResType, svc.BatchDeleteZone))
+
+	h.Add("ListZoneCapability", http.MethodPost, "/zone_capabilities/list", svc.ListZoneCapability)
+	h.Add("ReplaceZoneCapability", http.MethodPost, "/zone_capabilities/replace", svc.ReplaceZoneCapability)
 
 	h.Load(cap.WebService)
 }
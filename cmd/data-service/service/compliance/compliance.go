@@ -0,0 +1,387 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package compliance security compliance service
+package compliance
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	corecompliance "hcm/pkg/api/core/compliance"
+	datacompliance "hcm/pkg/api/data-service/compliance"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	daocompliance "hcm/pkg/dal/dao/compliance"
+	"hcm/pkg/dal/dao/types"
+	tablecompliance "hcm/pkg/dal/table/compliance"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+	"hcm/pkg/tools/times"
+)
+
+// InitService initial the service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("DetectCompliance", http.MethodPost, "/compliance/detect", svc.DetectCompliance)
+	h.Add("ListComplianceFinding", http.MethodPost, "/compliance/findings/list", svc.ListComplianceFinding)
+	h.Add("CreateComplianceExemption", http.MethodPost, "/compliance/exemptions/create",
+		svc.CreateComplianceExemption)
+	h.Add("ListComplianceExemption", http.MethodPost, "/compliance/exemptions/list", svc.ListComplianceExemption)
+	h.Add("BatchDeleteComplianceExemption", http.MethodDelete, "/compliance/exemptions/batch",
+		svc.BatchDeleteComplianceExemption)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
+
+// complianceRules 按顺序执行检测的规则
+var complianceRules = []enumor.ComplianceRule{enumor.ComplianceSGOpenAdminPort, enumor.ComplianceUnencryptedDisk}
+
+// DetectCompliance detect compliance findings of the enabled rules and sync the findings, findings of the disabled
+// rules are cleared.
+func (svc *service) DetectCompliance(cts *rest.Contexts) (interface{}, error) {
+	req := new(datacompliance.DetectReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	severities := make(map[enumor.ComplianceRule]enumor.ComplianceSeverity, len(req.Rules))
+	for _, one := range req.Rules {
+		severities[one.Rule] = one.Severity
+	}
+
+	result := &datacompliance.DetectResult{Counts: make(map[enumor.ComplianceRule]int, len(req.Rules))}
+	for _, rule := range complianceRules {
+		detected := make([]tablecompliance.FindingTable, 0)
+		if severity, enabled := severities[rule]; enabled {
+			candidates, err := svc.dao.ComplianceFinding().ListCandidates(cts.Kit, rule)
+			if err != nil {
+				logs.Errorf("list compliance candidates failed, err: %v, rule: %s, rid: %s", err, rule, cts.Kit.Rid)
+				return nil, err
+			}
+
+			detected, err = buildFindings(rule, severity, candidates, req.AdminPorts)
+			if err != nil {
+				return nil, err
+			}
+			result.Counts[rule] = len(detected)
+		}
+
+		if err := svc.dao.ComplianceFinding().SyncDetected(cts.Kit, rule, detected); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// buildFindings build findings from candidates, candidates of the same resource are merged into one finding.
+func buildFindings(rule enumor.ComplianceRule, severity enumor.ComplianceSeverity,
+	candidates []daocompliance.Candidate, adminPorts []int64) ([]tablecompliance.FindingTable, error) {
+
+	details := make(map[string]*corecompliance.FindingDetail)
+	resources := make([]daocompliance.Candidate, 0)
+	for _, one := range candidates {
+		detail := corecompliance.FindingDetail{}
+		if rule == enumor.ComplianceSGOpenAdminPort {
+			ports := matchAdminPorts(one, adminPorts)
+			if len(ports) == 0 {
+				continue
+			}
+			detail.RuleIDs = []string{one.RuleID}
+			detail.Ports = ports
+		}
+
+		exist, ok := details[one.ResID]
+		if !ok {
+			details[one.ResID] = &detail
+			resources = append(resources, one)
+			continue
+		}
+		exist.RuleIDs = append(exist.RuleIDs, detail.RuleIDs...)
+		exist.Ports = mergePorts(exist.Ports, detail.Ports)
+	}
+
+	findings := make([]tablecompliance.FindingTable, 0, len(resources))
+	for _, one := range resources {
+		detailJson, err := json.MarshalToString(details[one.ResID])
+		if err != nil {
+			return nil, err
+		}
+
+		findings = append(findings, tablecompliance.FindingTable{
+			Severity:  severity,
+			ResType:   enumor.ComplianceRuleResTypeMap[rule],
+			ResID:     one.ResID,
+			Vendor:    one.Vendor,
+			AccountID: one.AccountID,
+			BkBizID:   one.BkBizID,
+			Region:    one.Region,
+			ResName:   one.ResName,
+			Detail:    tabletype.JsonField(detailJson),
+		})
+	}
+
+	return findings, nil
+}
+
+// matchAdminPorts return the admin ports opened by the security group rule, only tcp rules or rules of all protocols
+// are checked.
+func matchAdminPorts(candidate daocompliance.Candidate, adminPorts []int64) []int64 {
+	protocol := strings.ToLower(strings.TrimSpace(candidate.Protocol))
+	allProtocol := protocol == "" || protocol == "all" || protocol == "-1" || protocol == "*"
+	if !allProtocol && protocol != "tcp" && protocol != "6" {
+		return nil
+	}
+
+	specs := append([]string{candidate.Port}, candidate.PortRanges...)
+	// 未指定端口时表示全部端口
+	if len(strings.TrimSpace(strings.Join(specs, ""))) == 0 {
+		return append([]int64{}, adminPorts...)
+	}
+
+	matched := make([]int64, 0)
+	for _, port := range adminPorts {
+		for _, spec := range specs {
+			if portSpecContains(spec, port) {
+				matched = append(matched, port)
+				break
+			}
+		}
+	}
+
+	return matched
+}
+
+// portSpecContains check if the port spec contains the port, port spec is like 22、20-30、22,3389、ALL、*,
+// empty port spec or -1 means all ports.
+func portSpecContains(spec string, port int64) bool {
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		switch strings.ToLower(item) {
+		case "all", "*", "-1", "-1--1", "0-65535", "1-65535":
+			return true
+		case "":
+			continue
+		}
+
+		start, end, found := strings.Cut(item, "-")
+		if !found {
+			end = start
+		}
+
+		from, err := strconv.ParseInt(start, 10, 64)
+		if err != nil {
+			continue
+		}
+		to, err := strconv.ParseInt(end, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		if from <= port && port <= to {
+			return true
+		}
+	}
+
+	return false
+}
+
+func mergePorts(ports []int64, others []int64) []int64 {
+	set := make(map[int64]struct{}, len(ports)+len(others))
+	for _, port := range append(ports, others...) {
+		set[port] = struct{}{}
+	}
+
+	merged := make([]int64, 0, len(set))
+	for port := range set {
+		merged = append(merged, port)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i] < merged[j] })
+
+	return merged
+}
+
+// ListComplianceFinding list compliance finding.
+func (svc *service) ListComplianceFinding(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.ComplianceFinding().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list compliance finding failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]corecompliance.Finding, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corecompliance.Finding{
+			ID:          one.ID,
+			Rule:        one.Rule,
+			Severity:    one.Severity,
+			ResType:     one.ResType,
+			ResID:       one.ResID,
+			Vendor:      one.Vendor,
+			AccountID:   one.AccountID,
+			BkBizID:     one.BkBizID,
+			Region:      one.Region,
+			ResName:     one.ResName,
+			Detail:      one.Detail,
+			ExemptionID: one.ExemptionID,
+			DetectedAt:  times.ConvStdTimeFormat(one.DetectedAt),
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &core.ListResultT[corecompliance.Finding]{Count: result.Count, Details: details}, nil
+}
+
+// CreateComplianceExemption create compliance exemption.
+func (svc *service) CreateComplianceExemption(cts *rest.Contexts) (interface{}, error) {
+	req := new(datacompliance.ExemptionCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablecompliance.ExemptionTable{
+		Rule:      req.Rule,
+		ResID:     req.ResID,
+		BkBizID:   req.BkBizID,
+		Reason:    req.Reason,
+		ExpiredAt: req.ExpiredAt,
+		Creator:   cts.Kit.User,
+		Reviser:   cts.Kit.User,
+	}
+	id, err := svc.dao.ComplianceExemption().Create(cts.Kit, model)
+	if err != nil {
+		if errf.IsDuplicated(err) {
+			return nil, errf.Newf(errf.RecordDuplicated, "%s of resource %s is already exempted", req.Rule,
+				req.ResID)
+		}
+		logs.Errorf("create compliance exemption failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// ListComplianceExemption list compliance exemption.
+func (svc *service) ListComplianceExemption(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.ComplianceExemption().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list compliance exemption failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]corecompliance.Exemption, 0, len(result.Details))
+	for _, one := range result.Details {
+		exemption := corecompliance.Exemption{
+			ID:      one.ID,
+			Rule:    one.Rule,
+			ResID:   one.ResID,
+			BkBizID: one.BkBizID,
+			Reason:  one.Reason,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		}
+		if one.ExpiredAt != nil {
+			exemption.ExpiredAt = times.ConvStdTimeFormat(*one.ExpiredAt)
+		}
+		details = append(details, exemption)
+	}
+
+	return &core.ListResultT[corecompliance.Exemption]{Count: result.Count, Details: details}, nil
+}
+
+// BatchDeleteComplianceExemption batch delete compliance exemption.
+func (svc *service) BatchDeleteComplianceExemption(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.dao.ComplianceExemption().DeleteByIDs(cts.Kit, req.IDs); err != nil {
+		logs.Errorf("delete compliance exemption failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
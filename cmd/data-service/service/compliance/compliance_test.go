@@ -0,0 +1,119 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package compliance
+
+import (
+	"reflect"
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+	daocompliance "hcm/pkg/dal/dao/compliance"
+)
+
+func TestPortSpecContains(t *testing.T) {
+	cases := []struct {
+		spec   string
+		port   int64
+		expect bool
+	}{
+		{spec: "22", port: 22, expect: true},
+		{spec: "20-30", port: 22, expect: true},
+		{spec: "80,3389", port: 3389, expect: true},
+		{spec: " 80 , 443 ", port: 443, expect: true},
+		{spec: "ALL", port: 22, expect: true},
+		{spec: "-1", port: 3389, expect: true},
+		{spec: "1-65535", port: 22, expect: true},
+		{spec: "80", port: 22},
+		{spec: "23-30", port: 22},
+		{spec: "invalid", port: 22},
+		{spec: "", port: 22},
+	}
+	for _, c := range cases {
+		if got := portSpecContains(c.spec, c.port); got != c.expect {
+			t.Errorf("port spec %q contains %d: expect %v, but got %v", c.spec, c.port, c.expect, got)
+		}
+	}
+}
+
+func TestMatchAdminPorts(t *testing.T) {
+	adminPorts := []int64{22, 3389}
+
+	cases := []struct {
+		name      string
+		candidate daocompliance.Candidate
+		expect    []int64
+	}{
+		{name: "tcp single port", candidate: daocompliance.Candidate{Protocol: "TCP", Port: "22"},
+			expect: []int64{22}},
+		{name: "all protocol range", candidate: daocompliance.Candidate{Protocol: "ALL", Port: "1-65535"},
+			expect: []int64{22, 3389}},
+		{name: "no port means all ports", candidate: daocompliance.Candidate{Protocol: "-1"},
+			expect: []int64{22, 3389}},
+		{name: "azure port ranges", candidate: daocompliance.Candidate{Protocol: "*", PortRanges: []string{"80",
+			"3389"}}, expect: []int64{3389}},
+		{name: "udp is not checked", candidate: daocompliance.Candidate{Protocol: "udp", Port: "22"}},
+		{name: "other port", candidate: daocompliance.Candidate{Protocol: "tcp", Port: "443"}, expect: []int64{}},
+	}
+	for _, c := range cases {
+		got := matchAdminPorts(c.candidate, adminPorts)
+		if len(got) == 0 && len(c.expect) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, c.expect) {
+			t.Errorf("%s: expect %v, but got %v", c.name, c.expect, got)
+		}
+	}
+}
+
+func TestBuildFindings(t *testing.T) {
+	candidates := []daocompliance.Candidate{
+		{ResID: "sg-1", Vendor: enumor.TCloud, RuleID: "rule-1", Protocol: "tcp", Port: "3389"},
+		{ResID: "sg-2", Vendor: enumor.TCloud, RuleID: "rule-2", Protocol: "tcp", Port: "80"},
+		{ResID: "sg-1", Vendor: enumor.TCloud, RuleID: "rule-3", Protocol: "tcp", Port: "22,3389"},
+	}
+
+	findings, err := buildFindings(enumor.ComplianceSGOpenAdminPort, enumor.ComplianceSeverityHigh, candidates,
+		[]int64{22, 3389})
+	if err != nil {
+		t.Fatalf("build findings failed, err: %v", err)
+	}
+
+	// 同一安全组的多条规则合并为一条检测结果，未开放管理端口的安全组不作为检测结果
+	if len(findings) != 1 {
+		t.Fatalf("expect 1 finding, but got %d", len(findings))
+	}
+	finding := findings[0]
+	if finding.ResID != "sg-1" || finding.ResType != enumor.SecurityGroupCloudResType ||
+		finding.Severity != enumor.ComplianceSeverityHigh {
+		t.Errorf("unexpected finding: %+v", finding)
+	}
+	if string(finding.Detail) != `{"rule_ids":["rule-1","rule-3"],"ports":[22,3389]}` {
+		t.Errorf("unexpected finding detail: %s", finding.Detail)
+	}
+
+	disks := []daocompliance.Candidate{{ResID: "disk-1"}, {ResID: "disk-2"}}
+	findings, err = buildFindings(enumor.ComplianceUnencryptedDisk, enumor.ComplianceSeverityLow, disks, nil)
+	if err != nil {
+		t.Fatalf("build findings failed, err: %v", err)
+	}
+	if len(findings) != 2 || findings[0].ResType != enumor.DiskCloudResType || string(findings[0].Detail) != `{}` {
+		t.Errorf("unexpected unencrypted disk findings: %+v", findings)
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consistency
+
+import (
+	"time"
+
+	"hcm/pkg/api/core"
+	dataconsistency "hcm/pkg/api/data-service/consistency"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/serviced"
+)
+
+// reportLimit 检查报告中每张表/每个关联关系最多返回的问题记录数
+const reportLimit = 100
+
+// CheckConsistency check data consistency and return the report.
+func (svc *service) CheckConsistency(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataconsistency.CheckReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return Check(cts.Kit, svc.dao, req.AutoFix)
+}
+
+// Check 检查重复的 (vendor, cloud_id) 记录、孤立的关联记录、资源与账号 vendor 不一致的记录，生成检查报告。
+// autoFix 为 true 时会删除孤立的关联记录，其余问题需要人工处理。
+func Check(kt *kit.Kit, dao dao.Set, autoFix bool) (*dataconsistency.Report, error) {
+	report := new(dataconsistency.Report)
+
+	duplicates, err := dao.Consistency().ListDuplicateCloudIDs(kt, reportLimit)
+	if err != nil {
+		return nil, err
+	}
+	report.Duplicates = duplicates
+
+	orphans, err := dao.Consistency().ListOrphanRelations(kt, reportLimit)
+	if err != nil {
+		return nil, err
+	}
+	report.OrphanRelations = orphans
+
+	mismatches, err := dao.Consistency().ListVendorMismatches(kt, reportLimit)
+	if err != nil {
+		return nil, err
+	}
+	report.VendorMismatches = mismatches
+
+	if autoFix && len(orphans) > 0 {
+		fixed, err := dao.Consistency().DeleteOrphanRelations(kt)
+		if err != nil {
+			return nil, err
+		}
+		report.FixedOrphanCount = fixed
+	}
+
+	return report, nil
+}
+
+// CheckTiming 定时检查数据一致性，只在主节点执行，检查报告输出到日志
+func CheckTiming(dao dao.Set, sd serviced.State, conf cc.ConsistencyCheck) {
+	for {
+		time.Sleep(time.Duration(conf.IntervalMin) * time.Minute)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+		tenantIDs, err := dao.Tenant().ListIDs(kt)
+		if err != nil {
+			logs.Errorf("list tenant ids failed, err: %v, rid: %s", err, kt.Rid)
+			continue
+		}
+
+		for _, tenantID := range tenantIDs {
+			checkTenant(kt.WithTenant(tenantID), dao, conf.AutoFix)
+		}
+	}
+}
+
+// checkTenant check the data consistency of the kit's tenant, each tenant is checked separately so that
+// the inconsistent data of one tenant will not be fixed or reported by the other tenant's check.
+func checkTenant(kt *kit.Kit, dao dao.Set, autoFix bool) {
+	report, err := Check(kt, dao, autoFix)
+	if err != nil {
+		logs.Errorf("check tenant %s data consistency failed, err: %v, rid: %s", kt.TenantID, err, kt.Rid)
+		return
+	}
+
+	if report.IsConsistent() {
+		logs.Infof("check tenant %s data consistency success, no inconsistent data found, rid: %s", kt.TenantID,
+			kt.Rid)
+		return
+	}
+
+	logs.Warnf("[consistency report] tenant: %s, duplicates: %+v, orphan relations: %+v, vendor mismatches: %+v, "+
+		"fixed orphan count: %d, rid: %s", kt.TenantID, report.Duplicates, report.OrphanRelations,
+		report.VendorMismatches, report.FixedOrphanCount, kt.Rid)
+}
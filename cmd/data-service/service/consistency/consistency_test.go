@@ -0,0 +1,103 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consistency
+
+import (
+	"testing"
+
+	"hcm/pkg/dal/dao"
+	daoconsistency "hcm/pkg/dal/dao/consistency"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/kit"
+)
+
+type fakeSet struct {
+	dao.Set
+	checker *fakeChecker
+}
+
+// Consistency ...
+func (f fakeSet) Consistency() daoconsistency.Interface {
+	return f.checker
+}
+
+// fakeChecker returns the prepared problems, and counts the orphan relation deletions.
+type fakeChecker struct {
+	orphans []daoconsistency.OrphanRelation
+	deleted int
+}
+
+// ListDuplicateCloudIDs ...
+func (f *fakeChecker) ListDuplicateCloudIDs(_ *kit.Kit, _ uint) ([]daoconsistency.DuplicateCloudID, error) {
+	return []daoconsistency.DuplicateCloudID{{Table: table.VpcTable, Vendor: "tcloud", CloudID: "vpc-1", Count: 2}},
+		nil
+}
+
+// ListOrphanRelations ...
+func (f *fakeChecker) ListOrphanRelations(_ *kit.Kit, limit uint) ([]daoconsistency.OrphanRelation, error) {
+	if limit != reportLimit {
+		return nil, nil
+	}
+	return f.orphans, nil
+}
+
+// DeleteOrphanRelations ...
+func (f *fakeChecker) DeleteOrphanRelations(_ *kit.Kit) (int64, error) {
+	f.deleted++
+	return int64(len(f.orphans)), nil
+}
+
+// ListVendorMismatches ...
+func (f *fakeChecker) ListVendorMismatches(_ *kit.Kit, _ uint) ([]daoconsistency.VendorMismatch, error) {
+	return nil, nil
+}
+
+func TestCheck(t *testing.T) {
+	checker := &fakeChecker{orphans: []daoconsistency.OrphanRelation{{ID: "rel-1", RefID: "sg-1"}}}
+	set := fakeSet{checker: checker}
+
+	report, err := Check(kit.New(), set, false)
+	if err != nil {
+		t.Fatalf("check consistency failed, err: %v", err)
+	}
+	if report.IsConsistent() || len(report.Duplicates) != 1 || len(report.OrphanRelations) != 1 {
+		t.Errorf("problems should be reported, got: %+v", report)
+	}
+	if checker.deleted != 0 || report.FixedOrphanCount != 0 {
+		t.Errorf("orphan relations should not be deleted without auto fix")
+	}
+
+	report, err = Check(kit.New(), set, true)
+	if err != nil {
+		t.Fatalf("check consistency failed, err: %v", err)
+	}
+	if checker.deleted != 1 || report.FixedOrphanCount != 1 {
+		t.Errorf("orphan relations should be deleted with auto fix, got: %+v", report)
+	}
+
+	// nothing to fix when there is no orphan relation.
+	checker.orphans = nil
+	if _, err = Check(kit.New(), set, true); err != nil {
+		t.Fatalf("check consistency failed, err: %v", err)
+	}
+	if checker.deleted != 1 {
+		t.Errorf("orphan relations should not be deleted when there is none")
+	}
+}
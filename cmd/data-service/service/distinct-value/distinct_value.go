@@ -0,0 +1,56 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package distinctvalue
+
+import (
+	datadistinct "hcm/pkg/api/data-service/distinct-value"
+	"hcm/pkg/criteria/errf"
+	daodistinct "hcm/pkg/dal/dao/distinct-value"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// ListDistinctValues list distinct values of the resource table field, which is used to drive dropdowns.
+func (svc *service) ListDistinctValues(cts *rest.Contexts) (interface{}, error) {
+	req := new(datadistinct.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		logs.Errorf("list distinct value decode request failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		logs.Errorf("list distinct value validate request failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &daodistinct.ListOption{
+		Table:  req.Table,
+		Field:  req.Field,
+		Filter: req.Filter,
+		Limit:  req.Limit,
+	}
+	values, err := svc.dao.DistinctValue().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list %s distinct %s failed, err: %v, rid: %s", req.Table, req.Field, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &datadistinct.ListResp{Details: values}, nil
+}
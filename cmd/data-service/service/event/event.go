@@ -0,0 +1,249 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package event resource change event service, the audit records are the durable event log, each audit record is an
+// event and its auto increment id is the event offset. integrators pull events of a topic (resource type) after their
+// committed offset and commit the offset after the events are handled, so that every event is delivered at least once.
+package event
+
+import (
+	"net/http"
+	"time"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/cmd/data-service/service/event/watcher"
+	"hcm/pkg/api/core"
+	coreevent "hcm/pkg/api/core/event"
+	dataevent "hcm/pkg/api/data-service/event"
+	"hcm/pkg/cdc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableaudit "hcm/pkg/dal/table/audit"
+	tableevent "hcm/pkg/dal/table/event"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// settleWindow 只返回创建时间早于该时间窗口的事件。审计记录的自增ID在插入时分配，提交顺序可能与ID顺序不一致，
+// 等待该时间窗口让较小ID所在的事务完成提交，避免消费方提交较大的位点后漏掉较小位点的事件
+const settleWindow = 5 * time.Second
+
+// InitService initial the event service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+	svc.watchHub = watcher.NewHub(svc.latestRevision, watchPollInterval)
+
+	h := rest.NewHandler()
+
+	h.Add("PullEvent", http.MethodPost, "/events/pull", svc.PullEvent)
+	h.Add("CommitEventOffset", http.MethodPost, "/events/offsets/commit", svc.CommitEventOffset)
+	h.Add("ListEventOffset", http.MethodPost, "/events/offsets/list", svc.ListEventOffset)
+	h.Add("WatchEvent", http.MethodPost, "/events/watch", svc.WatchEvent)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao      dao.Set
+	watchHub *watcher.Hub
+}
+
+// PullEvent pull events of the topic after the given offset or the consumer's committed offset.
+func (svc *service) PullEvent(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataevent.PullReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	start := uint64(0)
+	if req.Offset != nil {
+		start = *req.Offset
+	} else {
+		committed, err := svc.getCommittedOffset(cts.Kit, req)
+		if err != nil {
+			return nil, err
+		}
+		start = committed
+	}
+
+	events, nextOffset, err := svc.listEvents(cts.Kit, req.Topic, start, 0, req.Limit, req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dataevent.PullResult{Details: events, NextOffset: nextOffset}, nil
+}
+
+// listEvents list the settled events of the topic after the offset, returns the events and the offset of the last
+// event, the offset is the start offset if there is no new event. if end is not 0, only the events not after it are
+// listed, and the end is returned if all of them are listed, so that the caller can skip the scanned range.
+func (svc *service) listEvents(kt *kit.Kit, topic enumor.AuditResourceType, start, end uint64, limit uint,
+	expr *filter.Expression) ([]cdc.Event, uint64, error) {
+
+	if limit == 0 {
+		limit = dataevent.DefaultPullLimit
+	}
+
+	rules := []filter.RuleFactory{
+		tools.RuleEqual("res_type", topic),
+		tools.RuleGreaterThan("id", start),
+		tools.RuleLessThanEqual("created_at", time.Now().Add(-settleWindow).Format(constant.TimeStdFormat)),
+	}
+	if end != 0 {
+		rules = append(rules, tools.RuleLessThanEqual("id", end))
+	}
+	if expr != nil {
+		rules = append(rules, expr)
+	}
+	opt := &types.ListOption{
+		Filter: &filter.Expression{Op: filter.And, Rules: rules},
+		Page:   &core.BasePage{Start: 0, Limit: limit, Sort: "id", Order: core.Ascending},
+	}
+	result, err := svc.dao.Audit().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list audit as events failed, err: %v, topic: %s, offset: %d, rid: %s", err, topic, start, kt.Rid)
+		return nil, 0, err
+	}
+
+	events := toEvents(kt, result.Details)
+	nextOffset := start
+	if len(events) != 0 {
+		nextOffset = events[len(events)-1].Offset
+	}
+
+	if end != 0 && uint(len(result.Details)) < limit {
+		nextOffset = end
+	}
+
+	return events, nextOffset, nil
+}
+
+// toEvents convert the audits to change events.
+func toEvents(kt *kit.Kit, audits []tableaudit.AuditTable) []cdc.Event {
+	events := make([]cdc.Event, 0, len(audits))
+	for i := range audits {
+		one := &audits[i]
+		createdAt, err := time.Parse(constant.TimeStdFormat, string(one.CreatedAt))
+		if err != nil {
+			logs.Errorf("parse audit %d created at %s failed, err: %v, rid: %s", one.ID, one.CreatedAt, err, kt.Rid)
+		}
+		events = append(events, cdc.FromAudit(one, createdAt.Unix()))
+	}
+
+	return events
+}
+
+func (svc *service) getCommittedOffset(kt *kit.Kit, req *dataevent.PullReq) (uint64, error) {
+	opt := &types.ListOption{
+		Filter: tools.ExpressionAnd(tools.RuleEqual("consumer", req.Consumer), tools.RuleEqual("topic", req.Topic)),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dao.EventConsumerOffset().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list event consumer offset failed, err: %v, consumer: %s, topic: %s, rid: %s", err,
+			req.Consumer, req.Topic, kt.Rid)
+		return 0, err
+	}
+
+	if len(result.Details) == 0 {
+		return 0, nil
+	}
+
+	return result.Details[0].ConsumedOffset, nil
+}
+
+// CommitEventOffset commit the consumed offset of the consumer on the topic.
+func (svc *service) CommitEventOffset(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataevent.OffsetCommitReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tableevent.ConsumerOffsetTable{
+		Consumer:       req.Consumer,
+		Topic:          req.Topic,
+		ConsumedOffset: *req.Offset,
+		Creator:        cts.Kit.User,
+		Reviser:        cts.Kit.User,
+	}
+	if err := svc.dao.EventConsumerOffset().Upsert(cts.Kit, model); err != nil {
+		logs.Errorf("commit event offset failed, err: %v, consumer: %s, topic: %s, offset: %d, rid: %s", err,
+			req.Consumer, req.Topic, *req.Offset, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListEventOffset list the committed offsets of consumers.
+func (svc *service) ListEventOffset(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.EventConsumerOffset().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list event consumer offset failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]coreevent.ConsumerOffset, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, coreevent.ConsumerOffset{
+			ID:       one.ID,
+			Consumer: one.Consumer,
+			Topic:    one.Topic,
+			Offset:   one.ConsumedOffset,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &core.ListResultT[coreevent.ConsumerOffset]{Count: result.Count, Details: details}, nil
+}
@@ -0,0 +1,109 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package event
+
+import (
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/cc"
+	"hcm/pkg/cdc"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableevent "hcm/pkg/dal/table/event"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/serviced"
+)
+
+// NewProducer create the producer which sends the audits of all tenants as change events to the configured sink.
+func NewProducer(dao dao.Set, opt cc.ChangeEvent) (*cdc.Producer, error) {
+	return cdc.NewProducer(opt, &auditSource{dao: dao})
+}
+
+// ProduceTiming 定时将新的审计记录作为变更事件投递到 sink，只在主节点执行，投递失败时下个周期从已提交的位点重试
+func ProduceTiming(producer *cdc.Producer, sd serviced.State, interval time.Duration) {
+	for {
+		time.Sleep(interval)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+		count, err := producer.Produce(kt)
+		if err != nil {
+			logs.Errorf("produce change events failed, produced: %d, err: %v, rid: %s", count, err, kt.Rid)
+		}
+	}
+}
+
+// auditSource 以审计记录作为事件源，生产者的投递位点与集成方的消费位点一样记录在事件消费位点表中
+type auditSource struct {
+	dao dao.Set
+}
+
+// Committed returns the offset sent by the producer.
+func (s *auditSource) Committed(kt *kit.Kit) (uint64, error) {
+	opt := &types.ListOption{
+		Filter: tools.ExpressionAnd(tools.RuleEqual("consumer", cdc.ProducerConsumer),
+			tools.RuleEqual("topic", cdc.AllTopic)),
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := s.dao.EventConsumerOffset().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list event producer offset failed, err: %v, rid: %s", err, kt.Rid)
+		return 0, err
+	}
+
+	if len(result.Details) == 0 {
+		return 0, nil
+	}
+
+	return result.Details[0].ConsumedOffset, nil
+}
+
+// List the settled audits of all tenants after the offset as events.
+func (s *auditSource) List(kt *kit.Kit, after uint64, limit uint) ([]cdc.Event, error) {
+	audits, err := s.dao.Audit().ListAfter(kt, after, time.Now().Add(-settleWindow), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return toEvents(kt, audits), nil
+}
+
+// Commit the offset sent by the producer.
+func (s *auditSource) Commit(kt *kit.Kit, offset uint64) error {
+	model := &tableevent.ConsumerOffsetTable{
+		Consumer:       cdc.ProducerConsumer,
+		Topic:          cdc.AllTopic,
+		ConsumedOffset: offset,
+		Creator:        kt.User,
+		Reviser:        kt.User,
+	}
+	if err := s.dao.EventConsumerOffset().Upsert(kt, model); err != nil {
+		logs.Errorf("commit event producer offset %d failed, err: %v, rid: %s", offset, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package event
+
+import (
+	"time"
+
+	"hcm/cmd/data-service/service/event/watcher"
+	"hcm/pkg/api/core"
+	dataevent "hcm/pkg/api/data-service/event"
+	"hcm/pkg/cdc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// watchPollInterval 查询主题最新版本的间隔，同一租户的同一主题的所有监听请求共用一个轮询
+const watchPollInterval = time.Second
+
+// WatchEvent long poll the change events of the topic after the revision, the request returns as soon as there are
+// new events, or returns no event with the same revision after timeout. unlike pulling, the watcher keeps its revision
+// itself instead of committing offset, it is used to keep the external caches in sync without full re-lists.
+func (svc *service) WatchEvent(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataevent.WatchReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if req.Revision == nil {
+		revision, err := svc.latestRevision(cts.Kit, req.Topic)
+		if err != nil {
+			return nil, err
+		}
+		return &dataevent.WatchResult{Details: make([]cdc.Event, 0), Revision: revision}, nil
+	}
+
+	timeout := time.Duration(req.TimeoutSec) * time.Second
+	if timeout == 0 {
+		timeout = dataevent.DefaultWatchTimeoutSec * time.Second
+	}
+
+	list := func(start, end uint64) ([]cdc.Event, uint64, error) {
+		return svc.listEvents(cts.Kit, req.Topic, start, end, req.Limit, req.Filter)
+	}
+	events, revision, err := watcher.Watch(cts.Kit, svc.watchHub, req.Topic, *req.Revision, timeout, list)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dataevent.WatchResult{Details: events, Revision: revision}, nil
+}
+
+// latestRevision returns the offset of the latest settled event of the topic, the events after it are not settled
+// and will be returned by watch later.
+func (svc *service) latestRevision(kt *kit.Kit, topic enumor.AuditResourceType) (uint64, error) {
+	opt := &types.ListOption{
+		Fields: []string{"id"},
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("res_type", topic),
+			tools.RuleLessThanEqual("created_at", time.Now().Add(-settleWindow).Format(constant.TimeStdFormat)),
+		),
+		Page: &core.BasePage{Start: 0, Limit: 1, Sort: "id", Order: core.Descending},
+	}
+	result, err := svc.dao.Audit().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list latest audit of topic %s failed, err: %v, rid: %s", topic, err, kt.Rid)
+		return 0, err
+	}
+
+	if len(result.Details) == 0 {
+		return 0, nil
+	}
+
+	return result.Details[0].ID, nil
+}
@@ -0,0 +1,182 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+// Package watcher shares the polling of watch requests, there is one poll loop for each tenant and topic no matter
+// how many requests are watching it, the loop queries the latest settled revision of the topic and wakes up the
+// watchers when it changes, so that the watchers only query the events when there are new ones.
+package watcher
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// LatestFunc returns the latest settled revision of the topic.
+type LatestFunc func(kt *kit.Kit, topic enumor.AuditResourceType) (uint64, error)
+
+// ListFunc lists the events in the revision range (start, end], returns the events and the revision to continue
+// with. if there are less events than the limit, all the events in the range are returned and the revision is end,
+// so that the scanned range is skipped even if none of the events matches the filter of the watcher.
+type ListFunc[T any] func(start, end uint64) ([]T, uint64, error)
+
+// Hub manages the poll loops of the watched topics.
+type Hub struct {
+	latest   LatestFunc
+	interval time.Duration
+
+	mu      sync.Mutex
+	pollers map[pollerKey]*poller
+}
+
+type pollerKey struct {
+	tenantID string
+	topic    enumor.AuditResourceType
+}
+
+type poller struct {
+	// revision is the latest settled revision of the topic, it's valid only after the first poll is done.
+	revision uint64
+	polled   bool
+	// changed is closed when the revision changes, and replaced by a new channel for the next change.
+	changed  chan struct{}
+	watchers int
+}
+
+// NewHub new a watcher hub, the latest revision of each watched topic is polled at the interval.
+func NewHub(latest LatestFunc, interval time.Duration) *Hub {
+	return &Hub{
+		latest:   latest,
+		interval: interval,
+		pollers:  make(map[pollerKey]*poller),
+	}
+}
+
+// subscribe registers a watcher of the topic, the poll loop of the topic is started by its first watcher.
+func (h *Hub) subscribe(kt *kit.Kit, topic enumor.AuditResourceType) pollerKey {
+	key := pollerKey{tenantID: kt.GetTenantID(), topic: topic}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	p, exists := h.pollers[key]
+	if !exists {
+		p = &poller{changed: make(chan struct{})}
+		h.pollers[key] = p
+		go h.poll(key, p)
+	}
+	p.watchers++
+
+	return key
+}
+
+// unsubscribe removes a watcher of the topic, the poll loop stops after all the watchers are gone.
+func (h *Hub) unsubscribe(key pollerKey) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if p, exists := h.pollers[key]; exists {
+		p.watchers--
+	}
+}
+
+// revision returns the latest settled revision of the topic, and the channel closed when it changes.
+func (h *Hub) revision(key pollerKey) (uint64, bool, <-chan struct{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	p := h.pollers[key]
+	return p.revision, p.polled, p.changed
+}
+
+func (h *Hub) poll(key pollerKey, p *poller) {
+	kt := core.NewBackendKit()
+	kt.TenantID = key.tenantID
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		revision, err := h.latest(kt, key.topic)
+		if err != nil {
+			logs.Errorf("poll latest revision of topic %s failed, err: %v, tenant: %s, rid: %s", key.topic, err,
+				key.tenantID, kt.Rid)
+		}
+
+		h.mu.Lock()
+		if p.watchers <= 0 {
+			delete(h.pollers, key)
+			h.mu.Unlock()
+			return
+		}
+
+		if err == nil && (!p.polled || revision != p.revision) {
+			p.revision = revision
+			p.polled = true
+			close(p.changed)
+			p.changed = make(chan struct{})
+		}
+		h.mu.Unlock()
+
+		<-ticker.C
+	}
+}
+
+// Watch blocks until there are events of the topic after the revision, or the timeout is reached. it returns the
+// events and the revision to watch next, the revision is advanced past the scanned range even if none of the events
+// in it matches.
+func Watch[T any](kt *kit.Kit, h *Hub, topic enumor.AuditResourceType, revision uint64, timeout time.Duration,
+	list ListFunc[T]) ([]T, uint64, error) {
+
+	key := h.subscribe(kt, topic)
+	defer h.unsubscribe(key)
+
+	ctx, cancel := context.WithTimeout(kt.Ctx, timeout)
+	defer cancel()
+
+	for {
+		latest, polled, changed := h.revision(key)
+		if polled && latest > revision {
+			events, next, err := list(revision, latest)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			if len(events) != 0 {
+				return events, next, nil
+			}
+
+			// none of the events in the scanned range matches, continue with the events after the range.
+			revision = next
+		}
+
+		select {
+		case <-ctx.Done():
+			if kt.Ctx.Err() != nil {
+				return nil, 0, kt.Ctx.Err()
+			}
+			return make([]T, 0), revision, nil
+		case <-changed:
+		}
+	}
+}
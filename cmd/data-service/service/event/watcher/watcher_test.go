@@ -0,0 +1,138 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+package watcher
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTopic is a topic whose latest revision is changed by the test, and counts the polls.
+type fakeTopic struct {
+	latest atomic.Uint64
+	polls  atomic.Int64
+}
+
+func (f *fakeTopic) latestFunc(_ *kit.Kit, _ enumor.AuditResourceType) (uint64, error) {
+	f.polls.Add(1)
+	return f.latest.Load(), nil
+}
+
+func TestWatchSharedPoll(t *testing.T) {
+	topic := new(fakeTopic)
+	topic.latest.Store(10)
+	hub := NewHub(topic.latestFunc, 10*time.Millisecond)
+
+	var lists atomic.Int64
+	list := func(start, end uint64) ([]uint64, uint64, error) {
+		lists.Add(1)
+		events := make([]uint64, 0)
+		for id := start + 1; id <= end; id++ {
+			events = append(events, id)
+		}
+		return events, end, nil
+	}
+
+	// all the watchers of the topic are woken up by one poll loop when the revision changes.
+	wg := sync.WaitGroup{}
+	results := make([][]uint64, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			events, revision, err := Watch(kit.New(), hub, enumor.CvmAuditResType, 10, time.Second, list)
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(12), revision)
+			results[i] = events
+		}(i)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Zero(t, lists.Load(), "watchers should not list events before there are new ones")
+	topic.latest.Store(12)
+	wg.Wait()
+
+	for _, events := range results {
+		assert.Equal(t, []uint64{11, 12}, events)
+	}
+	assert.Equal(t, int64(len(results)), lists.Load())
+	// polls are shared, at most one poll for each interval no matter how many watchers there are.
+	assert.Less(t, topic.polls.Load(), int64(20))
+
+	// the poll loop stops after all the watchers are gone.
+	require.Eventually(t, func() bool {
+		hub.mu.Lock()
+		defer hub.mu.Unlock()
+		return len(hub.pollers) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestWatchAdvancePastScanned(t *testing.T) {
+	topic := new(fakeTopic)
+	topic.latest.Store(20)
+	hub := NewHub(topic.latestFunc, 10*time.Millisecond)
+
+	// none of the events matches the filter of the watcher.
+	ranges := make([][2]uint64, 0)
+	mu := sync.Mutex{}
+	list := func(start, end uint64) ([]uint64, uint64, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		ranges = append(ranges, [2]uint64{start, end})
+		return nil, end, nil
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		topic.latest.Store(25)
+	}()
+
+	events, revision, err := Watch(kit.New(), hub, enumor.CvmAuditResType, 10, 200*time.Millisecond, list)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+	// the revision is advanced past the scanned events, and each range is scanned only once.
+	assert.Equal(t, uint64(25), revision)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, [][2]uint64{{10, 20}, {20, 25}}, ranges)
+}
+
+func TestWatchCanceled(t *testing.T) {
+	topic := new(fakeTopic)
+	hub := NewHub(topic.latestFunc, 10*time.Millisecond)
+
+	kt := kit.New()
+	ctx, cancel := context.WithCancel(kt.Ctx)
+	kt.Ctx = ctx
+	cancel()
+	_, _, err := Watch(kt, hub, enumor.CvmAuditResType, 0, time.Second, func(start, end uint64) ([]uint64, uint64,
+		error) {
+		return nil, end, nil
+	})
+	assert.Error(t, err)
+}
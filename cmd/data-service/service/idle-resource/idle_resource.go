@@ -0,0 +1,236 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package idleresource idle resource service
+package idleresource
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	coreidle "hcm/pkg/api/core/idle-resource"
+	dataidle "hcm/pkg/api/data-service/idle-resource"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	daoidle "hcm/pkg/dal/dao/idle-resource"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableidle "hcm/pkg/dal/table/idle-resource"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+	"hcm/pkg/tools/times"
+
+	"github.com/shopspring/decimal"
+)
+
+// InitService initial the service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("DetectIdleResource", http.MethodPost, "/idle_resources/detect", svc.DetectIdleResource)
+	h.Add("ListIdleResource", http.MethodPost, "/idle_resources/list", svc.ListIdleResource)
+	h.Add("BatchUpdateIdleResourceStatus", http.MethodPatch, "/idle_resources/status/batch",
+		svc.BatchUpdateIdleResourceStatus)
+	h.Add("BatchDeleteIdleResource", http.MethodDelete, "/idle_resources/batch", svc.BatchDeleteIdleResource)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
+
+// idleReasons 按顺序执行检测的闲置原因
+var idleReasons = []enumor.IdleReason{enumor.IdleUnattachedDisk, enumor.IdleUnboundEip, enumor.IdleStoppedCvm,
+	enumor.IdleUnusedSecurityGroup}
+
+// DetectIdleResource detect idle resources of all reasons and sync the findings.
+func (svc *service) DetectIdleResource(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataidle.IdleDetectReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result := &dataidle.IdleDetectResult{Counts: make(map[enumor.IdleReason]int, len(idleReasons))}
+	opt := &daoidle.CandidateOption{StoppedCvmDays: req.StoppedCvmDays}
+	for _, reason := range idleReasons {
+		candidates, err := svc.dao.IdleResource().ListCandidates(cts.Kit, reason, opt)
+		if err != nil {
+			logs.Errorf("list idle resource candidates failed, err: %v, reason: %s, rid: %s", err, reason,
+				cts.Kit.Rid)
+			return nil, err
+		}
+
+		detected := make([]tableidle.IdleResourceTable, 0, len(candidates))
+		for _, one := range candidates {
+			model, err := buildIdleResource(reason, one, req)
+			if err != nil {
+				return nil, err
+			}
+			detected = append(detected, *model)
+		}
+
+		if err = svc.dao.IdleResource().SyncDetected(cts.Kit, reason, detected); err != nil {
+			return nil, err
+		}
+		result.Counts[reason] = len(detected)
+	}
+
+	return result, nil
+}
+
+// buildIdleResource build idle resource with detail and estimated monthly cost.
+func buildIdleResource(reason enumor.IdleReason, candidate daoidle.IdleCandidate, req *dataidle.IdleDetectReq) (
+	*tableidle.IdleResourceTable, error) {
+
+	detail := coreidle.IdleDetail{}
+	cost := decimal.Zero
+	switch reason {
+	case enumor.IdleUnattachedDisk:
+		detail.DiskSize = candidate.Size
+		cost = req.Price.DiskGBMonthly.Mul(decimal.NewFromInt(candidate.Size))
+	case enumor.IdleUnboundEip:
+		cost = req.Price.EipMonthly
+	case enumor.IdleStoppedCvm:
+		detail.CpuCore = candidate.Size
+		cost = req.Price.CvmCpuCoreMonthly.Mul(decimal.NewFromInt(candidate.Size))
+	}
+
+	detailJson, err := json.MarshalToString(detail)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tableidle.IdleResourceTable{
+		ResType:              enumor.IdleReasonResTypeMap[reason],
+		ResID:                candidate.ResID,
+		Vendor:               candidate.Vendor,
+		AccountID:            candidate.AccountID,
+		BkBizID:              candidate.BkBizID,
+		Region:               candidate.Region,
+		ResName:              candidate.ResName,
+		Detail:               tabletype.JsonField(detailJson),
+		EstimatedMonthlyCost: &tabletype.Decimal{Decimal: cost},
+		Currency:             req.Currency,
+	}, nil
+}
+
+// ListIdleResource list idle resource.
+func (svc *service) ListIdleResource(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.IdleResource().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list idle resource failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]coreidle.IdleResource, 0, len(result.Details))
+	for _, one := range result.Details {
+		idle := coreidle.IdleResource{
+			ID:         one.ID,
+			ResType:    one.ResType,
+			ResID:      one.ResID,
+			Vendor:     one.Vendor,
+			AccountID:  one.AccountID,
+			BkBizID:    one.BkBizID,
+			Region:     one.Region,
+			ResName:    one.ResName,
+			Reason:     one.Reason,
+			Detail:     one.Detail,
+			Currency:   one.Currency,
+			Status:     one.Status,
+			DetectedAt: times.ConvStdTimeFormat(one.DetectedAt),
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		}
+		if one.EstimatedMonthlyCost != nil {
+			idle.EstimatedMonthlyCost = one.EstimatedMonthlyCost.Decimal
+		}
+		details = append(details, idle)
+	}
+
+	return &core.ListResultT[coreidle.IdleResource]{Count: result.Count, Details: details}, nil
+}
+
+// BatchUpdateIdleResourceStatus batch update idle resource status.
+func (svc *service) BatchUpdateIdleResourceStatus(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataidle.IdleStatusUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.dao.IdleResource().UpdateStatus(cts.Kit, req.IDs, req.Status); err != nil {
+		logs.Errorf("update idle resource status failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchDeleteIdleResource batch delete idle resource.
+func (svc *service) BatchDeleteIdleResource(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.dao.IdleResource().Delete(cts.Kit, tools.ContainersExpression("id", req.IDs)); err != nil {
+		logs.Errorf("delete idle resource failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
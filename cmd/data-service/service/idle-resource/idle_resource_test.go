@@ -0,0 +1,76 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package idleresource
+
+import (
+	"testing"
+
+	dataidle "hcm/pkg/api/data-service/idle-resource"
+	"hcm/pkg/criteria/enumor"
+	daoidle "hcm/pkg/dal/dao/idle-resource"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestBuildIdleResource(t *testing.T) {
+	req := &dataidle.IdleDetectReq{
+		StoppedCvmDays: 30,
+		Currency:       enumor.CurrencyCNY,
+		Price: dataidle.IdlePrice{
+			DiskGBMonthly:     decimal.RequireFromString("0.5"),
+			EipMonthly:        decimal.NewFromInt(20),
+			CvmCpuCoreMonthly: decimal.NewFromInt(100),
+		},
+	}
+
+	cases := []struct {
+		reason       enumor.IdleReason
+		size         int64
+		expectType   enumor.CloudResourceType
+		expectDetail string
+		expectCost   string
+	}{
+		{reason: enumor.IdleUnattachedDisk, size: 100, expectType: enumor.DiskCloudResType,
+			expectDetail: `{"disk_size":100}`, expectCost: "50"},
+		{reason: enumor.IdleUnboundEip, expectType: enumor.EipCloudResType, expectDetail: `{}`, expectCost: "20"},
+		{reason: enumor.IdleStoppedCvm, size: 4, expectType: enumor.CvmCloudResType,
+			expectDetail: `{"cpu_core":4}`, expectCost: "400"},
+		{reason: enumor.IdleUnusedSecurityGroup, expectType: enumor.SecurityGroupCloudResType, expectDetail: `{}`,
+			expectCost: "0"},
+	}
+	for _, c := range cases {
+		candidate := daoidle.IdleCandidate{ResID: "res-1", Vendor: enumor.TCloud, BkBizID: 2, Size: c.size}
+		idle, err := buildIdleResource(c.reason, candidate, req)
+		if err != nil {
+			t.Fatalf("build %s idle resource failed, err: %v", c.reason, err)
+		}
+
+		if idle.ResType != c.expectType || idle.ResID != "res-1" || idle.BkBizID != 2 ||
+			idle.Currency != enumor.CurrencyCNY {
+			t.Errorf("%s: unexpected idle resource: %+v", c.reason, idle)
+		}
+		if string(idle.Detail) != c.expectDetail {
+			t.Errorf("%s: expect detail %s, but got %s", c.reason, c.expectDetail, idle.Detail)
+		}
+		if idle.EstimatedMonthlyCost.String() != c.expectCost {
+			t.Errorf("%s: expect cost %s, but got %s", c.reason, c.expectCost, idle.EstimatedMonthlyCost.String())
+		}
+	}
+}
@@ -0,0 +1,249 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package index
+
+import (
+	"strings"
+
+	dataindex "hcm/pkg/api/data-service/index"
+	"hcm/pkg/criteria/errf"
+	daoindex "hcm/pkg/dal/dao/index"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// AdviseIndexes report the slow filters and the missing indexes based on the captured query stats.
+func (svc *service) AdviseIndexes(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataindex.AdviseReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	minSlowCount := req.MinSlowCount
+	if minSlowCount == 0 {
+		minSlowCount = 1
+	}
+
+	result := &dataindex.AdviseResult{
+		SlowFilters:    make([]orm.QueryStat, 0),
+		MissingIndexes: make([]dataindex.Advice, 0),
+	}
+	adviceIdx := make(map[string]int)
+	tableIndexes := make(map[table.Name][]daoindex.Index)
+
+	for _, stat := range svc.dao.Index().QueryStats() {
+		if stat.SlowCount < minSlowCount {
+			continue
+		}
+		result.SlowFilters = append(result.SlowFilters, stat)
+
+		name := table.Name(stat.Table)
+		if len(stat.Columns) == 0 || name.Validate() != nil {
+			continue
+		}
+
+		indexes, exists := tableIndexes[name]
+		if !exists {
+			var err error
+			indexes, err = svc.dao.Index().ListIndexes(cts.Kit, name)
+			if err != nil {
+				return nil, err
+			}
+			tableIndexes[name] = indexes
+		}
+
+		if isFilterIndexed(indexes, stat.Columns) {
+			continue
+		}
+
+		columns := stat.Columns
+		if len(columns) > dataindex.MaxIndexColumns {
+			columns = columns[:dataindex.MaxIndexColumns]
+		}
+
+		key := stat.Table + "|" + strings.Join(columns, ",")
+		if idx, exists := adviceIdx[key]; exists {
+			result.MissingIndexes[idx].SlowCount += stat.SlowCount
+			continue
+		}
+
+		adviceIdx[key] = len(result.MissingIndexes)
+		result.MissingIndexes = append(result.MissingIndexes, dataindex.Advice{
+			Table:     name,
+			Columns:   columns,
+			SlowCount: stat.SlowCount,
+			Sample:    stat.Sample,
+		})
+	}
+
+	return result, nil
+}
+
+// isFilterIndexed 判断查询条件是否有可用的索引，索引的最左列在查询条件中即认为可用
+func isFilterIndexed(indexes []daoindex.Index, columns []string) bool {
+	columnMap := make(map[string]struct{}, len(columns))
+	for _, column := range columns {
+		columnMap[column] = struct{}{}
+	}
+
+	for _, index := range indexes {
+		if _, exists := columnMap[strings.ToLower(index.ColumnList()[0])]; exists {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ResetQueryStats clear the captured query stats, so that the stats after tuning can be observed.
+func (svc *service) ResetQueryStats(cts *rest.Contexts) (interface{}, error) {
+	svc.dao.Index().ResetQueryStats()
+	return nil, nil
+}
+
+// ListIndexes list indexes of the table.
+func (svc *service) ListIndexes(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataindex.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	indexes, err := svc.dao.Index().ListIndexes(cts.Kit, req.Table)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dataindex.ListResult{Details: indexes}, nil
+}
+
+// CreateIndex create secondary index on the table.
+func (svc *service) CreateIndex(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataindex.CreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.validateCreateIndex(cts.Kit, req); err != nil {
+		return nil, err
+	}
+
+	if err := svc.dao.Index().CreateIndex(cts.Kit, req.Table, req.Name, req.Columns); err != nil {
+		return nil, err
+	}
+
+	logs.Infof("create %s index %s success, columns: %v, operator: %s, rid: %s", req.Table, req.Name, req.Columns,
+		cts.Kit.User, cts.Kit.Rid)
+
+	return nil, nil
+}
+
+// validateCreateIndex 校验索引列在表中存在，且没有同名或相同列的索引
+func (svc *service) validateCreateIndex(kt *kit.Kit, req *dataindex.CreateReq) error {
+	columns, err := svc.dao.Index().ListColumns(kt, req.Table)
+	if err != nil {
+		return err
+	}
+
+	columnMap := make(map[string]struct{}, len(columns))
+	for _, column := range columns {
+		columnMap[strings.ToLower(column)] = struct{}{}
+	}
+
+	for _, column := range req.Columns {
+		if _, exists := columnMap[column]; !exists {
+			return errf.Newf(errf.InvalidParameter, "column %s not exists in table %s", column, req.Table)
+		}
+	}
+
+	indexes, err := svc.dao.Index().ListIndexes(kt, req.Table)
+	if err != nil {
+		return err
+	}
+
+	reqColumns := strings.Join(req.Columns, ",")
+	for _, index := range indexes {
+		if index.Name == req.Name {
+			return errf.Newf(errf.RecordDuplicated, "index %s already exists in table %s", req.Name, req.Table)
+		}
+
+		if strings.EqualFold(index.Columns, reqColumns) {
+			return errf.Newf(errf.RecordDuplicated, "index %s with the same columns %s already exists",
+				index.Name, index.Columns)
+		}
+	}
+
+	return nil
+}
+
+// DropIndex drop secondary index of the table, only the index created by index management api can be dropped.
+func (svc *service) DropIndex(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataindex.DropReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	indexes, err := svc.dao.Index().ListIndexes(cts.Kit, req.Table)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *daoindex.Index
+	for idx := range indexes {
+		if indexes[idx].Name == req.Name {
+			target = &indexes[idx]
+			break
+		}
+	}
+
+	if target == nil {
+		return nil, errf.Newf(errf.RecordNotFound, "index %s not found in table %s", req.Name, req.Table)
+	}
+
+	if !target.IsSecondary() {
+		return nil, errf.Newf(errf.InvalidParameter, "index %s is not secondary index", req.Name)
+	}
+
+	if err = svc.dao.Index().DropIndex(cts.Kit, req.Table, req.Name); err != nil {
+		return nil, err
+	}
+
+	logs.Infof("drop %s index %s success, operator: %s, rid: %s", req.Table, req.Name, cts.Kit.User, cts.Kit.Rid)
+
+	return nil, nil
+}
@@ -0,0 +1,212 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package landingzone landing zone service
+package landingzone
+
+import (
+	"fmt"
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	corelz "hcm/pkg/api/core/landing-zone"
+	datalz "hcm/pkg/api/data-service/landing-zone"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/types"
+	tablelz "hcm/pkg/dal/table/landing-zone"
+	tabletypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/json"
+)
+
+// InitService initial the service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateLandingZoneDeployment", http.MethodPost, "/landing_zone_deployments/create",
+		svc.CreateLandingZoneDeployment)
+	h.Add("UpdateLandingZoneDeployment", http.MethodPatch, "/landing_zone_deployments/{id}",
+		svc.UpdateLandingZoneDeployment)
+	h.Add("ListLandingZoneDeployment", http.MethodPost, "/landing_zone_deployments/list",
+		svc.ListLandingZoneDeployment)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
+
+// CreateLandingZoneDeployment create landing zone deployment, the deployment is created in deploying state.
+func (svc *service) CreateLandingZoneDeployment(cts *rest.Contexts) (interface{}, error) {
+	req := new(datalz.DeploymentCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	spec, err := json.MarshalToString(req.Spec)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablelz.DeploymentTable{
+		Name:      req.Name,
+		Vendor:    req.Vendor,
+		AccountID: req.AccountID,
+		BkBizID:   req.BkBizID,
+		Region:    req.Region,
+		Spec:      tabletypes.JsonField(spec),
+		Resources: tabletypes.JsonField("{}"),
+		State:     enumor.LandingZoneDeploying,
+		Memo:      req.Memo,
+		Creator:   cts.Kit.User,
+		Reviser:   cts.Kit.User,
+	}
+
+	id, err := svc.dao.LandingZoneDeployment().Create(cts.Kit, model)
+	if err != nil {
+		logs.Errorf("create landing zone deployment failed, err: %v, name: %s, rid: %s", err, req.Name, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// UpdateLandingZoneDeployment update landing zone deployment state, reason, flow id and created resources.
+func (svc *service) UpdateLandingZoneDeployment(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(datalz.DeploymentUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablelz.DeploymentTable{
+		State:   req.State,
+		Reason:  req.Reason,
+		FlowID:  req.FlowID,
+		Reviser: cts.Kit.User,
+	}
+	if req.Resources != nil {
+		resources, err := json.MarshalToString(req.Resources)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+		model.Resources = tabletypes.JsonField(resources)
+	}
+
+	if err := svc.dao.LandingZoneDeployment().UpdateByID(cts.Kit, id, model); err != nil {
+		logs.Errorf("update landing zone deployment failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListLandingZoneDeployment list landing zone deployment.
+func (svc *service) ListLandingZoneDeployment(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.LandingZoneDeployment().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list landing zone deployment failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]corelz.Deployment, 0, len(result.Details))
+	for _, one := range result.Details {
+		deployment, err := convDeployment(&one)
+		if err != nil {
+			logs.Errorf("convert landing zone deployment failed, err: %v, id: %s, rid: %s", err, one.ID, cts.Kit.Rid)
+			return nil, err
+		}
+		details = append(details, *deployment)
+	}
+
+	return &core.ListResultT[corelz.Deployment]{Count: result.Count, Details: details}, nil
+}
+
+func convDeployment(one *tablelz.DeploymentTable) (*corelz.Deployment, error) {
+	deployment := &corelz.Deployment{
+		ID:        one.ID,
+		Name:      one.Name,
+		Vendor:    one.Vendor,
+		AccountID: one.AccountID,
+		BkBizID:   one.BkBizID,
+		Region:    one.Region,
+		State:     one.State,
+		Reason:    converter.PtrToVal(one.Reason),
+		FlowID:    one.FlowID,
+		Memo:      one.Memo,
+		Revision: core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}
+
+	if len(one.Spec) != 0 {
+		deployment.Spec = new(corelz.DeploymentSpec)
+		if err := json.UnmarshalFromString(string(one.Spec), deployment.Spec); err != nil {
+			return nil, fmt.Errorf("unmarshal spec failed, err: %v", err)
+		}
+	}
+
+	if len(one.Resources) != 0 {
+		deployment.Resources = new(corelz.DeploymentResources)
+		if err := json.UnmarshalFromString(string(one.Resources), deployment.Resources); err != nil {
+			return nil, fmt.Errorf("unmarshal resources failed, err: %v", err)
+		}
+	}
+
+	return deployment, nil
+}
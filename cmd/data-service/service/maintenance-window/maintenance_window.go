@@ -0,0 +1,217 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package maintenancewindow maintenance window service
+package maintenancewindow
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	coremw "hcm/pkg/api/core/maintenance-window"
+	datamw "hcm/pkg/api/data-service/maintenance-window"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablemw "hcm/pkg/dal/table/maintenance-window"
+	tabletypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// InitService initial the service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateMaintenanceWindow", http.MethodPost, "/maintenance_windows/create", svc.CreateMaintenanceWindow)
+	h.Add("UpdateMaintenanceWindow", http.MethodPatch, "/maintenance_windows/{id}", svc.UpdateMaintenanceWindow)
+	h.Add("ListMaintenanceWindow", http.MethodPost, "/maintenance_windows/list", svc.ListMaintenanceWindow)
+	h.Add("BatchDeleteMaintenanceWindow", http.MethodDelete, "/maintenance_windows/batch",
+		svc.BatchDeleteMaintenanceWindow)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
+
+// CreateMaintenanceWindow create maintenance window.
+func (svc *service) CreateMaintenanceWindow(cts *rest.Contexts) (interface{}, error) {
+	req := new(datamw.MaintenanceWindowCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablemw.MaintenanceWindowTable{
+		BkBizID:   req.BkBizID,
+		Name:      req.Name,
+		Actions:   slice.Unique(actionsToStrings(req.Actions)),
+		Weekdays:  slice.Unique(req.Weekdays),
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Timezone:  req.Timezone,
+		Enabled:   converter.ValToPtr(req.Enabled),
+		Memo:      req.Memo,
+		Creator:   cts.Kit.User,
+		Reviser:   cts.Kit.User,
+	}
+
+	id, err := svc.dao.MaintenanceWindow().Create(cts.Kit, model)
+	if err != nil {
+		logs.Errorf("create maintenance window failed, err: %v, biz: %d, rid: %s", err, req.BkBizID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// UpdateMaintenanceWindow update maintenance window.
+func (svc *service) UpdateMaintenanceWindow(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(datamw.MaintenanceWindowUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablemw.MaintenanceWindowTable{
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Timezone:  req.Timezone,
+		Enabled:   req.Enabled,
+		Memo:      req.Memo,
+		Reviser:   cts.Kit.User,
+	}
+	if req.Name != nil {
+		model.Name = *req.Name
+	}
+	if len(req.Actions) != 0 {
+		model.Actions = slice.Unique(actionsToStrings(req.Actions))
+	}
+	if len(req.Weekdays) != 0 {
+		model.Weekdays = slice.Unique(req.Weekdays)
+	}
+
+	if err := svc.dao.MaintenanceWindow().UpdateByID(cts.Kit, id, model); err != nil {
+		logs.Errorf("update maintenance window failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func actionsToStrings(actions []enumor.MaintenanceAction) tabletypes.StringArray {
+	result := make(tabletypes.StringArray, 0, len(actions))
+	for _, action := range actions {
+		result = append(result, string(action))
+	}
+	return result
+}
+
+// ListMaintenanceWindow list maintenance window.
+func (svc *service) ListMaintenanceWindow(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.MaintenanceWindow().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list maintenance window failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]coremw.MaintenanceWindow, 0, len(result.Details))
+	for _, one := range result.Details {
+		actions := make([]enumor.MaintenanceAction, 0, len(one.Actions))
+		for _, action := range one.Actions {
+			actions = append(actions, enumor.MaintenanceAction(action))
+		}
+
+		details = append(details, coremw.MaintenanceWindow{
+			ID:        one.ID,
+			BkBizID:   one.BkBizID,
+			Name:      one.Name,
+			Actions:   actions,
+			Weekdays:  one.Weekdays,
+			StartTime: one.StartTime,
+			EndTime:   one.EndTime,
+			Timezone:  one.Timezone,
+			Enabled:   converter.PtrToVal(one.Enabled),
+			Memo:      one.Memo,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &core.ListResultT[coremw.MaintenanceWindow]{Count: result.Count, Details: details}, nil
+}
+
+// BatchDeleteMaintenanceWindow batch delete maintenance window.
+func (svc *service) BatchDeleteMaintenanceWindow(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.dao.MaintenanceWindow().Delete(cts.Kit, tools.ContainersExpression("id", req.IDs)); err != nil {
+		logs.Errorf("delete maintenance window failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
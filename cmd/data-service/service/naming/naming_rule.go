@@ -0,0 +1,189 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package naming resource naming rule service
+package naming
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	corenaming "hcm/pkg/api/core/naming"
+	datanaming "hcm/pkg/api/data-service/naming"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablenaming "hcm/pkg/dal/table/naming"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+)
+
+// InitService initial the service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateNamingRule", http.MethodPost, "/naming_rules/create", svc.CreateNamingRule)
+	h.Add("UpdateNamingRule", http.MethodPatch, "/naming_rules/{id}", svc.UpdateNamingRule)
+	h.Add("ListNamingRule", http.MethodPost, "/naming_rules/list", svc.ListNamingRule)
+	h.Add("BatchDeleteNamingRule", http.MethodDelete, "/naming_rules/batch", svc.BatchDeleteNamingRule)
+
+	h.Add("CheckNamingViolation", http.MethodPost, "/naming_violations/check", svc.CheckNamingViolation)
+	h.Add("ListNamingViolation", http.MethodPost, "/naming_violations/list", svc.ListNamingViolation)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
+
+// CreateNamingRule create naming rule.
+func (svc *service) CreateNamingRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(datanaming.NamingRuleCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablenaming.NamingRuleTable{
+		BkBizID: req.BkBizID,
+		ResType: req.ResType,
+		Prefix:  &req.Prefix,
+		Pattern: &req.Pattern,
+		Memo:    req.Memo,
+		Creator: cts.Kit.User,
+		Reviser: cts.Kit.User,
+	}
+
+	id, err := svc.dao.NamingRule().Create(cts.Kit, model)
+	if err != nil {
+		logs.Errorf("create naming rule failed, err: %v, biz: %d, res_type: %s, rid: %s", err, req.BkBizID,
+			req.ResType, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// UpdateNamingRule update naming rule.
+func (svc *service) UpdateNamingRule(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(datanaming.NamingRuleUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablenaming.NamingRuleTable{
+		Prefix:  req.Prefix,
+		Pattern: req.Pattern,
+		Memo:    req.Memo,
+		Reviser: cts.Kit.User,
+	}
+
+	if err := svc.dao.NamingRule().UpdateByID(cts.Kit, id, model); err != nil {
+		logs.Errorf("update naming rule failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListNamingRule list naming rule.
+func (svc *service) ListNamingRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.NamingRule().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list naming rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]corenaming.NamingRule, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, convNamingRule(one))
+	}
+
+	return &core.ListResultT[corenaming.NamingRule]{Count: result.Count, Details: details}, nil
+}
+
+func convNamingRule(one tablenaming.NamingRuleTable) corenaming.NamingRule {
+	return corenaming.NamingRule{
+		ID:      one.ID,
+		BkBizID: one.BkBizID,
+		ResType: one.ResType,
+		Prefix:  converter.PtrToVal(one.Prefix),
+		Pattern: converter.PtrToVal(one.Pattern),
+		Memo:    one.Memo,
+		Revision: core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}
+}
+
+// BatchDeleteNamingRule batch delete naming rule.
+func (svc *service) BatchDeleteNamingRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.dao.NamingRule().Delete(cts.Kit, tools.ContainersExpression("id", req.IDs)); err != nil {
+		logs.Errorf("delete naming rule failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,173 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package naming
+
+import (
+	"hcm/pkg/api/core"
+	corenaming "hcm/pkg/api/core/naming"
+	datanaming "hcm/pkg/api/data-service/naming"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablenaming "hcm/pkg/dal/table/naming"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/times"
+)
+
+// CheckNamingViolation check the names of account resources against the naming rules, and replace the naming
+// violations of the account with the detected ones.
+func (svc *service) CheckNamingViolation(cts *rest.Contexts) (interface{}, error) {
+	req := new(datanaming.NamingViolationCheckReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	rules, err := svc.listAllNamingRule(cts.Kit)
+	if err != nil {
+		return nil, err
+	}
+
+	resTypeRules := make(map[enumor.CloudResourceType][]corenaming.NamingRule)
+	for _, rule := range rules {
+		resTypeRules[rule.ResType] = append(resTypeRules[rule.ResType], rule)
+	}
+
+	violations := make([]tablenaming.NamingViolationTable, 0)
+	for resType, typeRules := range resTypeRules {
+		resources, err := svc.dao.NamingViolation().ListAccountResource(cts.Kit, req.AccountID, resType)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, res := range resources {
+			rule := corenaming.MatchRule(typeRules, res.BkBizID, resType)
+			if rule == nil {
+				continue
+			}
+
+			checkErr := rule.Check(res.Name)
+			if checkErr == nil {
+				continue
+			}
+
+			violations = append(violations, tablenaming.NamingViolationTable{
+				ResType: resType,
+				ResID:   res.ID,
+				Vendor:  res.Vendor,
+				BkBizID: res.BkBizID,
+				ResName: res.Name,
+				RuleID:  rule.ID,
+				Reason:  truncate(checkErr.Error(), 255),
+			})
+		}
+	}
+
+	if err = svc.dao.NamingViolation().SyncAccount(cts.Kit, req.AccountID, violations); err != nil {
+		return nil, err
+	}
+
+	return &datanaming.NamingViolationCheckResult{Count: len(violations)}, nil
+}
+
+// listAllNamingRule list all naming rules, the count of naming rules is limited by biz and resource type.
+func (svc *service) listAllNamingRule(kt *kit.Kit) ([]corenaming.NamingRule, error) {
+	opt := &types.ListOption{
+		Filter: tools.AllExpression(),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	rules := make([]corenaming.NamingRule, 0)
+	for {
+		result, err := svc.dao.NamingRule().List(kt, opt)
+		if err != nil {
+			logs.Errorf("list naming rule failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			rules = append(rules, convNamingRule(one))
+		}
+
+		if uint(len(result.Details)) < opt.Page.Limit {
+			break
+		}
+		opt.Page.Start += uint32(opt.Page.Limit)
+	}
+
+	return rules, nil
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
+
+// ListNamingViolation list naming violation.
+func (svc *service) ListNamingViolation(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.NamingViolation().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list naming violation failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]corenaming.NamingViolation, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corenaming.NamingViolation{
+			ID:         one.ID,
+			ResType:    one.ResType,
+			ResID:      one.ResID,
+			Vendor:     one.Vendor,
+			AccountID:  one.AccountID,
+			BkBizID:    one.BkBizID,
+			ResName:    one.ResName,
+			RuleID:     one.RuleID,
+			Reason:     one.Reason,
+			DetectedAt: times.ConvStdTimeFormat(one.DetectedAt),
+			CreatedAt:  one.CreatedAt.String(),
+			UpdatedAt:  one.UpdatedAt.String(),
+		})
+	}
+
+	return &core.ListResultT[corenaming.NamingViolation]{Count: result.Count, Details: details}, nil
+}
@@ -0,0 +1,493 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package notification notification template and subscription service
+package notification
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	corenotification "hcm/pkg/api/core/notification"
+	datanotification "hcm/pkg/api/data-service/notification"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablenotification "hcm/pkg/dal/table/notification"
+	tabletypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InitService initial the service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateNotificationTemplate", http.MethodPost, "/notifications/templates/create",
+		svc.CreateNotificationTemplate)
+	h.Add("UpdateNotificationTemplate", http.MethodPatch, "/notifications/templates/{id}",
+		svc.UpdateNotificationTemplate)
+	h.Add("ListNotificationTemplate", http.MethodPost, "/notifications/templates/list", svc.ListNotificationTemplate)
+	h.Add("BatchDeleteNotificationTemplate", http.MethodDelete, "/notifications/templates/batch",
+		svc.BatchDeleteNotificationTemplate)
+
+	h.Add("CreateNotificationSubscription", http.MethodPost, "/notifications/subscriptions/create",
+		svc.CreateNotificationSubscription)
+	h.Add("UpdateNotificationSubscription", http.MethodPatch, "/notifications/subscriptions/{id}",
+		svc.UpdateNotificationSubscription)
+	h.Add("ListNotificationSubscription", http.MethodPost, "/notifications/subscriptions/list",
+		svc.ListNotificationSubscription)
+	h.Add("BatchDeleteNotificationSubscription", http.MethodDelete, "/notifications/subscriptions/batch",
+		svc.BatchDeleteNotificationSubscription)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
+
+// CreateNotificationTemplate create notification template.
+func (svc *service) CreateNotificationTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(datanotification.TemplateCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablenotification.TemplateTable{
+		Name:      req.Name,
+		EventType: req.EventType,
+		Channel:   req.Channel,
+		Title:     req.Title,
+		Content:   req.Content,
+		Memo:      req.Memo,
+		Creator:   cts.Kit.User,
+		Reviser:   cts.Kit.User,
+	}
+
+	id, err := svc.dao.NotificationTemplate().Create(cts.Kit, model)
+	if err != nil {
+		logs.Errorf("create notification template failed, err: %v, name: %s, rid: %s", err, req.Name, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// UpdateNotificationTemplate update notification template.
+func (svc *service) UpdateNotificationTemplate(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(datanotification.TemplateUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablenotification.TemplateTable{
+		Memo:    req.Memo,
+		Reviser: cts.Kit.User,
+	}
+	if req.Name != nil {
+		model.Name = *req.Name
+	}
+	if req.Title != nil {
+		model.Title = *req.Title
+	}
+	if req.Content != nil {
+		model.Content = *req.Content
+	}
+
+	if err := svc.dao.NotificationTemplate().UpdateByID(cts.Kit, id, model); err != nil {
+		logs.Errorf("update notification template failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListNotificationTemplate list notification template.
+func (svc *service) ListNotificationTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.NotificationTemplate().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list notification template failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]corenotification.Template, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corenotification.Template{
+			ID:        one.ID,
+			Name:      one.Name,
+			EventType: one.EventType,
+			Channel:   one.Channel,
+			Title:     one.Title,
+			Content:   one.Content,
+			Memo:      one.Memo,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &core.ListResultT[corenotification.Template]{Count: result.Count, Details: details}, nil
+}
+
+// BatchDeleteNotificationTemplate batch delete notification template.
+func (svc *service) BatchDeleteNotificationTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 被订阅引用的模板不允许删除，避免订阅静默回退到默认模板
+	for _, id := range req.IDs {
+		opt := &types.ListOption{
+			Filter: tools.ExpressionAnd(tools.RuleJSONContains("template_ids", id)),
+			Page:   core.NewCountPage(),
+		}
+		result, err := svc.dao.NotificationSubscription().List(cts.Kit, opt)
+		if err != nil {
+			logs.Errorf("count subscription by template failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+			return nil, err
+		}
+		if result.Count > 0 {
+			return nil, errf.Newf(errf.InvalidParameter, "template %s is used by %d subscriptions", id, result.Count)
+		}
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.NotificationTemplate().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", req.IDs))
+	})
+	if err != nil {
+		logs.Errorf("delete notification template failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// CreateNotificationSubscription create notification subscription.
+func (svc *service) CreateNotificationSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(datanotification.SubscriptionCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.validateTemplates(cts.Kit, req.EventType, req.TemplateIDs); err != nil {
+		return nil, err
+	}
+
+	model := &tablenotification.SubscriptionTable{
+		Name:          req.Name,
+		EventType:     req.EventType,
+		Channels:      channelsToArray(req.Channels),
+		Receivers:     req.Receivers,
+		NotifyRelated: converter.ValToPtr(req.NotifyRelated),
+		WebhookUrl:    req.WebhookUrl,
+		TemplateIDs:   req.TemplateIDs,
+		AccountIDs:    req.AccountIDs,
+		BkBizIDs:      req.BkBizIDs,
+		Enabled:       req.Enabled,
+		Memo:          req.Memo,
+		Creator:       cts.Kit.User,
+		Reviser:       cts.Kit.User,
+	}
+
+	id, err := svc.dao.NotificationSubscription().Create(cts.Kit, model)
+	if err != nil {
+		logs.Errorf("create notification subscription failed, err: %v, name: %s, rid: %s", err, req.Name,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// UpdateNotificationSubscription update notification subscription.
+func (svc *service) UpdateNotificationSubscription(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(datanotification.SubscriptionUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dao.NotificationSubscription().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("get notification subscription failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "notification subscription: %s not found", id)
+	}
+	origin := result.Details[0]
+
+	model := &tablenotification.SubscriptionTable{
+		NotifyRelated: req.NotifyRelated,
+		Enabled:       req.Enabled,
+		Memo:          req.Memo,
+		Reviser:       cts.Kit.User,
+	}
+	if req.Name != nil {
+		model.Name = *req.Name
+	}
+	if req.Channels != nil {
+		model.Channels = channelsToArray(req.Channels)
+	}
+	if req.Receivers != nil {
+		model.Receivers = req.Receivers
+	}
+	if req.WebhookUrl != nil {
+		model.WebhookUrl = *req.WebhookUrl
+	}
+	if req.TemplateIDs != nil {
+		if err = svc.validateTemplates(cts.Kit, origin.EventType, req.TemplateIDs); err != nil {
+			return nil, err
+		}
+		model.TemplateIDs = req.TemplateIDs
+	}
+	if req.AccountIDs != nil {
+		model.AccountIDs = req.AccountIDs
+	}
+	if req.BkBizIDs != nil {
+		model.BkBizIDs = req.BkBizIDs
+	}
+
+	// 合并更新后的订阅，校验每个渠道仍然有可通知的对象
+	if err = validateMergedSubscription(&origin, req); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err = svc.dao.NotificationSubscription().UpdateByID(cts.Kit, id, model); err != nil {
+		logs.Errorf("update notification subscription failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListNotificationSubscription list notification subscription.
+func (svc *service) ListNotificationSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.NotificationSubscription().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list notification subscription failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]corenotification.Subscription, 0, len(result.Details))
+	for _, one := range result.Details {
+		channels := make([]enumor.NotificationChannel, 0, len(one.Channels))
+		for _, channel := range one.Channels {
+			channels = append(channels, enumor.NotificationChannel(channel))
+		}
+
+		details = append(details, corenotification.Subscription{
+			ID:            one.ID,
+			Name:          one.Name,
+			EventType:     one.EventType,
+			Channels:      channels,
+			Receivers:     one.Receivers,
+			NotifyRelated: converter.PtrToVal(one.NotifyRelated),
+			WebhookUrl:    one.WebhookUrl,
+			TemplateIDs:   one.TemplateIDs,
+			AccountIDs:    one.AccountIDs,
+			BkBizIDs:      one.BkBizIDs,
+			Enabled:       converter.PtrToVal(one.Enabled),
+			Memo:          one.Memo,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &core.ListResultT[corenotification.Subscription]{Count: result.Count, Details: details}, nil
+}
+
+// BatchDeleteNotificationSubscription batch delete notification subscription.
+func (svc *service) BatchDeleteNotificationSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.NotificationSubscription().DeleteWithTx(cts.Kit, txn,
+			tools.ContainersExpression("id", req.IDs))
+	})
+	if err != nil {
+		logs.Errorf("delete notification subscription failed, err: %v, ids: %v, rid: %s", err, req.IDs,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// validateTemplates validate that templates exist, belong to the event type and each channel has at most one.
+func (svc *service) validateTemplates(kt *kit.Kit, eventType enumor.NotificationEventType, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	opt := &types.ListOption{
+		Filter: tools.ContainersExpression("id", slice.Unique(ids)),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dao.NotificationTemplate().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list notification template failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return err
+	}
+
+	if len(result.Details) != len(slice.Unique(ids)) {
+		return errf.Newf(errf.InvalidParameter, "some of templates %v not found", ids)
+	}
+
+	channels := make(map[enumor.NotificationChannel]struct{})
+	for _, one := range result.Details {
+		if one.EventType != eventType {
+			return errf.Newf(errf.InvalidParameter, "template %s event type is %s, not %s", one.ID,
+				one.EventType, eventType)
+		}
+		if _, exists := channels[one.Channel]; exists {
+			return errf.Newf(errf.InvalidParameter, "only one template can be used for channel %s", one.Channel)
+		}
+		channels[one.Channel] = struct{}{}
+	}
+
+	return nil
+}
+
+func validateMergedSubscription(origin *tablenotification.SubscriptionTable,
+	req *datanotification.SubscriptionUpdateReq) error {
+
+	channels := make([]enumor.NotificationChannel, 0, len(origin.Channels))
+	for _, channel := range origin.Channels {
+		channels = append(channels, enumor.NotificationChannel(channel))
+	}
+	if req.Channels != nil {
+		channels = req.Channels
+	}
+
+	receivers := []string(origin.Receivers)
+	if req.Receivers != nil {
+		receivers = req.Receivers
+	}
+
+	notifyRelated := converter.PtrToVal(origin.NotifyRelated)
+	if req.NotifyRelated != nil {
+		notifyRelated = *req.NotifyRelated
+	}
+
+	webhookUrl := origin.WebhookUrl
+	if req.WebhookUrl != nil {
+		webhookUrl = *req.WebhookUrl
+	}
+
+	return datanotification.ValidateSubscriptionReceivers(channels, receivers, notifyRelated, webhookUrl)
+}
+
+func channelsToArray(channels []enumor.NotificationChannel) tabletypes.StringArray {
+	result := make(tabletypes.StringArray, 0, len(channels))
+	for _, channel := range slice.Unique(channels) {
+		result = append(result, string(channel))
+	}
+	return result
+}
@@ -0,0 +1,167 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package quota
+
+import (
+	"hcm/pkg/api/core"
+	corequota "hcm/pkg/api/core/quota"
+	dataquota "hcm/pkg/api/data-service/quota"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablequota "hcm/pkg/dal/table/quota"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateBizResWhitelist create biz resource whitelist.
+func (svc *service) CreateBizResWhitelist(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataquota.BizResWhitelistCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablequota.BizResWhitelistTable{
+		BkBizID:          req.BkBizID,
+		Vendor:           req.Vendor,
+		Regions:          req.Regions,
+		InstanceFamilies: req.InstanceFamilies,
+		CloudImageIDs:    req.CloudImageIDs,
+		Memo:             req.Memo,
+		Creator:          cts.Kit.User,
+		Reviser:          cts.Kit.User,
+	}
+
+	id, err := svc.dao.BizResWhitelist().Create(cts.Kit, model)
+	if err != nil {
+		logs.Errorf("create biz resource whitelist failed, err: %v, biz: %d, vendor: %s, rid: %s", err,
+			req.BkBizID, req.Vendor, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// UpdateBizResWhitelist update biz resource whitelist.
+func (svc *service) UpdateBizResWhitelist(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(dataquota.BizResWhitelistUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablequota.BizResWhitelistTable{
+		Regions:          req.Regions,
+		InstanceFamilies: req.InstanceFamilies,
+		CloudImageIDs:    req.CloudImageIDs,
+		Memo:             req.Memo,
+		Reviser:          cts.Kit.User,
+	}
+
+	if err := svc.dao.BizResWhitelist().UpdateByID(cts.Kit, id, model); err != nil {
+		logs.Errorf("update biz resource whitelist failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListBizResWhitelist list biz resource whitelist.
+func (svc *service) ListBizResWhitelist(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.BizResWhitelist().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list biz resource whitelist failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]corequota.BizResWhitelist, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corequota.BizResWhitelist{
+			ID:               one.ID,
+			BkBizID:          one.BkBizID,
+			Vendor:           one.Vendor,
+			Regions:          toStrings(one.Regions),
+			InstanceFamilies: toStrings(one.InstanceFamilies),
+			CloudImageIDs:    toStrings(one.CloudImageIDs),
+			Memo:             one.Memo,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &core.ListResultT[corequota.BizResWhitelist]{Count: result.Count, Details: details}, nil
+}
+
+func toStrings(values tabletype.StringArray) []string {
+	if values == nil {
+		return make([]string, 0)
+	}
+	return values
+}
+
+// BatchDeleteBizResWhitelist batch delete biz resource whitelist.
+func (svc *service) BatchDeleteBizResWhitelist(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.dao.BizResWhitelist().Delete(cts.Kit, tools.ContainersExpression("id", req.IDs)); err != nil {
+		logs.Errorf("delete biz resource whitelist failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,234 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package quota biz resource quota service
+package quota
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	corequota "hcm/pkg/api/core/quota"
+	dataquota "hcm/pkg/api/data-service/quota"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablequota "hcm/pkg/dal/table/quota"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateBizQuota", http.MethodPost, "/biz_quotas/create", svc.CreateBizQuota)
+	h.Add("UpdateBizQuota", http.MethodPatch, "/biz_quotas/{id}", svc.UpdateBizQuota)
+	h.Add("ListBizQuota", http.MethodPost, "/biz_quotas/list", svc.ListBizQuota)
+	h.Add("BatchDeleteBizQuota", http.MethodDelete, "/biz_quotas/batch", svc.BatchDeleteBizQuota)
+	h.Add("ListBizResUsage", http.MethodPost, "/biz_quotas/usages/list", svc.ListBizResUsage)
+
+	h.Add("CreateBizResWhitelist", http.MethodPost, "/biz_res_whitelists/create", svc.CreateBizResWhitelist)
+	h.Add("UpdateBizResWhitelist", http.MethodPatch, "/biz_res_whitelists/{id}", svc.UpdateBizResWhitelist)
+	h.Add("ListBizResWhitelist", http.MethodPost, "/biz_res_whitelists/list", svc.ListBizResWhitelist)
+	h.Add("BatchDeleteBizResWhitelist", http.MethodDelete, "/biz_res_whitelists/batch",
+		svc.BatchDeleteBizResWhitelist)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
+
+// CreateBizQuota create biz quota.
+func (svc *service) CreateBizQuota(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataquota.BizQuotaCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablequota.BizQuotaTable{
+		BkBizID:    req.BkBizID,
+		MaxCvm:     &req.MaxCvm,
+		MaxCpuCore: &req.MaxCpuCore,
+		MaxEip:     &req.MaxEip,
+		Memo:       req.Memo,
+		Creator:    cts.Kit.User,
+		Reviser:    cts.Kit.User,
+	}
+
+	id, err := svc.dao.BizQuota().Create(cts.Kit, model)
+	if err != nil {
+		logs.Errorf("create biz quota failed, err: %v, biz: %d, rid: %s", err, req.BkBizID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// UpdateBizQuota update biz quota.
+func (svc *service) UpdateBizQuota(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(dataquota.BizQuotaUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablequota.BizQuotaTable{
+		MaxCvm:     req.MaxCvm,
+		MaxCpuCore: req.MaxCpuCore,
+		MaxEip:     req.MaxEip,
+		Memo:       req.Memo,
+		Reviser:    cts.Kit.User,
+	}
+
+	if err := svc.dao.BizQuota().UpdateByID(cts.Kit, id, model); err != nil {
+		logs.Errorf("update biz quota failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListBizQuota list biz quota.
+func (svc *service) ListBizQuota(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.BizQuota().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list biz quota failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]corequota.BizQuota, 0, len(result.Details))
+	for _, one := range result.Details {
+		quota := corequota.BizQuota{
+			ID:      one.ID,
+			BkBizID: one.BkBizID,
+			Memo:    one.Memo,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		}
+		if one.MaxCvm != nil {
+			quota.MaxCvm = *one.MaxCvm
+		}
+		if one.MaxCpuCore != nil {
+			quota.MaxCpuCore = *one.MaxCpuCore
+		}
+		if one.MaxEip != nil {
+			quota.MaxEip = *one.MaxEip
+		}
+		details = append(details, quota)
+	}
+
+	return &core.ListResultT[corequota.BizQuota]{Count: result.Count, Details: details}, nil
+}
+
+// BatchDeleteBizQuota batch delete biz quota.
+func (svc *service) BatchDeleteBizQuota(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.dao.BizQuota().Delete(cts.Kit, tools.ContainersExpression("id", req.IDs)); err != nil {
+		logs.Errorf("delete biz quota failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListBizResUsage list biz resource usage counted from inventory, biz without resources has zero usage.
+func (svc *service) ListBizResUsage(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataquota.BizResUsageListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	usages, err := svc.dao.BizQuota().ListUsage(cts.Kit, req.BkBizIDs)
+	if err != nil {
+		logs.Errorf("list biz resource usage failed, err: %v, bizs: %v, rid: %s", err, req.BkBizIDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	usageMap := make(map[int64]corequota.BizResUsage, len(usages))
+	for _, one := range usages {
+		usageMap[one.BkBizID] = corequota.BizResUsage{
+			BkBizID:  one.BkBizID,
+			CvmCount: one.CvmCount,
+			CpuCore:  one.CpuCore,
+			EipCount: one.EipCount,
+		}
+	}
+
+	details := make([]corequota.BizResUsage, 0, len(req.BkBizIDs))
+	for _, bizID := range req.BkBizIDs {
+		usage, exists := usageMap[bizID]
+		if !exists {
+			usage = corequota.BizResUsage{BkBizID: bizID}
+		}
+		details = append(details, usage)
+	}
+
+	return &core.ListResultT[corequota.BizResUsage]{Details: details}, nil
+}
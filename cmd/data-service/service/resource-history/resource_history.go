@@ -0,0 +1,84 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package reshistory
+
+import (
+	datareshistory "hcm/pkg/api/data-service/resource-history"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// ListResourceHistories list resource histories.
+func (svc *service) ListResourceHistories(cts *rest.Contexts) (interface{}, error) {
+	req := new(datareshistory.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		logs.Errorf("list resource history decode request failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		logs.Errorf("list resource history validate request failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listOpt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.ResourceHistory().List(cts.Kit, listOpt)
+	if err != nil {
+		logs.Errorf("list resource history failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &datareshistory.ListResp{Count: result.Count, Details: result.Details}, nil
+}
+
+// GetResourceHistoryAsOf get the version of resource at the given time, which can be used to view or restore the
+// configuration of resource at that time.
+func (svc *service) GetResourceHistoryAsOf(cts *rest.Contexts) (interface{}, error) {
+	req := new(datareshistory.GetAsOfReq)
+	if err := cts.DecodeInto(req); err != nil {
+		logs.Errorf("get resource history decode request failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		logs.Errorf("get resource history validate request failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	at, err := req.ParseTime()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	history, err := svc.dao.ResourceHistory().GetAsOf(cts.Kit, req.ResType, req.ResID, at)
+	if err != nil {
+		logs.Errorf("get %s(%s) history as of %s failed, err: %v, rid: %s", req.ResType, req.ResID, req.Time, err,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return history, nil
+}
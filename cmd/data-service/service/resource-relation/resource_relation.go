@@ -0,0 +1,264 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resrelation
+
+import (
+	datarelation "hcm/pkg/api/data-service/resource-relation"
+	"hcm/pkg/criteria/errf"
+	daorelation "hcm/pkg/dal/dao/resource-relation"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tablerelation "hcm/pkg/dal/table/resource-relation"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+)
+
+// ListResourceRelations list resource relations.
+func (svc *service) ListResourceRelations(cts *rest.Contexts) (interface{}, error) {
+	req := new(datarelation.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		logs.Errorf("list resource relation decode request failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		logs.Errorf("list resource relation validate request failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listOpt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.ResourceRelation().List(cts.Kit, listOpt)
+	if err != nil {
+		logs.Errorf("list resource relation failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &datarelation.ListResp{Count: result.Count, Details: result.Details}, nil
+}
+
+type nodeKey struct {
+	resType table.Name
+	resID   string
+}
+
+// GetResourceRelationGraph get the relation graph starting from the resource, the graph is traversed in breadth
+// first order in both directions of the edges, so that all the resources affected by the resource can be found.
+func (svc *service) GetResourceRelationGraph(cts *rest.Contexts) (interface{}, error) {
+	req := new(datarelation.GraphReq)
+	if err := cts.DecodeInto(req); err != nil {
+		logs.Errorf("get resource relation graph decode request failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		logs.Errorf("get resource relation graph validate request failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	graph := &datarelation.GraphResp{
+		Nodes: []datarelation.Node{{ResType: req.ResType, ResID: req.ResID}},
+		Edges: make([]tablerelation.ResourceRelationTable, 0),
+	}
+	visited := map[nodeKey]struct{}{{resType: req.ResType, resID: req.ResID}: {}}
+	edgeSet := make(map[tablerelation.ResourceRelationTable]struct{})
+	frontier := map[table.Name][]string{req.ResType: {req.ResID}}
+
+	for depth := uint(0); depth <= req.Depth && len(frontier) != 0; depth++ {
+		next := make(map[table.Name][]string)
+		for resType, ids := range frontier {
+			edges, err := svc.dao.ResourceRelation().ListEdges(cts.Kit, resType, ids)
+			if err != nil {
+				logs.Errorf("list %s relation edges failed, err: %v, ids: %v, rid: %s", resType, err, ids, cts.Kit.Rid)
+				return nil, err
+			}
+
+			for _, edge := range edges {
+				if _, exists := edgeSet[edge]; exists {
+					continue
+				}
+				edgeSet[edge] = struct{}{}
+				graph.Edges = append(graph.Edges, edge)
+
+				for _, peer := range []nodeKey{{edge.SrcType, edge.SrcID}, {edge.DstType, edge.DstID}} {
+					if _, exists := visited[peer]; exists {
+						continue
+					}
+
+					if len(graph.Nodes) >= datarelation.MaxGraphNodes {
+						graph.Truncated = true
+						continue
+					}
+
+					visited[peer] = struct{}{}
+					graph.Nodes = append(graph.Nodes,
+						datarelation.Node{ResType: peer.resType, ResID: peer.resID, Depth: depth + 1})
+					next[peer.resType] = append(next[peer.resType], peer.resID)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	return graph, nil
+}
+
+// topologyLevels VPC网络拓扑的展开层级，每一层从上一层发现的资源出发，只接受指定类型的相邻资源作为新节点，
+// 从而避免通过共享的安全组等资源扩散到其他VPC中
+var topologyLevels = []struct {
+	from   table.Name
+	accept []table.Name
+}{
+	{from: table.VpcTable, accept: []table.Name{table.SubnetTable, table.RouteTableTable}},
+	{from: table.SubnetTable, accept: []table.Name{table.CvmTable}},
+	{from: table.RouteTableTable, accept: []table.Name{datarelation.NatGatewayNodeType,
+		datarelation.VpnGatewayNodeType, datarelation.VpcPeeringNodeType}},
+	{from: table.CvmTable, accept: []table.Name{table.SecurityGroupTable, table.EipTable, table.DiskTable}},
+}
+
+// topologyNodeTypes VPC网络拓扑中的节点类型，节点按此顺序返回
+var topologyNodeTypes = []table.Name{table.VpcTable, table.SubnetTable, table.RouteTableTable,
+	datarelation.NatGatewayNodeType, datarelation.VpnGatewayNodeType, datarelation.VpcPeeringNodeType,
+	table.CvmTable, table.SecurityGroupTable, table.EipTable, table.DiskTable}
+
+// GetVpcTopology get the network topology of the vpc, which contains subnets, route tables, nat/vpn gateways,
+// peerings of the vpc, and cvms in the vpc with their security groups, eips and disks.
+func (svc *service) GetVpcTopology(cts *rest.Contexts) (interface{}, error) {
+	req := new(datarelation.VpcTopologyReq)
+	if err := cts.DecodeInto(req); err != nil {
+		logs.Errorf("get vpc topology decode request failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		logs.Errorf("get vpc topology validate request failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	topo := &datarelation.VpcTopologyResp{Edges: make([]tablerelation.ResourceRelationTable, 0)}
+	nodes := map[table.Name][]string{table.VpcTable: {req.VpcID}}
+	visited := map[nodeKey]struct{}{{resType: table.VpcTable, resID: req.VpcID}: {}}
+	edgeSet := make(map[tablerelation.ResourceRelationTable]struct{})
+	nodeCount := 1
+
+	for _, level := range topologyLevels {
+		if len(nodes[level.from]) == 0 {
+			continue
+		}
+
+		edges, err := svc.dao.ResourceRelation().ListEdges(cts.Kit, level.from, nodes[level.from])
+		if err != nil {
+			logs.Errorf("list %s relation edges failed, err: %v, rid: %s", level.from, err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		accept := make(map[table.Name]struct{}, len(level.accept))
+		for _, resType := range level.accept {
+			accept[resType] = struct{}{}
+		}
+
+		fromIDs := converter.StringSliceToMap(nodes[level.from])
+		for _, edge := range edges {
+			if _, exists := edgeSet[edge]; exists {
+				continue
+			}
+
+			peer := nodeKey{resType: edge.DstType, resID: edge.DstID}
+			if _, exists := fromIDs[edge.DstID]; exists && edge.DstType == level.from {
+				peer = nodeKey{resType: edge.SrcType, resID: edge.SrcID}
+			}
+
+			if _, exists := visited[peer]; !exists {
+				if _, ok := accept[peer.resType]; !ok {
+					continue
+				}
+
+				if nodeCount >= datarelation.MaxGraphNodes {
+					topo.Truncated = true
+					continue
+				}
+
+				visited[peer] = struct{}{}
+				nodes[peer.resType] = append(nodes[peer.resType], peer.resID)
+				nodeCount++
+			}
+
+			edgeSet[edge] = struct{}{}
+			topo.Edges = append(topo.Edges, edge)
+		}
+	}
+
+	topoNodes, err := svc.fillTopologyNodes(cts.Kit, nodes)
+	if err != nil {
+		return nil, err
+	}
+	topo.Nodes = topoNodes
+
+	return topo, nil
+}
+
+// fillTopologyNodes fill the cloud id and name of the topology nodes, nat/vpn gateways and peerings are not managed
+// by hcm, their node id is the cloud id.
+func (svc *service) fillTopologyNodes(kt *kit.Kit, nodes map[table.Name][]string) ([]datarelation.TopologyNode,
+	error) {
+
+	result := make([]datarelation.TopologyNode, 0)
+	for _, resType := range topologyNodeTypes {
+		ids := nodes[resType]
+		if len(ids) == 0 {
+			continue
+		}
+
+		switch resType {
+		case datarelation.NatGatewayNodeType, datarelation.VpnGatewayNodeType, datarelation.VpcPeeringNodeType:
+			for _, id := range ids {
+				result = append(result, datarelation.TopologyNode{ResType: resType, ResID: id, CloudID: id})
+			}
+			continue
+		}
+
+		infos, err := svc.dao.ResourceRelation().ListNodeInfo(kt, resType, ids)
+		if err != nil {
+			logs.Errorf("list %s topology node info failed, err: %v, rid: %s", resType, err, kt.Rid)
+			return nil, err
+		}
+
+		infoMap := make(map[string]daorelation.NodeInfo, len(infos))
+		for _, info := range infos {
+			infoMap[info.ID] = info
+		}
+
+		for _, id := range ids {
+			node := datarelation.TopologyNode{ResType: resType, ResID: id}
+			if info, exists := infoMap[id]; exists {
+				node.CloudID = info.CloudID
+				node.Name = info.Name
+			}
+			result = append(result, node)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,183 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resrelation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	datarelation "hcm/pkg/api/data-service/resource-relation"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/dal/dao"
+	daorelation "hcm/pkg/dal/dao/resource-relation"
+	"hcm/pkg/dal/table"
+	tablerelation "hcm/pkg/dal/table/resource-relation"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSet struct {
+	dao.Set
+	relation *fakeRelation
+}
+
+// ResourceRelation ...
+func (f fakeSet) ResourceRelation() daorelation.Interface {
+	return f.relation
+}
+
+// fakeRelation is an in memory relation graph.
+type fakeRelation struct {
+	daorelation.Interface
+	edges []tablerelation.ResourceRelationTable
+}
+
+// ListEdges ...
+func (f *fakeRelation) ListEdges(_ *kit.Kit, resType table.Name, ids []string) (
+	[]tablerelation.ResourceRelationTable, error) {
+
+	idMap := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		idMap[id] = struct{}{}
+	}
+
+	result := make([]tablerelation.ResourceRelationTable, 0)
+	for _, edge := range f.edges {
+		if _, exists := idMap[edge.SrcID]; exists && edge.SrcType == resType {
+			result = append(result, edge)
+		}
+		if _, exists := idMap[edge.DstID]; exists && edge.DstType == resType {
+			result = append(result, edge)
+		}
+	}
+	return result, nil
+}
+
+// ListNodeInfo ...
+func (f *fakeRelation) ListNodeInfo(_ *kit.Kit, _ table.Name, ids []string) ([]daorelation.NodeInfo, error) {
+	infos := make([]daorelation.NodeInfo, 0, len(ids))
+	for _, id := range ids {
+		infos = append(infos, daorelation.NodeInfo{ID: id, CloudID: "cloud-" + id, Name: "name-" + id})
+	}
+	return infos, nil
+}
+
+func edge(srcType table.Name, srcID string, dstType table.Name, dstID string) tablerelation.ResourceRelationTable {
+	return tablerelation.ResourceRelationTable{SrcType: srcType, SrcID: srcID, DstType: dstType, DstID: dstID}
+}
+
+// two vpcs share the security group sg-1.
+var testEdges = []tablerelation.ResourceRelationTable{
+	edge(table.SubnetTable, "subnet-1", table.VpcTable, "vpc-1"),
+	edge(table.RouteTableTable, "rt-1", table.VpcTable, "vpc-1"),
+	edge(table.RouteTableTable, "rt-1", datarelation.NatGatewayNodeType, "nat-1"),
+	edge(table.CvmTable, "cvm-1", table.SubnetTable, "subnet-1"),
+	edge(table.CvmTable, "cvm-1", table.DiskTable, "disk-1"),
+	edge(table.CvmTable, "cvm-1", table.SecurityGroupTable, "sg-1"),
+	edge(table.CvmTable, "cvm-2", table.SecurityGroupTable, "sg-1"),
+	edge(table.CvmTable, "cvm-2", table.SubnetTable, "subnet-2"),
+	edge(table.SubnetTable, "subnet-2", table.VpcTable, "vpc-2"),
+}
+
+func newTestContainer() *restful.Container {
+	svc := &service{dao: fakeSet{relation: &fakeRelation{edges: testEdges}}}
+
+	h := rest.NewHandler()
+	h.Add("GetResourceRelationGraph", http.MethodPost, "/resource_relations/graph", svc.GetResourceRelationGraph)
+	h.Add("GetVpcTopology", http.MethodPost, "/resource_relations/vpc_topology", svc.GetVpcTopology)
+	ws := new(restful.WebService)
+	h.Load(ws)
+	container := restful.NewContainer()
+	container.Add(ws)
+	return container
+}
+
+func do(t *testing.T, container *restful.Container, path, body string, data interface{}) {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constant.UserKey, "admin")
+	req.Header.Set(constant.AppCodeKey, "hcm")
+	req.Header.Set(constant.RidKey, "resource-relation-test-rid")
+	recorder := httptest.NewRecorder()
+	container.ServeHTTP(recorder, req)
+
+	resp := &rest.Response{Data: data}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), resp))
+	require.Equal(t, int32(0), resp.Code, resp.Message)
+}
+
+func TestGetResourceRelationGraph(t *testing.T) {
+	container := newTestContainer()
+
+	graph := new(datarelation.GraphResp)
+	do(t, container, "/resource_relations/graph", `{"res_type":"vpc","res_id":"vpc-1"}`, graph)
+	depths := make(map[string]uint)
+	for _, node := range graph.Nodes {
+		depths[node.ResID] = node.Depth
+	}
+	assert.Equal(t, map[string]uint{"vpc-1": 0, "subnet-1": 1, "rt-1": 1}, depths)
+	assert.Len(t, graph.Edges, 2)
+
+	// the graph is traversed in both directions of the edges.
+	graph = new(datarelation.GraphResp)
+	do(t, container, "/resource_relations/graph", `{"res_type":"vpc","res_id":"vpc-1","depth":2}`, graph)
+	depths = make(map[string]uint)
+	for _, node := range graph.Nodes {
+		depths[node.ResID] = node.Depth
+	}
+	assert.Equal(t, uint(3), depths["sg-1"])
+	assert.Equal(t, uint(3), depths["disk-1"])
+	assert.Equal(t, uint(2), depths["cvm-1"])
+	assert.Equal(t, uint(2), depths["nat-1"])
+	assert.NotContains(t, depths, "cvm-2")
+	assert.False(t, graph.Truncated)
+}
+
+func TestGetVpcTopology(t *testing.T) {
+	container := newTestContainer()
+
+	topo := new(datarelation.VpcTopologyResp)
+	do(t, container, "/resource_relations/vpc_topology", `{"vpc_id":"vpc-1"}`, topo)
+
+	ids := make([]string, 0)
+	for _, node := range topo.Nodes {
+		ids = append(ids, node.ResID)
+	}
+	// the topology does not spread to the other vpc through the shared security group.
+	assert.Equal(t, []string{"vpc-1", "subnet-1", "rt-1", "nat-1", "cvm-1", "sg-1", "disk-1"}, ids)
+	assert.Len(t, topo.Edges, 6)
+
+	for _, node := range topo.Nodes {
+		switch node.ResType {
+		case datarelation.NatGatewayNodeType:
+			assert.Equal(t, "nat-1", node.CloudID, "unmanaged node uses cloud id as node id")
+		default:
+			assert.Equal(t, "cloud-"+node.ResID, node.CloudID)
+			assert.Equal(t, "name-"+node.ResID, node.Name)
+		}
+	}
+}
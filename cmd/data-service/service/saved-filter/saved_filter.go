@@ -0,0 +1,229 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package savedfilter saved filter service
+package savedfilter
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	coresavedfilter "hcm/pkg/api/core/saved-filter"
+	datasavedfilter "hcm/pkg/api/data-service/saved-filter"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablesavedfilter "hcm/pkg/dal/table/saved-filter"
+	tabletypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InitService initial the service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateSavedFilter", http.MethodPost, "/saved_filters/create", svc.CreateSavedFilter)
+	h.Add("UpdateSavedFilter", http.MethodPatch, "/saved_filters/{id}", svc.UpdateSavedFilter)
+	h.Add("ListSavedFilter", http.MethodPost, "/saved_filters/list", svc.ListSavedFilter)
+	h.Add("BatchDeleteSavedFilter", http.MethodDelete, "/saved_filters/batch", svc.BatchDeleteSavedFilter)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
+
+// CreateSavedFilter create saved filter.
+func (svc *service) CreateSavedFilter(cts *rest.Contexts) (interface{}, error) {
+	req := new(datasavedfilter.SavedFilterCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	filterJson, err := tabletypes.NewJsonField(req.Filter)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablesavedfilter.SavedFilterTable{
+		Name:    req.Name,
+		ResType: req.ResType,
+		Scope:   req.Scope,
+		BkBizID: scopeBizID(req.Scope, req.BkBizID),
+		Filter:  filterJson,
+		Memo:    req.Memo,
+		Creator: cts.Kit.User,
+		Reviser: cts.Kit.User,
+	}
+
+	id, err := svc.dao.SavedFilter().Create(cts.Kit, model)
+	if err != nil {
+		logs.Errorf("create saved filter failed, err: %v, name: %s, rid: %s", err, req.Name, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// UpdateSavedFilter update saved filter.
+func (svc *service) UpdateSavedFilter(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(datasavedfilter.SavedFilterUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablesavedfilter.SavedFilterTable{
+		Scope:   req.Scope,
+		Memo:    req.Memo,
+		Reviser: cts.Kit.User,
+	}
+	if req.Name != nil {
+		model.Name = *req.Name
+	}
+	if len(req.Scope) != 0 {
+		model.BkBizID = scopeBizID(req.Scope, req.BkBizID)
+	}
+	if req.Filter != nil {
+		filterJson, err := tabletypes.NewJsonField(req.Filter)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+		model.Filter = filterJson
+	}
+
+	if err := svc.dao.SavedFilter().UpdateByID(cts.Kit, id, model); err != nil {
+		logs.Errorf("update saved filter failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// scopeBizID 仅本人可见的过滤条件不属于任何业务，统一使用未分配业务标识
+func scopeBizID(scope enumor.SavedFilterScope, bizID int64) int64 {
+	if scope == enumor.SavedFilterUserScope {
+		return constant.UnassignedBiz
+	}
+
+	return bizID
+}
+
+// ListSavedFilter list saved filter.
+func (svc *service) ListSavedFilter(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.SavedFilter().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list saved filter failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]coresavedfilter.SavedFilter, 0, len(result.Details))
+	for _, one := range result.Details {
+		var expr *filter.Expression
+		if !one.Filter.IsEmpty() {
+			expr = new(filter.Expression)
+			if err = json.Unmarshal([]byte(one.Filter), expr); err != nil {
+				logs.Errorf("unmarshal saved filter expression failed, err: %v, id: %s, rid: %s", err, one.ID,
+					cts.Kit.Rid)
+				return nil, err
+			}
+		}
+
+		details = append(details, coresavedfilter.SavedFilter{
+			ID:      one.ID,
+			Name:    one.Name,
+			ResType: one.ResType,
+			Scope:   one.Scope,
+			BkBizID: one.BkBizID,
+			Filter:  expr,
+			Memo:    one.Memo,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &core.ListResultT[coresavedfilter.SavedFilter]{Count: result.Count, Details: details}, nil
+}
+
+// BatchDeleteSavedFilter batch delete saved filter.
+func (svc *service) BatchDeleteSavedFilter(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.SavedFilter().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", req.IDs))
+	})
+	if err != nil {
+		logs.Errorf("delete saved filter failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
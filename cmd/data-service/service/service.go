@@ -28,16 +28,21 @@ import (
 	"strconv"
 	"time"
 
+	accountgroup "hcm/cmd/data-service/service/account-group"
 	mainaccount "hcm/cmd/data-service/service/account-set/main-account"
 	rootaccount "hcm/cmd/data-service/service/account-set/root-account"
 	"hcm/cmd/data-service/service/application"
+	asyncjob "hcm/cmd/data-service/service/async-job"
 	"hcm/cmd/data-service/service/audit"
 	"hcm/cmd/data-service/service/auth"
+	"hcm/cmd/data-service/service/backup"
 	"hcm/cmd/data-service/service/bill/billadjustmentitem"
+	"hcm/cmd/data-service/service/bill/billbudget"
 	"hcm/cmd/data-service/service/bill/billdailytask"
 	"hcm/cmd/data-service/service/bill/billexchangerate"
 	"hcm/cmd/data-service/service/bill/billitem"
 	"hcm/cmd/data-service/service/bill/billmonthtask"
+	"hcm/cmd/data-service/service/bill/billreconciliation"
 	"hcm/cmd/data-service/service/bill/billsummarydaily"
 	"hcm/cmd/data-service/service/bill/billsummarymain"
 	"hcm/cmd/data-service/service/bill/billsummaryroot"
@@ -71,26 +76,53 @@ import (
 	subaccount "hcm/cmd/data-service/service/cloud/sub-account"
 	sync "hcm/cmd/data-service/service/cloud/sync"
 	"hcm/cmd/data-service/service/cloud/zone"
+	"hcm/cmd/data-service/service/compliance"
+	"hcm/cmd/data-service/service/consistency"
 	"hcm/cmd/data-service/service/cos"
+	distinctvalue "hcm/cmd/data-service/service/distinct-value"
+	"hcm/cmd/data-service/service/event"
 	globalconfig "hcm/cmd/data-service/service/global-config"
+	idleresource "hcm/cmd/data-service/service/idle-resource"
+	"hcm/cmd/data-service/service/index"
+	landingzone "hcm/cmd/data-service/service/landing-zone"
+	maintenancewindow "hcm/cmd/data-service/service/maintenance-window"
+	"hcm/cmd/data-service/service/naming"
+	"hcm/cmd/data-service/service/notification"
+	"hcm/cmd/data-service/service/quota"
 	recyclerecord "hcm/cmd/data-service/service/recycle-record"
+	reshistory "hcm/cmd/data-service/service/resource-history"
+	resrelation "hcm/cmd/data-service/service/resource-relation"
+	savedfilter "hcm/cmd/data-service/service/saved-filter"
+	tagpolicy "hcm/cmd/data-service/service/tag-policy"
 	"hcm/cmd/data-service/service/task"
+	"hcm/cmd/data-service/service/tenant"
 	"hcm/cmd/data-service/service/user"
+	"hcm/cmd/data-service/service/webhook"
+	"hcm/pkg/api/core"
+	"hcm/pkg/auditsink"
+	"hcm/pkg/cache"
 	"hcm/pkg/cc"
-	"hcm/pkg/criteria/errf"
+	"hcm/pkg/cdc"
 	"hcm/pkg/cryptography"
 	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/objectstore"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/featureflag"
 	"hcm/pkg/handler"
+	"hcm/pkg/health"
 	"hcm/pkg/logs"
 	"hcm/pkg/metrics"
 	"hcm/pkg/rest"
+	"hcm/pkg/rpc"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
 	"hcm/pkg/thirdparty/esb"
 	"hcm/pkg/tools/ssl"
+	hcmsql "hcm/scripts/sql"
 
 	"github.com/emicklei/go-restful/v3"
+	"google.golang.org/grpc"
 )
 
 // Service do all the data service's work
@@ -100,20 +132,73 @@ type Service struct {
 	cipher      cryptography.Crypto
 	esbClient   esb.Client
 	objectStore objectstore.Storage
+	// eventProducer sends the change events to the sink, it is nil if change event is disabled.
+	eventProducer *cdc.Producer
+	// sd is used to check the service registration in readiness probe
+	sd serviced.Service
+}
+
+// setTablePageLimits set the max page limits of the configured tables.
+func setTablePageLimits(opt cc.PageLimit) error {
+	limits := make(map[table.Name]uint, len(opt.Tables))
+	for name, limit := range opt.Tables {
+		if err := table.Name(name).Validate(); err != nil {
+			return fmt.Errorf("invalid page limit table, err: %v", err)
+		}
+		limits[table.Name(name)] = limit
+	}
+
+	core.SetTablePageLimits(limits)
+	return nil
 }
 
 // NewService create a service instance.
 func NewService() (*Service, error) {
-	dao, err := dao.NewDaoSet(cc.DataService().Database)
+	if cc.DataService().Database.Migration.Enable {
+		if err := dao.Migrate(cc.DataService().Database, hcmsql.FS); err != nil {
+			return nil, fmt.Errorf("migrate database failed, err: %v", err)
+		}
+	}
+
+	// 加解密器，账号密钥由账号dao加密后存储
+	cipher, err := newCipherFromConfig(cc.DataService().Crypto)
 	if err != nil {
 		return nil, err
 	}
 
-	// 加解密器
-	cipher, err := newCipherFromConfig(cc.DataService().Crypto)
+	dao, err := dao.NewDaoSet(cc.DataService().Database, dao.WithCipher(cipher))
 	if err != nil {
 		return nil, err
 	}
+	tools.EnableTenant(cc.DataService().Tenant.Enabled)
+
+	if err = setTablePageLimits(cc.DataService().PageLimit); err != nil {
+		return nil, err
+	}
+	cc.OnChange(func() {
+		if err := setTablePageLimits(cc.DataService().PageLimit); err != nil {
+			logs.Errorf("reload table page limits failed, err: %v", err)
+		}
+	})
+
+	featureflag.Init(func() cc.FeatureFlags { return cc.DataService().FeatureFlags })
+
+	// 变更事件由主节点的定时任务投递，启动时创建 sink 以尽早发现配置错误
+	var eventProducer *cdc.Producer
+	if conf := cc.DataService().ChangeEvent; conf.Enable {
+		if eventProducer, err = event.NewProducer(dao, conf); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = auditsink.Init(cc.DataService().AuditSink); err != nil {
+		return nil, err
+	}
+
+	// 账号、地域、可用区、镜像等热点查询接口的结果缓存在redis中
+	if cc.DataService().Cache.Enable {
+		cache.Enable(cc.DataService().Cache)
+	}
 
 	// esb client
 	esbConfig := cc.DataService().Esb
@@ -129,10 +214,11 @@ func NewService() (*Service, error) {
 	}
 
 	svr := &Service{
-		dao:         dao,
-		cipher:      cipher,
-		esbClient:   esbClient,
-		objectStore: oStore,
+		dao:           dao,
+		cipher:        cipher,
+		esbClient:     esbClient,
+		objectStore:   oStore,
+		eventProducer: eventProducer,
 	}
 
 	return svr, nil
@@ -142,21 +228,71 @@ func NewService() (*Service, error) {
 func newCipherFromConfig(cryptoConfig cc.Crypto) (cryptography.Crypto, error) {
 	// TODO: 目前只支持国际加密，还未支持中国国家商业加密，待后续支持再调整
 	cfg := cryptoConfig.AesGcm
-	return cryptography.NewAESGcm([]byte(cfg.Key), []byte(cfg.Nonce))
+	legacy, err := cryptography.NewAESGcm([]byte(cfg.Key), []byte(cfg.Nonce))
+	if err != nil {
+		return nil, err
+	}
+
+	envCfg := cryptoConfig.Envelope
+	if !envCfg.Enable {
+		return legacy, nil
+	}
+
+	// 开启信封加密后，新写入的密文使用信封加密，历史密文仍使用AesGcm解密
+	provider, err := cryptography.NewKeyProvider(cryptography.KeyProviderKind(envCfg.Provider), envCfg.KeyMap(),
+		envCfg.ActiveKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("new crypto key provider failed, err: %v", err)
+	}
+
+	return cryptography.NewEnvelope(provider, legacy)
+}
+
+// BindService bind the registered service instance of data service.
+func (s *Service) BindService(sd serviced.Service) {
+	s.sd = sd
+}
+
+// RunTimingJobs run the timing jobs of data service, the jobs are only executed on master.
+func (s *Service) RunTimingJobs(sd serviced.State) {
+	if conf := cc.DataService().ConsistencyCheck; conf.Enable {
+		go consistency.CheckTiming(s.dao, sd, conf)
+	}
+
+	if conf := cc.DataService().AuditRetention; conf.Enable {
+		go audit.RetentionTiming(s.dao, sd, conf)
+	}
+
+	if s.eventProducer != nil {
+		interval := time.Duration(cc.DataService().ChangeEvent.IntervalSec) * time.Second
+		go event.ProduceTiming(s.eventProducer, sd, interval)
+	}
 }
 
 // ListenAndServeRest listen and serve the restful server
 func (s *Service) ListenAndServeRest() error {
+	// grpc服务与restful服务共用端口，按请求的协议分发
+	var grpcServer *grpc.Server
+	if cc.DataService().Grpc.Enable {
+		grpcServer = rpc.NewServer(cc.DataService().Grpc)
+	}
+
 	root := http.NewServeMux()
-	root.HandleFunc("/", s.apiSet().ServeHTTP)
+	root.HandleFunc("/", s.apiSet(grpcServer).ServeHTTP)
 	root.HandleFunc("/healthz", s.Healthz)
+	root.HandleFunc("/readyz", s.Readyz)
 	handler.SetCommonHandler(root)
 
 	network := cc.DataService().Network
+	rest.SetBodyLimit(network.BodyLimit)
+	rest.SetAccessLog(network.AccessLog)
 	server := &http.Server{
 		Addr:    net.JoinHostPort(network.BindIP, strconv.FormatUint(uint64(network.Port), 10)),
 		Handler: root,
 	}
+	if grpcServer != nil {
+		server.Handler = rpc.Handler(grpcServer, root)
+	}
 
 	if network.TLS.Enable() {
 		tls := network.TLS
@@ -179,6 +315,10 @@ func (s *Service) ListenAndServeRest() error {
 
 			logs.Infof("start shutdown restful server gracefully...")
 
+			if grpcServer != nil {
+				grpcServer.GracefulStop()
+			}
+
 			ctx, cancel := context.WithTimeout(context.TODO(), 20*time.Second)
 			defer cancel()
 			if err := server.Shutdown(ctx); err != nil {
@@ -202,7 +342,7 @@ func (s *Service) ListenAndServeRest() error {
 	return nil
 }
 
-func (s *Service) apiSet() *restful.Container {
+func (s *Service) apiSet(grpcServer *grpc.Server) *restful.Container {
 	ws := new(restful.WebService)
 	ws.Path("/api/v1/data")
 	ws.Produces(restful.MIME_JSON)
@@ -213,6 +353,7 @@ func (s *Service) apiSet() *restful.Container {
 		Cipher:      s.cipher,
 		EsbClient:   s.esbClient,
 		ObjectStore: s.objectStore,
+		GrpcServer:  grpcServer,
 	}
 
 	account.InitService(capability)
@@ -270,8 +411,30 @@ func (s *Service) apiSet() *restful.Container {
 	sgcomrel.InitService(capability)
 
 	billexchangerate.InitService(capability)
+	billbudget.InitService(capability)
+	billreconciliation.InitService(capability)
 	billsyncrecord.InitService(capability)
 	globalconfig.InitService(capability)
+	reshistory.InitService(capability)
+	distinctvalue.InitService(capability)
+	consistency.InitService(capability)
+	resrelation.InitService(capability)
+	index.InitService(capability)
+	backup.InitService(capability)
+	quota.InitService(capability)
+	idleresource.InitService(capability)
+	naming.InitService(capability)
+	tagpolicy.InitService(capability)
+	accountgroup.InitService(capability)
+	notification.InitService(capability)
+	savedfilter.InitService(capability)
+	compliance.InitService(capability)
+	asyncjob.InitService(capability)
+	maintenancewindow.InitService(capability)
+	landingzone.InitService(capability)
+	event.InitService(capability)
+	webhook.InitService(capability)
+	tenant.InitService(capability)
 
 	task.InitService(capability)
 
@@ -280,19 +443,11 @@ func (s *Service) apiSet() *restful.Container {
 
 // Healthz check whether the service is healthy.
 func (s *Service) Healthz(w http.ResponseWriter, r *http.Request) {
-	if shutdown.IsShuttingDown() {
-		logs.Errorf("service healthz check failed, current service is shutting down")
-		w.WriteHeader(http.StatusServiceUnavailable)
-		rest.WriteResp(w, rest.NewBaseResp(errf.UnHealthy, "current service is shutting down"))
-		return
-	}
-
-	if err := serviced.Healthz(r.Context(), cc.DataService().Service); err != nil {
-		logs.Errorf("etcd healthz check failed, err: %v", err)
-		rest.WriteResp(w, rest.NewBaseResp(errf.UnHealthy, "etcd healthz error, "+err.Error()))
-		return
-	}
+	health.ServeLiveness(w, r, health.Etcd(cc.DataService().Service))
+}
 
-	rest.WriteResp(w, rest.NewBaseResp(errf.OK, "healthy"))
-	return
+// Readyz check whether the service and its dependencies are ready to serve requests.
+func (s *Service) Readyz(w http.ResponseWriter, r *http.Request) {
+	health.ServeReadiness(w, r, health.Etcd(cc.DataService().Service), health.Registration(s.sd),
+		health.Checker{Name: "database", Check: s.dao.Ping})
 }
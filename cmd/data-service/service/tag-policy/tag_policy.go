@@ -0,0 +1,201 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tagpolicy tag policy service
+package tagpolicy
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	coretagpolicy "hcm/pkg/api/core/tag-policy"
+	datatagpolicy "hcm/pkg/api/data-service/tag-policy"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tabletagpolicy "hcm/pkg/dal/table/tag-policy"
+	tabletypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateTagPolicy", http.MethodPost, "/tag_policies/create", svc.CreateTagPolicy)
+	h.Add("UpdateTagPolicy", http.MethodPatch, "/tag_policies/{id}", svc.UpdateTagPolicy)
+	h.Add("ListTagPolicy", http.MethodPost, "/tag_policies/list", svc.ListTagPolicy)
+	h.Add("BatchDeleteTagPolicy", http.MethodDelete, "/tag_policies/batch", svc.BatchDeleteTagPolicy)
+
+	h.Add("CheckTagViolation", http.MethodPost, "/tag_violations/check", svc.CheckTagViolation)
+	h.Add("ListTagViolation", http.MethodPost, "/tag_violations/list", svc.ListTagViolation)
+	h.Add("GetTagComplianceReport", http.MethodPost, "/tag_violations/compliance_report",
+		svc.GetTagComplianceReport)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
+
+// CreateTagPolicy create tag policy.
+func (svc *service) CreateTagPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(datatagpolicy.TagPolicyCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	allowedValues := tabletypes.StringArray(req.AllowedValues)
+	if allowedValues == nil {
+		allowedValues = make(tabletypes.StringArray, 0)
+	}
+	model := &tabletagpolicy.TagPolicyTable{
+		BkBizID:       req.BkBizID,
+		ResType:       req.ResType,
+		TagKey:        req.TagKey,
+		AllowedValues: allowedValues,
+		Memo:          req.Memo,
+		Creator:       cts.Kit.User,
+		Reviser:       cts.Kit.User,
+	}
+
+	id, err := svc.dao.TagPolicy().Create(cts.Kit, model)
+	if err != nil {
+		logs.Errorf("create tag policy failed, err: %v, biz: %d, res_type: %s, tag_key: %s, rid: %s", err,
+			req.BkBizID, req.ResType, req.TagKey, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// UpdateTagPolicy update tag policy.
+func (svc *service) UpdateTagPolicy(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(datatagpolicy.TagPolicyUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tabletagpolicy.TagPolicyTable{
+		Memo:    req.Memo,
+		Reviser: cts.Kit.User,
+	}
+	if req.AllowedValues != nil {
+		model.AllowedValues = append(make(tabletypes.StringArray, 0), *req.AllowedValues...)
+	}
+
+	if err := svc.dao.TagPolicy().UpdateByID(cts.Kit, id, model); err != nil {
+		logs.Errorf("update tag policy failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListTagPolicy list tag policy.
+func (svc *service) ListTagPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.TagPolicy().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list tag policy failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]coretagpolicy.TagPolicy, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, convTagPolicy(one))
+	}
+
+	return &core.ListResultT[coretagpolicy.TagPolicy]{Count: result.Count, Details: details}, nil
+}
+
+func convTagPolicy(one tabletagpolicy.TagPolicyTable) coretagpolicy.TagPolicy {
+	allowedValues := []string(one.AllowedValues)
+	if allowedValues == nil {
+		allowedValues = make([]string, 0)
+	}
+
+	return coretagpolicy.TagPolicy{
+		ID:            one.ID,
+		BkBizID:       one.BkBizID,
+		ResType:       one.ResType,
+		TagKey:        one.TagKey,
+		AllowedValues: allowedValues,
+		Memo:          one.Memo,
+		Revision: core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}
+}
+
+// BatchDeleteTagPolicy batch delete tag policy.
+func (svc *service) BatchDeleteTagPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.dao.TagPolicy().Delete(cts.Kit, tools.ContainersExpression("id", req.IDs)); err != nil {
+		logs.Errorf("delete tag policy failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,244 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tagpolicy
+
+import (
+	"hcm/pkg/api/core"
+	coretagpolicy "hcm/pkg/api/core/tag-policy"
+	datatagpolicy "hcm/pkg/api/data-service/tag-policy"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tabletagpolicy "hcm/pkg/dal/table/tag-policy"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/times"
+)
+
+// CheckTagViolation check the tags of account resources against the tag policies, and replace the tag violations
+// of the account with the detected ones.
+func (svc *service) CheckTagViolation(cts *rest.Contexts) (interface{}, error) {
+	req := new(datatagpolicy.TagViolationCheckReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	policies, err := svc.listAllTagPolicy(cts.Kit)
+	if err != nil {
+		return nil, err
+	}
+
+	resTypePolicies := make(map[enumor.CloudResourceType][]coretagpolicy.TagPolicy)
+	for _, policy := range policies {
+		resTypePolicies[policy.ResType] = append(resTypePolicies[policy.ResType], policy)
+	}
+
+	violations := make([]tabletagpolicy.TagViolationTable, 0)
+	for resType, typePolicies := range resTypePolicies {
+		resources, err := svc.dao.TagViolation().ListAccountResource(cts.Kit, req.AccountID, resType)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, res := range resources {
+			for _, policy := range coretagpolicy.MatchPolicies(typePolicies, res.BkBizID, resType) {
+				reason, value, ok := policy.Check(res.Tags)
+				if ok {
+					continue
+				}
+
+				violations = append(violations, tabletagpolicy.TagViolationTable{
+					ResType:  resType,
+					ResID:    res.ID,
+					Vendor:   res.Vendor,
+					BkBizID:  res.BkBizID,
+					ResName:  res.Name,
+					PolicyID: policy.ID,
+					TagKey:   policy.TagKey,
+					TagValue: truncate(value, 255),
+					Reason:   reason,
+				})
+			}
+		}
+	}
+
+	if err = svc.dao.TagViolation().SyncAccount(cts.Kit, req.AccountID, violations); err != nil {
+		return nil, err
+	}
+
+	return &datatagpolicy.TagViolationCheckResult{Count: len(violations)}, nil
+}
+
+// listAllTagPolicy list all tag policies, the count of tag policies is limited by biz, resource type and tag key.
+func (svc *service) listAllTagPolicy(kt *kit.Kit) ([]coretagpolicy.TagPolicy, error) {
+	opt := &types.ListOption{
+		Filter: tools.AllExpression(),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	policies := make([]coretagpolicy.TagPolicy, 0)
+	for {
+		result, err := svc.dao.TagPolicy().List(kt, opt)
+		if err != nil {
+			logs.Errorf("list tag policy failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			policies = append(policies, convTagPolicy(one))
+		}
+
+		if uint(len(result.Details)) < opt.Page.Limit {
+			break
+		}
+		opt.Page.Start += uint32(opt.Page.Limit)
+	}
+
+	return policies, nil
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
+
+// ListTagViolation list tag violation.
+func (svc *service) ListTagViolation(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.TagViolation().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list tag violation failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]coretagpolicy.TagViolation, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, coretagpolicy.TagViolation{
+			ID:         one.ID,
+			ResType:    one.ResType,
+			ResID:      one.ResID,
+			Vendor:     one.Vendor,
+			AccountID:  one.AccountID,
+			BkBizID:    one.BkBizID,
+			ResName:    one.ResName,
+			PolicyID:   one.PolicyID,
+			TagKey:     one.TagKey,
+			TagValue:   one.TagValue,
+			Reason:     one.Reason,
+			DetectedAt: times.ConvStdTimeFormat(one.DetectedAt),
+			CreatedAt:  one.CreatedAt.String(),
+			UpdatedAt:  one.UpdatedAt.String(),
+		})
+	}
+
+	return &core.ListResultT[coretagpolicy.TagViolation]{Count: result.Count, Details: details}, nil
+}
+
+// GetTagComplianceReport get the tag compliance report of accounts, which counts the untagged and mistagged
+// resources of each account.
+func (svc *service) GetTagComplianceReport(cts *rest.Contexts) (interface{}, error) {
+	req := new(datatagpolicy.TagComplianceReportReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	summaries, err := svc.dao.TagViolation().Summary(cts.Kit, req.AccountIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	reportMap := make(map[string]*coretagpolicy.AccountCompliance, len(req.AccountIDs))
+	resTypeMap := make(map[string]map[enumor.CloudResourceType]*coretagpolicy.ResTypeCompliance)
+	details := make([]coretagpolicy.AccountCompliance, 0, len(req.AccountIDs))
+	for _, accountID := range req.AccountIDs {
+		if _, exists := reportMap[accountID]; exists {
+			continue
+		}
+		reportMap[accountID] = &coretagpolicy.AccountCompliance{AccountID: accountID}
+		resTypeMap[accountID] = make(map[enumor.CloudResourceType]*coretagpolicy.ResTypeCompliance)
+	}
+
+	for _, one := range summaries {
+		report, exists := reportMap[one.AccountID]
+		if !exists {
+			continue
+		}
+
+		resType, exists := resTypeMap[one.AccountID][one.ResType]
+		if !exists {
+			resType = &coretagpolicy.ResTypeCompliance{ResType: one.ResType}
+			resTypeMap[one.AccountID][one.ResType] = resType
+		}
+
+		switch one.Reason {
+		case enumor.TagMissing:
+			report.MissingCount += one.ResCount
+			resType.MissingCount += one.ResCount
+		case enumor.TagMistagged:
+			report.MistaggedCount += one.ResCount
+			resType.MistaggedCount += one.ResCount
+		}
+	}
+
+	for _, accountID := range req.AccountIDs {
+		report, exists := reportMap[accountID]
+		if !exists {
+			continue
+		}
+		// 账号去重，保证每个账号只返回一次
+		delete(reportMap, accountID)
+
+		report.ResTypes = make([]coretagpolicy.ResTypeCompliance, 0, len(resTypeMap[accountID]))
+		for _, resType := range enumor.TagPolicyResTypes {
+			if one, exists := resTypeMap[accountID][resType]; exists {
+				report.ResTypes = append(report.ResTypes, *one)
+			}
+		}
+		details = append(details, *report)
+	}
+
+	return &datatagpolicy.TagComplianceReportResult{Details: details}, nil
+}
@@ -0,0 +1,189 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tagpolicy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	datatagpolicy "hcm/pkg/api/data-service/tag-policy"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao"
+	daotagpolicy "hcm/pkg/dal/dao/tag-policy"
+	"hcm/pkg/dal/dao/types"
+	tabletagpolicy "hcm/pkg/dal/table/tag-policy"
+	tabletypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSet struct {
+	dao.Set
+	policy    *fakePolicy
+	violation *fakeViolation
+}
+
+// TagPolicy ...
+func (f fakeSet) TagPolicy() daotagpolicy.TagPolicyInterface {
+	return f.policy
+}
+
+// TagViolation ...
+func (f fakeSet) TagViolation() daotagpolicy.TagViolationInterface {
+	return f.violation
+}
+
+type fakePolicy struct {
+	daotagpolicy.TagPolicyInterface
+	policies []tabletagpolicy.TagPolicyTable
+}
+
+// List ...
+func (f *fakePolicy) List(_ *kit.Kit, _ *types.ListOption) (*types.ListResult[tabletagpolicy.TagPolicyTable],
+	error) {
+
+	return &types.ListResult[tabletagpolicy.TagPolicyTable]{Details: f.policies}, nil
+}
+
+type fakeViolation struct {
+	daotagpolicy.TagViolationInterface
+	resources map[enumor.CloudResourceType][]daotagpolicy.TagResource
+	summaries []daotagpolicy.TagViolationSummary
+	synced    []tabletagpolicy.TagViolationTable
+}
+
+// ListAccountResource ...
+func (f *fakeViolation) ListAccountResource(_ *kit.Kit, _ string, resType enumor.CloudResourceType) (
+	[]daotagpolicy.TagResource, error) {
+
+	return f.resources[resType], nil
+}
+
+// Summary ...
+func (f *fakeViolation) Summary(_ *kit.Kit, _ []string) ([]daotagpolicy.TagViolationSummary, error) {
+	return f.summaries, nil
+}
+
+// SyncAccount ...
+func (f *fakeViolation) SyncAccount(_ *kit.Kit, _ string, violations []tabletagpolicy.TagViolationTable) error {
+	f.synced = violations
+	return nil
+}
+
+func newTestContainer(set fakeSet) *restful.Container {
+	svc := &service{dao: set}
+
+	h := rest.NewHandler()
+	h.Add("CheckTagViolation", http.MethodPost, "/tag_violations/check", svc.CheckTagViolation)
+	h.Add("GetTagComplianceReport", http.MethodPost, "/tag_violations/compliance_report",
+		svc.GetTagComplianceReport)
+	ws := new(restful.WebService)
+	h.Load(ws)
+	container := restful.NewContainer()
+	container.Add(ws)
+	return container
+}
+
+func do(t *testing.T, container *restful.Container, path, body string, data interface{}) {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constant.UserKey, "admin")
+	req.Header.Set(constant.AppCodeKey, "hcm")
+	req.Header.Set(constant.RidKey, "tag-violation-test-rid")
+	recorder := httptest.NewRecorder()
+	container.ServeHTTP(recorder, req)
+
+	resp := &rest.Response{Data: data}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), resp))
+	require.Equal(t, int32(0), resp.Code, resp.Message)
+}
+
+func TestCheckTagViolation(t *testing.T) {
+	violation := &fakeViolation{resources: map[enumor.CloudResourceType][]daotagpolicy.TagResource{
+		enumor.SecurityGroupCloudResType: {
+			{ID: "sg-1", BkBizID: 1, Tags: tabletypes.StringMap{"env": "prod"}},
+			{ID: "sg-2", BkBizID: 1, Tags: tabletypes.StringMap{"env": "dev"}},
+			{ID: "sg-3", BkBizID: 2},
+		},
+	}}
+	set := fakeSet{
+		policy: &fakePolicy{policies: []tabletagpolicy.TagPolicyTable{
+			{ID: "default-env", BkBizID: constant.UnassignedBiz, ResType: enumor.SecurityGroupCloudResType,
+				TagKey: "env"},
+			{ID: "biz1-env", BkBizID: 1, ResType: enumor.SecurityGroupCloudResType, TagKey: "env",
+				AllowedValues: []string{"prod"}},
+		}},
+		violation: violation,
+	}
+
+	result := new(datatagpolicy.TagViolationCheckResult)
+	do(t, newTestContainer(set), "/tag_violations/check", `{"account_id":"account1"}`, result)
+	assert.Equal(t, 2, result.Count)
+
+	require.Len(t, violation.synced, 2)
+	reasons := make(map[string]tabletagpolicy.TagViolationTable)
+	for _, one := range violation.synced {
+		reasons[one.ResID] = one
+	}
+	// 业务1的策略限制了取值，业务2使用全局默认策略
+	assert.Equal(t, enumor.TagMistagged, reasons["sg-2"].Reason)
+	assert.Equal(t, "biz1-env", reasons["sg-2"].PolicyID)
+	assert.Equal(t, "dev", reasons["sg-2"].TagValue)
+	assert.Equal(t, enumor.TagMissing, reasons["sg-3"].Reason)
+	assert.Equal(t, "default-env", reasons["sg-3"].PolicyID)
+}
+
+func TestGetTagComplianceReport(t *testing.T) {
+	set := fakeSet{violation: &fakeViolation{summaries: []daotagpolicy.TagViolationSummary{
+		{AccountID: "account1", ResType: enumor.LoadBalancerCloudResType, Reason: enumor.TagMissing, ResCount: 1},
+		{AccountID: "account1", ResType: enumor.SecurityGroupCloudResType, Reason: enumor.TagMissing, ResCount: 2},
+		{AccountID: "account1", ResType: enumor.SecurityGroupCloudResType, Reason: enumor.TagMistagged,
+			ResCount: 3},
+		{AccountID: "other", ResType: enumor.SecurityGroupCloudResType, Reason: enumor.TagMissing, ResCount: 5},
+	}}}
+
+	result := new(datatagpolicy.TagComplianceReportResult)
+	do(t, newTestContainer(set), "/tag_violations/compliance_report",
+		`{"account_ids":["account1","account2","account1"]}`, result)
+
+	// 账号去重，没有不合规资源的账号同样返回
+	require.Len(t, result.Details, 2)
+	report := result.Details[0]
+	assert.Equal(t, "account1", report.AccountID)
+	assert.Equal(t, uint64(3), report.MissingCount)
+	assert.Equal(t, uint64(3), report.MistaggedCount)
+	require.Len(t, report.ResTypes, 2)
+	assert.Equal(t, enumor.SecurityGroupCloudResType, report.ResTypes[0].ResType)
+	assert.Equal(t, uint64(2), report.ResTypes[0].MissingCount)
+	assert.Equal(t, uint64(3), report.ResTypes[0].MistaggedCount)
+	assert.Equal(t, enumor.LoadBalancerCloudResType, report.ResTypes[1].ResType)
+
+	assert.Equal(t, "account2", result.Details[1].AccountID)
+	assert.Equal(t, uint64(0), result.Details[1].MissingCount)
+	assert.Empty(t, result.Details[1].ResTypes)
+}
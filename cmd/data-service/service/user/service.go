@@ -39,6 +39,10 @@ func InitService(cap *capability.Capability) {
 	h.Add("BatchDeleteUserCollection", http.MethodDelete, "/users/collections/batch", svc.BatchDeleteUserCollection)
 	h.Add("CreateUserCollection", http.MethodPost, "/users/collections/create", svc.CreateUserCollection)
 
+	h.Add("RecordUserRecentView", http.MethodPost, "/users/recent_views/record", svc.RecordUserRecentView)
+	h.Add("ListUserRecentView", http.MethodPost, "/users/recent_views/list", svc.ListUserRecentView)
+	h.Add("BatchDeleteUserRecentView", http.MethodDelete, "/users/recent_views/batch", svc.BatchDeleteUserRecentView)
+
 	h.Load(cap.WebService)
 }
 
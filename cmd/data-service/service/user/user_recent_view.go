@@ -0,0 +1,165 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package user
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coreuser "hcm/pkg/api/core/user"
+	dataservice "hcm/pkg/api/data-service"
+	dsuser "hcm/pkg/api/data-service/user"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableuser "hcm/pkg/dal/table/user"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// RecordUserRecentView record the resource viewed by current user, only the latest MaxRecentViewCount records of
+// each resource type are kept.
+func (svc *service) RecordUserRecentView(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsuser.UserRecentViewRecordReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tableuser.UserRecentViewTable{
+		User:    cts.Kit.User,
+		ResType: req.ResType,
+		ResID:   req.ResID,
+	}
+	if err := svc.dao.UserRecentView().Upsert(cts.Kit, model); err != nil {
+		logs.Errorf("record user recent view failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if err := svc.trimUserRecentView(cts.Kit, req); err != nil {
+		// 清理失败不影响本次访问记录，下次记录时会再次清理
+		logs.Errorf("trim user recent view failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+	}
+
+	return nil, nil
+}
+
+// trimUserRecentView delete the records of the user and resource type which are out of MaxRecentViewCount.
+func (svc *service) trimUserRecentView(kt *kit.Kit, req *dsuser.UserRecentViewRecordReq) error {
+	opt := &types.ListOption{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("user", kt.User),
+			tools.RuleEqual("res_type", req.ResType),
+		),
+		Page: &core.BasePage{
+			Start: dsuser.MaxRecentViewCount,
+			Limit: core.DefaultMaxPageLimit,
+			Sort:  "updated_at",
+			Order: core.Descending,
+		},
+		Fields: []string{"id"},
+	}
+	result, err := svc.dao.UserRecentView().List(kt, opt)
+	if err != nil {
+		return err
+	}
+
+	if len(result.Details) == 0 {
+		return nil
+	}
+
+	delIDs := make([]string, 0, len(result.Details))
+	for _, one := range result.Details {
+		delIDs = append(delIDs, one.ID)
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.UserRecentView().DeleteWithTx(kt, txn, tools.ContainersExpression("id", delIDs))
+	})
+	return err
+}
+
+// ListUserRecentView list user recent view.
+func (svc *service) ListUserRecentView(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	result, err := svc.dao.UserRecentView().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list user recent view failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list user recent view failed, err: %v", err)
+	}
+	if req.Page.Count {
+		return &dsuser.UserRecentViewListResult{Count: result.Count}, nil
+	}
+
+	details := make([]coreuser.UserRecentView, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, coreuser.UserRecentView{
+			ID:       one.ID,
+			User:     one.User,
+			ResType:  one.ResType,
+			ResID:    one.ResID,
+			ViewedAt: one.UpdatedAt.String(),
+		})
+	}
+
+	return &dsuser.UserRecentViewListResult{Details: details}, nil
+}
+
+// BatchDeleteUserRecentView delete user recent view.
+func (svc *service) BatchDeleteUserRecentView(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataservice.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.UserRecentView().DeleteWithTx(cts.Kit, txn, req.Filter)
+	})
+	if err != nil {
+		logs.Errorf("delete user recent view failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
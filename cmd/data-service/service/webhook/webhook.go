@@ -0,0 +1,473 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package webhook webhook subscription and delivery service, each webhook subscription is a consumer of the resource
+// change events, the events matched by the subscription are recorded as deliveries before its offset is committed.
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	corewebhook "hcm/pkg/api/core/webhook"
+	datawebhook "hcm/pkg/api/data-service/webhook"
+	"hcm/pkg/cdc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableevent "hcm/pkg/dal/table/event"
+	tabletypes "hcm/pkg/dal/table/types"
+	tablewebhook "hcm/pkg/dal/table/webhook"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+	"hcm/pkg/tools/times"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InitService initial the webhook service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateWebhookSubscription", http.MethodPost, "/webhooks/subscriptions/create",
+		svc.CreateWebhookSubscription)
+	h.Add("UpdateWebhookSubscription", http.MethodPatch, "/webhooks/subscriptions/{id}",
+		svc.UpdateWebhookSubscription)
+	h.Add("ListWebhookSubscription", http.MethodPost, "/webhooks/subscriptions/list", svc.ListWebhookSubscription)
+	h.Add("BatchDeleteWebhookSubscription", http.MethodDelete, "/webhooks/subscriptions/batch",
+		svc.BatchDeleteWebhookSubscription)
+
+	h.Add("BatchCreateWebhookDelivery", http.MethodPost, "/webhooks/deliveries/batch/create",
+		svc.BatchCreateWebhookDelivery)
+	h.Add("UpdateWebhookDelivery", http.MethodPatch, "/webhooks/deliveries/update", svc.UpdateWebhookDelivery)
+	h.Add("ListWebhookDelivery", http.MethodPost, "/webhooks/deliveries/list", svc.ListWebhookDelivery)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
+
+// CreateWebhookSubscription create webhook subscription, the subscription only receives the events occurred after
+// it is created.
+func (svc *service) CreateWebhookSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(datawebhook.SubscriptionCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablewebhook.SubscriptionTable{
+		Name:     req.Name,
+		Url:      req.Url,
+		Secret:   req.Secret,
+		ResTypes: resTypesToArray(req.ResTypes),
+		Actions:  actionsToArray(req.Actions),
+		BkBizIDs: req.BkBizIDs,
+		Enabled:  req.Enabled,
+		Memo:     req.Memo,
+		Creator:  cts.Kit.User,
+		Reviser:  cts.Kit.User,
+	}
+	if model.Actions == nil {
+		model.Actions = make(tabletypes.StringArray, 0)
+	}
+	if model.BkBizIDs == nil {
+		model.BkBizIDs = make(tabletypes.Int64Array, 0)
+	}
+
+	id, err := svc.dao.WebhookSubscription().Create(cts.Kit, model)
+	if err != nil {
+		logs.Errorf("create webhook subscription failed, err: %v, name: %s, rid: %s", err, req.Name, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if err = svc.initOffsets(cts.Kit, id, req.ResTypes); err != nil {
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// initOffsets 将订阅在资源类型上的消费位点初始化为当前最新的事件位点，订阅只接收之后发生的事件，避免推送历史事件
+func (svc *service) initOffsets(kt *kit.Kit, subscriptionID string, resTypes []enumor.AuditResourceType) error {
+	if len(resTypes) == 0 {
+		return nil
+	}
+
+	opt := &types.ListOption{
+		Fields: []string{"id"},
+		Filter: tools.AllExpression(),
+		Page:   &core.BasePage{Start: 0, Limit: 1, Sort: "id", Order: core.Descending},
+	}
+	result, err := svc.dao.Audit().List(kt, opt)
+	if err != nil {
+		logs.Errorf("get latest audit failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	latest := uint64(0)
+	if len(result.Details) != 0 {
+		latest = result.Details[0].ID
+	}
+
+	for _, resType := range slice.Unique(resTypes) {
+		model := &tableevent.ConsumerOffsetTable{
+			Consumer:       datawebhook.ConsumerName(subscriptionID),
+			Topic:          resType,
+			ConsumedOffset: latest,
+			Creator:        kt.User,
+			Reviser:        kt.User,
+		}
+		if err = svc.dao.EventConsumerOffset().Upsert(kt, model); err != nil {
+			logs.Errorf("init webhook subscription offset failed, err: %v, id: %s, topic: %s, rid: %s", err,
+				subscriptionID, resType, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UpdateWebhookSubscription update webhook subscription.
+func (svc *service) UpdateWebhookSubscription(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(datawebhook.SubscriptionUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dao.WebhookSubscription().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("get webhook subscription failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "webhook subscription: %s not found", id)
+	}
+	origin := result.Details[0]
+
+	model := &tablewebhook.SubscriptionTable{
+		Enabled: req.Enabled,
+		Memo:    req.Memo,
+		Reviser: cts.Kit.User,
+	}
+	if req.Name != nil {
+		model.Name = *req.Name
+	}
+	if req.Url != nil {
+		model.Url = *req.Url
+	}
+	if req.Secret != nil {
+		model.Secret = *req.Secret
+	}
+	if req.ResTypes != nil {
+		model.ResTypes = resTypesToArray(req.ResTypes)
+	}
+	if req.Actions != nil {
+		model.Actions = actionsToArray(req.Actions)
+	}
+	if req.BkBizIDs != nil {
+		model.BkBizIDs = req.BkBizIDs
+	}
+
+	if err = svc.dao.WebhookSubscription().UpdateByID(cts.Kit, id, model); err != nil {
+		logs.Errorf("update webhook subscription failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// 新增订阅的资源类型同样只接收之后发生的事件
+	added := make([]enumor.AuditResourceType, 0)
+	for _, resType := range req.ResTypes {
+		if !slice.IsItemInSlice(origin.ResTypes, string(resType)) {
+			added = append(added, resType)
+		}
+	}
+	if err = svc.initOffsets(cts.Kit, id, added); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListWebhookSubscription list webhook subscription.
+func (svc *service) ListWebhookSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.WebhookSubscription().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list webhook subscription failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]corewebhook.Subscription, 0, len(result.Details))
+	for _, one := range result.Details {
+		resTypes := make([]enumor.AuditResourceType, 0, len(one.ResTypes))
+		for _, resType := range one.ResTypes {
+			resTypes = append(resTypes, enumor.AuditResourceType(resType))
+		}
+		actions := make([]enumor.AuditAction, 0, len(one.Actions))
+		for _, action := range one.Actions {
+			actions = append(actions, enumor.AuditAction(action))
+		}
+
+		details = append(details, corewebhook.Subscription{
+			ID:       one.ID,
+			Name:     one.Name,
+			Url:      one.Url,
+			Secret:   one.Secret,
+			ResTypes: resTypes,
+			Actions:  actions,
+			BkBizIDs: one.BkBizIDs,
+			Enabled:  converter.PtrToVal(one.Enabled),
+			Memo:     one.Memo,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &core.ListResultT[corewebhook.Subscription]{Count: result.Count, Details: details}, nil
+}
+
+// BatchDeleteWebhookSubscription batch delete webhook subscription and its deliveries.
+func (svc *service) BatchDeleteWebhookSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		err := svc.dao.WebhookDelivery().DeleteWithTx(cts.Kit, txn,
+			tools.ContainersExpression("subscription_id", req.IDs))
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, svc.dao.WebhookSubscription().DeleteWithTx(cts.Kit, txn,
+			tools.ContainersExpression("id", req.IDs))
+	})
+	if err != nil {
+		logs.Errorf("delete webhook subscription failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchCreateWebhookDelivery record the events to be delivered to the webhook subscription.
+func (svc *service) BatchCreateWebhookDelivery(cts *rest.Contexts) (interface{}, error) {
+	req := new(datawebhook.DeliveryBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	now := time.Now()
+	models := make([]tablewebhook.DeliveryTable, 0, len(req.Events))
+	for i := range req.Events {
+		event := req.Events[i]
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		models = append(models, tablewebhook.DeliveryTable{
+			SubscriptionID: req.SubscriptionID,
+			EventOffset:    event.Offset,
+			ResType:        event.ResType,
+			ResID:          event.ResID,
+			Action:         event.Action,
+			Payload:        tabletypes.JsonField(payload),
+			State:          enumor.WebhookDeliveryPending,
+			NextRetryAt:    now,
+			Creator:        cts.Kit.User,
+			Reviser:        cts.Kit.User,
+		})
+	}
+
+	if err := svc.dao.WebhookDelivery().BatchCreate(cts.Kit, models); err != nil {
+		logs.Errorf("create webhook delivery failed, err: %v, subscription: %s, rid: %s", err, req.SubscriptionID,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// UpdateWebhookDelivery update the delivery state and the result of the latest delivery.
+func (svc *service) UpdateWebhookDelivery(cts *rest.Contexts) (interface{}, error) {
+	req := new(datawebhook.DeliveryUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablewebhook.DeliveryTable{
+		ID:          req.ID,
+		State:       req.State,
+		Attempts:    req.Attempts,
+		StatusCode:  req.StatusCode,
+		Reason:      req.Reason,
+		NextRetryAt: req.NextRetryAt,
+		Reviser:     cts.Kit.User,
+	}
+	if model.NextRetryAt.IsZero() {
+		model.NextRetryAt = time.Now()
+	}
+
+	if err := svc.dao.WebhookDelivery().UpdateState(cts.Kit, model); err != nil {
+		logs.Errorf("update webhook delivery failed, err: %v, id: %s, rid: %s", err, req.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListWebhookDelivery list webhook delivery.
+func (svc *service) ListWebhookDelivery(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.WebhookDelivery().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list webhook delivery failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]corewebhook.Delivery, 0, len(result.Details))
+	for _, one := range result.Details {
+		delivery := corewebhook.Delivery{
+			ID:             one.ID,
+			SubscriptionID: one.SubscriptionID,
+			EventOffset:    one.EventOffset,
+			ResType:        one.ResType,
+			ResID:          one.ResID,
+			Action:         one.Action,
+			State:          one.State,
+			Attempts:       one.Attempts,
+			StatusCode:     one.StatusCode,
+			Reason:         one.Reason,
+			NextRetryAt:    times.ConvStdTimeFormat(one.NextRetryAt),
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		}
+		if len(one.Payload) != 0 {
+			payload := new(cdc.Event)
+			if err = json.Unmarshal([]byte(one.Payload), payload); err != nil {
+				logs.Errorf("unmarshal webhook delivery %s payload failed, err: %v, rid: %s", one.ID, err,
+					cts.Kit.Rid)
+				return nil, err
+			}
+			delivery.Payload = payload
+		}
+		details = append(details, delivery)
+	}
+
+	return &core.ListResultT[corewebhook.Delivery]{Count: result.Count, Details: details}, nil
+}
+
+func resTypesToArray(resTypes []enumor.AuditResourceType) tabletypes.StringArray {
+	result := make(tabletypes.StringArray, 0, len(resTypes))
+	for _, resType := range slice.Unique(resTypes) {
+		result = append(result, string(resType))
+	}
+	return result
+}
+
+func actionsToArray(actions []enumor.AuditAction) tabletypes.StringArray {
+	if actions == nil {
+		return nil
+	}
+
+	result := make(tabletypes.StringArray, 0, len(actions))
+	for _, action := range slice.Unique(actions) {
+		result = append(result, string(action))
+	}
+	return result
+}
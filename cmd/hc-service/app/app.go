@@ -35,6 +35,7 @@ import (
 	"hcm/pkg/runtime/ctl"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
+	"hcm/pkg/tracing"
 )
 
 // Run start the hc service.
@@ -53,7 +54,7 @@ func Run(opt *options.Option) error {
 	}
 
 	shutdown.RegisterFirstShutdown(hs.finalizer)
-	shutdown.WaitShutdown(20)
+	shutdown.WaitShutdown(cc.HCService().Shutdown.WaitTimeoutSec())
 	return nil
 }
 
@@ -73,11 +74,21 @@ func (ds *hcService) prepare(opt *options.Option) error {
 
 	logs.Infof("load settings from config file success.")
 
+	// watch settings to reload them once the config file or etcd overlay changed.
+	if conf := cc.HCService().ConfigWatch; conf.Enable {
+		if err := cc.WatchSettings(opt.Sys, cc.HCService().Service.Etcd, conf); err != nil {
+			return fmt.Errorf("watch settings failed, err: %v", err)
+		}
+	}
+
 	// init metrics
 	network := cc.HCService().Network
 	metrics.InitMetrics(net.JoinHostPort(network.BindIP, strconv.Itoa(int(network.Port))))
 	adptmetric.InitCloudApiMetrics(metrics.Register())
 
+	// init tracing
+	tracing.Init(cc.HCService().Tracing, string(cc.HCServiceName))
+
 	// register hc service.
 	svcOpt := serviced.NewServiceOption(cc.HCServiceName, cc.HCService().Network, opt.Sys)
 	disOpt := serviced.DiscoveryOption{
@@ -20,7 +20,11 @@
 package cloudadaptor
 
 import (
+	"fmt"
+	"time"
+
 	"hcm/pkg/adaptor"
+	"hcm/pkg/adaptor/apiusage"
 	"hcm/pkg/adaptor/aws"
 	"hcm/pkg/adaptor/azure"
 	"hcm/pkg/adaptor/gcp"
@@ -29,13 +33,15 @@ import (
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
+	"hcm/pkg/metrics"
 )
 
-// NewCloudAdaptorClient new cloud adaptor client.
-func NewCloudAdaptorClient(dataCli *dataservice.Client) *CloudAdaptorClient {
+// NewCloudAdaptorClient new cloud adaptor client, the clients of the accounts are pooled for poolTTL.
+func NewCloudAdaptorClient(dataCli *dataservice.Client, poolTTL time.Duration) *CloudAdaptorClient {
 	return &CloudAdaptorClient{
 		adaptor:   adaptor.New(),
 		secretCli: NewSecretClient(dataCli),
+		pool:      newClientPool(poolTTL, metrics.Register()),
 	}
 }
 
@@ -43,6 +49,12 @@ func NewCloudAdaptorClient(dataCli *dataservice.Client) *CloudAdaptorClient {
 type CloudAdaptorClient struct {
 	adaptor   *adaptor.Adaptor
 	secretCli *SecretClient
+	pool      *clientPool
+}
+
+// Invalidate removes the pooled clients of the account, the clients are created again by the latest secret.
+func (cli *CloudAdaptorClient) Invalidate(accountID string) {
+	cli.pool.invalidate(accountID)
 }
 
 // Adaptor return adaptor.
@@ -56,48 +68,68 @@ func (cli *CloudAdaptorClient) TCloud(kt *kit.Kit, accountID string) (tcloud.TCl
 	if err != nil {
 		return nil, err
 	}
+	apiusage.BindAccount(enumor.TCloud, secret.CloudSecretID, accountID)
 
-	client, err := cli.adaptor.TCloud(secret)
-	if err != nil {
-		return nil, err
+	// 异步任务的客户端在超过频率限制后重试，与其他请求的客户端分开缓存
+	asyncRetry := kt.RequestSource == enumor.AsynchronousTasks
+	key := poolKey{kind: string(enumor.TCloud), accountID: accountID}
+	if asyncRetry {
+		key.kind += "-async"
 	}
-	client.SetRateLimitRetryWithRandomInterval(kt.RequestSource == enumor.AsynchronousTasks)
 
-	return client, nil
+	return pooledClientOf(cli.pool, key, secret, func() (tcloud.TCloud, error) {
+		client, err := cli.adaptor.TCloud(secret)
+		if err != nil {
+			return nil, err
+		}
+		client.SetRateLimitRetryWithRandomInterval(asyncRetry)
+
+		return client, nil
+	})
 }
 
 // Aws return aws client.
-func (cli *CloudAdaptorClient) Aws(kt *kit.Kit, accountID string) (*aws.Aws, error) {
+func (cli *CloudAdaptorClient) Aws(kt *kit.Kit, accountID string) (aws.Aws, error) {
 	secret, cloudAccountID, site, err := cli.secretCli.AwsSecret(kt, accountID)
 	if err != nil {
 		return nil, err
 	}
+	apiusage.BindAccount(enumor.Aws, secret.CloudSecretID, accountID)
 
-	return cli.adaptor.Aws(secret, cloudAccountID, site)
+	key := poolKey{kind: string(enumor.Aws), accountID: accountID}
+	return pooledClientOf(cli.pool, key, []interface{}{secret, cloudAccountID, site}, func() (aws.Aws, error) {
+		return cli.adaptor.Aws(secret, cloudAccountID, site)
+	})
 }
 
 // HuaWei return huawei client.
-func (cli *CloudAdaptorClient) HuaWei(kt *kit.Kit, accountID string) (*huawei.HuaWei, error) {
+func (cli *CloudAdaptorClient) HuaWei(kt *kit.Kit, accountID string) (huawei.HuaWei, error) {
 	secret, err := cli.secretCli.HuaWeiSecret(kt, accountID)
 	if err != nil {
 		return nil, err
 	}
 
-	return cli.adaptor.HuaWei(secret)
+	key := poolKey{kind: string(enumor.HuaWei), accountID: accountID}
+	return pooledClientOf(cli.pool, key, secret, func() (huawei.HuaWei, error) {
+		return cli.adaptor.HuaWei(secret)
+	})
 }
 
 // Gcp return gcp client.
-func (cli *CloudAdaptorClient) Gcp(kt *kit.Kit, accountID string) (*gcp.Gcp, error) {
+func (cli *CloudAdaptorClient) Gcp(kt *kit.Kit, accountID string) (gcp.Gcp, error) {
 	cred, err := cli.secretCli.GcpCredential(kt, accountID)
 	if err != nil {
 		return nil, err
 	}
 
-	return cli.adaptor.Gcp(cred)
+	key := poolKey{kind: string(enumor.Gcp), accountID: accountID}
+	return pooledClientOf(cli.pool, key, cred, func() (gcp.Gcp, error) {
+		return cli.adaptor.Gcp(cred)
+	})
 }
 
 // GcpProxy return gcp proxy client.
-func (cli *CloudAdaptorClient) GcpProxy(kt *kit.Kit, accountID string) (*gcp.Gcp, error) {
+func (cli *CloudAdaptorClient) GcpProxy(kt *kit.Kit, accountID string) (gcp.Gcp, error) {
 	cred, err := cli.secretCli.GcpRegisterCredential(kt, accountID)
 	if err != nil {
 		return nil, err
@@ -107,27 +139,32 @@ func (cli *CloudAdaptorClient) GcpProxy(kt *kit.Kit, accountID string) (*gcp.Gcp
 }
 
 // Azure return azure client.
-func (cli *CloudAdaptorClient) Azure(kt *kit.Kit, accountID string) (*azure.Azure, error) {
+func (cli *CloudAdaptorClient) Azure(kt *kit.Kit, accountID string) (azure.Azure, error) {
 	cred, err := cli.secretCli.AzureCredential(kt, accountID)
 	if err != nil {
 		return nil, err
 	}
+	apiusage.BindAccount(enumor.Azure, cred.CloudSubscriptionID, accountID)
 
-	return cli.adaptor.Azure(cred)
+	key := poolKey{kind: string(enumor.Azure), accountID: accountID}
+	return pooledClientOf(cli.pool, key, cred, func() (azure.Azure, error) {
+		return cli.adaptor.Azure(cred)
+	})
 }
 
 // AwsRoot return aws root client.
-func (cli *CloudAdaptorClient) AwsRoot(kt *kit.Kit, accountID string) (*aws.Aws, error) {
+func (cli *CloudAdaptorClient) AwsRoot(kt *kit.Kit, accountID string) (aws.Aws, error) {
 	secret, cloudAccountID, site, err := cli.secretCli.AwsRootSecret(kt, accountID)
 	if err != nil {
 		return nil, err
 	}
+	apiusage.BindAccount(enumor.Aws, secret.CloudSecretID, accountID)
 
 	return cli.adaptor.Aws(secret, cloudAccountID, enumor.AccountSiteType(site))
 }
 
 // GcpRoot return gcp client.
-func (cli *CloudAdaptorClient) GcpRoot(kt *kit.Kit, accountID string) (*gcp.Gcp, error) {
+func (cli *CloudAdaptorClient) GcpRoot(kt *kit.Kit, accountID string) (gcp.Gcp, error) {
 	cred, err := cli.secretCli.GcpRootCredential(kt, accountID)
 	if err != nil {
 		return nil, err
@@ -137,7 +174,7 @@ func (cli *CloudAdaptorClient) GcpRoot(kt *kit.Kit, accountID string) (*gcp.Gcp,
 }
 
 // HuaWeiRoot return huawei client.
-func (cli *CloudAdaptorClient) HuaWeiRoot(kt *kit.Kit, accountID string) (*huawei.HuaWei, error) {
+func (cli *CloudAdaptorClient) HuaWeiRoot(kt *kit.Kit, accountID string) (huawei.HuaWei, error) {
 	secret, err := cli.secretCli.HuaWeiRootSecret(kt, accountID)
 	if err != nil {
 		return nil, err
@@ -147,11 +184,68 @@ func (cli *CloudAdaptorClient) HuaWeiRoot(kt *kit.Kit, accountID string) (*huawe
 }
 
 // AzureRoot return azure client.
-func (cli *CloudAdaptorClient) AzureRoot(kt *kit.Kit, accountID string) (*azure.Azure, error) {
+func (cli *CloudAdaptorClient) AzureRoot(kt *kit.Kit, accountID string) (azure.Azure, error) {
 	cred, err := cli.secretCli.AzureRootCredential(kt, accountID)
 	if err != nil {
 		return nil, err
 	}
+	apiusage.BindAccount(enumor.Azure, cred.CloudSubscriptionID, accountID)
 
 	return cli.adaptor.Azure(cred)
 }
+
+// CheckCredential checks if the secret of the account is still valid by getting the account info from cloud.
+func (cli *CloudAdaptorClient) CheckCredential(kt *kit.Kit, accountID string) error {
+	info, err := cli.secretCli.data.Global.Cloud.GetResBasicInfo(kt, enumor.AccountCloudResType, accountID)
+	if err != nil {
+		return fmt.Errorf("get account %s basic info failed, err: %v", accountID, err)
+	}
+
+	switch info.Vendor {
+	case enumor.TCloud:
+		client, err := cli.TCloud(kt, accountID)
+		if err != nil {
+			return err
+		}
+		_, err = client.GetAccountInfoBySecret(kt)
+		return err
+	case enumor.Aws:
+		client, err := cli.Aws(kt, accountID)
+		if err != nil {
+			return err
+		}
+		_, err = client.GetAccountInfoBySecret(kt)
+		return err
+	case enumor.Azure:
+		client, err := cli.Azure(kt, accountID)
+		if err != nil {
+			return err
+		}
+		_, err = client.GetAccountInfoBySecret(kt)
+		return err
+	case enumor.HuaWei:
+		secret, err := cli.secretCli.HuaWeiSecret(kt, accountID)
+		if err != nil {
+			return err
+		}
+		client, err := cli.adaptor.HuaWei(secret)
+		if err != nil {
+			return err
+		}
+		_, err = client.GetAccountInfoBySecret(kt, secret.CloudSecretID)
+		return err
+	case enumor.Gcp:
+		cred, err := cli.secretCli.GcpCredential(kt, accountID)
+		if err != nil {
+			return err
+		}
+		client, err := cli.adaptor.Gcp(cred)
+		if err != nil {
+			return err
+		}
+		_, err = client.GetAccountInfoBySecret(kt, string(cred.Json))
+		return err
+	default:
+		return fmt.Errorf("vendor %s does not support credential check", info.Vendor)
+	}
+}
@@ -0,0 +1,161 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloudadaptor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"hcm/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// poolKey 客户端池的key，kind区分同一账号下不同用途的客户端，如根账号、异步任务使用的客户端。
+// 各云厂商的区域客户端是在调用时按区域从账号客户端的凭据创建的，所以账号客户端可以在各区域复用。
+type poolKey struct {
+	kind      string
+	accountID string
+}
+
+type pooledClient struct {
+	client interface{}
+	// fingerprint 创建客户端时使用的秘钥的摘要，秘钥轮转后摘要变化，需要重新创建客户端
+	fingerprint string
+	expireAt    time.Time
+}
+
+// clientPool 按账号缓存云厂商的客户端，避免每个请求都重新创建客户端，导致重复进行凭据的鉴权握手(如azure的token获取)。
+// 客户端在ttl后过期重建，账号秘钥变化时立即重建。
+type clientPool struct {
+	ttl time.Duration
+
+	lock    sync.Mutex
+	clients map[poolKey]*pooledClient
+
+	counter *prometheus.CounterVec
+}
+
+func newClientPool(ttl time.Duration, reg prometheus.Registerer) *clientPool {
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metrics.CloudApiSubSys,
+		Name:      "client_pool_total_count",
+		Help:      "the total count to get cloud client from the client pool, result is hit or miss",
+	}, []string{"kind", "result"})
+	if err := reg.Register(counter); err != nil {
+		// 重复创建客户端池时(如测试中)复用已注册的指标
+		registered, ok := err.(prometheus.AlreadyRegisteredError)
+		if !ok {
+			panic(err)
+		}
+		counter = registered.ExistingCollector.(*prometheus.CounterVec)
+	}
+
+	return &clientPool{
+		ttl:     ttl,
+		clients: make(map[poolKey]*pooledClient),
+		counter: counter,
+	}
+}
+
+// get returns the pooled client which is created by the secret of the same fingerprint and not expired.
+func (p *clientPool) get(key poolKey, fingerprint string) (interface{}, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	pooled, exists := p.clients[key]
+	if !exists || pooled.fingerprint != fingerprint || time.Now().After(pooled.expireAt) {
+		p.counter.With(prometheus.Labels{"kind": key.kind, "result": "miss"}).Inc()
+		return nil, false
+	}
+
+	p.counter.With(prometheus.Labels{"kind": key.kind, "result": "hit"}).Inc()
+	return pooled.client, true
+}
+
+func (p *clientPool) put(key poolKey, fingerprint string, client interface{}) {
+	now := time.Now()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	// 清理过期的客户端，避免已删除账号的客户端一直占用内存
+	for k, pooled := range p.clients {
+		if now.After(pooled.expireAt) {
+			delete(p.clients, k)
+		}
+	}
+
+	p.clients[key] = &pooledClient{client: client, fingerprint: fingerprint, expireAt: now.Add(p.ttl)}
+}
+
+// invalidate removes all the pooled clients of the account.
+func (p *clientPool) invalidate(accountID string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for k := range p.clients {
+		if k.accountID == accountID {
+			delete(p.clients, k)
+		}
+	}
+}
+
+// pooledClientOf returns the pooled client of the account if it is created by the same secret, otherwise a new
+// client is created by build and put into the pool. the pool is bypassed if its ttl is not positive.
+func pooledClientOf[T any](p *clientPool, key poolKey, secret interface{}, build func() (T, error)) (T, error) {
+	if p == nil || p.ttl <= 0 {
+		return build()
+	}
+
+	fingerprint, err := secretFingerprint(secret)
+	if err != nil {
+		return build()
+	}
+
+	if client, ok := p.get(key, fingerprint); ok {
+		if typed, ok := client.(T); ok {
+			return typed, nil
+		}
+	}
+
+	client, err := build()
+	if err != nil {
+		return client, err
+	}
+
+	p.put(key, fingerprint, client)
+	return client, nil
+}
+
+// secretFingerprint returns the digest of the secret, the secret itself is not kept in the pool key.
+func secretFingerprint(secret interface{}) (string, error) {
+	raw, err := json.Marshal(secret)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,106 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloudadaptor
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"hcm/pkg/adaptor/types"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient struct {
+	secretID string
+}
+
+func TestClientPool(t *testing.T) {
+	pool := newClientPool(time.Hour, prometheus.NewRegistry())
+
+	builds := 0
+	get := func(accountID string, secret *types.BaseSecret) *fakeClient {
+		key := poolKey{kind: "tcloud", accountID: accountID}
+		client, err := pooledClientOf(pool, key, secret, func() (*fakeClient, error) {
+			builds++
+			return &fakeClient{secretID: secret.CloudSecretID}, nil
+		})
+		require.NoError(t, err)
+		return client
+	}
+
+	secret := &types.BaseSecret{CloudSecretID: "id", CloudSecretKey: "key"}
+	first := get("account-1", secret)
+	assert.Same(t, first, get("account-1", &types.BaseSecret{CloudSecretID: "id", CloudSecretKey: "key"}))
+	assert.Equal(t, 1, builds)
+	assert.Equal(t, float64(1), testutil.ToFloat64(pool.counter.WithLabelValues("tcloud", "hit")))
+
+	// the client of another account is not shared.
+	assert.NotSame(t, first, get("account-2", secret))
+	assert.Equal(t, 2, builds)
+
+	// the client is created again after the secret is rotated.
+	rotated := get("account-1", &types.BaseSecret{CloudSecretID: "id", CloudSecretKey: "rotated"})
+	assert.NotSame(t, first, rotated)
+	assert.Equal(t, 3, builds)
+
+	pool.invalidate("account-1")
+	assert.NotSame(t, rotated, get("account-1", &types.BaseSecret{CloudSecretID: "id", CloudSecretKey: "rotated"}))
+	assert.Equal(t, 4, builds)
+
+	// expired client is removed from the pool.
+	pool.lock.Lock()
+	for _, pooled := range pool.clients {
+		pooled.expireAt = time.Now().Add(-time.Second)
+	}
+	pool.lock.Unlock()
+	get("account-3", secret)
+	assert.Len(t, pool.clients, 1)
+}
+
+func TestClientPoolDisabled(t *testing.T) {
+	pool := newClientPool(0, prometheus.NewRegistry())
+
+	var lock sync.Mutex
+	builds := 0
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := pooledClientOf(pool, poolKey{kind: "aws", accountID: "account"}, "secret",
+				func() (*fakeClient, error) {
+					lock.Lock()
+					builds++
+					lock.Unlock()
+					return new(fakeClient), nil
+				})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 10, builds)
+	assert.Empty(t, pool.clients)
+}
@@ -24,9 +24,12 @@ import (
 	"fmt"
 
 	"hcm/pkg/adaptor/types"
+	protoaudit "hcm/pkg/api/data-service/audit"
 	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/credential"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
+	"hcm/pkg/logs"
 )
 
 // SecretClient used to get secret by account id from data-service.
@@ -59,6 +62,10 @@ func (cli *SecretClient) TCloudSecret(kt *kit.Kit, accountID string) (*types.Bas
 		CloudSecretKey: account.Extension.CloudSecretKey,
 	}
 
+	if err := credential.ResolveFields(kt, accountID, &secret.CloudSecretID, &secret.CloudSecretKey); err != nil {
+		return nil, err
+	}
+
 	if err := secret.Validate(); err != nil {
 		return nil, err
 	}
@@ -87,6 +94,10 @@ func (cli *SecretClient) AwsSecret(kt *kit.Kit, accountID string) (
 		CloudSecretID:  account.Extension.CloudSecretID,
 		CloudSecretKey: account.Extension.CloudSecretKey,
 	}
+	if err := credential.ResolveFields(kt, accountID, &secret.CloudSecretID, &secret.CloudSecretKey); err != nil {
+		return nil, "", "", err
+	}
+
 	if err := secret.Validate(); err != nil {
 		return nil, "", "", err
 	}
@@ -114,6 +125,10 @@ func (cli *SecretClient) HuaWeiSecret(kt *kit.Kit, accountID string) (*types.Bas
 		CloudSecretKey: account.Extension.CloudSecretKey,
 	}
 
+	if err := credential.ResolveFields(kt, accountID, &secret.CloudSecretID, &secret.CloudSecretKey); err != nil {
+		return nil, err
+	}
+
 	if err := secret.Validate(); err != nil {
 		return nil, err
 	}
@@ -143,6 +158,10 @@ func (cli *SecretClient) AzureCredential(kt *kit.Kit, accountID string) (*types.
 		CloudClientSecretKey: account.Extension.CloudClientSecretKey,
 	}
 
+	if err := credential.ResolveFields(kt, accountID, &cred.CloudClientSecretKey); err != nil {
+		return nil, err
+	}
+
 	if err := cred.Validate(); err != nil {
 		return nil, err
 	}
@@ -165,12 +184,17 @@ func (cli *SecretClient) GcpCredential(kt *kit.Kit, accountID string) (*types.Gc
 		return nil, errors.New("gcp account extension is nil")
 	}
 
+	secretKey, err := credential.Resolve(kt, accountID, account.Extension.CloudServiceSecretKey)
+	if err != nil {
+		return nil, err
+	}
+
 	cred := &types.GcpCredential{
 		CloudProjectID: account.Extension.CloudProjectID,
-		Json:           []byte(account.Extension.CloudServiceSecretKey),
+		Json:           []byte(secretKey),
 	}
 
-	if err := cred.Validate(); err != nil {
+	if err = cred.Validate(); err != nil {
 		return nil, err
 	}
 
@@ -188,9 +212,14 @@ func (cli *SecretClient) GcpRegisterCredential(kt *kit.Kit, accountID string) (*
 		return nil, errors.New("gcp account extension is nil")
 	}
 
+	secretKey, err := credential.Resolve(kt, accountID, account.Extension.CloudServiceSecretKey)
+	if err != nil {
+		return nil, err
+	}
+
 	cred := &types.GcpCredential{
 		CloudProjectID: account.Extension.CloudProjectID,
-		Json:           []byte(account.Extension.CloudServiceSecretKey),
+		Json:           []byte(secretKey),
 	}
 
 	if err = cred.Validate(); err != nil {
@@ -218,6 +247,10 @@ func (cli *SecretClient) AwsRootSecret(kt *kit.Kit, accountID string) (*types.Ba
 		CloudSecretKey: account.Extension.CloudSecretKey,
 	}
 
+	if err := credential.ResolveFields(kt, "", &secret.CloudSecretID, &secret.CloudSecretKey); err != nil {
+		return nil, "", "", err
+	}
+
 	if err := secret.Validate(); err != nil {
 		return nil, "", "", err
 	}
@@ -236,12 +269,17 @@ func (cli *SecretClient) GcpRootCredential(kt *kit.Kit, accountID string) (*type
 		return nil, errors.New("gcp root account extension is nil")
 	}
 
+	secretKey, err := credential.Resolve(kt, "", account.Extension.CloudServiceSecretKey)
+	if err != nil {
+		return nil, err
+	}
+
 	cred := &types.GcpCredential{
 		CloudProjectID: account.Extension.CloudProjectID,
-		Json:           []byte(account.Extension.CloudServiceSecretKey),
+		Json:           []byte(secretKey),
 	}
 
-	if err := cred.Validate(); err != nil {
+	if err = cred.Validate(); err != nil {
 		return nil, err
 	}
 
@@ -264,6 +302,10 @@ func (cli *SecretClient) HuaWeiRootSecret(kt *kit.Kit, accountID string) (*types
 		CloudSecretKey: account.Extension.CloudSecretKey,
 	}
 
+	if err := credential.ResolveFields(kt, "", &secret.CloudSecretID, &secret.CloudSecretKey); err != nil {
+		return nil, err
+	}
+
 	if err := secret.Validate(); err != nil {
 		return nil, err
 	}
@@ -289,9 +331,33 @@ func (cli *SecretClient) AzureRootCredential(kt *kit.Kit, accountID string) (*ty
 		CloudClientSecretKey: account.Extension.CloudClientSecretKey,
 	}
 
+	if err := credential.ResolveFields(kt, "", &cred.CloudClientSecretKey); err != nil {
+		return nil, err
+	}
+
 	if err := cred.Validate(); err != nil {
 		return nil, err
 	}
 
 	return cred, nil
 }
+
+// NewSecretAuditor new auditor that records the account secret access from external credential provider.
+func NewSecretAuditor(dataCli *dataservice.Client) credential.Auditor {
+	return func(kt *kit.Kit, accountID string, ref credential.Reference) {
+		req := &protoaudit.CloudResourceOperationAuditReq{
+			Operations: []protoaudit.CloudResourceOperationInfo{
+				{
+					ResType: enumor.AccountAuditResType,
+					ResID:   accountID,
+					Action:  protoaudit.AccessSecret,
+				},
+			},
+		}
+		// 审计失败不影响秘钥的使用
+		if err := dataCli.Global.Audit.CloudResourceOperationAudit(kt.Ctx, kt.Header(), req); err != nil {
+			logs.Errorf("audit account %s secret access failed, ref: %s, err: %v, rid: %s", accountID, ref.String(),
+				err, kt.Rid)
+		}
+	}
+}
@@ -81,34 +81,40 @@ func (cli *client) Disk(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskOption
 	addSlice, updateMap, delCloudIDs := common.Diff[adaptordisk.AwsDisk, *coredisk.Disk[coredisk.AwsExtension]](
 		diskFromCloud, diskFromDB, isDiskChange)
 
-	if len(delCloudIDs) > 0 {
-		if err := cli.deleteDisk(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
-			return nil, err
-		}
+	if len(addSlice) == 0 && len(updateMap) == 0 && len(delCloudIDs) == 0 {
+		return new(SyncResult), nil
 	}
 
-	if len(addSlice) > 0 {
-		if err = cli.createDisk(kt, params.AccountID, params.Region, addSlice); err != nil {
-			return nil, err
-		}
+	// 删除、更新、创建合并为一次请求，由 data-service 在同一个事务中完成，减少同步的请求次数
+	upsertReq := &disk.DiskExtBatchUpsertReq[coredisk.AwsExtension]{
+		Creates: convDiskCreateReq(params.AccountID, params.Region, addSlice),
+		Updates: convDiskUpdateReq(updateMap),
 	}
 
-	if len(updateMap) > 0 {
-		if err = cli.updateDisk(kt, params.AccountID, updateMap); err != nil {
+	if len(delCloudIDs) > 0 {
+		if err = cli.checkDiskDeletedFromCloud(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 			return nil, err
 		}
+		upsertReq.AccountID = params.AccountID
+		upsertReq.DeleteCloudIDs = delCloudIDs
 	}
 
+	if _, err = cli.dbCli.Aws.BatchUpsertDisk(kt, upsertReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch upsert disk failed, err: %v, rid: %s", enumor.Aws,
+			err, kt.Rid)
+		return nil, err
+	}
+
+	logs.Infof("[%s] sync disk success, accountID: %s, create count: %d, update count: %d, delete count: %d, "+
+		"rid: %s", enumor.Aws, params.AccountID, len(addSlice), len(updateMap), len(delCloudIDs), kt.Rid)
+
 	return new(SyncResult), nil
 }
 
-func (cli *client) updateDisk(kt *kit.Kit, accountID string, updateMap map[string]adaptordisk.AwsDisk) error {
+func convDiskUpdateReq(
+	updateMap map[string]adaptordisk.AwsDisk) disk.DiskExtBatchUpdateReq[coredisk.AwsExtension] {
 
-	if len(updateMap) <= 0 {
-		return fmt.Errorf("updateMap is <= 0, not update")
-	}
-
-	disks := make([]*disk.DiskExtUpdateReq[coredisk.AwsExtension], 0)
+	updateReq := make(disk.DiskExtBatchUpdateReq[coredisk.AwsExtension], 0, len(updateMap))
 
 	for id, one := range updateMap {
 		name := ""
@@ -137,7 +143,7 @@ func (cli *client) updateDisk(kt *kit.Kit, accountID string, updateMap map[strin
 			}
 		}
 
-		disk := &disk.DiskExtUpdateReq[coredisk.AwsExtension]{
+		updateReq = append(updateReq, &disk.DiskExtUpdateReq[coredisk.AwsExtension]{
 			ID:           id,
 			Status:       converter.PtrToVal(one.State),
 			Name:         name,
@@ -146,34 +152,16 @@ func (cli *client) updateDisk(kt *kit.Kit, accountID string, updateMap map[strin
 				Attachment: attachments,
 				Encrypted:  one.Encrypted,
 			},
-		}
-
-		disks = append(disks, disk)
-	}
-
-	var updateReq disk.DiskExtBatchUpdateReq[coredisk.AwsExtension]
-	for _, disk := range disks {
-		updateReq = append(updateReq, disk)
-	}
-	if _, err := cli.dbCli.Aws.BatchUpdateDisk(kt.Ctx, kt.Header(), &updateReq); err != nil {
-		logs.Errorf("[%s] request dataservice aws BatchUpdateDisk failed, err: %v, rid: %s", enumor.Aws,
-			err, kt.Rid)
-		return err
+		})
 	}
 
-	logs.Infof("[%s] sync disk to update disk success, accountID: %s, count: %d, rid: %s", enumor.Aws,
-		accountID, len(updateMap), kt.Rid)
-
-	return nil
+	return updateReq
 }
 
-func (cli *client) createDisk(kt *kit.Kit, accountID string, region string, addSlice []adaptordisk.AwsDisk) error {
-
-	if len(addSlice) <= 0 {
-		return fmt.Errorf("addSlice is <= 0, not create")
-	}
+func convDiskCreateReq(accountID string, region string,
+	addSlice []adaptordisk.AwsDisk) disk.DiskExtBatchCreateReq[coredisk.AwsExtension] {
 
-	var createReq disk.DiskExtBatchCreateReq[coredisk.AwsExtension]
+	createReq := make(disk.DiskExtBatchCreateReq[coredisk.AwsExtension], 0, len(addSlice))
 
 	for _, one := range addSlice {
 		attachments := make([]*coredisk.AwsDiskAttachment, 0)
@@ -202,7 +190,7 @@ func (cli *client) createDisk(kt *kit.Kit, accountID string, region string, addS
 			}
 		}
 
-		disk := &disk.DiskExtCreateReq[coredisk.AwsExtension]{
+		createReq = append(createReq, &disk.DiskExtCreateReq[coredisk.AwsExtension]{
 			AccountID:    accountID,
 			Name:         name,
 			CloudID:      converter.PtrToVal(one.VolumeId),
@@ -218,28 +206,15 @@ func (cli *client) createDisk(kt *kit.Kit, accountID string, region string, addS
 				Attachment: attachments,
 				Encrypted:  one.Encrypted,
 			},
-		}
-
-		createReq = append(createReq, disk)
-	}
-
-	_, err := cli.dbCli.Aws.BatchCreateDisk(kt.Ctx, kt.Header(), &createReq)
-	if err != nil {
-		logs.Errorf("[%s] request dataservice to create aws disk failed, err: %v, rid: %s", enumor.Aws,
-			err, kt.Rid)
-		return err
+		})
 	}
 
-	logs.Infof("[%s] sync disk to create disk success, accountID: %s, count: %d, rid: %s", enumor.Aws,
-		accountID, len(addSlice), kt.Rid)
-
-	return nil
+	return createReq
 }
 
-func (cli *client) deleteDisk(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
-	if len(delCloudIDs) <= 0 {
-		return fmt.Errorf("delCloudIDs is <= 0, not delete")
-	}
+// checkDiskDeletedFromCloud 删除前确认云盘已从云上删除
+func (cli *client) checkDiskDeletedFromCloud(kt *kit.Kit, accountID string, region string,
+	delCloudIDs []string) error {
 
 	checkParams := &SyncBaseParams{
 		AccountID: accountID,
@@ -257,10 +232,22 @@ func (cli *client) deleteDisk(kt *kit.Kit, accountID string, region string, delC
 		return fmt.Errorf("validate disk not exist failed, before delete")
 	}
 
+	return nil
+}
+
+func (cli *client) deleteDisk(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if len(delCloudIDs) <= 0 {
+		return fmt.Errorf("delCloudIDs is <= 0, not delete")
+	}
+
+	if err := cli.checkDiskDeletedFromCloud(kt, accountID, region, delCloudIDs); err != nil {
+		return err
+	}
+
 	deleteReq := &disk.DiskDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
-	if _, err = cli.dbCli.Global.DeleteDisk(kt.Ctx, kt.Header(), deleteReq); err != nil {
+	if _, err := cli.dbCli.Global.DeleteDisk(kt.Ctx, kt.Header(), deleteReq); err != nil {
 		logs.Errorf("[%s] request dataservice to batch delete disk failed, err: %v, rid: %s", enumor.Aws,
 			err, kt.Rid)
 		return err
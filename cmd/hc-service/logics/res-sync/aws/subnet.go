@@ -29,7 +29,6 @@ import (
 	"hcm/pkg/adaptor/types/subnet"
 	"hcm/pkg/api/core"
 	cloudcore "hcm/pkg/api/core/cloud"
-	dataservice "hcm/pkg/api/data-service"
 	"hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
@@ -117,8 +116,9 @@ func (cli *client) deleteSubnet(kt *kit.Kit, accountID string, region string, de
 		return fmt.Errorf("validate subnet not exist failed, before delete")
 	}
 
-	deleteReq := &dataservice.BatchDeleteReq{
-		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
+	deleteReq := &cloud.SubnetBatchDeleteReq{
+		Filter:         tools.ContainersExpression("cloud_id", delCloudIDs),
+		SkipInUseCheck: true,
 	}
 	if err := cli.dbCli.Global.Subnet.BatchDelete(kt.Ctx, kt.Header(), deleteReq); err != nil {
 		logs.Errorf("[%s] request dataservice to batch delete subnet failed, err: %v, rid: %s", enumor.Aws, err, kt.Rid)
@@ -27,7 +27,7 @@ import (
 
 // Interface support resource sync.
 type Interface interface {
-	CloudCli() *azure.Azure
+	CloudCli() azure.Azure
 
 	Cvm(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmOption) (*SyncResult, error)
 	CvmWithRelRes(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmWithRelResOption) (*SyncResult, error)
@@ -70,7 +70,7 @@ type Interface interface {
 var _ Interface = new(client)
 
 // NewClient new client.
-func NewClient(dbCli *dataservice.Client, cloudCli *azure.Azure) Interface {
+func NewClient(dbCli *dataservice.Client, cloudCli azure.Azure) Interface {
 	return &client{
 		dbCli:    dbCli,
 		cloudCli: cloudCli,
@@ -79,11 +79,11 @@ func NewClient(dbCli *dataservice.Client, cloudCli *azure.Azure) Interface {
 
 type client struct {
 	accountID string
-	cloudCli  *azure.Azure
+	cloudCli  azure.Azure
 	dbCli     *dataservice.Client
 }
 
 // CloudCli ...
-func (cli *client) CloudCli() *azure.Azure {
+func (cli *client) CloudCli() azure.Azure {
 	return cli.cloudCli
 }
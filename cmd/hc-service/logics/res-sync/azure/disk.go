@@ -80,39 +80,42 @@ func (cli *client) Disk(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskOption
 	addSlice, updateMap, delCloudIDs := common.Diff[typesdisk.AzureDisk, *coredisk.Disk[coredisk.AzureExtension]](
 		diskFromCloud, diskFromDB, isDiskChange)
 
-	if len(delCloudIDs) > 0 {
-		if err := cli.deleteDisk(kt, params.AccountID, params.ResourceGroupName, delCloudIDs); err != nil {
-			return nil, err
-		}
+	if len(addSlice) == 0 && len(updateMap) == 0 && len(delCloudIDs) == 0 {
+		return new(SyncResult), nil
 	}
 
-	if len(addSlice) > 0 {
-		if err = cli.createDisk(kt, params.AccountID, params.ResourceGroupName, addSlice); err != nil {
-			return nil, err
-		}
+	// 删除、更新、创建合并为一次请求，由 data-service 在同一个事务中完成，减少同步的请求次数
+	upsertReq := &disk.DiskExtBatchUpsertReq[coredisk.AzureExtension]{
+		Creates: convDiskCreateReq(params.AccountID, params.ResourceGroupName, addSlice),
+		Updates: convDiskUpdateReq(params.ResourceGroupName, updateMap),
 	}
 
-	if len(updateMap) > 0 {
-		if err = cli.updateDisk(kt, params.AccountID, params.ResourceGroupName, updateMap); err != nil {
+	if len(delCloudIDs) > 0 {
+		if err = cli.checkDiskDeletedFromCloud(kt, params.AccountID, params.ResourceGroupName, delCloudIDs); err != nil {
 			return nil, err
 		}
+		upsertReq.AccountID = params.AccountID
+		upsertReq.DeleteCloudIDs = delCloudIDs
 	}
 
-	return new(SyncResult), nil
-}
+	if _, err = cli.dbCli.Azure.BatchUpsertDisk(kt, upsertReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch upsert disk failed, err: %v, rid: %s", enumor.Azure,
+			err, kt.Rid)
+		return nil, err
+	}
 
-func (cli *client) updateDisk(kt *kit.Kit, accountID string, resGroupName string,
-	updateMap map[string]typesdisk.AzureDisk) error {
+	logs.Infof("[%s] sync disk success, accountID: %s, create count: %d, update count: %d, delete count: %d, "+
+		"rid: %s", enumor.Azure, params.AccountID, len(addSlice), len(updateMap), len(delCloudIDs), kt.Rid)
 
-	if len(updateMap) <= 0 {
-		return fmt.Errorf("updateMap is <= 0, not update")
-	}
+	return new(SyncResult), nil
+}
 
-	disks := make([]*disk.DiskExtUpdateReq[coredisk.AzureExtension], 0)
+func convDiskUpdateReq(resGroupName string,
+	updateMap map[string]typesdisk.AzureDisk) disk.DiskExtBatchUpdateReq[coredisk.AzureExtension] {
 
+	updateReq := make(disk.DiskExtBatchUpdateReq[coredisk.AzureExtension], 0, len(updateMap))
 	for id, one := range updateMap {
-
-		oneDisk := &disk.DiskExtUpdateReq[coredisk.AzureExtension]{
+		updateReq = append(updateReq, &disk.DiskExtUpdateReq[coredisk.AzureExtension]{
 			ID:           id,
 			Status:       converter.PtrToVal(one.Status),
 			IsSystemDisk: one.Boot,
@@ -123,37 +126,18 @@ func (cli *client) updateDisk(kt *kit.Kit, accountID string, resGroupName string
 				SKUTier:           one.SKUTier,
 				Zones:             one.Zones,
 			},
-		}
-		disks = append(disks, oneDisk)
+		})
 	}
 
-	var updateReq disk.DiskExtBatchUpdateReq[coredisk.AzureExtension]
-	for _, disk := range disks {
-		updateReq = append(updateReq, disk)
-	}
-	if _, err := cli.dbCli.Azure.BatchUpdateDisk(kt.Ctx, kt.Header(), &updateReq); err != nil {
-		logs.Errorf("[%s] request dataservice azure BatchUpdateDisk failed, err: %v, rid: %s", enumor.Azure,
-			err, kt.Rid)
-		return err
-	}
-
-	logs.Infof("[%s] sync disk to update disk success, accountID: %s, count: %d, rid: %s", enumor.Azure,
-		accountID, len(updateMap), kt.Rid)
-
-	return nil
+	return updateReq
 }
 
-func (cli *client) createDisk(kt *kit.Kit, accountID string, resGroupName string,
-	addSlice []typesdisk.AzureDisk) error {
-
-	if len(addSlice) <= 0 {
-		return fmt.Errorf("addSlice is <= 0, not create")
-	}
-
-	var createReq disk.DiskExtBatchCreateReq[coredisk.AzureExtension]
+func convDiskCreateReq(accountID string, resGroupName string,
+	addSlice []typesdisk.AzureDisk) disk.DiskExtBatchCreateReq[coredisk.AzureExtension] {
 
+	createReq := make(disk.DiskExtBatchCreateReq[coredisk.AzureExtension], 0, len(addSlice))
 	for _, one := range addSlice {
-		disk := &disk.DiskExtCreateReq[coredisk.AzureExtension]{
+		createReq = append(createReq, &disk.DiskExtCreateReq[coredisk.AzureExtension]{
 			AccountID:    accountID,
 			Name:         converter.PtrToVal(one.Name),
 			CloudID:      converter.PtrToVal(one.ID),
@@ -172,31 +156,16 @@ func (cli *client) createDisk(kt *kit.Kit, accountID string, resGroupName string
 				SKUTier:           one.SKUTier,
 				Zones:             one.Zones,
 			},
-		}
-
-		createReq = append(createReq, disk)
-	}
-
-	_, err := cli.dbCli.Azure.BatchCreateDisk(kt.Ctx, kt.Header(), &createReq)
-	if err != nil {
-		logs.Errorf("[%s] request dataservice to create azure disk failed, err: %v, rid: %s", enumor.Azure,
-			err, kt.Rid)
-		return err
+		})
 	}
 
-	logs.Infof("[%s] sync disk to create disk success, accountID: %s, count: %d, rid: %s", enumor.Azure,
-		accountID, len(addSlice), kt.Rid)
-
-	return nil
+	return createReq
 }
 
-func (cli *client) deleteDisk(kt *kit.Kit, accountID string, resGroupName string,
+// checkDiskDeletedFromCloud 删除前确认云盘已从云上删除
+func (cli *client) checkDiskDeletedFromCloud(kt *kit.Kit, accountID string, resGroupName string,
 	delCloudIDs []string) error {
 
-	if len(delCloudIDs) <= 0 {
-		return fmt.Errorf("delCloudIDs is <= 0, not delete")
-	}
-
 	checkParams := &SyncBaseParams{
 		AccountID:         accountID,
 		ResourceGroupName: resGroupName,
@@ -213,10 +182,24 @@ func (cli *client) deleteDisk(kt *kit.Kit, accountID string, resGroupName string
 		return fmt.Errorf("validate disk not exist failed, before delete")
 	}
 
+	return nil
+}
+
+func (cli *client) deleteDisk(kt *kit.Kit, accountID string, resGroupName string,
+	delCloudIDs []string) error {
+
+	if len(delCloudIDs) <= 0 {
+		return fmt.Errorf("delCloudIDs is <= 0, not delete")
+	}
+
+	if err := cli.checkDiskDeletedFromCloud(kt, accountID, resGroupName, delCloudIDs); err != nil {
+		return err
+	}
+
 	deleteReq := &disk.DiskDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
-	if _, err = cli.dbCli.Global.DeleteDisk(kt.Ctx, kt.Header(), deleteReq); err != nil {
+	if _, err := cli.dbCli.Global.DeleteDisk(kt.Ctx, kt.Header(), deleteReq); err != nil {
 		logs.Errorf("[%s] request dataservice to batch delete disk failed, err: %v, rid: %s", enumor.Azure,
 			err, kt.Rid)
 		return err
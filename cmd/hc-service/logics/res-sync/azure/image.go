@@ -210,7 +210,8 @@ func (cli *client) deleteImage(kt *kit.Kit, opt *SyncImageOption, delCloudIDs []
 	elems := slice.Split(delCloudIDs, constant.CloudResourceSyncMaxLimit)
 	for _, parts := range elems {
 		batchDeleteReq := &dataproto.DeleteReq{
-			Filter: tools.ContainersExpression("cloud_id", parts),
+			Filter:         tools.ContainersExpression("cloud_id", parts),
+			SkipInUseCheck: true,
 		}
 		if err = cli.dbCli.Global.DeleteImage(kt, batchDeleteReq); err != nil {
 			logs.Errorf("request dataservice delete azure image failed, err: %v, rid: %s", err, kt.Rid)
@@ -220,7 +220,8 @@ func (cli *client) deleteSG(kt *kit.Kit, accountID string, resGroupName string,
 	}
 
 	deleteReq := &protocloud.SecurityGroupBatchDeleteReq{
-		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
+		Filter:         tools.ContainersExpression("cloud_id", delCloudIDs),
+		SkipInUseCheck: true,
 	}
 	if err = cli.dbCli.Global.SecurityGroup.BatchDeleteSecurityGroup(kt.Ctx, kt.Header(), deleteReq); err != nil {
 		logs.Errorf("[%s] request dataservice to batch delete sg failed, err: %v, rid: %s", enumor.Azure,
@@ -0,0 +1,92 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/kit"
+)
+
+// Batcher 合并写入data-service的请求，将多次少量的创建、更新合并为批量请求，每攒够一批(不超过data-service
+// 批量接口的上限)写入一次，用于减少大规模同步时逐个资源调用data-service的次数。
+// 调用方在添加完所有数据后需要调用 Flush 写入剩余的数据。Batcher 不是并发安全的。
+type Batcher[T any] struct {
+	size  int
+	write func(kt *kit.Kit, items []T) error
+	items []T
+
+	total    int
+	requests int
+}
+
+// NewBatcher create a batcher which writes every size items by write, size is limited to
+// constant.BatchOperationMaxLimit, which is the max items of the data-service batch api.
+func NewBatcher[T any](size int, write func(kt *kit.Kit, items []T) error) *Batcher[T] {
+	if size <= 0 || size > constant.BatchOperationMaxLimit {
+		size = constant.BatchOperationMaxLimit
+	}
+
+	return &Batcher[T]{
+		size:  size,
+		write: write,
+		items: make([]T, 0, size),
+	}
+}
+
+// Add the items to the batcher, the full batches are written immediately.
+func (b *Batcher[T]) Add(kt *kit.Kit, items ...T) error {
+	for len(items) > 0 {
+		n := min(b.size-len(b.items), len(items))
+		b.items = append(b.items, items[:n]...)
+		items = items[n:]
+
+		if len(b.items) < b.size {
+			return nil
+		}
+
+		if err := b.Flush(kt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Flush write the remaining items.
+func (b *Batcher[T]) Flush(kt *kit.Kit) error {
+	if len(b.items) == 0 {
+		return nil
+	}
+
+	if err := b.write(kt, b.items); err != nil {
+		return err
+	}
+
+	b.total += len(b.items)
+	b.requests++
+	// write may hold the items, e.g. set them to the request, so the slice can not be reused.
+	b.items = make([]T, 0, b.size)
+	return nil
+}
+
+// Stats returns the count of the written items and the requests used to write them.
+func (b *Batcher[T]) Stats() (total int, requests int) {
+	return b.total, b.requests
+}
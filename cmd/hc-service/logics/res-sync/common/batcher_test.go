@@ -0,0 +1,84 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"errors"
+	"testing"
+
+	"hcm/pkg/kit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatcher(t *testing.T) {
+	batches := make([][]int, 0)
+	b := NewBatcher(3, func(kt *kit.Kit, items []int) error {
+		batches = append(batches, items)
+		return nil
+	})
+
+	kt := kit.New()
+	// single row adds are coalesced
+	require.NoError(t, b.Add(kt, 1))
+	require.NoError(t, b.Add(kt, 2))
+	assert.Empty(t, batches)
+
+	require.NoError(t, b.Add(kt, 3, 4, 5, 6, 7))
+	assert.Equal(t, [][]int{{1, 2, 3}, {4, 5, 6}}, batches)
+
+	require.NoError(t, b.Flush(kt))
+	require.NoError(t, b.Flush(kt))
+	assert.Equal(t, [][]int{{1, 2, 3}, {4, 5, 6}, {7}}, batches)
+
+	total, requests := b.Stats()
+	assert.Equal(t, 7, total)
+	assert.Equal(t, 3, requests)
+}
+
+func TestBatcherLimit(t *testing.T) {
+	sizes := make([]int, 0)
+	b := NewBatcher(1000, func(kt *kit.Kit, items []int) error {
+		sizes = append(sizes, len(items))
+		return nil
+	})
+
+	kt := kit.New()
+	for i := 0; i < 250; i++ {
+		require.NoError(t, b.Add(kt, i))
+	}
+	require.NoError(t, b.Flush(kt))
+	assert.Equal(t, []int{100, 100, 50}, sizes)
+}
+
+func TestBatcherError(t *testing.T) {
+	b := NewBatcher(2, func(kt *kit.Kit, items []int) error {
+		return errors.New("data-service error")
+	})
+
+	kt := kit.New()
+	require.NoError(t, b.Add(kt, 1))
+	assert.Error(t, b.Add(kt, 2))
+
+	total, requests := b.Stats()
+	assert.Zero(t, total)
+	assert.Zero(t, requests)
+}
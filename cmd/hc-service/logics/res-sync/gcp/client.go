@@ -27,7 +27,7 @@ import (
 
 // Interface support resource sync.
 type Interface interface {
-	CloudCli() *gcp.Gcp
+	CloudCli() gcp.Gcp
 
 	Cvm(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmOption) (*SyncResult, error)
 	CvmWithRelRes(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmWithRelResOption) (*SyncResult, error)
@@ -67,7 +67,7 @@ type Interface interface {
 var _ Interface = new(client)
 
 // NewClient new client.
-func NewClient(dbCli *dataservice.Client, cloudCli *gcp.Gcp) Interface {
+func NewClient(dbCli *dataservice.Client, cloudCli gcp.Gcp) Interface {
 	return &client{
 		dbCli:    dbCli,
 		cloudCli: cloudCli,
@@ -76,11 +76,11 @@ func NewClient(dbCli *dataservice.Client, cloudCli *gcp.Gcp) Interface {
 
 type client struct {
 	accountID string
-	cloudCli  *gcp.Gcp
+	cloudCli  gcp.Gcp
 	dbCli     *dataservice.Client
 }
 
 // CloudCli ...
-func (cli *client) CloudCli() *gcp.Gcp {
+func (cli *client) CloudCli() gcp.Gcp {
 	return cli.cloudCli
 }
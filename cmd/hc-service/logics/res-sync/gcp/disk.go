@@ -81,24 +81,33 @@ func (cli *client) Disk(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskOption
 	addSlice, updateMap, delCloudIDs := common.Diff[adaptordisk.GcpDisk, *coredisk.Disk[coredisk.GcpExtension]](
 		diskFromCloud, diskFromDB, isDiskChange)
 
-	if len(delCloudIDs) > 0 {
-		if err := cli.deleteDisk(kt, params.AccountID, opt.Zone, delCloudIDs); err != nil {
-			return nil, err
-		}
+	if len(addSlice) == 0 && len(updateMap) == 0 && len(delCloudIDs) == 0 {
+		return new(SyncResult), nil
 	}
 
-	if len(addSlice) > 0 {
-		if err = cli.createDisk(kt, params.AccountID, opt.Zone, addSlice); err != nil {
-			return nil, err
-		}
+	// 删除、更新、创建合并为一次请求，由 data-service 在同一个事务中完成，减少同步的请求次数
+	upsertReq := &disk.DiskExtBatchUpsertReq[coredisk.GcpExtension]{
+		Creates: convDiskCreateReq(params.AccountID, opt.Zone, addSlice),
+		Updates: convDiskUpdateReq(updateMap),
 	}
 
-	if len(updateMap) > 0 {
-		if err = cli.updateDisk(kt, params.AccountID, updateMap); err != nil {
+	if len(delCloudIDs) > 0 {
+		if err = cli.checkDiskDeletedFromCloud(kt, params.AccountID, opt.Zone, delCloudIDs); err != nil {
 			return nil, err
 		}
+		upsertReq.AccountID = params.AccountID
+		upsertReq.DeleteCloudIDs = delCloudIDs
 	}
 
+	if _, err = cli.dbCli.Gcp.BatchUpsertDisk(kt, upsertReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch upsert disk failed, err: %v, rid: %s", enumor.Gcp,
+			err, kt.Rid)
+		return nil, err
+	}
+
+	logs.Infof("[%s] sync disk success, accountID: %s, create count: %d, update count: %d, delete count: %d, "+
+		"rid: %s", enumor.Gcp, params.AccountID, len(addSlice), len(updateMap), len(delCloudIDs), kt.Rid)
+
 	return new(SyncResult), nil
 }
 
@@ -203,16 +212,12 @@ func (cli *client) listDiskFromDB(kt *kit.Kit, params *SyncBaseParams, option *S
 	return result.Details, nil
 }
 
-func (cli *client) updateDisk(kt *kit.Kit, accountID string, updateMap map[string]adaptordisk.GcpDisk) error {
-
-	if len(updateMap) <= 0 {
-		return fmt.Errorf("updateMap is <= 0, not update")
-	}
-
-	disks := make([]*disk.DiskExtUpdateReq[coredisk.GcpExtension], 0)
+func convDiskUpdateReq(
+	updateMap map[string]adaptordisk.GcpDisk) disk.DiskExtBatchUpdateReq[coredisk.GcpExtension] {
 
+	updateReq := make(disk.DiskExtBatchUpdateReq[coredisk.GcpExtension], 0, len(updateMap))
 	for id, one := range updateMap {
-		disk := &disk.DiskExtUpdateReq[coredisk.GcpExtension]{
+		updateReq = append(updateReq, &disk.DiskExtUpdateReq[coredisk.GcpExtension]{
 			ID:           id,
 			Region:       one.Region,
 			Status:       one.Status,
@@ -225,37 +230,18 @@ func (cli *client) updateDisk(kt *kit.Kit, accountID string, updateMap map[strin
 				// TODO: not find
 				Encrypted: nil,
 			},
-		}
-
-		disks = append(disks, disk)
+		})
 	}
 
-	var updateReq disk.DiskExtBatchUpdateReq[coredisk.GcpExtension]
-	for _, disk := range disks {
-		updateReq = append(updateReq, disk)
-	}
-	if _, err := cli.dbCli.Gcp.BatchUpdateDisk(kt.Ctx, kt.Header(), &updateReq); err != nil {
-		logs.Errorf("[%s] request dataservice gcp BatchUpdateDisk failed, err: %v, rid: %s", enumor.Gcp,
-			err, kt.Rid)
-		return err
-	}
-
-	logs.Infof("[%s] sync disk to update disk success, accountID: %s, count: %d, rid: %s", enumor.Gcp,
-		accountID, len(updateMap), kt.Rid)
-
-	return nil
+	return updateReq
 }
 
-func (cli *client) createDisk(kt *kit.Kit, accountID string, zone string, addSlice []adaptordisk.GcpDisk) error {
-
-	if len(addSlice) <= 0 {
-		return fmt.Errorf("addSlice is <= 0, not create")
-	}
-
-	var createReq disk.DiskExtBatchCreateReq[coredisk.GcpExtension]
+func convDiskCreateReq(accountID string, zone string,
+	addSlice []adaptordisk.GcpDisk) disk.DiskExtBatchCreateReq[coredisk.GcpExtension] {
 
+	createReq := make(disk.DiskExtBatchCreateReq[coredisk.GcpExtension], 0, len(addSlice))
 	for _, one := range addSlice {
-		disk := &disk.DiskExtCreateReq[coredisk.GcpExtension]{
+		createReq = append(createReq, &disk.DiskExtCreateReq[coredisk.GcpExtension]{
 			AccountID:    accountID,
 			Name:         one.Name,
 			CloudID:      fmt.Sprint(one.Id),
@@ -273,28 +259,15 @@ func (cli *client) createDisk(kt *kit.Kit, accountID string, zone string, addSli
 				// TODO: not find
 				Encrypted: nil,
 			},
-		}
-
-		createReq = append(createReq, disk)
+		})
 	}
 
-	_, err := cli.dbCli.Gcp.BatchCreateDisk(kt.Ctx, kt.Header(), &createReq)
-	if err != nil {
-		logs.Errorf("[%s] request dataservice to create gcp disk failed, err: %v, rid: %s", enumor.Gcp,
-			err, kt.Rid)
-		return err
-	}
-
-	logs.Infof("[%s] sync disk to create disk success, accountID: %s, count: %d, rid: %s", enumor.Gcp,
-		accountID, len(addSlice), kt.Rid)
-
-	return nil
+	return createReq
 }
 
-func (cli *client) deleteDisk(kt *kit.Kit, accountID string, zone string, delCloudIDs []string) error {
-	if len(delCloudIDs) <= 0 {
-		return fmt.Errorf("delCloudIDs is <= 0, not delete")
-	}
+// checkDiskDeletedFromCloud 删除前确认云盘已从云上删除
+func (cli *client) checkDiskDeletedFromCloud(kt *kit.Kit, accountID string, zone string,
+	delCloudIDs []string) error {
 
 	checkParams := &SyncBaseParams{
 		AccountID: accountID,
@@ -311,10 +284,22 @@ func (cli *client) deleteDisk(kt *kit.Kit, accountID string, zone string, delClo
 		return fmt.Errorf("validate disk not exist failed, before delete")
 	}
 
+	return nil
+}
+
+func (cli *client) deleteDisk(kt *kit.Kit, accountID string, zone string, delCloudIDs []string) error {
+	if len(delCloudIDs) <= 0 {
+		return fmt.Errorf("delCloudIDs is <= 0, not delete")
+	}
+
+	if err := cli.checkDiskDeletedFromCloud(kt, accountID, zone, delCloudIDs); err != nil {
+		return err
+	}
+
 	deleteReq := &disk.DiskDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
-	if _, err = cli.dbCli.Global.DeleteDisk(kt.Ctx, kt.Header(), deleteReq); err != nil {
+	if _, err := cli.dbCli.Global.DeleteDisk(kt.Ctx, kt.Header(), deleteReq); err != nil {
 		logs.Errorf("[%s] request dataservice to batch delete disk failed, err: %v, rid: %s", enumor.Gcp,
 			err, kt.Rid)
 		return err
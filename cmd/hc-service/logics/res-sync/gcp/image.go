@@ -186,7 +186,8 @@ func (cli *client) deleteImage(kt *kit.Kit, accountID string, projectID string,
 	}
 
 	batchDeleteReq := &dataproto.DeleteReq{
-		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
+		Filter:         tools.ContainersExpression("cloud_id", delCloudIDs),
+		SkipInUseCheck: true,
 	}
 	if err = cli.dbCli.Global.DeleteImage(kt, batchDeleteReq); err != nil {
 		logs.Errorf("request dataservice delete gcp image failed, err: %v, rid: %s", err, kt.Rid)
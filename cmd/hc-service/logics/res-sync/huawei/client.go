@@ -29,7 +29,7 @@ import (
 
 // Interface support resource sync.
 type Interface interface {
-	CloudCli() *huawei.HuaWei
+	CloudCli() huawei.HuaWei
 
 	Cvm(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmOption) (*SyncResult, error)
 	CvmWithRelRes(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmWithRelResOption) (*SyncResult, error)
@@ -73,7 +73,7 @@ type Interface interface {
 var _ Interface = new(client)
 
 // NewClient new client.
-func NewClient(dbCli *dataservice.Client, cloudCli *huawei.HuaWei) Interface {
+func NewClient(dbCli *dataservice.Client, cloudCli huawei.HuaWei) Interface {
 	return &client{
 		dbCli:    dbCli,
 		cloudCli: cloudCli,
@@ -82,11 +82,11 @@ func NewClient(dbCli *dataservice.Client, cloudCli *huawei.HuaWei) Interface {
 
 type client struct {
 	accountID string
-	cloudCli  *huawei.HuaWei
+	cloudCli  huawei.HuaWei
 	dbCli     *dataservice.Client
 }
 
 // CloudCli ...
-func (cli *client) CloudCli() *huawei.HuaWei {
+func (cli *client) CloudCli() huawei.HuaWei {
 	return cli.cloudCli
 }
@@ -81,31 +81,39 @@ func (cli *client) Disk(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskOption
 	addSlice, updateMap, delCloudIDs := common.Diff[adaptordisk.HuaWeiDisk, *coredisk.Disk[coredisk.HuaWeiExtension]](
 		diskFromCloud, diskFromDB, isDiskChange)
 
-	if len(delCloudIDs) > 0 {
-		if err := cli.deleteDisk(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
-			return nil, err
-		}
+	if len(addSlice) == 0 && len(updateMap) == 0 && len(delCloudIDs) == 0 {
+		return new(SyncResult), nil
 	}
 
-	if len(addSlice) > 0 {
-		if err = cli.createDisk(kt, params.AccountID, params.Region, addSlice); err != nil {
-			return nil, err
-		}
+	// 删除、更新、创建合并为一次请求，由 data-service 在同一个事务中完成，减少同步的请求次数
+	upsertReq := &disk.DiskExtBatchUpsertReq[coredisk.HuaWeiExtension]{
+		Creates: convDiskCreateReq(params.AccountID, params.Region, addSlice),
+		Updates: convDiskUpdateReq(updateMap),
 	}
 
-	if len(updateMap) > 0 {
-		if err = cli.updateDisk(kt, params.AccountID, updateMap); err != nil {
+	if len(delCloudIDs) > 0 {
+		if err = cli.checkDiskDeletedFromCloud(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 			return nil, err
 		}
+		upsertReq.AccountID = params.AccountID
+		upsertReq.DeleteCloudIDs = delCloudIDs
 	}
 
+	if _, err = cli.dbCli.HuaWei.BatchUpsertDisk(kt, upsertReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch upsert disk failed, err: %v, rid: %s", enumor.HuaWei,
+			err, kt.Rid)
+		return nil, err
+	}
+
+	logs.Infof("[%s] sync disk success, accountID: %s, create count: %d, update count: %d, delete count: %d, "+
+		"rid: %s", enumor.HuaWei, params.AccountID, len(addSlice), len(updateMap), len(delCloudIDs), kt.Rid)
+
 	return new(SyncResult), nil
 }
 
-func (cli *client) deleteDisk(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
-	if len(delCloudIDs) <= 0 {
-		return fmt.Errorf("delCloudIDs is <= 0, not delete")
-	}
+// checkDiskDeletedFromCloud 删除前确认云盘已从云上删除
+func (cli *client) checkDiskDeletedFromCloud(kt *kit.Kit, accountID string, region string,
+	delCloudIDs []string) error {
 
 	checkParams := &SyncBaseParams{
 		AccountID: accountID,
@@ -123,10 +131,22 @@ func (cli *client) deleteDisk(kt *kit.Kit, accountID string, region string, delC
 		return fmt.Errorf("validate disk not exist failed, before delete")
 	}
 
+	return nil
+}
+
+func (cli *client) deleteDisk(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if len(delCloudIDs) <= 0 {
+		return fmt.Errorf("delCloudIDs is <= 0, not delete")
+	}
+
+	if err := cli.checkDiskDeletedFromCloud(kt, accountID, region, delCloudIDs); err != nil {
+		return err
+	}
+
 	deleteReq := &disk.DiskDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
-	if _, err = cli.dbCli.Global.DeleteDisk(kt.Ctx, kt.Header(), deleteReq); err != nil {
+	if _, err := cli.dbCli.Global.DeleteDisk(kt.Ctx, kt.Header(), deleteReq); err != nil {
 		logs.Errorf("[%s] request dataservice to batch delete disk failed, err: %v, rid: %s", enumor.HuaWei,
 			err, kt.Rid)
 		return err
@@ -138,14 +158,10 @@ func (cli *client) deleteDisk(kt *kit.Kit, accountID string, region string, delC
 	return nil
 }
 
-func (cli *client) updateDisk(kt *kit.Kit, accountID string, updateMap map[string]adaptordisk.HuaWeiDisk) error {
-
-	if len(updateMap) <= 0 {
-		return fmt.Errorf("updateMap is <= 0, not update")
-	}
-
-	disks := make([]*disk.DiskExtUpdateReq[coredisk.HuaWeiExtension], 0)
+func convDiskUpdateReq(
+	updateMap map[string]adaptordisk.HuaWeiDisk) disk.DiskExtBatchUpdateReq[coredisk.HuaWeiExtension] {
 
+	updateReq := make(disk.DiskExtBatchUpdateReq[coredisk.HuaWeiExtension], 0, len(updateMap))
 	for id, one := range updateMap {
 
 		attachments := make([]*coredisk.HuaWeiDiskAttachment, 0)
@@ -164,7 +180,7 @@ func (cli *client) updateDisk(kt *kit.Kit, accountID string, updateMap map[strin
 			}
 		}
 
-		disk := &disk.DiskExtUpdateReq[coredisk.HuaWeiExtension]{
+		updateReq = append(updateReq, &disk.DiskExtUpdateReq[coredisk.HuaWeiExtension]{
 			ID:           id,
 			Memo:         converter.ValToPtr(one.Description),
 			Status:       one.Status,
@@ -177,36 +193,16 @@ func (cli *client) updateDisk(kt *kit.Kit, accountID string, updateMap map[strin
 				Attachment:  attachments,
 				Bootable:    one.Bootable,
 			},
-		}
-
-		disks = append(disks, disk)
+		})
 	}
 
-	var updateReq disk.DiskExtBatchUpdateReq[coredisk.HuaWeiExtension]
-	for _, disk := range disks {
-		updateReq = append(updateReq, disk)
-	}
-	if _, err := cli.dbCli.HuaWei.BatchUpdateDisk(kt.Ctx, kt.Header(), &updateReq); err != nil {
-		logs.Errorf("[%s] request dataservice huawei BatchUpdateDisk failed, err: %v, rid: %s", enumor.HuaWei,
-			err, kt.Rid)
-		return err
-	}
-
-	logs.Infof("[%s] sync disk to update disk success, accountID: %s, count: %d, rid: %s", enumor.HuaWei,
-		accountID, len(updateMap), kt.Rid)
-
-	return nil
+	return updateReq
 }
 
-func (cli *client) createDisk(kt *kit.Kit, accountID string, region string,
-	addSlice []adaptordisk.HuaWeiDisk) error {
-
-	if len(addSlice) <= 0 {
-		return fmt.Errorf("addSlice is <= 0, not create")
-	}
-
-	var createReq disk.DiskExtBatchCreateReq[coredisk.HuaWeiExtension]
+func convDiskCreateReq(accountID string, region string,
+	addSlice []adaptordisk.HuaWeiDisk) disk.DiskExtBatchCreateReq[coredisk.HuaWeiExtension] {
 
+	createReq := make(disk.DiskExtBatchCreateReq[coredisk.HuaWeiExtension], 0, len(addSlice))
 	for _, one := range addSlice {
 		attachments := make([]*coredisk.HuaWeiDiskAttachment, 0)
 		if len(one.Attachments) > 0 {
@@ -224,7 +220,7 @@ func (cli *client) createDisk(kt *kit.Kit, accountID string, region string,
 			}
 		}
 
-		disk := &disk.DiskExtCreateReq[coredisk.HuaWeiExtension]{
+		createReq = append(createReq, &disk.DiskExtCreateReq[coredisk.HuaWeiExtension]{
 			AccountID:    accountID,
 			Name:         one.Name,
 			CloudID:      one.Id,
@@ -243,22 +239,10 @@ func (cli *client) createDisk(kt *kit.Kit, accountID string, region string,
 				Attachment:  attachments,
 				Bootable:    one.Bootable,
 			},
-		}
-
-		createReq = append(createReq, disk)
-	}
-
-	_, err := cli.dbCli.HuaWei.BatchCreateDisk(kt.Ctx, kt.Header(), &createReq)
-	if err != nil {
-		logs.Errorf("[%s] request dataservice to create huawei disk failed, err: %v, rid: %s", enumor.HuaWei,
-			err, kt.Rid)
-		return err
+		})
 	}
 
-	logs.Infof("[%s] sync disk to create disk success, accountID: %s, count: %d, rid: %s", enumor.HuaWei,
-		accountID, len(addSlice), kt.Rid)
-
-	return nil
+	return createReq
 }
 
 func (cli *client) listDiskFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]adaptordisk.HuaWeiDisk, error) {
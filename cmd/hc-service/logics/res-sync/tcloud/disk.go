@@ -72,31 +72,39 @@ func (cli *client) Disk(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskOption
 	addSlice, updateMap, delCloudIDs := common.Diff[typesdisk.TCloudDisk, *coredisk.Disk[coredisk.TCloudExtension]](
 		diskFromCloud, diskFromDB, isDiskChange)
 
-	if len(delCloudIDs) > 0 {
-		if err := cli.deleteDisk(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
-			return nil, err
-		}
+	if len(addSlice) == 0 && len(updateMap) == 0 && len(delCloudIDs) == 0 {
+		return new(SyncResult), nil
 	}
 
-	if len(addSlice) > 0 {
-		if err = cli.createDisk(kt, params.AccountID, params.Region, addSlice); err != nil {
-			return nil, err
-		}
+	// 删除、更新、创建合并为一次请求，由 data-service 在同一个事务中完成，减少同步的请求次数
+	upsertReq := &disk.DiskExtBatchUpsertReq[coredisk.TCloudExtension]{
+		Creates: convDiskCreateReq(params.AccountID, params.Region, addSlice),
+		Updates: convDiskUpdateReq(updateMap),
 	}
 
-	if len(updateMap) > 0 {
-		if err = cli.updateDisk(kt, params.AccountID, updateMap); err != nil {
+	if len(delCloudIDs) > 0 {
+		if err = cli.checkDiskDeletedFromCloud(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 			return nil, err
 		}
+		upsertReq.AccountID = params.AccountID
+		upsertReq.DeleteCloudIDs = delCloudIDs
 	}
 
+	if _, err = cli.dbCli.TCloud.BatchUpsertDisk(kt, upsertReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch upsert disk failed, err: %v, rid: %s", enumor.TCloud,
+			err, kt.Rid)
+		return nil, err
+	}
+
+	logs.Infof("[%s] sync disk success, accountID: %s, create count: %d, update count: %d, delete count: %d, "+
+		"rid: %s", enumor.TCloud, params.AccountID, len(addSlice), len(updateMap), len(delCloudIDs), kt.Rid)
+
 	return new(SyncResult), nil
 }
 
-func (cli *client) deleteDisk(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
-	if len(delCloudIDs) <= 0 {
-		return fmt.Errorf("delCloudIDs is <= 0, not delete")
-	}
+// checkDiskDeletedFromCloud 删除前确认云盘已从云上删除
+func (cli *client) checkDiskDeletedFromCloud(kt *kit.Kit, accountID string, region string,
+	delCloudIDs []string) error {
 
 	checkParams := &SyncBaseParams{
 		AccountID: accountID,
@@ -114,10 +122,22 @@ func (cli *client) deleteDisk(kt *kit.Kit, accountID string, region string, delC
 		return fmt.Errorf("validate disk not exist failed, before delete")
 	}
 
+	return nil
+}
+
+func (cli *client) deleteDisk(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if len(delCloudIDs) <= 0 {
+		return fmt.Errorf("delCloudIDs is <= 0, not delete")
+	}
+
+	if err := cli.checkDiskDeletedFromCloud(kt, accountID, region, delCloudIDs); err != nil {
+		return err
+	}
+
 	deleteReq := &disk.DiskDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
-	if _, err = cli.dbCli.Global.DeleteDisk(kt.Ctx, kt.Header(), deleteReq); err != nil {
+	if _, err := cli.dbCli.Global.DeleteDisk(kt.Ctx, kt.Header(), deleteReq); err != nil {
 		logs.Errorf("[%s] request dataservice to batch delete disk failed, err: %v, rid: %s", enumor.TCloud,
 			err, kt.Rid)
 		return err
@@ -129,65 +149,28 @@ func (cli *client) deleteDisk(kt *kit.Kit, accountID string, region string, delC
 	return nil
 }
 
-func (cli *client) updateDisk(kt *kit.Kit, accountID string, updateMap map[string]typesdisk.TCloudDisk) error {
-	if len(updateMap) <= 0 {
-		return fmt.Errorf("updateMap is <= 0, not update")
-	}
-
-	disks := make([]*disk.DiskExtUpdateReq[coredisk.TCloudExtension], 0)
+func convDiskUpdateReq(
+	updateMap map[string]typesdisk.TCloudDisk) disk.DiskExtBatchUpdateReq[coredisk.TCloudExtension] {
 
+	updateReq := make(disk.DiskExtBatchUpdateReq[coredisk.TCloudExtension], 0, len(updateMap))
 	for id, one := range updateMap {
-		disk := &disk.DiskExtUpdateReq[coredisk.TCloudExtension]{
+		updateReq = append(updateReq, &disk.DiskExtUpdateReq[coredisk.TCloudExtension]{
 			ID:           id,
 			Status:       converter.PtrToVal(one.DiskState),
 			IsSystemDisk: converter.ValToPtr(one.Boot),
-			Extension: &coredisk.TCloudExtension{
-				DiskChargeType: converter.PtrToVal(one.DiskChargeType),
-				DiskChargePrepaid: &coredisk.TCloudDiskChargePrepaid{
-					RenewFlag: one.RenewFlag,
-					Period:    one.DifferDaysOfDeadline,
-				},
-				Encrypted:          one.Encrypt,
-				Attached:           one.Attached,
-				DiskUsage:          one.DiskUsage,
-				InstanceId:         one.InstanceId,
-				InstanceType:       one.InstanceType,
-				DeleteWithInstance: one.DeleteWithInstance,
-				DeadlineTime:       one.DeadlineTime,
-				BackupDisk:         one.BackupDisk,
-			},
-		}
-
-		disks = append(disks, disk)
-	}
-
-	var updateReq disk.DiskExtBatchUpdateReq[coredisk.TCloudExtension]
-	for _, disk := range disks {
-		updateReq = append(updateReq, disk)
-	}
-	if _, err := cli.dbCli.TCloud.BatchUpdateDisk(kt.Ctx, kt.Header(), &updateReq); err != nil {
-		logs.Errorf("[%s] request dataservice BatchUpdateDisk failed, err: %v, rid: %s", enumor.TCloud,
-			err, kt.Rid)
-		return err
+			Extension:    convDiskExtension(one),
+		})
 	}
 
-	logs.Infof("[%s] sync disk to update disk success, accountID: %s, count: %d, rid: %s", enumor.TCloud,
-		accountID, len(updateMap), kt.Rid)
-
-	return nil
+	return updateReq
 }
 
-func (cli *client) createDisk(kt *kit.Kit, accountID string, region string,
-	addSlice []typesdisk.TCloudDisk) error {
-
-	if len(addSlice) <= 0 {
-		return fmt.Errorf("addSlice is <= 0, not create")
-	}
-
-	var createReq disk.DiskExtBatchCreateReq[coredisk.TCloudExtension]
+func convDiskCreateReq(accountID string, region string,
+	addSlice []typesdisk.TCloudDisk) disk.DiskExtBatchCreateReq[coredisk.TCloudExtension] {
 
+	createReq := make(disk.DiskExtBatchCreateReq[coredisk.TCloudExtension], 0, len(addSlice))
 	for _, one := range addSlice {
-		disk := &disk.DiskExtCreateReq[coredisk.TCloudExtension]{
+		createDisk := &disk.DiskExtCreateReq[coredisk.TCloudExtension]{
 			AccountID: accountID,
 			Name:      converter.PtrToVal(one.DiskName),
 			CloudID:   converter.PtrToVal(one.DiskId),
@@ -197,42 +180,36 @@ func (cli *client) createDisk(kt *kit.Kit, accountID string, region string,
 			DiskType:  converter.PtrToVal(one.DiskType),
 			Status:    converter.PtrToVal(one.DiskState),
 			// tcloud no memo
-			Memo: nil,
-			Extension: &coredisk.TCloudExtension{
-				DiskChargeType: converter.PtrToVal(one.DiskChargeType),
-				DiskChargePrepaid: &coredisk.TCloudDiskChargePrepaid{
-					RenewFlag: one.RenewFlag,
-					Period:    one.DifferDaysOfDeadline,
-				},
-				Encrypted:          one.Encrypt,
-				Attached:           one.Attached,
-				DiskUsage:          one.DiskUsage,
-				InstanceId:         one.InstanceId,
-				InstanceType:       one.InstanceType,
-				DeleteWithInstance: one.DeleteWithInstance,
-				DeadlineTime:       one.DeadlineTime,
-				BackupDisk:         one.BackupDisk,
-			},
+			Memo:      nil,
+			Extension: convDiskExtension(one),
 		}
 
 		if one.DiskUsage != nil && converter.PtrToVal(one.DiskUsage) == "SYSTEM_DISK" {
-			disk.IsSystemDisk = true
+			createDisk.IsSystemDisk = true
 		}
 
-		createReq = append(createReq, disk)
+		createReq = append(createReq, createDisk)
 	}
 
-	_, err := cli.dbCli.TCloud.BatchCreateDisk(kt.Ctx, kt.Header(), &createReq)
-	if err != nil {
-		logs.Errorf("[%s] request dataservice to create tcloud disk failed, err: %v, rid: %s", enumor.TCloud,
-			err, kt.Rid)
-		return err
-	}
-
-	logs.Infof("[%s] sync disk to create disk success, accountID: %s, count: %d, rid: %s", enumor.TCloud,
-		accountID, len(addSlice), kt.Rid)
+	return createReq
+}
 
-	return nil
+func convDiskExtension(one typesdisk.TCloudDisk) *coredisk.TCloudExtension {
+	return &coredisk.TCloudExtension{
+		DiskChargeType: converter.PtrToVal(one.DiskChargeType),
+		DiskChargePrepaid: &coredisk.TCloudDiskChargePrepaid{
+			RenewFlag: one.RenewFlag,
+			Period:    one.DifferDaysOfDeadline,
+		},
+		Encrypted:          one.Encrypt,
+		Attached:           one.Attached,
+		DiskUsage:          one.DiskUsage,
+		InstanceId:         one.InstanceId,
+		InstanceType:       one.InstanceType,
+		DeleteWithInstance: one.DeleteWithInstance,
+		DeadlineTime:       one.DeadlineTime,
+		BackupDisk:         one.BackupDisk,
+	}
 }
 
 func (cli *client) listDiskFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]typesdisk.TCloudDisk, error) {
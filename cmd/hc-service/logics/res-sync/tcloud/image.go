@@ -185,7 +185,8 @@ func (cli *client) deleteImage(kt *kit.Kit, accountID string, region string, del
 	}
 
 	batchDeleteReq := &dataproto.DeleteReq{
-		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
+		Filter:         tools.ContainersExpression("cloud_id", delCloudIDs),
+		SkipInUseCheck: true,
 	}
 	if err = cli.dbCli.Global.DeleteImage(kt, batchDeleteReq); err != nil {
 		logs.Errorf("request dataservice delete tcloud image failed, err: %v, rid: %s", err, kt.Rid)
@@ -363,26 +363,43 @@ func (cli *client) createListener(kt *kit.Kit, accountID, region string, syncOpt
 		dbRules = append(dbRules, convL4Listener(lbl, accountID, region, syncOpt))
 	}
 	createdIDs := make([]string, 0, len(addSlice))
-	if len(dbListeners) > 0 {
-		lblCreated, err := cli.dbCli.TCloud.LoadBalancer.BatchCreateTCloudListener(kt,
-			&dataproto.TCloudListenerBatchCreateReq{Listeners: dbListeners})
-		if err != nil {
-			logs.Errorf("fail to create listener while sync, err: %v syncOpt: %+v, rid: %s",
-				err, syncOpt, kt.Rid)
-			return nil, err
-		}
-		createdIDs = append(createdIDs, lblCreated.IDs...)
+	// 按批量接口的上限分批创建
+	lblBatcher := common.NewBatcher(constant.BatchOperationMaxLimit,
+		func(kt *kit.Kit, listeners []dataproto.ListenersCreateReq[corelb.TCloudListenerExtension]) error {
+			lblCreated, err := cli.dbCli.TCloud.LoadBalancer.BatchCreateTCloudListener(kt,
+				&dataproto.TCloudListenerBatchCreateReq{Listeners: listeners})
+			if err != nil {
+				logs.Errorf("fail to create listener while sync, err: %v syncOpt: %+v, rid: %s",
+					err, syncOpt, kt.Rid)
+				return err
+			}
+			createdIDs = append(createdIDs, lblCreated.IDs...)
+			return nil
+		})
+	if err := lblBatcher.Add(kt, dbListeners...); err != nil {
+		return nil, err
+	}
+	if err := lblBatcher.Flush(kt); err != nil {
+		return nil, err
 	}
 
-	if len(dbRules) > 0 {
-		ruleCreated, err := cli.dbCli.TCloud.LoadBalancer.BatchCreateTCloudListenerWithRule(kt,
-			&dataproto.ListenerWithRuleBatchCreateReq{ListenerWithRules: dbRules})
-		if err != nil {
-			logs.Errorf("fail to create listener with rule while sync, err: %v syncOpt: %+v, rid: %s",
-				err, syncOpt, kt.Rid)
-			return nil, err
-		}
-		createdIDs = append(createdIDs, ruleCreated.IDs...)
+	ruleBatcher := common.NewBatcher(constant.BatchOperationMaxLimit,
+		func(kt *kit.Kit, rules []dataproto.ListenerWithRuleCreateReq) error {
+			ruleCreated, err := cli.dbCli.TCloud.LoadBalancer.BatchCreateTCloudListenerWithRule(kt,
+				&dataproto.ListenerWithRuleBatchCreateReq{ListenerWithRules: rules})
+			if err != nil {
+				logs.Errorf("fail to create listener with rule while sync, err: %v syncOpt: %+v, rid: %s",
+					err, syncOpt, kt.Rid)
+				return err
+			}
+			createdIDs = append(createdIDs, ruleCreated.IDs...)
+			return nil
+		})
+	if err := ruleBatcher.Add(kt, dbRules...); err != nil {
+		return nil, err
+	}
+	if err := ruleBatcher.Flush(kt); err != nil {
+		return nil, err
 	}
 
 	return createdIDs, nil
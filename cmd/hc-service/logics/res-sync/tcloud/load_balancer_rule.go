@@ -71,7 +71,8 @@ func (cli *client) loadBalancerRule(kt *kit.Kit, params *SyncBaseParams, opt *Sy
 		dbListenerMap[dbLbl.CloudID] = cvt.ValToPtr(dbLbl)
 	}
 
-	// 逐个同步监听器下的规则
+	// 逐个同步监听器下的规则，规则的创建、更新合并为批量请求写入
+	writer := cli.newLayer7RuleWriter()
 	for _, listener := range l7Listeners {
 		l7Opt.CloudListenerID = cvt.PtrToVal(listener.ListenerId)
 		dbLbl := dbListenerMap[listener.GetCloudID()]
@@ -81,12 +82,14 @@ func (cli *client) loadBalancerRule(kt *kit.Kit, params *SyncBaseParams, opt *Sy
 			continue
 		}
 		l7Opt.ListenerID = dbLbl.ID
-		_, err := cli.ListenerLayer7Rule(kt, params, l7Opt, listener)
-		if err != nil {
+		if err = cli.listenerLayer7Rule(kt, params, l7Opt, listener, writer); err != nil {
 			logs.Errorf("fail to sync rules of listener, err: %v, rid: %s", err, kt.Rid)
 			return nil, err
 		}
 	}
+	if err = writer.flush(kt); err != nil {
+		return nil, err
+	}
 
 	return new(SyncResult), nil
 }
@@ -121,14 +124,29 @@ func (cli *client) LoadBalancerLayer4Rule(kt *kit.Kit, lbID string, l4Listeners
 func (cli *client) ListenerLayer7Rule(kt *kit.Kit, params *SyncBaseParams, opt *SyncLayer7RuleOption,
 	cloudListener typeslb.TCloudListener) (*SyncResult, error) {
 
+	writer := cli.newLayer7RuleWriter()
+	if err := cli.listenerLayer7Rule(kt, params, opt, cloudListener, writer); err != nil {
+		return nil, err
+	}
+
+	if err := writer.flush(kt); err != nil {
+		return nil, err
+	}
+	return new(SyncResult), nil
+}
+
+// listenerLayer7Rule 同步指定监听器下的7层规则，删除的规则直接删除，创建、更新的规则添加到writer中合并写入
+func (cli *client) listenerLayer7Rule(kt *kit.Kit, params *SyncBaseParams, opt *SyncLayer7RuleOption,
+	cloudListener typeslb.TCloudListener, writer *layer7RuleWriter) error {
+
 	// 对于七层规则逐个监听器进行同步
 	dbRules, err := cli.listL7RuleFromDB(kt, opt.ListenerID)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if len(cloudListener.Rules) == 0 && len(dbRules) == 0 {
-		return new(SyncResult), nil
+		return nil
 	}
 
 	cloudRules := make([]typeslb.TCloudUrlRule, 0, len(cloudListener.Rules))
@@ -143,17 +161,64 @@ func (cli *client) ListenerLayer7Rule(kt *kit.Kit, params *SyncBaseParams, opt *
 		cloudRules, dbRules, isLayer7RuleChange)
 
 	if err = cli.deleteLayer7Rule(kt, params.Region, delCloudIDs); err != nil {
-		return nil, err
+		return err
 	}
 
-	if err = cli.updateLayer7Rule(kt, params.Region, updateMap); err != nil {
-		return nil, err
+	if err = cli.updateLayer7Rule(kt, params.Region, updateMap, writer); err != nil {
+		return err
 	}
 
-	if _, err = cli.createLayer7Rule(kt, params.Region, opt, addSlice); err != nil {
-		return nil, err
+	return cli.createLayer7Rule(kt, params.Region, opt, addSlice, writer)
+}
+
+// layer7RuleWriter 合并多个监听器的七层规则的创建、更新请求
+type layer7RuleWriter struct {
+	creator *common.Batcher[dataproto.TCloudUrlRuleCreate]
+	updater *common.Batcher[*dataproto.TCloudUrlRuleUpdate]
+}
+
+func (cli *client) newLayer7RuleWriter() *layer7RuleWriter {
+	create := func(kt *kit.Kit, rules []dataproto.TCloudUrlRuleCreate) error {
+		_, err := cli.dbCli.TCloud.LoadBalancer.BatchCreateTCloudUrlRule(kt,
+			&dataproto.TCloudUrlRuleBatchCreateReq{UrlRules: rules})
+		if err != nil {
+			logs.Errorf("fail to create rule while sync, err: %v, count: %d, rid: %s", err, len(rules), kt.Rid)
+			return err
+		}
+		return nil
+	}
+
+	update := func(kt *kit.Kit, rules []*dataproto.TCloudUrlRuleUpdate) error {
+		err := cli.dbCli.TCloud.LoadBalancer.BatchUpdateTCloudUrlRule(kt,
+			&dataproto.TCloudUrlRuleBatchUpdateReq{UrlRules: rules})
+		if err != nil {
+			logs.Errorf("fail to update rule while sync, err: %v, count: %d, rid: %s", err, len(rules), kt.Rid)
+			return err
+		}
+		return nil
+	}
+
+	return &layer7RuleWriter{
+		creator: common.NewBatcher(constant.BatchOperationMaxLimit, create),
+		updater: common.NewBatcher(constant.BatchOperationMaxLimit, update),
 	}
-	return nil, nil
+}
+
+// flush write the remaining rules, rules are updated before created as they are synced in a listener.
+func (w *layer7RuleWriter) flush(kt *kit.Kit) error {
+	if err := w.updater.Flush(kt); err != nil {
+		return err
+	}
+
+	if err := w.creator.Flush(kt); err != nil {
+		return err
+	}
+
+	updated, updateReqs := w.updater.Stats()
+	created, createReqs := w.creator.Stats()
+	logs.V(3).Infof("sync layer7 rule, updated %d rules by %d requests, created %d rules by %d requests, rid: %s",
+		updated, updateReqs, created, createReqs, kt.Rid)
+	return nil
 }
 
 func (cli *client) listL4RuleFromDB(kt *kit.Kit, lbID string) ([]corelb.TCloudLbUrlRule, error) {
@@ -243,51 +308,34 @@ func (cli *client) deleteLayer7Rule(kt *kit.Kit, region string, cloudIds []strin
 }
 
 func (cli *client) createLayer7Rule(kt *kit.Kit, region string, opt *SyncLayer7RuleOption,
-	addSlice []typeslb.TCloudUrlRule) ([]string, error) {
-
-	if len(addSlice) == 0 {
-		return nil, nil
-	}
+	addSlice []typeslb.TCloudUrlRule, writer *layer7RuleWriter) error {
 
-	createdIDs := make([]string, 0, len(addSlice))
 	dbRules := make([]dataproto.TCloudUrlRuleCreate, 0, len(addSlice))
-
-	for _, addSliceBatch := range slice.Split(addSlice, constant.BatchOperationMaxLimit) {
-		dbRules = dbRules[:0]
-		for _, cloud := range addSliceBatch {
-			dbRules = append(dbRules, dataproto.TCloudUrlRuleCreate{
-				LbID:       opt.LBID,
-				CloudLbID:  opt.CloudLBID,
-				LblID:      opt.ListenerID,
-				CloudLBLID: opt.CloudListenerID,
-				CloudID:    cloud.GetCloudID(),
-				RuleType:   enumor.Layer7RuleType,
-
-				Region:    region,
-				Domain:    cvt.PtrToVal(cloud.Domain),
-				URL:       cvt.PtrToVal(cloud.Url),
-				Scheduler: cvt.PtrToVal(cloud.Scheduler),
-
-				SessionExpire: cvt.PtrToVal(cloud.SessionExpireTime),
-				HealthCheck:   convHealthCheck(cloud.HealthCheck),
-				Certificate:   convCert(cloud.Certificate),
-			})
-		}
-
-		ruleCreated, err := cli.dbCli.TCloud.LoadBalancer.BatchCreateTCloudUrlRule(kt,
-			&dataproto.TCloudUrlRuleBatchCreateReq{UrlRules: dbRules})
-		if err != nil {
-			logs.Errorf("fail to create rule while sync, err: %v syncOpt: %+v, rid: %s",
-				err, opt, kt.Rid)
-			return nil, err
-		}
-		createdIDs = append(createdIDs, ruleCreated.IDs...)
+	for _, cloud := range addSlice {
+		dbRules = append(dbRules, dataproto.TCloudUrlRuleCreate{
+			LbID:       opt.LBID,
+			CloudLbID:  opt.CloudLBID,
+			LblID:      opt.ListenerID,
+			CloudLBLID: opt.CloudListenerID,
+			CloudID:    cloud.GetCloudID(),
+			RuleType:   enumor.Layer7RuleType,
+
+			Region:    region,
+			Domain:    cvt.PtrToVal(cloud.Domain),
+			URL:       cvt.PtrToVal(cloud.Url),
+			Scheduler: cvt.PtrToVal(cloud.Scheduler),
+
+			SessionExpire: cvt.PtrToVal(cloud.SessionExpireTime),
+			HealthCheck:   convHealthCheck(cloud.HealthCheck),
+			Certificate:   convCert(cloud.Certificate),
+		})
 	}
 
-	return createdIDs, nil
+	return writer.creator.Add(kt, dbRules...)
 }
 
-func (cli *client) updateLayer7Rule(kt *kit.Kit, region string, updateMap map[string]typeslb.TCloudUrlRule) error {
+func (cli *client) updateLayer7Rule(kt *kit.Kit, region string, updateMap map[string]typeslb.TCloudUrlRule,
+	writer *layer7RuleWriter) error {
 
 	if len(updateMap) == 0 {
 		return nil
@@ -308,16 +356,9 @@ func (cli *client) updateLayer7Rule(kt *kit.Kit, region string, updateMap map[st
 		})
 	}
 
-	for _, updateBatch := range slice.Split(updates, constant.BatchOperationMaxLimit) {
-		err := cli.dbCli.TCloud.LoadBalancer.BatchUpdateTCloudUrlRule(kt,
-			&dataproto.TCloudUrlRuleBatchUpdateReq{UrlRules: updateBatch})
-		if err != nil {
-			logs.Errorf("fail to update rule while sync, err: %v, rid: %s", err, kt.Rid)
-			return err
-		}
-	}
-	return nil
+	return writer.updater.Add(kt, updates...)
 }
+
 func convHealthCheck(cloud *tclb.HealthCheck) *corelb.TCloudHealthCheckInfo {
 	if cloud == nil {
 		return nil
@@ -85,7 +85,14 @@ func (cli *client) SecurityGroup(kt *kit.Kit, params *SyncBaseParams, opt *SyncS
 	if len(addSlice) > 0 {
 		_, err = cli.createSG(kt, params.AccountID, params.Region, addSlice)
 		if err != nil {
-			return nil, err
+			if !errf.IsDuplicated(err) {
+				return nil, err
+			}
+
+			// 安全组已被其他同步任务创建，重新对比DB数据，将已存在的安全组作为更新处理
+			if err = cli.resyncDuplicatedSG(kt, params, addSlice); err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -167,6 +174,44 @@ func (cli *client) updateSG(kt *kit.Kit, accountID string,
 	return nil
 }
 
+// resyncDuplicatedSG 创建安全组时数据已存在，重新从DB获取安全组，已存在的安全组作为更新处理，其余的安全组重新创建
+func (cli *client) resyncDuplicatedSG(kt *kit.Kit, params *SyncBaseParams, sgFromCloud []securitygroup.TCloudSG) error {
+	cloudIDs := make([]string, 0, len(sgFromCloud))
+	for _, one := range sgFromCloud {
+		cloudIDs = append(cloudIDs, cvt.PtrToVal(one.SecurityGroupId))
+	}
+
+	dbParams := &SyncBaseParams{
+		AccountID: params.AccountID,
+		Region:    params.Region,
+		CloudIDs:  cloudIDs,
+	}
+	sgFromDB, err := cli.listSGFromDB(kt, dbParams)
+	if err != nil {
+		return err
+	}
+
+	addSlice, updateMap, _ := common.Diff[securitygroup.TCloudSG, cloudcore.SecurityGroup[cloudcore.TCloudSecurityGroupExtension]](
+		sgFromCloud, sgFromDB, isSGChange)
+
+	logs.Warnf("[%s] sync sg but some sg already exists, retry to create: %d, update: %d, accountID: %s, rid: %s",
+		enumor.TCloud, len(addSlice), len(updateMap), params.AccountID, kt.Rid)
+
+	if len(addSlice) > 0 {
+		if _, err = cli.createSG(kt, params.AccountID, params.Region, addSlice); err != nil {
+			return err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updateSG(kt, params.AccountID, updateMap); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (cli *client) createSG(kt *kit.Kit, accountID string, region string,
 	addSlice []securitygroup.TCloudSG) ([]string, error) {
 
@@ -235,7 +280,8 @@ func (cli *client) deleteSG(kt *kit.Kit, accountID string, region string, delClo
 	}
 
 	deleteReq := &protocloud.SecurityGroupBatchDeleteReq{
-		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
+		Filter:         tools.ContainersExpression("cloud_id", delCloudIDs),
+		SkipInUseCheck: true,
 	}
 	if err = cli.dbCli.Global.SecurityGroup.BatchDeleteSecurityGroup(kt.Ctx, kt.Header(), deleteReq); err != nil {
 		logs.Errorf("[%s] request dataservice to batch delete sg failed, err: %v, rid: %s", enumor.TCloud,
@@ -23,6 +23,7 @@ import (
 	"hcm/pkg/adaptor/types"
 	"hcm/pkg/api/core/cloud"
 	proto "hcm/pkg/api/hc-service/account"
+	"hcm/pkg/credential"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/rest"
 )
@@ -37,6 +38,11 @@ func (svc *service) TCloudAccountCheck(cts *rest.Contexts) (interface{}, error)
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	// 秘钥可以是外部凭据的引用，校验前解析为秘钥明文
+	if err := credential.ResolveFields(cts.Kit, "", &req.CloudSecretID, &req.CloudSecretKey); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
 	client, err := svc.ad.Adaptor().TCloud(
 		&types.BaseSecret{
 			CloudSecretID:  req.CloudSecretID,
@@ -75,6 +81,10 @@ func (svc *service) AwsAccountCheck(cts *rest.Contexts) (interface{}, error) {
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	if err := credential.ResolveFields(cts.Kit, "", &req.CloudSecretID, &req.CloudSecretKey); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
 	client, err := svc.ad.Adaptor().Aws(
 		&types.BaseSecret{
 			CloudSecretID:  req.CloudSecretID,
@@ -110,6 +120,10 @@ func (svc *service) HuaWeiAccountCheck(cts *rest.Contexts) (interface{}, error)
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	if err := credential.ResolveFields(cts.Kit, "", &req.CloudSecretID, &req.CloudSecretKey); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
 	client, err := svc.ad.Adaptor().HuaWei(
 		&types.BaseSecret{
 			CloudSecretID:  req.CloudSecretID,
@@ -155,6 +169,10 @@ func (svc *service) GcpAccountCheck(cts *rest.Contexts) (interface{}, error) {
 		return nil, errf.Newf(errf.InvalidParameter, err.Error())
 	}
 
+	if err := credential.ResolveFields(cts.Kit, "", &req.CloudServiceSecretKey); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
 	client, err := svc.ad.Adaptor().Gcp(
 		&types.GcpCredential{
 			CloudProjectID: req.CloudProjectID,
@@ -209,6 +227,10 @@ func (svc *service) AzureAccountCheck(cts *rest.Contexts) (interface{}, error) {
 		return nil, errf.Newf(errf.InvalidParameter, err.Error())
 	}
 
+	if err := credential.ResolveFields(cts.Kit, "", &req.CloudClientSecretKey); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
 	client, err := svc.ad.Adaptor().Azure(
 		&types.AzureCredential{
 			CloudTenantID:        req.CloudTenantID,
@@ -55,7 +55,7 @@ func Test_TCloudGetResCountBySecret(t *testing.T) {
 	}
 
 	svc := &service{
-		ad: cloudadaptor.NewCloudAdaptorClient(nil),
+		ad: cloudadaptor.NewCloudAdaptorClient(nil, 0),
 	}
 	got, err := svc.TCloudGetResCountBySecret(&rest.Contexts{Kit: kit.New(), Request: restful.NewRequest(request)})
 	if err != nil {
@@ -98,7 +98,7 @@ func Test_AwsGetResCountBySecret(t *testing.T) {
 	}
 
 	svc := &service{
-		ad: cloudadaptor.NewCloudAdaptorClient(nil),
+		ad: cloudadaptor.NewCloudAdaptorClient(nil, 0),
 	}
 	got, err := svc.AwsGetResCountBySecret(&rest.Contexts{Kit: kit.New(), Request: restful.NewRequest(request)})
 	if err != nil {
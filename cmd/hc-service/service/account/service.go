@@ -25,6 +25,8 @@ import (
 
 	"hcm/cmd/hc-service/logics/cloud-adaptor"
 	"hcm/cmd/hc-service/service/capability"
+	"hcm/pkg/api/core/cloud"
+	proto "hcm/pkg/api/hc-service/account"
 	"hcm/pkg/rest"
 )
 
@@ -36,11 +38,16 @@ func InitAccountService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 	// 联通性和云上字段匹配校验
-	h.Add("TCloudAccountCheck", http.MethodPost, "/vendors/tcloud/accounts/check", svc.TCloudAccountCheck)
-	h.Add("AwsAccountCheck", http.MethodPost, "/vendors/aws/accounts/check", svc.AwsAccountCheck)
-	h.Add("HuaWeiAccountCheck", http.MethodPost, "/vendors/huawei/accounts/check", svc.HuaWeiAccountCheck)
-	h.Add("GcpAccountCheck", http.MethodPost, "/vendors/gcp/accounts/check", svc.GcpAccountCheck)
-	h.Add("AzureAccountCheck", http.MethodPost, "/vendors/azure/accounts/check", svc.AzureAccountCheck)
+	h.Add("TCloudAccountCheck", http.MethodPost, "/vendors/tcloud/accounts/check", svc.TCloudAccountCheck).
+		Reads(proto.TCloudAccountCheckReq{})
+	h.Add("AwsAccountCheck", http.MethodPost, "/vendors/aws/accounts/check", svc.AwsAccountCheck).
+		Reads(proto.AwsAccountCheckReq{})
+	h.Add("HuaWeiAccountCheck", http.MethodPost, "/vendors/huawei/accounts/check", svc.HuaWeiAccountCheck).
+		Reads(proto.HuaWeiAccountCheckReq{})
+	h.Add("GcpAccountCheck", http.MethodPost, "/vendors/gcp/accounts/check", svc.GcpAccountCheck).
+		Reads(proto.GcpAccountCheckReq{})
+	h.Add("AzureAccountCheck", http.MethodPost, "/vendors/azure/accounts/check", svc.AzureAccountCheck).
+		Reads(proto.AzureAccountCheckReq{})
 
 	// 获取账号配额
 	h.Add("GetTCloudAccountZoneQuota", http.MethodPost, "/vendors/tcloud/accounts/zones/quotas",
@@ -51,11 +58,16 @@ func InitAccountService(cap *capability.Capability) {
 		svc.GetGcpAccountRegionQuota)
 
 	// 通过秘钥获取账号信息
-	h.Add("TCloudGetInfoBySecret", http.MethodPost, "/vendors/tcloud/accounts/secret", svc.TCloudGetInfoBySecret)
-	h.Add("AwsGetInfoBySecret", http.MethodPost, "/vendors/aws/accounts/secret", svc.AwsGetInfoBySecret)
-	h.Add("HuaWeiGetInfoBySecret", http.MethodPost, "/vendors/huawei/accounts/secret", svc.HuaWeiGetInfoBySecret)
-	h.Add("GcpGetInfoBySecret", http.MethodPost, "/vendors/gcp/accounts/secret", svc.GcpGetInfoBySecret)
-	h.Add("AzureGetInfoBySecret", http.MethodPost, "/vendors/azure/accounts/secret", svc.AzureGetInfoBySecret)
+	h.Add("TCloudGetInfoBySecret", http.MethodPost, "/vendors/tcloud/accounts/secret", svc.TCloudGetInfoBySecret).
+		Reads(cloud.TCloudSecret{}).Writes(cloud.TCloudInfoBySecret{})
+	h.Add("AwsGetInfoBySecret", http.MethodPost, "/vendors/aws/accounts/secret", svc.AwsGetInfoBySecret).
+		Reads(cloud.AwsSecret{}).Writes(cloud.AwsInfoBySecret{})
+	h.Add("HuaWeiGetInfoBySecret", http.MethodPost, "/vendors/huawei/accounts/secret", svc.HuaWeiGetInfoBySecret).
+		Reads(cloud.HuaWeiSecret{}).Writes(cloud.HuaWeiInfoBySecret{})
+	h.Add("GcpGetInfoBySecret", http.MethodPost, "/vendors/gcp/accounts/secret", svc.GcpGetInfoBySecret).
+		Reads(cloud.GcpSecret{}).Writes(cloud.GcpInfoBySecret{})
+	h.Add("AzureGetInfoBySecret", http.MethodPost, "/vendors/azure/accounts/secret", svc.AzureGetInfoBySecret).
+		Reads(cloud.AzureSecret{}).Writes(cloud.AzureInfoBySecret{})
 
 	// 通过秘钥获取资源数量
 	h.Add("HuaWeiGetResCountBySecret", http.MethodPost, "/vendors/huawei/accounts/res_counts/by_secrets",
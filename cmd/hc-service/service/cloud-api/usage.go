@@ -0,0 +1,69 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package cloudapi defines the service to query the usage of cloud apis.
+package cloudapi
+
+import (
+	"net/http"
+	"strconv"
+
+	"hcm/cmd/hc-service/service/capability"
+	"hcm/pkg/adaptor/apiusage"
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the cloud api service
+func InitService(cap *capability.Capability) {
+	svc := &service{}
+
+	h := rest.NewHandler()
+
+	h.Add("ListCloudApiUsage", http.MethodGet, "/cloud_apis/usages", svc.ListCloudApiUsage)
+
+	h.Load(cap.WebService)
+}
+
+type service struct{}
+
+// ListCloudApiUsage 查询当前实例调用云上接口的次数和频率，可以按云厂商、账号过滤，near_limit=true时只返回接近频率限制的接口
+func (svc *service) ListCloudApiUsage(cts *rest.Contexts) (interface{}, error) {
+	opt := apiusage.ListOption{
+		Vendor:    enumor.Vendor(cts.Request.QueryParameter("vendor")),
+		AccountID: cts.Request.QueryParameter("account_id"),
+	}
+	if len(opt.Vendor) != 0 {
+		if err := opt.Vendor.Validate(); err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+	}
+
+	if nearLimit := cts.Request.QueryParameter("near_limit"); len(nearLimit) != 0 {
+		var err error
+		if opt.NearLimitOnly, err = strconv.ParseBool(nearLimit); err != nil {
+			return nil, errf.Newf(errf.InvalidParameter, "near_limit is invalid, err: %v", err)
+		}
+	}
+
+	usages := apiusage.List(opt)
+	return &core.ListResultT[apiusage.Usage]{Count: uint64(len(usages)), Details: usages}, nil
+}
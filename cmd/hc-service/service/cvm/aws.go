@@ -150,7 +150,10 @@ func (svc *cvmSvc) BatchCreateAwsCvm(cts *rest.Contexts) (interface{}, error) {
 		BlockDeviceMapping:    req.BlockDeviceMapping,
 		PublicIPAssigned:      req.PublicIPAssigned,
 	}
-	result, err := awsCli.CreateCvm(cts.Kit, createOpt)
+	createKt, cancel := createCvmKit(cts.Kit)
+	defer cancel()
+
+	result, err := awsCli.CreateCvm(createKt, createOpt)
 	if err != nil {
 		logs.Errorf("create aws cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
 		return nil, err
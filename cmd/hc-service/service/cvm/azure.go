@@ -89,7 +89,7 @@ func (svc *cvmSvc) CreateAzureCvm(cts *rest.Contexts) (interface{}, error) {
 	return &protocvm.AzureCreateResp{CloudID: cloudID}, nil
 }
 
-func (svc *cvmSvc) createAzureCvm(kt *kit.Kit, azureCli *azure.Azure, req *protocvm.AzureCreateReq) (
+func (svc *cvmSvc) createAzureCvm(kt *kit.Kit, azureCli azure.Azure, req *protocvm.AzureCreateReq) (
 	string, error) {
 
 	listImageReq := &core.ListReq{
@@ -151,7 +151,10 @@ func (svc *cvmSvc) createAzureCvm(kt *kit.Kit, azureCli *azure.Azure, req *proto
 			Type:   one.Type,
 		}
 	}
-	cloudID, err := azureCli.CreateCvm(kt, createOpt)
+	createKt, cancel := createCvmKit(kt)
+	defer cancel()
+
+	cloudID, err := azureCli.CreateCvm(createKt, createOpt)
 	if err != nil {
 		logs.Errorf("create cvm failed, err: %v, rid: %s", err, kt.Rid)
 		return "", err
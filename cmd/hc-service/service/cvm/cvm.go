@@ -21,6 +21,7 @@
 package cvm
 
 import (
+	"context"
 	"fmt"
 
 	cloudadaptor "hcm/cmd/hc-service/logics/cloud-adaptor"
@@ -29,6 +30,7 @@ import (
 	"hcm/pkg/api/core/cloud"
 	corecvm "hcm/pkg/api/core/cloud/cvm"
 	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/cc"
 	"hcm/pkg/client"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/dal/dao/tools"
@@ -57,6 +59,11 @@ type cvmSvc struct {
 	client  *client.ClientSet
 }
 
+// createCvmKit 生成调用云上接口创建主机的子kit，超时后取消创建，避免云上接口无响应时长时间占用处理协程
+func createCvmKit(kt *kit.Kit) (*kit.Kit, context.CancelFunc) {
+	return kt.WithTimeout(cc.HCService().Timeout.CreateCvm())
+}
+
 func (svc *cvmSvc) listCvms(kt *kit.Kit, cvmIDs ...string) ([]corecvm.BaseCvm, error) {
 	if len(cvmIDs) == 0 {
 		return nil, nil
@@ -101,7 +101,10 @@ func (svc *cvmSvc) BatchCreateGcpCvm(cts *rest.Contexts) (interface{}, error) {
 		SystemDisk:          req.SystemDisk,
 		DataDisk:            req.DataDisk,
 	}
-	result, err := gcpCli.CreateCvm(cts.Kit, createOpt)
+	createKt, cancel := createCvmKit(cts.Kit)
+	defer cancel()
+
+	result, err := gcpCli.CreateCvm(createKt, createOpt)
 	if err != nil {
 		logs.Errorf("create cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
 		return nil, err
@@ -134,7 +134,10 @@ func (svc *cvmSvc) BatchCreateHuaWeiCvm(cts *rest.Contexts) (interface{}, error)
 		PublicIPAssigned:      req.PublicIPAssigned,
 		Eip:                   req.Eip,
 	}
-	result, err := huawei.CreateCvm(cts.Kit, createOpt)
+	createKt, cancel := createCvmKit(cts.Kit)
+	defer cancel()
+
+	result, err := huawei.CreateCvm(createKt, createOpt)
 	if err != nil {
 		logs.Errorf("create huawei cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
 		return nil, err
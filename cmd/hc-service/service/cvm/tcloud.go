@@ -209,8 +209,12 @@ func (svc *cvmSvc) BatchCreateTCloudCvm(cts *rest.Contexts) (interface{}, error)
 		InternetMaxBandwidthOut: req.InternetMaxBandwidthOut,
 		InternetChargeType:      req.InternetChargeType,
 		BandwidthPackageID:      req.BandwidthPackageID,
+		UserData:                req.UserData,
 	}
-	result, err := tcloud.CreateCvm(cts.Kit, createOpt)
+	createKt, cancel := createCvmKit(cts.Kit)
+	defer cancel()
+
+	result, err := tcloud.CreateCvm(createKt, createOpt)
 	if err != nil {
 		logs.Errorf("create cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
 		return nil, err
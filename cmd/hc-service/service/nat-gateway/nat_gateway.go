@@ -0,0 +1,175 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package natgateway defines nat gateway service.
+package natgateway
+
+import (
+	"net/http"
+
+	cloudadaptor "hcm/cmd/hc-service/logics/cloud-adaptor"
+	"hcm/cmd/hc-service/service/capability"
+	adcore "hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/eip"
+	natgateway "hcm/pkg/adaptor/types/nat-gateway"
+	hcnat "hcm/pkg/api/hc-service/nat-gateway"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitNatGatewayService initial the nat gateway service, nat gateway is not managed by hcm, so it is operated by
+// cloud id directly.
+func InitNatGatewayService(cap *capability.Capability) {
+	svc := &natGateway{
+		ad: cap.CloudAdaptor,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("TCloudNatGatewayCreate", http.MethodPost, "/vendors/tcloud/nat_gateways/create",
+		svc.TCloudNatGatewayCreate)
+	h.Add("TCloudNatGatewayList", http.MethodPost, "/vendors/tcloud/nat_gateways/list", svc.TCloudNatGatewayList)
+	h.Add("TCloudNatGatewayDelete", http.MethodDelete, "/vendors/tcloud/nat_gateways", svc.TCloudNatGatewayDelete)
+
+	h.Load(cap.WebService)
+}
+
+type natGateway struct {
+	ad *cloudadaptor.CloudAdaptorClient
+}
+
+// TCloudNatGatewayCreate create tencent cloud nat gateway, return without waiting for it to be available.
+func (svc *natGateway) TCloudNatGatewayCreate(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcnat.TCloudNatGatewayCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cli, err := svc.ad.TCloud(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &natgateway.TCloudNatGatewayCreateOption{
+		Region:                  req.Region,
+		CloudVpcID:              req.CloudVpcID,
+		Name:                    req.Name,
+		Zone:                    req.Zone,
+		InternetMaxBandwidthOut: req.InternetMaxBandwidthOut,
+		MaxConcurrentConnection: req.MaxConcurrentConnection,
+		AddressCount:            req.AddressCount,
+	}
+	return cli.CreateNatGateway(cts.Kit, opt)
+}
+
+// TCloudNatGatewayList list tencent cloud nat gateway by cloud ids.
+func (svc *natGateway) TCloudNatGatewayList(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcnat.TCloudNatGatewayListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cli, err := svc.ad.TCloud(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &adcore.TCloudListOption{
+		Region:   req.Region,
+		CloudIDs: req.CloudIDs,
+		Page:     &adcore.TCloudPage{Offset: 0, Limit: adcore.TCloudQueryLimit},
+	}
+	result, err := cli.ListNatGateway(cts.Kit, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcnat.TCloudNatGatewayListResult{Details: result.Details}, nil
+}
+
+// TCloudNatGatewayDelete delete tencent cloud nat gateway and release the given eips, it is idempotent, nat gateway
+// which is already deleted is skipped.
+func (svc *natGateway) TCloudNatGatewayDelete(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcnat.TCloudNatGatewayDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cli, err := svc.ad.TCloud(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	listOpt := &adcore.TCloudListOption{
+		Region:   req.Region,
+		CloudIDs: []string{req.CloudID},
+		Page:     &adcore.TCloudPage{Offset: 0, Limit: adcore.TCloudQueryLimit},
+	}
+	listRes, err := cli.ListNatGateway(cts.Kit, listOpt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(listRes.Details) != 0 {
+		delOpt := &adcore.BaseRegionalDeleteOption{
+			BaseDeleteOption: adcore.BaseDeleteOption{ResourceID: req.CloudID},
+			Region:           req.Region,
+		}
+		if err = cli.DeleteNatGateway(cts.Kit, delOpt); err != nil {
+			logs.Errorf("delete tcloud nat gateway %s failed, err: %v, rid: %s", req.CloudID, err, cts.Kit.Rid)
+			return nil, err
+		}
+	}
+
+	if len(req.ReleaseCloudEipIDs) == 0 {
+		return nil, nil
+	}
+
+	// 只释放仍然存在的弹性IP，重试时已释放的弹性IP会被跳过
+	eipRes, err := cli.ListEip(cts.Kit, &eip.TCloudEipListOption{Region: req.Region, CloudIDs: req.ReleaseCloudEipIDs,
+		Page: &adcore.TCloudPage{Offset: 0, Limit: adcore.TCloudQueryLimit}})
+	if err != nil {
+		return nil, err
+	}
+	if len(eipRes.Details) == 0 {
+		return nil, nil
+	}
+
+	cloudIDs := make([]string, 0, len(eipRes.Details))
+	for _, one := range eipRes.Details {
+		cloudIDs = append(cloudIDs, one.CloudID)
+	}
+	if err = cli.DeleteEip(cts.Kit, &eip.TCloudEipDeleteOption{CloudIDs: cloudIDs, Region: req.Region}); err != nil {
+		logs.Errorf("release eips %v of nat gateway %s failed, err: %v, rid: %s", cloudIDs, req.CloudID, err,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
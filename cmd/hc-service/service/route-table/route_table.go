@@ -36,6 +36,8 @@ func InitRouteTableService(cap *capability.Capability) {
 
 	h := rest.NewHandler()
 
+	h.Add("TCloudRouteTableCreate", "POST", "/vendors/tcloud/route_tables/create", r.TCloudRouteTableCreate)
+
 	h.Add("TCloudRouteTableUpdate", "PATCH", "/vendors/tcloud/route_tables/{id}", r.TCloudRouteTableUpdate)
 	h.Add("AwsRouteTableUpdate", "PATCH", "/vendors/aws/route_tables/{id}", r.AwsRouteTableUpdate)
 	h.Add("HuaWeiRouteTableUpdate", "PATCH", "/vendors/huawei/route_tables/{id}", r.HuaWeiRouteTableUpdate)
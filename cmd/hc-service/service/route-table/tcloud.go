@@ -21,16 +21,83 @@
 package routetable
 
 import (
+	synctcloud "hcm/cmd/hc-service/logics/res-sync/tcloud"
 	adcore "hcm/pkg/adaptor/types/core"
 	routetable "hcm/pkg/adaptor/types/route-table"
+	"hcm/pkg/api/core"
 	dataservice "hcm/pkg/api/data-service"
 	dataproto "hcm/pkg/api/data-service/cloud/route-table"
 	hcroutetable "hcm/pkg/api/hc-service/route-table"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 )
 
+// TCloudRouteTableCreate create tencent cloud route table with routes, and sync it to hcm.
+func (r routeTable) TCloudRouteTableCreate(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcroutetable.TCloudRouteTableCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cli, err := r.ad.TCloud(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	createOpt := &routetable.TCloudRouteTableCreateOption{
+		Region:     req.Region,
+		CloudVpcID: req.CloudVpcID,
+		Name:       req.Name,
+		Routes:     make([]routetable.TCloudRouteCreateOption, 0, len(req.Routes)),
+	}
+	for _, route := range req.Routes {
+		createOpt.Routes = append(createOpt.Routes, routetable.TCloudRouteCreateOption{
+			DestinationCidrBlock: route.DestinationCidrBlock,
+			GatewayType:          route.GatewayType,
+			CloudGatewayID:       route.CloudGatewayID,
+			Memo:                 route.Memo,
+		})
+	}
+	created, err := cli.CreateRouteTable(cts.Kit, createOpt)
+	if err != nil {
+		return nil, err
+	}
+
+	// sync the created route table and its routes to hcm
+	syncClient := synctcloud.NewClient(r.cs.DataService(), cli)
+	params := &synctcloud.SyncBaseParams{
+		AccountID: req.AccountID,
+		Region:    req.Region,
+		CloudIDs:  []string{created.CloudID},
+	}
+	if _, err = syncClient.RouteTable(cts.Kit, params, &synctcloud.SyncRouteTableOption{}); err != nil {
+		logs.Errorf("sync tcloud route table %s failed, err: %v, rid: %s", created.CloudID, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(tools.RuleEqual("vendor", enumor.TCloud),
+			tools.RuleEqual("cloud_id", created.CloudID)),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id"},
+	}
+	listRes, err := r.cs.DataService().Global.RouteTable.List(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+	if err != nil {
+		return nil, err
+	}
+	if len(listRes.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "route table %s not found after sync", created.CloudID)
+	}
+
+	return core.CreateResult{ID: listRes.Details[0].ID}, nil
+}
+
 // TCloudRouteTableUpdate update tencent cloud route table.
 func (r routeTable) TCloudRouteTableUpdate(cts *rest.Contexts) (interface{}, error) {
 	id := cts.PathParameter("id").String()
@@ -36,6 +36,7 @@ import (
 	"hcm/cmd/hc-service/service/bill"
 	"hcm/cmd/hc-service/service/capability"
 	"hcm/cmd/hc-service/service/cert"
+	cloudapi "hcm/cmd/hc-service/service/cloud-api"
 	"hcm/cmd/hc-service/service/cvm"
 	"hcm/cmd/hc-service/service/disk"
 	"hcm/cmd/hc-service/service/eip"
@@ -44,15 +45,23 @@ import (
 	instancetype "hcm/cmd/hc-service/service/instance-type"
 	loadbalancer "hcm/cmd/hc-service/service/load-balancer"
 	mainaccount "hcm/cmd/hc-service/service/main-account"
+	natgateway "hcm/cmd/hc-service/service/nat-gateway"
 	routetable "hcm/cmd/hc-service/service/route-table"
 	securitygroup "hcm/cmd/hc-service/service/security-group"
 	"hcm/cmd/hc-service/service/subnet"
 	"hcm/cmd/hc-service/service/sync"
 	"hcm/cmd/hc-service/service/vpc"
+	"hcm/pkg/adaptor"
+	"hcm/pkg/adaptor/apiusage"
+	"hcm/pkg/adaptor/fault"
+	"hcm/pkg/adaptor/operatortag"
+	"hcm/pkg/api/core"
 	"hcm/pkg/cc"
 	"hcm/pkg/client"
-	"hcm/pkg/criteria/errf"
+	"hcm/pkg/credential"
+	"hcm/pkg/featureflag"
 	"hcm/pkg/handler"
+	"hcm/pkg/health"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 	restcli "hcm/pkg/rest/client"
@@ -66,8 +75,11 @@ import (
 // Service do all the hc service's work
 type Service struct {
 	serve        *http.Server
+	inFlight     *shutdown.InFlight
 	clientSet    *client.ClientSet
 	cloudAdaptor *cloudadaptor.CloudAdaptorClient
+	// dis is used to check the dependent services in readiness probe
+	dis serviced.Discover
 }
 
 // NewService create a service instance.
@@ -78,8 +90,29 @@ func NewService(dis serviced.Discover) (*Service, error) {
 	}
 
 	cliSet := client.NewClientSet(cli, dis)
+	// data-service实例短暂异常时重试查询请求，避免整个同步任务失败
+	cliSet.EnableDataServiceResilience(cc.HCService().DataServiceClient)
+	// 开启后云资源同步的主机批量创建、更新和查询接口通过grpc调用data-service
+	if cc.HCService().DataServiceGrpc.Enable {
+		if err = cliSet.EnableDataServiceGrpc(cc.HCService().DataServiceGrpc); err != nil {
+			logs.Errorf("enable data-service grpc client failed, err: %v", err)
+			return nil, err
+		}
+	}
+
+	err = credential.Init(cc.HCService().Credential, cloudadaptor.NewSecretAuditor(cliSet.DataService()))
+	if err != nil {
+		logs.Errorf("init credential provider failed, err: %v", err)
+		return nil, err
+	}
 
-	cloudAdaptor := cloudadaptor.NewCloudAdaptorClient(cliSet.DataService())
+	featureflag.Init(func() cc.FeatureFlags { return cc.HCService().FeatureFlags })
+	adaptor.RegisterErrorParsers()
+	fault.Init(func() cc.FaultInjection { return cc.HCService().FaultInjection })
+	apiusage.Init(func() cc.CloudApiQuota { return cc.HCService().CloudApiQuota })
+	operatortag.Init(func() cc.OperatorTag { return cc.HCService().OperatorTag })
+
+	cloudAdaptor := cloudadaptor.NewCloudAdaptorClient(cliSet.DataService(), cc.HCService().ClientPool.TTL())
 	logs.Infof("sync concurrent: default %d", cc.HCService().SyncConfig.DefaultConcurrent)
 	for i := range cc.HCService().SyncConfig.ConcurrentRules {
 		rule := cc.HCService().SyncConfig.ConcurrentRules[i]
@@ -87,6 +120,7 @@ func NewService(dis serviced.Discover) (*Service, error) {
 	}
 
 	svr := &Service{
+		dis:          dis,
 		clientSet:    cliSet,
 		cloudAdaptor: cloudAdaptor,
 	}
@@ -99,12 +133,15 @@ func (s *Service) ListenAndServeRest() error {
 	root := http.NewServeMux()
 	root.HandleFunc("/", s.apiSet().ServeHTTP)
 	root.HandleFunc("/healthz", s.Healthz)
+	root.HandleFunc("/readyz", s.Readyz)
 	handler.SetCommonHandler(root)
 
 	network := cc.HCService().Network
+	rest.SetBodyLimit(network.BodyLimit)
+	rest.SetAccessLog(network.AccessLog)
 	server := &http.Server{
 		Addr:    net.JoinHostPort(network.BindIP, strconv.FormatUint(uint64(network.Port), 10)),
-		Handler: root,
+		Handler: s.inFlight.Wrap(root),
 	}
 
 	if network.TLS.Enable() {
@@ -126,12 +163,20 @@ func (s *Service) ListenAndServeRest() error {
 		case <-notifier.Signal:
 			defer notifier.Done()
 
-			logs.Infof("start shutdown restful server gracefully...")
+			// 此时已从服务发现注销，继续处理请求直到调用方刷新服务地址，再停止接收新请求
+			conf := cc.HCService().Shutdown
+			logs.Infof("wait %d seconds for callers to refresh service addresses...", conf.DeregisterWaitSec)
+			time.Sleep(time.Duration(conf.DeregisterWaitSec) * time.Second)
+
+			// 等待处理中的请求完成，避免中断创建中的云资源
+			logs.Infof("start shutdown restful server gracefully, draining %d in-flight requests...",
+				s.inFlight.Count())
 
-			ctx, cancel := context.WithTimeout(context.TODO(), 20*time.Second)
+			ctx, cancel := context.WithTimeout(context.TODO(), time.Duration(conf.DrainTimeoutSec)*time.Second)
 			defer cancel()
 			if err := server.Shutdown(ctx); err != nil {
-				logs.Errorf("shutdown restful server failed, err: %v", err)
+				logs.Errorf("shutdown restful server failed, err: %v, unfinished requests: %v", err,
+					s.inFlight.Unfinished())
 				return
 			}
 
@@ -171,6 +216,7 @@ func (s *Service) apiSet() *restful.Container {
 	disk.InitDiskService(c)
 	cvm.InitCvmService(c)
 	routetable.InitRouteTableService(c)
+	natgateway.InitNatGatewayService(c)
 	eip.InitEipService(c)
 	instancetype.InitInstanceTypeService(c)
 	sync.InitService(c)
@@ -181,26 +227,29 @@ func (s *Service) apiSet() *restful.Container {
 	bwpkg.InitBwPkgService(c)
 	mainaccount.InitService(c)
 	image.InitImageService(c)
+	cloudapi.InitService(c)
 
 	return restful.NewContainer().Add(c.WebService)
 }
 
 // Healthz check whether the service is healthy.
 func (s *Service) Healthz(w http.ResponseWriter, r *http.Request) {
+	health.ServeLiveness(w, r, health.Etcd(cc.HCService().Service))
+}
 
-	if shutdown.IsShuttingDown() {
-		logs.Errorf("service healthz check failed, current service is shutting down")
-		w.WriteHeader(http.StatusServiceUnavailable)
-		rest.WriteResp(w, rest.NewBaseResp(errf.UnHealthy, "current service is shutting down"))
-		return
-	}
-
-	if err := serviced.Healthz(r.Context(), cc.HCService().Service); err != nil {
-		logs.Errorf("etcd healthz check failed, err: %v", err)
-		rest.WriteResp(w, rest.NewBaseResp(errf.UnHealthy, "etcd healthz error, "+err.Error()))
-		return
+// Readyz check whether the service and its dependencies are ready to serve requests.
+func (s *Service) Readyz(w http.ResponseWriter, r *http.Request) {
+	checkers := health.Readiness(cc.HCService().Service, s.dis)
+	if accountID := cc.HCService().HealthCheck.SampleAccountID; len(accountID) != 0 {
+		checkers = append(checkers, health.Checker{
+			Name: "cloud_credential",
+			Check: func(ctx context.Context) error {
+				kt := core.NewBackendKit()
+				kt.Ctx = ctx
+				return s.cloudAdaptor.CheckCredential(kt, accountID)
+			},
+		})
 	}
 
-	rest.WriteResp(w, rest.NewBaseResp(errf.OK, "healthy"))
-	return
+	health.ServeReadiness(w, r, checkers...)
 }
@@ -25,7 +25,6 @@ import (
 	adcore "hcm/pkg/adaptor/types/core"
 	"hcm/pkg/adaptor/types/subnet"
 	"hcm/pkg/api/core"
-	dataservice "hcm/pkg/api/data-service"
 	"hcm/pkg/api/data-service/cloud"
 	hcservice "hcm/pkg/api/hc-service/subnet"
 	"hcm/pkg/criteria/errf"
@@ -161,7 +160,7 @@ func (s subnet) GcpSubnetDelete(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	deleteReq := &dataservice.BatchDeleteReq{
+	deleteReq := &cloud.SubnetBatchDeleteReq{
 		Filter: tools.EqualExpression("id", id),
 	}
 	err = s.cs.DataService().Global.Subnet.BatchDelete(cts.Kit.Ctx, cts.Kit.Header(), deleteReq)
@@ -29,7 +29,6 @@ import (
 	adcore "hcm/pkg/adaptor/types/core"
 	adtysubnet "hcm/pkg/adaptor/types/subnet"
 	"hcm/pkg/api/core"
-	dataservice "hcm/pkg/api/data-service"
 	"hcm/pkg/api/data-service/cloud"
 	proto "hcm/pkg/api/hc-service/subnet"
 	dataclient "hcm/pkg/client/data-service"
@@ -218,7 +217,7 @@ func (s subnet) HuaWeiSubnetDelete(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	deleteReq := &dataservice.BatchDeleteReq{
+	deleteReq := &cloud.SubnetBatchDeleteReq{
 		Filter: tools.EqualExpression("id", id),
 	}
 	err = s.cs.DataService().Global.Subnet.BatchDelete(cts.Kit.Ctx, cts.Kit.Header(), deleteReq)
@@ -26,7 +26,6 @@ import (
 	adcore "hcm/pkg/adaptor/types/core"
 	"hcm/pkg/adaptor/types/subnet"
 	"hcm/pkg/api/core"
-	dataservice "hcm/pkg/api/data-service"
 	"hcm/pkg/api/data-service/cloud"
 	proto "hcm/pkg/api/hc-service/subnet"
 	"hcm/pkg/criteria/errf"
@@ -118,7 +117,7 @@ func (s subnet) TCloudSubnetDelete(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	deleteReq := &dataservice.BatchDeleteReq{
+	deleteReq := &cloud.SubnetBatchDeleteReq{
 		Filter: tools.EqualExpression("id", id),
 	}
 	err = s.cs.DataService().Global.Subnet.BatchDelete(cts.Kit.Ctx, cts.Kit.Header(), deleteReq)
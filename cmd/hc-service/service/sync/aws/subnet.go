@@ -30,7 +30,6 @@ import (
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
-	"hcm/pkg/tools/converter"
 )
 
 // SyncSubnet ....
@@ -43,9 +42,9 @@ type subnetHandler struct {
 	cli ressync.Interface
 
 	// Prepare 构建参数
-	request   *sync.AwsSyncReq
-	syncCli   aws.Interface
-	nextToken *string
+	request *sync.AwsSyncReq
+	syncCli aws.Interface
+	pages   *typecore.Iterator[string, *string]
 }
 
 var _ handler.Handler = new(subnetHandler)
@@ -59,28 +58,27 @@ func (hd *subnetHandler) Prepare(cts *rest.Contexts) error {
 
 	hd.request = request
 	hd.syncCli = syncCli
+	hd.pages = typecore.NewAwsIterator(constant.CloudResourceSyncMaxLimit, hd.list, nil)
 
 	return nil
 }
 
 // Next ...
 func (hd *subnetHandler) Next(kt *kit.Kit) ([]string, error) {
+	return hd.pages.Next(kt)
+}
+
+// list 查询一页云上的子网，返回云ID
+func (hd *subnetHandler) list(kt *kit.Kit, page *typecore.AwsPage) ([]string, *string, error) {
 	listOpt := &typecore.AwsListOption{
 		Region: hd.request.Region,
-		Page: &typecore.AwsPage{
-			NextToken:  hd.nextToken,
-			MaxResults: converter.ValToPtr(int64(constant.CloudResourceSyncMaxLimit)),
-		},
+		Page:   page,
 	}
 
 	subnetResult, err := hd.syncCli.CloudCli().ListSubnet(kt, listOpt)
 	if err != nil {
 		logs.Errorf("request adaptor list aws subnet failed, err: %v, opt: %v, rid: %s", err, listOpt, kt.Rid)
-		return nil, err
-	}
-
-	if len(subnetResult.Details) == 0 {
-		return nil, nil
+		return nil, nil, err
 	}
 
 	cloudIDs := make([]string, 0, len(subnetResult.Details))
@@ -88,8 +86,7 @@ func (hd *subnetHandler) Next(kt *kit.Kit) ([]string, error) {
 		cloudIDs = append(cloudIDs, one.CloudID)
 	}
 
-	hd.nextToken = subnetResult.NextToken
-	return cloudIDs, nil
+	return cloudIDs, subnetResult.NextToken, nil
 }
 
 // Sync ...
@@ -30,7 +30,6 @@ import (
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
-	"hcm/pkg/tools/converter"
 )
 
 // SyncVpc ....
@@ -43,9 +42,9 @@ type vpcHandler struct {
 	cli ressync.Interface
 
 	// Prepare 构建参数
-	request   *sync.AwsSyncReq
-	syncCli   aws.Interface
-	nextToken *string
+	request *sync.AwsSyncReq
+	syncCli aws.Interface
+	pages   *typecore.Iterator[string, *string]
 }
 
 var _ handler.Handler = new(vpcHandler)
@@ -59,28 +58,27 @@ func (hd *vpcHandler) Prepare(cts *rest.Contexts) error {
 
 	hd.request = request
 	hd.syncCli = syncCli
+	hd.pages = typecore.NewAwsIterator(constant.CloudResourceSyncMaxLimit, hd.list, nil)
 
 	return nil
 }
 
 // Next ...
 func (hd *vpcHandler) Next(kt *kit.Kit) ([]string, error) {
+	return hd.pages.Next(kt)
+}
+
+// list 查询一页云上的vpc，返回云ID
+func (hd *vpcHandler) list(kt *kit.Kit, page *typecore.AwsPage) ([]string, *string, error) {
 	listOpt := &typecore.AwsListOption{
 		Region: hd.request.Region,
-		Page: &typecore.AwsPage{
-			NextToken:  hd.nextToken,
-			MaxResults: converter.ValToPtr(int64(constant.CloudResourceSyncMaxLimit)),
-		},
+		Page:   page,
 	}
 
 	vpcResult, err := hd.syncCli.CloudCli().ListVpc(kt, listOpt)
 	if err != nil {
 		logs.Errorf("request adaptor list aws vpc failed, err: %v, opt: %v, rid: %s", err, listOpt, kt.Rid)
-		return nil, err
-	}
-
-	if len(vpcResult.Details) == 0 {
-		return nil, nil
+		return nil, nil, err
 	}
 
 	cloudIDs := make([]string, 0, len(vpcResult.Details))
@@ -88,8 +86,7 @@ func (hd *vpcHandler) Next(kt *kit.Kit) ([]string, error) {
 		cloudIDs = append(cloudIDs, one.CloudID)
 	}
 
-	hd.nextToken = vpcResult.NextToken
-	return cloudIDs, nil
+	return cloudIDs, vpcResult.NextToken, nil
 }
 
 // Sync ...
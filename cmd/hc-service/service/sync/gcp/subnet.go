@@ -43,9 +43,9 @@ type subnetHandler struct {
 	cli ressync.Interface
 
 	// Prepare 构建参数
-	request   *sync.GcpSyncReq
-	syncCli   gcp.Interface
-	pageToken string
+	request *sync.GcpSyncReq
+	syncCli gcp.Interface
+	pages   *typecore.Iterator[string, string]
 }
 
 var _ handler.Handler = new(subnetHandler)
@@ -59,30 +59,29 @@ func (hd *subnetHandler) Prepare(cts *rest.Contexts) error {
 
 	hd.request = request
 	hd.syncCli = syncCli
+	hd.pages = typecore.NewGcpIterator(constant.CloudResourceSyncMaxLimit, hd.list, nil)
 
 	return nil
 }
 
 // Next ...
 func (hd *subnetHandler) Next(kt *kit.Kit) ([]string, error) {
+	return hd.pages.Next(kt)
+}
+
+// list 查询一页云上的子网，返回云ID
+func (hd *subnetHandler) list(kt *kit.Kit, page *typecore.GcpPage) ([]string, string, error) {
 	listOpt := &adtysubnet.GcpSubnetListOption{
 		Region: hd.request.Region,
 		GcpListOption: typecore.GcpListOption{
-			Page: &typecore.GcpPage{
-				PageSize:  constant.CloudResourceSyncMaxLimit,
-				PageToken: hd.pageToken,
-			},
+			Page: page,
 		},
 	}
 
 	subnetResult, err := hd.syncCli.CloudCli().ListSubnet(kt, listOpt)
 	if err != nil {
 		logs.Errorf("request adaptor list gcp subnet failed, err: %v, opt: %v, rid: %s", err, listOpt, kt.Rid)
-		return nil, err
-	}
-
-	if len(subnetResult.Details) == 0 {
-		return nil, nil
+		return nil, "", err
 	}
 
 	cloudIDs := make([]string, 0, len(subnetResult.Details))
@@ -90,8 +89,7 @@ func (hd *subnetHandler) Next(kt *kit.Kit) ([]string, error) {
 		cloudIDs = append(cloudIDs, one.CloudID)
 	}
 
-	hd.pageToken = subnetResult.NextPageToken
-	return cloudIDs, nil
+	return cloudIDs, subnetResult.NextPageToken, nil
 }
 
 // Sync ...
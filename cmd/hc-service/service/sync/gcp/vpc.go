@@ -44,9 +44,9 @@ type vpcHandler struct {
 	cli ressync.Interface
 
 	// Prepare 构建参数
-	request   *sync.GcpGlobalSyncReq
-	syncCli   gcp.Interface
-	pageToken string
+	request *sync.GcpGlobalSyncReq
+	syncCli gcp.Interface
+	pages   *typecore.Iterator[string, string]
 }
 
 var _ handler.Handler = new(vpcHandler)
@@ -69,27 +69,26 @@ func (hd *vpcHandler) Prepare(cts *rest.Contexts) error {
 
 	hd.request = req
 	hd.syncCli = syncCli
+	hd.pages = typecore.NewGcpIterator(constant.CloudResourceSyncMaxLimit, hd.list, nil)
 
 	return nil
 }
 
 // Next ...
 func (hd *vpcHandler) Next(kt *kit.Kit) ([]string, error) {
+	return hd.pages.Next(kt)
+}
+
+// list 查询一页云上的vpc，返回云ID
+func (hd *vpcHandler) list(kt *kit.Kit, page *typecore.GcpPage) ([]string, string, error) {
 	listOpt := &types.GcpListOption{
-		Page: &typecore.GcpPage{
-			PageSize:  constant.CloudResourceSyncMaxLimit,
-			PageToken: hd.pageToken,
-		},
+		Page: page,
 	}
 
 	vpcResult, err := hd.syncCli.CloudCli().ListVpc(kt, listOpt)
 	if err != nil {
 		logs.Errorf("request adaptor list gcp vpc failed, err: %v, opt: %v, rid: %s", err, listOpt, kt.Rid)
-		return nil, err
-	}
-
-	if len(vpcResult.Details) == 0 {
-		return nil, nil
+		return nil, "", err
 	}
 
 	cloudIDs := make([]string, 0, len(vpcResult.Details))
@@ -97,8 +96,7 @@ func (hd *vpcHandler) Next(kt *kit.Kit) ([]string, error) {
 		cloudIDs = append(cloudIDs, one.CloudID)
 	}
 
-	hd.pageToken = vpcResult.NextPageToken
-	return cloudIDs, nil
+	return cloudIDs, vpcResult.NextPageToken, nil
 }
 
 // Sync ...
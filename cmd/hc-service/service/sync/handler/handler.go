@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"hcm/cmd/hc-service/logics/res-sync/common"
+	"hcm/pkg/cc"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
@@ -59,13 +60,14 @@ func ResourceSync(cts *rest.Contexts, handler Handler) error {
 		return err
 	}
 
-	if err := handler.RemoveDeleteFromCloud(kt); err != nil {
+	// 删除前的对比需要读取主库，避免从库延迟导致误判
+	if err := handler.RemoveDeleteFromCloud(kt.WithReadPrimary()); err != nil {
 		logs.Errorf("%s sync handler to removeDeleteFromCloud failed, err: %v, rid: %s", handler.Name(), err, kt.Rid)
 		return err
 	}
 
 	for {
-		cloudIDs, err := handler.Next(kt)
+		cloudIDs, err := nextPage(kt, handler.Next)
 		if err != nil {
 			logs.Errorf("%s sync handler to next failed, err: %v, rid: %s", handler.Name(), err, kt.Rid)
 			return err
@@ -137,7 +139,7 @@ func ResourceSyncV2[T common.CloudResType](cts *rest.Contexts, handler HandlerV2
 	total := 0
 	for {
 		startBatch := time.Now()
-		instances, err := handler.Next(kt)
+		instances, err := nextPage(kt, handler.Next)
 		if err != nil {
 			logs.Errorf("[ResourceSyncV2] %s sync handler to next failed, err: %v, rid: %s",
 				handler.Describe(), err, kt.Rid)
@@ -165,7 +167,7 @@ func ResourceSyncV2[T common.CloudResType](cts *rest.Contexts, handler HandlerV2
 		handler.Describe(), time.Since(startedAt), total, kt.Rid)
 
 	// 3. 删除云上已删除数据
-	if err := handler.RemoveDeletedFromCloud(kt, allCloudIDMap); err != nil {
+	if err := handler.RemoveDeletedFromCloud(kt.WithReadPrimary(), allCloudIDMap); err != nil {
 		logs.Errorf("[ResourceSyncV2] %s sync handler to remove deleted from cloud failed, err: %v, rid: %s",
 			handler.Describe(), err, kt.Rid)
 		return err
@@ -308,3 +310,11 @@ func (sw *syncWorker[T]) Start() {
 		}
 	}
 }
+
+// nextPage 分页查询云上资源，超时后取消查询，避免云上接口无响应时同步任务一直阻塞
+func nextPage[T any](kt *kit.Kit, next func(kt *kit.Kit) ([]T, error)) ([]T, error) {
+	pageKt, cancel := kt.WithTimeout(cc.HCService().Timeout.SyncPageFetch())
+	defer cancel()
+
+	return next(pageKt)
+}
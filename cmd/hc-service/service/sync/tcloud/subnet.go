@@ -44,7 +44,7 @@ type subnetHandler struct {
 	// Prepare 构建参数
 	request *sync.TCloudSyncReq
 	syncCli tcloud.Interface
-	offset  uint64
+	pages   *typecore.Iterator[string, uint64]
 }
 
 var _ handler.Handler = new(subnetHandler)
@@ -58,18 +58,21 @@ func (hd *subnetHandler) Prepare(cts *rest.Contexts) error {
 
 	hd.request = request
 	hd.syncCli = syncCli
+	hd.pages = typecore.NewTCloudIterator(constant.CloudResourceSyncMaxLimit, hd.list, nil)
 
 	return nil
 }
 
 // Next ...
 func (hd *subnetHandler) Next(kt *kit.Kit) ([]string, error) {
+	return hd.pages.Next(kt)
+}
+
+// list 查询一页云上的子网，返回云ID
+func (hd *subnetHandler) list(kt *kit.Kit, page *typecore.TCloudPage) ([]string, error) {
 	listOpt := &typecore.TCloudListOption{
 		Region: hd.request.Region,
-		Page: &typecore.TCloudPage{
-			Offset: hd.offset,
-			Limit:  constant.CloudResourceSyncMaxLimit,
-		},
+		Page:   page,
 	}
 
 	subnetResult, err := hd.syncCli.CloudCli().ListSubnet(kt, listOpt)
@@ -78,16 +81,11 @@ func (hd *subnetHandler) Next(kt *kit.Kit) ([]string, error) {
 		return nil, err
 	}
 
-	if len(subnetResult.Details) == 0 {
-		return nil, nil
-	}
-
 	cloudIDs := make([]string, 0, len(subnetResult.Details))
 	for _, one := range subnetResult.Details {
 		cloudIDs = append(cloudIDs, one.CloudID)
 	}
 
-	hd.offset += constant.CloudResourceSyncMaxLimit
 	return cloudIDs, nil
 }
 
@@ -0,0 +1,119 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	proto "hcm/pkg/api/cloud-server/account"
+	"hcm/pkg/rest"
+
+	"github.com/spf13/pflag"
+)
+
+func accountCommand() *command {
+	return &command{
+		name:  "account",
+		usage: "hcmctl account <command> [flags] [args]",
+		short: "sync account resources, check account secret.",
+		subs:  []*command{accountSyncCommand(), accountCheckCommand()},
+	}
+}
+
+func accountSyncCommand() *command {
+	return &command{
+		name:  "sync",
+		usage: "hcmctl account sync <account_id> [account_id...]",
+		short: "trigger the cloud resource sync of the accounts, and print the sync flow ids.",
+		run: func(ctl *ctl, args []string) error {
+			if len(args) == 0 {
+				return errors.New("account id is required")
+			}
+
+			cli, err := ctl.cloud(rest.Version2)
+			if err != nil {
+				return err
+			}
+
+			results := make(map[string]*proto.AccountSyncResult, len(args))
+			rows := make([][]string, 0, len(args))
+			for _, accountID := range args {
+				kt, cancel := ctl.kit()
+				result, err := cli.Account.SyncWithFlow(kt, accountID)
+				cancel()
+				if err != nil {
+					return fmt.Errorf("sync account %s failed, err: %v, rid: %s", accountID, err, kt.Rid)
+				}
+
+				results[accountID] = result
+				rows = append(rows, []string{accountID, result.FlowID})
+			}
+
+			return ctl.print(results, []string{"ACCOUNT_ID", "FLOW_ID"}, rows)
+		},
+	}
+}
+
+func accountCheckCommand() *command {
+	var extension string
+	return &command{
+		name:  "check",
+		usage: "hcmctl account check <account_id> --extension <json|@file>",
+		short: "check the connectivity of the account with the secret, and the cloud fields are consistent.",
+		flags: func(fs *pflag.FlagSet) {
+			fs.StringVar(&extension, "extension", "", "the account extension with secret, json string or "+
+				"json file path prefixed with @, like {\"cloud_secret_id\":\"xxx\",\"cloud_secret_key\":\"xxx\"}.")
+		},
+		run: func(ctl *ctl, args []string) error {
+			if len(args) != 1 {
+				return errors.New("one account id is required")
+			}
+
+			if len(extension) == 0 {
+				return errors.New("extension is required")
+			}
+
+			content, err := readJsonArg(extension)
+			if err != nil {
+				return err
+			}
+			if !json.Valid(content) {
+				return errors.New("extension is not a valid json")
+			}
+
+			cli, err := ctl.cloud(rest.Version1)
+			if err != nil {
+				return err
+			}
+
+			kt, cancel := ctl.kit()
+			defer cancel()
+			req := &proto.AccountCheckByIDReq{Extension: content}
+			if err = cli.Account.CheckByID(kt, args[0], req); err != nil {
+				return fmt.Errorf("check account %s failed, err: %v, rid: %s", args[0], err, kt.Rid)
+			}
+
+			result := map[string]string{"account_id": args[0], "result": "ok"}
+			return ctl.print(result, []string{"ACCOUNT_ID", "RESULT"}, [][]string{{args[0], "ok"}})
+		},
+	}
+}
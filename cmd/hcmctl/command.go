@@ -0,0 +1,261 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	cloudserver "hcm/pkg/client/cloud-server"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+	restclient "hcm/pkg/rest/client"
+	"hcm/pkg/tools/ssl"
+	"hcm/pkg/version"
+
+	"github.com/spf13/pflag"
+)
+
+// command is a hcmctl command, the command with sub commands only dispatches the args to its sub commands.
+type command struct {
+	name  string
+	usage string
+	short string
+	// flags defines the command's flags, the flags are parsed before run is called.
+	flags func(fs *pflag.FlagSet)
+	run   func(ctl *ctl, args []string) error
+	subs  []*command
+}
+
+// ctl is the runtime context of hcmctl commands.
+type ctl struct {
+	servers     []string
+	user        string
+	appCode     string
+	tenantID    string
+	timeout     time.Duration
+	output      string
+	tls         ssl.TLSConfig
+	out         io.Writer
+	cloudClient map[rest.Version]*cloudserver.Client
+}
+
+func newRootCommand() *command {
+	return &command{
+		name:  "hcmctl",
+		usage: "hcmctl [global flags] <command> [flags] [args]",
+		short: "hcmctl is the command line tool of the hcm administrators.",
+		subs: []*command{
+			accountCommand(),
+			taskCommand(),
+			exportCommand(),
+			quotaCommand(),
+			{
+				name:  "version",
+				usage: "hcmctl version",
+				short: "show the version of hcmctl.",
+				run: func(ctl *ctl, args []string) error {
+					fmt.Fprintln(ctl.out, version.FormatVersion())
+					return nil
+				},
+			},
+		},
+	}
+}
+
+// cmdOutput is the writer of the command results.
+var cmdOutput io.Writer = os.Stdout
+
+// execute parse the global flags and run the command.
+func (c *command) execute(args []string) error {
+	ctl := &ctl{out: cmdOutput, cloudClient: make(map[rest.Version]*cloudserver.Client)}
+
+	fs := pflag.NewFlagSet(c.name, pflag.ContinueOnError)
+	// global flags are before the command, the rest args are parsed by the command.
+	fs.SetInterspersed(false)
+	defaultServers := []string{"http://127.0.0.1:9602"}
+	if env := os.Getenv("HCMCTL_SERVERS"); len(env) != 0 {
+		defaultServers = strings.Split(env, ",")
+	}
+	fs.StringSliceVar(&ctl.servers, "servers", defaultServers,
+		"cloud-server addresses, like http://127.0.0.1:9602, can also be set by env HCMCTL_SERVERS.")
+	fs.StringVar(&ctl.user, "user", os.Getenv("USER"), "the user name of the requests, used for auth and audit.")
+	fs.StringVar(&ctl.appCode, "app-code", "hcmctl", "the app code of the requests.")
+	fs.StringVar(&ctl.tenantID, "tenant-id", "", "the tenant id of the requests.")
+	fs.DurationVar(&ctl.timeout, "timeout", 30*time.Second, "the timeout of each request.")
+	fs.StringVarP(&ctl.output, "output", "o", "table", "output format, table or json.")
+	fs.BoolVar(&ctl.tls.InsecureSkipVerify, "insecure-skip-verify", false, "skip verify the server certificate.")
+	fs.StringVar(&ctl.tls.CAFile, "ca-file", "", "the ca file of the server certificate.")
+	fs.StringVar(&ctl.tls.CertFile, "cert-file", "", "the client certificate file.")
+	fs.StringVar(&ctl.tls.KeyFile, "key-file", "", "the client key file.")
+	fs.Usage = func() { c.printUsage(fs) }
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if ctl.output != "table" && ctl.output != "json" {
+		return fmt.Errorf("unsupported output format: %s", ctl.output)
+	}
+
+	return c.dispatch(ctl, fs, fs.Args())
+}
+
+func (c *command) dispatch(ctl *ctl, fs *pflag.FlagSet, args []string) error {
+	if len(c.subs) == 0 {
+		return c.runWithFlags(ctl, args)
+	}
+
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		c.printUsage(fs)
+		return nil
+	}
+
+	for _, sub := range c.subs {
+		if sub.name == args[0] {
+			return sub.dispatch(ctl, pflag.NewFlagSet(sub.name, pflag.ContinueOnError), args[1:])
+		}
+	}
+
+	c.printUsage(fs)
+	return fmt.Errorf("unknown command %q", args[0])
+}
+
+func (c *command) runWithFlags(ctl *ctl, args []string) error {
+	fs := pflag.NewFlagSet(c.name, pflag.ContinueOnError)
+	if c.flags != nil {
+		c.flags(fs)
+	}
+	fs.Usage = func() { c.printUsage(fs) }
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	return c.run(ctl, fs.Args())
+}
+
+func (c *command) printUsage(fs *pflag.FlagSet) {
+	fmt.Fprintf(os.Stderr, "%s\n\nUsage:\n  %s\n", c.short, c.usage)
+	if len(c.subs) != 0 {
+		fmt.Fprintf(os.Stderr, "\nCommands:\n")
+		for _, sub := range c.subs {
+			fmt.Fprintf(os.Stderr, "  %-10s %s\n", sub.name, sub.short)
+		}
+	}
+
+	if fs.HasFlags() {
+		fmt.Fprintf(os.Stderr, "\nFlags:\n%s", fs.FlagUsages())
+	}
+}
+
+// kit create the request kit, the request is canceled after timeout.
+func (ctl *ctl) kit() (*kit.Kit, context.CancelFunc) {
+	kt := kit.New()
+	kt.User = ctl.user
+	kt.AppCode = ctl.appCode
+	kt.TenantID = ctl.tenantID
+
+	var cancel context.CancelFunc
+	kt.Ctx, cancel = context.WithTimeout(kt.Ctx, ctl.timeout)
+	return kt, cancel
+}
+
+// cloud returns the cloud-server client of the api version.
+func (ctl *ctl) cloud(version rest.Version) (*cloudserver.Client, error) {
+	if cli, exists := ctl.cloudClient[version]; exists {
+		return cli, nil
+	}
+
+	httpCli, err := restclient.NewClient(&ctl.tls)
+	if err != nil {
+		return nil, fmt.Errorf("create http client failed, err: %v", err)
+	}
+
+	servers := make([]string, 0, len(ctl.servers))
+	for _, server := range ctl.servers {
+		server = strings.TrimRight(strings.TrimSpace(server), "/")
+		if len(server) == 0 {
+			continue
+		}
+		if !strings.HasPrefix(server, "http://") && !strings.HasPrefix(server, "https://") {
+			server = "http://" + server
+		}
+		servers = append(servers, server)
+	}
+	if len(servers) == 0 {
+		return nil, errors.New("no cloud-server address is set")
+	}
+
+	capability := &restclient.Capability{Client: httpCli, Discover: staticServers(servers)}
+	cli := cloudserver.NewClient(capability, string(version))
+	ctl.cloudClient[version] = cli
+	return cli, nil
+}
+
+// staticServers is the cloud-server addresses set by flags, which is used instead of the etcd discovery.
+type staticServers []string
+
+// GetServers returns the cloud-server addresses.
+func (s staticServers) GetServers() ([]string, error) {
+	return s, nil
+}
+
+// print the result, json output prints the result as it is, table output prints the rows with the header.
+func (ctl *ctl) print(result interface{}, header []string, rows [][]string) error {
+	if ctl.output == "json" {
+		encoder := json.NewEncoder(ctl.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	w := tabwriter.NewWriter(ctl.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// readJsonArg read the json arg, which is the json string or the json file path prefixed with @.
+func readJsonArg(arg string) ([]byte, error) {
+	if !strings.HasPrefix(arg, "@") {
+		return []byte(arg), nil
+	}
+
+	content, err := os.ReadFile(strings.TrimPrefix(arg, "@"))
+	if err != nil {
+		return nil, fmt.Errorf("read file %s failed, err: %v", strings.TrimPrefix(arg, "@"), err)
+	}
+	return content, nil
+}
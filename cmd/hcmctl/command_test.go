@@ -0,0 +1,109 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedRequest struct {
+	method string
+	path   string
+	user   string
+	body   map[string]interface{}
+}
+
+func newFakeCloudServer(t *testing.T, data interface{}) (*httptest.Server, *[]recordedRequest) {
+	requests := make([]recordedRequest, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		req := recordedRequest{method: r.Method, path: r.URL.Path, user: r.Header.Get("X-Bkapi-User-Name")}
+		if len(body) != 0 {
+			require.NoError(t, json.Unmarshal(body, &req.body))
+		}
+		requests = append(requests, req)
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"code": 0, "message": "",
+			"data": data}))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func executeCommand(t *testing.T, args ...string) (string, error) {
+	out := new(bytes.Buffer)
+	cmd := newRootCommand()
+	ctlArgs := append([]string{"--user", "admin"}, args...)
+
+	stdout := cmdOutput
+	cmdOutput = out
+	defer func() { cmdOutput = stdout }()
+
+	err := cmd.execute(ctlArgs)
+	return out.String(), err
+}
+
+func TestAccountSync(t *testing.T) {
+	server, requests := newFakeCloudServer(t, map[string]string{"flow_id": "flow-1"})
+
+	out, err := executeCommand(t, "--servers", server.URL, "account", "sync", "account-1")
+	require.NoError(t, err)
+	assert.Contains(t, out, "flow-1")
+
+	require.Len(t, *requests, 1)
+	assert.Equal(t, http.MethodPost, (*requests)[0].method)
+	assert.Equal(t, "/api/v2/cloud/accounts/account-1/sync", (*requests)[0].path)
+	assert.Equal(t, "admin", (*requests)[0].user)
+}
+
+func TestQuotaSetOnlyChangedFlags(t *testing.T) {
+	server, requests := newFakeCloudServer(t, nil)
+
+	_, err := executeCommand(t, "--servers", server.URL, "quota", "set", "100", "--max-cvm", "-1")
+	require.NoError(t, err)
+
+	require.Len(t, *requests, 1)
+	assert.Equal(t, http.MethodPut, (*requests)[0].method)
+	assert.Equal(t, "/api/v1/cloud/biz_quotas/100", (*requests)[0].path)
+	assert.Equal(t, map[string]interface{}{"max_cvm": float64(-1), "max_cpu_core": nil, "max_eip": nil,
+		"memo": nil}, (*requests)[0].body)
+
+	// no quota field is set, the request is rejected before calling the api.
+	_, err = executeCommand(t, "--servers", server.URL, "quota", "set", "100")
+	assert.Error(t, err)
+	assert.Len(t, *requests, 1)
+}
+
+func TestUnknownCommand(t *testing.T) {
+	_, err := executeCommand(t, "unknown")
+	assert.Error(t, err)
+}
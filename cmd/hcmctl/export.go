@@ -0,0 +1,98 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	cloudserver "hcm/pkg/api/cloud-server"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/spf13/pflag"
+)
+
+func exportCommand() *command {
+	var format, filterArg, outDir string
+	var columns []string
+	return &command{
+		name: "export",
+		usage: "hcmctl export <resource> --columns <column,...> [--filter <json|@file>] [--format csv|xlsx] " +
+			"[--dir <dir>]",
+		short: "export the resources to file, resource is like cvms, eips, security_groups, bizs/100/cvms.",
+		flags: func(fs *pflag.FlagSet) {
+			fs.StringSliceVar(&columns, "columns", nil, "the columns to export in order, extension fields are "+
+				"prefixed with extension., like id,name,extension.cloud_project_id.")
+			fs.StringVar(&filterArg, "filter", "", "the filter expression, json string or json file path "+
+				"prefixed with @, default exports all the resources.")
+			fs.StringVar(&format, "format", string(enumor.CsvExportFormat), "the export file format, csv or xlsx.")
+			fs.StringVar(&outDir, "dir", ".", "the directory to save the export file.")
+		},
+		run: func(ctl *ctl, args []string) error {
+			if len(args) != 1 {
+				return errors.New("one resource is required")
+			}
+
+			req := &cloudserver.ResourceExportReq{
+				Filter:  &filter.Expression{Op: filter.And, Rules: []filter.RuleFactory{}},
+				Format:  enumor.ExportFormat(format),
+				Columns: columns,
+			}
+			if len(filterArg) != 0 {
+				content, err := readJsonArg(filterArg)
+				if err != nil {
+					return err
+				}
+				if err = json.Unmarshal(content, req.Filter); err != nil {
+					return fmt.Errorf("filter is invalid, err: %v", err)
+				}
+			}
+			if err := req.Validate(); err != nil {
+				return err
+			}
+
+			cli, err := ctl.cloud(rest.Version1)
+			if err != nil {
+				return err
+			}
+
+			kt, cancel := ctl.kit()
+			defer cancel()
+			file, err := cli.Export.Export(kt, args[0], req)
+			if err != nil {
+				return fmt.Errorf("export %s failed, err: %v, rid: %s", args[0], err, kt.Rid)
+			}
+
+			path := filepath.Join(outDir, filepath.Base(file.FileName))
+			if err = os.WriteFile(path, file.Content, 0644); err != nil {
+				return fmt.Errorf("save export file failed, err: %v", err)
+			}
+
+			result := map[string]interface{}{"resource": args[0], "file": path, "size": len(file.Content)}
+			return ctl.print(result, []string{"RESOURCE", "FILE", "SIZE"},
+				[][]string{{args[0], path, fmt.Sprint(len(file.Content))}})
+		},
+	}
+}
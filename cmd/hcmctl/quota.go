@@ -0,0 +1,99 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	csquota "hcm/pkg/api/cloud-server/quota"
+	"hcm/pkg/rest"
+
+	"github.com/spf13/pflag"
+)
+
+func quotaCommand() *command {
+	return &command{
+		name:  "quota",
+		usage: "hcmctl quota <command> [flags] [args]",
+		short: "manage the resource quota of the bizs.",
+		subs:  []*command{quotaSetCommand()},
+	}
+}
+
+func quotaSetCommand() *command {
+	var maxCvm, maxCpuCore, maxEip int64
+	var memo string
+	var fs *pflag.FlagSet
+	return &command{
+		name:  "set",
+		usage: "hcmctl quota set <bk_biz_id> [--max-cvm <n>] [--max-cpu-core <n>] [--max-eip <n>] [--memo <memo>]",
+		short: "set the resource quota of the biz, -1 means unlimited, the flags not set are not changed.",
+		flags: func(flags *pflag.FlagSet) {
+			fs = flags
+			fs.Int64Var(&maxCvm, "max-cvm", 0, "the max number of cvms.")
+			fs.Int64Var(&maxCpuCore, "max-cpu-core", 0, "the max number of cpu cores.")
+			fs.Int64Var(&maxEip, "max-eip", 0, "the max number of eips.")
+			fs.StringVar(&memo, "memo", "", "the memo of the quota.")
+		},
+		run: func(ctl *ctl, args []string) error {
+			if len(args) != 1 {
+				return errors.New("one bk_biz_id is required")
+			}
+
+			bizID, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil || bizID <= 0 {
+				return fmt.Errorf("bk_biz_id %s is invalid", args[0])
+			}
+
+			// only the flags set by user are updated.
+			req := new(csquota.BizQuotaSetReq)
+			if fs.Changed("max-cvm") {
+				req.MaxCvm = &maxCvm
+			}
+			if fs.Changed("max-cpu-core") {
+				req.MaxCpuCore = &maxCpuCore
+			}
+			if fs.Changed("max-eip") {
+				req.MaxEip = &maxEip
+			}
+			if fs.Changed("memo") {
+				req.Memo = &memo
+			}
+			if err = req.Validate(); err != nil {
+				return err
+			}
+
+			cli, err := ctl.cloud(rest.Version1)
+			if err != nil {
+				return err
+			}
+
+			kt, cancel := ctl.kit()
+			defer cancel()
+			if err = cli.Quota.SetBizQuota(kt, bizID, req); err != nil {
+				return fmt.Errorf("set biz %d quota failed, err: %v, rid: %s", bizID, err, kt.Rid)
+			}
+
+			return ctl.print(req, []string{"BK_BIZ_ID", "RESULT"}, [][]string{{args[0], "ok"}})
+		},
+	}
+}
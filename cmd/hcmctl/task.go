@@ -0,0 +1,92 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package main
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coreasync "hcm/pkg/api/core/async"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/spf13/pflag"
+)
+
+func taskCommand() *command {
+	return &command{
+		name:  "task",
+		usage: "hcmctl task <command> [flags]",
+		short: "list the async jobs of task center.",
+		subs:  []*command{taskFailedCommand()},
+	}
+}
+
+func taskFailedCommand() *command {
+	var accountID string
+	var limit uint
+	return &command{
+		name:  "failed",
+		usage: "hcmctl task failed [--account-id <account_id>] [--limit <limit>]",
+		short: "list the latest failed async jobs, including async flows and account syncs.",
+		flags: func(fs *pflag.FlagSet) {
+			fs.StringVar(&accountID, "account-id", "", "only list the failed jobs of the account.")
+			fs.UintVar(&limit, "limit", 20, "the max number of the jobs to list.")
+		},
+		run: func(ctl *ctl, args []string) error {
+			if limit == 0 || limit > core.DefaultMaxPageLimit {
+				return fmt.Errorf("limit should be in [1, %d]", core.DefaultMaxPageLimit)
+			}
+
+			rules := []filter.RuleFactory{
+				filter.AtomRule{Field: "state", Op: filter.Equal.Factory(), Value: enumor.FlowFailed},
+			}
+			if len(accountID) != 0 {
+				rules = append(rules, filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(),
+					Value: accountID})
+			}
+
+			cli, err := ctl.cloud(rest.Version1)
+			if err != nil {
+				return err
+			}
+
+			kt, cancel := ctl.kit()
+			defer cancel()
+			req := &core.ListReq{
+				Filter: &filter.Expression{Op: filter.And, Rules: rules},
+				Page:   &core.BasePage{Limit: limit, Sort: "created_at", Order: core.Descending},
+			}
+			result, err := cli.TaskCenter.ListAsyncJob(kt, req)
+			if err != nil {
+				return fmt.Errorf("list failed async jobs failed, err: %v, rid: %s", err, kt.Rid)
+			}
+
+			rows := slice.Map(result.Details, func(job coreasync.AsyncJob) []string {
+				return []string{job.ID, string(job.Kind), job.Name, job.AccountID, job.Creator, job.CreatedAt,
+					job.Reason}
+			})
+			return ctl.print(result.Details, []string{"ID", "KIND", "NAME", "ACCOUNT_ID", "CREATOR", "CREATED_AT",
+				"REASON"}, rows)
+		},
+	}
+}
@@ -0,0 +1,76 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package actionaccount defines the actions of account.
+package actionaccount
+
+import (
+	logicsaccount "hcm/cmd/cloud-server/logics/account"
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+)
+
+// --------------------------[同步账号]-----------------------------
+
+var _ action.Action = new(SyncAccountAction)
+var _ action.ParameterAction = new(SyncAccountAction)
+
+// SyncAccountAction 同步账号下的全部资源
+type SyncAccountAction struct{}
+
+// ParameterNew return request params.
+func (act SyncAccountAction) ParameterNew() (params any) {
+	return new(logicsaccount.SyncAccountOption)
+}
+
+// Name return action name
+func (act SyncAccountAction) Name() enumor.ActionName {
+	return enumor.ActionSyncAccount
+}
+
+// Run 同步账号下的全部资源
+func (act SyncAccountAction) Run(et run.ExecuteKit, params any) (result any, taskErr error) {
+	opt, ok := params.(*logicsaccount.SyncAccountOption)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
+	}
+
+	// 这里如果不重设rid会导致rid长度超长
+	kt := et.KitWithNewRid()
+	logs.Infof("reset rid to %s for sync account %s, old rid: %s", kt.Rid, opt.AccountID, et.Kit().Rid)
+
+	resType, err := logicsaccount.SyncAllResource(kt, actcli.GetClientSet(), opt.Vendor, opt.AccountID)
+	if err != nil {
+		logs.Errorf("[%s] sync account %s failed on %s, err: %v, rid: %s", opt.Vendor, opt.AccountID, resType, err,
+			kt.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// Rollback 同步支持重入，无需回滚
+func (act SyncAccountAction) Rollback(kt run.ExecuteKit, params any) error {
+	logs.Infof(" ----------- SyncAccountAction Rollback -----------, params: %+v, rid: %s", params, kt.Kit().Rid)
+	return nil
+}
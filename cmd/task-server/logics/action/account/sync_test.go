@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package actionaccount
+
+import (
+	"testing"
+
+	logicsaccount "hcm/cmd/cloud-server/logics/account"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/json"
+)
+
+func TestSyncAccountActionParameter(t *testing.T) {
+	act := new(SyncAccountAction)
+	if act.Name() != enumor.ActionSyncAccount {
+		t.Errorf("expect action name %s, but got %s", enumor.ActionSyncAccount, act.Name())
+	}
+
+	// 任务参数以json存储，调度时通过ParameterNew反序列化
+	raw, err := json.Marshal(&logicsaccount.SyncAccountOption{Vendor: enumor.TCloud, AccountID: "00000001"})
+	if err != nil {
+		t.Fatalf("marshal option failed, err: %v", err)
+	}
+	params := act.ParameterNew()
+	if err = json.Unmarshal(raw, params); err != nil {
+		t.Fatalf("unmarshal option failed, err: %v", err)
+	}
+	opt, ok := params.(*logicsaccount.SyncAccountOption)
+	if !ok || opt.Vendor != enumor.TCloud || opt.AccountID != "00000001" {
+		t.Errorf("unexpected params: %+v", params)
+	}
+}
+
+func TestSyncAccountActionRunInvalidParams(t *testing.T) {
+	var et run.ExecuteKit
+	if _, err := new(SyncAccountAction).Run(et, "00000001"); err == nil {
+		t.Errorf("run with mismatched params type should be failed")
+	}
+}
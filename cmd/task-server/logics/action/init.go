@@ -20,6 +20,7 @@
 package logicsaction
 
 import (
+	actionaccount "hcm/cmd/task-server/logics/action/account"
 	actionbilldailypull "hcm/cmd/task-server/logics/action/bill/dailypull"
 	actionbillsplit "hcm/cmd/task-server/logics/action/bill/dailysplit"
 	actiondailysummary "hcm/cmd/task-server/logics/action/bill/dailysummary"
@@ -30,6 +31,7 @@ import (
 	actioncvm "hcm/cmd/task-server/logics/action/cvm"
 	actioneip "hcm/cmd/task-server/logics/action/eip"
 	actionfirewall "hcm/cmd/task-server/logics/action/firewall"
+	actionlz "hcm/cmd/task-server/logics/action/landing-zone"
 	actionlb "hcm/cmd/task-server/logics/action/load-balancer"
 	actionsg "hcm/cmd/task-server/logics/action/security-group"
 	actionsubnet "hcm/cmd/task-server/logics/action/subnet"
@@ -91,4 +93,14 @@ func register() {
 
 	action.RegisterAction(actionlb.SyncTCloudLoadBalancerAction{})
 	action.RegisterAction(actionlb.SyncTCloudLoadBalancerListenerAction{})
+
+	action.RegisterAction(actionlz.CreateVpcAction{})
+	action.RegisterAction(actionlz.CreateNatGatewayAction{})
+	action.RegisterAction(actionlz.CreateRouteTableAction{})
+	action.RegisterAction(actionlz.CreateSubnetAction{})
+	action.RegisterAction(actionlz.CreateSecurityGroupAction{})
+	action.RegisterAction(actionflow.LandingZoneDeployWatchAction{})
+	action.RegisterTpl(actionflow.FlowLandingZoneDeployWatchTpl)
+
+	action.RegisterAction(actionaccount.SyncAccountAction{})
 }
@@ -0,0 +1,92 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package actionlandingzone defines the actions of landing zone deployment, each action provisions one resource and
+// records it in the flow share data, so that the created resources can be rolled back if the deployment failed.
+package actionlandingzone
+
+import (
+	"strings"
+
+	corelz "hcm/pkg/api/core/landing-zone"
+	"hcm/pkg/async/action/run"
+	tableasync "hcm/pkg/dal/table/async"
+)
+
+// 部署任务流共享数据中记录的资源
+const (
+	// DeploymentIDKey 部署记录ID
+	DeploymentIDKey = "deployment_id"
+	// VpcIDKey 已创建的VPC ID
+	VpcIDKey = "vpc_id"
+	// CloudVpcIDKey 已创建的VPC云上ID
+	CloudVpcIDKey = "cloud_vpc_id"
+	// CloudNatGatewayIDKey 已创建的NAT网关云上ID
+	CloudNatGatewayIDKey = "cloud_nat_gateway_id"
+	// CloudEipIDsKey NAT网关绑定的弹性IP云上ID列表
+	CloudEipIDsKey = "cloud_eip_ids"
+	// RouteTableIDKey 已创建的路由表ID
+	RouteTableIDKey = "route_table_id"
+	// CloudRouteTableIDKey 已创建的路由表云上ID
+	CloudRouteTableIDKey = "cloud_route_table_id"
+	// SubnetIDsKey 已创建的子网ID列表
+	SubnetIDsKey = "subnet_ids"
+	// SecurityGroupIDsKey 已创建的安全组ID列表
+	SecurityGroupIDsKey = "security_group_ids"
+)
+
+// ShareDataGetter get value from share data.
+type ShareDataGetter interface {
+	Get(key string) (string, bool)
+}
+
+// GetResources get the created resources recorded in share data.
+func GetResources(shareData ShareDataGetter) *corelz.DeploymentResources {
+	get := func(key string) string {
+		val, _ := shareData.Get(key)
+		return val
+	}
+	getIDs := func(key string) []string {
+		val := get(key)
+		if len(val) == 0 {
+			return nil
+		}
+		return tableasync.ParseIDsStr(val)
+	}
+
+	return &corelz.DeploymentResources{
+		VpcID:             get(VpcIDKey),
+		CloudVpcID:        get(CloudVpcIDKey),
+		CloudNatGatewayID: get(CloudNatGatewayIDKey),
+		CloudEipIDs:       getIDs(CloudEipIDsKey),
+		RouteTableID:      get(RouteTableIDKey),
+		CloudRouteTableID: get(CloudRouteTableIDKey),
+		SubnetIDs:         getIDs(SubnetIDsKey),
+		SecurityGroupIDs:  getIDs(SecurityGroupIDsKey),
+	}
+}
+
+// getRequired get the value which is recorded by the depended action from share data.
+func getRequired(kt run.ExecuteKit, key string) (string, bool) {
+	val, exist := kt.ShareData().Get(key)
+	if !exist || len(strings.TrimSpace(val)) == 0 {
+		return "", false
+	}
+	return val, true
+}
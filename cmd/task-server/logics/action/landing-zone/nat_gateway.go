@@ -0,0 +1,151 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package actionlandingzone
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	natgateway "hcm/pkg/adaptor/types/nat-gateway"
+	corelz "hcm/pkg/api/core/landing-zone"
+	hcnat "hcm/pkg/api/hc-service/nat-gateway"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/logs"
+)
+
+var _ action.Action = new(CreateNatGatewayAction)
+var _ action.ParameterAction = new(CreateNatGatewayAction)
+var _ action.RollbackAction = new(CreateNatGatewayAction)
+
+// CreateNatGatewayAction define create landing zone nat gateway action.
+type CreateNatGatewayAction struct{}
+
+// CreateNatGatewayOption define create landing zone nat gateway option.
+type CreateNatGatewayOption struct {
+	AccountID             string `json:"account_id" validate:"required"`
+	Region                string `json:"region" validate:"required"`
+	corelz.NatGatewaySpec `json:",inline" validate:"required"`
+}
+
+// Validate CreateNatGatewayOption.
+func (opt CreateNatGatewayOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ParameterNew return create nat gateway params.
+func (act CreateNatGatewayAction) ParameterNew() (params interface{}) {
+	return new(CreateNatGatewayOption)
+}
+
+// Name return action name.
+func (act CreateNatGatewayAction) Name() enumor.ActionName {
+	return enumor.ActionLandingZoneCreateNatGateway
+}
+
+// natWaitTimeout 单次执行等待NAT网关可用的最长时间，需要小于任务执行超时时间，超时后通过任务重试继续等待
+const natWaitTimeout = 90 * time.Second
+
+// Run create nat gateway and wait for it to be available, the nat gateway is created only once, the retried run
+// only waits for the created one.
+func (act CreateNatGatewayAction) Run(kt run.ExecuteKit, params interface{}) (interface{}, error) {
+	opt, ok := params.(*CreateNatGatewayOption)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	cloudID, exist := getRequired(kt, CloudNatGatewayIDKey)
+	if !exist {
+		cloudVpcID, exist := getRequired(kt, CloudVpcIDKey)
+		if !exist {
+			return nil, errf.New(errf.InvalidParameter, "cloud vpc id is not found in share data")
+		}
+
+		createReq := &hcnat.TCloudNatGatewayCreateReq{
+			AccountID:               opt.AccountID,
+			Region:                  opt.Region,
+			CloudVpcID:              cloudVpcID,
+			Name:                    opt.Name,
+			Zone:                    opt.Zone,
+			InternetMaxBandwidthOut: opt.InternetMaxBandwidthOut,
+			MaxConcurrentConnection: opt.MaxConcurrentConnection,
+			AddressCount:            opt.AddressCount,
+		}
+		nat, err := actcli.GetHCService().TCloud.NatGateway.Create(kt.Kit(), createReq)
+		if err != nil {
+			logs.Errorf("create landing zone nat gateway failed, err: %v, rid: %s", err, kt.Kit().Rid)
+			return nil, err
+		}
+
+		cloudID = nat.CloudID
+		if err = kt.ShareData().Set(kt.Kit(), CloudNatGatewayIDKey, cloudID); err != nil {
+			return nil, err
+		}
+	}
+
+	end := time.Now().Add(natWaitTimeout)
+	for {
+		listReq := &hcnat.TCloudNatGatewayListReq{AccountID: opt.AccountID, Region: opt.Region,
+			CloudIDs: []string{cloudID}}
+		result, err := actcli.GetHCService().TCloud.NatGateway.List(kt.Kit(), listReq)
+		if err != nil {
+			logs.Errorf("list nat gateway %s failed, err: %v, rid: %s", cloudID, err, kt.Kit().Rid)
+			return nil, err
+		}
+
+		if len(result.Details) == 0 {
+			return nil, fmt.Errorf("nat gateway %s is not found", cloudID)
+		}
+
+		nat := result.Details[0]
+		switch nat.State {
+		case natgateway.TCloudNatAvailable:
+			if len(nat.CloudEipIDs) != 0 {
+				if err = kt.ShareData().Set(kt.Kit(), CloudEipIDsKey, strings.Join(nat.CloudEipIDs, ",")); err != nil {
+					return nil, err
+				}
+			}
+			return nil, nil
+		case natgateway.TCloudNatPendFailure:
+			return nil, fmt.Errorf("nat gateway %s create failed", cloudID)
+		}
+
+		if time.Now().After(end) {
+			return nil, fmt.Errorf("wait timeout, nat gateway %s is %s", cloudID, nat.State)
+		}
+		time.Sleep(3 * time.Second)
+	}
+}
+
+// Rollback 重试前无需回滚，创建的NAT网关已记录在共享数据中，重试时继续等待其可用
+func (act CreateNatGatewayAction) Rollback(kt run.ExecuteKit, params interface{}) error {
+	logs.Infof(" ----------- CreateNatGatewayAction Rollback -----------, params: %+v, rid: %s", params,
+		kt.Kit().Rid)
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package actionlandingzone
+
+import (
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	"hcm/pkg/api/core"
+	hcroutetable "hcm/pkg/api/hc-service/route-table"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+)
+
+var _ action.Action = new(CreateRouteTableAction)
+var _ action.ParameterAction = new(CreateRouteTableAction)
+
+// CreateRouteTableAction define create landing zone route table action, the route table routes all the outbound
+// traffic to the nat gateway, it is associated with the private subnets.
+type CreateRouteTableAction struct{}
+
+// CreateRouteTableOption define create landing zone route table option.
+type CreateRouteTableOption struct {
+	AccountID string `json:"account_id" validate:"required"`
+	Region    string `json:"region" validate:"required"`
+	Name      string `json:"name" validate:"required"`
+}
+
+// Validate CreateRouteTableOption.
+func (opt CreateRouteTableOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ParameterNew return create route table params.
+func (act CreateRouteTableAction) ParameterNew() (params interface{}) {
+	return new(CreateRouteTableOption)
+}
+
+// Name return action name.
+func (act CreateRouteTableAction) Name() enumor.ActionName {
+	return enumor.ActionLandingZoneCreateRouteTable
+}
+
+// Run create route table with default route to nat gateway.
+func (act CreateRouteTableAction) Run(kt run.ExecuteKit, params interface{}) (interface{}, error) {
+	opt, ok := params.(*CreateRouteTableOption)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	cloudVpcID, exist := getRequired(kt, CloudVpcIDKey)
+	if !exist {
+		return nil, errf.New(errf.InvalidParameter, "cloud vpc id is not found in share data")
+	}
+	cloudNatID, exist := getRequired(kt, CloudNatGatewayIDKey)
+	if !exist {
+		return nil, errf.New(errf.InvalidParameter, "cloud nat gateway id is not found in share data")
+	}
+
+	createReq := &hcroutetable.TCloudRouteTableCreateReq{
+		AccountID:  opt.AccountID,
+		Region:     opt.Region,
+		CloudVpcID: cloudVpcID,
+		Name:       opt.Name,
+		Routes: []hcroutetable.TCloudRouteCreateReq{{
+			DestinationCidrBlock: "0.0.0.0/0",
+			GatewayType:          "NAT",
+			CloudGatewayID:       cloudNatID,
+			Memo:                 converter.ValToPtr("landing zone default route to nat gateway"),
+		}},
+	}
+	result, err := actcli.GetHCService().TCloud.RouteTable.Create(kt.Kit(), createReq)
+	if err != nil {
+		logs.Errorf("create landing zone route table failed, err: %v, rid: %s", err, kt.Kit().Rid)
+		return nil, err
+	}
+
+	if err = kt.ShareData().Set(kt.Kit(), RouteTableIDKey, result.ID); err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{Filter: tools.EqualExpression("id", result.ID), Page: core.NewDefaultBasePage()}
+	rtResult, err := actcli.GetDataService().Global.RouteTable.List(kt.Kit().Ctx, kt.Kit().Header(), listReq)
+	if err != nil {
+		logs.Errorf("list created route table %s failed, err: %v, rid: %s", result.ID, err, kt.Kit().Rid)
+		return nil, err
+	}
+	if len(rtResult.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "created route table %s is not found", result.ID)
+	}
+	if err = kt.ShareData().Set(kt.Kit(), CloudRouteTableIDKey, rtResult.Details[0].CloudID); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,127 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package actionlandingzone
+
+import (
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	corelz "hcm/pkg/api/core/landing-zone"
+	hcproto "hcm/pkg/api/hc-service"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+)
+
+var _ action.Action = new(CreateSecurityGroupAction)
+var _ action.ParameterAction = new(CreateSecurityGroupAction)
+
+// CreateSecurityGroupAction define create landing zone security group action.
+type CreateSecurityGroupAction struct{}
+
+// CreateSecurityGroupOption define create landing zone security group option.
+type CreateSecurityGroupOption struct {
+	AccountID                string `json:"account_id" validate:"required"`
+	BkBizID                  int64  `json:"bk_biz_id" validate:"required,min=1"`
+	Region                   string `json:"region" validate:"required"`
+	corelz.SecurityGroupSpec `json:",inline" validate:"required"`
+}
+
+// Validate CreateSecurityGroupOption.
+func (opt CreateSecurityGroupOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ParameterNew return create security group params.
+func (act CreateSecurityGroupAction) ParameterNew() (params interface{}) {
+	return new(CreateSecurityGroupOption)
+}
+
+// Name return action name.
+func (act CreateSecurityGroupAction) Name() enumor.ActionName {
+	return enumor.ActionLandingZoneCreateSecurityGroup
+}
+
+// Run create security group and its rules.
+func (act CreateSecurityGroupAction) Run(kt run.ExecuteKit, params interface{}) (interface{}, error) {
+	opt, ok := params.(*CreateSecurityGroupOption)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	createReq := &hcproto.TCloudSecurityGroupCreateReq{
+		Region:    opt.Region,
+		Name:      opt.Name,
+		Memo:      opt.Memo,
+		AccountID: opt.AccountID,
+		BkBizID:   opt.BkBizID,
+	}
+	result, err := actcli.GetHCService().TCloud.SecurityGroup.CreateSecurityGroup(kt.Kit().Ctx,
+		kt.Kit().Header(), createReq)
+	if err != nil {
+		logs.Errorf("create landing zone security group %s failed, err: %v, rid: %s", opt.Name, err, kt.Kit().Rid)
+		return nil, err
+	}
+
+	if err = kt.ShareData().AppendIDs(kt.Kit(), SecurityGroupIDsKey, result.ID); err != nil {
+		return nil, err
+	}
+
+	// 云上一次只能添加一个方向的规则
+	ruleReqs := make([]*hcproto.TCloudSGRuleCreateReq, 0, 2)
+	if len(opt.IngressRules) != 0 {
+		ruleReqs = append(ruleReqs, &hcproto.TCloudSGRuleCreateReq{AccountID: opt.AccountID,
+			IngressRuleSet: convRules(opt.IngressRules)})
+	}
+	if len(opt.EgressRules) != 0 {
+		ruleReqs = append(ruleReqs, &hcproto.TCloudSGRuleCreateReq{AccountID: opt.AccountID,
+			EgressRuleSet: convRules(opt.EgressRules)})
+	}
+	for _, ruleReq := range ruleReqs {
+		_, err = actcli.GetHCService().TCloud.SecurityGroup.BatchCreateSecurityGroupRule(kt.Kit().Ctx,
+			kt.Kit().Header(), result.ID, ruleReq)
+		if err != nil {
+			logs.Errorf("create security group %s rules failed, err: %v, rid: %s", result.ID, err, kt.Kit().Rid)
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func convRules(rules []corelz.SecurityGroupRuleSpec) []hcproto.TCloudSGRuleCreate {
+	result := make([]hcproto.TCloudSGRuleCreate, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, hcproto.TCloudSGRuleCreate{
+			Protocol: converter.ValToPtr(rule.Protocol),
+			Port:     converter.ValToPtr(rule.Port),
+			IPv4Cidr: converter.ValToPtr(rule.IPv4Cidr),
+			Action:   rule.Action,
+			Memo:     rule.Memo,
+		})
+	}
+	return result
+}
@@ -0,0 +1,110 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package actionlandingzone
+
+import (
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	corelz "hcm/pkg/api/core/landing-zone"
+	hcsubnet "hcm/pkg/api/hc-service/subnet"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/logs"
+)
+
+var _ action.Action = new(CreateSubnetAction)
+var _ action.ParameterAction = new(CreateSubnetAction)
+
+// CreateSubnetAction define create landing zone subnet action.
+type CreateSubnetAction struct{}
+
+// CreateSubnetOption define create landing zone subnet option.
+type CreateSubnetOption struct {
+	AccountID         string `json:"account_id" validate:"required"`
+	BkBizID           int64  `json:"bk_biz_id" validate:"required,min=1"`
+	Region            string `json:"region" validate:"required"`
+	corelz.SubnetSpec `json:",inline" validate:"required"`
+}
+
+// Validate CreateSubnetOption.
+func (opt CreateSubnetOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ParameterNew return create subnet params.
+func (act CreateSubnetAction) ParameterNew() (params interface{}) {
+	return new(CreateSubnetOption)
+}
+
+// Name return action name.
+func (act CreateSubnetAction) Name() enumor.ActionName {
+	return enumor.ActionLandingZoneCreateSubnet
+}
+
+// Run create subnet, private subnet is associated with the route table which routes to the nat gateway.
+func (act CreateSubnetAction) Run(kt run.ExecuteKit, params interface{}) (interface{}, error) {
+	opt, ok := params.(*CreateSubnetOption)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	cloudVpcID, exist := getRequired(kt, CloudVpcIDKey)
+	if !exist {
+		return nil, errf.New(errf.InvalidParameter, "cloud vpc id is not found in share data")
+	}
+
+	subnet := hcsubnet.TCloudOneSubnetCreateReq{
+		IPv4Cidr: opt.IPv4Cidr,
+		Name:     opt.Name,
+		Zone:     opt.Zone,
+	}
+	if opt.Private {
+		cloudRouteTableID, exist := getRequired(kt, CloudRouteTableIDKey)
+		if !exist {
+			return nil, errf.New(errf.InvalidParameter, "cloud route table id is not found in share data")
+		}
+		subnet.CloudRouteTableID = cloudRouteTableID
+	}
+
+	createReq := &hcsubnet.TCloudSubnetBatchCreateReq{
+		BkBizID:    opt.BkBizID,
+		AccountID:  opt.AccountID,
+		Region:     opt.Region,
+		CloudVpcID: cloudVpcID,
+		Subnets:    []hcsubnet.TCloudOneSubnetCreateReq{subnet},
+	}
+	result, err := actcli.GetHCService().TCloud.Subnet.BatchCreate(kt.Kit().Ctx, kt.Kit().Header(), createReq)
+	if err != nil {
+		logs.Errorf("create landing zone subnet %s failed, err: %v, rid: %s", opt.Name, err, kt.Kit().Rid)
+		return nil, err
+	}
+
+	if err = kt.ShareData().AppendIDs(kt.Kit(), SubnetIDsKey, result.IDs...); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,127 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package actionlandingzone
+
+import (
+	"fmt"
+
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	hcvpc "hcm/pkg/api/hc-service/vpc"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/logs"
+)
+
+var _ action.Action = new(CreateVpcAction)
+var _ action.ParameterAction = new(CreateVpcAction)
+
+// CreateVpcAction define create landing zone vpc action.
+type CreateVpcAction struct{}
+
+// CreateVpcOption define create landing zone vpc option.
+type CreateVpcOption struct {
+	AccountID string  `json:"account_id" validate:"required"`
+	BkBizID   int64   `json:"bk_biz_id" validate:"required,min=1"`
+	Region    string  `json:"region" validate:"required"`
+	Name      string  `json:"name" validate:"required"`
+	IPv4Cidr  string  `json:"ipv4_cidr" validate:"required,cidrv4"`
+	BkCloudID int64   `json:"bk_cloud_id" validate:"required"`
+	Memo      *string `json:"memo" validate:"omitempty"`
+}
+
+// Validate CreateVpcOption.
+func (opt CreateVpcOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ParameterNew return create vpc params.
+func (act CreateVpcAction) ParameterNew() (params interface{}) {
+	return new(CreateVpcOption)
+}
+
+// Name return action name.
+func (act CreateVpcAction) Name() enumor.ActionName {
+	return enumor.ActionLandingZoneCreateVpc
+}
+
+// Run create vpc and assign it to the biz.
+func (act CreateVpcAction) Run(kt run.ExecuteKit, params interface{}) (interface{}, error) {
+	opt, ok := params.(*CreateVpcOption)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	createReq := &hcvpc.VpcCreateReq[hcvpc.TCloudVpcCreateExt]{
+		BaseVpcCreateReq: &hcvpc.BaseVpcCreateReq{
+			AccountID: opt.AccountID,
+			Name:      opt.Name,
+			Category:  enumor.BizVpcCategory,
+			Memo:      opt.Memo,
+			BkCloudID: opt.BkCloudID,
+			BkBizID:   opt.BkBizID,
+		},
+		Extension: &hcvpc.TCloudVpcCreateExt{
+			Region:   opt.Region,
+			IPv4Cidr: opt.IPv4Cidr,
+		},
+	}
+	result, err := actcli.GetHCService().TCloud.Vpc.Create(kt.Kit().Ctx, kt.Kit().Header(), createReq)
+	if err != nil {
+		logs.Errorf("create landing zone vpc failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Kit().Rid)
+		return nil, err
+	}
+
+	if err = kt.ShareData().Set(kt.Kit(), VpcIDKey, result.ID); err != nil {
+		return nil, err
+	}
+
+	vpc, err := actcli.GetDataService().TCloud.Vpc.Get(kt.Kit().Ctx, kt.Kit().Header(), result.ID)
+	if err != nil {
+		logs.Errorf("get created vpc %s failed, err: %v, rid: %s", result.ID, err, kt.Kit().Rid)
+		return nil, err
+	}
+	if err = kt.ShareData().Set(kt.Kit(), CloudVpcIDKey, vpc.CloudID); err != nil {
+		return nil, err
+	}
+
+	// 创建的VPC默认未分配业务，需要分配到部署所属的业务
+	updateReq := &protocloud.VpcBaseInfoBatchUpdateReq{
+		Vpcs: []protocloud.VpcBaseInfoUpdateReq{{
+			IDs:  []string{result.ID},
+			Data: &protocloud.VpcUpdateBaseInfo{BkBizID: opt.BkBizID},
+		}},
+	}
+	if err = actcli.GetDataService().Global.Vpc.BatchUpdateBaseInfo(kt.Kit().Ctx, kt.Kit().Header(),
+		updateReq); err != nil {
+
+		logs.Errorf("assign vpc %s to biz %d failed, err: %v, rid: %s", result.ID, opt.BkBizID, err, kt.Kit().Rid)
+		return nil, fmt.Errorf("assign vpc to biz failed, err: %v", err)
+	}
+
+	return result, nil
+}
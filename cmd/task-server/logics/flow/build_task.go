@@ -44,3 +44,25 @@ var FlowLoadBalancerOperateWatchTpl = action.FlowTemplate{
 		},
 	},
 }
+
+// landingZoneWatchRetryLimit 网络基线部署监听任务的最大重试次数，监听任务在部署任务流未结束或者资源回滚失败时重试
+const landingZoneWatchRetryLimit = 200
+
+// FlowLandingZoneDeployWatchTpl define flow landing zone deploy watch template.
+var FlowLandingZoneDeployWatchTpl = action.FlowTemplate{
+	Name:      enumor.FlowLandingZoneDeployWatch,
+	ShareData: tableasync.NewShareData(nil),
+	Tasks: []action.TaskTemplate{
+		{
+			ActionID:   "1",
+			ActionName: enumor.ActionLandingZoneDeployWatch,
+			Retry: &tableasync.Retry{
+				Enable: true,
+				Policy: &tableasync.RetryPolicy{
+					Count:        landingZoneWatchRetryLimit,
+					SleepRangeMS: [2]uint{5000, 10000},
+				},
+			},
+		},
+	},
+}
@@ -0,0 +1,304 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package actionflow
+
+import (
+	"fmt"
+	"time"
+
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	actionlz "hcm/cmd/task-server/logics/action/landing-zone"
+	"hcm/pkg/api/core"
+	corelz "hcm/pkg/api/core/landing-zone"
+	datalz "hcm/pkg/api/data-service/landing-zone"
+	hcnat "hcm/pkg/api/hc-service/nat-gateway"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+var _ action.Action = new(LandingZoneDeployWatchAction)
+var _ action.ParameterAction = new(LandingZoneDeployWatchAction)
+var _ action.RollbackAction = new(LandingZoneDeployWatchAction)
+
+// LandingZoneDeployWatchAction watch the landing zone deploy flow, record the created resources to the deployment
+// when the flow is finished, and roll back the created resources in reverse order if the flow failed.
+type LandingZoneDeployWatchAction struct{}
+
+// LandingZoneDeployWatchOption define landing zone deploy watch option.
+type LandingZoneDeployWatchOption struct {
+	DeploymentID string `json:"deployment_id" validate:"required"`
+	FlowID       string `json:"flow_id" validate:"required"`
+}
+
+// Validate LandingZoneDeployWatchOption.
+func (opt LandingZoneDeployWatchOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ParameterNew return request params.
+func (act LandingZoneDeployWatchAction) ParameterNew() (params interface{}) {
+	return new(LandingZoneDeployWatchOption)
+}
+
+// Name return action name
+func (act LandingZoneDeployWatchAction) Name() enumor.ActionName {
+	return enumor.ActionLandingZoneDeployWatch
+}
+
+// landingZoneWatchTimeout 单次执行等待部署任务流结束的最长时间，需要小于任务执行超时时间，超时后通过任务重试继续等待
+const landingZoneWatchTimeout = 90 * time.Second
+
+// Run watch deploy flow.
+func (act LandingZoneDeployWatchAction) Run(kt run.ExecuteKit, params interface{}) (interface{}, error) {
+	opt, ok := params.(*LandingZoneDeployWatchOption)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	end := time.Now().Add(landingZoneWatchTimeout)
+	for {
+		req := &types.ListOption{
+			Filter: tools.EqualExpression("id", opt.FlowID),
+			Page:   core.NewDefaultBasePage(),
+		}
+		flowList, err := actcli.GetDaoSet().AsyncFlow().List(kt.Kit(), req)
+		if err != nil {
+			logs.Errorf("list query flow failed, err: %v, flowID: %s, rid: %s", err, opt.FlowID, kt.Kit().Rid)
+			return nil, err
+		}
+
+		if len(flowList.Details) == 0 {
+			logs.Infof("list query flow not found, flowID: %s, rid: %s", opt.FlowID, kt.Kit().Rid)
+			return nil, nil
+		}
+
+		flow := flowList.Details[0]
+		switch flow.State {
+		case enumor.FlowSuccess:
+			updateReq := &datalz.DeploymentUpdateReq{
+				State:     enumor.LandingZoneDeploySuccess,
+				Resources: actionlz.GetResources(flow.ShareData),
+			}
+			return nil, actcli.GetDataService().Global.LandingZoneDeployment.Update(kt.Kit(), opt.DeploymentID,
+				updateReq)
+		case enumor.FlowFailed, enumor.FlowCancel:
+			return nil, act.rollback(kt.Kit(), opt, &flow)
+		}
+
+		if time.Now().After(end) {
+			return nil, fmt.Errorf("wait timeout, landing zone deploy flow: %s is %s", opt.FlowID, flow.State)
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// rollback delete the created resources in reverse order of creation, the remaining resources are persisted after
+// each deletion, so that the retried rollback continues from where it failed.
+func (act LandingZoneDeployWatchAction) rollback(kt *kit.Kit, opt *LandingZoneDeployWatchOption,
+	flow *tableasync.AsyncFlowTable) error {
+
+	listReq := &core.ListReq{Filter: tools.EqualExpression("id", opt.DeploymentID), Page: core.NewDefaultBasePage()}
+	result, err := actcli.GetDataService().Global.LandingZoneDeployment.List(kt, listReq)
+	if err != nil {
+		logs.Errorf("list landing zone deployment failed, err: %v, id: %s, rid: %s", err, opt.DeploymentID, kt.Rid)
+		return err
+	}
+	if len(result.Details) == 0 {
+		logs.Infof("landing zone deployment %s not found, skip rollback, rid: %s", opt.DeploymentID, kt.Rid)
+		return nil
+	}
+	deployment := result.Details[0]
+
+	var resources *corelz.DeploymentResources
+	switch deployment.State {
+	case enumor.LandingZoneRolledBack, enumor.LandingZoneDeploySuccess:
+		return nil
+	case enumor.LandingZoneDeploying:
+		// 首次回滚，已创建的资源以部署任务流中记录的为准
+		resources = actionlz.GetResources(flow.ShareData)
+		reason := fmt.Sprintf("deploy flow %s", flow.State)
+		if flow.Reason != nil && len(flow.Reason.Message) != 0 {
+			reason = flow.Reason.Message
+		}
+		updateReq := &datalz.DeploymentUpdateReq{
+			State:     enumor.LandingZoneRollingBack,
+			Reason:    converter.ValToPtr(truncate(reason)),
+			Resources: resources,
+		}
+		if err = act.update(kt, opt.DeploymentID, updateReq); err != nil {
+			return err
+		}
+	default:
+		resources = deployment.Resources
+		if resources == nil {
+			resources = new(corelz.DeploymentResources)
+		}
+	}
+
+	if err = act.deleteResources(kt, &deployment, resources); err != nil {
+		logs.Errorf("rollback landing zone deployment %s failed, err: %v, rid: %s", opt.DeploymentID, err, kt.Rid)
+		// 回滚失败时记录失败原因，任务重试时继续回滚剩余的资源
+		updateReq := &datalz.DeploymentUpdateReq{
+			State:  enumor.LandingZoneRollbackFailed,
+			Reason: converter.ValToPtr(truncate(fmt.Sprintf("rollback failed, err: %v", err))),
+		}
+		if updateErr := act.update(kt, opt.DeploymentID, updateReq); updateErr != nil {
+			return updateErr
+		}
+		return err
+	}
+
+	return act.update(kt, opt.DeploymentID, &datalz.DeploymentUpdateReq{State: enumor.LandingZoneRolledBack})
+}
+
+func (act LandingZoneDeployWatchAction) deleteResources(kt *kit.Kit, deployment *corelz.Deployment,
+	res *corelz.DeploymentResources) error {
+
+	hcCli := actcli.GetHCService().TCloud
+	save := func() error {
+		return act.update(kt, deployment.ID, &datalz.DeploymentUpdateReq{Resources: res})
+	}
+
+	for len(res.SecurityGroupIDs) != 0 {
+		id := res.SecurityGroupIDs[0]
+		if err := hcCli.SecurityGroup.DeleteSecurityGroup(kt, id); err != nil && !errf.IsRecordNotFound(err) {
+			return fmt.Errorf("delete security group %s failed, err: %v", id, err)
+		}
+		res.SecurityGroupIDs = res.SecurityGroupIDs[1:]
+		if err := save(); err != nil {
+			return err
+		}
+	}
+
+	for len(res.SubnetIDs) != 0 {
+		id := res.SubnetIDs[0]
+		if err := hcCli.Subnet.Delete(kt, id); err != nil && !errf.IsRecordNotFound(err) {
+			return fmt.Errorf("delete subnet %s failed, err: %v", id, err)
+		}
+		res.SubnetIDs = res.SubnetIDs[1:]
+		if err := save(); err != nil {
+			return err
+		}
+	}
+
+	if len(res.RouteTableID) != 0 {
+		err := hcCli.RouteTable.Delete(kt.Ctx, kt.Header(), res.RouteTableID)
+		if err != nil && !errf.IsRecordNotFound(err) {
+			return fmt.Errorf("delete route table %s failed, err: %v", res.RouteTableID, err)
+		}
+		res.RouteTableID, res.CloudRouteTableID = "", ""
+		if err = save(); err != nil {
+			return err
+		}
+	}
+
+	if len(res.CloudNatGatewayID) != 0 {
+		if err := act.deleteNatGateway(kt, deployment, res); err != nil {
+			return err
+		}
+		res.CloudNatGatewayID, res.CloudEipIDs = "", nil
+		if err := save(); err != nil {
+			return err
+		}
+	}
+
+	if len(res.VpcID) != 0 {
+		err := hcCli.Vpc.Delete(kt.Ctx, kt.Header(), res.VpcID)
+		if err != nil && !errf.IsRecordNotFound(err) {
+			return fmt.Errorf("delete vpc %s failed, err: %v", res.VpcID, err)
+		}
+		res.VpcID, res.CloudVpcID = "", ""
+		if err = save(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteNatGateway delete nat gateway and release its eips, the eips may not be recorded if the deploy flow failed
+// before the nat gateway is available, so they are queried from cloud again.
+func (act LandingZoneDeployWatchAction) deleteNatGateway(kt *kit.Kit, deployment *corelz.Deployment,
+	res *corelz.DeploymentResources) error {
+
+	natCli := actcli.GetHCService().TCloud.NatGateway
+	listReq := &hcnat.TCloudNatGatewayListReq{AccountID: deployment.AccountID, Region: deployment.Region,
+		CloudIDs: []string{res.CloudNatGatewayID}}
+	result, err := natCli.List(kt, listReq)
+	if err != nil {
+		return fmt.Errorf("list nat gateway %s failed, err: %v", res.CloudNatGatewayID, err)
+	}
+
+	eipIDs := res.CloudEipIDs
+	for _, nat := range result.Details {
+		eipIDs = append(eipIDs, nat.CloudEipIDs...)
+	}
+
+	deleteReq := &hcnat.TCloudNatGatewayDeleteReq{
+		AccountID:          deployment.AccountID,
+		Region:             deployment.Region,
+		CloudID:            res.CloudNatGatewayID,
+		ReleaseCloudEipIDs: slice.Unique(eipIDs),
+	}
+	if err = natCli.Delete(kt, deleteReq); err != nil {
+		return fmt.Errorf("delete nat gateway %s failed, err: %v", res.CloudNatGatewayID, err)
+	}
+
+	return nil
+}
+
+func (act LandingZoneDeployWatchAction) update(kt *kit.Kit, id string, req *datalz.DeploymentUpdateReq) error {
+	if err := actcli.GetDataService().Global.LandingZoneDeployment.Update(kt, id, req); err != nil {
+		logs.Errorf("update landing zone deployment failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+	return nil
+}
+
+// truncate the reason to fit the reason column.
+func truncate(reason string) string {
+	const maxLen = 1024
+	runes := []rune(reason)
+	if len(runes) <= maxLen {
+		return reason
+	}
+	return string(runes[:maxLen])
+}
+
+// Rollback 重试前无需回滚，资源回滚的进度已记录在部署记录中
+func (act LandingZoneDeployWatchAction) Rollback(kt run.ExecuteKit, params interface{}) error {
+	logs.Infof(" ----------- LandingZoneDeployWatchAction Rollback -----------, params: %+v, rid: %s",
+		params, kt.Kit().Rid)
+	return nil
+}
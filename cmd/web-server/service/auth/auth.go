@@ -24,8 +24,10 @@ import (
 	"hcm/cmd/web-server/service/capability"
 	webserver "hcm/pkg/api/web-server"
 	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 )
@@ -40,6 +42,7 @@ func InitAuthService(c *capability.Capability) {
 	h := rest.NewHandler()
 	h.Add("AuthVerify", "POST", "/auth/verify", svr.AuthVerify)
 	h.Add("GetApplyPermUrl", "POST", "/auth/find/apply_perm_url", svr.GetApplyPermUrl)
+	h.Add("AuthCheck", "POST", "/auth/check", svr.AuthCheck)
 
 	h.Load(c.WebService)
 }
@@ -56,11 +59,67 @@ func (a *authSvc) AuthVerify(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
+	resources, unauthorizedRes, err := a.verify(cts.Kit, input.Resources)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(unauthorizedRes) == 0 {
+		return &webserver.AuthVerifyResp{Results: resources}, nil
+	}
+
+	permission, err := a.authorizer.GetPermissionToApply(cts.Kit, unauthorizedRes...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webserver.AuthVerifyResp{Results: resources, Permission: permission}, nil
+}
+
+// AuthCheck batch check whether current user can perform the actions on the resources, returns the apply permission
+// url of the unauthorized ones, so that front end can disable the operations that user has no permission in advance.
+func (a *authSvc) AuthCheck(cts *rest.Contexts) (interface{}, error) {
+	input := new(webserver.AuthCheckReq)
+	if err := cts.DecodeInto(input); err != nil {
+		return nil, err
+	}
+	if err := input.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	resources, unauthorizedRes, err := a.verify(cts.Kit, input.Resources)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(unauthorizedRes) == 0 {
+		return &webserver.AuthCheckResp{Results: resources}, nil
+	}
+
+	permission, err := a.authorizer.GetPermissionToApply(cts.Kit, unauthorizedRes...)
+	if err != nil {
+		logs.Errorf("get permission to apply failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	url, err := a.authorizer.GetApplyPermUrl(cts.Kit, permission)
+	if err != nil {
+		logs.Errorf("get iam apply permission url failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &webserver.AuthCheckResp{Results: resources, ApplyUrl: url}, nil
+}
+
+// verify returns the authorize results of the resources and the unauthorized resources.
+func (a *authSvc) verify(kt *kit.Kit, inputs []webserver.AuthVerifyResource) ([]webserver.AuthVerifyRes,
+	[]meta.ResourceAttribute, error) {
+
 	anyAuthAttrs := make([]meta.ResourceAttribute, 0)
 	exactAuthAttrs := make([]meta.ResourceAttribute, 0)
 
 	exactAuthMap := make(map[int]struct{})
-	for idx, res := range input.Resources {
+	for idx, res := range inputs {
 		attr := meta.ResourceAttribute{
 			Basic: &meta.Basic{
 				Type:       meta.ResourceType(res.ResourceType),
@@ -79,15 +138,15 @@ func (a *authSvc) AuthVerify(cts *rest.Contexts) (interface{}, error) {
 		}
 	}
 
-	resourceLen := len(input.Resources)
+	resourceLen := len(inputs)
 	resources := make([]webserver.AuthVerifyRes, resourceLen)
 	unauthorizedRes := make([]meta.ResourceAttribute, 0)
 
 	if len(exactAuthAttrs) > 0 {
-		verifyResults, _, err := a.authorizer.Authorize(cts.Kit, exactAuthAttrs...)
+		verifyResults, _, err := a.authorizer.Authorize(kt, exactAuthAttrs...)
 		if err != nil {
-			logs.Errorf("authorize failed, err: %v, attrs: %#v, rid: %s", err, exactAuthAttrs, cts.Kit.Rid)
-			return nil, err
+			logs.Errorf("authorize failed, err: %v, attrs: %#v, rid: %s", err, exactAuthAttrs, kt.Rid)
+			return nil, nil, err
 		}
 
 		index := 0
@@ -103,10 +162,10 @@ func (a *authSvc) AuthVerify(cts *rest.Contexts) (interface{}, error) {
 	}
 
 	if len(anyAuthAttrs) > 0 {
-		verifyResults, err := a.authorizer.AuthorizeAny(cts.Kit, anyAuthAttrs...)
+		verifyResults, err := a.authorizer.AuthorizeAny(kt, anyAuthAttrs...)
 		if err != nil {
-			logs.Errorf("authorize any failed, err: %v, attrs: %#v, rid: %s", err, anyAuthAttrs, cts.Kit.Rid)
-			return nil, err
+			logs.Errorf("authorize any failed, err: %v, attrs: %#v, rid: %s", err, anyAuthAttrs, kt.Rid)
+			return nil, nil, err
 		}
 
 		index := 0
@@ -121,16 +180,7 @@ func (a *authSvc) AuthVerify(cts *rest.Contexts) (interface{}, error) {
 		}
 	}
 
-	if len(unauthorizedRes) == 0 {
-		return &webserver.AuthVerifyResp{Results: resources}, nil
-	}
-
-	permission, err := a.authorizer.GetPermissionToApply(cts.Kit, unauthorizedRes...)
-	if err != nil {
-		return nil, err
-	}
-
-	return &webserver.AuthVerifyResp{Results: resources, Permission: permission}, nil
+	return resources, unauthorizedRes, nil
 }
 
 // GetApplyPermUrl get iam apply permission url for front end to redirect auth to it.
@@ -0,0 +1,131 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	webserver "hcm/pkg/api/web-server"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAuthorizer authorizes the resources whose id is in the authorized set.
+type fakeAuthorizer struct {
+	auth.Authorizer
+	authorized map[string]bool
+	toApply    []meta.ResourceAttribute
+}
+
+// Authorize ...
+func (f *fakeAuthorizer) Authorize(_ *kit.Kit, resources ...meta.ResourceAttribute) ([]meta.Decision, bool, error) {
+	decisions := make([]meta.Decision, len(resources))
+	for idx, res := range resources {
+		decisions[idx].Authorized = f.authorized[res.Basic.ResourceID]
+	}
+	return decisions, false, nil
+}
+
+// AuthorizeAny ...
+func (f *fakeAuthorizer) AuthorizeAny(_ *kit.Kit, resources ...meta.ResourceAttribute) ([]meta.Decision, error) {
+	decisions := make([]meta.Decision, len(resources))
+	for idx, res := range resources {
+		decisions[idx].Authorized = f.authorized[string(res.Basic.Type)]
+	}
+	return decisions, nil
+}
+
+// GetPermissionToApply ...
+func (f *fakeAuthorizer) GetPermissionToApply(_ *kit.Kit, res ...meta.ResourceAttribute) (*meta.IamPermission,
+	error) {
+
+	f.toApply = res
+	return &meta.IamPermission{SystemID: "bk-hcm"}, nil
+}
+
+// GetApplyPermUrl ...
+func (f *fakeAuthorizer) GetApplyPermUrl(_ *kit.Kit, _ *meta.IamPermission) (string, error) {
+	return "http://iam/apply", nil
+}
+
+func doAuthCheck(t *testing.T, authorizer *fakeAuthorizer, body string) (*webserver.AuthCheckResp, *rest.Response) {
+	svc := &authSvc{authorizer: authorizer}
+	h := rest.NewHandler()
+	h.Add("AuthCheck", http.MethodPost, "/auth/check", svc.AuthCheck)
+	ws := new(restful.WebService)
+	h.Load(ws)
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/check", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constant.UserKey, "admin")
+	req.Header.Set(constant.AppCodeKey, "hcm")
+	req.Header.Set(constant.RidKey, "auth-check-test-rid")
+	recorder := httptest.NewRecorder()
+	container.ServeHTTP(recorder, req)
+
+	result := new(webserver.AuthCheckResp)
+	resp := &rest.Response{Data: result}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), resp))
+	return result, resp
+}
+
+func TestAuthCheck(t *testing.T) {
+	authorizer := &fakeAuthorizer{authorized: map[string]bool{"cvm-1": true, "account": true}}
+	body := `{"resources":[
+		{"bk_biz_id":1,"resource_type":"cvm","resource_id":"cvm-1","action":"delete"},
+		{"resource_type":"account","action":"find"},
+		{"bk_biz_id":1,"resource_type":"cvm","resource_id":"cvm-2","action":"delete"}]}`
+
+	result, resp := doAuthCheck(t, authorizer, body)
+	require.Equal(t, int32(0), resp.Code, resp.Message)
+
+	// 按请求顺序返回鉴权结果，并返回未授权资源的权限申请链接
+	require.Len(t, result.Results, 3)
+	assert.True(t, result.Results[0].Authorized)
+	assert.True(t, result.Results[1].Authorized)
+	assert.False(t, result.Results[2].Authorized)
+	assert.Equal(t, "http://iam/apply", result.ApplyUrl)
+	require.Len(t, authorizer.toApply, 1)
+	assert.Equal(t, "cvm-2", authorizer.toApply[0].Basic.ResourceID)
+
+	// 全部有权限时不需要申请链接
+	authorizer = &fakeAuthorizer{authorized: map[string]bool{"cvm-1": true}}
+	result, resp = doAuthCheck(t, authorizer,
+		`{"resources":[{"bk_biz_id":1,"resource_type":"cvm","resource_id":"cvm-1","action":"delete"}]}`)
+	require.Equal(t, int32(0), resp.Code, resp.Message)
+	assert.Empty(t, result.ApplyUrl)
+	assert.Nil(t, authorizer.toApply)
+
+	_, resp = doAuthCheck(t, authorizer, `{"resources":[{"resource_type":"cvm"}]}`)
+	assert.NotEqual(t, int32(0), resp.Code)
+}
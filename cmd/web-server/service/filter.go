@@ -45,6 +45,7 @@ var ConvMap map[int32]int32 = map[int32]int32{
 
 type loginVerifyRespData struct {
 	UserName string `json:"username"`
+	TenantID string `json:"tenant_id"`
 }
 
 func isITSMCallbackRequest(req *restful.Request) bool {
@@ -102,6 +103,7 @@ func newCheckLogin(esbClient esb.Client, bkLoginUrl, bkLoginCookieName string) f
 			Message: resp.Message,
 			Data: loginVerifyRespData{
 				UserName: resp.Data.Username,
+				TenantID: resp.Data.TenantID,
 			},
 		}, nil
 	}
@@ -114,7 +116,7 @@ func NewUserAuthenticateFilter(esbClient esb.Client, bkLoginUrl, bkLoginCookieNa
 
 	return func(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
 		var err error
-		username := ""
+		username, tenantID := "", ""
 		// 对于itsm 的回调请求，不能用户认证，而是处理请求时进行单独的Token认证，这里直接通过
 		if isITSMCallbackRequest(req) {
 			username = "itsm_callback"
@@ -137,16 +139,30 @@ func NewUserAuthenticateFilter(esbClient esb.Client, bkLoginUrl, bkLoginCookieNa
 			if ret != nil {
 				dataContent, ok := ret.Data.(loginVerifyRespData)
 				if ok {
-					username = dataContent.UserName
+					username, tenantID = dataContent.UserName, dataContent.TenantID
 				} else {
 					logs.Errorf("change ret data to loginVerifyRespData failed")
 				}
 			}
 		}
 
+		// 租户以登录用户所属租户为准，前端请求头中的租户ID必须与之一致
+		tenantID, err = kit.MatchTenant(req.Request.Header.Get(constant.TenantIDKey), tenantID)
+		if err != nil {
+			resp.WriteError(http.StatusForbidden, err)
+			return
+		}
+		req.Request.Header.Set(constant.TenantIDKey, tenantID)
+
+		// 强制读主库的请求头只在服务间传递，不允许前端请求设置，避免外部请求绕过只读库把读压力打到主库
+		req.Request.Header.Del(constant.ReadPrimaryKey)
 		// 这里直接修改请求的Header，后面需要用，可以直接从Header头里取
 		req.Request.Header.Set(constant.UserKey, username)
 		req.Request.Header.Set(constant.AppCodeKey, "hcm-web-server")
+		// 前端通过 cookie 选择语言，转换为请求头传递给后端服务，使错误信息等以用户选择的语言返回
+		if _, err := req.Request.Cookie(constant.BKHTTPCookieLanguageKey); err == nil {
+			req.Request.Header.Set(constant.LanguageKey, string(rest.GetLanguageByHTTPRequest(req)))
+		}
 
 		// 使用Kit便于校验通用的Header是否满足
 		kt, err := kit.FromHeader(req.Request.Context(), req.Request.Header)
@@ -44,13 +44,12 @@ import (
 	"hcm/cmd/web-server/service/version"
 	"hcm/pkg/cc"
 	apiclient "hcm/pkg/client"
-	"hcm/pkg/criteria/errf"
 	"hcm/pkg/handler"
+	"hcm/pkg/health"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/metrics"
-	"hcm/pkg/rest"
 	"hcm/pkg/rest/client"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
@@ -77,6 +76,8 @@ type Service struct {
 	itsmCli pkgitsm.Client
 	// noticeCli notification center client
 	noticeCli pkgnotice.Client
+	// dis is used to check the dependent services in readiness probe
+	dis serviced.Discover
 }
 
 // NewService create a service instance.
@@ -134,6 +135,7 @@ func NewService(dis serviced.Discover) (*Service, error) {
 	}
 
 	return &Service{
+		dis:        dis,
 		client:     apiClientSet,
 		esbClient:  esbClient,
 		proxy:      p,
@@ -169,6 +171,7 @@ func (s *Service) ListenAndServeRest() error {
 	// Basic API 使用net/http 路由处理
 	// Healthz
 	root.HandleFunc("/healthz", s.Healthz)
+	root.HandleFunc("/readyz", s.Readyz)
 	// metric/debug/ctl
 	handler.SetCommonHandler(root)
 
@@ -194,6 +197,7 @@ func (s *Service) ListenAndServeRest() error {
 	container.Add(s.staticFileSet())
 	container.Add(s.apiSet())
 	container.Add(s.proxyApiSet("/api/v1/cloud"))
+	container.Add(s.proxyApiSet("/api/v2/cloud"))
 	container.Add(s.proxyApiSet("/api/v1/account"))
 	container.Add(s.indexSet())
 
@@ -364,21 +368,12 @@ func (s *Service) indexHandleFunc(req *restful.Request, resp *restful.Response)
 	}
 }
 
-// Healthz service health check.
+// Healthz check whether the service is healthy.
 func (s *Service) Healthz(w http.ResponseWriter, r *http.Request) {
-	if shutdown.IsShuttingDown() {
-		logs.Errorf("service healthz check failed, current service is shutting down")
-		w.WriteHeader(http.StatusServiceUnavailable)
-		rest.WriteResp(w, rest.NewBaseResp(errf.UnHealthy, "current service is shutting down"))
-		return
-	}
-
-	if err := serviced.Healthz(r.Context(), cc.WebServer().Service); err != nil {
-		logs.Errorf("serviced healthz check failed, err: %v", err)
-		rest.WriteResp(w, rest.NewBaseResp(errf.UnHealthy, "serviced healthz error, "+err.Error()))
-		return
-	}
+	health.ServeLiveness(w, r, health.Etcd(cc.WebServer().Service))
+}
 
-	rest.WriteResp(w, rest.NewBaseResp(errf.OK, "healthy"))
-	return
+// Readyz check whether the service and its dependencies are ready to serve requests.
+func (s *Service) Readyz(w http.ResponseWriter, r *http.Request) {
+	health.ServeReadiness(w, r, health.Readiness(cc.WebServer().Service, s.dis)...)
 }
@@ -15,6 +15,7 @@ export enum AuditActionEnum {
   RECPVER = 'recover',
   DELIVER = 'deliver',
   EDIT = 'edit',
+  ACCESS_SECRET = 'access_secret',
 }
 
 export enum AuditActionNameEnum {
@@ -34,6 +35,7 @@ export enum AuditActionNameEnum {
   BIND = '绑定',
   RECPVER = '绑定',
   DELIVER = '交付',
+  ACCESS_SECRET = '访问秘钥',
 }
 
 export enum AuditSourceEnum {
@@ -63,4 +65,5 @@ export const AUDIT_ACTION_MAP = {
   [AuditActionEnum.DELIVER]: AuditActionNameEnum.DELIVER,
   [AuditActionEnum.BIND]: AuditActionNameEnum.BIND,
   [AuditActionEnum.EDIT]: AuditActionNameEnum.EDIT,
+  [AuditActionEnum.ACCESS_SECRET]: AuditActionNameEnum.ACCESS_SECRET,
 };
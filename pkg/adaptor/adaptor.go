@@ -47,21 +47,21 @@ func (a *Adaptor) TCloud(s *types.BaseSecret) (tcloud.TCloud, error) {
 }
 
 // Aws returns Aws operations.
-func (a *Adaptor) Aws(s *types.BaseSecret, cloudAccountID string, site enumor.AccountSiteType) (*aws.Aws, error) {
+func (a *Adaptor) Aws(s *types.BaseSecret, cloudAccountID string, site enumor.AccountSiteType) (aws.Aws, error) {
 	return aws.NewAws(s, cloudAccountID, site)
 }
 
 // Gcp returns Gcp operations.
-func (a *Adaptor) Gcp(credential *types.GcpCredential) (*gcp.Gcp, error) {
+func (a *Adaptor) Gcp(credential *types.GcpCredential) (gcp.Gcp, error) {
 	return gcp.NewGcp(credential)
 }
 
 // Azure returns Azure operations.
-func (a *Adaptor) Azure(credential *types.AzureCredential) (*azure.Azure, error) {
+func (a *Adaptor) Azure(credential *types.AzureCredential) (azure.Azure, error) {
 	return azure.NewAzure(credential)
 }
 
 // HuaWei returns HuaWei operations.
-func (a *Adaptor) HuaWei(s *types.BaseSecret) (*huawei.HuaWei, error) {
+func (a *Adaptor) HuaWei(s *types.BaseSecret) (huawei.HuaWei, error) {
 	return huawei.NewHuaWei(s)
 }
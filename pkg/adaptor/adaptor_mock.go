@@ -23,15 +23,18 @@
 package adaptor
 
 import (
-	"errors"
-
 	"hcm/pkg/adaptor/aws"
 	"hcm/pkg/adaptor/azure"
 	"hcm/pkg/adaptor/gcp"
 	"hcm/pkg/adaptor/huawei"
+	mockaws "hcm/pkg/adaptor/mock/aws"
+	mockazure "hcm/pkg/adaptor/mock/azure"
+	mockgcp "hcm/pkg/adaptor/mock/gcp"
+	mockhuawei "hcm/pkg/adaptor/mock/huawei"
 	mocktcloud "hcm/pkg/adaptor/mock/tcloud"
 	"hcm/pkg/adaptor/tcloud"
 	"hcm/pkg/adaptor/types"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/logs"
 )
 
@@ -53,21 +56,25 @@ func (a *Adaptor) TCloud(s *types.BaseSecret) (tcloud.TCloud, error) {
 }
 
 // Aws returns Aws operations.
-func (a *Adaptor) Aws(s *types.BaseSecret, cloudAccountID string) (*aws.Aws, error) {
-	return nil, errors.New("mock of aws not implemented")
+func (a *Adaptor) Aws(s *types.BaseSecret, cloudAccountID string, site enumor.AccountSiteType) (aws.Aws, error) {
+	mockAws := mockaws.GetMockCloud()
+	return mockAws, nil
 }
 
 // Gcp returns Gcp operations.
-func (a *Adaptor) Gcp(credential *types.GcpCredential) (*gcp.Gcp, error) {
-	return nil, errors.New("mock of gcp not implemented")
+func (a *Adaptor) Gcp(credential *types.GcpCredential) (gcp.Gcp, error) {
+	mockGcp := mockgcp.GetMockCloud()
+	return mockGcp, nil
 }
 
 // Azure returns Azure operations.
-func (a *Adaptor) Azure(credential *types.AzureCredential) (*azure.Azure, error) {
-	return nil, errors.New("mock of azure not implemented")
+func (a *Adaptor) Azure(credential *types.AzureCredential) (azure.Azure, error) {
+	mockAzure := mockazure.GetMockCloud()
+	return mockAzure, nil
 }
 
 // HuaWei returns HuaWei operations.
-func (a *Adaptor) HuaWei(s *types.BaseSecret) (*huawei.HuaWei, error) {
-	return nil, errors.New("mock of huawei not implemented")
+func (a *Adaptor) HuaWei(s *types.BaseSecret) (huawei.HuaWei, error) {
+	mockHuaWei := mockhuawei.GetMockCloud()
+	return mockHuaWei, nil
 }
@@ -0,0 +1,296 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package apiusage 统计每个云账号调用云上接口的次数和频率，在调用频率接近云厂商的接口频率限制时告警，用于发现同步配置
+// 过于激进导致接口配额即将耗尽等问题。调用量按云账号的凭据统计，与云厂商按凭据所属账号计算频率限制的方式一致。
+// 统计数据保存在当前进程内，每个实例只统计自身发出的请求。目前支持腾讯云、aws、azure的SDK。
+package apiusage
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/logs"
+)
+
+// defaultWarnPercent 默认在调用频率达到限制的该百分比时告警
+const defaultWarnPercent = 80
+
+// windowSeconds 统计调用频率的时间窗口，单位：秒
+const windowSeconds = 60
+
+// now returns the current time, it is replaced in tests.
+var now = time.Now
+
+// usageKey identify the calls of an api by the credential of the cloud account.
+type usageKey struct {
+	vendor   enumor.Vendor
+	identity string
+	apiName  string
+}
+
+// bindingKey identify the credential of the cloud account.
+type bindingKey struct {
+	vendor   enumor.Vendor
+	identity string
+}
+
+// limitKey identify the api of the vendor.
+type limitKey struct {
+	vendor  enumor.Vendor
+	apiName string
+}
+
+// quota is the evaluated api quota setting.
+type quota struct {
+	warnPercent uint
+	// limits value is the qps limit of the api.
+	limits map[limitKey]uint
+}
+
+var (
+	counters sync.Map
+	// bindings key is the credential of the cloud account, value is the hcm account id.
+	bindings sync.Map
+	active   atomic.Pointer[quota]
+)
+
+// Init set the api quota setting getter, the setting is reloaded once the settings are changed.
+func Init(getter func() cc.CloudApiQuota) {
+	load(getter())
+	cc.OnChange(func() {
+		load(getter())
+	})
+}
+
+func load(conf cc.CloudApiQuota) {
+	q := &quota{
+		warnPercent: conf.WarnPercent,
+		limits:      make(map[limitKey]uint, len(conf.Limits)),
+	}
+	if q.warnPercent == 0 {
+		q.warnPercent = defaultWarnPercent
+	}
+	for _, limit := range conf.Limits {
+		q.limits[limitKey{vendor: enumor.Vendor(limit.Vendor), apiName: limit.ApiName}] = limit.QPS
+	}
+	active.Store(q)
+}
+
+// limitOf returns the qps limit of the api and the qps to warn, zero means no limit is configured.
+func limitOf(vendor enumor.Vendor, apiName string) (uint, uint) {
+	q := active.Load()
+	if q == nil {
+		return 0, 0
+	}
+
+	limit := q.limits[limitKey{vendor: vendor, apiName: apiName}]
+	return limit, (limit*q.warnPercent + 99) / 100
+}
+
+// BindAccount bind the credential of the cloud account to the hcm account, so that the usage is reported with the
+// hcm account id. identity is the credential id which is used to sign the requests, such as tcloud secret id, aws
+// access key id and azure subscription id.
+func BindAccount(vendor enumor.Vendor, identity, accountID string) {
+	if len(identity) == 0 {
+		return
+	}
+
+	bindings.Store(bindingKey{vendor: vendor, identity: identity}, accountID)
+}
+
+// Record a call of the cloud api, identity is the credential id which signs the request, throttled means the call is
+// rejected by the vendor for exceeding the rate limit.
+func Record(vendor enumor.Vendor, identity, apiName string, failed, throttled bool) {
+	key := usageKey{vendor: vendor, identity: identity, apiName: apiName}
+	value, ok := counters.Load(key)
+	if !ok {
+		value, _ = counters.LoadOrStore(key, new(counter))
+	}
+
+	c := value.(*counter)
+	limit, warnQPS := limitOf(vendor, apiName)
+	qps, warn := c.add(now(), failed, throttled, warnQPS)
+	if warn {
+		logs.Warnf("cloud api %s %s is called %d times in a second by account %s, which is near the limit %d qps",
+			vendor, apiName, qps, accountOf(vendor, identity), limit)
+	}
+}
+
+func accountOf(vendor enumor.Vendor, identity string) string {
+	accountID, ok := bindings.Load(bindingKey{vendor: vendor, identity: identity})
+	if !ok {
+		return ""
+	}
+
+	return accountID.(string)
+}
+
+// counter is the calls of an api by a cloud account.
+type counter struct {
+	lock      sync.Mutex
+	total     uint64
+	failed    uint64
+	throttled uint64
+	// seconds and counts is the ring of the calls in each second of the window.
+	seconds     [windowSeconds]int64
+	counts      [windowSeconds]uint32
+	lastCallAt  time.Time
+	throttledAt time.Time
+	warnedAt    time.Time
+}
+
+// add a call to the counter, returns the calls in current second, and whether to warn that the qps reaches warnQPS,
+// it warns at most once a window to avoid flooding the log.
+func (c *counter) add(at time.Time, failed, throttled bool, warnQPS uint) (uint32, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.total++
+	if failed {
+		c.failed++
+	}
+	if throttled {
+		c.throttled++
+		c.throttledAt = at
+	}
+	c.lastCallAt = at
+
+	sec := at.Unix()
+	idx := sec % windowSeconds
+	if c.seconds[idx] != sec {
+		c.seconds[idx], c.counts[idx] = sec, 0
+	}
+	c.counts[idx]++
+
+	qps := c.counts[idx]
+	if warnQPS == 0 || uint(qps) < warnQPS || at.Sub(c.warnedAt) < windowSeconds*time.Second {
+		return qps, false
+	}
+
+	c.warnedAt = at
+	return qps, true
+}
+
+// window returns the calls and the peak qps in the window before at.
+func (c *counter) window(at time.Time) (uint64, uint32) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var calls uint64
+	var peak uint32
+	for i := range c.seconds {
+		if at.Unix()-c.seconds[i] >= windowSeconds {
+			continue
+		}
+		calls += uint64(c.counts[i])
+		if c.counts[i] > peak {
+			peak = c.counts[i]
+		}
+	}
+
+	return calls, peak
+}
+
+// Usage is the usage of a cloud api by a cloud account.
+type Usage struct {
+	Vendor    enumor.Vendor `json:"vendor"`
+	AccountID string        `json:"account_id"`
+	ApiName   string        `json:"api_name"`
+	// Total 进程启动以来的调用次数
+	Total     uint64 `json:"total"`
+	Failed    uint64 `json:"failed"`
+	Throttled uint64 `json:"throttled"`
+	// LastMinute 最近一分钟的调用次数
+	LastMinute uint64 `json:"last_minute"`
+	// PeakQPS 最近一分钟内每秒调用次数的最大值
+	PeakQPS uint32 `json:"peak_qps"`
+	// LimitQPS 配置的接口频率限制，未配置时为0
+	LimitQPS uint `json:"limit_qps"`
+	// NearLimit 最近一分钟的调用频率达到了告警阈值，或有调用被云厂商限频
+	NearLimit  bool   `json:"near_limit"`
+	LastCallAt string `json:"last_call_at"`
+}
+
+// ListOption is the option to list usages, the empty field means no filter.
+type ListOption struct {
+	Vendor    enumor.Vendor
+	AccountID string
+	// NearLimitOnly only returns the usages near the limit.
+	NearLimitOnly bool
+}
+
+// List the usages of cloud apis, sorted by the peak qps in descending order, so that the apis which are about to
+// exhaust the quota are listed first.
+func List(opt ListOption) []Usage {
+	at := now()
+	usages := make([]Usage, 0)
+	counters.Range(func(k, v interface{}) bool {
+		key, c := k.(usageKey), v.(*counter)
+		if len(opt.Vendor) != 0 && key.vendor != opt.Vendor {
+			return true
+		}
+
+		accountID := accountOf(key.vendor, key.identity)
+		if len(opt.AccountID) != 0 && accountID != opt.AccountID {
+			return true
+		}
+
+		lastMinute, peak := c.window(at)
+		limit, warnQPS := limitOf(key.vendor, key.apiName)
+		c.lock.Lock()
+		usage := Usage{
+			Vendor:     key.vendor,
+			AccountID:  accountID,
+			ApiName:    key.apiName,
+			Total:      c.total,
+			Failed:     c.failed,
+			Throttled:  c.throttled,
+			LastMinute: lastMinute,
+			PeakQPS:    peak,
+			LimitQPS:   limit,
+			NearLimit: (warnQPS != 0 && uint(peak) >= warnQPS) ||
+				(!c.throttledAt.IsZero() && at.Sub(c.throttledAt) < windowSeconds*time.Second),
+			LastCallAt: c.lastCallAt.Format(time.RFC3339),
+		}
+		c.lock.Unlock()
+
+		if opt.NearLimitOnly && !usage.NearLimit {
+			return true
+		}
+		usages = append(usages, usage)
+		return true
+	})
+
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].PeakQPS != usages[j].PeakQPS {
+			return usages[i].PeakQPS > usages[j].PeakQPS
+		}
+		if usages[i].AccountID != usages[j].AccountID {
+			return usages[i].AccountID < usages[j].AccountID
+		}
+		return usages[i].ApiName < usages[j].ApiName
+	})
+
+	return usages
+}
@@ -0,0 +1,121 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package apiusage
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reset() {
+	counters = sync.Map{}
+	bindings = sync.Map{}
+	active.Store(nil)
+	now = time.Now
+}
+
+func TestRecordAndList(t *testing.T) {
+	defer reset()
+
+	at := time.Unix(1700000000, 0)
+	now = func() time.Time { return at }
+	load(cc.CloudApiQuota{
+		Limits: []cc.CloudApiLimit{{Vendor: string(enumor.TCloud), ApiName: "DescribeInstances", QPS: 10}},
+	})
+	BindAccount(enumor.TCloud, "AKID1", "00000001")
+
+	for i := 0; i < 8; i++ {
+		Record(enumor.TCloud, "AKID1", "DescribeInstances", false, false)
+	}
+	Record(enumor.TCloud, "AKID1", "DescribeVpcs", true, false)
+	Record(enumor.Aws, "AKIA2", "DescribeInstances", false, false)
+
+	usages := List(ListOption{})
+	require.Len(t, usages, 3)
+	assert.Equal(t, Usage{
+		Vendor:     enumor.TCloud,
+		AccountID:  "00000001",
+		ApiName:    "DescribeInstances",
+		Total:      8,
+		LastMinute: 8,
+		PeakQPS:    8,
+		LimitQPS:   10,
+		NearLimit:  true,
+		LastCallAt: at.Format(time.RFC3339),
+	}, usages[0], "8 qps reaches 80% of the limit")
+	assert.Empty(t, usages[1].AccountID, "aws credential is not bound")
+	assert.Equal(t, "DescribeVpcs", usages[2].ApiName)
+	assert.Equal(t, uint64(1), usages[2].Failed)
+	assert.False(t, usages[2].NearLimit, "no limit is configured")
+
+	assert.Len(t, List(ListOption{Vendor: enumor.TCloud}), 2)
+	assert.Len(t, List(ListOption{AccountID: "00000001"}), 2)
+	nearLimit := List(ListOption{NearLimitOnly: true})
+	require.Len(t, nearLimit, 1)
+	assert.Equal(t, "DescribeInstances", nearLimit[0].ApiName)
+
+	// the calls out of the window are not counted in the qps
+	at = at.Add(windowSeconds * time.Second)
+	Record(enumor.TCloud, "AKID1", "DescribeInstances", false, false)
+	usage := List(ListOption{Vendor: enumor.TCloud})[0]
+	assert.Equal(t, uint64(9), usage.Total)
+	assert.Equal(t, uint64(1), usage.LastMinute)
+	assert.False(t, usage.NearLimit)
+}
+
+func TestThrottled(t *testing.T) {
+	defer reset()
+
+	at := time.Unix(1700000000, 0)
+	now = func() time.Time { return at }
+	Record(enumor.Azure, "sub-1", "GET virtualMachines", true, true)
+
+	usages := List(ListOption{NearLimitOnly: true})
+	require.Len(t, usages, 1)
+	assert.Equal(t, uint64(1), usages[0].Throttled)
+
+	at = at.Add(windowSeconds * time.Second)
+	assert.Empty(t, List(ListOption{NearLimitOnly: true}), "throttled out of the window")
+}
+
+func TestWarnOncePerWindow(t *testing.T) {
+	c := new(counter)
+	at := time.Unix(1700000000, 0)
+
+	_, warn := c.add(at, false, false, 2)
+	assert.False(t, warn)
+	qps, warn := c.add(at, false, false, 2)
+	assert.True(t, warn)
+	assert.Equal(t, uint32(2), qps)
+	_, warn = c.add(at, false, false, 2)
+	assert.False(t, warn, "warned in the window")
+
+	at = at.Add(windowSeconds * time.Second)
+	c.add(at, false, false, 2)
+	_, warn = c.add(at, false, false, 2)
+	assert.True(t, warn)
+}
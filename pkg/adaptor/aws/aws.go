@@ -27,16 +27,16 @@ import (
 )
 
 // NewAws new aws.
-func NewAws(s *types.BaseSecret, cloudAccountID string, site enumor.AccountSiteType) (*Aws, error) {
+func NewAws(s *types.BaseSecret, cloudAccountID string, site enumor.AccountSiteType) (Aws, error) {
 	if err := validateSecret(s); err != nil {
 		return nil, err
 	}
 
-	return &Aws{clientSet: newClientSet(s), cloudAccountID: cloudAccountID, site: site}, nil
+	return &AwsImpl{clientSet: newClientSet(s), cloudAccountID: cloudAccountID, site: site}, nil
 }
 
-// Aws is aws operator.
-type Aws struct {
+// AwsImpl is aws operator.
+type AwsImpl struct {
 	clientSet      *clientSet
 	cloudAccountID string
 	site           enumor.AccountSiteType
@@ -55,17 +55,17 @@ func validateSecret(s *types.BaseSecret) error {
 }
 
 // CloudAccountID return cloud account id.
-func (a *Aws) CloudAccountID() string {
+func (a *AwsImpl) CloudAccountID() string {
 	return a.cloudAccountID
 }
 
 // IsChinaSite is china site.
-func (a *Aws) IsChinaSite() bool {
+func (a *AwsImpl) IsChinaSite() bool {
 	return a.site == enumor.ChinaSite
 }
 
 // DefaultRegion return default region.
-func (a *Aws) DefaultRegion() string {
+func (a *AwsImpl) DefaultRegion() string {
 	if a.IsChinaSite() {
 		return "cn-north-1"
 	}
@@ -20,7 +20,13 @@
 package aws
 
 import (
+	"net/http"
+
+	"hcm/pkg/adaptor/fault"
+	"hcm/pkg/adaptor/metric"
+	"hcm/pkg/adaptor/recorder"
 	"hcm/pkg/adaptor/types"
+	"hcm/pkg/criteria/enumor"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -70,7 +76,7 @@ func (c *clientSet) ec2Client(region string) (*ec2.EC2, error) {
 		cfg.Region = aws.String(region)
 	}
 
-	sess, err := session.NewSession(cfg)
+	sess, err := newSession(cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -92,7 +98,7 @@ func (c *clientSet) stsClient(region *string) (*sts.STS, error) {
 		Region:      region,
 	}
 
-	sess, err := session.NewSession(cfg)
+	sess, err := newSession(cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -116,7 +122,7 @@ func (c *clientSet) athenaClient(region string) (*athena.Athena, error) {
 		cfg.Region = aws.String(region)
 	}
 
-	sess, err := session.NewSession(cfg)
+	sess, err := newSession(cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -136,7 +142,7 @@ func (c *clientSet) organizations() (*organizations.Organizations, error) {
 		SleepDelay:  nil,
 	}
 
-	sess, err := session.NewSession(cfg)
+	sess, err := newSession(cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -160,7 +166,7 @@ func (c *clientSet) s3Client(region string) (*s3.S3, error) {
 		cfg.Region = aws.String(region)
 	}
 
-	sess, err := session.NewSession(cfg)
+	sess, err := newSession(cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -184,7 +190,7 @@ func (c *clientSet) costAndUsageReportClient(region string) (*curservice.Costand
 		cfg.Region = aws.String(region)
 	}
 
-	sess, err := session.NewSession(cfg)
+	sess, err := newSession(cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -208,10 +214,26 @@ func (c *clientSet) cloudFormationClient(region string) (*cloudformation.CloudFo
 		cfg.Region = aws.String(region)
 	}
 
-	sess, err := session.NewSession(cfg)
+	sess, err := newSession(cfg)
 	if err != nil {
 		return nil, err
 	}
 
 	return cloudformation.New(sess, aws.NewConfig().WithRegion(region)), nil
 }
+
+// newSession create aws session which records the api call metrics and traces.
+func newSession(cfg *aws.Config) (*session.Session, error) {
+	if recorder.Enabled() || fault.Available() {
+		cfg.HTTPClient = &http.Client{Transport: fault.Transport(enumor.Aws, recorder.Transport(nil))}
+	}
+
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, err
+	}
+	sess.Handlers.Build.PushFront(metric.AwsStartSpanHandler)
+	sess.Handlers.Complete.PushBack(metric.AwsRecordHandler)
+
+	return sess, nil
+}
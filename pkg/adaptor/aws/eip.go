@@ -35,7 +35,7 @@ import (
 
 // ListEip ...
 // reference: https://docs.amazonaws.cn/en_us/AWSEC2/latest/APIReference/API_DescribeAddresses.html
-func (a *Aws) ListEip(kt *kit.Kit, opt *eip.AwsEipListOption) (*eip.AwsEipListResult, error) {
+func (a *AwsImpl) ListEip(kt *kit.Kit, opt *eip.AwsEipListOption) (*eip.AwsEipListResult, error) {
 	if err := opt.Validate(); err != nil {
 		return nil, err
 	}
@@ -99,7 +99,7 @@ func (a *Aws) ListEip(kt *kit.Kit, opt *eip.AwsEipListOption) (*eip.AwsEipListRe
 
 // CountEip 返回给定地域下所有EIP数量，基于DescribeAddresses接口
 // reference: https://docs.amazonaws.cn/en_us/AWSEC2/latest/APIReference/API_DescribeAddresses.html
-func (a *Aws) CountEip(kt *kit.Kit, region string) (int32, error) {
+func (a *AwsImpl) CountEip(kt *kit.Kit, region string) (int32, error) {
 	client, err := a.clientSet.ec2Client(region)
 	if err != nil {
 		return 0, err
@@ -116,7 +116,7 @@ func (a *Aws) CountEip(kt *kit.Kit, region string) (int32, error) {
 
 // DeleteEip ...
 // reference: https://docs.amazonaws.cn/en_us/AWSEC2/latest/APIReference/API_ReleaseAddress.html
-func (a *Aws) DeleteEip(kt *kit.Kit, opt *eip.AwsEipDeleteOption) error {
+func (a *AwsImpl) DeleteEip(kt *kit.Kit, opt *eip.AwsEipDeleteOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "aws eip delete option is required")
 	}
@@ -142,7 +142,7 @@ func (a *Aws) DeleteEip(kt *kit.Kit, opt *eip.AwsEipDeleteOption) error {
 
 // AssociateEip ...
 // reference: https://docs.amazonaws.cn/en_us/AWSEC2/latest/APIReference/API_AssociateAddress.html
-func (a *Aws) AssociateEip(kt *kit.Kit, opt *eip.AwsEipAssociateOption) error {
+func (a *AwsImpl) AssociateEip(kt *kit.Kit, opt *eip.AwsEipAssociateOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "aws eip associate option is required")
 	}
@@ -163,7 +163,7 @@ func (a *Aws) AssociateEip(kt *kit.Kit, opt *eip.AwsEipAssociateOption) error {
 		return err
 	}
 
-	respPoller := poller.Poller[*Aws, []*eip.AwsEip,
+	respPoller := poller.Poller[*AwsImpl, []*eip.AwsEip,
 		poller.BaseDoneResult]{Handler: &associateEipPollingHandler{region: opt.Region}}
 	_, err = respPoller.PollUntilDone(a, kt, []*string{&opt.PublicIp}, nil)
 	if err != nil {
@@ -175,7 +175,7 @@ func (a *Aws) AssociateEip(kt *kit.Kit, opt *eip.AwsEipAssociateOption) error {
 
 // DisassociateEip ...
 // reference: https://docs.amazonaws.cn/en_us/AWSEC2/latest/APIReference/API_DisassociateAddress.html
-func (a *Aws) DisassociateEip(kt *kit.Kit, opt *eip.AwsEipDisassociateOption) error {
+func (a *AwsImpl) DisassociateEip(kt *kit.Kit, opt *eip.AwsEipDisassociateOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "aws eip disassociate option is required")
 	}
@@ -203,7 +203,7 @@ func (a *Aws) DisassociateEip(kt *kit.Kit, opt *eip.AwsEipDisassociateOption) er
 		return err
 	}
 
-	respPoller := poller.Poller[*Aws, []*eip.AwsEip,
+	respPoller := poller.Poller[*AwsImpl, []*eip.AwsEip,
 		poller.BaseDoneResult]{Handler: &disassociateEipPollingHandler{region: opt.Region}}
 	_, err = respPoller.PollUntilDone(a, kt, []*string{&opt.PublicIp}, nil)
 	if err != nil {
@@ -215,7 +215,7 @@ func (a *Aws) DisassociateEip(kt *kit.Kit, opt *eip.AwsEipDisassociateOption) er
 
 // CreateEip ...
 // reference: https://docs.amazonaws.cn/en_us/AWSEC2/latest/APIReference/API_AllocateAddress.html
-func (a *Aws) CreateEip(kt *kit.Kit, opt *eip.AwsEipCreateOption) (*string, error) {
+func (a *AwsImpl) CreateEip(kt *kit.Kit, opt *eip.AwsEipCreateOption) (*string, error) {
 	if opt == nil {
 		return nil, errf.New(errf.InvalidParameter, "aws eip create option is required")
 	}
@@ -224,6 +224,7 @@ func (a *Aws) CreateEip(kt *kit.Kit, opt *eip.AwsEipCreateOption) (*string, erro
 	if err != nil {
 		return nil, err
 	}
+	req.TagSpecifications = withOperatorTags(kt, req.TagSpecifications, eipTagResType)
 
 	client, err := a.clientSet.ec2Client(opt.Region)
 	if err != nil {
@@ -235,7 +236,7 @@ func (a *Aws) CreateEip(kt *kit.Kit, opt *eip.AwsEipCreateOption) (*string, erro
 		return nil, err
 	}
 
-	respPoller := poller.Poller[*Aws, []*eip.AwsEip,
+	respPoller := poller.Poller[*AwsImpl, []*eip.AwsEip,
 		poller.BaseDoneResult]{Handler: &createEipPollingHandler{region: opt.Region}}
 	_, err = respPoller.PollUntilDone(a, kt, []*string{resp.PublicIp}, nil)
 	if err != nil {
@@ -259,7 +260,7 @@ func (h *createEipPollingHandler) Done(pollResult []*eip.AwsEip) (bool, *poller.
 }
 
 // Poll ...
-func (h *createEipPollingHandler) Poll(client *Aws, kt *kit.Kit, Ips []*string) ([]*eip.AwsEip, error) {
+func (h *createEipPollingHandler) Poll(client *AwsImpl, kt *kit.Kit, Ips []*string) ([]*eip.AwsEip, error) {
 	if len(Ips) != 1 {
 		return nil, fmt.Errorf("poll only support one ip param, but get %v. rid: %s", Ips, kt.Rid)
 	}
@@ -286,7 +287,7 @@ func (h *associateEipPollingHandler) Done(pollResult []*eip.AwsEip) (bool, *poll
 }
 
 // Poll ...
-func (h *associateEipPollingHandler) Poll(client *Aws, kt *kit.Kit, Ips []*string) ([]*eip.AwsEip, error) {
+func (h *associateEipPollingHandler) Poll(client *AwsImpl, kt *kit.Kit, Ips []*string) ([]*eip.AwsEip, error) {
 	if len(Ips) != 1 {
 		return nil, fmt.Errorf("poll only support one ip param, but get %v. rid: %s", Ips, kt.Rid)
 	}
@@ -313,7 +314,7 @@ func (h *disassociateEipPollingHandler) Done(pollResult []*eip.AwsEip) (bool, *p
 }
 
 // Poll ...
-func (h *disassociateEipPollingHandler) Poll(client *Aws, kt *kit.Kit, Ips []*string) ([]*eip.AwsEip, error) {
+func (h *disassociateEipPollingHandler) Poll(client *AwsImpl, kt *kit.Kit, Ips []*string) ([]*eip.AwsEip, error) {
 	if len(Ips) != 1 {
 		return nil, fmt.Errorf("poll only support one ip param, but get %v. rid: %s", Ips, kt.Rid)
 	}
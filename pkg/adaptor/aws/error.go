@@ -0,0 +1,54 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+)
+
+// ParseError parse the aws sdk error to cloud vendor error with the error code and request id.
+func ParseError(err error) *errf.ErrorF {
+	var reqErr awserr.RequestFailure
+	if errors.As(err, &reqErr) {
+		return &errf.ErrorF{
+			Code:    errf.CloudVendorError,
+			Message: reqErr.Message(),
+			SubCode: reqErr.Code(),
+			Details: &errf.Details{Vendor: string(enumor.Aws), CloudRequestID: reqErr.RequestID()},
+		}
+	}
+
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return &errf.ErrorF{
+			Code:    errf.CloudVendorError,
+			Message: awsErr.Message(),
+			SubCode: awsErr.Code(),
+			Details: &errf.Details{Vendor: string(enumor.Aws)},
+		}
+	}
+
+	return nil
+}
@@ -47,7 +47,7 @@ const (
 
 // CreateAccount
 // reference: https://docs.aws.amazon.com/organizations/latest/APIReference/API_CreateAccount.html
-func (a *Aws) CreateAccount(kt *kit.Kit, req *proto.CreateAwsMainAccountReq) (*proto.CreateAwsMainAccountResp, error) {
+func (a *AwsImpl) CreateAccount(kt *kit.Kit, req *proto.CreateAwsMainAccountReq) (*proto.CreateAwsMainAccountResp, error) {
 	// get aws client
 	client, err := a.clientSet.organizations()
 	if err != nil {
@@ -69,7 +69,7 @@ func (a *Aws) CreateAccount(kt *kit.Kit, req *proto.CreateAwsMainAccountReq) (*p
 	}
 
 	handler := &createMainAccountPollingHandler{}
-	resPoller := poller.Poller[*Aws, *organizations.CreateAccountStatus, organizations.CreateAccountStatus]{Handler: handler}
+	resPoller := poller.Poller[*AwsImpl, *organizations.CreateAccountStatus, organizations.CreateAccountStatus]{Handler: handler}
 	result, err := resPoller.PollUntilDone(a, kt, []*string{output.CreateAccountStatus.Id},
 		types.NewCreateMainAccountPollerOption())
 	if err != nil {
@@ -114,7 +114,7 @@ func (h *createMainAccountPollingHandler) Done(status *organizations.CreateAccou
 }
 
 // Poll ...
-func (h *createMainAccountPollingHandler) Poll(client *Aws, kt *kit.Kit, reqIds []*string) (
+func (h *createMainAccountPollingHandler) Poll(client *AwsImpl, kt *kit.Kit, reqIds []*string) (
 	*organizations.CreateAccountStatus, error) {
 
 	if len(reqIds) == 0 {
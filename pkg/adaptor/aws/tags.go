@@ -20,6 +20,8 @@
 package aws
 
 import (
+	"hcm/pkg/adaptor/operatortag"
+	"hcm/pkg/kit"
 	"hcm/pkg/tools/converter"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -34,8 +36,12 @@ const tagKeyForResourceName = "ImportMode"
 type tagResourceType string
 
 const (
-	vpcTagResType    tagResourceType = "vpc"
-	subnetTagResType tagResourceType = "subnet"
+	vpcTagResType      tagResourceType = "vpc"
+	subnetTagResType   tagResourceType = "subnet"
+	instanceTagResType tagResourceType = "instance"
+	sgTagResType       tagResourceType = "security-group"
+	volumeTagResType   tagResourceType = "volume"
+	eipTagResType      tagResourceType = "elastic-ip"
 )
 
 // genNameTags generate name ec2 tags.
@@ -55,6 +61,43 @@ func genNameTags(resourceType tagResourceType, name string) []*ec2.TagSpecificat
 	return []*ec2.TagSpecification{tagSpec}
 }
 
+// withOperatorTags add the operator tags of the request to the tag specification of the resource type, the tag
+// specified in the tag specification takes precedence over the operator tag with the same key.
+func withOperatorTags(kt *kit.Kit, specs []*ec2.TagSpecification,
+	resourceType tagResourceType) []*ec2.TagSpecification {
+
+	pairs := operatortag.Pairs(kt)
+	if len(pairs) == 0 {
+		return specs
+	}
+
+	var spec *ec2.TagSpecification
+	for _, one := range specs {
+		if one != nil && converter.PtrToVal(one.ResourceType) == string(resourceType) {
+			spec = one
+			break
+		}
+	}
+	if spec == nil {
+		spec = &ec2.TagSpecification{ResourceType: aws.String(string(resourceType))}
+		specs = append(specs, spec)
+	}
+
+	exists := make(map[string]struct{}, len(spec.Tags))
+	for _, tag := range spec.Tags {
+		if tag != nil {
+			exists[converter.PtrToVal(tag.Key)] = struct{}{}
+		}
+	}
+	for _, pair := range pairs {
+		if _, ok := exists[pair.Key]; !ok {
+			spec.Tags = append(spec.Tags, &ec2.Tag{Key: aws.String(pair.Key), Value: aws.String(pair.Value)})
+		}
+	}
+
+	return specs
+}
+
 // parseTags parse ec2 tags to name and other tags.
 func parseTags(tags []*ec2.Tag) (string, []*ec2.Tag) {
 	for i, tag := range tags {
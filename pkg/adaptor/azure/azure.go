@@ -27,15 +27,15 @@ import (
 )
 
 // NewAzure new azure.
-func NewAzure(credential *types.AzureCredential) (*Azure, error) {
+func NewAzure(credential *types.AzureCredential) (Azure, error) {
 	if err := credential.Validate(); err != nil {
 		return nil, err
 	}
-	return &Azure{clientSet: newClientSet(credential)}, nil
+	return &AzureImpl{clientSet: newClientSet(credential)}, nil
 }
 
-// Azure is azure operator.
-type Azure struct {
+// AzureImpl is azure operator.
+type AzureImpl struct {
 	clientSet *clientSet
 }
 
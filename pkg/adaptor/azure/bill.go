@@ -211,7 +211,7 @@ func (b *billClient) GetUsageDetail(kt *kit.Kit, opt *typesBill.AzureBillListOpt
 
 // GetBillList get bill list.
 // reference: https://learn.microsoft.com/zh-cn/rest/api/consumption/usage-details/list?tabs=HTTP#usagedetailslistresult
-func (az *Azure) GetBillList(kt *kit.Kit, opt *typesBill.AzureBillListOption) (
+func (az *AzureImpl) GetBillList(kt *kit.Kit, opt *typesBill.AzureBillListOption) (
 	*armconsumption.UsageDetailsListResult, error) {
 
 	if err := opt.Validate(); err != nil {
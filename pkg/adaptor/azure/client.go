@@ -21,11 +21,16 @@ package azure
 
 import (
 	"fmt"
+	"net/http"
+	"sync"
 
+	"hcm/pkg/adaptor/metric"
+	"hcm/pkg/adaptor/recorder"
 	"hcm/pkg/adaptor/types"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
@@ -37,10 +42,15 @@ import (
 
 type clientSet struct {
 	credential *types.AzureCredential
+
+	// tokenCredential 各客户端共用同一个凭据对象，凭据对象会缓存获取到的token，避免每个客户端都重新获取token
+	tokenCredential *azidentity.ClientSecretCredential
+	credentialErr   error
+	credentialOnce  sync.Once
 }
 
 func newClientSet(credential *types.AzureCredential) *clientSet {
-	return &clientSet{credential}
+	return &clientSet{credential: credential}
 }
 
 // graphServiceClient ...
@@ -65,7 +75,7 @@ func (c *clientSet) subscriptionClient() (*armsubscription.SubscriptionsClient,
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armsubscription.NewSubscriptionsClient(credential, nil)
+	client, err := armsubscription.NewSubscriptionsClient(credential, metric.AzureClientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure subscription client failed, err: %v", err)
 	}
@@ -83,7 +93,7 @@ func (c *clientSet) vpcClient() (*armnetwork.VirtualNetworksClient, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewVirtualNetworksClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewVirtualNetworksClient(c.credential.CloudSubscriptionID, credential, metric.AzureClientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure vpc client failed, err: %v", err)
 	}
@@ -100,7 +110,7 @@ func (c *clientSet) usageClient() (*armnetwork.UsagesClient, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewUsagesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewUsagesClient(c.credential.CloudSubscriptionID, credential, metric.AzureClientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure usage client failed, err: %v", err)
 	}
@@ -117,7 +127,7 @@ func (c *clientSet) subnetClient() (*armnetwork.SubnetsClient, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewSubnetsClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewSubnetsClient(c.credential.CloudSubscriptionID, credential, metric.AzureClientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure vpc client failed, err: %v", err)
 	}
@@ -130,7 +140,7 @@ func (c *clientSet) diskClient() (*armcompute.DisksClient, error) {
 	if err != nil {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
-	return armcompute.NewDisksClient(c.credential.CloudSubscriptionID, credential, nil)
+	return armcompute.NewDisksClient(c.credential.CloudSubscriptionID, credential, metric.AzureClientOptions())
 }
 
 // imageClient ...
@@ -140,15 +150,26 @@ func (c *clientSet) imageClient() (*armcompute.VirtualMachineImagesClient, error
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	return armcompute.NewVirtualMachineImagesClient(c.credential.CloudSubscriptionID, credential, nil)
+	return armcompute.NewVirtualMachineImagesClient(c.credential.CloudSubscriptionID, credential, metric.AzureClientOptions())
 }
 
 // newClientSecretCredential ...
 func (c *clientSet) newClientSecretCredential() (*azidentity.ClientSecretCredential, error) {
-	return azidentity.NewClientSecretCredential(
-		c.credential.CloudTenantID,
-		c.credential.CloudApplicationID,
-		c.credential.CloudClientSecretKey, nil)
+	c.credentialOnce.Do(func() {
+		var opts *azidentity.ClientSecretCredentialOptions
+		if recorder.Enabled() {
+			opts = &azidentity.ClientSecretCredentialOptions{
+				ClientOptions: policy.ClientOptions{Transport: &http.Client{Transport: recorder.Transport(nil)}},
+			}
+		}
+
+		c.tokenCredential, c.credentialErr = azidentity.NewClientSecretCredential(
+			c.credential.CloudTenantID,
+			c.credential.CloudApplicationID,
+			c.credential.CloudClientSecretKey, opts)
+	})
+
+	return c.tokenCredential, c.credentialErr
 }
 
 // securityGroupClient ...
@@ -158,7 +179,7 @@ func (c *clientSet) securityGroupClient() (*armnetwork.SecurityGroupsClient, err
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewSecurityGroupsClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewSecurityGroupsClient(c.credential.CloudSubscriptionID, credential, metric.AzureClientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure security group client failed, err: %v", err)
 	}
@@ -173,7 +194,7 @@ func (c *clientSet) virtualMachineClient() (*armcompute.VirtualMachinesClient, e
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armcompute.NewVirtualMachinesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armcompute.NewVirtualMachinesClient(c.credential.CloudSubscriptionID, credential, metric.AzureClientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure virtual machines client failed, err: %v", err)
 	}
@@ -188,7 +209,7 @@ func (c *clientSet) virtualMachineSizeClient() (*armcompute.VirtualMachineSizesC
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armcompute.NewVirtualMachineSizesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armcompute.NewVirtualMachineSizesClient(c.credential.CloudSubscriptionID, credential, metric.AzureClientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure virtual machine sizes client failed, err: %v", err)
 	}
@@ -203,7 +224,7 @@ func (c *clientSet) clientFactory() (*armcompute.ClientFactory, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armcompute.NewClientFactory(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armcompute.NewClientFactory(c.credential.CloudSubscriptionID, credential, metric.AzureClientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure client factory failed, err: %v", err)
 	}
@@ -218,7 +239,7 @@ func (c *clientSet) resourceGroupsClient() (*armresources.ResourceGroupsClient,
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armresources.NewResourceGroupsClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armresources.NewResourceGroupsClient(c.credential.CloudSubscriptionID, credential, metric.AzureClientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init resourceGroups client failed, err: %v", err)
 	}
@@ -233,7 +254,7 @@ func (c *clientSet) regionClient() (*armsubscriptions.Client, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armsubscriptions.NewClient(credential, nil)
+	client, err := armsubscriptions.NewClient(credential, metric.AzureClientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init region client failed, err: %v", err)
 	}
@@ -251,7 +272,7 @@ func (c *clientSet) routeTableClient() (*armnetwork.RouteTablesClient, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewRouteTablesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewRouteTablesClient(c.credential.CloudSubscriptionID, credential, metric.AzureClientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure vpc client failed, err: %v", err)
 	}
@@ -268,7 +289,7 @@ func (c *clientSet) routeClient() (*armnetwork.RoutesClient, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewRoutesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewRoutesClient(c.credential.CloudSubscriptionID, credential, metric.AzureClientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure vpc client failed, err: %v", err)
 	}
@@ -281,7 +302,7 @@ func (c *clientSet) publicIPAddressesClient() (*armnetwork.PublicIPAddressesClie
 	if err != nil {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
-	client, err := armnetwork.NewPublicIPAddressesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewPublicIPAddressesClient(c.credential.CloudSubscriptionID, credential, metric.AzureClientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure public ip addresses client failed, err: %v", err)
 	}
@@ -295,7 +316,7 @@ func (c *clientSet) networkInterfaceClient() (*armnetwork.InterfacesClient, erro
 		return nil, fmt.Errorf("init network interface credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewInterfacesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewInterfacesClient(c.credential.CloudSubscriptionID, credential, metric.AzureClientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init network interface client failed, err: %v", err)
 	}
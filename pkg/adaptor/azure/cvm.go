@@ -37,7 +37,7 @@ import (
 
 type cvmResultHandler struct {
 	resGroupName string
-	az           *Azure
+	az           *AzureImpl
 	kt           *kit.Kit
 }
 
@@ -55,7 +55,7 @@ func (handler *cvmResultHandler) BuildResult(resp armcompute.VirtualMachinesClie
 
 // CountCvm count cvm.
 // reference: https://learn.microsoft.com/en-us/rest/api/compute/virtual-machines/list?tabs=HTTP
-func (az *Azure) CountCvm(kt *kit.Kit) (int32, error) {
+func (az *AzureImpl) CountCvm(kt *kit.Kit) (int32, error) {
 
 	client, err := az.clientSet.virtualMachineClient()
 	if err != nil {
@@ -79,7 +79,7 @@ func (az *Azure) CountCvm(kt *kit.Kit) (int32, error) {
 
 // ListCvmByPage ...
 // reference: https://learn.microsoft.com/en-us/rest/api/compute/virtual-machines/list?tabs=HTTP
-func (az *Azure) ListCvmByPage(kt *kit.Kit, opt *typecvm.AzureListOption) (
+func (az *AzureImpl) ListCvmByPage(kt *kit.Kit, opt *typecvm.AzureListOption) (
 	*Pager[armcompute.VirtualMachinesClientListResponse, typecvm.AzureCvm], error) {
 
 	client, err := az.clientSet.virtualMachineClient()
@@ -103,7 +103,7 @@ func (az *Azure) ListCvmByPage(kt *kit.Kit, opt *typecvm.AzureListOption) (
 
 // ListCvm reference: https://learn.microsoft.com/en-us/rest/api/compute/virtual-machines/list?tabs=HTTP
 // https://learn.microsoft.com/en-us/rest/api/compute/virtual-machines/instance-view
-func (az *Azure) ListCvm(kt *kit.Kit, opt *typecvm.AzureListOption) ([]*typecvm.AzureCvm, error) {
+func (az *AzureImpl) ListCvm(kt *kit.Kit, opt *typecvm.AzureListOption) ([]*typecvm.AzureCvm, error) {
 	if opt == nil {
 		return nil, errf.New(errf.InvalidParameter, "list option is required")
 	}
@@ -142,7 +142,7 @@ func (az *Azure) ListCvm(kt *kit.Kit, opt *typecvm.AzureListOption) ([]*typecvm.
 
 // ListCvmByID reference: https://learn.microsoft.com/en-us/rest/api/compute/virtual-machines/list?tabs=HTTP
 // https://learn.microsoft.com/en-us/rest/api/compute/virtual-machines/instance-view
-func (az *Azure) ListCvmByID(kt *kit.Kit, opt *core.AzureListByIDOption) ([]*typecvm.AzureCvm, error) {
+func (az *AzureImpl) ListCvmByID(kt *kit.Kit, opt *core.AzureListByIDOption) ([]*typecvm.AzureCvm, error) {
 	if opt == nil {
 		return nil, errf.New(errf.InvalidParameter, "list option is required")
 	}
@@ -341,7 +341,7 @@ func converterToAzureCvm(vms []*armcompute.VirtualMachine) []*typecvm.AzureCvm {
 }
 
 // DeleteCvm reference: https://learn.microsoft.com/en-us/rest/api/compute/virtual-machines/delete?tabs=Go
-func (az *Azure) DeleteCvm(kt *kit.Kit, opt *typecvm.AzureDeleteOption) error {
+func (az *AzureImpl) DeleteCvm(kt *kit.Kit, opt *typecvm.AzureDeleteOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "delete option is required")
 	}
@@ -372,7 +372,7 @@ func (az *Azure) DeleteCvm(kt *kit.Kit, opt *typecvm.AzureDeleteOption) error {
 }
 
 // StartCvm reference: https://learn.microsoft.com/en-us/rest/api/compute/virtual-machines/start?tabs=HTTP
-func (az *Azure) StartCvm(kt *kit.Kit, opt *typecvm.AzureStartOption) error {
+func (az *AzureImpl) StartCvm(kt *kit.Kit, opt *typecvm.AzureStartOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "start option is required")
 	}
@@ -402,7 +402,7 @@ func (az *Azure) StartCvm(kt *kit.Kit, opt *typecvm.AzureStartOption) error {
 }
 
 // RebootCvm reference: https://learn.microsoft.com/en-us/rest/api/compute/virtual-machines/restart?tabs=HTTP
-func (az *Azure) RebootCvm(kt *kit.Kit, opt *typecvm.AzureRebootOption) error {
+func (az *AzureImpl) RebootCvm(kt *kit.Kit, opt *typecvm.AzureRebootOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "reboot option is required")
 	}
@@ -432,7 +432,7 @@ func (az *Azure) RebootCvm(kt *kit.Kit, opt *typecvm.AzureRebootOption) error {
 }
 
 // StopCvm reference: https://learn.microsoft.com/en-us/rest/api/compute/virtual-machines/restart?tabs=HTTP
-func (az *Azure) StopCvm(kt *kit.Kit, opt *typecvm.AzureStopOption) error {
+func (az *AzureImpl) StopCvm(kt *kit.Kit, opt *typecvm.AzureStopOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "stop option is required")
 	}
@@ -463,7 +463,7 @@ func (az *Azure) StopCvm(kt *kit.Kit, opt *typecvm.AzureStopOption) error {
 }
 
 // CreateCvm reference: https://learn.microsoft.com/en-us/rest/api/compute/virtual-machines/create-or-update?tabs=HTTP
-func (az *Azure) CreateCvm(kt *kit.Kit, opt *typecvm.AzureCreateOption) (string, error) {
+func (az *AzureImpl) CreateCvm(kt *kit.Kit, opt *typecvm.AzureCreateOption) (string, error) {
 	if opt == nil {
 		return "", errf.New(errf.InvalidParameter, "create option is required")
 	}
@@ -559,6 +559,7 @@ func (az *Azure) CreateCvm(kt *kit.Kit, opt *typecvm.AzureCreateOption) (string,
 	if len(opt.Zones) != 0 {
 		instance.Zones = to.SliceOfPtrs(opt.Zones...)
 	}
+	instance.Tags = operatorTags(kt)
 	poller, err := client.BeginCreateOrUpdate(kt.Ctx, opt.ResourceGroupName, opt.Name, instance, nil)
 	if err != nil {
 		logs.Errorf("begin create cvm failed, err: %v, rid: %s", err, kt.Rid)
@@ -576,7 +577,7 @@ func (az *Azure) CreateCvm(kt *kit.Kit, opt *typecvm.AzureCreateOption) (string,
 
 // GetCvm 查询单个 cvm
 // reference: https://learn.microsoft.com/en-us/rest/api/compute/virtual-machines/get?tabs=Go
-func (az *Azure) GetCvm(kt *kit.Kit, opt *typecvm.AzureGetOption) (*typecvm.AzureCvm, error) {
+func (az *AzureImpl) GetCvm(kt *kit.Kit, opt *typecvm.AzureGetOption) (*typecvm.AzureCvm, error) {
 	if opt == nil {
 		return nil, errf.New(errf.InvalidParameter, "get option is required")
 	}
@@ -609,7 +610,7 @@ func (az *Azure) GetCvm(kt *kit.Kit, opt *typecvm.AzureGetOption) (*typecvm.Azur
 }
 
 // GetCvmStatus https://learn.microsoft.com/en-us/rest/api/compute/virtual-machines/instance-view?tabs=HTTP#code-try-0
-func (az *Azure) GetCvmStatus(kt *kit.Kit, resGroupName, cvmName string) (string, error) {
+func (az *AzureImpl) GetCvmStatus(kt *kit.Kit, resGroupName, cvmName string) (string, error) {
 
 	client, err := az.clientSet.virtualMachineClient()
 	if err != nil {
@@ -26,6 +26,8 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/tidwall/gjson"
 
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
 	"hcm/pkg/logs"
 )
 
@@ -61,8 +63,37 @@ func errorf(azureError error) error {
 			return azureErr
 		}
 
-		return errors.New(message)
+		return &errf.ErrorF{
+			Code:    errf.CloudVendorError,
+			Message: message,
+			SubCode: azureErr.ErrorCode,
+			Details: &errf.Details{Vendor: string(enumor.Azure), CloudRequestID: azureRequestID(azureErr)},
+		}
 	} else {
 		return azureErr
 	}
 }
+
+// ParseError parse the azure sdk error to cloud vendor error with the error code and request id, the error message
+// is not readable, use errorf to convert the error returned by azure sdk if possible.
+func ParseError(err error) *errf.ErrorF {
+	var azureErr *azcore.ResponseError
+	if !errors.As(err, &azureErr) {
+		return nil
+	}
+
+	return &errf.ErrorF{
+		Code:    errf.CloudVendorError,
+		Message: azureErr.Error(),
+		SubCode: azureErr.ErrorCode,
+		Details: &errf.Details{Vendor: string(enumor.Azure), CloudRequestID: azureRequestID(azureErr)},
+	}
+}
+
+func azureRequestID(azureErr *azcore.ResponseError) string {
+	if azureErr.RawResponse == nil {
+		return ""
+	}
+
+	return azureErr.RawResponse.Header.Get("x-ms-request-id")
+}
@@ -34,7 +34,7 @@ import (
 
 // ListImage 查询公共镜像列表
 // reference: https://learn.microsoft.com/en-us/rest/api/compute/virtual-machine-images/list?tabs=HTTP
-func (az *Azure) ListImage(kt *kit.Kit,
+func (az *AzureImpl) ListImage(kt *kit.Kit,
 	opt *image.AzureImageListOption) (*image.AzureImageListResult, error) {
 
 	client, err := az.clientSet.imageClient()
@@ -0,0 +1,41 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"hcm/pkg/adaptor/operatortag"
+	"hcm/pkg/kit"
+)
+
+// operatorTags 返回请求的操作人标签对应的azure资源标签，未开启操作人标签时返回nil
+func operatorTags(kt *kit.Kit) map[string]*string {
+	pairs := operatortag.Pairs(kt)
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	tags := make(map[string]*string, len(pairs))
+	for _, pair := range pairs {
+		value := pair.Value
+		tags[pair.Key] = &value
+	}
+
+	return tags
+}
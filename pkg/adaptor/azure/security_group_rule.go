@@ -34,7 +34,7 @@ import (
 
 // CreateSecurityGroupRule create security group rule.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/network-security-groups/create-or-update
-func (az *Azure) CreateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AzureCreateOption) (
+func (az *AzureImpl) CreateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AzureCreateOption) (
 	[]*securitygrouprule.AzureSGRule, error) {
 
 	if opt == nil {
@@ -155,7 +155,7 @@ func convSecurityGroupRule(direction armnetwork.SecurityRuleDirection, rules []s
 
 // UpdateSecurityGroupRule update security group rule.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/network-security-groups/create-or-update
-func (az *Azure) UpdateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AzureUpdateOption) error {
+func (az *AzureImpl) UpdateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AzureUpdateOption) error {
 
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "security group rule update option is required")
@@ -256,7 +256,7 @@ func modifySgRule(sg *securitygroup.AzureSecurityGroup, opt *securitygrouprule.A
 
 // DeleteSecurityGroupRule delete security group rule.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/network-security-groups/create-or-update
-func (az *Azure) DeleteSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AzureDeleteOption) error {
+func (az *AzureImpl) DeleteSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AzureDeleteOption) error {
 
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "security group rule delete option is required")
@@ -314,7 +314,7 @@ func (az *Azure) DeleteSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.Azu
 
 // ListSecurityGroupRule list security group rule.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/network-security-groups/list-all
-func (az *Azure) ListSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AzureListOption) ([]*securitygrouprule.AzureSGRule,
+func (az *AzureImpl) ListSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AzureListOption) ([]*securitygrouprule.AzureSGRule,
 	error) {
 
 	if opt == nil {
@@ -338,7 +338,7 @@ func (az *Azure) ListSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.Azure
 	return securityRules, nil
 }
 
-func (az *Azure) converCloudToSecurityRule(cloud *armnetwork.SecurityRule) *securitygrouprule.AzureSGRule {
+func (az *AzureImpl) converCloudToSecurityRule(cloud *armnetwork.SecurityRule) *securitygrouprule.AzureSGRule {
 	return &securitygrouprule.AzureSGRule{
 		ID:                                   SPtrToLowerSPtr(cloud.ID),
 		Etag:                                 cloud.Etag,
@@ -0,0 +1,39 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package adaptor
+
+import (
+	"hcm/pkg/adaptor/aws"
+	"hcm/pkg/adaptor/azure"
+	"hcm/pkg/adaptor/gcp"
+	"hcm/pkg/adaptor/huawei"
+	"hcm/pkg/adaptor/tcloud"
+	"hcm/pkg/criteria/errf"
+)
+
+// RegisterErrorParsers register the parsers of the cloud sdk errors, so that the error code and request id returned
+// by the vendor are kept in the response error as sub code and details.
+func RegisterErrorParsers() {
+	errf.RegisterParser(tcloud.ParseError)
+	errf.RegisterParser(aws.ParseError)
+	errf.RegisterParser(huawei.ParseError)
+	errf.RegisterParser(gcp.ParseError)
+	errf.RegisterParser(azure.ParseError)
+}
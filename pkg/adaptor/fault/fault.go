@@ -0,0 +1,224 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package fault 云上接口的故障注入，按配置的比例对云厂商SDK的http请求增加延迟、返回网络错误或限频错误，用于在非生产环境
+// 验证云厂商故障时同步重试、熔断、任务恢复等逻辑。故障注入仅在debug版本中生效，非debug版本不会包装SDK的transport。
+// 目前支持腾讯云、aws、azure的SDK。
+package fault
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/logs"
+	"hcm/pkg/version"
+)
+
+// injector is the evaluated fault injection setting.
+type injector struct {
+	// vendors is the vendors to inject faults, empty means all vendors.
+	vendors      map[enumor.Vendor]struct{}
+	errorRate    float64
+	throttleRate float64
+	latencyRate  float64
+	latency      time.Duration
+}
+
+var active atomic.Pointer[injector]
+
+var defaultRandom = rand.Float64
+
+// random returns a number in [0, 1) to decide whether to inject a fault, it is replaced in tests.
+var random = defaultRandom
+
+// Init set the fault injection setting getter, the setting is reloaded once the settings are changed. It is
+// ignored in non-debug builds, so that faults are never injected into production.
+func Init(getter func() cc.FaultInjection) {
+	if !Available() {
+		if getter().Enable {
+			logs.Warnf("fault injection is enabled, but it is ignored in non-debug build")
+		}
+		return
+	}
+
+	load(getter())
+	cc.OnChange(func() {
+		load(getter())
+	})
+}
+
+func load(conf cc.FaultInjection) {
+	if !conf.Enable {
+		active.Store(nil)
+		return
+	}
+
+	inj := &injector{
+		vendors:      make(map[enumor.Vendor]struct{}, len(conf.Vendors)),
+		errorRate:    conf.ErrorRate,
+		throttleRate: conf.ThrottleRate,
+		latencyRate:  conf.LatencyRate,
+		latency:      time.Duration(conf.LatencyMS) * time.Millisecond,
+	}
+	for _, vendor := range conf.Vendors {
+		inj.vendors[enumor.Vendor(vendor)] = struct{}{}
+	}
+	active.Store(inj)
+
+	logs.Warnf("fault injection is enabled, vendors: %v, error rate: %v, throttle rate: %v, latency rate: %v, "+
+		"latency: %s", conf.Vendors, conf.ErrorRate, conf.ThrottleRate, conf.LatencyRate, inj.latency)
+}
+
+// Available returns whether the fault injection can be enabled, which is only true in debug builds.
+func Available() bool {
+	return version.Debug()
+}
+
+// Transport returns the round tripper which injects faults into the requests of the vendor before they are sent
+// by next, next is returned directly in non-debug builds. nil next means http.DefaultTransport.
+func Transport(vendor enumor.Vendor, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	if !Available() {
+		return next
+	}
+
+	return &transport{vendor: vendor, next: next}
+}
+
+type transport struct {
+	vendor enumor.Vendor
+	next   http.RoundTripper
+}
+
+// RoundTrip inject the fault decided by the current setting, the request is sent by next if no fault is injected.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	inj := active.Load()
+	if inj == nil {
+		return t.next.RoundTrip(req)
+	}
+
+	if len(inj.vendors) != 0 {
+		if _, ok := inj.vendors[t.vendor]; !ok {
+			return t.next.RoundTrip(req)
+		}
+	}
+
+	if inj.latency > 0 && random() < inj.latencyRate {
+		if err := sleep(req.Context(), inj.latency); err != nil {
+			closeBody(req)
+			return nil, err
+		}
+	}
+
+	dice := random()
+	switch {
+	case dice < inj.errorRate:
+		logs.V(3).Infof("fault injection returns connection error for %s request %s %s", t.vendor, req.Method,
+			req.URL.Path)
+		closeBody(req)
+		return nil, fmt.Errorf("fault injected, %w", syscall.ECONNRESET)
+
+	case dice < inj.errorRate+inj.throttleRate:
+		logs.V(3).Infof("fault injection returns throttle response for %s request %s %s", t.vendor, req.Method,
+			req.URL.Path)
+		closeBody(req)
+		return throttleResponse(t.vendor, req), nil
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// closeBody close the request body which is not sent, as the round tripper is responsible for closing it.
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
+
+const throttleMessage = "fault injected, request limit exceeded"
+
+// throttleResponse returns the response which is parsed as the rate limit error by the sdk of the vendor, so that
+// the retry logic of the sdk and hcm is triggered as if the vendor is throttling the requests.
+func throttleResponse(vendor enumor.Vendor, req *http.Request) *http.Response {
+	code := http.StatusTooManyRequests
+	header := make(http.Header)
+	var body string
+
+	switch vendor {
+	case enumor.TCloud:
+		// 腾讯云接口的错误通过http 200的响应体返回
+		code = http.StatusOK
+		header.Set("Content-Type", "application/json")
+		body = fmt.Sprintf(`{"Response":{"Error":{"Code":"RequestLimitExceeded","Message":"%s"},`+
+			`"RequestId":"fault-injection"}}`, throttleMessage)
+
+	case enumor.Aws:
+		// 使用ec2的query协议的错误格式，同步使用的接口大多为该协议
+		code = http.StatusServiceUnavailable
+		header.Set("Content-Type", "text/xml;charset=UTF-8")
+		body = fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?><Response><Errors><Error>`+
+			`<Code>RequestLimitExceeded</Code><Message>%s</Message></Error></Errors>`+
+			`<RequestID>fault-injection</RequestID></Response>`, throttleMessage)
+
+	case enumor.Azure:
+		header.Set("Content-Type", "application/json")
+		header.Set("Retry-After", "1")
+		body = fmt.Sprintf(`{"error":{"code":"TooManyRequests","message":"%s"}}`, throttleMessage)
+
+	default:
+		header.Set("Content-Type", "text/plain")
+		body = throttleMessage
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", code, http.StatusText(code)),
+		StatusCode:    code,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package fault
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/version"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	version.DEBUG = "false"
+	assert.Equal(t, http.DefaultTransport, Transport(enumor.TCloud, nil), "no injection in non-debug build")
+
+	version.DEBUG = "true"
+	defer func() {
+		version.DEBUG = "false"
+		active.Store(nil)
+		random = defaultRandom
+	}()
+
+	dice := 0.0
+	random = func() float64 { return dice }
+	client := &http.Client{Transport: Transport(enumor.TCloud, nil)}
+	get := func() (*http.Response, error) {
+		return client.Get(server.URL)
+	}
+
+	// disabled
+	resp, err := get()
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, 1, calls)
+
+	load(cc.FaultInjection{Enable: true, ErrorRate: 0.2, ThrottleRate: 0.3})
+
+	dice = 0.1
+	_, err = get()
+	assert.True(t, errors.Is(err, syscall.ECONNRESET))
+
+	dice = 0.4
+	resp, err = get()
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), "RequestLimitExceeded")
+
+	dice = 0.6
+	resp, err = get()
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, 2, calls)
+
+	// vendor not matched
+	load(cc.FaultInjection{Enable: true, Vendors: []string{string(enumor.Aws)}, ErrorRate: 1})
+	dice = 0
+	resp, err = get()
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, 3, calls)
+}
+
+func TestLatency(t *testing.T) {
+	version.DEBUG = "true"
+	defer func() {
+		version.DEBUG = "false"
+		active.Store(nil)
+		random = defaultRandom
+	}()
+
+	random = func() float64 { return 0.5 }
+	load(cc.FaultInjection{Enable: true, LatencyRate: 1, LatencyMS: 1000})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://127.0.0.1:1", nil)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = Transport(enumor.Azure, nil).RoundTrip(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestThrottleResponse(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+
+	resp := throttleResponse(enumor.Aws, req)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	resp = throttleResponse(enumor.Azure, req)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get("Retry-After"))
+}
@@ -74,7 +74,7 @@ const (
 )
 
 // GetBillList demonstrates issuing a query and reading results.
-func (g *Gcp) GetBillList(kt *kit.Kit, opt *typesBill.GcpBillListOption,
+func (g *GcpImpl) GetBillList(kt *kit.Kit, opt *typesBill.GcpBillListOption,
 	billInfo *cloud.AccountBillConfig[cloud.GcpBillConfigExtension]) (interface{}, int64, error) {
 
 	where, err := g.parseCondition(opt)
@@ -105,7 +105,7 @@ func (g *Gcp) GetBillList(kt *kit.Kit, opt *typesBill.GcpBillListOption,
 }
 
 // GetBillTotal get bill total num
-func (g *Gcp) GetBillTotal(kt *kit.Kit, where string, billInfo *cloud.AccountBillConfig[cloud.GcpBillConfigExtension]) (
+func (g *GcpImpl) GetBillTotal(kt *kit.Kit, where string, billInfo *cloud.AccountBillConfig[cloud.GcpBillConfigExtension]) (
 	int64, error) {
 
 	sql := fmt.Sprintf(QueryBillTotalSQL, billInfo.CloudDatabaseName, billInfo.CloudTableName, where)
@@ -183,7 +183,7 @@ FROM (
 )
 
 // GetRootAccountBillTotal get bill total num
-func (g *Gcp) GetRootAccountBillTotal(
+func (g *GcpImpl) GetRootAccountBillTotal(
 	kt *kit.Kit, where string, billInfo *billcore.RootAccountBillConfig[billcore.GcpBillConfigExtension]) (
 	int64, error) {
 
@@ -196,7 +196,7 @@ func (g *Gcp) GetRootAccountBillTotal(
 }
 
 // QueryRootCreditList query credits list.
-func (g *Gcp) QueryRootCreditList(kt *kit.Kit, opt *typesBill.GcpRootAccountBillListOption,
+func (g *GcpImpl) QueryRootCreditList(kt *kit.Kit, opt *typesBill.GcpRootAccountBillListOption,
 	billInfo *billcore.RootAccountBillConfig[billcore.GcpBillConfigExtension]) (interface{}, error) {
 
 	conditionOpt := &typesBill.GcpBillListOption{
@@ -224,7 +224,7 @@ func (g *Gcp) QueryRootCreditList(kt *kit.Kit, opt *typesBill.GcpRootAccountBill
 }
 
 // GetRootAccountBillList demonstrates issuing a query and reading results.
-func (g *Gcp) GetRootAccountBillList(kt *kit.Kit, opt *typesBill.GcpRootAccountBillListOption,
+func (g *GcpImpl) GetRootAccountBillList(kt *kit.Kit, opt *typesBill.GcpRootAccountBillListOption,
 	billInfo *billcore.RootAccountBillConfig[billcore.GcpBillConfigExtension]) (interface{}, int64, error) {
 
 	conditionOpt := &typesBill.GcpBillListOption{
@@ -264,7 +264,7 @@ func (g *Gcp) GetRootAccountBillList(kt *kit.Kit, opt *typesBill.GcpRootAccountB
 	return list, total, err
 }
 
-func (g *Gcp) GetBigQuery(kt *kit.Kit, query string) ([]map[string]bigquery.Value, int64, error) {
+func (g *GcpImpl) GetBigQuery(kt *kit.Kit, query string) ([]map[string]bigquery.Value, int64, error) {
 	client, err := g.clientSet.bigQueryClient(kt)
 	if err != nil {
 		return nil, 0, fmt.Errorf("gcp.billquery.NewClient, err: %+v", err)
@@ -307,7 +307,7 @@ func (g *Gcp) GetBigQuery(kt *kit.Kit, query string) ([]map[string]bigquery.Valu
 	return list, num, nil
 }
 
-func (g *Gcp) parseCondition(opt *typesBill.GcpBillListOption) (string, error) {
+func (g *GcpImpl) parseCondition(opt *typesBill.GcpBillListOption) (string, error) {
 	var condition []string
 	if len(opt.ProjectID) != 0 {
 		condition = []string{fmt.Sprintf("project.id = '%s'", opt.ProjectID)}
@@ -335,7 +335,7 @@ func (g *Gcp) parseCondition(opt *typesBill.GcpBillListOption) (string, error) {
 	return "", nil
 }
 
-func (g *Gcp) parseRootAccountCondition(opt *typesBill.GcpBillListOption) (string, error) {
+func (g *GcpImpl) parseRootAccountCondition(opt *typesBill.GcpBillListOption) (string, error) {
 	var condition []string
 	if len(opt.ProjectID) != 0 {
 		condition = []string{fmt.Sprintf("project.id = '%s'", opt.ProjectID)}
@@ -366,7 +366,7 @@ func (g *Gcp) parseRootAccountCondition(opt *typesBill.GcpBillListOption) (strin
 
 	return "", nil
 }
-func (g *Gcp) parseRootCreditCondition(opt *typesBill.GcpBillListOption) (string, error) {
+func (g *GcpImpl) parseRootCreditCondition(opt *typesBill.GcpBillListOption) (string, error) {
 	var condition []string
 	if len(opt.ProjectID) != 0 {
 		if opt.ProjectID == "NULL" {
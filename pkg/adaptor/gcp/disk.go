@@ -25,7 +25,9 @@ import (
 	"strings"
 	"time"
 
+	"hcm/pkg/adaptor/operatortag"
 	"hcm/pkg/adaptor/poller"
+	"hcm/pkg/adaptor/types/core"
 	"hcm/pkg/adaptor/types/disk"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
@@ -38,7 +40,7 @@ import (
 
 // CreateDisk 创建云硬盘
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/disks/insert
-func (g *Gcp) CreateDisk(kt *kit.Kit, opt *disk.GcpDiskCreateOption) (*poller.BaseDoneResult, error) {
+func (g *GcpImpl) CreateDisk(kt *kit.Kit, opt *disk.GcpDiskCreateOption) (*poller.BaseDoneResult, error) {
 	if opt == nil {
 		return nil, errf.New(errf.InvalidParameter, "gcp disk create option is required")
 	}
@@ -78,13 +80,13 @@ func (g *Gcp) CreateDisk(kt *kit.Kit, opt *disk.GcpDiskCreateOption) (*poller.Ba
 		}
 	}
 
-	respPoller := poller.Poller[*Gcp, []disk.GcpDisk, poller.BaseDoneResult]{
+	respPoller := poller.Poller[*GcpImpl, []disk.GcpDisk, poller.BaseDoneResult]{
 		Handler: &createDiskPollingHandler{Zone: opt.Zone},
 	}
 	return respPoller.PollUntilDone(g, kt, converter.SliceToPtr(diskCloudIDs), nil)
 }
 
-func (g *Gcp) createDisk(kt *kit.Kit, opt *disk.GcpDiskCreateOption) (*compute.Operation, error) {
+func (g *GcpImpl) createDisk(kt *kit.Kit, opt *disk.GcpDiskCreateOption) (*compute.Operation, error) {
 	client, err := g.clientSet.computeClient(kt)
 	if err != nil {
 		return nil, err
@@ -95,6 +97,7 @@ func (g *Gcp) createDisk(kt *kit.Kit, opt *disk.GcpDiskCreateOption) (*compute.O
 	if err != nil {
 		return nil, err
 	}
+	req.Labels = operatortag.GcpLabels(kt, req.Labels)
 
 	var call *compute.DisksInsertCall
 	call = client.Disks.Insert(cloudProjectID, opt.Zone, req).Context(kt.Ctx)
@@ -103,7 +106,7 @@ func (g *Gcp) createDisk(kt *kit.Kit, opt *disk.GcpDiskCreateOption) (*compute.O
 
 // ListDisk 查看云硬盘
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/disks/list
-func (g *Gcp) ListDisk(kt *kit.Kit, opt *disk.GcpDiskListOption) ([]disk.GcpDisk, string, error) {
+func (g *GcpImpl) ListDisk(kt *kit.Kit, opt *disk.GcpDiskListOption) ([]disk.GcpDisk, string, error) {
 	if opt == nil {
 		return nil, "", errf.New(errf.InvalidParameter, "gcp disk list option is required")
 	}
@@ -156,7 +159,7 @@ func (g *Gcp) ListDisk(kt *kit.Kit, opt *disk.GcpDiskListOption) ([]disk.GcpDisk
 
 // CountDisk count disk
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/disks/list
-func (g *Gcp) CountDisk(kt *kit.Kit) (int32, error) {
+func (g *GcpImpl) CountDisk(kt *kit.Kit) (int32, error) {
 
 	client, err := g.clientSet.computeClient(kt)
 	if err != nil {
@@ -164,22 +167,28 @@ func (g *Gcp) CountDisk(kt *kit.Kit) (int32, error) {
 	}
 
 	request := client.Disks.AggregatedList(g.CloudProjectID()).Context(kt.Ctx)
-
-	var count int32
-	for {
-		resp, err := request.Do()
+	list := func(_ *kit.Kit, page *core.GcpPage) ([]compute.DisksScopedList, string, error) {
+		resp, err := request.MaxResults(page.PageSize).PageToken(page.PageToken).Do()
 		if err != nil {
-			logs.Errorf("list disk failed, err: %v, rid: %s", err, kt.Rid)
-			return 0, err
+			return nil, "", err
 		}
 
+		scoped := make([]compute.DisksScopedList, 0, len(resp.Items))
 		for _, one := range resp.Items {
-			count += int32(len(one.Disks))
+			scoped = append(scoped, one)
 		}
+		return scoped, resp.NextPageToken, nil
+	}
+	pages := core.NewGcpIterator(core.GcpQueryLimit, list, nil)
 
-		if resp.NextPageToken == "" {
-			break
-		}
+	var count int32
+	err = pages.ForEach(kt, func(one compute.DisksScopedList) error {
+		count += int32(len(one.Disks))
+		return nil
+	})
+	if err != nil {
+		logs.Errorf("list disk failed, err: %v, rid: %s", err, kt.Rid)
+		return 0, err
 	}
 
 	return count, nil
@@ -187,7 +196,7 @@ func (g *Gcp) CountDisk(kt *kit.Kit) (int32, error) {
 
 // DeleteDisk 删除云盘
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/disks/delete
-func (g *Gcp) DeleteDisk(kt *kit.Kit, opt *disk.GcpDiskDeleteOption) error {
+func (g *GcpImpl) DeleteDisk(kt *kit.Kit, opt *disk.GcpDiskDeleteOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "gcp disk delete option is required")
 	}
@@ -207,7 +216,7 @@ func (g *Gcp) DeleteDisk(kt *kit.Kit, opt *disk.GcpDiskDeleteOption) error {
 
 // AttachDisk 挂载云盘
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/instances/attachDisk
-func (g *Gcp) AttachDisk(kt *kit.Kit, opt *disk.GcpDiskAttachOption) error {
+func (g *GcpImpl) AttachDisk(kt *kit.Kit, opt *disk.GcpDiskAttachOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "gcp disk attach option is required")
 	}
@@ -234,7 +243,7 @@ func (g *Gcp) AttachDisk(kt *kit.Kit, opt *disk.GcpDiskAttachOption) error {
 	handler := &attachDiskPollingHandler{
 		opt.Zone,
 	}
-	respPoller := poller.Poller[*Gcp, []disk.GcpDisk, []uint64]{Handler: handler}
+	respPoller := poller.Poller[*GcpImpl, []disk.GcpDisk, []uint64]{Handler: handler}
 	_, err = respPoller.PollUntilDone(g, kt, []*string{to.Ptr(opt.DiskName)}, nil)
 	if err != nil {
 		return err
@@ -245,7 +254,7 @@ func (g *Gcp) AttachDisk(kt *kit.Kit, opt *disk.GcpDiskAttachOption) error {
 
 // DetachDisk 卸载云盘
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/instances/detachDisk
-func (g *Gcp) DetachDisk(kt *kit.Kit, opt *disk.GcpDiskDetachOption) error {
+func (g *GcpImpl) DetachDisk(kt *kit.Kit, opt *disk.GcpDiskDetachOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "gcp disk detach option is required")
 	}
@@ -270,7 +279,7 @@ func (g *Gcp) DetachDisk(kt *kit.Kit, opt *disk.GcpDiskDetachOption) error {
 	handler := &detachDiskPollingHandler{
 		opt.Zone,
 	}
-	respPoller := poller.Poller[*Gcp, []disk.GcpDisk, []uint64]{Handler: handler}
+	respPoller := poller.Poller[*GcpImpl, []disk.GcpDisk, []uint64]{Handler: handler}
 	_, err = respPoller.PollUntilDone(g, kt, []*string{to.Ptr(opt.DiskName)}, nil)
 	if err != nil {
 		return err
@@ -279,7 +288,7 @@ func (g *Gcp) DetachDisk(kt *kit.Kit, opt *disk.GcpDiskDetachOption) error {
 	return nil
 }
 
-func (g *Gcp) getDiskCloudID(kt *kit.Kit, zone string, diskName string) (*string, error) {
+func (g *GcpImpl) getDiskCloudID(kt *kit.Kit, zone string, diskName string) (*string, error) {
 	client, err := g.clientSet.computeClient(kt)
 	if err != nil {
 		return nil, err
@@ -332,7 +341,7 @@ func (h *createDiskPollingHandler) Done(pollResult []disk.GcpDisk) (bool, *polle
 }
 
 // Poll ...
-func (h *createDiskPollingHandler) Poll(client *Gcp, kt *kit.Kit, cloudIDs []*string) ([]disk.GcpDisk, error) {
+func (h *createDiskPollingHandler) Poll(client *GcpImpl, kt *kit.Kit, cloudIDs []*string) ([]disk.GcpDisk, error) {
 	cIDs := converter.PtrToSlice(cloudIDs)
 	result, _, err := client.ListDisk(
 		kt,
@@ -341,7 +350,7 @@ func (h *createDiskPollingHandler) Poll(client *Gcp, kt *kit.Kit, cloudIDs []*st
 	return result, err
 }
 
-var _ poller.PollingHandler[*Gcp, []disk.GcpDisk, poller.BaseDoneResult] = new(createDiskPollingHandler)
+var _ poller.PollingHandler[*GcpImpl, []disk.GcpDisk, poller.BaseDoneResult] = new(createDiskPollingHandler)
 
 type attachDiskPollingHandler struct {
 	zone string
@@ -353,7 +362,7 @@ func (h *attachDiskPollingHandler) Done(items []disk.GcpDisk) (bool, *[]uint64)
 }
 
 // Poll ...
-func (h *attachDiskPollingHandler) Poll(client *Gcp, kt *kit.Kit, names []*string) ([]disk.GcpDisk, error) {
+func (h *attachDiskPollingHandler) Poll(client *GcpImpl, kt *kit.Kit, names []*string) ([]disk.GcpDisk, error) {
 	return diskPoll(client, kt, h.zone, names)
 }
 
@@ -367,7 +376,7 @@ func (h *detachDiskPollingHandler) Done(items []disk.GcpDisk) (bool, *[]uint64)
 }
 
 // Poll ...
-func (h *detachDiskPollingHandler) Poll(client *Gcp, kt *kit.Kit, names []*string) ([]disk.GcpDisk, error) {
+func (h *detachDiskPollingHandler) Poll(client *GcpImpl, kt *kit.Kit, names []*string) ([]disk.GcpDisk, error) {
 	return diskPoll(client, kt, h.zone, names)
 }
 
@@ -386,7 +395,7 @@ func diskDone(disks []disk.GcpDisk) (bool, *[]uint64) {
 	return flag, converter.ValToPtr(results)
 }
 
-func diskPoll(client *Gcp, kt *kit.Kit, zone string, names []*string) ([]disk.GcpDisk, error) {
+func diskPoll(client *GcpImpl, kt *kit.Kit, zone string, names []*string) ([]disk.GcpDisk, error) {
 	listNames := converter.PtrToSlice(names)
 	result, _, err := client.ListDisk(
 		kt,
@@ -22,7 +22,9 @@ package gcp
 import (
 	"strconv"
 
+	"hcm/pkg/adaptor/operatortag"
 	"hcm/pkg/adaptor/poller"
+	"hcm/pkg/adaptor/types/core"
 	"hcm/pkg/adaptor/types/eip"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
@@ -36,7 +38,7 @@ import (
 // ListEip ...
 // reference: global address reference: https://cloud.google.com/compute/docs/reference/rest/v1/globalAddresses/list
 // reference: regional address reference: https://cloud.google.com/compute/docs/reference/rest/v1/addresses/list
-func (g *Gcp) ListEip(kt *kit.Kit, opt *eip.GcpEipListOption) (*eip.GcpEipListResult, error) {
+func (g *GcpImpl) ListEip(kt *kit.Kit, opt *eip.GcpEipListOption) (*eip.GcpEipListResult, error) {
 	if err := opt.Validate(); err != nil {
 		return nil, err
 	}
@@ -92,7 +94,7 @@ func (g *Gcp) ListEip(kt *kit.Kit, opt *eip.GcpEipListOption) (*eip.GcpEipListRe
 
 // CountEip count eip.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/addresses/aggregatedList
-func (g *Gcp) CountEip(kt *kit.Kit) (int32, error) {
+func (g *GcpImpl) CountEip(kt *kit.Kit) (int32, error) {
 
 	client, err := g.clientSet.computeClient(kt)
 	if err != nil {
@@ -100,22 +102,28 @@ func (g *Gcp) CountEip(kt *kit.Kit) (int32, error) {
 	}
 
 	request := client.Addresses.AggregatedList(g.CloudProjectID()).Context(kt.Ctx)
-
-	var count int32
-	for {
-		resp, err := request.Do()
+	list := func(_ *kit.Kit, page *core.GcpPage) ([]compute.AddressesScopedList, string, error) {
+		resp, err := request.MaxResults(page.PageSize).PageToken(page.PageToken).Do()
 		if err != nil {
-			logs.Errorf("list eip failed, err: %v, rid: %s", err, kt.Rid)
-			return 0, err
+			return nil, "", err
 		}
 
+		scoped := make([]compute.AddressesScopedList, 0, len(resp.Items))
 		for _, one := range resp.Items {
-			count += int32(len(one.Addresses))
+			scoped = append(scoped, one)
 		}
+		return scoped, resp.NextPageToken, nil
+	}
+	pages := core.NewGcpIterator(core.GcpQueryLimit, list, nil)
 
-		if resp.NextPageToken == "" {
-			break
-		}
+	var count int32
+	err = pages.ForEach(kt, func(one compute.AddressesScopedList) error {
+		count += int32(len(one.Addresses))
+		return nil
+	})
+	if err != nil {
+		logs.Errorf("list eip failed, err: %v, rid: %s", err, kt.Rid)
+		return 0, err
 	}
 
 	return count, nil
@@ -123,7 +131,7 @@ func (g *Gcp) CountEip(kt *kit.Kit) (int32, error) {
 
 // ListAggregatedEip ...
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/addresses/aggregatedList
-func (g *Gcp) ListAggregatedEip(kt *kit.Kit, opt *eip.GcpEipAggregatedListOption) ([]*compute.Address, error) {
+func (g *GcpImpl) ListAggregatedEip(kt *kit.Kit, opt *eip.GcpEipAggregatedListOption) ([]*compute.Address, error) {
 	if err := opt.Validate(); err != nil {
 		return nil, err
 	}
@@ -154,7 +162,7 @@ func (g *Gcp) ListAggregatedEip(kt *kit.Kit, opt *eip.GcpEipAggregatedListOption
 // DeleteEip ...
 // reference: global address reference: https://cloud.google.com/compute/docs/reference/rest/v1/globalAddresses/delete
 // reference: regional address reference: https://cloud.google.com/compute/docs/reference/rest/v1/addresses/delete
-func (g *Gcp) DeleteEip(kt *kit.Kit, opt *eip.GcpEipDeleteOption) error {
+func (g *GcpImpl) DeleteEip(kt *kit.Kit, opt *eip.GcpEipDeleteOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "gcp eip delete option is required")
 	}
@@ -186,7 +194,7 @@ func (g *Gcp) DeleteEip(kt *kit.Kit, opt *eip.GcpEipDeleteOption) error {
 
 // AssociateEip associate eip.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/instances/addAccessConfig
-func (g *Gcp) AssociateEip(kt *kit.Kit, opt *eip.GcpEipAssociateOption) error {
+func (g *GcpImpl) AssociateEip(kt *kit.Kit, opt *eip.GcpEipAssociateOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "gcp eip associate option is required")
 	}
@@ -211,7 +219,7 @@ func (g *Gcp) AssociateEip(kt *kit.Kit, opt *eip.GcpEipAssociateOption) error {
 	handler := &associateEipPollingHandler{
 		opt.Region,
 	}
-	respPoller := poller.Poller[*Gcp, []*eip.GcpEip, []string]{Handler: handler}
+	respPoller := poller.Poller[*GcpImpl, []*eip.GcpEip, []string]{Handler: handler}
 	_, err = respPoller.PollUntilDone(g, kt, []*string{to.Ptr(opt.CloudID)}, nil)
 	if err != nil {
 		return err
@@ -222,7 +230,7 @@ func (g *Gcp) AssociateEip(kt *kit.Kit, opt *eip.GcpEipAssociateOption) error {
 
 // DisassociateEip disassociate eip.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/instances/deleteAccessConfig
-func (g *Gcp) DisassociateEip(kt *kit.Kit, opt *eip.GcpEipDisassociateOption) error {
+func (g *GcpImpl) DisassociateEip(kt *kit.Kit, opt *eip.GcpEipDisassociateOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "gcp eip disassociate option is required")
 	}
@@ -247,7 +255,7 @@ func (g *Gcp) DisassociateEip(kt *kit.Kit, opt *eip.GcpEipDisassociateOption) er
 	handler := &disassociateEipPollingHandler{
 		opt.Region,
 	}
-	respPoller := poller.Poller[*Gcp, []*eip.GcpEip, []string]{Handler: handler}
+	respPoller := poller.Poller[*GcpImpl, []*eip.GcpEip, []string]{Handler: handler}
 	_, err = respPoller.PollUntilDone(g, kt, []*string{to.Ptr(opt.CloudID)}, nil)
 	if err != nil {
 		return err
@@ -259,7 +267,7 @@ func (g *Gcp) DisassociateEip(kt *kit.Kit, opt *eip.GcpEipDisassociateOption) er
 // CreateEip ...
 // reference: regional https://cloud.google.com/compute/docs/reference/rest/v1/addresses/insert
 // reference: global https://cloud.google.com/compute/docs/reference/rest/v1/globalAddresses/insert
-func (g *Gcp) CreateEip(kt *kit.Kit, opt *eip.GcpEipCreateOption) (*poller.BaseDoneResult, error) {
+func (g *GcpImpl) CreateEip(kt *kit.Kit, opt *eip.GcpEipCreateOption) (*poller.BaseDoneResult, error) {
 	if opt == nil {
 		return nil, errf.New(errf.InvalidParameter, "gcp eip create option is required")
 	}
@@ -268,6 +276,7 @@ func (g *Gcp) CreateEip(kt *kit.Kit, opt *eip.GcpEipCreateOption) (*poller.BaseD
 	if err != nil {
 		return nil, err
 	}
+	req.Labels = operatortag.GcpLabels(kt, req.Labels)
 
 	client, err := g.clientSet.computeClient(kt)
 	if err != nil {
@@ -299,12 +308,12 @@ func (g *Gcp) CreateEip(kt *kit.Kit, opt *eip.GcpEipCreateOption) (*poller.BaseD
 		}
 	}
 
-	respPoller := poller.Poller[*Gcp, []*eip.GcpEip,
+	respPoller := poller.Poller[*GcpImpl, []*eip.GcpEip,
 		poller.BaseDoneResult]{Handler: &createEipPollingHandler{region: opt.Region}}
 	return respPoller.PollUntilDone(g, kt, []*string{&cloudID}, nil)
 }
 
-func (g *Gcp) getEip(kt *kit.Kit, region string, eipName string) (*compute.Address, error) {
+func (g *GcpImpl) getEip(kt *kit.Kit, region string, eipName string) (*compute.Address, error) {
 	client, err := g.clientSet.computeClient(kt)
 	if err != nil {
 		return nil, err
@@ -363,7 +372,7 @@ func (h *associateEipPollingHandler) Done(eips []*eip.GcpEip) (bool, *[]string)
 }
 
 // Poll ...
-func (h *associateEipPollingHandler) Poll(client *Gcp, kt *kit.Kit, cloudIDs []*string) ([]*eip.GcpEip, error) {
+func (h *associateEipPollingHandler) Poll(client *GcpImpl, kt *kit.Kit, cloudIDs []*string) ([]*eip.GcpEip, error) {
 	return eipPoll(client, kt, h.region, cloudIDs)
 }
 
@@ -377,7 +386,7 @@ func (h *disassociateEipPollingHandler) Done(eips []*eip.GcpEip) (bool, *[]strin
 }
 
 // Poll ...
-func (h *disassociateEipPollingHandler) Poll(client *Gcp, kt *kit.Kit, cloudIDs []*string) ([]*eip.GcpEip, error) {
+func (h *disassociateEipPollingHandler) Poll(client *GcpImpl, kt *kit.Kit, cloudIDs []*string) ([]*eip.GcpEip, error) {
 	return eipPoll(client, kt, h.region, cloudIDs)
 }
 
@@ -396,7 +405,7 @@ func eipDone(eips []*eip.GcpEip, succeed string) (bool, *[]string) {
 	return flag, converter.ValToPtr(results)
 }
 
-func eipPoll(client *Gcp, kt *kit.Kit, region string, cloudIDs []*string) ([]*eip.GcpEip, error) {
+func eipPoll(client *GcpImpl, kt *kit.Kit, region string, cloudIDs []*string) ([]*eip.GcpEip, error) {
 	cIDs := converter.PtrToSlice(cloudIDs)
 	result, err := client.ListEip(kt, &eip.GcpEipListOption{Region: region, CloudIDs: cIDs})
 	if err != nil {
@@ -435,7 +444,7 @@ func (h *createEipPollingHandler) Done(pollResult []*eip.GcpEip) (bool, *poller.
 }
 
 // Poll ...
-func (h *createEipPollingHandler) Poll(client *Gcp, kt *kit.Kit, cloudIDs []*string) ([]*eip.GcpEip, error) {
+func (h *createEipPollingHandler) Poll(client *GcpImpl, kt *kit.Kit, cloudIDs []*string) ([]*eip.GcpEip, error) {
 	cIDs := converter.PtrToSlice(cloudIDs)
 	result, err := client.ListEip(kt, &eip.GcpEipListOption{Region: h.region, CloudIDs: cIDs})
 	if err != nil {
@@ -445,4 +454,4 @@ func (h *createEipPollingHandler) Poll(client *Gcp, kt *kit.Kit, cloudIDs []*str
 	return result.Details, nil
 }
 
-var _ poller.PollingHandler[*Gcp, []*eip.GcpEip, poller.BaseDoneResult] = new(createEipPollingHandler)
+var _ poller.PollingHandler[*GcpImpl, []*eip.GcpEip, poller.BaseDoneResult] = new(createEipPollingHandler)
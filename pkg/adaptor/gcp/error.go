@@ -0,0 +1,55 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+
+	"google.golang.org/api/googleapi"
+)
+
+// ParseError parse the gcp api error to cloud vendor error, the reason of the first error item is used as the
+// error code, gcp does not return the request id.
+func ParseError(err error) *errf.ErrorF {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return nil
+	}
+
+	subCode := ""
+	if len(apiErr.Errors) != 0 {
+		subCode = apiErr.Errors[0].Reason
+	}
+
+	message := apiErr.Message
+	if len(message) == 0 {
+		message = apiErr.Error()
+	}
+
+	return &errf.ErrorF{
+		Code:    errf.CloudVendorError,
+		Message: message,
+		SubCode: subCode,
+		Details: &errf.Details{Vendor: string(enumor.Gcp)},
+	}
+}
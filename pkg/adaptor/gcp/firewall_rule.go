@@ -20,6 +20,7 @@
 package gcp
 
 import (
+	"hcm/pkg/adaptor/types/core"
 	firewallrule "hcm/pkg/adaptor/types/firewall-rule"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
@@ -31,7 +32,7 @@ import (
 
 // ListFirewallRule list firewall rule.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/firewalls/list
-func (g *Gcp) ListFirewallRule(kt *kit.Kit, opt *firewallrule.ListOption) ([]firewallrule.GcpFirewall, string, error) {
+func (g *GcpImpl) ListFirewallRule(kt *kit.Kit, opt *firewallrule.ListOption) ([]firewallrule.GcpFirewall, string, error) {
 	if opt == nil {
 		return nil, "", errf.New(errf.InvalidParameter, "list option is required")
 	}
@@ -71,7 +72,7 @@ func (g *Gcp) ListFirewallRule(kt *kit.Kit, opt *firewallrule.ListOption) ([]fir
 
 // CountFirewall count firewall rule.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/firewalls/list
-func (g *Gcp) CountFirewall(kt *kit.Kit) (int32, error) {
+func (g *GcpImpl) CountFirewall(kt *kit.Kit) (int32, error) {
 
 	client, err := g.clientSet.computeClient(kt)
 	if err != nil {
@@ -79,20 +80,24 @@ func (g *Gcp) CountFirewall(kt *kit.Kit) (int32, error) {
 	}
 
 	request := client.Firewalls.List(g.CloudProjectID()).Context(kt.Ctx)
-
-	var count int32
-	for {
-		resp, err := request.Do()
+	list := func(_ *kit.Kit, page *core.GcpPage) ([]*compute.Firewall, string, error) {
+		resp, err := request.MaxResults(page.PageSize).PageToken(page.PageToken).Do()
 		if err != nil {
-			logs.Errorf("list firewall failed, err: %v, rid: %s", err, kt.Rid)
-			return 0, err
+			return nil, "", err
 		}
 
-		count += int32(len(resp.Items))
+		return resp.Items, resp.NextPageToken, nil
+	}
+	pages := core.NewGcpIterator(core.GcpQueryLimit, list, nil)
 
-		if resp.NextPageToken == "" {
-			break
-		}
+	var count int32
+	err = pages.ForEach(kt, func(_ *compute.Firewall) error {
+		count++
+		return nil
+	})
+	if err != nil {
+		logs.Errorf("list firewall failed, err: %v, rid: %s", err, kt.Rid)
+		return 0, err
 	}
 
 	return count, nil
@@ -100,7 +105,7 @@ func (g *Gcp) CountFirewall(kt *kit.Kit) (int32, error) {
 
 // UpdateFirewallRule update firewall rule.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/firewalls/patch
-func (g *Gcp) UpdateFirewallRule(kt *kit.Kit, opt *firewallrule.UpdateOption) error {
+func (g *GcpImpl) UpdateFirewallRule(kt *kit.Kit, opt *firewallrule.UpdateOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "update option is required")
 	}
@@ -157,7 +162,7 @@ func (g *Gcp) UpdateFirewallRule(kt *kit.Kit, opt *firewallrule.UpdateOption) er
 
 // DeleteFirewallRule delete firewall rule.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/firewalls/delete
-func (g *Gcp) DeleteFirewallRule(kt *kit.Kit, opt *firewallrule.DeleteOption) error {
+func (g *GcpImpl) DeleteFirewallRule(kt *kit.Kit, opt *firewallrule.DeleteOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "delete option is required")
 	}
@@ -181,7 +186,7 @@ func (g *Gcp) DeleteFirewallRule(kt *kit.Kit, opt *firewallrule.DeleteOption) er
 
 // CreateFirewallRule create firewall rule.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/firewalls/patch
-func (g *Gcp) CreateFirewallRule(kt *kit.Kit, opt *firewallrule.CreateOption) (uint64, error) {
+func (g *GcpImpl) CreateFirewallRule(kt *kit.Kit, opt *firewallrule.CreateOption) (uint64, error) {
 	if opt == nil {
 		return 0, errf.New(errf.InvalidParameter, "create option is required")
 	}
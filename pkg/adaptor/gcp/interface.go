@@ -0,0 +1,123 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+//go:generate  mockgen -destination ../mock/gcp/gcp_mock.go  -package=mockgcp -typed -source=interface.go
+
+package gcp
+
+import (
+	"hcm/pkg/adaptor/poller"
+	"hcm/pkg/adaptor/types"
+	typeaccount "hcm/pkg/adaptor/types/account"
+	typesBill "hcm/pkg/adaptor/types/bill"
+	"hcm/pkg/adaptor/types/core"
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/adaptor/types/disk"
+	"hcm/pkg/adaptor/types/eip"
+	firewallrule "hcm/pkg/adaptor/types/firewall-rule"
+	"hcm/pkg/adaptor/types/image"
+	typesinstancetype "hcm/pkg/adaptor/types/instance-type"
+	typesniproto "hcm/pkg/adaptor/types/network-interface"
+	typesRegion "hcm/pkg/adaptor/types/region"
+	routetable "hcm/pkg/adaptor/types/route-table"
+	typessubnet "hcm/pkg/adaptor/types/subnet"
+	typeszone "hcm/pkg/adaptor/types/zone"
+	billcore "hcm/pkg/api/core/bill"
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/kit"
+
+	"cloud.google.com/go/bigquery"
+	compute "google.golang.org/api/compute/v1"
+)
+
+// Gcp adaptor interface for gcp cloud
+type Gcp interface {
+	CountAccount(kt *kit.Kit) (int32, error)
+	ListAccount(kt *kit.Kit) ([]typeaccount.GcpAccount, error)
+	GetProjectRegionQuota(kt *kit.Kit, opt *typeaccount.GcpProjectRegionQuotaOption) (
+		*typeaccount.GcpProjectQuota, error)
+	GetAccountInfoBySecret(kit *kit.Kit, cloudSecretKeyString string) (*cloud.GcpInfoBySecret, error)
+	GetBillList(kt *kit.Kit, opt *typesBill.GcpBillListOption,
+		billInfo *cloud.AccountBillConfig[cloud.GcpBillConfigExtension]) (interface{}, int64, error)
+	GetBillTotal(kt *kit.Kit, where string, billInfo *cloud.AccountBillConfig[cloud.GcpBillConfigExtension]) (
+		int64, error)
+	GetRootAccountBillTotal(
+		kt *kit.Kit, where string, billInfo *billcore.RootAccountBillConfig[billcore.GcpBillConfigExtension]) (
+		int64, error)
+	QueryRootCreditList(kt *kit.Kit, opt *typesBill.GcpRootAccountBillListOption,
+		billInfo *billcore.RootAccountBillConfig[billcore.GcpBillConfigExtension]) (interface{}, error)
+	GetRootAccountBillList(kt *kit.Kit, opt *typesBill.GcpRootAccountBillListOption,
+		billInfo *billcore.RootAccountBillConfig[billcore.GcpBillConfigExtension]) (interface{}, int64, error)
+	GetBigQuery(kt *kit.Kit, query string) ([]map[string]bigquery.Value, int64, error)
+	ListCvm(kt *kit.Kit, opt *typecvm.GcpListOption) ([]typecvm.GcpCvm, string, error)
+	CountCvmAndNI(kt *kit.Kit) (int32, int32, error)
+	DeleteCvm(kt *kit.Kit, opt *typecvm.GcpDeleteOption) error
+	StopCvm(kt *kit.Kit, opt *typecvm.GcpStopOption) error
+	StartCvm(kt *kit.Kit, opt *typecvm.GcpStartOption) error
+	ResetCvm(kt *kit.Kit, opt *typecvm.GcpResetOption) error
+	CreateCvm(kt *kit.Kit, opt *typecvm.GcpCreateOption) (*poller.BaseDoneResult, error)
+	CreateDisk(kt *kit.Kit, opt *disk.GcpDiskCreateOption) (*poller.BaseDoneResult, error)
+	ListDisk(kt *kit.Kit, opt *disk.GcpDiskListOption) ([]disk.GcpDisk, string, error)
+	CountDisk(kt *kit.Kit) (int32, error)
+	DeleteDisk(kt *kit.Kit, opt *disk.GcpDiskDeleteOption) error
+	AttachDisk(kt *kit.Kit, opt *disk.GcpDiskAttachOption) error
+	DetachDisk(kt *kit.Kit, opt *disk.GcpDiskDetachOption) error
+	ListEip(kt *kit.Kit, opt *eip.GcpEipListOption) (*eip.GcpEipListResult, error)
+	CountEip(kt *kit.Kit) (int32, error)
+	ListAggregatedEip(kt *kit.Kit, opt *eip.GcpEipAggregatedListOption) ([]*compute.Address, error)
+	DeleteEip(kt *kit.Kit, opt *eip.GcpEipDeleteOption) error
+	AssociateEip(kt *kit.Kit, opt *eip.GcpEipAssociateOption) error
+	DisassociateEip(kt *kit.Kit, opt *eip.GcpEipDisassociateOption) error
+	CreateEip(kt *kit.Kit, opt *eip.GcpEipCreateOption) (*poller.BaseDoneResult, error)
+	ListFirewallRule(kt *kit.Kit, opt *firewallrule.ListOption) ([]firewallrule.GcpFirewall, string, error)
+	CountFirewall(kt *kit.Kit) (int32, error)
+	UpdateFirewallRule(kt *kit.Kit, opt *firewallrule.UpdateOption) error
+	DeleteFirewallRule(kt *kit.Kit, opt *firewallrule.DeleteOption) error
+	CreateFirewallRule(kt *kit.Kit, opt *firewallrule.CreateOption) (uint64, error)
+	CloudProjectID() string
+	ListImage(kt *kit.Kit,
+		opt *image.GcpImageListOption) (*image.GcpImageListResult, string, error)
+	ListInstanceType(
+		kt *kit.Kit, opt *typesinstancetype.GcpInstanceTypeListOption,
+	) (*typesinstancetype.GcpInstanceTypeListResult, error)
+	CreateProject(kt *kit.Kit, name string, organization string) (projectId string, err error)
+	UpdateBillingInfo(kt *kit.Kit, projectId string, billingAccountName string) error
+	BindingProjectEditor(kt *kit.Kit, projectId, email string) error
+	ListNetworkInterface(kt *kit.Kit, opt *core.GcpListOption) (*typesniproto.GcpInterfaceListResult, error)
+	ListNetworkInterfacePage(kt *kit.Kit, opt *core.GcpListOption) (*compute.InstancesListCall, error)
+	ListNetworkInterfaceByCvmID(kt *kit.Kit, opt *typesniproto.GcpListByCvmIDOption) (
+		map[string][]typesniproto.GcpNI, error)
+	ConvertNetworkInterface(data *compute.Instance, niItem *compute.NetworkInterface) *typesniproto.GcpNI
+	ListRegion(kt *kit.Kit, opt *core.GcpListOption) (*typesRegion.GcpRegionListResult, error)
+	CountRoute(kt *kit.Kit) (int32, error)
+	ListRoute(kt *kit.Kit, opt *routetable.GcpListOption) (*routetable.GcpRouteListResult, error)
+	CreateSubnet(kt *kit.Kit, opt *typessubnet.GcpSubnetCreateOption) (uint64, error)
+	UpdateSubnet(_ *kit.Kit, _ *typessubnet.GcpSubnetUpdateOption) error
+	DeleteSubnet(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error
+	CountSubnet(kt *kit.Kit) (int32, error)
+	ListSubnet(kt *kit.Kit, opt *typessubnet.GcpSubnetListOption) (*typessubnet.GcpSubnetListResult, error)
+	ListSubnetWithIPNumber(kt *kit.Kit, opt *typessubnet.GcpSubnetListOption) (
+		*typessubnet.GcpSubnetListResult, error)
+	CreateVpc(kt *kit.Kit, opt *types.GcpVpcCreateOption) (uint64, error)
+	UpdateVpc(kt *kit.Kit, opt *types.GcpVpcUpdateOption) error
+	DeleteVpc(kt *kit.Kit, opt *core.BaseDeleteOption) error
+	CountVpc(kt *kit.Kit) (int32, error)
+	ListVpc(kt *kit.Kit, opt *types.GcpListOption) (*types.GcpVpcListResult, error)
+	ListZone(kit *kit.Kit, opt *typeszone.GcpZoneListOption) ([]typeszone.GcpZone, error)
+}
@@ -29,7 +29,7 @@ import (
 
 // ListRegion list region.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/regions/list
-func (g *Gcp) ListRegion(kt *kit.Kit, opt *core.GcpListOption) (*typesRegion.GcpRegionListResult, error) {
+func (g *GcpImpl) ListRegion(kt *kit.Kit, opt *core.GcpListOption) (*typesRegion.GcpRegionListResult, error) {
 	if err := opt.Validate(); err != nil {
 		return nil, err
 	}
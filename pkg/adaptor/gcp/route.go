@@ -22,6 +22,7 @@ package gcp
 import (
 	"strconv"
 
+	"hcm/pkg/adaptor/types/core"
 	routetable "hcm/pkg/adaptor/types/route-table"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
@@ -32,7 +33,7 @@ import (
 
 // CountRoute count route.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/routes/list
-func (g *Gcp) CountRoute(kt *kit.Kit) (int32, error) {
+func (g *GcpImpl) CountRoute(kt *kit.Kit) (int32, error) {
 
 	client, err := g.clientSet.computeClient(kt)
 	if err != nil {
@@ -40,20 +41,24 @@ func (g *Gcp) CountRoute(kt *kit.Kit) (int32, error) {
 	}
 
 	request := client.Routes.List(g.CloudProjectID()).Context(kt.Ctx)
-
-	var count int32
-	for {
-		resp, err := request.Do()
+	list := func(_ *kit.Kit, page *core.GcpPage) ([]*compute.Route, string, error) {
+		resp, err := request.MaxResults(page.PageSize).PageToken(page.PageToken).Do()
 		if err != nil {
-			logs.Errorf("list route failed, err: %v, rid: %s", err, kt.Rid)
-			return 0, err
+			return nil, "", err
 		}
 
-		count += int32(len(resp.Items))
+		return resp.Items, resp.NextPageToken, nil
+	}
+	pages := core.NewGcpIterator(core.GcpQueryLimit, list, nil)
 
-		if resp.NextPageToken == "" {
-			break
-		}
+	var count int32
+	err = pages.ForEach(kt, func(_ *compute.Route) error {
+		count++
+		return nil
+	})
+	if err != nil {
+		logs.Errorf("list route failed, err: %v, rid: %s", err, kt.Rid)
+		return 0, err
 	}
 
 	return count, nil
@@ -61,7 +66,7 @@ func (g *Gcp) CountRoute(kt *kit.Kit) (int32, error) {
 
 // ListRoute list route.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/routes/list
-func (g *Gcp) ListRoute(kt *kit.Kit, opt *routetable.GcpListOption) (*routetable.GcpRouteListResult, error) {
+func (g *GcpImpl) ListRoute(kt *kit.Kit, opt *routetable.GcpListOption) (*routetable.GcpRouteListResult, error) {
 	if err := opt.Validate(); err != nil {
 		return nil, err
 	}
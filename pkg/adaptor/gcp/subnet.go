@@ -38,7 +38,7 @@ import (
 
 // CreateSubnet create subnet.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/subnetworks/insert
-func (g *Gcp) CreateSubnet(kt *kit.Kit, opt *typessubnet.GcpSubnetCreateOption) (uint64, error) {
+func (g *GcpImpl) CreateSubnet(kt *kit.Kit, opt *typessubnet.GcpSubnetCreateOption) (uint64, error) {
 	if err := opt.Validate(); err != nil {
 		return 0, err
 	}
@@ -78,7 +78,7 @@ func (g *Gcp) CreateSubnet(kt *kit.Kit, opt *typessubnet.GcpSubnetCreateOption)
 	handler := &createSubnetPollingHandler{
 		region: req.Region,
 	}
-	respPoller := poller.Poller[*Gcp, []*compute.Operation, createSubnetResult]{Handler: handler}
+	respPoller := poller.Poller[*GcpImpl, []*compute.Operation, createSubnetResult]{Handler: handler}
 	result, err := respPoller.PollUntilDone(g, kt, []*string{converter.ValToPtr(strconv.FormatUint(resp.Id, 10))},
 		types.NewBatchCreateSubnetPollerOption())
 	if err != nil {
@@ -95,13 +95,13 @@ func (g *Gcp) CreateSubnet(kt *kit.Kit, opt *typessubnet.GcpSubnetCreateOption)
 // UpdateSubnet update subnet.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/subnetworks/patch
 // TODO right now only memo is supported to update, but gcp description can not be updated.
-func (g *Gcp) UpdateSubnet(_ *kit.Kit, _ *typessubnet.GcpSubnetUpdateOption) error {
+func (g *GcpImpl) UpdateSubnet(_ *kit.Kit, _ *typessubnet.GcpSubnetUpdateOption) error {
 	return nil
 }
 
 // DeleteSubnet delete subnet.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/subnetworks/delete
-func (g *Gcp) DeleteSubnet(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+func (g *GcpImpl) DeleteSubnet(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
 	if err := opt.Validate(); err != nil {
 		return err
 	}
@@ -124,7 +124,7 @@ func (g *Gcp) DeleteSubnet(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) erro
 
 // CountSubnet count subnet.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/networks/list
-func (g *Gcp) CountSubnet(kt *kit.Kit) (int32, error) {
+func (g *GcpImpl) CountSubnet(kt *kit.Kit) (int32, error) {
 
 	client, err := g.clientSet.computeClient(kt)
 	if err != nil {
@@ -132,22 +132,28 @@ func (g *Gcp) CountSubnet(kt *kit.Kit) (int32, error) {
 	}
 
 	request := client.Subnetworks.AggregatedList(g.CloudProjectID()).Context(kt.Ctx)
-
-	var count int32
-	for {
-		resp, err := request.Do()
+	list := func(_ *kit.Kit, page *core.GcpPage) ([]compute.SubnetworksScopedList, string, error) {
+		resp, err := request.MaxResults(page.PageSize).PageToken(page.PageToken).Do()
 		if err != nil {
-			logs.Errorf("list subnet failed, err: %v, rid: %s", err, kt.Rid)
-			return 0, err
+			return nil, "", err
 		}
 
+		scoped := make([]compute.SubnetworksScopedList, 0, len(resp.Items))
 		for _, one := range resp.Items {
-			count += int32(len(one.Subnetworks))
+			scoped = append(scoped, one)
 		}
+		return scoped, resp.NextPageToken, nil
+	}
+	pages := core.NewGcpIterator(core.GcpQueryLimit, list, nil)
 
-		if resp.NextPageToken == "" {
-			break
-		}
+	var count int32
+	err = pages.ForEach(kt, func(one compute.SubnetworksScopedList) error {
+		count += int32(len(one.Subnetworks))
+		return nil
+	})
+	if err != nil {
+		logs.Errorf("list subnet failed, err: %v, rid: %s", err, kt.Rid)
+		return 0, err
 	}
 
 	return count, nil
@@ -155,7 +161,7 @@ func (g *Gcp) CountSubnet(kt *kit.Kit) (int32, error) {
 
 // ListSubnet list subnet.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/subnetworks/list
-func (g *Gcp) ListSubnet(kt *kit.Kit, opt *typessubnet.GcpSubnetListOption) (*typessubnet.GcpSubnetListResult, error) {
+func (g *GcpImpl) ListSubnet(kt *kit.Kit, opt *typessubnet.GcpSubnetListOption) (*typessubnet.GcpSubnetListResult, error) {
 	if err := opt.Validate(); err != nil {
 		return nil, err
 	}
@@ -197,7 +203,7 @@ func (g *Gcp) ListSubnet(kt *kit.Kit, opt *typessubnet.GcpSubnetListOption) (*ty
 
 // ListSubnetWithIPNumber 查询子网列表和子网的IP计数.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/subnetworks/list
-func (g *Gcp) ListSubnetWithIPNumber(kt *kit.Kit, opt *typessubnet.GcpSubnetListOption) (
+func (g *GcpImpl) ListSubnetWithIPNumber(kt *kit.Kit, opt *typessubnet.GcpSubnetListOption) (
 	*typessubnet.GcpSubnetListResult, error) {
 
 	if err := opt.Validate(); err != nil {
@@ -348,7 +354,7 @@ func (h *createSubnetPollingHandler) Done(items []*compute.Operation) (bool, *cr
 }
 
 // Poll ...
-func (h *createSubnetPollingHandler) Poll(client *Gcp, kt *kit.Kit, opIDs []*string) ([]*compute.Operation, error) {
+func (h *createSubnetPollingHandler) Poll(client *GcpImpl, kt *kit.Kit, opIDs []*string) ([]*compute.Operation, error) {
 
 	if len(opIDs) == 0 {
 		return nil, errors.New("operation group id is required")
@@ -37,7 +37,7 @@ import (
 
 // CreateVpc create vpc.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/networks/insert
-func (g *Gcp) CreateVpc(kt *kit.Kit, opt *types.GcpVpcCreateOption) (uint64, error) {
+func (g *GcpImpl) CreateVpc(kt *kit.Kit, opt *types.GcpVpcCreateOption) (uint64, error) {
 	if err := opt.Validate(); err != nil {
 		return 0, err
 	}
@@ -78,7 +78,7 @@ func (g *Gcp) CreateVpc(kt *kit.Kit, opt *types.GcpVpcCreateOption) (uint64, err
 	}
 
 	handler := &createVpcPollingHandler{}
-	respPoller := poller.Poller[*Gcp, []*compute.Operation, []uint64]{Handler: handler}
+	respPoller := poller.Poller[*GcpImpl, []*compute.Operation, []uint64]{Handler: handler}
 	results, err := respPoller.PollUntilDone(g, kt, []*string{converter.ValToPtr(strconv.FormatUint(resp.Id, 10))},
 		types.NewBatchCreateVpcPollerOption())
 	if err != nil {
@@ -95,13 +95,13 @@ func (g *Gcp) CreateVpc(kt *kit.Kit, opt *types.GcpVpcCreateOption) (uint64, err
 // UpdateVpc update vpc.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/networks/patch
 // TODO right now only memo is supported to update, but gcp description can not be updated.
-func (g *Gcp) UpdateVpc(kt *kit.Kit, opt *types.GcpVpcUpdateOption) error {
+func (g *GcpImpl) UpdateVpc(kt *kit.Kit, opt *types.GcpVpcUpdateOption) error {
 	return nil
 }
 
 // DeleteVpc delete vpc.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/networks/delete
-func (g *Gcp) DeleteVpc(kt *kit.Kit, opt *core.BaseDeleteOption) error {
+func (g *GcpImpl) DeleteVpc(kt *kit.Kit, opt *core.BaseDeleteOption) error {
 	if err := opt.Validate(); err != nil {
 		return err
 	}
@@ -124,7 +124,7 @@ func (g *Gcp) DeleteVpc(kt *kit.Kit, opt *core.BaseDeleteOption) error {
 
 // CountVpc count vpc.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/networks/list
-func (g *Gcp) CountVpc(kt *kit.Kit) (int32, error) {
+func (g *GcpImpl) CountVpc(kt *kit.Kit) (int32, error) {
 
 	client, err := g.clientSet.computeClient(kt)
 	if err != nil {
@@ -132,20 +132,24 @@ func (g *Gcp) CountVpc(kt *kit.Kit) (int32, error) {
 	}
 
 	request := client.Networks.List(g.CloudProjectID()).Context(kt.Ctx)
-
-	var count int32
-	for {
-		resp, err := request.Do()
+	list := func(_ *kit.Kit, page *core.GcpPage) ([]*compute.Network, string, error) {
+		resp, err := request.MaxResults(page.PageSize).PageToken(page.PageToken).Do()
 		if err != nil {
-			logs.Errorf("list vpc failed, err: %v, rid: %s", err, kt.Rid)
-			return 0, err
+			return nil, "", err
 		}
 
-		count += int32(len(resp.Items))
+		return resp.Items, resp.NextPageToken, nil
+	}
+	pages := core.NewGcpIterator(core.GcpQueryLimit, list, nil)
 
-		if resp.NextPageToken == "" {
-			break
-		}
+	var count int32
+	err = pages.ForEach(kt, func(_ *compute.Network) error {
+		count++
+		return nil
+	})
+	if err != nil {
+		logs.Errorf("list vpc failed, err: %v, rid: %s", err, kt.Rid)
+		return 0, err
 	}
 
 	return count, nil
@@ -153,7 +157,7 @@ func (g *Gcp) CountVpc(kt *kit.Kit) (int32, error) {
 
 // ListVpc list vpc.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/networks/list
-func (g *Gcp) ListVpc(kt *kit.Kit, opt *types.GcpListOption) (*types.GcpVpcListResult, error) {
+func (g *GcpImpl) ListVpc(kt *kit.Kit, opt *types.GcpListOption) (*types.GcpVpcListResult, error) {
 	if err := opt.Validate(); err != nil {
 		return nil, err
 	}
@@ -243,7 +247,7 @@ func (h *createVpcPollingHandler) Done(items []*compute.Operation) (bool, *[]uin
 }
 
 // Poll ...
-func (h *createVpcPollingHandler) Poll(client *Gcp, kt *kit.Kit, opIds []*string) ([]*compute.Operation, error) {
+func (h *createVpcPollingHandler) Poll(client *GcpImpl, kt *kit.Kit, opIds []*string) ([]*compute.Operation, error) {
 	if len(opIds) == 0 {
 		return nil, errors.New("operation group id is required")
 	}
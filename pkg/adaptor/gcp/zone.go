@@ -32,7 +32,7 @@ import (
 
 // ListZone list zone
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/zones/list
-func (g *Gcp) ListZone(kit *kit.Kit, opt *typeszone.GcpZoneListOption) ([]typeszone.GcpZone, error) {
+func (g *GcpImpl) ListZone(kit *kit.Kit, opt *typeszone.GcpZoneListOption) ([]typeszone.GcpZone, error) {
 
 	if opt == nil {
 		return nil, errf.New(errf.InvalidParameter, "gcp zone list option is required")
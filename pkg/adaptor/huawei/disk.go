@@ -39,12 +39,12 @@ import (
 
 // CreateDisk 创建云硬盘
 // reference: https://support.huaweicloud.com/api-evs/evs_04_2003.html
-func (h *HuaWei) CreateDisk(kt *kit.Kit, opt *disk.HuaWeiDiskCreateOption) (*poller.BaseDoneResult, error) {
+func (h *HuaWeiImpl) CreateDisk(kt *kit.Kit, opt *disk.HuaWeiDiskCreateOption) (*poller.BaseDoneResult, error) {
 	if opt == nil {
 		return nil, errf.New(errf.InvalidParameter, "huawei disk create option is required")
 	}
 
-	resp, err := h.createDisk(opt)
+	resp, err := h.createDisk(kt, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +53,7 @@ func (h *HuaWei) CreateDisk(kt *kit.Kit, opt *disk.HuaWeiDiskCreateOption) (*pol
 		return nil, fmt.Errorf("create disk return volume_ids is empty, orderID: %v", converter.ValToPtr(resp.OrderId))
 	}
 
-	respPoller := poller.Poller[*HuaWei, []disk.HuaWeiDisk, poller.BaseDoneResult]{
+	respPoller := poller.Poller[*HuaWeiImpl, []disk.HuaWeiDisk, poller.BaseDoneResult]{
 		Handler: &createDiskPollingHandler{region: opt.Region},
 	}
 	return respPoller.PollUntilDone(h, kt, common.StringPtrs(*resp.VolumeIds), nil)
@@ -62,7 +62,7 @@ func (h *HuaWei) CreateDisk(kt *kit.Kit, opt *disk.HuaWeiDiskCreateOption) (*pol
 // InquiryPriceDisk 创建云硬盘询价
 // reference: https://console-intl.huaweicloud.com/apiexplorer/#/openapi/BSSINTL/debug?api=ListRateOnPeriodDetail
 // reference: https://console-intl.huaweicloud.com/apiexplorer/#/openapi/BSSINTL/debug?api=ListOnDemandResourceRatings
-func (h *HuaWei) InquiryPriceDisk(kt *kit.Kit, opt *disk.HuaWeiDiskCreateOption) (
+func (h *HuaWeiImpl) InquiryPriceDisk(kt *kit.Kit, opt *disk.HuaWeiDiskCreateOption) (
 	*disk.InquiryPriceResult, error) {
 
 	if opt == nil {
@@ -85,7 +85,7 @@ func (h *HuaWei) InquiryPriceDisk(kt *kit.Kit, opt *disk.HuaWeiDiskCreateOption)
 	}
 }
 
-func (h *HuaWei) inquiryPricePostPaidDisk(kt *kit.Kit, opt *disk.HuaWeiDiskCreateOption, projectID string) (
+func (h *HuaWeiImpl) inquiryPricePostPaidDisk(kt *kit.Kit, opt *disk.HuaWeiDiskCreateOption, projectID string) (
 	*disk.InquiryPriceResult, error) {
 
 	client, err := h.clientSet.bssintlGlobalClient()
@@ -128,7 +128,7 @@ func (h *HuaWei) inquiryPricePostPaidDisk(kt *kit.Kit, opt *disk.HuaWeiDiskCreat
 	return result, nil
 }
 
-func (h *HuaWei) inquiryPricePrepaidDisk(kt *kit.Kit, opt *disk.HuaWeiDiskCreateOption, projectID string) (
+func (h *HuaWeiImpl) inquiryPricePrepaidDisk(kt *kit.Kit, opt *disk.HuaWeiDiskCreateOption, projectID string) (
 	*disk.InquiryPriceResult, error) {
 
 	client, err := h.clientSet.bssintlGlobalClient()
@@ -180,7 +180,9 @@ func (h *HuaWei) inquiryPricePrepaidDisk(kt *kit.Kit, opt *disk.HuaWeiDiskCreate
 	return result, nil
 }
 
-func (h *HuaWei) createDisk(opt *disk.HuaWeiDiskCreateOption) (*model.CreateVolumeResponse, error) {
+func (h *HuaWeiImpl) createDisk(kt *kit.Kit, opt *disk.HuaWeiDiskCreateOption) (*model.CreateVolumeResponse,
+	error) {
+
 	client, err := h.clientSet.evsClient(opt.Region)
 	if err != nil {
 		return nil, err
@@ -190,13 +192,14 @@ func (h *HuaWei) createDisk(opt *disk.HuaWeiDiskCreateOption) (*model.CreateVolu
 	if err != nil {
 		return nil, err
 	}
+	req.Body.Volume.Tags = volumeTags(kt)
 
 	return client.CreateVolume(req)
 }
 
 // ListDisk 查看云硬盘
 // reference: https://support.huaweicloud.com/api-evs/evs_04_2006.html
-func (h *HuaWei) ListDisk(kt *kit.Kit, opt *disk.HuaWeiDiskListOption) ([]disk.HuaWeiDisk, error) {
+func (h *HuaWeiImpl) ListDisk(kt *kit.Kit, opt *disk.HuaWeiDiskListOption) ([]disk.HuaWeiDisk, error) {
 	if opt == nil {
 		return nil, errf.New(errf.InvalidParameter, "huawei disk list option is required")
 	}
@@ -238,7 +241,7 @@ func (h *HuaWei) ListDisk(kt *kit.Kit, opt *disk.HuaWeiDiskListOption) ([]disk.H
 	return disks, nil
 }
 
-func (h *HuaWei) buildDisk(kt *kit.Kit, details []model.VolumeDetail) ([]disk.HuaWeiDisk, error) {
+func (h *HuaWeiImpl) buildDisk(kt *kit.Kit, details []model.VolumeDetail) ([]disk.HuaWeiDisk, error) {
 
 	client, err := h.clientSet.bssintlGlobalClient()
 	if err != nil {
@@ -296,7 +299,7 @@ func (h *HuaWei) buildDisk(kt *kit.Kit, details []model.VolumeDetail) ([]disk.Hu
 
 // DeleteDisk 删除云盘
 // reference: https://support.huaweicloud.com/api-evs/evs_04_2008.html
-func (h *HuaWei) DeleteDisk(kt *kit.Kit, chargeType string, opt *disk.HuaWeiDiskDeleteOption) error {
+func (h *HuaWeiImpl) DeleteDisk(kt *kit.Kit, chargeType string, opt *disk.HuaWeiDiskDeleteOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "huawei disk delete option is required")
 	}
@@ -329,7 +332,7 @@ func (h *HuaWei) DeleteDisk(kt *kit.Kit, chargeType string, opt *disk.HuaWeiDisk
 	return nil
 }
 
-func (h *HuaWei) DeletePrePaidResource(kt *kit.Kit, cloudIDs []string) error {
+func (h *HuaWeiImpl) DeletePrePaidResource(kt *kit.Kit, cloudIDs []string) error {
 
 	client, err := h.clientSet.bssintlGlobalClient()
 	if err != nil {
@@ -352,7 +355,7 @@ func (h *HuaWei) DeletePrePaidResource(kt *kit.Kit, cloudIDs []string) error {
 
 // AttachDisk 挂载云盘
 // reference: https://support.huaweicloud.com/api-ecs/ecs_02_0605.html
-func (h *HuaWei) AttachDisk(kt *kit.Kit, opt *disk.HuaWeiDiskAttachOption) error {
+func (h *HuaWeiImpl) AttachDisk(kt *kit.Kit, opt *disk.HuaWeiDiskAttachOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "huawei disk attach option is required")
 	}
@@ -373,7 +376,7 @@ func (h *HuaWei) AttachDisk(kt *kit.Kit, opt *disk.HuaWeiDiskAttachOption) error
 		return err
 	}
 
-	respPoller := poller.Poller[*HuaWei, []disk.HuaWeiDisk, poller.BaseDoneResult]{
+	respPoller := poller.Poller[*HuaWeiImpl, []disk.HuaWeiDisk, poller.BaseDoneResult]{
 		Handler: &attachDiskPollingHandler{region: opt.Region},
 	}
 	_, err = respPoller.PollUntilDone(h, kt, []*string{&opt.CloudDiskID}, nil)
@@ -382,7 +385,7 @@ func (h *HuaWei) AttachDisk(kt *kit.Kit, opt *disk.HuaWeiDiskAttachOption) error
 
 // DetachDisk 卸载云盘
 // reference: https://support.huaweicloud.com/api-ecs/ecs_02_0606.html
-func (h *HuaWei) DetachDisk(kt *kit.Kit, opt *disk.HuaWeiDiskDetachOption) error {
+func (h *HuaWeiImpl) DetachDisk(kt *kit.Kit, opt *disk.HuaWeiDiskDetachOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "huawei disk detach option is required")
 	}
@@ -403,7 +406,7 @@ func (h *HuaWei) DetachDisk(kt *kit.Kit, opt *disk.HuaWeiDiskDetachOption) error
 		return err
 	}
 
-	respPoller := poller.Poller[*HuaWei, []disk.HuaWeiDisk, poller.BaseDoneResult]{
+	respPoller := poller.Poller[*HuaWeiImpl, []disk.HuaWeiDisk, poller.BaseDoneResult]{
 		Handler: &detachDiskPollingHandler{region: opt.Region},
 	}
 	_, err = respPoller.PollUntilDone(h, kt, []*string{&opt.CloudDiskID}, nil)
@@ -438,7 +441,7 @@ func (h *createDiskPollingHandler) Done(pollResult []disk.HuaWeiDisk) (bool, *po
 }
 
 func (h *createDiskPollingHandler) Poll(
-	client *HuaWei,
+	client *HuaWeiImpl,
 	kt *kit.Kit,
 	cloudIDs []*string,
 ) ([]disk.HuaWeiDisk, error) {
@@ -455,7 +458,7 @@ func (h *createDiskPollingHandler) Poll(
 	return result, nil
 }
 
-var _ poller.PollingHandler[*HuaWei, []disk.HuaWeiDisk, poller.BaseDoneResult] = new(createDiskPollingHandler)
+var _ poller.PollingHandler[*HuaWeiImpl, []disk.HuaWeiDisk, poller.BaseDoneResult] = new(createDiskPollingHandler)
 
 type attachDiskPollingHandler struct {
 	region string
@@ -470,7 +473,7 @@ func (h *attachDiskPollingHandler) Done(pollResult []disk.HuaWeiDisk) (bool, *po
 }
 
 func (h *attachDiskPollingHandler) Poll(
-	client *HuaWei,
+	client *HuaWeiImpl,
 	kt *kit.Kit,
 	cloudIDs []*string,
 ) ([]disk.HuaWeiDisk, error) {
@@ -500,7 +503,7 @@ func (h *detachDiskPollingHandler) Done(pollResult []disk.HuaWeiDisk) (bool, *po
 }
 
 func (h *detachDiskPollingHandler) Poll(
-	client *HuaWei,
+	client *HuaWeiImpl,
 	kt *kit.Kit,
 	cloudIDs []*string,
 ) ([]disk.HuaWeiDisk, error) {
@@ -36,7 +36,7 @@ var PublicImagePlatforms = []model.ListImagesRequestPlatform{model.GetListImages
 
 // ListImage 查询公共镜像列表
 // reference: https://support.huaweicloud.com/api-ims/ims_03_0602.html
-func (h *HuaWei) ListImage(kt *kit.Kit, opt *image.HuaWeiImageListOption) (*image.HuaWeiImageListResult, error) {
+func (h *HuaWeiImpl) ListImage(kt *kit.Kit, opt *image.HuaWeiImageListOption) (*image.HuaWeiImageListResult, error) {
 
 	client, err := h.clientSet.imsClientV2(region.ValueOf(opt.Region))
 	if err != nil {
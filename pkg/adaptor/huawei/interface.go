@@ -0,0 +1,136 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+//go:generate  mockgen -destination ../mock/huawei/huawei_mock.go  -package=mockhuawei -typed -source=interface.go
+
+package huawei
+
+import (
+	"hcm/pkg/adaptor/poller"
+	"hcm/pkg/adaptor/types"
+	typeaccount "hcm/pkg/adaptor/types/account"
+	typesBill "hcm/pkg/adaptor/types/bill"
+	"hcm/pkg/adaptor/types/core"
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/adaptor/types/disk"
+	"hcm/pkg/adaptor/types/eip"
+	"hcm/pkg/adaptor/types/image"
+	typesinstancetype "hcm/pkg/adaptor/types/instance-type"
+	typesniproto "hcm/pkg/adaptor/types/network-interface"
+	"hcm/pkg/adaptor/types/region"
+	routetable "hcm/pkg/adaptor/types/route-table"
+	securitygroup "hcm/pkg/adaptor/types/security-group"
+	securitygrouprule "hcm/pkg/adaptor/types/security-group-rule"
+	adtysubnet "hcm/pkg/adaptor/types/subnet"
+	typeszone "hcm/pkg/adaptor/types/zone"
+	"hcm/pkg/api/core/cloud"
+	coreni "hcm/pkg/api/core/cloud/network-interface"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+
+	bssmodel "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/bssintl/v2/model"
+	rmsmodel "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/rms/v1/model"
+	vpcmodel "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/vpc/v2/model"
+	vpcv3model "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/vpc/v3/model"
+)
+
+// HuaWei adaptor interface for huawei cloud
+type HuaWei interface {
+	ListAccount(kt *kit.Kit) ([]typeaccount.HuaWeiAccount, error)
+	GetAccountQuota(kt *kit.Kit, opt *typeaccount.GetHuaWeiAccountZoneQuotaOption) (
+		*typeaccount.HuaWeiAccountQuota, error)
+	GetAccountInfoBySecret(kt *kit.Kit, accessKeyID string) (*cloud.HuaWeiInfoBySecret, error)
+	GetBillList(kt *kit.Kit, opt *typesBill.HuaWeiBillListOption) (
+		*bssmodel.ListCustomerselfResourceRecordDetailsResponse, error)
+	GetFeeRecordList(kt *kit.Kit, opt *typesBill.HuaWeiFeeRecordListOption) (
+		*bssmodel.ListCustomerselfResourceRecordsResponse, error)
+	CountAllResources(kt *kit.Kit, typ enumor.HuaWeiProviderType) (*rmsmodel.CountAllResourcesResponse, error)
+	CountSubAccountResources(kt *kit.Kit) (int32, error)
+	CountSubnetRouteTableRes(kt *kit.Kit) (int32, int32, error)
+	CountNIResources(kt *kit.Kit) (int32, error)
+	ListCvm(kt *kit.Kit, opt *typecvm.HuaWeiListOption) ([]typecvm.HuaWeiCvm, error)
+	DeleteCvm(kt *kit.Kit, opt *typecvm.HuaWeiDeleteOption) error
+	StartCvm(kt *kit.Kit, opt *typecvm.HuaWeiStartOption) error
+	StopCvm(kt *kit.Kit, opt *typecvm.HuaWeiStopOption) error
+	RebootCvm(kt *kit.Kit, opt *typecvm.HuaWeiRebootOption) error
+	ResetCvmPwd(kt *kit.Kit, opt *typecvm.HuaWeiResetPwdOption) error
+	InquiryPriceCvm(kt *kit.Kit, opt *typecvm.HuaWeiCreateOption) (
+		*typecvm.InquiryPriceResult, error)
+	CreateCvm(kt *kit.Kit, opt *typecvm.HuaWeiCreateOption) (*poller.BaseDoneResult, error)
+	CreateDisk(kt *kit.Kit, opt *disk.HuaWeiDiskCreateOption) (*poller.BaseDoneResult, error)
+	InquiryPriceDisk(kt *kit.Kit, opt *disk.HuaWeiDiskCreateOption) (
+		*disk.InquiryPriceResult, error)
+	ListDisk(kt *kit.Kit, opt *disk.HuaWeiDiskListOption) ([]disk.HuaWeiDisk, error)
+	DeleteDisk(kt *kit.Kit, chargeType string, opt *disk.HuaWeiDiskDeleteOption) error
+	DeletePrePaidResource(kt *kit.Kit, cloudIDs []string) error
+	AttachDisk(kt *kit.Kit, opt *disk.HuaWeiDiskAttachOption) error
+	DetachDisk(kt *kit.Kit, opt *disk.HuaWeiDiskDetachOption) error
+	ListEip(kt *kit.Kit, opt *eip.HuaWeiEipListOption) (*eip.HuaWeiEipListResult, error)
+	DeleteEip(kt *kit.Kit, opt *eip.HuaWeiEipDeleteOption) error
+	AssociateEip(kt *kit.Kit, opt *eip.HuaWeiEipAssociateOption) error
+	DisassociateEip(kt *kit.Kit, opt *eip.HuaWeiEipDisassociateOption) error
+	CreateEip(kt *kit.Kit, opt *eip.HuaWeiEipCreateOption) (*poller.BaseDoneResult, error)
+	ListImage(kt *kit.Kit, opt *image.HuaWeiImageListOption) (*image.HuaWeiImageListResult, error)
+	ListInstanceType(kt *kit.Kit, opt *typesinstancetype.HuaWeiInstanceTypeListOption) (
+		[]*typesinstancetype.HuaWeiInstanceType, error)
+	ListNetworkInterface(kt *kit.Kit, opt *typesniproto.HuaWeiNIListOption) (
+		*typesniproto.HuaWeiInterfaceListResult, error)
+	GetPublicIpsMapByPortIDs(kt *kit.Kit, opt *typesniproto.HuaWeiEipListOption) (
+		map[string]*coreni.EipNetwork, error)
+	GetSecurityGroupsByPortID(kt *kit.Kit, opt *typesniproto.HuaWeiPortInfoOption) (
+		map[string][]string, error)
+	GetSecurityGroupsByNetID(kt *kit.Kit, opt *typesniproto.HuaWeiPortInfoOption) (
+		map[string][]string, []coreni.NetVirtualIP, error)
+	GetProjectID(kt *kit.Kit, name string) (string, error)
+	ListRegion(kt *kit.Kit) ([]*region.HuaWeiRegionModel, error)
+	UpdateRouteTable(kt *kit.Kit, opt *routetable.HuaWeiRouteTableUpdateOption) error
+	DeleteRouteTable(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error
+	ListRouteTables(kt *kit.Kit, opt *routetable.HuaWeiRouteTableListOption) ([]routetable.HuaWeiRouteTable, error)
+	ListRouteTableIDs(kt *kit.Kit, opt *routetable.HuaWeiRouteTableListOption) ([]string, error)
+	GetRouteTable(kt *kit.Kit, opt *routetable.HuaWeiRouteTableGetOption) (*routetable.HuaWeiRouteTable,
+		error)
+	CreateSecurityGroup(kt *kit.Kit, opt *securitygroup.HuaWeiCreateOption) (
+		*vpcv3model.SecurityGroupInfo, error)
+	DeleteSecurityGroup(kt *kit.Kit, opt *securitygroup.HuaWeiDeleteOption) error
+	UpdateSecurityGroup(kt *kit.Kit, opt *securitygroup.HuaWeiUpdateOption) error
+	ListSecurityGroup(kt *kit.Kit, opt *securitygroup.HuaWeiListOption) ([]securitygroup.HuaWeiSG,
+		*vpcv3model.PageInfo, error)
+	SecurityGroupCvmAssociate(kt *kit.Kit, opt *securitygroup.HuaWeiAssociateCvmOption) error
+	SecurityGroupCvmDisassociate(kt *kit.Kit, opt *securitygroup.HuaWeiAssociateCvmOption) error
+	CreateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.HuaWeiCreateOption) (*vpcv3model.SecurityGroupRule,
+		error)
+	DeleteSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.HuaWeiDeleteOption) error
+	ListSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.HuaWeiListOption) (
+		[]securitygrouprule.HuaWeiSGRule, error)
+	CreateSubnet(kt *kit.Kit, opt *adtysubnet.HuaWeiSubnetCreateOption) (*adtysubnet.HuaWeiSubnet, error)
+	UpdateSubnet(kt *kit.Kit, opt *adtysubnet.HuaWeiSubnetUpdateOption) error
+	DeleteSubnet(kt *kit.Kit, opt *adtysubnet.HuaWeiSubnetDeleteOption) error
+	ListSubnet(kt *kit.Kit, opt *adtysubnet.HuaWeiSubnetListOption) (*adtysubnet.HuaWeiSubnetListResult,
+		error)
+	ListSubnetByID(kt *kit.Kit, opt *adtysubnet.HuaWeiSubnetListByIDOption) (*adtysubnet.HuaWeiSubnetListResult,
+		error)
+	GetSubnetIPAvailabilities(kt *kit.Kit, opt *types.HuaWeiVpcIPAvailGetOption) (
+		*vpcmodel.NetworkIpAvailability, error)
+	CreateVpc(kt *kit.Kit, opt *types.HuaWeiVpcCreateOption) error
+	UpdateVpc(kt *kit.Kit, opt *types.HuaWeiVpcUpdateOption) error
+	DeleteVpc(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error
+	ListVpcRaw(kt *kit.Kit, opt *types.HuaWeiVpcListOption) (*vpcv3model.ListVpcsResponse, error)
+	ListVpc(kt *kit.Kit, opt *types.HuaWeiVpcListOption) (*types.HuaWeiVpcListResult, error)
+	ListZone(kt *kit.Kit, opt *typeszone.HuaWeiZoneListOption) ([]typeszone.HuaWeiZone, error)
+}
@@ -0,0 +1,68 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"hcm/pkg/adaptor/operatortag"
+	"hcm/pkg/kit"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/ecs/v2/model"
+)
+
+// tagValueMaxLength 华为云标签值的最大长度
+const tagValueMaxLength = 43
+
+// serverTags 返回请求的操作人标签对应的云主机标签，未开启操作人标签时返回nil
+func serverTags(kt *kit.Kit) *[]model.PrePaidServerTag {
+	pairs := operatortag.Pairs(kt)
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	tags := make([]model.PrePaidServerTag, 0, len(pairs))
+	for _, pair := range pairs {
+		tags = append(tags, model.PrePaidServerTag{Key: pair.Key, Value: truncateTagValue(pair.Value)})
+	}
+
+	return &tags
+}
+
+// volumeTags 返回请求的操作人标签对应的云硬盘标签，未开启操作人标签时返回nil
+func volumeTags(kt *kit.Kit) map[string]string {
+	pairs := operatortag.Pairs(kt)
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	tags := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		tags[pair.Key] = truncateTagValue(pair.Value)
+	}
+
+	return tags
+}
+
+func truncateTagValue(value string) string {
+	if runes := []rune(value); len(runes) > tagValueMaxLength {
+		return string(runes[:tagValueMaxLength])
+	}
+
+	return value
+}
@@ -32,7 +32,7 @@ import (
 )
 
 // GetProjectID reference: https://support.huaweicloud.com/intl/zh-cn/api-iam/iam_06_0001.html
-func (h *HuaWei) GetProjectID(kt *kit.Kit, name string) (string, error) {
+func (h *HuaWeiImpl) GetProjectID(kt *kit.Kit, name string) (string, error) {
 
 	if len(name) == 0 {
 		return "", errors.New("name is required")
@@ -34,7 +34,7 @@ import (
 
 // CreateSecurityGroupRule create security group rule.
 // reference: https://support.huaweicloud.com/api-vpc/vpc_apiv3_0016.html
-func (h *HuaWei) CreateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.HuaWeiCreateOption) (*model.SecurityGroupRule,
+func (h *HuaWeiImpl) CreateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.HuaWeiCreateOption) (*model.SecurityGroupRule,
 	error) {
 
 	if opt == nil {
@@ -87,7 +87,7 @@ func (h *HuaWei) CreateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.Hua
 
 // DeleteSecurityGroupRule delete security group rule.
 // reference: https://support.huaweicloud.com/api-vpc/vpc_apiv3_0019.html
-func (h *HuaWei) DeleteSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.HuaWeiDeleteOption) error {
+func (h *HuaWeiImpl) DeleteSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.HuaWeiDeleteOption) error {
 
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "security group rule delete option is required")
@@ -116,7 +116,7 @@ func (h *HuaWei) DeleteSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.Hua
 
 // ListSecurityGroupRule list security group rule.
 // reference: https://support.huaweicloud.com/api-vpc/vpc_apiv3_0019.html
-func (h *HuaWei) ListSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.HuaWeiListOption) ([]securitygrouprule.
+func (h *HuaWeiImpl) ListSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.HuaWeiListOption) ([]securitygrouprule.
 	HuaWeiSGRule, error) {
 
 	if opt == nil {
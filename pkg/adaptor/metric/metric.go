@@ -17,24 +17,39 @@
  * to the current version of the project delivered to anyone in the future.
  */
 
-// Package metric is used to collect cloud api metrics.
+// Package metric is used to collect cloud api metrics and traces.
 package metric
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
+	"hcm/pkg/adaptor/apiusage"
+	"hcm/pkg/adaptor/fault"
+	"hcm/pkg/adaptor/recorder"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/metrics"
+	"hcm/pkg/tracing"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // restMetric is used to collect cloud api metrics.
 var cloudApiMetric *metric
 
+var cloudApiLabels = []string{"vendor", "res_type", "http_code", "api_name", "region", "endpoint"}
+
 // InitCloudApiMetrics ..
 func InitCloudApiMetrics(reg prometheus.Registerer) {
 	m := new(metric)
@@ -48,7 +63,7 @@ func InitCloudApiMetrics(reg prometheus.Registerer) {
 		Help:        "the lag seconds to request the cloud API",
 		ConstLabels: labels,
 		Buckets:     []float64{0.05, 0.075, 0.1, 0.15, 0.2, 0.3, 0.4, 0.5, 0.7, 1, 2, 3, 4, 5, 10, 20, 30},
-	}, []string{"vendor", "http_code", "api_name", "region", "endpoint"})
+	}, cloudApiLabels)
 	reg.MustRegister(m.lagSec)
 
 	m.errCounter = prometheus.NewCounterVec(
@@ -58,7 +73,7 @@ func InitCloudApiMetrics(reg prometheus.Registerer) {
 			Name:        "total_err_count",
 			Help:        "the total error count to request the restful API",
 			ConstLabels: labels,
-		}, []string{"vendor", "http_code", "api_name", "region", "endpoint"})
+		}, cloudApiLabels)
 	reg.MustRegister(m.errCounter)
 
 	cloudApiMetric = m
@@ -72,39 +87,206 @@ type metric struct {
 	errCounter *prometheus.CounterVec
 }
 
+// record the cloud api call result, it is skipped when metrics is not initialized, e.g. adaptor used in tools.
+func (m *metric) record(labels prometheus.Labels, failed bool, start time.Time) {
+	if m == nil {
+		return
+	}
+
+	if failed {
+		m.errCounter.With(labels).Inc()
+	}
+	m.lagSec.With(labels).Observe(time.Since(start).Seconds())
+}
+
 // GetTCloudRecordRoundTripper get record round tripper for tcloud
 func GetTCloudRecordRoundTripper(next http.RoundTripper) promhttp.RoundTripperFunc {
-	if next == nil {
-		next = http.DefaultTransport
-	}
+	// 故障在录制之前注入，注入的响应不会被录制
+	next = fault.Transport(enumor.TCloud, recorder.Transport(next))
 	return func(req *http.Request) (*http.Response, error) {
 		action := strings.Join(req.Header["X-TC-Action"], ",")
 		region := strings.Join(req.Header["X-TC-Region"], ",")
+		// tcloud endpoint is like cvm.tencentcloudapi.com, use the service name as resource type
+		resType, _, _ := strings.Cut(req.Host, ".")
+
+		_, span := startSpan(req.Context(), enumor.TCloud, resType, action, region)
 		start := time.Now()
 		code := "nil"
 		ret, err := next.RoundTrip(req)
 		if ret != nil {
 			code = ret.Status
 		}
+		endSpan(span, ret, err)
 
-		if err != nil || (ret != nil && ret.StatusCode != http.StatusOK) {
-			cloudApiMetric.errCounter.With(prometheus.Labels{
-				"vendor":    string(enumor.TCloud),
-				"endpoint":  req.Host,
-				"region":    region,
-				"api_name":  action,
-				"http_code": code,
-			}).Inc()
-		}
-		cost := time.Since(start).Seconds()
-		cloudApiMetric.lagSec.With(
-			prometheus.Labels{
-				"vendor":    string(enumor.TCloud),
-				"endpoint":  req.Host,
-				"region":    region,
-				"api_name":  action,
-				"http_code": code,
-			}).Observe(cost)
+		failed := err != nil || (ret != nil && ret.StatusCode != http.StatusOK)
+		cloudApiMetric.record(prometheus.Labels{
+			"vendor":    string(enumor.TCloud),
+			"res_type":  resType,
+			"endpoint":  req.Host,
+			"region":    region,
+			"api_name":  action,
+			"http_code": code,
+		}, failed, start)
+		// 腾讯云的限频错误通过http 200的响应体返回，这里只能识别http层面的限频
+		apiusage.Record(enumor.TCloud, tcloudSecretID(req.Header.Get("Authorization")), action, failed,
+			ret != nil && ret.StatusCode == http.StatusTooManyRequests)
 		return ret, err
 	}
 }
+
+// awsSpanKey is the context key of the aws api call span.
+type awsSpanKey struct{}
+
+// AwsStartSpanHandler start the span of aws api call, it should be pushed to the build handlers of aws session.
+func AwsStartSpanHandler(r *request.Request) {
+	apiName := ""
+	if r.Operation != nil {
+		apiName = r.Operation.Name
+	}
+
+	ctx, span := startSpan(r.Context(), enumor.Aws, r.ClientInfo.ServiceName, apiName,
+		aws.StringValue(r.Config.Region))
+	r.SetContext(context.WithValue(ctx, awsSpanKey{}, span))
+}
+
+// AwsRecordHandler record aws api call metrics, it should be pushed to the complete handlers of aws session.
+func AwsRecordHandler(r *request.Request) {
+	code := "nil"
+	if r.HTTPResponse != nil {
+		code = r.HTTPResponse.Status
+	}
+
+	apiName := ""
+	if r.Operation != nil {
+		apiName = r.Operation.Name
+	}
+
+	if span, ok := r.Context().Value(awsSpanKey{}).(trace.Span); ok {
+		endSpan(span, r.HTTPResponse, r.Error)
+	}
+
+	cloudApiMetric.record(prometheus.Labels{
+		"vendor":    string(enumor.Aws),
+		"res_type":  r.ClientInfo.ServiceName,
+		"endpoint":  r.ClientInfo.Endpoint,
+		"region":    aws.StringValue(r.Config.Region),
+		"api_name":  apiName,
+		"http_code": code,
+	}, r.Error != nil, r.Time)
+
+	accessKeyID := ""
+	if r.Config.Credentials != nil {
+		// the credentials have been retrieved when the request is signed, so it's read from cache here.
+		if value, err := r.Config.Credentials.Get(); err == nil {
+			accessKeyID = value.AccessKeyID
+		}
+	}
+	apiusage.Record(enumor.Aws, accessKeyID, apiName, r.Error != nil, request.IsErrorThrottle(r.Error))
+}
+
+// AzureClientOptions returns azure arm client options which record api call metrics.
+func AzureClientOptions() *arm.ClientOptions {
+	opts := &arm.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			PerRetryPolicies: []policy.Policy{azureRecordPolicy{}},
+		},
+	}
+	if recorder.Enabled() || fault.Available() {
+		opts.Transport = &http.Client{Transport: fault.Transport(enumor.Azure, recorder.Transport(nil))}
+	}
+
+	return opts
+}
+
+// azureRecordPolicy is azure pipeline policy to record api call metrics.
+type azureRecordPolicy struct{}
+
+// Do record api call metrics of azure request.
+func (azureRecordPolicy) Do(req *policy.Request) (*http.Response, error) {
+	raw := req.Raw()
+	resType := azureResType(raw.URL.Path)
+
+	_, span := startSpan(raw.Context(), enumor.Azure, resType, raw.Method+" "+resType, "")
+	start := time.Now()
+	code := "nil"
+	resp, err := req.Next()
+	if resp != nil {
+		code = resp.Status
+	}
+	endSpan(span, resp, err)
+
+	failed := err != nil || (resp != nil && resp.StatusCode >= http.StatusBadRequest)
+	cloudApiMetric.record(prometheus.Labels{
+		"vendor":    string(enumor.Azure),
+		"res_type":  resType,
+		"endpoint":  raw.URL.Host,
+		"region":    "",
+		"api_name":  raw.Method + " " + resType,
+		"http_code": code,
+	}, failed, start)
+	apiusage.Record(enumor.Azure, azureSubscriptionID(raw.URL.Path), raw.Method+" "+resType, failed,
+		resp != nil && resp.StatusCode == http.StatusTooManyRequests)
+	return resp, err
+}
+
+// startSpan start the client span of cloud api call.
+func startSpan(ctx context.Context, vendor enumor.Vendor, resType, apiName, region string) (context.Context,
+	trace.Span) {
+
+	return tracing.Start(ctx, fmt.Sprintf("%s %s", vendor, apiName), trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("vendor", string(vendor)), attribute.String("res_type", resType),
+			attribute.String("api_name", apiName), attribute.String("region", region)))
+}
+
+// endSpan end the span of cloud api call, the span is set to error if the call failed.
+func endSpan(span trace.Span, resp *http.Response, err error) {
+	if resp != nil {
+		span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+		if err == nil && resp.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+	tracing.End(span, err)
+}
+
+// azureResType parse resource type from azure resource path, e.g. virtualNetworks for path
+// /subscriptions/{id}/resourceGroups/{name}/providers/Microsoft.Network/virtualNetworks/{name}.
+func azureResType(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := len(segments) - 3; i >= 0; i-- {
+		if strings.EqualFold(segments[i], "providers") {
+			return segments[i+2]
+		}
+	}
+
+	// path without provider, e.g. /subscriptions/{id}/resourceGroups or /subscriptions/{id}
+	if len(segments)%2 == 1 {
+		return segments[len(segments)-1]
+	}
+	return segments[len(segments)-2]
+}
+
+// tcloudSecretID parse the secret id from the tcloud authorization header, which is like
+// "TC3-HMAC-SHA256 Credential=AKIDxxx/2024-01-01/cvm/tc3_request, SignedHeaders=..., Signature=...".
+func tcloudSecretID(authorization string) string {
+	_, credential, found := strings.Cut(authorization, "Credential=")
+	if !found {
+		return ""
+	}
+
+	secretID, _, _ := strings.Cut(credential, "/")
+	return secretID
+}
+
+// azureSubscriptionID parse the subscription id from azure resource path, which is like /subscriptions/{id}/...,
+// the azure account of hcm is bound to a subscription.
+func azureSubscriptionID(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if strings.EqualFold(segments[i], "subscriptions") {
+			return segments[i+1]
+		}
+	}
+
+	return ""
+}
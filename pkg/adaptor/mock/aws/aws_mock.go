@@ -0,0 +1,2997 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+
+// Package mockaws is a generated GoMock package.
+package mockaws
+
+import (
+	poller "hcm/pkg/adaptor/poller"
+	types "hcm/pkg/adaptor/types"
+	account "hcm/pkg/adaptor/types/account"
+	bill "hcm/pkg/adaptor/types/bill"
+	core "hcm/pkg/adaptor/types/core"
+	cvm "hcm/pkg/adaptor/types/cvm"
+	disk "hcm/pkg/adaptor/types/disk"
+	eip "hcm/pkg/adaptor/types/eip"
+	image "hcm/pkg/adaptor/types/image"
+	instancetype "hcm/pkg/adaptor/types/instance-type"
+	region "hcm/pkg/adaptor/types/region"
+	routetable "hcm/pkg/adaptor/types/route-table"
+	securitygroup "hcm/pkg/adaptor/types/security-group"
+	securitygrouprule "hcm/pkg/adaptor/types/security-group-rule"
+	adtysubnet "hcm/pkg/adaptor/types/subnet"
+	zone "hcm/pkg/adaptor/types/zone"
+	bill0 "hcm/pkg/api/core/bill"
+	cloud "hcm/pkg/api/core/cloud"
+	hsmainaccount "hcm/pkg/api/hc-service/main-account"
+	kit "hcm/pkg/kit"
+	reflect "reflect"
+
+	cloudformation "github.com/aws/aws-sdk-go/service/cloudformation"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+	s3 "github.com/aws/aws-sdk-go/service/s3"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAws is a mock of Aws interface.
+type MockAws struct {
+	ctrl     *gomock.Controller
+	recorder *MockAwsMockRecorder
+}
+
+// MockAwsMockRecorder is the mock recorder for MockAws.
+type MockAwsMockRecorder struct {
+	mock *MockAws
+}
+
+// NewMockAws creates a new mock instance.
+func NewMockAws(ctrl *gomock.Controller) *MockAws {
+	mock := &MockAws{ctrl: ctrl}
+	mock.recorder = &MockAwsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAws) EXPECT() *MockAwsMockRecorder {
+	return m.recorder
+}
+
+// AssociateEip mocks base method.
+func (m *MockAws) AssociateEip(kt *kit.Kit, opt *eip.AwsEipAssociateOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssociateEip", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssociateEip indicates an expected call of AssociateEip.
+func (mr *MockAwsMockRecorder) AssociateEip(kt, opt interface{}) *AwsAssociateEipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssociateEip", reflect.TypeOf((*MockAws)(nil).AssociateEip), kt, opt)
+	return &AwsAssociateEipCall{Call: call}
+}
+
+// AwsAssociateEipCall wrap *gomock.Call
+type AwsAssociateEipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsAssociateEipCall) Return(arg0 error) *AwsAssociateEipCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsAssociateEipCall) Do(f func(*kit.Kit, *eip.AwsEipAssociateOption) error) *AwsAssociateEipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsAssociateEipCall) DoAndReturn(f func(*kit.Kit, *eip.AwsEipAssociateOption) error) *AwsAssociateEipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// AttachDisk mocks base method.
+func (m *MockAws) AttachDisk(kt *kit.Kit, opt *disk.AwsDiskAttachOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachDisk", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachDisk indicates an expected call of AttachDisk.
+func (mr *MockAwsMockRecorder) AttachDisk(kt, opt interface{}) *AwsAttachDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachDisk", reflect.TypeOf((*MockAws)(nil).AttachDisk), kt, opt)
+	return &AwsAttachDiskCall{Call: call}
+}
+
+// AwsAttachDiskCall wrap *gomock.Call
+type AwsAttachDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsAttachDiskCall) Return(arg0 error) *AwsAttachDiskCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsAttachDiskCall) Do(f func(*kit.Kit, *disk.AwsDiskAttachOption) error) *AwsAttachDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsAttachDiskCall) DoAndReturn(f func(*kit.Kit, *disk.AwsDiskAttachOption) error) *AwsAttachDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// AwsListRootOutsideMonthBill mocks base method.
+func (m *MockAws) AwsListRootOutsideMonthBill(kt *kit.Kit, opt *bill.AwsMainOutsideMonthBillLitOpt, billInfo *bill0.RootAccountBillConfig[bill0.AwsBillConfigExtension]) ([]map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AwsListRootOutsideMonthBill", kt, opt, billInfo)
+	ret0, _ := ret[0].([]map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AwsListRootOutsideMonthBill indicates an expected call of AwsListRootOutsideMonthBill.
+func (mr *MockAwsMockRecorder) AwsListRootOutsideMonthBill(kt, opt, billInfo interface{}) *AwsAwsListRootOutsideMonthBillCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AwsListRootOutsideMonthBill", reflect.TypeOf((*MockAws)(nil).AwsListRootOutsideMonthBill), kt, opt, billInfo)
+	return &AwsAwsListRootOutsideMonthBillCall{Call: call}
+}
+
+// AwsAwsListRootOutsideMonthBillCall wrap *gomock.Call
+type AwsAwsListRootOutsideMonthBillCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsAwsListRootOutsideMonthBillCall) Return(arg0 []map[string]string, arg1 error) *AwsAwsListRootOutsideMonthBillCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsAwsListRootOutsideMonthBillCall) Do(f func(*kit.Kit, *bill.AwsMainOutsideMonthBillLitOpt, *bill0.RootAccountBillConfig[bill0.AwsBillConfigExtension]) ([]map[string]string, error)) *AwsAwsListRootOutsideMonthBillCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsAwsListRootOutsideMonthBillCall) DoAndReturn(f func(*kit.Kit, *bill.AwsMainOutsideMonthBillLitOpt, *bill0.RootAccountBillConfig[bill0.AwsBillConfigExtension]) ([]map[string]string, error)) *AwsAwsListRootOutsideMonthBillCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// BatchAssociateSecurityGroup mocks base method.
+func (m *MockAws) BatchAssociateSecurityGroup(kt *kit.Kit, opt *cvm.AwsAssociateSecurityGroupsOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchAssociateSecurityGroup", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchAssociateSecurityGroup indicates an expected call of BatchAssociateSecurityGroup.
+func (mr *MockAwsMockRecorder) BatchAssociateSecurityGroup(kt, opt interface{}) *AwsBatchAssociateSecurityGroupCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchAssociateSecurityGroup", reflect.TypeOf((*MockAws)(nil).BatchAssociateSecurityGroup), kt, opt)
+	return &AwsBatchAssociateSecurityGroupCall{Call: call}
+}
+
+// AwsBatchAssociateSecurityGroupCall wrap *gomock.Call
+type AwsBatchAssociateSecurityGroupCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsBatchAssociateSecurityGroupCall) Return(arg0 error) *AwsBatchAssociateSecurityGroupCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsBatchAssociateSecurityGroupCall) Do(f func(*kit.Kit, *cvm.AwsAssociateSecurityGroupsOption) error) *AwsBatchAssociateSecurityGroupCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsBatchAssociateSecurityGroupCall) DoAndReturn(f func(*kit.Kit, *cvm.AwsAssociateSecurityGroupsOption) error) *AwsBatchAssociateSecurityGroupCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CloudAccountID mocks base method.
+func (m *MockAws) CloudAccountID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudAccountID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// CloudAccountID indicates an expected call of CloudAccountID.
+func (mr *MockAwsMockRecorder) CloudAccountID() *AwsCloudAccountIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudAccountID", reflect.TypeOf((*MockAws)(nil).CloudAccountID))
+	return &AwsCloudAccountIDCall{Call: call}
+}
+
+// AwsCloudAccountIDCall wrap *gomock.Call
+type AwsCloudAccountIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsCloudAccountIDCall) Return(arg0 string) *AwsCloudAccountIDCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsCloudAccountIDCall) Do(f func() string) *AwsCloudAccountIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsCloudAccountIDCall) DoAndReturn(f func() string) *AwsCloudAccountIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountAccount mocks base method.
+func (m *MockAws) CountAccount(kt *kit.Kit) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAccount", kt)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAccount indicates an expected call of CountAccount.
+func (mr *MockAwsMockRecorder) CountAccount(kt interface{}) *AwsCountAccountCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAccount", reflect.TypeOf((*MockAws)(nil).CountAccount), kt)
+	return &AwsCountAccountCall{Call: call}
+}
+
+// AwsCountAccountCall wrap *gomock.Call
+type AwsCountAccountCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsCountAccountCall) Return(arg0 int32, arg1 error) *AwsCountAccountCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsCountAccountCall) Do(f func(*kit.Kit) (int32, error)) *AwsCountAccountCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsCountAccountCall) DoAndReturn(f func(*kit.Kit) (int32, error)) *AwsCountAccountCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountCvm mocks base method.
+func (m *MockAws) CountCvm(kt *kit.Kit, region string) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCvm", kt, region)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCvm indicates an expected call of CountCvm.
+func (mr *MockAwsMockRecorder) CountCvm(kt, region interface{}) *AwsCountCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCvm", reflect.TypeOf((*MockAws)(nil).CountCvm), kt, region)
+	return &AwsCountCvmCall{Call: call}
+}
+
+// AwsCountCvmCall wrap *gomock.Call
+type AwsCountCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsCountCvmCall) Return(arg0 int32, arg1 error) *AwsCountCvmCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsCountCvmCall) Do(f func(*kit.Kit, string) (int32, error)) *AwsCountCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsCountCvmCall) DoAndReturn(f func(*kit.Kit, string) (int32, error)) *AwsCountCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountDisk mocks base method.
+func (m *MockAws) CountDisk(kt *kit.Kit, region string) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDisk", kt, region)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDisk indicates an expected call of CountDisk.
+func (mr *MockAwsMockRecorder) CountDisk(kt, region interface{}) *AwsCountDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDisk", reflect.TypeOf((*MockAws)(nil).CountDisk), kt, region)
+	return &AwsCountDiskCall{Call: call}
+}
+
+// AwsCountDiskCall wrap *gomock.Call
+type AwsCountDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsCountDiskCall) Return(arg0 int32, arg1 error) *AwsCountDiskCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsCountDiskCall) Do(f func(*kit.Kit, string) (int32, error)) *AwsCountDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsCountDiskCall) DoAndReturn(f func(*kit.Kit, string) (int32, error)) *AwsCountDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountEip mocks base method.
+func (m *MockAws) CountEip(kt *kit.Kit, region string) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountEip", kt, region)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountEip indicates an expected call of CountEip.
+func (mr *MockAwsMockRecorder) CountEip(kt, region interface{}) *AwsCountEipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEip", reflect.TypeOf((*MockAws)(nil).CountEip), kt, region)
+	return &AwsCountEipCall{Call: call}
+}
+
+// AwsCountEipCall wrap *gomock.Call
+type AwsCountEipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsCountEipCall) Return(arg0 int32, arg1 error) *AwsCountEipCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsCountEipCall) Do(f func(*kit.Kit, string) (int32, error)) *AwsCountEipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsCountEipCall) DoAndReturn(f func(*kit.Kit, string) (int32, error)) *AwsCountEipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountRouteTable mocks base method.
+func (m *MockAws) CountRouteTable(kt *kit.Kit, region string) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRouteTable", kt, region)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRouteTable indicates an expected call of CountRouteTable.
+func (mr *MockAwsMockRecorder) CountRouteTable(kt, region interface{}) *AwsCountRouteTableCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRouteTable", reflect.TypeOf((*MockAws)(nil).CountRouteTable), kt, region)
+	return &AwsCountRouteTableCall{Call: call}
+}
+
+// AwsCountRouteTableCall wrap *gomock.Call
+type AwsCountRouteTableCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsCountRouteTableCall) Return(arg0 int32, arg1 error) *AwsCountRouteTableCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsCountRouteTableCall) Do(f func(*kit.Kit, string) (int32, error)) *AwsCountRouteTableCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsCountRouteTableCall) DoAndReturn(f func(*kit.Kit, string) (int32, error)) *AwsCountRouteTableCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountSecurityGroup mocks base method.
+func (m *MockAws) CountSecurityGroup(kt *kit.Kit, region string) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSecurityGroup", kt, region)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSecurityGroup indicates an expected call of CountSecurityGroup.
+func (mr *MockAwsMockRecorder) CountSecurityGroup(kt, region interface{}) *AwsCountSecurityGroupCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSecurityGroup", reflect.TypeOf((*MockAws)(nil).CountSecurityGroup), kt, region)
+	return &AwsCountSecurityGroupCall{Call: call}
+}
+
+// AwsCountSecurityGroupCall wrap *gomock.Call
+type AwsCountSecurityGroupCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsCountSecurityGroupCall) Return(arg0 int32, arg1 error) *AwsCountSecurityGroupCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsCountSecurityGroupCall) Do(f func(*kit.Kit, string) (int32, error)) *AwsCountSecurityGroupCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsCountSecurityGroupCall) DoAndReturn(f func(*kit.Kit, string) (int32, error)) *AwsCountSecurityGroupCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountSubnet mocks base method.
+func (m *MockAws) CountSubnet(kt *kit.Kit, region string) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSubnet", kt, region)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSubnet indicates an expected call of CountSubnet.
+func (mr *MockAwsMockRecorder) CountSubnet(kt, region interface{}) *AwsCountSubnetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSubnet", reflect.TypeOf((*MockAws)(nil).CountSubnet), kt, region)
+	return &AwsCountSubnetCall{Call: call}
+}
+
+// AwsCountSubnetCall wrap *gomock.Call
+type AwsCountSubnetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsCountSubnetCall) Return(arg0 int32, arg1 error) *AwsCountSubnetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsCountSubnetCall) Do(f func(*kit.Kit, string) (int32, error)) *AwsCountSubnetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsCountSubnetCall) DoAndReturn(f func(*kit.Kit, string) (int32, error)) *AwsCountSubnetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountVpc mocks base method.
+func (m *MockAws) CountVpc(kt *kit.Kit, region string) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountVpc", kt, region)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountVpc indicates an expected call of CountVpc.
+func (mr *MockAwsMockRecorder) CountVpc(kt, region interface{}) *AwsCountVpcCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountVpc", reflect.TypeOf((*MockAws)(nil).CountVpc), kt, region)
+	return &AwsCountVpcCall{Call: call}
+}
+
+// AwsCountVpcCall wrap *gomock.Call
+type AwsCountVpcCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsCountVpcCall) Return(arg0 int32, arg1 error) *AwsCountVpcCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsCountVpcCall) Do(f func(*kit.Kit, string) (int32, error)) *AwsCountVpcCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsCountVpcCall) DoAndReturn(f func(*kit.Kit, string) (int32, error)) *AwsCountVpcCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateAccount mocks base method.
+func (m *MockAws) CreateAccount(kt *kit.Kit, req *hsmainaccount.CreateAwsMainAccountReq) (*hsmainaccount.CreateAwsMainAccountResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccount", kt, req)
+	ret0, _ := ret[0].(*hsmainaccount.CreateAwsMainAccountResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccount indicates an expected call of CreateAccount.
+func (mr *MockAwsMockRecorder) CreateAccount(kt, req interface{}) *AwsCreateAccountCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockAws)(nil).CreateAccount), kt, req)
+	return &AwsCreateAccountCall{Call: call}
+}
+
+// AwsCreateAccountCall wrap *gomock.Call
+type AwsCreateAccountCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsCreateAccountCall) Return(arg0 *hsmainaccount.CreateAwsMainAccountResp, arg1 error) *AwsCreateAccountCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsCreateAccountCall) Do(f func(*kit.Kit, *hsmainaccount.CreateAwsMainAccountReq) (*hsmainaccount.CreateAwsMainAccountResp, error)) *AwsCreateAccountCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsCreateAccountCall) DoAndReturn(f func(*kit.Kit, *hsmainaccount.CreateAwsMainAccountReq) (*hsmainaccount.CreateAwsMainAccountResp, error)) *AwsCreateAccountCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateBucket mocks base method.
+func (m *MockAws) CreateBucket(kt *kit.Kit, opt *bill.AwsBillBucketCreateReq) (*string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBucket", kt, opt)
+	ret0, _ := ret[0].(*string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBucket indicates an expected call of CreateBucket.
+func (mr *MockAwsMockRecorder) CreateBucket(kt, opt interface{}) *AwsCreateBucketCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBucket", reflect.TypeOf((*MockAws)(nil).CreateBucket), kt, opt)
+	return &AwsCreateBucketCall{Call: call}
+}
+
+// AwsCreateBucketCall wrap *gomock.Call
+type AwsCreateBucketCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsCreateBucketCall) Return(arg0 *string, arg1 error) *AwsCreateBucketCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsCreateBucketCall) Do(f func(*kit.Kit, *bill.AwsBillBucketCreateReq) (*string, error)) *AwsCreateBucketCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsCreateBucketCall) DoAndReturn(f func(*kit.Kit, *bill.AwsBillBucketCreateReq) (*string, error)) *AwsCreateBucketCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateCvm mocks base method.
+func (m *MockAws) CreateCvm(kt *kit.Kit, opt *cvm.AwsCreateOption) (*poller.BaseDoneResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCvm", kt, opt)
+	ret0, _ := ret[0].(*poller.BaseDoneResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCvm indicates an expected call of CreateCvm.
+func (mr *MockAwsMockRecorder) CreateCvm(kt, opt interface{}) *AwsCreateCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCvm", reflect.TypeOf((*MockAws)(nil).CreateCvm), kt, opt)
+	return &AwsCreateCvmCall{Call: call}
+}
+
+// AwsCreateCvmCall wrap *gomock.Call
+type AwsCreateCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsCreateCvmCall) Return(arg0 *poller.BaseDoneResult, arg1 error) *AwsCreateCvmCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsCreateCvmCall) Do(f func(*kit.Kit, *cvm.AwsCreateOption) (*poller.BaseDoneResult, error)) *AwsCreateCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsCreateCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.AwsCreateOption) (*poller.BaseDoneResult, error)) *AwsCreateCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateDefaultSubnet mocks base method.
+func (m *MockAws) CreateDefaultSubnet(kt *kit.Kit, opt *adtysubnet.AwsDefaultSubnetCreateOption) (*adtysubnet.AwsSubnet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDefaultSubnet", kt, opt)
+	ret0, _ := ret[0].(*adtysubnet.AwsSubnet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDefaultSubnet indicates an expected call of CreateDefaultSubnet.
+func (mr *MockAwsMockRecorder) CreateDefaultSubnet(kt, opt interface{}) *AwsCreateDefaultSubnetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDefaultSubnet", reflect.TypeOf((*MockAws)(nil).CreateDefaultSubnet), kt, opt)
+	return &AwsCreateDefaultSubnetCall{Call: call}
+}
+
+// AwsCreateDefaultSubnetCall wrap *gomock.Call
+type AwsCreateDefaultSubnetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsCreateDefaultSubnetCall) Return(arg0 *adtysubnet.AwsSubnet, arg1 error) *AwsCreateDefaultSubnetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsCreateDefaultSubnetCall) Do(f func(*kit.Kit, *adtysubnet.AwsDefaultSubnetCreateOption) (*adtysubnet.AwsSubnet, error)) *AwsCreateDefaultSubnetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsCreateDefaultSubnetCall) DoAndReturn(f func(*kit.Kit, *adtysubnet.AwsDefaultSubnetCreateOption) (*adtysubnet.AwsSubnet, error)) *AwsCreateDefaultSubnetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateDisk mocks base method.
+func (m *MockAws) CreateDisk(kt *kit.Kit, opt *disk.AwsDiskCreateOption) (*poller.BaseDoneResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDisk", kt, opt)
+	ret0, _ := ret[0].(*poller.BaseDoneResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDisk indicates an expected call of CreateDisk.
+func (mr *MockAwsMockRecorder) CreateDisk(kt, opt interface{}) *AwsCreateDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDisk", reflect.TypeOf((*MockAws)(nil).CreateDisk), kt, opt)
+	return &AwsCreateDiskCall{Call: call}
+}
+
+// AwsCreateDiskCall wrap *gomock.Call
+type AwsCreateDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsCreateDiskCall) Return(arg0 *poller.BaseDoneResult, arg1 error) *AwsCreateDiskCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsCreateDiskCall) Do(f func(*kit.Kit, *disk.AwsDiskCreateOption) (*poller.BaseDoneResult, error)) *AwsCreateDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsCreateDiskCall) DoAndReturn(f func(*kit.Kit, *disk.AwsDiskCreateOption) (*poller.BaseDoneResult, error)) *AwsCreateDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateEip mocks base method.
+func (m *MockAws) CreateEip(kt *kit.Kit, opt *eip.AwsEipCreateOption) (*string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEip", kt, opt)
+	ret0, _ := ret[0].(*string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEip indicates an expected call of CreateEip.
+func (mr *MockAwsMockRecorder) CreateEip(kt, opt interface{}) *AwsCreateEipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEip", reflect.TypeOf((*MockAws)(nil).CreateEip), kt, opt)
+	return &AwsCreateEipCall{Call: call}
+}
+
+// AwsCreateEipCall wrap *gomock.Call
+type AwsCreateEipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsCreateEipCall) Return(arg0 *string, arg1 error) *AwsCreateEipCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsCreateEipCall) Do(f func(*kit.Kit, *eip.AwsEipCreateOption) (*string, error)) *AwsCreateEipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsCreateEipCall) DoAndReturn(f func(*kit.Kit, *eip.AwsEipCreateOption) (*string, error)) *AwsCreateEipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateSecurityGroup mocks base method.
+func (m *MockAws) CreateSecurityGroup(kt *kit.Kit, opt *securitygroup.AwsCreateOption) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSecurityGroup", kt, opt)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSecurityGroup indicates an expected call of CreateSecurityGroup.
+func (mr *MockAwsMockRecorder) CreateSecurityGroup(kt, opt interface{}) *AwsCreateSecurityGroupCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecurityGroup", reflect.TypeOf((*MockAws)(nil).CreateSecurityGroup), kt, opt)
+	return &AwsCreateSecurityGroupCall{Call: call}
+}
+
+// AwsCreateSecurityGroupCall wrap *gomock.Call
+type AwsCreateSecurityGroupCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsCreateSecurityGroupCall) Return(arg0 string, arg1 error) *AwsCreateSecurityGroupCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsCreateSecurityGroupCall) Do(f func(*kit.Kit, *securitygroup.AwsCreateOption) (string, error)) *AwsCreateSecurityGroupCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsCreateSecurityGroupCall) DoAndReturn(f func(*kit.Kit, *securitygroup.AwsCreateOption) (string, error)) *AwsCreateSecurityGroupCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateSecurityGroupRule mocks base method.
+func (m *MockAws) CreateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AwsCreateOption) ([]*ec2.SecurityGroupRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSecurityGroupRule", kt, opt)
+	ret0, _ := ret[0].([]*ec2.SecurityGroupRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSecurityGroupRule indicates an expected call of CreateSecurityGroupRule.
+func (mr *MockAwsMockRecorder) CreateSecurityGroupRule(kt, opt interface{}) *AwsCreateSecurityGroupRuleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecurityGroupRule", reflect.TypeOf((*MockAws)(nil).CreateSecurityGroupRule), kt, opt)
+	return &AwsCreateSecurityGroupRuleCall{Call: call}
+}
+
+// AwsCreateSecurityGroupRuleCall wrap *gomock.Call
+type AwsCreateSecurityGroupRuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsCreateSecurityGroupRuleCall) Return(arg0 []*ec2.SecurityGroupRule, arg1 error) *AwsCreateSecurityGroupRuleCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsCreateSecurityGroupRuleCall) Do(f func(*kit.Kit, *securitygrouprule.AwsCreateOption) ([]*ec2.SecurityGroupRule, error)) *AwsCreateSecurityGroupRuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsCreateSecurityGroupRuleCall) DoAndReturn(f func(*kit.Kit, *securitygrouprule.AwsCreateOption) ([]*ec2.SecurityGroupRule, error)) *AwsCreateSecurityGroupRuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateStack mocks base method.
+func (m *MockAws) CreateStack(kt *kit.Kit, opt *bill.AwsCreateStackReq) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStack", kt, opt)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStack indicates an expected call of CreateStack.
+func (mr *MockAwsMockRecorder) CreateStack(kt, opt interface{}) *AwsCreateStackCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStack", reflect.TypeOf((*MockAws)(nil).CreateStack), kt, opt)
+	return &AwsCreateStackCall{Call: call}
+}
+
+// AwsCreateStackCall wrap *gomock.Call
+type AwsCreateStackCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsCreateStackCall) Return(arg0 string, arg1 error) *AwsCreateStackCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsCreateStackCall) Do(f func(*kit.Kit, *bill.AwsCreateStackReq) (string, error)) *AwsCreateStackCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsCreateStackCall) DoAndReturn(f func(*kit.Kit, *bill.AwsCreateStackReq) (string, error)) *AwsCreateStackCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateSubnet mocks base method.
+func (m *MockAws) CreateSubnet(kt *kit.Kit, opt *adtysubnet.AwsSubnetCreateOption) (*adtysubnet.AwsSubnet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubnet", kt, opt)
+	ret0, _ := ret[0].(*adtysubnet.AwsSubnet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubnet indicates an expected call of CreateSubnet.
+func (mr *MockAwsMockRecorder) CreateSubnet(kt, opt interface{}) *AwsCreateSubnetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubnet", reflect.TypeOf((*MockAws)(nil).CreateSubnet), kt, opt)
+	return &AwsCreateSubnetCall{Call: call}
+}
+
+// AwsCreateSubnetCall wrap *gomock.Call
+type AwsCreateSubnetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsCreateSubnetCall) Return(arg0 *adtysubnet.AwsSubnet, arg1 error) *AwsCreateSubnetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsCreateSubnetCall) Do(f func(*kit.Kit, *adtysubnet.AwsSubnetCreateOption) (*adtysubnet.AwsSubnet, error)) *AwsCreateSubnetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsCreateSubnetCall) DoAndReturn(f func(*kit.Kit, *adtysubnet.AwsSubnetCreateOption) (*adtysubnet.AwsSubnet, error)) *AwsCreateSubnetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateVpc mocks base method.
+func (m *MockAws) CreateVpc(kt *kit.Kit, opt *types.AwsVpcCreateOption) (*types.AwsVpc, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVpc", kt, opt)
+	ret0, _ := ret[0].(*types.AwsVpc)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVpc indicates an expected call of CreateVpc.
+func (mr *MockAwsMockRecorder) CreateVpc(kt, opt interface{}) *AwsCreateVpcCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVpc", reflect.TypeOf((*MockAws)(nil).CreateVpc), kt, opt)
+	return &AwsCreateVpcCall{Call: call}
+}
+
+// AwsCreateVpcCall wrap *gomock.Call
+type AwsCreateVpcCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsCreateVpcCall) Return(arg0 *types.AwsVpc, arg1 error) *AwsCreateVpcCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsCreateVpcCall) Do(f func(*kit.Kit, *types.AwsVpcCreateOption) (*types.AwsVpc, error)) *AwsCreateVpcCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsCreateVpcCall) DoAndReturn(f func(*kit.Kit, *types.AwsVpcCreateOption) (*types.AwsVpc, error)) *AwsCreateVpcCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DefaultRegion mocks base method.
+func (m *MockAws) DefaultRegion() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DefaultRegion")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// DefaultRegion indicates an expected call of DefaultRegion.
+func (mr *MockAwsMockRecorder) DefaultRegion() *AwsDefaultRegionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DefaultRegion", reflect.TypeOf((*MockAws)(nil).DefaultRegion))
+	return &AwsDefaultRegionCall{Call: call}
+}
+
+// AwsDefaultRegionCall wrap *gomock.Call
+type AwsDefaultRegionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsDefaultRegionCall) Return(arg0 string) *AwsDefaultRegionCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsDefaultRegionCall) Do(f func() string) *AwsDefaultRegionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsDefaultRegionCall) DoAndReturn(f func() string) *AwsDefaultRegionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteBucket mocks base method.
+func (m *MockAws) DeleteBucket(kt *kit.Kit, opt *bill.AwsBillBucketDeleteReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBucket", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBucket indicates an expected call of DeleteBucket.
+func (mr *MockAwsMockRecorder) DeleteBucket(kt, opt interface{}) *AwsDeleteBucketCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBucket", reflect.TypeOf((*MockAws)(nil).DeleteBucket), kt, opt)
+	return &AwsDeleteBucketCall{Call: call}
+}
+
+// AwsDeleteBucketCall wrap *gomock.Call
+type AwsDeleteBucketCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsDeleteBucketCall) Return(arg0 error) *AwsDeleteBucketCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsDeleteBucketCall) Do(f func(*kit.Kit, *bill.AwsBillBucketDeleteReq) error) *AwsDeleteBucketCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsDeleteBucketCall) DoAndReturn(f func(*kit.Kit, *bill.AwsBillBucketDeleteReq) error) *AwsDeleteBucketCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteCvm mocks base method.
+func (m *MockAws) DeleteCvm(kt *kit.Kit, opt *cvm.AwsDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCvm", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCvm indicates an expected call of DeleteCvm.
+func (mr *MockAwsMockRecorder) DeleteCvm(kt, opt interface{}) *AwsDeleteCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCvm", reflect.TypeOf((*MockAws)(nil).DeleteCvm), kt, opt)
+	return &AwsDeleteCvmCall{Call: call}
+}
+
+// AwsDeleteCvmCall wrap *gomock.Call
+type AwsDeleteCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsDeleteCvmCall) Return(arg0 error) *AwsDeleteCvmCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsDeleteCvmCall) Do(f func(*kit.Kit, *cvm.AwsDeleteOption) error) *AwsDeleteCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsDeleteCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.AwsDeleteOption) error) *AwsDeleteCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteDisk mocks base method.
+func (m *MockAws) DeleteDisk(kt *kit.Kit, opt *disk.AwsDiskDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDisk", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDisk indicates an expected call of DeleteDisk.
+func (mr *MockAwsMockRecorder) DeleteDisk(kt, opt interface{}) *AwsDeleteDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDisk", reflect.TypeOf((*MockAws)(nil).DeleteDisk), kt, opt)
+	return &AwsDeleteDiskCall{Call: call}
+}
+
+// AwsDeleteDiskCall wrap *gomock.Call
+type AwsDeleteDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsDeleteDiskCall) Return(arg0 error) *AwsDeleteDiskCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsDeleteDiskCall) Do(f func(*kit.Kit, *disk.AwsDiskDeleteOption) error) *AwsDeleteDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsDeleteDiskCall) DoAndReturn(f func(*kit.Kit, *disk.AwsDiskDeleteOption) error) *AwsDeleteDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteEip mocks base method.
+func (m *MockAws) DeleteEip(kt *kit.Kit, opt *eip.AwsEipDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEip", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEip indicates an expected call of DeleteEip.
+func (mr *MockAwsMockRecorder) DeleteEip(kt, opt interface{}) *AwsDeleteEipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEip", reflect.TypeOf((*MockAws)(nil).DeleteEip), kt, opt)
+	return &AwsDeleteEipCall{Call: call}
+}
+
+// AwsDeleteEipCall wrap *gomock.Call
+type AwsDeleteEipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsDeleteEipCall) Return(arg0 error) *AwsDeleteEipCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsDeleteEipCall) Do(f func(*kit.Kit, *eip.AwsEipDeleteOption) error) *AwsDeleteEipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsDeleteEipCall) DoAndReturn(f func(*kit.Kit, *eip.AwsEipDeleteOption) error) *AwsDeleteEipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteReportDefinition mocks base method.
+func (m *MockAws) DeleteReportDefinition(kt *kit.Kit, opt *bill.AwsBillDeleteReportDefinitionReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReportDefinition", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReportDefinition indicates an expected call of DeleteReportDefinition.
+func (mr *MockAwsMockRecorder) DeleteReportDefinition(kt, opt interface{}) *AwsDeleteReportDefinitionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReportDefinition", reflect.TypeOf((*MockAws)(nil).DeleteReportDefinition), kt, opt)
+	return &AwsDeleteReportDefinitionCall{Call: call}
+}
+
+// AwsDeleteReportDefinitionCall wrap *gomock.Call
+type AwsDeleteReportDefinitionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsDeleteReportDefinitionCall) Return(arg0 error) *AwsDeleteReportDefinitionCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsDeleteReportDefinitionCall) Do(f func(*kit.Kit, *bill.AwsBillDeleteReportDefinitionReq) error) *AwsDeleteReportDefinitionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsDeleteReportDefinitionCall) DoAndReturn(f func(*kit.Kit, *bill.AwsBillDeleteReportDefinitionReq) error) *AwsDeleteReportDefinitionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteRouteTable mocks base method.
+func (m *MockAws) DeleteRouteTable(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRouteTable", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRouteTable indicates an expected call of DeleteRouteTable.
+func (mr *MockAwsMockRecorder) DeleteRouteTable(kt, opt interface{}) *AwsDeleteRouteTableCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRouteTable", reflect.TypeOf((*MockAws)(nil).DeleteRouteTable), kt, opt)
+	return &AwsDeleteRouteTableCall{Call: call}
+}
+
+// AwsDeleteRouteTableCall wrap *gomock.Call
+type AwsDeleteRouteTableCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsDeleteRouteTableCall) Return(arg0 error) *AwsDeleteRouteTableCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsDeleteRouteTableCall) Do(f func(*kit.Kit, *core.BaseRegionalDeleteOption) error) *AwsDeleteRouteTableCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsDeleteRouteTableCall) DoAndReturn(f func(*kit.Kit, *core.BaseRegionalDeleteOption) error) *AwsDeleteRouteTableCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteSecurityGroup mocks base method.
+func (m *MockAws) DeleteSecurityGroup(kt *kit.Kit, opt *securitygroup.AwsDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecurityGroup", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecurityGroup indicates an expected call of DeleteSecurityGroup.
+func (mr *MockAwsMockRecorder) DeleteSecurityGroup(kt, opt interface{}) *AwsDeleteSecurityGroupCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecurityGroup", reflect.TypeOf((*MockAws)(nil).DeleteSecurityGroup), kt, opt)
+	return &AwsDeleteSecurityGroupCall{Call: call}
+}
+
+// AwsDeleteSecurityGroupCall wrap *gomock.Call
+type AwsDeleteSecurityGroupCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsDeleteSecurityGroupCall) Return(arg0 error) *AwsDeleteSecurityGroupCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsDeleteSecurityGroupCall) Do(f func(*kit.Kit, *securitygroup.AwsDeleteOption) error) *AwsDeleteSecurityGroupCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsDeleteSecurityGroupCall) DoAndReturn(f func(*kit.Kit, *securitygroup.AwsDeleteOption) error) *AwsDeleteSecurityGroupCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteSecurityGroupRule mocks base method.
+func (m *MockAws) DeleteSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AwsDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecurityGroupRule", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecurityGroupRule indicates an expected call of DeleteSecurityGroupRule.
+func (mr *MockAwsMockRecorder) DeleteSecurityGroupRule(kt, opt interface{}) *AwsDeleteSecurityGroupRuleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecurityGroupRule", reflect.TypeOf((*MockAws)(nil).DeleteSecurityGroupRule), kt, opt)
+	return &AwsDeleteSecurityGroupRuleCall{Call: call}
+}
+
+// AwsDeleteSecurityGroupRuleCall wrap *gomock.Call
+type AwsDeleteSecurityGroupRuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsDeleteSecurityGroupRuleCall) Return(arg0 error) *AwsDeleteSecurityGroupRuleCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsDeleteSecurityGroupRuleCall) Do(f func(*kit.Kit, *securitygrouprule.AwsDeleteOption) error) *AwsDeleteSecurityGroupRuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsDeleteSecurityGroupRuleCall) DoAndReturn(f func(*kit.Kit, *securitygrouprule.AwsDeleteOption) error) *AwsDeleteSecurityGroupRuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteStack mocks base method.
+func (m *MockAws) DeleteStack(kt *kit.Kit, opt *bill.AwsDeleteStackReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStack", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStack indicates an expected call of DeleteStack.
+func (mr *MockAwsMockRecorder) DeleteStack(kt, opt interface{}) *AwsDeleteStackCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStack", reflect.TypeOf((*MockAws)(nil).DeleteStack), kt, opt)
+	return &AwsDeleteStackCall{Call: call}
+}
+
+// AwsDeleteStackCall wrap *gomock.Call
+type AwsDeleteStackCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsDeleteStackCall) Return(arg0 error) *AwsDeleteStackCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsDeleteStackCall) Do(f func(*kit.Kit, *bill.AwsDeleteStackReq) error) *AwsDeleteStackCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsDeleteStackCall) DoAndReturn(f func(*kit.Kit, *bill.AwsDeleteStackReq) error) *AwsDeleteStackCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteSubnet mocks base method.
+func (m *MockAws) DeleteSubnet(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubnet", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubnet indicates an expected call of DeleteSubnet.
+func (mr *MockAwsMockRecorder) DeleteSubnet(kt, opt interface{}) *AwsDeleteSubnetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubnet", reflect.TypeOf((*MockAws)(nil).DeleteSubnet), kt, opt)
+	return &AwsDeleteSubnetCall{Call: call}
+}
+
+// AwsDeleteSubnetCall wrap *gomock.Call
+type AwsDeleteSubnetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsDeleteSubnetCall) Return(arg0 error) *AwsDeleteSubnetCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsDeleteSubnetCall) Do(f func(*kit.Kit, *core.BaseRegionalDeleteOption) error) *AwsDeleteSubnetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsDeleteSubnetCall) DoAndReturn(f func(*kit.Kit, *core.BaseRegionalDeleteOption) error) *AwsDeleteSubnetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteVpc mocks base method.
+func (m *MockAws) DeleteVpc(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVpc", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVpc indicates an expected call of DeleteVpc.
+func (mr *MockAwsMockRecorder) DeleteVpc(kt, opt interface{}) *AwsDeleteVpcCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVpc", reflect.TypeOf((*MockAws)(nil).DeleteVpc), kt, opt)
+	return &AwsDeleteVpcCall{Call: call}
+}
+
+// AwsDeleteVpcCall wrap *gomock.Call
+type AwsDeleteVpcCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsDeleteVpcCall) Return(arg0 error) *AwsDeleteVpcCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsDeleteVpcCall) Do(f func(*kit.Kit, *core.BaseRegionalDeleteOption) error) *AwsDeleteVpcCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsDeleteVpcCall) DoAndReturn(f func(*kit.Kit, *core.BaseRegionalDeleteOption) error) *AwsDeleteVpcCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DescribeStack mocks base method.
+func (m *MockAws) DescribeStack(kt *kit.Kit, opt *bill.AwsDeleteStackReq) ([]*cloudformation.Stack, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeStack", kt, opt)
+	ret0, _ := ret[0].([]*cloudformation.Stack)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeStack indicates an expected call of DescribeStack.
+func (mr *MockAwsMockRecorder) DescribeStack(kt, opt interface{}) *AwsDescribeStackCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeStack", reflect.TypeOf((*MockAws)(nil).DescribeStack), kt, opt)
+	return &AwsDescribeStackCall{Call: call}
+}
+
+// AwsDescribeStackCall wrap *gomock.Call
+type AwsDescribeStackCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsDescribeStackCall) Return(arg0 []*cloudformation.Stack, arg1 error) *AwsDescribeStackCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsDescribeStackCall) Do(f func(*kit.Kit, *bill.AwsDeleteStackReq) ([]*cloudformation.Stack, error)) *AwsDescribeStackCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsDescribeStackCall) DoAndReturn(f func(*kit.Kit, *bill.AwsDeleteStackReq) ([]*cloudformation.Stack, error)) *AwsDescribeStackCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DetachDisk mocks base method.
+func (m *MockAws) DetachDisk(kt *kit.Kit, opt *disk.AwsDiskDetachOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachDisk", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachDisk indicates an expected call of DetachDisk.
+func (mr *MockAwsMockRecorder) DetachDisk(kt, opt interface{}) *AwsDetachDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachDisk", reflect.TypeOf((*MockAws)(nil).DetachDisk), kt, opt)
+	return &AwsDetachDiskCall{Call: call}
+}
+
+// AwsDetachDiskCall wrap *gomock.Call
+type AwsDetachDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsDetachDiskCall) Return(arg0 error) *AwsDetachDiskCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsDetachDiskCall) Do(f func(*kit.Kit, *disk.AwsDiskDetachOption) error) *AwsDetachDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsDetachDiskCall) DoAndReturn(f func(*kit.Kit, *disk.AwsDiskDetachOption) error) *AwsDetachDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DisassociateEip mocks base method.
+func (m *MockAws) DisassociateEip(kt *kit.Kit, opt *eip.AwsEipDisassociateOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisassociateEip", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisassociateEip indicates an expected call of DisassociateEip.
+func (mr *MockAwsMockRecorder) DisassociateEip(kt, opt interface{}) *AwsDisassociateEipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisassociateEip", reflect.TypeOf((*MockAws)(nil).DisassociateEip), kt, opt)
+	return &AwsDisassociateEipCall{Call: call}
+}
+
+// AwsDisassociateEipCall wrap *gomock.Call
+type AwsDisassociateEipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsDisassociateEipCall) Return(arg0 error) *AwsDisassociateEipCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsDisassociateEipCall) Do(f func(*kit.Kit, *eip.AwsEipDisassociateOption) error) *AwsDisassociateEipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsDisassociateEipCall) DoAndReturn(f func(*kit.Kit, *eip.AwsEipDisassociateOption) error) *AwsDisassociateEipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAccountInfoBySecret mocks base method.
+func (m *MockAws) GetAccountInfoBySecret(kt *kit.Kit) (*cloud.AwsInfoBySecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountInfoBySecret", kt)
+	ret0, _ := ret[0].(*cloud.AwsInfoBySecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountInfoBySecret indicates an expected call of GetAccountInfoBySecret.
+func (mr *MockAwsMockRecorder) GetAccountInfoBySecret(kt interface{}) *AwsGetAccountInfoBySecretCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountInfoBySecret", reflect.TypeOf((*MockAws)(nil).GetAccountInfoBySecret), kt)
+	return &AwsGetAccountInfoBySecretCall{Call: call}
+}
+
+// AwsGetAccountInfoBySecretCall wrap *gomock.Call
+type AwsGetAccountInfoBySecretCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsGetAccountInfoBySecretCall) Return(arg0 *cloud.AwsInfoBySecret, arg1 error) *AwsGetAccountInfoBySecretCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsGetAccountInfoBySecretCall) Do(f func(*kit.Kit) (*cloud.AwsInfoBySecret, error)) *AwsGetAccountInfoBySecretCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsGetAccountInfoBySecretCall) DoAndReturn(f func(*kit.Kit) (*cloud.AwsInfoBySecret, error)) *AwsGetAccountInfoBySecretCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAwsAthenaQuery mocks base method.
+func (m *MockAws) GetAwsAthenaQuery(kt *kit.Kit, query string, billInfo *cloud.AccountBillConfig[cloud.AwsBillConfigExtension]) ([]map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAwsAthenaQuery", kt, query, billInfo)
+	ret0, _ := ret[0].([]map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAwsAthenaQuery indicates an expected call of GetAwsAthenaQuery.
+func (mr *MockAwsMockRecorder) GetAwsAthenaQuery(kt, query, billInfo interface{}) *AwsGetAwsAthenaQueryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAwsAthenaQuery", reflect.TypeOf((*MockAws)(nil).GetAwsAthenaQuery), kt, query, billInfo)
+	return &AwsGetAwsAthenaQueryCall{Call: call}
+}
+
+// AwsGetAwsAthenaQueryCall wrap *gomock.Call
+type AwsGetAwsAthenaQueryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsGetAwsAthenaQueryCall) Return(arg0 []map[string]string, arg1 error) *AwsGetAwsAthenaQueryCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsGetAwsAthenaQueryCall) Do(f func(*kit.Kit, string, *cloud.AccountBillConfig[cloud.AwsBillConfigExtension]) ([]map[string]string, error)) *AwsGetAwsAthenaQueryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsGetAwsAthenaQueryCall) DoAndReturn(f func(*kit.Kit, string, *cloud.AccountBillConfig[cloud.AwsBillConfigExtension]) ([]map[string]string, error)) *AwsGetAwsAthenaQueryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetBillList mocks base method.
+func (m *MockAws) GetBillList(kt *kit.Kit, opt *bill.AwsBillListOption, billInfo *cloud.AccountBillConfig[cloud.AwsBillConfigExtension]) (int64, []map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBillList", kt, opt, billInfo)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]map[string]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBillList indicates an expected call of GetBillList.
+func (mr *MockAwsMockRecorder) GetBillList(kt, opt, billInfo interface{}) *AwsGetBillListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBillList", reflect.TypeOf((*MockAws)(nil).GetBillList), kt, opt, billInfo)
+	return &AwsGetBillListCall{Call: call}
+}
+
+// AwsGetBillListCall wrap *gomock.Call
+type AwsGetBillListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsGetBillListCall) Return(arg0 int64, arg1 []map[string]string, arg2 error) *AwsGetBillListCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsGetBillListCall) Do(f func(*kit.Kit, *bill.AwsBillListOption, *cloud.AccountBillConfig[cloud.AwsBillConfigExtension]) (int64, []map[string]string, error)) *AwsGetBillListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsGetBillListCall) DoAndReturn(f func(*kit.Kit, *bill.AwsBillListOption, *cloud.AccountBillConfig[cloud.AwsBillConfigExtension]) (int64, []map[string]string, error)) *AwsGetBillListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetBillTotal mocks base method.
+func (m *MockAws) GetBillTotal(kt *kit.Kit, where string, billInfo *cloud.AccountBillConfig[cloud.AwsBillConfigExtension]) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBillTotal", kt, where, billInfo)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBillTotal indicates an expected call of GetBillTotal.
+func (mr *MockAwsMockRecorder) GetBillTotal(kt, where, billInfo interface{}) *AwsGetBillTotalCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBillTotal", reflect.TypeOf((*MockAws)(nil).GetBillTotal), kt, where, billInfo)
+	return &AwsGetBillTotalCall{Call: call}
+}
+
+// AwsGetBillTotalCall wrap *gomock.Call
+type AwsGetBillTotalCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsGetBillTotalCall) Return(arg0 int64, arg1 error) *AwsGetBillTotalCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsGetBillTotalCall) Do(f func(*kit.Kit, string, *cloud.AccountBillConfig[cloud.AwsBillConfigExtension]) (int64, error)) *AwsGetBillTotalCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsGetBillTotalCall) DoAndReturn(f func(*kit.Kit, string, *cloud.AccountBillConfig[cloud.AwsBillConfigExtension]) (int64, error)) *AwsGetBillTotalCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetBucketPolicy mocks base method.
+func (m *MockAws) GetBucketPolicy(kt *kit.Kit, opt *bill.AwsBillBucketPolicyReq) (*string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBucketPolicy", kt, opt)
+	ret0, _ := ret[0].(*string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBucketPolicy indicates an expected call of GetBucketPolicy.
+func (mr *MockAwsMockRecorder) GetBucketPolicy(kt, opt interface{}) *AwsGetBucketPolicyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBucketPolicy", reflect.TypeOf((*MockAws)(nil).GetBucketPolicy), kt, opt)
+	return &AwsGetBucketPolicyCall{Call: call}
+}
+
+// AwsGetBucketPolicyCall wrap *gomock.Call
+type AwsGetBucketPolicyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsGetBucketPolicyCall) Return(arg0 *string, arg1 error) *AwsGetBucketPolicyCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsGetBucketPolicyCall) Do(f func(*kit.Kit, *bill.AwsBillBucketPolicyReq) (*string, error)) *AwsGetBucketPolicyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsGetBucketPolicyCall) DoAndReturn(f func(*kit.Kit, *bill.AwsBillBucketPolicyReq) (*string, error)) *AwsGetBucketPolicyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetMainAccountBillList mocks base method.
+func (m *MockAws) GetMainAccountBillList(kt *kit.Kit, opt *bill.AwsMainBillListOption, billInfo *bill0.RootAccountBillConfig[bill0.AwsBillConfigExtension]) (int64, []map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMainAccountBillList", kt, opt, billInfo)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]map[string]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMainAccountBillList indicates an expected call of GetMainAccountBillList.
+func (mr *MockAwsMockRecorder) GetMainAccountBillList(kt, opt, billInfo interface{}) *AwsGetMainAccountBillListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMainAccountBillList", reflect.TypeOf((*MockAws)(nil).GetMainAccountBillList), kt, opt, billInfo)
+	return &AwsGetMainAccountBillListCall{Call: call}
+}
+
+// AwsGetMainAccountBillListCall wrap *gomock.Call
+type AwsGetMainAccountBillListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsGetMainAccountBillListCall) Return(arg0 int64, arg1 []map[string]string, arg2 error) *AwsGetMainAccountBillListCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsGetMainAccountBillListCall) Do(f func(*kit.Kit, *bill.AwsMainBillListOption, *bill0.RootAccountBillConfig[bill0.AwsBillConfigExtension]) (int64, []map[string]string, error)) *AwsGetMainAccountBillListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsGetMainAccountBillListCall) DoAndReturn(f func(*kit.Kit, *bill.AwsMainBillListOption, *bill0.RootAccountBillConfig[bill0.AwsBillConfigExtension]) (int64, []map[string]string, error)) *AwsGetMainAccountBillListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetObject mocks base method.
+func (m *MockAws) GetObject(kt *kit.Kit, opt *bill.AwsBillGetObjectReq) (*s3.GetObjectOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObject", kt, opt)
+	ret0, _ := ret[0].(*s3.GetObjectOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObject indicates an expected call of GetObject.
+func (mr *MockAwsMockRecorder) GetObject(kt, opt interface{}) *AwsGetObjectCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObject", reflect.TypeOf((*MockAws)(nil).GetObject), kt, opt)
+	return &AwsGetObjectCall{Call: call}
+}
+
+// AwsGetObjectCall wrap *gomock.Call
+type AwsGetObjectCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsGetObjectCall) Return(arg0 *s3.GetObjectOutput, arg1 error) *AwsGetObjectCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsGetObjectCall) Do(f func(*kit.Kit, *bill.AwsBillGetObjectReq) (*s3.GetObjectOutput, error)) *AwsGetObjectCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsGetObjectCall) DoAndReturn(f func(*kit.Kit, *bill.AwsBillGetObjectReq) (*s3.GetObjectOutput, error)) *AwsGetObjectCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetRootAccountAwsAthenaQuery mocks base method.
+func (m *MockAws) GetRootAccountAwsAthenaQuery(kt *kit.Kit, query string, billInfo *bill0.AwsRootBillConfig) ([]map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRootAccountAwsAthenaQuery", kt, query, billInfo)
+	ret0, _ := ret[0].([]map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRootAccountAwsAthenaQuery indicates an expected call of GetRootAccountAwsAthenaQuery.
+func (mr *MockAwsMockRecorder) GetRootAccountAwsAthenaQuery(kt, query, billInfo interface{}) *AwsGetRootAccountAwsAthenaQueryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRootAccountAwsAthenaQuery", reflect.TypeOf((*MockAws)(nil).GetRootAccountAwsAthenaQuery), kt, query, billInfo)
+	return &AwsGetRootAccountAwsAthenaQueryCall{Call: call}
+}
+
+// AwsGetRootAccountAwsAthenaQueryCall wrap *gomock.Call
+type AwsGetRootAccountAwsAthenaQueryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsGetRootAccountAwsAthenaQueryCall) Return(arg0 []map[string]string, arg1 error) *AwsGetRootAccountAwsAthenaQueryCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsGetRootAccountAwsAthenaQueryCall) Do(f func(*kit.Kit, string, *bill0.AwsRootBillConfig) ([]map[string]string, error)) *AwsGetRootAccountAwsAthenaQueryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsGetRootAccountAwsAthenaQueryCall) DoAndReturn(f func(*kit.Kit, string, *bill0.AwsRootBillConfig) ([]map[string]string, error)) *AwsGetRootAccountAwsAthenaQueryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetRootAccountBillTotal mocks base method.
+func (m *MockAws) GetRootAccountBillTotal(kt *kit.Kit, where string, billInfo *bill0.AwsRootBillConfig) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRootAccountBillTotal", kt, where, billInfo)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRootAccountBillTotal indicates an expected call of GetRootAccountBillTotal.
+func (mr *MockAwsMockRecorder) GetRootAccountBillTotal(kt, where, billInfo interface{}) *AwsGetRootAccountBillTotalCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRootAccountBillTotal", reflect.TypeOf((*MockAws)(nil).GetRootAccountBillTotal), kt, where, billInfo)
+	return &AwsGetRootAccountBillTotalCall{Call: call}
+}
+
+// AwsGetRootAccountBillTotalCall wrap *gomock.Call
+type AwsGetRootAccountBillTotalCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsGetRootAccountBillTotalCall) Return(arg0 int64, arg1 error) *AwsGetRootAccountBillTotalCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsGetRootAccountBillTotalCall) Do(f func(*kit.Kit, string, *bill0.AwsRootBillConfig) (int64, error)) *AwsGetRootAccountBillTotalCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsGetRootAccountBillTotalCall) DoAndReturn(f func(*kit.Kit, string, *bill0.AwsRootBillConfig) (int64, error)) *AwsGetRootAccountBillTotalCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetRootSpTotalUsage mocks base method.
+func (m *MockAws) GetRootSpTotalUsage(kt *kit.Kit, billInfo *bill0.AwsRootBillConfig, opt *bill.AwsRootSpUsageOption) (*bill.AwsSpUsageTotalResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRootSpTotalUsage", kt, billInfo, opt)
+	ret0, _ := ret[0].(*bill.AwsSpUsageTotalResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRootSpTotalUsage indicates an expected call of GetRootSpTotalUsage.
+func (mr *MockAwsMockRecorder) GetRootSpTotalUsage(kt, billInfo, opt interface{}) *AwsGetRootSpTotalUsageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRootSpTotalUsage", reflect.TypeOf((*MockAws)(nil).GetRootSpTotalUsage), kt, billInfo, opt)
+	return &AwsGetRootSpTotalUsageCall{Call: call}
+}
+
+// AwsGetRootSpTotalUsageCall wrap *gomock.Call
+type AwsGetRootSpTotalUsageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsGetRootSpTotalUsageCall) Return(arg0 *bill.AwsSpUsageTotalResult, arg1 error) *AwsGetRootSpTotalUsageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsGetRootSpTotalUsageCall) Do(f func(*kit.Kit, *bill0.AwsRootBillConfig, *bill.AwsRootSpUsageOption) (*bill.AwsSpUsageTotalResult, error)) *AwsGetRootSpTotalUsageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsGetRootSpTotalUsageCall) DoAndReturn(f func(*kit.Kit, *bill0.AwsRootBillConfig, *bill.AwsRootSpUsageOption) (*bill.AwsSpUsageTotalResult, error)) *AwsGetRootSpTotalUsageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetVpcAttribute mocks base method.
+func (m *MockAws) GetVpcAttribute(kt *kit.Kit, vpcID, region string) (bool, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVpcAttribute", kt, vpcID, region)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetVpcAttribute indicates an expected call of GetVpcAttribute.
+func (mr *MockAwsMockRecorder) GetVpcAttribute(kt, vpcID, region interface{}) *AwsGetVpcAttributeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVpcAttribute", reflect.TypeOf((*MockAws)(nil).GetVpcAttribute), kt, vpcID, region)
+	return &AwsGetVpcAttributeCall{Call: call}
+}
+
+// AwsGetVpcAttributeCall wrap *gomock.Call
+type AwsGetVpcAttributeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsGetVpcAttributeCall) Return(arg0, arg1 bool, arg2 error) *AwsGetVpcAttributeCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsGetVpcAttributeCall) Do(f func(*kit.Kit, string, string) (bool, bool, error)) *AwsGetVpcAttributeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsGetVpcAttributeCall) DoAndReturn(f func(*kit.Kit, string, string) (bool, bool, error)) *AwsGetVpcAttributeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// IsChinaSite mocks base method.
+func (m *MockAws) IsChinaSite() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsChinaSite")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsChinaSite indicates an expected call of IsChinaSite.
+func (mr *MockAwsMockRecorder) IsChinaSite() *AwsIsChinaSiteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsChinaSite", reflect.TypeOf((*MockAws)(nil).IsChinaSite))
+	return &AwsIsChinaSiteCall{Call: call}
+}
+
+// AwsIsChinaSiteCall wrap *gomock.Call
+type AwsIsChinaSiteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsIsChinaSiteCall) Return(arg0 bool) *AwsIsChinaSiteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsIsChinaSiteCall) Do(f func() bool) *AwsIsChinaSiteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsIsChinaSiteCall) DoAndReturn(f func() bool) *AwsIsChinaSiteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListAccount mocks base method.
+func (m *MockAws) ListAccount(kt *kit.Kit) ([]account.AwsAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccount", kt)
+	ret0, _ := ret[0].([]account.AwsAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccount indicates an expected call of ListAccount.
+func (mr *MockAwsMockRecorder) ListAccount(kt interface{}) *AwsListAccountCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccount", reflect.TypeOf((*MockAws)(nil).ListAccount), kt)
+	return &AwsListAccountCall{Call: call}
+}
+
+// AwsListAccountCall wrap *gomock.Call
+type AwsListAccountCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsListAccountCall) Return(arg0 []account.AwsAccount, arg1 error) *AwsListAccountCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsListAccountCall) Do(f func(*kit.Kit) ([]account.AwsAccount, error)) *AwsListAccountCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsListAccountCall) DoAndReturn(f func(*kit.Kit) ([]account.AwsAccount, error)) *AwsListAccountCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListBucket mocks base method.
+func (m *MockAws) ListBucket(kt *kit.Kit, region string) ([]*s3.Bucket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBucket", kt, region)
+	ret0, _ := ret[0].([]*s3.Bucket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBucket indicates an expected call of ListBucket.
+func (mr *MockAwsMockRecorder) ListBucket(kt, region interface{}) *AwsListBucketCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBucket", reflect.TypeOf((*MockAws)(nil).ListBucket), kt, region)
+	return &AwsListBucketCall{Call: call}
+}
+
+// AwsListBucketCall wrap *gomock.Call
+type AwsListBucketCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsListBucketCall) Return(arg0 []*s3.Bucket, arg1 error) *AwsListBucketCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsListBucketCall) Do(f func(*kit.Kit, string) ([]*s3.Bucket, error)) *AwsListBucketCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsListBucketCall) DoAndReturn(f func(*kit.Kit, string) ([]*s3.Bucket, error)) *AwsListBucketCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListCvm mocks base method.
+func (m *MockAws) ListCvm(kt *kit.Kit, opt *cvm.AwsListOption) ([]cvm.AwsCvm, *ec2.DescribeInstancesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCvm", kt, opt)
+	ret0, _ := ret[0].([]cvm.AwsCvm)
+	ret1, _ := ret[1].(*ec2.DescribeInstancesOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListCvm indicates an expected call of ListCvm.
+func (mr *MockAwsMockRecorder) ListCvm(kt, opt interface{}) *AwsListCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCvm", reflect.TypeOf((*MockAws)(nil).ListCvm), kt, opt)
+	return &AwsListCvmCall{Call: call}
+}
+
+// AwsListCvmCall wrap *gomock.Call
+type AwsListCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsListCvmCall) Return(arg0 []cvm.AwsCvm, arg1 *ec2.DescribeInstancesOutput, arg2 error) *AwsListCvmCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsListCvmCall) Do(f func(*kit.Kit, *cvm.AwsListOption) ([]cvm.AwsCvm, *ec2.DescribeInstancesOutput, error)) *AwsListCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsListCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.AwsListOption) ([]cvm.AwsCvm, *ec2.DescribeInstancesOutput, error)) *AwsListCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListDisk mocks base method.
+func (m *MockAws) ListDisk(kt *kit.Kit, opt *disk.AwsDiskListOption) ([]disk.AwsDisk, *string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDisk", kt, opt)
+	ret0, _ := ret[0].([]disk.AwsDisk)
+	ret1, _ := ret[1].(*string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDisk indicates an expected call of ListDisk.
+func (mr *MockAwsMockRecorder) ListDisk(kt, opt interface{}) *AwsListDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDisk", reflect.TypeOf((*MockAws)(nil).ListDisk), kt, opt)
+	return &AwsListDiskCall{Call: call}
+}
+
+// AwsListDiskCall wrap *gomock.Call
+type AwsListDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsListDiskCall) Return(arg0 []disk.AwsDisk, arg1 *string, arg2 error) *AwsListDiskCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsListDiskCall) Do(f func(*kit.Kit, *disk.AwsDiskListOption) ([]disk.AwsDisk, *string, error)) *AwsListDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsListDiskCall) DoAndReturn(f func(*kit.Kit, *disk.AwsDiskListOption) ([]disk.AwsDisk, *string, error)) *AwsListDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListEip mocks base method.
+func (m *MockAws) ListEip(kt *kit.Kit, opt *eip.AwsEipListOption) (*eip.AwsEipListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEip", kt, opt)
+	ret0, _ := ret[0].(*eip.AwsEipListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEip indicates an expected call of ListEip.
+func (mr *MockAwsMockRecorder) ListEip(kt, opt interface{}) *AwsListEipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEip", reflect.TypeOf((*MockAws)(nil).ListEip), kt, opt)
+	return &AwsListEipCall{Call: call}
+}
+
+// AwsListEipCall wrap *gomock.Call
+type AwsListEipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsListEipCall) Return(arg0 *eip.AwsEipListResult, arg1 error) *AwsListEipCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsListEipCall) Do(f func(*kit.Kit, *eip.AwsEipListOption) (*eip.AwsEipListResult, error)) *AwsListEipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsListEipCall) DoAndReturn(f func(*kit.Kit, *eip.AwsEipListOption) (*eip.AwsEipListResult, error)) *AwsListEipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListImage mocks base method.
+func (m *MockAws) ListImage(kt *kit.Kit, opt *image.AwsImageListOption) (*image.AwsImageListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImage", kt, opt)
+	ret0, _ := ret[0].(*image.AwsImageListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImage indicates an expected call of ListImage.
+func (mr *MockAwsMockRecorder) ListImage(kt, opt interface{}) *AwsListImageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImage", reflect.TypeOf((*MockAws)(nil).ListImage), kt, opt)
+	return &AwsListImageCall{Call: call}
+}
+
+// AwsListImageCall wrap *gomock.Call
+type AwsListImageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsListImageCall) Return(arg0 *image.AwsImageListResult, arg1 error) *AwsListImageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsListImageCall) Do(f func(*kit.Kit, *image.AwsImageListOption) (*image.AwsImageListResult, error)) *AwsListImageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsListImageCall) DoAndReturn(f func(*kit.Kit, *image.AwsImageListOption) (*image.AwsImageListResult, error)) *AwsListImageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListInstanceType mocks base method.
+func (m *MockAws) ListInstanceType(kt *kit.Kit, opt *instancetype.AwsInstanceTypeListOption) (*instancetype.AwsInstanceTypeListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInstanceType", kt, opt)
+	ret0, _ := ret[0].(*instancetype.AwsInstanceTypeListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInstanceType indicates an expected call of ListInstanceType.
+func (mr *MockAwsMockRecorder) ListInstanceType(kt, opt interface{}) *AwsListInstanceTypeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstanceType", reflect.TypeOf((*MockAws)(nil).ListInstanceType), kt, opt)
+	return &AwsListInstanceTypeCall{Call: call}
+}
+
+// AwsListInstanceTypeCall wrap *gomock.Call
+type AwsListInstanceTypeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsListInstanceTypeCall) Return(arg0 *instancetype.AwsInstanceTypeListResult, arg1 error) *AwsListInstanceTypeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsListInstanceTypeCall) Do(f func(*kit.Kit, *instancetype.AwsInstanceTypeListOption) (*instancetype.AwsInstanceTypeListResult, error)) *AwsListInstanceTypeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsListInstanceTypeCall) DoAndReturn(f func(*kit.Kit, *instancetype.AwsInstanceTypeListOption) (*instancetype.AwsInstanceTypeListResult, error)) *AwsListInstanceTypeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListRegion mocks base method.
+func (m *MockAws) ListRegion(kt *kit.Kit) (*region.AwsRegionListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRegion", kt)
+	ret0, _ := ret[0].(*region.AwsRegionListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRegion indicates an expected call of ListRegion.
+func (mr *MockAwsMockRecorder) ListRegion(kt interface{}) *AwsListRegionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRegion", reflect.TypeOf((*MockAws)(nil).ListRegion), kt)
+	return &AwsListRegionCall{Call: call}
+}
+
+// AwsListRegionCall wrap *gomock.Call
+type AwsListRegionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsListRegionCall) Return(arg0 *region.AwsRegionListResult, arg1 error) *AwsListRegionCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsListRegionCall) Do(f func(*kit.Kit) (*region.AwsRegionListResult, error)) *AwsListRegionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsListRegionCall) DoAndReturn(f func(*kit.Kit) (*region.AwsRegionListResult, error)) *AwsListRegionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListRouteTable mocks base method.
+func (m *MockAws) ListRouteTable(kt *kit.Kit, opt *routetable.AwsRouteTableListOption) (*routetable.AwsRouteTableListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRouteTable", kt, opt)
+	ret0, _ := ret[0].(*routetable.AwsRouteTableListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRouteTable indicates an expected call of ListRouteTable.
+func (mr *MockAwsMockRecorder) ListRouteTable(kt, opt interface{}) *AwsListRouteTableCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRouteTable", reflect.TypeOf((*MockAws)(nil).ListRouteTable), kt, opt)
+	return &AwsListRouteTableCall{Call: call}
+}
+
+// AwsListRouteTableCall wrap *gomock.Call
+type AwsListRouteTableCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsListRouteTableCall) Return(arg0 *routetable.AwsRouteTableListResult, arg1 error) *AwsListRouteTableCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsListRouteTableCall) Do(f func(*kit.Kit, *routetable.AwsRouteTableListOption) (*routetable.AwsRouteTableListResult, error)) *AwsListRouteTableCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsListRouteTableCall) DoAndReturn(f func(*kit.Kit, *routetable.AwsRouteTableListOption) (*routetable.AwsRouteTableListResult, error)) *AwsListRouteTableCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListSecurityGroup mocks base method.
+func (m *MockAws) ListSecurityGroup(kt *kit.Kit, opt *securitygroup.AwsListOption) ([]securitygroup.AwsSG, *ec2.DescribeSecurityGroupsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecurityGroup", kt, opt)
+	ret0, _ := ret[0].([]securitygroup.AwsSG)
+	ret1, _ := ret[1].(*ec2.DescribeSecurityGroupsOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListSecurityGroup indicates an expected call of ListSecurityGroup.
+func (mr *MockAwsMockRecorder) ListSecurityGroup(kt, opt interface{}) *AwsListSecurityGroupCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecurityGroup", reflect.TypeOf((*MockAws)(nil).ListSecurityGroup), kt, opt)
+	return &AwsListSecurityGroupCall{Call: call}
+}
+
+// AwsListSecurityGroupCall wrap *gomock.Call
+type AwsListSecurityGroupCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsListSecurityGroupCall) Return(arg0 []securitygroup.AwsSG, arg1 *ec2.DescribeSecurityGroupsOutput, arg2 error) *AwsListSecurityGroupCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsListSecurityGroupCall) Do(f func(*kit.Kit, *securitygroup.AwsListOption) ([]securitygroup.AwsSG, *ec2.DescribeSecurityGroupsOutput, error)) *AwsListSecurityGroupCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsListSecurityGroupCall) DoAndReturn(f func(*kit.Kit, *securitygroup.AwsListOption) ([]securitygroup.AwsSG, *ec2.DescribeSecurityGroupsOutput, error)) *AwsListSecurityGroupCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListSecurityGroupRule mocks base method.
+func (m *MockAws) ListSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AwsListOption) ([]securitygrouprule.AwsSGRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecurityGroupRule", kt, opt)
+	ret0, _ := ret[0].([]securitygrouprule.AwsSGRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecurityGroupRule indicates an expected call of ListSecurityGroupRule.
+func (mr *MockAwsMockRecorder) ListSecurityGroupRule(kt, opt interface{}) *AwsListSecurityGroupRuleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecurityGroupRule", reflect.TypeOf((*MockAws)(nil).ListSecurityGroupRule), kt, opt)
+	return &AwsListSecurityGroupRuleCall{Call: call}
+}
+
+// AwsListSecurityGroupRuleCall wrap *gomock.Call
+type AwsListSecurityGroupRuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsListSecurityGroupRuleCall) Return(arg0 []securitygrouprule.AwsSGRule, arg1 error) *AwsListSecurityGroupRuleCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsListSecurityGroupRuleCall) Do(f func(*kit.Kit, *securitygrouprule.AwsListOption) ([]securitygrouprule.AwsSGRule, error)) *AwsListSecurityGroupRuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsListSecurityGroupRuleCall) DoAndReturn(f func(*kit.Kit, *securitygrouprule.AwsListOption) ([]securitygrouprule.AwsSGRule, error)) *AwsListSecurityGroupRuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListSubnet mocks base method.
+func (m *MockAws) ListSubnet(kt *kit.Kit, opt *core.AwsListOption) (*adtysubnet.AwsSubnetListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubnet", kt, opt)
+	ret0, _ := ret[0].(*adtysubnet.AwsSubnetListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubnet indicates an expected call of ListSubnet.
+func (mr *MockAwsMockRecorder) ListSubnet(kt, opt interface{}) *AwsListSubnetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubnet", reflect.TypeOf((*MockAws)(nil).ListSubnet), kt, opt)
+	return &AwsListSubnetCall{Call: call}
+}
+
+// AwsListSubnetCall wrap *gomock.Call
+type AwsListSubnetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsListSubnetCall) Return(arg0 *adtysubnet.AwsSubnetListResult, arg1 error) *AwsListSubnetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsListSubnetCall) Do(f func(*kit.Kit, *core.AwsListOption) (*adtysubnet.AwsSubnetListResult, error)) *AwsListSubnetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsListSubnetCall) DoAndReturn(f func(*kit.Kit, *core.AwsListOption) (*adtysubnet.AwsSubnetListResult, error)) *AwsListSubnetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListVpc mocks base method.
+func (m *MockAws) ListVpc(kt *kit.Kit, opt *core.AwsListOption) (*types.AwsVpcListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVpc", kt, opt)
+	ret0, _ := ret[0].(*types.AwsVpcListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVpc indicates an expected call of ListVpc.
+func (mr *MockAwsMockRecorder) ListVpc(kt, opt interface{}) *AwsListVpcCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVpc", reflect.TypeOf((*MockAws)(nil).ListVpc), kt, opt)
+	return &AwsListVpcCall{Call: call}
+}
+
+// AwsListVpcCall wrap *gomock.Call
+type AwsListVpcCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsListVpcCall) Return(arg0 *types.AwsVpcListResult, arg1 error) *AwsListVpcCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsListVpcCall) Do(f func(*kit.Kit, *core.AwsListOption) (*types.AwsVpcListResult, error)) *AwsListVpcCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsListVpcCall) DoAndReturn(f func(*kit.Kit, *core.AwsListOption) (*types.AwsVpcListResult, error)) *AwsListVpcCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListZone mocks base method.
+func (m *MockAws) ListZone(kit *kit.Kit, opt *zone.AwsZoneListOption) ([]zone.AwsZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListZone", kit, opt)
+	ret0, _ := ret[0].([]zone.AwsZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListZone indicates an expected call of ListZone.
+func (mr *MockAwsMockRecorder) ListZone(kit, opt interface{}) *AwsListZoneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListZone", reflect.TypeOf((*MockAws)(nil).ListZone), kit, opt)
+	return &AwsListZoneCall{Call: call}
+}
+
+// AwsListZoneCall wrap *gomock.Call
+type AwsListZoneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsListZoneCall) Return(arg0 []zone.AwsZone, arg1 error) *AwsListZoneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsListZoneCall) Do(f func(*kit.Kit, *zone.AwsZoneListOption) ([]zone.AwsZone, error)) *AwsListZoneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsListZoneCall) DoAndReturn(f func(*kit.Kit, *zone.AwsZoneListOption) ([]zone.AwsZone, error)) *AwsListZoneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// PutBucketPolicy mocks base method.
+func (m *MockAws) PutBucketPolicy(kt *kit.Kit, opt *bill.AwsBillBucketPolicyReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutBucketPolicy", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutBucketPolicy indicates an expected call of PutBucketPolicy.
+func (mr *MockAwsMockRecorder) PutBucketPolicy(kt, opt interface{}) *AwsPutBucketPolicyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutBucketPolicy", reflect.TypeOf((*MockAws)(nil).PutBucketPolicy), kt, opt)
+	return &AwsPutBucketPolicyCall{Call: call}
+}
+
+// AwsPutBucketPolicyCall wrap *gomock.Call
+type AwsPutBucketPolicyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsPutBucketPolicyCall) Return(arg0 error) *AwsPutBucketPolicyCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsPutBucketPolicyCall) Do(f func(*kit.Kit, *bill.AwsBillBucketPolicyReq) error) *AwsPutBucketPolicyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsPutBucketPolicyCall) DoAndReturn(f func(*kit.Kit, *bill.AwsBillBucketPolicyReq) error) *AwsPutBucketPolicyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// PutReportDefinition mocks base method.
+func (m *MockAws) PutReportDefinition(kt *kit.Kit, opt *bill.AwsBillPutReportDefinitionReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutReportDefinition", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutReportDefinition indicates an expected call of PutReportDefinition.
+func (mr *MockAwsMockRecorder) PutReportDefinition(kt, opt interface{}) *AwsPutReportDefinitionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutReportDefinition", reflect.TypeOf((*MockAws)(nil).PutReportDefinition), kt, opt)
+	return &AwsPutReportDefinitionCall{Call: call}
+}
+
+// AwsPutReportDefinitionCall wrap *gomock.Call
+type AwsPutReportDefinitionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsPutReportDefinitionCall) Return(arg0 error) *AwsPutReportDefinitionCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsPutReportDefinitionCall) Do(f func(*kit.Kit, *bill.AwsBillPutReportDefinitionReq) error) *AwsPutReportDefinitionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsPutReportDefinitionCall) DoAndReturn(f func(*kit.Kit, *bill.AwsBillPutReportDefinitionReq) error) *AwsPutReportDefinitionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RebootCvm mocks base method.
+func (m *MockAws) RebootCvm(kt *kit.Kit, opt *cvm.AwsRebootOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebootCvm", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RebootCvm indicates an expected call of RebootCvm.
+func (mr *MockAwsMockRecorder) RebootCvm(kt, opt interface{}) *AwsRebootCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebootCvm", reflect.TypeOf((*MockAws)(nil).RebootCvm), kt, opt)
+	return &AwsRebootCvmCall{Call: call}
+}
+
+// AwsRebootCvmCall wrap *gomock.Call
+type AwsRebootCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsRebootCvmCall) Return(arg0 error) *AwsRebootCvmCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsRebootCvmCall) Do(f func(*kit.Kit, *cvm.AwsRebootOption) error) *AwsRebootCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsRebootCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.AwsRebootOption) error) *AwsRebootCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SecurityGroupCvmAssociate mocks base method.
+func (m *MockAws) SecurityGroupCvmAssociate(kt *kit.Kit, opt *securitygroup.AwsAssociateCvmOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecurityGroupCvmAssociate", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SecurityGroupCvmAssociate indicates an expected call of SecurityGroupCvmAssociate.
+func (mr *MockAwsMockRecorder) SecurityGroupCvmAssociate(kt, opt interface{}) *AwsSecurityGroupCvmAssociateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecurityGroupCvmAssociate", reflect.TypeOf((*MockAws)(nil).SecurityGroupCvmAssociate), kt, opt)
+	return &AwsSecurityGroupCvmAssociateCall{Call: call}
+}
+
+// AwsSecurityGroupCvmAssociateCall wrap *gomock.Call
+type AwsSecurityGroupCvmAssociateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsSecurityGroupCvmAssociateCall) Return(arg0 error) *AwsSecurityGroupCvmAssociateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsSecurityGroupCvmAssociateCall) Do(f func(*kit.Kit, *securitygroup.AwsAssociateCvmOption) error) *AwsSecurityGroupCvmAssociateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsSecurityGroupCvmAssociateCall) DoAndReturn(f func(*kit.Kit, *securitygroup.AwsAssociateCvmOption) error) *AwsSecurityGroupCvmAssociateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SecurityGroupCvmDisassociate mocks base method.
+func (m *MockAws) SecurityGroupCvmDisassociate(kt *kit.Kit, opt *securitygroup.AwsAssociateCvmOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecurityGroupCvmDisassociate", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SecurityGroupCvmDisassociate indicates an expected call of SecurityGroupCvmDisassociate.
+func (mr *MockAwsMockRecorder) SecurityGroupCvmDisassociate(kt, opt interface{}) *AwsSecurityGroupCvmDisassociateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecurityGroupCvmDisassociate", reflect.TypeOf((*MockAws)(nil).SecurityGroupCvmDisassociate), kt, opt)
+	return &AwsSecurityGroupCvmDisassociateCall{Call: call}
+}
+
+// AwsSecurityGroupCvmDisassociateCall wrap *gomock.Call
+type AwsSecurityGroupCvmDisassociateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsSecurityGroupCvmDisassociateCall) Return(arg0 error) *AwsSecurityGroupCvmDisassociateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsSecurityGroupCvmDisassociateCall) Do(f func(*kit.Kit, *securitygroup.AwsAssociateCvmOption) error) *AwsSecurityGroupCvmDisassociateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsSecurityGroupCvmDisassociateCall) DoAndReturn(f func(*kit.Kit, *securitygroup.AwsAssociateCvmOption) error) *AwsSecurityGroupCvmDisassociateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// StartCvm mocks base method.
+func (m *MockAws) StartCvm(kt *kit.Kit, opt *cvm.AwsStartOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartCvm", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartCvm indicates an expected call of StartCvm.
+func (mr *MockAwsMockRecorder) StartCvm(kt, opt interface{}) *AwsStartCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartCvm", reflect.TypeOf((*MockAws)(nil).StartCvm), kt, opt)
+	return &AwsStartCvmCall{Call: call}
+}
+
+// AwsStartCvmCall wrap *gomock.Call
+type AwsStartCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsStartCvmCall) Return(arg0 error) *AwsStartCvmCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsStartCvmCall) Do(f func(*kit.Kit, *cvm.AwsStartOption) error) *AwsStartCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsStartCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.AwsStartOption) error) *AwsStartCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// StopCvm mocks base method.
+func (m *MockAws) StopCvm(kt *kit.Kit, opt *cvm.AwsStopOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopCvm", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopCvm indicates an expected call of StopCvm.
+func (mr *MockAwsMockRecorder) StopCvm(kt, opt interface{}) *AwsStopCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopCvm", reflect.TypeOf((*MockAws)(nil).StopCvm), kt, opt)
+	return &AwsStopCvmCall{Call: call}
+}
+
+// AwsStopCvmCall wrap *gomock.Call
+type AwsStopCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsStopCvmCall) Return(arg0 error) *AwsStopCvmCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsStopCvmCall) Do(f func(*kit.Kit, *cvm.AwsStopOption) error) *AwsStopCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsStopCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.AwsStopOption) error) *AwsStopCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateRouteTable mocks base method.
+func (m *MockAws) UpdateRouteTable(kt *kit.Kit, opt *routetable.AwsRouteTableUpdateOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRouteTable", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRouteTable indicates an expected call of UpdateRouteTable.
+func (mr *MockAwsMockRecorder) UpdateRouteTable(kt, opt interface{}) *AwsUpdateRouteTableCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRouteTable", reflect.TypeOf((*MockAws)(nil).UpdateRouteTable), kt, opt)
+	return &AwsUpdateRouteTableCall{Call: call}
+}
+
+// AwsUpdateRouteTableCall wrap *gomock.Call
+type AwsUpdateRouteTableCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsUpdateRouteTableCall) Return(arg0 error) *AwsUpdateRouteTableCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsUpdateRouteTableCall) Do(f func(*kit.Kit, *routetable.AwsRouteTableUpdateOption) error) *AwsUpdateRouteTableCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsUpdateRouteTableCall) DoAndReturn(f func(*kit.Kit, *routetable.AwsRouteTableUpdateOption) error) *AwsUpdateRouteTableCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateSecurityGroupRule mocks base method.
+func (m *MockAws) UpdateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AwsUpdateOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecurityGroupRule", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSecurityGroupRule indicates an expected call of UpdateSecurityGroupRule.
+func (mr *MockAwsMockRecorder) UpdateSecurityGroupRule(kt, opt interface{}) *AwsUpdateSecurityGroupRuleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecurityGroupRule", reflect.TypeOf((*MockAws)(nil).UpdateSecurityGroupRule), kt, opt)
+	return &AwsUpdateSecurityGroupRuleCall{Call: call}
+}
+
+// AwsUpdateSecurityGroupRuleCall wrap *gomock.Call
+type AwsUpdateSecurityGroupRuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsUpdateSecurityGroupRuleCall) Return(arg0 error) *AwsUpdateSecurityGroupRuleCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsUpdateSecurityGroupRuleCall) Do(f func(*kit.Kit, *securitygrouprule.AwsUpdateOption) error) *AwsUpdateSecurityGroupRuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsUpdateSecurityGroupRuleCall) DoAndReturn(f func(*kit.Kit, *securitygrouprule.AwsUpdateOption) error) *AwsUpdateSecurityGroupRuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateSubnet mocks base method.
+func (m *MockAws) UpdateSubnet(arg0 *kit.Kit, arg1 *adtysubnet.AwsSubnetUpdateOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubnet", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSubnet indicates an expected call of UpdateSubnet.
+func (mr *MockAwsMockRecorder) UpdateSubnet(arg0, arg1 interface{}) *AwsUpdateSubnetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubnet", reflect.TypeOf((*MockAws)(nil).UpdateSubnet), arg0, arg1)
+	return &AwsUpdateSubnetCall{Call: call}
+}
+
+// AwsUpdateSubnetCall wrap *gomock.Call
+type AwsUpdateSubnetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsUpdateSubnetCall) Return(arg0 error) *AwsUpdateSubnetCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsUpdateSubnetCall) Do(f func(*kit.Kit, *adtysubnet.AwsSubnetUpdateOption) error) *AwsUpdateSubnetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsUpdateSubnetCall) DoAndReturn(f func(*kit.Kit, *adtysubnet.AwsSubnetUpdateOption) error) *AwsUpdateSubnetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateVpc mocks base method.
+func (m *MockAws) UpdateVpc(kt *kit.Kit, opt *types.AwsVpcUpdateOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVpc", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVpc indicates an expected call of UpdateVpc.
+func (mr *MockAwsMockRecorder) UpdateVpc(kt, opt interface{}) *AwsUpdateVpcCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVpc", reflect.TypeOf((*MockAws)(nil).UpdateVpc), kt, opt)
+	return &AwsUpdateVpcCall{Call: call}
+}
+
+// AwsUpdateVpcCall wrap *gomock.Call
+type AwsUpdateVpcCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AwsUpdateVpcCall) Return(arg0 error) *AwsUpdateVpcCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AwsUpdateVpcCall) Do(f func(*kit.Kit, *types.AwsVpcUpdateOption) error) *AwsUpdateVpcCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AwsUpdateVpcCall) DoAndReturn(f func(*kit.Kit, *types.AwsVpcUpdateOption) error) *AwsUpdateVpcCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
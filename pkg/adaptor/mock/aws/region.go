@@ -0,0 +1,59 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package mockaws
+
+import (
+	"hcm/pkg/adaptor/types/region"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/converter"
+
+	"go.uber.org/mock/gomock"
+)
+
+var regionList = []region.AwsRegion{
+	{RegionID: "ap-northeast-1", RegionName: "ap-northeast-1", RegionState: "opt-in-not-required",
+		Endpoint: "ec2.ap-northeast-1.amazonaws.com"},
+	{RegionID: "us-east-1", RegionName: "us-east-1", RegionState: "opt-in-not-required",
+		Endpoint: "ec2.us-east-1.amazonaws.com"},
+}
+
+type regionPlaybook struct {
+	regionList []region.AwsRegion
+}
+
+// Name region
+func (r *regionPlaybook) Name() string {
+	return string(enumor.RegionCloudResType)
+}
+
+// Apply to add list region method ability, always return same region list
+func (r *regionPlaybook) Apply(mockCloud *MockAws, controller *gomock.Controller) {
+	result := &region.AwsRegionListResult{
+		Count:   converter.ValToPtr(uint64(len(r.regionList))),
+		Details: r.regionList,
+	}
+	// 不管传入什么都返回指定的regionList,最少要调用一次
+	mockCloud.EXPECT().ListRegion(gomock.Any()).MinTimes(1).Return(result, nil)
+}
+
+// NewRegionPlaybook  return region playbook, always returns the same region list
+func NewRegionPlaybook() Playbook {
+	return &regionPlaybook{regionList: regionList}
+}
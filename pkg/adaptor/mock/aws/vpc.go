@@ -0,0 +1,193 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package mockaws
+
+import (
+	adaptormock "hcm/pkg/adaptor/mock"
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/adaptor/types/core"
+	adtysubnet "hcm/pkg/adaptor/types/subnet"
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/rand"
+	"hcm/pkg/tools/slice"
+
+	"go.uber.org/mock/gomock"
+)
+
+// vpcPlaybook in memory crud vpc playbook, add ability to mock vpc, subnet related functions.
+type vpcPlaybook struct {
+	vpcStore    *adaptormock.Store[string, types.AwsVpc]
+	subnetStore *adaptormock.Store[string, adtysubnet.AwsSubnet]
+}
+
+// NewCrudVpcPlaybook  vpc, subnet all in one.
+func NewCrudVpcPlaybook() Playbook {
+	return &vpcPlaybook{
+		vpcStore:    adaptormock.NewCloudResStore[types.AwsVpc](),
+		subnetStore: adaptormock.NewCloudResStore[adtysubnet.AwsSubnet](),
+	}
+}
+
+// Name vpc playbook
+func (v *vpcPlaybook) Name() string {
+	return string(enumor.VpcCloudResType)
+}
+
+// Apply mock method
+func (v *vpcPlaybook) Apply(mockCloud *MockAws, ctrl *gomock.Controller) {
+
+	v.applyVpc(mockCloud)
+
+	v.applySubnet(mockCloud)
+}
+
+func (v *vpcPlaybook) applyVpc(mockCloud *MockAws) {
+	mockCloud.EXPECT().ListVpc(gomock.Any(), gomock.Any()).DoAndReturn(v.listVpc).MinTimes(1)
+	mockCloud.EXPECT().CreateVpc(gomock.Any(), gomock.Any()).DoAndReturn(v.createVpc).MinTimes(1)
+	// aws 目前只支持更新备注，而备注只保存在本地，因此更新不需要操作云上资源
+	mockCloud.EXPECT().UpdateVpc(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockCloud.EXPECT().DeleteVpc(gomock.Any(), gomock.Any()).DoAndReturn(v.deleteVpc).MinTimes(1)
+}
+
+func (v *vpcPlaybook) applySubnet(mockCloud *MockAws) {
+	mockCloud.EXPECT().ListSubnet(gomock.Any(), gomock.Any()).DoAndReturn(v.listSubnet).MinTimes(1)
+	mockCloud.EXPECT().CreateSubnet(gomock.Any(), gomock.Any()).DoAndReturn(v.createSubnet).MinTimes(1)
+	mockCloud.EXPECT().UpdateSubnet(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockCloud.EXPECT().DeleteSubnet(gomock.Any(), gomock.Any()).DoAndReturn(v.deleteSubnet).MinTimes(1)
+}
+
+// listVpc 按地域返回全部数据，指定了id时只返回匹配的数据，mock数据量小不做分页
+func (v *vpcPlaybook) listVpc(_ *kit.Kit, opt *core.AwsListOption) (*types.AwsVpcListResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	found := v.vpcStore.Filter(func(vpc types.AwsVpc) bool {
+		if vpc.Region != opt.Region {
+			return false
+		}
+		return len(opt.CloudIDs) == 0 || slice.IsItemInSlice(opt.CloudIDs, vpc.CloudID)
+	})
+	return &types.AwsVpcListResult{Details: found}, nil
+}
+
+func (v *vpcPlaybook) createVpc(_ *kit.Kit, opt *types.AwsVpcCreateOption) (*types.AwsVpc, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	cloudVpc := types.AwsVpc{
+		CloudID: rand.Prefix("vpc-", 17),
+		Name:    opt.Name,
+		Region:  opt.Extension.Region,
+		Memo:    opt.Memo,
+		Extension: &cloud.AwsVpcExtension{
+			Cidr: []cloud.AwsCidr{{Type: enumor.Ipv4, Cidr: opt.Extension.IPv4Cidr,
+				State: "associated"}},
+			State:              "available",
+			InstanceTenancy:    opt.Extension.InstanceTenancy,
+			EnableDnsHostnames: false,
+			EnableDnsSupport:   true,
+		},
+	}
+	v.vpcStore.Add(cloudVpc.CloudID, cloudVpc)
+	return &cloudVpc, nil
+}
+
+// deleteVpc 与aws保持一致，vpc下存在子网时不允许删除
+func (v *vpcPlaybook) deleteVpc(_ *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if _, exists := v.vpcStore.Get(opt.ResourceID); !exists {
+		return errf.New(errf.RecordNotFound, "not found vpc: "+opt.ResourceID)
+	}
+
+	subnet := v.subnetStore.Find(func(n adtysubnet.AwsSubnet) bool {
+		return n.CloudVpcID == opt.ResourceID
+	})
+	if subnet != nil {
+		return errf.Newf(errf.InvalidParameter, "vpc %s still has subnet %s", opt.ResourceID, subnet.CloudID)
+	}
+	return v.vpcStore.Remove(opt.ResourceID)
+}
+
+// listSubnet 按地域返回全部数据，指定了id时只返回匹配的数据
+func (v *vpcPlaybook) listSubnet(_ *kit.Kit, opt *core.AwsListOption) (*adtysubnet.AwsSubnetListResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	found := v.subnetStore.Filter(func(n adtysubnet.AwsSubnet) bool {
+		if n.Region != opt.Region {
+			return false
+		}
+		return len(opt.CloudIDs) == 0 || slice.IsItemInSlice(opt.CloudIDs, n.CloudID)
+	})
+	return &adtysubnet.AwsSubnetListResult{Details: found}, nil
+}
+
+func (v *vpcPlaybook) createSubnet(_ *kit.Kit, opt *adtysubnet.AwsSubnetCreateOption) (*adtysubnet.AwsSubnet,
+	error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, exists := v.vpcStore.Get(opt.CloudVpcID); !exists {
+		return nil, errf.New(errf.RecordNotFound, "not found vpc: "+opt.CloudVpcID)
+	}
+
+	subnet := adtysubnet.AwsSubnet{
+		CloudVpcID: opt.CloudVpcID,
+		CloudID:    rand.Prefix("subnet-", 17),
+		Name:       opt.Name,
+		Region:     opt.Extension.Region,
+		Memo:       opt.Memo,
+		Extension: &adtysubnet.AwsSubnetExtension{
+			State: "available",
+			Zone:  converter.PtrToVal(opt.Extension.Zone),
+		},
+	}
+	if opt.Extension.IPv4Cidr != nil {
+		subnet.Ipv4Cidr = []string{*opt.Extension.IPv4Cidr}
+	}
+	if opt.Extension.IPv6Cidr != nil {
+		subnet.Ipv6Cidr = []string{*opt.Extension.IPv6Cidr}
+	}
+	v.subnetStore.Add(subnet.CloudID, subnet)
+	return &subnet, nil
+}
+
+func (v *vpcPlaybook) deleteSubnet(_ *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if _, exists := v.subnetStore.Get(opt.ResourceID); !exists {
+		return errf.New(errf.RecordNotFound, "not found subnet: "+opt.ResourceID)
+	}
+	return v.subnetStore.Remove(opt.ResourceID)
+}
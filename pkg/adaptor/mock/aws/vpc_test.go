@@ -0,0 +1,81 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package mockaws
+
+import (
+	"testing"
+
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/adaptor/types/core"
+	adtysubnet "hcm/pkg/adaptor/types/subnet"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVpcPlaybook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cloud := NewMockAws(ctrl)
+	NewCrudVpcPlaybook().Apply(cloud, ctrl)
+	kt := kit.New()
+	region := "us-east-1"
+
+	vpc, err := cloud.CreateVpc(kt, &types.AwsVpcCreateOption{AccountID: "00000001", Name: "demo",
+		Extension: &types.AwsVpcCreateExt{Region: region, IPv4Cidr: "10.0.0.0/16", InstanceTenancy: "default"}})
+	require.NoError(t, err)
+
+	vpcs, err := cloud.ListVpc(kt, &core.AwsListOption{Region: region, CloudIDs: []string{vpc.CloudID}})
+	require.NoError(t, err)
+	require.Len(t, vpcs.Details, 1)
+	assert.Equal(t, "demo", vpcs.Details[0].Name)
+
+	subnet, err := cloud.CreateSubnet(kt, &adtysubnet.AwsSubnetCreateOption{Name: "demo", CloudVpcID: vpc.CloudID,
+		Extension: &adtysubnet.AwsSubnetCreateExt{Region: region, Zone: converter.ValToPtr("us-east-1a"),
+			IPv4Cidr: converter.ValToPtr("10.0.1.0/24")}})
+	require.NoError(t, err)
+
+	// 其他地域下查不到新建的子网
+	subnets, err := cloud.ListSubnet(kt, &core.AwsListOption{Region: "ap-northeast-1"})
+	require.NoError(t, err)
+	assert.Empty(t, subnets.Details)
+
+	subnets, err = cloud.ListSubnet(kt, &core.AwsListOption{Region: region})
+	require.NoError(t, err)
+	require.Len(t, subnets.Details, 1)
+	assert.Equal(t, subnet.CloudID, subnets.Details[0].CloudID)
+	assert.Equal(t, []string{"10.0.1.0/24"}, subnets.Details[0].Ipv4Cidr)
+
+	// vpc下还有子网时不允许删除
+	deleteVpcOpt := &core.BaseRegionalDeleteOption{Region: region,
+		BaseDeleteOption: core.BaseDeleteOption{ResourceID: vpc.CloudID}}
+	require.Error(t, cloud.DeleteVpc(kt, deleteVpcOpt))
+
+	err = cloud.DeleteSubnet(kt, &core.BaseRegionalDeleteOption{Region: region,
+		BaseDeleteOption: core.BaseDeleteOption{ResourceID: subnet.CloudID}})
+	require.NoError(t, err)
+	require.NoError(t, cloud.DeleteVpc(kt, deleteVpcOpt))
+
+	vpcs, err = cloud.ListVpc(kt, &core.AwsListOption{Region: region})
+	require.NoError(t, err)
+	assert.Empty(t, vpcs.Details)
+}
@@ -0,0 +1,3311 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+
+// Package mockazure is a generated GoMock package.
+package mockazure
+
+import (
+	azure "hcm/pkg/adaptor/azure"
+	types "hcm/pkg/adaptor/types"
+	account "hcm/pkg/adaptor/types/account"
+	bill "hcm/pkg/adaptor/types/bill"
+	core "hcm/pkg/adaptor/types/core"
+	cvm "hcm/pkg/adaptor/types/cvm"
+	disk "hcm/pkg/adaptor/types/disk"
+	eip "hcm/pkg/adaptor/types/eip"
+	image "hcm/pkg/adaptor/types/image"
+	instance_type "hcm/pkg/adaptor/types/instance-type"
+	network_interface "hcm/pkg/adaptor/types/network-interface"
+	region "hcm/pkg/adaptor/types/region"
+	resource_group "hcm/pkg/adaptor/types/resource-group"
+	route_table "hcm/pkg/adaptor/types/route-table"
+	security_group "hcm/pkg/adaptor/types/security-group"
+	security_group_rule "hcm/pkg/adaptor/types/security-group-rule"
+	subnet "hcm/pkg/adaptor/types/subnet"
+	cloud "hcm/pkg/api/core/cloud"
+	network_interface0 "hcm/pkg/api/core/cloud/network-interface"
+	kit "hcm/pkg/kit"
+	reflect "reflect"
+
+	runtime "github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	v5 "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	armconsumption "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/consumption/armconsumption"
+	v2 "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAzure is a mock of Azure interface.
+type MockAzure struct {
+	ctrl     *gomock.Controller
+	recorder *MockAzureMockRecorder
+}
+
+// MockAzureMockRecorder is the mock recorder for MockAzure.
+type MockAzureMockRecorder struct {
+	mock *MockAzure
+}
+
+// NewMockAzure creates a new mock instance.
+func NewMockAzure(ctrl *gomock.Controller) *MockAzure {
+	mock := &MockAzure{ctrl: ctrl}
+	mock.recorder = &MockAzureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAzure) EXPECT() *MockAzureMockRecorder {
+	return m.recorder
+}
+
+// AssociateEip mocks base method.
+func (m *MockAzure) AssociateEip(kt *kit.Kit, opt *eip.AzureEipAssociateOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssociateEip", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssociateEip indicates an expected call of AssociateEip.
+func (mr *MockAzureMockRecorder) AssociateEip(kt, opt interface{}) *AzureAssociateEipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssociateEip", reflect.TypeOf((*MockAzure)(nil).AssociateEip), kt, opt)
+	return &AzureAssociateEipCall{Call: call}
+}
+
+// AzureAssociateEipCall wrap *gomock.Call
+type AzureAssociateEipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureAssociateEipCall) Return(arg0 error) *AzureAssociateEipCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureAssociateEipCall) Do(f func(*kit.Kit, *eip.AzureEipAssociateOption) error) *AzureAssociateEipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureAssociateEipCall) DoAndReturn(f func(*kit.Kit, *eip.AzureEipAssociateOption) error) *AzureAssociateEipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// AttachDisk mocks base method.
+func (m *MockAzure) AttachDisk(kt *kit.Kit, opt *disk.AzureDiskAttachOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachDisk", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachDisk indicates an expected call of AttachDisk.
+func (mr *MockAzureMockRecorder) AttachDisk(kt, opt interface{}) *AzureAttachDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachDisk", reflect.TypeOf((*MockAzure)(nil).AttachDisk), kt, opt)
+	return &AzureAttachDiskCall{Call: call}
+}
+
+// AzureAttachDiskCall wrap *gomock.Call
+type AzureAttachDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureAttachDiskCall) Return(arg0 error) *AzureAttachDiskCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureAttachDiskCall) Do(f func(*kit.Kit, *disk.AzureDiskAttachOption) error) *AzureAttachDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureAttachDiskCall) DoAndReturn(f func(*kit.Kit, *disk.AzureDiskAttachOption) error) *AzureAttachDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ConvertCloudNetworkInterface mocks base method.
+func (m *MockAzure) ConvertCloudNetworkInterface(kt *kit.Kit, data *v2.Interface) *network_interface.AzureNI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConvertCloudNetworkInterface", kt, data)
+	ret0, _ := ret[0].(*network_interface.AzureNI)
+	return ret0
+}
+
+// ConvertCloudNetworkInterface indicates an expected call of ConvertCloudNetworkInterface.
+func (mr *MockAzureMockRecorder) ConvertCloudNetworkInterface(kt, data interface{}) *AzureConvertCloudNetworkInterfaceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConvertCloudNetworkInterface", reflect.TypeOf((*MockAzure)(nil).ConvertCloudNetworkInterface), kt, data)
+	return &AzureConvertCloudNetworkInterfaceCall{Call: call}
+}
+
+// AzureConvertCloudNetworkInterfaceCall wrap *gomock.Call
+type AzureConvertCloudNetworkInterfaceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureConvertCloudNetworkInterfaceCall) Return(arg0 *network_interface.AzureNI) *AzureConvertCloudNetworkInterfaceCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureConvertCloudNetworkInterfaceCall) Do(f func(*kit.Kit, *v2.Interface) *network_interface.AzureNI) *AzureConvertCloudNetworkInterfaceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureConvertCloudNetworkInterfaceCall) DoAndReturn(f func(*kit.Kit, *v2.Interface) *network_interface.AzureNI) *AzureConvertCloudNetworkInterfaceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ConvertRouteTable mocks base method.
+func (m *MockAzure) ConvertRouteTable(data *v2.RouteTable, resourceGroup, subscription string) *route_table.AzureRouteTable {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConvertRouteTable", data, resourceGroup, subscription)
+	ret0, _ := ret[0].(*route_table.AzureRouteTable)
+	return ret0
+}
+
+// ConvertRouteTable indicates an expected call of ConvertRouteTable.
+func (mr *MockAzureMockRecorder) ConvertRouteTable(data, resourceGroup, subscription interface{}) *AzureConvertRouteTableCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConvertRouteTable", reflect.TypeOf((*MockAzure)(nil).ConvertRouteTable), data, resourceGroup, subscription)
+	return &AzureConvertRouteTableCall{Call: call}
+}
+
+// AzureConvertRouteTableCall wrap *gomock.Call
+type AzureConvertRouteTableCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureConvertRouteTableCall) Return(arg0 *route_table.AzureRouteTable) *AzureConvertRouteTableCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureConvertRouteTableCall) Do(f func(*v2.RouteTable, string, string) *route_table.AzureRouteTable) *AzureConvertRouteTableCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureConvertRouteTableCall) DoAndReturn(f func(*v2.RouteTable, string, string) *route_table.AzureRouteTable) *AzureConvertRouteTableCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountAccount mocks base method.
+func (m *MockAzure) CountAccount(kt *kit.Kit) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAccount", kt)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAccount indicates an expected call of CountAccount.
+func (mr *MockAzureMockRecorder) CountAccount(kt interface{}) *AzureCountAccountCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAccount", reflect.TypeOf((*MockAzure)(nil).CountAccount), kt)
+	return &AzureCountAccountCall{Call: call}
+}
+
+// AzureCountAccountCall wrap *gomock.Call
+type AzureCountAccountCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureCountAccountCall) Return(arg0 int32, arg1 error) *AzureCountAccountCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureCountAccountCall) Do(f func(*kit.Kit) (int32, error)) *AzureCountAccountCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureCountAccountCall) DoAndReturn(f func(*kit.Kit) (int32, error)) *AzureCountAccountCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountCvm mocks base method.
+func (m *MockAzure) CountCvm(kt *kit.Kit) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCvm", kt)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCvm indicates an expected call of CountCvm.
+func (mr *MockAzureMockRecorder) CountCvm(kt interface{}) *AzureCountCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCvm", reflect.TypeOf((*MockAzure)(nil).CountCvm), kt)
+	return &AzureCountCvmCall{Call: call}
+}
+
+// AzureCountCvmCall wrap *gomock.Call
+type AzureCountCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureCountCvmCall) Return(arg0 int32, arg1 error) *AzureCountCvmCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureCountCvmCall) Do(f func(*kit.Kit) (int32, error)) *AzureCountCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureCountCvmCall) DoAndReturn(f func(*kit.Kit) (int32, error)) *AzureCountCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountDisk mocks base method.
+func (m *MockAzure) CountDisk(kt *kit.Kit) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDisk", kt)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDisk indicates an expected call of CountDisk.
+func (mr *MockAzureMockRecorder) CountDisk(kt interface{}) *AzureCountDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDisk", reflect.TypeOf((*MockAzure)(nil).CountDisk), kt)
+	return &AzureCountDiskCall{Call: call}
+}
+
+// AzureCountDiskCall wrap *gomock.Call
+type AzureCountDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureCountDiskCall) Return(arg0 int32, arg1 error) *AzureCountDiskCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureCountDiskCall) Do(f func(*kit.Kit) (int32, error)) *AzureCountDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureCountDiskCall) DoAndReturn(f func(*kit.Kit) (int32, error)) *AzureCountDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountEip mocks base method.
+func (m *MockAzure) CountEip(kt *kit.Kit) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountEip", kt)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountEip indicates an expected call of CountEip.
+func (mr *MockAzureMockRecorder) CountEip(kt interface{}) *AzureCountEipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEip", reflect.TypeOf((*MockAzure)(nil).CountEip), kt)
+	return &AzureCountEipCall{Call: call}
+}
+
+// AzureCountEipCall wrap *gomock.Call
+type AzureCountEipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureCountEipCall) Return(arg0 int32, arg1 error) *AzureCountEipCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureCountEipCall) Do(f func(*kit.Kit) (int32, error)) *AzureCountEipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureCountEipCall) DoAndReturn(f func(*kit.Kit) (int32, error)) *AzureCountEipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountNI mocks base method.
+func (m *MockAzure) CountNI(kt *kit.Kit) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountNI", kt)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountNI indicates an expected call of CountNI.
+func (mr *MockAzureMockRecorder) CountNI(kt interface{}) *AzureCountNICall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountNI", reflect.TypeOf((*MockAzure)(nil).CountNI), kt)
+	return &AzureCountNICall{Call: call}
+}
+
+// AzureCountNICall wrap *gomock.Call
+type AzureCountNICall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureCountNICall) Return(arg0 int32, arg1 error) *AzureCountNICall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureCountNICall) Do(f func(*kit.Kit) (int32, error)) *AzureCountNICall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureCountNICall) DoAndReturn(f func(*kit.Kit) (int32, error)) *AzureCountNICall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountRouteTable mocks base method.
+func (m *MockAzure) CountRouteTable(kt *kit.Kit) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRouteTable", kt)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRouteTable indicates an expected call of CountRouteTable.
+func (mr *MockAzureMockRecorder) CountRouteTable(kt interface{}) *AzureCountRouteTableCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRouteTable", reflect.TypeOf((*MockAzure)(nil).CountRouteTable), kt)
+	return &AzureCountRouteTableCall{Call: call}
+}
+
+// AzureCountRouteTableCall wrap *gomock.Call
+type AzureCountRouteTableCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureCountRouteTableCall) Return(arg0 int32, arg1 error) *AzureCountRouteTableCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureCountRouteTableCall) Do(f func(*kit.Kit) (int32, error)) *AzureCountRouteTableCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureCountRouteTableCall) DoAndReturn(f func(*kit.Kit) (int32, error)) *AzureCountRouteTableCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountSecurityGroup mocks base method.
+func (m *MockAzure) CountSecurityGroup(kt *kit.Kit) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSecurityGroup", kt)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSecurityGroup indicates an expected call of CountSecurityGroup.
+func (mr *MockAzureMockRecorder) CountSecurityGroup(kt interface{}) *AzureCountSecurityGroupCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSecurityGroup", reflect.TypeOf((*MockAzure)(nil).CountSecurityGroup), kt)
+	return &AzureCountSecurityGroupCall{Call: call}
+}
+
+// AzureCountSecurityGroupCall wrap *gomock.Call
+type AzureCountSecurityGroupCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureCountSecurityGroupCall) Return(arg0 int32, arg1 error) *AzureCountSecurityGroupCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureCountSecurityGroupCall) Do(f func(*kit.Kit) (int32, error)) *AzureCountSecurityGroupCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureCountSecurityGroupCall) DoAndReturn(f func(*kit.Kit) (int32, error)) *AzureCountSecurityGroupCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountVpcAndSubnet mocks base method.
+func (m *MockAzure) CountVpcAndSubnet(kt *kit.Kit) (int32, int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountVpcAndSubnet", kt)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(int32)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CountVpcAndSubnet indicates an expected call of CountVpcAndSubnet.
+func (mr *MockAzureMockRecorder) CountVpcAndSubnet(kt interface{}) *AzureCountVpcAndSubnetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountVpcAndSubnet", reflect.TypeOf((*MockAzure)(nil).CountVpcAndSubnet), kt)
+	return &AzureCountVpcAndSubnetCall{Call: call}
+}
+
+// AzureCountVpcAndSubnetCall wrap *gomock.Call
+type AzureCountVpcAndSubnetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureCountVpcAndSubnetCall) Return(arg0, arg1 int32, arg2 error) *AzureCountVpcAndSubnetCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureCountVpcAndSubnetCall) Do(f func(*kit.Kit) (int32, int32, error)) *AzureCountVpcAndSubnetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureCountVpcAndSubnetCall) DoAndReturn(f func(*kit.Kit) (int32, int32, error)) *AzureCountVpcAndSubnetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateCvm mocks base method.
+func (m *MockAzure) CreateCvm(kt *kit.Kit, opt *cvm.AzureCreateOption) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCvm", kt, opt)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCvm indicates an expected call of CreateCvm.
+func (mr *MockAzureMockRecorder) CreateCvm(kt, opt interface{}) *AzureCreateCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCvm", reflect.TypeOf((*MockAzure)(nil).CreateCvm), kt, opt)
+	return &AzureCreateCvmCall{Call: call}
+}
+
+// AzureCreateCvmCall wrap *gomock.Call
+type AzureCreateCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureCreateCvmCall) Return(arg0 string, arg1 error) *AzureCreateCvmCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureCreateCvmCall) Do(f func(*kit.Kit, *cvm.AzureCreateOption) (string, error)) *AzureCreateCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureCreateCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.AzureCreateOption) (string, error)) *AzureCreateCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateDisk mocks base method.
+func (m *MockAzure) CreateDisk(kt *kit.Kit, opt *disk.AzureDiskCreateOption) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDisk", kt, opt)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDisk indicates an expected call of CreateDisk.
+func (mr *MockAzureMockRecorder) CreateDisk(kt, opt interface{}) *AzureCreateDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDisk", reflect.TypeOf((*MockAzure)(nil).CreateDisk), kt, opt)
+	return &AzureCreateDiskCall{Call: call}
+}
+
+// AzureCreateDiskCall wrap *gomock.Call
+type AzureCreateDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureCreateDiskCall) Return(arg0 []string, arg1 error) *AzureCreateDiskCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureCreateDiskCall) Do(f func(*kit.Kit, *disk.AzureDiskCreateOption) ([]string, error)) *AzureCreateDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureCreateDiskCall) DoAndReturn(f func(*kit.Kit, *disk.AzureDiskCreateOption) ([]string, error)) *AzureCreateDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateEip mocks base method.
+func (m *MockAzure) CreateEip(kt *kit.Kit, opt *eip.AzureEipCreateOption) (*string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEip", kt, opt)
+	ret0, _ := ret[0].(*string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEip indicates an expected call of CreateEip.
+func (mr *MockAzureMockRecorder) CreateEip(kt, opt interface{}) *AzureCreateEipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEip", reflect.TypeOf((*MockAzure)(nil).CreateEip), kt, opt)
+	return &AzureCreateEipCall{Call: call}
+}
+
+// AzureCreateEipCall wrap *gomock.Call
+type AzureCreateEipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureCreateEipCall) Return(arg0 *string, arg1 error) *AzureCreateEipCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureCreateEipCall) Do(f func(*kit.Kit, *eip.AzureEipCreateOption) (*string, error)) *AzureCreateEipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureCreateEipCall) DoAndReturn(f func(*kit.Kit, *eip.AzureEipCreateOption) (*string, error)) *AzureCreateEipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateSecurityGroup mocks base method.
+func (m *MockAzure) CreateSecurityGroup(kt *kit.Kit, opt *security_group.AzureOption) (*security_group.AzureSecurityGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSecurityGroup", kt, opt)
+	ret0, _ := ret[0].(*security_group.AzureSecurityGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSecurityGroup indicates an expected call of CreateSecurityGroup.
+func (mr *MockAzureMockRecorder) CreateSecurityGroup(kt, opt interface{}) *AzureCreateSecurityGroupCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecurityGroup", reflect.TypeOf((*MockAzure)(nil).CreateSecurityGroup), kt, opt)
+	return &AzureCreateSecurityGroupCall{Call: call}
+}
+
+// AzureCreateSecurityGroupCall wrap *gomock.Call
+type AzureCreateSecurityGroupCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureCreateSecurityGroupCall) Return(arg0 *security_group.AzureSecurityGroup, arg1 error) *AzureCreateSecurityGroupCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureCreateSecurityGroupCall) Do(f func(*kit.Kit, *security_group.AzureOption) (*security_group.AzureSecurityGroup, error)) *AzureCreateSecurityGroupCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureCreateSecurityGroupCall) DoAndReturn(f func(*kit.Kit, *security_group.AzureOption) (*security_group.AzureSecurityGroup, error)) *AzureCreateSecurityGroupCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateSecurityGroupRule mocks base method.
+func (m *MockAzure) CreateSecurityGroupRule(kt *kit.Kit, opt *security_group_rule.AzureCreateOption) ([]*security_group_rule.AzureSGRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSecurityGroupRule", kt, opt)
+	ret0, _ := ret[0].([]*security_group_rule.AzureSGRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSecurityGroupRule indicates an expected call of CreateSecurityGroupRule.
+func (mr *MockAzureMockRecorder) CreateSecurityGroupRule(kt, opt interface{}) *AzureCreateSecurityGroupRuleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecurityGroupRule", reflect.TypeOf((*MockAzure)(nil).CreateSecurityGroupRule), kt, opt)
+	return &AzureCreateSecurityGroupRuleCall{Call: call}
+}
+
+// AzureCreateSecurityGroupRuleCall wrap *gomock.Call
+type AzureCreateSecurityGroupRuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureCreateSecurityGroupRuleCall) Return(arg0 []*security_group_rule.AzureSGRule, arg1 error) *AzureCreateSecurityGroupRuleCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureCreateSecurityGroupRuleCall) Do(f func(*kit.Kit, *security_group_rule.AzureCreateOption) ([]*security_group_rule.AzureSGRule, error)) *AzureCreateSecurityGroupRuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureCreateSecurityGroupRuleCall) DoAndReturn(f func(*kit.Kit, *security_group_rule.AzureCreateOption) ([]*security_group_rule.AzureSGRule, error)) *AzureCreateSecurityGroupRuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateSubnet mocks base method.
+func (m *MockAzure) CreateSubnet(kt *kit.Kit, opt *subnet.AzureSubnetCreateOption) (*subnet.AzureSubnet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubnet", kt, opt)
+	ret0, _ := ret[0].(*subnet.AzureSubnet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubnet indicates an expected call of CreateSubnet.
+func (mr *MockAzureMockRecorder) CreateSubnet(kt, opt interface{}) *AzureCreateSubnetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubnet", reflect.TypeOf((*MockAzure)(nil).CreateSubnet), kt, opt)
+	return &AzureCreateSubnetCall{Call: call}
+}
+
+// AzureCreateSubnetCall wrap *gomock.Call
+type AzureCreateSubnetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureCreateSubnetCall) Return(arg0 *subnet.AzureSubnet, arg1 error) *AzureCreateSubnetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureCreateSubnetCall) Do(f func(*kit.Kit, *subnet.AzureSubnetCreateOption) (*subnet.AzureSubnet, error)) *AzureCreateSubnetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureCreateSubnetCall) DoAndReturn(f func(*kit.Kit, *subnet.AzureSubnetCreateOption) (*subnet.AzureSubnet, error)) *AzureCreateSubnetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateVpc mocks base method.
+func (m *MockAzure) CreateVpc(kt *kit.Kit, opt *types.AzureVpcCreateOption) (*types.AzureVpc, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVpc", kt, opt)
+	ret0, _ := ret[0].(*types.AzureVpc)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVpc indicates an expected call of CreateVpc.
+func (mr *MockAzureMockRecorder) CreateVpc(kt, opt interface{}) *AzureCreateVpcCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVpc", reflect.TypeOf((*MockAzure)(nil).CreateVpc), kt, opt)
+	return &AzureCreateVpcCall{Call: call}
+}
+
+// AzureCreateVpcCall wrap *gomock.Call
+type AzureCreateVpcCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureCreateVpcCall) Return(arg0 *types.AzureVpc, arg1 error) *AzureCreateVpcCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureCreateVpcCall) Do(f func(*kit.Kit, *types.AzureVpcCreateOption) (*types.AzureVpc, error)) *AzureCreateVpcCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureCreateVpcCall) DoAndReturn(f func(*kit.Kit, *types.AzureVpcCreateOption) (*types.AzureVpc, error)) *AzureCreateVpcCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteCvm mocks base method.
+func (m *MockAzure) DeleteCvm(kt *kit.Kit, opt *cvm.AzureDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCvm", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCvm indicates an expected call of DeleteCvm.
+func (mr *MockAzureMockRecorder) DeleteCvm(kt, opt interface{}) *AzureDeleteCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCvm", reflect.TypeOf((*MockAzure)(nil).DeleteCvm), kt, opt)
+	return &AzureDeleteCvmCall{Call: call}
+}
+
+// AzureDeleteCvmCall wrap *gomock.Call
+type AzureDeleteCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureDeleteCvmCall) Return(arg0 error) *AzureDeleteCvmCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureDeleteCvmCall) Do(f func(*kit.Kit, *cvm.AzureDeleteOption) error) *AzureDeleteCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureDeleteCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.AzureDeleteOption) error) *AzureDeleteCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteDisk mocks base method.
+func (m *MockAzure) DeleteDisk(kt *kit.Kit, opt *disk.AzureDiskDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDisk", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDisk indicates an expected call of DeleteDisk.
+func (mr *MockAzureMockRecorder) DeleteDisk(kt, opt interface{}) *AzureDeleteDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDisk", reflect.TypeOf((*MockAzure)(nil).DeleteDisk), kt, opt)
+	return &AzureDeleteDiskCall{Call: call}
+}
+
+// AzureDeleteDiskCall wrap *gomock.Call
+type AzureDeleteDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureDeleteDiskCall) Return(arg0 error) *AzureDeleteDiskCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureDeleteDiskCall) Do(f func(*kit.Kit, *disk.AzureDiskDeleteOption) error) *AzureDeleteDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureDeleteDiskCall) DoAndReturn(f func(*kit.Kit, *disk.AzureDiskDeleteOption) error) *AzureDeleteDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteEip mocks base method.
+func (m *MockAzure) DeleteEip(kt *kit.Kit, opt *eip.AzureEipDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEip", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEip indicates an expected call of DeleteEip.
+func (mr *MockAzureMockRecorder) DeleteEip(kt, opt interface{}) *AzureDeleteEipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEip", reflect.TypeOf((*MockAzure)(nil).DeleteEip), kt, opt)
+	return &AzureDeleteEipCall{Call: call}
+}
+
+// AzureDeleteEipCall wrap *gomock.Call
+type AzureDeleteEipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureDeleteEipCall) Return(arg0 error) *AzureDeleteEipCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureDeleteEipCall) Do(f func(*kit.Kit, *eip.AzureEipDeleteOption) error) *AzureDeleteEipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureDeleteEipCall) DoAndReturn(f func(*kit.Kit, *eip.AzureEipDeleteOption) error) *AzureDeleteEipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteRouteTable mocks base method.
+func (m *MockAzure) DeleteRouteTable(kt *kit.Kit, opt *core.AzureDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRouteTable", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRouteTable indicates an expected call of DeleteRouteTable.
+func (mr *MockAzureMockRecorder) DeleteRouteTable(kt, opt interface{}) *AzureDeleteRouteTableCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRouteTable", reflect.TypeOf((*MockAzure)(nil).DeleteRouteTable), kt, opt)
+	return &AzureDeleteRouteTableCall{Call: call}
+}
+
+// AzureDeleteRouteTableCall wrap *gomock.Call
+type AzureDeleteRouteTableCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureDeleteRouteTableCall) Return(arg0 error) *AzureDeleteRouteTableCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureDeleteRouteTableCall) Do(f func(*kit.Kit, *core.AzureDeleteOption) error) *AzureDeleteRouteTableCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureDeleteRouteTableCall) DoAndReturn(f func(*kit.Kit, *core.AzureDeleteOption) error) *AzureDeleteRouteTableCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteSecurityGroup mocks base method.
+func (m *MockAzure) DeleteSecurityGroup(kt *kit.Kit, opt *security_group.AzureOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecurityGroup", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecurityGroup indicates an expected call of DeleteSecurityGroup.
+func (mr *MockAzureMockRecorder) DeleteSecurityGroup(kt, opt interface{}) *AzureDeleteSecurityGroupCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecurityGroup", reflect.TypeOf((*MockAzure)(nil).DeleteSecurityGroup), kt, opt)
+	return &AzureDeleteSecurityGroupCall{Call: call}
+}
+
+// AzureDeleteSecurityGroupCall wrap *gomock.Call
+type AzureDeleteSecurityGroupCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureDeleteSecurityGroupCall) Return(arg0 error) *AzureDeleteSecurityGroupCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureDeleteSecurityGroupCall) Do(f func(*kit.Kit, *security_group.AzureOption) error) *AzureDeleteSecurityGroupCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureDeleteSecurityGroupCall) DoAndReturn(f func(*kit.Kit, *security_group.AzureOption) error) *AzureDeleteSecurityGroupCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteSecurityGroupRule mocks base method.
+func (m *MockAzure) DeleteSecurityGroupRule(kt *kit.Kit, opt *security_group_rule.AzureDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecurityGroupRule", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecurityGroupRule indicates an expected call of DeleteSecurityGroupRule.
+func (mr *MockAzureMockRecorder) DeleteSecurityGroupRule(kt, opt interface{}) *AzureDeleteSecurityGroupRuleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecurityGroupRule", reflect.TypeOf((*MockAzure)(nil).DeleteSecurityGroupRule), kt, opt)
+	return &AzureDeleteSecurityGroupRuleCall{Call: call}
+}
+
+// AzureDeleteSecurityGroupRuleCall wrap *gomock.Call
+type AzureDeleteSecurityGroupRuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureDeleteSecurityGroupRuleCall) Return(arg0 error) *AzureDeleteSecurityGroupRuleCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureDeleteSecurityGroupRuleCall) Do(f func(*kit.Kit, *security_group_rule.AzureDeleteOption) error) *AzureDeleteSecurityGroupRuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureDeleteSecurityGroupRuleCall) DoAndReturn(f func(*kit.Kit, *security_group_rule.AzureDeleteOption) error) *AzureDeleteSecurityGroupRuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteSubnet mocks base method.
+func (m *MockAzure) DeleteSubnet(kt *kit.Kit, opt *subnet.AzureSubnetDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubnet", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubnet indicates an expected call of DeleteSubnet.
+func (mr *MockAzureMockRecorder) DeleteSubnet(kt, opt interface{}) *AzureDeleteSubnetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubnet", reflect.TypeOf((*MockAzure)(nil).DeleteSubnet), kt, opt)
+	return &AzureDeleteSubnetCall{Call: call}
+}
+
+// AzureDeleteSubnetCall wrap *gomock.Call
+type AzureDeleteSubnetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureDeleteSubnetCall) Return(arg0 error) *AzureDeleteSubnetCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureDeleteSubnetCall) Do(f func(*kit.Kit, *subnet.AzureSubnetDeleteOption) error) *AzureDeleteSubnetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureDeleteSubnetCall) DoAndReturn(f func(*kit.Kit, *subnet.AzureSubnetDeleteOption) error) *AzureDeleteSubnetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteVpc mocks base method.
+func (m *MockAzure) DeleteVpc(kt *kit.Kit, opt *core.AzureDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVpc", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVpc indicates an expected call of DeleteVpc.
+func (mr *MockAzureMockRecorder) DeleteVpc(kt, opt interface{}) *AzureDeleteVpcCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVpc", reflect.TypeOf((*MockAzure)(nil).DeleteVpc), kt, opt)
+	return &AzureDeleteVpcCall{Call: call}
+}
+
+// AzureDeleteVpcCall wrap *gomock.Call
+type AzureDeleteVpcCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureDeleteVpcCall) Return(arg0 error) *AzureDeleteVpcCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureDeleteVpcCall) Do(f func(*kit.Kit, *core.AzureDeleteOption) error) *AzureDeleteVpcCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureDeleteVpcCall) DoAndReturn(f func(*kit.Kit, *core.AzureDeleteOption) error) *AzureDeleteVpcCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DetachDisk mocks base method.
+func (m *MockAzure) DetachDisk(kt *kit.Kit, opt *disk.AzureDiskDetachOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachDisk", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachDisk indicates an expected call of DetachDisk.
+func (mr *MockAzureMockRecorder) DetachDisk(kt, opt interface{}) *AzureDetachDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachDisk", reflect.TypeOf((*MockAzure)(nil).DetachDisk), kt, opt)
+	return &AzureDetachDiskCall{Call: call}
+}
+
+// AzureDetachDiskCall wrap *gomock.Call
+type AzureDetachDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureDetachDiskCall) Return(arg0 error) *AzureDetachDiskCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureDetachDiskCall) Do(f func(*kit.Kit, *disk.AzureDiskDetachOption) error) *AzureDetachDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureDetachDiskCall) DoAndReturn(f func(*kit.Kit, *disk.AzureDiskDetachOption) error) *AzureDetachDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DisassociateEip mocks base method.
+func (m *MockAzure) DisassociateEip(kt *kit.Kit, opt *eip.AzureEipDisassociateOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisassociateEip", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisassociateEip indicates an expected call of DisassociateEip.
+func (mr *MockAzureMockRecorder) DisassociateEip(kt, opt interface{}) *AzureDisassociateEipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisassociateEip", reflect.TypeOf((*MockAzure)(nil).DisassociateEip), kt, opt)
+	return &AzureDisassociateEipCall{Call: call}
+}
+
+// AzureDisassociateEipCall wrap *gomock.Call
+type AzureDisassociateEipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureDisassociateEipCall) Return(arg0 error) *AzureDisassociateEipCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureDisassociateEipCall) Do(f func(*kit.Kit, *eip.AzureEipDisassociateOption) error) *AzureDisassociateEipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureDisassociateEipCall) DoAndReturn(f func(*kit.Kit, *eip.AzureEipDisassociateOption) error) *AzureDisassociateEipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAccountInfoBySecret mocks base method.
+func (m *MockAzure) GetAccountInfoBySecret(kt *kit.Kit) (*cloud.AzureInfoBySecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountInfoBySecret", kt)
+	ret0, _ := ret[0].(*cloud.AzureInfoBySecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountInfoBySecret indicates an expected call of GetAccountInfoBySecret.
+func (mr *MockAzureMockRecorder) GetAccountInfoBySecret(kt interface{}) *AzureGetAccountInfoBySecretCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountInfoBySecret", reflect.TypeOf((*MockAzure)(nil).GetAccountInfoBySecret), kt)
+	return &AzureGetAccountInfoBySecretCall{Call: call}
+}
+
+// AzureGetAccountInfoBySecretCall wrap *gomock.Call
+type AzureGetAccountInfoBySecretCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureGetAccountInfoBySecretCall) Return(arg0 *cloud.AzureInfoBySecret, arg1 error) *AzureGetAccountInfoBySecretCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureGetAccountInfoBySecretCall) Do(f func(*kit.Kit) (*cloud.AzureInfoBySecret, error)) *AzureGetAccountInfoBySecretCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureGetAccountInfoBySecretCall) DoAndReturn(f func(*kit.Kit) (*cloud.AzureInfoBySecret, error)) *AzureGetAccountInfoBySecretCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetBillList mocks base method.
+func (m *MockAzure) GetBillList(kt *kit.Kit, opt *bill.AzureBillListOption) (*armconsumption.UsageDetailsListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBillList", kt, opt)
+	ret0, _ := ret[0].(*armconsumption.UsageDetailsListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBillList indicates an expected call of GetBillList.
+func (mr *MockAzureMockRecorder) GetBillList(kt, opt interface{}) *AzureGetBillListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBillList", reflect.TypeOf((*MockAzure)(nil).GetBillList), kt, opt)
+	return &AzureGetBillListCall{Call: call}
+}
+
+// AzureGetBillListCall wrap *gomock.Call
+type AzureGetBillListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureGetBillListCall) Return(arg0 *armconsumption.UsageDetailsListResult, arg1 error) *AzureGetBillListCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureGetBillListCall) Do(f func(*kit.Kit, *bill.AzureBillListOption) (*armconsumption.UsageDetailsListResult, error)) *AzureGetBillListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureGetBillListCall) DoAndReturn(f func(*kit.Kit, *bill.AzureBillListOption) (*armconsumption.UsageDetailsListResult, error)) *AzureGetBillListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetCvm mocks base method.
+func (m *MockAzure) GetCvm(kt *kit.Kit, opt *cvm.AzureGetOption) (*cvm.AzureCvm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCvm", kt, opt)
+	ret0, _ := ret[0].(*cvm.AzureCvm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCvm indicates an expected call of GetCvm.
+func (mr *MockAzureMockRecorder) GetCvm(kt, opt interface{}) *AzureGetCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCvm", reflect.TypeOf((*MockAzure)(nil).GetCvm), kt, opt)
+	return &AzureGetCvmCall{Call: call}
+}
+
+// AzureGetCvmCall wrap *gomock.Call
+type AzureGetCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureGetCvmCall) Return(arg0 *cvm.AzureCvm, arg1 error) *AzureGetCvmCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureGetCvmCall) Do(f func(*kit.Kit, *cvm.AzureGetOption) (*cvm.AzureCvm, error)) *AzureGetCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureGetCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.AzureGetOption) (*cvm.AzureCvm, error)) *AzureGetCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetCvmStatus mocks base method.
+func (m *MockAzure) GetCvmStatus(kt *kit.Kit, resGroupName, cvmName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCvmStatus", kt, resGroupName, cvmName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCvmStatus indicates an expected call of GetCvmStatus.
+func (mr *MockAzureMockRecorder) GetCvmStatus(kt, resGroupName, cvmName interface{}) *AzureGetCvmStatusCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCvmStatus", reflect.TypeOf((*MockAzure)(nil).GetCvmStatus), kt, resGroupName, cvmName)
+	return &AzureGetCvmStatusCall{Call: call}
+}
+
+// AzureGetCvmStatusCall wrap *gomock.Call
+type AzureGetCvmStatusCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureGetCvmStatusCall) Return(arg0 string, arg1 error) *AzureGetCvmStatusCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureGetCvmStatusCall) Do(f func(*kit.Kit, string, string) (string, error)) *AzureGetCvmStatusCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureGetCvmStatusCall) DoAndReturn(f func(*kit.Kit, string, string) (string, error)) *AzureGetCvmStatusCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetDisk mocks base method.
+func (m *MockAzure) GetDisk(kt *kit.Kit, opt *disk.AzureDiskGetOption) (*disk.AzureDisk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDisk", kt, opt)
+	ret0, _ := ret[0].(*disk.AzureDisk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDisk indicates an expected call of GetDisk.
+func (mr *MockAzureMockRecorder) GetDisk(kt, opt interface{}) *AzureGetDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDisk", reflect.TypeOf((*MockAzure)(nil).GetDisk), kt, opt)
+	return &AzureGetDiskCall{Call: call}
+}
+
+// AzureGetDiskCall wrap *gomock.Call
+type AzureGetDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureGetDiskCall) Return(arg0 *disk.AzureDisk, arg1 error) *AzureGetDiskCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureGetDiskCall) Do(f func(*kit.Kit, *disk.AzureDiskGetOption) (*disk.AzureDisk, error)) *AzureGetDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureGetDiskCall) DoAndReturn(f func(*kit.Kit, *disk.AzureDiskGetOption) (*disk.AzureDisk, error)) *AzureGetDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetEipByCloudID mocks base method.
+func (m *MockAzure) GetEipByCloudID(kt *kit.Kit, resourceGroupName, cloudPublicIP string) (*eip.AzureEip, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEipByCloudID", kt, resourceGroupName, cloudPublicIP)
+	ret0, _ := ret[0].(*eip.AzureEip)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEipByCloudID indicates an expected call of GetEipByCloudID.
+func (mr *MockAzureMockRecorder) GetEipByCloudID(kt, resourceGroupName, cloudPublicIP interface{}) *AzureGetEipByCloudIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEipByCloudID", reflect.TypeOf((*MockAzure)(nil).GetEipByCloudID), kt, resourceGroupName, cloudPublicIP)
+	return &AzureGetEipByCloudIDCall{Call: call}
+}
+
+// AzureGetEipByCloudIDCall wrap *gomock.Call
+type AzureGetEipByCloudIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureGetEipByCloudIDCall) Return(arg0 *eip.AzureEip, arg1 error) *AzureGetEipByCloudIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureGetEipByCloudIDCall) Do(f func(*kit.Kit, string, string) (*eip.AzureEip, error)) *AzureGetEipByCloudIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureGetEipByCloudIDCall) DoAndReturn(f func(*kit.Kit, string, string) (*eip.AzureEip, error)) *AzureGetEipByCloudIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetNetworkInterface mocks base method.
+func (m *MockAzure) GetNetworkInterface(kt *kit.Kit, opt *core.AzureListOption) (*network_interface.AzureNI, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkInterface", kt, opt)
+	ret0, _ := ret[0].(*network_interface.AzureNI)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetworkInterface indicates an expected call of GetNetworkInterface.
+func (mr *MockAzureMockRecorder) GetNetworkInterface(kt, opt interface{}) *AzureGetNetworkInterfaceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkInterface", reflect.TypeOf((*MockAzure)(nil).GetNetworkInterface), kt, opt)
+	return &AzureGetNetworkInterfaceCall{Call: call}
+}
+
+// AzureGetNetworkInterfaceCall wrap *gomock.Call
+type AzureGetNetworkInterfaceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureGetNetworkInterfaceCall) Return(arg0 *network_interface.AzureNI, arg1 error) *AzureGetNetworkInterfaceCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureGetNetworkInterfaceCall) Do(f func(*kit.Kit, *core.AzureListOption) (*network_interface.AzureNI, error)) *AzureGetNetworkInterfaceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureGetNetworkInterfaceCall) DoAndReturn(f func(*kit.Kit, *core.AzureListOption) (*network_interface.AzureNI, error)) *AzureGetNetworkInterfaceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetRouteTable mocks base method.
+func (m *MockAzure) GetRouteTable(kt *kit.Kit, opt *route_table.AzureRouteTableGetOption) (*route_table.AzureRouteTable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRouteTable", kt, opt)
+	ret0, _ := ret[0].(*route_table.AzureRouteTable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRouteTable indicates an expected call of GetRouteTable.
+func (mr *MockAzureMockRecorder) GetRouteTable(kt, opt interface{}) *AzureGetRouteTableCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRouteTable", reflect.TypeOf((*MockAzure)(nil).GetRouteTable), kt, opt)
+	return &AzureGetRouteTableCall{Call: call}
+}
+
+// AzureGetRouteTableCall wrap *gomock.Call
+type AzureGetRouteTableCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureGetRouteTableCall) Return(arg0 *route_table.AzureRouteTable, arg1 error) *AzureGetRouteTableCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureGetRouteTableCall) Do(f func(*kit.Kit, *route_table.AzureRouteTableGetOption) (*route_table.AzureRouteTable, error)) *AzureGetRouteTableCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureGetRouteTableCall) DoAndReturn(f func(*kit.Kit, *route_table.AzureRouteTableGetOption) (*route_table.AzureRouteTable, error)) *AzureGetRouteTableCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListAccount mocks base method.
+func (m *MockAzure) ListAccount(kt *kit.Kit) ([]account.AzureAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccount", kt)
+	ret0, _ := ret[0].([]account.AzureAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccount indicates an expected call of ListAccount.
+func (mr *MockAzureMockRecorder) ListAccount(kt interface{}) *AzureListAccountCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccount", reflect.TypeOf((*MockAzure)(nil).ListAccount), kt)
+	return &AzureListAccountCall{Call: call}
+}
+
+// AzureListAccountCall wrap *gomock.Call
+type AzureListAccountCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListAccountCall) Return(arg0 []account.AzureAccount, arg1 error) *AzureListAccountCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListAccountCall) Do(f func(*kit.Kit) ([]account.AzureAccount, error)) *AzureListAccountCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListAccountCall) DoAndReturn(f func(*kit.Kit) ([]account.AzureAccount, error)) *AzureListAccountCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListCvm mocks base method.
+func (m *MockAzure) ListCvm(kt *kit.Kit, opt *cvm.AzureListOption) ([]*cvm.AzureCvm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCvm", kt, opt)
+	ret0, _ := ret[0].([]*cvm.AzureCvm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCvm indicates an expected call of ListCvm.
+func (mr *MockAzureMockRecorder) ListCvm(kt, opt interface{}) *AzureListCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCvm", reflect.TypeOf((*MockAzure)(nil).ListCvm), kt, opt)
+	return &AzureListCvmCall{Call: call}
+}
+
+// AzureListCvmCall wrap *gomock.Call
+type AzureListCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListCvmCall) Return(arg0 []*cvm.AzureCvm, arg1 error) *AzureListCvmCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListCvmCall) Do(f func(*kit.Kit, *cvm.AzureListOption) ([]*cvm.AzureCvm, error)) *AzureListCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.AzureListOption) ([]*cvm.AzureCvm, error)) *AzureListCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListCvmByID mocks base method.
+func (m *MockAzure) ListCvmByID(kt *kit.Kit, opt *core.AzureListByIDOption) ([]*cvm.AzureCvm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCvmByID", kt, opt)
+	ret0, _ := ret[0].([]*cvm.AzureCvm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCvmByID indicates an expected call of ListCvmByID.
+func (mr *MockAzureMockRecorder) ListCvmByID(kt, opt interface{}) *AzureListCvmByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCvmByID", reflect.TypeOf((*MockAzure)(nil).ListCvmByID), kt, opt)
+	return &AzureListCvmByIDCall{Call: call}
+}
+
+// AzureListCvmByIDCall wrap *gomock.Call
+type AzureListCvmByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListCvmByIDCall) Return(arg0 []*cvm.AzureCvm, arg1 error) *AzureListCvmByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListCvmByIDCall) Do(f func(*kit.Kit, *core.AzureListByIDOption) ([]*cvm.AzureCvm, error)) *AzureListCvmByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListCvmByIDCall) DoAndReturn(f func(*kit.Kit, *core.AzureListByIDOption) ([]*cvm.AzureCvm, error)) *AzureListCvmByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListCvmByPage mocks base method.
+func (m *MockAzure) ListCvmByPage(kt *kit.Kit, opt *cvm.AzureListOption) (*azure.Pager[v5.VirtualMachinesClientListResponse, cvm.AzureCvm], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCvmByPage", kt, opt)
+	ret0, _ := ret[0].(*azure.Pager[v5.VirtualMachinesClientListResponse, cvm.AzureCvm])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCvmByPage indicates an expected call of ListCvmByPage.
+func (mr *MockAzureMockRecorder) ListCvmByPage(kt, opt interface{}) *AzureListCvmByPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCvmByPage", reflect.TypeOf((*MockAzure)(nil).ListCvmByPage), kt, opt)
+	return &AzureListCvmByPageCall{Call: call}
+}
+
+// AzureListCvmByPageCall wrap *gomock.Call
+type AzureListCvmByPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListCvmByPageCall) Return(arg0 *azure.Pager[v5.VirtualMachinesClientListResponse, cvm.AzureCvm], arg1 error) *AzureListCvmByPageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListCvmByPageCall) Do(f func(*kit.Kit, *cvm.AzureListOption) (*azure.Pager[v5.VirtualMachinesClientListResponse, cvm.AzureCvm], error)) *AzureListCvmByPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListCvmByPageCall) DoAndReturn(f func(*kit.Kit, *cvm.AzureListOption) (*azure.Pager[v5.VirtualMachinesClientListResponse, cvm.AzureCvm], error)) *AzureListCvmByPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListDisk mocks base method.
+func (m *MockAzure) ListDisk(kt *kit.Kit, opt *disk.AzureDiskListOption) ([]*disk.AzureDisk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDisk", kt, opt)
+	ret0, _ := ret[0].([]*disk.AzureDisk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDisk indicates an expected call of ListDisk.
+func (mr *MockAzureMockRecorder) ListDisk(kt, opt interface{}) *AzureListDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDisk", reflect.TypeOf((*MockAzure)(nil).ListDisk), kt, opt)
+	return &AzureListDiskCall{Call: call}
+}
+
+// AzureListDiskCall wrap *gomock.Call
+type AzureListDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListDiskCall) Return(arg0 []*disk.AzureDisk, arg1 error) *AzureListDiskCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListDiskCall) Do(f func(*kit.Kit, *disk.AzureDiskListOption) ([]*disk.AzureDisk, error)) *AzureListDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListDiskCall) DoAndReturn(f func(*kit.Kit, *disk.AzureDiskListOption) ([]*disk.AzureDisk, error)) *AzureListDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListDiskByID mocks base method.
+func (m *MockAzure) ListDiskByID(kit *kit.Kit, opt *core.AzureListByIDOption) ([]*disk.AzureDisk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDiskByID", kit, opt)
+	ret0, _ := ret[0].([]*disk.AzureDisk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDiskByID indicates an expected call of ListDiskByID.
+func (mr *MockAzureMockRecorder) ListDiskByID(kit, opt interface{}) *AzureListDiskByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDiskByID", reflect.TypeOf((*MockAzure)(nil).ListDiskByID), kit, opt)
+	return &AzureListDiskByIDCall{Call: call}
+}
+
+// AzureListDiskByIDCall wrap *gomock.Call
+type AzureListDiskByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListDiskByIDCall) Return(arg0 []*disk.AzureDisk, arg1 error) *AzureListDiskByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListDiskByIDCall) Do(f func(*kit.Kit, *core.AzureListByIDOption) ([]*disk.AzureDisk, error)) *AzureListDiskByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListDiskByIDCall) DoAndReturn(f func(*kit.Kit, *core.AzureListByIDOption) ([]*disk.AzureDisk, error)) *AzureListDiskByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListDiskByPage mocks base method.
+func (m *MockAzure) ListDiskByPage(kt *kit.Kit, opt *disk.AzureDiskListOption) (*azure.Pager[v5.DisksClientListByResourceGroupResponse, disk.AzureDisk], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDiskByPage", kt, opt)
+	ret0, _ := ret[0].(*azure.Pager[v5.DisksClientListByResourceGroupResponse, disk.AzureDisk])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDiskByPage indicates an expected call of ListDiskByPage.
+func (mr *MockAzureMockRecorder) ListDiskByPage(kt, opt interface{}) *AzureListDiskByPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDiskByPage", reflect.TypeOf((*MockAzure)(nil).ListDiskByPage), kt, opt)
+	return &AzureListDiskByPageCall{Call: call}
+}
+
+// AzureListDiskByPageCall wrap *gomock.Call
+type AzureListDiskByPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListDiskByPageCall) Return(arg0 *azure.Pager[v5.DisksClientListByResourceGroupResponse, disk.AzureDisk], arg1 error) *AzureListDiskByPageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListDiskByPageCall) Do(f func(*kit.Kit, *disk.AzureDiskListOption) (*azure.Pager[v5.DisksClientListByResourceGroupResponse, disk.AzureDisk], error)) *AzureListDiskByPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListDiskByPageCall) DoAndReturn(f func(*kit.Kit, *disk.AzureDiskListOption) (*azure.Pager[v5.DisksClientListByResourceGroupResponse, disk.AzureDisk], error)) *AzureListDiskByPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListEipByID mocks base method.
+func (m *MockAzure) ListEipByID(kt *kit.Kit, opt *core.AzureListByIDOption) (*eip.AzureEipListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEipByID", kt, opt)
+	ret0, _ := ret[0].(*eip.AzureEipListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEipByID indicates an expected call of ListEipByID.
+func (mr *MockAzureMockRecorder) ListEipByID(kt, opt interface{}) *AzureListEipByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEipByID", reflect.TypeOf((*MockAzure)(nil).ListEipByID), kt, opt)
+	return &AzureListEipByIDCall{Call: call}
+}
+
+// AzureListEipByIDCall wrap *gomock.Call
+type AzureListEipByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListEipByIDCall) Return(arg0 *eip.AzureEipListResult, arg1 error) *AzureListEipByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListEipByIDCall) Do(f func(*kit.Kit, *core.AzureListByIDOption) (*eip.AzureEipListResult, error)) *AzureListEipByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListEipByIDCall) DoAndReturn(f func(*kit.Kit, *core.AzureListByIDOption) (*eip.AzureEipListResult, error)) *AzureListEipByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListEipByPage mocks base method.
+func (m *MockAzure) ListEipByPage(kt *kit.Kit, opt *core.AzureListOption) (*azure.Pager[v2.PublicIPAddressesClientListResponse, eip.AzureEip], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEipByPage", kt, opt)
+	ret0, _ := ret[0].(*azure.Pager[v2.PublicIPAddressesClientListResponse, eip.AzureEip])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEipByPage indicates an expected call of ListEipByPage.
+func (mr *MockAzureMockRecorder) ListEipByPage(kt, opt interface{}) *AzureListEipByPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEipByPage", reflect.TypeOf((*MockAzure)(nil).ListEipByPage), kt, opt)
+	return &AzureListEipByPageCall{Call: call}
+}
+
+// AzureListEipByPageCall wrap *gomock.Call
+type AzureListEipByPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListEipByPageCall) Return(arg0 *azure.Pager[v2.PublicIPAddressesClientListResponse, eip.AzureEip], arg1 error) *AzureListEipByPageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListEipByPageCall) Do(f func(*kit.Kit, *core.AzureListOption) (*azure.Pager[v2.PublicIPAddressesClientListResponse, eip.AzureEip], error)) *AzureListEipByPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListEipByPageCall) DoAndReturn(f func(*kit.Kit, *core.AzureListOption) (*azure.Pager[v2.PublicIPAddressesClientListResponse, eip.AzureEip], error)) *AzureListEipByPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListIP mocks base method.
+func (m *MockAzure) ListIP(kt *kit.Kit, opt *core.AzureListOption) ([]*network_interface0.InterfaceIPConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIP", kt, opt)
+	ret0, _ := ret[0].([]*network_interface0.InterfaceIPConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIP indicates an expected call of ListIP.
+func (mr *MockAzureMockRecorder) ListIP(kt, opt interface{}) *AzureListIPCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIP", reflect.TypeOf((*MockAzure)(nil).ListIP), kt, opt)
+	return &AzureListIPCall{Call: call}
+}
+
+// AzureListIPCall wrap *gomock.Call
+type AzureListIPCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListIPCall) Return(arg0 []*network_interface0.InterfaceIPConfiguration, arg1 error) *AzureListIPCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListIPCall) Do(f func(*kit.Kit, *core.AzureListOption) ([]*network_interface0.InterfaceIPConfiguration, error)) *AzureListIPCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListIPCall) DoAndReturn(f func(*kit.Kit, *core.AzureListOption) ([]*network_interface0.InterfaceIPConfiguration, error)) *AzureListIPCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListImage mocks base method.
+func (m *MockAzure) ListImage(kt *kit.Kit, opt *image.AzureImageListOption) (*image.AzureImageListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImage", kt, opt)
+	ret0, _ := ret[0].(*image.AzureImageListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImage indicates an expected call of ListImage.
+func (mr *MockAzureMockRecorder) ListImage(kt, opt interface{}) *AzureListImageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImage", reflect.TypeOf((*MockAzure)(nil).ListImage), kt, opt)
+	return &AzureListImageCall{Call: call}
+}
+
+// AzureListImageCall wrap *gomock.Call
+type AzureListImageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListImageCall) Return(arg0 *image.AzureImageListResult, arg1 error) *AzureListImageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListImageCall) Do(f func(*kit.Kit, *image.AzureImageListOption) (*image.AzureImageListResult, error)) *AzureListImageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListImageCall) DoAndReturn(f func(*kit.Kit, *image.AzureImageListOption) (*image.AzureImageListResult, error)) *AzureListImageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListInstanceType mocks base method.
+func (m *MockAzure) ListInstanceType(kt *kit.Kit, opt *instance_type.AzureInstanceTypeListOption) ([]*instance_type.AzureInstanceType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInstanceType", kt, opt)
+	ret0, _ := ret[0].([]*instance_type.AzureInstanceType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInstanceType indicates an expected call of ListInstanceType.
+func (mr *MockAzureMockRecorder) ListInstanceType(kt, opt interface{}) *AzureListInstanceTypeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstanceType", reflect.TypeOf((*MockAzure)(nil).ListInstanceType), kt, opt)
+	return &AzureListInstanceTypeCall{Call: call}
+}
+
+// AzureListInstanceTypeCall wrap *gomock.Call
+type AzureListInstanceTypeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListInstanceTypeCall) Return(its []*instance_type.AzureInstanceType, err error) *AzureListInstanceTypeCall {
+	c.Call = c.Call.Return(its, err)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListInstanceTypeCall) Do(f func(*kit.Kit, *instance_type.AzureInstanceTypeListOption) ([]*instance_type.AzureInstanceType, error)) *AzureListInstanceTypeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListInstanceTypeCall) DoAndReturn(f func(*kit.Kit, *instance_type.AzureInstanceTypeListOption) ([]*instance_type.AzureInstanceType, error)) *AzureListInstanceTypeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListNetworkInterface mocks base method.
+func (m *MockAzure) ListNetworkInterface(kt *kit.Kit) (*network_interface.AzureInterfaceListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNetworkInterface", kt)
+	ret0, _ := ret[0].(*network_interface.AzureInterfaceListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNetworkInterface indicates an expected call of ListNetworkInterface.
+func (mr *MockAzureMockRecorder) ListNetworkInterface(kt interface{}) *AzureListNetworkInterfaceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNetworkInterface", reflect.TypeOf((*MockAzure)(nil).ListNetworkInterface), kt)
+	return &AzureListNetworkInterfaceCall{Call: call}
+}
+
+// AzureListNetworkInterfaceCall wrap *gomock.Call
+type AzureListNetworkInterfaceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListNetworkInterfaceCall) Return(arg0 *network_interface.AzureInterfaceListResult, arg1 error) *AzureListNetworkInterfaceCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListNetworkInterfaceCall) Do(f func(*kit.Kit) (*network_interface.AzureInterfaceListResult, error)) *AzureListNetworkInterfaceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListNetworkInterfaceCall) DoAndReturn(f func(*kit.Kit) (*network_interface.AzureInterfaceListResult, error)) *AzureListNetworkInterfaceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListNetworkInterfaceByID mocks base method.
+func (m *MockAzure) ListNetworkInterfaceByID(kt *kit.Kit, opt *core.AzureListByIDOption) (*network_interface.AzureInterfaceListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNetworkInterfaceByID", kt, opt)
+	ret0, _ := ret[0].(*network_interface.AzureInterfaceListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNetworkInterfaceByID indicates an expected call of ListNetworkInterfaceByID.
+func (mr *MockAzureMockRecorder) ListNetworkInterfaceByID(kt, opt interface{}) *AzureListNetworkInterfaceByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNetworkInterfaceByID", reflect.TypeOf((*MockAzure)(nil).ListNetworkInterfaceByID), kt, opt)
+	return &AzureListNetworkInterfaceByIDCall{Call: call}
+}
+
+// AzureListNetworkInterfaceByIDCall wrap *gomock.Call
+type AzureListNetworkInterfaceByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListNetworkInterfaceByIDCall) Return(arg0 *network_interface.AzureInterfaceListResult, arg1 error) *AzureListNetworkInterfaceByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListNetworkInterfaceByIDCall) Do(f func(*kit.Kit, *core.AzureListByIDOption) (*network_interface.AzureInterfaceListResult, error)) *AzureListNetworkInterfaceByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListNetworkInterfaceByIDCall) DoAndReturn(f func(*kit.Kit, *core.AzureListByIDOption) (*network_interface.AzureInterfaceListResult, error)) *AzureListNetworkInterfaceByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListNetworkInterfaceByIDPage mocks base method.
+func (m *MockAzure) ListNetworkInterfaceByIDPage(opt *core.AzureListByIDOption) (*runtime.Pager[v2.InterfacesClientListResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNetworkInterfaceByIDPage", opt)
+	ret0, _ := ret[0].(*runtime.Pager[v2.InterfacesClientListResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNetworkInterfaceByIDPage indicates an expected call of ListNetworkInterfaceByIDPage.
+func (mr *MockAzureMockRecorder) ListNetworkInterfaceByIDPage(opt interface{}) *AzureListNetworkInterfaceByIDPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNetworkInterfaceByIDPage", reflect.TypeOf((*MockAzure)(nil).ListNetworkInterfaceByIDPage), opt)
+	return &AzureListNetworkInterfaceByIDPageCall{Call: call}
+}
+
+// AzureListNetworkInterfaceByIDPageCall wrap *gomock.Call
+type AzureListNetworkInterfaceByIDPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListNetworkInterfaceByIDPageCall) Return(arg0 *runtime.Pager[v2.InterfacesClientListResponse], arg1 error) *AzureListNetworkInterfaceByIDPageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListNetworkInterfaceByIDPageCall) Do(f func(*core.AzureListByIDOption) (*runtime.Pager[v2.InterfacesClientListResponse], error)) *AzureListNetworkInterfaceByIDPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListNetworkInterfaceByIDPageCall) DoAndReturn(f func(*core.AzureListByIDOption) (*runtime.Pager[v2.InterfacesClientListResponse], error)) *AzureListNetworkInterfaceByIDPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListNetworkInterfaceByPage mocks base method.
+func (m *MockAzure) ListNetworkInterfaceByPage(kt *kit.Kit) (*azure.Pager[v2.InterfacesClientListAllResponse, network_interface.AzureNI], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNetworkInterfaceByPage", kt)
+	ret0, _ := ret[0].(*azure.Pager[v2.InterfacesClientListAllResponse, network_interface.AzureNI])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNetworkInterfaceByPage indicates an expected call of ListNetworkInterfaceByPage.
+func (mr *MockAzureMockRecorder) ListNetworkInterfaceByPage(kt interface{}) *AzureListNetworkInterfaceByPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNetworkInterfaceByPage", reflect.TypeOf((*MockAzure)(nil).ListNetworkInterfaceByPage), kt)
+	return &AzureListNetworkInterfaceByPageCall{Call: call}
+}
+
+// AzureListNetworkInterfaceByPageCall wrap *gomock.Call
+type AzureListNetworkInterfaceByPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListNetworkInterfaceByPageCall) Return(arg0 *azure.Pager[v2.InterfacesClientListAllResponse, network_interface.AzureNI], arg1 error) *AzureListNetworkInterfaceByPageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListNetworkInterfaceByPageCall) Do(f func(*kit.Kit) (*azure.Pager[v2.InterfacesClientListAllResponse, network_interface.AzureNI], error)) *AzureListNetworkInterfaceByPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListNetworkInterfaceByPageCall) DoAndReturn(f func(*kit.Kit) (*azure.Pager[v2.InterfacesClientListAllResponse, network_interface.AzureNI], error)) *AzureListNetworkInterfaceByPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListNetworkInterfacePage mocks base method.
+func (m *MockAzure) ListNetworkInterfacePage() (*runtime.Pager[v2.InterfacesClientListAllResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNetworkInterfacePage")
+	ret0, _ := ret[0].(*runtime.Pager[v2.InterfacesClientListAllResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNetworkInterfacePage indicates an expected call of ListNetworkInterfacePage.
+func (mr *MockAzureMockRecorder) ListNetworkInterfacePage() *AzureListNetworkInterfacePageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNetworkInterfacePage", reflect.TypeOf((*MockAzure)(nil).ListNetworkInterfacePage))
+	return &AzureListNetworkInterfacePageCall{Call: call}
+}
+
+// AzureListNetworkInterfacePageCall wrap *gomock.Call
+type AzureListNetworkInterfacePageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListNetworkInterfacePageCall) Return(arg0 *runtime.Pager[v2.InterfacesClientListAllResponse], arg1 error) *AzureListNetworkInterfacePageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListNetworkInterfacePageCall) Do(f func() (*runtime.Pager[v2.InterfacesClientListAllResponse], error)) *AzureListNetworkInterfacePageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListNetworkInterfacePageCall) DoAndReturn(f func() (*runtime.Pager[v2.InterfacesClientListAllResponse], error)) *AzureListNetworkInterfacePageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListNetworkSecurityGroup mocks base method.
+func (m *MockAzure) ListNetworkSecurityGroup(kt *kit.Kit, opt *core.AzureListOption) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNetworkSecurityGroup", kt, opt)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNetworkSecurityGroup indicates an expected call of ListNetworkSecurityGroup.
+func (mr *MockAzureMockRecorder) ListNetworkSecurityGroup(kt, opt interface{}) *AzureListNetworkSecurityGroupCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNetworkSecurityGroup", reflect.TypeOf((*MockAzure)(nil).ListNetworkSecurityGroup), kt, opt)
+	return &AzureListNetworkSecurityGroupCall{Call: call}
+}
+
+// AzureListNetworkSecurityGroupCall wrap *gomock.Call
+type AzureListNetworkSecurityGroupCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListNetworkSecurityGroupCall) Return(arg0 interface{}, arg1 error) *AzureListNetworkSecurityGroupCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListNetworkSecurityGroupCall) Do(f func(*kit.Kit, *core.AzureListOption) (interface{}, error)) *AzureListNetworkSecurityGroupCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListNetworkSecurityGroupCall) DoAndReturn(f func(*kit.Kit, *core.AzureListOption) (interface{}, error)) *AzureListNetworkSecurityGroupCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListRawNetworkInterfaceByIDs mocks base method.
+func (m *MockAzure) ListRawNetworkInterfaceByIDs(kt *kit.Kit, opt *core.AzureListByIDOption) ([]*v2.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRawNetworkInterfaceByIDs", kt, opt)
+	ret0, _ := ret[0].([]*v2.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRawNetworkInterfaceByIDs indicates an expected call of ListRawNetworkInterfaceByIDs.
+func (mr *MockAzureMockRecorder) ListRawNetworkInterfaceByIDs(kt, opt interface{}) *AzureListRawNetworkInterfaceByIDsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRawNetworkInterfaceByIDs", reflect.TypeOf((*MockAzure)(nil).ListRawNetworkInterfaceByIDs), kt, opt)
+	return &AzureListRawNetworkInterfaceByIDsCall{Call: call}
+}
+
+// AzureListRawNetworkInterfaceByIDsCall wrap *gomock.Call
+type AzureListRawNetworkInterfaceByIDsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListRawNetworkInterfaceByIDsCall) Return(arg0 []*v2.Interface, arg1 error) *AzureListRawNetworkInterfaceByIDsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListRawNetworkInterfaceByIDsCall) Do(f func(*kit.Kit, *core.AzureListByIDOption) ([]*v2.Interface, error)) *AzureListRawNetworkInterfaceByIDsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListRawNetworkInterfaceByIDsCall) DoAndReturn(f func(*kit.Kit, *core.AzureListByIDOption) ([]*v2.Interface, error)) *AzureListRawNetworkInterfaceByIDsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListRegion mocks base method.
+func (m *MockAzure) ListRegion(kit *kit.Kit) ([]*region.AzureRegion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRegion", kit)
+	ret0, _ := ret[0].([]*region.AzureRegion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRegion indicates an expected call of ListRegion.
+func (mr *MockAzureMockRecorder) ListRegion(kit interface{}) *AzureListRegionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRegion", reflect.TypeOf((*MockAzure)(nil).ListRegion), kit)
+	return &AzureListRegionCall{Call: call}
+}
+
+// AzureListRegionCall wrap *gomock.Call
+type AzureListRegionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListRegionCall) Return(arg0 []*region.AzureRegion, arg1 error) *AzureListRegionCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListRegionCall) Do(f func(*kit.Kit) ([]*region.AzureRegion, error)) *AzureListRegionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListRegionCall) DoAndReturn(f func(*kit.Kit) ([]*region.AzureRegion, error)) *AzureListRegionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListResourceGroup mocks base method.
+func (m *MockAzure) ListResourceGroup(kt *kit.Kit) ([]*resource_group.AzureResourceGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListResourceGroup", kt)
+	ret0, _ := ret[0].([]*resource_group.AzureResourceGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListResourceGroup indicates an expected call of ListResourceGroup.
+func (mr *MockAzureMockRecorder) ListResourceGroup(kt interface{}) *AzureListResourceGroupCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourceGroup", reflect.TypeOf((*MockAzure)(nil).ListResourceGroup), kt)
+	return &AzureListResourceGroupCall{Call: call}
+}
+
+// AzureListResourceGroupCall wrap *gomock.Call
+type AzureListResourceGroupCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListResourceGroupCall) Return(arg0 []*resource_group.AzureResourceGroup, arg1 error) *AzureListResourceGroupCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListResourceGroupCall) Do(f func(*kit.Kit) ([]*resource_group.AzureResourceGroup, error)) *AzureListResourceGroupCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListResourceGroupCall) DoAndReturn(f func(*kit.Kit) ([]*resource_group.AzureResourceGroup, error)) *AzureListResourceGroupCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListRouteTable mocks base method.
+func (m *MockAzure) ListRouteTable(kt *kit.Kit, opt *core.AzureListOption) (*route_table.AzureRouteTableListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRouteTable", kt, opt)
+	ret0, _ := ret[0].(*route_table.AzureRouteTableListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRouteTable indicates an expected call of ListRouteTable.
+func (mr *MockAzureMockRecorder) ListRouteTable(kt, opt interface{}) *AzureListRouteTableCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRouteTable", reflect.TypeOf((*MockAzure)(nil).ListRouteTable), kt, opt)
+	return &AzureListRouteTableCall{Call: call}
+}
+
+// AzureListRouteTableCall wrap *gomock.Call
+type AzureListRouteTableCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListRouteTableCall) Return(arg0 *route_table.AzureRouteTableListResult, arg1 error) *AzureListRouteTableCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListRouteTableCall) Do(f func(*kit.Kit, *core.AzureListOption) (*route_table.AzureRouteTableListResult, error)) *AzureListRouteTableCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListRouteTableCall) DoAndReturn(f func(*kit.Kit, *core.AzureListOption) (*route_table.AzureRouteTableListResult, error)) *AzureListRouteTableCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListRouteTableByID mocks base method.
+func (m *MockAzure) ListRouteTableByID(kt *kit.Kit, opt *core.AzureListByIDOption) (*route_table.AzureRouteTableListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRouteTableByID", kt, opt)
+	ret0, _ := ret[0].(*route_table.AzureRouteTableListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRouteTableByID indicates an expected call of ListRouteTableByID.
+func (mr *MockAzureMockRecorder) ListRouteTableByID(kt, opt interface{}) *AzureListRouteTableByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRouteTableByID", reflect.TypeOf((*MockAzure)(nil).ListRouteTableByID), kt, opt)
+	return &AzureListRouteTableByIDCall{Call: call}
+}
+
+// AzureListRouteTableByIDCall wrap *gomock.Call
+type AzureListRouteTableByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListRouteTableByIDCall) Return(arg0 *route_table.AzureRouteTableListResult, arg1 error) *AzureListRouteTableByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListRouteTableByIDCall) Do(f func(*kit.Kit, *core.AzureListByIDOption) (*route_table.AzureRouteTableListResult, error)) *AzureListRouteTableByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListRouteTableByIDCall) DoAndReturn(f func(*kit.Kit, *core.AzureListByIDOption) (*route_table.AzureRouteTableListResult, error)) *AzureListRouteTableByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListRouteTableByPage mocks base method.
+func (m *MockAzure) ListRouteTableByPage(kt *kit.Kit, opt *core.AzureListOption) (*azure.Pager[v2.RouteTablesClientListResponse, route_table.AzureRouteTable], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRouteTableByPage", kt, opt)
+	ret0, _ := ret[0].(*azure.Pager[v2.RouteTablesClientListResponse, route_table.AzureRouteTable])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRouteTableByPage indicates an expected call of ListRouteTableByPage.
+func (mr *MockAzureMockRecorder) ListRouteTableByPage(kt, opt interface{}) *AzureListRouteTableByPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRouteTableByPage", reflect.TypeOf((*MockAzure)(nil).ListRouteTableByPage), kt, opt)
+	return &AzureListRouteTableByPageCall{Call: call}
+}
+
+// AzureListRouteTableByPageCall wrap *gomock.Call
+type AzureListRouteTableByPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListRouteTableByPageCall) Return(arg0 *azure.Pager[v2.RouteTablesClientListResponse, route_table.AzureRouteTable], arg1 error) *AzureListRouteTableByPageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListRouteTableByPageCall) Do(f func(*kit.Kit, *core.AzureListOption) (*azure.Pager[v2.RouteTablesClientListResponse, route_table.AzureRouteTable], error)) *AzureListRouteTableByPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListRouteTableByPageCall) DoAndReturn(f func(*kit.Kit, *core.AzureListOption) (*azure.Pager[v2.RouteTablesClientListResponse, route_table.AzureRouteTable], error)) *AzureListRouteTableByPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListRouteTablePage mocks base method.
+func (m *MockAzure) ListRouteTablePage(opt *core.AzureListByIDOption) (*runtime.Pager[v2.RouteTablesClientListResponse], string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRouteTablePage", opt)
+	ret0, _ := ret[0].(*runtime.Pager[v2.RouteTablesClientListResponse])
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListRouteTablePage indicates an expected call of ListRouteTablePage.
+func (mr *MockAzureMockRecorder) ListRouteTablePage(opt interface{}) *AzureListRouteTablePageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRouteTablePage", reflect.TypeOf((*MockAzure)(nil).ListRouteTablePage), opt)
+	return &AzureListRouteTablePageCall{Call: call}
+}
+
+// AzureListRouteTablePageCall wrap *gomock.Call
+type AzureListRouteTablePageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListRouteTablePageCall) Return(arg0 *runtime.Pager[v2.RouteTablesClientListResponse], arg1 string, arg2 error) *AzureListRouteTablePageCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListRouteTablePageCall) Do(f func(*core.AzureListByIDOption) (*runtime.Pager[v2.RouteTablesClientListResponse], string, error)) *AzureListRouteTablePageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListRouteTablePageCall) DoAndReturn(f func(*core.AzureListByIDOption) (*runtime.Pager[v2.RouteTablesClientListResponse], string, error)) *AzureListRouteTablePageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListSecurityGroup mocks base method.
+func (m *MockAzure) ListSecurityGroup(kt *kit.Kit, opt *security_group.AzureListOption) ([]*security_group.AzureSecurityGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecurityGroup", kt, opt)
+	ret0, _ := ret[0].([]*security_group.AzureSecurityGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecurityGroup indicates an expected call of ListSecurityGroup.
+func (mr *MockAzureMockRecorder) ListSecurityGroup(kt, opt interface{}) *AzureListSecurityGroupCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecurityGroup", reflect.TypeOf((*MockAzure)(nil).ListSecurityGroup), kt, opt)
+	return &AzureListSecurityGroupCall{Call: call}
+}
+
+// AzureListSecurityGroupCall wrap *gomock.Call
+type AzureListSecurityGroupCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListSecurityGroupCall) Return(arg0 []*security_group.AzureSecurityGroup, arg1 error) *AzureListSecurityGroupCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListSecurityGroupCall) Do(f func(*kit.Kit, *security_group.AzureListOption) ([]*security_group.AzureSecurityGroup, error)) *AzureListSecurityGroupCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListSecurityGroupCall) DoAndReturn(f func(*kit.Kit, *security_group.AzureListOption) ([]*security_group.AzureSecurityGroup, error)) *AzureListSecurityGroupCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListSecurityGroupByID mocks base method.
+func (m *MockAzure) ListSecurityGroupByID(kt *kit.Kit, opt *core.AzureListByIDOption) ([]*security_group.AzureSecurityGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecurityGroupByID", kt, opt)
+	ret0, _ := ret[0].([]*security_group.AzureSecurityGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecurityGroupByID indicates an expected call of ListSecurityGroupByID.
+func (mr *MockAzureMockRecorder) ListSecurityGroupByID(kt, opt interface{}) *AzureListSecurityGroupByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecurityGroupByID", reflect.TypeOf((*MockAzure)(nil).ListSecurityGroupByID), kt, opt)
+	return &AzureListSecurityGroupByIDCall{Call: call}
+}
+
+// AzureListSecurityGroupByIDCall wrap *gomock.Call
+type AzureListSecurityGroupByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListSecurityGroupByIDCall) Return(arg0 []*security_group.AzureSecurityGroup, arg1 error) *AzureListSecurityGroupByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListSecurityGroupByIDCall) Do(f func(*kit.Kit, *core.AzureListByIDOption) ([]*security_group.AzureSecurityGroup, error)) *AzureListSecurityGroupByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListSecurityGroupByIDCall) DoAndReturn(f func(*kit.Kit, *core.AzureListByIDOption) ([]*security_group.AzureSecurityGroup, error)) *AzureListSecurityGroupByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListSecurityGroupByPage mocks base method.
+func (m *MockAzure) ListSecurityGroupByPage(kt *kit.Kit, opt *security_group.AzureListOption) (*azure.Pager[v2.SecurityGroupsClientListResponse, security_group.AzureSecurityGroup], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecurityGroupByPage", kt, opt)
+	ret0, _ := ret[0].(*azure.Pager[v2.SecurityGroupsClientListResponse, security_group.AzureSecurityGroup])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecurityGroupByPage indicates an expected call of ListSecurityGroupByPage.
+func (mr *MockAzureMockRecorder) ListSecurityGroupByPage(kt, opt interface{}) *AzureListSecurityGroupByPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecurityGroupByPage", reflect.TypeOf((*MockAzure)(nil).ListSecurityGroupByPage), kt, opt)
+	return &AzureListSecurityGroupByPageCall{Call: call}
+}
+
+// AzureListSecurityGroupByPageCall wrap *gomock.Call
+type AzureListSecurityGroupByPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListSecurityGroupByPageCall) Return(arg0 *azure.Pager[v2.SecurityGroupsClientListResponse, security_group.AzureSecurityGroup], arg1 error) *AzureListSecurityGroupByPageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListSecurityGroupByPageCall) Do(f func(*kit.Kit, *security_group.AzureListOption) (*azure.Pager[v2.SecurityGroupsClientListResponse, security_group.AzureSecurityGroup], error)) *AzureListSecurityGroupByPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListSecurityGroupByPageCall) DoAndReturn(f func(*kit.Kit, *security_group.AzureListOption) (*azure.Pager[v2.SecurityGroupsClientListResponse, security_group.AzureSecurityGroup], error)) *AzureListSecurityGroupByPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListSecurityGroupRule mocks base method.
+func (m *MockAzure) ListSecurityGroupRule(kt *kit.Kit, opt *security_group_rule.AzureListOption) ([]*security_group_rule.AzureSGRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecurityGroupRule", kt, opt)
+	ret0, _ := ret[0].([]*security_group_rule.AzureSGRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecurityGroupRule indicates an expected call of ListSecurityGroupRule.
+func (mr *MockAzureMockRecorder) ListSecurityGroupRule(kt, opt interface{}) *AzureListSecurityGroupRuleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecurityGroupRule", reflect.TypeOf((*MockAzure)(nil).ListSecurityGroupRule), kt, opt)
+	return &AzureListSecurityGroupRuleCall{Call: call}
+}
+
+// AzureListSecurityGroupRuleCall wrap *gomock.Call
+type AzureListSecurityGroupRuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListSecurityGroupRuleCall) Return(arg0 []*security_group_rule.AzureSGRule, arg1 error) *AzureListSecurityGroupRuleCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListSecurityGroupRuleCall) Do(f func(*kit.Kit, *security_group_rule.AzureListOption) ([]*security_group_rule.AzureSGRule, error)) *AzureListSecurityGroupRuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListSecurityGroupRuleCall) DoAndReturn(f func(*kit.Kit, *security_group_rule.AzureListOption) ([]*security_group_rule.AzureSGRule, error)) *AzureListSecurityGroupRuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListSubnet mocks base method.
+func (m *MockAzure) ListSubnet(kt *kit.Kit, opt *subnet.AzureSubnetListOption) (*subnet.AzureSubnetListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubnet", kt, opt)
+	ret0, _ := ret[0].(*subnet.AzureSubnetListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubnet indicates an expected call of ListSubnet.
+func (mr *MockAzureMockRecorder) ListSubnet(kt, opt interface{}) *AzureListSubnetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubnet", reflect.TypeOf((*MockAzure)(nil).ListSubnet), kt, opt)
+	return &AzureListSubnetCall{Call: call}
+}
+
+// AzureListSubnetCall wrap *gomock.Call
+type AzureListSubnetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListSubnetCall) Return(arg0 *subnet.AzureSubnetListResult, arg1 error) *AzureListSubnetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListSubnetCall) Do(f func(*kit.Kit, *subnet.AzureSubnetListOption) (*subnet.AzureSubnetListResult, error)) *AzureListSubnetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListSubnetCall) DoAndReturn(f func(*kit.Kit, *subnet.AzureSubnetListOption) (*subnet.AzureSubnetListResult, error)) *AzureListSubnetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListSubnetByID mocks base method.
+func (m *MockAzure) ListSubnetByID(kt *kit.Kit, opt *subnet.AzureSubnetListByIDOption) (*subnet.AzureSubnetListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubnetByID", kt, opt)
+	ret0, _ := ret[0].(*subnet.AzureSubnetListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubnetByID indicates an expected call of ListSubnetByID.
+func (mr *MockAzureMockRecorder) ListSubnetByID(kt, opt interface{}) *AzureListSubnetByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubnetByID", reflect.TypeOf((*MockAzure)(nil).ListSubnetByID), kt, opt)
+	return &AzureListSubnetByIDCall{Call: call}
+}
+
+// AzureListSubnetByIDCall wrap *gomock.Call
+type AzureListSubnetByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListSubnetByIDCall) Return(arg0 *subnet.AzureSubnetListResult, arg1 error) *AzureListSubnetByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListSubnetByIDCall) Do(f func(*kit.Kit, *subnet.AzureSubnetListByIDOption) (*subnet.AzureSubnetListResult, error)) *AzureListSubnetByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListSubnetByIDCall) DoAndReturn(f func(*kit.Kit, *subnet.AzureSubnetListByIDOption) (*subnet.AzureSubnetListResult, error)) *AzureListSubnetByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListSubnetByPage mocks base method.
+func (m *MockAzure) ListSubnetByPage(kt *kit.Kit, opt *subnet.AzureSubnetListOption) (*azure.Pager[v2.SubnetsClientListResponse, subnet.AzureSubnet], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubnetByPage", kt, opt)
+	ret0, _ := ret[0].(*azure.Pager[v2.SubnetsClientListResponse, subnet.AzureSubnet])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubnetByPage indicates an expected call of ListSubnetByPage.
+func (mr *MockAzureMockRecorder) ListSubnetByPage(kt, opt interface{}) *AzureListSubnetByPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubnetByPage", reflect.TypeOf((*MockAzure)(nil).ListSubnetByPage), kt, opt)
+	return &AzureListSubnetByPageCall{Call: call}
+}
+
+// AzureListSubnetByPageCall wrap *gomock.Call
+type AzureListSubnetByPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListSubnetByPageCall) Return(arg0 *azure.Pager[v2.SubnetsClientListResponse, subnet.AzureSubnet], arg1 error) *AzureListSubnetByPageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListSubnetByPageCall) Do(f func(*kit.Kit, *subnet.AzureSubnetListOption) (*azure.Pager[v2.SubnetsClientListResponse, subnet.AzureSubnet], error)) *AzureListSubnetByPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListSubnetByPageCall) DoAndReturn(f func(*kit.Kit, *subnet.AzureSubnetListOption) (*azure.Pager[v2.SubnetsClientListResponse, subnet.AzureSubnet], error)) *AzureListSubnetByPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListVpc mocks base method.
+func (m *MockAzure) ListVpc(kt *kit.Kit, opt *core.AzureListOption) (*types.AzureVpcListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVpc", kt, opt)
+	ret0, _ := ret[0].(*types.AzureVpcListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVpc indicates an expected call of ListVpc.
+func (mr *MockAzureMockRecorder) ListVpc(kt, opt interface{}) *AzureListVpcCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVpc", reflect.TypeOf((*MockAzure)(nil).ListVpc), kt, opt)
+	return &AzureListVpcCall{Call: call}
+}
+
+// AzureListVpcCall wrap *gomock.Call
+type AzureListVpcCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListVpcCall) Return(arg0 *types.AzureVpcListResult, arg1 error) *AzureListVpcCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListVpcCall) Do(f func(*kit.Kit, *core.AzureListOption) (*types.AzureVpcListResult, error)) *AzureListVpcCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListVpcCall) DoAndReturn(f func(*kit.Kit, *core.AzureListOption) (*types.AzureVpcListResult, error)) *AzureListVpcCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListVpcByID mocks base method.
+func (m *MockAzure) ListVpcByID(kt *kit.Kit, opt *core.AzureListByIDOption) (*types.AzureVpcListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVpcByID", kt, opt)
+	ret0, _ := ret[0].(*types.AzureVpcListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVpcByID indicates an expected call of ListVpcByID.
+func (mr *MockAzureMockRecorder) ListVpcByID(kt, opt interface{}) *AzureListVpcByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVpcByID", reflect.TypeOf((*MockAzure)(nil).ListVpcByID), kt, opt)
+	return &AzureListVpcByIDCall{Call: call}
+}
+
+// AzureListVpcByIDCall wrap *gomock.Call
+type AzureListVpcByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListVpcByIDCall) Return(arg0 *types.AzureVpcListResult, arg1 error) *AzureListVpcByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListVpcByIDCall) Do(f func(*kit.Kit, *core.AzureListByIDOption) (*types.AzureVpcListResult, error)) *AzureListVpcByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListVpcByIDCall) DoAndReturn(f func(*kit.Kit, *core.AzureListByIDOption) (*types.AzureVpcListResult, error)) *AzureListVpcByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListVpcByPage mocks base method.
+func (m *MockAzure) ListVpcByPage(kt *kit.Kit, opt *core.AzureListOption) (*azure.Pager[v2.VirtualNetworksClientListResponse, types.AzureVpc], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVpcByPage", kt, opt)
+	ret0, _ := ret[0].(*azure.Pager[v2.VirtualNetworksClientListResponse, types.AzureVpc])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVpcByPage indicates an expected call of ListVpcByPage.
+func (mr *MockAzureMockRecorder) ListVpcByPage(kt, opt interface{}) *AzureListVpcByPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVpcByPage", reflect.TypeOf((*MockAzure)(nil).ListVpcByPage), kt, opt)
+	return &AzureListVpcByPageCall{Call: call}
+}
+
+// AzureListVpcByPageCall wrap *gomock.Call
+type AzureListVpcByPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListVpcByPageCall) Return(arg0 *azure.Pager[v2.VirtualNetworksClientListResponse, types.AzureVpc], arg1 error) *AzureListVpcByPageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListVpcByPageCall) Do(f func(*kit.Kit, *core.AzureListOption) (*azure.Pager[v2.VirtualNetworksClientListResponse, types.AzureVpc], error)) *AzureListVpcByPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListVpcByPageCall) DoAndReturn(f func(*kit.Kit, *core.AzureListOption) (*azure.Pager[v2.VirtualNetworksClientListResponse, types.AzureVpc], error)) *AzureListVpcByPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListVpcUsage mocks base method.
+func (m *MockAzure) ListVpcUsage(kt *kit.Kit, opt *types.AzureVpcListUsageOption) ([]types.VpcUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVpcUsage", kt, opt)
+	ret0, _ := ret[0].([]types.VpcUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVpcUsage indicates an expected call of ListVpcUsage.
+func (mr *MockAzureMockRecorder) ListVpcUsage(kt, opt interface{}) *AzureListVpcUsageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVpcUsage", reflect.TypeOf((*MockAzure)(nil).ListVpcUsage), kt, opt)
+	return &AzureListVpcUsageCall{Call: call}
+}
+
+// AzureListVpcUsageCall wrap *gomock.Call
+type AzureListVpcUsageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureListVpcUsageCall) Return(arg0 []types.VpcUsage, arg1 error) *AzureListVpcUsageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureListVpcUsageCall) Do(f func(*kit.Kit, *types.AzureVpcListUsageOption) ([]types.VpcUsage, error)) *AzureListVpcUsageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureListVpcUsageCall) DoAndReturn(f func(*kit.Kit, *types.AzureVpcListUsageOption) ([]types.VpcUsage, error)) *AzureListVpcUsageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RebootCvm mocks base method.
+func (m *MockAzure) RebootCvm(kt *kit.Kit, opt *cvm.AzureRebootOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebootCvm", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RebootCvm indicates an expected call of RebootCvm.
+func (mr *MockAzureMockRecorder) RebootCvm(kt, opt interface{}) *AzureRebootCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebootCvm", reflect.TypeOf((*MockAzure)(nil).RebootCvm), kt, opt)
+	return &AzureRebootCvmCall{Call: call}
+}
+
+// AzureRebootCvmCall wrap *gomock.Call
+type AzureRebootCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureRebootCvmCall) Return(arg0 error) *AzureRebootCvmCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureRebootCvmCall) Do(f func(*kit.Kit, *cvm.AzureRebootOption) error) *AzureRebootCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureRebootCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.AzureRebootOption) error) *AzureRebootCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SecurityGroupNetworkInterfaceAssociate mocks base method.
+func (m *MockAzure) SecurityGroupNetworkInterfaceAssociate(kt *kit.Kit, opt *security_group.AzureAssociateNetworkInterfaceOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecurityGroupNetworkInterfaceAssociate", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SecurityGroupNetworkInterfaceAssociate indicates an expected call of SecurityGroupNetworkInterfaceAssociate.
+func (mr *MockAzureMockRecorder) SecurityGroupNetworkInterfaceAssociate(kt, opt interface{}) *AzureSecurityGroupNetworkInterfaceAssociateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecurityGroupNetworkInterfaceAssociate", reflect.TypeOf((*MockAzure)(nil).SecurityGroupNetworkInterfaceAssociate), kt, opt)
+	return &AzureSecurityGroupNetworkInterfaceAssociateCall{Call: call}
+}
+
+// AzureSecurityGroupNetworkInterfaceAssociateCall wrap *gomock.Call
+type AzureSecurityGroupNetworkInterfaceAssociateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureSecurityGroupNetworkInterfaceAssociateCall) Return(arg0 error) *AzureSecurityGroupNetworkInterfaceAssociateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureSecurityGroupNetworkInterfaceAssociateCall) Do(f func(*kit.Kit, *security_group.AzureAssociateNetworkInterfaceOption) error) *AzureSecurityGroupNetworkInterfaceAssociateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureSecurityGroupNetworkInterfaceAssociateCall) DoAndReturn(f func(*kit.Kit, *security_group.AzureAssociateNetworkInterfaceOption) error) *AzureSecurityGroupNetworkInterfaceAssociateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SecurityGroupNetworkInterfaceDisassociate mocks base method.
+func (m *MockAzure) SecurityGroupNetworkInterfaceDisassociate(kt *kit.Kit, opt *security_group.AzureAssociateNetworkInterfaceOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecurityGroupNetworkInterfaceDisassociate", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SecurityGroupNetworkInterfaceDisassociate indicates an expected call of SecurityGroupNetworkInterfaceDisassociate.
+func (mr *MockAzureMockRecorder) SecurityGroupNetworkInterfaceDisassociate(kt, opt interface{}) *AzureSecurityGroupNetworkInterfaceDisassociateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecurityGroupNetworkInterfaceDisassociate", reflect.TypeOf((*MockAzure)(nil).SecurityGroupNetworkInterfaceDisassociate), kt, opt)
+	return &AzureSecurityGroupNetworkInterfaceDisassociateCall{Call: call}
+}
+
+// AzureSecurityGroupNetworkInterfaceDisassociateCall wrap *gomock.Call
+type AzureSecurityGroupNetworkInterfaceDisassociateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureSecurityGroupNetworkInterfaceDisassociateCall) Return(arg0 error) *AzureSecurityGroupNetworkInterfaceDisassociateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureSecurityGroupNetworkInterfaceDisassociateCall) Do(f func(*kit.Kit, *security_group.AzureAssociateNetworkInterfaceOption) error) *AzureSecurityGroupNetworkInterfaceDisassociateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureSecurityGroupNetworkInterfaceDisassociateCall) DoAndReturn(f func(*kit.Kit, *security_group.AzureAssociateNetworkInterfaceOption) error) *AzureSecurityGroupNetworkInterfaceDisassociateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SecurityGroupSubnetAssociate mocks base method.
+func (m *MockAzure) SecurityGroupSubnetAssociate(kt *kit.Kit, opt *security_group.AzureAssociateSubnetOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecurityGroupSubnetAssociate", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SecurityGroupSubnetAssociate indicates an expected call of SecurityGroupSubnetAssociate.
+func (mr *MockAzureMockRecorder) SecurityGroupSubnetAssociate(kt, opt interface{}) *AzureSecurityGroupSubnetAssociateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecurityGroupSubnetAssociate", reflect.TypeOf((*MockAzure)(nil).SecurityGroupSubnetAssociate), kt, opt)
+	return &AzureSecurityGroupSubnetAssociateCall{Call: call}
+}
+
+// AzureSecurityGroupSubnetAssociateCall wrap *gomock.Call
+type AzureSecurityGroupSubnetAssociateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureSecurityGroupSubnetAssociateCall) Return(arg0 error) *AzureSecurityGroupSubnetAssociateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureSecurityGroupSubnetAssociateCall) Do(f func(*kit.Kit, *security_group.AzureAssociateSubnetOption) error) *AzureSecurityGroupSubnetAssociateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureSecurityGroupSubnetAssociateCall) DoAndReturn(f func(*kit.Kit, *security_group.AzureAssociateSubnetOption) error) *AzureSecurityGroupSubnetAssociateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SecurityGroupSubnetDisassociate mocks base method.
+func (m *MockAzure) SecurityGroupSubnetDisassociate(kt *kit.Kit, opt *security_group.AzureAssociateSubnetOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecurityGroupSubnetDisassociate", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SecurityGroupSubnetDisassociate indicates an expected call of SecurityGroupSubnetDisassociate.
+func (mr *MockAzureMockRecorder) SecurityGroupSubnetDisassociate(kt, opt interface{}) *AzureSecurityGroupSubnetDisassociateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecurityGroupSubnetDisassociate", reflect.TypeOf((*MockAzure)(nil).SecurityGroupSubnetDisassociate), kt, opt)
+	return &AzureSecurityGroupSubnetDisassociateCall{Call: call}
+}
+
+// AzureSecurityGroupSubnetDisassociateCall wrap *gomock.Call
+type AzureSecurityGroupSubnetDisassociateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureSecurityGroupSubnetDisassociateCall) Return(arg0 error) *AzureSecurityGroupSubnetDisassociateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureSecurityGroupSubnetDisassociateCall) Do(f func(*kit.Kit, *security_group.AzureAssociateSubnetOption) error) *AzureSecurityGroupSubnetDisassociateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureSecurityGroupSubnetDisassociateCall) DoAndReturn(f func(*kit.Kit, *security_group.AzureAssociateSubnetOption) error) *AzureSecurityGroupSubnetDisassociateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// StartCvm mocks base method.
+func (m *MockAzure) StartCvm(kt *kit.Kit, opt *cvm.AzureStartOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartCvm", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartCvm indicates an expected call of StartCvm.
+func (mr *MockAzureMockRecorder) StartCvm(kt, opt interface{}) *AzureStartCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartCvm", reflect.TypeOf((*MockAzure)(nil).StartCvm), kt, opt)
+	return &AzureStartCvmCall{Call: call}
+}
+
+// AzureStartCvmCall wrap *gomock.Call
+type AzureStartCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureStartCvmCall) Return(arg0 error) *AzureStartCvmCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureStartCvmCall) Do(f func(*kit.Kit, *cvm.AzureStartOption) error) *AzureStartCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureStartCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.AzureStartOption) error) *AzureStartCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// StopCvm mocks base method.
+func (m *MockAzure) StopCvm(kt *kit.Kit, opt *cvm.AzureStopOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopCvm", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopCvm indicates an expected call of StopCvm.
+func (mr *MockAzureMockRecorder) StopCvm(kt, opt interface{}) *AzureStopCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopCvm", reflect.TypeOf((*MockAzure)(nil).StopCvm), kt, opt)
+	return &AzureStopCvmCall{Call: call}
+}
+
+// AzureStopCvmCall wrap *gomock.Call
+type AzureStopCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureStopCvmCall) Return(arg0 error) *AzureStopCvmCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureStopCvmCall) Do(f func(*kit.Kit, *cvm.AzureStopOption) error) *AzureStopCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureStopCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.AzureStopOption) error) *AzureStopCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateRouteTable mocks base method.
+func (m *MockAzure) UpdateRouteTable(arg0 *kit.Kit, arg1 *route_table.AzureRouteTableUpdateOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRouteTable", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRouteTable indicates an expected call of UpdateRouteTable.
+func (mr *MockAzureMockRecorder) UpdateRouteTable(arg0, arg1 interface{}) *AzureUpdateRouteTableCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRouteTable", reflect.TypeOf((*MockAzure)(nil).UpdateRouteTable), arg0, arg1)
+	return &AzureUpdateRouteTableCall{Call: call}
+}
+
+// AzureUpdateRouteTableCall wrap *gomock.Call
+type AzureUpdateRouteTableCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureUpdateRouteTableCall) Return(arg0 error) *AzureUpdateRouteTableCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureUpdateRouteTableCall) Do(f func(*kit.Kit, *route_table.AzureRouteTableUpdateOption) error) *AzureUpdateRouteTableCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureUpdateRouteTableCall) DoAndReturn(f func(*kit.Kit, *route_table.AzureRouteTableUpdateOption) error) *AzureUpdateRouteTableCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateSecurityGroupRule mocks base method.
+func (m *MockAzure) UpdateSecurityGroupRule(kt *kit.Kit, opt *security_group_rule.AzureUpdateOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecurityGroupRule", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSecurityGroupRule indicates an expected call of UpdateSecurityGroupRule.
+func (mr *MockAzureMockRecorder) UpdateSecurityGroupRule(kt, opt interface{}) *AzureUpdateSecurityGroupRuleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecurityGroupRule", reflect.TypeOf((*MockAzure)(nil).UpdateSecurityGroupRule), kt, opt)
+	return &AzureUpdateSecurityGroupRuleCall{Call: call}
+}
+
+// AzureUpdateSecurityGroupRuleCall wrap *gomock.Call
+type AzureUpdateSecurityGroupRuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureUpdateSecurityGroupRuleCall) Return(arg0 error) *AzureUpdateSecurityGroupRuleCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureUpdateSecurityGroupRuleCall) Do(f func(*kit.Kit, *security_group_rule.AzureUpdateOption) error) *AzureUpdateSecurityGroupRuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureUpdateSecurityGroupRuleCall) DoAndReturn(f func(*kit.Kit, *security_group_rule.AzureUpdateOption) error) *AzureUpdateSecurityGroupRuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateSubnet mocks base method.
+func (m *MockAzure) UpdateSubnet(arg0 *kit.Kit, arg1 *subnet.AzureSubnetUpdateOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubnet", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSubnet indicates an expected call of UpdateSubnet.
+func (mr *MockAzureMockRecorder) UpdateSubnet(arg0, arg1 interface{}) *AzureUpdateSubnetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubnet", reflect.TypeOf((*MockAzure)(nil).UpdateSubnet), arg0, arg1)
+	return &AzureUpdateSubnetCall{Call: call}
+}
+
+// AzureUpdateSubnetCall wrap *gomock.Call
+type AzureUpdateSubnetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureUpdateSubnetCall) Return(arg0 error) *AzureUpdateSubnetCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureUpdateSubnetCall) Do(f func(*kit.Kit, *subnet.AzureSubnetUpdateOption) error) *AzureUpdateSubnetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureUpdateSubnetCall) DoAndReturn(f func(*kit.Kit, *subnet.AzureSubnetUpdateOption) error) *AzureUpdateSubnetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateVpc mocks base method.
+func (m *MockAzure) UpdateVpc(kt *kit.Kit, opt *types.AzureVpcUpdateOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVpc", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVpc indicates an expected call of UpdateVpc.
+func (mr *MockAzureMockRecorder) UpdateVpc(kt, opt interface{}) *AzureUpdateVpcCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVpc", reflect.TypeOf((*MockAzure)(nil).UpdateVpc), kt, opt)
+	return &AzureUpdateVpcCall{Call: call}
+}
+
+// AzureUpdateVpcCall wrap *gomock.Call
+type AzureUpdateVpcCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *AzureUpdateVpcCall) Return(arg0 error) *AzureUpdateVpcCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *AzureUpdateVpcCall) Do(f func(*kit.Kit, *types.AzureVpcUpdateOption) error) *AzureUpdateVpcCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *AzureUpdateVpcCall) DoAndReturn(f func(*kit.Kit, *types.AzureVpcUpdateOption) error) *AzureUpdateVpcCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
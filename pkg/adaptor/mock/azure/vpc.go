@@ -0,0 +1,230 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package mockazure
+
+import (
+	"fmt"
+	"strings"
+
+	adaptormock "hcm/pkg/adaptor/mock"
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/adaptor/types/core"
+	adtysubnet "hcm/pkg/adaptor/types/subnet"
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+
+	"go.uber.org/mock/gomock"
+)
+
+// vpcIDFormat azure vpc id格式，与azure适配器保持一致统一转为小写
+const vpcIDFormat = "/subscriptions/mock-subscription/resourcegroups/%s/providers/microsoft.network/virtualnetworks/%s"
+
+// vpcPlaybook in memory crud vpc playbook, add ability to mock vpc, subnet related functions.
+type vpcPlaybook struct {
+	vpcStore    *adaptormock.Store[string, types.AzureVpc]
+	subnetStore *adaptormock.Store[string, adtysubnet.AzureSubnet]
+}
+
+// NewCrudVpcPlaybook  vpc, subnet all in one.
+func NewCrudVpcPlaybook() Playbook {
+	return &vpcPlaybook{
+		vpcStore:    adaptormock.NewCloudResStore[types.AzureVpc](),
+		subnetStore: adaptormock.NewCloudResStore[adtysubnet.AzureSubnet](),
+	}
+}
+
+// Name vpc playbook
+func (v *vpcPlaybook) Name() string {
+	return string(enumor.VpcCloudResType)
+}
+
+// Apply mock method
+func (v *vpcPlaybook) Apply(mockCloud *MockAzure, ctrl *gomock.Controller) {
+
+	v.applyVpc(mockCloud)
+
+	v.applySubnet(mockCloud)
+}
+
+func (v *vpcPlaybook) applyVpc(mockCloud *MockAzure) {
+	mockCloud.EXPECT().ListVpc(gomock.Any(), gomock.Any()).DoAndReturn(v.listVpc).MinTimes(1)
+	mockCloud.EXPECT().CreateVpc(gomock.Any(), gomock.Any()).DoAndReturn(v.createVpc).MinTimes(1)
+	// azure 目前只支持更新备注，而备注只保存在本地，因此更新不需要操作云上资源
+	mockCloud.EXPECT().UpdateVpc(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockCloud.EXPECT().DeleteVpc(gomock.Any(), gomock.Any()).DoAndReturn(v.deleteVpc).MinTimes(1)
+}
+
+func (v *vpcPlaybook) applySubnet(mockCloud *MockAzure) {
+	mockCloud.EXPECT().ListSubnet(gomock.Any(), gomock.Any()).DoAndReturn(v.listSubnet).MinTimes(1)
+	mockCloud.EXPECT().CreateSubnet(gomock.Any(), gomock.Any()).DoAndReturn(v.createSubnet).MinTimes(1)
+	mockCloud.EXPECT().UpdateSubnet(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockCloud.EXPECT().DeleteSubnet(gomock.Any(), gomock.Any()).DoAndReturn(v.deleteSubnet).MinTimes(1)
+}
+
+// listVpc 返回资源组下的全部vpc，vpc详情中携带其下的子网
+func (v *vpcPlaybook) listVpc(_ *kit.Kit, opt *core.AzureListOption) (*types.AzureVpcListResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	resGroup := strings.ToLower(opt.ResourceGroupName)
+	found := v.vpcStore.Filter(func(vpc types.AzureVpc) bool {
+		return vpc.Extension.ResourceGroupName == resGroup
+	})
+	for idx := range found {
+		ext := *found[idx].Extension
+		ext.Subnets = v.subnetStore.Filter(func(n adtysubnet.AzureSubnet) bool {
+			return n.CloudVpcID == found[idx].CloudID
+		})
+		found[idx].Extension = &ext
+	}
+	return &types.AzureVpcListResult{Details: found}, nil
+}
+
+// createVpc 与azure保持一致，创建vpc时同时创建请求中的子网
+func (v *vpcPlaybook) createVpc(kt *kit.Kit, opt *types.AzureVpcCreateOption) (*types.AzureVpc, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	resGroup := strings.ToLower(opt.Extension.ResourceGroup)
+	cloudID := fmt.Sprintf(vpcIDFormat, resGroup, strings.ToLower(opt.Name))
+	if _, exists := v.vpcStore.Get(cloudID); exists {
+		return nil, errf.Newf(errf.RecordDuplicated, "vpc %s already exists", opt.Name)
+	}
+
+	cidrs := make([]cloud.AzureCidr, 0, len(opt.Extension.IPv4Cidr)+len(opt.Extension.IPv6Cidr))
+	for _, one := range opt.Extension.IPv4Cidr {
+		cidrs = append(cidrs, cloud.AzureCidr{Type: enumor.Ipv4, Cidr: one})
+	}
+	for _, one := range opt.Extension.IPv6Cidr {
+		cidrs = append(cidrs, cloud.AzureCidr{Type: enumor.Ipv6, Cidr: one})
+	}
+	cloudVpc := types.AzureVpc{
+		CloudID: cloudID,
+		Name:    strings.ToLower(opt.Name),
+		Region:  strings.ToLower(opt.Extension.Region),
+		Memo:    opt.Memo,
+		Extension: &types.AzureVpcExtension{
+			ResourceGroupName: resGroup,
+			DNSServers:        make([]string, 0),
+			Cidr:              cidrs,
+		},
+	}
+	v.vpcStore.Add(cloudVpc.CloudID, cloudVpc)
+
+	for idx := range opt.Extension.Subnets {
+		subnetOpt := opt.Extension.Subnets[idx]
+		subnetOpt.CloudVpcID = cloudID
+		subnet, err := v.createSubnet(kt, &subnetOpt)
+		if err != nil {
+			return nil, err
+		}
+		cloudVpc.Extension.Subnets = append(cloudVpc.Extension.Subnets, *subnet)
+	}
+	return &cloudVpc, nil
+}
+
+// deleteVpc 与azure保持一致，删除vpc时一并删除其下的子网
+func (v *vpcPlaybook) deleteVpc(_ *kit.Kit, opt *core.AzureDeleteOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	cloudID := fmt.Sprintf(vpcIDFormat, strings.ToLower(opt.ResourceGroupName), strings.ToLower(opt.ResourceID))
+	if _, exists := v.vpcStore.Get(cloudID); !exists {
+		return errf.New(errf.RecordNotFound, "not found vpc: "+opt.ResourceID)
+	}
+
+	subnets := v.subnetStore.Filter(func(n adtysubnet.AzureSubnet) bool {
+		return n.CloudVpcID == cloudID
+	})
+	for _, one := range subnets {
+		if err := v.subnetStore.Remove(one.CloudID); err != nil {
+			return err
+		}
+	}
+	return v.vpcStore.Remove(cloudID)
+}
+
+func (v *vpcPlaybook) listSubnet(_ *kit.Kit, opt *adtysubnet.AzureSubnetListOption) (
+	*adtysubnet.AzureSubnetListResult, error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	cloudVpcID := strings.ToLower(opt.CloudVpcID)
+	found := v.subnetStore.Filter(func(n adtysubnet.AzureSubnet) bool {
+		return n.CloudVpcID == cloudVpcID
+	})
+	return &adtysubnet.AzureSubnetListResult{Details: found}, nil
+}
+
+func (v *vpcPlaybook) createSubnet(_ *kit.Kit, opt *adtysubnet.AzureSubnetCreateOption) (
+	*adtysubnet.AzureSubnet, error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	cloudVpcID := strings.ToLower(opt.CloudVpcID)
+	vpc, exists := v.vpcStore.Get(cloudVpcID)
+	if !exists {
+		return nil, errf.New(errf.RecordNotFound, "not found vpc: "+opt.CloudVpcID)
+	}
+
+	subnet := adtysubnet.AzureSubnet{
+		CloudVpcID: cloudVpcID,
+		CloudID:    cloudVpcID + "/subnets/" + strings.ToLower(opt.Name),
+		Name:       strings.ToLower(opt.Name),
+		Region:     vpc.Region,
+		Ipv4Cidr:   opt.Extension.IPv4Cidr,
+		Ipv6Cidr:   opt.Extension.IPv6Cidr,
+		Memo:       opt.Memo,
+		Extension: &adtysubnet.AzureSubnetExtension{
+			ResourceGroupName:    strings.ToLower(opt.Extension.ResourceGroup),
+			NatGateway:           opt.Extension.NatGateway,
+			NetworkSecurityGroup: opt.Extension.NetworkSecurityGroup,
+		},
+	}
+	if len(opt.Extension.CloudRouteTableID) != 0 {
+		subnet.Extension.CloudRouteTableID = &opt.Extension.CloudRouteTableID
+	}
+	if !v.subnetStore.Add(subnet.CloudID, subnet) {
+		return nil, errf.Newf(errf.RecordDuplicated, "subnet %s already exists", opt.Name)
+	}
+	return &subnet, nil
+}
+
+// deleteSubnet azure按vpc和子网名称删除子网
+func (v *vpcPlaybook) deleteSubnet(_ *kit.Kit, opt *adtysubnet.AzureSubnetDeleteOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	cloudID := strings.ToLower(opt.VpcID) + "/subnets/" + strings.ToLower(opt.ResourceID)
+	if _, exists := v.subnetStore.Get(cloudID); !exists {
+		return errf.New(errf.RecordNotFound, "not found subnet: "+opt.ResourceID)
+	}
+	return v.subnetStore.Remove(cloudID)
+}
@@ -0,0 +1,85 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package mockazure
+
+import (
+	"testing"
+
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/adaptor/types/core"
+	adtysubnet "hcm/pkg/adaptor/types/subnet"
+	"hcm/pkg/kit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVpcPlaybook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cloud := NewMockAzure(ctrl)
+	NewCrudVpcPlaybook().Apply(cloud, ctrl)
+	kt := kit.New()
+	resGroup := "Demo-Group"
+
+	// azure 创建vpc时必须同时创建至少一个子网
+	vpc, err := cloud.CreateVpc(kt, &types.AzureVpcCreateOption{AccountID: "00000001", Name: "Demo",
+		Extension: &types.AzureVpcCreateExt{Region: "eastasia", ResourceGroup: resGroup,
+			IPv4Cidr: []string{"10.0.0.0/16"}, Subnets: []adtysubnet.AzureSubnetCreateOption{{Name: "default",
+				Extension: &adtysubnet.AzureSubnetCreateExt{ResourceGroup: resGroup,
+					IPv4Cidr: []string{"10.0.0.0/24"}}}}}})
+	require.NoError(t, err)
+	require.Len(t, vpc.Extension.Subnets, 1)
+	assert.Equal(t, "demo", vpc.Name)
+
+	subnet, err := cloud.CreateSubnet(kt, &adtysubnet.AzureSubnetCreateOption{Name: "second",
+		CloudVpcID: vpc.CloudID, Extension: &adtysubnet.AzureSubnetCreateExt{ResourceGroup: resGroup,
+			IPv4Cidr: []string{"10.0.1.0/24"}}})
+	require.NoError(t, err)
+
+	subnets, err := cloud.ListSubnet(kt, &adtysubnet.AzureSubnetListOption{CloudVpcID: vpc.CloudID,
+		AzureListOption: core.AzureListOption{ResourceGroupName: resGroup}})
+	require.NoError(t, err)
+	require.Len(t, subnets.Details, 2)
+
+	vpcs, err := cloud.ListVpc(kt, &core.AzureListOption{ResourceGroupName: resGroup})
+	require.NoError(t, err)
+	require.Len(t, vpcs.Details, 1)
+	assert.Len(t, vpcs.Details[0].Extension.Subnets, 2)
+
+	err = cloud.DeleteSubnet(kt, &adtysubnet.AzureSubnetDeleteOption{VpcID: vpc.CloudID,
+		AzureDeleteOption: core.AzureDeleteOption{ResourceGroupName: resGroup,
+			BaseDeleteOption: core.BaseDeleteOption{ResourceID: subnet.Name}}})
+	require.NoError(t, err)
+
+	// 删除vpc时一并删除其下的子网
+	err = cloud.DeleteVpc(kt, &core.AzureDeleteOption{ResourceGroupName: resGroup,
+		BaseDeleteOption: core.BaseDeleteOption{ResourceID: "Demo"}})
+	require.NoError(t, err)
+
+	subnets, err = cloud.ListSubnet(kt, &adtysubnet.AzureSubnetListOption{CloudVpcID: vpc.CloudID,
+		AzureListOption: core.AzureListOption{ResourceGroupName: resGroup}})
+	require.NoError(t, err)
+	assert.Empty(t, subnets.Details)
+
+	vpcs, err = cloud.ListVpc(kt, &core.AzureListOption{ResourceGroupName: resGroup})
+	require.NoError(t, err)
+	assert.Empty(t, vpcs.Details)
+}
@@ -0,0 +1,2383 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+
+// Package mockgcp is a generated GoMock package.
+package mockgcp
+
+import (
+	poller "hcm/pkg/adaptor/poller"
+	types "hcm/pkg/adaptor/types"
+	account "hcm/pkg/adaptor/types/account"
+	bill "hcm/pkg/adaptor/types/bill"
+	core "hcm/pkg/adaptor/types/core"
+	cvm "hcm/pkg/adaptor/types/cvm"
+	disk "hcm/pkg/adaptor/types/disk"
+	eip "hcm/pkg/adaptor/types/eip"
+	firewall_rule "hcm/pkg/adaptor/types/firewall-rule"
+	image "hcm/pkg/adaptor/types/image"
+	instance_type "hcm/pkg/adaptor/types/instance-type"
+	network_interface "hcm/pkg/adaptor/types/network-interface"
+	region "hcm/pkg/adaptor/types/region"
+	route_table "hcm/pkg/adaptor/types/route-table"
+	subnet "hcm/pkg/adaptor/types/subnet"
+	zone "hcm/pkg/adaptor/types/zone"
+	bill0 "hcm/pkg/api/core/bill"
+	cloud "hcm/pkg/api/core/cloud"
+	kit "hcm/pkg/kit"
+	reflect "reflect"
+
+	bigquery "cloud.google.com/go/bigquery"
+	gomock "go.uber.org/mock/gomock"
+	v1 "google.golang.org/api/compute/v1"
+)
+
+// MockGcp is a mock of Gcp interface.
+type MockGcp struct {
+	ctrl     *gomock.Controller
+	recorder *MockGcpMockRecorder
+}
+
+// MockGcpMockRecorder is the mock recorder for MockGcp.
+type MockGcpMockRecorder struct {
+	mock *MockGcp
+}
+
+// NewMockGcp creates a new mock instance.
+func NewMockGcp(ctrl *gomock.Controller) *MockGcp {
+	mock := &MockGcp{ctrl: ctrl}
+	mock.recorder = &MockGcpMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGcp) EXPECT() *MockGcpMockRecorder {
+	return m.recorder
+}
+
+// AssociateEip mocks base method.
+func (m *MockGcp) AssociateEip(kt *kit.Kit, opt *eip.GcpEipAssociateOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssociateEip", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssociateEip indicates an expected call of AssociateEip.
+func (mr *MockGcpMockRecorder) AssociateEip(kt, opt interface{}) *GcpAssociateEipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssociateEip", reflect.TypeOf((*MockGcp)(nil).AssociateEip), kt, opt)
+	return &GcpAssociateEipCall{Call: call}
+}
+
+// GcpAssociateEipCall wrap *gomock.Call
+type GcpAssociateEipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpAssociateEipCall) Return(arg0 error) *GcpAssociateEipCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpAssociateEipCall) Do(f func(*kit.Kit, *eip.GcpEipAssociateOption) error) *GcpAssociateEipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpAssociateEipCall) DoAndReturn(f func(*kit.Kit, *eip.GcpEipAssociateOption) error) *GcpAssociateEipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// AttachDisk mocks base method.
+func (m *MockGcp) AttachDisk(kt *kit.Kit, opt *disk.GcpDiskAttachOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachDisk", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachDisk indicates an expected call of AttachDisk.
+func (mr *MockGcpMockRecorder) AttachDisk(kt, opt interface{}) *GcpAttachDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachDisk", reflect.TypeOf((*MockGcp)(nil).AttachDisk), kt, opt)
+	return &GcpAttachDiskCall{Call: call}
+}
+
+// GcpAttachDiskCall wrap *gomock.Call
+type GcpAttachDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpAttachDiskCall) Return(arg0 error) *GcpAttachDiskCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpAttachDiskCall) Do(f func(*kit.Kit, *disk.GcpDiskAttachOption) error) *GcpAttachDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpAttachDiskCall) DoAndReturn(f func(*kit.Kit, *disk.GcpDiskAttachOption) error) *GcpAttachDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// BindingProjectEditor mocks base method.
+func (m *MockGcp) BindingProjectEditor(kt *kit.Kit, projectId, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BindingProjectEditor", kt, projectId, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BindingProjectEditor indicates an expected call of BindingProjectEditor.
+func (mr *MockGcpMockRecorder) BindingProjectEditor(kt, projectId, email interface{}) *GcpBindingProjectEditorCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BindingProjectEditor", reflect.TypeOf((*MockGcp)(nil).BindingProjectEditor), kt, projectId, email)
+	return &GcpBindingProjectEditorCall{Call: call}
+}
+
+// GcpBindingProjectEditorCall wrap *gomock.Call
+type GcpBindingProjectEditorCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpBindingProjectEditorCall) Return(arg0 error) *GcpBindingProjectEditorCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpBindingProjectEditorCall) Do(f func(*kit.Kit, string, string) error) *GcpBindingProjectEditorCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpBindingProjectEditorCall) DoAndReturn(f func(*kit.Kit, string, string) error) *GcpBindingProjectEditorCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CloudProjectID mocks base method.
+func (m *MockGcp) CloudProjectID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudProjectID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// CloudProjectID indicates an expected call of CloudProjectID.
+func (mr *MockGcpMockRecorder) CloudProjectID() *GcpCloudProjectIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudProjectID", reflect.TypeOf((*MockGcp)(nil).CloudProjectID))
+	return &GcpCloudProjectIDCall{Call: call}
+}
+
+// GcpCloudProjectIDCall wrap *gomock.Call
+type GcpCloudProjectIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpCloudProjectIDCall) Return(arg0 string) *GcpCloudProjectIDCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpCloudProjectIDCall) Do(f func() string) *GcpCloudProjectIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpCloudProjectIDCall) DoAndReturn(f func() string) *GcpCloudProjectIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ConvertNetworkInterface mocks base method.
+func (m *MockGcp) ConvertNetworkInterface(data *v1.Instance, niItem *v1.NetworkInterface) *network_interface.GcpNI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConvertNetworkInterface", data, niItem)
+	ret0, _ := ret[0].(*network_interface.GcpNI)
+	return ret0
+}
+
+// ConvertNetworkInterface indicates an expected call of ConvertNetworkInterface.
+func (mr *MockGcpMockRecorder) ConvertNetworkInterface(data, niItem interface{}) *GcpConvertNetworkInterfaceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConvertNetworkInterface", reflect.TypeOf((*MockGcp)(nil).ConvertNetworkInterface), data, niItem)
+	return &GcpConvertNetworkInterfaceCall{Call: call}
+}
+
+// GcpConvertNetworkInterfaceCall wrap *gomock.Call
+type GcpConvertNetworkInterfaceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpConvertNetworkInterfaceCall) Return(arg0 *network_interface.GcpNI) *GcpConvertNetworkInterfaceCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpConvertNetworkInterfaceCall) Do(f func(*v1.Instance, *v1.NetworkInterface) *network_interface.GcpNI) *GcpConvertNetworkInterfaceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpConvertNetworkInterfaceCall) DoAndReturn(f func(*v1.Instance, *v1.NetworkInterface) *network_interface.GcpNI) *GcpConvertNetworkInterfaceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountAccount mocks base method.
+func (m *MockGcp) CountAccount(kt *kit.Kit) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAccount", kt)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAccount indicates an expected call of CountAccount.
+func (mr *MockGcpMockRecorder) CountAccount(kt interface{}) *GcpCountAccountCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAccount", reflect.TypeOf((*MockGcp)(nil).CountAccount), kt)
+	return &GcpCountAccountCall{Call: call}
+}
+
+// GcpCountAccountCall wrap *gomock.Call
+type GcpCountAccountCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpCountAccountCall) Return(arg0 int32, arg1 error) *GcpCountAccountCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpCountAccountCall) Do(f func(*kit.Kit) (int32, error)) *GcpCountAccountCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpCountAccountCall) DoAndReturn(f func(*kit.Kit) (int32, error)) *GcpCountAccountCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountCvmAndNI mocks base method.
+func (m *MockGcp) CountCvmAndNI(kt *kit.Kit) (int32, int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCvmAndNI", kt)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(int32)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CountCvmAndNI indicates an expected call of CountCvmAndNI.
+func (mr *MockGcpMockRecorder) CountCvmAndNI(kt interface{}) *GcpCountCvmAndNICall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCvmAndNI", reflect.TypeOf((*MockGcp)(nil).CountCvmAndNI), kt)
+	return &GcpCountCvmAndNICall{Call: call}
+}
+
+// GcpCountCvmAndNICall wrap *gomock.Call
+type GcpCountCvmAndNICall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpCountCvmAndNICall) Return(arg0, arg1 int32, arg2 error) *GcpCountCvmAndNICall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpCountCvmAndNICall) Do(f func(*kit.Kit) (int32, int32, error)) *GcpCountCvmAndNICall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpCountCvmAndNICall) DoAndReturn(f func(*kit.Kit) (int32, int32, error)) *GcpCountCvmAndNICall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountDisk mocks base method.
+func (m *MockGcp) CountDisk(kt *kit.Kit) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDisk", kt)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDisk indicates an expected call of CountDisk.
+func (mr *MockGcpMockRecorder) CountDisk(kt interface{}) *GcpCountDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDisk", reflect.TypeOf((*MockGcp)(nil).CountDisk), kt)
+	return &GcpCountDiskCall{Call: call}
+}
+
+// GcpCountDiskCall wrap *gomock.Call
+type GcpCountDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpCountDiskCall) Return(arg0 int32, arg1 error) *GcpCountDiskCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpCountDiskCall) Do(f func(*kit.Kit) (int32, error)) *GcpCountDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpCountDiskCall) DoAndReturn(f func(*kit.Kit) (int32, error)) *GcpCountDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountEip mocks base method.
+func (m *MockGcp) CountEip(kt *kit.Kit) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountEip", kt)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountEip indicates an expected call of CountEip.
+func (mr *MockGcpMockRecorder) CountEip(kt interface{}) *GcpCountEipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEip", reflect.TypeOf((*MockGcp)(nil).CountEip), kt)
+	return &GcpCountEipCall{Call: call}
+}
+
+// GcpCountEipCall wrap *gomock.Call
+type GcpCountEipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpCountEipCall) Return(arg0 int32, arg1 error) *GcpCountEipCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpCountEipCall) Do(f func(*kit.Kit) (int32, error)) *GcpCountEipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpCountEipCall) DoAndReturn(f func(*kit.Kit) (int32, error)) *GcpCountEipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountFirewall mocks base method.
+func (m *MockGcp) CountFirewall(kt *kit.Kit) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFirewall", kt)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFirewall indicates an expected call of CountFirewall.
+func (mr *MockGcpMockRecorder) CountFirewall(kt interface{}) *GcpCountFirewallCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFirewall", reflect.TypeOf((*MockGcp)(nil).CountFirewall), kt)
+	return &GcpCountFirewallCall{Call: call}
+}
+
+// GcpCountFirewallCall wrap *gomock.Call
+type GcpCountFirewallCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpCountFirewallCall) Return(arg0 int32, arg1 error) *GcpCountFirewallCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpCountFirewallCall) Do(f func(*kit.Kit) (int32, error)) *GcpCountFirewallCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpCountFirewallCall) DoAndReturn(f func(*kit.Kit) (int32, error)) *GcpCountFirewallCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountRoute mocks base method.
+func (m *MockGcp) CountRoute(kt *kit.Kit) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRoute", kt)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRoute indicates an expected call of CountRoute.
+func (mr *MockGcpMockRecorder) CountRoute(kt interface{}) *GcpCountRouteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRoute", reflect.TypeOf((*MockGcp)(nil).CountRoute), kt)
+	return &GcpCountRouteCall{Call: call}
+}
+
+// GcpCountRouteCall wrap *gomock.Call
+type GcpCountRouteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpCountRouteCall) Return(arg0 int32, arg1 error) *GcpCountRouteCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpCountRouteCall) Do(f func(*kit.Kit) (int32, error)) *GcpCountRouteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpCountRouteCall) DoAndReturn(f func(*kit.Kit) (int32, error)) *GcpCountRouteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountSubnet mocks base method.
+func (m *MockGcp) CountSubnet(kt *kit.Kit) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSubnet", kt)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSubnet indicates an expected call of CountSubnet.
+func (mr *MockGcpMockRecorder) CountSubnet(kt interface{}) *GcpCountSubnetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSubnet", reflect.TypeOf((*MockGcp)(nil).CountSubnet), kt)
+	return &GcpCountSubnetCall{Call: call}
+}
+
+// GcpCountSubnetCall wrap *gomock.Call
+type GcpCountSubnetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpCountSubnetCall) Return(arg0 int32, arg1 error) *GcpCountSubnetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpCountSubnetCall) Do(f func(*kit.Kit) (int32, error)) *GcpCountSubnetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpCountSubnetCall) DoAndReturn(f func(*kit.Kit) (int32, error)) *GcpCountSubnetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountVpc mocks base method.
+func (m *MockGcp) CountVpc(kt *kit.Kit) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountVpc", kt)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountVpc indicates an expected call of CountVpc.
+func (mr *MockGcpMockRecorder) CountVpc(kt interface{}) *GcpCountVpcCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountVpc", reflect.TypeOf((*MockGcp)(nil).CountVpc), kt)
+	return &GcpCountVpcCall{Call: call}
+}
+
+// GcpCountVpcCall wrap *gomock.Call
+type GcpCountVpcCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpCountVpcCall) Return(arg0 int32, arg1 error) *GcpCountVpcCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpCountVpcCall) Do(f func(*kit.Kit) (int32, error)) *GcpCountVpcCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpCountVpcCall) DoAndReturn(f func(*kit.Kit) (int32, error)) *GcpCountVpcCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateCvm mocks base method.
+func (m *MockGcp) CreateCvm(kt *kit.Kit, opt *cvm.GcpCreateOption) (*poller.BaseDoneResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCvm", kt, opt)
+	ret0, _ := ret[0].(*poller.BaseDoneResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCvm indicates an expected call of CreateCvm.
+func (mr *MockGcpMockRecorder) CreateCvm(kt, opt interface{}) *GcpCreateCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCvm", reflect.TypeOf((*MockGcp)(nil).CreateCvm), kt, opt)
+	return &GcpCreateCvmCall{Call: call}
+}
+
+// GcpCreateCvmCall wrap *gomock.Call
+type GcpCreateCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpCreateCvmCall) Return(arg0 *poller.BaseDoneResult, arg1 error) *GcpCreateCvmCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpCreateCvmCall) Do(f func(*kit.Kit, *cvm.GcpCreateOption) (*poller.BaseDoneResult, error)) *GcpCreateCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpCreateCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.GcpCreateOption) (*poller.BaseDoneResult, error)) *GcpCreateCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateDisk mocks base method.
+func (m *MockGcp) CreateDisk(kt *kit.Kit, opt *disk.GcpDiskCreateOption) (*poller.BaseDoneResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDisk", kt, opt)
+	ret0, _ := ret[0].(*poller.BaseDoneResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDisk indicates an expected call of CreateDisk.
+func (mr *MockGcpMockRecorder) CreateDisk(kt, opt interface{}) *GcpCreateDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDisk", reflect.TypeOf((*MockGcp)(nil).CreateDisk), kt, opt)
+	return &GcpCreateDiskCall{Call: call}
+}
+
+// GcpCreateDiskCall wrap *gomock.Call
+type GcpCreateDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpCreateDiskCall) Return(arg0 *poller.BaseDoneResult, arg1 error) *GcpCreateDiskCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpCreateDiskCall) Do(f func(*kit.Kit, *disk.GcpDiskCreateOption) (*poller.BaseDoneResult, error)) *GcpCreateDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpCreateDiskCall) DoAndReturn(f func(*kit.Kit, *disk.GcpDiskCreateOption) (*poller.BaseDoneResult, error)) *GcpCreateDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateEip mocks base method.
+func (m *MockGcp) CreateEip(kt *kit.Kit, opt *eip.GcpEipCreateOption) (*poller.BaseDoneResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEip", kt, opt)
+	ret0, _ := ret[0].(*poller.BaseDoneResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEip indicates an expected call of CreateEip.
+func (mr *MockGcpMockRecorder) CreateEip(kt, opt interface{}) *GcpCreateEipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEip", reflect.TypeOf((*MockGcp)(nil).CreateEip), kt, opt)
+	return &GcpCreateEipCall{Call: call}
+}
+
+// GcpCreateEipCall wrap *gomock.Call
+type GcpCreateEipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpCreateEipCall) Return(arg0 *poller.BaseDoneResult, arg1 error) *GcpCreateEipCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpCreateEipCall) Do(f func(*kit.Kit, *eip.GcpEipCreateOption) (*poller.BaseDoneResult, error)) *GcpCreateEipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpCreateEipCall) DoAndReturn(f func(*kit.Kit, *eip.GcpEipCreateOption) (*poller.BaseDoneResult, error)) *GcpCreateEipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateFirewallRule mocks base method.
+func (m *MockGcp) CreateFirewallRule(kt *kit.Kit, opt *firewall_rule.CreateOption) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFirewallRule", kt, opt)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFirewallRule indicates an expected call of CreateFirewallRule.
+func (mr *MockGcpMockRecorder) CreateFirewallRule(kt, opt interface{}) *GcpCreateFirewallRuleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFirewallRule", reflect.TypeOf((*MockGcp)(nil).CreateFirewallRule), kt, opt)
+	return &GcpCreateFirewallRuleCall{Call: call}
+}
+
+// GcpCreateFirewallRuleCall wrap *gomock.Call
+type GcpCreateFirewallRuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpCreateFirewallRuleCall) Return(arg0 uint64, arg1 error) *GcpCreateFirewallRuleCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpCreateFirewallRuleCall) Do(f func(*kit.Kit, *firewall_rule.CreateOption) (uint64, error)) *GcpCreateFirewallRuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpCreateFirewallRuleCall) DoAndReturn(f func(*kit.Kit, *firewall_rule.CreateOption) (uint64, error)) *GcpCreateFirewallRuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateProject mocks base method.
+func (m *MockGcp) CreateProject(kt *kit.Kit, name, organization string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProject", kt, name, organization)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProject indicates an expected call of CreateProject.
+func (mr *MockGcpMockRecorder) CreateProject(kt, name, organization interface{}) *GcpCreateProjectCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockGcp)(nil).CreateProject), kt, name, organization)
+	return &GcpCreateProjectCall{Call: call}
+}
+
+// GcpCreateProjectCall wrap *gomock.Call
+type GcpCreateProjectCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpCreateProjectCall) Return(projectId string, err error) *GcpCreateProjectCall {
+	c.Call = c.Call.Return(projectId, err)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpCreateProjectCall) Do(f func(*kit.Kit, string, string) (string, error)) *GcpCreateProjectCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpCreateProjectCall) DoAndReturn(f func(*kit.Kit, string, string) (string, error)) *GcpCreateProjectCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateSubnet mocks base method.
+func (m *MockGcp) CreateSubnet(kt *kit.Kit, opt *subnet.GcpSubnetCreateOption) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubnet", kt, opt)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubnet indicates an expected call of CreateSubnet.
+func (mr *MockGcpMockRecorder) CreateSubnet(kt, opt interface{}) *GcpCreateSubnetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubnet", reflect.TypeOf((*MockGcp)(nil).CreateSubnet), kt, opt)
+	return &GcpCreateSubnetCall{Call: call}
+}
+
+// GcpCreateSubnetCall wrap *gomock.Call
+type GcpCreateSubnetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpCreateSubnetCall) Return(arg0 uint64, arg1 error) *GcpCreateSubnetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpCreateSubnetCall) Do(f func(*kit.Kit, *subnet.GcpSubnetCreateOption) (uint64, error)) *GcpCreateSubnetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpCreateSubnetCall) DoAndReturn(f func(*kit.Kit, *subnet.GcpSubnetCreateOption) (uint64, error)) *GcpCreateSubnetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateVpc mocks base method.
+func (m *MockGcp) CreateVpc(kt *kit.Kit, opt *types.GcpVpcCreateOption) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVpc", kt, opt)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVpc indicates an expected call of CreateVpc.
+func (mr *MockGcpMockRecorder) CreateVpc(kt, opt interface{}) *GcpCreateVpcCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVpc", reflect.TypeOf((*MockGcp)(nil).CreateVpc), kt, opt)
+	return &GcpCreateVpcCall{Call: call}
+}
+
+// GcpCreateVpcCall wrap *gomock.Call
+type GcpCreateVpcCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpCreateVpcCall) Return(arg0 uint64, arg1 error) *GcpCreateVpcCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpCreateVpcCall) Do(f func(*kit.Kit, *types.GcpVpcCreateOption) (uint64, error)) *GcpCreateVpcCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpCreateVpcCall) DoAndReturn(f func(*kit.Kit, *types.GcpVpcCreateOption) (uint64, error)) *GcpCreateVpcCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteCvm mocks base method.
+func (m *MockGcp) DeleteCvm(kt *kit.Kit, opt *cvm.GcpDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCvm", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCvm indicates an expected call of DeleteCvm.
+func (mr *MockGcpMockRecorder) DeleteCvm(kt, opt interface{}) *GcpDeleteCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCvm", reflect.TypeOf((*MockGcp)(nil).DeleteCvm), kt, opt)
+	return &GcpDeleteCvmCall{Call: call}
+}
+
+// GcpDeleteCvmCall wrap *gomock.Call
+type GcpDeleteCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpDeleteCvmCall) Return(arg0 error) *GcpDeleteCvmCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpDeleteCvmCall) Do(f func(*kit.Kit, *cvm.GcpDeleteOption) error) *GcpDeleteCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpDeleteCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.GcpDeleteOption) error) *GcpDeleteCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteDisk mocks base method.
+func (m *MockGcp) DeleteDisk(kt *kit.Kit, opt *disk.GcpDiskDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDisk", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDisk indicates an expected call of DeleteDisk.
+func (mr *MockGcpMockRecorder) DeleteDisk(kt, opt interface{}) *GcpDeleteDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDisk", reflect.TypeOf((*MockGcp)(nil).DeleteDisk), kt, opt)
+	return &GcpDeleteDiskCall{Call: call}
+}
+
+// GcpDeleteDiskCall wrap *gomock.Call
+type GcpDeleteDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpDeleteDiskCall) Return(arg0 error) *GcpDeleteDiskCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpDeleteDiskCall) Do(f func(*kit.Kit, *disk.GcpDiskDeleteOption) error) *GcpDeleteDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpDeleteDiskCall) DoAndReturn(f func(*kit.Kit, *disk.GcpDiskDeleteOption) error) *GcpDeleteDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteEip mocks base method.
+func (m *MockGcp) DeleteEip(kt *kit.Kit, opt *eip.GcpEipDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEip", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEip indicates an expected call of DeleteEip.
+func (mr *MockGcpMockRecorder) DeleteEip(kt, opt interface{}) *GcpDeleteEipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEip", reflect.TypeOf((*MockGcp)(nil).DeleteEip), kt, opt)
+	return &GcpDeleteEipCall{Call: call}
+}
+
+// GcpDeleteEipCall wrap *gomock.Call
+type GcpDeleteEipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpDeleteEipCall) Return(arg0 error) *GcpDeleteEipCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpDeleteEipCall) Do(f func(*kit.Kit, *eip.GcpEipDeleteOption) error) *GcpDeleteEipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpDeleteEipCall) DoAndReturn(f func(*kit.Kit, *eip.GcpEipDeleteOption) error) *GcpDeleteEipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteFirewallRule mocks base method.
+func (m *MockGcp) DeleteFirewallRule(kt *kit.Kit, opt *firewall_rule.DeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFirewallRule", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFirewallRule indicates an expected call of DeleteFirewallRule.
+func (mr *MockGcpMockRecorder) DeleteFirewallRule(kt, opt interface{}) *GcpDeleteFirewallRuleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFirewallRule", reflect.TypeOf((*MockGcp)(nil).DeleteFirewallRule), kt, opt)
+	return &GcpDeleteFirewallRuleCall{Call: call}
+}
+
+// GcpDeleteFirewallRuleCall wrap *gomock.Call
+type GcpDeleteFirewallRuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpDeleteFirewallRuleCall) Return(arg0 error) *GcpDeleteFirewallRuleCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpDeleteFirewallRuleCall) Do(f func(*kit.Kit, *firewall_rule.DeleteOption) error) *GcpDeleteFirewallRuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpDeleteFirewallRuleCall) DoAndReturn(f func(*kit.Kit, *firewall_rule.DeleteOption) error) *GcpDeleteFirewallRuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteSubnet mocks base method.
+func (m *MockGcp) DeleteSubnet(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubnet", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubnet indicates an expected call of DeleteSubnet.
+func (mr *MockGcpMockRecorder) DeleteSubnet(kt, opt interface{}) *GcpDeleteSubnetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubnet", reflect.TypeOf((*MockGcp)(nil).DeleteSubnet), kt, opt)
+	return &GcpDeleteSubnetCall{Call: call}
+}
+
+// GcpDeleteSubnetCall wrap *gomock.Call
+type GcpDeleteSubnetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpDeleteSubnetCall) Return(arg0 error) *GcpDeleteSubnetCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpDeleteSubnetCall) Do(f func(*kit.Kit, *core.BaseRegionalDeleteOption) error) *GcpDeleteSubnetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpDeleteSubnetCall) DoAndReturn(f func(*kit.Kit, *core.BaseRegionalDeleteOption) error) *GcpDeleteSubnetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteVpc mocks base method.
+func (m *MockGcp) DeleteVpc(kt *kit.Kit, opt *core.BaseDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVpc", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVpc indicates an expected call of DeleteVpc.
+func (mr *MockGcpMockRecorder) DeleteVpc(kt, opt interface{}) *GcpDeleteVpcCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVpc", reflect.TypeOf((*MockGcp)(nil).DeleteVpc), kt, opt)
+	return &GcpDeleteVpcCall{Call: call}
+}
+
+// GcpDeleteVpcCall wrap *gomock.Call
+type GcpDeleteVpcCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpDeleteVpcCall) Return(arg0 error) *GcpDeleteVpcCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpDeleteVpcCall) Do(f func(*kit.Kit, *core.BaseDeleteOption) error) *GcpDeleteVpcCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpDeleteVpcCall) DoAndReturn(f func(*kit.Kit, *core.BaseDeleteOption) error) *GcpDeleteVpcCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DetachDisk mocks base method.
+func (m *MockGcp) DetachDisk(kt *kit.Kit, opt *disk.GcpDiskDetachOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachDisk", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachDisk indicates an expected call of DetachDisk.
+func (mr *MockGcpMockRecorder) DetachDisk(kt, opt interface{}) *GcpDetachDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachDisk", reflect.TypeOf((*MockGcp)(nil).DetachDisk), kt, opt)
+	return &GcpDetachDiskCall{Call: call}
+}
+
+// GcpDetachDiskCall wrap *gomock.Call
+type GcpDetachDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpDetachDiskCall) Return(arg0 error) *GcpDetachDiskCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpDetachDiskCall) Do(f func(*kit.Kit, *disk.GcpDiskDetachOption) error) *GcpDetachDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpDetachDiskCall) DoAndReturn(f func(*kit.Kit, *disk.GcpDiskDetachOption) error) *GcpDetachDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DisassociateEip mocks base method.
+func (m *MockGcp) DisassociateEip(kt *kit.Kit, opt *eip.GcpEipDisassociateOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisassociateEip", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisassociateEip indicates an expected call of DisassociateEip.
+func (mr *MockGcpMockRecorder) DisassociateEip(kt, opt interface{}) *GcpDisassociateEipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisassociateEip", reflect.TypeOf((*MockGcp)(nil).DisassociateEip), kt, opt)
+	return &GcpDisassociateEipCall{Call: call}
+}
+
+// GcpDisassociateEipCall wrap *gomock.Call
+type GcpDisassociateEipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpDisassociateEipCall) Return(arg0 error) *GcpDisassociateEipCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpDisassociateEipCall) Do(f func(*kit.Kit, *eip.GcpEipDisassociateOption) error) *GcpDisassociateEipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpDisassociateEipCall) DoAndReturn(f func(*kit.Kit, *eip.GcpEipDisassociateOption) error) *GcpDisassociateEipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAccountInfoBySecret mocks base method.
+func (m *MockGcp) GetAccountInfoBySecret(kit *kit.Kit, cloudSecretKeyString string) (*cloud.GcpInfoBySecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountInfoBySecret", kit, cloudSecretKeyString)
+	ret0, _ := ret[0].(*cloud.GcpInfoBySecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountInfoBySecret indicates an expected call of GetAccountInfoBySecret.
+func (mr *MockGcpMockRecorder) GetAccountInfoBySecret(kit, cloudSecretKeyString interface{}) *GcpGetAccountInfoBySecretCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountInfoBySecret", reflect.TypeOf((*MockGcp)(nil).GetAccountInfoBySecret), kit, cloudSecretKeyString)
+	return &GcpGetAccountInfoBySecretCall{Call: call}
+}
+
+// GcpGetAccountInfoBySecretCall wrap *gomock.Call
+type GcpGetAccountInfoBySecretCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpGetAccountInfoBySecretCall) Return(arg0 *cloud.GcpInfoBySecret, arg1 error) *GcpGetAccountInfoBySecretCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpGetAccountInfoBySecretCall) Do(f func(*kit.Kit, string) (*cloud.GcpInfoBySecret, error)) *GcpGetAccountInfoBySecretCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpGetAccountInfoBySecretCall) DoAndReturn(f func(*kit.Kit, string) (*cloud.GcpInfoBySecret, error)) *GcpGetAccountInfoBySecretCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetBigQuery mocks base method.
+func (m *MockGcp) GetBigQuery(kt *kit.Kit, query string) ([]map[string]bigquery.Value, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBigQuery", kt, query)
+	ret0, _ := ret[0].([]map[string]bigquery.Value)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBigQuery indicates an expected call of GetBigQuery.
+func (mr *MockGcpMockRecorder) GetBigQuery(kt, query interface{}) *GcpGetBigQueryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBigQuery", reflect.TypeOf((*MockGcp)(nil).GetBigQuery), kt, query)
+	return &GcpGetBigQueryCall{Call: call}
+}
+
+// GcpGetBigQueryCall wrap *gomock.Call
+type GcpGetBigQueryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpGetBigQueryCall) Return(arg0 []map[string]bigquery.Value, arg1 int64, arg2 error) *GcpGetBigQueryCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpGetBigQueryCall) Do(f func(*kit.Kit, string) ([]map[string]bigquery.Value, int64, error)) *GcpGetBigQueryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpGetBigQueryCall) DoAndReturn(f func(*kit.Kit, string) ([]map[string]bigquery.Value, int64, error)) *GcpGetBigQueryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetBillList mocks base method.
+func (m *MockGcp) GetBillList(kt *kit.Kit, opt *bill.GcpBillListOption, billInfo *cloud.AccountBillConfig[cloud.GcpBillConfigExtension]) (interface{}, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBillList", kt, opt, billInfo)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBillList indicates an expected call of GetBillList.
+func (mr *MockGcpMockRecorder) GetBillList(kt, opt, billInfo interface{}) *GcpGetBillListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBillList", reflect.TypeOf((*MockGcp)(nil).GetBillList), kt, opt, billInfo)
+	return &GcpGetBillListCall{Call: call}
+}
+
+// GcpGetBillListCall wrap *gomock.Call
+type GcpGetBillListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpGetBillListCall) Return(arg0 interface{}, arg1 int64, arg2 error) *GcpGetBillListCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpGetBillListCall) Do(f func(*kit.Kit, *bill.GcpBillListOption, *cloud.AccountBillConfig[cloud.GcpBillConfigExtension]) (interface{}, int64, error)) *GcpGetBillListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpGetBillListCall) DoAndReturn(f func(*kit.Kit, *bill.GcpBillListOption, *cloud.AccountBillConfig[cloud.GcpBillConfigExtension]) (interface{}, int64, error)) *GcpGetBillListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetBillTotal mocks base method.
+func (m *MockGcp) GetBillTotal(kt *kit.Kit, where string, billInfo *cloud.AccountBillConfig[cloud.GcpBillConfigExtension]) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBillTotal", kt, where, billInfo)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBillTotal indicates an expected call of GetBillTotal.
+func (mr *MockGcpMockRecorder) GetBillTotal(kt, where, billInfo interface{}) *GcpGetBillTotalCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBillTotal", reflect.TypeOf((*MockGcp)(nil).GetBillTotal), kt, where, billInfo)
+	return &GcpGetBillTotalCall{Call: call}
+}
+
+// GcpGetBillTotalCall wrap *gomock.Call
+type GcpGetBillTotalCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpGetBillTotalCall) Return(arg0 int64, arg1 error) *GcpGetBillTotalCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpGetBillTotalCall) Do(f func(*kit.Kit, string, *cloud.AccountBillConfig[cloud.GcpBillConfigExtension]) (int64, error)) *GcpGetBillTotalCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpGetBillTotalCall) DoAndReturn(f func(*kit.Kit, string, *cloud.AccountBillConfig[cloud.GcpBillConfigExtension]) (int64, error)) *GcpGetBillTotalCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetProjectRegionQuota mocks base method.
+func (m *MockGcp) GetProjectRegionQuota(kt *kit.Kit, opt *account.GcpProjectRegionQuotaOption) (*account.GcpProjectQuota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectRegionQuota", kt, opt)
+	ret0, _ := ret[0].(*account.GcpProjectQuota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectRegionQuota indicates an expected call of GetProjectRegionQuota.
+func (mr *MockGcpMockRecorder) GetProjectRegionQuota(kt, opt interface{}) *GcpGetProjectRegionQuotaCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectRegionQuota", reflect.TypeOf((*MockGcp)(nil).GetProjectRegionQuota), kt, opt)
+	return &GcpGetProjectRegionQuotaCall{Call: call}
+}
+
+// GcpGetProjectRegionQuotaCall wrap *gomock.Call
+type GcpGetProjectRegionQuotaCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpGetProjectRegionQuotaCall) Return(arg0 *account.GcpProjectQuota, arg1 error) *GcpGetProjectRegionQuotaCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpGetProjectRegionQuotaCall) Do(f func(*kit.Kit, *account.GcpProjectRegionQuotaOption) (*account.GcpProjectQuota, error)) *GcpGetProjectRegionQuotaCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpGetProjectRegionQuotaCall) DoAndReturn(f func(*kit.Kit, *account.GcpProjectRegionQuotaOption) (*account.GcpProjectQuota, error)) *GcpGetProjectRegionQuotaCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetRootAccountBillList mocks base method.
+func (m *MockGcp) GetRootAccountBillList(kt *kit.Kit, opt *bill.GcpRootAccountBillListOption, billInfo *bill0.RootAccountBillConfig[bill0.GcpBillConfigExtension]) (interface{}, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRootAccountBillList", kt, opt, billInfo)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRootAccountBillList indicates an expected call of GetRootAccountBillList.
+func (mr *MockGcpMockRecorder) GetRootAccountBillList(kt, opt, billInfo interface{}) *GcpGetRootAccountBillListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRootAccountBillList", reflect.TypeOf((*MockGcp)(nil).GetRootAccountBillList), kt, opt, billInfo)
+	return &GcpGetRootAccountBillListCall{Call: call}
+}
+
+// GcpGetRootAccountBillListCall wrap *gomock.Call
+type GcpGetRootAccountBillListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpGetRootAccountBillListCall) Return(arg0 interface{}, arg1 int64, arg2 error) *GcpGetRootAccountBillListCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpGetRootAccountBillListCall) Do(f func(*kit.Kit, *bill.GcpRootAccountBillListOption, *bill0.RootAccountBillConfig[bill0.GcpBillConfigExtension]) (interface{}, int64, error)) *GcpGetRootAccountBillListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpGetRootAccountBillListCall) DoAndReturn(f func(*kit.Kit, *bill.GcpRootAccountBillListOption, *bill0.RootAccountBillConfig[bill0.GcpBillConfigExtension]) (interface{}, int64, error)) *GcpGetRootAccountBillListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetRootAccountBillTotal mocks base method.
+func (m *MockGcp) GetRootAccountBillTotal(kt *kit.Kit, where string, billInfo *bill0.RootAccountBillConfig[bill0.GcpBillConfigExtension]) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRootAccountBillTotal", kt, where, billInfo)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRootAccountBillTotal indicates an expected call of GetRootAccountBillTotal.
+func (mr *MockGcpMockRecorder) GetRootAccountBillTotal(kt, where, billInfo interface{}) *GcpGetRootAccountBillTotalCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRootAccountBillTotal", reflect.TypeOf((*MockGcp)(nil).GetRootAccountBillTotal), kt, where, billInfo)
+	return &GcpGetRootAccountBillTotalCall{Call: call}
+}
+
+// GcpGetRootAccountBillTotalCall wrap *gomock.Call
+type GcpGetRootAccountBillTotalCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpGetRootAccountBillTotalCall) Return(arg0 int64, arg1 error) *GcpGetRootAccountBillTotalCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpGetRootAccountBillTotalCall) Do(f func(*kit.Kit, string, *bill0.RootAccountBillConfig[bill0.GcpBillConfigExtension]) (int64, error)) *GcpGetRootAccountBillTotalCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpGetRootAccountBillTotalCall) DoAndReturn(f func(*kit.Kit, string, *bill0.RootAccountBillConfig[bill0.GcpBillConfigExtension]) (int64, error)) *GcpGetRootAccountBillTotalCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListAccount mocks base method.
+func (m *MockGcp) ListAccount(kt *kit.Kit) ([]account.GcpAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccount", kt)
+	ret0, _ := ret[0].([]account.GcpAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccount indicates an expected call of ListAccount.
+func (mr *MockGcpMockRecorder) ListAccount(kt interface{}) *GcpListAccountCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccount", reflect.TypeOf((*MockGcp)(nil).ListAccount), kt)
+	return &GcpListAccountCall{Call: call}
+}
+
+// GcpListAccountCall wrap *gomock.Call
+type GcpListAccountCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpListAccountCall) Return(arg0 []account.GcpAccount, arg1 error) *GcpListAccountCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpListAccountCall) Do(f func(*kit.Kit) ([]account.GcpAccount, error)) *GcpListAccountCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpListAccountCall) DoAndReturn(f func(*kit.Kit) ([]account.GcpAccount, error)) *GcpListAccountCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListAggregatedEip mocks base method.
+func (m *MockGcp) ListAggregatedEip(kt *kit.Kit, opt *eip.GcpEipAggregatedListOption) ([]*v1.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAggregatedEip", kt, opt)
+	ret0, _ := ret[0].([]*v1.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAggregatedEip indicates an expected call of ListAggregatedEip.
+func (mr *MockGcpMockRecorder) ListAggregatedEip(kt, opt interface{}) *GcpListAggregatedEipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAggregatedEip", reflect.TypeOf((*MockGcp)(nil).ListAggregatedEip), kt, opt)
+	return &GcpListAggregatedEipCall{Call: call}
+}
+
+// GcpListAggregatedEipCall wrap *gomock.Call
+type GcpListAggregatedEipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpListAggregatedEipCall) Return(arg0 []*v1.Address, arg1 error) *GcpListAggregatedEipCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpListAggregatedEipCall) Do(f func(*kit.Kit, *eip.GcpEipAggregatedListOption) ([]*v1.Address, error)) *GcpListAggregatedEipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpListAggregatedEipCall) DoAndReturn(f func(*kit.Kit, *eip.GcpEipAggregatedListOption) ([]*v1.Address, error)) *GcpListAggregatedEipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListCvm mocks base method.
+func (m *MockGcp) ListCvm(kt *kit.Kit, opt *cvm.GcpListOption) ([]cvm.GcpCvm, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCvm", kt, opt)
+	ret0, _ := ret[0].([]cvm.GcpCvm)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListCvm indicates an expected call of ListCvm.
+func (mr *MockGcpMockRecorder) ListCvm(kt, opt interface{}) *GcpListCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCvm", reflect.TypeOf((*MockGcp)(nil).ListCvm), kt, opt)
+	return &GcpListCvmCall{Call: call}
+}
+
+// GcpListCvmCall wrap *gomock.Call
+type GcpListCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpListCvmCall) Return(arg0 []cvm.GcpCvm, arg1 string, arg2 error) *GcpListCvmCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpListCvmCall) Do(f func(*kit.Kit, *cvm.GcpListOption) ([]cvm.GcpCvm, string, error)) *GcpListCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpListCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.GcpListOption) ([]cvm.GcpCvm, string, error)) *GcpListCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListDisk mocks base method.
+func (m *MockGcp) ListDisk(kt *kit.Kit, opt *disk.GcpDiskListOption) ([]disk.GcpDisk, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDisk", kt, opt)
+	ret0, _ := ret[0].([]disk.GcpDisk)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDisk indicates an expected call of ListDisk.
+func (mr *MockGcpMockRecorder) ListDisk(kt, opt interface{}) *GcpListDiskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDisk", reflect.TypeOf((*MockGcp)(nil).ListDisk), kt, opt)
+	return &GcpListDiskCall{Call: call}
+}
+
+// GcpListDiskCall wrap *gomock.Call
+type GcpListDiskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpListDiskCall) Return(arg0 []disk.GcpDisk, arg1 string, arg2 error) *GcpListDiskCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpListDiskCall) Do(f func(*kit.Kit, *disk.GcpDiskListOption) ([]disk.GcpDisk, string, error)) *GcpListDiskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpListDiskCall) DoAndReturn(f func(*kit.Kit, *disk.GcpDiskListOption) ([]disk.GcpDisk, string, error)) *GcpListDiskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListEip mocks base method.
+func (m *MockGcp) ListEip(kt *kit.Kit, opt *eip.GcpEipListOption) (*eip.GcpEipListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEip", kt, opt)
+	ret0, _ := ret[0].(*eip.GcpEipListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEip indicates an expected call of ListEip.
+func (mr *MockGcpMockRecorder) ListEip(kt, opt interface{}) *GcpListEipCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEip", reflect.TypeOf((*MockGcp)(nil).ListEip), kt, opt)
+	return &GcpListEipCall{Call: call}
+}
+
+// GcpListEipCall wrap *gomock.Call
+type GcpListEipCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpListEipCall) Return(arg0 *eip.GcpEipListResult, arg1 error) *GcpListEipCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpListEipCall) Do(f func(*kit.Kit, *eip.GcpEipListOption) (*eip.GcpEipListResult, error)) *GcpListEipCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpListEipCall) DoAndReturn(f func(*kit.Kit, *eip.GcpEipListOption) (*eip.GcpEipListResult, error)) *GcpListEipCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListFirewallRule mocks base method.
+func (m *MockGcp) ListFirewallRule(kt *kit.Kit, opt *firewall_rule.ListOption) ([]firewall_rule.GcpFirewall, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFirewallRule", kt, opt)
+	ret0, _ := ret[0].([]firewall_rule.GcpFirewall)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListFirewallRule indicates an expected call of ListFirewallRule.
+func (mr *MockGcpMockRecorder) ListFirewallRule(kt, opt interface{}) *GcpListFirewallRuleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFirewallRule", reflect.TypeOf((*MockGcp)(nil).ListFirewallRule), kt, opt)
+	return &GcpListFirewallRuleCall{Call: call}
+}
+
+// GcpListFirewallRuleCall wrap *gomock.Call
+type GcpListFirewallRuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpListFirewallRuleCall) Return(arg0 []firewall_rule.GcpFirewall, arg1 string, arg2 error) *GcpListFirewallRuleCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpListFirewallRuleCall) Do(f func(*kit.Kit, *firewall_rule.ListOption) ([]firewall_rule.GcpFirewall, string, error)) *GcpListFirewallRuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpListFirewallRuleCall) DoAndReturn(f func(*kit.Kit, *firewall_rule.ListOption) ([]firewall_rule.GcpFirewall, string, error)) *GcpListFirewallRuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListImage mocks base method.
+func (m *MockGcp) ListImage(kt *kit.Kit, opt *image.GcpImageListOption) (*image.GcpImageListResult, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImage", kt, opt)
+	ret0, _ := ret[0].(*image.GcpImageListResult)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListImage indicates an expected call of ListImage.
+func (mr *MockGcpMockRecorder) ListImage(kt, opt interface{}) *GcpListImageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImage", reflect.TypeOf((*MockGcp)(nil).ListImage), kt, opt)
+	return &GcpListImageCall{Call: call}
+}
+
+// GcpListImageCall wrap *gomock.Call
+type GcpListImageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpListImageCall) Return(arg0 *image.GcpImageListResult, arg1 string, arg2 error) *GcpListImageCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpListImageCall) Do(f func(*kit.Kit, *image.GcpImageListOption) (*image.GcpImageListResult, string, error)) *GcpListImageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpListImageCall) DoAndReturn(f func(*kit.Kit, *image.GcpImageListOption) (*image.GcpImageListResult, string, error)) *GcpListImageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListInstanceType mocks base method.
+func (m *MockGcp) ListInstanceType(kt *kit.Kit, opt *instance_type.GcpInstanceTypeListOption) (*instance_type.GcpInstanceTypeListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInstanceType", kt, opt)
+	ret0, _ := ret[0].(*instance_type.GcpInstanceTypeListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInstanceType indicates an expected call of ListInstanceType.
+func (mr *MockGcpMockRecorder) ListInstanceType(kt, opt interface{}) *GcpListInstanceTypeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstanceType", reflect.TypeOf((*MockGcp)(nil).ListInstanceType), kt, opt)
+	return &GcpListInstanceTypeCall{Call: call}
+}
+
+// GcpListInstanceTypeCall wrap *gomock.Call
+type GcpListInstanceTypeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpListInstanceTypeCall) Return(arg0 *instance_type.GcpInstanceTypeListResult, arg1 error) *GcpListInstanceTypeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpListInstanceTypeCall) Do(f func(*kit.Kit, *instance_type.GcpInstanceTypeListOption) (*instance_type.GcpInstanceTypeListResult, error)) *GcpListInstanceTypeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpListInstanceTypeCall) DoAndReturn(f func(*kit.Kit, *instance_type.GcpInstanceTypeListOption) (*instance_type.GcpInstanceTypeListResult, error)) *GcpListInstanceTypeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListNetworkInterface mocks base method.
+func (m *MockGcp) ListNetworkInterface(kt *kit.Kit, opt *core.GcpListOption) (*network_interface.GcpInterfaceListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNetworkInterface", kt, opt)
+	ret0, _ := ret[0].(*network_interface.GcpInterfaceListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNetworkInterface indicates an expected call of ListNetworkInterface.
+func (mr *MockGcpMockRecorder) ListNetworkInterface(kt, opt interface{}) *GcpListNetworkInterfaceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNetworkInterface", reflect.TypeOf((*MockGcp)(nil).ListNetworkInterface), kt, opt)
+	return &GcpListNetworkInterfaceCall{Call: call}
+}
+
+// GcpListNetworkInterfaceCall wrap *gomock.Call
+type GcpListNetworkInterfaceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpListNetworkInterfaceCall) Return(arg0 *network_interface.GcpInterfaceListResult, arg1 error) *GcpListNetworkInterfaceCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpListNetworkInterfaceCall) Do(f func(*kit.Kit, *core.GcpListOption) (*network_interface.GcpInterfaceListResult, error)) *GcpListNetworkInterfaceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpListNetworkInterfaceCall) DoAndReturn(f func(*kit.Kit, *core.GcpListOption) (*network_interface.GcpInterfaceListResult, error)) *GcpListNetworkInterfaceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListNetworkInterfaceByCvmID mocks base method.
+func (m *MockGcp) ListNetworkInterfaceByCvmID(kt *kit.Kit, opt *network_interface.GcpListByCvmIDOption) (map[string][]network_interface.GcpNI, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNetworkInterfaceByCvmID", kt, opt)
+	ret0, _ := ret[0].(map[string][]network_interface.GcpNI)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNetworkInterfaceByCvmID indicates an expected call of ListNetworkInterfaceByCvmID.
+func (mr *MockGcpMockRecorder) ListNetworkInterfaceByCvmID(kt, opt interface{}) *GcpListNetworkInterfaceByCvmIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNetworkInterfaceByCvmID", reflect.TypeOf((*MockGcp)(nil).ListNetworkInterfaceByCvmID), kt, opt)
+	return &GcpListNetworkInterfaceByCvmIDCall{Call: call}
+}
+
+// GcpListNetworkInterfaceByCvmIDCall wrap *gomock.Call
+type GcpListNetworkInterfaceByCvmIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpListNetworkInterfaceByCvmIDCall) Return(arg0 map[string][]network_interface.GcpNI, arg1 error) *GcpListNetworkInterfaceByCvmIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpListNetworkInterfaceByCvmIDCall) Do(f func(*kit.Kit, *network_interface.GcpListByCvmIDOption) (map[string][]network_interface.GcpNI, error)) *GcpListNetworkInterfaceByCvmIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpListNetworkInterfaceByCvmIDCall) DoAndReturn(f func(*kit.Kit, *network_interface.GcpListByCvmIDOption) (map[string][]network_interface.GcpNI, error)) *GcpListNetworkInterfaceByCvmIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListNetworkInterfacePage mocks base method.
+func (m *MockGcp) ListNetworkInterfacePage(kt *kit.Kit, opt *core.GcpListOption) (*v1.InstancesListCall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNetworkInterfacePage", kt, opt)
+	ret0, _ := ret[0].(*v1.InstancesListCall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNetworkInterfacePage indicates an expected call of ListNetworkInterfacePage.
+func (mr *MockGcpMockRecorder) ListNetworkInterfacePage(kt, opt interface{}) *GcpListNetworkInterfacePageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNetworkInterfacePage", reflect.TypeOf((*MockGcp)(nil).ListNetworkInterfacePage), kt, opt)
+	return &GcpListNetworkInterfacePageCall{Call: call}
+}
+
+// GcpListNetworkInterfacePageCall wrap *gomock.Call
+type GcpListNetworkInterfacePageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpListNetworkInterfacePageCall) Return(arg0 *v1.InstancesListCall, arg1 error) *GcpListNetworkInterfacePageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpListNetworkInterfacePageCall) Do(f func(*kit.Kit, *core.GcpListOption) (*v1.InstancesListCall, error)) *GcpListNetworkInterfacePageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpListNetworkInterfacePageCall) DoAndReturn(f func(*kit.Kit, *core.GcpListOption) (*v1.InstancesListCall, error)) *GcpListNetworkInterfacePageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListRegion mocks base method.
+func (m *MockGcp) ListRegion(kt *kit.Kit, opt *core.GcpListOption) (*region.GcpRegionListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRegion", kt, opt)
+	ret0, _ := ret[0].(*region.GcpRegionListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRegion indicates an expected call of ListRegion.
+func (mr *MockGcpMockRecorder) ListRegion(kt, opt interface{}) *GcpListRegionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRegion", reflect.TypeOf((*MockGcp)(nil).ListRegion), kt, opt)
+	return &GcpListRegionCall{Call: call}
+}
+
+// GcpListRegionCall wrap *gomock.Call
+type GcpListRegionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpListRegionCall) Return(arg0 *region.GcpRegionListResult, arg1 error) *GcpListRegionCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpListRegionCall) Do(f func(*kit.Kit, *core.GcpListOption) (*region.GcpRegionListResult, error)) *GcpListRegionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpListRegionCall) DoAndReturn(f func(*kit.Kit, *core.GcpListOption) (*region.GcpRegionListResult, error)) *GcpListRegionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListRoute mocks base method.
+func (m *MockGcp) ListRoute(kt *kit.Kit, opt *route_table.GcpListOption) (*route_table.GcpRouteListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoute", kt, opt)
+	ret0, _ := ret[0].(*route_table.GcpRouteListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoute indicates an expected call of ListRoute.
+func (mr *MockGcpMockRecorder) ListRoute(kt, opt interface{}) *GcpListRouteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoute", reflect.TypeOf((*MockGcp)(nil).ListRoute), kt, opt)
+	return &GcpListRouteCall{Call: call}
+}
+
+// GcpListRouteCall wrap *gomock.Call
+type GcpListRouteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpListRouteCall) Return(arg0 *route_table.GcpRouteListResult, arg1 error) *GcpListRouteCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpListRouteCall) Do(f func(*kit.Kit, *route_table.GcpListOption) (*route_table.GcpRouteListResult, error)) *GcpListRouteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpListRouteCall) DoAndReturn(f func(*kit.Kit, *route_table.GcpListOption) (*route_table.GcpRouteListResult, error)) *GcpListRouteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListSubnet mocks base method.
+func (m *MockGcp) ListSubnet(kt *kit.Kit, opt *subnet.GcpSubnetListOption) (*subnet.GcpSubnetListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubnet", kt, opt)
+	ret0, _ := ret[0].(*subnet.GcpSubnetListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubnet indicates an expected call of ListSubnet.
+func (mr *MockGcpMockRecorder) ListSubnet(kt, opt interface{}) *GcpListSubnetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubnet", reflect.TypeOf((*MockGcp)(nil).ListSubnet), kt, opt)
+	return &GcpListSubnetCall{Call: call}
+}
+
+// GcpListSubnetCall wrap *gomock.Call
+type GcpListSubnetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpListSubnetCall) Return(arg0 *subnet.GcpSubnetListResult, arg1 error) *GcpListSubnetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpListSubnetCall) Do(f func(*kit.Kit, *subnet.GcpSubnetListOption) (*subnet.GcpSubnetListResult, error)) *GcpListSubnetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpListSubnetCall) DoAndReturn(f func(*kit.Kit, *subnet.GcpSubnetListOption) (*subnet.GcpSubnetListResult, error)) *GcpListSubnetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListSubnetWithIPNumber mocks base method.
+func (m *MockGcp) ListSubnetWithIPNumber(kt *kit.Kit, opt *subnet.GcpSubnetListOption) (*subnet.GcpSubnetListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubnetWithIPNumber", kt, opt)
+	ret0, _ := ret[0].(*subnet.GcpSubnetListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubnetWithIPNumber indicates an expected call of ListSubnetWithIPNumber.
+func (mr *MockGcpMockRecorder) ListSubnetWithIPNumber(kt, opt interface{}) *GcpListSubnetWithIPNumberCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubnetWithIPNumber", reflect.TypeOf((*MockGcp)(nil).ListSubnetWithIPNumber), kt, opt)
+	return &GcpListSubnetWithIPNumberCall{Call: call}
+}
+
+// GcpListSubnetWithIPNumberCall wrap *gomock.Call
+type GcpListSubnetWithIPNumberCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpListSubnetWithIPNumberCall) Return(arg0 *subnet.GcpSubnetListResult, arg1 error) *GcpListSubnetWithIPNumberCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpListSubnetWithIPNumberCall) Do(f func(*kit.Kit, *subnet.GcpSubnetListOption) (*subnet.GcpSubnetListResult, error)) *GcpListSubnetWithIPNumberCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpListSubnetWithIPNumberCall) DoAndReturn(f func(*kit.Kit, *subnet.GcpSubnetListOption) (*subnet.GcpSubnetListResult, error)) *GcpListSubnetWithIPNumberCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListVpc mocks base method.
+func (m *MockGcp) ListVpc(kt *kit.Kit, opt *types.GcpListOption) (*types.GcpVpcListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVpc", kt, opt)
+	ret0, _ := ret[0].(*types.GcpVpcListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVpc indicates an expected call of ListVpc.
+func (mr *MockGcpMockRecorder) ListVpc(kt, opt interface{}) *GcpListVpcCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVpc", reflect.TypeOf((*MockGcp)(nil).ListVpc), kt, opt)
+	return &GcpListVpcCall{Call: call}
+}
+
+// GcpListVpcCall wrap *gomock.Call
+type GcpListVpcCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpListVpcCall) Return(arg0 *types.GcpVpcListResult, arg1 error) *GcpListVpcCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpListVpcCall) Do(f func(*kit.Kit, *types.GcpListOption) (*types.GcpVpcListResult, error)) *GcpListVpcCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpListVpcCall) DoAndReturn(f func(*kit.Kit, *types.GcpListOption) (*types.GcpVpcListResult, error)) *GcpListVpcCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListZone mocks base method.
+func (m *MockGcp) ListZone(kit *kit.Kit, opt *zone.GcpZoneListOption) ([]zone.GcpZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListZone", kit, opt)
+	ret0, _ := ret[0].([]zone.GcpZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListZone indicates an expected call of ListZone.
+func (mr *MockGcpMockRecorder) ListZone(kit, opt interface{}) *GcpListZoneCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListZone", reflect.TypeOf((*MockGcp)(nil).ListZone), kit, opt)
+	return &GcpListZoneCall{Call: call}
+}
+
+// GcpListZoneCall wrap *gomock.Call
+type GcpListZoneCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpListZoneCall) Return(arg0 []zone.GcpZone, arg1 error) *GcpListZoneCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpListZoneCall) Do(f func(*kit.Kit, *zone.GcpZoneListOption) ([]zone.GcpZone, error)) *GcpListZoneCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpListZoneCall) DoAndReturn(f func(*kit.Kit, *zone.GcpZoneListOption) ([]zone.GcpZone, error)) *GcpListZoneCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// QueryRootCreditList mocks base method.
+func (m *MockGcp) QueryRootCreditList(kt *kit.Kit, opt *bill.GcpRootAccountBillListOption, billInfo *bill0.RootAccountBillConfig[bill0.GcpBillConfigExtension]) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryRootCreditList", kt, opt, billInfo)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryRootCreditList indicates an expected call of QueryRootCreditList.
+func (mr *MockGcpMockRecorder) QueryRootCreditList(kt, opt, billInfo interface{}) *GcpQueryRootCreditListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRootCreditList", reflect.TypeOf((*MockGcp)(nil).QueryRootCreditList), kt, opt, billInfo)
+	return &GcpQueryRootCreditListCall{Call: call}
+}
+
+// GcpQueryRootCreditListCall wrap *gomock.Call
+type GcpQueryRootCreditListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpQueryRootCreditListCall) Return(arg0 interface{}, arg1 error) *GcpQueryRootCreditListCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpQueryRootCreditListCall) Do(f func(*kit.Kit, *bill.GcpRootAccountBillListOption, *bill0.RootAccountBillConfig[bill0.GcpBillConfigExtension]) (interface{}, error)) *GcpQueryRootCreditListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpQueryRootCreditListCall) DoAndReturn(f func(*kit.Kit, *bill.GcpRootAccountBillListOption, *bill0.RootAccountBillConfig[bill0.GcpBillConfigExtension]) (interface{}, error)) *GcpQueryRootCreditListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ResetCvm mocks base method.
+func (m *MockGcp) ResetCvm(kt *kit.Kit, opt *cvm.GcpResetOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetCvm", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetCvm indicates an expected call of ResetCvm.
+func (mr *MockGcpMockRecorder) ResetCvm(kt, opt interface{}) *GcpResetCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetCvm", reflect.TypeOf((*MockGcp)(nil).ResetCvm), kt, opt)
+	return &GcpResetCvmCall{Call: call}
+}
+
+// GcpResetCvmCall wrap *gomock.Call
+type GcpResetCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpResetCvmCall) Return(arg0 error) *GcpResetCvmCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpResetCvmCall) Do(f func(*kit.Kit, *cvm.GcpResetOption) error) *GcpResetCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpResetCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.GcpResetOption) error) *GcpResetCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// StartCvm mocks base method.
+func (m *MockGcp) StartCvm(kt *kit.Kit, opt *cvm.GcpStartOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartCvm", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartCvm indicates an expected call of StartCvm.
+func (mr *MockGcpMockRecorder) StartCvm(kt, opt interface{}) *GcpStartCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartCvm", reflect.TypeOf((*MockGcp)(nil).StartCvm), kt, opt)
+	return &GcpStartCvmCall{Call: call}
+}
+
+// GcpStartCvmCall wrap *gomock.Call
+type GcpStartCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpStartCvmCall) Return(arg0 error) *GcpStartCvmCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpStartCvmCall) Do(f func(*kit.Kit, *cvm.GcpStartOption) error) *GcpStartCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpStartCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.GcpStartOption) error) *GcpStartCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// StopCvm mocks base method.
+func (m *MockGcp) StopCvm(kt *kit.Kit, opt *cvm.GcpStopOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopCvm", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopCvm indicates an expected call of StopCvm.
+func (mr *MockGcpMockRecorder) StopCvm(kt, opt interface{}) *GcpStopCvmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopCvm", reflect.TypeOf((*MockGcp)(nil).StopCvm), kt, opt)
+	return &GcpStopCvmCall{Call: call}
+}
+
+// GcpStopCvmCall wrap *gomock.Call
+type GcpStopCvmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpStopCvmCall) Return(arg0 error) *GcpStopCvmCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpStopCvmCall) Do(f func(*kit.Kit, *cvm.GcpStopOption) error) *GcpStopCvmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpStopCvmCall) DoAndReturn(f func(*kit.Kit, *cvm.GcpStopOption) error) *GcpStopCvmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateBillingInfo mocks base method.
+func (m *MockGcp) UpdateBillingInfo(kt *kit.Kit, projectId, billingAccountName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBillingInfo", kt, projectId, billingAccountName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBillingInfo indicates an expected call of UpdateBillingInfo.
+func (mr *MockGcpMockRecorder) UpdateBillingInfo(kt, projectId, billingAccountName interface{}) *GcpUpdateBillingInfoCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBillingInfo", reflect.TypeOf((*MockGcp)(nil).UpdateBillingInfo), kt, projectId, billingAccountName)
+	return &GcpUpdateBillingInfoCall{Call: call}
+}
+
+// GcpUpdateBillingInfoCall wrap *gomock.Call
+type GcpUpdateBillingInfoCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpUpdateBillingInfoCall) Return(arg0 error) *GcpUpdateBillingInfoCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpUpdateBillingInfoCall) Do(f func(*kit.Kit, string, string) error) *GcpUpdateBillingInfoCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpUpdateBillingInfoCall) DoAndReturn(f func(*kit.Kit, string, string) error) *GcpUpdateBillingInfoCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateFirewallRule mocks base method.
+func (m *MockGcp) UpdateFirewallRule(kt *kit.Kit, opt *firewall_rule.UpdateOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFirewallRule", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFirewallRule indicates an expected call of UpdateFirewallRule.
+func (mr *MockGcpMockRecorder) UpdateFirewallRule(kt, opt interface{}) *GcpUpdateFirewallRuleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFirewallRule", reflect.TypeOf((*MockGcp)(nil).UpdateFirewallRule), kt, opt)
+	return &GcpUpdateFirewallRuleCall{Call: call}
+}
+
+// GcpUpdateFirewallRuleCall wrap *gomock.Call
+type GcpUpdateFirewallRuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpUpdateFirewallRuleCall) Return(arg0 error) *GcpUpdateFirewallRuleCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpUpdateFirewallRuleCall) Do(f func(*kit.Kit, *firewall_rule.UpdateOption) error) *GcpUpdateFirewallRuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpUpdateFirewallRuleCall) DoAndReturn(f func(*kit.Kit, *firewall_rule.UpdateOption) error) *GcpUpdateFirewallRuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateSubnet mocks base method.
+func (m *MockGcp) UpdateSubnet(arg0 *kit.Kit, arg1 *subnet.GcpSubnetUpdateOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubnet", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSubnet indicates an expected call of UpdateSubnet.
+func (mr *MockGcpMockRecorder) UpdateSubnet(arg0, arg1 interface{}) *GcpUpdateSubnetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubnet", reflect.TypeOf((*MockGcp)(nil).UpdateSubnet), arg0, arg1)
+	return &GcpUpdateSubnetCall{Call: call}
+}
+
+// GcpUpdateSubnetCall wrap *gomock.Call
+type GcpUpdateSubnetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpUpdateSubnetCall) Return(arg0 error) *GcpUpdateSubnetCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpUpdateSubnetCall) Do(f func(*kit.Kit, *subnet.GcpSubnetUpdateOption) error) *GcpUpdateSubnetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpUpdateSubnetCall) DoAndReturn(f func(*kit.Kit, *subnet.GcpSubnetUpdateOption) error) *GcpUpdateSubnetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateVpc mocks base method.
+func (m *MockGcp) UpdateVpc(kt *kit.Kit, opt *types.GcpVpcUpdateOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVpc", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVpc indicates an expected call of UpdateVpc.
+func (mr *MockGcpMockRecorder) UpdateVpc(kt, opt interface{}) *GcpUpdateVpcCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVpc", reflect.TypeOf((*MockGcp)(nil).UpdateVpc), kt, opt)
+	return &GcpUpdateVpcCall{Call: call}
+}
+
+// GcpUpdateVpcCall wrap *gomock.Call
+type GcpUpdateVpcCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *GcpUpdateVpcCall) Return(arg0 error) *GcpUpdateVpcCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *GcpUpdateVpcCall) Do(f func(*kit.Kit, *types.GcpVpcUpdateOption) error) *GcpUpdateVpcCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *GcpUpdateVpcCall) DoAndReturn(f func(*kit.Kit, *types.GcpVpcUpdateOption) error) *GcpUpdateVpcCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
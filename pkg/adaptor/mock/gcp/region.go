@@ -0,0 +1,73 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package mockgcp
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/region"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	"go.uber.org/mock/gomock"
+)
+
+var regionList = []region.GcpRegion{
+	{RegionID: "asia-east1", RegionName: "asia-east1", RegionState: "UP",
+		SelfLink: "https://www.googleapis.com/compute/v1/projects/mock-project/regions/asia-east1"},
+	{RegionID: "us-central1", RegionName: "us-central1", RegionState: "UP",
+		SelfLink: "https://www.googleapis.com/compute/v1/projects/mock-project/regions/us-central1"},
+}
+
+type regionPlaybook struct {
+	regionList []region.GcpRegion
+}
+
+// Name region
+func (r *regionPlaybook) Name() string {
+	return string(enumor.RegionCloudResType)
+}
+
+// Apply to add list region method ability, return the regions matched by cloud ids
+func (r *regionPlaybook) Apply(mockCloud *MockGcp, controller *gomock.Controller) {
+	mockCloud.EXPECT().ListRegion(gomock.Any(), gomock.Any()).MinTimes(1).DoAndReturn(r.listRegion)
+}
+
+// listRegion 与gcp保持一致，指定了id时只返回匹配的地域，mock数据量小不做分页
+func (r *regionPlaybook) listRegion(_ *kit.Kit, opt *core.GcpListOption) (*region.GcpRegionListResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	details := make([]region.GcpRegion, 0, len(r.regionList))
+	for _, one := range r.regionList {
+		if len(opt.CloudIDs) != 0 && !slice.IsItemInSlice(opt.CloudIDs, one.RegionID) {
+			continue
+		}
+		details = append(details, one)
+	}
+	return &region.GcpRegionListResult{Count: converter.ValToPtr(uint64(len(details))), Details: details}, nil
+}
+
+// NewRegionPlaybook  return region playbook, lists regions from a fixed region list
+func NewRegionPlaybook() Playbook {
+	return &regionPlaybook{regionList: regionList}
+}
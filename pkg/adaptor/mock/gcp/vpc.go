@@ -0,0 +1,226 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package mockgcp
+
+import (
+	"strconv"
+	"sync/atomic"
+
+	adaptormock "hcm/pkg/adaptor/mock"
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/adaptor/types/core"
+	typessubnet "hcm/pkg/adaptor/types/subnet"
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/slice"
+
+	"go.uber.org/mock/gomock"
+)
+
+// selfLinkPrefix gcp 资源self link前缀，mock使用固定的项目
+const selfLinkPrefix = "https://www.googleapis.com/compute/v1/projects/mock-project/"
+
+// vpcPlaybook in memory crud vpc playbook, add ability to mock vpc, subnet related functions.
+type vpcPlaybook struct {
+	vpcStore    *adaptormock.Store[string, types.GcpVpc]
+	subnetStore *adaptormock.Store[string, typessubnet.GcpSubnet]
+	// lastID gcp 资源id为数字，按序分配
+	lastID atomic.Uint64
+}
+
+// NewCrudVpcPlaybook  vpc, subnet all in one.
+func NewCrudVpcPlaybook() Playbook {
+	v := &vpcPlaybook{
+		vpcStore:    adaptormock.NewCloudResStore[types.GcpVpc](),
+		subnetStore: adaptormock.NewCloudResStore[typessubnet.GcpSubnet](),
+	}
+	v.lastID.Store(1000000000000000000)
+	return v
+}
+
+// Name vpc playbook
+func (v *vpcPlaybook) Name() string {
+	return string(enumor.VpcCloudResType)
+}
+
+// Apply mock method
+func (v *vpcPlaybook) Apply(mockCloud *MockGcp, ctrl *gomock.Controller) {
+
+	v.applyVpc(mockCloud)
+
+	v.applySubnet(mockCloud)
+}
+
+func (v *vpcPlaybook) applyVpc(mockCloud *MockGcp) {
+	mockCloud.EXPECT().ListVpc(gomock.Any(), gomock.Any()).DoAndReturn(v.listVpc).MinTimes(1)
+	mockCloud.EXPECT().CreateVpc(gomock.Any(), gomock.Any()).DoAndReturn(v.createVpc).MinTimes(1)
+	// gcp 的描述不支持修改，与gcp适配器保持一致直接返回成功
+	mockCloud.EXPECT().UpdateVpc(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockCloud.EXPECT().DeleteVpc(gomock.Any(), gomock.Any()).DoAndReturn(v.deleteVpc).MinTimes(1)
+}
+
+func (v *vpcPlaybook) applySubnet(mockCloud *MockGcp) {
+	mockCloud.EXPECT().ListSubnet(gomock.Any(), gomock.Any()).DoAndReturn(v.listSubnet).MinTimes(1)
+	mockCloud.EXPECT().CreateSubnet(gomock.Any(), gomock.Any()).DoAndReturn(v.createSubnet).MinTimes(1)
+	mockCloud.EXPECT().UpdateSubnet(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockCloud.EXPECT().DeleteSubnet(gomock.Any(), gomock.Any()).DoAndReturn(v.deleteSubnet).MinTimes(1)
+}
+
+func (v *vpcPlaybook) nextID() uint64 {
+	return v.lastID.Add(1)
+}
+
+// listVpc 指定了id或self link时只返回匹配的数据，mock数据量小不做分页
+func (v *vpcPlaybook) listVpc(_ *kit.Kit, opt *types.GcpListOption) (*types.GcpVpcListResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	found := v.vpcStore.Filter(func(vpc types.GcpVpc) bool {
+		if len(opt.CloudIDs) != 0 && !slice.IsItemInSlice(opt.CloudIDs, vpc.CloudID) {
+			return false
+		}
+		return len(opt.SelfLinks) == 0 || slice.IsItemInSlice(opt.SelfLinks, vpc.Extension.SelfLink)
+	})
+	return &types.GcpVpcListResult{Details: found}, nil
+}
+
+// createVpc gcp创建vpc接口只返回vpc id
+func (v *vpcPlaybook) createVpc(_ *kit.Kit, opt *types.GcpVpcCreateOption) (uint64, error) {
+	if err := opt.Validate(); err != nil {
+		return 0, err
+	}
+
+	if v.findVpcByName(opt.Name) != nil {
+		return 0, errf.Newf(errf.RecordDuplicated, "vpc %s already exists", opt.Name)
+	}
+
+	id := v.nextID()
+	cloudVpc := types.GcpVpc{
+		CloudID: strconv.FormatUint(id, 10),
+		Name:    opt.Name,
+		Memo:    opt.Memo,
+		Extension: &cloud.GcpVpcExtension{
+			SelfLink:              selfLinkPrefix + "global/networks/" + opt.Name,
+			AutoCreateSubnetworks: opt.Extension.AutoCreateSubnetworks,
+			EnableUlaInternalIpv6: opt.Extension.EnableUlaInternalIpv6,
+			InternalIpv6Range:     opt.Extension.InternalIpv6Range,
+			Mtu:                   1460,
+			RoutingMode:           opt.Extension.RoutingMode,
+		},
+	}
+	v.vpcStore.Add(cloudVpc.CloudID, cloudVpc)
+	return id, nil
+}
+
+func (v *vpcPlaybook) findVpcByName(name string) *types.GcpVpc {
+	return v.vpcStore.Find(func(vpc types.GcpVpc) bool {
+		return vpc.Name == name
+	})
+}
+
+// deleteVpc gcp按名称删除vpc，与gcp保持一致，vpc下存在子网时不允许删除
+func (v *vpcPlaybook) deleteVpc(_ *kit.Kit, opt *core.BaseDeleteOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	vpc := v.findVpcByName(opt.ResourceID)
+	if vpc == nil {
+		return errf.New(errf.RecordNotFound, "not found vpc: "+opt.ResourceID)
+	}
+
+	subnet := v.subnetStore.Find(func(n typessubnet.GcpSubnet) bool {
+		return n.CloudVpcID == vpc.Extension.SelfLink
+	})
+	if subnet != nil {
+		return errf.Newf(errf.InvalidParameter, "vpc %s still has subnet %s", opt.ResourceID, subnet.Name)
+	}
+	return v.vpcStore.Remove(vpc.CloudID)
+}
+
+// listSubnet 按地域返回全部数据，指定了id或self link时只返回匹配的数据
+func (v *vpcPlaybook) listSubnet(_ *kit.Kit, opt *typessubnet.GcpSubnetListOption) (
+	*typessubnet.GcpSubnetListResult, error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	found := v.subnetStore.Filter(func(n typessubnet.GcpSubnet) bool {
+		if n.Region != opt.Region {
+			return false
+		}
+		if len(opt.CloudIDs) != 0 && !slice.IsItemInSlice(opt.CloudIDs, n.CloudID) {
+			return false
+		}
+		return len(opt.SelfLinks) == 0 || slice.IsItemInSlice(opt.SelfLinks, n.Extension.SelfLink)
+	})
+	return &typessubnet.GcpSubnetListResult{Details: found}, nil
+}
+
+// createSubnet gcp子网的CloudVpcID为vpc的self link
+func (v *vpcPlaybook) createSubnet(_ *kit.Kit, opt *typessubnet.GcpSubnetCreateOption) (uint64, error) {
+	if err := opt.Validate(); err != nil {
+		return 0, err
+	}
+
+	vpc := v.vpcStore.Find(func(vpc types.GcpVpc) bool {
+		return vpc.Extension.SelfLink == opt.CloudVpcID
+	})
+	if vpc == nil {
+		return 0, errf.New(errf.RecordNotFound, "not found vpc: "+opt.CloudVpcID)
+	}
+
+	id := v.nextID()
+	subnet := typessubnet.GcpSubnet{
+		CloudVpcID: opt.CloudVpcID,
+		CloudID:    strconv.FormatUint(id, 10),
+		Name:       opt.Name,
+		Region:     opt.Extension.Region,
+		Ipv4Cidr:   []string{opt.Extension.IPv4Cidr},
+		Memo:       opt.Memo,
+		Extension: &typessubnet.GcpSubnetExtension{
+			SelfLink:              selfLinkPrefix + "regions/" + opt.Extension.Region + "/subnetworks/" + opt.Name,
+			StackType:             "IPV4_ONLY",
+			PrivateIpGoogleAccess: opt.Extension.PrivateIpGoogleAccess,
+			EnableFlowLogs:        opt.Extension.EnableFlowLogs,
+		},
+	}
+	v.subnetStore.Add(subnet.CloudID, subnet)
+	return id, nil
+}
+
+// deleteSubnet gcp按地域和名称删除子网
+func (v *vpcPlaybook) deleteSubnet(_ *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	subnet := v.subnetStore.Find(func(n typessubnet.GcpSubnet) bool {
+		return n.Region == opt.Region && n.Name == opt.ResourceID
+	})
+	if subnet == nil {
+		return errf.New(errf.RecordNotFound, "not found subnet: "+opt.ResourceID)
+	}
+	return v.subnetStore.Remove(subnet.CloudID)
+}
@@ -0,0 +1,82 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package mockgcp
+
+import (
+	"strconv"
+	"testing"
+
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/adaptor/types/core"
+	typessubnet "hcm/pkg/adaptor/types/subnet"
+	"hcm/pkg/kit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVpcPlaybook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cloud := NewMockGcp(ctrl)
+	NewCrudVpcPlaybook().Apply(cloud, ctrl)
+	kt := kit.New()
+	region := "us-central1"
+	page := &core.GcpPage{PageSize: core.GcpQueryLimit}
+
+	vpcID, err := cloud.CreateVpc(kt, &types.GcpVpcCreateOption{AccountID: "00000001", Name: "demo",
+		Extension: &types.GcpVpcCreateExt{RoutingMode: "REGIONAL"}})
+	require.NoError(t, err)
+
+	// 同名vpc不允许重复创建
+	_, err = cloud.CreateVpc(kt, &types.GcpVpcCreateOption{AccountID: "00000001", Name: "demo",
+		Extension: &types.GcpVpcCreateExt{}})
+	require.Error(t, err)
+
+	vpcs, err := cloud.ListVpc(kt, &types.GcpListOption{CloudIDs: []string{strconv.FormatUint(vpcID, 10)},
+		Page: page})
+	require.NoError(t, err)
+	require.Len(t, vpcs.Details, 1)
+	selfLink := vpcs.Details[0].Extension.SelfLink
+
+	subnetID, err := cloud.CreateSubnet(kt, &typessubnet.GcpSubnetCreateOption{Name: "demo-subnet",
+		CloudVpcID: selfLink, Extension: &typessubnet.GcpSubnetCreateExt{Region: region, IPv4Cidr: "10.0.1.0/24"}})
+	require.NoError(t, err)
+
+	subnets, err := cloud.ListSubnet(kt, &typessubnet.GcpSubnetListOption{Region: region,
+		GcpListOption: core.GcpListOption{Page: page}})
+	require.NoError(t, err)
+	require.Len(t, subnets.Details, 1)
+	assert.Equal(t, strconv.FormatUint(subnetID, 10), subnets.Details[0].CloudID)
+	assert.Equal(t, selfLink, subnets.Details[0].CloudVpcID)
+
+	// vpc下还有子网时不允许删除，gcp按名称删除资源
+	deleteVpcOpt := &core.BaseDeleteOption{ResourceID: "demo"}
+	require.Error(t, cloud.DeleteVpc(kt, deleteVpcOpt))
+
+	err = cloud.DeleteSubnet(kt, &core.BaseRegionalDeleteOption{Region: region,
+		BaseDeleteOption: core.BaseDeleteOption{ResourceID: "demo-subnet"}})
+	require.NoError(t, err)
+	require.NoError(t, cloud.DeleteVpc(kt, deleteVpcOpt))
+
+	vpcs, err = cloud.ListVpc(kt, &types.GcpListOption{Page: page})
+	require.NoError(t, err)
+	assert.Empty(t, vpcs.Details)
+}
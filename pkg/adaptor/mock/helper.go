@@ -121,7 +121,7 @@ func (st *Store[K, V]) Add(key K, val V) (ok bool) {
 	if _, exists := st.dict[key]; exists {
 		return false
 	}
-	logs.V(3).Infof("[mock] Adding  %s -> %+v", key, val)
+	logs.V(3).Infof("[mock] Adding  %v -> %+v", key, val)
 	st.dict[key] = val
 
 	return true
@@ -165,7 +165,7 @@ func (st *Store[K, V]) Remove(key K) error {
 	if _, exits := st.dict[key]; !exits {
 		return errf.Newf(errf.RecordNotFound, "not found in mock store: %v", key)
 	}
-	logs.V(3).Infof("[mock] Delete %s -> %+v", key, st.dict[key])
+	logs.V(3).Infof("[mock] Delete %v -> %+v", key, st.dict[key])
 
 	delete(st.dict, key)
 	return nil
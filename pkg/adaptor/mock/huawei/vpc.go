@@ -0,0 +1,220 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package mockhuawei
+
+import (
+	adaptormock "hcm/pkg/adaptor/mock"
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/adaptor/types/core"
+	adtysubnet "hcm/pkg/adaptor/types/subnet"
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/rand"
+	"hcm/pkg/tools/slice"
+
+	"go.uber.org/mock/gomock"
+)
+
+// vpcPlaybook in memory crud vpc playbook, add ability to mock vpc, subnet related functions.
+type vpcPlaybook struct {
+	vpcStore    *adaptormock.Store[string, types.HuaWeiVpc]
+	subnetStore *adaptormock.Store[string, adtysubnet.HuaWeiSubnet]
+}
+
+// NewCrudVpcPlaybook  vpc, subnet all in one.
+func NewCrudVpcPlaybook() Playbook {
+	return &vpcPlaybook{
+		vpcStore:    adaptormock.NewCloudResStore[types.HuaWeiVpc](),
+		subnetStore: adaptormock.NewCloudResStore[adtysubnet.HuaWeiSubnet](),
+	}
+}
+
+// Name vpc playbook
+func (v *vpcPlaybook) Name() string {
+	return string(enumor.VpcCloudResType)
+}
+
+// Apply mock method
+func (v *vpcPlaybook) Apply(mockCloud *MockHuaWei, ctrl *gomock.Controller) {
+
+	v.applyVpc(mockCloud)
+
+	v.applySubnet(mockCloud)
+}
+
+func (v *vpcPlaybook) applyVpc(mockCloud *MockHuaWei) {
+	mockCloud.EXPECT().ListVpc(gomock.Any(), gomock.Any()).DoAndReturn(v.listVpc).MinTimes(1)
+	mockCloud.EXPECT().CreateVpc(gomock.Any(), gomock.Any()).DoAndReturn(v.createVpc).MinTimes(1)
+	mockCloud.EXPECT().UpdateVpc(gomock.Any(), gomock.Any()).DoAndReturn(v.updateVpc).MinTimes(1)
+	mockCloud.EXPECT().DeleteVpc(gomock.Any(), gomock.Any()).DoAndReturn(v.deleteVpc).MinTimes(1)
+}
+
+func (v *vpcPlaybook) applySubnet(mockCloud *MockHuaWei) {
+	mockCloud.EXPECT().ListSubnet(gomock.Any(), gomock.Any()).DoAndReturn(v.listSubnet).MinTimes(1)
+	mockCloud.EXPECT().CreateSubnet(gomock.Any(), gomock.Any()).DoAndReturn(v.createSubnet).MinTimes(1)
+	mockCloud.EXPECT().UpdateSubnet(gomock.Any(), gomock.Any()).DoAndReturn(v.updateSubnet).MinTimes(1)
+	mockCloud.EXPECT().DeleteSubnet(gomock.Any(), gomock.Any()).DoAndReturn(v.deleteSubnet).MinTimes(1)
+}
+
+// listVpc 按地域返回全部数据，指定了id或名称时只返回匹配的数据，mock数据量小不做分页
+func (v *vpcPlaybook) listVpc(_ *kit.Kit, opt *types.HuaWeiVpcListOption) (*types.HuaWeiVpcListResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	found := v.vpcStore.Filter(func(vpc types.HuaWeiVpc) bool {
+		if vpc.Region != opt.Region {
+			return false
+		}
+		if len(opt.CloudIDs) != 0 && !slice.IsItemInSlice(opt.CloudIDs, vpc.CloudID) {
+			return false
+		}
+		if len(opt.Names) != 0 && !slice.IsItemInSlice(opt.Names, vpc.Name) {
+			return false
+		}
+		return true
+	})
+	return &types.HuaWeiVpcListResult{Details: found}, nil
+}
+
+// createVpc 华为云创建vpc接口不返回vpc详情，调用方需要通过名称再次查询
+func (v *vpcPlaybook) createVpc(_ *kit.Kit, opt *types.HuaWeiVpcCreateOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	cloudVpc := types.HuaWeiVpc{
+		CloudID: rand.Prefix("vpc-", 8),
+		Name:    opt.Name,
+		Region:  opt.Extension.Region,
+		Memo:    opt.Memo,
+		Extension: &cloud.HuaWeiVpcExtension{
+			Cidr:                []cloud.HuaWeiCidr{{Type: enumor.Ipv4, Cidr: opt.Extension.IPv4Cidr}},
+			Status:              "OK",
+			EnterpriseProjectId: converter.PtrToVal(opt.Extension.EnterpriseProjectID),
+		},
+	}
+	v.vpcStore.Add(cloudVpc.CloudID, cloudVpc)
+	return nil
+}
+
+func (v *vpcPlaybook) updateVpc(_ *kit.Kit, opt *types.HuaWeiVpcUpdateOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	vpc, exists := v.vpcStore.Get(opt.ResourceID)
+	if !exists {
+		return errf.New(errf.RecordNotFound, "not found vpc: "+opt.ResourceID)
+	}
+	vpc.Memo = opt.Data.Memo
+	return v.vpcStore.Update(vpc.CloudID, vpc)
+}
+
+// deleteVpc 与华为云保持一致，vpc下存在子网时不允许删除
+func (v *vpcPlaybook) deleteVpc(_ *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if _, exists := v.vpcStore.Get(opt.ResourceID); !exists {
+		return errf.New(errf.RecordNotFound, "not found vpc: "+opt.ResourceID)
+	}
+
+	subnet := v.subnetStore.Find(func(n adtysubnet.HuaWeiSubnet) bool {
+		return n.CloudVpcID == opt.ResourceID
+	})
+	if subnet != nil {
+		return errf.Newf(errf.InvalidParameter, "vpc %s still has subnet %s", opt.ResourceID, subnet.CloudID)
+	}
+	return v.vpcStore.Remove(opt.ResourceID)
+}
+
+func (v *vpcPlaybook) listSubnet(_ *kit.Kit, opt *adtysubnet.HuaWeiSubnetListOption) (
+	*adtysubnet.HuaWeiSubnetListResult, error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	found := v.subnetStore.Filter(func(n adtysubnet.HuaWeiSubnet) bool {
+		if n.Region != opt.Region {
+			return false
+		}
+		return len(opt.CloudVpcID) == 0 || n.CloudVpcID == opt.CloudVpcID
+	})
+	return &adtysubnet.HuaWeiSubnetListResult{Details: found}, nil
+}
+
+func (v *vpcPlaybook) createSubnet(_ *kit.Kit, opt *adtysubnet.HuaWeiSubnetCreateOption) (
+	*adtysubnet.HuaWeiSubnet, error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, exists := v.vpcStore.Get(opt.CloudVpcID); !exists {
+		return nil, errf.New(errf.RecordNotFound, "not found vpc: "+opt.CloudVpcID)
+	}
+
+	subnet := adtysubnet.HuaWeiSubnet{
+		CloudVpcID: opt.CloudVpcID,
+		CloudID:    rand.Prefix("subnet-", 8),
+		Name:       opt.Name,
+		Region:     opt.Extension.Region,
+		Ipv4Cidr:   []string{opt.Extension.IPv4Cidr},
+		Memo:       opt.Memo,
+		Extension: &adtysubnet.HuaWeiSubnetExtension{
+			Status:     "ACTIVE",
+			DhcpEnable: true,
+			GatewayIp:  opt.Extension.GatewayIp,
+			DnsList:    []string{"100.125.1.250", "100.125.64.250"},
+		},
+	}
+	v.subnetStore.Add(subnet.CloudID, subnet)
+	return &subnet, nil
+}
+
+func (v *vpcPlaybook) updateSubnet(_ *kit.Kit, opt *adtysubnet.HuaWeiSubnetUpdateOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	subnet, exists := v.subnetStore.Get(opt.ResourceID)
+	if !exists {
+		return errf.New(errf.RecordNotFound, "not found subnet: "+opt.ResourceID)
+	}
+	subnet.Memo = opt.Data.Memo
+	return v.subnetStore.Update(subnet.CloudID, subnet)
+}
+
+func (v *vpcPlaybook) deleteSubnet(_ *kit.Kit, opt *adtysubnet.HuaWeiSubnetDeleteOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if _, exists := v.subnetStore.Get(opt.ResourceID); !exists {
+		return errf.New(errf.RecordNotFound, "not found subnet: "+opt.ResourceID)
+	}
+	return v.subnetStore.Remove(opt.ResourceID)
+}
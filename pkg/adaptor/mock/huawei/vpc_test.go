@@ -0,0 +1,89 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package mockhuawei
+
+import (
+	"testing"
+
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/adaptor/types/core"
+	adtysubnet "hcm/pkg/adaptor/types/subnet"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVpcPlaybook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cloud := NewMockHuaWei(ctrl)
+	NewCrudVpcPlaybook().Apply(cloud, ctrl)
+	kt := kit.New()
+	region := "cn-south-1"
+	page := &core.HuaWeiPage{Limit: converter.ValToPtr(int32(core.HuaWeiQueryLimit))}
+
+	err := cloud.CreateVpc(kt, &types.HuaWeiVpcCreateOption{AccountID: "00000001", Name: "demo",
+		Extension: &types.HuaWeiVpcCreateExt{Region: region, IPv4Cidr: "10.0.0.0/16"}})
+	require.NoError(t, err)
+
+	// 华为云创建vpc不返回详情，按名称查询新建的vpc
+	vpcs, err := cloud.ListVpc(kt, &types.HuaWeiVpcListOption{Names: []string{"demo"},
+		HuaWeiListOption: core.HuaWeiListOption{Region: region, Page: page}})
+	require.NoError(t, err)
+	require.Len(t, vpcs.Details, 1)
+	vpcID := vpcs.Details[0].CloudID
+
+	err = cloud.UpdateVpc(kt, &types.HuaWeiVpcUpdateOption{Region: region, VpcUpdateOption: types.VpcUpdateOption{
+		ResourceID: vpcID, Data: &types.BaseVpcUpdateData{Memo: converter.ValToPtr("updated")}}})
+	require.NoError(t, err)
+
+	subnet, err := cloud.CreateSubnet(kt, &adtysubnet.HuaWeiSubnetCreateOption{Name: "demo", CloudVpcID: vpcID,
+		Extension: &adtysubnet.HuaWeiSubnetCreateExt{Region: region, IPv4Cidr: "10.0.1.0/24",
+			GatewayIp: "10.0.1.1"}})
+	require.NoError(t, err)
+
+	subnets, err := cloud.ListSubnet(kt, &adtysubnet.HuaWeiSubnetListOption{Region: region, CloudVpcID: vpcID})
+	require.NoError(t, err)
+	require.Len(t, subnets.Details, 1)
+	assert.Equal(t, subnet.CloudID, subnets.Details[0].CloudID)
+
+	err = cloud.UpdateSubnet(kt, &adtysubnet.HuaWeiSubnetUpdateOption{Region: region, VpcID: vpcID,
+		SubnetUpdateOption: adtysubnet.SubnetUpdateOption{ResourceID: subnet.CloudID,
+			Data: &adtysubnet.BaseSubnetUpdateData{Memo: converter.ValToPtr("updated")}}})
+	require.NoError(t, err)
+
+	// vpc下还有子网时不允许删除
+	deleteVpcOpt := &core.BaseRegionalDeleteOption{Region: region,
+		BaseDeleteOption: core.BaseDeleteOption{ResourceID: vpcID}}
+	require.Error(t, cloud.DeleteVpc(kt, deleteVpcOpt))
+
+	err = cloud.DeleteSubnet(kt, &adtysubnet.HuaWeiSubnetDeleteOption{VpcID: vpcID,
+		BaseRegionalDeleteOption: core.BaseRegionalDeleteOption{Region: region,
+			BaseDeleteOption: core.BaseDeleteOption{ResourceID: subnet.CloudID}}})
+	require.NoError(t, err)
+	require.NoError(t, cloud.DeleteVpc(kt, deleteVpcOpt))
+
+	vpcs, err = cloud.ListVpc(kt, &types.HuaWeiVpcListOption{
+		HuaWeiListOption: core.HuaWeiListOption{Region: region, Page: page}})
+	require.NoError(t, err)
+	assert.Empty(t, vpcs.Details)
+}
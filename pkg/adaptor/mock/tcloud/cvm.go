@@ -0,0 +1,251 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package mocktcloud
+
+import (
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	adaptormock "hcm/pkg/adaptor/mock"
+	"hcm/pkg/adaptor/poller"
+	"hcm/pkg/adaptor/tcloud"
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+	tcvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+	"go.uber.org/mock/gomock"
+)
+
+// seedCvmCount 每个可用地域预置的主机数量
+const seedCvmCount = 3
+
+// seedCreatedTime 预置主机的创建时间，使用固定值保证每次启动的数据一致
+const seedCreatedTime = "2024-01-01T00:00:00Z"
+
+// cvmPlaybook in memory crud cvm playbook, the cloud ids are generated in sequence, so that the resources are the
+// same in every run, which makes the sync, create and delete flows can be asserted in ci and demos.
+type cvmPlaybook struct {
+	cvmStore *adaptormock.Store[string, typecvm.TCloudCvm]
+	seq      atomic.Uint64
+}
+
+// NewCvmPlaybook return cvm playbook, several cvms are preset in each available region for sync.
+func NewCvmPlaybook() Playbook {
+	p := &cvmPlaybook{cvmStore: adaptormock.NewCloudResStore[typecvm.TCloudCvm]()}
+	for _, region := range regionList {
+		zones := zoneMap[region.RegionID]
+		for i := 0; i < seedCvmCount && len(zones) != 0; i++ {
+			zone := converter.PtrToVal(zones[i%len(zones)].Zone)
+			instance := p.newInstance(region.RegionID, zone, fmt.Sprintf("mock-seed-%d", i), "S5.MEDIUM2",
+				"img-mock0001", "vpc-mock0001", "subnet-mock0001", nil)
+			instance.CreatedTime = converter.ValToPtr(seedCreatedTime)
+			p.cvmStore.Add(converter.PtrToVal(instance.InstanceId), typecvm.TCloudCvm{Instance: instance})
+		}
+	}
+
+	return p
+}
+
+// Name cvm playbook
+func (p *cvmPlaybook) Name() string {
+	return string(enumor.CvmCloudResType)
+}
+
+// Apply mock cvm methods
+func (p *cvmPlaybook) Apply(mockCloud *MockTCloud, _ *gomock.Controller) {
+	mockCloud.EXPECT().ListCvm(gomock.Any(), gomock.Any()).DoAndReturn(p.listCvm).AnyTimes()
+	mockCloud.EXPECT().ListCvmWithCount(gomock.Any(), gomock.Any()).DoAndReturn(p.listCvmWithCount).AnyTimes()
+	mockCloud.EXPECT().CountCvm(gomock.Any(), gomock.Any()).DoAndReturn(p.countCvm).AnyTimes()
+	mockCloud.EXPECT().CreateCvm(gomock.Any(), gomock.Any()).DoAndReturn(p.createCvm).AnyTimes()
+	mockCloud.EXPECT().DeleteCvm(gomock.Any(), gomock.Any()).DoAndReturn(p.deleteCvm).AnyTimes()
+	mockCloud.EXPECT().StartCvm(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ *kit.Kit, opt *typecvm.TCloudStartOption) error {
+			return p.setState(opt.Region, opt.CloudIDs, "RUNNING")
+		}).AnyTimes()
+	mockCloud.EXPECT().StopCvm(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ *kit.Kit, opt *typecvm.TCloudStopOption) error {
+			return p.setState(opt.Region, opt.CloudIDs, "STOPPED")
+		}).AnyTimes()
+	mockCloud.EXPECT().RebootCvm(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ *kit.Kit, opt *typecvm.TCloudRebootOption) error {
+			return p.setState(opt.Region, opt.CloudIDs, "RUNNING")
+		}).AnyTimes()
+}
+
+// listRegion returns the cvms of the region sorted by cloud id, so that the paging is stable.
+func (p *cvmPlaybook) listRegion(region string, cloudIDs []string) []typecvm.TCloudCvm {
+	var found []typecvm.TCloudCvm
+	if len(cloudIDs) == 0 {
+		found = p.cvmStore.Filter(func(cvm typecvm.TCloudCvm) bool {
+			return p.regionOf(cvm) == region
+		})
+	} else {
+		found = p.cvmStore.GetByKeys(cloudIDs...)
+	}
+
+	sort.Slice(found, func(i, j int) bool { return found[i].GetCloudID() < found[j].GetCloudID() })
+	return found
+}
+
+func (p *cvmPlaybook) regionOf(cvm typecvm.TCloudCvm) string {
+	zone := converter.PtrToVal(cvm.Placement.Zone)
+	for region, zones := range zoneMap {
+		for _, one := range zones {
+			if converter.PtrToVal(one.Zone) == zone {
+				return region
+			}
+		}
+	}
+	return ""
+}
+
+func (p *cvmPlaybook) listCvm(_ *kit.Kit, opt *typecvm.TCloudListOption) ([]typecvm.TCloudCvm, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	found := p.listRegion(opt.Region, opt.CloudIDs)
+	if opt.Page == nil {
+		return found, nil
+	}
+
+	return page(found, opt.Page.Offset, opt.Page.Limit), nil
+}
+
+func (p *cvmPlaybook) listCvmWithCount(_ *kit.Kit, opt *typecvm.ListCvmWithCountOption) (*typecvm.CvmWithCountResp,
+	error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	found := p.listRegion(opt.Region, opt.CloudIDs)
+	total := len(found)
+	if opt.Page != nil {
+		found = page(found, opt.Page.Offset, opt.Page.Limit)
+	}
+
+	return &typecvm.CvmWithCountResp{TotalCount: int64(total), Cvms: found}, nil
+}
+
+func (p *cvmPlaybook) countCvm(_ *kit.Kit, region string) (int32, error) {
+	return int32(len(p.listRegion(region, nil))), nil
+}
+
+func (p *cvmPlaybook) createCvm(_ *kit.Kit, opt *typecvm.TCloudCreateOption) (*poller.BaseDoneResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, exists := zoneMap[opt.Region]; !exists {
+		return nil, errors.NewTencentCloudSDKError("InvalidParameterValue",
+			"[mock] region "+opt.Region+" is not supported", "request-id")
+	}
+
+	result := new(poller.BaseDoneResult)
+	if opt.DryRun {
+		return result, nil
+	}
+
+	for i := int64(0); i < opt.RequiredCount; i++ {
+		instance := p.newInstance(opt.Region, opt.Zone, opt.Name, opt.InstanceType, opt.CloudImageID, opt.CloudVpcID,
+			opt.CloudSubnetID, opt.CloudSecurityGroupIDs)
+		cloudID := converter.PtrToVal(instance.InstanceId)
+		p.cvmStore.Add(cloudID, typecvm.TCloudCvm{Instance: instance})
+		result.SuccessCloudIDs = append(result.SuccessCloudIDs, cloudID)
+	}
+
+	return result, nil
+}
+
+func (p *cvmPlaybook) deleteCvm(_ *kit.Kit, opt *typecvm.TCloudDeleteOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	for _, cloudID := range opt.CloudIDs {
+		if _, exists := p.cvmStore.Get(cloudID); !exists {
+			return errors.NewTencentCloudSDKError(tcloud.ErrNotFound, "[mock] not found cvm: "+cloudID, "request-id")
+		}
+	}
+
+	for _, cloudID := range opt.CloudIDs {
+		if err := p.cvmStore.Remove(cloudID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *cvmPlaybook) setState(region string, cloudIDs []string, state string) error {
+	for _, cvm := range p.listRegion(region, cloudIDs) {
+		instance := *cvm.Instance
+		instance.InstanceState = converter.ValToPtr(state)
+		if err := p.cvmStore.Update(cvm.GetCloudID(), typecvm.TCloudCvm{Instance: &instance}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *cvmPlaybook) newInstance(region, zone, name, instanceType, imageID, vpcID, subnetID string,
+	sgIDs []string) *tcvm.Instance {
+
+	seq := p.seq.Add(1)
+	return &tcvm.Instance{
+		InstanceId:         converter.ValToPtr(fmt.Sprintf("ins-mock%04d", seq)),
+		InstanceName:       converter.ValToPtr(name),
+		InstanceState:      converter.ValToPtr("RUNNING"),
+		InstanceType:       converter.ValToPtr(instanceType),
+		InstanceChargeType: converter.ValToPtr("POSTPAID_BY_HOUR"),
+		CPU:                converter.ValToPtr(int64(2)),
+		Memory:             converter.ValToPtr(int64(4)),
+		ImageId:            converter.ValToPtr(imageID),
+		OsName:             converter.ValToPtr("TencentOS Server 3.1"),
+		Placement:          &tcvm.Placement{Zone: converter.ValToPtr(zone)},
+		VirtualPrivateCloud: &tcvm.VirtualPrivateCloud{
+			VpcId:    converter.ValToPtr(vpcID),
+			SubnetId: converter.ValToPtr(subnetID),
+		},
+		PrivateIpAddresses: []*string{converter.ValToPtr(fmt.Sprintf("10.0.%d.%d", seq/250, seq%250+1))},
+		SecurityGroupIds:   converter.SliceToPtr(sgIDs),
+		CreatedTime:        converter.ValToPtr(time.Now().UTC().Format(time.RFC3339)),
+		Uuid:               converter.ValToPtr(fmt.Sprintf("mock-uuid-%04d", seq)),
+		RenewFlag:          converter.ValToPtr("NOTIFY_AND_MANUAL_RENEW"),
+	}
+}
+
+// page returns the items in the page.
+func page[T any](items []T, offset, limit uint64) []T {
+	if offset >= uint64(len(items)) {
+		return nil
+	}
+
+	end := uint64(len(items))
+	if limit != 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return items[offset:end]
+}
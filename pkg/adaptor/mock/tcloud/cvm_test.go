@@ -0,0 +1,80 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package mocktcloud
+
+import (
+	"testing"
+
+	"hcm/pkg/adaptor/types/core"
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/kit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCvmPlaybook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cloud := NewMockTCloud(ctrl)
+	NewCvmPlaybook().Apply(cloud, ctrl)
+	kt := kit.New()
+
+	// the seed cvms are the same in every run.
+	seeds, err := cloud.ListCvm(kt, &typecvm.TCloudListOption{Region: "ap-guangzhou"})
+	require.NoError(t, err)
+	require.Len(t, seeds, seedCvmCount)
+	assert.Equal(t, "ins-mock0004", seeds[0].GetCloudID())
+
+	paged, err := cloud.ListCvm(kt, &typecvm.TCloudListOption{Region: "ap-guangzhou",
+		Page: &core.TCloudPage{Offset: 1, Limit: 1}})
+	require.NoError(t, err)
+	require.Len(t, paged, 1)
+	assert.Equal(t, seeds[1].GetCloudID(), paged[0].GetCloudID())
+
+	result, err := cloud.CreateCvm(kt, &typecvm.TCloudCreateOption{Region: "ap-guangzhou", Name: "demo",
+		Zone: "ap-guangzhou-3", InstanceType: "S5.MEDIUM2", CloudImageID: "img-mock0001", Password: "Mock@Password1",
+		RequiredCount: 2, CloudSecurityGroupIDs: []string{"sg-mock0001"}, CloudVpcID: "vpc-mock0001",
+		CloudSubnetID: "subnet-mock0001", InstanceChargeType: typecvm.PostpaidByHour,
+		SystemDisk: new(typecvm.TCloudSystemDisk)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ins-mock0007", "ins-mock0008"}, result.SuccessCloudIDs)
+
+	count, err := cloud.CountCvm(kt, "ap-guangzhou")
+	require.NoError(t, err)
+	assert.Equal(t, int32(seedCvmCount+2), count)
+
+	err = cloud.StopCvm(kt, &typecvm.TCloudStopOption{Region: "ap-guangzhou", CloudIDs: result.SuccessCloudIDs[:1],
+		StopType: typecvm.SoftFirst, StoppedMode: typecvm.KeepCharging})
+	require.NoError(t, err)
+	stopped, err := cloud.ListCvm(kt, &typecvm.TCloudListOption{Region: "ap-guangzhou",
+		CloudIDs: result.SuccessCloudIDs[:1]})
+	require.NoError(t, err)
+	assert.Equal(t, "STOPPED", *stopped[0].InstanceState)
+
+	require.NoError(t, cloud.DeleteCvm(kt, &typecvm.TCloudDeleteOption{Region: "ap-guangzhou",
+		CloudIDs: result.SuccessCloudIDs}))
+	assert.Error(t, cloud.DeleteCvm(kt, &typecvm.TCloudDeleteOption{Region: "ap-guangzhou",
+		CloudIDs: result.SuccessCloudIDs}))
+
+	count, err = cloud.CountCvm(kt, "ap-guangzhou")
+	require.NoError(t, err)
+	assert.Equal(t, int32(seedCvmCount), count)
+}
@@ -54,12 +54,15 @@ func getPlaybooks() []Playbook {
 		/* add playbook here */
 		NewRegionPlaybook(),
 		NewCrudVpcPlaybook(),
+		NewCvmPlaybook(),
 	}
 }
 
 func initMock() {
 	ctrl = gomock.NewController(&adaptormock.LogReporter{})
 	mockCloud = NewMockTCloud(ctrl)
+	// 客户端池中的客户端创建时会设置频率限制的重试策略，mock客户端忽略该设置
+	mockCloud.EXPECT().SetRateLimitRetryWithRandomInterval(gomock.Any()).AnyTimes()
 
 	defaultPlaybook := getPlaybooks()
 	for i, playbook := range defaultPlaybook {
@@ -0,0 +1,154 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package operatortag 创建云上资源时自动添加记录hcm请求ID、操作人和业务的标签，用于将云上的账单、审计追溯到hcm的操作。
+// 标签的值从请求的kit中获取，各云厂商的adaptor在创建资源时将其与用户指定的标签合并，用户指定了同名标签时以用户指定的为准。
+package operatortag
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/cc"
+	"hcm/pkg/kit"
+)
+
+// gcpLabelMaxLength gcp label 值的最大长度
+const gcpLabelMaxLength = 63
+
+var active atomic.Pointer[cc.OperatorTag]
+
+// Init set the operator tag setting getter, the setting is reloaded once the settings are changed.
+func Init(getter func() cc.OperatorTag) {
+	load(getter())
+	cc.OnChange(func() {
+		load(getter())
+	})
+}
+
+func load(conf cc.OperatorTag) {
+	if !conf.Enable {
+		active.Store(nil)
+		return
+	}
+
+	active.Store(&conf)
+}
+
+// Pairs returns the operator tags of the request, the tag whose value is empty is not returned, such as the biz tag
+// of the request not in a business. nil is returned if the operator tag is not enabled.
+func Pairs(kt *kit.Kit) []core.TagPair {
+	conf := active.Load()
+	if conf == nil || kt == nil {
+		return nil
+	}
+
+	pairs := make([]core.TagPair, 0, 3)
+	for _, pair := range []core.TagPair{
+		{Key: conf.RidKey, Value: kt.Rid},
+		{Key: conf.OperatorKey, Value: kt.User},
+		{Key: conf.BizKey, Value: kt.BizID},
+	} {
+		if len(pair.Value) != 0 {
+			pairs = append(pairs, pair)
+		}
+	}
+
+	return pairs
+}
+
+// Merge returns the tags specified by user with the operator tags of the request appended, the operator tag is
+// skipped if user has specified a tag with the same key.
+func Merge(kt *kit.Kit, tags []core.TagPair) []core.TagPair {
+	pairs := Pairs(kt)
+	if len(pairs) == 0 {
+		return tags
+	}
+
+	keys := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		keys[tag.Key] = struct{}{}
+	}
+
+	merged := make([]core.TagPair, 0, len(tags)+len(pairs))
+	merged = append(merged, tags...)
+	for _, pair := range pairs {
+		if _, exists := keys[pair.Key]; !exists {
+			merged = append(merged, pair)
+		}
+	}
+
+	return merged
+}
+
+// MergeMap is the same as Merge, but the tags is a map, which is used by azure and gcp.
+func MergeMap(kt *kit.Kit, tags map[string]string) map[string]string {
+	pairs := Pairs(kt)
+	if len(pairs) == 0 {
+		return tags
+	}
+
+	merged := make(map[string]string, len(tags)+len(pairs))
+	for _, pair := range pairs {
+		merged[pair.Key] = pair.Value
+	}
+	for key, value := range tags {
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// GcpLabels is the same as MergeMap, but the values of the operator tags are converted to match the format of gcp
+// labels, which only contains lowercase letters, numbers, underscores and dashes, and is at most 63 characters.
+func GcpLabels(kt *kit.Kit, labels map[string]string) map[string]string {
+	pairs := Pairs(kt)
+	if len(pairs) == 0 {
+		return labels
+	}
+
+	merged := make(map[string]string, len(labels)+len(pairs))
+	for _, pair := range pairs {
+		merged[pair.Key] = gcpLabelValue(pair.Value)
+	}
+	for key, value := range labels {
+		merged[key] = value
+	}
+
+	return merged
+}
+
+func gcpLabelValue(value string) string {
+	value = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		default:
+			return '_'
+		}
+	}, value)
+
+	if len(value) > gcpLabelMaxLength {
+		value = value[:gcpLabelMaxLength]
+	}
+	return value
+}
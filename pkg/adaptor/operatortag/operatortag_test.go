@@ -0,0 +1,63 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package operatortag
+
+import (
+	"testing"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/cc"
+	"hcm/pkg/kit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	defer active.Store(nil)
+
+	kt := &kit.Kit{Rid: "bad4bb0d28e044fb8c5a0dbc4ab8ac41", User: "Admin.Zhang", BizID: "310"}
+	userTags := []core.TagPair{{Key: "hcm_operator", Value: "owner"}, {Key: "env", Value: "prod"}}
+	assert.Nil(t, Pairs(kt), "operator tag is not enabled")
+	assert.Equal(t, userTags, Merge(kt, userTags))
+
+	conf := cc.OperatorTag{Enable: true}
+	conf.RidKey, conf.OperatorKey, conf.BizKey = "hcm_rid", "hcm_operator", "hcm_biz"
+	load(conf)
+
+	assert.Equal(t, []core.TagPair{
+		{Key: "hcm_operator", Value: "owner"},
+		{Key: "env", Value: "prod"},
+		{Key: "hcm_rid", Value: kt.Rid},
+		{Key: "hcm_biz", Value: "310"},
+	}, Merge(kt, userTags), "tag specified by user takes precedence")
+
+	assert.Equal(t, map[string]string{"hcm_rid": kt.Rid, "hcm_operator": "Admin.Zhang", "hcm_biz": "310"},
+		MergeMap(kt, nil))
+	assert.Equal(t, map[string]string{"hcm_rid": kt.Rid, "hcm_operator": "admin_zhang", "hcm_biz": "310"},
+		GcpLabels(kt, nil), "value is converted to gcp label format")
+
+	// biz tag is not added if the resource is not created in a business
+	kt.BizID = ""
+	assert.Equal(t, []core.TagPair{{Key: "hcm_rid", Value: kt.Rid}, {Key: "hcm_operator", Value: "Admin.Zhang"}},
+		Pairs(kt))
+
+	load(cc.OperatorTag{Enable: false})
+	assert.Nil(t, Pairs(kt))
+}
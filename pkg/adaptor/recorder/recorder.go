@@ -0,0 +1,315 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package recorder 云厂商SDK的http录制与回放。录制模式下请求发送到云上，并将响应保存到文件；回放模式下从文件中查找
+// 相同请求的响应返回，不访问云上，用于基于真实的(大量)云上数据对同步的对比逻辑等进行回归测试。
+// 目前支持腾讯云、aws、azure的SDK。
+package recorder
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Mode is the mode of the recorder.
+type Mode string
+
+const (
+	// Off the requests are sent to cloud directly.
+	Off Mode = "off"
+	// Record the requests are sent to cloud, and the responses are saved to the cassette file.
+	Record Mode = "record"
+	// Replay the responses are replied from the cassette file, the requests are not sent to cloud.
+	Replay Mode = "replay"
+)
+
+// ModeEnv is the env to override the mode of the recorder started by StartFromEnv, e.g. set it to record to
+// capture the responses from cloud again.
+const ModeEnv = "HCM_ADAPTOR_RECORD_MODE"
+
+// Interaction is a recorded request and its response. the request body is kept as digest, because it may contain
+// secrets, e.g. the azure token request.
+type Interaction struct {
+	Key      string   `json:"key"`
+	Response Response `json:"response"`
+}
+
+// Response is the recorded response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+// Cassette is the recorded interactions which is saved to file.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// recordedHeaders are the response headers which are recorded, the others are dropped to keep the cassette small
+// and free of sensitive data.
+var recordedHeaders = []string{"Content-Type", "X-Ms-Request-Id", "X-Amzn-Requestid", "Location",
+	"Azure-Asyncoperation", "Retry-After"}
+
+// tokenRegexp matches the tokens in response body, they are redacted before saved.
+var tokenRegexp = regexp.MustCompile(`"(access_token|refresh_token|id_token)"\s*:\s*"[^"]*"`)
+
+// Recorder is the http.RoundTripper which records or replays the cloud sdk requests.
+type Recorder struct {
+	mode Mode
+	file string
+	next http.RoundTripper
+
+	lock         sync.Mutex
+	interactions []Interaction
+	// replayed the count of the replayed interactions of each key, the interactions of the same key are replied
+	// in order, and the last one is replied repeatedly when all of them are replayed.
+	replayed map[string]int
+}
+
+// New create a recorder, the cassette file is loaded in replay mode. the file is gzip compressed if the name ends
+// with .gz, which is recommended for the large payloads.
+func New(mode Mode, file string, next http.RoundTripper) (*Recorder, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	r := &Recorder{mode: mode, file: file, next: next, replayed: make(map[string]int)}
+	switch mode {
+	case Record:
+	case Replay:
+		cassette, err := load(file)
+		if err != nil {
+			return nil, err
+		}
+		r.interactions = cassette.Interactions
+	default:
+		return nil, fmt.Errorf("unsupported recorder mode: %s", mode)
+	}
+
+	return r, nil
+}
+
+// RoundTrip records or replays the request.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	key, err := requestKey(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == Replay {
+		return r.replay(req, key)
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	recorded := Response{StatusCode: resp.StatusCode, Header: make(http.Header),
+		Body: tokenRegexp.ReplaceAllString(string(body), `"$1":"redacted"`)}
+	for _, name := range recordedHeaders {
+		if values := resp.Header.Values(name); len(values) != 0 {
+			recorded.Header[name] = values
+		}
+	}
+
+	r.lock.Lock()
+	r.interactions = append(r.interactions, Interaction{Key: key, Response: recorded})
+	r.lock.Unlock()
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, key string) (*http.Response, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	matched := make([]*Response, 0)
+	for idx := range r.interactions {
+		if r.interactions[idx].Key == key {
+			matched = append(matched, &r.interactions[idx].Response)
+		}
+	}
+
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("no recorded response of %s %s%s in %s", req.Method, req.URL.Host, req.URL.Path,
+			r.file)
+	}
+
+	idx := r.replayed[key]
+	if idx >= len(matched) {
+		idx = len(matched) - 1
+	}
+	r.replayed[key]++
+
+	recorded := matched[idx]
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+// Save the recorded interactions to the cassette file, it does nothing in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode != Record {
+		return nil
+	}
+
+	r.lock.Lock()
+	cassette := Cassette{Interactions: r.interactions}
+	raw, err := json.MarshalIndent(cassette, "", "  ")
+	r.lock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if strings.HasSuffix(r.file, ".gz") {
+		buf := new(bytes.Buffer)
+		gz := gzip.NewWriter(buf)
+		if _, err = gz.Write(raw); err != nil {
+			return err
+		}
+		if err = gz.Close(); err != nil {
+			return err
+		}
+		raw = buf.Bytes()
+	}
+
+	return os.WriteFile(r.file, raw, 0644)
+}
+
+func load(file string) (*Cassette, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read cassette %s failed, err: %v", file, err)
+	}
+
+	if strings.HasSuffix(file, ".gz") {
+		gz, err := gzip.NewReader(bytes.NewReader(raw))
+		if err != nil {
+			return nil, fmt.Errorf("read cassette %s failed, err: %v", file, err)
+		}
+		defer gz.Close()
+
+		if raw, err = io.ReadAll(gz); err != nil {
+			return nil, fmt.Errorf("read cassette %s failed, err: %v", file, err)
+		}
+	}
+
+	cassette := new(Cassette)
+	if err = json.Unmarshal(raw, cassette); err != nil {
+		return nil, fmt.Errorf("unmarshal cassette %s failed, err: %v", file, err)
+	}
+
+	return cassette, nil
+}
+
+// requestKey returns the key to match the recorded request, it consists of the method, host, path, query, the
+// tcloud action and the digest of the body. the body is read and reset to the request.
+func requestKey(req *http.Request) (string, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return "", err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	sum := sha256.Sum256(body)
+	return strings.Join([]string{req.Method, req.URL.Host, req.URL.Path, req.URL.RawQuery,
+		req.Header.Get("X-TC-Action"), req.Header.Get("X-TC-Region"), hex.EncodeToString(sum[:8])}, " "), nil
+}
+
+var active atomic.Pointer[Recorder]
+
+// Start the recorder which is used by the transports of the cloud sdk clients created afterwards, the returned
+// stop func restores the default transport and saves the cassette in record mode. it is used in tests, and the
+// tests using it should not run in parallel.
+func Start(mode Mode, file string) (func() error, error) {
+	if mode == Off {
+		return func() error { return nil }, nil
+	}
+
+	r, err := New(mode, file, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	active.Store(r)
+	return func() error {
+		active.Store(nil)
+		return r.Save()
+	}, nil
+}
+
+// StartFromEnv start the recorder in the mode of ModeEnv, or the default mode if the env is not set.
+func StartFromEnv(file string, defaultMode Mode) (func() error, error) {
+	mode := defaultMode
+	if env := os.Getenv(ModeEnv); len(env) != 0 {
+		mode = Mode(env)
+	}
+
+	return Start(mode, file)
+}
+
+// Transport returns the transport of the cloud sdk clients, it is the active recorder if started, otherwise it is
+// the next transport, http.DefaultTransport is used if next is nil.
+func Transport(next http.RoundTripper) http.RoundTripper {
+	if r := active.Load(); r != nil {
+		return r
+	}
+
+	if next == nil {
+		return http.DefaultTransport
+	}
+	return next
+}
+
+// Enabled returns whether the recorder is started.
+func Enabled() bool {
+	return active.Load() != nil
+}
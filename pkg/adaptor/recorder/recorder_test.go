@@ -0,0 +1,118 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recorder
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		if strings.Contains(r.URL.Path, "token") {
+			_, _ = w.Write([]byte(`{"access_token": "real-token", "expires_in": 3600}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"Response": {"Page": "` + string(body) + `", "Call": ` + strconv.Itoa(calls) + `}}`))
+	}))
+
+	do := func(client *http.Client, path, body string) (int, string) {
+		req, err := http.NewRequest(http.MethodPost, server.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("X-TC-Action", "DescribeInstances")
+		req.Header.Set("Authorization", "TC3-HMAC-SHA256 Credential=secret-id")
+		resp, err := client.Do(req)
+		if err != nil {
+			return 0, err.Error()
+		}
+		defer resp.Body.Close()
+		raw, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(raw)
+	}
+
+	file := filepath.Join(t.TempDir(), "cassette.json.gz")
+	stop, err := Start(Record, file)
+	require.NoError(t, err)
+	client := &http.Client{Transport: Transport(nil)}
+	_, page1 := do(client, "/", "page-1")
+	_, page2 := do(client, "/", "page-2")
+	_, poll := do(client, "/", "page-2")
+	_, token := do(client, "/token", "client_secret=secret")
+	require.NoError(t, stop())
+	assert.Equal(t, 4, calls)
+	assert.False(t, Enabled())
+
+	raw, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "secret")
+
+	server.Close()
+	stop, err = Start(Replay, file)
+	require.NoError(t, err)
+	defer stop()
+
+	client = &http.Client{Transport: Transport(nil)}
+	code, replayed := do(client, "/", "page-1")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, page1, replayed)
+
+	// the responses of the same request are replied in order, and the last one is replied repeatedly.
+	_, replayed = do(client, "/", "page-2")
+	assert.Equal(t, page2, replayed)
+	_, replayed = do(client, "/", "page-2")
+	assert.Equal(t, poll, replayed)
+	_, replayed = do(client, "/", "page-2")
+	assert.Equal(t, poll, replayed)
+
+	_, replayed = do(client, "/token", "client_secret=secret")
+	assert.NotEqual(t, token, replayed)
+	assert.Contains(t, replayed, `"access_token":"redacted"`)
+
+	_, replayed = do(client, "/", "page-3")
+	assert.Contains(t, replayed, "no recorded response")
+	assert.Equal(t, 4, calls)
+}
+
+func TestStartFromEnv(t *testing.T) {
+	t.Setenv(ModeEnv, string(Off))
+	stop, err := StartFromEnv(filepath.Join(t.TempDir(), "missing.json"), Replay)
+	require.NoError(t, err)
+	assert.False(t, Enabled())
+	assert.NoError(t, stop())
+
+	t.Setenv(ModeEnv, "")
+	_, err = StartFromEnv(filepath.Join(t.TempDir(), "missing.json"), Replay)
+	assert.Error(t, err)
+}
@@ -177,6 +177,8 @@ func (t *TCloudImpl) CreateLoadBalancer(kt *kit.Kit, opt *typelb.TCloudCreateClb
 	}
 
 	req := t.formatCreateClbRequest(opt)
+	// 用户指定的标签与操作人标签合并后添加到负载均衡上
+	req.Tags = clbTags(kt, opt.Tags)
 
 	createResp, err := client.CreateLoadBalancerWithContext(kt.Ctx, req)
 	if err != nil {
@@ -296,13 +298,6 @@ func (t *TCloudImpl) formatCreateClbRequest(opt *typelb.TCloudCreateClbOption) *
 	req.BandwidthPackageId = opt.BandwidthPackageID
 	req.SnatIps = opt.SnatIps
 
-	for _, tag := range opt.Tags {
-		req.Tags = append(req.Tags, &clb.TagInfo{
-			TagKey:   cvt.ValToPtr(tag.Key),
-			TagValue: cvt.ValToPtr(tag.Value),
-		})
-	}
-
 	// 使用默认ISP时传递空即可
 	ispVal := cvt.PtrToVal(opt.VipIsp)
 	if ispVal != "" && ispVal != typelb.TCloudDefaultISP {
@@ -374,6 +374,8 @@ func (t *TCloudImpl) CreateCvm(kt *kit.Kit, opt *typecvm.TCloudCreateOption) (*p
 	req.LoginSettings = &cvm.LoginSettings{
 		Password: common.StringPtr(opt.Password),
 	}
+	req.UserData = opt.UserData
+	req.TagSpecification = cvmTagSpecification(kt)
 	req.InternetAccessible = &cvm.InternetAccessible{
 		InternetMaxBandwidthOut: common.Int64Ptr(opt.InternetMaxBandwidthOut),
 		PublicIpAssigned:        common.BoolPtr(opt.PublicIPAssigned),
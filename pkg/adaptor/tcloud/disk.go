@@ -113,6 +113,7 @@ func (t *TCloudImpl) createDisk(kt *kit.Kit, opt *disk.TCloudDiskCreateOption) (
 	if err != nil {
 		return nil, err
 	}
+	req.Tags = cbsTags(kt)
 
 	return client.CreateDisksWithContext(kt.Ctx, req)
 }
@@ -268,6 +268,7 @@ func (t *TCloudImpl) CreateEip(kt *kit.Kit, opt *eip.TCloudEipCreateOption) (*po
 	if err != nil {
 		return nil, err
 	}
+	req.Tags = vpcTags(kt, nil)
 
 	client, err := t.clientSet.VpcClient(opt.Region)
 	if err != nil {
@@ -35,6 +35,7 @@ import (
 	"hcm/pkg/adaptor/types/image"
 	"hcm/pkg/adaptor/types/instance-type"
 	typelb "hcm/pkg/adaptor/types/load-balancer"
+	natgateway "hcm/pkg/adaptor/types/nat-gateway"
 	"hcm/pkg/adaptor/types/region"
 	"hcm/pkg/adaptor/types/route-table"
 	"hcm/pkg/adaptor/types/security-group"
@@ -102,10 +103,14 @@ type TCloud interface {
 	GetBillList(kt *kit.Kit, opt *typesBill.TCloudBillListOption) (*billing.DescribeBillDetailResponseParams, error)
 	ListInstanceType(kt *kit.Kit, opt *instancetype.TCloudInstanceTypeListOption) (
 		[]instancetype.TCloudInstanceType, error)
+	CreateRouteTable(kt *kit.Kit, opt *routetable.TCloudRouteTableCreateOption) (*routetable.TCloudRouteTable, error)
 	UpdateRouteTable(_ *kit.Kit, _ *routetable.TCloudRouteTableUpdateOption) error
 	DeleteRouteTable(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error
 	ListRouteTable(kt *kit.Kit, opt *core.TCloudListOption) (*routetable.TCloudRouteTableListResult, error)
 	CountRouteTable(kt *kit.Kit, region string) (int32, error)
+	CreateNatGateway(kt *kit.Kit, opt *natgateway.TCloudNatGatewayCreateOption) (*natgateway.TCloudNatGateway, error)
+	ListNatGateway(kt *kit.Kit, opt *core.TCloudListOption) (*natgateway.TCloudNatGatewayListResult, error)
+	DeleteNatGateway(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error
 	CreateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.TCloudCreateOption) error
 	DeleteSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.TCloudDeleteOption) error
 	UpdateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.TCloudUpdateOption) error
@@ -0,0 +1,214 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/poller"
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/adaptor/types/core"
+	natgateway "hcm/pkg/adaptor/types/nat-gateway"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
+)
+
+// CreateNatGateway create nat gateway, nat gateway is created asynchronously on cloud, the returned nat gateway
+// may be in PENDING state, use ListNatGateway to check if it is AVAILABLE.
+// reference: https://cloud.tencent.com/document/api/215/36722
+func (t *TCloudImpl) CreateNatGateway(kt *kit.Kit, opt *natgateway.TCloudNatGatewayCreateOption) (
+	*natgateway.TCloudNatGateway, error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	vpcClient, err := t.clientSet.VpcClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new vpc client failed, err: %v", err)
+	}
+
+	req := vpc.NewCreateNatGatewayRequest()
+	req.NatGatewayName = converter.ValToPtr(opt.Name)
+	req.VpcId = converter.ValToPtr(opt.CloudVpcID)
+	req.AddressCount = converter.ValToPtr(opt.AddressCount)
+	if len(opt.Zone) != 0 {
+		req.Zone = converter.ValToPtr(opt.Zone)
+	}
+	if opt.InternetMaxBandwidthOut != 0 {
+		req.InternetMaxBandwidthOut = converter.ValToPtr(opt.InternetMaxBandwidthOut)
+	}
+	if opt.MaxConcurrentConnection != 0 {
+		req.MaxConcurrentConnection = converter.ValToPtr(opt.MaxConcurrentConnection)
+	}
+
+	resp, err := vpcClient.CreateNatGatewayWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create tencent cloud nat gateway failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	if len(resp.Response.NatGatewaySet) == 0 {
+		return nil, fmt.Errorf("create nat gateway succeeded but no nat gateway returned")
+	}
+
+	return convertNatGateway(resp.Response.NatGatewaySet[0], opt.Region), nil
+}
+
+// ListNatGateway list nat gateway.
+// reference: https://cloud.tencent.com/document/api/215/36034
+func (t *TCloudImpl) ListNatGateway(kt *kit.Kit, opt *core.TCloudListOption) (*natgateway.TCloudNatGatewayListResult,
+	error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	vpcClient, err := t.clientSet.VpcClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new vpc client failed, err: %v", err)
+	}
+
+	// 使用过滤条件按ID查询，网关不存在时返回空而不是报错
+	req := vpc.NewDescribeNatGatewaysRequest()
+	if len(opt.CloudIDs) != 0 {
+		req.Filters = []*vpc.Filter{{Name: converter.ValToPtr("nat-gateway-id"),
+			Values: converter.SliceToPtr(opt.CloudIDs)}}
+		req.Limit = converter.ValToPtr(uint64(core.TCloudQueryLimit))
+	}
+
+	if opt.Page != nil {
+		req.Offset = converter.ValToPtr(opt.Page.Offset)
+		req.Limit = converter.ValToPtr(opt.Page.Limit)
+	}
+
+	resp, err := vpcClient.DescribeNatGatewaysWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("list tencent cloud nat gateway failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return nil, fmt.Errorf("list tencent cloud nat gateway failed, err: %v", err)
+	}
+
+	details := make([]natgateway.TCloudNatGateway, 0, len(resp.Response.NatGatewaySet))
+	for _, data := range resp.Response.NatGatewaySet {
+		details = append(details, converter.PtrToVal(convertNatGateway(data, opt.Region)))
+	}
+
+	return &natgateway.TCloudNatGatewayListResult{Count: resp.Response.TotalCount, Details: details}, nil
+}
+
+// DeleteNatGateway delete nat gateway and wait until it is deleted, the routes to the nat gateway are deleted and
+// the eips are unbound by cloud, but the eips are not released.
+// reference: https://cloud.tencent.com/document/api/215/36717
+func (t *TCloudImpl) DeleteNatGateway(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	vpcClient, err := t.clientSet.VpcClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new vpc client failed, err: %v", err)
+	}
+
+	req := vpc.NewDeleteNatGatewayRequest()
+	req.NatGatewayId = converter.ValToPtr(opt.ResourceID)
+
+	if _, err = vpcClient.DeleteNatGatewayWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete tencent cloud nat gateway failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	handler := &deleteNatGatewayPollingHandler{region: opt.Region}
+	respPoller := poller.Poller[*TCloudImpl, []*vpc.NatGateway, []string]{Handler: handler}
+	remains, err := respPoller.PollUntilDone(t, kt, []*string{converter.ValToPtr(opt.ResourceID)},
+		types.NewDeleteNatGatewayPollerOption())
+	if err != nil {
+		return err
+	}
+
+	if len(converter.PtrToVal(remains)) != 0 {
+		return fmt.Errorf("nat gateway %s is still deleting", opt.ResourceID)
+	}
+
+	return nil
+}
+
+func convertNatGateway(data *vpc.NatGateway, region string) *natgateway.TCloudNatGateway {
+	if data == nil {
+		return nil
+	}
+
+	nat := &natgateway.TCloudNatGateway{
+		CloudID:     converter.PtrToVal(data.NatGatewayId),
+		Name:        converter.PtrToVal(data.NatGatewayName),
+		Region:      region,
+		Zone:        converter.PtrToVal(data.Zone),
+		CloudVpcID:  converter.PtrToVal(data.VpcId),
+		State:       natgateway.NatGatewayState(converter.PtrToVal(data.State)),
+		CreatedTime: converter.PtrToVal(data.CreatedTime),
+	}
+
+	for _, address := range data.PublicIpAddressSet {
+		if address == nil {
+			continue
+		}
+		nat.CloudEipIDs = append(nat.CloudEipIDs, converter.PtrToVal(address.AddressId))
+		nat.PublicIPAddresses = append(nat.PublicIPAddresses, converter.PtrToVal(address.PublicIpAddress))
+	}
+
+	return nat
+}
+
+type deleteNatGatewayPollingHandler struct {
+	region string
+}
+
+// Done return the nat gateways which are not deleted yet.
+func (h *deleteNatGatewayPollingHandler) Done(nats []*vpc.NatGateway) (bool, *[]string) {
+	remains := make([]string, 0)
+	for _, nat := range nats {
+		remains = append(remains, converter.PtrToVal(nat.NatGatewayId))
+	}
+
+	return len(remains) == 0, converter.ValToPtr(remains)
+}
+
+// Poll ...
+func (h *deleteNatGatewayPollingHandler) Poll(client *TCloudImpl, kt *kit.Kit, cloudIDs []*string) (
+	[]*vpc.NatGateway, error) {
+
+	vpcClient, err := client.clientSet.VpcClient(h.region)
+	if err != nil {
+		return nil, err
+	}
+
+	req := vpc.NewDescribeNatGatewaysRequest()
+	req.Filters = []*vpc.Filter{{Name: converter.ValToPtr("nat-gateway-id"), Values: cloudIDs}}
+	req.Limit = converter.ValToPtr(uint64(core.TCloudQueryLimit))
+
+	resp, err := vpcClient.DescribeNatGatewaysWithContext(kt.Ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Response.NatGatewaySet, nil
+}
@@ -0,0 +1,83 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"hcm/pkg/adaptor/operatortag"
+	"hcm/pkg/api/core"
+	"hcm/pkg/kit"
+	cvt "hcm/pkg/tools/converter"
+
+	cbs "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cbs/v20170312"
+	clb "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/clb/v20180317"
+	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
+)
+
+// cvmTagResourceType 创建实例时为实例添加标签的资源类型
+const cvmTagResourceType = "instance"
+
+// vpcTags 返回用户指定的标签与请求的操作人标签合并后的vpc标签
+func vpcTags(kt *kit.Kit, tags []core.TagPair) []*vpc.Tag {
+	merged := operatortag.Merge(kt, tags)
+	result := make([]*vpc.Tag, 0, len(merged))
+	for _, tag := range merged {
+		result = append(result, &vpc.Tag{Key: cvt.ValToPtr(tag.Key), Value: cvt.ValToPtr(tag.Value)})
+	}
+
+	return result
+}
+
+// cbsTags 返回请求的操作人标签对应的云硬盘标签
+func cbsTags(kt *kit.Kit) []*cbs.Tag {
+	pairs := operatortag.Pairs(kt)
+	result := make([]*cbs.Tag, 0, len(pairs))
+	for _, tag := range pairs {
+		result = append(result, &cbs.Tag{Key: cvt.ValToPtr(tag.Key), Value: cvt.ValToPtr(tag.Value)})
+	}
+
+	return result
+}
+
+// clbTags 返回用户指定的标签与请求的操作人标签合并后的负载均衡标签
+func clbTags(kt *kit.Kit, tags []core.TagPair) []*clb.TagInfo {
+	merged := operatortag.Merge(kt, tags)
+	result := make([]*clb.TagInfo, 0, len(merged))
+	for _, tag := range merged {
+		result = append(result, &clb.TagInfo{TagKey: cvt.ValToPtr(tag.Key), TagValue: cvt.ValToPtr(tag.Value)})
+	}
+
+	return result
+}
+
+// cvmTagSpecification 返回请求的操作人标签对应的实例标签，未开启操作人标签时返回nil
+func cvmTagSpecification(kt *kit.Kit) []*cvm.TagSpecification {
+	pairs := operatortag.Pairs(kt)
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	tags := make([]*cvm.Tag, 0, len(pairs))
+	for _, tag := range pairs {
+		tags = append(tags, &cvm.Tag{Key: cvt.ValToPtr(tag.Key), Value: cvt.ValToPtr(tag.Value)})
+	}
+
+	return []*cvm.TagSpecification{{ResourceType: cvt.ValToPtr(cvmTagResourceType), Tags: tags}}
+}
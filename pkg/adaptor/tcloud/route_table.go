@@ -32,6 +32,67 @@ import (
 	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
 )
 
+// CreateRouteTable create route table and add routes to it, the route table is deleted if routes add failed.
+// reference: https://cloud.tencent.com/document/api/215/15768
+// reference: https://cloud.tencent.com/document/api/215/15773
+func (t *TCloudImpl) CreateRouteTable(kt *kit.Kit, opt *routetable.TCloudRouteTableCreateOption) (
+	*routetable.TCloudRouteTable, error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	vpcClient, err := t.clientSet.VpcClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new route table client failed, err: %v", err)
+	}
+
+	req := vpc.NewCreateRouteTableRequest()
+	req.VpcId = converter.ValToPtr(opt.CloudVpcID)
+	req.RouteTableName = converter.ValToPtr(opt.Name)
+
+	resp, err := vpcClient.CreateRouteTableWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create tencent cloud route table failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+	cloudID := converter.PtrToVal(resp.Response.RouteTable.RouteTableId)
+
+	if len(opt.Routes) == 0 {
+		return convertRouteTable(resp.Response.RouteTable, opt.Region), nil
+	}
+
+	routeReq := vpc.NewCreateRoutesRequest()
+	routeReq.RouteTableId = converter.ValToPtr(cloudID)
+	for _, one := range opt.Routes {
+		routeReq.Routes = append(routeReq.Routes, &vpc.Route{
+			DestinationCidrBlock: converter.ValToPtr(one.DestinationCidrBlock),
+			GatewayType:          converter.ValToPtr(one.GatewayType),
+			GatewayId:            converter.ValToPtr(one.CloudGatewayID),
+			RouteDescription:     one.Memo,
+		})
+	}
+
+	routeResp, err := vpcClient.CreateRoutesWithContext(kt.Ctx, routeReq)
+	if err != nil {
+		logs.Errorf("create tencent cloud routes failed, err: %v, route table: %s, rid: %s", err, cloudID, kt.Rid)
+
+		// 路由添加失败时删除已创建的路由表，避免残留
+		delOpt := &core.BaseRegionalDeleteOption{BaseDeleteOption: core.BaseDeleteOption{ResourceID: cloudID},
+			Region: opt.Region}
+		if delErr := t.DeleteRouteTable(kt, delOpt); delErr != nil {
+			logs.Errorf("delete route table %s after routes create failed, err: %v, rid: %s", cloudID, delErr, kt.Rid)
+		}
+		return nil, err
+	}
+
+	if len(routeResp.Response.RouteTableSet) == 0 {
+		return nil, fmt.Errorf("route table %s not found after routes created", cloudID)
+	}
+
+	return convertRouteTable(routeResp.Response.RouteTableSet[0], opt.Region), nil
+}
+
 // UpdateRouteTable update route table.
 // TODO right now only memo is supported to update, add other update operations later.
 func (t *TCloudImpl) UpdateRouteTable(_ *kit.Kit, _ *routetable.TCloudRouteTableUpdateOption) error {
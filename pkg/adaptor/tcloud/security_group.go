@@ -57,13 +57,7 @@ func (t *TCloudImpl) CreateSecurityGroup(kt *kit.Kit, opt *securitygroup.TCloudC
 	req := vpc.NewCreateSecurityGroupRequest()
 	req.GroupName = common.StringPtr(opt.Name)
 	req.GroupDescription = opt.Description
-	for _, tag := range opt.Tags {
-		vpcTag := &vpc.Tag{
-			Key:   cvt.ValToPtr(tag.Key),
-			Value: cvt.ValToPtr(tag.Value),
-		}
-		req.Tags = append(req.Tags, vpcTag)
-	}
+	req.Tags = vpcTags(kt, opt.Tags)
 	resp, err := vpcCli.CreateSecurityGroupWithContext(kt.Ctx, req)
 	if err != nil {
 		logs.Errorf("create tcloud security group failed, err: %v, rid: %s", err, kt.Rid)
@@ -54,6 +54,7 @@ func (t *TCloudImpl) CreateSubnet(kt *kit.Kit, opt *adtysubnet.TCloudSubnetCreat
 	req.SubnetName = common.StringPtr(opt.Name)
 	req.CidrBlock = common.StringPtr(opt.Extension.IPv4Cidr)
 	req.Zone = common.StringPtr(opt.Extension.Zone)
+	req.Tags = vpcTags(kt, nil)
 
 	resp, err := subnetClient.CreateSubnetWithContext(kt.Ctx, req)
 	if err != nil {
@@ -91,6 +92,7 @@ func (t *TCloudImpl) CreateSubnets(kt *kit.Kit, opt *adtysubnet.TCloudSubnetsCre
 		}
 		req.Subnets = append(req.Subnets, one)
 	}
+	req.Tags = vpcTags(kt, nil)
 
 	resp, err := subnetClient.CreateSubnetsWithContext(kt.Ctx, req)
 	if err != nil {
@@ -52,6 +52,7 @@ func (t *TCloudImpl) CreateVpc(kt *kit.Kit, opt *types.TCloudVpcCreateOption) (*
 	req := vpc.NewCreateVpcRequest()
 	req.VpcName = converter.ValToPtr(opt.Name)
 	req.CidrBlock = converter.ValToPtr(opt.Extension.IPv4Cidr)
+	req.Tags = vpcTags(kt, nil)
 
 	resp, err := vpcClient.CreateVpcWithContext(kt.Ctx, req)
 	if err != nil {
@@ -0,0 +1,166 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package core
+
+import (
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+
+	"golang.org/x/time/rate"
+)
+
+// PageFunc 查询一页云上资源，token为上一页返回的翻页标识，查询第一页时为零值。返回本页的资源、下一页的翻页标识，
+// 以及是否还有下一页
+type PageFunc[T any, P any] func(kt *kit.Kit, token P) (items []T, next P, more bool, err error)
+
+// IteratorOption defines the options of the page iterator.
+type IteratorOption struct {
+	// MaxItems 最多返回的资源数量，为0时不限制
+	MaxItems uint
+	// PagesPerSec 每秒最多查询的页数，用于避免触发云上接口的限频，为0时不限制
+	PagesPerSec float64
+}
+
+// Iterator 云上资源的分页迭代器，封装各云厂商不同的翻页方式(offset、next token、page token等)，调用方只需要
+// 循环调用 Next 或使用 ForEach 遍历。Iterator 不是并发安全的。
+type Iterator[T any, P any] struct {
+	fetch    PageFunc[T, P]
+	maxItems uint
+	limiter  *rate.Limiter
+
+	token P
+	count uint
+	done  bool
+}
+
+// NewIterator create a page iterator, opt is optional.
+func NewIterator[T any, P any](fetch PageFunc[T, P], opt *IteratorOption) *Iterator[T, P] {
+	it := &Iterator[T, P]{fetch: fetch}
+	if opt == nil {
+		return it
+	}
+
+	it.maxItems = opt.MaxItems
+	if opt.PagesPerSec > 0 {
+		it.limiter = rate.NewLimiter(rate.Limit(opt.PagesPerSec), 1)
+	}
+
+	return it
+}
+
+// Next returns the resources of the next page, empty result means there are no more resources.
+func (it *Iterator[T, P]) Next(kt *kit.Kit) ([]T, error) {
+	if it.done {
+		return nil, nil
+	}
+
+	if it.limiter != nil {
+		if err := it.limiter.Wait(kt.Ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	items, next, more, err := it.fetch(kt, it.token)
+	if err != nil {
+		return nil, err
+	}
+
+	// 云上返回空页时也结束遍历，避免翻页标识异常时一直重复查询
+	if !more || len(items) == 0 {
+		it.done = true
+	}
+	it.token = next
+
+	if it.maxItems > 0 && it.count+uint(len(items)) >= it.maxItems {
+		items = items[:it.maxItems-it.count]
+		it.done = true
+	}
+	it.count += uint(len(items))
+
+	return items, nil
+}
+
+// Done returns whether all the resources are iterated.
+func (it *Iterator[T, P]) Done() bool {
+	return it.done
+}
+
+// ForEach call fn with each of the remaining resources, it stops when fn returns error.
+func (it *Iterator[T, P]) ForEach(kt *kit.Kit, fn func(item T) error) error {
+	for !it.done {
+		items, err := it.Next(kt)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			if err = fn(item); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// NewTCloudIterator create iterator for the tcloud list api which pages by offset, it ends when the page has less
+// resources than the limit.
+func NewTCloudIterator[T any](limit uint64, list func(kt *kit.Kit, page *TCloudPage) ([]T, error),
+	opt *IteratorOption) *Iterator[T, uint64] {
+
+	return NewIterator(func(kt *kit.Kit, offset uint64) ([]T, uint64, bool, error) {
+		items, err := list(kt, &TCloudPage{Offset: offset, Limit: limit})
+		if err != nil {
+			return nil, offset, false, err
+		}
+
+		return items, offset + uint64(len(items)), uint64(len(items)) >= limit, nil
+	}, opt)
+}
+
+// NewAwsIterator create iterator for the aws list api which pages by next token, list returns the resources and
+// the next token of the page.
+func NewAwsIterator[T any](maxResults int64, list func(kt *kit.Kit, page *AwsPage) ([]T, *string, error),
+	opt *IteratorOption) *Iterator[T, *string] {
+
+	return NewIterator(func(kt *kit.Kit, token *string) ([]T, *string, bool, error) {
+		items, next, err := list(kt, &AwsPage{MaxResults: converter.ValToPtr(maxResults), NextToken: token})
+		if err != nil {
+			return nil, token, false, err
+		}
+
+		return items, next, len(converter.PtrToVal(next)) != 0, nil
+	}, opt)
+}
+
+// NewGcpIterator create iterator for the gcp list api which pages by page token, list returns the resources and
+// the next page token of the page.
+func NewGcpIterator[T any](pageSize int64, list func(kt *kit.Kit, page *GcpPage) ([]T, string, error),
+	opt *IteratorOption) *Iterator[T, string] {
+
+	return NewIterator(func(kt *kit.Kit, token string) ([]T, string, bool, error) {
+		items, next, err := list(kt, &GcpPage{PageSize: pageSize, PageToken: token})
+		if err != nil {
+			return nil, token, false, err
+		}
+
+		return items, next, len(next) != 0, nil
+	}, opt)
+}
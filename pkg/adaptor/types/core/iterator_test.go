@@ -0,0 +1,125 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package core
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCloud returns the ids of the resources on cloud.
+func fakeCloud(total int) []string {
+	ids := make([]string, 0, total)
+	for i := 0; i < total; i++ {
+		ids = append(ids, strconv.Itoa(i))
+	}
+	return ids
+}
+
+func TestTCloudIterator(t *testing.T) {
+	ids := fakeCloud(25)
+	calls := 0
+	it := NewTCloudIterator(10, func(kt *kit.Kit, page *TCloudPage) ([]string, error) {
+		calls++
+		end := min(int(page.Offset+page.Limit), len(ids))
+		return ids[page.Offset:end], nil
+	}, nil)
+
+	got := make([]string, 0)
+	err := it.ForEach(kit.New(), func(item string) error {
+		got = append(got, item)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ids, got)
+	assert.Equal(t, 3, calls)
+	assert.True(t, it.Done())
+
+	items, err := it.Next(kit.New())
+	require.NoError(t, err)
+	assert.Empty(t, items)
+	assert.Equal(t, 3, calls)
+}
+
+func TestAwsIterator(t *testing.T) {
+	ids := fakeCloud(12)
+	list := func(kt *kit.Kit, page *AwsPage) ([]string, *string, error) {
+		start, _ := strconv.Atoi(converter.PtrToVal(page.NextToken))
+		end := min(start+int(*page.MaxResults), len(ids))
+		if end == len(ids) {
+			return ids[start:end], nil, nil
+		}
+		return ids[start:end], converter.ValToPtr(strconv.Itoa(end)), nil
+	}
+
+	// max items stops the iteration in the middle of a page
+	it := NewAwsIterator(5, list, &IteratorOption{MaxItems: 7})
+	first, err := it.Next(kit.New())
+	require.NoError(t, err)
+	assert.Equal(t, ids[:5], first)
+	assert.False(t, it.Done())
+
+	second, err := it.Next(kit.New())
+	require.NoError(t, err)
+	assert.Equal(t, ids[5:7], second)
+	assert.True(t, it.Done())
+}
+
+func TestGcpIteratorError(t *testing.T) {
+	calls := 0
+	it := NewGcpIterator(10, func(kt *kit.Kit, page *GcpPage) ([]string, string, error) {
+		calls++
+		if calls == 2 {
+			return nil, "", errors.New("cloud error")
+		}
+		return []string{"a"}, "token", nil
+	}, nil)
+
+	err := it.ForEach(kit.New(), func(string) error { return nil })
+	assert.EqualError(t, err, "cloud error")
+	assert.Equal(t, 2, calls)
+}
+
+func TestIteratorRateLimit(t *testing.T) {
+	it := NewGcpIterator(10, func(kt *kit.Kit, page *GcpPage) ([]string, string, error) {
+		return []string{"a"}, "token", nil
+	}, &IteratorOption{PagesPerSec: 1})
+
+	kt := kit.New()
+	var cancel context.CancelFunc
+	kt.Ctx, cancel = context.WithTimeout(kt.Ctx, 100*time.Millisecond)
+	defer cancel()
+
+	_, err := it.Next(kt)
+	require.NoError(t, err)
+
+	// the second page has to wait for a second, which exceeds the deadline
+	_, err = it.Next(kt)
+	assert.Error(t, err)
+}
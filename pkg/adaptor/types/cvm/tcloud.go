@@ -199,6 +199,8 @@ type TCloudCreateOption struct {
 	InternetMaxBandwidthOut int64                        `json:"internet_max_bandwidth_out" validate:"omitempty"`
 	InternetChargeType      TCloudInternetChargeType     `json:"internet_charge_type" validate:"omitempty"`
 	BandwidthPackageID      *string                      `json:"bandwidth_package_id" validate:"omitempty"`
+	// UserData 实例自定义数据，需要进行base64编码
+	UserData *string `json:"user_data" validate:"omitempty"`
 }
 
 // Validate aws cvm operation option.
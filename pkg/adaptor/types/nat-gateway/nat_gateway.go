@@ -0,0 +1,85 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package natgateway defines nat gateway adaptor types.
+package natgateway
+
+import (
+	"hcm/pkg/criteria/validator"
+)
+
+// NatGatewayState defines tencent cloud nat gateway state.
+type NatGatewayState string
+
+const (
+	// TCloudNatPending nat gateway is creating.
+	TCloudNatPending NatGatewayState = "PENDING"
+	// TCloudNatAvailable nat gateway is running.
+	TCloudNatAvailable NatGatewayState = "AVAILABLE"
+	// TCloudNatPendFailure nat gateway create failed.
+	TCloudNatPendFailure NatGatewayState = "PENDFAILURE"
+)
+
+// -------------------------- Create --------------------------
+
+// TCloudNatGatewayCreateOption defines tencent cloud create nat gateway options.
+type TCloudNatGatewayCreateOption struct {
+	Region     string `json:"region" validate:"required"`
+	CloudVpcID string `json:"cloud_vpc_id" validate:"required"`
+	Name       string `json:"name" validate:"required,max=60"`
+	Zone       string `json:"zone" validate:"omitempty"`
+	// InternetMaxBandwidthOut 网关最大外网出带宽(单位:Mbps)，不传使用云上默认值
+	InternetMaxBandwidthOut uint64 `json:"internet_max_bandwidth_out" validate:"omitempty"`
+	// MaxConcurrentConnection 网关并发连接上限，不传使用云上默认值
+	MaxConcurrentConnection uint64 `json:"max_concurrent_connection" validate:"omitempty"`
+	// AddressCount 需要申请的弹性IP个数，系统会按您的要求生产N个弹性IP
+	AddressCount uint64 `json:"address_count" validate:"required,min=1,max=50"`
+}
+
+// Validate TCloudNatGatewayCreateOption.
+func (opt TCloudNatGatewayCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// -------------------------- List --------------------------
+
+// TCloudNatGatewayListResult defines tencent cloud list nat gateway result.
+type TCloudNatGatewayListResult struct {
+	Count   *uint64            `json:"count,omitempty"`
+	Details []TCloudNatGateway `json:"details"`
+}
+
+// TCloudNatGateway defines tencent cloud nat gateway.
+type TCloudNatGateway struct {
+	CloudID    string          `json:"cloud_id"`
+	Name       string          `json:"name"`
+	Region     string          `json:"region"`
+	Zone       string          `json:"zone"`
+	CloudVpcID string          `json:"cloud_vpc_id"`
+	State      NatGatewayState `json:"state"`
+	// CloudEipIDs 网关绑定的弹性IP的云上ID
+	CloudEipIDs       []string `json:"cloud_eip_ids"`
+	PublicIPAddresses []string `json:"public_ip_addresses"`
+	CreatedTime       string   `json:"created_time"`
+}
+
+// GetCloudID ...
+func (nat TCloudNatGateway) GetCloudID() string {
+	return nat.CloudID
+}
@@ -111,3 +111,11 @@ func NewBatchResetCvmPollerOption() *poller.PollUntilDoneOption {
 		Retry:             retry.NewRetryPolicy(10, [2]uint{1000, 5000}),
 	}
 }
+
+// NewDeleteNatGatewayPollerOption 超时时间1分钟，10次之内重试间隔时间1s，10次之后重试间隔时间1-5s之间
+func NewDeleteNatGatewayPollerOption() *poller.PollUntilDoneOption {
+	return &poller.PollUntilDoneOption{
+		TimeoutTimeSecond: 60,
+		Retry:             retry.NewRetryPolicy(10, [2]uint{1000, 5000}),
+	}
+}
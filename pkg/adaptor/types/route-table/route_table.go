@@ -22,8 +22,34 @@ package routetable
 import (
 	"hcm/pkg/adaptor/types/core"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
 )
 
+// -------------------------- Create --------------------------
+
+// TCloudRouteTableCreateOption defines tencent cloud create route table options.
+type TCloudRouteTableCreateOption struct {
+	Region     string `json:"region" validate:"required"`
+	CloudVpcID string `json:"cloud_vpc_id" validate:"required"`
+	Name       string `json:"name" validate:"required,max=60"`
+	// Routes 路由表创建后添加的路由策略
+	Routes []TCloudRouteCreateOption `json:"routes" validate:"omitempty,dive"`
+}
+
+// Validate TCloudRouteTableCreateOption.
+func (r TCloudRouteTableCreateOption) Validate() error {
+	return validator.Validate.Struct(r)
+}
+
+// TCloudRouteCreateOption defines tencent cloud create route options.
+type TCloudRouteCreateOption struct {
+	DestinationCidrBlock string `json:"destination_cidr_block" validate:"required,cidrv4"`
+	// GatewayType 下一跳类型，如 NAT、NORMAL_CVM 等
+	GatewayType    string  `json:"gateway_type" validate:"required"`
+	CloudGatewayID string  `json:"cloud_gateway_id" validate:"required"`
+	Memo           *string `json:"memo" validate:"omitempty"`
+}
+
 // -------------------------- Update --------------------------
 
 // RouteTableUpdateOption defines update route table options.
@@ -0,0 +1,35 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package accountgroup defines account group cloud-server api.
+package accountgroup
+
+// AccountGroupSyncResult sync resources of accounts in account group result.
+type AccountGroupSyncResult struct {
+	// Succeeded 已触发同步的账号ID
+	Succeeded []string `json:"succeeded"`
+	// Failed 触发同步失败的账号，如账号正在同步中
+	Failed []AccountGroupSyncFailure `json:"failed"`
+}
+
+// AccountGroupSyncFailure define the account which is failed to sync.
+type AccountGroupSyncFailure struct {
+	AccountID string `json:"account_id"`
+	Reason    string `json:"reason"`
+}
@@ -36,3 +36,8 @@ type TCloudResCondSyncReq struct {
 func (r *TCloudResCondSyncReq) Validate() error {
 	return validator.Validate.Struct(r)
 }
+
+// AccountSyncResult is the result of account sync.
+type AccountSyncResult struct {
+	FlowID string `json:"flow_id"`
+}
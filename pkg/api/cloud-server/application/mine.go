@@ -0,0 +1,86 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package application
+
+import (
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// MyApplicationListReq list the applications submitted by current user.
+type MyApplicationListReq struct {
+	Statuses []enumor.ApplicationStatus `json:"statuses" validate:"omitempty,max=20"`
+	Types    []enumor.ApplicationType   `json:"types" validate:"omitempty,max=50"`
+	Page     *core.BasePage             `json:"page" validate:"required"`
+}
+
+// Validate ...
+func (req *MyApplicationListReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// MyApplication application with status transitions.
+type MyApplication struct {
+	dataproto.ApplicationResp `json:",inline"`
+	// NextStatuses 单据可以流转到的状态，终结态为空
+	NextStatuses []enumor.ApplicationStatus `json:"next_statuses"`
+	// Cancelable 申请人是否可以撤销单据，审批中、交付失败及部分交付的单据可以撤销
+	Cancelable bool `json:"cancelable"`
+	// Retryable 申请人是否可以从失败的步骤重试交付
+	Retryable bool `json:"retryable"`
+}
+
+// MyApplicationListResult ...
+type MyApplicationListResult struct {
+	Count   uint64          `json:"count"`
+	Details []MyApplication `json:"details"`
+}
+
+// MyApprovalListReq list the itsm tickets of hcm waiting for current user to approve.
+type MyApprovalListReq struct {
+	Page core.PageWithoutSort `json:"page" validate:"required"`
+}
+
+// Validate ...
+func (req *MyApprovalListReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// MyApproval itsm ticket waiting for approval and the hcm application of the ticket.
+type MyApproval struct {
+	SN            string `json:"sn"`
+	Title         string `json:"title"`
+	ServiceName   string `json:"service_name"`
+	CurrentStatus string `json:"current_status"`
+	// StateID 当前审批节点ID，审批单据时使用
+	StateID  int64  `json:"state_id"`
+	Creator  string `json:"creator"`
+	CreateAt string `json:"create_at"`
+	// Application 单据对应的hcm申请单，非hcm发起的单据为空
+	Application *MyApplication `json:"application,omitempty"`
+}
+
+// MyApprovalListResult ...
+type MyApprovalListResult struct {
+	Count   int64        `json:"count"`
+	Details []MyApproval `json:"details"`
+}
@@ -0,0 +1,67 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package application
+
+import "github.com/shopspring/decimal"
+
+// PriceSource 预估费用单价的来源
+type PriceSource string
+
+const (
+	// ConfigPriceSource 单价来自平台配置的价格
+	ConfigPriceSource PriceSource = "config"
+	// CloudPriceSource 单价来自云上机型列表返回的价格
+	CloudPriceSource PriceSource = "cloud"
+)
+
+// PriceItemType 预估费用的计费项
+type PriceItemType string
+
+const (
+	// InstancePriceItem 主机机型
+	InstancePriceItem PriceItemType = "instance"
+	// SystemDiskPriceItem 系统盘，数量为硬盘大小GB
+	SystemDiskPriceItem PriceItemType = "system_disk"
+	// DataDiskPriceItem 数据盘，数量为所有数据盘的总大小GB
+	DataDiskPriceItem PriceItemType = "data_disk"
+	// EipPriceItem 弹性IP
+	EipPriceItem PriceItemType = "eip"
+)
+
+// CvmPriceEstimate estimated monthly cost of a cvm application.
+type CvmPriceEstimate struct {
+	Currency      string `json:"currency"`
+	RequiredCount int64  `json:"required_count"`
+	// Items 单台主机的计费项
+	Items []PriceItem `json:"items"`
+	// UnitMonthly 单台主机每月预估费用
+	UnitMonthly decimal.Decimal `json:"unit_monthly"`
+	// TotalMonthly 所有申请主机每月预估费用
+	TotalMonthly decimal.Decimal `json:"total_monthly"`
+}
+
+// PriceItem estimated monthly cost of one price item of one cvm.
+type PriceItem struct {
+	Type        PriceItemType   `json:"type"`
+	Source      PriceSource     `json:"source"`
+	Quantity    int64           `json:"quantity"`
+	UnitMonthly decimal.Decimal `json:"unit_monthly"`
+	Monthly     decimal.Decimal `json:"monthly"`
+}
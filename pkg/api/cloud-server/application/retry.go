@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package application
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// ApplicationRetryReq retry the delivery of application from the failed step.
+type ApplicationRetryReq struct {
+	// StepID 重试的步骤ID，为空时重试第一个失败的步骤
+	StepID string `json:"step_id" validate:"omitempty"`
+}
+
+// Validate ...
+func (req *ApplicationRetryReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ApplicationStep the delivery step of application, the step status is stored in the async task.
+type ApplicationStep struct {
+	ID         string            `json:"id"`
+	ActionID   string            `json:"action_id"`
+	ActionName enumor.ActionName `json:"action_name"`
+	DependOn   []string          `json:"depend_on"`
+	State      enumor.TaskState  `json:"state"`
+	Reason     string            `json:"reason"`
+	CreatedAt  string            `json:"created_at"`
+	UpdatedAt  string            `json:"updated_at"`
+}
+
+// ApplicationStepListResult ...
+type ApplicationStepListResult struct {
+	FlowID    string            `json:"flow_id"`
+	FlowState enumor.FlowState  `json:"flow_state"`
+	Details   []ApplicationStep `json:"details"`
+}
+
+// ApplicationCancelResult the result of application cancel, the resources created by failed delivery are cleaned
+// up when application is cancelled.
+type ApplicationCancelResult struct {
+	DeletedCloudIDs []string `json:"deleted_cloud_ids,omitempty"`
+}
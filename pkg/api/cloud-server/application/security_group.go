@@ -0,0 +1,47 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package application
+
+import (
+	"fmt"
+
+	cloudserver "hcm/pkg/api/cloud-server"
+	"hcm/pkg/criteria/validator"
+)
+
+// SGRuleCreateReq 新增安全组规则申请单请求，审批通过后在安全组上新增规则
+type SGRuleCreateReq[T cloudserver.SecurityGroupRule] struct {
+	BkBizID                                   int64  `json:"bk_biz_id" validate:"required,min=1"`
+	SecurityGroupID                           string `json:"security_group_id" validate:"required"`
+	cloudserver.SecurityGroupRuleCreateReq[T] `json:",inline"`
+}
+
+// Validate SGRuleCreateReq.
+func (req *SGRuleCreateReq[T]) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := req.SecurityGroupRuleCreateReq.Validate(); err != nil {
+		return fmt.Errorf("invalid security group rule, err: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package application
+
+import (
+	"encoding/json"
+	"testing"
+
+	cloudserver "hcm/pkg/api/cloud-server"
+)
+
+func TestSGRuleCreateReqValidate(t *testing.T) {
+	cases := []struct {
+		name  string
+		body  string
+		valid bool
+	}{
+		{
+			name: "ingress rule",
+			body: `{"bk_biz_id":100,"security_group_id":"00000001","ingress_rule_set":[{"protocol":"TCP",` +
+				`"port":"80","ipv4_cidr":"10.0.0.0/8","action":"ACCEPT"}]}`,
+			valid: true,
+		},
+		{
+			name: "without biz",
+			body: `{"security_group_id":"00000001","ingress_rule_set":[{"protocol":"TCP","port":"80",` +
+				`"ipv4_cidr":"10.0.0.0/8","action":"ACCEPT"}]}`,
+			valid: false,
+		},
+		{
+			name:  "without rule",
+			body:  `{"bk_biz_id":100,"security_group_id":"00000001"}`,
+			valid: false,
+		},
+		{
+			name: "rule without source address",
+			body: `{"bk_biz_id":100,"security_group_id":"00000001","egress_rule_set":[{"protocol":"TCP",` +
+				`"port":"80","action":"ACCEPT"}]}`,
+			valid: false,
+		},
+	}
+
+	for _, c := range cases {
+		req := new(SGRuleCreateReq[cloudserver.TCloudSecurityGroupRule])
+		if err := json.Unmarshal([]byte(c.body), req); err != nil {
+			t.Fatalf("case %s: unmarshal request failed, err: %v", c.name, err)
+		}
+
+		if err := req.Validate(); (err == nil) != c.valid {
+			t.Errorf("case %s: validate result should be %v, err: %v", c.name, c.valid, err)
+		}
+	}
+}
@@ -23,6 +23,8 @@ package assign
 import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
 )
 
 // AssignResourceToBizReq assign cloud resource to biz request.
@@ -53,3 +55,27 @@ func (a AssignResourceToBizReq) Validate() error {
 
 	return nil
 }
+
+// BatchAssignResourceToBizReq batch assign filtered unassigned cloud resource and its dependent resource to biz request.
+type BatchAssignResourceToBizReq struct {
+	BkBizID int64                    `json:"bk_biz_id" validate:"min=1"`
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	Filter  *filter.Expression       `json:"filter" validate:"required"`
+}
+
+// Validate BatchAssignResourceToBizReq.
+func (a BatchAssignResourceToBizReq) Validate() error {
+	return validator.Validate.Struct(a)
+}
+
+// TransferResourceBizReq transfer biz resource and its dependent resource to another biz request.
+type TransferResourceBizReq struct {
+	TargetBkBizID int64                    `json:"target_bk_biz_id" validate:"min=1"`
+	ResType       enumor.CloudResourceType `json:"res_type" validate:"required"`
+	IDs           []string                 `json:"ids" validate:"required,min=1,max=100"`
+}
+
+// Validate TransferResourceBizReq.
+func (a TransferResourceBizReq) Validate() error {
+	return validator.Validate.Struct(a)
+}
@@ -20,7 +20,13 @@
 package cloudserver
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/runtime/filter"
 )
@@ -38,6 +44,146 @@ func (req *AuditListReq) Validate() error {
 	return validator.Validate.Struct(req)
 }
 
+// -------------------------- Query --------------------------
+
+// AuditQueryCondition define audit compound query condition, conditions are combined with and,
+// values in the same condition are combined with or.
+type AuditQueryCondition struct {
+	Operators  []string                   `json:"operators" validate:"omitempty,max=100"`
+	ResTypes   []enumor.AuditResourceType `json:"res_types" validate:"omitempty,max=100"`
+	Actions    []enumor.AuditAction       `json:"actions" validate:"omitempty,max=100"`
+	BkBizIDs   []int64                    `json:"bk_biz_ids" validate:"omitempty,max=100"`
+	AccountIDs []string                   `json:"account_ids" validate:"omitempty,max=100"`
+	ResIDs     []string                   `json:"res_ids" validate:"omitempty,max=100"`
+	// StartTime 操作时间的起始时间(包含)，格式为 RFC3339
+	StartTime string `json:"start_time"`
+	// EndTime 操作时间的结束时间(包含)，格式为 RFC3339
+	EndTime string `json:"end_time"`
+}
+
+// Validate audit query condition.
+func (c *AuditQueryCondition) Validate() error {
+	if err := validator.Validate.Struct(c); err != nil {
+		return err
+	}
+
+	var start, end time.Time
+	var err error
+	if len(c.StartTime) != 0 {
+		if start, err = time.Parse(constant.TimeStdFormat, c.StartTime); err != nil {
+			return fmt.Errorf("start_time should be like %s", constant.TimeStdFormat)
+		}
+	}
+
+	if len(c.EndTime) != 0 {
+		if end, err = time.Parse(constant.TimeStdFormat, c.EndTime); err != nil {
+			return fmt.Errorf("end_time should be like %s", constant.TimeStdFormat)
+		}
+	}
+
+	if !start.IsZero() && !end.IsZero() && start.After(end) {
+		return errors.New("start_time should not be after end_time")
+	}
+
+	return nil
+}
+
+// Expression convert query condition to filter expression.
+func (c *AuditQueryCondition) Expression() *filter.Expression {
+	rules := make([]filter.RuleFactory, 0)
+	if len(c.Operators) != 0 {
+		rules = append(rules, &filter.AtomRule{Field: "operator", Op: filter.In.Factory(), Value: c.Operators})
+	}
+	if len(c.ResTypes) != 0 {
+		rules = append(rules, &filter.AtomRule{Field: "res_type", Op: filter.In.Factory(), Value: c.ResTypes})
+	}
+	if len(c.Actions) != 0 {
+		rules = append(rules, &filter.AtomRule{Field: "action", Op: filter.In.Factory(), Value: c.Actions})
+	}
+	if len(c.BkBizIDs) != 0 {
+		rules = append(rules, &filter.AtomRule{Field: "bk_biz_id", Op: filter.In.Factory(), Value: c.BkBizIDs})
+	}
+	if len(c.AccountIDs) != 0 {
+		rules = append(rules, &filter.AtomRule{Field: "account_id", Op: filter.In.Factory(), Value: c.AccountIDs})
+	}
+	if len(c.ResIDs) != 0 {
+		rules = append(rules, &filter.AtomRule{Field: "res_id", Op: filter.In.Factory(), Value: c.ResIDs})
+	}
+	if len(c.StartTime) != 0 {
+		rules = append(rules, &filter.AtomRule{Field: "created_at", Op: filter.GreaterThanEqual.Factory(),
+			Value: c.StartTime})
+	}
+	if len(c.EndTime) != 0 {
+		rules = append(rules, &filter.AtomRule{Field: "created_at", Op: filter.LessThanEqual.Factory(), Value: c.EndTime})
+	}
+
+	return &filter.Expression{Op: filter.And, Rules: rules}
+}
+
+// AuditQueryReq define audit query req, the audits are returned in descending order of id,
+// use next_cursor of the last result as cursor to query the next page.
+type AuditQueryReq struct {
+	AuditQueryCondition
+	// Cursor 游标，返回 id 小于游标的审计记录，为0时从最新的记录开始查询
+	Cursor uint64 `json:"cursor"`
+	// Limit 每页条数，默认100，最大500
+	Limit uint `json:"limit" validate:"omitempty,max=500"`
+}
+
+// Validate audit query req.
+func (req *AuditQueryReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.AuditQueryCondition.Validate()
+}
+
+// AuditQueryResult define audit query result.
+type AuditQueryResult[T any] struct {
+	Details    []T    `json:"details"`
+	NextCursor uint64 `json:"next_cursor"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// -------------------------- Export --------------------------
+
+// AuditExportReq define audit export req.
+type AuditExportReq struct {
+	AuditQueryCondition
+}
+
+// Validate audit export req.
+func (req *AuditExportReq) Validate() error {
+	return req.AuditQueryCondition.Validate()
+}
+
+// AuditExportFile define audit export csv file, the file is deleted after download.
+type AuditExportFile struct {
+	FileName string
+	FilePath string
+}
+
+// ContentType ...
+func (f *AuditExportFile) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+// ContentDisposition ...
+func (f *AuditExportFile) ContentDisposition() string {
+	return fmt.Sprintf(`attachment; filename="%s"`, f.FileName)
+}
+
+// Filepath return file path.
+func (f *AuditExportFile) Filepath() string {
+	return f.FilePath
+}
+
+// IsDeleteFile is true, file will be deleted after download.
+func (f *AuditExportFile) IsDeleteFile() bool {
+	return true
+}
+
 // -------------------------- List Audit Async Flow --------------------------
 
 // AuditAsyncFlowListReq define audit async flow list req.
@@ -0,0 +1,84 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloudserver
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/runtime/filter"
+)
+
+func TestAuditQueryConditionValidate(t *testing.T) {
+	cond := &AuditQueryCondition{StartTime: "2024-03-01T00:00:00+08:00", EndTime: "2024-03-31T23:59:59+08:00"}
+	if err := cond.Validate(); err != nil {
+		t.Fatalf("validate audit query condition failed, err: %v", err)
+	}
+
+	cases := map[string]*AuditQueryCondition{
+		"invalid start time":        {StartTime: "2024-03-01 00:00:00"},
+		"invalid end time":          {EndTime: "2024/03/31"},
+		"start time after end time": {StartTime: "2024-04-01T00:00:00Z", EndTime: "2024-03-01T00:00:00Z"},
+	}
+	for name, cond := range cases {
+		if err := cond.Validate(); err == nil {
+			t.Errorf("%s: expect validate failed, but not", name)
+		}
+	}
+}
+
+func TestAuditQueryConditionExpression(t *testing.T) {
+	cond := &AuditQueryCondition{
+		Operators: []string{"admin"},
+		Actions:   []enumor.AuditAction{enumor.Delete},
+		BkBizIDs:  []int64{2},
+		StartTime: "2024-03-01T00:00:00Z",
+		EndTime:   "2024-03-31T00:00:00Z",
+	}
+
+	expr := cond.Expression()
+	if expr.Op != filter.And {
+		t.Fatalf("conditions should be combined with and, but got %s", expr.Op)
+	}
+
+	expects := []struct {
+		field string
+		op    filter.OpType
+	}{
+		{field: "operator", op: filter.In},
+		{field: "action", op: filter.In},
+		{field: "bk_biz_id", op: filter.In},
+		{field: "created_at", op: filter.GreaterThanEqual},
+		{field: "created_at", op: filter.LessThanEqual},
+	}
+	if len(expr.Rules) != len(expects) {
+		t.Fatalf("expect %d rules, but got %d", len(expects), len(expr.Rules))
+	}
+	for idx, rule := range expr.Rules {
+		atom, ok := rule.(*filter.AtomRule)
+		if !ok || atom.Field != expects[idx].field || atom.Op != expects[idx].op.Factory() {
+			t.Errorf("rule %d should be %s %s, but got %+v", idx, expects[idx].field, expects[idx].op, rule)
+		}
+	}
+
+	if len((&AuditQueryCondition{}).Expression().Rules) != 0 {
+		t.Errorf("empty condition should have no rules")
+	}
+}
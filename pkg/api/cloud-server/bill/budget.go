@@ -0,0 +1,59 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+
+	"github.com/shopspring/decimal"
+)
+
+// BudgetCreateReq create bill budget request
+type BudgetCreateReq = dsbill.BudgetCreateReq
+
+// BudgetUpdateReq update bill budget request, budget scope can not be updated.
+type BudgetUpdateReq struct {
+	Name        string              `json:"name" validate:"omitempty,max=255"`
+	Currency    enumor.CurrencyCode `json:"currency" validate:"omitempty"`
+	Amount      *decimal.Decimal    `json:"amount" validate:"omitempty"`
+	Thresholds  []int64             `json:"thresholds" validate:"omitempty,max=10,dive,min=1,max=1000"`
+	NoticeUsers []string            `json:"notice_users" validate:"omitempty,max=20"`
+	Memo        *string             `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate ...
+func (r *BudgetUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+	if r.Amount != nil && !r.Amount.IsPositive() {
+		return errors.New("amount should be positive")
+	}
+	return nil
+}
+
+// AlertRelated 是否修改了影响预算告警的字段，修改后需要重新评估告警
+func (r *BudgetUpdateReq) AlertRelated() bool {
+	return len(r.Currency) != 0 || r.Amount != nil || len(r.Thresholds) != 0
+}
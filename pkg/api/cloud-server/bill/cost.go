@@ -0,0 +1,148 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+
+	"github.com/shopspring/decimal"
+)
+
+// BillCostCondition 账单费用查询条件，为空表示不限制
+type BillCostCondition struct {
+	Vendors        []enumor.Vendor `json:"vendors" validate:"omitempty,max=10"`
+	BkBizIDs       []int64         `json:"bk_biz_ids" validate:"omitempty,max=500"`
+	MainAccountIDs []string        `json:"main_account_ids" validate:"omitempty,max=500"`
+	HcProductCodes []string        `json:"hc_product_codes" validate:"omitempty,max=100"`
+	// AccountGroupID 账号组ID，指定时仅统计该账号组及其子账号组下二级账号的费用
+	AccountGroupID string `json:"account_group_id" validate:"omitempty,max=64"`
+}
+
+// Validate ...
+func (c *BillCostCondition) Validate() error {
+	for _, vendor := range c.Vendors {
+		if err := vendor.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BillCostSummaryReq 账单费用汇总请求，汇总指定月份的费用并与上月对比
+type BillCostSummaryReq struct {
+	BillYear           int                        `json:"bill_year" validate:"required,min=2000"`
+	BillMonth          int                        `json:"bill_month" validate:"required,min=1,max=12"`
+	GroupBy            []enumor.BillCostDimension `json:"group_by" validate:"required,min=1,max=3"`
+	*BillCostCondition `json:",inline" validate:"omitempty"`
+}
+
+// Validate ...
+func (r *BillCostSummaryReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+	if err := validateCostDimensions(r.GroupBy); err != nil {
+		return err
+	}
+	if r.BillCostCondition == nil {
+		return nil
+	}
+	return r.BillCostCondition.Validate()
+}
+
+// BillCostSummary 账单费用汇总结果，未参与汇总的维度字段为空
+type BillCostSummary struct {
+	BkBizID       int64               `json:"bk_biz_id,omitempty"`
+	MainAccountID string              `json:"main_account_id,omitempty"`
+	Vendor        enumor.Vendor       `json:"vendor,omitempty"`
+	Region        string              `json:"region,omitempty"`
+	HcProductCode string              `json:"hc_product_code,omitempty"`
+	Currency      enumor.CurrencyCode `json:"currency"`
+	// Cost 当月费用
+	Cost decimal.Decimal `json:"cost"`
+	// LastMonthCost 上月费用
+	LastMonthCost decimal.Decimal `json:"last_month_cost"`
+	// MonthOnMonthValue 环比，上月费用为0时为空
+	MonthOnMonthValue *float64 `json:"month_on_month_value"`
+}
+
+// BillCostSummaryResult ...
+type BillCostSummaryResult struct {
+	Details []BillCostSummary `json:"details"`
+}
+
+// BillCostTrendReq 账单费用趋势请求，返回截止指定月份的近几个月费用
+type BillCostTrendReq struct {
+	BillYear  int `json:"bill_year" validate:"required,min=2000"`
+	BillMonth int `json:"bill_month" validate:"required,min=1,max=12"`
+	// Months 统计月份数，包含截止月份
+	Months             int                        `json:"months" validate:"required,min=1,max=12"`
+	GroupBy            []enumor.BillCostDimension `json:"group_by" validate:"omitempty,max=2"`
+	*BillCostCondition `json:",inline" validate:"omitempty"`
+}
+
+// Validate ...
+func (r *BillCostTrendReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+	if err := validateCostDimensions(r.GroupBy); err != nil {
+		return err
+	}
+	if r.BillCostCondition == nil {
+		return nil
+	}
+	return r.BillCostCondition.Validate()
+}
+
+// BillCostTrend 单月账单费用，未参与汇总的维度字段为空
+type BillCostTrend struct {
+	BillYear      int                 `json:"bill_year"`
+	BillMonth     int                 `json:"bill_month"`
+	BkBizID       int64               `json:"bk_biz_id,omitempty"`
+	MainAccountID string              `json:"main_account_id,omitempty"`
+	Vendor        enumor.Vendor       `json:"vendor,omitempty"`
+	Region        string              `json:"region,omitempty"`
+	HcProductCode string              `json:"hc_product_code,omitempty"`
+	Currency      enumor.CurrencyCode `json:"currency"`
+	Cost          decimal.Decimal     `json:"cost"`
+}
+
+// BillCostTrendResult ...
+type BillCostTrendResult struct {
+	Details []BillCostTrend `json:"details"`
+}
+
+func validateCostDimensions(dims []enumor.BillCostDimension) error {
+	exists := make(map[enumor.BillCostDimension]struct{}, len(dims))
+	for _, dim := range dims {
+		if err := dim.Validate(); err != nil {
+			return err
+		}
+		if _, ok := exists[dim]; ok {
+			return errors.New("duplicate bill cost dimension: " + string(dim))
+		}
+		exists[dim] = struct{}{}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestBillCostSummaryReqValidate(t *testing.T) {
+	req := &BillCostSummaryReq{
+		BillYear:  2024,
+		BillMonth: 3,
+		GroupBy:   []enumor.BillCostDimension{enumor.BillCostDimBiz, enumor.BillCostDimVendor},
+	}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("validate summary req failed, err: %v", err)
+	}
+
+	cases := map[string]*BillCostSummaryReq{
+		"missing group by": {BillYear: 2024, BillMonth: 3},
+		"invalid month": {BillYear: 2024, BillMonth: 13,
+			GroupBy: []enumor.BillCostDimension{enumor.BillCostDimBiz}},
+		"unsupported dimension": {BillYear: 2024, BillMonth: 3,
+			GroupBy: []enumor.BillCostDimension{"zone"}},
+		"duplicate dimension": {BillYear: 2024, BillMonth: 3,
+			GroupBy: []enumor.BillCostDimension{enumor.BillCostDimRegion, enumor.BillCostDimRegion}},
+		"invalid vendor": {BillYear: 2024, BillMonth: 3,
+			GroupBy:           []enumor.BillCostDimension{enumor.BillCostDimBiz},
+			BillCostCondition: &BillCostCondition{Vendors: []enumor.Vendor{"unknown"}}},
+	}
+	for name, req := range cases {
+		if err := req.Validate(); err == nil {
+			t.Errorf("%s: expect validate failed, but not", name)
+		}
+	}
+}
+
+func TestBillCostTrendReqValidate(t *testing.T) {
+	req := &BillCostTrendReq{BillYear: 2024, BillMonth: 3, Months: 6}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("trend req without group by should be valid, err: %v", err)
+	}
+
+	req.Months = 13
+	if err := req.Validate(); err == nil {
+		t.Errorf("trend req of more than 12 months should be invalid")
+	}
+
+	req.Months = 6
+	req.GroupBy = []enumor.BillCostDimension{enumor.BillCostDimBiz, enumor.BillCostDimVendor,
+		enumor.BillCostDimRegion}
+	if err := req.Validate(); err == nil {
+		t.Errorf("trend req grouped by more than 2 dimensions should be invalid")
+	}
+}
@@ -0,0 +1,62 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// ReconcileSupportedVendors 支持账单对账的云厂商，其余云厂商的账单明细中不包含资源ID，无法与HCM资源进行比对
+var ReconcileSupportedVendors = []enumor.Vendor{enumor.Aws, enumor.HuaWei, enumor.Gcp}
+
+// BillReconcileReq run bill reconciliation request
+type BillReconcileReq struct {
+	BillYear       int             `json:"bill_year" validate:"required,min=2000"`
+	BillMonth      int             `json:"bill_month" validate:"required,min=1,max=12"`
+	Vendors        []enumor.Vendor `json:"vendors" validate:"omitempty,max=3"`
+	MainAccountIDs []string        `json:"main_account_ids" validate:"omitempty,max=100"`
+}
+
+// Validate ...
+func (r *BillReconcileReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	for _, vendor := range r.Vendors {
+		if !IsReconcileSupported(vendor) {
+			return fmt.Errorf("vendor %s does not support bill reconciliation", vendor)
+		}
+	}
+	return nil
+}
+
+// IsReconcileSupported 判断云厂商是否支持账单对账
+func IsReconcileSupported(vendor enumor.Vendor) bool {
+	for _, one := range ReconcileSupportedVendors {
+		if one == vendor {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestBillReconcileReqValidate(t *testing.T) {
+	cases := []struct {
+		name  string
+		req   BillReconcileReq
+		valid bool
+	}{
+		{name: "all vendors", req: BillReconcileReq{BillYear: 2024, BillMonth: 3}, valid: true},
+		{name: "supported vendor", req: BillReconcileReq{BillYear: 2024, BillMonth: 3,
+			Vendors: []enumor.Vendor{enumor.Aws, enumor.Gcp}}, valid: true},
+		{name: "unsupported vendor", req: BillReconcileReq{BillYear: 2024, BillMonth: 3,
+			Vendors: []enumor.Vendor{enumor.TCloud}}},
+		{name: "invalid month", req: BillReconcileReq{BillYear: 2024, BillMonth: 13}},
+	}
+
+	for _, c := range cases {
+		if err := c.req.Validate(); (err == nil) != c.valid {
+			t.Errorf("%s: expect valid %v, but got err: %v", c.name, c.valid, err)
+		}
+	}
+}
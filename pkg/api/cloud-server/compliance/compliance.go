@@ -0,0 +1,49 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package compliance defines security compliance cloud-server api.
+package compliance
+
+import (
+	"errors"
+	"time"
+
+	"hcm/pkg/criteria/validator"
+)
+
+// ExemptionCreateReq create compliance exemption request, the rule and resource of the finding is exempted.
+type ExemptionCreateReq struct {
+	FindingID string `json:"finding_id" validate:"required"`
+	Reason    string `json:"reason" validate:"required,lte=255"`
+	// ExpiredAt 豁免过期时间，为空时永久有效
+	ExpiredAt *time.Time `json:"expired_at" validate:"omitempty"`
+}
+
+// Validate ExemptionCreateReq.
+func (req *ExemptionCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.ExpiredAt != nil && req.ExpiredAt.Before(time.Now()) {
+		return errors.New("expired_at should be later than now")
+	}
+
+	return nil
+}
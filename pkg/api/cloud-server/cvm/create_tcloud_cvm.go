@@ -81,6 +81,8 @@ type TCloudCvmCreateReq struct {
 	BandwidthPackageID *string                          `json:"bandwidth_package_id" validate:"omitempty"`
 
 	Memo *string `json:"memo" validate:"omitempty"`
+	// UserData 实例自定义数据，需要进行base64编码
+	UserData *string `json:"user_data" validate:"omitempty,max=16384"`
 }
 
 // Validate ...
@@ -0,0 +1,120 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cscvm
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// CvmOnboardingRow define one row of cvm onboarding import excel.
+type CvmOnboardingRow struct {
+	// RowNum excel 中的行号，从1开始
+	RowNum int `json:"row_num"`
+	// Identifier 主机的云ID、内网IPv4或公网IPv4
+	Identifier string            `json:"identifier"`
+	BkBizID    int64             `json:"bk_biz_id"`
+	Owner      string            `json:"owner"`
+	Labels     map[string]string `json:"labels"`
+}
+
+// CvmOnboardingPreviewItem define cvm onboarding import preview item.
+type CvmOnboardingPreviewItem struct {
+	CvmOnboardingRow `json:",inline"`
+	CvmID            string                     `json:"cvm_id"`
+	CloudID          string                     `json:"cloud_id"`
+	CurBkBizID       int64                      `json:"cur_bk_biz_id"`
+	Status           enumor.CvmOnboardingStatus `json:"status"`
+	Reason           string                     `json:"reason"`
+}
+
+// CvmOnboardingPreviewResult define cvm onboarding import preview result.
+type CvmOnboardingPreviewResult struct {
+	Details []CvmOnboardingPreviewItem `json:"details"`
+}
+
+// CvmOnboardingSubmitReq define cvm onboarding import submit req, details are the matched items of preview.
+type CvmOnboardingSubmitReq struct {
+	Details []CvmOnboardingItem `json:"details" validate:"required,min=1,dive"`
+}
+
+// CvmOnboardingItem define cvm onboarding item.
+type CvmOnboardingItem struct {
+	CvmID   string            `json:"cvm_id" validate:"required"`
+	BkBizID int64             `json:"bk_biz_id" validate:"required,min=1"`
+	Owner   string            `json:"owner" validate:"max=64"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// Validate cvm onboarding submit req.
+func (req *CvmOnboardingSubmitReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Details) > constant.ExcelImportRowLimit {
+		return fmt.Errorf("details count should <= %d", constant.ExcelImportRowLimit)
+	}
+
+	exists := make(map[string]struct{}, len(req.Details))
+	for _, one := range req.Details {
+		if _, ok := exists[one.CvmID]; ok {
+			return fmt.Errorf("cvm: %s is duplicated", one.CvmID)
+		}
+		exists[one.CvmID] = struct{}{}
+
+		if err := ValidateLabels(one.Labels); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CvmOnboardingSubmitResult define cvm onboarding import submit result.
+type CvmOnboardingSubmitResult struct {
+	SucceededIDs []string                  `json:"succeeded_ids"`
+	Failed       []CvmOnboardingFailedItem `json:"failed"`
+}
+
+// CvmOnboardingFailedItem define cvm onboarding failed item.
+type CvmOnboardingFailedItem struct {
+	CvmIDs []string `json:"cvm_ids"`
+	Reason string   `json:"reason"`
+}
+
+// ValidateLabels validate cvm labels set by onboarding.
+func ValidateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if len(key) == 0 {
+			return errors.New("label key can not be empty")
+		}
+
+		if len(key) > 128 || len(value) > 256 {
+			return fmt.Errorf("label %s=%s is too long", key, value)
+		}
+	}
+
+	return nil
+}
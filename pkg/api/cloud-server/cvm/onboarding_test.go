@@ -0,0 +1,55 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cscvm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCvmOnboardingSubmitReqValidate(t *testing.T) {
+	req := &CvmOnboardingSubmitReq{Details: []CvmOnboardingItem{
+		{CvmID: "cvm-1", BkBizID: 100, Owner: "alice", Labels: map[string]string{"env": "prod"}},
+		{CvmID: "cvm-2", BkBizID: 100},
+	}}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("validate cvm onboarding submit req failed, err: %v", err)
+	}
+
+	req.Details[1].CvmID = "cvm-1"
+	if err := req.Validate(); err == nil {
+		t.Errorf("duplicated cvm should be invalid")
+	}
+
+	req.Details[1] = CvmOnboardingItem{CvmID: "cvm-2", BkBizID: 100, Labels: map[string]string{"": "prod"}}
+	if err := req.Validate(); err == nil {
+		t.Errorf("label with empty key should be invalid")
+	}
+
+	req.Details[1].Labels = map[string]string{"env": strings.Repeat("a", 257)}
+	if err := req.Validate(); err == nil {
+		t.Errorf("too long label value should be invalid")
+	}
+
+	req.Details[1] = CvmOnboardingItem{CvmID: "cvm-2"}
+	if err := req.Validate(); err == nil {
+		t.Errorf("biz id is required")
+	}
+}
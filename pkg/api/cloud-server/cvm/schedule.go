@@ -0,0 +1,168 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cscvm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/cron"
+)
+
+// DefaultScheduleTimezone 定时开关机策略默认时区
+const DefaultScheduleTimezone = "Asia/Shanghai"
+
+// ValidateScheduleCron 校验定时开关机策略的cron表达式及时区
+func ValidateScheduleCron(startCron, stopCron, timezone string) error {
+	if len(startCron) != 0 {
+		if _, err := cron.Parse(startCron); err != nil {
+			return fmt.Errorf("invalid start_cron, err: %v", err)
+		}
+	}
+
+	if len(stopCron) != 0 {
+		if _, err := cron.Parse(stopCron); err != nil {
+			return fmt.Errorf("invalid stop_cron, err: %v", err)
+		}
+	}
+
+	if len(timezone) != 0 {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return fmt.Errorf("invalid timezone, err: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// CvmScheduleCreateReq cvm schedule create request.
+type CvmScheduleCreateReq struct {
+	Name string `json:"name" validate:"required,max=255"`
+	// Filter 目标主机过滤条件，如指定业务、指定标签的主机
+	Filter    *filter.Expression `json:"filter" validate:"required"`
+	StartCron string             `json:"start_cron" validate:"omitempty,max=64"`
+	StopCron  string             `json:"stop_cron" validate:"omitempty,max=64"`
+	Timezone  string             `json:"timezone" validate:"omitempty,max=64"`
+	Enabled   bool               `json:"enabled"`
+	Memo      *string            `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate CvmScheduleCreateReq.
+func (req *CvmScheduleCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.StartCron) == 0 && len(req.StopCron) == 0 {
+		return errors.New("start_cron and stop_cron can not be both empty")
+	}
+
+	return ValidateScheduleCron(req.StartCron, req.StopCron, req.Timezone)
+}
+
+// CvmScheduleUpdateReq cvm schedule update request.
+type CvmScheduleUpdateReq struct {
+	Name      string             `json:"name" validate:"omitempty,max=255"`
+	Filter    *filter.Expression `json:"filter" validate:"omitempty"`
+	StartCron *string            `json:"start_cron" validate:"omitempty,max=64"`
+	StopCron  *string            `json:"stop_cron" validate:"omitempty,max=64"`
+	Timezone  string             `json:"timezone" validate:"omitempty,max=64"`
+	Enabled   *bool              `json:"enabled" validate:"omitempty"`
+	Memo      *string            `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate CvmScheduleUpdateReq.
+func (req *CvmScheduleUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	var startCron, stopCron string
+	if req.StartCron != nil {
+		startCron = *req.StartCron
+	}
+	if req.StopCron != nil {
+		stopCron = *req.StopCron
+	}
+
+	return ValidateScheduleCron(startCron, stopCron, req.Timezone)
+}
+
+// CvmScheduleSavingsReq cvm schedule savings report request.
+type CvmScheduleSavingsReq struct {
+	// StartTime 统计开始时间，格式为RFC3339
+	StartTime string `json:"start_time" validate:"required"`
+	// EndTime 统计结束时间，格式为RFC3339
+	EndTime string `json:"end_time" validate:"required"`
+}
+
+// Validate CvmScheduleSavingsReq.
+func (req *CvmScheduleSavingsReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	start, end, err := req.ParseTimeRange()
+	if err != nil {
+		return err
+	}
+
+	if !start.Before(end) {
+		return errors.New("start_time should be before end_time")
+	}
+
+	if end.Sub(start) > 366*24*time.Hour {
+		return errors.New("time range should not exceed 366 days")
+	}
+
+	return nil
+}
+
+// ParseTimeRange parse start and end time.
+func (req *CvmScheduleSavingsReq) ParseTimeRange() (time.Time, time.Time, error) {
+	start, err := time.Parse(constant.TimeStdFormat, req.StartTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start_time, err: %v", err)
+	}
+
+	end, err := time.Parse(constant.TimeStdFormat, req.EndTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid end_time, err: %v", err)
+	}
+
+	return start, end, nil
+}
+
+// CvmScheduleSavingsResult cvm schedule savings report result.
+type CvmScheduleSavingsResult struct {
+	ScheduleID string `json:"schedule_id"`
+	// StopCount 统计时间内执行关机的次数
+	StopCount int64 `json:"stop_count"`
+	// StartCount 统计时间内执行开机的次数
+	StartCount int64 `json:"start_count"`
+	// SavedCvmHours 主机处于关机状态的台时数
+	SavedCvmHours float64 `json:"saved_cvm_hours"`
+	// SavedCpuCoreHours 主机处于关机状态的vCPU核时数
+	SavedCpuCoreHours float64 `json:"saved_cpu_core_hours"`
+}
@@ -0,0 +1,127 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cscvm
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table/types"
+)
+
+// userDataSupportedVendors 主机创建时支持传入自定义数据的云厂商
+var userDataSupportedVendors = map[enumor.Vendor]struct{}{
+	enumor.TCloud: {},
+}
+
+// ValidateUserData 校验自定义数据是否为base64编码，以及云厂商是否支持
+func ValidateUserData(vendor enumor.Vendor, userData string) error {
+	if len(userData) == 0 {
+		return nil
+	}
+
+	if _, exists := userDataSupportedVendors[vendor]; !exists {
+		return fmt.Errorf("user data is not supported by vendor: %s", vendor)
+	}
+
+	if _, err := base64.StdEncoding.DecodeString(userData); err != nil {
+		return fmt.Errorf("user data should be base64 encoded, err: %v", err)
+	}
+
+	return nil
+}
+
+// CvmTemplateCreateReq cvm template create request.
+type CvmTemplateCreateReq struct {
+	Name                  string          `json:"name" validate:"required,max=255"`
+	Vendor                enumor.Vendor   `json:"vendor" validate:"required"`
+	AccountID             string          `json:"account_id" validate:"required"`
+	Region                string          `json:"region" validate:"required"`
+	Zone                  string          `json:"zone" validate:"omitempty"`
+	CloudImageID          string          `json:"cloud_image_id" validate:"required"`
+	InstanceType          string          `json:"instance_type" validate:"required"`
+	CloudSecurityGroupIDs []string        `json:"cloud_security_group_ids" validate:"omitempty"`
+	SystemDisk            types.JsonField `json:"system_disk" validate:"omitempty"`
+	DataDisk              types.JsonField `json:"data_disk" validate:"omitempty"`
+	UserData              string          `json:"user_data" validate:"omitempty,max=16384"`
+	Extension             types.JsonField `json:"extension" validate:"omitempty"`
+	Memo                  *string         `json:"memo" validate:"omitempty"`
+}
+
+// Validate CvmTemplateCreateReq.
+func (req *CvmTemplateCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := req.Vendor.Validate(); err != nil {
+		return err
+	}
+
+	return ValidateUserData(req.Vendor, req.UserData)
+}
+
+// CvmTemplateUpdateReq cvm template update request.
+type CvmTemplateUpdateReq struct {
+	Name                  string          `json:"name" validate:"omitempty,max=255"`
+	Region                string          `json:"region" validate:"omitempty"`
+	Zone                  string          `json:"zone" validate:"omitempty"`
+	CloudImageID          string          `json:"cloud_image_id" validate:"omitempty"`
+	InstanceType          string          `json:"instance_type" validate:"omitempty"`
+	CloudSecurityGroupIDs []string        `json:"cloud_security_group_ids" validate:"omitempty"`
+	SystemDisk            types.JsonField `json:"system_disk" validate:"omitempty"`
+	DataDisk              types.JsonField `json:"data_disk" validate:"omitempty"`
+	UserData              *string         `json:"user_data" validate:"omitempty,max=16384"`
+	Extension             types.JsonField `json:"extension" validate:"omitempty"`
+	Memo                  *string         `json:"memo" validate:"omitempty"`
+}
+
+// Validate CvmTemplateUpdateReq.
+func (req *CvmTemplateUpdateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// CvmTemplateApplyReq apply cvm by template request, the fields will be merged with template to generate the
+// vendor's create cvm request.
+type CvmTemplateApplyReq struct {
+	BkBizID           int64   `json:"bk_biz_id" validate:"required,min=1"`
+	Name              string  `json:"name" validate:"required"`
+	Password          string  `json:"password" validate:"omitempty"`
+	ConfirmedPassword string  `json:"confirmed_password" validate:"omitempty"`
+	RequiredCount     int64   `json:"required_count" validate:"required,min=1"`
+	Memo              *string `json:"memo" validate:"omitempty"`
+	Remark            *string `json:"remark" validate:"omitempty"`
+}
+
+// Validate CvmTemplateApplyReq.
+func (req *CvmTemplateApplyReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.Password != req.ConfirmedPassword {
+		return errors.New("password and confirmed password not match")
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cscvm
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestValidateUserData(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString([]byte("#!/bin/bash\necho hello"))
+
+	if err := ValidateUserData(enumor.Aws, ""); err != nil {
+		t.Errorf("empty user data should be valid, err: %v", err)
+	}
+
+	if err := ValidateUserData(enumor.TCloud, encoded); err != nil {
+		t.Errorf("base64 user data of tcloud should be valid, err: %v", err)
+	}
+
+	if err := ValidateUserData(enumor.Aws, encoded); err == nil {
+		t.Errorf("user data of unsupported vendor should be invalid")
+	}
+
+	if err := ValidateUserData(enumor.TCloud, "not base64!"); err == nil {
+		t.Errorf("user data not base64 encoded should be invalid")
+	}
+}
+
+func TestCvmTemplateCreateReqValidate(t *testing.T) {
+	req := &CvmTemplateCreateReq{
+		Name:         "tpl",
+		Vendor:       enumor.TCloud,
+		AccountID:    "account-1",
+		Region:       "ap-guangzhou",
+		CloudImageID: "img-1",
+		InstanceType: "S5.MEDIUM2",
+	}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("validate create req failed, err: %v", err)
+	}
+
+	req.UserData = "not base64!"
+	if err := req.Validate(); err == nil {
+		t.Errorf("create req with invalid user data should be invalid")
+	}
+
+	req.UserData = ""
+	req.Vendor = "unknown"
+	if err := req.Validate(); err == nil {
+		t.Errorf("create req with invalid vendor should be invalid")
+	}
+}
+
+func TestCvmTemplateApplyReqValidate(t *testing.T) {
+	req := &CvmTemplateApplyReq{
+		BkBizID:           1,
+		Name:              "cvm",
+		Password:          "Passw0rd!",
+		ConfirmedPassword: "Passw0rd!",
+		RequiredCount:     2,
+	}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("validate apply req failed, err: %v", err)
+	}
+
+	req.ConfirmedPassword = "other"
+	if err := req.Validate(); err == nil {
+		t.Errorf("apply req with mismatched password should be invalid")
+	}
+
+	req.ConfirmedPassword = req.Password
+	req.RequiredCount = 0
+	if err := req.Validate(); err == nil {
+		t.Errorf("apply req without required count should be invalid")
+	}
+}
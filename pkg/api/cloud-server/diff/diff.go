@@ -0,0 +1,108 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package diff defines resource configuration diff cloud-server api.
+package diff
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// CvmTemplateResType cvm template, which can be compared with a cvm to check if the cvm drifts from its template.
+const CvmTemplateResType enumor.CloudResourceType = "cvm_template"
+
+// SupportedResTypes 支持配置对比的资源类型，源和目标需要是同一类型，或者是主机和主机模板
+var SupportedResTypes = []enumor.CloudResourceType{enumor.CvmCloudResType, enumor.SecurityGroupCloudResType,
+	enumor.VpcCloudResType, enumor.SubnetCloudResType}
+
+// ResourceDiffReq compare the configuration of two resources request.
+type ResourceDiffReq struct {
+	Source DiffResource `json:"source" validate:"required"`
+	Target DiffResource `json:"target" validate:"required"`
+}
+
+// Validate ResourceDiffReq.
+func (req *ResourceDiffReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.Source == req.Target {
+		return errors.New("source and target should be different resources")
+	}
+
+	if req.Source.ResType == req.Target.ResType {
+		for _, one := range SupportedResTypes {
+			if one == req.Source.ResType {
+				return nil
+			}
+		}
+		return fmt.Errorf("resource type %s does not support diff", req.Source.ResType)
+	}
+
+	if req.IsCvmWithTemplate() {
+		return nil
+	}
+
+	return fmt.Errorf("resource type %s can not be compared with %s", req.Source.ResType, req.Target.ResType)
+}
+
+// IsCvmWithTemplate return if the diff compares a cvm with a cvm template.
+func (req *ResourceDiffReq) IsCvmWithTemplate() bool {
+	return (req.Source.ResType == enumor.CvmCloudResType && req.Target.ResType == CvmTemplateResType) ||
+		(req.Source.ResType == CvmTemplateResType && req.Target.ResType == enumor.CvmCloudResType)
+}
+
+// DiffResource define resource to compare.
+type DiffResource struct {
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	ID      string                   `json:"id" validate:"required"`
+}
+
+// ResourceDiffResult compare the configuration of two resources result.
+type ResourceDiffResult struct {
+	// Identical 两个资源的配置是否一致
+	Identical bool        `json:"identical"`
+	Fields    []FieldDiff `json:"fields"`
+}
+
+// DiffType define field diff type.
+type DiffType string
+
+const (
+	// Changed 字段在源和目标中的值不同
+	Changed DiffType = "changed"
+	// Added 字段或数组元素只存在于目标中
+	Added DiffType = "added"
+	// Removed 字段或数组元素只存在于源中
+	Removed DiffType = "removed"
+)
+
+// FieldDiff define field level diff, nested fields are joined by dot, like extension.vpc_id, array elements
+// (e.g. security group rules) are compared as sets and reported one by one with the field of the array.
+type FieldDiff struct {
+	Field  string      `json:"field"`
+	Type   DiffType    `json:"type"`
+	Source interface{} `json:"source,omitempty"`
+	Target interface{} `json:"target,omitempty"`
+}
@@ -40,8 +40,10 @@ func (req *AttachReq) Validate() error {
 
 // DiskListReq ...
 type DiskListReq struct {
-	Filter *filter.Expression `json:"filter" validate:"required"`
+	Filter *filter.Expression `json:"filter" validate:"required_without=SavedFilterID"`
 	Page   *core.BasePage     `json:"page" validate:"required"`
+	// SavedFilterID 保存的过滤条件ID，设置时与filter取交集
+	SavedFilterID string `json:"saved_filter_id,omitempty"`
 }
 
 // Validate ...
@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package eip
+
+import (
+	"fmt"
+	"testing"
+
+	rr "hcm/pkg/api/core/recycle-record"
+)
+
+func TestEipRecycleReqValidate(t *testing.T) {
+	if err := (EipRecycleReq{}).Validate(); err == nil {
+		t.Errorf("recycle request without eip should be rejected")
+	}
+
+	req := EipRecycleReq{Infos: []EipRecycleInfo{
+		{ID: "eip-1"},
+		{ID: "eip-2", EipRecycleOptions: new(rr.EipRecycleOptions)},
+	}}
+	if err := req.Validate(); err != nil {
+		t.Errorf("recycle request should be valid, err: %v", err)
+	}
+
+	infos := make([]EipRecycleInfo, 0, 101)
+	recordIDs := make([]string, 0, 101)
+	for idx := 0; idx < 101; idx++ {
+		infos = append(infos, EipRecycleInfo{ID: fmt.Sprintf("eip-%d", idx)})
+		recordIDs = append(recordIDs, fmt.Sprintf("record-%d", idx))
+	}
+	if err := (EipRecycleReq{Infos: infos}).Validate(); err == nil {
+		t.Errorf("recycle more than 100 eips should be rejected")
+	}
+
+	if err := (EipRecoverReq{RecordIDs: recordIDs[:1]}).Validate(); err != nil {
+		t.Errorf("recover request should be valid, err: %v", err)
+	}
+	if err := (EipRecoverReq{RecordIDs: recordIDs}).Validate(); err == nil {
+		t.Errorf("recover more than 100 eips should be rejected")
+	}
+	if err := (EipDeleteRecycleReq{}).Validate(); err == nil {
+		t.Errorf("delete recycled request without record should be rejected")
+	}
+}
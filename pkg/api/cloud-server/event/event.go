@@ -0,0 +1,42 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package event defines resource change event cloud-server api.
+package event
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// PullReq pull resource change events request.
+type PullReq struct {
+	// Consumer 消费方名称，不同的集成方应使用不同的名称，各自独立记录消费位点
+	Consumer string `json:"consumer" validate:"required,max=64"`
+	// Topic 事件topic，即资源类型，如 vpc、subnet、cvm
+	Topic enumor.AuditResourceType `json:"topic" validate:"required,max=50"`
+	// Offset 从该位点之后开始拉取，不指定时从已提交的位点之后开始拉取
+	Offset *uint64 `json:"offset" validate:"omitempty"`
+	Limit  uint    `json:"limit" validate:"omitempty,max=500"`
+}
+
+// Validate PullReq.
+func (req *PullReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
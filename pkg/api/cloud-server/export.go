@@ -0,0 +1,105 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloudserver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// ResourceExportReq define resource list export req.
+type ResourceExportReq struct {
+	Filter *filter.Expression  `json:"filter" validate:"required"`
+	Format enumor.ExportFormat `json:"format" validate:"required"`
+	// Columns 导出的列，按顺序输出，扩展字段使用 extension. 前缀，如 extension.cloud_project_id
+	Columns []string `json:"columns" validate:"required,min=1,max=100"`
+}
+
+// Validate resource export req.
+func (req *ResourceExportReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := req.Format.Validate(); err != nil {
+		return err
+	}
+
+	exists := make(map[string]struct{}, len(req.Columns))
+	for _, column := range req.Columns {
+		if len(column) == 0 {
+			return errors.New("column can not be empty")
+		}
+
+		if _, ok := exists[column]; ok {
+			return fmt.Errorf("column: %s is duplicated", column)
+		}
+		exists[column] = struct{}{}
+	}
+
+	return nil
+}
+
+// NeedExtension return true if any of the columns is extension field.
+func (req *ResourceExportReq) NeedExtension() bool {
+	for _, column := range req.Columns {
+		if strings.HasPrefix(column, "extension.") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ResourceExportFile define resource export file, the file is deleted after download.
+type ResourceExportFile struct {
+	FileName string
+	FilePath string
+	Format   enumor.ExportFormat
+}
+
+// ContentType ...
+func (f *ResourceExportFile) ContentType() string {
+	if f.Format == enumor.XlsxExportFormat {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	return "text/csv; charset=utf-8"
+}
+
+// ContentDisposition ...
+func (f *ResourceExportFile) ContentDisposition() string {
+	return fmt.Sprintf(`attachment; filename="%s"`, f.FileName)
+}
+
+// Filepath return file path.
+func (f *ResourceExportFile) Filepath() string {
+	return f.FilePath
+}
+
+// IsDeleteFile is true, file will be deleted after download.
+func (f *ResourceExportFile) IsDeleteFile() bool {
+	return true
+}
@@ -0,0 +1,84 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package idleresource defines idle resource cloud-server api.
+package idleresource
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// IdleStatusUpdateReq update idle resource status request, only support ignore or restore to pending.
+type IdleStatusUpdateReq struct {
+	IDs    []string                  `json:"ids" validate:"required,min=1"`
+	Status enumor.IdleResourceStatus `json:"status" validate:"required"`
+}
+
+// Validate IdleStatusUpdateReq.
+func (req *IdleStatusUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.IDs) > constant.BatchOperationMaxLimit {
+		return errors.New("ids should <= 100")
+	}
+
+	if req.Status != enumor.IdleResourcePending && req.Status != enumor.IdleResourceIgnored {
+		return errors.New("status only supports pending or ignored")
+	}
+
+	return nil
+}
+
+// IdleRemediateReq remediate idle resource request.
+type IdleRemediateReq struct {
+	IDs []string `json:"ids" validate:"required,min=1"`
+}
+
+// Validate IdleRemediateReq.
+func (req *IdleRemediateReq) Validate() error {
+	if len(req.IDs) > constant.BatchOperationMaxLimit {
+		return errors.New("ids should <= 100")
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// IdleRemediateResult remediate idle resource result.
+type IdleRemediateResult struct {
+	// RecycleTaskIDs 硬盘、弹性IP、主机进入回收站的回收任务ID
+	RecycleTaskIDs []string `json:"recycle_task_ids"`
+	// FlowIDs 删除安全组的异步任务ID
+	FlowIDs []string `json:"flow_ids"`
+	// Succeeded 已提交清理的闲置资源ID
+	Succeeded []string `json:"succeeded"`
+	// Failed 清理失败的闲置资源
+	Failed []IdleRemediateFailed `json:"failed"`
+}
+
+// IdleRemediateFailed remediate failed idle resource.
+type IdleRemediateFailed struct {
+	ID    string `json:"id"`
+	Error string `json:"error"`
+}
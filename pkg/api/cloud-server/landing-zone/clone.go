@@ -0,0 +1,87 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package landingzone
+
+import (
+	"fmt"
+	"net"
+
+	corelz "hcm/pkg/api/core/landing-zone"
+	"hcm/pkg/criteria/validator"
+)
+
+// CloneReq clone the layout of a vpc into another region or account request, the cloned layout is provisioned as a
+// landing zone deployment.
+type CloneReq struct {
+	VpcID           string  `json:"vpc_id" validate:"required"`
+	Name            string  `json:"name" validate:"required,max=255"`
+	TargetAccountID string  `json:"target_account_id" validate:"required"`
+	TargetBkBizID   int64   `json:"target_bk_biz_id" validate:"required,min=1"`
+	TargetRegion    string  `json:"target_region" validate:"required"`
+	VpcName         string  `json:"vpc_name" validate:"omitempty,max=60"`
+	BkCloudID       int64   `json:"bk_cloud_id" validate:"required"`
+	Memo            *string `json:"memo" validate:"omitempty,max=255"`
+	// ZoneMappings 源子网可用区到目标地域可用区的映射，源VPC下所有子网的可用区都需要映射
+	ZoneMappings map[string]string `json:"zone_mappings" validate:"required,min=1"`
+	// CidrMappings 网段映射，落在映射源网段内的VPC、子网及安全组规则网段平移到目标网段，为空时保持原网段
+	CidrMappings []CidrMapping `json:"cidr_mappings" validate:"omitempty,max=10,dive"`
+	// SecurityGroupIDs 需要一并克隆的安全组，腾讯云安全组不归属于VPC，需要显式指定
+	SecurityGroupIDs []string `json:"security_group_ids" validate:"omitempty,max=10"`
+	// NatGateway 源VPC存在以NAT网关为下一跳的默认路由时创建的NAT网关，为空时使用默认配置
+	NatGateway *corelz.NatGatewaySpec `json:"nat_gateway" validate:"omitempty"`
+	// DryRun 为true时只返回克隆出的部署规格，不创建资源
+	DryRun bool `json:"dry_run"`
+}
+
+// Validate CloneReq.
+func (req *CloneReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for _, mapping := range req.CidrMappings {
+		_, sourceNet, _ := net.ParseCIDR(mapping.Source)
+		_, targetNet, _ := net.ParseCIDR(mapping.Target)
+		sourceOnes, _ := sourceNet.Mask.Size()
+		targetOnes, _ := targetNet.Mask.Size()
+		if sourceOnes != targetOnes {
+			return fmt.Errorf("cidr mapping %s -> %s should have the same mask length", mapping.Source,
+				mapping.Target)
+		}
+	}
+
+	return nil
+}
+
+// CidrMapping define cidr mapping of clone.
+type CidrMapping struct {
+	Source string `json:"source" validate:"required,cidrv4"`
+	Target string `json:"target" validate:"required,cidrv4"`
+}
+
+// CloneResult clone vpc layout result.
+type CloneResult struct {
+	Spec *corelz.DeploymentSpec `json:"spec"`
+	// Skipped 无法克隆的配置，如以其他资源为下一跳的路由、引用参数模板或安全组的规则
+	Skipped []string `json:"skipped"`
+	// ID 着陆区部署ID，DryRun时为空
+	ID     string `json:"id,omitempty"`
+	FlowID string `json:"flow_id,omitempty"`
+}
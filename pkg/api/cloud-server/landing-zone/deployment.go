@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package landingzone defines landing zone cloud-server api.
+package landingzone
+
+import (
+	corelz "hcm/pkg/api/core/landing-zone"
+	"hcm/pkg/criteria/validator"
+)
+
+// DeploymentCreateReq create landing zone deployment request.
+type DeploymentCreateReq struct {
+	Name      string                 `json:"name" validate:"required,max=255"`
+	AccountID string                 `json:"account_id" validate:"required"`
+	BkBizID   int64                  `json:"bk_biz_id" validate:"required,min=1"`
+	Region    string                 `json:"region" validate:"required"`
+	Spec      *corelz.DeploymentSpec `json:"spec" validate:"required"`
+	Memo      *string                `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate DeploymentCreateReq.
+func (req *DeploymentCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.Spec.Validate()
+}
+
+// DeploymentCreateResult create landing zone deployment result.
+type DeploymentCreateResult struct {
+	ID string `json:"id"`
+	// FlowID 部署任务流ID，可通过异步任务接口查询各资源的创建进度
+	FlowID string `json:"flow_id"`
+}
@@ -0,0 +1,102 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package quota defines biz resource quota cloud-server api.
+package quota
+
+import (
+	"errors"
+
+	corequota "hcm/pkg/api/core/quota"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// BizQuotaSetReq set biz quota request, quota value -1 means unlimited, the omitted quota keeps its current
+// value and is unlimited when the quota is created.
+type BizQuotaSetReq struct {
+	MaxCvm     *int64  `json:"max_cvm" validate:"omitempty,min=-1"`
+	MaxCpuCore *int64  `json:"max_cpu_core" validate:"omitempty,min=-1"`
+	MaxEip     *int64  `json:"max_eip" validate:"omitempty,min=-1"`
+	Memo       *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate BizQuotaSetReq.
+func (req *BizQuotaSetReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.MaxCvm == nil && req.MaxCpuCore == nil && req.MaxEip == nil && req.Memo == nil {
+		return errors.New("at least one field should be set")
+	}
+
+	return nil
+}
+
+// BizQuotaUsageListReq list biz quota and usage request.
+type BizQuotaUsageListReq struct {
+	BkBizIDs []int64 `json:"bk_biz_ids" validate:"required,min=1"`
+}
+
+// Validate BizQuotaUsageListReq.
+func (req *BizQuotaUsageListReq) Validate() error {
+	if len(req.BkBizIDs) > constant.BatchOperationMaxLimit {
+		return errors.New("bk_biz_ids should <= 100")
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// BizQuotaUsage biz quota and current usage, quota is nil when the biz has no quota.
+type BizQuotaUsage struct {
+	BkBizID int64                 `json:"bk_biz_id"`
+	Quota   *corequota.BizQuota   `json:"quota"`
+	Usage   corequota.BizResUsage `json:"usage"`
+}
+
+// BizResWhitelistSetReq set biz resource whitelist of the vendor request, the whole whitelist is replaced,
+// empty list means unlimited.
+type BizResWhitelistSetReq struct {
+	Vendor           enumor.Vendor `json:"vendor" validate:"required"`
+	Regions          []string      `json:"regions" validate:"omitempty,max=100"`
+	InstanceFamilies []string      `json:"instance_families" validate:"omitempty,max=100"`
+	CloudImageIDs    []string      `json:"cloud_image_ids" validate:"omitempty,max=100"`
+	Memo             *string       `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate BizResWhitelistSetReq.
+func (req *BizResWhitelistSetReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.Vendor.Validate()
+}
+
+// BizResWhitelistDeleteReq delete biz resource whitelist of the vendor request.
+type BizResWhitelistDeleteReq struct {
+	Vendor enumor.Vendor `json:"vendor" validate:"required"`
+}
+
+// Validate BizResWhitelistDeleteReq.
+func (req *BizResWhitelistDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -47,11 +47,13 @@ func (req *AccountReq) Validate() error {
 type ListReq struct {
 	Filter *filter.Expression `json:"filter"`
 	Page   *core.BasePage     `json:"page"`
+	// SavedFilterID 保存的过滤条件ID，设置时与filter取交集，仅支持保存过滤条件的资源列表接口
+	SavedFilterID string `json:"saved_filter_id,omitempty"`
 }
 
 // Validate ListReq.
 func (l *ListReq) Validate() error {
-	if l.Filter == nil {
+	if l.Filter == nil && len(l.SavedFilterID) == 0 {
 		return errf.New(errf.InvalidParameter, "filter is required")
 	}
 
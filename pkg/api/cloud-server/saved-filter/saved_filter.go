@@ -0,0 +1,50 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package savedfilter defines saved filter cloud-server api.
+package savedfilter
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// ListSavedFilterReq list saved filter visible to current user request.
+type ListSavedFilterReq struct {
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	// BkBizID 业务ID，设置时同时返回该业务下共享的过滤条件
+	BkBizID int64          `json:"bk_biz_id" validate:"omitempty,min=0"`
+	Page    *core.BasePage `json:"page" validate:"required"`
+}
+
+// Validate ListSavedFilterReq.
+func (req *ListSavedFilterReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if _, exists := enumor.SavedFilterResTypes[req.ResType]; !exists {
+		return fmt.Errorf("res_type: %s not support saved filter", req.ResType)
+	}
+
+	return req.Page.Validate(core.NewDefaultPageOption())
+}
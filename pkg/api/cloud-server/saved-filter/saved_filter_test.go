@@ -0,0 +1,45 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package savedfilter
+
+import (
+	"testing"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestListSavedFilterReqValidate(t *testing.T) {
+	req := &ListSavedFilterReq{ResType: enumor.CvmCloudResType, BkBizID: 1, Page: core.NewDefaultBasePage()}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("validate list saved filter req failed, err: %v", err)
+	}
+
+	// 只有资源列表接口支持保存过滤条件的资源类型可以查询
+	req.ResType = enumor.VpcCloudResType
+	if err := req.Validate(); err == nil {
+		t.Errorf("vpc should not support saved filter")
+	}
+
+	req = &ListSavedFilterReq{ResType: enumor.DiskCloudResType}
+	if err := req.Validate(); err == nil {
+		t.Errorf("page is required")
+	}
+}
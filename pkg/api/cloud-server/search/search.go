@@ -0,0 +1,106 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package search defines cross account resource search cloud-server api.
+package search
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+const (
+	// DefaultSearchLimit 每种资源类型默认返回的最大资源数
+	DefaultSearchLimit = 20
+	// MaxSearchLimit 每种资源类型最多返回的资源数
+	MaxSearchLimit = 100
+)
+
+// SupportedResTypes 支持跨账号搜索的资源类型
+var SupportedResTypes = []enumor.CloudResourceType{enumor.CvmCloudResType, enumor.VpcCloudResType,
+	enumor.SecurityGroupCloudResType, enumor.EipCloudResType, enumor.DiskCloudResType}
+
+// ResourceSearchReq search resources across all vendors and accounts by ip, cloud id or name request.
+type ResourceSearchReq struct {
+	// Keyword 搜索关键字，精确匹配IP、云资源ID，模糊匹配资源名称
+	Keyword string `json:"keyword" validate:"required,max=255"`
+	// ResTypes 需要搜索的资源类型，为空时搜索所有支持的资源类型
+	ResTypes []enumor.CloudResourceType `json:"res_types" validate:"omitempty,max=5"`
+	// Limit 每种资源类型最多返回的资源数，默认20，最大100
+	Limit uint `json:"limit" validate:"omitempty,max=100"`
+	// AccountGroupID 账号组ID，指定时仅搜索该账号组及其子账号组下资源账号的资源
+	AccountGroupID string `json:"account_group_id" validate:"omitempty,max=64"`
+}
+
+// Validate ResourceSearchReq.
+func (req *ResourceSearchReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	req.Keyword = strings.TrimSpace(req.Keyword)
+	if len(req.Keyword) == 0 {
+		return errors.New("keyword is required")
+	}
+
+	for _, resType := range req.ResTypes {
+		if !isSupportedResType(resType) {
+			return fmt.Errorf("resource type %s does not support search", resType)
+		}
+	}
+
+	return nil
+}
+
+func isSupportedResType(resType enumor.CloudResourceType) bool {
+	for _, one := range SupportedResTypes {
+		if one == resType {
+			return true
+		}
+	}
+	return false
+}
+
+// ResourceSearchResult resource search result, grouped by resource type.
+type ResourceSearchResult struct {
+	Details []ResTypeSearchResult `json:"details"`
+}
+
+// ResTypeSearchResult search result of one resource type.
+type ResTypeSearchResult struct {
+	ResType enumor.CloudResourceType `json:"res_type"`
+	Details []SearchResource         `json:"details"`
+}
+
+// SearchResource resource matched by search keyword.
+type SearchResource struct {
+	ID        string        `json:"id"`
+	CloudID   string        `json:"cloud_id"`
+	Name      string        `json:"name"`
+	Vendor    enumor.Vendor `json:"vendor"`
+	AccountID string        `json:"account_id"`
+	BkBizID   int64         `json:"bk_biz_id"`
+	Region    string        `json:"region"`
+	// IPs 资源的IP地址，仅主机及弹性IP返回
+	IPs []string `json:"ips,omitempty"`
+}
@@ -0,0 +1,48 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package search
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestResourceSearchReqValidate(t *testing.T) {
+	req := &ResourceSearchReq{Keyword: "  10.0.0.1 ", ResTypes: []enumor.CloudResourceType{enumor.CvmCloudResType}}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("validate search req failed, err: %v", err)
+	}
+	if req.Keyword != "10.0.0.1" {
+		t.Errorf("keyword should be trimmed, but got %q", req.Keyword)
+	}
+
+	cases := map[string]*ResourceSearchReq{
+		"blank keyword": {Keyword: "   "},
+		"unsupported res type": {Keyword: "web",
+			ResTypes: []enumor.CloudResourceType{enumor.RouteTableCloudResType}},
+		"limit exceeded": {Keyword: "web", Limit: MaxSearchLimit + 1},
+	}
+	for name, req := range cases {
+		if err := req.Validate(); err == nil {
+			t.Errorf("%s: expect validate failed, but not", name)
+		}
+	}
+}
@@ -63,8 +63,10 @@ type AzureSecurityGroupExtensionCreate struct {
 
 // SecurityGroupListReq security group list req.
 type SecurityGroupListReq struct {
-	Filter *filter.Expression `json:"filter" validate:"required"`
+	Filter *filter.Expression `json:"filter" validate:"required_without=SavedFilterID"`
 	Page   *core.BasePage     `json:"page" validate:"required"`
+	// SavedFilterID 保存的过滤条件ID，设置时与filter取交集
+	SavedFilterID string `json:"saved_filter_id,omitempty"`
 }
 
 // Validate security group list request.
@@ -0,0 +1,107 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package taskcenter defines task center cloud-server api.
+package taskcenter
+
+import (
+	"errors"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// JobReq define async job operate request, the job is identified by kind and id.
+type JobReq struct {
+	Kind enumor.AsyncJobKind `json:"kind" validate:"required"`
+	ID   string              `json:"id" validate:"required"`
+}
+
+// Validate JobReq.
+func (req *JobReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.Kind.Validate()
+}
+
+// JobRetryReq define async job retry request.
+type JobRetryReq struct {
+	JobReq `json:",inline"`
+	// TaskID 需要重试的子任务ID，仅异步任务流有效，为空时重试第一个失败的子任务
+	TaskID string `json:"task_id"`
+}
+
+// Validate JobRetryReq.
+func (req *JobRetryReq) Validate() error {
+	if err := req.JobReq.Validate(); err != nil {
+		return err
+	}
+
+	if req.Kind == enumor.AsyncJobSync && len(req.TaskID) != 0 {
+		return errors.New("task_id is not supported for sync job")
+	}
+
+	return nil
+}
+
+// SubTaskListReq define async job sub task list request.
+type SubTaskListReq struct {
+	JobReq `json:",inline"`
+	Page   *core.BasePage `json:"page" validate:"required"`
+}
+
+// Validate SubTaskListReq.
+func (req *SubTaskListReq) Validate() error {
+	if err := req.JobReq.Validate(); err != nil {
+		return err
+	}
+
+	if req.Page == nil {
+		return errors.New("page is required")
+	}
+
+	return req.Page.Validate(core.NewDefaultPageOption())
+}
+
+// SubTask async job sub task, the sub task of flow is async flow task, the sub task of sync is the sync detail of
+// each resource type.
+type SubTask struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	Reason    string `json:"reason"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// JobRetryResult define async job retry result.
+type JobRetryResult struct {
+	// TaskID 被重试的子任务ID，账号资源同步任务为空
+	TaskID string `json:"task_id,omitempty"`
+}
+
+const (
+	// JobEventJob 异步任务状态变更事件，数据为异步任务详情
+	JobEventJob = "job"
+	// JobEventSubTask 子任务状态变更事件，数据为状态发生变更的子任务列表
+	JobEventSubTask = "sub_task"
+)
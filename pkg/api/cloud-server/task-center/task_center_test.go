@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package taskcenter
+
+import (
+	"testing"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestJobRetryReqValidate(t *testing.T) {
+	cases := []struct {
+		req   JobRetryReq
+		valid bool
+	}{
+		{req: JobRetryReq{JobReq: JobReq{Kind: enumor.AsyncJobFlow, ID: "flow-1"}, TaskID: "task-1"}, valid: true},
+		{req: JobRetryReq{JobReq: JobReq{Kind: enumor.AsyncJobSync, ID: "sync-1"}}, valid: true},
+		{req: JobRetryReq{JobReq: JobReq{Kind: enumor.AsyncJobSync, ID: "sync-1"}, TaskID: "task-1"}},
+		{req: JobRetryReq{JobReq: JobReq{Kind: "cron", ID: "job-1"}}},
+		{req: JobRetryReq{JobReq: JobReq{Kind: enumor.AsyncJobFlow}}},
+	}
+	for idx, c := range cases {
+		if err := c.req.Validate(); (err == nil) != c.valid {
+			t.Errorf("case %d: expect valid %v, but got err: %v", idx, c.valid, err)
+		}
+	}
+}
+
+func TestSubTaskListReqValidate(t *testing.T) {
+	req := &SubTaskListReq{JobReq: JobReq{Kind: enumor.AsyncJobFlow, ID: "flow-1"}, Page: core.NewDefaultBasePage()}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("validate sub task list req failed, err: %v", err)
+	}
+
+	req.Page = nil
+	if err := req.Validate(); err == nil {
+		t.Errorf("sub task list req without page should be invalid")
+	}
+}
@@ -0,0 +1,96 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package terraform defines terraform interoperability cloud-server api.
+package terraform
+
+import (
+	"encoding/json"
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// ExportReq export resources as terraform configuration request.
+type ExportReq struct {
+	Vendor           enumor.Vendor `json:"vendor" validate:"required"`
+	VpcIDs           []string      `json:"vpc_ids" validate:"omitempty,max=100"`
+	SubnetIDs        []string      `json:"subnet_ids" validate:"omitempty,max=100"`
+	SecurityGroupIDs []string      `json:"security_group_ids" validate:"omitempty,max=100"`
+}
+
+// Validate ExportReq.
+func (req *ExportReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.VpcIDs)+len(req.SubnetIDs)+len(req.SecurityGroupIDs) == 0 {
+		return errors.New("at least one of vpc_ids, subnet_ids and security_group_ids is required")
+	}
+
+	return nil
+}
+
+// ExportResult export resources as terraform configuration result.
+type ExportResult struct {
+	// Content terraform 配置内容，包含 resource 及 import 块
+	Content string `json:"content"`
+}
+
+// StateImportReq map terraform state onto hcm resources request.
+type StateImportReq struct {
+	// State terraform state 文件内容，仅支持 version 4
+	State json.RawMessage `json:"state" validate:"required"`
+}
+
+// Validate StateImportReq.
+func (req *StateImportReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.State) == 0 {
+		return errors.New("state is required")
+	}
+
+	return nil
+}
+
+// StateImportResult map terraform state onto hcm resources result.
+type StateImportResult struct {
+	Details []StateMapping `json:"details"`
+}
+
+// StateMapping define the mapping of one terraform resource instance onto hcm resource.
+type StateMapping struct {
+	// Address terraform 资源地址，如 module.net.tencentcloud_vpc.main
+	Address string `json:"address"`
+	// Type terraform 资源类型，如 tencentcloud_vpc
+	Type      string                        `json:"type"`
+	CloudID   string                        `json:"cloud_id"`
+	Vendor    enumor.Vendor                 `json:"vendor,omitempty"`
+	ResType   enumor.CloudResourceType      `json:"res_type,omitempty"`
+	ResID     string                        `json:"res_id,omitempty"`
+	AccountID string                        `json:"account_id,omitempty"`
+	BkBizID   int64                         `json:"bk_biz_id"`
+	Status    enumor.TerraformMappingStatus `json:"status"`
+	Reason    string                        `json:"reason,omitempty"`
+}
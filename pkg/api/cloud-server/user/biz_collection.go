@@ -44,3 +44,18 @@ type CreateCollectionReq struct {
 func (req CreateCollectionReq) Validate() error {
 	return validator.Validate.Struct(req)
 }
+
+// RecordRecentViewReq define record recent view request.
+type RecordRecentViewReq struct {
+	ResType enumor.UserCollectionResType `json:"res_type" validate:"required"`
+	ResID   string                       `json:"res_id" validate:"required,max=64"`
+}
+
+// Validate RecordRecentViewReq.
+func (req RecordRecentViewReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.ResType.Validate()
+}
@@ -0,0 +1,81 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package webhook defines webhook cloud-server api.
+package webhook
+
+import (
+	"errors"
+
+	datawebhook "hcm/pkg/api/data-service/webhook"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// SubscriptionCreateReq webhook subscription create request.
+type SubscriptionCreateReq struct {
+	Name string `json:"name" validate:"required,max=255"`
+	Url  string `json:"url" validate:"required,max=1024"`
+	// Secret 签名密钥，用于计算请求体的HMAC-SHA256签名，订阅方使用相同的密钥校验请求来源
+	Secret   string                     `json:"secret" validate:"required,min=16,max=128"`
+	ResTypes []enumor.AuditResourceType `json:"res_types" validate:"required,min=1,max=30"`
+	Actions  []enumor.AuditAction       `json:"actions" validate:"omitempty,max=20"`
+	BkBizIDs []int64                    `json:"bk_biz_ids" validate:"omitempty,max=100"`
+	Enabled  *bool                      `json:"enabled" validate:"required"`
+	Memo     *string                    `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate SubscriptionCreateReq.
+func (req *SubscriptionCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return datawebhook.ValidateSubscriptionFilter(req.Url, req.ResTypes, req.Actions)
+}
+
+// SubscriptionUpdateReq webhook subscription update request.
+type SubscriptionUpdateReq struct {
+	Name     *string                    `json:"name" validate:"omitempty,min=1,max=255"`
+	Url      *string                    `json:"url" validate:"omitempty,min=1,max=1024"`
+	Secret   *string                    `json:"secret" validate:"omitempty,min=16,max=128"`
+	ResTypes []enumor.AuditResourceType `json:"res_types" validate:"omitempty,min=1,max=30"`
+	Actions  []enumor.AuditAction       `json:"actions" validate:"omitempty,max=20"`
+	BkBizIDs []int64                    `json:"bk_biz_ids" validate:"omitempty,max=100"`
+	Enabled  *bool                      `json:"enabled"`
+	Memo     *string                    `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate SubscriptionUpdateReq.
+func (req *SubscriptionUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.Name == nil && req.Url == nil && req.Secret == nil && req.ResTypes == nil && req.Actions == nil &&
+		req.BkBizIDs == nil && req.Enabled == nil && req.Memo == nil {
+		return errors.New("at least one field should be updated")
+	}
+
+	var webhookUrl string
+	if req.Url != nil {
+		webhookUrl = *req.Url
+	}
+	return datawebhook.ValidateSubscriptionFilter(webhookUrl, req.ResTypes, req.Actions)
+}
@@ -0,0 +1,71 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package zone
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// CapabilityMatrixReq zone capability matrix request.
+type CapabilityMatrixReq struct {
+	// Regions 为空时返回云厂商下所有地域
+	Regions []string `json:"regions" validate:"omitempty,max=100"`
+}
+
+// Validate CapabilityMatrixReq.
+func (req *CapabilityMatrixReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// CapabilityMatrixResult zone capability matrix result.
+type CapabilityMatrixResult struct {
+	Vendor  enumor.Vendor      `json:"vendor"`
+	Regions []RegionCapability `json:"regions"`
+}
+
+// RegionCapability define the capabilities of region, the instance families and features of region are the union
+// of all zones under it.
+type RegionCapability struct {
+	Region           string               `json:"region"`
+	InstanceFamilies []string             `json:"instance_families"`
+	Features         []enumor.ZoneFeature `json:"features"`
+	Zones            []ZoneCapability     `json:"zones"`
+}
+
+// ZoneCapability define the capabilities of zone.
+type ZoneCapability struct {
+	Zone             string               `json:"zone"`
+	InstanceFamilies []string             `json:"instance_families"`
+	Features         []enumor.ZoneFeature `json:"features"`
+	// Synced 可用区能力数据是否已同步，未同步时前端不应据此隐藏选项
+	Synced bool `json:"synced"`
+}
+
+// CapabilityRefreshReq zone capability refresh request.
+type CapabilityRefreshReq struct {
+	AccountID string   `json:"account_id" validate:"required"`
+	Regions   []string `json:"regions" validate:"required,min=1,max=5"`
+}
+
+// Validate CapabilityRefreshReq.
+func (req *CapabilityRefreshReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
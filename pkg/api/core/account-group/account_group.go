@@ -0,0 +1,46 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package accountgroup defines account group core types.
+package accountgroup
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// AccountGroup define account group, which is used to group accounts (e.g. "prod accounts", "AWS-CN") so that
+// operators can use the group as the scope of sync, search and cost reports.
+type AccountGroup struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// ParentID 父账号组ID，为空表示顶层账号组
+	ParentID      string  `json:"parent_id"`
+	Memo          *string `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// AccountGroupMember define account group member.
+type AccountGroupMember struct {
+	GroupID     string                        `json:"group_id"`
+	AccountType enumor.AccountGroupMemberType `json:"account_type"`
+	AccountID   string                        `json:"account_id"`
+	Creator     string                        `json:"creator"`
+	CreatedAt   string                        `json:"created_at"`
+}
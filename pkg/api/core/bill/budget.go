@@ -0,0 +1,54 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/shopspring/decimal"
+)
+
+// Budget 账单预算
+type Budget struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// ScopeType 预算范围类型
+	ScopeType enumor.BudgetScopeType `json:"scope_type"`
+	// BkBizID 业务ID，业务预算时有效
+	BkBizID int64 `json:"bk_biz_id"`
+	// MainAccountID 二级账号ID，二级账号预算时有效
+	MainAccountID string `json:"main_account_id"`
+	// Currency 币种
+	Currency enumor.CurrencyCode `json:"currency"`
+	// Amount 月度预算金额
+	Amount decimal.Decimal `json:"amount"`
+	// Thresholds 告警阈值，预算金额的百分比
+	Thresholds []int64 `json:"thresholds"`
+	// NoticeUsers 告警通知人
+	NoticeUsers []string `json:"notice_users"`
+	// AlertMonth 最近一次告警的账期，格式：yyyymm
+	AlertMonth int `json:"alert_month"`
+	// AlertThreshold 最近一次告警账期内已告警的最高阈值
+	AlertThreshold int64  `json:"alert_threshold"`
+	Memo           string `json:"memo"`
+
+	*core.Revision `json:",inline"`
+}
@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/shopspring/decimal"
+)
+
+// Reconciliation 账单对账结果，账单中无法与HCM资源对应的费用
+type Reconciliation struct {
+	ID            string        `json:"id"`
+	Vendor        enumor.Vendor `json:"vendor"`
+	RootAccountID string        `json:"root_account_id"`
+	MainAccountID string        `json:"main_account_id"`
+	BillYear      int           `json:"bill_year"`
+	BillMonth     int           `json:"bill_month"`
+	// ResType 资源类型
+	ResType enumor.CloudResourceType `json:"res_type"`
+	// ResCloudID 账单中的云资源ID
+	ResCloudID    string              `json:"res_cloud_id"`
+	HcProductCode string              `json:"hc_product_code"`
+	Currency      enumor.CurrencyCode `json:"currency"`
+	// Cost 该资源当月费用
+	Cost decimal.Decimal `json:"cost"`
+	// ResAmount 该资源当月用量，如主机的计费时长
+	ResAmount     decimal.Decimal `json:"res_amount"`
+	ResAmountUnit string          `json:"res_amount_unit"`
+	// Reason 无法对应的原因
+	Reason enumor.BillReconcileReason `json:"reason"`
+
+	*core.Revision `json:",inline"`
+}
@@ -22,7 +22,6 @@ package cloud
 import (
 	"hcm/pkg/api/core"
 	"hcm/pkg/criteria/enumor"
-	"hcm/pkg/cryptography"
 )
 
 // BaseAccount 云账号
@@ -51,19 +50,6 @@ type TCloudAccountExtension struct {
 	CloudSecretKey     string `json:"cloud_secret_key,omitempty"`
 }
 
-// DecryptSecretKey ...
-func (e *TCloudAccountExtension) DecryptSecretKey(cipher cryptography.Crypto) error {
-	if e.CloudSecretKey != "" {
-		plainSecretKey, err := cipher.DecryptFromBase64(e.CloudSecretKey)
-		if err != nil {
-			return err
-		}
-		e.CloudSecretKey = plainSecretKey
-	}
-
-	return nil
-}
-
 // AwsAccountExtension define aws account extension.
 type AwsAccountExtension struct {
 	CloudAccountID   string `json:"cloud_account_id"`
@@ -72,19 +58,6 @@ type AwsAccountExtension struct {
 	CloudSecretKey   string `json:"cloud_secret_key,omitempty"`
 }
 
-// DecryptSecretKey ...
-func (e *AwsAccountExtension) DecryptSecretKey(cipher cryptography.Crypto) error {
-	if e.CloudSecretKey != "" {
-		plainSecretKey, err := cipher.DecryptFromBase64(e.CloudSecretKey)
-		if err != nil {
-			return err
-		}
-		e.CloudSecretKey = plainSecretKey
-	}
-
-	return nil
-}
-
 // HuaWeiAccountExtension define huawei account extension.
 type HuaWeiAccountExtension struct {
 	CloudMainAccountName string `json:"cloud_main_account_name"`
@@ -96,19 +69,6 @@ type HuaWeiAccountExtension struct {
 	CloudIamUsername     string `json:"cloud_iam_username"`
 }
 
-// DecryptSecretKey ...
-func (e *HuaWeiAccountExtension) DecryptSecretKey(cipher cryptography.Crypto) error {
-	if e.CloudSecretKey != "" {
-		plainSecretKey, err := cipher.DecryptFromBase64(e.CloudSecretKey)
-		if err != nil {
-			return err
-		}
-		e.CloudSecretKey = plainSecretKey
-	}
-
-	return nil
-}
-
 // GcpAccountExtension define gcp account extension.
 type GcpAccountExtension struct {
 	Email                   string `json:"email"`
@@ -120,19 +80,6 @@ type GcpAccountExtension struct {
 	CloudServiceSecretKey   string `json:"cloud_service_secret_key,omitempty"`
 }
 
-// DecryptSecretKey ...
-func (e *GcpAccountExtension) DecryptSecretKey(cipher cryptography.Crypto) error {
-	if e.CloudServiceSecretKey != "" {
-		plainSecretKey, err := cipher.DecryptFromBase64(e.CloudServiceSecretKey)
-		if err != nil {
-			return err
-		}
-		e.CloudServiceSecretKey = plainSecretKey
-	}
-
-	return nil
-}
-
 // AzureAccountExtension ...
 type AzureAccountExtension struct {
 	DisplayNameName       string `json:"display_name_name"`
@@ -144,16 +91,3 @@ type AzureAccountExtension struct {
 	CloudClientSecretID   string `json:"cloud_client_secret_id"`
 	CloudClientSecretKey  string `json:"cloud_client_secret_key,omitempty"`
 }
-
-// DecryptSecretKey ...
-func (e *AzureAccountExtension) DecryptSecretKey(cipher cryptography.Crypto) error {
-	if e.CloudClientSecretKey != "" {
-		plainSecretKey, err := cipher.DecryptFromBase64(e.CloudClientSecretKey)
-		if err != nil {
-			return err
-		}
-		e.CloudClientSecretKey = plainSecretKey
-	}
-
-	return nil
-}
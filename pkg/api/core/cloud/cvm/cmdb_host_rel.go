@@ -0,0 +1,42 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// CmdbHostRel define cvm and cmdb host relation, which records the result of syncing cvm to cmdb.
+type CmdbHostRel struct {
+	ID        string        `json:"id"`
+	CvmID     string        `json:"cvm_id"`
+	Vendor    enumor.Vendor `json:"vendor"`
+	AccountID string        `json:"account_id"`
+	CloudID   string        `json:"cloud_id"`
+	BkBizID   int64         `json:"bk_biz_id"`
+	// BkHostID cmdb主机ID，同步失败时为0
+	BkHostID int64 `json:"bk_host_id"`
+	// BkModuleID 转移到的cmdb模块ID，未转移时为0
+	BkModuleID    int64                     `json:"bk_module_id"`
+	SyncStatus    enumor.CmdbHostSyncStatus `json:"sync_status"`
+	SyncMessage   string                    `json:"sync_message"`
+	core.Revision `json:",inline"`
+}
@@ -74,7 +74,12 @@ type BaseCvm struct {
 	CloudCreatedTime  string `json:"cloud_created_time"`
 	CloudLaunchedTime string `json:"cloud_launched_time"`
 	CloudExpiredTime  string `json:"cloud_expired_time"`
-	*core.Revision    `json:",inline"`
+
+	// Owner 负责人，由存量主机导入时设置
+	Owner string `json:"owner"`
+	// Labels 标签，由存量主机导入时设置，与云上标签相互独立
+	Labels         map[string]string `json:"labels"`
+	*core.Revision `json:",inline"`
 }
 
 // Cvm define cvm.
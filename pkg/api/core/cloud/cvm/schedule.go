@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/runtime/filter"
+)
+
+// Schedule define cvm scheduled start/stop policy.
+type Schedule struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Filter 目标主机过滤条件
+	Filter        *filter.Expression `json:"filter"`
+	StartCron     string             `json:"start_cron"`
+	StopCron      string             `json:"stop_cron"`
+	Timezone      string             `json:"timezone"`
+	Enabled       bool               `json:"enabled"`
+	Memo          *string            `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// ScheduleRecord define cvm schedule execution record.
+type ScheduleRecord struct {
+	ID           string                   `json:"id"`
+	ScheduleID   string                   `json:"schedule_id"`
+	Action       enumor.CvmScheduleAction `json:"action"`
+	CvmIDs       []string                 `json:"cvm_ids"`
+	FailedCvmIDs []string                 `json:"failed_cvm_ids"`
+	CpuCore      int64                    `json:"cpu_core"`
+	Creator      string                   `json:"creator"`
+	CreatedAt    string                   `json:"created_at"`
+}
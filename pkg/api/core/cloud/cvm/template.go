@@ -0,0 +1,45 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table/types"
+)
+
+// Template define cvm template.
+type Template struct {
+	ID                    string          `json:"id"`
+	Name                  string          `json:"name"`
+	Vendor                enumor.Vendor   `json:"vendor"`
+	AccountID             string          `json:"account_id"`
+	Region                string          `json:"region"`
+	Zone                  string          `json:"zone"`
+	CloudImageID          string          `json:"cloud_image_id"`
+	InstanceType          string          `json:"instance_type"`
+	CloudSecurityGroupIDs []string        `json:"cloud_security_group_ids"`
+	SystemDisk            types.JsonField `json:"system_disk"`
+	DataDisk              types.JsonField `json:"data_disk"`
+	UserData              string          `json:"user_data"`
+	Extension             types.JsonField `json:"extension"`
+	Memo                  *string         `json:"memo"`
+	core.Revision         `json:",inline"`
+}
@@ -0,0 +1,37 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package zone
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// ZoneCapability define the instance families and features supported by region or zone.
+type ZoneCapability struct {
+	ID     string        `json:"id"`
+	Vendor enumor.Vendor `json:"vendor"`
+	Region string        `json:"region"`
+	// Zone 为空表示该能力是地域级别的，对地域下所有可用区生效
+	Zone             string               `json:"zone"`
+	InstanceFamilies []string             `json:"instance_families"`
+	Features         []enumor.ZoneFeature `json:"features"`
+	core.Revision    `json:",inline"`
+}
@@ -0,0 +1,66 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package compliance ...
+package compliance
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table/types"
+)
+
+// Finding 安全合规检测结果
+type Finding struct {
+	ID        string                    `json:"id"`
+	Rule      enumor.ComplianceRule     `json:"rule"`
+	Severity  enumor.ComplianceSeverity `json:"severity"`
+	ResType   enumor.CloudResourceType  `json:"res_type"`
+	ResID     string                    `json:"res_id"`
+	Vendor    enumor.Vendor             `json:"vendor"`
+	AccountID string                    `json:"account_id"`
+	BkBizID   int64                     `json:"bk_biz_id"`
+	Region    string                    `json:"region"`
+	ResName   string                    `json:"res_name"`
+	Detail    types.JsonField           `json:"detail"`
+	// ExemptionID 豁免ID，不为空时该检测结果已被豁免
+	ExemptionID   string `json:"exemption_id"`
+	DetectedAt    string `json:"detected_at"`
+	core.Revision `json:",inline"`
+}
+
+// FindingDetail 检测详情
+type FindingDetail struct {
+	// RuleIDs 安全组中允许任意来源访问管理端口的规则ID，仅 sg_open_admin_port 有效
+	RuleIDs []string `json:"rule_ids,omitempty"`
+	// Ports 安全组中对任意来源开放的管理端口，仅 sg_open_admin_port 有效
+	Ports []int64 `json:"ports,omitempty"`
+}
+
+// Exemption 安全合规豁免，豁免有效期内对应资源的检测结果标记为已豁免
+type Exemption struct {
+	ID      string                `json:"id"`
+	Rule    enumor.ComplianceRule `json:"rule"`
+	ResID   string                `json:"res_id"`
+	BkBizID int64                 `json:"bk_biz_id"`
+	Reason  string                `json:"reason"`
+	// ExpiredAt 豁免过期时间，为空时永久有效
+	ExpiredAt     string `json:"expired_at"`
+	core.Revision `json:",inline"`
+}
@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package idleresource ...
+package idleresource
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table/types"
+
+	"github.com/shopspring/decimal"
+)
+
+// IdleResource 闲置资源检测结果
+type IdleResource struct {
+	ID        string                   `json:"id"`
+	ResType   enumor.CloudResourceType `json:"res_type"`
+	ResID     string                   `json:"res_id"`
+	Vendor    enumor.Vendor            `json:"vendor"`
+	AccountID string                   `json:"account_id"`
+	BkBizID   int64                    `json:"bk_biz_id"`
+	Region    string                   `json:"region"`
+	ResName   string                   `json:"res_name"`
+	Reason    enumor.IdleReason        `json:"reason"`
+	Detail    types.JsonField          `json:"detail"`
+	// EstimatedMonthlyCost 根据配置的单价预估的每月费用
+	EstimatedMonthlyCost decimal.Decimal           `json:"estimated_monthly_cost"`
+	Currency             enumor.CurrencyCode       `json:"currency"`
+	Status               enumor.IdleResourceStatus `json:"status"`
+	DetectedAt           string                    `json:"detected_at"`
+	core.Revision        `json:",inline"`
+}
+
+// IdleDetail 闲置详情
+type IdleDetail struct {
+	// DiskSize 硬盘容量(GB)，仅未挂载硬盘有效
+	DiskSize int64 `json:"disk_size,omitempty"`
+	// CpuCore 主机vCPU核数，仅关机主机有效
+	CpuCore int64 `json:"cpu_core,omitempty"`
+}
@@ -0,0 +1,158 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package landingzone defines landing zone core types.
+package landingzone
+
+import (
+	"fmt"
+	"net"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// Deployment define landing zone deployment, one deployment provisions a network baseline (vpc, subnets, nat
+// gateway, route table and security groups) as a whole, the created resources are rolled back if any step failed.
+type Deployment struct {
+	ID            string                        `json:"id"`
+	Name          string                        `json:"name"`
+	Vendor        enumor.Vendor                 `json:"vendor"`
+	AccountID     string                        `json:"account_id"`
+	BkBizID       int64                         `json:"bk_biz_id"`
+	Region        string                        `json:"region"`
+	Spec          *DeploymentSpec               `json:"spec"`
+	Resources     *DeploymentResources          `json:"resources"`
+	State         enumor.LandingZoneDeployState `json:"state"`
+	Reason        string                        `json:"reason"`
+	FlowID        string                        `json:"flow_id"`
+	Memo          *string                       `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// DeploymentSpec define the resources to provision of landing zone deployment.
+type DeploymentSpec struct {
+	VpcName   string `json:"vpc_name" validate:"required,max=60"`
+	VpcCidr   string `json:"vpc_cidr" validate:"required,cidrv4"`
+	BkCloudID int64  `json:"bk_cloud_id" validate:"required"`
+	// Subnets 子网需要在VPC网段内且互不重叠
+	Subnets []SubnetSpec `json:"subnets" validate:"required,min=1,max=20,dive"`
+	// NatGateway 为空时不创建NAT网关，私有子网需要NAT网关提供公网出口
+	NatGateway     *NatGatewaySpec     `json:"nat_gateway" validate:"omitempty"`
+	SecurityGroups []SecurityGroupSpec `json:"security_groups" validate:"omitempty,max=10,dive"`
+}
+
+// Validate DeploymentSpec.
+func (s *DeploymentSpec) Validate() error {
+	if err := validator.Validate.Struct(s); err != nil {
+		return err
+	}
+
+	_, vpcNet, err := net.ParseCIDR(s.VpcCidr)
+	if err != nil {
+		return fmt.Errorf("vpc cidr %s is invalid", s.VpcCidr)
+	}
+
+	subnetNets := make([]*net.IPNet, 0, len(s.Subnets))
+	for _, subnet := range s.Subnets {
+		_, subnetNet, err := net.ParseCIDR(subnet.IPv4Cidr)
+		if err != nil {
+			return fmt.Errorf("subnet %s cidr %s is invalid", subnet.Name, subnet.IPv4Cidr)
+		}
+
+		if !containsNet(vpcNet, subnetNet) {
+			return fmt.Errorf("subnet %s cidr %s is not in vpc cidr %s", subnet.Name, subnet.IPv4Cidr, s.VpcCidr)
+		}
+
+		for _, exist := range subnetNets {
+			if exist.Contains(subnetNet.IP) || subnetNet.Contains(exist.IP) {
+				return fmt.Errorf("subnet %s cidr %s overlaps with cidr %s", subnet.Name, subnet.IPv4Cidr, exist)
+			}
+		}
+		subnetNets = append(subnetNets, subnetNet)
+
+		if subnet.Private && s.NatGateway == nil {
+			return fmt.Errorf("private subnet %s requires nat gateway", subnet.Name)
+		}
+	}
+
+	return nil
+}
+
+// containsNet return if the child network is entirely in the parent network.
+func containsNet(parent, child *net.IPNet) bool {
+	parentOnes, _ := parent.Mask.Size()
+	childOnes, _ := child.Mask.Size()
+	return parentOnes <= childOnes && parent.Contains(child.IP)
+}
+
+// SubnetSpec define subnet to provision.
+type SubnetSpec struct {
+	Name     string `json:"name" validate:"required,max=60"`
+	Zone     string `json:"zone" validate:"required"`
+	IPv4Cidr string `json:"ipv4_cidr" validate:"required,cidrv4"`
+	// Private 私有子网关联到以NAT网关为默认出口的路由表
+	Private bool `json:"private"`
+}
+
+// NatGatewaySpec define nat gateway to provision.
+type NatGatewaySpec struct {
+	Name                    string `json:"name" validate:"required,max=60"`
+	Zone                    string `json:"zone" validate:"omitempty"`
+	InternetMaxBandwidthOut uint64 `json:"internet_max_bandwidth_out" validate:"omitempty"`
+	MaxConcurrentConnection uint64 `json:"max_concurrent_connection" validate:"omitempty"`
+	// AddressCount 为NAT网关创建的弹性IP数量，回滚时一并释放
+	AddressCount uint64 `json:"address_count" validate:"required,min=1,max=50"`
+}
+
+// SecurityGroupSpec define security group to provision.
+type SecurityGroupSpec struct {
+	Name         string                  `json:"name" validate:"required,max=60"`
+	Memo         *string                 `json:"memo" validate:"omitempty,max=100"`
+	IngressRules []SecurityGroupRuleSpec `json:"ingress_rules" validate:"omitempty,max=100,dive"`
+	EgressRules  []SecurityGroupRuleSpec `json:"egress_rules" validate:"omitempty,max=100,dive"`
+}
+
+// SecurityGroupRuleSpec define security group rule to provision.
+type SecurityGroupRuleSpec struct {
+	Protocol string  `json:"protocol" validate:"required"`
+	Port     string  `json:"port" validate:"required"`
+	IPv4Cidr string  `json:"ipv4_cidr" validate:"required,cidrv4"`
+	Action   string  `json:"action" validate:"required,oneof=ACCEPT DROP"`
+	Memo     *string `json:"memo" validate:"omitempty,max=100"`
+}
+
+// DeploymentResources define the resources created by landing zone deployment.
+type DeploymentResources struct {
+	VpcID             string   `json:"vpc_id,omitempty"`
+	CloudVpcID        string   `json:"cloud_vpc_id,omitempty"`
+	CloudNatGatewayID string   `json:"cloud_nat_gateway_id,omitempty"`
+	CloudEipIDs       []string `json:"cloud_eip_ids,omitempty"`
+	RouteTableID      string   `json:"route_table_id,omitempty"`
+	CloudRouteTableID string   `json:"cloud_route_table_id,omitempty"`
+	SubnetIDs         []string `json:"subnet_ids,omitempty"`
+	SecurityGroupIDs  []string `json:"security_group_ids,omitempty"`
+}
+
+// IsEmpty return if no resource is left.
+func (r *DeploymentResources) IsEmpty() bool {
+	return len(r.VpcID) == 0 && len(r.CloudNatGatewayID) == 0 && len(r.CloudEipIDs) == 0 &&
+		len(r.RouteTableID) == 0 && len(r.SubnetIDs) == 0 && len(r.SecurityGroupIDs) == 0
+}
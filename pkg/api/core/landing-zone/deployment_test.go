@@ -0,0 +1,77 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package landingzone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeploymentSpecValidate(t *testing.T) {
+	nat := &NatGatewaySpec{Name: "nat", AddressCount: 1}
+
+	cases := []struct {
+		name    string
+		subnets []SubnetSpec
+		nat     *NatGatewaySpec
+		wantErr bool
+	}{
+		{
+			name: "valid subnets",
+			subnets: []SubnetSpec{
+				{Name: "public", Zone: "ap-guangzhou-3", IPv4Cidr: "10.0.0.0/24"},
+				{Name: "private", Zone: "ap-guangzhou-3", IPv4Cidr: "10.0.1.0/24", Private: true},
+			},
+			nat:     nat,
+			wantErr: false,
+		},
+		{
+			name:    "subnet out of vpc cidr",
+			subnets: []SubnetSpec{{Name: "public", Zone: "ap-guangzhou-3", IPv4Cidr: "192.168.0.0/24"}},
+			wantErr: true,
+		},
+		{
+			name:    "subnet larger than vpc cidr",
+			subnets: []SubnetSpec{{Name: "public", Zone: "ap-guangzhou-3", IPv4Cidr: "10.0.0.0/8"}},
+			wantErr: true,
+		},
+		{
+			name: "overlapped subnets",
+			subnets: []SubnetSpec{
+				{Name: "a", Zone: "ap-guangzhou-3", IPv4Cidr: "10.0.0.0/23"},
+				{Name: "b", Zone: "ap-guangzhou-3", IPv4Cidr: "10.0.1.0/24"},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "private subnet without nat gateway",
+			subnets: []SubnetSpec{{Name: "private", Zone: "ap-guangzhou-3", IPv4Cidr: "10.0.1.0/24", Private: true}},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		spec := &DeploymentSpec{VpcName: "vpc", VpcCidr: "10.0.0.0/16", BkCloudID: 1, Subnets: c.subnets,
+			NatGateway: c.nat}
+		err := spec.Validate()
+		assert.Equal(t, c.wantErr, err != nil, c.name)
+	}
+}
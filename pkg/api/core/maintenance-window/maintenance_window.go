@@ -0,0 +1,115 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package maintenancewindow defines maintenance window core types.
+package maintenancewindow
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/slice"
+)
+
+// MaintenanceWindow define biz maintenance window, the disruptive actions of the biz resources can only be executed
+// in one of the enabled maintenance windows of the biz which restrict the action.
+type MaintenanceWindow struct {
+	ID      string                     `json:"id"`
+	BkBizID int64                      `json:"bk_biz_id"`
+	Name    string                     `json:"name"`
+	Actions []enumor.MaintenanceAction `json:"actions"`
+	// Weekdays 窗口生效的星期，0表示星期日，跨天的窗口按开始时间所在的星期计算
+	Weekdays []int64 `json:"weekdays"`
+	// StartTime 窗口开始时间，格式HH:MM
+	StartTime string `json:"start_time"`
+	// EndTime 窗口结束时间，格式HH:MM，不晚于开始时间表示窗口跨天
+	EndTime       string  `json:"end_time"`
+	Timezone      string  `json:"timezone"`
+	Enabled       bool    `json:"enabled"`
+	Memo          *string `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// Restricts return if the action is restricted by the maintenance window.
+func (w *MaintenanceWindow) Restricts(action enumor.MaintenanceAction) bool {
+	return slice.IsItemInSlice(w.Actions, action)
+}
+
+// Contains return if the time is in the maintenance window.
+func (w *MaintenanceWindow) Contains(t time.Time) (bool, error) {
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return false, fmt.Errorf("load timezone %s failed, err: %v", w.Timezone, err)
+	}
+
+	start, err := ParseClock(w.StartTime)
+	if err != nil {
+		return false, err
+	}
+
+	end, err := ParseClock(w.EndTime)
+	if err != nil {
+		return false, err
+	}
+
+	t = t.In(loc)
+	now := t.Hour()*60 + t.Minute()
+	weekday := int64(t.Weekday())
+	if start < end {
+		return now >= start && now < end && slice.IsItemInSlice(w.Weekdays, weekday), nil
+	}
+
+	// 跨天的窗口，开始时间之后属于当天的窗口，结束时间之前属于前一天的窗口
+	if now >= start {
+		return slice.IsItemInSlice(w.Weekdays, weekday), nil
+	}
+	if now < end {
+		return slice.IsItemInSlice(w.Weekdays, (weekday+6)%7), nil
+	}
+
+	return false, nil
+}
+
+// ParseClock parse clock time in HH:MM format, return the minutes since 00:00.
+func ParseClock(clock string) (int, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("time %s should be in HH:MM format", clock)
+	}
+
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// ValidateWeekdays validate maintenance window weekdays.
+func ValidateWeekdays(weekdays []int64) error {
+	if len(weekdays) == 0 {
+		return errors.New("weekdays is required")
+	}
+
+	for _, one := range weekdays {
+		if one < 0 || one > 6 {
+			return fmt.Errorf("weekday %d should be in [0, 6]", one)
+		}
+	}
+
+	return nil
+}
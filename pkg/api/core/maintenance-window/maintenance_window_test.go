@@ -0,0 +1,101 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package maintenancewindow
+
+import (
+	"testing"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenanceWindowContains(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	assert.NoError(t, err)
+
+	// 2025-06-09 is monday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, 6, day, hour, minute, 0, 0, loc)
+	}
+
+	cases := []struct {
+		name   string
+		window MaintenanceWindow
+		t      time.Time
+		want   bool
+	}{
+		{
+			name:   "in same day window",
+			window: MaintenanceWindow{Weekdays: []int64{1}, StartTime: "02:00", EndTime: "04:00"},
+			t:      at(9, 3, 0),
+			want:   true,
+		},
+		{
+			name:   "end time is excluded",
+			window: MaintenanceWindow{Weekdays: []int64{1}, StartTime: "02:00", EndTime: "04:00"},
+			t:      at(9, 4, 0),
+			want:   false,
+		},
+		{
+			name:   "weekday not matched",
+			window: MaintenanceWindow{Weekdays: []int64{2}, StartTime: "02:00", EndTime: "04:00"},
+			t:      at(9, 3, 0),
+			want:   false,
+		},
+		{
+			name:   "cross day window before midnight",
+			window: MaintenanceWindow{Weekdays: []int64{0}, StartTime: "22:00", EndTime: "02:00"},
+			t:      at(8, 23, 0),
+			want:   true,
+		},
+		{
+			name:   "cross day window after midnight belongs to previous day",
+			window: MaintenanceWindow{Weekdays: []int64{0}, StartTime: "22:00", EndTime: "02:00"},
+			t:      at(9, 1, 0),
+			want:   true,
+		},
+		{
+			name:   "cross day window after midnight of start day",
+			window: MaintenanceWindow{Weekdays: []int64{0}, StartTime: "22:00", EndTime: "02:00"},
+			t:      at(8, 1, 0),
+			want:   false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			c.window.Timezone = "Asia/Shanghai"
+			got, err := c.window.Contains(c.t)
+			assert.NoError(t, err)
+			assert.Equal(t, c.want, got)
+		})
+	}
+
+	// the time is converted to the timezone of the window
+	window := MaintenanceWindow{Weekdays: []int64{1}, StartTime: "02:00", EndTime: "04:00", Timezone: "Asia/Shanghai",
+		Actions: []enumor.MaintenanceAction{enumor.MaintenanceRebootCvm}}
+	got, err := window.Contains(time.Date(2025, 6, 8, 19, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.True(t, got)
+	assert.True(t, window.Restricts(enumor.MaintenanceRebootCvm))
+	assert.False(t, window.Restricts(enumor.MaintenanceStopCvm))
+}
@@ -0,0 +1,101 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package naming defines resource naming rule core types.
+package naming
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+)
+
+// NamingRule define resource naming rule, the name should start with the prefix and match the pattern if set.
+type NamingRule struct {
+	ID string `json:"id"`
+	// BkBizID 业务ID，-1表示未单独配置规则的业务使用的全局默认规则
+	BkBizID       int64                    `json:"bk_biz_id"`
+	ResType       enumor.CloudResourceType `json:"res_type"`
+	Prefix        string                   `json:"prefix"`
+	Pattern       string                   `json:"pattern"`
+	Memo          *string                  `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// Check whether the name conforms to the naming rule, returns the reason if not.
+func (r NamingRule) Check(name string) error {
+	if len(r.Prefix) != 0 && !strings.HasPrefix(name, r.Prefix) {
+		return fmt.Errorf("name %s should start with %s", name, r.Prefix)
+	}
+
+	if len(r.Pattern) == 0 {
+		return nil
+	}
+
+	matched, err := regexp.MatchString(r.Pattern, name)
+	if err != nil {
+		return fmt.Errorf("naming rule %s pattern is invalid, err: %v", r.ID, err)
+	}
+
+	if !matched {
+		return fmt.Errorf("name %s should match pattern %s", name, r.Pattern)
+	}
+
+	return nil
+}
+
+// MatchRule 获取业务下该资源类型生效的命名规则，业务未单独配置时使用全局默认规则，均未配置时返回nil
+func MatchRule(rules []NamingRule, bizID int64, resType enumor.CloudResourceType) *NamingRule {
+	var defaultRule *NamingRule
+	for idx := range rules {
+		if rules[idx].ResType != resType {
+			continue
+		}
+
+		if rules[idx].BkBizID == bizID {
+			return &rules[idx]
+		}
+
+		if rules[idx].BkBizID == constant.UnassignedBiz {
+			defaultRule = &rules[idx]
+		}
+	}
+
+	return defaultRule
+}
+
+// NamingViolation define resource that does not conform to the naming rule.
+type NamingViolation struct {
+	ID         string                   `json:"id"`
+	ResType    enumor.CloudResourceType `json:"res_type"`
+	ResID      string                   `json:"res_id"`
+	Vendor     enumor.Vendor            `json:"vendor"`
+	AccountID  string                   `json:"account_id"`
+	BkBizID    int64                    `json:"bk_biz_id"`
+	ResName    string                   `json:"res_name"`
+	RuleID     string                   `json:"rule_id"`
+	Reason     string                   `json:"reason"`
+	DetectedAt string                   `json:"detected_at"`
+	CreatedAt  string                   `json:"created_at"`
+	UpdatedAt  string                   `json:"updated_at"`
+}
@@ -0,0 +1,82 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package naming
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestNamingRuleCheck(t *testing.T) {
+	rule := NamingRule{ID: "rule1", Prefix: "prod-", Pattern: `^prod-[a-z]+-\d{2}$`}
+
+	if err := rule.Check("prod-web-01"); err != nil {
+		t.Errorf("name conforms to the naming rule should pass, err: %v", err)
+	}
+
+	for _, name := range []string{"test-web-01", "prod-web-1", "prod-WEB-01"} {
+		if err := rule.Check(name); err == nil {
+			t.Errorf("name %s does not conform to the naming rule should fail", name)
+		}
+	}
+
+	if err := (NamingRule{Prefix: "prod-"}).Check("prod-anything"); err != nil {
+		t.Errorf("rule with only prefix should not check pattern, err: %v", err)
+	}
+
+	if err := (NamingRule{ID: "rule2", Pattern: "(["}).Check("prod"); err == nil {
+		t.Errorf("rule with invalid pattern should fail")
+	}
+}
+
+func TestMatchRule(t *testing.T) {
+	rules := []NamingRule{
+		{ID: "default-cvm", BkBizID: constant.UnassignedBiz, ResType: enumor.CvmCloudResType},
+		{ID: "biz1-cvm", BkBizID: 1, ResType: enumor.CvmCloudResType},
+		{ID: "biz1-disk", BkBizID: 1, ResType: enumor.DiskCloudResType},
+	}
+
+	cases := []struct {
+		bizID   int64
+		resType enumor.CloudResourceType
+		expect  string
+	}{
+		{bizID: 1, resType: enumor.CvmCloudResType, expect: "biz1-cvm"},
+		{bizID: 2, resType: enumor.CvmCloudResType, expect: "default-cvm"},
+		{bizID: 1, resType: enumor.DiskCloudResType, expect: "biz1-disk"},
+		{bizID: 2, resType: enumor.DiskCloudResType},
+		{bizID: 1, resType: enumor.VpcCloudResType},
+	}
+	for _, c := range cases {
+		rule := MatchRule(rules, c.bizID, c.resType)
+		if len(c.expect) == 0 {
+			if rule != nil {
+				t.Errorf("biz %d %s should match no rule, but got %s", c.bizID, c.resType, rule.ID)
+			}
+			continue
+		}
+
+		if rule == nil || rule.ID != c.expect {
+			t.Errorf("biz %d %s should match rule %s, but got %+v", c.bizID, c.resType, c.expect, rule)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package notification defines notification core types.
+package notification
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// Template define notification template, title and content are rendered by go text/template with event data.
+type Template struct {
+	ID            string                       `json:"id"`
+	Name          string                       `json:"name"`
+	EventType     enumor.NotificationEventType `json:"event_type"`
+	Channel       enumor.NotificationChannel   `json:"channel"`
+	Title         string                       `json:"title"`
+	Content       string                       `json:"content"`
+	Memo          *string                      `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// Subscription define notification subscription, which decides who will be notified by which channels
+// when an event occurs.
+type Subscription struct {
+	ID        string                       `json:"id"`
+	Name      string                       `json:"name"`
+	EventType enumor.NotificationEventType `json:"event_type"`
+	Channels  []enumor.NotificationChannel `json:"channels"`
+	Receivers []string                     `json:"receivers"`
+	// NotifyRelated 是否同时通知事件相关人员，如单据申请人
+	NotifyRelated bool   `json:"notify_related"`
+	WebhookUrl    string `json:"webhook_url"`
+	// TemplateIDs 使用的通知模板，每个渠道最多一个，未配置的渠道使用默认模板
+	TemplateIDs []string `json:"template_ids"`
+	// AccountIDs 订阅范围，为空表示不限制账号
+	AccountIDs []string `json:"account_ids"`
+	// BkBizIDs 订阅范围，为空表示不限制业务
+	BkBizIDs      []int64 `json:"bk_biz_ids"`
+	Enabled       bool    `json:"enabled"`
+	Memo          *string `json:"memo"`
+	core.Revision `json:",inline"`
+}
@@ -134,6 +134,26 @@ func (sd Order) Order() Order {
 	}
 }
 
+// MaxSortFieldsLimit is the max number of the multi-column sort fields.
+const MaxSortFieldsLimit = 5
+
+// SortField defines one sort column and its direction in multi-column sorting.
+type SortField struct {
+	// Field is the sorted column.
+	Field string `json:"field"`
+	// Order is the sort direction of the Field, if not set, use ascending.
+	Order Order `json:"order"`
+}
+
+// Validate the sort field.
+func (sf SortField) Validate() error {
+	if len(sf.Field) == 0 {
+		return errors.New("sort field is required")
+	}
+
+	return sf.Order.Validate()
+}
+
 // BasePage define the basic page limitation to query resources.
 type BasePage struct {
 	// Count describe if this query only return the total request
@@ -142,6 +162,11 @@ type BasePage struct {
 	// without the resource's detail infos. and start, limit must
 	// be 0.
 	Count bool `json:"count"`
+	// WithCount describe if this query return the total count of the
+	// resources along with the details of the current page, so that
+	// the caller does not need another count request.
+	// Note: WithCount can not be used together with Count.
+	WithCount bool `json:"with_count"`
 	// Start is the start position of the queried resource's page.
 	// Note:
 	// 1. Start only works when the Count = false.
@@ -159,6 +184,9 @@ type BasePage struct {
 	// Order is the direction when do sort operation.
 	// it works only when the Sort is set.
 	Order Order `json:"order"`
+	// Sorts defines multi-column sort, the resources are sorted by the fields in order.
+	// Note: Sorts can not be used together with Sort.
+	Sorts []SortField `json:"sorts,omitempty"`
 }
 
 // Validate the base page's options.
@@ -174,6 +202,10 @@ func (bp BasePage) Validate(opt ...*PageOption) (err error) {
 		return errors.New("at most one page options is allows")
 	}
 
+	if bp.Count && bp.WithCount {
+		return errors.New("page.count and page.with_count can not be both true")
+	}
+
 	if bp.Count {
 		if bp.Start > 0 {
 			return errors.New("count is enabled, page.start should be 0")
@@ -191,6 +223,10 @@ func (bp BasePage) Validate(opt ...*PageOption) (err error) {
 			return errors.New("count is enabled, page.order should be empty")
 		}
 
+		if len(bp.Sorts) > 0 {
+			return errors.New("count is enabled, page.sorts should be empty")
+		}
+
 		return nil
 	}
 
@@ -213,6 +249,10 @@ func (bp BasePage) Validate(opt ...*PageOption) (err error) {
 			if len(bp.Order) > 0 {
 				return errors.New("invalid page.order, page.order is not allowed")
 			}
+
+			if len(bp.Sorts) > 0 {
+				return errors.New("page.sorts is not allowed")
+			}
 		}
 	}
 
@@ -241,5 +281,21 @@ func (bp BasePage) Validate(opt ...*PageOption) (err error) {
 		}
 	}
 
+	if len(bp.Sorts) != 0 {
+		if len(bp.Sort) != 0 || len(bp.Order) != 0 {
+			return errors.New("page.sorts can not be used together with page.sort or page.order")
+		}
+
+		if len(bp.Sorts) > MaxSortFieldsLimit {
+			return fmt.Errorf("page.sorts length should <= %d", MaxSortFieldsLimit)
+		}
+
+		for _, one := range bp.Sorts {
+			if err := one.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
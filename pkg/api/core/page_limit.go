@@ -0,0 +1,67 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package core
+
+import (
+	"sync"
+
+	"hcm/pkg/dal/table"
+)
+
+// tablePageLimits the max page limits of tables, which overrides the DefaultMaxPageLimit, so that the tables
+// with small rows can be paged larger, and the tables with huge rows(e.g. cvm with big extension) smaller.
+var tablePageLimits = struct {
+	sync.RWMutex
+	limits map[table.Name]uint
+}{limits: make(map[table.Name]uint)}
+
+// SetTablePageLimits set the max page limits of tables, it should be called on the service startup.
+func SetTablePageLimits(limits map[table.Name]uint) {
+	tablePageLimits.Lock()
+	defer tablePageLimits.Unlock()
+
+	tablePageLimits.limits = make(map[table.Name]uint, len(limits))
+	for name, limit := range limits {
+		if limit == 0 {
+			continue
+		}
+		tablePageLimits.limits[name] = limit
+	}
+}
+
+// GetTableMaxPageLimit get the max page limit of the table, DefaultMaxPageLimit is returned if not configured.
+func GetTableMaxPageLimit(name table.Name) uint {
+	tablePageLimits.RLock()
+	defer tablePageLimits.RUnlock()
+
+	if limit, exists := tablePageLimits.limits[name]; exists {
+		return limit
+	}
+
+	return DefaultMaxPageLimit
+}
+
+// NewTablePageOption define the default page option of the table, whose max limit is the max page limit
+// of the table.
+func NewTablePageOption(name table.Name) *PageOption {
+	opt := NewDefaultPageOption()
+	opt.MaxLimit = GetTableMaxPageLimit(name)
+	return opt
+}
@@ -0,0 +1,65 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package core
+
+import (
+	"testing"
+
+	"hcm/pkg/dal/table"
+)
+
+func TestTablePageLimit(t *testing.T) {
+	SetTablePageLimits(map[table.Name]uint{
+		table.TCloudSecurityGroupRuleTable: 1000,
+		table.CvmTable:                     100,
+		table.VpcTable:                     0,
+	})
+	defer SetTablePageLimits(nil)
+
+	cases := map[table.Name]uint{
+		table.TCloudSecurityGroupRuleTable: 1000,
+		table.CvmTable:                     100,
+		// zero limit is ignored, and the table not configured uses the default limit.
+		table.VpcTable:    DefaultMaxPageLimit,
+		table.SubnetTable: DefaultMaxPageLimit,
+	}
+	for name, expect := range cases {
+		if limit := GetTableMaxPageLimit(name); limit != expect {
+			t.Errorf("max page limit of %s should be %d, got: %d", name, expect, limit)
+		}
+	}
+
+	page := BasePage{Start: 0, Limit: 800}
+	if err := page.Validate(NewTablePageOption(table.TCloudSecurityGroupRuleTable)); err != nil {
+		t.Errorf("page limit under the table max limit should be allowed, err: %v", err)
+	}
+	if err := page.Validate(NewTablePageOption(table.SubnetTable)); err == nil {
+		t.Errorf("page limit over the default max limit should be rejected")
+	}
+	if err := (BasePage{Limit: 200}).Validate(NewTablePageOption(table.CvmTable)); err == nil {
+		t.Errorf("page limit over the table max limit should be rejected")
+	}
+
+	// the limits are replaced as a whole.
+	SetTablePageLimits(map[table.Name]uint{table.SubnetTable: 800})
+	if GetTableMaxPageLimit(table.CvmTable) != DefaultMaxPageLimit || GetTableMaxPageLimit(table.SubnetTable) != 800 {
+		t.Errorf("table page limits should be reset")
+	}
+}
@@ -0,0 +1,42 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package quota defines biz resource quota core types.
+package quota
+
+import "hcm/pkg/api/core"
+
+// BizQuota define biz resource quota, quota value -1 means unlimited.
+type BizQuota struct {
+	ID            string  `json:"id"`
+	BkBizID       int64   `json:"bk_biz_id"`
+	MaxCvm        int64   `json:"max_cvm"`
+	MaxCpuCore    int64   `json:"max_cpu_core"`
+	MaxEip        int64   `json:"max_eip"`
+	Memo          *string `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// BizResUsage define biz resource usage counted from inventory.
+type BizResUsage struct {
+	BkBizID  int64 `json:"bk_biz_id"`
+	CvmCount int64 `json:"cvm_count"`
+	CpuCore  int64 `json:"cpu_core"`
+	EipCount int64 `json:"eip_count"`
+}
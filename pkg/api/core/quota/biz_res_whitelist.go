@@ -0,0 +1,93 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package quota
+
+import (
+	"strings"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// BizResWhitelist define the regions, instance families and images which a biz may use when applying for cvm,
+// empty list means unlimited.
+type BizResWhitelist struct {
+	ID               string        `json:"id"`
+	BkBizID          int64         `json:"bk_biz_id"`
+	Vendor           enumor.Vendor `json:"vendor"`
+	Regions          []string      `json:"regions"`
+	InstanceFamilies []string      `json:"instance_families"`
+	CloudImageIDs    []string      `json:"cloud_image_ids"`
+	Memo             *string       `json:"memo"`
+	core.Revision    `json:",inline"`
+}
+
+// AllowRegion return if the region is allowed by the whitelist.
+func (w *BizResWhitelist) AllowRegion(region string) bool {
+	return len(w.Regions) == 0 || contains(w.Regions, region)
+}
+
+// AllowImage return if the cloud image is allowed by the whitelist.
+func (w *BizResWhitelist) AllowImage(cloudImageID string) bool {
+	return len(w.CloudImageIDs) == 0 || contains(w.CloudImageIDs, cloudImageID)
+}
+
+// AllowInstanceType return if the instance type belongs to one of the allowed instance families.
+func (w *BizResWhitelist) AllowInstanceType(instanceType string) bool {
+	if len(w.InstanceFamilies) == 0 {
+		return true
+	}
+
+	for _, family := range w.InstanceFamilies {
+		if MatchInstanceFamily(family, instanceType) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchInstanceFamily return if the instance type belongs to the instance family. the family can be an instance
+// type itself, or the prefix of instance types separated by '.', '-' or '_', such as S5 of S5.MEDIUM2 (tcloud),
+// t3 of t3.micro (aws), n2 of n2-standard-2 (gcp), Standard_D2s of Standard_D2s_v3 (azure).
+func MatchInstanceFamily(family, instanceType string) bool {
+	if len(family) == 0 || !strings.HasPrefix(instanceType, family) {
+		return false
+	}
+
+	if len(instanceType) == len(family) {
+		return true
+	}
+
+	switch instanceType[len(family)] {
+	case '.', '-', '_':
+		return true
+	default:
+		return false
+	}
+}
+
+func contains(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
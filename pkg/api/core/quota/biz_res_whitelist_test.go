@@ -0,0 +1,67 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package quota
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchInstanceFamily(t *testing.T) {
+	cases := []struct {
+		family       string
+		instanceType string
+		expected     bool
+	}{
+		{family: "S5", instanceType: "S5.MEDIUM2", expected: true},
+		{family: "S5", instanceType: "S5se.MEDIUM2", expected: false},
+		{family: "S5.MEDIUM2", instanceType: "S5.MEDIUM2", expected: true},
+		{family: "t3", instanceType: "t3.micro", expected: true},
+		{family: "t3", instanceType: "t3a.micro", expected: false},
+		{family: "n2", instanceType: "n2-standard-2", expected: true},
+		{family: "Standard_D2s", instanceType: "Standard_D2s_v3", expected: true},
+		{family: "", instanceType: "S5.MEDIUM2", expected: false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, MatchInstanceFamily(c.family, c.instanceType), "%s of %s", c.family,
+			c.instanceType)
+	}
+}
+
+func TestBizResWhitelistAllow(t *testing.T) {
+	unlimited := &BizResWhitelist{}
+	assert.True(t, unlimited.AllowRegion("ap-guangzhou"))
+	assert.True(t, unlimited.AllowInstanceType("S5.MEDIUM2"))
+	assert.True(t, unlimited.AllowImage("img-xxx"))
+
+	whitelist := &BizResWhitelist{
+		Regions:          []string{"ap-guangzhou"},
+		InstanceFamilies: []string{"S5", "SA2"},
+		CloudImageIDs:    []string{"img-xxx"},
+	}
+	assert.True(t, whitelist.AllowRegion("ap-guangzhou"))
+	assert.False(t, whitelist.AllowRegion("ap-shanghai"))
+	assert.True(t, whitelist.AllowInstanceType("SA2.LARGE8"))
+	assert.False(t, whitelist.AllowInstanceType("M5.LARGE8"))
+	assert.True(t, whitelist.AllowImage("img-xxx"))
+	assert.False(t, whitelist.AllowImage("img-yyy"))
+}
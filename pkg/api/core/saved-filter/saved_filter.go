@@ -0,0 +1,41 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package savedfilter defines saved filter core types.
+package savedfilter
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/runtime/filter"
+)
+
+// SavedFilter define saved filter, which is a named filter expression of resource list that can be reused by
+// its creator or shared with the users of a biz.
+type SavedFilter struct {
+	ID      string                   `json:"id"`
+	Name    string                   `json:"name"`
+	ResType enumor.CloudResourceType `json:"res_type"`
+	Scope   enumor.SavedFilterScope  `json:"scope"`
+	// BkBizID 共享的业务ID，仅本人可见时为-1
+	BkBizID       int64              `json:"bk_biz_id"`
+	Filter        *filter.Expression `json:"filter"`
+	Memo          *string            `json:"memo"`
+	core.Revision `json:",inline"`
+}
@@ -0,0 +1,127 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tagpolicy defines tag policy core types.
+package tagpolicy
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+)
+
+// TagPolicy define tag policy, the resource must have the tag key, and the value should be one of the allowed values
+// if allowed values is not empty.
+type TagPolicy struct {
+	ID string `json:"id"`
+	// BkBizID 业务ID，-1表示所有业务均需遵循的全局默认策略
+	BkBizID       int64                    `json:"bk_biz_id"`
+	ResType       enumor.CloudResourceType `json:"res_type"`
+	TagKey        string                   `json:"tag_key"`
+	AllowedValues []string                 `json:"allowed_values"`
+	Memo          *string                  `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// Check whether the tags conform to the tag policy, returns the violation reason and current tag value if not.
+func (p TagPolicy) Check(tags map[string]string) (enumor.TagViolationReason, string, bool) {
+	value, exists := tags[p.TagKey]
+	if !exists || len(value) == 0 {
+		return enumor.TagMissing, "", false
+	}
+
+	if len(p.AllowedValues) == 0 {
+		return "", value, true
+	}
+
+	for _, allowed := range p.AllowedValues {
+		if allowed == value {
+			return "", value, true
+		}
+	}
+
+	return enumor.TagMistagged, value, false
+}
+
+// MatchPolicies 获取业务下该资源类型生效的标签策略，同一标签键业务单独配置的策略优先于全局默认策略
+func MatchPolicies(policies []TagPolicy, bizID int64, resType enumor.CloudResourceType) []TagPolicy {
+	bizPolicies := make(map[string]TagPolicy)
+	defaultPolicies := make(map[string]TagPolicy)
+	for _, one := range policies {
+		if one.ResType != resType {
+			continue
+		}
+
+		switch one.BkBizID {
+		case bizID:
+			bizPolicies[one.TagKey] = one
+		case constant.UnassignedBiz:
+			defaultPolicies[one.TagKey] = one
+		}
+	}
+
+	for key, one := range defaultPolicies {
+		if _, exists := bizPolicies[key]; !exists {
+			bizPolicies[key] = one
+		}
+	}
+
+	matched := make([]TagPolicy, 0, len(bizPolicies))
+	for _, one := range bizPolicies {
+		matched = append(matched, one)
+	}
+
+	return matched
+}
+
+// TagViolation define resource that does not conform to the tag policy.
+type TagViolation struct {
+	ID         string                    `json:"id"`
+	ResType    enumor.CloudResourceType  `json:"res_type"`
+	ResID      string                    `json:"res_id"`
+	Vendor     enumor.Vendor             `json:"vendor"`
+	AccountID  string                    `json:"account_id"`
+	BkBizID    int64                     `json:"bk_biz_id"`
+	ResName    string                    `json:"res_name"`
+	PolicyID   string                    `json:"policy_id"`
+	TagKey     string                    `json:"tag_key"`
+	TagValue   string                    `json:"tag_value"`
+	Reason     enumor.TagViolationReason `json:"reason"`
+	DetectedAt string                    `json:"detected_at"`
+	CreatedAt  string                    `json:"created_at"`
+	UpdatedAt  string                    `json:"updated_at"`
+}
+
+// AccountCompliance 账号下资源的标签合规情况
+type AccountCompliance struct {
+	AccountID string `json:"account_id"`
+	// MissingCount 缺少必填标签的资源数
+	MissingCount uint64 `json:"missing_count"`
+	// MistaggedCount 标签值不合规的资源数
+	MistaggedCount uint64 `json:"mistagged_count"`
+	// ResTypes 按资源类型统计的不合规资源数
+	ResTypes []ResTypeCompliance `json:"res_types"`
+}
+
+// ResTypeCompliance 资源类型维度的标签合规情况
+type ResTypeCompliance struct {
+	ResType        enumor.CloudResourceType `json:"res_type"`
+	MissingCount   uint64                   `json:"missing_count"`
+	MistaggedCount uint64                   `json:"mistagged_count"`
+}
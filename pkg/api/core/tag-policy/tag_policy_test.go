@@ -0,0 +1,85 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tagpolicy
+
+import (
+	"sort"
+	"testing"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestTagPolicyCheck(t *testing.T) {
+	policy := TagPolicy{TagKey: "env", AllowedValues: []string{"prod", "test"}}
+
+	cases := []struct {
+		tags         map[string]string
+		expectReason enumor.TagViolationReason
+		expectValue  string
+		expectOK     bool
+	}{
+		{tags: map[string]string{"env": "prod"}, expectValue: "prod", expectOK: true},
+		{tags: map[string]string{"owner": "admin"}, expectReason: enumor.TagMissing},
+		{tags: map[string]string{"env": ""}, expectReason: enumor.TagMissing},
+		{tags: map[string]string{"env": "dev"}, expectReason: enumor.TagMistagged, expectValue: "dev"},
+	}
+	for _, c := range cases {
+		reason, value, ok := policy.Check(c.tags)
+		if reason != c.expectReason || value != c.expectValue || ok != c.expectOK {
+			t.Errorf("tags %v: expect (%s, %s, %v), but got (%s, %s, %v)", c.tags, c.expectReason, c.expectValue,
+				c.expectOK, reason, value, ok)
+		}
+	}
+
+	if _, _, ok := (TagPolicy{TagKey: "env"}).Check(map[string]string{"env": "any"}); !ok {
+		t.Errorf("policy without allowed values should accept any non empty value")
+	}
+}
+
+func TestMatchPolicies(t *testing.T) {
+	policies := []TagPolicy{
+		{ID: "default-env", BkBizID: constant.UnassignedBiz, ResType: enumor.SecurityGroupCloudResType, TagKey: "env"},
+		{ID: "default-owner", BkBizID: constant.UnassignedBiz, ResType: enumor.SecurityGroupCloudResType,
+			TagKey: "owner"},
+		{ID: "biz1-env", BkBizID: 1, ResType: enumor.SecurityGroupCloudResType, TagKey: "env"},
+		{ID: "biz2-env", BkBizID: 2, ResType: enumor.SecurityGroupCloudResType, TagKey: "env"},
+		{ID: "default-lb", BkBizID: constant.UnassignedBiz, ResType: enumor.LoadBalancerCloudResType, TagKey: "env"},
+	}
+
+	cases := []struct {
+		bizID  int64
+		expect []string
+	}{
+		// 业务单独配置的策略覆盖同一标签键的全局默认策略
+		{bizID: 1, expect: []string{"biz1-env", "default-owner"}},
+		{bizID: 3, expect: []string{"default-env", "default-owner"}},
+	}
+	for _, c := range cases {
+		ids := make([]string, 0)
+		for _, one := range MatchPolicies(policies, c.bizID, enumor.SecurityGroupCloudResType) {
+			ids = append(ids, one.ID)
+		}
+		sort.Strings(ids)
+		if len(ids) != len(c.expect) || ids[0] != c.expect[0] || ids[1] != c.expect[1] {
+			t.Errorf("biz %d should match policies %v, but got %v", c.bizID, c.expect, ids)
+		}
+	}
+}
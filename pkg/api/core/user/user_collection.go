@@ -32,3 +32,13 @@ type UserCollection struct {
 	ResID                string                       `json:"res_id"`
 	core.CreatedRevision `json:",inline"`
 }
+
+// UserRecentView define user recent view.
+type UserRecentView struct {
+	ID      string                       `json:"id"`
+	User    string                       `json:"user"`
+	ResType enumor.UserCollectionResType `json:"res_type"`
+	ResID   string                       `json:"res_id"`
+	// ViewedAt 最近访问时间
+	ViewedAt string `json:"viewed_at"`
+}
@@ -0,0 +1,82 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package webhook defines webhook subscription and delivery core struct.
+package webhook
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/cdc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/slice"
+)
+
+// Subscription define webhook subscription, the resource change events matched by the subscription are delivered
+// to the url with a hmac signature computed by the secret.
+type Subscription struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Url  string `json:"url"`
+	// Secret 加密后的签名密钥，只在服务内部使用，不对外返回
+	Secret   string                     `json:"secret,omitempty"`
+	ResTypes []enumor.AuditResourceType `json:"res_types"`
+	// Actions 订阅的操作类型，为空表示不限制
+	Actions []enumor.AuditAction `json:"actions"`
+	// BkBizIDs 订阅的业务范围，为空表示不限制
+	BkBizIDs      []int64 `json:"bk_biz_ids"`
+	Enabled       bool    `json:"enabled"`
+	Memo          *string `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// Match 事件是否满足订阅的过滤条件
+func (s *Subscription) Match(event *cdc.Event) bool {
+	if !slice.IsItemInSlice(s.ResTypes, event.ResType) {
+		return false
+	}
+
+	if len(s.Actions) > 0 && !slice.IsItemInSlice(s.Actions, event.Action) {
+		return false
+	}
+
+	if len(s.BkBizIDs) > 0 && !slice.IsItemInSlice(s.BkBizIDs, event.BkBizID) {
+		return false
+	}
+
+	return true
+}
+
+// Delivery define the delivery of an event to a webhook subscription.
+type Delivery struct {
+	ID             string                      `json:"id"`
+	SubscriptionID string                      `json:"subscription_id"`
+	EventOffset    uint64                      `json:"event_offset"`
+	ResType        enumor.AuditResourceType    `json:"res_type"`
+	ResID          string                      `json:"res_id"`
+	Action         enumor.AuditAction          `json:"action"`
+	Payload        *cdc.Event                  `json:"payload"`
+	State          enumor.WebhookDeliveryState `json:"state"`
+	Attempts       uint                        `json:"attempts"`
+	// StatusCode 最近一次投递的http响应码，请求未得到响应时为0
+	StatusCode int `json:"status_code"`
+	// Reason 最近一次投递失败的原因
+	Reason        string `json:"reason"`
+	NextRetryAt   string `json:"next_retry_at"`
+	core.Revision `json:",inline"`
+}
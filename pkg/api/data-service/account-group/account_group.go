@@ -0,0 +1,103 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package accountgroup defines account group data-service api.
+package accountgroup
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// AccountGroupCreateReq account group create request.
+type AccountGroupCreateReq struct {
+	Name     string  `json:"name" validate:"required,max=255"`
+	ParentID string  `json:"parent_id" validate:"omitempty,max=64"`
+	Memo     *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate AccountGroupCreateReq.
+func (req *AccountGroupCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// AccountGroupUpdateReq account group update request.
+type AccountGroupUpdateReq struct {
+	Name *string `json:"name" validate:"omitempty,min=1,max=255"`
+	// ParentID 父账号组ID，传空字符串表示调整为顶层账号组
+	ParentID *string `json:"parent_id" validate:"omitempty,max=64"`
+	Memo     *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate AccountGroupUpdateReq.
+func (req *AccountGroupUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.Name == nil && req.ParentID == nil && req.Memo == nil {
+		return errors.New("at least one field should be updated")
+	}
+
+	return nil
+}
+
+// AccountGroupMember define account group member.
+type AccountGroupMember struct {
+	AccountType enumor.AccountGroupMemberType `json:"account_type" validate:"required"`
+	AccountID   string                        `json:"account_id" validate:"required,max=64"`
+}
+
+// Validate AccountGroupMember.
+func (m AccountGroupMember) Validate() error {
+	if err := validator.Validate.Struct(m); err != nil {
+		return err
+	}
+
+	return m.AccountType.Validate()
+}
+
+// AccountGroupMemberReq add or remove account group members request.
+type AccountGroupMemberReq struct {
+	GroupID string               `json:"group_id" validate:"required"`
+	Members []AccountGroupMember `json:"members" validate:"required,min=1"`
+}
+
+// Validate AccountGroupMemberReq.
+func (req *AccountGroupMemberReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Members) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("members should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	for _, one := range req.Members {
+		if err := one.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
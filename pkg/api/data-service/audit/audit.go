@@ -141,6 +141,8 @@ func (o *OperationAction) ConvAuditAction() (enumor.AuditAction, error) {
 		return enumor.Associate, nil
 	case Disassociate:
 		return enumor.Disassociate, nil
+	case AccessSecret:
+		return enumor.AccessSecret, nil
 
 	default:
 		return "", fmt.Errorf("action is not corresponding audit action")
@@ -157,6 +159,8 @@ const (
 	Associate OperationAction = "associate"
 	// Disassociate 解绑、解挂载等操作
 	Disassociate OperationAction = "disassociate"
+	// AccessSecret 从外部凭据管理获取账号秘钥
+	AccessSecret OperationAction = "access_secret"
 )
 
 // CloudResourceOperationAuditReq define cloud resource operation audit req.
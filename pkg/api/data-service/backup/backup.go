@@ -0,0 +1,99 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package databackup inventory data logical backup and restore data service
+package databackup
+
+import (
+	"fmt"
+
+	daobackup "hcm/pkg/dal/dao/backup"
+	"hcm/pkg/dal/table"
+)
+
+const (
+	// MaxFilterCount 过滤条件中账号、业务的最大数量
+	MaxFilterCount = 100
+	// MaxImportRecords 单次导入的最大记录数
+	MaxImportRecords = 1000
+)
+
+// ExportReq 导出备份数据请求
+type ExportReq struct {
+	// Tables 需要导出的表，为空时导出所有支持备份的表
+	Tables     []table.Name `json:"tables"`
+	AccountIDs []string     `json:"account_ids"`
+	BkBizIDs   []int64      `json:"bk_biz_ids"`
+}
+
+// Validate ExportReq
+func (req *ExportReq) Validate() error {
+	for _, name := range req.Tables {
+		if !daobackup.IsBackupTable(name) {
+			return fmt.Errorf("table %s is not supported to backup", name)
+		}
+	}
+
+	if len(req.AccountIDs) > MaxFilterCount {
+		return fmt.Errorf("account_ids should <= %d", MaxFilterCount)
+	}
+
+	if len(req.BkBizIDs) > MaxFilterCount {
+		return fmt.Errorf("bk_biz_ids should <= %d", MaxFilterCount)
+	}
+
+	return nil
+}
+
+// ImportReq 导入备份数据请求，记录在一个事务中导入
+type ImportReq struct {
+	// Mode 主键冲突时的处理方式，默认跳过已存在的记录
+	Mode    daobackup.ImportMode `json:"mode"`
+	Records []daobackup.Record   `json:"records"`
+}
+
+// Validate ImportReq
+func (req *ImportReq) Validate() error {
+	switch req.Mode {
+	case "", daobackup.SkipExisting, daobackup.OverwriteExisting:
+	default:
+		return fmt.Errorf("unsupported import mode: %s", req.Mode)
+	}
+
+	if len(req.Records) == 0 || len(req.Records) > MaxImportRecords {
+		return fmt.Errorf("records count should be in [1, %d]", MaxImportRecords)
+	}
+
+	for _, record := range req.Records {
+		if !daobackup.IsBackupTable(record.Table) {
+			return fmt.Errorf("table %s is not supported to restore", record.Table)
+		}
+
+		if len(record.Row) == 0 {
+			return fmt.Errorf("row of table %s is empty", record.Table)
+		}
+	}
+
+	return nil
+}
+
+// ImportResult 导入备份数据结果
+type ImportResult struct {
+	Count int64 `json:"count"`
+}
@@ -157,3 +157,37 @@ func (r *BillItemDeleteReq) Validate() error {
 
 // ItemCommonOpt general option for all bill item operations
 type ItemCommonOpt = typesbill.ItemCommonOpt
+
+// BillItemCostSumReq sum bill item cost request
+type BillItemCostSumReq struct {
+	*ItemCommonOpt `json:",inline" validate:"required"`
+	Filter         *filter.Expression         `json:"filter" validate:"required"`
+	GroupBy        []enumor.BillCostDimension `json:"group_by" validate:"omitempty,max=5"`
+}
+
+// Validate ...
+func (r *BillItemCostSumReq) Validate() error {
+	if r.Filter == nil {
+		return errf.New(errf.InvalidParameter, "filter is required")
+	}
+	if r.ItemCommonOpt == nil {
+		return errf.New(errf.InvalidParameter, "item common option is required")
+	}
+	if err := r.ItemCommonOpt.Validate(); err != nil {
+		return err
+	}
+	for _, dim := range r.GroupBy {
+		if err := dim.Validate(); err != nil {
+			return errf.NewFromErr(errf.InvalidParameter, err)
+		}
+	}
+	return validator.Validate.Struct(r)
+}
+
+// ItemCostSum bill item cost summed by dimensions
+type ItemCostSum = typesbill.ItemCostSum
+
+// BillItemCostSumResult ...
+type BillItemCostSumResult struct {
+	Details []ItemCostSum `json:"details"`
+}
@@ -0,0 +1,97 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/bill"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+
+	"github.com/shopspring/decimal"
+)
+
+// BudgetCreateReq create bill budget request
+type BudgetCreateReq struct {
+	Name          string                 `json:"name" validate:"required,max=255"`
+	ScopeType     enumor.BudgetScopeType `json:"scope_type" validate:"required"`
+	BkBizID       int64                  `json:"bk_biz_id" validate:"omitempty"`
+	MainAccountID string                 `json:"main_account_id" validate:"omitempty,max=64"`
+	Currency      enumor.CurrencyCode    `json:"currency" validate:"required"`
+	Amount        decimal.Decimal        `json:"amount" validate:"required"`
+	Thresholds    []int64                `json:"thresholds" validate:"required,min=1,max=10,dive,min=1,max=1000"`
+	NoticeUsers   []string               `json:"notice_users" validate:"required,min=1,max=20"`
+	Memo          *string                `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate ...
+func (r *BudgetCreateReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+	if err := r.ScopeType.Validate(); err != nil {
+		return err
+	}
+	switch r.ScopeType {
+	case enumor.BudgetScopeBiz:
+		if r.BkBizID <= 0 || len(r.MainAccountID) != 0 {
+			return errors.New("biz budget should only set bk_biz_id")
+		}
+	case enumor.BudgetScopeMainAccount:
+		if len(r.MainAccountID) == 0 || r.BkBizID != 0 {
+			return errors.New("main account budget should only set main_account_id")
+		}
+	}
+	if !r.Amount.IsPositive() {
+		return errors.New("amount should be positive")
+	}
+	return nil
+}
+
+// BudgetUpdateReq update bill budget request
+type BudgetUpdateReq struct {
+	ID          string              `json:"id" validate:"required"`
+	Name        string              `json:"name" validate:"omitempty,max=255"`
+	Currency    enumor.CurrencyCode `json:"currency" validate:"omitempty"`
+	Amount      *decimal.Decimal    `json:"amount" validate:"omitempty"`
+	Thresholds  []int64             `json:"thresholds" validate:"omitempty,max=10,dive,min=1,max=1000"`
+	NoticeUsers []string            `json:"notice_users" validate:"omitempty,max=20"`
+	Memo        *string             `json:"memo" validate:"omitempty,max=255"`
+
+	// AlertMonth 告警状态，仅由预算告警评估时更新
+	AlertMonth     *int   `json:"alert_month" validate:"omitempty"`
+	AlertThreshold *int64 `json:"alert_threshold" validate:"omitempty"`
+}
+
+// Validate ...
+func (r *BudgetUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+	if r.Amount != nil && !r.Amount.IsPositive() {
+		return errors.New("amount should be positive")
+	}
+	return nil
+}
+
+// BudgetListResult ...
+type BudgetListResult = core.ListResultT[bill.Budget]
@@ -0,0 +1,89 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/shopspring/decimal"
+)
+
+func validBudgetCreateReq() *BudgetCreateReq {
+	return &BudgetCreateReq{
+		Name:        "budget",
+		ScopeType:   enumor.BudgetScopeBiz,
+		BkBizID:     1,
+		Currency:    enumor.CurrencyUSD,
+		Amount:      decimal.NewFromInt(1000),
+		Thresholds:  []int64{80, 100},
+		NoticeUsers: []string{"admin"},
+	}
+}
+
+func TestBudgetCreateReqValidate(t *testing.T) {
+	if err := validBudgetCreateReq().Validate(); err != nil {
+		t.Fatalf("validate biz budget failed, err: %v", err)
+	}
+
+	req := validBudgetCreateReq()
+	req.ScopeType = enumor.BudgetScopeMainAccount
+	req.BkBizID = 0
+	req.MainAccountID = "00000001"
+	if err := req.Validate(); err != nil {
+		t.Fatalf("validate main account budget failed, err: %v", err)
+	}
+
+	cases := map[string]func(req *BudgetCreateReq){
+		"unsupported scope":           func(req *BudgetCreateReq) { req.ScopeType = "vendor" },
+		"biz budget without biz":      func(req *BudgetCreateReq) { req.BkBizID = 0 },
+		"biz budget with account":     func(req *BudgetCreateReq) { req.MainAccountID = "00000001" },
+		"account budget with biz":     func(req *BudgetCreateReq) { req.ScopeType = enumor.BudgetScopeMainAccount },
+		"negative amount":             func(req *BudgetCreateReq) { req.Amount = decimal.NewFromInt(-1) },
+		"threshold out of range":      func(req *BudgetCreateReq) { req.Thresholds = []int64{0} },
+		"missing thresholds":          func(req *BudgetCreateReq) { req.Thresholds = nil },
+		"missing notice users":        func(req *BudgetCreateReq) { req.NoticeUsers = nil },
+		"threshold more than allowed": func(req *BudgetCreateReq) { req.Thresholds = []int64{1001} },
+	}
+	for name, mutate := range cases {
+		req := validBudgetCreateReq()
+		mutate(req)
+		if err := req.Validate(); err == nil {
+			t.Errorf("%s: expect validate failed, but not", name)
+		}
+	}
+}
+
+func TestBudgetUpdateReqValidate(t *testing.T) {
+	amount := decimal.NewFromInt(2000)
+	if err := (&BudgetUpdateReq{ID: "1", Amount: &amount}).Validate(); err != nil {
+		t.Fatalf("validate update req failed, err: %v", err)
+	}
+
+	zero := decimal.Zero
+	if err := (&BudgetUpdateReq{ID: "1", Amount: &zero}).Validate(); err == nil {
+		t.Errorf("update req with zero amount should be invalid")
+	}
+
+	if err := (&BudgetUpdateReq{Name: "budget"}).Validate(); err == nil {
+		t.Errorf("update req without id should be invalid")
+	}
+}
@@ -0,0 +1,75 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/bill"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+
+	"github.com/shopspring/decimal"
+)
+
+// ReconciliationReplaceReq replace the bill reconciliation results of main account in the month.
+type ReconciliationReplaceReq struct {
+	Vendor        enumor.Vendor              `json:"vendor" validate:"required"`
+	RootAccountID string                     `json:"root_account_id" validate:"required"`
+	MainAccountID string                     `json:"main_account_id" validate:"required"`
+	BillYear      int                        `json:"bill_year" validate:"required,min=2000"`
+	BillMonth     int                        `json:"bill_month" validate:"required,min=1,max=12"`
+	Items         []ReconciliationCreateItem `json:"items" validate:"omitempty,dive"`
+}
+
+// Validate ...
+func (r *ReconciliationReplaceReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+	for i := range r.Items {
+		if err := r.Items[i].Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReconciliationCreateItem the charge which can not be matched with hcm resource.
+type ReconciliationCreateItem struct {
+	ResType       enumor.CloudResourceType   `json:"res_type" validate:"required"`
+	ResCloudID    string                     `json:"res_cloud_id" validate:"required,max=255"`
+	HcProductCode string                     `json:"hc_product_code" validate:"omitempty,max=128"`
+	Currency      enumor.CurrencyCode        `json:"currency" validate:"required"`
+	Cost          decimal.Decimal            `json:"cost"`
+	ResAmount     decimal.Decimal            `json:"res_amount"`
+	ResAmountUnit string                     `json:"res_amount_unit" validate:"omitempty,max=64"`
+	Reason        enumor.BillReconcileReason `json:"reason" validate:"required"`
+}
+
+// Validate ...
+func (r *ReconciliationCreateItem) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+	return r.Reason.Validate()
+}
+
+// ReconciliationListResult ...
+type ReconciliationListResult = core.ListResultT[bill.Reconciliation]
@@ -25,7 +25,6 @@ import (
 	"hcm/pkg/api/core/cloud"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/validator"
-	"hcm/pkg/cryptography"
 	"hcm/pkg/rest"
 	"hcm/pkg/runtime/filter"
 )
@@ -46,11 +45,6 @@ type TCloudAccountExtensionCreateReq struct {
 	CloudSecretKey     string `json:"cloud_secret_key" validate:"omitempty"`
 }
 
-// EncryptSecretKey ...
-func (req *TCloudAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) {
-	req.CloudSecretKey = cipher.EncryptToBase64(req.CloudSecretKey)
-}
-
 // AwsAccountExtensionCreateReq ...
 type AwsAccountExtensionCreateReq struct {
 	CloudAccountID   string `json:"cloud_account_id" validate:"required"`
@@ -59,11 +53,6 @@ type AwsAccountExtensionCreateReq struct {
 	CloudSecretKey   string `json:"cloud_secret_key" validate:"omitempty"`
 }
 
-// EncryptSecretKey ...
-func (req *AwsAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) {
-	req.CloudSecretKey = cipher.EncryptToBase64(req.CloudSecretKey)
-}
-
 // HuaWeiAccountExtensionCreateReq ...
 type HuaWeiAccountExtensionCreateReq struct {
 	CloudSubAccountID   string `json:"cloud_sub_account_id" validate:"required"`
@@ -74,11 +63,6 @@ type HuaWeiAccountExtensionCreateReq struct {
 	CloudIamUsername    string `json:"cloud_iam_username" validate:"required"`
 }
 
-// EncryptSecretKey ...
-func (req *HuaWeiAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) {
-	req.CloudSecretKey = cipher.EncryptToBase64(req.CloudSecretKey)
-}
-
 // GcpAccountExtensionCreateReq ...
 type GcpAccountExtensionCreateReq struct {
 	Email                   string `json:"email" validate:"omitempty"`
@@ -90,11 +74,6 @@ type GcpAccountExtensionCreateReq struct {
 	CloudServiceSecretKey   string `json:"cloud_service_secret_key" validate:"omitempty"`
 }
 
-// EncryptSecretKey ...
-func (req *GcpAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) {
-	req.CloudServiceSecretKey = cipher.EncryptToBase64(req.CloudServiceSecretKey)
-}
-
 // AzureAccountExtensionCreateReq ...
 type AzureAccountExtensionCreateReq struct {
 	DisplayNameName       string `json:"display_name_name" validate:"omitempty"`
@@ -106,11 +85,6 @@ type AzureAccountExtensionCreateReq struct {
 	CloudClientSecretKey  string `json:"cloud_client_secret_key" validate:"omitempty"`
 }
 
-// EncryptSecretKey ...
-func (req *AzureAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) {
-	req.CloudClientSecretKey = cipher.EncryptToBase64(req.CloudClientSecretKey)
-}
-
 // AccountCreateReq ...
 type AccountCreateReq[T AccountExtensionCreateReq] struct {
 	Name      string                 `json:"name" validate:"required"`
@@ -143,14 +117,6 @@ type TCloudAccountExtensionUpdateReq struct {
 	CloudSecretKey     *string `json:"cloud_secret_key,omitempty" validate:"omitempty"`
 }
 
-// EncryptSecretKey ...
-func (req *TCloudAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) {
-	if req.CloudSecretKey != nil {
-		encryptedCloudSecretKey := cipher.EncryptToBase64(*req.CloudSecretKey)
-		req.CloudSecretKey = &encryptedCloudSecretKey
-	}
-}
-
 type AwsAccountExtensionUpdateReq struct {
 	CloudAccountID   string  `json:"cloud_account_id,omitempty" validate:"omitempty"`
 	CloudIamUsername string  `json:"cloud_iam_username,omitempty" validate:"omitempty"`
@@ -158,14 +124,6 @@ type AwsAccountExtensionUpdateReq struct {
 	CloudSecretKey   *string `json:"cloud_secret_key,omitempty" validate:"omitempty"`
 }
 
-// EncryptSecretKey ...
-func (req *AwsAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) {
-	if req.CloudSecretKey != nil {
-		encryptedCloudSecretKey := cipher.EncryptToBase64(*req.CloudSecretKey)
-		req.CloudSecretKey = &encryptedCloudSecretKey
-	}
-}
-
 type HuaWeiAccountExtensionUpdateReq struct {
 	CloudSubAccountID   string  `json:"cloud_sub_account_id,omitempty" validate:"omitempty"`
 	CloudSubAccountName string  `json:"cloud_sub_account_name,omitempty" validate:"omitempty"`
@@ -175,14 +133,6 @@ type HuaWeiAccountExtensionUpdateReq struct {
 	CloudIamUsername    string  `json:"cloud_iam_username,omitempty" validate:"omitempty"`
 }
 
-// EncryptSecretKey ...
-func (req *HuaWeiAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) {
-	if req.CloudSecretKey != nil {
-		encryptedCloudSecretKey := cipher.EncryptToBase64(*req.CloudSecretKey)
-		req.CloudSecretKey = &encryptedCloudSecretKey
-	}
-}
-
 type GcpAccountExtensionUpdateReq struct {
 	Email                   string  `json:"email" validate:"omitempty"`
 	CloudProjectID          string  `json:"cloud_project_id,omitempty" validate:"omitempty"`
@@ -193,14 +143,6 @@ type GcpAccountExtensionUpdateReq struct {
 	CloudServiceSecretKey   *string `json:"cloud_service_secret_key,omitempty" validate:"omitempty"`
 }
 
-// EncryptSecretKey ...
-func (req *GcpAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) {
-	if req.CloudServiceSecretKey != nil {
-		encryptedCloudServiceSecretKey := cipher.EncryptToBase64(*req.CloudServiceSecretKey)
-		req.CloudServiceSecretKey = &encryptedCloudServiceSecretKey
-	}
-}
-
 type AzureAccountExtensionUpdateReq struct {
 	DisplayNameName       string  `json:"display_name_name" validate:"omitempty"`
 	CloudTenantID         string  `json:"cloud_tenant_id,omitempty" validate:"omitempty"`
@@ -212,14 +154,6 @@ type AzureAccountExtensionUpdateReq struct {
 	CloudClientSecretKey  *string `json:"cloud_client_secret_key,omitempty" validate:"omitempty"`
 }
 
-// EncryptSecretKey ...
-func (req *AzureAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) {
-	if req.CloudClientSecretKey != nil {
-		encryptedCloudClientSecretKey := cipher.EncryptToBase64(*req.CloudClientSecretKey)
-		req.CloudClientSecretKey = &encryptedCloudClientSecretKey
-	}
-}
-
 // AccountUpdateReq ...
 type AccountUpdateReq[T AccountExtensionUpdateReq] struct {
 	Name               string   `json:"name" validate:"omitempty"`
@@ -323,18 +257,10 @@ type AccountWithExtensionListResp struct {
 	Data          *AccountWithExtensionListResult `json:"data"`
 }
 
-// -------------------------- Secret Encrypt And Decrypt --------------------------
+// -------------------------- Rotate Secret --------------------------
 
-// SecretEncryptor 用于加密"泛型"Extension密钥
-type SecretEncryptor[T AccountExtensionCreateReq | AccountExtensionUpdateReq] interface {
-	// EncryptSecretKey 加密约束，将密钥进行加密设置
-	EncryptSecretKey(cryptography.Crypto)
-	*T
-}
-
-// SecretDecryptor 用于解密"泛型"Extension密钥
-type SecretDecryptor[T AccountExtensionGetResp] interface {
-	// DecryptSecretKey 解密约束，需要支持将加密的密钥还原成明文
-	DecryptSecretKey(cryptography.Crypto) error
-	*T
+// AccountSecretRotateResult ...
+type AccountSecretRotateResult struct {
+	// Count 重新加密的账号数量
+	Count uint64 `json:"count"`
 }
@@ -21,10 +21,14 @@
 package cloud
 
 import (
+	"errors"
+
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
 )
 
 // -------------------------- Get --------------------------
@@ -99,3 +103,75 @@ type AssignResourceToBizReq struct {
 func (a AssignResourceToBizReq) Validate() error {
 	return validator.Validate.Struct(a)
 }
+
+// BatchAssignResourceToBizReq batch assign filtered unassigned cloud resource and its dependent resource to biz request.
+type BatchAssignResourceToBizReq struct {
+	BkBizID int64                    `json:"bk_biz_id" validate:"min=1"`
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	Filter  *filter.Expression       `json:"filter" validate:"required"`
+}
+
+// Validate BatchAssignResourceToBizReq.
+func (a BatchAssignResourceToBizReq) Validate() error {
+	return validator.Validate.Struct(a)
+}
+
+// TransferResourceBizReq transfer cloud resource and its dependent resource between biz request.
+type TransferResourceBizReq struct {
+	SourceBkBizID int64                    `json:"source_bk_biz_id" validate:"min=1"`
+	TargetBkBizID int64                    `json:"target_bk_biz_id" validate:"min=1"`
+	ResType       enumor.CloudResourceType `json:"res_type" validate:"required"`
+	IDs           []string                 `json:"ids" validate:"required,min=1,max=100"`
+}
+
+// Validate TransferResourceBizReq.
+func (a TransferResourceBizReq) Validate() error {
+	if err := validator.Validate.Struct(a); err != nil {
+		return err
+	}
+
+	if a.SourceBkBizID == a.TargetBkBizID {
+		return errors.New("source biz and target biz can not be the same")
+	}
+
+	return nil
+}
+
+// AssignResourcePreviewResult assign resource to biz preview result.
+type AssignResourcePreviewResult struct {
+	// Resources 将被分配到业务的资源，包括过滤出的资源及其依赖资源
+	Resources []AssignResourceItem `json:"resources"`
+	// Conflicts 无法分配到业务的资源，存在冲突时不允许执行分配
+	Conflicts []AssignResourceConflict `json:"conflicts"`
+}
+
+// AccountIDs return distinct account ids of the resources to be assigned.
+func (r *AssignResourcePreviewResult) AccountIDs() []string {
+	ids := make([]string, 0)
+	for _, one := range r.Resources {
+		ids = append(ids, one.AccountID)
+	}
+	return slice.Unique(ids)
+}
+
+// AssignResourceItem resource to be assigned.
+type AssignResourceItem struct {
+	ResType   enumor.CloudResourceType `json:"res_type"`
+	ID        string                   `json:"id"`
+	AccountID string                   `json:"account_id"`
+	// DependOnID 所依赖的过滤出的资源ID，为空表示该资源为过滤出的资源
+	DependOnID string `json:"depend_on_id,omitempty"`
+}
+
+// AssignResourceConflict resource that can not be assigned.
+type AssignResourceConflict struct {
+	ResType enumor.CloudResourceType `json:"res_type"`
+	ID      string                   `json:"id"`
+	Reason  string                   `json:"reason"`
+}
+
+// AssignResourcePreviewResp assign resource to biz preview response.
+type AssignResourcePreviewResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *AssignResourcePreviewResult `json:"data"`
+}
@@ -138,6 +138,31 @@ func (req *CvmCommonInfoBatchUpdateReq) Validate() error {
 	return nil
 }
 
+// CvmOwnerInfoBatchUpdateReq define cvm owner and labels batch update req.
+type CvmOwnerInfoBatchUpdateReq struct {
+	Cvms []CvmOwnerInfo `json:"cvms" validate:"required,min=1,dive"`
+}
+
+// CvmOwnerInfo define cvm owner and labels, empty field is not updated.
+type CvmOwnerInfo struct {
+	ID     string            `json:"id" validate:"required"`
+	Owner  string            `json:"owner" validate:"max=64"`
+	Labels map[string]string `json:"labels"`
+}
+
+// Validate cvm owner info batch update req.
+func (req *CvmOwnerInfoBatchUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Cvms) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("cvms count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return nil
+}
+
 // -------------------------- List --------------------------
 
 // CvmListReq list req.
@@ -164,6 +189,17 @@ type CvmListResp struct {
 	Data          *CvmListResult `json:"data"`
 }
 
+// CvmStreamListReq stream list req, cvms are returned as newline-delimited json in the order of id.
+type CvmStreamListReq struct {
+	Field  []string           `json:"field" validate:"omitempty"`
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate stream list request.
+func (req *CvmStreamListReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
 // CvmExtListReq list req.
 type CvmExtListReq struct {
 	Field  []string           `json:"field" validate:"omitempty"`
@@ -0,0 +1,48 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"hcm/pkg/criteria/validator"
+)
+
+// CvmCmdbSyncReq sync cvm to cmdb request.
+type CvmCmdbSyncReq struct {
+	CvmIDs []string `json:"cvm_ids" validate:"required,min=1,max=100"`
+	// BkModuleID 同步后将主机转移到的cmdb模块ID，为0时不转移模块
+	BkModuleID int64 `json:"bk_module_id" validate:"min=0"`
+}
+
+// Validate CvmCmdbSyncReq.
+func (req *CvmCmdbSyncReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// CvmCmdbSyncResult sync cvm to cmdb result.
+type CvmCmdbSyncResult struct {
+	Succeeded []string            `json:"succeeded"`
+	Failed    []CvmCmdbSyncFailed `json:"failed"`
+}
+
+// CvmCmdbSyncFailed define cvm that failed to sync to cmdb.
+type CvmCmdbSyncFailed struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason"`
+}
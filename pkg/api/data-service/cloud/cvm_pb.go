@@ -0,0 +1,316 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	"hcm/pkg/api/data-service/grpc/pb"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/json"
+)
+
+// ToPb convert cvm batch create request to protobuf message.
+func (req *CvmBatchCreateReq[T]) ToPb(vendor enumor.Vendor) (*pb.CvmBatchCreateReq, error) {
+	cvms := make([]*pb.CvmCreate, 0, len(req.Cvms))
+	for _, one := range req.Cvms {
+		extension, err := marshalExtension(one.Extension)
+		if err != nil {
+			return nil, err
+		}
+
+		cvms = append(cvms, &pb.CvmCreate{
+			CloudId:              one.CloudID,
+			Name:                 one.Name,
+			BkBizId:              one.BkBizID,
+			BkCloudId:            one.BkCloudID,
+			AccountId:            one.AccountID,
+			Region:               one.Region,
+			Zone:                 one.Zone,
+			CloudVpcIds:          one.CloudVpcIDs,
+			VpcIds:               one.VpcIDs,
+			CloudSubnetIds:       one.CloudSubnetIDs,
+			SubnetIds:            one.SubnetIDs,
+			CloudImageId:         one.CloudImageID,
+			ImageId:              one.ImageID,
+			OsName:               one.OsName,
+			Memo:                 one.Memo,
+			Status:               one.Status,
+			PrivateIpv4Addresses: one.PrivateIPv4Addresses,
+			PrivateIpv6Addresses: one.PrivateIPv6Addresses,
+			PublicIpv4Addresses:  one.PublicIPv4Addresses,
+			PublicIpv6Addresses:  one.PublicIPv6Addresses,
+			MachineType:          one.MachineType,
+			CloudCreatedTime:     one.CloudCreatedTime,
+			CloudLaunchedTime:    one.CloudLaunchedTime,
+			CloudExpiredTime:     one.CloudExpiredTime,
+			Extension:            extension,
+		})
+	}
+
+	return &pb.CvmBatchCreateReq{Vendor: string(vendor), Cvms: cvms}, nil
+}
+
+// CvmBatchCreateReqFromPb convert protobuf message to cvm batch create request.
+func CvmBatchCreateReqFromPb[T corecvm.Extension](req *pb.CvmBatchCreateReq) (*CvmBatchCreateReq[T], error) {
+	cvms := make([]CvmBatchCreate[T], 0, len(req.Cvms))
+	for _, one := range req.Cvms {
+		extension, err := unmarshalExtension[T](one.Extension)
+		if err != nil {
+			return nil, err
+		}
+
+		cvms = append(cvms, CvmBatchCreate[T]{
+			CloudID:              one.CloudId,
+			Name:                 one.Name,
+			BkBizID:              one.BkBizId,
+			BkCloudID:            one.BkCloudId,
+			AccountID:            one.AccountId,
+			Region:               one.Region,
+			Zone:                 one.Zone,
+			CloudVpcIDs:          one.CloudVpcIds,
+			VpcIDs:               one.VpcIds,
+			CloudSubnetIDs:       one.CloudSubnetIds,
+			SubnetIDs:            one.SubnetIds,
+			CloudImageID:         one.CloudImageId,
+			ImageID:              one.ImageId,
+			OsName:               one.OsName,
+			Memo:                 one.Memo,
+			Status:               one.Status,
+			PrivateIPv4Addresses: one.PrivateIpv4Addresses,
+			PrivateIPv6Addresses: one.PrivateIpv6Addresses,
+			PublicIPv4Addresses:  one.PublicIpv4Addresses,
+			PublicIPv6Addresses:  one.PublicIpv6Addresses,
+			MachineType:          one.MachineType,
+			CloudCreatedTime:     one.CloudCreatedTime,
+			CloudLaunchedTime:    one.CloudLaunchedTime,
+			CloudExpiredTime:     one.CloudExpiredTime,
+			Extension:            extension,
+		})
+	}
+
+	return &CvmBatchCreateReq[T]{Cvms: cvms}, nil
+}
+
+// ToPb convert cvm batch update request to protobuf message.
+func (req *CvmBatchUpdateReq[T]) ToPb(vendor enumor.Vendor) (*pb.CvmBatchUpdateReq, error) {
+	cvms := make([]*pb.CvmUpdate, 0, len(req.Cvms))
+	for _, one := range req.Cvms {
+		extension, err := marshalExtension(one.Extension)
+		if err != nil {
+			return nil, err
+		}
+
+		cvms = append(cvms, &pb.CvmUpdate{
+			Id:                   one.ID,
+			Name:                 one.Name,
+			BkBizId:              one.BkBizID,
+			BkCloudId:            one.BkCloudID,
+			CloudVpcIds:          one.CloudVpcIDs,
+			VpcIds:               one.VpcIDs,
+			CloudSubnetIds:       one.CloudSubnetIDs,
+			SubnetIds:            one.SubnetIDs,
+			CloudImageId:         one.CloudImageID,
+			ImageId:              one.ImageID,
+			Memo:                 one.Memo,
+			Status:               one.Status,
+			PrivateIpv4Addresses: one.PrivateIPv4Addresses,
+			PrivateIpv6Addresses: one.PrivateIPv6Addresses,
+			PublicIpv4Addresses:  one.PublicIPv4Addresses,
+			PublicIpv6Addresses:  one.PublicIPv6Addresses,
+			CloudLaunchedTime:    one.CloudLaunchedTime,
+			CloudExpiredTime:     one.CloudExpiredTime,
+			Extension:            extension,
+		})
+	}
+
+	return &pb.CvmBatchUpdateReq{Vendor: string(vendor), Cvms: cvms}, nil
+}
+
+// CvmBatchUpdateReqFromPb convert protobuf message to cvm batch update request.
+func CvmBatchUpdateReqFromPb[T corecvm.Extension](req *pb.CvmBatchUpdateReq) (*CvmBatchUpdateReq[T], error) {
+	cvms := make([]CvmBatchUpdate[T], 0, len(req.Cvms))
+	for _, one := range req.Cvms {
+		extension, err := unmarshalExtension[T](one.Extension)
+		if err != nil {
+			return nil, err
+		}
+
+		cvms = append(cvms, CvmBatchUpdate[T]{
+			ID:                   one.Id,
+			Name:                 one.Name,
+			BkBizID:              one.BkBizId,
+			BkCloudID:            one.BkCloudId,
+			CloudVpcIDs:          one.CloudVpcIds,
+			VpcIDs:               one.VpcIds,
+			CloudSubnetIDs:       one.CloudSubnetIds,
+			SubnetIDs:            one.SubnetIds,
+			CloudImageID:         one.CloudImageId,
+			ImageID:              one.ImageId,
+			Memo:                 one.Memo,
+			Status:               one.Status,
+			PrivateIPv4Addresses: one.PrivateIpv4Addresses,
+			PrivateIPv6Addresses: one.PrivateIpv6Addresses,
+			PublicIPv4Addresses:  one.PublicIpv4Addresses,
+			PublicIPv6Addresses:  one.PublicIpv6Addresses,
+			CloudLaunchedTime:    one.CloudLaunchedTime,
+			CloudExpiredTime:     one.CloudExpiredTime,
+			Extension:            extension,
+		})
+	}
+
+	return &CvmBatchUpdateReq[T]{Cvms: cvms}, nil
+}
+
+// ToPb convert cvm list request to protobuf message.
+func (req *CvmListReq) ToPb(vendor enumor.Vendor) (*pb.ListReq, error) {
+	return listReqToPb(vendor, req.Field, req.Filter, req.Page)
+}
+
+// CvmExtListReqFromPb convert protobuf message to cvm list with extension request.
+func CvmExtListReqFromPb(req *pb.ListReq) (*CvmExtListReq, error) {
+	listReq := &CvmExtListReq{Field: req.Fields}
+	if len(req.Filter) != 0 {
+		listReq.Filter = new(filter.Expression)
+		if err := json.Unmarshal(req.Filter, listReq.Filter); err != nil {
+			return nil, fmt.Errorf("unmarshal list filter failed, err: %v", err)
+		}
+	}
+
+	if len(req.Page) != 0 {
+		listReq.Page = new(core.BasePage)
+		if err := json.Unmarshal(req.Page, listReq.Page); err != nil {
+			return nil, fmt.Errorf("unmarshal list page failed, err: %v", err)
+		}
+	}
+
+	return listReq, nil
+}
+
+// CvmExtListResultFromPb convert protobuf message to cvm list with extension result.
+func CvmExtListResultFromPb[T corecvm.Extension](result *pb.CvmListResult) (*CvmExtListResult[T], error) {
+	details := make([]corecvm.Cvm[T], 0, len(result.Details))
+	for _, one := range result.Details {
+		extension, err := unmarshalExtension[T](one.Extension)
+		if err != nil {
+			return nil, err
+		}
+		if extension == nil {
+			extension = new(T)
+		}
+
+		details = append(details, corecvm.Cvm[T]{
+			BaseCvm: corecvm.BaseCvm{
+				ID:                   one.Id,
+				CloudID:              one.CloudId,
+				Name:                 one.Name,
+				Vendor:               enumor.Vendor(one.Vendor),
+				BkBizID:              one.BkBizId,
+				BkCloudID:            one.BkCloudId,
+				AccountID:            one.AccountId,
+				Region:               one.Region,
+				Zone:                 one.Zone,
+				CloudVpcIDs:          one.CloudVpcIds,
+				VpcIDs:               one.VpcIds,
+				CloudSubnetIDs:       one.CloudSubnetIds,
+				SubnetIDs:            one.SubnetIds,
+				CloudImageID:         one.CloudImageId,
+				ImageID:              one.ImageId,
+				OsName:               one.OsName,
+				Memo:                 one.Memo,
+				Status:               one.Status,
+				RecycleStatus:        one.RecycleStatus,
+				PrivateIPv4Addresses: one.PrivateIpv4Addresses,
+				PrivateIPv6Addresses: one.PrivateIpv6Addresses,
+				PublicIPv4Addresses:  one.PublicIpv4Addresses,
+				PublicIPv6Addresses:  one.PublicIpv6Addresses,
+				MachineType:          one.MachineType,
+				CloudCreatedTime:     one.CloudCreatedTime,
+				CloudLaunchedTime:    one.CloudLaunchedTime,
+				CloudExpiredTime:     one.CloudExpiredTime,
+				Owner:                one.Owner,
+				Labels:               one.Labels,
+				Revision: &core.Revision{
+					Creator:   one.Creator,
+					Reviser:   one.Reviser,
+					CreatedAt: one.CreatedAt,
+					UpdatedAt: one.UpdatedAt,
+				},
+			},
+			Extension: extension,
+		})
+	}
+
+	return &CvmExtListResult[T]{Count: result.Count, Details: details}, nil
+}
+
+func listReqToPb(vendor enumor.Vendor, fields []string, expr *filter.Expression, page *core.BasePage) (
+	*pb.ListReq, error) {
+
+	req := &pb.ListReq{Vendor: string(vendor), Fields: fields}
+	if expr != nil {
+		data, err := json.Marshal(expr)
+		if err != nil {
+			return nil, fmt.Errorf("marshal list filter failed, err: %v", err)
+		}
+		req.Filter = data
+	}
+
+	if page != nil {
+		data, err := json.Marshal(page)
+		if err != nil {
+			return nil, fmt.Errorf("marshal list page failed, err: %v", err)
+		}
+		req.Page = data
+	}
+
+	return req, nil
+}
+
+// marshalExtension marshal the extension to json, nil extension is marshaled to empty bytes.
+func marshalExtension[T any](extension *T) ([]byte, error) {
+	if extension == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(extension)
+	if err != nil {
+		return nil, fmt.Errorf("marshal extension failed, err: %v", err)
+	}
+
+	return data, nil
+}
+
+// unmarshalExtension unmarshal the extension json, empty bytes is unmarshaled to nil extension.
+func unmarshalExtension[T any](data []byte) (*T, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	extension := new(T)
+	if err := json.Unmarshal(data, extension); err != nil {
+		return nil, fmt.Errorf("unmarshal extension failed, err: %v", err)
+	}
+
+	return extension, nil
+}
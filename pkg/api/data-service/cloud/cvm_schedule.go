@@ -0,0 +1,92 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// CvmScheduleCreateReq cvm schedule create request.
+type CvmScheduleCreateReq struct {
+	Name      string             `json:"name" validate:"required,max=255"`
+	Filter    *filter.Expression `json:"filter" validate:"required"`
+	StartCron string             `json:"start_cron" validate:"omitempty,max=64"`
+	StopCron  string             `json:"stop_cron" validate:"omitempty,max=64"`
+	Timezone  string             `json:"timezone" validate:"required,max=64"`
+	Enabled   bool               `json:"enabled"`
+	Memo      *string            `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate CvmScheduleCreateReq.
+func (req *CvmScheduleCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// CvmScheduleUpdateReq cvm schedule update request.
+type CvmScheduleUpdateReq struct {
+	Name      string             `json:"name" validate:"omitempty,max=255"`
+	Filter    *filter.Expression `json:"filter" validate:"omitempty"`
+	StartCron *string            `json:"start_cron" validate:"omitempty,max=64"`
+	StopCron  *string            `json:"stop_cron" validate:"omitempty,max=64"`
+	Timezone  string             `json:"timezone" validate:"omitempty,max=64"`
+	Enabled   *bool              `json:"enabled" validate:"omitempty"`
+	Memo      *string            `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate CvmScheduleUpdateReq.
+func (req *CvmScheduleUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Name) == 0 && req.Filter == nil && req.StartCron == nil && req.StopCron == nil &&
+		len(req.Timezone) == 0 && req.Enabled == nil && req.Memo == nil {
+		return errors.New("at least one field should be updated")
+	}
+
+	return nil
+}
+
+// CvmScheduleRecordCreateReq cvm schedule execution record create request, the cpu core of succeeded cvms
+// is calculated by data-service.
+type CvmScheduleRecordCreateReq struct {
+	ScheduleID   string                   `json:"schedule_id" validate:"required"`
+	Action       enumor.CvmScheduleAction `json:"action" validate:"required"`
+	CvmIDs       []string                 `json:"cvm_ids" validate:"omitempty"`
+	FailedCvmIDs []string                 `json:"failed_cvm_ids" validate:"omitempty"`
+}
+
+// Validate CvmScheduleRecordCreateReq.
+func (req *CvmScheduleRecordCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.CvmIDs)+len(req.FailedCvmIDs) > constant.BatchOperationMaxLimit*10 {
+		return errors.New("cvm ids should <= 1000")
+	}
+
+	return req.Action.Validate()
+}
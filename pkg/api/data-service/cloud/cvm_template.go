@@ -0,0 +1,84 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table/types"
+)
+
+// CvmTemplateCreateReq cvm template create request.
+type CvmTemplateCreateReq struct {
+	Name                  string          `json:"name" validate:"required,max=255"`
+	Vendor                enumor.Vendor   `json:"vendor" validate:"required"`
+	AccountID             string          `json:"account_id" validate:"required"`
+	Region                string          `json:"region" validate:"required"`
+	Zone                  string          `json:"zone" validate:"omitempty"`
+	CloudImageID          string          `json:"cloud_image_id" validate:"required"`
+	InstanceType          string          `json:"instance_type" validate:"required"`
+	CloudSecurityGroupIDs []string        `json:"cloud_security_group_ids" validate:"omitempty"`
+	SystemDisk            types.JsonField `json:"system_disk" validate:"omitempty"`
+	DataDisk              types.JsonField `json:"data_disk" validate:"omitempty"`
+	UserData              string          `json:"user_data" validate:"omitempty"`
+	Extension             types.JsonField `json:"extension" validate:"omitempty"`
+	Memo                  *string         `json:"memo" validate:"omitempty"`
+}
+
+// Validate CvmTemplateCreateReq.
+func (req *CvmTemplateCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.Vendor.Validate()
+}
+
+// CvmTemplateUpdateReq cvm template update request, vendor and account can not be updated.
+type CvmTemplateUpdateReq struct {
+	Name                  string          `json:"name" validate:"omitempty,max=255"`
+	Region                string          `json:"region" validate:"omitempty"`
+	Zone                  string          `json:"zone" validate:"omitempty"`
+	CloudImageID          string          `json:"cloud_image_id" validate:"omitempty"`
+	InstanceType          string          `json:"instance_type" validate:"omitempty"`
+	CloudSecurityGroupIDs []string        `json:"cloud_security_group_ids" validate:"omitempty"`
+	SystemDisk            types.JsonField `json:"system_disk" validate:"omitempty"`
+	DataDisk              types.JsonField `json:"data_disk" validate:"omitempty"`
+	UserData              *string         `json:"user_data" validate:"omitempty"`
+	Extension             types.JsonField `json:"extension" validate:"omitempty"`
+	Memo                  *string         `json:"memo" validate:"omitempty"`
+}
+
+// Validate CvmTemplateUpdateReq.
+func (req *CvmTemplateUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Name) == 0 && len(req.Region) == 0 && len(req.Zone) == 0 && len(req.CloudImageID) == 0 &&
+		len(req.InstanceType) == 0 && req.CloudSecurityGroupIDs == nil && len(req.SystemDisk) == 0 &&
+		len(req.DataDisk) == 0 && req.UserData == nil && len(req.Extension) == 0 && req.Memo == nil {
+		return errors.New("at least one field should be updated")
+	}
+
+	return nil
+}
@@ -99,6 +99,33 @@ func (req *DiskExtBatchUpdateReq[T]) Validate() error {
 	return nil
 }
 
+// DiskExtBatchUpsertReq 在一个事务中批量删除、更新和创建云盘，用于同步时减少请求次数
+type DiskExtBatchUpsertReq[T coredisk.Extension] struct {
+	Creates DiskExtBatchCreateReq[T] `json:"creates"`
+	Updates DiskExtBatchUpdateReq[T] `json:"updates"`
+	// AccountID 删除云盘所属的账号，DeleteCloudIDs 不为空时必填
+	AccountID string `json:"account_id"`
+	// DeleteCloudIDs 需要删除的云盘云上ID
+	DeleteCloudIDs []string `json:"delete_cloud_ids"`
+}
+
+// Validate ...
+func (req *DiskExtBatchUpsertReq[T]) Validate() error {
+	if len(req.Creates) == 0 && len(req.Updates) == 0 && len(req.DeleteCloudIDs) == 0 {
+		return fmt.Errorf("creates, updates and delete_cloud_ids can not be all empty")
+	}
+
+	if len(req.DeleteCloudIDs) != 0 && len(req.AccountID) == 0 {
+		return fmt.Errorf("account_id is required when delete_cloud_ids is set")
+	}
+
+	if err := req.Creates.Validate(); err != nil {
+		return err
+	}
+
+	return req.Updates.Validate()
+}
+
 // DiskBatchUpdateReq ...
 type DiskBatchUpdateReq struct {
 	IDs     []string `json:"ids" validate:"required"`
@@ -0,0 +1,79 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disk
+
+import (
+	"testing"
+
+	coredisk "hcm/pkg/api/core/cloud/disk"
+)
+
+func TestDiskExtBatchUpsertReqValidate(t *testing.T) {
+	create := &DiskExtCreateReq[coredisk.TCloudExtension]{AccountID: "account-1", Name: "disk-1", CloudID: "disk-xxx",
+		Region: "ap-guangzhou", DiskSize: 50, DiskType: "CLOUD_SSD", Status: "ATTACHED"}
+	update := &DiskExtUpdateReq[coredisk.TCloudExtension]{ID: "00000001", Status: "UNATTACHED"}
+
+	cases := []struct {
+		name  string
+		req   *DiskExtBatchUpsertReq[coredisk.TCloudExtension]
+		valid bool
+	}{
+		{name: "empty", req: &DiskExtBatchUpsertReq[coredisk.TCloudExtension]{}, valid: false},
+		{
+			name: "creates and updates",
+			req: &DiskExtBatchUpsertReq[coredisk.TCloudExtension]{
+				Creates: DiskExtBatchCreateReq[coredisk.TCloudExtension]{create},
+				Updates: DiskExtBatchUpdateReq[coredisk.TCloudExtension]{update},
+			},
+			valid: true,
+		},
+		{
+			name:  "delete without account",
+			req:   &DiskExtBatchUpsertReq[coredisk.TCloudExtension]{DeleteCloudIDs: []string{"disk-yyy"}},
+			valid: false,
+		},
+		{
+			name: "delete only",
+			req: &DiskExtBatchUpsertReq[coredisk.TCloudExtension]{AccountID: "account-1",
+				DeleteCloudIDs: []string{"disk-yyy"}},
+			valid: true,
+		},
+		{
+			name: "invalid create",
+			req: &DiskExtBatchUpsertReq[coredisk.TCloudExtension]{
+				Creates: DiskExtBatchCreateReq[coredisk.TCloudExtension]{{AccountID: "account-1", Name: "disk-1"}},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid update",
+			req: &DiskExtBatchUpsertReq[coredisk.TCloudExtension]{
+				Updates: DiskExtBatchUpdateReq[coredisk.TCloudExtension]{{Status: "UNATTACHED"}},
+			},
+			valid: false,
+		},
+	}
+
+	for _, c := range cases {
+		if err := c.req.Validate(); (err == nil) != c.valid {
+			t.Errorf("case %s: validate result should be %v, err: %v", c.name, c.valid, err)
+		}
+	}
+}
@@ -99,6 +99,8 @@ func (req *ImageUpdate[T]) Validate() error {
 // DeleteReq ...
 type DeleteReq struct {
 	Filter *filter.Expression `json:"filter" validate:"required"`
+	// SkipInUseCheck 是否跳过删除前的资源引用检查，仅供同步删除云上已不存在的资源使用
+	SkipInUseCheck bool `json:"skip_in_use_check,omitempty"`
 }
 
 // Validate ...
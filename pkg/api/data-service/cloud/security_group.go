@@ -163,6 +163,8 @@ type SecurityGroupExtListResp[T cloud.SecurityGroupExtension] struct {
 // SecurityGroupBatchDeleteReq security group delete request.
 type SecurityGroupBatchDeleteReq struct {
 	Filter *filter.Expression `json:"filter" validate:"required"`
+	// SkipInUseCheck 是否跳过删除前的资源引用检查，仅供同步删除云上已不存在的资源使用
+	SkipInUseCheck bool `json:"skip_in_use_check,omitempty"`
 }
 
 // Validate security group delete request.
@@ -23,6 +23,7 @@ import (
 	"hcm/pkg/api/core/cloud"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
 )
 
 // -------------------------- Create --------------------------
@@ -193,6 +194,20 @@ func (u *SubnetBaseInfoBatchUpdateReq) Validate() error {
 	return validator.Validate.Struct(u)
 }
 
+// -------------------------- Delete --------------------------
+
+// SubnetBatchDeleteReq defines batch delete subnet request.
+type SubnetBatchDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+	// SkipInUseCheck 是否跳过删除前的资源引用检查，仅供同步删除云上已不存在的资源使用
+	SkipInUseCheck bool `json:"skip_in_use_check,omitempty"`
+}
+
+// Validate SubnetBatchDeleteReq.
+func (req *SubnetBatchDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
 // SubnetBaseInfoUpdateReq defines update subnet base info request.
 type SubnetBaseInfoUpdateReq struct {
 	IDs  []string              `json:"id" validate:"required"`
@@ -0,0 +1,69 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package zone
+
+import (
+	"fmt"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// ZoneCapabilityReplaceMaxRegion is the max region count of one zone capability replace request.
+const ZoneCapabilityReplaceMaxRegion = 20
+
+// ZoneCapabilityReplaceReq replace all zone capabilities of the given regions.
+type ZoneCapabilityReplaceReq struct {
+	Vendor  enumor.Vendor `json:"vendor" validate:"required"`
+	Regions []string      `json:"regions" validate:"required,min=1"`
+	// Capabilities 为空时仅清理地域下的能力数据
+	Capabilities []ZoneCapabilityCreate `json:"capabilities" validate:"omitempty,dive"`
+}
+
+// ZoneCapabilityCreate define zone capability create.
+type ZoneCapabilityCreate struct {
+	Region           string               `json:"region" validate:"required"`
+	Zone             string               `json:"zone" validate:"omitempty"`
+	InstanceFamilies []string             `json:"instance_families" validate:"omitempty"`
+	Features         []enumor.ZoneFeature `json:"features" validate:"omitempty"`
+}
+
+// Validate zone capability replace request.
+func (req *ZoneCapabilityReplaceReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Regions) > ZoneCapabilityReplaceMaxRegion {
+		return fmt.Errorf("regions count should <= %d", ZoneCapabilityReplaceMaxRegion)
+	}
+
+	regions := make(map[string]struct{}, len(req.Regions))
+	for _, region := range req.Regions {
+		regions[region] = struct{}{}
+	}
+	for _, one := range req.Capabilities {
+		if _, exists := regions[one.Region]; !exists {
+			return fmt.Errorf("capability region %s is not in regions", one.Region)
+		}
+	}
+
+	return nil
+}
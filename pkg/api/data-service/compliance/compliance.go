@@ -0,0 +1,113 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package compliance ...
+package compliance
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// DetectReq detect compliance finding request.
+type DetectReq struct {
+	// Rules 需要执行的检测规则及其风险等级，未包含的规则的检测结果会被清理
+	Rules []RuleSetting `json:"rules" validate:"omitempty,dive"`
+	// AdminPorts 安全组不允许对任意来源开放的管理端口
+	AdminPorts []int64 `json:"admin_ports" validate:"omitempty,max=20"`
+}
+
+// Validate DetectReq.
+func (req *DetectReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	rules := make(map[enumor.ComplianceRule]struct{}, len(req.Rules))
+	for _, one := range req.Rules {
+		if err := one.Validate(); err != nil {
+			return err
+		}
+
+		if _, exists := rules[one.Rule]; exists {
+			return fmt.Errorf("compliance rule %s is duplicated", one.Rule)
+		}
+		rules[one.Rule] = struct{}{}
+
+		if one.Rule == enumor.ComplianceSGOpenAdminPort && len(req.AdminPorts) == 0 {
+			return errors.New("admin_ports is required when sg_open_admin_port rule is enabled")
+		}
+	}
+
+	for _, port := range req.AdminPorts {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("admin port %d is invalid", port)
+		}
+	}
+
+	return nil
+}
+
+// RuleSetting 检测规则配置
+type RuleSetting struct {
+	Rule     enumor.ComplianceRule     `json:"rule" validate:"required"`
+	Severity enumor.ComplianceSeverity `json:"severity" validate:"required"`
+}
+
+// Validate RuleSetting.
+func (s RuleSetting) Validate() error {
+	if err := s.Rule.Validate(); err != nil {
+		return err
+	}
+
+	return s.Severity.Validate()
+}
+
+// DetectResult detect compliance finding result.
+type DetectResult struct {
+	// Counts 各检测规则检测到的不合规资源数
+	Counts map[enumor.ComplianceRule]int `json:"counts"`
+}
+
+// ExemptionCreateReq create compliance exemption request.
+type ExemptionCreateReq struct {
+	Rule    enumor.ComplianceRule `json:"rule" validate:"required"`
+	ResID   string                `json:"res_id" validate:"required,lte=64"`
+	BkBizID int64                 `json:"bk_biz_id" validate:"required"`
+	Reason  string                `json:"reason" validate:"required,lte=255"`
+	// ExpiredAt 豁免过期时间，为空时永久有效
+	ExpiredAt *time.Time `json:"expired_at" validate:"omitempty"`
+}
+
+// Validate ExemptionCreateReq.
+func (req *ExemptionCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.ExpiredAt != nil && req.ExpiredAt.Before(time.Now()) {
+		return errors.New("expired_at should be later than now")
+	}
+
+	return req.Rule.Validate()
+}
@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package compliance
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestDetectReqValidate(t *testing.T) {
+	req := &DetectReq{
+		Rules: []RuleSetting{
+			{Rule: enumor.ComplianceSGOpenAdminPort, Severity: enumor.ComplianceSeverityHigh},
+			{Rule: enumor.ComplianceUnencryptedDisk, Severity: enumor.ComplianceSeverityLow},
+		},
+		AdminPorts: []int64{22, 3389},
+	}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("validate detect req failed, err: %v", err)
+	}
+
+	if err := (&DetectReq{}).Validate(); err != nil {
+		t.Errorf("detect req without rules should be valid to clear all findings, err: %v", err)
+	}
+
+	cases := map[string]*DetectReq{
+		"duplicate rule": {Rules: []RuleSetting{
+			{Rule: enumor.ComplianceUnencryptedDisk, Severity: enumor.ComplianceSeverityLow},
+			{Rule: enumor.ComplianceUnencryptedDisk, Severity: enumor.ComplianceSeverityHigh},
+		}},
+		"unsupported rule": {Rules: []RuleSetting{{Rule: "public_bucket", Severity: enumor.ComplianceSeverityLow}}},
+		"invalid severity": {Rules: []RuleSetting{{Rule: enumor.ComplianceUnencryptedDisk, Severity: "fatal"}}},
+		"missing admin ports": {Rules: []RuleSetting{
+			{Rule: enumor.ComplianceSGOpenAdminPort, Severity: enumor.ComplianceSeverityHigh},
+		}},
+		"invalid admin port": {AdminPorts: []int64{65536}},
+	}
+	for name, req := range cases {
+		if err := req.Validate(); err == nil {
+			t.Errorf("%s: expect validate failed, but not", name)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package dataconsistency data consistency check data service
+package dataconsistency
+
+import (
+	daoconsistency "hcm/pkg/dal/dao/consistency"
+)
+
+// CheckReq 数据一致性检查请求
+type CheckReq struct {
+	// AutoFix 是否自动修复可安全修复的问题(删除孤立的关联记录)
+	AutoFix bool `json:"auto_fix"`
+}
+
+// Validate CheckReq
+func (req *CheckReq) Validate() error {
+	return nil
+}
+
+// Report 数据一致性检查报告
+type Report struct {
+	// Duplicates 重复的 (vendor, cloud_id) 记录
+	Duplicates []daoconsistency.DuplicateCloudID `json:"duplicates"`
+	// OrphanRelations 引用的父资源已不存在的关联记录
+	OrphanRelations []daoconsistency.OrphanRelation `json:"orphan_relations"`
+	// VendorMismatches 资源的 vendor 与所属账号不一致的记录
+	VendorMismatches []daoconsistency.VendorMismatch `json:"vendor_mismatches"`
+	// FixedOrphanCount 自动修复时删除的孤立关联记录数
+	FixedOrphanCount int64 `json:"fixed_orphan_count"`
+}
+
+// IsConsistent 判断检查报告中是否没有发现问题
+func (r *Report) IsConsistent() bool {
+	return len(r.Duplicates) == 0 && len(r.OrphanRelations) == 0 && len(r.VendorMismatches) == 0
+}
@@ -0,0 +1,48 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package datadistinct distinct value data service
+package datadistinct
+
+import (
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/runtime/filter"
+)
+
+// ListReq 查询资源表字段去重值的请求
+type ListReq struct {
+	// Table 资源表，如 cvm、vpc
+	Table table.Name `json:"table" validate:"required"`
+	// Field 去重的字段，如 region、vendor
+	Field  string             `json:"field" validate:"required"`
+	Filter *filter.Expression `json:"filter" validate:"required"`
+	// Limit 返回的去重值的最大数量，为0时使用默认值
+	Limit uint `json:"limit" validate:"omitempty,max=500"`
+}
+
+// Validate ListReq
+func (req *ListReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ListResp 查询资源表字段去重值的结果
+type ListResp struct {
+	Details []string `json:"details"`
+}
@@ -0,0 +1,112 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package event defines resource change event data-service api.
+package event
+
+import (
+	"fmt"
+
+	"hcm/pkg/cdc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// DefaultPullLimit is the default max events returned by one pull.
+const DefaultPullLimit = 100
+
+// MaxPullLimit is the max events returned by one pull.
+const MaxPullLimit = 500
+
+// PullReq pull resource change events request.
+type PullReq struct {
+	Consumer string                   `json:"consumer" validate:"required,max=64"`
+	Topic    enumor.AuditResourceType `json:"topic" validate:"required,max=50"`
+	// Offset 从该位点之后开始拉取，不指定时从消费方已提交的位点之后开始拉取
+	Offset *uint64 `json:"offset" validate:"omitempty"`
+	Limit  uint    `json:"limit" validate:"omitempty,max=500"`
+	// Filter 事件的附加过滤条件，用于按权限过滤事件，字段为审计记录的字段
+	Filter *filter.Expression `json:"filter" validate:"omitempty"`
+}
+
+// Validate PullReq.
+func (req *PullReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// PullResult pull resource change events result.
+type PullResult struct {
+	Details []cdc.Event `json:"details"`
+	// NextOffset 处理完本次拉取的事件后应提交的位点，没有新事件时为本次拉取的起始位点
+	NextOffset uint64 `json:"next_offset"`
+}
+
+// OffsetCommitReq commit consumed offset request.
+type OffsetCommitReq struct {
+	Consumer string                   `json:"consumer" validate:"required,max=64"`
+	Topic    enumor.AuditResourceType `json:"topic" validate:"required,max=50"`
+	Offset   *uint64                  `json:"offset" validate:"required"`
+}
+
+// Validate OffsetCommitReq.
+func (req *OffsetCommitReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	// 生产者的投递位点只能由生产者提交，避免集成方误提交导致事件漏投或重复投递
+	if req.Consumer == cdc.ProducerConsumer {
+		return fmt.Errorf("consumer %s is reserved", cdc.ProducerConsumer)
+	}
+
+	return nil
+}
+
+const (
+	// DefaultWatchTimeoutSec is the default seconds to wait for new events of one watch.
+	DefaultWatchTimeoutSec = 30
+	// MaxWatchTimeoutSec is the max seconds to wait for new events of one watch.
+	MaxWatchTimeoutSec = 60
+)
+
+// WatchReq watch resource change events request, the request is blocked until there are new events after the
+// revision or the timeout is reached.
+type WatchReq struct {
+	Topic enumor.AuditResourceType `json:"topic" validate:"required,max=50"`
+	// Revision 从该版本之后开始监听，版本即事件位点。不指定时不返回事件，只返回当前的最新版本，
+	// 集成方可先全量拉取资源，再从返回的版本开始监听，避免漏掉全量拉取期间的变更
+	Revision *uint64 `json:"revision" validate:"omitempty"`
+	// Filter 事件的附加过滤条件，如按资源ID、账号、业务过滤，字段为审计记录的字段
+	Filter     *filter.Expression `json:"filter" validate:"omitempty"`
+	Limit      uint               `json:"limit" validate:"omitempty,max=500"`
+	TimeoutSec uint               `json:"timeout_sec" validate:"omitempty,max=60"`
+}
+
+// Validate WatchReq.
+func (req *WatchReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// WatchResult watch resource change events result.
+type WatchResult struct {
+	Details []cdc.Event `json:"details"`
+	// Revision 下次监听时使用的版本，超时没有新事件时为本次监听的起始版本
+	Revision uint64 `json:"revision"`
+}
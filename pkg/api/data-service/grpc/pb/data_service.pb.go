@@ -0,0 +1,1526 @@
+// TencentBlueKing is pleased to support the open source community by making
+// 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+// Copyright (C) 2024 THL A29 Limited,
+// a Tencent company. All rights reserved.
+// Licensed under the MIT License (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at http://opensource.org/licenses/MIT
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+//
+// We undertake not to change the open source license (MIT license) applicable
+//
+// to the current version of the project delivered to anyone in the future.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        (unknown)
+// source: data_service.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CvmBatchCreateReq defines batch create cvm request.
+type CvmBatchCreateReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vendor string       `protobuf:"bytes,1,opt,name=vendor,proto3" json:"vendor,omitempty"`
+	Cvms   []*CvmCreate `protobuf:"bytes,2,rep,name=cvms,proto3" json:"cvms,omitempty"`
+}
+
+func (x *CvmBatchCreateReq) Reset() {
+	*x = CvmBatchCreateReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CvmBatchCreateReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CvmBatchCreateReq) ProtoMessage() {}
+
+func (x *CvmBatchCreateReq) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CvmBatchCreateReq.ProtoReflect.Descriptor instead.
+func (*CvmBatchCreateReq) Descriptor() ([]byte, []int) {
+	return file_data_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CvmBatchCreateReq) GetVendor() string {
+	if x != nil {
+		return x.Vendor
+	}
+	return ""
+}
+
+func (x *CvmBatchCreateReq) GetCvms() []*CvmCreate {
+	if x != nil {
+		return x.Cvms
+	}
+	return nil
+}
+
+// CvmCreate defines the cvm to create.
+type CvmCreate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CloudId              string   `protobuf:"bytes,1,opt,name=cloud_id,json=cloudId,proto3" json:"cloud_id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	BkBizId              int64    `protobuf:"varint,3,opt,name=bk_biz_id,json=bkBizId,proto3" json:"bk_biz_id,omitempty"`
+	BkCloudId            int64    `protobuf:"varint,4,opt,name=bk_cloud_id,json=bkCloudId,proto3" json:"bk_cloud_id,omitempty"`
+	AccountId            string   `protobuf:"bytes,5,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Region               string   `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`
+	Zone                 string   `protobuf:"bytes,7,opt,name=zone,proto3" json:"zone,omitempty"`
+	CloudVpcIds          []string `protobuf:"bytes,8,rep,name=cloud_vpc_ids,json=cloudVpcIds,proto3" json:"cloud_vpc_ids,omitempty"`
+	VpcIds               []string `protobuf:"bytes,9,rep,name=vpc_ids,json=vpcIds,proto3" json:"vpc_ids,omitempty"`
+	CloudSubnetIds       []string `protobuf:"bytes,10,rep,name=cloud_subnet_ids,json=cloudSubnetIds,proto3" json:"cloud_subnet_ids,omitempty"`
+	SubnetIds            []string `protobuf:"bytes,11,rep,name=subnet_ids,json=subnetIds,proto3" json:"subnet_ids,omitempty"`
+	CloudImageId         string   `protobuf:"bytes,12,opt,name=cloud_image_id,json=cloudImageId,proto3" json:"cloud_image_id,omitempty"`
+	ImageId              string   `protobuf:"bytes,13,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	OsName               string   `protobuf:"bytes,14,opt,name=os_name,json=osName,proto3" json:"os_name,omitempty"`
+	Memo                 *string  `protobuf:"bytes,15,opt,name=memo,proto3,oneof" json:"memo,omitempty"`
+	Status               string   `protobuf:"bytes,16,opt,name=status,proto3" json:"status,omitempty"`
+	PrivateIpv4Addresses []string `protobuf:"bytes,17,rep,name=private_ipv4_addresses,json=privateIpv4Addresses,proto3" json:"private_ipv4_addresses,omitempty"`
+	PrivateIpv6Addresses []string `protobuf:"bytes,18,rep,name=private_ipv6_addresses,json=privateIpv6Addresses,proto3" json:"private_ipv6_addresses,omitempty"`
+	PublicIpv4Addresses  []string `protobuf:"bytes,19,rep,name=public_ipv4_addresses,json=publicIpv4Addresses,proto3" json:"public_ipv4_addresses,omitempty"`
+	PublicIpv6Addresses  []string `protobuf:"bytes,20,rep,name=public_ipv6_addresses,json=publicIpv6Addresses,proto3" json:"public_ipv6_addresses,omitempty"`
+	MachineType          string   `protobuf:"bytes,21,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`
+	CloudCreatedTime     string   `protobuf:"bytes,22,opt,name=cloud_created_time,json=cloudCreatedTime,proto3" json:"cloud_created_time,omitempty"`
+	CloudLaunchedTime    string   `protobuf:"bytes,23,opt,name=cloud_launched_time,json=cloudLaunchedTime,proto3" json:"cloud_launched_time,omitempty"`
+	CloudExpiredTime     string   `protobuf:"bytes,24,opt,name=cloud_expired_time,json=cloudExpiredTime,proto3" json:"cloud_expired_time,omitempty"`
+	// extension is the json of the vendor's cvm extension.
+	Extension []byte `protobuf:"bytes,25,opt,name=extension,proto3" json:"extension,omitempty"`
+}
+
+func (x *CvmCreate) Reset() {
+	*x = CvmCreate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CvmCreate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CvmCreate) ProtoMessage() {}
+
+func (x *CvmCreate) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CvmCreate.ProtoReflect.Descriptor instead.
+func (*CvmCreate) Descriptor() ([]byte, []int) {
+	return file_data_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CvmCreate) GetCloudId() string {
+	if x != nil {
+		return x.CloudId
+	}
+	return ""
+}
+
+func (x *CvmCreate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CvmCreate) GetBkBizId() int64 {
+	if x != nil {
+		return x.BkBizId
+	}
+	return 0
+}
+
+func (x *CvmCreate) GetBkCloudId() int64 {
+	if x != nil {
+		return x.BkCloudId
+	}
+	return 0
+}
+
+func (x *CvmCreate) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *CvmCreate) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *CvmCreate) GetZone() string {
+	if x != nil {
+		return x.Zone
+	}
+	return ""
+}
+
+func (x *CvmCreate) GetCloudVpcIds() []string {
+	if x != nil {
+		return x.CloudVpcIds
+	}
+	return nil
+}
+
+func (x *CvmCreate) GetVpcIds() []string {
+	if x != nil {
+		return x.VpcIds
+	}
+	return nil
+}
+
+func (x *CvmCreate) GetCloudSubnetIds() []string {
+	if x != nil {
+		return x.CloudSubnetIds
+	}
+	return nil
+}
+
+func (x *CvmCreate) GetSubnetIds() []string {
+	if x != nil {
+		return x.SubnetIds
+	}
+	return nil
+}
+
+func (x *CvmCreate) GetCloudImageId() string {
+	if x != nil {
+		return x.CloudImageId
+	}
+	return ""
+}
+
+func (x *CvmCreate) GetImageId() string {
+	if x != nil {
+		return x.ImageId
+	}
+	return ""
+}
+
+func (x *CvmCreate) GetOsName() string {
+	if x != nil {
+		return x.OsName
+	}
+	return ""
+}
+
+func (x *CvmCreate) GetMemo() string {
+	if x != nil && x.Memo != nil {
+		return *x.Memo
+	}
+	return ""
+}
+
+func (x *CvmCreate) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CvmCreate) GetPrivateIpv4Addresses() []string {
+	if x != nil {
+		return x.PrivateIpv4Addresses
+	}
+	return nil
+}
+
+func (x *CvmCreate) GetPrivateIpv6Addresses() []string {
+	if x != nil {
+		return x.PrivateIpv6Addresses
+	}
+	return nil
+}
+
+func (x *CvmCreate) GetPublicIpv4Addresses() []string {
+	if x != nil {
+		return x.PublicIpv4Addresses
+	}
+	return nil
+}
+
+func (x *CvmCreate) GetPublicIpv6Addresses() []string {
+	if x != nil {
+		return x.PublicIpv6Addresses
+	}
+	return nil
+}
+
+func (x *CvmCreate) GetMachineType() string {
+	if x != nil {
+		return x.MachineType
+	}
+	return ""
+}
+
+func (x *CvmCreate) GetCloudCreatedTime() string {
+	if x != nil {
+		return x.CloudCreatedTime
+	}
+	return ""
+}
+
+func (x *CvmCreate) GetCloudLaunchedTime() string {
+	if x != nil {
+		return x.CloudLaunchedTime
+	}
+	return ""
+}
+
+func (x *CvmCreate) GetCloudExpiredTime() string {
+	if x != nil {
+		return x.CloudExpiredTime
+	}
+	return ""
+}
+
+func (x *CvmCreate) GetExtension() []byte {
+	if x != nil {
+		return x.Extension
+	}
+	return nil
+}
+
+// BatchCreateResult defines batch create result.
+type BatchCreateResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *BatchCreateResult) Reset() {
+	*x = BatchCreateResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchCreateResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateResult) ProtoMessage() {}
+
+func (x *BatchCreateResult) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateResult.ProtoReflect.Descriptor instead.
+func (*BatchCreateResult) Descriptor() ([]byte, []int) {
+	return file_data_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BatchCreateResult) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+// CvmBatchUpdateReq defines batch update cvm request.
+type CvmBatchUpdateReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vendor string       `protobuf:"bytes,1,opt,name=vendor,proto3" json:"vendor,omitempty"`
+	Cvms   []*CvmUpdate `protobuf:"bytes,2,rep,name=cvms,proto3" json:"cvms,omitempty"`
+}
+
+func (x *CvmBatchUpdateReq) Reset() {
+	*x = CvmBatchUpdateReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CvmBatchUpdateReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CvmBatchUpdateReq) ProtoMessage() {}
+
+func (x *CvmBatchUpdateReq) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CvmBatchUpdateReq.ProtoReflect.Descriptor instead.
+func (*CvmBatchUpdateReq) Descriptor() ([]byte, []int) {
+	return file_data_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CvmBatchUpdateReq) GetVendor() string {
+	if x != nil {
+		return x.Vendor
+	}
+	return ""
+}
+
+func (x *CvmBatchUpdateReq) GetCvms() []*CvmUpdate {
+	if x != nil {
+		return x.Cvms
+	}
+	return nil
+}
+
+// CvmUpdate defines the cvm to update.
+type CvmUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	BkBizId              int64    `protobuf:"varint,3,opt,name=bk_biz_id,json=bkBizId,proto3" json:"bk_biz_id,omitempty"`
+	BkCloudId            int64    `protobuf:"varint,4,opt,name=bk_cloud_id,json=bkCloudId,proto3" json:"bk_cloud_id,omitempty"`
+	CloudVpcIds          []string `protobuf:"bytes,5,rep,name=cloud_vpc_ids,json=cloudVpcIds,proto3" json:"cloud_vpc_ids,omitempty"`
+	VpcIds               []string `protobuf:"bytes,6,rep,name=vpc_ids,json=vpcIds,proto3" json:"vpc_ids,omitempty"`
+	CloudSubnetIds       []string `protobuf:"bytes,7,rep,name=cloud_subnet_ids,json=cloudSubnetIds,proto3" json:"cloud_subnet_ids,omitempty"`
+	SubnetIds            []string `protobuf:"bytes,8,rep,name=subnet_ids,json=subnetIds,proto3" json:"subnet_ids,omitempty"`
+	CloudImageId         string   `protobuf:"bytes,9,opt,name=cloud_image_id,json=cloudImageId,proto3" json:"cloud_image_id,omitempty"`
+	ImageId              string   `protobuf:"bytes,10,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	Memo                 *string  `protobuf:"bytes,11,opt,name=memo,proto3,oneof" json:"memo,omitempty"`
+	Status               string   `protobuf:"bytes,12,opt,name=status,proto3" json:"status,omitempty"`
+	PrivateIpv4Addresses []string `protobuf:"bytes,13,rep,name=private_ipv4_addresses,json=privateIpv4Addresses,proto3" json:"private_ipv4_addresses,omitempty"`
+	PrivateIpv6Addresses []string `protobuf:"bytes,14,rep,name=private_ipv6_addresses,json=privateIpv6Addresses,proto3" json:"private_ipv6_addresses,omitempty"`
+	PublicIpv4Addresses  []string `protobuf:"bytes,15,rep,name=public_ipv4_addresses,json=publicIpv4Addresses,proto3" json:"public_ipv4_addresses,omitempty"`
+	PublicIpv6Addresses  []string `protobuf:"bytes,16,rep,name=public_ipv6_addresses,json=publicIpv6Addresses,proto3" json:"public_ipv6_addresses,omitempty"`
+	CloudLaunchedTime    string   `protobuf:"bytes,17,opt,name=cloud_launched_time,json=cloudLaunchedTime,proto3" json:"cloud_launched_time,omitempty"`
+	CloudExpiredTime     string   `protobuf:"bytes,18,opt,name=cloud_expired_time,json=cloudExpiredTime,proto3" json:"cloud_expired_time,omitempty"`
+	// extension is the json of the vendor's cvm extension to update, empty means not update.
+	Extension []byte `protobuf:"bytes,19,opt,name=extension,proto3" json:"extension,omitempty"`
+}
+
+func (x *CvmUpdate) Reset() {
+	*x = CvmUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CvmUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CvmUpdate) ProtoMessage() {}
+
+func (x *CvmUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CvmUpdate.ProtoReflect.Descriptor instead.
+func (*CvmUpdate) Descriptor() ([]byte, []int) {
+	return file_data_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CvmUpdate) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CvmUpdate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CvmUpdate) GetBkBizId() int64 {
+	if x != nil {
+		return x.BkBizId
+	}
+	return 0
+}
+
+func (x *CvmUpdate) GetBkCloudId() int64 {
+	if x != nil {
+		return x.BkCloudId
+	}
+	return 0
+}
+
+func (x *CvmUpdate) GetCloudVpcIds() []string {
+	if x != nil {
+		return x.CloudVpcIds
+	}
+	return nil
+}
+
+func (x *CvmUpdate) GetVpcIds() []string {
+	if x != nil {
+		return x.VpcIds
+	}
+	return nil
+}
+
+func (x *CvmUpdate) GetCloudSubnetIds() []string {
+	if x != nil {
+		return x.CloudSubnetIds
+	}
+	return nil
+}
+
+func (x *CvmUpdate) GetSubnetIds() []string {
+	if x != nil {
+		return x.SubnetIds
+	}
+	return nil
+}
+
+func (x *CvmUpdate) GetCloudImageId() string {
+	if x != nil {
+		return x.CloudImageId
+	}
+	return ""
+}
+
+func (x *CvmUpdate) GetImageId() string {
+	if x != nil {
+		return x.ImageId
+	}
+	return ""
+}
+
+func (x *CvmUpdate) GetMemo() string {
+	if x != nil && x.Memo != nil {
+		return *x.Memo
+	}
+	return ""
+}
+
+func (x *CvmUpdate) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CvmUpdate) GetPrivateIpv4Addresses() []string {
+	if x != nil {
+		return x.PrivateIpv4Addresses
+	}
+	return nil
+}
+
+func (x *CvmUpdate) GetPrivateIpv6Addresses() []string {
+	if x != nil {
+		return x.PrivateIpv6Addresses
+	}
+	return nil
+}
+
+func (x *CvmUpdate) GetPublicIpv4Addresses() []string {
+	if x != nil {
+		return x.PublicIpv4Addresses
+	}
+	return nil
+}
+
+func (x *CvmUpdate) GetPublicIpv6Addresses() []string {
+	if x != nil {
+		return x.PublicIpv6Addresses
+	}
+	return nil
+}
+
+func (x *CvmUpdate) GetCloudLaunchedTime() string {
+	if x != nil {
+		return x.CloudLaunchedTime
+	}
+	return ""
+}
+
+func (x *CvmUpdate) GetCloudExpiredTime() string {
+	if x != nil {
+		return x.CloudExpiredTime
+	}
+	return ""
+}
+
+func (x *CvmUpdate) GetExtension() []byte {
+	if x != nil {
+		return x.Extension
+	}
+	return nil
+}
+
+// BatchUpdateResult defines batch update result.
+type BatchUpdateResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *BatchUpdateResult) Reset() {
+	*x = BatchUpdateResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchUpdateResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateResult) ProtoMessage() {}
+
+func (x *BatchUpdateResult) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateResult.ProtoReflect.Descriptor instead.
+func (*BatchUpdateResult) Descriptor() ([]byte, []int) {
+	return file_data_service_proto_rawDescGZIP(), []int{5}
+}
+
+// ListReq defines list request.
+type ListReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vendor string   `protobuf:"bytes,1,opt,name=vendor,proto3" json:"vendor,omitempty"`
+	Fields []string `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	// filter is the json of the filter expression.
+	Filter []byte `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// page is the json of the base page.
+	Page []byte `protobuf:"bytes,4,opt,name=page,proto3" json:"page,omitempty"`
+}
+
+func (x *ListReq) Reset() {
+	*x = ListReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReq) ProtoMessage() {}
+
+func (x *ListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReq.ProtoReflect.Descriptor instead.
+func (*ListReq) Descriptor() ([]byte, []int) {
+	return file_data_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListReq) GetVendor() string {
+	if x != nil {
+		return x.Vendor
+	}
+	return ""
+}
+
+func (x *ListReq) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *ListReq) GetFilter() []byte {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListReq) GetPage() []byte {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+// CvmListResult defines list cvm result.
+type CvmListResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count   uint64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Details []*Cvm `protobuf:"bytes,2,rep,name=details,proto3" json:"details,omitempty"`
+}
+
+func (x *CvmListResult) Reset() {
+	*x = CvmListResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CvmListResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CvmListResult) ProtoMessage() {}
+
+func (x *CvmListResult) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CvmListResult.ProtoReflect.Descriptor instead.
+func (*CvmListResult) Descriptor() ([]byte, []int) {
+	return file_data_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CvmListResult) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CvmListResult) GetDetails() []*Cvm {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+// Cvm defines cvm with extension.
+type Cvm struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                   string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CloudId              string            `protobuf:"bytes,2,opt,name=cloud_id,json=cloudId,proto3" json:"cloud_id,omitempty"`
+	Name                 string            `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Vendor               string            `protobuf:"bytes,4,opt,name=vendor,proto3" json:"vendor,omitempty"`
+	BkBizId              int64             `protobuf:"varint,5,opt,name=bk_biz_id,json=bkBizId,proto3" json:"bk_biz_id,omitempty"`
+	BkCloudId            int64             `protobuf:"varint,6,opt,name=bk_cloud_id,json=bkCloudId,proto3" json:"bk_cloud_id,omitempty"`
+	AccountId            string            `protobuf:"bytes,7,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Region               string            `protobuf:"bytes,8,opt,name=region,proto3" json:"region,omitempty"`
+	Zone                 string            `protobuf:"bytes,9,opt,name=zone,proto3" json:"zone,omitempty"`
+	CloudVpcIds          []string          `protobuf:"bytes,10,rep,name=cloud_vpc_ids,json=cloudVpcIds,proto3" json:"cloud_vpc_ids,omitempty"`
+	VpcIds               []string          `protobuf:"bytes,11,rep,name=vpc_ids,json=vpcIds,proto3" json:"vpc_ids,omitempty"`
+	CloudSubnetIds       []string          `protobuf:"bytes,12,rep,name=cloud_subnet_ids,json=cloudSubnetIds,proto3" json:"cloud_subnet_ids,omitempty"`
+	SubnetIds            []string          `protobuf:"bytes,13,rep,name=subnet_ids,json=subnetIds,proto3" json:"subnet_ids,omitempty"`
+	CloudImageId         string            `protobuf:"bytes,14,opt,name=cloud_image_id,json=cloudImageId,proto3" json:"cloud_image_id,omitempty"`
+	ImageId              string            `protobuf:"bytes,15,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	OsName               string            `protobuf:"bytes,16,opt,name=os_name,json=osName,proto3" json:"os_name,omitempty"`
+	Memo                 *string           `protobuf:"bytes,17,opt,name=memo,proto3,oneof" json:"memo,omitempty"`
+	Status               string            `protobuf:"bytes,18,opt,name=status,proto3" json:"status,omitempty"`
+	RecycleStatus        string            `protobuf:"bytes,19,opt,name=recycle_status,json=recycleStatus,proto3" json:"recycle_status,omitempty"`
+	PrivateIpv4Addresses []string          `protobuf:"bytes,20,rep,name=private_ipv4_addresses,json=privateIpv4Addresses,proto3" json:"private_ipv4_addresses,omitempty"`
+	PrivateIpv6Addresses []string          `protobuf:"bytes,21,rep,name=private_ipv6_addresses,json=privateIpv6Addresses,proto3" json:"private_ipv6_addresses,omitempty"`
+	PublicIpv4Addresses  []string          `protobuf:"bytes,22,rep,name=public_ipv4_addresses,json=publicIpv4Addresses,proto3" json:"public_ipv4_addresses,omitempty"`
+	PublicIpv6Addresses  []string          `protobuf:"bytes,23,rep,name=public_ipv6_addresses,json=publicIpv6Addresses,proto3" json:"public_ipv6_addresses,omitempty"`
+	MachineType          string            `protobuf:"bytes,24,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`
+	CloudCreatedTime     string            `protobuf:"bytes,25,opt,name=cloud_created_time,json=cloudCreatedTime,proto3" json:"cloud_created_time,omitempty"`
+	CloudLaunchedTime    string            `protobuf:"bytes,26,opt,name=cloud_launched_time,json=cloudLaunchedTime,proto3" json:"cloud_launched_time,omitempty"`
+	CloudExpiredTime     string            `protobuf:"bytes,27,opt,name=cloud_expired_time,json=cloudExpiredTime,proto3" json:"cloud_expired_time,omitempty"`
+	Owner                string            `protobuf:"bytes,28,opt,name=owner,proto3" json:"owner,omitempty"`
+	Labels               map[string]string `protobuf:"bytes,29,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Creator              string            `protobuf:"bytes,30,opt,name=creator,proto3" json:"creator,omitempty"`
+	Reviser              string            `protobuf:"bytes,31,opt,name=reviser,proto3" json:"reviser,omitempty"`
+	CreatedAt            string            `protobuf:"bytes,32,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt            string            `protobuf:"bytes,33,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// extension is the json of the vendor's cvm extension.
+	Extension []byte `protobuf:"bytes,34,opt,name=extension,proto3" json:"extension,omitempty"`
+}
+
+func (x *Cvm) Reset() {
+	*x = Cvm{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Cvm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cvm) ProtoMessage() {}
+
+func (x *Cvm) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cvm.ProtoReflect.Descriptor instead.
+func (*Cvm) Descriptor() ([]byte, []int) {
+	return file_data_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Cvm) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Cvm) GetCloudId() string {
+	if x != nil {
+		return x.CloudId
+	}
+	return ""
+}
+
+func (x *Cvm) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Cvm) GetVendor() string {
+	if x != nil {
+		return x.Vendor
+	}
+	return ""
+}
+
+func (x *Cvm) GetBkBizId() int64 {
+	if x != nil {
+		return x.BkBizId
+	}
+	return 0
+}
+
+func (x *Cvm) GetBkCloudId() int64 {
+	if x != nil {
+		return x.BkCloudId
+	}
+	return 0
+}
+
+func (x *Cvm) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Cvm) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Cvm) GetZone() string {
+	if x != nil {
+		return x.Zone
+	}
+	return ""
+}
+
+func (x *Cvm) GetCloudVpcIds() []string {
+	if x != nil {
+		return x.CloudVpcIds
+	}
+	return nil
+}
+
+func (x *Cvm) GetVpcIds() []string {
+	if x != nil {
+		return x.VpcIds
+	}
+	return nil
+}
+
+func (x *Cvm) GetCloudSubnetIds() []string {
+	if x != nil {
+		return x.CloudSubnetIds
+	}
+	return nil
+}
+
+func (x *Cvm) GetSubnetIds() []string {
+	if x != nil {
+		return x.SubnetIds
+	}
+	return nil
+}
+
+func (x *Cvm) GetCloudImageId() string {
+	if x != nil {
+		return x.CloudImageId
+	}
+	return ""
+}
+
+func (x *Cvm) GetImageId() string {
+	if x != nil {
+		return x.ImageId
+	}
+	return ""
+}
+
+func (x *Cvm) GetOsName() string {
+	if x != nil {
+		return x.OsName
+	}
+	return ""
+}
+
+func (x *Cvm) GetMemo() string {
+	if x != nil && x.Memo != nil {
+		return *x.Memo
+	}
+	return ""
+}
+
+func (x *Cvm) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Cvm) GetRecycleStatus() string {
+	if x != nil {
+		return x.RecycleStatus
+	}
+	return ""
+}
+
+func (x *Cvm) GetPrivateIpv4Addresses() []string {
+	if x != nil {
+		return x.PrivateIpv4Addresses
+	}
+	return nil
+}
+
+func (x *Cvm) GetPrivateIpv6Addresses() []string {
+	if x != nil {
+		return x.PrivateIpv6Addresses
+	}
+	return nil
+}
+
+func (x *Cvm) GetPublicIpv4Addresses() []string {
+	if x != nil {
+		return x.PublicIpv4Addresses
+	}
+	return nil
+}
+
+func (x *Cvm) GetPublicIpv6Addresses() []string {
+	if x != nil {
+		return x.PublicIpv6Addresses
+	}
+	return nil
+}
+
+func (x *Cvm) GetMachineType() string {
+	if x != nil {
+		return x.MachineType
+	}
+	return ""
+}
+
+func (x *Cvm) GetCloudCreatedTime() string {
+	if x != nil {
+		return x.CloudCreatedTime
+	}
+	return ""
+}
+
+func (x *Cvm) GetCloudLaunchedTime() string {
+	if x != nil {
+		return x.CloudLaunchedTime
+	}
+	return ""
+}
+
+func (x *Cvm) GetCloudExpiredTime() string {
+	if x != nil {
+		return x.CloudExpiredTime
+	}
+	return ""
+}
+
+func (x *Cvm) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *Cvm) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Cvm) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *Cvm) GetReviser() string {
+	if x != nil {
+		return x.Reviser
+	}
+	return ""
+}
+
+func (x *Cvm) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Cvm) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Cvm) GetExtension() []byte {
+	if x != nil {
+		return x.Extension
+	}
+	return nil
+}
+
+var File_data_service_proto protoreflect.FileDescriptor
+
+var file_data_service_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x68, 0x63, 0x6d, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x22, 0x5b, 0x0a, 0x11, 0x43, 0x76, 0x6d, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65,
+	0x6e, 0x64, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x65, 0x6e, 0x64,
+	0x6f, 0x72, 0x12, 0x2e, 0x0a, 0x04, 0x63, 0x76, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x68, 0x63, 0x6d, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x43, 0x76, 0x6d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x04, 0x63, 0x76,
+	0x6d, 0x73, 0x22, 0xfc, 0x06, 0x0a, 0x09, 0x43, 0x76, 0x6d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x12, 0x19, 0x0a, 0x08, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1a, 0x0a, 0x09, 0x62, 0x6b, 0x5f, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x62, 0x6b, 0x42, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0b, 0x62,
+	0x6b, 0x5f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x62, 0x6b, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x67, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69,
+	0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f,
+	0x76, 0x70, 0x63, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x63,
+	0x6c, 0x6f, 0x75, 0x64, 0x56, 0x70, 0x63, 0x49, 0x64, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x76, 0x70,
+	0x63, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x70, 0x63,
+	0x49, 0x64, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x73, 0x75, 0x62,
+	0x6e, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x63,
+	0x6c, 0x6f, 0x75, 0x64, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x49, 0x64, 0x73, 0x12, 0x1d, 0x0a,
+	0x0a, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x09, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x49, 0x64, 0x73, 0x12, 0x24, 0x0a, 0x0e,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x49, 0x6d, 0x61, 0x67, 0x65,
+	0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x17, 0x0a,
+	0x07, 0x6f, 0x73, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x6f, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x04, 0x6d, 0x65, 0x6d, 0x6f, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x6d, 0x65, 0x6d, 0x6f, 0x88, 0x01, 0x01, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x70, 0x72, 0x69, 0x76, 0x61,
+	0x74, 0x65, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x09, 0x52, 0x14, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65,
+	0x49, 0x70, 0x76, 0x34, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x34, 0x0a,
+	0x16, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x69, 0x70, 0x76, 0x36, 0x5f, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x09, 0x52, 0x14, 0x70,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x49, 0x70, 0x76, 0x36, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x69, 0x70,
+	0x76, 0x34, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x13, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x13, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x49, 0x70, 0x76, 0x34, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x5f, 0x69, 0x70, 0x76, 0x36, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73,
+	0x18, 0x14, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x49, 0x70,
+	0x76, 0x36, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6d,
+	0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2c,
+	0x0a, 0x12, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x13,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x6c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x4c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x12,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x18, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x45,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x78,
+	0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x6d, 0x65, 0x6d,
+	0x6f, 0x22, 0x25, 0x0a, 0x11, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x5b, 0x0a, 0x11, 0x43, 0x76, 0x6d, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76,
+	0x65, 0x6e, 0x64, 0x6f, 0x72, 0x12, 0x2e, 0x0a, 0x04, 0x63, 0x76, 0x6d, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x68, 0x63, 0x6d, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x76, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
+	0x04, 0x63, 0x76, 0x6d, 0x73, 0x22, 0xbc, 0x05, 0x0a, 0x09, 0x43, 0x76, 0x6d, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x09, 0x62, 0x6b, 0x5f, 0x62, 0x69,
+	0x7a, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x62, 0x6b, 0x42, 0x69,
+	0x7a, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0b, 0x62, 0x6b, 0x5f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f,
+	0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x62, 0x6b, 0x43, 0x6c, 0x6f, 0x75,
+	0x64, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x76, 0x70, 0x63,
+	0x5f, 0x69, 0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x56, 0x70, 0x63, 0x49, 0x64, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x76, 0x70, 0x63, 0x5f, 0x69,
+	0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x70, 0x63, 0x49, 0x64, 0x73,
+	0x12, 0x28, 0x0a, 0x10, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74,
+	0x5f, 0x69, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x49, 0x64, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x75,
+	0x62, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x49, 0x64, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12,
+	0x19, 0x0a, 0x08, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x04, 0x6d, 0x65,
+	0x6d, 0x6f, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x6d, 0x65, 0x6d, 0x6f,
+	0x88, 0x01, 0x01, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x70,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x14, 0x70, 0x72, 0x69,
+	0x76, 0x61, 0x74, 0x65, 0x49, 0x70, 0x76, 0x34, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x12, 0x34, 0x0a, 0x16, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x69, 0x70, 0x76,
+	0x36, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x14, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x49, 0x70, 0x76, 0x36, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73,
+	0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x49, 0x70,
+	0x76, 0x34, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x69, 0x70, 0x76, 0x36, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x65, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x49, 0x70, 0x76, 0x36, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12,
+	0x2e, 0x0a, 0x13, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x6c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x65,
+	0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x4c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x09, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x07, 0x0a, 0x05, 0x5f,
+	0x6d, 0x65, 0x6d, 0x6f, 0x22, 0x13, 0x0a, 0x11, 0x42, 0x61, 0x74, 0x63, 0x68, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x65, 0x0a, 0x07, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65,
+	0x22, 0x55, 0x0a, 0x0d, 0x43, 0x76, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x68, 0x63, 0x6d, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x76, 0x6d, 0x52, 0x07,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x22, 0xc2, 0x09, 0x0a, 0x03, 0x43, 0x76, 0x6d, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x19, 0x0a, 0x08, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x12, 0x1a, 0x0a, 0x09, 0x62, 0x6b, 0x5f, 0x62, 0x69, 0x7a,
+	0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x62, 0x6b, 0x42, 0x69, 0x7a,
+	0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0b, 0x62, 0x6b, 0x5f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x69,
+	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x62, 0x6b, 0x43, 0x6c, 0x6f, 0x75, 0x64,
+	0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x7a, 0x6f, 0x6e,
+	0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x22, 0x0a,
+	0x0d, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x76, 0x70, 0x63, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x0a,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x56, 0x70, 0x63, 0x49, 0x64,
+	0x73, 0x12, 0x17, 0x0a, 0x07, 0x76, 0x70, 0x63, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x0b, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x76, 0x70, 0x63, 0x49, 0x64, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x5f, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x0c,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x53, 0x75, 0x62, 0x6e, 0x65,
+	0x74, 0x49, 0x64, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x5f, 0x69,
+	0x64, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74,
+	0x49, 0x64, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x69, 0x6d, 0x61,
+	0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x6d, 0x61,
+	0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x6d, 0x61,
+	0x67, 0x65, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6f, 0x73, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a,
+	0x04, 0x6d, 0x65, 0x6d, 0x6f, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x6d,
+	0x65, 0x6d, 0x6f, 0x88, 0x01, 0x01, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25,
+	0x0a, 0x0e, 0x72, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65,
+	0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18,
+	0x14, 0x20, 0x03, 0x28, 0x09, 0x52, 0x14, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x49, 0x70,
+	0x76, 0x34, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x70,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x69, 0x70, 0x76, 0x36, 0x5f, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x15, 0x20, 0x03, 0x28, 0x09, 0x52, 0x14, 0x70, 0x72, 0x69,
+	0x76, 0x61, 0x74, 0x65, 0x49, 0x70, 0x76, 0x36, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x12, 0x32, 0x0a, 0x15, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x69, 0x70, 0x76, 0x34,
+	0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x16, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x13, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x49, 0x70, 0x76, 0x34, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f,
+	0x69, 0x70, 0x76, 0x36, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x17,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x49, 0x70, 0x76, 0x36,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x18, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2c, 0x0a, 0x12,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x19, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x5f, 0x6c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x4c, 0x61,
+	0x75, 0x6e, 0x63, 0x68, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x1b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x45, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65,
+	0x72, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x38,
+	0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x1d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x68, 0x63, 0x6d, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x43, 0x76, 0x6d, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x6f, 0x72, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x76, 0x69, 0x73, 0x65, 0x72, 0x18, 0x1f, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x76, 0x69, 0x73, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x20, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x21, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x78,
+	0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x22, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x32, 0x88, 0x02, 0x0a,
+	0x0a, 0x43, 0x76, 0x6d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x58, 0x0a, 0x0e, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x76, 0x6d, 0x12, 0x22, 0x2e,
+	0x68, 0x63, 0x6d, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x43, 0x76, 0x6d, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x1a, 0x22, 0x2e, 0x68, 0x63, 0x6d, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x58, 0x0a, 0x0e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x43, 0x76, 0x6d, 0x12, 0x22, 0x2e, 0x68, 0x63, 0x6d, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x76, 0x6d, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x22, 0x2e, 0x68, 0x63,
+	0x6d, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
+	0x46, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x76, 0x6d, 0x45, 0x78, 0x74, 0x12, 0x18, 0x2e,
+	0x68, 0x63, 0x6d, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x1e, 0x2e, 0x68, 0x63, 0x6d, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x76, 0x6d, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x42, 0x22, 0x5a, 0x20, 0x68, 0x63, 0x6d, 0x2f, 0x70,
+	0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x2d, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_data_service_proto_rawDescOnce sync.Once
+	file_data_service_proto_rawDescData = file_data_service_proto_rawDesc
+)
+
+func file_data_service_proto_rawDescGZIP() []byte {
+	file_data_service_proto_rawDescOnce.Do(func() {
+		file_data_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_data_service_proto_rawDescData)
+	})
+	return file_data_service_proto_rawDescData
+}
+
+var file_data_service_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_data_service_proto_goTypes = []interface{}{
+	(*CvmBatchCreateReq)(nil), // 0: hcm.dataservice.CvmBatchCreateReq
+	(*CvmCreate)(nil),         // 1: hcm.dataservice.CvmCreate
+	(*BatchCreateResult)(nil), // 2: hcm.dataservice.BatchCreateResult
+	(*CvmBatchUpdateReq)(nil), // 3: hcm.dataservice.CvmBatchUpdateReq
+	(*CvmUpdate)(nil),         // 4: hcm.dataservice.CvmUpdate
+	(*BatchUpdateResult)(nil), // 5: hcm.dataservice.BatchUpdateResult
+	(*ListReq)(nil),           // 6: hcm.dataservice.ListReq
+	(*CvmListResult)(nil),     // 7: hcm.dataservice.CvmListResult
+	(*Cvm)(nil),               // 8: hcm.dataservice.Cvm
+	nil,                       // 9: hcm.dataservice.Cvm.LabelsEntry
+}
+var file_data_service_proto_depIdxs = []int32{
+	1, // 0: hcm.dataservice.CvmBatchCreateReq.cvms:type_name -> hcm.dataservice.CvmCreate
+	4, // 1: hcm.dataservice.CvmBatchUpdateReq.cvms:type_name -> hcm.dataservice.CvmUpdate
+	8, // 2: hcm.dataservice.CvmListResult.details:type_name -> hcm.dataservice.Cvm
+	9, // 3: hcm.dataservice.Cvm.labels:type_name -> hcm.dataservice.Cvm.LabelsEntry
+	0, // 4: hcm.dataservice.CvmService.BatchCreateCvm:input_type -> hcm.dataservice.CvmBatchCreateReq
+	3, // 5: hcm.dataservice.CvmService.BatchUpdateCvm:input_type -> hcm.dataservice.CvmBatchUpdateReq
+	6, // 6: hcm.dataservice.CvmService.ListCvmExt:input_type -> hcm.dataservice.ListReq
+	2, // 7: hcm.dataservice.CvmService.BatchCreateCvm:output_type -> hcm.dataservice.BatchCreateResult
+	5, // 8: hcm.dataservice.CvmService.BatchUpdateCvm:output_type -> hcm.dataservice.BatchUpdateResult
+	7, // 9: hcm.dataservice.CvmService.ListCvmExt:output_type -> hcm.dataservice.CvmListResult
+	7, // [7:10] is the sub-list for method output_type
+	4, // [4:7] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_data_service_proto_init() }
+func file_data_service_proto_init() {
+	if File_data_service_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_data_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CvmBatchCreateReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CvmCreate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchCreateResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CvmBatchUpdateReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CvmUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchUpdateResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CvmListResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Cvm); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_data_service_proto_msgTypes[1].OneofWrappers = []interface{}{}
+	file_data_service_proto_msgTypes[4].OneofWrappers = []interface{}{}
+	file_data_service_proto_msgTypes[8].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_data_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_data_service_proto_goTypes,
+		DependencyIndexes: file_data_service_proto_depIdxs,
+		MessageInfos:      file_data_service_proto_msgTypes,
+	}.Build()
+	File_data_service_proto = out.File
+	file_data_service_proto_rawDesc = nil
+	file_data_service_proto_goTypes = nil
+	file_data_service_proto_depIdxs = nil
+}
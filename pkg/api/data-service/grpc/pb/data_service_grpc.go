@@ -0,0 +1,185 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package pb defines the protobuf messages and grpc services of data-service.
+package pb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative data_service.proto
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// CvmServiceBatchCreateCvmMethod is the full method name of CvmService.BatchCreateCvm.
+	CvmServiceBatchCreateCvmMethod = "/hcm.dataservice.CvmService/BatchCreateCvm"
+	// CvmServiceBatchUpdateCvmMethod is the full method name of CvmService.BatchUpdateCvm.
+	CvmServiceBatchUpdateCvmMethod = "/hcm.dataservice.CvmService/BatchUpdateCvm"
+	// CvmServiceListCvmExtMethod is the full method name of CvmService.ListCvmExt.
+	CvmServiceListCvmExtMethod = "/hcm.dataservice.CvmService/ListCvmExt"
+)
+
+// CvmServiceClient is the client api of CvmService.
+type CvmServiceClient interface {
+	BatchCreateCvm(ctx context.Context, in *CvmBatchCreateReq, opts ...grpc.CallOption) (*BatchCreateResult, error)
+	BatchUpdateCvm(ctx context.Context, in *CvmBatchUpdateReq, opts ...grpc.CallOption) (*BatchUpdateResult, error)
+	ListCvmExt(ctx context.Context, in *ListReq, opts ...grpc.CallOption) (*CvmListResult, error)
+}
+
+type cvmServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewCvmServiceClient create a new CvmService client.
+func NewCvmServiceClient(cc grpc.ClientConnInterface) CvmServiceClient {
+	return &cvmServiceClient{cc: cc}
+}
+
+// BatchCreateCvm batch create cvm.
+func (c *cvmServiceClient) BatchCreateCvm(ctx context.Context, in *CvmBatchCreateReq, opts ...grpc.CallOption) (
+	*BatchCreateResult, error) {
+
+	out := new(BatchCreateResult)
+	if err := c.cc.Invoke(ctx, CvmServiceBatchCreateCvmMethod, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BatchUpdateCvm batch update cvm.
+func (c *cvmServiceClient) BatchUpdateCvm(ctx context.Context, in *CvmBatchUpdateReq, opts ...grpc.CallOption) (
+	*BatchUpdateResult, error) {
+
+	out := new(BatchUpdateResult)
+	if err := c.cc.Invoke(ctx, CvmServiceBatchUpdateCvmMethod, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListCvmExt list cvm with extension.
+func (c *cvmServiceClient) ListCvmExt(ctx context.Context, in *ListReq, opts ...grpc.CallOption) (*CvmListResult,
+	error) {
+
+	out := new(CvmListResult)
+	if err := c.cc.Invoke(ctx, CvmServiceListCvmExtMethod, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CvmServiceServer is the server api of CvmService.
+type CvmServiceServer interface {
+	BatchCreateCvm(ctx context.Context, in *CvmBatchCreateReq) (*BatchCreateResult, error)
+	BatchUpdateCvm(ctx context.Context, in *CvmBatchUpdateReq) (*BatchUpdateResult, error)
+	ListCvmExt(ctx context.Context, in *ListReq) (*CvmListResult, error)
+}
+
+// UnimplementedCvmServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedCvmServiceServer struct{}
+
+// BatchCreateCvm is not implemented.
+func (UnimplementedCvmServiceServer) BatchCreateCvm(context.Context, *CvmBatchCreateReq) (*BatchCreateResult,
+	error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateCvm not implemented")
+}
+
+// BatchUpdateCvm is not implemented.
+func (UnimplementedCvmServiceServer) BatchUpdateCvm(context.Context, *CvmBatchUpdateReq) (*BatchUpdateResult,
+	error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateCvm not implemented")
+}
+
+// ListCvmExt is not implemented.
+func (UnimplementedCvmServiceServer) ListCvmExt(context.Context, *ListReq) (*CvmListResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCvmExt not implemented")
+}
+
+// RegisterCvmServiceServer register the CvmService implementation to the grpc server.
+func RegisterCvmServiceServer(s grpc.ServiceRegistrar, srv CvmServiceServer) {
+	s.RegisterService(&CvmServiceDesc, srv)
+}
+
+func cvmServiceBatchCreateCvmHandler(srv interface{}, ctx context.Context, dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+
+	in := new(CvmBatchCreateReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CvmServiceServer).BatchCreateCvm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: CvmServiceBatchCreateCvmMethod}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CvmServiceServer).BatchCreateCvm(ctx, req.(*CvmBatchCreateReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func cvmServiceBatchUpdateCvmHandler(srv interface{}, ctx context.Context, dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+
+	in := new(CvmBatchUpdateReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CvmServiceServer).BatchUpdateCvm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: CvmServiceBatchUpdateCvmMethod}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CvmServiceServer).BatchUpdateCvm(ctx, req.(*CvmBatchUpdateReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func cvmServiceListCvmExtHandler(srv interface{}, ctx context.Context, dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+
+	in := new(ListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CvmServiceServer).ListCvmExt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: CvmServiceListCvmExtMethod}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CvmServiceServer).ListCvmExt(ctx, req.(*ListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CvmServiceDesc is the grpc service descriptor of CvmService.
+var CvmServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hcm.dataservice.CvmService",
+	HandlerType: (*CvmServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "BatchCreateCvm", Handler: cvmServiceBatchCreateCvmHandler},
+		{MethodName: "BatchUpdateCvm", Handler: cvmServiceBatchUpdateCvmHandler},
+		{MethodName: "ListCvmExt", Handler: cvmServiceListCvmExtHandler},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "data_service.proto",
+}
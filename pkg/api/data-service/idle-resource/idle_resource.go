@@ -0,0 +1,93 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package idleresource ...
+package idleresource
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+
+	"github.com/shopspring/decimal"
+)
+
+// IdleDetectReq detect idle resource request.
+type IdleDetectReq struct {
+	// StoppedCvmDays 主机处于关机状态且超过该天数未发生变更时视为闲置
+	StoppedCvmDays uint `json:"stopped_cvm_days" validate:"required,min=1"`
+	// Currency 预估费用的币种
+	Currency enumor.CurrencyCode `json:"currency" validate:"required"`
+	Price    IdlePrice           `json:"price" validate:"required"`
+}
+
+// Validate IdleDetectReq.
+func (req *IdleDetectReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.Price.Validate()
+}
+
+// IdlePrice 用于预估闲置资源每月费用的单价
+type IdlePrice struct {
+	// DiskGBMonthly 硬盘每GB每月单价
+	DiskGBMonthly decimal.Decimal `json:"disk_gb_monthly"`
+	// EipMonthly 弹性IP每个每月单价
+	EipMonthly decimal.Decimal `json:"eip_monthly"`
+	// CvmCpuCoreMonthly 主机每vCPU核每月单价
+	CvmCpuCoreMonthly decimal.Decimal `json:"cvm_cpu_core_monthly"`
+}
+
+// Validate IdlePrice.
+func (p IdlePrice) Validate() error {
+	if p.DiskGBMonthly.IsNegative() || p.EipMonthly.IsNegative() || p.CvmCpuCoreMonthly.IsNegative() {
+		return errors.New("price should not be negative")
+	}
+
+	return nil
+}
+
+// IdleDetectResult detect idle resource result.
+type IdleDetectResult struct {
+	// Counts 各闲置原因检测到的资源数
+	Counts map[enumor.IdleReason]int `json:"counts"`
+}
+
+// IdleStatusUpdateReq batch update idle resource status request.
+type IdleStatusUpdateReq struct {
+	IDs    []string                  `json:"ids" validate:"required,min=1"`
+	Status enumor.IdleResourceStatus `json:"status" validate:"required"`
+}
+
+// Validate IdleStatusUpdateReq.
+func (req *IdleStatusUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.IDs) > constant.BatchOperationMaxLimit {
+		return errors.New("ids should <= 100")
+	}
+
+	return req.Status.Validate()
+}
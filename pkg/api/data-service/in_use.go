@@ -0,0 +1,39 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package dataservice
+
+import "hcm/pkg/criteria/enumor"
+
+// InUseBlocker 资源被其他资源使用而不能删除的阻塞项
+type InUseBlocker struct {
+	// ResType 待删除的资源类型
+	ResType enumor.CloudResourceType `json:"res_type"`
+	// ResID 待删除的资源ID
+	ResID string `json:"res_id"`
+	// RefResType 使用该资源的资源类型
+	RefResType enumor.CloudResourceType `json:"ref_res_type"`
+	// RefResIDs 使用该资源的资源ID
+	RefResIDs []string `json:"ref_res_ids"`
+}
+
+// InUseResult 资源正在被使用时，删除请求返回的阻塞项
+type InUseResult struct {
+	Blockers []InUseBlocker `json:"blockers"`
+}
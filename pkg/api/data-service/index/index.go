@@ -0,0 +1,141 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package dataindex table index management and advisory data service
+package dataindex
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	daoindex "hcm/pkg/dal/dao/index"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/table"
+)
+
+// MaxIndexColumns 单个索引最多包含的列数
+const MaxIndexColumns = 5
+
+// indexNameRegexp 通过接口管理的二级索引名需以 idx_ops_ 开头，以区分建表脚本中的索引，
+// 避免误删除业务依赖的索引
+var indexNameRegexp = regexp.MustCompile(`^idx_ops_[a-z0-9_]{1,56}$`)
+
+// columnNameRegexp 索引列名格式
+var columnNameRegexp = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,63}$`)
+
+// AdviseReq 索引建议请求
+type AdviseReq struct {
+	// MinSlowCount 慢查询次数达到该值的查询条件才会生成索引建议，默认为1
+	MinSlowCount uint64 `json:"min_slow_count"`
+}
+
+// Validate AdviseReq
+func (req *AdviseReq) Validate() error {
+	return nil
+}
+
+// Advice 缺失索引建议
+type Advice struct {
+	Table table.Name `json:"table"`
+	// Columns 建议建立索引的列
+	Columns   []string `json:"columns"`
+	SlowCount uint64   `json:"slow_count"`
+	Sample    string   `json:"sample"`
+}
+
+// AdviseResult 索引建议报告
+type AdviseResult struct {
+	// SlowFilters 出现过慢查询的查询条件统计
+	SlowFilters []orm.QueryStat `json:"slow_filters"`
+	// MissingIndexes 查询条件中没有可用索引的建议
+	MissingIndexes []Advice `json:"missing_indexes"`
+}
+
+// ListReq 查询表索引请求
+type ListReq struct {
+	Table table.Name `json:"table" validate:"required"`
+}
+
+// Validate ListReq
+func (req *ListReq) Validate() error {
+	return req.Table.Validate()
+}
+
+// ListResult 查询表索引结果
+type ListResult struct {
+	Details []daoindex.Index `json:"details"`
+}
+
+// CreateReq 创建二级索引请求
+type CreateReq struct {
+	Table   table.Name `json:"table"`
+	Name    string     `json:"name"`
+	Columns []string   `json:"columns"`
+}
+
+// Validate CreateReq
+func (req *CreateReq) Validate() error {
+	if err := req.Table.Validate(); err != nil {
+		return err
+	}
+
+	if err := validateIndexName(req.Name); err != nil {
+		return err
+	}
+
+	if len(req.Columns) == 0 || len(req.Columns) > MaxIndexColumns {
+		return fmt.Errorf("index columns count should be in [1, %d]", MaxIndexColumns)
+	}
+
+	for _, column := range req.Columns {
+		if !columnNameRegexp.MatchString(column) {
+			return fmt.Errorf("invalid index column: %s", column)
+		}
+	}
+
+	return nil
+}
+
+// DropReq 删除二级索引请求
+type DropReq struct {
+	Table table.Name `json:"table"`
+	Name  string     `json:"name"`
+}
+
+// Validate DropReq
+func (req *DropReq) Validate() error {
+	if err := req.Table.Validate(); err != nil {
+		return err
+	}
+
+	return validateIndexName(req.Name)
+}
+
+func validateIndexName(name string) error {
+	if len(name) == 0 {
+		return errors.New("index name is required")
+	}
+
+	if !indexNameRegexp.MatchString(name) {
+		return fmt.Errorf("index name %s is invalid, should match %s", name, indexNameRegexp.String())
+	}
+
+	return nil
+}
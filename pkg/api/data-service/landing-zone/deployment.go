@@ -0,0 +1,74 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package landingzone defines landing zone data-service api.
+package landingzone
+
+import (
+	"errors"
+
+	corelz "hcm/pkg/api/core/landing-zone"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// DeploymentCreateReq landing zone deployment create request.
+type DeploymentCreateReq struct {
+	Name      string                 `json:"name" validate:"required,max=255"`
+	Vendor    enumor.Vendor          `json:"vendor" validate:"required"`
+	AccountID string                 `json:"account_id" validate:"required"`
+	BkBizID   int64                  `json:"bk_biz_id" validate:"required,min=1"`
+	Region    string                 `json:"region" validate:"required"`
+	Spec      *corelz.DeploymentSpec `json:"spec" validate:"required"`
+	Memo      *string                `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate DeploymentCreateReq.
+func (req *DeploymentCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.Spec.Validate()
+}
+
+// DeploymentUpdateReq landing zone deployment update request.
+type DeploymentUpdateReq struct {
+	State     enumor.LandingZoneDeployState `json:"state"`
+	Reason    *string                       `json:"reason" validate:"omitempty,max=1024"`
+	FlowID    string                        `json:"flow_id" validate:"omitempty,max=64"`
+	Resources *corelz.DeploymentResources   `json:"resources"`
+}
+
+// Validate DeploymentUpdateReq.
+func (req *DeploymentUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.State) == 0 && req.Reason == nil && len(req.FlowID) == 0 && req.Resources == nil {
+		return errors.New("at least one field should be updated")
+	}
+
+	if len(req.State) != 0 {
+		return req.State.Validate()
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package maintenancewindow defines maintenance window data-service api.
+package maintenancewindow
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	coremw "hcm/pkg/api/core/maintenance-window"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// MaintenanceWindowCreateReq maintenance window create request.
+type MaintenanceWindowCreateReq struct {
+	BkBizID   int64                      `json:"bk_biz_id" validate:"required,min=1"`
+	Name      string                     `json:"name" validate:"required,max=255"`
+	Actions   []enumor.MaintenanceAction `json:"actions" validate:"required,min=1"`
+	Weekdays  []int64                    `json:"weekdays" validate:"required,min=1,max=7"`
+	StartTime string                     `json:"start_time" validate:"required"`
+	EndTime   string                     `json:"end_time" validate:"required"`
+	Timezone  string                     `json:"timezone" validate:"required,max=64"`
+	Enabled   bool                       `json:"enabled"`
+	Memo      *string                    `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate MaintenanceWindowCreateReq.
+func (req *MaintenanceWindowCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return validateWindow(req.Actions, req.Weekdays, req.StartTime, req.EndTime, req.Timezone)
+}
+
+// MaintenanceWindowUpdateReq maintenance window update request.
+type MaintenanceWindowUpdateReq struct {
+	Name      *string                    `json:"name" validate:"omitempty,min=1,max=255"`
+	Actions   []enumor.MaintenanceAction `json:"actions" validate:"omitempty,min=1"`
+	Weekdays  []int64                    `json:"weekdays" validate:"omitempty,min=1,max=7"`
+	StartTime string                     `json:"start_time"`
+	EndTime   string                     `json:"end_time"`
+	Timezone  string                     `json:"timezone" validate:"omitempty,max=64"`
+	Enabled   *bool                      `json:"enabled"`
+	Memo      *string                    `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate MaintenanceWindowUpdateReq.
+func (req *MaintenanceWindowUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.Name == nil && len(req.Actions) == 0 && len(req.Weekdays) == 0 && len(req.StartTime) == 0 &&
+		len(req.EndTime) == 0 && len(req.Timezone) == 0 && req.Enabled == nil && req.Memo == nil {
+		return errors.New("at least one field should be updated")
+	}
+
+	return validateWindow(req.Actions, req.Weekdays, req.StartTime, req.EndTime, req.Timezone)
+}
+
+// validateWindow validate the set fields of maintenance window.
+func validateWindow(actions []enumor.MaintenanceAction, weekdays []int64, startTime, endTime, timezone string) error {
+	for _, action := range actions {
+		if err := action.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if len(weekdays) != 0 {
+		if err := coremw.ValidateWeekdays(weekdays); err != nil {
+			return err
+		}
+	}
+
+	for _, clock := range []string{startTime, endTime} {
+		if len(clock) == 0 {
+			continue
+		}
+		if _, err := coremw.ParseClock(clock); err != nil {
+			return err
+		}
+	}
+
+	if len(timezone) != 0 {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return fmt.Errorf("timezone %s is invalid, err: %v", timezone, err)
+		}
+	}
+
+	return nil
+}
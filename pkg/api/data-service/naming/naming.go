@@ -0,0 +1,113 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package naming defines resource naming rule data-service api.
+package naming
+
+import (
+	"errors"
+	"regexp"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// NamingRuleCreateReq naming rule create request.
+type NamingRuleCreateReq struct {
+	BkBizID int64                    `json:"bk_biz_id" validate:"required"`
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	Prefix  string                   `json:"prefix" validate:"omitempty,max=64"`
+	Pattern string                   `json:"pattern" validate:"omitempty,max=255"`
+	Memo    *string                  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate NamingRuleCreateReq.
+func (req *NamingRuleCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.BkBizID <= 0 && req.BkBizID != constant.UnassignedBiz {
+		return errors.New("bk_biz_id should be -1 or greater than 0")
+	}
+
+	if err := enumor.ValidateNamingRuleResType(req.ResType); err != nil {
+		return err
+	}
+
+	if len(req.Prefix) == 0 && len(req.Pattern) == 0 {
+		return errors.New("prefix or pattern is required")
+	}
+
+	return ValidatePattern(req.Pattern)
+}
+
+// NamingRuleUpdateReq naming rule update request, biz and resource type can not be updated.
+type NamingRuleUpdateReq struct {
+	Prefix  *string `json:"prefix" validate:"omitempty,max=64"`
+	Pattern *string `json:"pattern" validate:"omitempty,max=255"`
+	Memo    *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate NamingRuleUpdateReq.
+func (req *NamingRuleUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.Prefix == nil && req.Pattern == nil && req.Memo == nil {
+		return errors.New("at least one field should be updated")
+	}
+
+	if req.Pattern != nil {
+		return ValidatePattern(*req.Pattern)
+	}
+
+	return nil
+}
+
+// ValidatePattern validate the naming rule pattern is a valid regular expression.
+func ValidatePattern(pattern string) error {
+	if len(pattern) == 0 {
+		return nil
+	}
+
+	if _, err := regexp.Compile(pattern); err != nil {
+		return errors.New("pattern is not a valid regular expression")
+	}
+
+	return nil
+}
+
+// NamingViolationCheckReq check naming violation of account resources request.
+type NamingViolationCheckReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+}
+
+// Validate NamingViolationCheckReq.
+func (req *NamingViolationCheckReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// NamingViolationCheckResult check naming violation result.
+type NamingViolationCheckResult struct {
+	// Count 检测到的命名不合规资源数
+	Count int `json:"count"`
+}
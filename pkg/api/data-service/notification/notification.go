@@ -0,0 +1,170 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package notification defines notification data-service api.
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// TemplateCreateReq notification template create request.
+type TemplateCreateReq struct {
+	Name      string                       `json:"name" validate:"required,max=255"`
+	EventType enumor.NotificationEventType `json:"event_type" validate:"required"`
+	Channel   enumor.NotificationChannel   `json:"channel" validate:"required"`
+	Title     string                       `json:"title" validate:"omitempty,max=255"`
+	Content   string                       `json:"content" validate:"required"`
+	Memo      *string                      `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate TemplateCreateReq.
+func (req *TemplateCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := req.EventType.Validate(); err != nil {
+		return err
+	}
+
+	return req.Channel.Validate()
+}
+
+// TemplateUpdateReq notification template update request.
+type TemplateUpdateReq struct {
+	Name    *string `json:"name" validate:"omitempty,min=1,max=255"`
+	Title   *string `json:"title" validate:"omitempty,max=255"`
+	Content *string `json:"content" validate:"omitempty,min=1"`
+	Memo    *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate TemplateUpdateReq.
+func (req *TemplateUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.Name == nil && req.Title == nil && req.Content == nil && req.Memo == nil {
+		return errors.New("at least one field should be updated")
+	}
+
+	return nil
+}
+
+// SubscriptionCreateReq notification subscription create request.
+type SubscriptionCreateReq struct {
+	Name          string                       `json:"name" validate:"required,max=255"`
+	EventType     enumor.NotificationEventType `json:"event_type" validate:"required"`
+	Channels      []enumor.NotificationChannel `json:"channels" validate:"required,min=1,max=3"`
+	Receivers     []string                     `json:"receivers" validate:"omitempty,max=100"`
+	NotifyRelated bool                         `json:"notify_related"`
+	WebhookUrl    string                       `json:"webhook_url" validate:"omitempty,max=1024"`
+	TemplateIDs   []string                     `json:"template_ids" validate:"omitempty,max=3"`
+	AccountIDs    []string                     `json:"account_ids" validate:"omitempty,max=100"`
+	BkBizIDs      []int64                      `json:"bk_biz_ids" validate:"omitempty,max=100"`
+	Enabled       *bool                        `json:"enabled" validate:"required"`
+	Memo          *string                      `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate SubscriptionCreateReq.
+func (req *SubscriptionCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := req.EventType.Validate(); err != nil {
+		return err
+	}
+
+	return ValidateSubscriptionReceivers(req.Channels, req.Receivers, req.NotifyRelated, req.WebhookUrl)
+}
+
+// SubscriptionUpdateReq notification subscription update request.
+type SubscriptionUpdateReq struct {
+	Name          *string                      `json:"name" validate:"omitempty,min=1,max=255"`
+	Channels      []enumor.NotificationChannel `json:"channels" validate:"omitempty,min=1,max=3"`
+	Receivers     []string                     `json:"receivers" validate:"omitempty,max=100"`
+	NotifyRelated *bool                        `json:"notify_related"`
+	WebhookUrl    *string                      `json:"webhook_url" validate:"omitempty,max=1024"`
+	TemplateIDs   []string                     `json:"template_ids" validate:"omitempty,max=3"`
+	AccountIDs    []string                     `json:"account_ids" validate:"omitempty,max=100"`
+	BkBizIDs      []int64                      `json:"bk_biz_ids" validate:"omitempty,max=100"`
+	Enabled       *bool                        `json:"enabled"`
+	Memo          *string                      `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate SubscriptionUpdateReq.
+func (req *SubscriptionUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.Name == nil && req.Channels == nil && req.Receivers == nil && req.NotifyRelated == nil &&
+		req.WebhookUrl == nil && req.TemplateIDs == nil && req.AccountIDs == nil && req.BkBizIDs == nil &&
+		req.Enabled == nil && req.Memo == nil {
+		return errors.New("at least one field should be updated")
+	}
+
+	for _, channel := range req.Channels {
+		if err := channel.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if req.WebhookUrl != nil && len(*req.WebhookUrl) != 0 {
+		if _, err := url.ParseRequestURI(*req.WebhookUrl); err != nil {
+			return fmt.Errorf("webhook_url is invalid, err: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateSubscriptionReceivers validate that every channel of the subscription has someone to notify.
+func ValidateSubscriptionReceivers(channels []enumor.NotificationChannel, receivers []string, notifyRelated bool,
+	webhookUrl string) error {
+
+	for _, channel := range channels {
+		if err := channel.Validate(); err != nil {
+			return err
+		}
+
+		switch channel {
+		case enumor.NotificationEmail, enumor.NotificationWecom:
+			if len(receivers) == 0 && !notifyRelated {
+				return fmt.Errorf("receivers is required when channel is %s and notify_related is false", channel)
+			}
+		case enumor.NotificationWebhook:
+			if len(webhookUrl) == 0 {
+				return errors.New("webhook_url is required when channel is webhook")
+			}
+			if _, err := url.ParseRequestURI(webhookUrl); err != nil {
+				return fmt.Errorf("webhook_url is invalid, err: %v", err)
+			}
+		}
+	}
+
+	return nil
+}
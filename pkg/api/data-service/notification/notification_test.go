@@ -0,0 +1,56 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package notification
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestValidateSubscriptionReceivers(t *testing.T) {
+	cases := []struct {
+		name          string
+		channels      []enumor.NotificationChannel
+		receivers     []string
+		notifyRelated bool
+		webhookUrl    string
+		valid         bool
+	}{
+		{name: "email with receivers", channels: []enumor.NotificationChannel{enumor.NotificationEmail},
+			receivers: []string{"admin"}, valid: true},
+		{name: "wecom notify related users", channels: []enumor.NotificationChannel{enumor.NotificationWecom},
+			notifyRelated: true, valid: true},
+		{name: "email without receivers", channels: []enumor.NotificationChannel{enumor.NotificationEmail}},
+		{name: "webhook", channels: []enumor.NotificationChannel{enumor.NotificationWebhook},
+			webhookUrl: "https://example.com/hook", valid: true},
+		{name: "webhook without url", channels: []enumor.NotificationChannel{enumor.NotificationWebhook}},
+		{name: "webhook with invalid url", channels: []enumor.NotificationChannel{enumor.NotificationWebhook},
+			webhookUrl: "example"},
+		{name: "unsupported channel", channels: []enumor.NotificationChannel{"sms"}, receivers: []string{"admin"}},
+	}
+
+	for _, c := range cases {
+		err := ValidateSubscriptionReceivers(c.channels, c.receivers, c.notifyRelated, c.webhookUrl)
+		if (err == nil) != c.valid {
+			t.Errorf("%s: expect valid %v, but got err: %v", c.name, c.valid, err)
+		}
+	}
+}
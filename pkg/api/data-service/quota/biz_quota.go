@@ -0,0 +1,77 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package quota defines biz resource quota data-service api.
+package quota
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+)
+
+// BizQuotaCreateReq biz quota create request.
+type BizQuotaCreateReq struct {
+	BkBizID    int64   `json:"bk_biz_id" validate:"required,min=1"`
+	MaxCvm     int64   `json:"max_cvm" validate:"min=-1"`
+	MaxCpuCore int64   `json:"max_cpu_core" validate:"min=-1"`
+	MaxEip     int64   `json:"max_eip" validate:"min=-1"`
+	Memo       *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate BizQuotaCreateReq.
+func (req *BizQuotaCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// BizQuotaUpdateReq biz quota update request, biz can not be updated.
+type BizQuotaUpdateReq struct {
+	MaxCvm     *int64  `json:"max_cvm" validate:"omitempty,min=-1"`
+	MaxCpuCore *int64  `json:"max_cpu_core" validate:"omitempty,min=-1"`
+	MaxEip     *int64  `json:"max_eip" validate:"omitempty,min=-1"`
+	Memo       *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate BizQuotaUpdateReq.
+func (req *BizQuotaUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.MaxCvm == nil && req.MaxCpuCore == nil && req.MaxEip == nil && req.Memo == nil {
+		return errors.New("at least one field should be updated")
+	}
+
+	return nil
+}
+
+// BizResUsageListReq list biz resource usage request.
+type BizResUsageListReq struct {
+	BkBizIDs []int64 `json:"bk_biz_ids" validate:"required,min=1"`
+}
+
+// Validate BizResUsageListReq.
+func (req *BizResUsageListReq) Validate() error {
+	if len(req.BkBizIDs) > constant.BatchOperationMaxLimit {
+		return errors.New("bk_biz_ids should <= 100")
+	}
+
+	return validator.Validate.Struct(req)
+}
@@ -0,0 +1,68 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package quota
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// BizResWhitelistCreateReq biz resource whitelist create request.
+type BizResWhitelistCreateReq struct {
+	BkBizID          int64         `json:"bk_biz_id" validate:"required,min=1"`
+	Vendor           enumor.Vendor `json:"vendor" validate:"required"`
+	Regions          []string      `json:"regions" validate:"omitempty,max=100"`
+	InstanceFamilies []string      `json:"instance_families" validate:"omitempty,max=100"`
+	CloudImageIDs    []string      `json:"cloud_image_ids" validate:"omitempty,max=100"`
+	Memo             *string       `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate BizResWhitelistCreateReq.
+func (req *BizResWhitelistCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.Vendor.Validate()
+}
+
+// BizResWhitelistUpdateReq biz resource whitelist update request, nil list means not updated, empty list means
+// unlimited, biz and vendor can not be updated.
+type BizResWhitelistUpdateReq struct {
+	Regions          []string `json:"regions" validate:"omitempty,max=100"`
+	InstanceFamilies []string `json:"instance_families" validate:"omitempty,max=100"`
+	CloudImageIDs    []string `json:"cloud_image_ids" validate:"omitempty,max=100"`
+	Memo             *string  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate BizResWhitelistUpdateReq.
+func (req *BizResWhitelistUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.Regions == nil && req.InstanceFamilies == nil && req.CloudImageIDs == nil && req.Memo == nil {
+		return errors.New("at least one field should be updated")
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package datareshistory resource history data service
+package datareshistory
+
+import (
+	"fmt"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	tablereshistory "hcm/pkg/dal/table/resource-history"
+)
+
+// ListReq ...
+type ListReq struct {
+	core.ListReq `json:",inline"`
+}
+
+// Validate ListReq
+func (req *ListReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := req.ListReq.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListResp ...
+type ListResp core.ListResultT[tablereshistory.ResourceHistoryTable]
+
+// GetAsOfReq 查询资源在指定时间点的版本
+type GetAsOfReq struct {
+	ResType table.Name `json:"res_type" validate:"required"`
+	ResID   string     `json:"res_id" validate:"required"`
+	// Time 时间点，格式为 2006-01-02T15:04:05Z07:00
+	Time string `json:"time" validate:"required"`
+}
+
+// Validate GetAsOfReq
+func (req *GetAsOfReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if _, err := req.ParseTime(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ParseTime parse the time point of GetAsOfReq.
+func (req *GetAsOfReq) ParseTime() (time.Time, error) {
+	at, err := time.ParseInLocation(constant.TimeStdFormat, req.Time, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time format, should be like: %s", constant.TimeStdFormat)
+	}
+
+	return at, nil
+}
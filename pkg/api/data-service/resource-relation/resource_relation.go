@@ -0,0 +1,120 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package datarelation resource relation data service
+package datarelation
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	tablerelation "hcm/pkg/dal/table/resource-relation"
+)
+
+const (
+	// MaxGraphDepth 资源关系图查询的最大深度
+	MaxGraphDepth = 3
+	// MaxGraphNodes 资源关系图返回的最大节点数，超过时停止遍历并标记结果为截断
+	MaxGraphNodes = 1000
+)
+
+// ListReq ...
+type ListReq struct {
+	core.ListReq `json:",inline"`
+}
+
+// Validate ListReq
+func (req *ListReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := req.ListReq.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListResp ...
+type ListResp core.ListResultT[tablerelation.ResourceRelationTable]
+
+// GraphReq 查询以资源为起点的关系图，用于拓扑展示和影响分析(如删除VPC会影响哪些资源)
+type GraphReq struct {
+	ResType table.Name `json:"res_type" validate:"required"`
+	ResID   string     `json:"res_id" validate:"required"`
+	// Depth 遍历深度，为0时只查询与资源直接相连的资源
+	Depth uint `json:"depth" validate:"omitempty,max=3"`
+}
+
+// Validate GraphReq
+func (req *GraphReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// Node 资源关系图中的节点
+type Node struct {
+	ResType table.Name `json:"res_type"`
+	ResID   string     `json:"res_id"`
+	// Depth 节点与起点的距离，起点为0
+	Depth uint `json:"depth"`
+}
+
+// GraphResp 资源关系图
+type GraphResp struct {
+	Nodes []Node                                `json:"nodes"`
+	Edges []tablerelation.ResourceRelationTable `json:"edges"`
+	// Truncated 节点数超过 MaxGraphNodes 时为true，此时关系图不完整
+	Truncated bool `json:"truncated"`
+}
+
+const (
+	// NatGatewayNodeType NAT网关节点类型，NAT网关未纳管，节点基于路由下一跳生成，ResID为云上ID
+	NatGatewayNodeType table.Name = "nat_gateway"
+	// VpnGatewayNodeType VPN网关节点类型，节点基于路由下一跳生成，ResID为云上ID
+	VpnGatewayNodeType table.Name = "vpn_gateway"
+	// VpcPeeringNodeType 对等连接节点类型，节点基于路由下一跳生成，ResID为云上ID
+	VpcPeeringNodeType table.Name = "vpc_peering"
+)
+
+// VpcTopologyReq 查询VPC网络拓扑
+type VpcTopologyReq struct {
+	VpcID string `json:"vpc_id" validate:"required"`
+}
+
+// Validate VpcTopologyReq
+func (req *VpcTopologyReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// TopologyNode VPC网络拓扑中的节点
+type TopologyNode struct {
+	ResType table.Name `json:"res_type"`
+	ResID   string     `json:"res_id"`
+	CloudID string     `json:"cloud_id"`
+	Name    string     `json:"name"`
+}
+
+// VpcTopologyResp VPC网络拓扑，包含子网、路由表、NAT/VPN网关、对等连接、主机及主机关联的安全组、EIP和硬盘
+type VpcTopologyResp struct {
+	Nodes []TopologyNode                        `json:"nodes"`
+	Edges []tablerelation.ResourceRelationTable `json:"edges"`
+	// Truncated 节点数超过 MaxGraphNodes 时为true，此时拓扑不完整
+	Truncated bool `json:"truncated"`
+}
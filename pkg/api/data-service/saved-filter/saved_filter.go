@@ -0,0 +1,104 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package savedfilter defines saved filter data-service api.
+package savedfilter
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// SavedFilterCreateReq saved filter create request.
+type SavedFilterCreateReq struct {
+	Name    string                   `json:"name" validate:"required,max=255"`
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	Scope   enumor.SavedFilterScope  `json:"scope" validate:"required"`
+	BkBizID int64                    `json:"bk_biz_id"`
+	Filter  *filter.Expression       `json:"filter" validate:"required"`
+	Memo    *string                  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate SavedFilterCreateReq.
+func (req *SavedFilterCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if _, exists := enumor.SavedFilterResTypes[req.ResType]; !exists {
+		return fmt.Errorf("res_type: %s not support saved filter", req.ResType)
+	}
+
+	return ValidateSavedFilterScope(req.Scope, req.BkBizID)
+}
+
+// SavedFilterUpdateReq saved filter update request, scope and bk_biz_id must be updated together.
+type SavedFilterUpdateReq struct {
+	Name    *string                 `json:"name" validate:"omitempty,min=1,max=255"`
+	Scope   enumor.SavedFilterScope `json:"scope"`
+	BkBizID int64                   `json:"bk_biz_id"`
+	Filter  *filter.Expression      `json:"filter"`
+	Memo    *string                 `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate SavedFilterUpdateReq.
+func (req *SavedFilterUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.Name == nil && len(req.Scope) == 0 && req.Filter == nil && req.Memo == nil {
+		return errors.New("at least one field should be updated")
+	}
+
+	if len(req.Scope) != 0 {
+		return ValidateSavedFilterScope(req.Scope, req.BkBizID)
+	}
+
+	if req.BkBizID != 0 {
+		return errors.New("bk_biz_id can only be updated with scope")
+	}
+
+	return nil
+}
+
+// ValidateSavedFilterScope validate saved filter scope and the biz it shared with.
+func ValidateSavedFilterScope(scope enumor.SavedFilterScope, bizID int64) error {
+	if err := scope.Validate(); err != nil {
+		return err
+	}
+
+	switch scope {
+	case enumor.SavedFilterBizScope:
+		if bizID <= 0 {
+			return errors.New("bk_biz_id is required when scope is biz")
+		}
+	case enumor.SavedFilterUserScope:
+		if bizID != 0 && bizID != constant.UnassignedBiz {
+			return errors.New("bk_biz_id should not be set when scope is user")
+		}
+	}
+
+	return nil
+}
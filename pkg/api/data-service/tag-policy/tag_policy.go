@@ -0,0 +1,111 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tagpolicy defines tag policy data-service api.
+package tagpolicy
+
+import (
+	"errors"
+	"fmt"
+
+	coretagpolicy "hcm/pkg/api/core/tag-policy"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// TagPolicyCreateReq tag policy create request.
+type TagPolicyCreateReq struct {
+	BkBizID       int64                    `json:"bk_biz_id" validate:"required"`
+	ResType       enumor.CloudResourceType `json:"res_type" validate:"required"`
+	TagKey        string                   `json:"tag_key" validate:"required,max=128"`
+	AllowedValues []string                 `json:"allowed_values" validate:"omitempty,max=100,dive,max=255"`
+	Memo          *string                  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate TagPolicyCreateReq.
+func (req *TagPolicyCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.BkBizID <= 0 && req.BkBizID != constant.UnassignedBiz {
+		return errors.New("bk_biz_id should be -1 or greater than 0")
+	}
+
+	return enumor.ValidateTagPolicyResType(req.ResType)
+}
+
+// TagPolicyUpdateReq tag policy update request, biz, resource type and tag key can not be updated.
+type TagPolicyUpdateReq struct {
+	AllowedValues *[]string `json:"allowed_values" validate:"omitempty,max=100,dive,max=255"`
+	Memo          *string   `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate TagPolicyUpdateReq.
+func (req *TagPolicyUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.AllowedValues == nil && req.Memo == nil {
+		return errors.New("at least one field should be updated")
+	}
+
+	return nil
+}
+
+// TagViolationCheckReq check tag violation of account resources request.
+type TagViolationCheckReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+}
+
+// Validate TagViolationCheckReq.
+func (req *TagViolationCheckReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// TagViolationCheckResult check tag violation result.
+type TagViolationCheckResult struct {
+	// Count 检测到的标签不合规记录数
+	Count int `json:"count"`
+}
+
+// TagComplianceReportReq tag compliance report request.
+type TagComplianceReportReq struct {
+	AccountIDs []string `json:"account_ids" validate:"required,min=1"`
+}
+
+// Validate TagComplianceReportReq.
+func (req *TagComplianceReportReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.AccountIDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("account_ids should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return nil
+}
+
+// TagComplianceReportResult tag compliance report result.
+type TagComplianceReportResult struct {
+	Details []coretagpolicy.AccountCompliance `json:"details"`
+}
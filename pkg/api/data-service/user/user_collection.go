@@ -45,3 +45,29 @@ type UserCollectionListResult struct {
 	Count   uint64                    `json:"count"`
 	Details []coreuser.UserCollection `json:"details"`
 }
+
+// -------------------------- Recent View --------------------------
+
+// MaxRecentViewCount 每个用户每类资源保留的最近访问记录数，超出时删除最早访问的记录
+const MaxRecentViewCount = 50
+
+// UserRecentViewRecordReq define record user recent view req.
+type UserRecentViewRecordReq struct {
+	ResType enumor.UserCollectionResType `json:"res_type" validate:"required"`
+	ResID   string                       `json:"res_id" validate:"required,max=64"`
+}
+
+// Validate UserRecentViewRecordReq.
+func (req UserRecentViewRecordReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.ResType.Validate()
+}
+
+// UserRecentViewListResult define list user recent view result.
+type UserRecentViewListResult struct {
+	Count   uint64                    `json:"count"`
+	Details []coreuser.UserRecentView `json:"details"`
+}
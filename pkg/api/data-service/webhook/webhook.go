@@ -0,0 +1,150 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package webhook defines webhook data-service api.
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"hcm/pkg/cdc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// ConsumerName 返回webhook订阅在资源变更事件上的消费方名称，每个订阅独立记录消费位点
+func ConsumerName(subscriptionID string) string {
+	return "webhook-" + subscriptionID
+}
+
+// SubscriptionCreateReq webhook subscription create request.
+type SubscriptionCreateReq struct {
+	Name string `json:"name" validate:"required,max=255"`
+	Url  string `json:"url" validate:"required,max=1024"`
+	// Secret 加密后的签名密钥
+	Secret   string                     `json:"secret" validate:"required,max=512"`
+	ResTypes []enumor.AuditResourceType `json:"res_types" validate:"required,min=1,max=30"`
+	Actions  []enumor.AuditAction       `json:"actions" validate:"omitempty,max=20"`
+	BkBizIDs []int64                    `json:"bk_biz_ids" validate:"omitempty,max=100"`
+	Enabled  *bool                      `json:"enabled" validate:"required"`
+	Memo     *string                    `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate SubscriptionCreateReq.
+func (req *SubscriptionCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return ValidateSubscriptionFilter(req.Url, req.ResTypes, req.Actions)
+}
+
+// SubscriptionUpdateReq webhook subscription update request.
+type SubscriptionUpdateReq struct {
+	Name     *string                    `json:"name" validate:"omitempty,min=1,max=255"`
+	Url      *string                    `json:"url" validate:"omitempty,min=1,max=1024"`
+	Secret   *string                    `json:"secret" validate:"omitempty,min=1,max=512"`
+	ResTypes []enumor.AuditResourceType `json:"res_types" validate:"omitempty,min=1,max=30"`
+	Actions  []enumor.AuditAction       `json:"actions" validate:"omitempty,max=20"`
+	BkBizIDs []int64                    `json:"bk_biz_ids" validate:"omitempty,max=100"`
+	Enabled  *bool                      `json:"enabled"`
+	Memo     *string                    `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate SubscriptionUpdateReq.
+func (req *SubscriptionUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.Name == nil && req.Url == nil && req.Secret == nil && req.ResTypes == nil && req.Actions == nil &&
+		req.BkBizIDs == nil && req.Enabled == nil && req.Memo == nil {
+		return errors.New("at least one field should be updated")
+	}
+
+	var webhookUrl string
+	if req.Url != nil {
+		webhookUrl = *req.Url
+	}
+	return ValidateSubscriptionFilter(webhookUrl, req.ResTypes, req.Actions)
+}
+
+// ValidateSubscriptionFilter validate the url and the event filters of the webhook subscription, empty url is not
+// validated.
+func ValidateSubscriptionFilter(webhookUrl string, resTypes []enumor.AuditResourceType,
+	actions []enumor.AuditAction) error {
+
+	if len(webhookUrl) != 0 {
+		parsed, err := url.ParseRequestURI(webhookUrl)
+		if err != nil {
+			return fmt.Errorf("url is invalid, err: %v", err)
+		}
+		if parsed.Scheme != "http" && parsed.Scheme != "https" {
+			return fmt.Errorf("url scheme %s is not supported, should be http or https", parsed.Scheme)
+		}
+	}
+
+	for _, resType := range resTypes {
+		if !resType.Exist() {
+			return fmt.Errorf("res_type %s is not supported", resType)
+		}
+	}
+
+	for _, action := range actions {
+		if !action.Exist() {
+			return fmt.Errorf("action %s is not supported", action)
+		}
+	}
+
+	return nil
+}
+
+// DeliveryBatchCreateReq webhook delivery batch create request.
+type DeliveryBatchCreateReq struct {
+	SubscriptionID string      `json:"subscription_id" validate:"required,max=64"`
+	Events         []cdc.Event `json:"events" validate:"required,min=1,max=500"`
+}
+
+// Validate DeliveryBatchCreateReq.
+func (req *DeliveryBatchCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DeliveryUpdateReq webhook delivery update request, update the delivery state and the result of the latest delivery.
+type DeliveryUpdateReq struct {
+	ID         string                      `json:"id" validate:"required,max=64"`
+	State      enumor.WebhookDeliveryState `json:"state" validate:"required"`
+	Attempts   uint                        `json:"attempts"`
+	StatusCode int                         `json:"status_code"`
+	Reason     string                      `json:"reason" validate:"max=1024"`
+	// NextRetryAt 下次投递时间，状态为pending时有效
+	NextRetryAt time.Time `json:"next_retry_at"`
+}
+
+// Validate DeliveryUpdateReq.
+func (req *DeliveryUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.State.Validate()
+}
@@ -137,6 +137,7 @@ type TCloudBatchCreateReq struct {
 	InternetMaxBandwidthOut int64                                `json:"internet_max_bandwidth_out" validate:"omitempty"`
 	InternetChargeType      typecvm.TCloudInternetChargeType     `json:"internet_charge_type" validate:"omitempty"`
 	BandwidthPackageID      *string                              `json:"bandwidth_package_id" validate:"omitempty"`
+	UserData                *string                              `json:"user_data" validate:"omitempty"`
 }
 
 // Validate request.
@@ -0,0 +1,74 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package natgateway defines nat gateway hc-service api.
+package natgateway
+
+import (
+	natgateway "hcm/pkg/adaptor/types/nat-gateway"
+	"hcm/pkg/criteria/validator"
+)
+
+// TCloudNatGatewayCreateReq defines create tencent cloud nat gateway request.
+type TCloudNatGatewayCreateReq struct {
+	AccountID               string `json:"account_id" validate:"required"`
+	Region                  string `json:"region" validate:"required"`
+	CloudVpcID              string `json:"cloud_vpc_id" validate:"required"`
+	Name                    string `json:"name" validate:"required,max=60"`
+	Zone                    string `json:"zone" validate:"omitempty"`
+	InternetMaxBandwidthOut uint64 `json:"internet_max_bandwidth_out" validate:"omitempty"`
+	MaxConcurrentConnection uint64 `json:"max_concurrent_connection" validate:"omitempty"`
+	AddressCount            uint64 `json:"address_count" validate:"required,min=1,max=50"`
+}
+
+// Validate TCloudNatGatewayCreateReq.
+func (req *TCloudNatGatewayCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// TCloudNatGatewayListReq defines list tencent cloud nat gateway request.
+type TCloudNatGatewayListReq struct {
+	AccountID string   `json:"account_id" validate:"required"`
+	Region    string   `json:"region" validate:"required"`
+	CloudIDs  []string `json:"cloud_ids" validate:"required,min=1,max=100"`
+}
+
+// Validate TCloudNatGatewayListReq.
+func (req *TCloudNatGatewayListReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// TCloudNatGatewayListResult defines list tencent cloud nat gateway result.
+type TCloudNatGatewayListResult struct {
+	Details []natgateway.TCloudNatGateway `json:"details"`
+}
+
+// TCloudNatGatewayDeleteReq defines delete tencent cloud nat gateway request.
+type TCloudNatGatewayDeleteReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	Region    string `json:"region" validate:"required"`
+	CloudID   string `json:"cloud_id" validate:"required"`
+	// ReleaseCloudEipIDs 网关删除后需要释放的弹性IP的云上ID，网关删除时云上只解绑弹性IP不会释放
+	ReleaseCloudEipIDs []string `json:"release_cloud_eip_ids" validate:"omitempty,max=50"`
+}
+
+// Validate TCloudNatGatewayDeleteReq.
+func (req *TCloudNatGatewayDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
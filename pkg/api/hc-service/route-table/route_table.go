@@ -21,6 +21,31 @@ package routetable
 
 import "hcm/pkg/criteria/validator"
 
+// -------------------------- Create --------------------------
+
+// TCloudRouteTableCreateReq defines create tencent cloud route table request.
+type TCloudRouteTableCreateReq struct {
+	AccountID  string `json:"account_id" validate:"required"`
+	Region     string `json:"region" validate:"required"`
+	CloudVpcID string `json:"cloud_vpc_id" validate:"required"`
+	Name       string `json:"name" validate:"required,max=60"`
+	// Routes 路由表创建后添加的路由策略
+	Routes []TCloudRouteCreateReq `json:"routes" validate:"omitempty,max=100,dive"`
+}
+
+// Validate TCloudRouteTableCreateReq.
+func (c *TCloudRouteTableCreateReq) Validate() error {
+	return validator.Validate.Struct(c)
+}
+
+// TCloudRouteCreateReq defines create tencent cloud route request.
+type TCloudRouteCreateReq struct {
+	DestinationCidrBlock string  `json:"destination_cidr_block" validate:"required,cidrv4"`
+	GatewayType          string  `json:"gateway_type" validate:"required"`
+	CloudGatewayID       string  `json:"cloud_gateway_id" validate:"required"`
+	Memo                 *string `json:"memo" validate:"omitempty"`
+}
+
 // RouteTableUpdateReq defines update route table request.
 type RouteTableUpdateReq struct {
 	Memo *string `json:"memo" validate:"omitempty"`
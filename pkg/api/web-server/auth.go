@@ -20,7 +20,12 @@
 // Package webserver defines api-server api call protocols.
 package webserver
 
-import "hcm/pkg/iam/meta"
+import (
+	"errors"
+
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/iam/meta"
+)
 
 // AuthVerifyReq auth verify request.
 type AuthVerifyReq struct {
@@ -45,3 +50,29 @@ type AuthVerifyResp struct {
 	Results    []AuthVerifyRes     `json:"results"`
 	Permission *meta.IamPermission `json:"permission"`
 }
+
+// AuthCheckReq batch check whether current user can perform the actions on the resources request.
+type AuthCheckReq struct {
+	Resources []AuthVerifyResource `json:"resources" validate:"required,min=1,max=100"`
+}
+
+// Validate ...
+func (r *AuthCheckReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	for _, res := range r.Resources {
+		if len(res.ResourceType) == 0 || len(res.Action) == 0 {
+			return errors.New("resource_type and action are required")
+		}
+	}
+	return nil
+}
+
+// AuthCheckResp batch check whether current user can perform the actions on the resources response.
+type AuthCheckResp struct {
+	Results []AuthVerifyRes `json:"results"`
+	// ApplyUrl iam apply permission url of all the unauthorized resources, empty when all are authorized.
+	ApplyUrl string `json:"apply_url"`
+}
@@ -0,0 +1,187 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package auditsink ships the audit records to external sinks like syslog, kafka and BlueKing audit center
+// in addition to mysql, for organizations with central audit requirements.
+package auditsink
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/dal/table/audit"
+	"hcm/pkg/logs"
+)
+
+// Backpressure 投递队列满时的处理策略
+type Backpressure string
+
+const (
+	// DropBackpressure 队列满时直接丢弃审计记录，不影响写请求
+	DropBackpressure Backpressure = "drop"
+	// BlockBackpressure 队列满时阻塞写请求，超过阻塞时间后丢弃审计记录
+	BlockBackpressure Backpressure = "block"
+)
+
+// Record 投递到外部系统的审计记录
+type Record struct {
+	audit.AuditTable
+	// Timestamp 审计记录的产生时间，单位：秒
+	Timestamp int64 `json:"timestamp"`
+}
+
+// shipper 异步批量投递审计记录到一个投递目标，每个投递目标有独立的队列，慢的投递目标不会影响其他投递目标
+type shipper struct {
+	sink      Sink
+	queue     chan Record
+	batchSize int
+	dropped   uint64
+}
+
+var (
+	shippers     []*shipper
+	backpressure Backpressure
+	blockTimeout time.Duration
+)
+
+// Init 根据配置初始化审计记录投递，未开启时 Publish 不做任何处理
+func Init(opt cc.AuditSink) error {
+	if !opt.Enable {
+		return nil
+	}
+
+	list := make([]*shipper, 0, len(opt.Sinks))
+	for _, target := range opt.Sinks {
+		sink, err := NewSink(SinkKind(target.Kind), target)
+		if err != nil {
+			return fmt.Errorf("new audit sink %s failed, err: %v", target.Kind, err)
+		}
+
+		list = append(list, &shipper{
+			sink:      sink,
+			queue:     make(chan Record, opt.QueueSize),
+			batchSize: int(opt.BatchSize),
+		})
+	}
+
+	backpressure = Backpressure(opt.Backpressure)
+	blockTimeout = time.Duration(opt.BlockTimeoutMS) * time.Millisecond
+	for _, one := range list {
+		go one.run()
+	}
+	shippers = list
+
+	logs.Infof("audit sink is enabled, sinks: %d, backpressure: %s", len(list), backpressure)
+	return nil
+}
+
+// Enabled 是否开启了审计记录投递
+func Enabled() bool {
+	return len(shippers) != 0
+}
+
+// Publish 投递审计记录，审计记录进入队列后异步发送。队列满时按照配置的策略丢弃，或阻塞调用方直到超时
+func Publish(audits ...*audit.AuditTable) {
+	if len(shippers) == 0 || len(audits) == 0 {
+		return
+	}
+
+	now := time.Now().Unix()
+	records := make([]Record, 0, len(audits))
+	for _, one := range audits {
+		records = append(records, Record{AuditTable: *one, Timestamp: now})
+	}
+
+	var timeout <-chan time.Time
+	if backpressure == BlockBackpressure {
+		timer := time.NewTimer(blockTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	for _, one := range shippers {
+		one.enqueue(records, timeout)
+	}
+}
+
+// enqueue 记录入队，timeout 为空时队列满直接丢弃，否则阻塞到超时后丢弃剩余记录
+func (s *shipper) enqueue(records []Record, timeout <-chan time.Time) {
+	for idx, record := range records {
+		select {
+		case s.queue <- record:
+			continue
+		default:
+		}
+
+		if timeout == nil {
+			atomic.AddUint64(&s.dropped, 1)
+			continue
+		}
+
+		select {
+		case s.queue <- record:
+		case <-timeout:
+			atomic.AddUint64(&s.dropped, uint64(len(records)-idx))
+			return
+		}
+	}
+}
+
+// run 按批次或每秒将队列中的审计记录发送到投递目标
+func (s *shipper) run() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	batch := make([]Record, 0, s.batchSize)
+	for {
+		select {
+		case record := <-s.queue:
+			batch = append(batch, record)
+			if len(batch) < s.batchSize {
+				continue
+			}
+		case <-ticker.C:
+			if dropped := atomic.SwapUint64(&s.dropped, 0); dropped > 0 {
+				logs.Errorf("audit sink %s queue is full, %d audit records are dropped", s.sink.Kind(), dropped)
+			}
+
+			if len(batch) == 0 {
+				continue
+			}
+		}
+
+		s.send(batch)
+		batch = make([]Record, 0, s.batchSize)
+	}
+}
+
+// send 发送审计记录，失败时最多重试3次，仍失败则丢弃并记录日志
+func (s *shipper) send(batch []Record) {
+	var err error
+	for retry := 0; retry < 3; retry++ {
+		if err = s.sink.Send(batch); err == nil {
+			return
+		}
+		time.Sleep(time.Duration(retry+1) * 500 * time.Millisecond)
+	}
+
+	logs.Errorf("send %d audit records to %s sink failed, err: %v", len(batch), s.sink.Kind(), err)
+}
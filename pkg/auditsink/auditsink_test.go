@@ -0,0 +1,80 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package auditsink
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"hcm/pkg/dal/table/audit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type blockingSink struct {
+	lock    sync.Mutex
+	release chan struct{}
+	records []Record
+}
+
+func (s *blockingSink) Kind() SinkKind {
+	return "test"
+}
+
+func (s *blockingSink) Send(records []Record) error {
+	<-s.release
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.records = append(s.records, records...)
+	return nil
+}
+
+func TestShipperBackpressure(t *testing.T) {
+	records := []Record{{AuditTable: audit.AuditTable{ResID: "1"}}, {AuditTable: audit.AuditTable{ResID: "2"}},
+		{AuditTable: audit.AuditTable{ResID: "3"}}}
+
+	// drop 策略下队列满时直接丢弃
+	s := &shipper{sink: new(blockingSink), queue: make(chan Record, 2), batchSize: 10}
+	s.enqueue(records, nil)
+	assert.Equal(t, 2, len(s.queue))
+	assert.Equal(t, uint64(1), s.dropped)
+
+	// block 策略下阻塞到超时后丢弃剩余记录
+	s = &shipper{sink: new(blockingSink), queue: make(chan Record, 1), batchSize: 10}
+	start := time.Now()
+	s.enqueue(records, time.After(100*time.Millisecond))
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	assert.Equal(t, 1, len(s.queue))
+	assert.Equal(t, uint64(2), s.dropped)
+
+	// 投递目标消费后阻塞的写请求可以继续
+	sink := &blockingSink{release: make(chan struct{})}
+	s = &shipper{sink: sink, queue: make(chan Record, 1), batchSize: 1}
+	go s.run()
+	close(sink.release)
+	s.enqueue(records, time.After(5*time.Second))
+	assert.Eventually(t, func() bool {
+		sink.lock.Lock()
+		defer sink.lock.Unlock()
+		return len(sink.records) == 3
+	}, 3*time.Second, 10*time.Millisecond)
+	assert.Equal(t, uint64(0), s.dropped)
+}
@@ -0,0 +1,276 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package auditsink
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/syslog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"hcm/pkg/cc"
+)
+
+// SinkKind 审计记录投递目标类型
+type SinkKind string
+
+const (
+	// SyslogSink 将审计记录以 json 格式写入 syslog
+	SyslogSink SinkKind = "syslog"
+	// KafkaSink 通过 kafka rest proxy 将审计记录写入 kafka 的 topic
+	KafkaSink SinkKind = "kafka"
+	// BkAuditSink 通过蓝鲸监控自定义事件上报接口将审计记录接入蓝鲸审计中心
+	BkAuditSink SinkKind = "bkaudit"
+)
+
+// Sink 审计记录投递目标，其他外部系统可实现该接口后通过 RegisterSink 注册
+type Sink interface {
+	// Kind 返回投递目标类型
+	Kind() SinkKind
+	// Send 批量发送审计记录，返回错误时会重试
+	Send(records []Record) error
+}
+
+// SinkFactory 根据配置创建审计记录投递目标
+type SinkFactory func(opt cc.AuditSinkTarget) (Sink, error)
+
+var (
+	sinkLock      sync.RWMutex
+	sinkFactories = map[SinkKind]SinkFactory{
+		SyslogSink:  newSyslogSink,
+		KafkaSink:   newKafkaSink,
+		BkAuditSink: newBkAuditSink,
+	}
+)
+
+// RegisterSink 注册审计记录投递目标
+func RegisterSink(kind SinkKind, factory SinkFactory) {
+	sinkLock.Lock()
+	defer sinkLock.Unlock()
+
+	sinkFactories[kind] = factory
+}
+
+// NewSink 根据类型创建审计记录投递目标
+func NewSink(kind SinkKind, opt cc.AuditSinkTarget) (Sink, error) {
+	sinkLock.RLock()
+	factory, exist := sinkFactories[kind]
+	sinkLock.RUnlock()
+
+	if !exist {
+		return nil, fmt.Errorf("audit sink %s is not registered", kind)
+	}
+
+	return factory(opt)
+}
+
+// syslogSink 每条审计记录以一行 json 写入 syslog，endpoint 为空时写入本机 syslog
+type syslogSink struct {
+	writer *syslog.Writer
+}
+
+func newSyslogSink(opt cc.AuditSinkTarget) (Sink, error) {
+	tag := opt.Options["tag"]
+	if len(tag) == 0 {
+		tag = "hcm-audit"
+	}
+
+	var network, addr string
+	if len(opt.Endpoint) != 0 {
+		u, err := url.Parse(opt.Endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("parse syslog endpoint failed, err: %v", err)
+		}
+		network, addr = u.Scheme, u.Host
+	}
+
+	writer, err := syslog.Dial(network, addr, syslog.LOG_INFO|syslog.LOG_LOCAL0, tag)
+	if err != nil {
+		return nil, fmt.Errorf("dial syslog failed, err: %v", err)
+	}
+
+	return &syslogSink{writer: writer}, nil
+}
+
+// Kind ...
+func (s *syslogSink) Kind() SinkKind {
+	return SyslogSink
+}
+
+// Send ...
+func (s *syslogSink) Send(records []Record) error {
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+
+		if err = s.writer.Info(string(line)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// kafkaSink 通过 kafka rest proxy(v2) 写入 kafka，以资源类型和资源ID作为消息的 key，保证同一资源的审计记录有序
+type kafkaSink struct {
+	url    string
+	client *http.Client
+}
+
+func newKafkaSink(opt cc.AuditSinkTarget) (Sink, error) {
+	if len(opt.Endpoint) == 0 {
+		return nil, fmt.Errorf("endpoint is required for kafka sink")
+	}
+
+	topic := opt.Options["topic"]
+	if len(topic) == 0 {
+		return nil, fmt.Errorf("options.topic is required for kafka sink")
+	}
+
+	return &kafkaSink{
+		url:    strings.TrimSuffix(opt.Endpoint, "/") + "/topics/" + url.PathEscape(topic),
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Kind ...
+func (s *kafkaSink) Kind() SinkKind {
+	return KafkaSink
+}
+
+type kafkaRecord struct {
+	Key   string `json:"key"`
+	Value Record `json:"value"`
+}
+
+// Send ...
+func (s *kafkaSink) Send(records []Record) error {
+	list := make([]kafkaRecord, 0, len(records))
+	for _, record := range records {
+		list = append(list, kafkaRecord{Key: string(record.ResType) + "/" + record.ResID, Value: record})
+	}
+
+	return postJson(s.client, s.url, "application/vnd.kafka.json.v2+json", map[string]interface{}{"records": list})
+}
+
+// bkAuditSink 通过蓝鲸监控自定义事件上报接口上报审计记录，蓝鲸审计中心接入该数据源后进行审计
+type bkAuditSink struct {
+	endpoint    string
+	dataID      int64
+	accessToken string
+	client      *http.Client
+}
+
+func newBkAuditSink(opt cc.AuditSinkTarget) (Sink, error) {
+	if len(opt.Endpoint) == 0 {
+		return nil, fmt.Errorf("endpoint is required for bkaudit sink")
+	}
+
+	dataID, err := strconv.ParseInt(opt.Options["dataID"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("options.dataID of bkaudit sink is invalid, err: %v", err)
+	}
+
+	if len(opt.Options["accessToken"]) == 0 {
+		return nil, fmt.Errorf("options.accessToken is required for bkaudit sink")
+	}
+
+	return &bkAuditSink{
+		endpoint:    opt.Endpoint,
+		dataID:      dataID,
+		accessToken: opt.Options["accessToken"],
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Kind ...
+func (s *bkAuditSink) Kind() SinkKind {
+	return BkAuditSink
+}
+
+type bkAuditEvent struct {
+	EventName string            `json:"event_name"`
+	Event     map[string]string `json:"event"`
+	Target    string            `json:"target"`
+	Dimension map[string]string `json:"dimension"`
+	Timestamp int64             `json:"timestamp"`
+}
+
+// Send ...
+func (s *bkAuditSink) Send(records []Record) error {
+	events := make([]bkAuditEvent, 0, len(records))
+	for _, record := range records {
+		content, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+
+		events = append(events, bkAuditEvent{
+			EventName: "hcm_audit",
+			Event:     map[string]string{"content": string(content)},
+			Target:    "hcm",
+			Dimension: map[string]string{
+				"username":         record.Operator,
+				"action_id":        string(record.Action),
+				"resource_type_id": string(record.ResType),
+				"instance_id":      record.ResID,
+				"instance_name":    record.ResName,
+				"bk_biz_id":        strconv.FormatInt(record.BkBizID, 10),
+				"request_id":       record.Rid,
+				"bk_app_code":      record.AppCode,
+			},
+			Timestamp: record.Timestamp * 1000,
+		})
+	}
+
+	req := map[string]interface{}{
+		"data_id":      s.dataID,
+		"access_token": s.accessToken,
+		"data":         events,
+	}
+	return postJson(s.client, s.endpoint, "application/json", req)
+}
+
+func postJson(client *http.Client, addr, contentType string, body interface{}) error {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Post(addr, contentType, bytes.NewReader(raw))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%s responds with status code %d", addr, resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+// Package cache provides the redis cache of the frequently read and rarely changed list apis. Cached results of a
+// resource type are versioned, the write apis of the resource type increase the version, so that all cached results
+// are invalidated at once, and the stale results are expired by ttl.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// keyPrefix is the prefix of all cache keys.
+const keyPrefix = "hcm:cache"
+
+// store is the redis cache of the list apis, cache is disabled if nil.
+var store *redisCache
+
+type redisCache struct {
+	cli redis.Cmdable
+	ttl time.Duration
+	// readPrimary is the duration after invalidation in which the cache miss reads the primary database, so that
+	// the stale result of the lagged replica is not cached under the new version.
+	readPrimary time.Duration
+}
+
+// Enable the cache of the list apis wrapped by Cached.
+func Enable(opt cc.Cache) {
+	store = &redisCache{
+		cli:         NewRedisClient(opt.Redis),
+		ttl:         time.Duration(opt.TTLSec) * time.Second,
+		readPrimary: time.Duration(opt.ReadPrimarySec) * time.Second,
+	}
+}
+
+// HandlerFunc is the rest api handler.
+type HandlerFunc func(cts *rest.Contexts) (interface{}, error)
+
+// Cached cache the results of the list api of the resource type, results are keyed by tenant, path and request
+// body. The request reading primary database is never cached, and the api is called directly if redis fails.
+func Cached(resType enumor.CloudResourceType, handler HandlerFunc) HandlerFunc {
+	return func(cts *rest.Contexts) (interface{}, error) {
+		if store == nil || cts.Kit.ReadPrimary {
+			return handler(cts)
+		}
+
+		key, invalidated, err := store.key(cts, resType)
+		if err != nil {
+			logs.Errorf("get %s cache key failed, err: %v, rid: %s", resType, err, cts.Kit.Rid)
+			return handler(cts)
+		}
+
+		value, err := store.cli.Get(cts.Kit.Ctx, key).Bytes()
+		if err == nil {
+			return json.RawMessage(value), nil
+		}
+		if !errors.Is(err, redis.Nil) {
+			logs.Errorf("get %s cache failed, err: %v, rid: %s", resType, err, cts.Kit.Rid)
+		}
+
+		// 失效后短时间内从库可能尚未同步，缓存未命中时从主库读取，避免将从库的旧数据缓存到新版本下
+		if invalidated {
+			cts.Kit = cts.Kit.WithReadPrimary()
+		}
+
+		reply, err := handler(cts)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err = json.Marshal(reply)
+		if err != nil {
+			logs.Errorf("marshal %s cache value failed, err: %v, rid: %s", resType, err, cts.Kit.Rid)
+			return reply, nil
+		}
+		if err = store.cli.SetEx(cts.Kit.Ctx, key, value, store.ttl).Err(); err != nil {
+			logs.Errorf("set %s cache failed, err: %v, rid: %s", resType, err, cts.Kit.Rid)
+		}
+
+		return reply, nil
+	}
+}
+
+// Invalidating invalidate all cached results of the resource type after the write api succeeds.
+func Invalidating(resType enumor.CloudResourceType, handler HandlerFunc) HandlerFunc {
+	return func(cts *rest.Contexts) (interface{}, error) {
+		reply, err := handler(cts)
+		if err != nil || store == nil {
+			return reply, err
+		}
+
+		_, pipeErr := store.cli.TxPipelined(cts.Kit.Ctx, func(pipe redis.Pipeliner) error {
+			pipe.Incr(cts.Kit.Ctx, versionKey(resType))
+			if store.readPrimary > 0 {
+				pipe.SetEx(cts.Kit.Ctx, invalidatedKey(resType), "1", store.readPrimary)
+			}
+			return nil
+		})
+		if pipeErr != nil {
+			// 失效失败时缓存最多在有效期后过期，不影响写接口的结果
+			logs.Errorf("invalidate %s cache failed, err: %v, rid: %s", resType, pipeErr, cts.Kit.Rid)
+		}
+
+		return reply, err
+	}
+}
+
+// key get the cache key of the request with the current version of the resource type, and whether the resource
+// type is invalidated recently.
+func (c *redisCache) key(cts *rest.Contexts, resType enumor.CloudResourceType) (string, bool, error) {
+	tenantID, err := cacheTenant(cts)
+	if err != nil {
+		return "", false, err
+	}
+
+	values, err := c.cli.MGet(cts.Kit.Ctx, versionKey(resType), invalidatedKey(resType)).Result()
+	if err != nil {
+		return "", false, err
+	}
+
+	version, _ := values[0].(string)
+	if len(version) == 0 {
+		version = "0"
+	}
+	invalidated := values[1] != nil
+
+	body, err := cts.RequestBody()
+	if err != nil {
+		return "", false, err
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(cts.Request.Request.URL.Path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+
+	return fmt.Sprintf("%s:%s:%s:%s:%s", keyPrefix, tenantID, resType, version, hex.EncodeToString(hash.Sum(nil))),
+		invalidated, nil
+}
+
+// cacheTenant get the tenant of the cache key, the request without tenant is not cached when multi-tenant is
+// enabled, so that the results of different tenants are never mixed up.
+func cacheTenant(cts *rest.Contexts) (string, error) {
+	if !tools.TenantEnabled() {
+		return cts.Kit.GetTenantID(), nil
+	}
+
+	tenantID := cts.Kit.TenantID
+	if len(tenantID) == 0 {
+		return "", errors.New("tenant id is required when multi-tenant is enabled")
+	}
+
+	if strings.ContainsAny(tenantID, ": \t\r\n") {
+		return "", fmt.Errorf("invalid tenant id %q", tenantID)
+	}
+
+	return tenantID, nil
+}
+
+func versionKey(resType enumor.CloudResourceType) string {
+	return fmt.Sprintf("%s:%s:version", keyPrefix, resType)
+}
+
+func invalidatedKey(resType enumor.CloudResourceType) string {
+	return fmt.Sprintf("%s:%s:invalidated", keyPrefix, resType)
+}
@@ -0,0 +1,149 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+package cache
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/emicklei/go-restful/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func enableTestCache(t *testing.T) *miniredis.Miniredis {
+	server := miniredis.RunT(t)
+
+	Enable(cc.Cache{
+		Enable:         true,
+		Redis:          cc.Redis{Endpoint: server.Addr(), DialTimeoutMS: 1000, PoolSize: 2},
+		TTLSec:         60,
+		ReadPrimarySec: 10,
+	})
+	t.Cleanup(func() { store = nil })
+
+	return server
+}
+
+func newContexts(tenantID string, body string) *rest.Contexts {
+	kt := kit.New()
+	kt.TenantID = tenantID
+
+	return &rest.Contexts{
+		Kit:     kt,
+		Request: restful.NewRequest(httptest.NewRequest("POST", "/api/v1/data/regions/list", strings.NewReader(body))),
+	}
+}
+
+// listHandler returns the count of calls, and records whether the call reads primary database.
+type listHandler struct {
+	calls       int
+	readPrimary []bool
+}
+
+func (h *listHandler) handle(cts *rest.Contexts) (interface{}, error) {
+	h.calls++
+	h.readPrimary = append(h.readPrimary, cts.Kit.ReadPrimary)
+	return map[string]int{"calls": h.calls}, nil
+}
+
+func TestCachedAndInvalidating(t *testing.T) {
+	server := enableTestCache(t)
+
+	h := new(listHandler)
+	list := Cached(enumor.RegionCloudResType, h.handle)
+	write := Invalidating(enumor.RegionCloudResType, func(cts *rest.Contexts) (interface{}, error) {
+		return nil, nil
+	})
+
+	_, err := list(newContexts("", `{"page":1}`))
+	require.NoError(t, err)
+	reply, err := list(newContexts("", `{"page":1}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"calls":1}`, string(reply.(json.RawMessage)))
+	assert.Equal(t, 1, h.calls)
+
+	// different body is cached separately
+	_, err = list(newContexts("", `{"page":2}`))
+	require.NoError(t, err)
+	assert.Equal(t, 2, h.calls)
+
+	// write api invalidates all cached results, the next miss reads primary database
+	_, err = write(newContexts("", `{}`))
+	require.NoError(t, err)
+	_, err = list(newContexts("", `{"page":1}`))
+	require.NoError(t, err)
+	assert.Equal(t, 3, h.calls)
+	assert.Equal(t, []bool{false, false, true}, h.readPrimary)
+
+	// after the read primary window, the miss reads replica again
+	server.FastForward(11 * time.Second)
+	_, err = list(newContexts("", `{"page":3}`))
+	require.NoError(t, err)
+	assert.Equal(t, []bool{false, false, true, false}, h.readPrimary)
+}
+
+func TestCachedRedisFailure(t *testing.T) {
+	server := enableTestCache(t)
+	server.Close()
+
+	h := new(listHandler)
+	list := Cached(enumor.RegionCloudResType, h.handle)
+	for i := 0; i < 2; i++ {
+		_, err := list(newContexts("", `{}`))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, h.calls)
+}
+
+func TestCachedTenant(t *testing.T) {
+	enableTestCache(t)
+	tools.EnableTenant(true)
+	defer tools.EnableTenant(false)
+
+	h := new(listHandler)
+	list := Cached(enumor.RegionCloudResType, h.handle)
+
+	_, err := list(newContexts("t1", `{}`))
+	require.NoError(t, err)
+	_, err = list(newContexts("t2", `{}`))
+	require.NoError(t, err)
+	assert.Equal(t, 2, h.calls, "results of different tenants should not be shared")
+
+	_, err = list(newContexts("t1", `{}`))
+	require.NoError(t, err)
+	assert.Equal(t, 2, h.calls)
+
+	// request without or with invalid tenant is never cached
+	for _, tenantID := range []string{"", "t1:x", ""} {
+		_, err = list(newContexts(tenantID, `{}`))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 5, h.calls)
+}
@@ -0,0 +1,51 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBkMonitorValidate(t *testing.T) {
+	valid := BkMonitor{Enable: true, IntervalMin: 5, Endpoints: []string{"http://127.0.0.1"}, DataID: 1,
+		AccessToken: "token"}
+	assert.NoError(t, valid.validate())
+
+	// disabled bk monitor is not validated.
+	assert.NoError(t, BkMonitor{}.validate())
+
+	invalid := valid
+	invalid.IntervalMin = 0
+	assert.Error(t, invalid.validate())
+
+	invalid = valid
+	invalid.Endpoints = nil
+	assert.Error(t, invalid.validate())
+
+	invalid = valid
+	invalid.DataID = 0
+	assert.Error(t, invalid.validate())
+
+	invalid = valid
+	invalid.AccessToken = ""
+	assert.Error(t, invalid.validate())
+}
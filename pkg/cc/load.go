@@ -29,30 +29,46 @@ import (
 
 // LoadSettings load service's configuration
 func LoadSettings(sys *SysOption) error {
+	s, err := buildSettings(sys, nil)
+	if err != nil {
+		return err
+	}
+
+	initRuntime(s)
+
+	return nil
+}
+
+// buildSettings load settings from the config file, and override it with the yaml overlay if it is not empty.
+func buildSettings(sys *SysOption, overlay []byte) (Setting, error) {
 	if len(sys.ConfigFile) == 0 {
-		return errors.New("service's configuration file path is not configured")
+		return nil, errors.New("service's configuration file path is not configured")
 	}
 
 	// configure file is configured, then load configuration from file.
 	s, err := loadFromFile(sys.ConfigFile)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if len(overlay) != 0 {
+		if err = yaml.Unmarshal(overlay, s); err != nil {
+			return nil, fmt.Errorf("unmarshal setting overlay yaml failed, err: %v", err)
+		}
 	}
 
 	if err = s.trySetFlagBindIP(sys.BindIP); err != nil {
-		return err
+		return nil, err
 	}
 
 	// s the default value if user not configured.
 	s.trySetDefault()
 
-	if err := s.Validate(); err != nil {
-		return err
+	if err = s.Validate(); err != nil {
+		return nil, err
 	}
 
-	initRuntime(s)
-
-	return nil
+	return s, nil
 }
 
 // loadFromFile load service's configuration from local config file.
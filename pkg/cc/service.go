@@ -80,6 +80,7 @@ type ApiServerSetting struct {
 	Network Network   `yaml:"network"`
 	Service Service   `yaml:"service"`
 	Log     LogOption `yaml:"log"`
+	AppAuth AppAuth   `yaml:"appAuth"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -107,23 +108,43 @@ func (s ApiServerSetting) Validate() error {
 		return err
 	}
 
+	if err := s.AppAuth.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // CloudServerSetting defines cloud server used setting options.
 type CloudServerSetting struct {
-	Network        Network        `yaml:"network"`
-	Service        Service        `yaml:"service"`
-	Log            LogOption      `yaml:"log"`
-	Crypto         Crypto         `yaml:"crypto"`
-	Esb            Esb            `yaml:"esb"`
-	BkHcmUrl       string         `yaml:"bkHcmUrl"`
-	CloudResource  CloudResource  `yaml:"cloudResource"`
-	Recycle        Recycle        `yaml:"recycle"`
-	BillConfig     BillConfig     `yaml:"billConfig"`
-	Itsm           ApiGateway     `yaml:"itsm"`
-	CloudSelection CloudSelection `yaml:"cloudSelection"`
-	Cmsi           CMSI           `yaml:"cmsi"`
+	Network        Network         `yaml:"network"`
+	Service        Service         `yaml:"service"`
+	Log            LogOption       `yaml:"log"`
+	Crypto         Crypto          `yaml:"crypto"`
+	Esb            Esb             `yaml:"esb"`
+	BkHcmUrl       string          `yaml:"bkHcmUrl"`
+	CloudResource  CloudResource   `yaml:"cloudResource"`
+	Recycle        Recycle         `yaml:"recycle"`
+	BillConfig     BillConfig      `yaml:"billConfig"`
+	BudgetAlert    BudgetAlert     `yaml:"budgetAlert"`
+	BillReconcile  BillReconcile   `yaml:"billReconcile"`
+	CvmSchedule    CvmSchedule     `yaml:"cvmSchedule"`
+	CmdbHostSync   CmdbHostSync    `yaml:"cmdbHostSync"`
+	BkMonitor      BkMonitor       `yaml:"bkMonitor"`
+	PreDeleteHooks []PreDeleteHook `yaml:"preDeleteHooks"`
+	IdleResource   IdleResource    `yaml:"idleResource"`
+	PriceEstimate  PriceEstimate   `yaml:"priceEstimate"`
+	NamingPolicy   NamingPolicy    `yaml:"namingPolicy"`
+	TagPolicy      TagPolicy       `yaml:"tagPolicy"`
+	Compliance     Compliance      `yaml:"compliance"`
+	Webhook        Webhook         `yaml:"webhook"`
+	Tracing        Tracing         `yaml:"tracing"`
+	Idempotency    Idempotency     `yaml:"idempotency"`
+	Itsm           ApiGateway      `yaml:"itsm"`
+	CloudSelection CloudSelection  `yaml:"cloudSelection"`
+	Cmsi           CMSI            `yaml:"cmsi"`
+	ConfigWatch    ConfigWatch     `yaml:"configWatch"`
+	FeatureFlags   FeatureFlags    `yaml:"featureFlags"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -136,6 +157,16 @@ func (s *CloudServerSetting) trySetDefault() {
 	s.Network.trySetDefault()
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
+	s.IdleResource.trySetDefault()
+	s.PriceEstimate.trySetDefault()
+	s.Compliance.trySetDefault()
+	s.Webhook.trySetDefault()
+	s.Tracing.trySetDefault()
+	s.Idempotency.trySetDefault()
+	s.ConfigWatch.trySetDefault()
+	for i := range s.PreDeleteHooks {
+		s.PreDeleteHooks[i].trySetDefault()
+	}
 
 	return
 }
@@ -174,10 +205,60 @@ func (s CloudServerSetting) Validate() error {
 		return err
 	}
 
+	if err := s.BudgetAlert.validate(); err != nil {
+		return err
+	}
+
+	if err := s.BillReconcile.validate(); err != nil {
+		return err
+	}
+
+	if err := s.CvmSchedule.validate(); err != nil {
+		return err
+	}
+
+	if err := s.CmdbHostSync.validate(); err != nil {
+		return err
+	}
+
+	if err := s.BkMonitor.validate(); err != nil {
+		return err
+	}
+
+	for _, hook := range s.PreDeleteHooks {
+		if err := hook.validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := s.IdleResource.validate(); err != nil {
+		return err
+	}
+
+	if err := s.PriceEstimate.validate(); err != nil {
+		return err
+	}
+
+	if err := s.Compliance.validate(); err != nil {
+		return err
+	}
+
+	if err := s.Webhook.validate(); err != nil {
+		return err
+	}
+
+	if err := s.Tracing.validate(); err != nil {
+		return err
+	}
+
 	if err := s.Cmsi.validate(); err != nil {
 		return err
 	}
 
+	if err := s.FeatureFlags.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -190,6 +271,27 @@ type DataServiceSetting struct {
 	Objectstore ObjectStore `yaml:"objectstore"`
 	Crypto      Crypto      `yaml:"crypto"`
 	Esb         Esb         `yaml:"esb"`
+	Tenant      Tenant      `yaml:"tenant"`
+	// ConsistencyCheck 数据一致性检查配置
+	ConsistencyCheck ConsistencyCheck `yaml:"consistencyCheck"`
+	// PageLimit 分页查询的最大条数配置
+	PageLimit PageLimit `yaml:"pageLimit"`
+	// ChangeEvent 数据变更事件配置
+	ChangeEvent ChangeEvent `yaml:"changeEvent"`
+	// AuditRetention 审计记录保留配置
+	AuditRetention AuditRetention `yaml:"auditRetention"`
+	// AuditSink 审计记录外部投递配置
+	AuditSink AuditSink `yaml:"auditSink"`
+	// Tracing 链路追踪配置
+	Tracing Tracing `yaml:"tracing"`
+	// Grpc grpc传输配置，开启后提供热点接口的grpc服务
+	Grpc Grpc `yaml:"grpc"`
+	// Cache 热点读接口的redis缓存配置
+	Cache Cache `yaml:"cache"`
+	// ConfigWatch 配置热更新配置
+	ConfigWatch ConfigWatch `yaml:"configWatch"`
+	// FeatureFlags 特性开关配置
+	FeatureFlags FeatureFlags `yaml:"featureFlags"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -203,6 +305,13 @@ func (s *DataServiceSetting) trySetDefault() {
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
 	s.Database.trySetDefault()
+	s.ChangeEvent.trySetDefault()
+	s.AuditRetention.trySetDefault()
+	s.AuditSink.trySetDefault()
+	s.Tracing.trySetDefault()
+	s.Grpc.trySetDefault()
+	s.Cache.trySetDefault()
+	s.ConfigWatch.trySetDefault()
 
 	return
 }
@@ -229,6 +338,38 @@ func (s DataServiceSetting) Validate() error {
 		return err
 	}
 
+	if err := s.ConsistencyCheck.validate(); err != nil {
+		return err
+	}
+
+	if err := s.PageLimit.validate(); err != nil {
+		return err
+	}
+
+	if err := s.ChangeEvent.validate(); err != nil {
+		return err
+	}
+
+	if err := s.AuditRetention.validate(); err != nil {
+		return err
+	}
+
+	if err := s.AuditSink.validate(); err != nil {
+		return err
+	}
+
+	if err := s.Tracing.validate(); err != nil {
+		return err
+	}
+
+	if err := s.Cache.validate(); err != nil {
+		return err
+	}
+
+	if err := s.FeatureFlags.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -238,6 +379,31 @@ type HCServiceSetting struct {
 	Service    Service    `yaml:"service"`
 	Log        LogOption  `yaml:"log"`
 	SyncConfig SyncConfig `yaml:"sync"`
+	Tracing    Tracing    `yaml:"tracing"`
+	// DataServiceGrpc 调用data-service的grpc传输配置，开启后热点接口通过grpc调用
+	DataServiceGrpc Grpc `yaml:"dataServiceGrpc"`
+	// DataServiceClient 调用data-service的容错配置
+	DataServiceClient ClientResilience `yaml:"dataServiceClient"`
+	// ConfigWatch 配置热更新配置
+	ConfigWatch ConfigWatch `yaml:"configWatch"`
+	// Credential 外部凭据管理配置
+	Credential Credential `yaml:"credential"`
+	// HealthCheck 健康检查配置
+	HealthCheck HealthCheck `yaml:"healthCheck"`
+	// Shutdown 优雅退出配置
+	Shutdown GracefulShutdown `yaml:"shutdown"`
+	// FeatureFlags 特性开关配置
+	FeatureFlags FeatureFlags `yaml:"featureFlags"`
+	// Timeout 操作超时配置
+	Timeout OperationTimeout `yaml:"timeout"`
+	// ClientPool 云厂商客户端池配置
+	ClientPool ClientPool `yaml:"clientPool"`
+	// FaultInjection 云上接口故障注入配置，仅用于非生产环境的故障演练
+	FaultInjection FaultInjection `yaml:"faultInjection"`
+	// CloudApiQuota 云上接口调用量统计配置
+	CloudApiQuota CloudApiQuota `yaml:"cloudApiQuota"`
+	// OperatorTag 创建云上资源时自动添加的标签配置
+	OperatorTag OperatorTag `yaml:"operatorTag"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -251,6 +417,15 @@ func (s *HCServiceSetting) trySetDefault() {
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
 	s.SyncConfig.trySetDefault()
+	s.Tracing.trySetDefault()
+	s.DataServiceGrpc.trySetDefault()
+	s.DataServiceClient.trySetDefault()
+	s.ConfigWatch.trySetDefault()
+	s.Credential.trySetDefault()
+	s.Shutdown.trySetDefault()
+	s.Timeout.trySetDefault()
+	s.ClientPool.trySetDefault()
+	s.OperatorTag.trySetDefault()
 
 	return
 }
@@ -268,6 +443,24 @@ func (s HCServiceSetting) Validate() error {
 	if err := s.SyncConfig.Validate(); err != nil {
 		return fmt.Errorf("syncConfig validate error: %w", err)
 	}
+	if err := s.Tracing.validate(); err != nil {
+		return err
+	}
+	if err := s.Credential.validate(); err != nil {
+		return err
+	}
+	if err := s.FeatureFlags.validate(); err != nil {
+		return err
+	}
+	if err := s.FaultInjection.validate(); err != nil {
+		return err
+	}
+	if err := s.CloudApiQuota.validate(); err != nil {
+		return err
+	}
+	if err := s.OperatorTag.validate(); err != nil {
+		return err
+	}
 	return nil
 }
 
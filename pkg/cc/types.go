@@ -26,10 +26,12 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
 	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/tools/ssl"
 	"hcm/pkg/version"
@@ -132,6 +134,13 @@ func (es Etcd) validate() error {
 	return nil
 }
 
+// Tenant defines the multi-tenant isolation options.
+type Tenant struct {
+	// Enabled defines whether the tenant scoped resources are isolated by the request's tenant id,
+	// if the request does not specify tenant id, the default tenant id is used.
+	Enabled bool `yaml:"enabled"`
+}
+
 // Limiter defines the request limit options
 type Limiter struct {
 	// QPS should >=1
@@ -204,28 +213,48 @@ type WatchDog struct {
 // DataBase defines database related runtime
 type DataBase struct {
 	Resource ResourceDB `yaml:"resource"`
+	// Replicas defines the read-only replicas of the resource database, list and count
+	// queries will be routed to these replicas, while writes always go to the primary.
+	Replicas []ResourceDB `yaml:"replicas"`
 	// MaxSlowLogLatencyMS defines the max tolerance in millisecond to execute
 	// the database command, if the cost time of execute have >= the MaxSlowLogLatencyMS
 	// then this request will be logged.
 	MaxSlowLogLatencyMS uint `yaml:"maxSlowLogLatencyMS"`
+	// SlowLogWithArgs defines whether to log the sql args in the slow log, which helps to find
+	// the filters that need index. args may contain sensitive data, so it is disabled by default.
+	SlowLogWithArgs bool `yaml:"slowLogWithArgs"`
+	// WriteOpTimeoutSec defines the max time in second of a write request or a transaction, it is canceled and
+	// rolled back when the time is exceeded, so that a blocked write can not hold the connection forever.
+	WriteOpTimeoutSec uint `yaml:"writeOpTimeoutSec"`
 	// Limiter defines request's to ORM's limitation for each sharding, and
 	// each sharding have the independent request limitation.
 	Limiter *Limiter `yaml:"limiter"`
+	// Migration defines the schema migration options, which is applied on data-service startup.
+	Migration Migration `yaml:"migration"`
 }
 
 // trySetDefault set the sharding default value if user not configured.
 func (s *DataBase) trySetDefault() {
 	s.Resource.trySetDefault()
 
+	for idx := range s.Replicas {
+		s.Replicas[idx].trySetDefault()
+	}
+
 	if s.MaxSlowLogLatencyMS == 0 {
 		s.MaxSlowLogLatencyMS = 100
 	}
 
+	if s.WriteOpTimeoutSec == 0 {
+		s.WriteOpTimeoutSec = 30
+	}
+
 	if s.Limiter == nil {
 		s.Limiter = new(Limiter)
 	}
 
 	s.Limiter.trySetDefault()
+	s.Migration.trySetDefault()
 }
 
 // validate sharding runtime
@@ -234,6 +263,12 @@ func (s DataBase) validate() error {
 		return err
 	}
 
+	for idx, replica := range s.Replicas {
+		if err := replica.validate(); err != nil {
+			return fmt.Errorf("replicas[%d] is invalid, %v", idx, err)
+		}
+	}
+
 	if s.MaxSlowLogLatencyMS <= 0 {
 		return errors.New("invalid maxSlowLogLatencyMS")
 	}
@@ -247,6 +282,22 @@ func (s DataBase) validate() error {
 	return nil
 }
 
+// Migration defines the schema migration options.
+type Migration struct {
+	// Enable defines whether to apply the embedded sql files on startup.
+	Enable bool `yaml:"enable"`
+	// LockTimeoutSec defines the max seconds to wait for the migration lock,
+	// which makes sure only one instance do migration at the same time.
+	LockTimeoutSec uint `yaml:"lockTimeoutSec"`
+}
+
+// trySetDefault set the migration default value if user not configured.
+func (m *Migration) trySetDefault() {
+	if m.LockTimeoutSec == 0 {
+		m.LockTimeoutSec = 300
+	}
+}
+
 // ResourceDB defines database related runtime.
 type ResourceDB struct {
 	// Endpoints is a seed list of host:port addresses of database nodes.
@@ -381,6 +432,10 @@ type Network struct {
 	// Port is port where server listen to http port.
 	Port uint      `yaml:"port"`
 	TLS  TLSConfig `yaml:"tls"`
+	// BodyLimit 请求体大小限制
+	BodyLimit BodyLimit `yaml:"bodyLimit"`
+	// AccessLog 访问日志配置
+	AccessLog AccessLog `yaml:"accessLog"`
 }
 
 // trySetFlagBindIP try set flag bind ip, bindIP only can set by one of the flag or configuration file.
@@ -402,6 +457,57 @@ func (n *Network) trySetDefault() {
 	if len(n.BindIP) == 0 {
 		n.BindIP = "127.0.0.1"
 	}
+	n.BodyLimit.trySetDefault()
+	n.AccessLog.trySetDefault()
+}
+
+// BodyLimit 请求体大小限制，超过限制的请求返回413，避免超大请求体耗尽服务的内存
+type BodyLimit struct {
+	// MaxSizeMB 普通请求一次性解析的请求体的最大长度，单位：MB，默认32
+	MaxSizeMB uint `yaml:"maxSizeMB"`
+	// MaxStreamSizeMB 流式解析的大批量请求体、上传文件的最大长度，单位：MB，默认512
+	MaxStreamSizeMB uint `yaml:"maxStreamSizeMB"`
+}
+
+func (b *BodyLimit) trySetDefault() {
+	if b.MaxSizeMB == 0 {
+		b.MaxSizeMB = 32
+	}
+
+	if b.MaxStreamSizeMB == 0 {
+		b.MaxStreamSizeMB = 512
+	}
+}
+
+// AccessLog 访问日志配置，按比例采样记录请求的访问日志，失败的请求和慢请求总是记录，慢请求额外记录请求头、请求体等
+// 完整的请求上下文用于排查问题
+type AccessLog struct {
+	// Enable 是否开启访问日志
+	Enable bool `yaml:"enable"`
+	// SamplePercent 成功且非慢请求的访问日志的采样百分比，取值范围[0, 100]，0表示只记录失败的请求和慢请求
+	SamplePercent uint `yaml:"samplePercent"`
+	// SlowThresholdMS 处理耗时超过该值的请求为慢请求，单位：毫秒，默认3000
+	SlowThresholdMS uint `yaml:"slowThresholdMS"`
+	// MaxBodyKB 慢请求记录的请求体的最大长度，超过的部分被截断，单位：KB，默认4
+	MaxBodyKB uint `yaml:"maxBodyKB"`
+}
+
+func (a *AccessLog) trySetDefault() {
+	if a.SlowThresholdMS == 0 {
+		a.SlowThresholdMS = 3000
+	}
+
+	if a.MaxBodyKB == 0 {
+		a.MaxBodyKB = 4
+	}
+}
+
+func (a AccessLog) validate() error {
+	if a.SamplePercent > 100 {
+		return fmt.Errorf("samplePercent should be in [0, 100], but got %d", a.SamplePercent)
+	}
+
+	return nil
 }
 
 // validate network options
@@ -418,6 +524,10 @@ func (n Network) validate() error {
 		return fmt.Errorf("network tls, %v", err)
 	}
 
+	if err := n.AccessLog.validate(); err != nil {
+		return fmt.Errorf("network accessLog, %v", err)
+	}
+
 	return nil
 }
 
@@ -604,7 +714,8 @@ func (a AesGcm) validate() error {
 // Crypto 定义项目里需要用到的加密，包括选择的算法等
 // TODO: 这里默认只支持AES Gcm算法，后续需要支持国密等的选择，可能还需要支持根据不同场景配置不同（比如不同场景，加密的密钥等都不一样）
 type Crypto struct {
-	AesGcm AesGcm `yaml:"aesGcm"`
+	AesGcm   AesGcm         `yaml:"aesGcm"`
+	Envelope CryptoEnvelope `yaml:"envelope"`
 }
 
 func (c Crypto) validate() error {
@@ -612,9 +723,80 @@ func (c Crypto) validate() error {
 		return err
 	}
 
+	if err := c.Envelope.validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CryptoEnvelope 信封加密配置，开启后敏感数据使用主密钥加密的数据密钥进行加密，历史AesGcm密文仍可正常解密
+type CryptoEnvelope struct {
+	Enable bool `yaml:"enable"`
+	// Provider 主密钥提供方，可选值: local, sm
+	Provider string `yaml:"provider"`
+	// ActiveKeyID 当前用于加密的主密钥ID，轮换主密钥时修改该值并保留旧主密钥用于解密
+	ActiveKeyID string      `yaml:"activeKeyID"`
+	Keys        []CryptoKey `yaml:"keys"`
+}
+
+func (c CryptoEnvelope) validate() error {
+	if !c.Enable {
+		return nil
+	}
+
+	switch c.Provider {
+	case "local", "sm":
+	case "":
+		return errors.New("crypto envelope provider is not set")
+	default:
+		return fmt.Errorf("crypto envelope provider %s is not supported", c.Provider)
+	}
+
+	if len(c.ActiveKeyID) == 0 {
+		return errors.New("crypto envelope activeKeyID is not set")
+	}
+
+	found := false
+	ids := make(map[string]struct{}, len(c.Keys))
+	for _, key := range c.Keys {
+		if len(key.ID) == 0 {
+			return errors.New("crypto envelope key id is not set")
+		}
+
+		if _, exist := ids[key.ID]; exist {
+			return fmt.Errorf("crypto envelope key id %s is duplicated", key.ID)
+		}
+		ids[key.ID] = struct{}{}
+
+		if key.ID == c.ActiveKeyID {
+			found = true
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("crypto envelope active key %s not found in keys", c.ActiveKeyID)
+	}
+
 	return nil
 }
 
+// KeyMap 返回主密钥ID到主密钥的映射
+func (c CryptoEnvelope) KeyMap() map[string]string {
+	keys := make(map[string]string, len(c.Keys))
+	for _, key := range c.Keys {
+		keys[key.ID] = key.Key
+	}
+
+	return keys
+}
+
+// CryptoKey 主密钥配置，local类型为AES密钥(16或32字节)，sm类型为SM4密钥(16字节)
+type CryptoKey struct {
+	ID  string `yaml:"id"`
+	Key string `yaml:"key"`
+}
+
 // CloudResource 云资源配置
 type CloudResource struct {
 	Sync CloudResourceSync `yaml:"sync"`
@@ -645,6 +827,178 @@ func (c CloudResourceSync) validate() error {
 	return nil
 }
 
+// ConsistencyCheck 数据一致性检查配置
+type ConsistencyCheck struct {
+	Enable bool `yaml:"enable"`
+	// IntervalMin 定时检查的间隔，单位：分钟
+	IntervalMin uint `yaml:"intervalMin"`
+	// AutoFix 是否自动修复可安全修复的问题(如删除孤立的关联记录)，其余问题只输出到检查报告中
+	AutoFix bool `yaml:"autoFix"`
+}
+
+func (c ConsistencyCheck) validate() error {
+	if c.Enable && c.IntervalMin < 10 {
+		return errors.New("consistencyCheck.intervalMin must >= 10")
+	}
+
+	return nil
+}
+
+// AuditRetention 审计记录保留配置，开启后定时删除超过保留天数的审计记录
+type AuditRetention struct {
+	Enable bool `yaml:"enable"`
+	// Days 审计记录保留天数
+	Days uint `yaml:"days"`
+	// IntervalMin 定时清理的间隔，单位：分钟，默认1440
+	IntervalMin uint `yaml:"intervalMin"`
+	// BatchSize 每批删除的最大记录数，默认1000
+	BatchSize uint `yaml:"batchSize"`
+}
+
+func (a *AuditRetention) trySetDefault() {
+	if a.IntervalMin == 0 {
+		a.IntervalMin = 1440
+	}
+
+	if a.BatchSize == 0 {
+		a.BatchSize = 1000
+	}
+}
+
+func (a AuditRetention) validate() error {
+	if !a.Enable {
+		return nil
+	}
+
+	if a.Days < 1 {
+		return errors.New("auditRetention.days must >= 1")
+	}
+
+	if a.IntervalMin < 10 {
+		return errors.New("auditRetention.intervalMin must >= 10")
+	}
+
+	return nil
+}
+
+// ChangeEvent 数据变更事件配置，开启后 data-service 主节点按位点顺序将每次写操作的审计记录作为变更事件投递到 sink，
+// sink 接收后才提交位点，每个事件至少投递一次
+type ChangeEvent struct {
+	Enable bool `yaml:"enable"`
+	// Sink 事件投递目标类型，内置 log、webhook、kafka、redis_stream，其它类型可通过 cdc.RegisterSink 接入
+	Sink string `yaml:"sink"`
+	// Endpoint 投递地址，webhook 为回调地址，kafka 为 kafka rest proxy 的地址，redis_stream 为 redis 的地址
+	Endpoint string `yaml:"endpoint"`
+	// IntervalSec 投递新事件的轮询间隔，单位：秒，默认1
+	IntervalSec uint `yaml:"intervalSec"`
+	// BatchSize 每次投递的最大事件数，默认100
+	BatchSize uint `yaml:"batchSize"`
+	// Options sink 的自定义配置，如 kafka、redis_stream 的 topicPrefix，redis_stream 的 password、db、maxLen
+	Options map[string]string `yaml:"options"`
+}
+
+func (c *ChangeEvent) trySetDefault() {
+	if c.IntervalSec == 0 {
+		c.IntervalSec = 1
+	}
+
+	if c.BatchSize == 0 {
+		c.BatchSize = 100
+	}
+}
+
+func (c ChangeEvent) validate() error {
+	if c.Enable && len(c.Sink) == 0 {
+		return errors.New("changeEvent.sink is required")
+	}
+
+	return nil
+}
+
+// AuditSink 审计记录外部投递配置，开启后审计记录写入数据库的同时投递到 syslog、kafka、蓝鲸审计中心等外部系统
+type AuditSink struct {
+	Enable bool `yaml:"enable"`
+	// Sinks 投递目标，每个目标有独立的缓冲队列，互不影响
+	Sinks []AuditSinkTarget `yaml:"sinks"`
+	// QueueSize 每个投递目标的缓冲队列大小，默认10000
+	QueueSize uint `yaml:"queueSize"`
+	// BatchSize 每次投递的最大记录数，默认100
+	BatchSize uint `yaml:"batchSize"`
+	// Backpressure 队列满时的处理策略，drop: 直接丢弃审计记录(默认)；block: 阻塞写请求直到队列有空位，
+	// 超过 BlockTimeoutMS 后丢弃
+	Backpressure string `yaml:"backpressure"`
+	// BlockTimeoutMS block 策略下单次写请求的最长阻塞时间，单位：毫秒，默认1000
+	BlockTimeoutMS uint `yaml:"blockTimeoutMS"`
+}
+
+func (a *AuditSink) trySetDefault() {
+	if a.QueueSize == 0 {
+		a.QueueSize = 10000
+	}
+
+	if a.BatchSize == 0 {
+		a.BatchSize = 100
+	}
+
+	if len(a.Backpressure) == 0 {
+		a.Backpressure = "drop"
+	}
+
+	if a.BlockTimeoutMS == 0 {
+		a.BlockTimeoutMS = 1000
+	}
+}
+
+func (a AuditSink) validate() error {
+	if !a.Enable {
+		return nil
+	}
+
+	if len(a.Sinks) == 0 {
+		return errors.New("auditSink.sinks is required")
+	}
+
+	for idx, sink := range a.Sinks {
+		if len(sink.Kind) == 0 {
+			return fmt.Errorf("auditSink.sinks[%d].kind is required", idx)
+		}
+	}
+
+	if a.Backpressure != "drop" && a.Backpressure != "block" {
+		return fmt.Errorf("auditSink.backpressure %s is invalid, should be drop or block", a.Backpressure)
+	}
+
+	return nil
+}
+
+// AuditSinkTarget 审计记录投递目标
+type AuditSinkTarget struct {
+	// Kind 投递目标类型，内置 syslog、kafka、bkaudit，其他类型可通过 auditsink.RegisterSink 接入
+	Kind string `yaml:"kind"`
+	// Endpoint 投递地址，syslog 为 udp://127.0.0.1:514 格式，为空时使用本机 syslog；kafka 为 kafka rest proxy 的地址；
+	// bkaudit 为蓝鲸监控自定义事件的上报地址
+	Endpoint string `yaml:"endpoint"`
+	// Options 投递目标的自定义配置，如 syslog 的 tag，kafka 的 topic，bkaudit 的 dataID、accessToken
+	Options map[string]string `yaml:"options"`
+}
+
+// PageLimit 分页查询的最大条数配置
+type PageLimit struct {
+	// Tables 各表的分页最大条数，未配置的表使用默认值500，数据量小的表(如安全组规则)可配置更大的值，
+	// 数据量大的表(如扩展字段较大的主机)可配置更小的值。key为表名
+	Tables map[string]uint `yaml:"tables"`
+}
+
+func (p PageLimit) validate() error {
+	for name, limit := range p.Tables {
+		if limit == 0 {
+			return fmt.Errorf("pageLimit.tables.%s must > 0", name)
+		}
+	}
+
+	return nil
+}
+
 // Recycle configuration.
 type Recycle struct {
 	AutoDeleteTime uint `yaml:"autoDeleteTimeHour"`
@@ -672,6 +1026,740 @@ func (c BillConfig) validate() error {
 	return nil
 }
 
+// BudgetAlert 账单预算告警配置
+type BudgetAlert struct {
+	Enable      bool   `yaml:"enable"`
+	IntervalMin uint64 `yaml:"intervalMin"`
+}
+
+func (c BudgetAlert) validate() error {
+	if c.Enable && c.IntervalMin < 1 {
+		return errors.New("budgetAlert.intervalMin must >= 1")
+	}
+
+	return nil
+}
+
+// BillReconcile 账单对账配置
+type BillReconcile struct {
+	Enable bool `yaml:"enable"`
+	// IntervalMin 对上月账单进行对账的间隔，上月账单在月初仍可能被调整，因此需要周期性重新对账
+	IntervalMin uint64 `yaml:"intervalMin"`
+}
+
+func (c BillReconcile) validate() error {
+	if c.Enable && c.IntervalMin < 1 {
+		return errors.New("billReconcile.intervalMin must >= 1")
+	}
+
+	return nil
+}
+
+// CvmSchedule 主机定时开关机执行配置
+type CvmSchedule struct {
+	Enable bool `yaml:"enable"`
+	// IntervalMin 检查定时策略是否触发的间隔，策略的触发精度为该间隔
+	IntervalMin uint64 `yaml:"intervalMin"`
+}
+
+func (c CvmSchedule) validate() error {
+	if c.Enable && c.IntervalMin < 1 {
+		return errors.New("cvmSchedule.intervalMin must >= 1")
+	}
+
+	return nil
+}
+
+// CmdbHostSync 主机同步到cmdb的配置
+type CmdbHostSync struct {
+	Enable bool `yaml:"enable"`
+	// IntervalMin 全量同步已分配业务的主机到cmdb的间隔
+	IntervalMin uint64 `yaml:"intervalMin"`
+}
+
+func (c CmdbHostSync) validate() error {
+	if c.Enable && c.IntervalMin < 1 {
+		return errors.New("cmdbHostSync.intervalMin must >= 1")
+	}
+
+	return nil
+}
+
+// BkMonitor 蓝鲸监控自定义指标上报配置
+type BkMonitor struct {
+	Enable bool `yaml:"enable"`
+	// IntervalMin 指标采集及上报的间隔
+	IntervalMin uint64 `yaml:"intervalMin"`
+	// Endpoints 蓝鲸监控自定义指标HTTP上报服务地址
+	Endpoints []string `yaml:"endpoints"`
+	// DataID 蓝鲸监控中创建的自定义指标数据ID
+	DataID int64 `yaml:"dataID"`
+	// AccessToken 自定义指标数据ID对应的上报token
+	AccessToken string    `yaml:"accessToken"`
+	TLS         TLSConfig `yaml:"tls"`
+}
+
+func (c BkMonitor) validate() error {
+	if !c.Enable {
+		return nil
+	}
+
+	if c.IntervalMin < 1 {
+		return errors.New("bkMonitor.intervalMin must >= 1")
+	}
+
+	if len(c.Endpoints) == 0 {
+		return errors.New("bkMonitor.endpoints is not set")
+	}
+
+	if c.DataID <= 0 {
+		return errors.New("bkMonitor.dataID is not set")
+	}
+
+	if len(c.AccessToken) == 0 {
+		return errors.New("bkMonitor.accessToken is not set")
+	}
+
+	if err := c.TLS.validate(); err != nil {
+		return fmt.Errorf("validate bkMonitor.tls failed, err: %v", err)
+	}
+
+	return nil
+}
+
+// PreDeleteHook 删除资源前调用的外部钩子，钩子可以拒绝本次删除，或者返回备注信息
+type PreDeleteHook struct {
+	Name string `yaml:"name"`
+	// URL 钩子地址，删除前以POST方式调用
+	URL string `yaml:"url"`
+	// ResTypes 钩子生效的资源类型，支持 cvm、security_group_rule
+	ResTypes []string `yaml:"resTypes"`
+	// TimeoutSec 调用钩子的超时时间
+	TimeoutSec uint `yaml:"timeoutSec"`
+	// FailOpen 钩子调用失败（超时、返回非2xx等）时是否放行删除操作，默认不放行
+	FailOpen bool `yaml:"failOpen"`
+}
+
+func (h *PreDeleteHook) trySetDefault() {
+	if h.TimeoutSec == 0 {
+		h.TimeoutSec = 5
+	}
+}
+
+func (h PreDeleteHook) validate() error {
+	if len(h.Name) == 0 {
+		return errors.New("preDeleteHooks.name is not set")
+	}
+
+	if len(h.URL) == 0 {
+		return fmt.Errorf("preDeleteHooks[%s].url is not set", h.Name)
+	}
+
+	if len(h.ResTypes) == 0 {
+		return fmt.Errorf("preDeleteHooks[%s].resTypes is not set", h.Name)
+	}
+
+	for _, resType := range h.ResTypes {
+		switch resType {
+		case "cvm", "security_group_rule":
+		default:
+			return fmt.Errorf("preDeleteHooks[%s].resTypes %s is not supported", h.Name, resType)
+		}
+	}
+
+	return nil
+}
+
+// IdleResource 闲置资源检测配置
+type IdleResource struct {
+	Enable bool `yaml:"enable"`
+	// IntervalMin 闲置资源检测的间隔
+	IntervalMin uint64 `yaml:"intervalMin"`
+	// StoppedCvmDays 主机关机且超过该天数未发生变更时视为闲置，默认30天
+	StoppedCvmDays uint `yaml:"stoppedCvmDays"`
+	// Currency 预估费用的币种，默认CNY
+	Currency string `yaml:"currency"`
+	// Price 用于预估闲置资源每月费用的单价
+	Price IdlePrice `yaml:"price"`
+}
+
+// IdlePrice 闲置资源预估费用单价
+type IdlePrice struct {
+	// DiskGBMonthly 硬盘每GB每月单价
+	DiskGBMonthly float64 `yaml:"diskGBMonthly"`
+	// EipMonthly 弹性IP每个每月单价
+	EipMonthly float64 `yaml:"eipMonthly"`
+	// CvmCpuCoreMonthly 主机每vCPU核每月单价
+	CvmCpuCoreMonthly float64 `yaml:"cvmCpuCoreMonthly"`
+}
+
+func (c *IdleResource) trySetDefault() {
+	if c.StoppedCvmDays == 0 {
+		c.StoppedCvmDays = 30
+	}
+
+	if len(c.Currency) == 0 {
+		c.Currency = "CNY"
+	}
+}
+
+func (c IdleResource) validate() error {
+	if c.Enable && c.IntervalMin < 1 {
+		return errors.New("idleResource.intervalMin must >= 1")
+	}
+
+	if c.Price.DiskGBMonthly < 0 || c.Price.EipMonthly < 0 || c.Price.CvmCpuCoreMonthly < 0 {
+		return errors.New("idleResource.price should not be negative")
+	}
+
+	return nil
+}
+
+// PriceEstimate 申请单预估费用配置，机型价格优先使用配置的机型月单价，其次使用云上机型列表返回的价格，
+// 均没有时按vCPU核数及内存计算
+type PriceEstimate struct {
+	// Currency 预估费用的币种，默认CNY
+	Currency string `yaml:"currency"`
+	// CvmCpuCoreMonthly 主机每vCPU核每月单价
+	CvmCpuCoreMonthly float64 `yaml:"cvmCpuCoreMonthly"`
+	// CvmMemoryGBMonthly 主机每GB内存每月单价
+	CvmMemoryGBMonthly float64 `yaml:"cvmMemoryGBMonthly"`
+	// DiskGBMonthly 硬盘每GB每月单价
+	DiskGBMonthly float64 `yaml:"diskGBMonthly"`
+	// EipMonthly 弹性IP每个每月单价
+	EipMonthly float64 `yaml:"eipMonthly"`
+	// InstanceTypeMonthly 机型每台每月单价，key为机型
+	InstanceTypeMonthly map[string]float64 `yaml:"instanceTypeMonthly"`
+}
+
+func (c *PriceEstimate) trySetDefault() {
+	if len(c.Currency) == 0 {
+		c.Currency = "CNY"
+	}
+}
+
+func (c PriceEstimate) validate() error {
+	if c.CvmCpuCoreMonthly < 0 || c.CvmMemoryGBMonthly < 0 || c.DiskGBMonthly < 0 || c.EipMonthly < 0 {
+		return errors.New("priceEstimate price should not be negative")
+	}
+
+	for instanceType, price := range c.InstanceTypeMonthly {
+		if price < 0 {
+			return fmt.Errorf("priceEstimate.instanceTypeMonthly of %s should not be negative", instanceType)
+		}
+	}
+
+	return nil
+}
+
+// NamingPolicy 资源命名规则配置
+type NamingPolicy struct {
+	// CheckOnSync 云资源同步完成后是否检测账号下资源的命名是否符合命名规则，用于发现在云上直接创建的不合规资源
+	CheckOnSync bool `yaml:"checkOnSync"`
+}
+
+// TagPolicy 标签策略配置
+type TagPolicy struct {
+	// CheckOnSync 云资源同步完成后是否检测账号下资源的标签是否符合标签策略，用于生成标签合规报告
+	CheckOnSync bool `yaml:"checkOnSync"`
+}
+
+// Compliance 安全合规检测配置
+type Compliance struct {
+	Enable bool `yaml:"enable"`
+	// IntervalMin 安全合规检测的间隔
+	IntervalMin uint64 `yaml:"intervalMin"`
+	// AdminPorts 安全组不允许对任意来源开放的管理端口，默认22、3389
+	AdminPorts []int64 `yaml:"adminPorts"`
+	// DisabledRules 不执行检测的内置规则，其已有的检测结果会被清理
+	DisabledRules []string `yaml:"disabledRules"`
+	// Severity 内置规则的风险等级，未配置时使用规则默认的风险等级
+	Severity map[string]string `yaml:"severity"`
+}
+
+func (c *Compliance) trySetDefault() {
+	if len(c.AdminPorts) == 0 {
+		c.AdminPorts = []int64{22, 3389}
+	}
+}
+
+func (c Compliance) validate() error {
+	if c.Enable && c.IntervalMin < 1 {
+		return errors.New("compliance.intervalMin must >= 1")
+	}
+
+	for _, rule := range c.DisabledRules {
+		if err := enumor.ComplianceRule(rule).Validate(); err != nil {
+			return fmt.Errorf("compliance.disabledRules is invalid, err: %v", err)
+		}
+	}
+
+	for rule, severity := range c.Severity {
+		if err := enumor.ComplianceRule(rule).Validate(); err != nil {
+			return fmt.Errorf("compliance.severity is invalid, err: %v", err)
+		}
+
+		if err := enumor.ComplianceSeverity(severity).Validate(); err != nil {
+			return fmt.Errorf("compliance.severity is invalid, err: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// Webhook 外部系统webhook订阅的事件投递配置
+type Webhook struct {
+	Enable bool `yaml:"enable"`
+	// IntervalSec 拉取资源变更事件并投递的间隔，默认30秒
+	IntervalSec uint64 `yaml:"intervalSec"`
+	// MaxAttempts 单个事件的最大投递次数，超过后不再重试，默认6次
+	MaxAttempts uint `yaml:"maxAttempts"`
+	// TimeoutSec 单次投递请求的超时时间，默认10秒
+	TimeoutSec uint64 `yaml:"timeoutSec"`
+}
+
+func (w *Webhook) trySetDefault() {
+	if w.IntervalSec == 0 {
+		w.IntervalSec = 30
+	}
+
+	if w.MaxAttempts == 0 {
+		w.MaxAttempts = 6
+	}
+
+	if w.TimeoutSec == 0 {
+		w.TimeoutSec = 10
+	}
+}
+
+func (w Webhook) validate() error {
+	if w.Enable && w.IntervalSec < 5 {
+		return errors.New("webhook.intervalSec must >= 5")
+	}
+
+	return nil
+}
+
+// Idempotency 变更请求幂等配置，带有Idempotency-Key请求头的请求在有效期内重试时返回首次请求的响应
+type Idempotency struct {
+	// TTLMin 幂等键的有效期，默认1440分钟
+	TTLMin uint `yaml:"ttlMin"`
+}
+
+func (i *Idempotency) trySetDefault() {
+	if i.TTLMin == 0 {
+		i.TTLMin = 1440
+	}
+}
+
+// Tracing 链路追踪配置，span通过OTLP HTTP协议上报到collector
+type Tracing struct {
+	Enable bool `yaml:"enable"`
+	// Endpoint OTLP HTTP collector地址，如 http://127.0.0.1:4318
+	Endpoint string `yaml:"endpoint"`
+	// SampleRatio 采样率，取值范围(0, 1]，默认1即全部采样
+	SampleRatio float64 `yaml:"sampleRatio"`
+	// BatchSize 单次上报的最大span数量，默认512
+	BatchSize uint `yaml:"batchSize"`
+	// FlushIntervalSec 上报间隔，默认5秒
+	FlushIntervalSec uint `yaml:"flushIntervalSec"`
+}
+
+func (t *Tracing) trySetDefault() {
+	if t.SampleRatio == 0 {
+		t.SampleRatio = 1
+	}
+
+	if t.BatchSize == 0 {
+		t.BatchSize = 512
+	}
+
+	if t.FlushIntervalSec == 0 {
+		t.FlushIntervalSec = 5
+	}
+}
+
+func (t Tracing) validate() error {
+	if !t.Enable {
+		return nil
+	}
+
+	if len(t.Endpoint) == 0 {
+		return errors.New("tracing.endpoint is required when tracing is enabled")
+	}
+
+	if t.SampleRatio < 0 || t.SampleRatio > 1 {
+		return errors.New("tracing.sampleRatio should be in range (0, 1]")
+	}
+
+	return nil
+}
+
+// Grpc grpc传输配置，服务端与restful服务共用端口提供grpc接口，客户端开启后通过grpc调用服务端的热点接口
+type Grpc struct {
+	Enable bool `yaml:"enable"`
+	// MaxMsgSizeMB 单个消息的最大大小，默认16MB
+	MaxMsgSizeMB uint `yaml:"maxMsgSizeMB"`
+}
+
+func (g *Grpc) trySetDefault() {
+	if g.MaxMsgSizeMB == 0 {
+		g.MaxMsgSizeMB = 16
+	}
+}
+
+// ClientResilience 调用其他服务的容错配置，幂等请求(查询、带幂等键的写请求)在连接失败、实例不可用时重试，
+// 响应慢时向其他实例发送对冲请求，连续失败的实例熔断一段时间
+type ClientResilience struct {
+	Enable bool `yaml:"enable"`
+	// MaxAttempts 幂等请求最多请求次数，包含首次请求，默认3
+	MaxAttempts uint `yaml:"maxAttempts"`
+	// RetryBackoffMS 首次重试前的等待时间，之后每次重试翻倍，单位：毫秒，默认100
+	RetryBackoffMS uint `yaml:"retryBackoffMS"`
+	// HedgeDelayMS 幂等请求超过该时间未返回时向其他实例发送对冲请求，单位：毫秒，为0时不对冲
+	HedgeDelayMS uint `yaml:"hedgeDelayMS"`
+	// MaxHedged 最多额外发送的对冲请求数，默认1
+	MaxHedged uint `yaml:"maxHedged"`
+	// BreakerFailureThreshold 实例连续失败次数达到该值后熔断，默认5
+	BreakerFailureThreshold uint `yaml:"breakerFailureThreshold"`
+	// BreakerOpenSec 实例熔断的时间，单位：秒，默认10
+	BreakerOpenSec uint `yaml:"breakerOpenSec"`
+}
+
+func (c *ClientResilience) trySetDefault() {
+	if c.MaxAttempts == 0 {
+		c.MaxAttempts = 3
+	}
+	if c.RetryBackoffMS == 0 {
+		c.RetryBackoffMS = 100
+	}
+	if c.MaxHedged == 0 {
+		c.MaxHedged = 1
+	}
+	if c.BreakerFailureThreshold == 0 {
+		c.BreakerFailureThreshold = 5
+	}
+	if c.BreakerOpenSec == 0 {
+		c.BreakerOpenSec = 10
+	}
+}
+
+// ConfigWatch 配置热更新配置，监听配置文件及etcd中的配置覆盖项，变更后重新加载配置，网络、服务发现及数据库配置需重启生效
+type ConfigWatch struct {
+	Enable bool `yaml:"enable"`
+	// FileCheckIntervalSec 检查配置文件是否变更的间隔，默认10秒
+	FileCheckIntervalSec uint `yaml:"fileCheckIntervalSec"`
+	// EtcdKey etcd中配置覆盖项的key，值为yaml格式的配置片段，覆盖配置文件中的同名配置，默认 /hcm/config/{服务名}
+	EtcdKey string `yaml:"etcdKey"`
+}
+
+func (c *ConfigWatch) trySetDefault() {
+	if c.FileCheckIntervalSec == 0 {
+		c.FileCheckIntervalSec = 10
+	}
+	if len(c.EtcdKey) == 0 {
+		c.EtcdKey = "/hcm/config/" + string(ServiceName())
+	}
+}
+
+// Cache 热点读接口缓存配置，查询结果缓存在redis中，对应资源的写接口调用后缓存失效
+type Cache struct {
+	Enable bool  `yaml:"enable"`
+	Redis  Redis `yaml:"redis"`
+	// TTLSec 缓存有效期，默认60秒，写接口之外的数据变更最多在有效期后可见
+	TTLSec uint `yaml:"ttlSec"`
+	// ReadPrimarySec 写接口使缓存失效后，缓存未命中时从主库读取的时长，需大于从库同步延迟，默认10秒
+	ReadPrimarySec uint `yaml:"readPrimarySec"`
+}
+
+func (c *Cache) trySetDefault() {
+	if c.TTLSec == 0 {
+		c.TTLSec = 60
+	}
+	if c.ReadPrimarySec == 0 {
+		c.ReadPrimarySec = 10
+	}
+	c.Redis.trySetDefault()
+}
+
+func (c Cache) validate() error {
+	if !c.Enable {
+		return nil
+	}
+
+	if len(c.Redis.Endpoint) == 0 {
+		return errors.New("cache.redis.endpoint is required when cache is enabled")
+	}
+
+	return nil
+}
+
+// Redis redis连接配置
+type Redis struct {
+	// Endpoint redis地址，如 127.0.0.1:6379
+	Endpoint string `yaml:"endpoint"`
+	Password string `yaml:"password"`
+	DB       uint   `yaml:"db"`
+	// DialTimeoutMS 建立连接及读写的超时时间，默认1000毫秒
+	DialTimeoutMS uint `yaml:"dialTimeoutMS"`
+	// PoolSize 连接池最大连接数，默认20
+	PoolSize uint `yaml:"poolSize"`
+}
+
+func (r *Redis) trySetDefault() {
+	if r.DialTimeoutMS == 0 {
+		r.DialTimeoutMS = 1000
+	}
+	if r.PoolSize == 0 {
+		r.PoolSize = 20
+	}
+}
+
+// GracefulShutdown 优雅退出配置，退出时先从服务发现注销，等待调用方刷新服务地址后停止接收新请求，再等待处理中的请求完成
+type GracefulShutdown struct {
+	// DeregisterWaitSec 从服务发现注销后继续处理请求的时间，单位：秒，默认5
+	DeregisterWaitSec uint `yaml:"deregisterWaitSec"`
+	// DrainTimeoutSec 停止接收新请求后等待处理中请求完成的最长时间，单位：秒，默认600。超时后仍未完成的请求会被中断，
+	// 并记录请求的rid，需要通过资源同步修正云上资源的状态，部署时 terminationGracePeriodSeconds 需要大于两者之和
+	DrainTimeoutSec uint `yaml:"drainTimeoutSec"`
+}
+
+func (g *GracefulShutdown) trySetDefault() {
+	if g.DeregisterWaitSec == 0 {
+		g.DeregisterWaitSec = 5
+	}
+
+	if g.DrainTimeoutSec == 0 {
+		g.DrainTimeoutSec = 600
+	}
+}
+
+// WaitTimeoutSec returns the max time to wait for the shutdown process.
+func (g GracefulShutdown) WaitTimeoutSec() int {
+	return int(g.DeregisterWaitSec+g.DrainTimeoutSec) + 10
+}
+
+// OperationTimeout 各类操作的超时配置，超时后操作的context被取消，避免云上接口无响应时长时间占用处理协程
+type OperationTimeout struct {
+	// SyncPageFetchSec 资源同步时分页查询一页云上资源的超时时间，单位：秒，默认120
+	SyncPageFetchSec uint `yaml:"syncPageFetchSec"`
+	// CreateCvmSec 调用云上接口创建主机（包括等待创建结果）的超时时间，单位：秒，默认600
+	CreateCvmSec uint `yaml:"createCvmSec"`
+}
+
+func (o *OperationTimeout) trySetDefault() {
+	if o.SyncPageFetchSec == 0 {
+		o.SyncPageFetchSec = 120
+	}
+
+	if o.CreateCvmSec == 0 {
+		o.CreateCvmSec = 600
+	}
+}
+
+// SyncPageFetch returns the timeout of fetching a page of cloud resources in sync.
+func (o OperationTimeout) SyncPageFetch() time.Duration {
+	return time.Duration(o.SyncPageFetchSec) * time.Second
+}
+
+// CreateCvm returns the timeout of creating cvms on cloud.
+func (o OperationTimeout) CreateCvm() time.Duration {
+	return time.Duration(o.CreateCvmSec) * time.Second
+}
+
+// ClientPool 云厂商客户端池配置，按账号复用客户端，减少凭据鉴权的握手次数
+type ClientPool struct {
+	// TTLSec 客户端在池中缓存的时间，单位：秒，默认1800，账号秘钥变化时立即重建客户端
+	TTLSec uint `yaml:"ttlSec"`
+	// Disable 是否关闭客户端池，关闭后每个请求都重新创建客户端
+	Disable bool `yaml:"disable"`
+}
+
+func (p *ClientPool) trySetDefault() {
+	if p.TTLSec == 0 {
+		p.TTLSec = 1800
+	}
+}
+
+// TTL returns the time to keep the client in pool, zero means the pool is disabled.
+func (p ClientPool) TTL() time.Duration {
+	if p.Disable {
+		return 0
+	}
+
+	return time.Duration(p.TTLSec) * time.Second
+}
+
+// CloudApiQuota 云上接口调用量统计配置，在调用频率接近云厂商的接口频率限制时告警
+type CloudApiQuota struct {
+	// WarnPercent 调用频率达到接口频率限制的该百分比时告警，默认为80
+	WarnPercent uint `yaml:"warnPercent"`
+	// Limits 云上接口的频率限制，未配置的接口只统计调用量，不告警
+	Limits []CloudApiLimit `yaml:"limits"`
+}
+
+// CloudApiLimit 云上接口的频率限制
+type CloudApiLimit struct {
+	// Vendor 云厂商，目前支持 tcloud、aws、azure
+	Vendor string `yaml:"vendor"`
+	// ApiName 接口名称，如腾讯云的 DescribeInstances，azure 的接口名称为请求方法和资源类型，如 GET virtualMachines
+	ApiName string `yaml:"apiName"`
+	// QPS 每个云账号每秒调用该接口的次数限制
+	QPS uint `yaml:"qps"`
+}
+
+func (q CloudApiQuota) validate() error {
+	if q.WarnPercent > 100 {
+		return fmt.Errorf("cloudApiQuota.warnPercent should be in [0, 100], but got %d", q.WarnPercent)
+	}
+
+	for i, limit := range q.Limits {
+		if err := enumor.Vendor(limit.Vendor).Validate(); err != nil {
+			return fmt.Errorf("cloudApiQuota.limits[%d].vendor is invalid, err: %v", i, err)
+		}
+		if len(limit.ApiName) == 0 {
+			return fmt.Errorf("cloudApiQuota.limits[%d].apiName is required", i)
+		}
+		if limit.QPS == 0 {
+			return fmt.Errorf("cloudApiQuota.limits[%d].qps should be greater than 0", i)
+		}
+	}
+
+	return nil
+}
+
+// OperatorTag 创建云上资源时自动添加的标签配置，标签记录创建资源的hcm请求ID、操作人和业务，用于将云上的账单、审计追溯到
+// hcm的操作。用户指定了同名标签时以用户指定的为准
+type OperatorTag struct {
+	// Enable 是否在创建云上资源时添加标签
+	Enable bool `yaml:"enable"`
+	// RidKey 记录hcm请求ID的标签键，默认为 hcm_rid
+	RidKey string `yaml:"ridKey"`
+	// OperatorKey 记录操作人的标签键，默认为 hcm_operator
+	OperatorKey string `yaml:"operatorKey"`
+	// BizKey 记录业务ID的标签键，资源不是在业务下创建时不添加该标签，默认为 hcm_biz
+	BizKey string `yaml:"bizKey"`
+}
+
+// operatorTagKeyRegex 标签键需要同时满足各云厂商标签、gcp label的格式要求
+var operatorTagKeyRegex = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,62}$`)
+
+func (o *OperatorTag) trySetDefault() {
+	if len(o.RidKey) == 0 {
+		o.RidKey = "hcm_rid"
+	}
+
+	if len(o.OperatorKey) == 0 {
+		o.OperatorKey = "hcm_operator"
+	}
+
+	if len(o.BizKey) == 0 {
+		o.BizKey = "hcm_biz"
+	}
+}
+
+func (o OperatorTag) validate() error {
+	keys := [][2]string{{"ridKey", o.RidKey}, {"operatorKey", o.OperatorKey}, {"bizKey", o.BizKey}}
+	exists := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if !operatorTagKeyRegex.MatchString(key[1]) {
+			return fmt.Errorf("operatorTag.%s %s is invalid, should match %s", key[0], key[1], operatorTagKeyRegex)
+		}
+
+		if _, ok := exists[key[1]]; ok {
+			return fmt.Errorf("operatorTag.%s %s is duplicated", key[0], key[1])
+		}
+		exists[key[1]] = struct{}{}
+	}
+
+	return nil
+}
+
+// FaultInjection 云上接口的故障注入配置，仅在debug版本中生效，用于验证云厂商故障时同步重试、熔断、任务恢复等逻辑是否符合预期
+type FaultInjection struct {
+	// Enable 是否开启故障注入
+	Enable bool `yaml:"enable"`
+	// Vendors 注入故障的云厂商，为空时对所有支持的云厂商注入，目前支持 tcloud、aws、azure
+	Vendors []string `yaml:"vendors"`
+	// ErrorRate 云上接口返回网络错误的比例，取值范围 [0, 1]
+	ErrorRate float64 `yaml:"errorRate"`
+	// ThrottleRate 云上接口返回限频错误的比例，取值范围 [0, 1]
+	ThrottleRate float64 `yaml:"throttleRate"`
+	// LatencyRate 云上接口增加延迟的比例，取值范围 [0, 1]
+	LatencyRate float64 `yaml:"latencyRate"`
+	// LatencyMS 增加的延迟时间，单位：毫秒
+	LatencyMS uint `yaml:"latencyMS"`
+}
+
+func (f FaultInjection) validate() error {
+	if !f.Enable {
+		return nil
+	}
+
+	for _, vendor := range f.Vendors {
+		if err := enumor.Vendor(vendor).Validate(); err != nil {
+			return fmt.Errorf("faultInjection.vendors %w", err)
+		}
+	}
+
+	rates := map[string]float64{"errorRate": f.ErrorRate, "throttleRate": f.ThrottleRate,
+		"latencyRate": f.LatencyRate}
+	for name, rate := range rates {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("faultInjection.%s should be in [0, 1], but got %v", name, rate)
+		}
+	}
+
+	if f.ErrorRate+f.ThrottleRate > 1 {
+		return errors.New("faultInjection.errorRate + faultInjection.throttleRate should not be greater than 1")
+	}
+
+	return nil
+}
+
+// HealthCheck 健康检查配置
+type HealthCheck struct {
+	// SampleAccountID 就绪检查时校验该账号的凭据在云上是否可用，为空时不校验。校验会调用云上接口，需要适当调大就绪探针的间隔
+	SampleAccountID string `yaml:"sampleAccountID"`
+}
+
+// Credential 外部凭据管理配置，账号的秘钥可以保存为外部凭据的引用，如 vault:secret/data/hcm/account#secret_key、
+// bkcred:hcm-account#secret_key，使用时从凭据管理获取，不在数据库中保存秘钥明文
+type Credential struct {
+	// CacheTTLSec 凭据在本地缓存的时间，默认300秒，凭据的租约更短时以租约为准
+	CacheTTLSec uint `yaml:"cacheTTLSec"`
+	// Vault HashiCorp Vault配置，地址为空时不支持vault引用
+	Vault Vault `yaml:"vault"`
+	// BkCredential 蓝鲸凭据管理的网关配置，网关地址为空时不支持bkcred引用
+	BkCredential ApiGateway `yaml:"bkCredential"`
+}
+
+func (c *Credential) trySetDefault() {
+	if c.CacheTTLSec == 0 {
+		c.CacheTTLSec = 300
+	}
+}
+
+func (c Credential) validate() error {
+	if len(c.Vault.Address) != 0 && len(c.Vault.Token) == 0 && len(c.Vault.RoleID) == 0 {
+		return errors.New("credential.vault.token or credential.vault.roleID is required")
+	}
+
+	return nil
+}
+
+// Vault HashiCorp Vault配置，使用token或AppRole认证
+type Vault struct {
+	// Address vault地址，如 https://127.0.0.1:8200
+	Address   string `yaml:"address"`
+	Namespace string `yaml:"namespace"`
+	Token     string `yaml:"token"`
+	// RoleID、SecretID AppRole认证信息，token为空时使用
+	RoleID   string    `yaml:"roleID"`
+	SecretID string    `yaml:"secretID"`
+	TLS      TLSConfig `yaml:"tls"`
+}
+
 // ApiGateway defines the api gateway config.
 type ApiGateway struct {
 	// Endpoints is a seed list of host:port addresses of api gateway.
@@ -1130,3 +2218,104 @@ func (r *SyncConcurrentRule) Validate() error {
 	}
 	return nil
 }
+
+// AppAuth 第三方系统使用蓝鲸应用身份(bk_app_code/bk_app_secret)调用HCM的认证配置
+type AppAuth struct {
+	Enable bool         `yaml:"enable"`
+	Apps   []AppAccount `yaml:"apps"`
+}
+
+func (a AppAuth) validate() error {
+	if !a.Enable {
+		return nil
+	}
+
+	appCodes := make(map[string]struct{}, len(a.Apps))
+	for _, app := range a.Apps {
+		if err := app.validate(); err != nil {
+			return err
+		}
+
+		if _, exists := appCodes[app.AppCode]; exists {
+			return fmt.Errorf("appAuth.apps app code %s is duplicated", app.AppCode)
+		}
+		appCodes[app.AppCode] = struct{}{}
+	}
+
+	return nil
+}
+
+// AppAccount 第三方应用及其映射的服务账号
+type AppAccount struct {
+	AppCode   string `yaml:"appCode"`
+	AppSecret string `yaml:"appSecret"`
+	// ServiceAccount 应用映射的服务账号，作为请求的操作用户进行鉴权，需要在IAM中为该账号授予所需的权限
+	ServiceAccount string `yaml:"serviceAccount"`
+	// AllowedPaths 应用允许调用的接口路径前缀，如 /api/v1/cloud/bizs/，为空时不限制
+	AllowedPaths []string `yaml:"allowedPaths"`
+	// AllowReadPrimary 是否允许应用通过 X-Bkhcm-Read-Primary 请求头强制db读请求路由到主库，未授权的应用该请求头会被忽略
+	AllowReadPrimary bool `yaml:"allowReadPrimary"`
+	// TenantID 服务账号所属的租户，应用的请求只能操作该租户的资源，为空时属于默认租户
+	TenantID string `yaml:"tenantID"`
+}
+
+func (a AppAccount) validate() error {
+	if len(a.AppCode) == 0 {
+		return errors.New("appAuth.apps appCode is not set")
+	}
+
+	if len(a.AppSecret) == 0 {
+		return fmt.Errorf("appAuth.apps %s appSecret is not set", a.AppCode)
+	}
+
+	if len(a.ServiceAccount) == 0 {
+		return fmt.Errorf("appAuth.apps %s serviceAccount is not set", a.AppCode)
+	}
+
+	if len(a.TenantID) != 0 {
+		if err := kit.ValidateTenantID(a.TenantID); err != nil {
+			return fmt.Errorf("appAuth.apps %s tenantID is invalid, err: %v", a.AppCode, err)
+		}
+	}
+
+	return nil
+}
+
+// FeatureFlags 特性开关配置，key为特性名称，用于按云厂商、账号灰度开启有风险的新功能，未配置的特性默认关闭
+type FeatureFlags map[string]FeatureFlag
+
+func (f FeatureFlags) validate() error {
+	for name, flag := range f {
+		if len(name) == 0 {
+			return errors.New("featureFlags name is not set")
+		}
+
+		if err := flag.validate(); err != nil {
+			return fmt.Errorf("featureFlags %s %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// FeatureFlag 单个特性的开关配置，判断顺序为：ExcludeAccountIDs > AccountIDs > Vendors > Enable
+type FeatureFlag struct {
+	// Enable 是否对所有云厂商、账号开启
+	Enable bool `yaml:"enable"`
+	// Vendors 对指定云厂商的账号开启，如 tcloud、aws
+	Vendors []string `yaml:"vendors"`
+	// AccountIDs 对指定账号开启
+	AccountIDs []string `yaml:"accountIDs"`
+	// ExcludeAccountIDs 对指定账号关闭，优先级最高，用于全量开启后个别账号出现问题时回退
+	ExcludeAccountIDs []string `yaml:"excludeAccountIDs"`
+}
+
+func (f FeatureFlag) validate() error {
+	for _, vendor := range f.Vendors {
+		if err := enumor.Vendor(vendor).Validate(); err != nil {
+			return fmt.Errorf("vendors %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+package cc
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"hcm/pkg/logs"
+
+	etcd3 "go.etcd.io/etcd/client/v3"
+)
+
+// staticFields are the setting fields only used on the service startup, their changes are ignored until restart.
+var staticFields = []string{"Network", "Service", "Database"}
+
+// ChangeHandler is called after the settings are reloaded, the new settings can be got by the accessors like
+// HCService(), so settings read by the accessors on every use take effect without any handler.
+type ChangeHandler func()
+
+var changeHandlers struct {
+	sync.Mutex
+	handlers []ChangeHandler
+}
+
+// OnChange register the handler called after the settings are reloaded.
+func OnChange(handler ChangeHandler) {
+	changeHandlers.Lock()
+	defer changeHandlers.Unlock()
+
+	changeHandlers.handlers = append(changeHandlers.handlers, handler)
+}
+
+// WatchSettings watch the config file and the etcd overlay key of the service, and reload the settings once changed.
+// The etcd overlay is a yaml config snippet which overrides the same fields in the config file, it is applied right
+// after the watch starts. Settings with error are ignored, and the current settings are kept.
+func WatchSettings(sys *SysOption, etcdOpt Etcd, opt ConfigWatch) error {
+	etcdConf, err := etcdOpt.ToConfig()
+	if err != nil {
+		return fmt.Errorf("get etcd config failed, err: %v", err)
+	}
+
+	cli, err := etcd3.New(etcdConf)
+	if err != nil {
+		return fmt.Errorf("new config watch etcd client failed, err: %v", err)
+	}
+
+	hash, err := fileHash(sys.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	w := &settingWatcher{sys: sys, opt: opt, cli: cli, fileHash: hash}
+	rev, err := w.getOverlay()
+	if err != nil {
+		return err
+	}
+	if len(w.overlay) != 0 {
+		w.reload("etcd")
+	}
+
+	go w.run(rev)
+
+	logs.Infof("watch settings from file %s and etcd key %s", sys.ConfigFile, opt.EtcdKey)
+	return nil
+}
+
+type settingWatcher struct {
+	sys *SysOption
+	opt ConfigWatch
+	cli *etcd3.Client
+	// fileHash is the hash of the loaded config file.
+	fileHash [sha256.Size]byte
+	// overlay is the loaded etcd overlay.
+	overlay []byte
+}
+
+// getOverlay get the etcd overlay, returns the revision to watch from.
+func (w *settingWatcher) getOverlay() (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := w.cli.Get(ctx, w.opt.EtcdKey)
+	if err != nil {
+		return 0, fmt.Errorf("get setting overlay from etcd key %s failed, err: %v", w.opt.EtcdKey, err)
+	}
+
+	w.overlay = nil
+	if len(resp.Kvs) != 0 {
+		w.overlay = resp.Kvs[0].Value
+	}
+
+	return resp.Header.Revision + 1, nil
+}
+
+func (w *settingWatcher) run(rev int64) {
+	ticker := time.NewTicker(time.Duration(w.opt.FileCheckIntervalSec) * time.Second)
+	defer ticker.Stop()
+
+	ctx := etcd3.WithRequireLeader(context.Background())
+	watchCh := w.cli.Watch(ctx, w.opt.EtcdKey, etcd3.WithRev(rev))
+	for {
+		select {
+		case <-ticker.C:
+			hash, err := fileHash(w.sys.ConfigFile)
+			if err != nil {
+				logs.Errorf("check config file change failed, err: %v", err)
+				continue
+			}
+			if hash == w.fileHash {
+				continue
+			}
+			w.fileHash = hash
+			w.reload("file")
+
+		case resp, ok := <-watchCh:
+			if !ok || resp.Err() != nil {
+				// 监听中断(如revision被压缩)时重新获取覆盖项后再监听
+				logs.Errorf("watch setting overlay etcd key %s failed, err: %v", w.opt.EtcdKey, resp.Err())
+				time.Sleep(time.Second)
+				newRev, err := w.getOverlay()
+				if err != nil {
+					logs.Errorf("get setting overlay failed, err: %v", err)
+					continue
+				}
+				w.reload("etcd")
+				watchCh = w.cli.Watch(ctx, w.opt.EtcdKey, etcd3.WithRev(newRev))
+				continue
+			}
+
+			for _, event := range resp.Events {
+				w.overlay = nil
+				if event.Type == etcd3.EventTypePut {
+					w.overlay = event.Kv.Value
+				}
+			}
+			w.reload("etcd")
+		}
+	}
+}
+
+// reload the settings, the static fields are kept, and the change handlers are called.
+func (w *settingWatcher) reload(source string) {
+	s, err := buildSettings(w.sys, w.overlay)
+	if err != nil {
+		logs.Errorf("reload settings on %s change failed, keep the current settings, err: %v", source, err)
+		return
+	}
+
+	rt.lock.Lock()
+	keepStaticFields(rt.settings, s)
+	rt.settings = s
+	rt.lock.Unlock()
+
+	applyLogVerbosity(s)
+
+	changeHandlers.Lock()
+	handlers := append([]ChangeHandler(nil), changeHandlers.handlers...)
+	changeHandlers.Unlock()
+	for _, handler := range handlers {
+		handler()
+	}
+
+	logs.Infof("settings reloaded on %s change", source)
+}
+
+// keepStaticFields set the static fields of the new settings to the old ones.
+func keepStaticFields(old, s Setting) {
+	oldVal := reflect.ValueOf(old).Elem()
+	newVal := reflect.ValueOf(s).Elem()
+	for _, name := range staticFields {
+		oldField := oldVal.FieldByName(name)
+		if !oldField.IsValid() {
+			continue
+		}
+
+		newField := newVal.FieldByName(name)
+		if !reflect.DeepEqual(oldField.Interface(), newField.Interface()) {
+			logs.Infof("setting %s is changed, it takes effect after restart", name)
+		}
+		newField.Set(oldField)
+	}
+}
+
+// applyLogVerbosity apply the log verbosity of the settings.
+func applyLogVerbosity(s Setting) {
+	field := reflect.ValueOf(s).Elem().FieldByName("Log")
+	if !field.IsValid() {
+		return
+	}
+
+	option, ok := field.Interface().(LogOption)
+	if !ok || int32(option.Verbosity) == logs.GetV() {
+		return
+	}
+
+	logs.Infof("log verbosity is changed from %d to %d", logs.GetV(), option.Verbosity)
+	logs.SetV(int32(option.Verbosity))
+}
+
+func fileHash(filename string) ([sha256.Size]byte, error) {
+	file, err := os.ReadFile(filename)
+	if err != nil {
+		return [sha256.Size]byte{}, fmt.Errorf("read config file %s failed, err: %v", filename, err)
+	}
+
+	return sha256.Sum256(file), nil
+}
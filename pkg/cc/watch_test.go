@@ -0,0 +1,74 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+package cc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testHCServiceConfig = `
+network:
+  bindIP: 127.0.0.1
+  port: %d
+service:
+  etcd:
+    endpoints:
+      - 127.0.0.1:2379
+sync:
+  defaultConcurrent: %d
+`
+
+func TestReloadSettings(t *testing.T) {
+	InitService(HCServiceName)
+
+	file := filepath.Join(t.TempDir(), "hc_service.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(fmt.Sprintf(testHCServiceConfig, 9600, 1)), 0644))
+	sys := &SysOption{ConfigFile: file}
+	require.NoError(t, LoadSettings(sys))
+
+	changed := 0
+	OnChange(func() { changed++ })
+	w := &settingWatcher{sys: sys, opt: ConfigWatch{EtcdKey: "/hcm/config/hc-service"}}
+
+	// network is static, it is kept until restart
+	require.NoError(t, os.WriteFile(file, []byte(fmt.Sprintf(testHCServiceConfig, 9700, 2)), 0644))
+	w.reload("file")
+	assert.Equal(t, 1, changed)
+	assert.Equal(t, uint(9600), HCService().Network.Port)
+	assert.Equal(t, uint(2), HCService().SyncConfig.DefaultConcurrent)
+
+	// etcd overlay overrides the config file
+	w.overlay = []byte("sync:\n  defaultConcurrent: 5\nlog:\n  verbosity: 3\n")
+	w.reload("etcd")
+	assert.Equal(t, 2, changed)
+	assert.Equal(t, uint(5), HCService().SyncConfig.DefaultConcurrent)
+	assert.Equal(t, uint(3), HCService().Log.Verbosity)
+
+	// invalid settings are ignored
+	w.overlay = []byte("sync: [")
+	w.reload("etcd")
+	assert.Equal(t, 2, changed)
+	assert.Equal(t, uint(5), HCService().SyncConfig.DefaultConcurrent)
+}
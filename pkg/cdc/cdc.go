@@ -0,0 +1,100 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package cdc publishes the change data capture events of data-service writes to a pluggable sink,
+// so that the downstream systems like cmdb and notification can react to the changes without polling.
+// the audit records are the durable event log, the producer sends them to the sink in offset order and
+// commits the offset after the sink accepts them, so that every event is delivered at least once.
+package cdc
+
+import (
+	"encoding/json"
+	"sort"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table/audit"
+)
+
+// Event 数据变更事件，每种资源类型对应一个 topic
+type Event struct {
+	// Offset 事件在所属 topic 中的位点，单调递增，拉取事件时返回，集成方消费后提交该位点
+	Offset     uint64                   `json:"offset,omitempty"`
+	TenantID   string                   `json:"tenant_id,omitempty"`
+	ResType    enumor.AuditResourceType `json:"res_type"`
+	ResID      string                   `json:"res_id"`
+	CloudResID string                   `json:"cloud_res_id,omitempty"`
+	Vendor     enumor.Vendor            `json:"vendor,omitempty"`
+	AccountID  string                   `json:"account_id,omitempty"`
+	BkBizID    int64                    `json:"bk_biz_id"`
+	Action     enumor.AuditAction       `json:"action"`
+	Operator   string                   `json:"operator"`
+	Rid        string                   `json:"rid"`
+	// ChangedFields 变更的字段，只有更新操作有值，作为变更摘要，完整的变更内容可通过审计查询
+	ChangedFields []string `json:"changed_fields,omitempty"`
+	Timestamp     int64    `json:"timestamp"`
+}
+
+// Topic 返回事件所属的 topic，topic 按资源类型划分，kafka、redis stream 等 sink 可按 topic 投递到不同的队列
+func (e Event) Topic() string {
+	return string(e.ResType)
+}
+
+// FromAudit 每条审计记录对应一次资源写操作，转换为数据变更事件，审计记录的自增ID即为事件位点
+func FromAudit(one *audit.AuditTable, timestamp int64) Event {
+	event := Event{
+		Offset:     one.ID,
+		TenantID:   one.TenantID,
+		ResType:    one.ResType,
+		ResID:      one.ResID,
+		CloudResID: one.CloudResID,
+		Vendor:     one.Vendor,
+		AccountID:  one.AccountID,
+		BkBizID:    one.BkBizID,
+		Action:     one.Action,
+		Operator:   one.Operator,
+		Rid:        one.Rid,
+		Timestamp:  timestamp,
+	}
+	if one.Detail != nil && one.Detail.Changed != nil {
+		event.ChangedFields = changedFields(one.Detail.Changed)
+	}
+
+	return event
+}
+
+// changedFields 返回变更内容中的顶层字段名，作为变更摘要
+func changedFields(changed interface{}) []string {
+	raw, err := json.Marshal(changed)
+	if err != nil {
+		return nil
+	}
+
+	fieldMap := make(map[string]json.RawMessage)
+	if err = json.Unmarshal(raw, &fieldMap); err != nil {
+		return nil
+	}
+
+	fields := make([]string, 0, len(fieldMap))
+	for field := range fieldMap {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	return fields
+}
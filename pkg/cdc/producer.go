@@ -0,0 +1,103 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cdc
+
+import (
+	"fmt"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+const (
+	// ProducerConsumer 生产者提交投递位点时使用的消费方名称
+	ProducerConsumer = "hcm-event-producer"
+	// AllTopic 生产者按位点顺序投递所有资源类型的事件，使用该 topic 记录投递位点
+	AllTopic enumor.AuditResourceType = "*"
+)
+
+// Source 事件源，按位点升序提供已落定的事件，并持久化已投递的位点
+type Source interface {
+	// Committed 返回已投递的位点
+	Committed(kt *kit.Kit) (uint64, error)
+	// List 返回位点之后最多 limit 个已落定的事件，按位点升序排列
+	List(kt *kit.Kit, after uint64, limit uint) ([]Event, error)
+	// Commit 提交已投递的位点
+	Commit(kt *kit.Kit, offset uint64) error
+}
+
+// Producer 从事件源按位点顺序读取事件并投递到 sink，sink 接收后才提交位点。投递失败或进程重启后从已提交的位点
+// 重新投递，因此每个事件至少投递一次，sink 的下游需要按事件位点去重。
+type Producer struct {
+	sink      Sink
+	source    Source
+	batchSize uint
+}
+
+// NewProducer 根据配置从注册的 sink 中创建投递目标，并创建事件生产者
+func NewProducer(opt cc.ChangeEvent, source Source) (*Producer, error) {
+	sink, err := NewSink(SinkKind(opt.Sink), opt)
+	if err != nil {
+		return nil, fmt.Errorf("new change event sink failed, err: %v", err)
+	}
+
+	return &Producer{sink: sink, source: source, batchSize: opt.BatchSize}, nil
+}
+
+// Produce 将已提交位点之后的所有已落定事件分批投递到 sink，返回投递的事件数
+func (p *Producer) Produce(kt *kit.Kit) (int, error) {
+	offset, err := p.source.Committed(kt)
+	if err != nil {
+		return 0, fmt.Errorf("get committed offset failed, err: %v", err)
+	}
+
+	total := 0
+	for {
+		events, err := p.source.List(kt, offset, p.batchSize)
+		if err != nil {
+			return total, fmt.Errorf("list events after offset %d failed, err: %v", offset, err)
+		}
+
+		if len(events) == 0 {
+			return total, nil
+		}
+
+		if err = p.sink.Send(events); err != nil {
+			return total, fmt.Errorf("send %d events after offset %d to %s sink failed, err: %v", len(events), offset,
+				p.sink.Kind(), err)
+		}
+
+		offset = events[len(events)-1].Offset
+		if err = p.source.Commit(kt, offset); err != nil {
+			// 位点提交失败时下次从旧位点重新投递，事件会重复但不会丢失
+			return total, fmt.Errorf("commit offset %d failed, err: %v", offset, err)
+		}
+		total += len(events)
+
+		logs.V(4).Infof("send %d change events to %s sink, offset: %d, rid: %s", len(events), p.sink.Kind(), offset,
+			kt.Rid)
+
+		if uint(len(events)) < p.batchSize {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,133 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cdc
+
+import (
+	"errors"
+	"testing"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/kit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memSource keeps the events and the committed offset in memory.
+type memSource struct {
+	events    []Event
+	committed uint64
+	commitErr error
+}
+
+func (s *memSource) Committed(_ *kit.Kit) (uint64, error) {
+	return s.committed, nil
+}
+
+func (s *memSource) List(_ *kit.Kit, after uint64, limit uint) ([]Event, error) {
+	events := make([]Event, 0)
+	for _, event := range s.events {
+		if event.Offset > after && uint(len(events)) < limit {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (s *memSource) Commit(_ *kit.Kit, offset uint64) error {
+	if s.commitErr != nil {
+		return s.commitErr
+	}
+	s.committed = offset
+	return nil
+}
+
+// memSink records the sent events, and fails the sending if err is set.
+type memSink struct {
+	sent []Event
+	err  error
+}
+
+func (s *memSink) Kind() SinkKind {
+	return "test"
+}
+
+func (s *memSink) Send(events []Event) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.sent = append(s.sent, events...)
+	return nil
+}
+
+func offsets(events []Event) []uint64 {
+	result := make([]uint64, 0, len(events))
+	for _, event := range events {
+		result = append(result, event.Offset)
+	}
+	return result
+}
+
+func TestProducerAtLeastOnce(t *testing.T) {
+	source := &memSource{events: []Event{{Offset: 1}, {Offset: 3}, {Offset: 4}, {Offset: 7}, {Offset: 9}}}
+	sink := new(memSink)
+	producer := &Producer{sink: sink, source: source, batchSize: 2}
+	kt := kit.New()
+
+	// 投递失败时不提交位点
+	sink.err = errors.New("sink is down")
+	count, err := producer.Produce(kt)
+	assert.Error(t, err)
+	assert.Equal(t, 0, count)
+	assert.Equal(t, uint64(0), source.committed)
+
+	// 恢复后从已提交的位点开始分批投递全部事件
+	sink.err = nil
+	count, err = producer.Produce(kt)
+	require.NoError(t, err)
+	assert.Equal(t, 5, count)
+	assert.Equal(t, []uint64{1, 3, 4, 7, 9}, offsets(sink.sent))
+	assert.Equal(t, uint64(9), source.committed)
+
+	// 没有新事件时不投递
+	count, err = producer.Produce(kt)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	// 位点提交失败时下次重新投递，事件重复但不丢失
+	source.events = append(source.events, Event{Offset: 10})
+	source.commitErr = errors.New("db is down")
+	_, err = producer.Produce(kt)
+	assert.Error(t, err)
+	source.commitErr = nil
+	_, err = producer.Produce(kt)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 3, 4, 7, 9, 10, 10}, offsets(sink.sent))
+	assert.Equal(t, uint64(10), source.committed)
+}
+
+func TestNewProducer(t *testing.T) {
+	_, err := NewProducer(cc.ChangeEvent{Sink: "unknown"}, new(memSource))
+	assert.Error(t, err)
+
+	producer, err := NewProducer(cc.ChangeEvent{Sink: string(LogSink), BatchSize: 10}, new(memSource))
+	require.NoError(t, err)
+	assert.Equal(t, LogSink, producer.sink.Kind())
+}
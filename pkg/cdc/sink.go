@@ -0,0 +1,313 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cdc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/logs"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SinkKind 事件投递目标类型
+type SinkKind string
+
+const (
+	// LogSink 将事件输出到日志，用于调试
+	LogSink SinkKind = "log"
+	// WebhookSink 将事件以 json 格式 POST 到配置的地址
+	WebhookSink SinkKind = "webhook"
+	// KafkaSink 通过 kafka rest proxy 将事件写入 kafka，每种资源类型对应一个 kafka topic
+	KafkaSink SinkKind = "kafka"
+	// RedisStreamSink 将事件写入 redis stream，每种资源类型对应一个 stream
+	RedisStreamSink SinkKind = "redis_stream"
+)
+
+const (
+	// defaultTopicPrefix kafka topic、redis stream 名称的默认前缀，完整名称为 前缀.资源类型
+	defaultTopicPrefix = "hcm.change_event"
+	// sinkTimeout 单次投递的超时时间
+	sinkTimeout = 10 * time.Second
+)
+
+// Sink 事件投递目标，其它消息队列可实现该接口后通过 RegisterSink 注册
+type Sink interface {
+	// Kind 返回投递目标类型
+	Kind() SinkKind
+	// Send 批量发送事件，返回错误时会重试
+	Send(events []Event) error
+}
+
+// SinkFactory 根据配置创建事件投递目标
+type SinkFactory func(opt cc.ChangeEvent) (Sink, error)
+
+var (
+	sinkLock      sync.RWMutex
+	sinkFactories = map[SinkKind]SinkFactory{
+		LogSink:         newLogSink,
+		WebhookSink:     newWebhookSink,
+		KafkaSink:       newKafkaSink,
+		RedisStreamSink: newRedisStreamSink,
+	}
+)
+
+// RegisterSink 注册事件投递目标，用于接入其它消息队列
+func RegisterSink(kind SinkKind, factory SinkFactory) {
+	sinkLock.Lock()
+	defer sinkLock.Unlock()
+
+	sinkFactories[kind] = factory
+}
+
+// NewSink 根据类型创建事件投递目标
+func NewSink(kind SinkKind, opt cc.ChangeEvent) (Sink, error) {
+	sinkLock.RLock()
+	factory, exist := sinkFactories[kind]
+	sinkLock.RUnlock()
+
+	if !exist {
+		return nil, fmt.Errorf("change event sink %s is not registered", kind)
+	}
+
+	return factory(opt)
+}
+
+// logSink 将事件输出到日志
+type logSink struct{}
+
+func newLogSink(_ cc.ChangeEvent) (Sink, error) {
+	return new(logSink), nil
+}
+
+// Kind ...
+func (s *logSink) Kind() SinkKind {
+	return LogSink
+}
+
+// Send ...
+func (s *logSink) Send(events []Event) error {
+	for _, event := range events {
+		logs.Infof("[change event] res_type: %s, res_id: %s, action: %s, changed_fields: %v, rid: %s",
+			event.ResType, event.ResID, event.Action, event.ChangedFields, event.Rid)
+	}
+	return nil
+}
+
+// webhookSink 将事件以 {"events": [...]} 的格式 POST 到配置的地址
+type webhookSink struct {
+	endpoint string
+	client   *http.Client
+}
+
+func newWebhookSink(opt cc.ChangeEvent) (Sink, error) {
+	if len(opt.Endpoint) == 0 {
+		return nil, fmt.Errorf("endpoint is required for webhook sink")
+	}
+
+	return &webhookSink{
+		endpoint: opt.Endpoint,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Kind ...
+func (s *webhookSink) Kind() SinkKind {
+	return WebhookSink
+}
+
+// Send ...
+func (s *webhookSink) Send(events []Event) error {
+	body, err := json.Marshal(map[string]interface{}{"events": events})
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Post(s.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook responds with status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// topicName 返回事件在 kafka、redis stream 中的 topic 名称，未配置前缀时使用默认前缀
+func topicName(opt cc.ChangeEvent, event Event) string {
+	prefix := opt.Options["topicPrefix"]
+	if len(prefix) == 0 {
+		prefix = defaultTopicPrefix
+	}
+
+	return prefix + "." + event.Topic()
+}
+
+// groupByTopic 按 topic 对事件分组，组内保持事件原有的顺序，返回的 topic 按首次出现的顺序排列
+func groupByTopic(opt cc.ChangeEvent, events []Event) ([]string, map[string][]Event) {
+	topics := make([]string, 0)
+	groups := make(map[string][]Event)
+	for _, event := range events {
+		topic := topicName(opt, event)
+		if _, exists := groups[topic]; !exists {
+			topics = append(topics, topic)
+		}
+		groups[topic] = append(groups[topic], event)
+	}
+
+	return topics, groups
+}
+
+// kafkaSink 通过 kafka rest proxy(v2) 写入 kafka，以资源ID作为消息的 key，保证同一资源的事件有序
+type kafkaSink struct {
+	opt      cc.ChangeEvent
+	endpoint string
+	client   *http.Client
+}
+
+func newKafkaSink(opt cc.ChangeEvent) (Sink, error) {
+	if len(opt.Endpoint) == 0 {
+		return nil, fmt.Errorf("endpoint is required for kafka sink")
+	}
+
+	return &kafkaSink{
+		opt:      opt,
+		endpoint: strings.TrimSuffix(opt.Endpoint, "/"),
+		client:   &http.Client{Timeout: sinkTimeout},
+	}, nil
+}
+
+// Kind ...
+func (s *kafkaSink) Kind() SinkKind {
+	return KafkaSink
+}
+
+type kafkaRecord struct {
+	Key   string `json:"key"`
+	Value Event  `json:"value"`
+}
+
+// Send 按 topic 分别写入，某个 topic 写入失败时整批重试，事件可能重复投递
+func (s *kafkaSink) Send(events []Event) error {
+	topics, groups := groupByTopic(s.opt, events)
+	for _, topic := range topics {
+		records := make([]kafkaRecord, 0, len(groups[topic]))
+		for _, event := range groups[topic] {
+			records = append(records, kafkaRecord{Key: event.ResID, Value: event})
+		}
+
+		body, err := json.Marshal(map[string]interface{}{"records": records})
+		if err != nil {
+			return err
+		}
+
+		addr := s.endpoint + "/topics/" + url.PathEscape(topic)
+		resp, err := s.client.Post(addr, "application/vnd.kafka.json.v2+json", bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+			return fmt.Errorf("kafka rest proxy responds with status code %d, topic: %s", resp.StatusCode, topic)
+		}
+	}
+
+	return nil
+}
+
+// redisStreamSink 将事件以 XADD 写入 redis stream，消息的 event 字段为 json 格式的事件
+type redisStreamSink struct {
+	opt    cc.ChangeEvent
+	cli    redis.Cmdable
+	maxLen int64
+}
+
+func newRedisStreamSink(opt cc.ChangeEvent) (Sink, error) {
+	if len(opt.Endpoint) == 0 {
+		return nil, fmt.Errorf("endpoint is required for redis stream sink")
+	}
+
+	db, maxLen := 0, int64(0)
+	var err error
+	if value := opt.Options["db"]; len(value) != 0 {
+		if db, err = strconv.Atoi(value); err != nil {
+			return nil, fmt.Errorf("options.db of redis stream sink is invalid, err: %v", err)
+		}
+	}
+	// maxLen 每个 stream 保留的近似最大消息数，为0时不裁剪
+	if value := opt.Options["maxLen"]; len(value) != 0 {
+		if maxLen, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return nil, fmt.Errorf("options.maxLen of redis stream sink is invalid, err: %v", err)
+		}
+	}
+
+	cli := redis.NewClient(&redis.Options{
+		Addr:         opt.Endpoint,
+		Password:     opt.Options["password"],
+		DB:           db,
+		ReadTimeout:  sinkTimeout,
+		WriteTimeout: sinkTimeout,
+	})
+
+	return &redisStreamSink{opt: opt, cli: cli, maxLen: maxLen}, nil
+}
+
+// Kind ...
+func (s *redisStreamSink) Kind() SinkKind {
+	return RedisStreamSink
+}
+
+// Send 在一个 pipeline 中写入所有事件，失败时整批重试，事件可能重复投递
+func (s *redisStreamSink) Send(events []Event) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sinkTimeout)
+	defer cancel()
+
+	pipe := s.cli.Pipeline()
+	for _, event := range events {
+		value, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: topicName(s.opt, event),
+			MaxLen: s.maxLen,
+			Approx: s.maxLen > 0,
+			Values: []interface{}{"event", value},
+		})
+	}
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
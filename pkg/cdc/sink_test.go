@@ -0,0 +1,129 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cdc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testEvents = []Event{
+	{Offset: 1, ResType: enumor.CvmAuditResType, ResID: "cvm-1", Action: enumor.Create},
+	{Offset: 2, ResType: enumor.DiskAuditResType, ResID: "disk-1", Action: enumor.Update},
+	{Offset: 3, ResType: enumor.CvmAuditResType, ResID: "cvm-1", Action: enumor.Delete},
+}
+
+func TestNewSink(t *testing.T) {
+	for _, kind := range []SinkKind{KafkaSink, RedisStreamSink, WebhookSink} {
+		_, err := NewSink(kind, cc.ChangeEvent{})
+		assert.Error(t, err, "%s sink without endpoint should fail", kind)
+
+		sink, err := NewSink(kind, cc.ChangeEvent{Endpoint: "127.0.0.1:6379"})
+		require.NoError(t, err)
+		assert.Equal(t, kind, sink.Kind())
+	}
+
+	_, err := NewSink(RedisStreamSink, cc.ChangeEvent{Endpoint: "127.0.0.1:6379",
+		Options: map[string]string{"maxLen": "x"}})
+	assert.Error(t, err)
+
+	_, err = NewSink("unknown", cc.ChangeEvent{})
+	assert.Error(t, err)
+}
+
+func TestKafkaSink(t *testing.T) {
+	var lock sync.Mutex
+	var fail atomic.Bool
+	posted := make(map[string][]kafkaRecord)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		assert.Equal(t, "application/vnd.kafka.json.v2+json", r.Header.Get("Content-Type"))
+
+		body, _ := io.ReadAll(r.Body)
+		req := struct {
+			Records []kafkaRecord `json:"records"`
+		}{}
+		require.NoError(t, json.Unmarshal(body, &req))
+
+		lock.Lock()
+		posted[r.URL.Path] = append(posted[r.URL.Path], req.Records...)
+		lock.Unlock()
+	}))
+	defer server.Close()
+
+	sink, err := NewSink(KafkaSink, cc.ChangeEvent{Endpoint: server.URL + "/",
+		Options: map[string]string{"topicPrefix": "hcm"}})
+	require.NoError(t, err)
+	require.NoError(t, sink.Send(testEvents))
+
+	cvm := posted["/topics/hcm."+string(enumor.CvmAuditResType)]
+	require.Len(t, cvm, 2)
+	assert.Equal(t, "cvm-1", cvm[0].Key)
+	assert.Equal(t, uint64(1), cvm[0].Value.Offset)
+	assert.Equal(t, uint64(3), cvm[1].Value.Offset)
+	assert.Len(t, posted["/topics/hcm."+string(enumor.DiskAuditResType)], 1)
+
+	fail.Store(true)
+	assert.Error(t, sink.Send(testEvents))
+}
+
+func TestRedisStreamSink(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	sink, err := NewSink(RedisStreamSink, cc.ChangeEvent{Endpoint: server.Addr()})
+	require.NoError(t, err)
+	require.NoError(t, sink.Send(testEvents))
+
+	cli := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer cli.Close()
+
+	messages, err := cli.XRange(context.Background(), defaultTopicPrefix+"."+string(enumor.CvmAuditResType), "-",
+		"+").Result()
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+
+	event := new(Event)
+	require.NoError(t, json.Unmarshal([]byte(messages[1].Values["event"].(string)), event))
+	assert.Equal(t, testEvents[2], *event)
+
+	count, err := cli.XLen(context.Background(), defaultTopicPrefix+"."+string(enumor.DiskAuditResType)).Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	server.Close()
+	assert.Error(t, sink.Send(testEvents))
+}
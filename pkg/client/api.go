@@ -21,6 +21,8 @@
 package client
 
 import (
+	"time"
+
 	"hcm/pkg/cc"
 	accountserver "hcm/pkg/client/account-server"
 	authserver "hcm/pkg/client/auth-server"
@@ -32,7 +34,10 @@ import (
 	taskserver "hcm/pkg/client/task-server"
 	"hcm/pkg/rest/client"
 	rdisc "hcm/pkg/rest/discovery"
+	"hcm/pkg/rpc"
 	"hcm/pkg/serviced"
+
+	"google.golang.org/grpc"
 )
 
 // ClientSet defines all server's api client set.
@@ -40,6 +45,10 @@ type ClientSet struct {
 	version      string
 	client       client.HTTPClient
 	apiDiscovery map[cc.Name]*discovery.APIDiscovery
+	// dataGrpcConn is the grpc connection to data-service, it is nil if grpc is not enabled.
+	dataGrpcConn *grpc.ClientConn
+	// dataResilience is the resilience policies of the data-service requests, it is nil if not enabled.
+	dataResilience *client.Resilience
 	// TODO add flow control option
 }
 
@@ -94,11 +103,50 @@ func (cs *ClientSet) CloudServer() *cloudserver.Client {
 // DataService get data-service client.
 func (cs *ClientSet) DataService() *dataservice.Client {
 	c := &client.Capability{
-		Client:   cs.client,
-		Discover: cs.discovery(cc.DataServiceName),
+		Client:     cs.client,
+		Discover:   cs.discovery(cc.DataServiceName),
+		Resilience: cs.dataResilience,
+	}
+
+	cli := dataservice.NewClient(c, cs.version)
+	if cs.dataGrpcConn != nil {
+		cli.EnableGrpc(cs.dataGrpcConn)
+	}
+	return cli
+}
+
+// EnableDataServiceGrpc call the hot path apis of data-service by grpc, grpc connection is shared by all clients.
+func (cs *ClientSet) EnableDataServiceGrpc(opt cc.Grpc) error {
+	conn, err := rpc.NewClient(cc.DataServiceName, cs.discovery(cc.DataServiceName), opt)
+	if err != nil {
+		return err
+	}
+	cs.dataGrpcConn = conn
+	return nil
+}
+
+// EnableDataServiceResilience retry, hedge and break the data-service requests with the policies, the circuit
+// breaker states of the data-service instances are shared by all clients.
+func (cs *ClientSet) EnableDataServiceResilience(opt cc.ClientResilience) {
+	if !opt.Enable {
+		return
 	}
 
-	return dataservice.NewClient(c, cs.version)
+	cs.dataResilience = client.NewResilience(
+		client.RetryPolicy{
+			MaxAttempts: opt.MaxAttempts,
+			Backoff:     time.Duration(opt.RetryBackoffMS) * time.Millisecond,
+			MaxBackoff:  5 * time.Second,
+		},
+		client.HedgePolicy{
+			Delay:     time.Duration(opt.HedgeDelayMS) * time.Millisecond,
+			MaxHedged: opt.MaxHedged,
+		},
+		client.BreakerPolicy{
+			FailureThreshold: opt.BreakerFailureThreshold,
+			OpenDuration:     time.Duration(opt.BreakerOpenSec) * time.Second,
+		},
+	)
 }
 
 // HCService get hc-service client.
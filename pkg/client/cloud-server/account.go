@@ -20,6 +20,8 @@
 package cloudserver
 
 import (
+	proto "hcm/pkg/api/cloud-server/account"
+	"hcm/pkg/client/common"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/rest"
@@ -58,3 +60,15 @@ func (c AccountClient) Sync(kt *kit.Kit, accountID string) error {
 
 	return nil
 }
+
+// SyncWithFlow 账号同步，返回同步任务流ID，需要使用v2版本的客户端
+func (c AccountClient) SyncWithFlow(kt *kit.Kit, accountID string) (*proto.AccountSyncResult, error) {
+	return common.Request[common.Empty, proto.AccountSyncResult](c.client, rest.POST, kt, common.NoData,
+		"/accounts/%s/sync", accountID)
+}
+
+// CheckByID 使用传入的秘钥校验账号的联通性和云上字段是否一致
+func (c AccountClient) CheckByID(kt *kit.Kit, accountID string, req *proto.AccountCheckByIDReq) error {
+	return common.RequestNoResp[proto.AccountCheckByIDReq](c.client, rest.POST, kt, req, "/accounts/%s/check",
+		accountID)
+}
@@ -38,6 +38,9 @@ type Client struct {
 	RouteTable        *RouteTableClient
 	ApprovalProcess   *ApprovalProcessClient
 	ApplicationClient *ApplicationClient
+	TaskCenter        *TaskCenterClient
+	Quota             *QuotaClient
+	Export            *ExportClient
 }
 
 // NewClient create a new cloud-server api client.
@@ -52,5 +55,8 @@ func NewClient(c *client.Capability, version string) *Client {
 		ApprovalProcess:   NewApprovalProcessClient(restCli),
 		RouteTable:        NewRouteTable(restCli),
 		ApplicationClient: NewApplicationClient(restCli),
+		TaskCenter:        NewTaskCenterClient(restCli),
+		Quota:             NewQuotaClient(restCli),
+		Export:            NewExportClient(restCli),
 	}
 }
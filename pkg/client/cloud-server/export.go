@@ -0,0 +1,89 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloudserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	cloudserver "hcm/pkg/api/cloud-server"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// ExportClient is cloud-server resource export api client.
+type ExportClient struct {
+	client rest.ClientInterface
+}
+
+// NewExportClient create a new resource export api client.
+func NewExportClient(client rest.ClientInterface) *ExportClient {
+	return &ExportClient{
+		client: client,
+	}
+}
+
+// ExportFile is the exported resource file.
+type ExportFile struct {
+	FileName string
+	Content  []byte
+}
+
+// Export the resources to file, resPath is the resource path of the export api, e.g. cvms, bizs/100/cvms.
+func (c *ExportClient) Export(kt *kit.Kit, resPath string, req *cloudserver.ResourceExportReq) (*ExportFile,
+	error) {
+
+	result := c.client.Post().
+		WithContext(kt.Ctx).
+		Body(req).
+		SubResourcef("/%s/export", strings.Trim(resPath, "/")).
+		WithHeaders(kt.Header()).
+		Do()
+	if result.Err != nil {
+		return nil, result.Err
+	}
+
+	// export failed, the error is responded as json.
+	if strings.HasPrefix(result.Header.Get("Content-Type"), "application/json") {
+		resp := new(rest.BaseResp)
+		if err := json.Unmarshal(result.Body, resp); err != nil {
+			return nil, fmt.Errorf("decode export response failed, err: %v", err)
+		}
+		if err := resp.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	if result.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("export %s failed, status: %s", resPath, result.Status)
+	}
+
+	file := &ExportFile{FileName: fmt.Sprintf("%s.%s", strings.ReplaceAll(resPath, "/", "_"), req.Format),
+		Content: result.Body}
+	if _, params, err := mime.ParseMediaType(result.Header.Get("Content-Disposition")); err == nil &&
+		len(params["filename"]) != 0 {
+		file.FileName = params["filename"]
+	}
+
+	return file, nil
+}
@@ -0,0 +1,48 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloudserver
+
+import (
+	"hcm/pkg/api/core"
+	coreasync "hcm/pkg/api/core/async"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// TaskCenterClient is cloud-server task center api client.
+type TaskCenterClient struct {
+	client rest.ClientInterface
+}
+
+// NewTaskCenterClient create a new task center api client.
+func NewTaskCenterClient(client rest.ClientInterface) *TaskCenterClient {
+	return &TaskCenterClient{
+		client: client,
+	}
+}
+
+// ListAsyncJob list async jobs, including async flows and account syncs.
+func (c *TaskCenterClient) ListAsyncJob(kt *kit.Kit, req *core.ListReq) (*core.ListResultT[coreasync.AsyncJob],
+	error) {
+
+	return common.Request[core.ListReq, core.ListResultT[coreasync.AsyncJob]](c.client, rest.POST, kt, req,
+		"/task_center/jobs/list")
+}
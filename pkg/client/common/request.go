@@ -21,7 +21,6 @@ package common
 
 import (
 	"hcm/pkg/api/core"
-	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/rest"
 )
@@ -65,8 +64,8 @@ func Request[T any, R any](cli rest.ClientInterface, method rest.VerbType, kt *k
 		return nil, err
 	}
 
-	if resp.Code != errf.OK {
-		return nil, errf.New(resp.Code, resp.Message)
+	if err = resp.Err(); err != nil {
+		return nil, err
 	}
 
 	return resp.Data, nil
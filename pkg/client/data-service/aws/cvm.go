@@ -26,7 +26,11 @@ import (
 	"hcm/pkg/api/core"
 	corecvm "hcm/pkg/api/core/cloud/cvm"
 	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/api/data-service/grpc/pb"
+	grpcclient "hcm/pkg/client/data-service/grpc-client"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/featureflag"
 	"hcm/pkg/rest"
 )
 
@@ -40,12 +44,23 @@ func NewCloudCvmClient(client rest.ClientInterface) *CvmClient {
 // CvmClient is data service cvm api client.
 type CvmClient struct {
 	client rest.ClientInterface
+	// grpc is the grpc client of the hot path apis, it is nil if grpc is not enabled.
+	grpc pb.CvmServiceClient
+}
+
+// EnableGrpc call the hot path apis by the grpc client.
+func (cli *CvmClient) EnableGrpc(grpc pb.CvmServiceClient) {
+	cli.grpc = grpc
 }
 
 // BatchCreateCvm batch create cvm rule.
 func (cli *CvmClient) BatchCreateCvm(ctx context.Context, h http.Header,
 	request *protocloud.CvmBatchCreateReq[corecvm.AwsCvmExtension]) (*core.BatchCreateResult, error) {
 
+	if cli.grpc != nil && featureflag.Enabled(featureflag.DataServiceGrpc, enumor.Aws, "") {
+		return grpcclient.BatchCreateCvm(ctx, h, cli.grpc, enumor.Aws, request)
+	}
+
 	resp := new(core.BatchCreateResp)
 
 	err := cli.client.Post().
@@ -70,6 +85,10 @@ func (cli *CvmClient) BatchCreateCvm(ctx context.Context, h http.Header,
 func (cli *CvmClient) BatchUpdateCvm(ctx context.Context, h http.Header,
 	request *protocloud.CvmBatchUpdateReq[corecvm.AwsCvmExtension]) error {
 
+	if cli.grpc != nil && featureflag.Enabled(featureflag.DataServiceGrpc, enumor.Aws, "") {
+		return grpcclient.BatchUpdateCvm(ctx, h, cli.grpc, enumor.Aws, request)
+	}
+
 	resp := new(rest.BaseResp)
 
 	err := cli.client.Patch().
@@ -118,6 +137,10 @@ func (cli *CvmClient) GetCvm(ctx context.Context, h http.Header, id string) (
 func (cli *CvmClient) ListCvmExt(ctx context.Context, h http.Header, request *protocloud.CvmListReq) (
 	*protocloud.CvmExtListResult[corecvm.AwsCvmExtension], error) {
 
+	if cli.grpc != nil && featureflag.Enabled(featureflag.DataServiceGrpc, enumor.Aws, "") {
+		return grpcclient.ListCvmExt[corecvm.AwsCvmExtension](ctx, h, cli.grpc, enumor.Aws, request)
+	}
+
 	resp := new(protocloud.CvmExtListResp[corecvm.AwsCvmExtension])
 
 	err := cli.client.Post().
@@ -26,7 +26,10 @@ import (
 	"hcm/pkg/api/core"
 	coredisk "hcm/pkg/api/core/cloud/disk"
 	dataproto "hcm/pkg/api/data-service/cloud/disk"
+	"hcm/pkg/client/common"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
 )
 
 // BatchCreateDisk 批量创建云盘
@@ -53,6 +56,14 @@ func (rc *restClient) BatchCreateDisk(ctx context.Context,
 	return resp.Data, nil
 }
 
+// BatchUpsertDisk 在一个事务中批量删除、更新和创建云盘
+func (rc *restClient) BatchUpsertDisk(kt *kit.Kit,
+	request *dataproto.DiskExtBatchUpsertReq[coredisk.AwsExtension]) (*core.BatchCreateResult, error) {
+
+	return common.Request[dataproto.DiskExtBatchUpsertReq[coredisk.AwsExtension], core.BatchCreateResult](
+		rc.client, rest.POST, kt, request, "/disks/batch/upsert")
+}
+
 // RetrieveDisk 查询单个云盘详情
 func (rc *restClient) RetrieveDisk(
 	ctx context.Context,
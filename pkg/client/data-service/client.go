@@ -22,6 +22,7 @@ package dataservice
 import (
 	"fmt"
 
+	"hcm/pkg/api/data-service/grpc/pb"
 	"hcm/pkg/client/data-service/aws"
 	"hcm/pkg/client/data-service/azure"
 	"hcm/pkg/client/data-service/gcp"
@@ -33,6 +34,8 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/rest"
 	"hcm/pkg/rest/client"
+
+	"google.golang.org/grpc"
 )
 
 // Client is data-service api client.
@@ -78,3 +81,13 @@ func NewClient(c *client.Capability, version string) *Client {
 		),
 	}
 }
+
+// EnableGrpc call the cvm hot path apis of all vendors by the grpc connection.
+func (c *Client) EnableGrpc(conn grpc.ClientConnInterface) {
+	cvmCli := pb.NewCvmServiceClient(conn)
+	c.TCloud.Cvm.EnableGrpc(cvmCli)
+	c.Aws.Cvm.EnableGrpc(cvmCli)
+	c.HuaWei.Cvm.EnableGrpc(cvmCli)
+	c.Gcp.Cvm.EnableGrpc(cvmCli)
+	c.Azure.Cvm.EnableGrpc(cvmCli)
+}
@@ -25,6 +25,7 @@ import (
 
 	"hcm/pkg/api/core"
 	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/client/common"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/rest"
@@ -208,3 +209,9 @@ func (a *AccountClient) ListAccountBizRel(ctx context.Context, h http.Header, re
 
 	return resp.Data, nil
 }
+
+// RotateSecret re-encrypt the account secrets with the active key.
+func (a *AccountClient) RotateSecret(kt *kit.Kit) (*protocloud.AccountSecretRotateResult, error) {
+	return common.Request[common.Empty, protocloud.AccountSecretRotateResult](a.client, rest.POST, kt, common.NoData,
+		"/accounts/secrets/rotate")
+}
@@ -0,0 +1,88 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	coreaccountgroup "hcm/pkg/api/core/account-group"
+	dataaccountgroup "hcm/pkg/api/data-service/account-group"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// AccountGroupClient is data service account group api client.
+type AccountGroupClient struct {
+	client rest.ClientInterface
+}
+
+// NewAccountGroupClient create a new account group api client.
+func NewAccountGroupClient(client rest.ClientInterface) *AccountGroupClient {
+	return &AccountGroupClient{
+		client: client,
+	}
+}
+
+// Create account group.
+func (cli *AccountGroupClient) Create(kt *kit.Kit, req *dataaccountgroup.AccountGroupCreateReq) (
+	*core.CreateResult, error) {
+
+	return common.Request[dataaccountgroup.AccountGroupCreateReq, core.CreateResult](cli.client, rest.POST, kt,
+		req, "/account_groups/create")
+}
+
+// Update account group.
+func (cli *AccountGroupClient) Update(kt *kit.Kit, id string, req *dataaccountgroup.AccountGroupUpdateReq) error {
+	return common.RequestNoResp[dataaccountgroup.AccountGroupUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/account_groups/%s", id)
+}
+
+// List account group.
+func (cli *AccountGroupClient) List(kt *kit.Kit, req *core.ListReq) (
+	*core.ListResultT[coreaccountgroup.AccountGroup], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[coreaccountgroup.AccountGroup]](cli.client, rest.POST,
+		kt, req, "/account_groups/list")
+}
+
+// BatchDelete account group and its members.
+func (cli *AccountGroupClient) BatchDelete(kt *kit.Kit, req *core.BatchDeleteReq) error {
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req, "/account_groups/batch")
+}
+
+// AddMember add account group members.
+func (cli *AccountGroupClient) AddMember(kt *kit.Kit, req *dataaccountgroup.AccountGroupMemberReq) error {
+	return common.RequestNoResp[dataaccountgroup.AccountGroupMemberReq](cli.client, rest.POST, kt, req,
+		"/account_groups/members/add")
+}
+
+// RemoveMember remove account group members.
+func (cli *AccountGroupClient) RemoveMember(kt *kit.Kit, req *dataaccountgroup.AccountGroupMemberReq) error {
+	return common.RequestNoResp[dataaccountgroup.AccountGroupMemberReq](cli.client, rest.POST, kt, req,
+		"/account_groups/members/remove")
+}
+
+// ListMember list account group member.
+func (cli *AccountGroupClient) ListMember(kt *kit.Kit, req *core.ListReq) (
+	*core.ListResultT[coreaccountgroup.AccountGroupMember], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[coreaccountgroup.AccountGroupMember]](cli.client,
+		rest.POST, kt, req, "/account_groups/members/list")
+}
@@ -0,0 +1,46 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	coreasync "hcm/pkg/api/core/async"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// AsyncJobClient is data service async job api client.
+type AsyncJobClient struct {
+	client rest.ClientInterface
+}
+
+// NewAsyncJobClient create a new async job api client.
+func NewAsyncJobClient(client rest.ClientInterface) *AsyncJobClient {
+	return &AsyncJobClient{
+		client: client,
+	}
+}
+
+// List async job.
+func (cli *AsyncJobClient) List(kt *kit.Kit, req *core.ListReq) (*core.ListResultT[coreasync.AsyncJob], error) {
+	return common.Request[core.ListReq, core.ListResultT[coreasync.AsyncJob]](cli.client, rest.POST, kt, req,
+		"/async_jobs/list")
+}
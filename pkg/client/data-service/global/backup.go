@@ -0,0 +1,106 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"hcm/pkg/api/core"
+	databackup "hcm/pkg/api/data-service/backup"
+	"hcm/pkg/client/common"
+	"hcm/pkg/criteria/errf"
+	daobackup "hcm/pkg/dal/dao/backup"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// BackupClient is data service inventory data backup and restore api client.
+type BackupClient struct {
+	client rest.ClientInterface
+}
+
+// NewBackupClient create a new inventory data backup and restore api client.
+func NewBackupClient(client rest.ClientInterface) *BackupClient {
+	return &BackupClient{
+		client: client,
+	}
+}
+
+// Export the snapshot of the tables, handler is called with each exported record, returns the manifest of the
+// exported data.
+func (c *BackupClient) Export(kt *kit.Kit, req *databackup.ExportReq,
+	handler func(record *daobackup.Record) error) (*daobackup.Manifest, error) {
+
+	result := c.client.Post().
+		WithContext(kt.Ctx).
+		Body(req).
+		SubResourcef("/backups/export").
+		WithHeaders(kt.Header()).
+		Do()
+	if result.Err != nil {
+		return nil, result.Err
+	}
+
+	// request is rejected before streaming, the response is the normal json response.
+	if !strings.HasPrefix(result.Header.Get("Content-Type"), rest.MIMENDJSON) {
+		resp := new(core.BaseResp[interface{}])
+		if err := result.Into(resp); err != nil {
+			return nil, err
+		}
+
+		if resp.Code != errf.OK {
+			return nil, errf.New(resp.Code, resp.Message)
+		}
+		return nil, fmt.Errorf("unexpected export response, status: %s", result.Status)
+	}
+
+	var manifest *daobackup.Manifest
+	_, err := rest.DecodeStream(bytes.NewReader(result.Body), func(data json.RawMessage) error {
+		record := new(daobackup.Record)
+		if err := json.Unmarshal(data, record); err != nil {
+			return err
+		}
+
+		if record.Manifest != nil {
+			manifest = record.Manifest
+			return nil
+		}
+		return handler(record)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest == nil {
+		return nil, errors.New("manifest is missing in the exported data")
+	}
+
+	return manifest, nil
+}
+
+// Import the exported records.
+func (c *BackupClient) Import(kt *kit.Kit, req *databackup.ImportReq) (*databackup.ImportResult, error) {
+	return common.Request[databackup.ImportReq, databackup.ImportResult](c.client, rest.POST, kt, req,
+		"/backups/import")
+}
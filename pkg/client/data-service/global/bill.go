@@ -159,6 +159,14 @@ func (b *BillClient) ListBillItemRaw(kt *kit.Kit, req *billproto.BillItemListReq
 		b.client, rest.POST, kt, req, "/bills/items/list_with_extension")
 }
 
+// SumBillItemCost sum bill item cost group by dimensions
+func (b *BillClient) SumBillItemCost(kt *kit.Kit, req *billproto.BillItemCostSumReq) (
+	*billproto.BillItemCostSumResult, error) {
+
+	return common.Request[billproto.BillItemCostSumReq, billproto.BillItemCostSumResult](
+		b.client, rest.POST, kt, req, "/bills/items/cost/sum")
+}
+
 // --- bill daily pull task ---
 
 // CreateBillDailyPullTask create bill daily pull task
@@ -498,3 +506,41 @@ func (b *BillClient) ListBillSyncRecord(kt *kit.Kit, req *billproto.BillSyncReco
 	return common.Request[billproto.BillSyncRecordListReq, billproto.BillSyncRecordListResult](
 		b.client, rest.POST, kt, req, "/bills/sync_records/list")
 }
+
+// --- bill budget ---
+
+// CreateBillBudget create bill budget
+func (b *BillClient) CreateBillBudget(kt *kit.Kit, req *billproto.BudgetCreateReq) (*core.CreateResult, error) {
+	return common.Request[billproto.BudgetCreateReq, core.CreateResult](b.client, rest.POST, kt, req,
+		"/bills/budgets/create")
+}
+
+// UpdateBillBudget update bill budget
+func (b *BillClient) UpdateBillBudget(kt *kit.Kit, req *billproto.BudgetUpdateReq) error {
+	return common.RequestNoResp[billproto.BudgetUpdateReq](b.client, rest.PATCH, kt, req, "/bills/budgets")
+}
+
+// ListBillBudget list bill budget
+func (b *BillClient) ListBillBudget(kt *kit.Kit, req *core.ListReq) (*billproto.BudgetListResult, error) {
+	return common.Request[core.ListReq, billproto.BudgetListResult](b.client, rest.POST, kt, req,
+		"/bills/budgets/list")
+}
+
+// BatchDeleteBillBudget batch delete bill budget
+func (b *BillClient) BatchDeleteBillBudget(kt *kit.Kit, req *dataservice.BatchDeleteReq) error {
+	return common.RequestNoResp[dataservice.BatchDeleteReq](b.client, rest.DELETE, kt, req, "/bills/budgets/batch")
+}
+
+// ReplaceBillReconciliation replace bill reconciliation results of main account in the month
+func (b *BillClient) ReplaceBillReconciliation(kt *kit.Kit, req *billproto.ReconciliationReplaceReq) error {
+	return common.RequestNoResp[billproto.ReconciliationReplaceReq](b.client, rest.POST, kt, req,
+		"/bills/reconciliations/replace")
+}
+
+// ListBillReconciliation list bill reconciliation
+func (b *BillClient) ListBillReconciliation(kt *kit.Kit, req *core.ListReq) (
+	*billproto.ReconciliationListResult, error) {
+
+	return common.Request[core.ListReq, billproto.ReconciliationListResult](b.client, rest.POST, kt, req,
+		"/bills/reconciliations/list")
+}
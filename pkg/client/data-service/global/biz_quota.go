@@ -0,0 +1,110 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	corequota "hcm/pkg/api/core/quota"
+	dataquota "hcm/pkg/api/data-service/quota"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// BizQuotaClient is data service biz resource quota api client.
+type BizQuotaClient struct {
+	client rest.ClientInterface
+}
+
+// NewBizQuotaClient create a new biz resource quota api client.
+func NewBizQuotaClient(client rest.ClientInterface) *BizQuotaClient {
+	return &BizQuotaClient{
+		client: client,
+	}
+}
+
+// Create biz quota.
+func (cli *BizQuotaClient) Create(kt *kit.Kit, req *dataquota.BizQuotaCreateReq) (*core.CreateResult, error) {
+	return common.Request[dataquota.BizQuotaCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/biz_quotas/create")
+}
+
+// Update biz quota.
+func (cli *BizQuotaClient) Update(kt *kit.Kit, id string, req *dataquota.BizQuotaUpdateReq) error {
+	return common.RequestNoResp[dataquota.BizQuotaUpdateReq](cli.client, rest.PATCH, kt, req, "/biz_quotas/%s", id)
+}
+
+// List biz quota.
+func (cli *BizQuotaClient) List(kt *kit.Kit, req *core.ListReq) (*core.ListResultT[corequota.BizQuota], error) {
+	return common.Request[core.ListReq, core.ListResultT[corequota.BizQuota]](cli.client, rest.POST, kt, req,
+		"/biz_quotas/list")
+}
+
+// BatchDelete biz quota.
+func (cli *BizQuotaClient) BatchDelete(kt *kit.Kit, req *core.BatchDeleteReq) error {
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req, "/biz_quotas/batch")
+}
+
+// ListUsage list biz resource usage counted from inventory.
+func (cli *BizQuotaClient) ListUsage(kt *kit.Kit, req *dataquota.BizResUsageListReq) (
+	*core.ListResultT[corequota.BizResUsage], error) {
+
+	return common.Request[dataquota.BizResUsageListReq, core.ListResultT[corequota.BizResUsage]](cli.client,
+		rest.POST, kt, req, "/biz_quotas/usages/list")
+}
+
+// BizResWhitelistClient is data service biz resource whitelist api client.
+type BizResWhitelistClient struct {
+	client rest.ClientInterface
+}
+
+// NewBizResWhitelistClient create a new biz resource whitelist api client.
+func NewBizResWhitelistClient(client rest.ClientInterface) *BizResWhitelistClient {
+	return &BizResWhitelistClient{
+		client: client,
+	}
+}
+
+// Create biz resource whitelist.
+func (cli *BizResWhitelistClient) Create(kt *kit.Kit, req *dataquota.BizResWhitelistCreateReq) (
+	*core.CreateResult, error) {
+
+	return common.Request[dataquota.BizResWhitelistCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/biz_res_whitelists/create")
+}
+
+// Update biz resource whitelist.
+func (cli *BizResWhitelistClient) Update(kt *kit.Kit, id string, req *dataquota.BizResWhitelistUpdateReq) error {
+	return common.RequestNoResp[dataquota.BizResWhitelistUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/biz_res_whitelists/%s", id)
+}
+
+// List biz resource whitelist.
+func (cli *BizResWhitelistClient) List(kt *kit.Kit, req *core.ListReq) (
+	*core.ListResultT[corequota.BizResWhitelist], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[corequota.BizResWhitelist]](cli.client, rest.POST, kt,
+		req, "/biz_res_whitelists/list")
+}
+
+// BatchDelete biz resource whitelist.
+func (cli *BizResWhitelistClient) BatchDelete(kt *kit.Kit, req *core.BatchDeleteReq) error {
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req, "/biz_res_whitelists/batch")
+}
@@ -52,6 +52,7 @@ type Client struct {
 	Bill            *BillClient
 
 	UserCollection *UserCollectionClient
+	UserRecentView *UserRecentViewClient
 
 	CloudSelection *CloudSelectionClient
 	ArgsTpl        *ArgsTplClient
@@ -65,7 +66,28 @@ type Client struct {
 	TaskDetail     *TaskDetailClient
 	TaskManagement *TaskManagementClient
 
-	GlobalConfig *GlobalConfigsClient
+	GlobalConfig          *GlobalConfigsClient
+	ResourceHistory       *ResourceHistoryClient
+	DistinctValue         *DistinctValueClient
+	Consistency           *ConsistencyClient
+	ResourceRelation      *ResourceRelationClient
+	Index                 *IndexClient
+	Backup                *BackupClient
+	BizQuota              *BizQuotaClient
+	IdleResource          *IdleResourceClient
+	NamingRule            *NamingRuleClient
+	TagPolicy             *TagPolicyClient
+	Compliance            *ComplianceClient
+	AsyncJob              *AsyncJobClient
+	AccountGroup          *AccountGroupClient
+	Notification          *NotificationClient
+	SavedFilter           *SavedFilterClient
+	MaintenanceWindow     *MaintenanceWindowClient
+	LandingZoneDeployment *LandingZoneDeploymentClient
+	BizResWhitelist       *BizResWhitelistClient
+	Event                 *EventClient
+	Webhook               *WebhookClient
+	Tenant                *TenantClient
 }
 
 type restClient struct {
@@ -101,6 +123,7 @@ func NewClient(client rest.ClientInterface) *Client {
 		Bill:            NewBillClient(client),
 
 		UserCollection: NewUserCollectionClient(client),
+		UserRecentView: NewUserRecentViewClient(client),
 
 		CloudSelection: NewCloudCloudSelectionClient(client),
 		ArgsTpl:        NewCloudArgumentTemplateClient(client),
@@ -113,5 +136,27 @@ func NewClient(client rest.ClientInterface) *Client {
 		TaskDetail:     NewTaskDetailClient(client),
 		TaskManagement: NewTaskManagementClient(client),
 		GlobalConfig:   NewGlobalConfigClient(client),
+
+		ResourceHistory:       NewResourceHistoryClient(client),
+		DistinctValue:         NewDistinctValueClient(client),
+		Consistency:           NewConsistencyClient(client),
+		ResourceRelation:      NewResourceRelationClient(client),
+		Index:                 NewIndexClient(client),
+		Backup:                NewBackupClient(client),
+		BizQuota:              NewBizQuotaClient(client),
+		IdleResource:          NewIdleResourceClient(client),
+		NamingRule:            NewNamingRuleClient(client),
+		TagPolicy:             NewTagPolicyClient(client),
+		Compliance:            NewComplianceClient(client),
+		AsyncJob:              NewAsyncJobClient(client),
+		AccountGroup:          NewAccountGroupClient(client),
+		Notification:          NewNotificationClient(client),
+		SavedFilter:           NewSavedFilterClient(client),
+		MaintenanceWindow:     NewMaintenanceWindowClient(client),
+		LandingZoneDeployment: NewLandingZoneDeploymentClient(client),
+		BizResWhitelist:       NewBizResWhitelistClient(client),
+		Event:                 NewEventClient(client),
+		Webhook:               NewWebhookClient(client),
+		Tenant:                NewTenantClient(client),
 	}
 }
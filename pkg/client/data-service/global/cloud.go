@@ -24,6 +24,7 @@ import (
 	"net/http"
 
 	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/client/common"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/types"
@@ -139,3 +140,35 @@ func (cli *CloudClient) AssignResourceToBiz(ctx context.Context, h http.Header,
 
 	return nil
 }
+
+// PreviewAssignResourceToBiz preview the resources to be assigned by BatchAssignResourceToBiz.
+func (cli *CloudClient) PreviewAssignResourceToBiz(kt *kit.Kit, req *protocloud.BatchAssignResourceToBizReq) (
+	*protocloud.AssignResourcePreviewResult, error) {
+
+	return common.Request[protocloud.BatchAssignResourceToBizReq, protocloud.AssignResourcePreviewResult](
+		cli.client, rest.POST, kt, req, "/cloud/resources/assign/bizs/preview")
+}
+
+// BatchAssignResourceToBiz assign filtered unassigned resource and its dependent resource to biz.
+func (cli *CloudClient) BatchAssignResourceToBiz(kt *kit.Kit, req *protocloud.BatchAssignResourceToBizReq) (
+	*protocloud.AssignResourcePreviewResult, error) {
+
+	return common.Request[protocloud.BatchAssignResourceToBizReq, protocloud.AssignResourcePreviewResult](
+		cli.client, rest.POST, kt, req, "/cloud/resources/assign/bizs/batch")
+}
+
+// PreviewTransferResourceBiz preview the resources to be transferred by TransferResourceBiz.
+func (cli *CloudClient) PreviewTransferResourceBiz(kt *kit.Kit, req *protocloud.TransferResourceBizReq) (
+	*protocloud.AssignResourcePreviewResult, error) {
+
+	return common.Request[protocloud.TransferResourceBizReq, protocloud.AssignResourcePreviewResult](
+		cli.client, rest.POST, kt, req, "/cloud/resources/transfer/bizs/preview")
+}
+
+// TransferResourceBiz transfer resource and its dependent resource from source biz to target biz.
+func (cli *CloudClient) TransferResourceBiz(kt *kit.Kit, req *protocloud.TransferResourceBizReq) (
+	*protocloud.AssignResourcePreviewResult, error) {
+
+	return common.Request[protocloud.TransferResourceBizReq, protocloud.AssignResourcePreviewResult](
+		cli.client, rest.POST, kt, req, "/cloud/resources/transfer/bizs")
+}
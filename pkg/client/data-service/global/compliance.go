@@ -0,0 +1,78 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	corecompliance "hcm/pkg/api/core/compliance"
+	datacompliance "hcm/pkg/api/data-service/compliance"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// ComplianceClient is data service security compliance api client.
+type ComplianceClient struct {
+	client rest.ClientInterface
+}
+
+// NewComplianceClient create a new security compliance api client.
+func NewComplianceClient(client rest.ClientInterface) *ComplianceClient {
+	return &ComplianceClient{
+		client: client,
+	}
+}
+
+// Detect compliance finding.
+func (cli *ComplianceClient) Detect(kt *kit.Kit, req *datacompliance.DetectReq) (*datacompliance.DetectResult,
+	error) {
+
+	return common.Request[datacompliance.DetectReq, datacompliance.DetectResult](cli.client, rest.POST, kt, req,
+		"/compliance/detect")
+}
+
+// ListFinding list compliance finding.
+func (cli *ComplianceClient) ListFinding(kt *kit.Kit, req *core.ListReq) (
+	*core.ListResultT[corecompliance.Finding], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[corecompliance.Finding]](cli.client, rest.POST, kt, req,
+		"/compliance/findings/list")
+}
+
+// CreateExemption create compliance exemption.
+func (cli *ComplianceClient) CreateExemption(kt *kit.Kit, req *datacompliance.ExemptionCreateReq) (
+	*core.CreateResult, error) {
+
+	return common.Request[datacompliance.ExemptionCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/compliance/exemptions/create")
+}
+
+// ListExemption list compliance exemption.
+func (cli *ComplianceClient) ListExemption(kt *kit.Kit, req *core.ListReq) (
+	*core.ListResultT[corecompliance.Exemption], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[corecompliance.Exemption]](cli.client, rest.POST, kt, req,
+		"/compliance/exemptions/list")
+}
+
+// BatchDeleteExemption batch delete compliance exemption.
+func (cli *ComplianceClient) BatchDeleteExemption(kt *kit.Kit, req *core.BatchDeleteReq) error {
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req, "/compliance/exemptions/batch")
+}
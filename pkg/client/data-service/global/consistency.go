@@ -0,0 +1,45 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	dataconsistency "hcm/pkg/api/data-service/consistency"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// ConsistencyClient is data service data consistency check api client.
+type ConsistencyClient struct {
+	client rest.ClientInterface
+}
+
+// NewConsistencyClient create a new data consistency check api client.
+func NewConsistencyClient(client rest.ClientInterface) *ConsistencyClient {
+	return &ConsistencyClient{
+		client: client,
+	}
+}
+
+// Check data consistency and return the report.
+func (c *ConsistencyClient) Check(kt *kit.Kit, req *dataconsistency.CheckReq) (*dataconsistency.Report, error) {
+	return common.Request[dataconsistency.CheckReq, dataconsistency.Report](
+		c.client, rest.POST, kt, req, "/consistency/check")
+}
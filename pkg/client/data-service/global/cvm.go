@@ -25,6 +25,7 @@ import (
 
 	"hcm/pkg/api/core"
 	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/client/common"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/rest"
@@ -113,3 +114,9 @@ func (cli *CvmClient) BatchUpdateCvmCommonInfo(kt *kit.Kit,
 
 	return nil
 }
+
+// BatchUpdateCvmOwnerInfo batch update cvm owner and labels.
+func (cli *CvmClient) BatchUpdateCvmOwnerInfo(kt *kit.Kit, request *protocloud.CvmOwnerInfoBatchUpdateReq) error {
+	return common.RequestNoResp[protocloud.CvmOwnerInfoBatchUpdateReq](cli.client, rest.PATCH, kt, request,
+		"/cvms/owner/info/batch/update")
+}
@@ -0,0 +1,45 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// SyncCvmToCmdbHost sync cvms to cmdb hosts of their biz.
+func (cli *CvmClient) SyncCvmToCmdbHost(kt *kit.Kit, req *protocloud.CvmCmdbSyncReq) (
+	*protocloud.CvmCmdbSyncResult, error) {
+
+	return common.Request[protocloud.CvmCmdbSyncReq, protocloud.CvmCmdbSyncResult](cli.client, rest.POST, kt, req,
+		"/cvms/cmdb/sync")
+}
+
+// ListCmdbHostRel list cvm and cmdb host relations.
+func (cli *CvmClient) ListCmdbHostRel(kt *kit.Kit, req *core.ListReq) (*core.ListResultT[corecvm.CmdbHostRel],
+	error) {
+
+	return common.Request[core.ListReq, core.ListResultT[corecvm.CmdbHostRel]](cli.client, rest.POST, kt, req,
+		"/cvms/cmdb/host_rels/list")
+}
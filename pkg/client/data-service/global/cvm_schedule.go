@@ -0,0 +1,70 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// CreateCvmSchedule create cvm schedule.
+func (cli *CvmClient) CreateCvmSchedule(kt *kit.Kit, req *protocloud.CvmScheduleCreateReq) (*core.CreateResult,
+	error) {
+
+	return common.Request[protocloud.CvmScheduleCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/cvm_schedules/create")
+}
+
+// UpdateCvmSchedule update cvm schedule.
+func (cli *CvmClient) UpdateCvmSchedule(kt *kit.Kit, id string, req *protocloud.CvmScheduleUpdateReq) error {
+	return common.RequestNoResp[protocloud.CvmScheduleUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/cvm_schedules/%s", id)
+}
+
+// ListCvmSchedule list cvm schedule.
+func (cli *CvmClient) ListCvmSchedule(kt *kit.Kit, req *core.ListReq) (*core.ListResultT[corecvm.Schedule], error) {
+	return common.Request[core.ListReq, core.ListResultT[corecvm.Schedule]](cli.client, rest.POST, kt, req,
+		"/cvm_schedules/list")
+}
+
+// BatchDeleteCvmSchedule batch delete cvm schedule.
+func (cli *CvmClient) BatchDeleteCvmSchedule(kt *kit.Kit, req *core.BatchDeleteReq) error {
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req, "/cvm_schedules/batch")
+}
+
+// CreateCvmScheduleRecord create cvm schedule execution record.
+func (cli *CvmClient) CreateCvmScheduleRecord(kt *kit.Kit, req *protocloud.CvmScheduleRecordCreateReq) (
+	*core.CreateResult, error) {
+
+	return common.Request[protocloud.CvmScheduleRecordCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/cvm_schedules/records/create")
+}
+
+// ListCvmScheduleRecord list cvm schedule execution record.
+func (cli *CvmClient) ListCvmScheduleRecord(kt *kit.Kit, req *core.ListReq) (
+	*core.ListResultT[corecvm.ScheduleRecord], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[corecvm.ScheduleRecord]](cli.client, rest.POST, kt, req,
+		"/cvm_schedules/records/list")
+}
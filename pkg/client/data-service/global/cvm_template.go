@@ -0,0 +1,54 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// CreateCvmTemplate create cvm template.
+func (cli *CvmClient) CreateCvmTemplate(kt *kit.Kit, req *protocloud.CvmTemplateCreateReq) (*core.CreateResult,
+	error) {
+
+	return common.Request[protocloud.CvmTemplateCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/cvm_templates/create")
+}
+
+// UpdateCvmTemplate update cvm template.
+func (cli *CvmClient) UpdateCvmTemplate(kt *kit.Kit, id string, req *protocloud.CvmTemplateUpdateReq) error {
+	return common.RequestNoResp[protocloud.CvmTemplateUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/cvm_templates/%s", id)
+}
+
+// ListCvmTemplate list cvm template.
+func (cli *CvmClient) ListCvmTemplate(kt *kit.Kit, req *core.ListReq) (*core.ListResultT[corecvm.Template], error) {
+	return common.Request[core.ListReq, core.ListResultT[corecvm.Template]](cli.client, rest.POST, kt, req,
+		"/cvm_templates/list")
+}
+
+// BatchDeleteCvmTemplate batch delete cvm template.
+func (cli *CvmClient) BatchDeleteCvmTemplate(kt *kit.Kit, req *core.BatchDeleteReq) error {
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req, "/cvm_templates/batch")
+}
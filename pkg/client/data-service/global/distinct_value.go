@@ -0,0 +1,45 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	datadistinct "hcm/pkg/api/data-service/distinct-value"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// DistinctValueClient is data service distinct value api client.
+type DistinctValueClient struct {
+	client rest.ClientInterface
+}
+
+// NewDistinctValueClient create a new distinct value api client.
+func NewDistinctValueClient(client rest.ClientInterface) *DistinctValueClient {
+	return &DistinctValueClient{
+		client: client,
+	}
+}
+
+// List distinct values of the resource table field.
+func (d *DistinctValueClient) List(kt *kit.Kit, req *datadistinct.ListReq) (*datadistinct.ListResp, error) {
+	return common.Request[datadistinct.ListReq, datadistinct.ListResp](
+		d.client, rest.POST, kt, req, "/distinct_values/list")
+}
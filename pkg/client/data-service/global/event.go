@@ -0,0 +1,64 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	coreevent "hcm/pkg/api/core/event"
+	dataevent "hcm/pkg/api/data-service/event"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// EventClient is data service resource change event api client.
+type EventClient struct {
+	client rest.ClientInterface
+}
+
+// NewEventClient create a new resource change event api client.
+func NewEventClient(client rest.ClientInterface) *EventClient {
+	return &EventClient{
+		client: client,
+	}
+}
+
+// Pull resource change events.
+func (cli *EventClient) Pull(kt *kit.Kit, req *dataevent.PullReq) (*dataevent.PullResult, error) {
+	return common.Request[dataevent.PullReq, dataevent.PullResult](cli.client, rest.POST, kt, req, "/events/pull")
+}
+
+// CommitOffset commit consumed offset.
+func (cli *EventClient) CommitOffset(kt *kit.Kit, req *dataevent.OffsetCommitReq) error {
+	return common.RequestNoResp[dataevent.OffsetCommitReq](cli.client, rest.POST, kt, req, "/events/offsets/commit")
+}
+
+// ListOffset list committed offsets.
+func (cli *EventClient) ListOffset(kt *kit.Kit, req *core.ListReq) (*core.ListResultT[coreevent.ConsumerOffset],
+	error) {
+
+	return common.Request[core.ListReq, core.ListResultT[coreevent.ConsumerOffset]](cli.client, rest.POST, kt, req,
+		"/events/offsets/list")
+}
+
+// Watch resource change events after the revision, the request is blocked until there are new events or timeout.
+func (cli *EventClient) Watch(kt *kit.Kit, req *dataevent.WatchReq) (*dataevent.WatchResult, error) {
+	return common.Request[dataevent.WatchReq, dataevent.WatchResult](cli.client, rest.POST, kt, req, "/events/watch")
+}
@@ -0,0 +1,66 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	coreidle "hcm/pkg/api/core/idle-resource"
+	dataidle "hcm/pkg/api/data-service/idle-resource"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// IdleResourceClient is data service idle resource api client.
+type IdleResourceClient struct {
+	client rest.ClientInterface
+}
+
+// NewIdleResourceClient create a new idle resource api client.
+func NewIdleResourceClient(client rest.ClientInterface) *IdleResourceClient {
+	return &IdleResourceClient{
+		client: client,
+	}
+}
+
+// Detect idle resource.
+func (cli *IdleResourceClient) Detect(kt *kit.Kit, req *dataidle.IdleDetectReq) (*dataidle.IdleDetectResult, error) {
+	return common.Request[dataidle.IdleDetectReq, dataidle.IdleDetectResult](cli.client, rest.POST, kt, req,
+		"/idle_resources/detect")
+}
+
+// List idle resource.
+func (cli *IdleResourceClient) List(kt *kit.Kit, req *core.ListReq) (*core.ListResultT[coreidle.IdleResource],
+	error) {
+
+	return common.Request[core.ListReq, core.ListResultT[coreidle.IdleResource]](cli.client, rest.POST, kt, req,
+		"/idle_resources/list")
+}
+
+// BatchUpdateStatus batch update idle resource status.
+func (cli *IdleResourceClient) BatchUpdateStatus(kt *kit.Kit, req *dataidle.IdleStatusUpdateReq) error {
+	return common.RequestNoResp[dataidle.IdleStatusUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/idle_resources/status/batch")
+}
+
+// BatchDelete idle resource.
+func (cli *IdleResourceClient) BatchDelete(kt *kit.Kit, req *core.BatchDeleteReq) error {
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req, "/idle_resources/batch")
+}
@@ -0,0 +1,66 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	dataindex "hcm/pkg/api/data-service/index"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// IndexClient is data service table index management api client.
+type IndexClient struct {
+	client rest.ClientInterface
+}
+
+// NewIndexClient create a new table index management api client.
+func NewIndexClient(client rest.ClientInterface) *IndexClient {
+	return &IndexClient{
+		client: client,
+	}
+}
+
+// Advise report the slow filters and the missing indexes.
+func (c *IndexClient) Advise(kt *kit.Kit, req *dataindex.AdviseReq) (*dataindex.AdviseResult, error) {
+	return common.Request[dataindex.AdviseReq, dataindex.AdviseResult](
+		c.client, rest.POST, kt, req, "/indexes/advise")
+}
+
+// ResetQueryStats clear the captured query stats.
+func (c *IndexClient) ResetQueryStats(kt *kit.Kit) error {
+	return common.RequestNoResp[common.Empty](c.client, rest.POST, kt, new(common.Empty),
+		"/indexes/query_stats/reset")
+}
+
+// List indexes of the table.
+func (c *IndexClient) List(kt *kit.Kit, req *dataindex.ListReq) (*dataindex.ListResult, error) {
+	return common.Request[dataindex.ListReq, dataindex.ListResult](c.client, rest.POST, kt, req, "/indexes/list")
+}
+
+// Create secondary index on the table.
+func (c *IndexClient) Create(kt *kit.Kit, req *dataindex.CreateReq) error {
+	return common.RequestNoResp[dataindex.CreateReq](c.client, rest.POST, kt, req, "/indexes/create")
+}
+
+// Drop secondary index of the table.
+func (c *IndexClient) Drop(kt *kit.Kit, req *dataindex.DropReq) error {
+	return common.RequestNoResp[dataindex.DropReq](c.client, rest.POST, kt, req, "/indexes/drop")
+}
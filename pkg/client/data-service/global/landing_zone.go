@@ -0,0 +1,63 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	corelz "hcm/pkg/api/core/landing-zone"
+	datalz "hcm/pkg/api/data-service/landing-zone"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// LandingZoneDeploymentClient is data service landing zone deployment api client.
+type LandingZoneDeploymentClient struct {
+	client rest.ClientInterface
+}
+
+// NewLandingZoneDeploymentClient create a new landing zone deployment api client.
+func NewLandingZoneDeploymentClient(client rest.ClientInterface) *LandingZoneDeploymentClient {
+	return &LandingZoneDeploymentClient{
+		client: client,
+	}
+}
+
+// Create landing zone deployment.
+func (cli *LandingZoneDeploymentClient) Create(kt *kit.Kit, req *datalz.DeploymentCreateReq) (*core.CreateResult,
+	error) {
+
+	return common.Request[datalz.DeploymentCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/landing_zone_deployments/create")
+}
+
+// Update landing zone deployment.
+func (cli *LandingZoneDeploymentClient) Update(kt *kit.Kit, id string, req *datalz.DeploymentUpdateReq) error {
+	return common.RequestNoResp[datalz.DeploymentUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/landing_zone_deployments/%s", id)
+}
+
+// List landing zone deployment.
+func (cli *LandingZoneDeploymentClient) List(kt *kit.Kit, req *core.ListReq) (
+	*core.ListResultT[corelz.Deployment], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[corelz.Deployment]](cli.client, rest.POST, kt, req,
+		"/landing_zone_deployments/list")
+}
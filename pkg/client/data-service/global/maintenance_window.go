@@ -0,0 +1,68 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	coremw "hcm/pkg/api/core/maintenance-window"
+	datamw "hcm/pkg/api/data-service/maintenance-window"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// MaintenanceWindowClient is data service maintenance window api client.
+type MaintenanceWindowClient struct {
+	client rest.ClientInterface
+}
+
+// NewMaintenanceWindowClient create a new maintenance window api client.
+func NewMaintenanceWindowClient(client rest.ClientInterface) *MaintenanceWindowClient {
+	return &MaintenanceWindowClient{
+		client: client,
+	}
+}
+
+// Create maintenance window.
+func (cli *MaintenanceWindowClient) Create(kt *kit.Kit, req *datamw.MaintenanceWindowCreateReq) (
+	*core.CreateResult, error) {
+
+	return common.Request[datamw.MaintenanceWindowCreateReq, core.CreateResult](cli.client, rest.POST, kt,
+		req, "/maintenance_windows/create")
+}
+
+// Update maintenance window.
+func (cli *MaintenanceWindowClient) Update(kt *kit.Kit, id string, req *datamw.MaintenanceWindowUpdateReq) error {
+	return common.RequestNoResp[datamw.MaintenanceWindowUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/maintenance_windows/%s", id)
+}
+
+// List maintenance window.
+func (cli *MaintenanceWindowClient) List(kt *kit.Kit, req *core.ListReq) (
+	*core.ListResultT[coremw.MaintenanceWindow], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[coremw.MaintenanceWindow]](cli.client, rest.POST,
+		kt, req, "/maintenance_windows/list")
+}
+
+// BatchDelete maintenance window.
+func (cli *MaintenanceWindowClient) BatchDelete(kt *kit.Kit, req *core.BatchDeleteReq) error {
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req, "/maintenance_windows/batch")
+}
@@ -0,0 +1,80 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	corenaming "hcm/pkg/api/core/naming"
+	datanaming "hcm/pkg/api/data-service/naming"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NamingRuleClient is data service resource naming rule api client.
+type NamingRuleClient struct {
+	client rest.ClientInterface
+}
+
+// NewNamingRuleClient create a new resource naming rule api client.
+func NewNamingRuleClient(client rest.ClientInterface) *NamingRuleClient {
+	return &NamingRuleClient{
+		client: client,
+	}
+}
+
+// Create naming rule.
+func (cli *NamingRuleClient) Create(kt *kit.Kit, req *datanaming.NamingRuleCreateReq) (*core.CreateResult, error) {
+	return common.Request[datanaming.NamingRuleCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/naming_rules/create")
+}
+
+// Update naming rule.
+func (cli *NamingRuleClient) Update(kt *kit.Kit, id string, req *datanaming.NamingRuleUpdateReq) error {
+	return common.RequestNoResp[datanaming.NamingRuleUpdateReq](cli.client, rest.PATCH, kt, req, "/naming_rules/%s",
+		id)
+}
+
+// List naming rule.
+func (cli *NamingRuleClient) List(kt *kit.Kit, req *core.ListReq) (*core.ListResultT[corenaming.NamingRule], error) {
+	return common.Request[core.ListReq, core.ListResultT[corenaming.NamingRule]](cli.client, rest.POST, kt, req,
+		"/naming_rules/list")
+}
+
+// BatchDelete naming rule.
+func (cli *NamingRuleClient) BatchDelete(kt *kit.Kit, req *core.BatchDeleteReq) error {
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req, "/naming_rules/batch")
+}
+
+// CheckViolation check naming violation of account resources.
+func (cli *NamingRuleClient) CheckViolation(kt *kit.Kit, req *datanaming.NamingViolationCheckReq) (
+	*datanaming.NamingViolationCheckResult, error) {
+
+	return common.Request[datanaming.NamingViolationCheckReq, datanaming.NamingViolationCheckResult](cli.client,
+		rest.POST, kt, req, "/naming_violations/check")
+}
+
+// ListViolation list naming violation.
+func (cli *NamingRuleClient) ListViolation(kt *kit.Kit, req *core.ListReq) (
+	*core.ListResultT[corenaming.NamingViolation], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[corenaming.NamingViolation]](cli.client, rest.POST, kt, req,
+		"/naming_violations/list")
+}
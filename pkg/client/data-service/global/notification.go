@@ -0,0 +1,99 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	corenotification "hcm/pkg/api/core/notification"
+	datanotification "hcm/pkg/api/data-service/notification"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NotificationClient is data service notification api client.
+type NotificationClient struct {
+	client rest.ClientInterface
+}
+
+// NewNotificationClient create a new notification api client.
+func NewNotificationClient(client rest.ClientInterface) *NotificationClient {
+	return &NotificationClient{
+		client: client,
+	}
+}
+
+// CreateTemplate create notification template.
+func (cli *NotificationClient) CreateTemplate(kt *kit.Kit, req *datanotification.TemplateCreateReq) (
+	*core.CreateResult, error) {
+
+	return common.Request[datanotification.TemplateCreateReq, core.CreateResult](cli.client, rest.POST, kt,
+		req, "/notifications/templates/create")
+}
+
+// UpdateTemplate update notification template.
+func (cli *NotificationClient) UpdateTemplate(kt *kit.Kit, id string, req *datanotification.TemplateUpdateReq) error {
+	return common.RequestNoResp[datanotification.TemplateUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/notifications/templates/%s", id)
+}
+
+// ListTemplate list notification template.
+func (cli *NotificationClient) ListTemplate(kt *kit.Kit, req *core.ListReq) (
+	*core.ListResultT[corenotification.Template], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[corenotification.Template]](cli.client, rest.POST,
+		kt, req, "/notifications/templates/list")
+}
+
+// BatchDeleteTemplate batch delete notification template.
+func (cli *NotificationClient) BatchDeleteTemplate(kt *kit.Kit, req *core.BatchDeleteReq) error {
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req,
+		"/notifications/templates/batch")
+}
+
+// CreateSubscription create notification subscription.
+func (cli *NotificationClient) CreateSubscription(kt *kit.Kit, req *datanotification.SubscriptionCreateReq) (
+	*core.CreateResult, error) {
+
+	return common.Request[datanotification.SubscriptionCreateReq, core.CreateResult](cli.client, rest.POST, kt,
+		req, "/notifications/subscriptions/create")
+}
+
+// UpdateSubscription update notification subscription.
+func (cli *NotificationClient) UpdateSubscription(kt *kit.Kit, id string,
+	req *datanotification.SubscriptionUpdateReq) error {
+
+	return common.RequestNoResp[datanotification.SubscriptionUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/notifications/subscriptions/%s", id)
+}
+
+// ListSubscription list notification subscription.
+func (cli *NotificationClient) ListSubscription(kt *kit.Kit, req *core.ListReq) (
+	*core.ListResultT[corenotification.Subscription], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[corenotification.Subscription]](cli.client, rest.POST,
+		kt, req, "/notifications/subscriptions/list")
+}
+
+// BatchDeleteSubscription batch delete notification subscription.
+func (cli *NotificationClient) BatchDeleteSubscription(kt *kit.Kit, req *core.BatchDeleteReq) error {
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req,
+		"/notifications/subscriptions/batch")
+}
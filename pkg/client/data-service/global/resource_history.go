@@ -0,0 +1,54 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	datareshistory "hcm/pkg/api/data-service/resource-history"
+	"hcm/pkg/client/common"
+	tablereshistory "hcm/pkg/dal/table/resource-history"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// ResourceHistoryClient is data service resource history api client.
+type ResourceHistoryClient struct {
+	client rest.ClientInterface
+}
+
+// NewResourceHistoryClient create a new resource history api client.
+func NewResourceHistoryClient(client rest.ClientInterface) *ResourceHistoryClient {
+	return &ResourceHistoryClient{
+		client: client,
+	}
+}
+
+// List resource histories.
+func (r *ResourceHistoryClient) List(kt *kit.Kit, req *datareshistory.ListReq) (*datareshistory.ListResp, error) {
+	return common.Request[datareshistory.ListReq, datareshistory.ListResp](
+		r.client, rest.POST, kt, req, "/resource_histories/list")
+}
+
+// GetAsOf get the version of resource at the given time.
+func (r *ResourceHistoryClient) GetAsOf(kt *kit.Kit, req *datareshistory.GetAsOfReq) (
+	*tablereshistory.ResourceHistoryTable, error) {
+
+	return common.Request[datareshistory.GetAsOfReq, tablereshistory.ResourceHistoryTable](
+		r.client, rest.POST, kt, req, "/resource_histories/as_of")
+}
@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	datarelation "hcm/pkg/api/data-service/resource-relation"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// ResourceRelationClient is data service resource relation api client.
+type ResourceRelationClient struct {
+	client rest.ClientInterface
+}
+
+// NewResourceRelationClient create a new resource relation api client.
+func NewResourceRelationClient(client rest.ClientInterface) *ResourceRelationClient {
+	return &ResourceRelationClient{
+		client: client,
+	}
+}
+
+// List resource relations.
+func (r *ResourceRelationClient) List(kt *kit.Kit, req *datarelation.ListReq) (*datarelation.ListResp, error) {
+	return common.Request[datarelation.ListReq, datarelation.ListResp](
+		r.client, rest.POST, kt, req, "/resource_relations/list")
+}
+
+// GetGraph get the relation graph starting from the resource.
+func (r *ResourceRelationClient) GetGraph(kt *kit.Kit, req *datarelation.GraphReq) (*datarelation.GraphResp,
+	error) {
+
+	return common.Request[datarelation.GraphReq, datarelation.GraphResp](
+		r.client, rest.POST, kt, req, "/resource_relations/graph")
+}
+
+// GetVpcTopology get the network topology of the vpc.
+func (r *ResourceRelationClient) GetVpcTopology(kt *kit.Kit, req *datarelation.VpcTopologyReq) (
+	*datarelation.VpcTopologyResp, error) {
+
+	return common.Request[datarelation.VpcTopologyReq, datarelation.VpcTopologyResp](
+		r.client, rest.POST, kt, req, "/resource_relations/vpc_topology")
+}
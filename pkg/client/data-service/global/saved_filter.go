@@ -0,0 +1,68 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	coresavedfilter "hcm/pkg/api/core/saved-filter"
+	datasavedfilter "hcm/pkg/api/data-service/saved-filter"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// SavedFilterClient is data service saved filter api client.
+type SavedFilterClient struct {
+	client rest.ClientInterface
+}
+
+// NewSavedFilterClient create a new saved filter api client.
+func NewSavedFilterClient(client rest.ClientInterface) *SavedFilterClient {
+	return &SavedFilterClient{
+		client: client,
+	}
+}
+
+// Create saved filter.
+func (cli *SavedFilterClient) Create(kt *kit.Kit, req *datasavedfilter.SavedFilterCreateReq) (
+	*core.CreateResult, error) {
+
+	return common.Request[datasavedfilter.SavedFilterCreateReq, core.CreateResult](cli.client, rest.POST, kt,
+		req, "/saved_filters/create")
+}
+
+// Update saved filter.
+func (cli *SavedFilterClient) Update(kt *kit.Kit, id string, req *datasavedfilter.SavedFilterUpdateReq) error {
+	return common.RequestNoResp[datasavedfilter.SavedFilterUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/saved_filters/%s", id)
+}
+
+// List saved filter.
+func (cli *SavedFilterClient) List(kt *kit.Kit, req *core.ListReq) (
+	*core.ListResultT[coresavedfilter.SavedFilter], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[coresavedfilter.SavedFilter]](cli.client, rest.POST,
+		kt, req, "/saved_filters/list")
+}
+
+// BatchDelete saved filter.
+func (cli *SavedFilterClient) BatchDelete(kt *kit.Kit, req *core.BatchDeleteReq) error {
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req, "/saved_filters/batch")
+}
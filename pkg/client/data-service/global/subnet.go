@@ -24,7 +24,6 @@ import (
 	"net/http"
 
 	"hcm/pkg/api/core"
-	dataservice "hcm/pkg/api/data-service"
 	protocloud "hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/rest"
@@ -91,7 +90,7 @@ func (v *SubnetClient) BatchUpdateBaseInfo(ctx context.Context, h http.Header,
 }
 
 // BatchDelete subnet.
-func (v *SubnetClient) BatchDelete(ctx context.Context, h http.Header, req *dataservice.BatchDeleteReq) error {
+func (v *SubnetClient) BatchDelete(ctx context.Context, h http.Header, req *protocloud.SubnetBatchDeleteReq) error {
 	resp := new(rest.BaseResp)
 
 	err := v.client.Delete().
@@ -0,0 +1,90 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	coretagpolicy "hcm/pkg/api/core/tag-policy"
+	datatagpolicy "hcm/pkg/api/data-service/tag-policy"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// TagPolicyClient is data service tag policy api client.
+type TagPolicyClient struct {
+	client rest.ClientInterface
+}
+
+// NewTagPolicyClient create a new tag policy api client.
+func NewTagPolicyClient(client rest.ClientInterface) *TagPolicyClient {
+	return &TagPolicyClient{
+		client: client,
+	}
+}
+
+// Create tag policy.
+func (cli *TagPolicyClient) Create(kt *kit.Kit, req *datatagpolicy.TagPolicyCreateReq) (*core.CreateResult, error) {
+	return common.Request[datatagpolicy.TagPolicyCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/tag_policies/create")
+}
+
+// Update tag policy.
+func (cli *TagPolicyClient) Update(kt *kit.Kit, id string, req *datatagpolicy.TagPolicyUpdateReq) error {
+	return common.RequestNoResp[datatagpolicy.TagPolicyUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/tag_policies/%s", id)
+}
+
+// List tag policy.
+func (cli *TagPolicyClient) List(kt *kit.Kit, req *core.ListReq) (*core.ListResultT[coretagpolicy.TagPolicy],
+	error) {
+
+	return common.Request[core.ListReq, core.ListResultT[coretagpolicy.TagPolicy]](cli.client, rest.POST, kt, req,
+		"/tag_policies/list")
+}
+
+// BatchDelete tag policy.
+func (cli *TagPolicyClient) BatchDelete(kt *kit.Kit, req *core.BatchDeleteReq) error {
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req, "/tag_policies/batch")
+}
+
+// CheckViolation check tag violation of account resources.
+func (cli *TagPolicyClient) CheckViolation(kt *kit.Kit, req *datatagpolicy.TagViolationCheckReq) (
+	*datatagpolicy.TagViolationCheckResult, error) {
+
+	return common.Request[datatagpolicy.TagViolationCheckReq, datatagpolicy.TagViolationCheckResult](cli.client,
+		rest.POST, kt, req, "/tag_violations/check")
+}
+
+// ListViolation list tag violation.
+func (cli *TagPolicyClient) ListViolation(kt *kit.Kit, req *core.ListReq) (
+	*core.ListResultT[coretagpolicy.TagViolation], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[coretagpolicy.TagViolation]](cli.client, rest.POST, kt,
+		req, "/tag_violations/list")
+}
+
+// GetComplianceReport get tag compliance report of accounts.
+func (cli *TagPolicyClient) GetComplianceReport(kt *kit.Kit, req *datatagpolicy.TagComplianceReportReq) (
+	*datatagpolicy.TagComplianceReportResult, error) {
+
+	return common.Request[datatagpolicy.TagComplianceReportReq, datatagpolicy.TagComplianceReportResult](
+		cli.client, rest.POST, kt, req, "/tag_violations/compliance_report")
+}
@@ -0,0 +1,45 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	datatenant "hcm/pkg/api/data-service/tenant"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// TenantClient is data service tenant api client.
+type TenantClient struct {
+	client rest.ClientInterface
+}
+
+// NewTenantClient create a new tenant api client.
+func NewTenantClient(client rest.ClientInterface) *TenantClient {
+	return &TenantClient{
+		client: client,
+	}
+}
+
+// ListIDs list the tenant ids which own accounts.
+func (c *TenantClient) ListIDs(kt *kit.Kit) (*datatenant.ListIDsResult, error) {
+	return common.Request[common.Empty, datatenant.ListIDsResult](c.client, rest.POST, kt, common.NoData,
+		"/tenants/ids/list")
+}
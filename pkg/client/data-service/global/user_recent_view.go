@@ -0,0 +1,59 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dsuser "hcm/pkg/api/data-service/user"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// UserRecentViewClient is data service user recent view api client.
+type UserRecentViewClient struct {
+	client rest.ClientInterface
+}
+
+// NewUserRecentViewClient create a new user recent view api client.
+func NewUserRecentViewClient(client rest.ClientInterface) *UserRecentViewClient {
+	return &UserRecentViewClient{
+		client: client,
+	}
+}
+
+// Record the resource viewed by current user.
+func (cli *UserRecentViewClient) Record(kt *kit.Kit, req *dsuser.UserRecentViewRecordReq) error {
+	return common.RequestNoResp[dsuser.UserRecentViewRecordReq](cli.client, rest.POST, kt, req,
+		"/users/recent_views/record")
+}
+
+// List user recent view.
+func (cli *UserRecentViewClient) List(kt *kit.Kit, req *core.ListReq) (*dsuser.UserRecentViewListResult, error) {
+	return common.Request[core.ListReq, dsuser.UserRecentViewListResult](cli.client, rest.POST, kt, req,
+		"/users/recent_views/list")
+}
+
+// BatchDelete user recent view.
+func (cli *UserRecentViewClient) BatchDelete(kt *kit.Kit, req *dataservice.BatchDeleteReq) error {
+	return common.RequestNoResp[dataservice.BatchDeleteReq](cli.client, rest.DELETE, kt, req,
+		"/users/recent_views/batch")
+}
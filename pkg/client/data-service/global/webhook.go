@@ -0,0 +1,89 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	corewebhook "hcm/pkg/api/core/webhook"
+	datawebhook "hcm/pkg/api/data-service/webhook"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// WebhookClient is data service webhook api client.
+type WebhookClient struct {
+	client rest.ClientInterface
+}
+
+// NewWebhookClient create a new webhook api client.
+func NewWebhookClient(client rest.ClientInterface) *WebhookClient {
+	return &WebhookClient{
+		client: client,
+	}
+}
+
+// CreateSubscription create webhook subscription.
+func (cli *WebhookClient) CreateSubscription(kt *kit.Kit, req *datawebhook.SubscriptionCreateReq) (
+	*core.CreateResult, error) {
+
+	return common.Request[datawebhook.SubscriptionCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/webhooks/subscriptions/create")
+}
+
+// UpdateSubscription update webhook subscription.
+func (cli *WebhookClient) UpdateSubscription(kt *kit.Kit, id string, req *datawebhook.SubscriptionUpdateReq) error {
+	return common.RequestNoResp[datawebhook.SubscriptionUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/webhooks/subscriptions/%s", id)
+}
+
+// ListSubscription list webhook subscription.
+func (cli *WebhookClient) ListSubscription(kt *kit.Kit, req *core.ListReq) (
+	*core.ListResultT[corewebhook.Subscription], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[corewebhook.Subscription]](cli.client, rest.POST, kt,
+		req, "/webhooks/subscriptions/list")
+}
+
+// BatchDeleteSubscription batch delete webhook subscription.
+func (cli *WebhookClient) BatchDeleteSubscription(kt *kit.Kit, req *core.BatchDeleteReq) error {
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req,
+		"/webhooks/subscriptions/batch")
+}
+
+// BatchCreateDelivery record the events to be delivered to the webhook subscription.
+func (cli *WebhookClient) BatchCreateDelivery(kt *kit.Kit, req *datawebhook.DeliveryBatchCreateReq) error {
+	return common.RequestNoResp[datawebhook.DeliveryBatchCreateReq](cli.client, rest.POST, kt, req,
+		"/webhooks/deliveries/batch/create")
+}
+
+// UpdateDelivery update webhook delivery state.
+func (cli *WebhookClient) UpdateDelivery(kt *kit.Kit, req *datawebhook.DeliveryUpdateReq) error {
+	return common.RequestNoResp[datawebhook.DeliveryUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/webhooks/deliveries/update")
+}
+
+// ListDelivery list webhook delivery.
+func (cli *WebhookClient) ListDelivery(kt *kit.Kit, req *core.ListReq) (*core.ListResultT[corewebhook.Delivery],
+	error) {
+
+	return common.Request[core.ListReq, core.ListResultT[corewebhook.Delivery]](cli.client, rest.POST, kt, req,
+		"/webhooks/deliveries/list")
+}
@@ -0,0 +1,43 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/cloud/zone"
+	protocloud "hcm/pkg/api/data-service/cloud/zone"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// ListZoneCapability list zone capability.
+func (cli *ZoneClient) ListZoneCapability(kt *kit.Kit, req *core.ListReq) (
+	*core.ListResultT[zone.ZoneCapability], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[zone.ZoneCapability]](cli.client, rest.POST, kt, req,
+		"/zone_capabilities/list")
+}
+
+// ReplaceZoneCapability replace all zone capabilities of the given regions.
+func (cli *ZoneClient) ReplaceZoneCapability(kt *kit.Kit, req *protocloud.ZoneCapabilityReplaceReq) error {
+	return common.RequestNoResp[protocloud.ZoneCapabilityReplaceReq](cli.client, rest.POST, kt, req,
+		"/zone_capabilities/replace")
+}
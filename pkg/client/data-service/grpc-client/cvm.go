@@ -0,0 +1,81 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package grpcclient is the data-service grpc api client of the hot paths, it has the same request and response with
+// the restful api client, so that the restful api client can switch to grpc transparently.
+package grpcclient
+
+import (
+	"context"
+	"net/http"
+
+	"hcm/pkg/api/core"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/api/data-service/grpc/pb"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/rpc"
+)
+
+// BatchCreateCvm batch create cvm.
+func BatchCreateCvm[T corecvm.Extension](ctx context.Context, h http.Header, cli pb.CvmServiceClient,
+	vendor enumor.Vendor, request *protocloud.CvmBatchCreateReq[T]) (*core.BatchCreateResult, error) {
+
+	in, err := request.ToPb(vendor)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := cli.BatchCreateCvm(rpc.NewOutgoingContext(ctx, h), in)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.BatchCreateResult{IDs: result.Ids}, nil
+}
+
+// BatchUpdateCvm batch update cvm.
+func BatchUpdateCvm[T corecvm.Extension](ctx context.Context, h http.Header, cli pb.CvmServiceClient,
+	vendor enumor.Vendor, request *protocloud.CvmBatchUpdateReq[T]) error {
+
+	in, err := request.ToPb(vendor)
+	if err != nil {
+		return err
+	}
+
+	_, err = cli.BatchUpdateCvm(rpc.NewOutgoingContext(ctx, h), in)
+	return err
+}
+
+// ListCvmExt list cvm with extension.
+func ListCvmExt[T corecvm.Extension](ctx context.Context, h http.Header, cli pb.CvmServiceClient,
+	vendor enumor.Vendor, request *protocloud.CvmListReq) (*protocloud.CvmExtListResult[T], error) {
+
+	in, err := request.ToPb(vendor)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := cli.ListCvmExt(rpc.NewOutgoingContext(ctx, h), in)
+	if err != nil {
+		return nil, err
+	}
+
+	return protocloud.CvmExtListResultFromPb[T](result)
+}
@@ -0,0 +1,64 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"net/http"
+
+	natgateway "hcm/pkg/adaptor/types/nat-gateway"
+	hcnat "hcm/pkg/api/hc-service/nat-gateway"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewNatGatewayClient create a new nat gateway api client.
+func NewNatGatewayClient(client rest.ClientInterface) *NatGatewayClient {
+	return &NatGatewayClient{
+		client: client,
+	}
+}
+
+// NatGatewayClient is hc service tencent cloud nat gateway api client.
+type NatGatewayClient struct {
+	client rest.ClientInterface
+}
+
+// Create nat gateway, the returned nat gateway may be still pending.
+func (c *NatGatewayClient) Create(kt *kit.Kit, req *hcnat.TCloudNatGatewayCreateReq) (*natgateway.TCloudNatGateway,
+	error) {
+
+	return common.Request[hcnat.TCloudNatGatewayCreateReq, natgateway.TCloudNatGateway](c.client, http.MethodPost,
+		kt, req, "/nat_gateways/create")
+}
+
+// List nat gateway by cloud ids.
+func (c *NatGatewayClient) List(kt *kit.Kit, req *hcnat.TCloudNatGatewayListReq) (*hcnat.TCloudNatGatewayListResult,
+	error) {
+
+	return common.Request[hcnat.TCloudNatGatewayListReq, hcnat.TCloudNatGatewayListResult](c.client,
+		http.MethodPost, kt, req, "/nat_gateways/list")
+}
+
+// Delete nat gateway and release the given eips.
+func (c *NatGatewayClient) Delete(kt *kit.Kit, req *hcnat.TCloudNatGatewayDeleteReq) error {
+	return common.RequestNoResp[hcnat.TCloudNatGatewayDeleteReq](c.client, http.MethodDelete, kt, req,
+		"/nat_gateways")
+}
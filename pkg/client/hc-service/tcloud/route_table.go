@@ -23,9 +23,12 @@ import (
 	"context"
 	"net/http"
 
+	"hcm/pkg/api/core"
 	routetable "hcm/pkg/api/hc-service/route-table"
 	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/client/common"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
 	"hcm/pkg/rest"
 )
 
@@ -41,6 +44,12 @@ func NewRouteTableClient(client rest.ClientInterface) *RouteTableClient {
 	}
 }
 
+// Create route table with routes.
+func (r *RouteTableClient) Create(kt *kit.Kit, req *routetable.TCloudRouteTableCreateReq) (*core.CreateResult, error) {
+	return common.Request[routetable.TCloudRouteTableCreateReq, core.CreateResult](r.client, http.MethodPost, kt, req,
+		"/route_tables/create")
+}
+
 // Update route table.
 func (r *RouteTableClient) Update(ctx context.Context, h http.Header, id string,
 	req *routetable.RouteTableUpdateReq) error {
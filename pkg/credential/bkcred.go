@@ -0,0 +1,86 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package credential
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+	"hcm/pkg/rest/client"
+	apigateway "hcm/pkg/thirdparty/api-gateway"
+	"hcm/pkg/tools/ssl"
+)
+
+// bkCredentialProvider 通过蓝鲸API网关获取蓝鲸凭据管理中的凭据，凭据没有租约
+type bkCredentialProvider struct {
+	config *cc.ApiGateway
+	client rest.ClientInterface
+}
+
+func newBkCredentialProvider(opt cc.ApiGateway) (*bkCredentialProvider, error) {
+	cli, err := client.NewClient(&ssl.TLSConfig{
+		InsecureSkipVerify: opt.TLS.InsecureSkipVerify,
+		CertFile:           opt.TLS.CertFile,
+		KeyFile:            opt.TLS.KeyFile,
+		CAFile:             opt.TLS.CAFile,
+		Password:           opt.TLS.Password,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c := &client.Capability{
+		Client: cli,
+		Discover: &apigateway.Discovery{
+			Name:    "bk-credential",
+			Servers: opt.Endpoints,
+		},
+	}
+
+	return &bkCredentialProvider{config: &opt, client: rest.NewClient(c, "/api/v1")}, nil
+}
+
+type bkCredential struct {
+	Data map[string]string `json:"data"`
+}
+
+// Fetch get credential by id.
+func (b *bkCredentialProvider) Fetch(kt *kit.Kit, path string) (*Secret, error) {
+	result, err := apigateway.ApiGatewayCallWithoutReq[bkCredential](b.client, b.config, rest.GET, kt, nil,
+		"/credentials/%s/", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, fmt.Errorf("bk credential %s not found", path)
+	}
+
+	return &Secret{Data: result.Data}, nil
+}
+
+// Renew bk credential has no lease, renew is not supported.
+func (b *bkCredentialProvider) Renew(_ *kit.Kit, _ string) (time.Duration, error) {
+	return 0, errors.New("bk credential does not support lease renew")
+}
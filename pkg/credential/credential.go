@@ -0,0 +1,252 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package credential 从外部凭据管理(HashiCorp Vault、蓝鲸凭据管理)解析账号秘钥的引用，账号秘钥字段可以保存为
+// {scheme}:{path}#{key} 格式的引用，调用云厂商前解析为秘钥明文，并在本地按租约缓存。
+package credential
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+const (
+	// VaultScheme HashiCorp Vault凭据引用前缀
+	VaultScheme = "vault"
+	// BkCredentialScheme 蓝鲸凭据管理凭据引用前缀
+	BkCredentialScheme = "bkcred"
+)
+
+// Reference 外部凭据引用，格式为 {scheme}:{path}#{key}，如 vault:secret/data/hcm/account#secret_key
+type Reference struct {
+	Scheme string
+	Path   string
+	Key    string
+}
+
+// String ...
+func (r Reference) String() string {
+	return fmt.Sprintf("%s:%s#%s", r.Scheme, r.Path, r.Key)
+}
+
+// ParseReference 解析外部凭据引用，不是支持的凭据引用时返回false，此时值应当作为秘钥明文使用
+func ParseReference(value string) (Reference, bool) {
+	scheme, rest, found := strings.Cut(value, ":")
+	if !found || (scheme != VaultScheme && scheme != BkCredentialScheme) {
+		return Reference{}, false
+	}
+
+	idx := strings.LastIndex(rest, "#")
+	if idx <= 0 || idx == len(rest)-1 {
+		return Reference{}, false
+	}
+
+	return Reference{Scheme: scheme, Path: rest[:idx], Key: rest[idx+1:]}, true
+}
+
+// Secret 从外部凭据管理获取到的凭据
+type Secret struct {
+	Data map[string]string
+	// LeaseID 凭据租约ID，为空时表示凭据没有租约
+	LeaseID string
+	// LeaseDuration 凭据租约时长，为0时表示凭据没有租约
+	LeaseDuration time.Duration
+	// Renewable 凭据租约是否可以续期
+	Renewable bool
+}
+
+// Provider 外部凭据管理
+type Provider interface {
+	// Fetch 获取指定路径的凭据
+	Fetch(kt *kit.Kit, path string) (*Secret, error)
+	// Renew 续期凭据租约，返回续期后的租约时长
+	Renew(kt *kit.Kit, leaseID string) (time.Duration, error)
+}
+
+// Auditor 记录账号秘钥的访问，从外部凭据管理获取账号的凭据时调用
+type Auditor func(kt *kit.Kit, accountID string, ref Reference)
+
+type cachedSecret struct {
+	secret *Secret
+	// renewAt 租约续期时间，为零值时表示凭据不需要续期
+	renewAt  time.Time
+	expireAt time.Time
+}
+
+// Resolver 解析外部凭据引用，并缓存获取到的凭据。
+// 可续期的凭据(如动态生成的临时秘钥)在租约时长的2/3时续期，续期失败时重新获取；
+// 其余凭据缓存 min(ttl, 租约时长) 后重新获取，以感知凭据的轮转。
+type Resolver struct {
+	providers map[string]Provider
+	ttl       time.Duration
+	auditor   Auditor
+
+	lock  sync.Mutex
+	cache map[string]*cachedSecret
+}
+
+// NewResolver new resolver, auditor can be nil.
+func NewResolver(providers map[string]Provider, ttl time.Duration, auditor Auditor) *Resolver {
+	return &Resolver{
+		providers: providers,
+		ttl:       ttl,
+		auditor:   auditor,
+		cache:     make(map[string]*cachedSecret),
+	}
+}
+
+// Resolve 解析账号秘钥字段，不是凭据引用时原样返回。accountID为空时(如根账号、一级账号)不记录访问审计。
+func (r *Resolver) Resolve(kt *kit.Kit, accountID string, value string) (string, error) {
+	ref, ok := ParseReference(value)
+	if !ok {
+		return value, nil
+	}
+
+	secret, err := r.getSecret(kt, accountID, ref)
+	if err != nil {
+		return "", err
+	}
+
+	data, exists := secret.Data[ref.Key]
+	if !exists {
+		return "", fmt.Errorf("key %s not found in credential %s:%s", ref.Key, ref.Scheme, ref.Path)
+	}
+
+	return data, nil
+}
+
+func (r *Resolver) getSecret(kt *kit.Kit, accountID string, ref Reference) (*Secret, error) {
+	provider, exists := r.providers[ref.Scheme]
+	if !exists {
+		return nil, fmt.Errorf("credential provider %s is not configured", ref.Scheme)
+	}
+
+	cacheKey := ref.Scheme + ":" + ref.Path
+	now := time.Now()
+
+	r.lock.Lock()
+	entry, exists := r.cache[cacheKey]
+	r.lock.Unlock()
+
+	if exists && now.Before(entry.expireAt) {
+		if entry.renewAt.IsZero() || now.Before(entry.renewAt) {
+			return entry.secret, nil
+		}
+
+		duration, err := provider.Renew(kt, entry.secret.LeaseID)
+		if err == nil && duration > 0 {
+			r.lock.Lock()
+			entry.renewAt = now.Add(duration * 2 / 3)
+			entry.expireAt = now.Add(duration)
+			r.lock.Unlock()
+			return entry.secret, nil
+		}
+		logs.Warnf("renew credential %s lease failed, fetch it again, err: %v, rid: %s", cacheKey, err, kt.Rid)
+	}
+
+	secret, err := provider.Fetch(kt, ref.Path)
+	if err != nil {
+		logs.Errorf("fetch credential %s failed, err: %v, rid: %s", cacheKey, err, kt.Rid)
+		return nil, err
+	}
+	logs.Infof("fetch credential %s, account: %s, rid: %s", cacheKey, accountID, kt.Rid)
+
+	if len(accountID) != 0 && r.auditor != nil {
+		r.auditor(kt, accountID, ref)
+	}
+
+	entry = &cachedSecret{secret: secret, expireAt: now.Add(r.ttl)}
+	if secret.LeaseDuration > 0 {
+		if secret.Renewable && len(secret.LeaseID) != 0 {
+			entry.renewAt = now.Add(secret.LeaseDuration * 2 / 3)
+			entry.expireAt = now.Add(secret.LeaseDuration)
+		} else if secret.LeaseDuration < r.ttl {
+			entry.expireAt = now.Add(secret.LeaseDuration)
+		}
+	}
+
+	r.lock.Lock()
+	r.cache[cacheKey] = entry
+	r.lock.Unlock()
+
+	return secret, nil
+}
+
+var resolver *Resolver
+
+// Init 根据配置初始化外部凭据管理，没有配置任何外部凭据管理时，凭据引用会解析失败
+func Init(opt cc.Credential, auditor Auditor) error {
+	providers := make(map[string]Provider)
+
+	if len(opt.Vault.Address) != 0 {
+		vault, err := newVaultProvider(opt.Vault)
+		if err != nil {
+			return fmt.Errorf("init vault credential provider failed, err: %v", err)
+		}
+		providers[VaultScheme] = vault
+	}
+
+	if len(opt.BkCredential.Endpoints) != 0 {
+		bkCred, err := newBkCredentialProvider(opt.BkCredential)
+		if err != nil {
+			return fmt.Errorf("init bk credential provider failed, err: %v", err)
+		}
+		providers[BkCredentialScheme] = bkCred
+	}
+
+	resolver = NewResolver(providers, time.Duration(opt.CacheTTLSec)*time.Second, auditor)
+	return nil
+}
+
+// Resolve 使用全局的Resolver解析账号秘钥字段，不是凭据引用时原样返回
+func Resolve(kt *kit.Kit, accountID string, value string) (string, error) {
+	if _, ok := ParseReference(value); !ok {
+		return value, nil
+	}
+
+	if resolver == nil {
+		return "", errors.New("credential provider is not initialized")
+	}
+
+	return resolver.Resolve(kt, accountID, value)
+}
+
+// ResolveFields 解析账号的多个秘钥字段，将凭据引用原地替换为秘钥明文
+func ResolveFields(kt *kit.Kit, accountID string, fields ...*string) error {
+	for _, field := range fields {
+		if field == nil {
+			continue
+		}
+
+		value, err := Resolve(kt, accountID, *field)
+		if err != nil {
+			return err
+		}
+		*field = value
+	}
+
+	return nil
+}
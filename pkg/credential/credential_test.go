@@ -0,0 +1,122 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package credential
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/kit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReference(t *testing.T) {
+	ref, ok := ParseReference("vault:secret/data/hcm/account#secret_key")
+	assert.True(t, ok)
+	assert.Equal(t, Reference{Scheme: VaultScheme, Path: "secret/data/hcm/account", Key: "secret_key"}, ref)
+
+	ref, ok = ParseReference("bkcred:hcm-account#secret_id")
+	assert.True(t, ok)
+	assert.Equal(t, Reference{Scheme: BkCredentialScheme, Path: "hcm-account", Key: "secret_id"}, ref)
+
+	for _, value := range []string{"plain-secret", "vault:no-key", "vault:path#", "other:path#key", ""} {
+		_, ok = ParseReference(value)
+		assert.False(t, ok, value)
+	}
+}
+
+func TestVaultResolve(t *testing.T) {
+	var fetched, renewed int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/approle/login":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"auth": map[string]string{"client_token": "tk"}})
+		case "/v1/secret/data/hcm/account":
+			if r.Header.Get("X-Vault-Token") != "tk" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			atomic.AddInt32(&fetched, 1)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{
+					"data":     map[string]interface{}{"secret_id": "id", "secret_key": "key"},
+					"metadata": map[string]interface{}{"version": 1},
+				},
+			})
+		case "/v1/aws/creds/hcm":
+			atomic.AddInt32(&fetched, 1)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"lease_id": "aws/creds/hcm/1", "lease_duration": 3, "renewable": true,
+				"data": map[string]interface{}{"access_key": "ak"},
+			})
+		case "/v1/sys/leases/renew":
+			atomic.AddInt32(&renewed, 1)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"lease_id": "aws/creds/hcm/1", "lease_duration": 3})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	vault, err := newVaultProvider(cc.Vault{Address: server.URL, RoleID: "role", SecretID: "secret"})
+	require.NoError(t, err)
+
+	var audited []string
+	auditor := func(kt *kit.Kit, accountID string, ref Reference) { audited = append(audited, accountID) }
+	r := NewResolver(map[string]Provider{VaultScheme: vault}, time.Minute, auditor)
+	kt := kit.New()
+
+	value, err := r.Resolve(kt, "00000001", "vault:secret/data/hcm/account#secret_key")
+	require.NoError(t, err)
+	assert.Equal(t, "key", value)
+
+	value, err = r.Resolve(kt, "00000001", "vault:secret/data/hcm/account#secret_id")
+	require.NoError(t, err)
+	assert.Equal(t, "id", value)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetched))
+	assert.Equal(t, []string{"00000001"}, audited)
+
+	_, err = r.Resolve(kt, "00000001", "vault:secret/data/hcm/account#not_exist")
+	assert.Error(t, err)
+
+	value, err = r.Resolve(kt, "", "plain")
+	require.NoError(t, err)
+	assert.Equal(t, "plain", value)
+
+	_, err = r.Resolve(kt, "", "bkcred:hcm#secret_key")
+	assert.Error(t, err)
+
+	// 可续期的凭据在租约的2/3时续期，不重新获取
+	value, err = r.Resolve(kt, "", "vault:aws/creds/hcm#access_key")
+	require.NoError(t, err)
+	assert.Equal(t, "ak", value)
+	time.Sleep(2100 * time.Millisecond)
+	_, err = r.Resolve(kt, "", "vault:aws/creds/hcm#access_key")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetched))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&renewed))
+}
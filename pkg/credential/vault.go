@@ -0,0 +1,220 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package credential
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest/client"
+	"hcm/pkg/tools/ssl"
+)
+
+// vaultProvider 通过 HashiCorp Vault HTTP API 获取凭据，支持KV v1、KV v2以及动态凭据
+type vaultProvider struct {
+	opt    cc.Vault
+	client *http.Client
+
+	lock  sync.Mutex
+	token string
+}
+
+func newVaultProvider(opt cc.Vault) (*vaultProvider, error) {
+	cli, err := client.NewClient(&ssl.TLSConfig{
+		InsecureSkipVerify: opt.TLS.InsecureSkipVerify,
+		CertFile:           opt.TLS.CertFile,
+		KeyFile:            opt.TLS.KeyFile,
+		CAFile:             opt.TLS.CAFile,
+		Password:           opt.TLS.Password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	cli.Timeout = 10 * time.Second
+
+	return &vaultProvider{opt: opt, client: cli, token: opt.Token}, nil
+}
+
+type vaultResp struct {
+	LeaseID       string                 `json:"lease_id"`
+	LeaseDuration int64                  `json:"lease_duration"`
+	Renewable     bool                   `json:"renewable"`
+	Data          map[string]interface{} `json:"data"`
+	Auth          *struct {
+		ClientToken string `json:"client_token"`
+	} `json:"auth"`
+	Errors []string `json:"errors"`
+}
+
+// Fetch read secret from vault path.
+func (v *vaultProvider) Fetch(kt *kit.Kit, path string) (*Secret, error) {
+	resp, err := v.do(kt, http.MethodGet, "/v1/"+strings.TrimPrefix(path, "/"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	data := resp.Data
+	// KV v2 的数据保存在 data.data 中
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, isKVv2 := data["metadata"]; isKVv2 {
+			data = nested
+		}
+	}
+
+	secret := &Secret{
+		Data:          make(map[string]string, len(data)),
+		LeaseID:       resp.LeaseID,
+		LeaseDuration: time.Duration(resp.LeaseDuration) * time.Second,
+		Renewable:     resp.Renewable,
+	}
+	for key, value := range data {
+		switch val := value.(type) {
+		case string:
+			secret.Data[key] = val
+		default:
+			// 非字符串的值(如gcp的json秘钥)以json格式返回
+			raw, err := json.Marshal(val)
+			if err != nil {
+				return nil, fmt.Errorf("marshal vault secret %s failed, err: %v", key, err)
+			}
+			secret.Data[key] = string(raw)
+		}
+	}
+
+	return secret, nil
+}
+
+// Renew renew vault lease.
+func (v *vaultProvider) Renew(kt *kit.Kit, leaseID string) (time.Duration, error) {
+	resp, err := v.do(kt, http.MethodPut, "/v1/sys/leases/renew", map[string]string{"lease_id": leaseID})
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(resp.LeaseDuration) * time.Second, nil
+}
+
+// do 请求vault，使用AppRole认证时，token为空或已失效会重新登录后重试一次
+func (v *vaultProvider) do(kt *kit.Kit, method, path string, body interface{}) (*vaultResp, error) {
+	token, err := v.getToken(kt, false)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, status, err := v.request(kt, method, path, token, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == http.StatusForbidden && len(v.opt.RoleID) != 0 {
+		if token, err = v.getToken(kt, true); err != nil {
+			return nil, err
+		}
+
+		if resp, status, err = v.request(kt, method, path, token, body); err != nil {
+			return nil, err
+		}
+	}
+
+	if status < 200 || status >= 300 {
+		return nil, fmt.Errorf("vault %s %s failed, status: %d, errors: %v", method, path, status, resp.Errors)
+	}
+
+	return resp, nil
+}
+
+func (v *vaultProvider) getToken(kt *kit.Kit, relogin bool) (string, error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if len(v.token) != 0 && !relogin {
+		return v.token, nil
+	}
+
+	if len(v.opt.RoleID) == 0 {
+		return v.token, nil
+	}
+
+	req := map[string]string{"role_id": v.opt.RoleID, "secret_id": v.opt.SecretID}
+	resp, status, err := v.request(kt, http.MethodPost, "/v1/auth/approle/login", "", req)
+	if err != nil {
+		return "", err
+	}
+
+	if status != http.StatusOK || resp.Auth == nil || len(resp.Auth.ClientToken) == 0 {
+		return "", fmt.Errorf("vault approle login failed, status: %d, errors: %v", status, resp.Errors)
+	}
+
+	v.token = resp.Auth.ClientToken
+	return v.token, nil
+}
+
+func (v *vaultProvider) request(kt *kit.Kit, method, path, token string, body interface{}) (*vaultResp, int,
+	error) {
+
+	var reader io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return nil, 0, err
+		}
+		reader = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(kt.Ctx, method, strings.TrimSuffix(v.opt.Address, "/")+path, reader)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(token) != 0 {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if len(v.opt.Namespace) != 0 {
+		req.Header.Set("X-Vault-Namespace", v.opt.Namespace)
+	}
+
+	httpResp, err := v.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("request vault %s failed, err: %v", path, err)
+	}
+	defer httpResp.Body.Close()
+
+	resp := new(vaultResp)
+	raw, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(raw) != 0 {
+		if err = json.Unmarshal(raw, resp); err != nil {
+			return nil, 0, fmt.Errorf("unmarshal vault response failed, status: %d, err: %v", httpResp.StatusCode,
+				err)
+		}
+	}
+
+	return resp, httpResp.StatusCode, nil
+}
@@ -46,6 +46,29 @@ const (
 
 	// BKGWAuthKey is blueking api gateway authorization header key.
 	BKGWAuthKey = "X-Bkapi-Authorization"
+
+	// ReadPrimaryKey is blueking hcm header key, which forces the db read requests to be routed to primary database.
+	ReadPrimaryKey = "X-Bkhcm-Read-Primary"
+
+	// SQLTraceKey is blueking hcm header key, which enables logging every sql statement executed for the request
+	// with its args and latency, so that a single request can be traced without enabling global debug log.
+	SQLTraceKey = "X-Bkhcm-Sql-Trace"
+
+	// TimeoutKey is blueking hcm header key, which carries the remaining time in millisecond of the caller's
+	// deadline, the callee stops processing the request when it is exceeded. the remaining time is used instead
+	// of the deadline timestamp, so that it is not affected by the clock skew between the hosts.
+	TimeoutKey = "X-Bkhcm-Timeout-Ms"
+
+	// BizIDKey is blueking hcm header key, which carries the business id of the request, it is resolved from the
+	// request path of the business apis, and used to tag the cloud resources created by the request.
+	BizIDKey = "X-Bkhcm-Biz-Id"
+
+	// IdempotencyKey is the header key of mutation requests, retries of the request with the same key and body
+	// within the ttl are replied with the response of the first request instead of being executed again.
+	IdempotencyKey = "Idempotency-Key"
+
+	// IdempotentReplayedKey is the response header key, which is set to true if the response is replayed.
+	IdempotentReplayedKey = "Idempotent-Replayed"
 )
 
 const (
@@ -0,0 +1,44 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// AccountGroupMemberType is account group member account type.
+type AccountGroupMemberType string
+
+// Validate AccountGroupMemberType.
+func (t AccountGroupMemberType) Validate() error {
+	switch t {
+	case AccountGroupMemberAccount:
+	case AccountGroupMemberMainAccount:
+	default:
+		return fmt.Errorf("unsupported account group member type: %s", t)
+	}
+
+	return nil
+}
+
+const (
+	// AccountGroupMemberAccount 资源账号，用于同步、资源搜索等按资源账号划分范围的场景
+	AccountGroupMemberAccount AccountGroupMemberType = "account"
+	// AccountGroupMemberMainAccount 二级账号，用于费用报表等按二级账号划分范围的场景
+	AccountGroupMemberMainAccount AccountGroupMemberType = "main_account"
+)
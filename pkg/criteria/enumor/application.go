@@ -49,6 +49,7 @@ func (a ApplicationType) Validate() error {
 	case UpdateMainAccount:
 
 	case CreateLoadBalancer:
+	case TransferResource:
 	default:
 		return fmt.Errorf("unsupported application type: %s", a)
 	}
@@ -71,6 +72,8 @@ const (
 	UpdateMainAccount ApplicationType = "update_main_account"
 	// CreateLoadBalancer 创建负载均衡
 	CreateLoadBalancer ApplicationType = "create_load_balancer"
+	// TransferResource 资源业务间转移
+	TransferResource ApplicationType = "transfer_resource"
 
 	// CreateSecurityGroup 创建安全组
 	CreateSecurityGroup ApplicationType = "create_security_group"
@@ -113,6 +116,26 @@ const (
 	DeliverError ApplicationStatus = "deliver_error"
 )
 
+// applicationStatusTransitions 单据状态流转，审批通过后会立即进入交付中，未列出的状态为终结态；
+// 交付失败或部分交付的单据可以从失败的步骤重试进入交付中，或撤销并清理已创建的资源
+var applicationStatusTransitions = map[ApplicationStatus][]ApplicationStatus{
+	Pending:        {Pass, Rejected, Cancelled},
+	Pass:           {Delivering},
+	Delivering:     {Completed, DeliverPartial, DeliverError},
+	DeliverPartial: {Delivering, Cancelled},
+	DeliverError:   {Delivering, Cancelled},
+}
+
+// NextStatuses return the statuses that the application can transit to, empty for final status.
+func (s ApplicationStatus) NextStatuses() []ApplicationStatus {
+	next, exists := applicationStatusTransitions[s]
+	if !exists {
+		return make([]ApplicationStatus, 0)
+	}
+
+	return next
+}
+
 // ApplicationSource 单据来源
 type ApplicationSource string
 
@@ -0,0 +1,46 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplicationStatusNextStatuses(t *testing.T) {
+	cases := []struct {
+		status ApplicationStatus
+		expect []ApplicationStatus
+	}{
+		{status: Pending, expect: []ApplicationStatus{Pass, Rejected, Cancelled}},
+		{status: Pass, expect: []ApplicationStatus{Delivering}},
+		{status: Delivering, expect: []ApplicationStatus{Completed, DeliverPartial, DeliverError}},
+		{status: DeliverPartial, expect: []ApplicationStatus{Delivering, Cancelled}},
+		{status: DeliverError, expect: []ApplicationStatus{Delivering, Cancelled}},
+		{status: Completed, expect: []ApplicationStatus{}},
+		{status: Rejected, expect: []ApplicationStatus{}},
+		{status: Cancelled, expect: []ApplicationStatus{}},
+	}
+	for _, c := range cases {
+		if next := c.status.NextStatuses(); !reflect.DeepEqual(next, c.expect) {
+			t.Errorf("status %s expect next statuses %v, but got %v", c.status, c.expect, next)
+		}
+	}
+}
@@ -59,6 +59,9 @@ var defaultFlowNameMap = map[FlowName]struct{}{
 	FlowBillMainAccountSummary: {},
 	FlowBillRootAccountSummary: {},
 	FlowBillMonthTask:          {},
+	FlowLandingZoneDeploy:      {},
+	FlowLandingZoneDeployWatch: {},
+	FlowSyncAccount:            {},
 }
 
 // ValidateDefault validate default FlowName.
@@ -173,3 +176,15 @@ const (
 	FlowBillRootAccountSummary FlowName = "bill_root_account_summary"
 	FlowBillMonthTask          FlowName = "bill_month_task"
 )
+
+// 网络基线部署相关Flow
+const (
+	FlowLandingZoneDeploy      FlowName = "landing_zone_deploy"
+	FlowLandingZoneDeployWatch FlowName = "landing_zone_deploy_watch"
+)
+
+// 账号相关Flow
+const (
+	// FlowSyncAccount 账号全量资源同步
+	FlowSyncAccount FlowName = "sync_account"
+)
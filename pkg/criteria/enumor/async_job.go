@@ -0,0 +1,64 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// AsyncJobKind is async job kind, defines which table the job comes from.
+type AsyncJobKind string
+
+// Validate AsyncJobKind.
+func (k AsyncJobKind) Validate() error {
+	switch k {
+	case AsyncJobFlow:
+	case AsyncJobSync:
+	default:
+		return fmt.Errorf("unsupported async job kind: %s", k)
+	}
+
+	return nil
+}
+
+const (
+	// AsyncJobFlow 异步任务流，数据来源于 async_flow，子任务为 async_flow_task
+	AsyncJobFlow AsyncJobKind = "flow"
+	// AsyncJobSync 账号资源同步，数据来源于 account_sync_detail，子任务为账号下各资源的同步详情
+	AsyncJobSync AsyncJobKind = "sync"
+)
+
+// AsyncJobCategory is async job category.
+type AsyncJobCategory string
+
+const (
+	// AsyncJobCategorySync 资源同步
+	AsyncJobCategorySync AsyncJobCategory = "sync"
+	// AsyncJobCategoryPower 主机开关机、重启
+	AsyncJobCategoryPower AsyncJobCategory = "power"
+	// AsyncJobCategoryCreate 批量创建
+	AsyncJobCategoryCreate AsyncJobCategory = "create"
+	// AsyncJobCategoryDelete 批量删除
+	AsyncJobCategoryDelete AsyncJobCategory = "delete"
+	// AsyncJobCategoryLoadBalancer 负载均衡操作
+	AsyncJobCategoryLoadBalancer AsyncJobCategory = "load_balancer"
+	// AsyncJobCategoryBill 账单任务
+	AsyncJobCategoryBill AsyncJobCategory = "bill"
+	// AsyncJobCategoryOther 其他异步任务
+	AsyncJobCategoryOther AsyncJobCategory = "other"
+)
@@ -48,6 +48,9 @@ func (v ActionName) Validate() error {
 	case ActionBatchTaskTCloudCreateL7Rule, ActionBatchTaskTCloudBindTarget, ActionBatchTaskTCloudCreateListener,
 		ActionBatchTaskTCloudUnBindTarget, ActionBatchTaskTCloudModifyRsWeight, ActionBatchTaskDeleteListener:
 	case ActionSyncTCloudLoadBalancer, SyncTCloudLoadBalancerListener:
+	case ActionLandingZoneCreateVpc, ActionLandingZoneCreateNatGateway, ActionLandingZoneCreateRouteTable,
+		ActionLandingZoneCreateSubnet, ActionLandingZoneCreateSecurityGroup, ActionLandingZoneDeployWatch:
+	case ActionSyncAccount:
 
 	default:
 		return fmt.Errorf("unsupported action name type: %s", v)
@@ -155,3 +158,20 @@ const (
 	// SyncTCloudLoadBalancerListener ...
 	SyncTCloudLoadBalancerListener = "sync_tcloud_load_balancer_listener"
 )
+
+// 网络基线部署相关Action
+const (
+	ActionLandingZoneCreateVpc           ActionName = "landing_zone_create_vpc"
+	ActionLandingZoneCreateNatGateway    ActionName = "landing_zone_create_nat_gateway"
+	ActionLandingZoneCreateRouteTable    ActionName = "landing_zone_create_route_table"
+	ActionLandingZoneCreateSubnet        ActionName = "landing_zone_create_subnet"
+	ActionLandingZoneCreateSecurityGroup ActionName = "landing_zone_create_security_group"
+	// ActionLandingZoneDeployWatch 监听部署任务流，失败时回滚已创建的资源
+	ActionLandingZoneDeployWatch ActionName = "landing_zone_deploy_watch"
+)
+
+// 账号相关Action
+const (
+	// ActionSyncAccount 同步账号下的全部资源
+	ActionSyncAccount ActionName = "sync_account"
+)
@@ -115,6 +115,10 @@ const (
 	Bind AuditAction = "bind"
 	// Deliver 交付
 	Deliver AuditAction = "deliver"
+	// Transfer 业务间转移
+	Transfer AuditAction = "transfer"
+	// AccessSecret 从外部凭据管理获取账号秘钥
+	AccessSecret AuditAction = "access_secret"
 )
 
 // AuditActionEnums op type map.
@@ -133,6 +137,8 @@ var AuditActionEnums = map[AuditAction]struct{}{
 	Disassociate: {},
 	Bind:         {},
 	Deliver:      {},
+	Transfer:     {},
+	AccessSecret: {},
 }
 
 // Exist judge enum value exist.
@@ -149,6 +155,7 @@ const (
 	BizAuditAssignedResType       AuditAssignedResType = "biz"
 	CloudAreaAuditAssignedResType AuditAssignedResType = "cloud_area"
 	DeliverAssignedResType        AuditAssignedResType = "deliver"
+	TransferAssignedResType       AuditAssignedResType = "transfer"
 )
 
 // AuditAssignedResTypeEnums audit assigned resource type map.
@@ -156,6 +163,7 @@ var AuditAssignedResTypeEnums = map[AuditAssignedResType]struct{}{
 	BizAuditAssignedResType:       {},
 	CloudAreaAuditAssignedResType: {},
 	DeliverAssignedResType:        {},
+	TransferAssignedResType:       {},
 }
 
 // Exist judge enum value exist.
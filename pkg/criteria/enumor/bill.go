@@ -276,3 +276,69 @@ var (
 		RootAccountBillSummaryStateStop:       "停止中",
 	}
 )
+
+// BillCostDimension 账单费用汇总维度
+type BillCostDimension string
+
+// Validate the BillCostDimension is valid or not
+func (d BillCostDimension) Validate() error {
+	switch d {
+	case BillCostDimBiz, BillCostDimMainAccount, BillCostDimVendor, BillCostDimRegion, BillCostDimResType:
+	default:
+		return fmt.Errorf("unsupported bill cost dimension: %s", d)
+	}
+	return nil
+}
+
+const (
+	// BillCostDimBiz 按业务汇总
+	BillCostDimBiz BillCostDimension = "bk_biz_id"
+	// BillCostDimMainAccount 按二级账号汇总
+	BillCostDimMainAccount BillCostDimension = "main_account_id"
+	// BillCostDimVendor 按云厂商汇总
+	BillCostDimVendor BillCostDimension = "vendor"
+	// BillCostDimRegion 按地域汇总
+	BillCostDimRegion BillCostDimension = "region"
+	// BillCostDimResType 按资源类型(云服务代号)汇总
+	BillCostDimResType BillCostDimension = "hc_product_code"
+)
+
+// BudgetScopeType 预算范围类型
+type BudgetScopeType string
+
+// Validate the BudgetScopeType is valid or not
+func (s BudgetScopeType) Validate() error {
+	switch s {
+	case BudgetScopeBiz, BudgetScopeMainAccount:
+	default:
+		return fmt.Errorf("unsupported budget scope type: %s", s)
+	}
+	return nil
+}
+
+const (
+	// BudgetScopeBiz 业务预算
+	BudgetScopeBiz BudgetScopeType = "biz"
+	// BudgetScopeMainAccount 二级账号预算
+	BudgetScopeMainAccount BudgetScopeType = "main_account"
+)
+
+// BillReconcileReason 账单费用无法与HCM资源对应的原因
+type BillReconcileReason string
+
+// Validate the BillReconcileReason is valid or not
+func (r BillReconcileReason) Validate() error {
+	switch r {
+	case BillReconcileUnmanaged, BillReconcileRecycled:
+	default:
+		return fmt.Errorf("unsupported bill reconcile reason: %s", r)
+	}
+	return nil
+}
+
+const (
+	// BillReconcileUnmanaged 账单中的资源在HCM中不存在，可能是未纳管或影子资源
+	BillReconcileUnmanaged BillReconcileReason = "unmanaged"
+	// BillReconcileRecycled 账单中的资源在HCM中已回收，但仍在产生费用
+	BillReconcileRecycled BillReconcileReason = "recycled"
+)
@@ -66,26 +66,27 @@ func (rt CloudResourceType) ConvTableName() (table.Name, error) {
 
 // CloudResourceType define all cloud resource type.
 const (
-	AccountCloudResType          CloudResourceType = "account"
-	SubAccountCloudResType       CloudResourceType = "sub_account"
-	SecurityGroupCloudResType    CloudResourceType = "security_group"
-	GcpFirewallRuleCloudResType  CloudResourceType = "gcp_firewall_rule"
-	VpcCloudResType              CloudResourceType = "vpc"
-	SubnetCloudResType           CloudResourceType = "subnet"
-	EipCloudResType              CloudResourceType = "eip"
-	CvmCloudResType              CloudResourceType = "cvm"
-	DiskCloudResType             CloudResourceType = "disk"
-	RouteTableCloudResType       CloudResourceType = "route_table"
-	RouteCloudResType            CloudResourceType = "route"
-	NetworkInterfaceCloudResType CloudResourceType = "network_interface"
-	RegionCloudResType           CloudResourceType = "region"
-	ImageCloudResType            CloudResourceType = "image"
-	ZoneCloudResType             CloudResourceType = "zone"
-	AzureResourceGroup           CloudResourceType = "azure_resource_group"
-	ArgumentTemplateResType      CloudResourceType = "argument_template"
-	CertCloudResType             CloudResourceType = "cert"
-	LoadBalancerCloudResType     CloudResourceType = "load_balancer"
-	ListenerCloudResType         CloudResourceType = "listener"
-	TargetGroupCloudResType      CloudResourceType = "target_group"
-	TCLoudUrlRuleCloudResType    CloudResourceType = "tcloud_url_rule"
+	AccountCloudResType           CloudResourceType = "account"
+	SubAccountCloudResType        CloudResourceType = "sub_account"
+	SecurityGroupCloudResType     CloudResourceType = "security_group"
+	SecurityGroupRuleCloudResType CloudResourceType = "security_group_rule"
+	GcpFirewallRuleCloudResType   CloudResourceType = "gcp_firewall_rule"
+	VpcCloudResType               CloudResourceType = "vpc"
+	SubnetCloudResType            CloudResourceType = "subnet"
+	EipCloudResType               CloudResourceType = "eip"
+	CvmCloudResType               CloudResourceType = "cvm"
+	DiskCloudResType              CloudResourceType = "disk"
+	RouteTableCloudResType        CloudResourceType = "route_table"
+	RouteCloudResType             CloudResourceType = "route"
+	NetworkInterfaceCloudResType  CloudResourceType = "network_interface"
+	RegionCloudResType            CloudResourceType = "region"
+	ImageCloudResType             CloudResourceType = "image"
+	ZoneCloudResType              CloudResourceType = "zone"
+	AzureResourceGroup            CloudResourceType = "azure_resource_group"
+	ArgumentTemplateResType       CloudResourceType = "argument_template"
+	CertCloudResType              CloudResourceType = "cert"
+	LoadBalancerCloudResType      CloudResourceType = "load_balancer"
+	ListenerCloudResType          CloudResourceType = "listener"
+	TargetGroupCloudResType       CloudResourceType = "target_group"
+	TCLoudUrlRuleCloudResType     CloudResourceType = "tcloud_url_rule"
 )
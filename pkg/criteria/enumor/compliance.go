@@ -0,0 +1,76 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// ComplianceRule 内置的安全合规检测规则，对象存储桶未纳管，暂不支持公开存储桶检测
+type ComplianceRule string
+
+const (
+	// ComplianceSGOpenAdminPort 安全组入站规则允许任意来源(0.0.0.0/0、::/0)访问管理端口(默认22、3389)
+	ComplianceSGOpenAdminPort ComplianceRule = "sg_open_admin_port"
+	// ComplianceUnencryptedDisk 未加密的硬盘
+	ComplianceUnencryptedDisk ComplianceRule = "unencrypted_disk"
+)
+
+// ComplianceRuleResTypeMap 合规检测规则对应的资源类型
+var ComplianceRuleResTypeMap = map[ComplianceRule]CloudResourceType{
+	ComplianceSGOpenAdminPort: SecurityGroupCloudResType,
+	ComplianceUnencryptedDisk: DiskCloudResType,
+}
+
+// ComplianceRuleDefaultSeverity 合规检测规则默认的风险等级
+var ComplianceRuleDefaultSeverity = map[ComplianceRule]ComplianceSeverity{
+	ComplianceSGOpenAdminPort: ComplianceSeverityHigh,
+	ComplianceUnencryptedDisk: ComplianceSeverityMedium,
+}
+
+// Validate ComplianceRule.
+func (r ComplianceRule) Validate() error {
+	if _, exists := ComplianceRuleResTypeMap[r]; !exists {
+		return fmt.Errorf("unsupported compliance rule: %s", r)
+	}
+
+	return nil
+}
+
+// ComplianceSeverity 合规检测结果的风险等级
+type ComplianceSeverity string
+
+const (
+	// ComplianceSeverityHigh 高风险
+	ComplianceSeverityHigh ComplianceSeverity = "high"
+	// ComplianceSeverityMedium 中风险
+	ComplianceSeverityMedium ComplianceSeverity = "medium"
+	// ComplianceSeverityLow 低风险
+	ComplianceSeverityLow ComplianceSeverity = "low"
+)
+
+// Validate ComplianceSeverity.
+func (s ComplianceSeverity) Validate() error {
+	switch s {
+	case ComplianceSeverityHigh, ComplianceSeverityMedium, ComplianceSeverityLow:
+	default:
+		return fmt.Errorf("unsupported compliance severity: %s", s)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// CvmScheduleAction 主机定时开关机动作
+type CvmScheduleAction string
+
+const (
+	// CvmScheduleStart 开机
+	CvmScheduleStart CvmScheduleAction = "start"
+	// CvmScheduleStop 关机
+	CvmScheduleStop CvmScheduleAction = "stop"
+)
+
+// Validate CvmScheduleAction.
+func (a CvmScheduleAction) Validate() error {
+	switch a {
+	case CvmScheduleStart, CvmScheduleStop:
+	default:
+		return fmt.Errorf("unsupported cvm schedule action: %s", a)
+	}
+
+	return nil
+}
+
+// CvmOnboardingStatus 存量主机导入时每行数据的匹配状态
+type CvmOnboardingStatus string
+
+const (
+	// CvmOnboardingMatched 匹配到未分配的主机，提交后分配到目标业务
+	CvmOnboardingMatched CvmOnboardingStatus = "matched"
+	// CvmOnboardingAssigned 匹配到的主机已在目标业务下，提交后仅更新负责人及标签
+	CvmOnboardingAssigned CvmOnboardingStatus = "assigned"
+	// CvmOnboardingMismatch 未匹配到主机、匹配到多台主机、主机已分配到其他业务或数据格式错误
+	CvmOnboardingMismatch CvmOnboardingStatus = "mismatch"
+)
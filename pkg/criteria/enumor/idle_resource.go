@@ -0,0 +1,76 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// IdleReason 闲置资源的判定原因
+type IdleReason string
+
+const (
+	// IdleUnattachedDisk 未挂载的数据盘
+	IdleUnattachedDisk IdleReason = "unattached_disk"
+	// IdleUnboundEip 未绑定的弹性IP
+	IdleUnboundEip IdleReason = "unbound_eip"
+	// IdleStoppedCvm 长期关机的主机
+	IdleStoppedCvm IdleReason = "stopped_cvm"
+	// IdleUnusedSecurityGroup 未关联任何资源的安全组
+	IdleUnusedSecurityGroup IdleReason = "unused_security_group"
+)
+
+// IdleReasonResTypeMap 闲置原因对应的资源类型
+var IdleReasonResTypeMap = map[IdleReason]CloudResourceType{
+	IdleUnattachedDisk:      DiskCloudResType,
+	IdleUnboundEip:          EipCloudResType,
+	IdleStoppedCvm:          CvmCloudResType,
+	IdleUnusedSecurityGroup: SecurityGroupCloudResType,
+}
+
+// Validate IdleReason.
+func (r IdleReason) Validate() error {
+	if _, exists := IdleReasonResTypeMap[r]; !exists {
+		return fmt.Errorf("unsupported idle reason: %s", r)
+	}
+
+	return nil
+}
+
+// IdleResourceStatus 闲置资源的处理状态
+type IdleResourceStatus string
+
+const (
+	// IdleResourcePending 待处理
+	IdleResourcePending IdleResourceStatus = "pending"
+	// IdleResourceIgnored 已忽略，后续检测不再改变该状态
+	IdleResourceIgnored IdleResourceStatus = "ignored"
+	// IdleResourceRemediating 已提交清理，资源进入回收站或删除中
+	IdleResourceRemediating IdleResourceStatus = "remediating"
+)
+
+// Validate IdleResourceStatus.
+func (s IdleResourceStatus) Validate() error {
+	switch s {
+	case IdleResourcePending, IdleResourceIgnored, IdleResourceRemediating:
+	default:
+		return fmt.Errorf("unsupported idle resource status: %s", s)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// LandingZoneDeployState is the state of landing zone deployment.
+type LandingZoneDeployState string
+
+// Validate LandingZoneDeployState.
+func (s LandingZoneDeployState) Validate() error {
+	switch s {
+	case LandingZoneDeploying:
+	case LandingZoneDeploySuccess:
+	case LandingZoneRollingBack:
+	case LandingZoneRolledBack:
+	case LandingZoneRollbackFailed:
+	default:
+		return fmt.Errorf("unsupported landing zone deploy state: %s", s)
+	}
+
+	return nil
+}
+
+const (
+	// LandingZoneDeploying 部署中
+	LandingZoneDeploying LandingZoneDeployState = "deploying"
+	// LandingZoneDeploySuccess 部署成功
+	LandingZoneDeploySuccess LandingZoneDeployState = "success"
+	// LandingZoneRollingBack 部署失败，回滚已创建的资源中
+	LandingZoneRollingBack LandingZoneDeployState = "rolling_back"
+	// LandingZoneRolledBack 部署失败，已创建的资源已全部回滚
+	LandingZoneRolledBack LandingZoneDeployState = "rolled_back"
+	// LandingZoneRollbackFailed 部署失败，回滚失败，需要手动清理残留资源
+	LandingZoneRollbackFailed LandingZoneDeployState = "rollback_failed"
+)
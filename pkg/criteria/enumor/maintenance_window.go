@@ -0,0 +1,44 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// MaintenanceAction is the disruptive action restricted by maintenance window.
+type MaintenanceAction string
+
+// Validate MaintenanceAction.
+func (a MaintenanceAction) Validate() error {
+	switch a {
+	case MaintenanceRebootCvm:
+	case MaintenanceStopCvm:
+	default:
+		return fmt.Errorf("unsupported maintenance action: %s", a)
+	}
+
+	return nil
+}
+
+const (
+	// MaintenanceRebootCvm 重启主机
+	MaintenanceRebootCvm MaintenanceAction = "reboot_cvm"
+	// MaintenanceStopCvm 关机，包括定时关机
+	MaintenanceStopCvm MaintenanceAction = "stop_cvm"
+)
@@ -0,0 +1,75 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// NotificationChannel is notification channel.
+type NotificationChannel string
+
+// Validate NotificationChannel.
+func (c NotificationChannel) Validate() error {
+	switch c {
+	case NotificationEmail:
+	case NotificationWecom:
+	case NotificationWebhook:
+	default:
+		return fmt.Errorf("unsupported notification channel: %s", c)
+	}
+
+	return nil
+}
+
+const (
+	// NotificationEmail 邮件，通过蓝鲸消息通知服务(cmsi)发送
+	NotificationEmail NotificationChannel = "email"
+	// NotificationWecom 企业微信，通过蓝鲸消息通知服务(cmsi)发送
+	NotificationWecom NotificationChannel = "wecom"
+	// NotificationWebhook 通用webhook，将通知内容以json格式POST到订阅配置的地址
+	NotificationWebhook NotificationChannel = "webhook"
+)
+
+// NotificationEventType is notification event type.
+type NotificationEventType string
+
+// Validate NotificationEventType.
+func (t NotificationEventType) Validate() error {
+	switch t {
+	case NotificationSyncFailed:
+	case NotificationCredentialInvalid:
+	case NotificationBudgetAlert:
+	case NotificationApplicationStatus:
+	default:
+		return fmt.Errorf("unsupported notification event type: %s", t)
+	}
+
+	return nil
+}
+
+const (
+	// NotificationSyncFailed 云资源同步失败
+	NotificationSyncFailed NotificationEventType = "sync_failed"
+	// NotificationCredentialInvalid 云账号密钥失效，如密钥过期、被禁用或被删除导致同步鉴权失败
+	NotificationCredentialInvalid NotificationEventType = "credential_invalid"
+	// NotificationBudgetAlert 账单预算告警
+	NotificationBudgetAlert NotificationEventType = "budget_alert"
+	// NotificationApplicationStatus 单据审批状态变更
+	NotificationApplicationStatus NotificationEventType = "application_status"
+)
@@ -44,6 +44,7 @@ const (
 var RecycleAuditResTypeMap = map[AuditResourceType]CloudResourceType{
 	CvmAuditResType:  CvmCloudResType,
 	DiskAuditResType: DiskCloudResType,
+	EipAuditResType:  EipCloudResType,
 }
 
 // RecycleType 回收类型
@@ -0,0 +1,54 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// SavedFilterScope is saved filter visible scope.
+type SavedFilterScope string
+
+// Validate SavedFilterScope.
+func (s SavedFilterScope) Validate() error {
+	switch s {
+	case SavedFilterUserScope:
+	case SavedFilterBizScope:
+	default:
+		return fmt.Errorf("unsupported saved filter scope: %s", s)
+	}
+
+	return nil
+}
+
+const (
+	// SavedFilterUserScope 仅创建者本人可见
+	SavedFilterUserScope SavedFilterScope = "user"
+	// SavedFilterBizScope 在业务内共享，拥有该业务访问权限的用户均可见
+	SavedFilterBizScope SavedFilterScope = "biz"
+)
+
+// SavedFilterResTypes 支持保存过滤条件的资源类型，与资源列表接口一一对应
+var SavedFilterResTypes = map[CloudResourceType]struct{}{
+	CvmCloudResType:           {},
+	DiskCloudResType:          {},
+	SecurityGroupCloudResType: {},
+	EipCloudResType:           {},
+	LoadBalancerCloudResType:  {},
+	CertCloudResType:          {},
+}
@@ -0,0 +1,46 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// TagPolicyResTypes 支持配置标签策略的资源类型，仅包含本地记录了云上标签的资源
+var TagPolicyResTypes = []CloudResourceType{SecurityGroupCloudResType, LoadBalancerCloudResType}
+
+// ValidateTagPolicyResType validate whether the resource type supports tag policy.
+func ValidateTagPolicyResType(resType CloudResourceType) error {
+	for _, one := range TagPolicyResTypes {
+		if one == resType {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("resource type %s does not support tag policy", resType)
+}
+
+// TagViolationReason 标签不合规原因
+type TagViolationReason string
+
+const (
+	// TagMissing 缺少必填标签
+	TagMissing TagViolationReason = "missing"
+	// TagMistagged 标签值不在允许的取值范围内
+	TagMistagged TagViolationReason = "mistagged"
+)
@@ -21,7 +21,7 @@ package enumor
 
 import "fmt"
 
-// UserCollectionResType 用户收藏的资源类型
+// UserCollectionResType 用户收藏及最近访问的资源类型
 type UserCollectionResType string
 
 // Validate UserCollectionResType.
@@ -29,6 +29,9 @@ func (typ UserCollectionResType) Validate() error {
 	switch typ {
 	case BizCollResType:
 	case CloudSelectionSchemeCollResType:
+	case AccountCollResType, CvmCollResType, DiskCollResType, VpcCollResType, SubnetCollResType,
+		SecurityGroupCollResType, EipCollResType, LoadBalancerCollResType, CertCollResType, RouteTableCollResType,
+		NetworkInterfaceCollResType:
 	default:
 		return fmt.Errorf("res type: %s not support", typ)
 	}
@@ -41,4 +44,26 @@ const (
 	BizCollResType UserCollectionResType = "biz"
 	// CloudSelectionSchemeCollResType 云选型资源类型
 	CloudSelectionSchemeCollResType UserCollectionResType = "cloud_selection_scheme"
+	// AccountCollResType 云账号资源类型
+	AccountCollResType UserCollectionResType = "account"
+	// CvmCollResType 主机资源类型
+	CvmCollResType UserCollectionResType = "cvm"
+	// DiskCollResType 云硬盘资源类型
+	DiskCollResType UserCollectionResType = "disk"
+	// VpcCollResType vpc资源类型
+	VpcCollResType UserCollectionResType = "vpc"
+	// SubnetCollResType 子网资源类型
+	SubnetCollResType UserCollectionResType = "subnet"
+	// SecurityGroupCollResType 安全组资源类型
+	SecurityGroupCollResType UserCollectionResType = "security_group"
+	// EipCollResType 弹性IP资源类型
+	EipCollResType UserCollectionResType = "eip"
+	// LoadBalancerCollResType 负载均衡资源类型
+	LoadBalancerCollResType UserCollectionResType = "load_balancer"
+	// CertCollResType 证书资源类型
+	CertCollResType UserCollectionResType = "cert"
+	// RouteTableCollResType 路由表资源类型
+	RouteTableCollResType UserCollectionResType = "route_table"
+	// NetworkInterfaceCollResType 网络接口资源类型
+	NetworkInterfaceCollResType UserCollectionResType = "network_interface"
 )
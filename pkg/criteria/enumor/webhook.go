@@ -0,0 +1,47 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// WebhookDeliveryState is webhook delivery state.
+type WebhookDeliveryState string
+
+// Validate WebhookDeliveryState.
+func (s WebhookDeliveryState) Validate() error {
+	switch s {
+	case WebhookDeliveryPending:
+	case WebhookDeliverySuccess:
+	case WebhookDeliveryFailed:
+	default:
+		return fmt.Errorf("unsupported webhook delivery state: %s", s)
+	}
+
+	return nil
+}
+
+const (
+	// WebhookDeliveryPending 待投递，包括投递失败后等待重试的事件
+	WebhookDeliveryPending WebhookDeliveryState = "pending"
+	// WebhookDeliverySuccess 投递成功，订阅地址返回了2xx响应
+	WebhookDeliverySuccess WebhookDeliveryState = "success"
+	// WebhookDeliveryFailed 投递失败，且已达到最大投递次数，不再自动重试
+	WebhookDeliveryFailed WebhookDeliveryState = "failed"
+)
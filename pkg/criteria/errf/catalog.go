@@ -0,0 +1,138 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package errf
+
+import "hcm/pkg/criteria/constant"
+
+// CodeInfo defines the meaning of an error code, it's published by api so that the frontend and integrators can
+// branch on the code instead of parsing the error message.
+type CodeInfo struct {
+	// Code is the stable error code.
+	Code int32 `json:"code"`
+	// Name is the stable name of the code.
+	Name string `json:"name"`
+	// Description is the meaning of the code.
+	Description string `json:"description"`
+	// Retryable is true if the request can be retried as it is later.
+	Retryable bool `json:"retryable"`
+	// HasSubCode is true if the error carries the error code of the cloud vendor as sub code.
+	HasSubCode bool `json:"has_sub_code"`
+}
+
+// catalog is all the error codes, a new error code must be added here.
+var catalog = []CodeInfo{
+	{Code: OK, Name: "OK", Description: "成功"},
+	{Code: PermissionDenied, Name: "PermissionDenied", Description: "没有操作权限，需要申请的权限在响应的 permission 中"},
+	{Code: Unknown, Name: "Unknown", Description: "未知错误"},
+	{Code: InvalidParameter, Name: "InvalidParameter",
+		Description: "请求参数不合法，参数校验失败的字段在 details.field_errors 中"},
+	{Code: TooManyRequest, Name: "TooManyRequest", Description: "请求超过频率限制", Retryable: true},
+	{Code: RecordNotFound, Name: "RecordNotFound", Description: "资源不存在"},
+	{Code: DecodeRequestFailed, Name: "DecodeRequestFailed", Description: "解析请求体失败"},
+	{Code: UnHealthy, Name: "UnHealthy", Description: "服务不健康", Retryable: true},
+	{Code: Aborted, Name: "Aborted", Description: "请求因异常中断", Retryable: true},
+	{Code: DoAuthorizeFailed, Name: "DoAuthorizeFailed", Description: "鉴权失败，无法确认是否有权限", Retryable: true},
+	{Code: PartialFailed, Name: "PartialFailed", Description: "批量操作部分失败"},
+	{Code: UserNoAppAccess, Name: "UserNoAppAccess", Description: "用户没有应用的访问权限"},
+	{Code: RecordNotUpdate, Name: "RecordNotUpdate", Description: "没有数据被更新"},
+	{Code: RecordDuplicated, Name: "RecordDuplicated", Description: "数据重复"},
+	{Code: CloudVendorError, Name: "CloudVendorError",
+		Description: "云上接口返回错误，云厂商的错误码在 sub_code 中，云上请求ID在 details.cloud_request_id 中",
+		HasSubCode:  true},
+	{Code: LoadBalancerTaskExecuting, Name: "LoadBalancerTaskExecuting", Description: "负载均衡正在变更中",
+		Retryable: true},
+	{Code: BillItemImportBillDateError, Name: "BillItemImportBillDateError", Description: "账单导入的账单日期不匹配"},
+	{Code: BillItemImportDataError, Name: "BillItemImportDataError", Description: "账单导入的数据格式不正确"},
+	{Code: BillItemImportEmptyDataError, Name: "BillItemImportEmptyDataError", Description: "账单导入的数据为空"},
+	{Code: RecordReferenceViolated, Name: "RecordReferenceViolated", Description: "数据违反引用约束"},
+	{Code: ResourceInUse, Name: "ResourceInUse", Description: "资源正在被其他资源使用，不能删除"},
+	{Code: QuotaExceeded, Name: "QuotaExceeded", Description: "申请的资源超出业务资源配额"},
+	{Code: NamingRuleViolated, Name: "NamingRuleViolated", Description: "资源名称不符合业务配置的命名规则"},
+	{Code: TagPolicyViolated, Name: "TagPolicyViolated", Description: "资源标签不符合业务配置的标签策略"},
+	{Code: PreDeleteHookRejected, Name: "PreDeleteHookRejected", Description: "删除操作被删除前钩子拒绝"},
+	{Code: OutOfMaintenanceWindow, Name: "OutOfMaintenanceWindow", Description: "当前时间不在业务的维护窗口内",
+		Retryable: true},
+	{Code: ResWhitelistViolated, Name: "ResWhitelistViolated", Description: "申请的资源不在业务的资源申请白名单内"},
+	{Code: IdempotentRequestInProgress, Name: "IdempotentRequestInProgress", Description: "相同幂等键的请求正在处理中",
+		Retryable: true},
+	{Code: RequestEntityTooLarge, Name: "RequestEntityTooLarge", Description: "请求体超过接口允许的最大长度"},
+}
+
+// enDescriptions is the english description of the error codes, the description in catalog is chinese.
+var enDescriptions = map[int32]string{
+	OK:                  "success",
+	PermissionDenied:    "permission denied, the permissions to apply are in the permission of the response",
+	Unknown:             "unknown error",
+	InvalidParameter:    "invalid parameter, the invalid fields are in details.field_errors",
+	TooManyRequest:      "too many requests",
+	RecordNotFound:      "resource not found",
+	DecodeRequestFailed: "decode request body failed",
+	UnHealthy:           "service is unhealthy",
+	Aborted:             "request is aborted",
+	DoAuthorizeFailed:   "authorize failed, can not confirm the permission",
+	PartialFailed:       "batch operation is partially failed",
+	UserNoAppAccess:     "user has no access to the app",
+	RecordNotUpdate:     "no record is updated",
+	RecordDuplicated:    "record is duplicated",
+	CloudVendorError: "cloud api returns error, the error code of vendor is in sub_code, the cloud request " +
+		"id is in details.cloud_request_id",
+	LoadBalancerTaskExecuting:    "load balancer is changing",
+	BillItemImportBillDateError:  "bill date of the imported bill is not matched",
+	BillItemImportDataError:      "data format of the imported bill is invalid",
+	BillItemImportEmptyDataError: "data of the imported bill is empty",
+	RecordReferenceViolated:      "record violates the reference constraint",
+	ResourceInUse:                "resource is used by other resources, can not be deleted",
+	QuotaExceeded:                "applied resources exceed the resource quota of the business",
+	NamingRuleViolated:           "resource name violates the naming rule of the business",
+	TagPolicyViolated:            "resource tags violate the tag policy of the business",
+	PreDeleteHookRejected:        "delete operation is rejected by the pre delete hook",
+	OutOfMaintenanceWindow:       "current time is out of the maintenance window of the business",
+	ResWhitelistViolated:         "applied resources are not in the resource whitelist of the business",
+	IdempotentRequestInProgress:  "request with the same idempotency key is in progress",
+	RequestEntityTooLarge:        "request body exceeds the max length of the api",
+}
+
+// Catalog returns all the error codes with the description in the language.
+func Catalog(lang constant.Language) []CodeInfo {
+	result := make([]CodeInfo, len(catalog))
+	copy(result, catalog)
+
+	if lang != constant.English {
+		return result
+	}
+
+	for i := range result {
+		if desc, ok := enDescriptions[result[i].Code]; ok {
+			result[i].Description = desc
+		}
+	}
+	return result
+}
+
+// LookupCode returns the info of the error code.
+func LookupCode(code int32) (CodeInfo, bool) {
+	for _, one := range catalog {
+		if one.Code == code {
+			return one, true
+		}
+	}
+
+	return CodeInfo{}, false
+}
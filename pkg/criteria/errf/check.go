@@ -24,10 +24,22 @@ import (
 	"errors"
 	"strings"
 
+	"hcm/pkg/i18n"
+
 	"github.com/go-sql-driver/mysql"
 )
 
-const mysqlDuplicatedNumber = 1062
+const (
+	mysqlDuplicatedNumber = 1062
+	// mysqlRowIsReferencedNumber 删除或更新被其他数据通过外键引用的数据
+	mysqlRowIsReferencedNumber = 1451
+	// mysqlNoReferencedRowNumber 插入或更新的数据通过外键引用的数据不存在
+	mysqlNoReferencedRowNumber = 1452
+	// mysqlLockWaitTimeoutNumber 等待行锁超时
+	mysqlLockWaitTimeoutNumber = 1205
+	// mysqlDeadlockNumber 事务间发生死锁，其中一个事务被回滚
+	mysqlDeadlockNumber = 1213
+)
 
 // GetTypedError 尝试转换为指定类型的错误
 func GetTypedError[T error](err error) *T {
@@ -46,6 +58,38 @@ func GetMySQLDuplicated(err error) (merr *mysql.MySQLError) {
 	return nil
 }
 
+// ConvConstraintError convert the mysql unique key and foreign key violation error to typed ErrorF, so that the
+// caller can tell the "already exists" and "reference violated" cases apart from other db errors.
+// other errors are returned as it is.
+func ConvConstraintError(err error) error {
+	var merr *mysql.MySQLError
+	if !errors.As(err, &merr) {
+		return err
+	}
+
+	switch merr.Number {
+	case mysqlDuplicatedNumber:
+		return &ErrorF{Code: RecordDuplicated, Message: merr.Message}
+	case mysqlRowIsReferencedNumber, mysqlNoReferencedRowNumber:
+		return &ErrorF{Code: RecordReferenceViolated, Message: merr.Message}
+	default:
+		return err
+	}
+}
+
+// IsReferenceViolated return true if error is a foreign key violation error
+func IsReferenceViolated(err error) bool {
+	var merr *mysql.MySQLError
+	if errors.As(err, &merr) {
+		return merr.Number == mysqlRowIsReferencedNumber || merr.Number == mysqlNoReferencedRowNumber
+	}
+	var ef *ErrorF
+	if errors.As(err, &ef) {
+		return ef.Code == RecordReferenceViolated
+	}
+	return false
+}
+
 // IsRecordNotFound return true if error is a not found error
 func IsRecordNotFound(err error) bool {
 	if err == nil {
@@ -58,6 +102,23 @@ func IsRecordNotFound(err error) bool {
 	return false
 }
 
+// IsTxnRetryable return true if error is a mysql deadlock or lock wait timeout error, the transaction which hits
+// these errors can be retried as a whole.
+func IsTxnRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var merr *mysql.MySQLError
+	if errors.As(err, &merr) {
+		return merr.Number == mysqlDeadlockNumber || merr.Number == mysqlLockWaitTimeoutNumber
+	}
+
+	// the mysql error may be formatted into a string error by the upper layer.
+	s := err.Error()
+	return strings.Contains(s, "Error 1213") || strings.Contains(s, "Error 1205")
+}
+
 // IsContextCanceled return true if error contains string "context canceled"
 func IsContextCanceled(err error) bool {
 	if err == nil {
@@ -96,6 +157,19 @@ func Error(err error) *ErrorF {
 
 	s := err.Error()
 
+	if localized, ok := err.(*i18n.Error); ok {
+		return &ErrorF{Code: Unknown, Message: s, localized: localized}
+	}
+
+	// typed constraint error may be wrapped by the upper layer, keep its code so that the caller can identify it.
+	if errors.As(err, &ef) && (ef.Code == RecordDuplicated || ef.Code == RecordReferenceViolated) {
+		return &ErrorF{Code: ef.Code, Message: s}
+	}
+
+	if ef = parseByParsers(err); ef != nil {
+		return ef
+	}
+
 	// test if the error is a json error,
 	// if not, then this is an error without error code.
 	if !strings.HasPrefix(s, "{") {
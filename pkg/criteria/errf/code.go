@@ -72,4 +72,24 @@ const (
 	BillItemImportDataError int32 = 2000016
 	// BillItemImportEmptyDataError 账单导入空列表
 	BillItemImportEmptyDataError int32 = 2000017
+	// RecordReferenceViolated 数据违反外键约束，对应 MySQL Error 1451/1452 (23000)
+	RecordReferenceViolated int32 = 2000018
+	// ResourceInUse 资源正在被其他资源使用，不能删除
+	ResourceInUse int32 = 2000019
+	// QuotaExceeded 申请的资源超出业务资源配额
+	QuotaExceeded int32 = 2000020
+	// NamingRuleViolated 资源名称不符合业务配置的命名规则
+	NamingRuleViolated int32 = 2000021
+	// TagPolicyViolated 资源标签不符合业务配置的标签策略
+	TagPolicyViolated int32 = 2000022
+	// PreDeleteHookRejected 删除操作被删除前钩子拒绝
+	PreDeleteHookRejected int32 = 2000023
+	// OutOfMaintenanceWindow 当前时间不在业务的维护窗口内，不允许执行中断业务的操作
+	OutOfMaintenanceWindow int32 = 2000024
+	// ResWhitelistViolated 申请的资源不在业务的资源申请白名单内
+	ResWhitelistViolated int32 = 2000025
+	// IdempotentRequestInProgress 相同幂等键的请求正在处理中
+	IdempotentRequestInProgress int32 = 2000026
+	// RequestEntityTooLarge 请求体超过接口允许的最大长度
+	RequestEntityTooLarge int32 = 2000027
 )
@@ -0,0 +1,104 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package errf
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Details is the structured details of an error, the frontend and integrators can branch on them instead of
+// parsing the error message.
+type Details struct {
+	// Vendor is the cloud vendor which returns the error.
+	Vendor string `json:"vendor,omitempty"`
+	// CloudRequestID is the request id of the failed cloud api call, it's used to ask the vendor for help.
+	CloudRequestID string `json:"cloud_request_id,omitempty"`
+	// FieldErrors are the invalid fields of the request.
+	FieldErrors []FieldError `json:"field_errors,omitempty"`
+}
+
+// FieldError defines an invalid field of the request.
+type FieldError struct {
+	// Field is the json path of the field, such as "extension.zone".
+	Field string `json:"field"`
+	// Reason is why the field is invalid.
+	Reason string `json:"reason"`
+}
+
+// NewWithDetails create an error with error code, message, sub code and details.
+func NewWithDetails(code int32, message string, subCode string, details *Details) error {
+	return &ErrorF{Code: code, Message: message, SubCode: subCode, Details: details}
+}
+
+// NewCloudError create a cloud vendor error with the error code and request id returned by the vendor.
+func NewCloudError(vendor string, subCode, requestID, message string) error {
+	return &ErrorF{
+		Code:    CloudVendorError,
+		Message: message,
+		SubCode: subCode,
+		Details: &Details{Vendor: vendor, CloudRequestID: requestID},
+	}
+}
+
+// NewFieldErrors create an invalid parameter error with the invalid fields.
+func NewFieldErrors(fieldErrs ...FieldError) error {
+	reasons := make([]string, 0, len(fieldErrs))
+	for _, one := range fieldErrs {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", one.Field, one.Reason))
+	}
+
+	return &ErrorF{
+		Code:    InvalidParameter,
+		Message: strings.Join(reasons, "; "),
+		Details: &Details{FieldErrors: fieldErrs},
+	}
+}
+
+// Parser parse the error of other libraries such as cloud sdk to ErrorF, returns nil if the error is not supported.
+type Parser func(err error) *ErrorF
+
+var parsers struct {
+	sync.RWMutex
+	list []Parser
+}
+
+// RegisterParser register the parser used by Error to keep the code and details of the errors returned by other
+// libraries, such as the error code and request id of the cloud sdk errors.
+func RegisterParser(parser Parser) {
+	parsers.Lock()
+	defer parsers.Unlock()
+
+	parsers.list = append(parsers.list, parser)
+}
+
+func parseByParsers(err error) *ErrorF {
+	parsers.RLock()
+	defer parsers.RUnlock()
+
+	for _, parser := range parsers.list {
+		if ef := parser(err); ef != nil {
+			return ef
+		}
+	}
+
+	return nil
+}
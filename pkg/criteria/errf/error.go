@@ -21,8 +21,11 @@
 package errf
 
 import (
+	"encoding/json"
 	"fmt"
 
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/i18n"
 	"hcm/pkg/iam/meta"
 )
 
@@ -34,6 +37,12 @@ type ErrorF struct {
 	Message string `json:"message"`
 	// Permissions is no permission error related permission.
 	Permissions *meta.IamPermission `json:"permission,omitempty"`
+	// SubCode is the error code of the cloud vendor, such as "InvalidParameterValue", it's empty for hcm errors.
+	SubCode string `json:"sub_code,omitempty"`
+	// Details is the structured details of the error, such as the field errors and the cloud request id.
+	Details *Details `json:"details,omitempty"`
+	// localized is the i18n message of the error, the message is rendered in the language of the request with it.
+	localized *i18n.Error
 }
 
 // Error implement the golang's basic error interface
@@ -42,6 +51,14 @@ func (e *ErrorF) Error() string {
 		return "nil"
 	}
 
+	if len(e.SubCode) != 0 || e.Details != nil {
+		// the error with details is encoded as json, so that the details are kept when it's decoded by Wrap.
+		byt, err := json.Marshal(e)
+		if err == nil {
+			return string(byt)
+		}
+	}
+
 	// return with a json format string error, so that the upper service
 	// can use Wrap to decode it.
 	return fmt.Sprintf(`{"code": %d, "message": "%s"}`, e.Code, e.Message)
@@ -57,7 +74,7 @@ func (e *ErrorF) Format() string {
 }
 
 func (e *ErrorF) String() string {
-	return fmt.Sprint(e)
+	return e.Error()
 }
 
 // Resp get the http response of the error.
@@ -66,6 +83,8 @@ func (e ErrorF) Resp() *ErrorResp {
 		Code:        e.Code,
 		Message:     e.Message,
 		Permissions: e.Permissions,
+		SubCode:     e.SubCode,
+		Details:     e.Details,
 	}
 }
 
@@ -77,6 +96,10 @@ type ErrorResp struct {
 	Message string `json:"message"`
 	// Permissions is no permission error related permission.
 	Permissions *meta.IamPermission `json:"permission,omitempty"`
+	// SubCode is the error code of the cloud vendor.
+	SubCode string `json:"sub_code,omitempty"`
+	// Details is the structured details of the error.
+	Details *Details `json:"details,omitempty"`
 }
 
 // New an error with error code and message.
@@ -91,7 +114,8 @@ func NewFromErr(code int32, err error) error {
 	}
 
 	errorf := Error(err)
-	return &ErrorF{Code: code, Message: errorf.Message}
+	return &ErrorF{Code: code, Message: errorf.Message, SubCode: errorf.SubCode, Details: errorf.Details,
+		localized: errorf.localized}
 }
 
 // Newf create an error with error code and formatted message.
@@ -103,3 +127,22 @@ func Newf(code int32, format string, args ...interface{}) error {
 func NewWithPerm(code int32, message string, permissions *meta.IamPermission) error {
 	return &ErrorF{Code: code, Message: message, Permissions: permissions}
 }
+
+// NewLocalized create an error with error code and the i18n message of the key, the message is rendered in the
+// language of the request when it's responded.
+func NewLocalized(code int32, key string, args ...interface{}) error {
+	localized := &i18n.Error{Key: key, Args: args}
+	return &ErrorF{Code: code, Message: localized.Error(), localized: localized}
+}
+
+// Localize returns the error with the message rendered in the language, the error is returned as it is if the
+// message is not an i18n message.
+func (e *ErrorF) Localize(lang constant.Language) *ErrorF {
+	if e == nil || e.localized == nil || len(lang) == 0 {
+		return e
+	}
+
+	localized := *e
+	localized.Message = e.localized.Localize(lang)
+	return &localized
+}
@@ -21,7 +21,14 @@ package errf
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"testing"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/i18n"
+
+	"github.com/go-sql-driver/mysql"
 )
 
 func TestWrap(t *testing.T) {
@@ -59,3 +66,157 @@ func TestAssignResp(t *testing.T) {
 		t.Error("errorF assign code message failed")
 	}
 }
+
+func TestIsTxnRetryable(t *testing.T) {
+	deadlock := &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+	cases := []struct {
+		err    error
+		expect bool
+	}{
+		{err: nil, expect: false},
+		{err: deadlock, expect: true},
+		{err: fmt.Errorf("update cvm failed, err: %w", deadlock), expect: true},
+		{err: fmt.Errorf("update cvm failed, err: %v", deadlock), expect: true},
+		{err: &mysql.MySQLError{Number: 1205, Message: "Lock wait timeout exceeded"}, expect: true},
+		{err: &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}, expect: false},
+		{err: errors.New("connection refused"), expect: false},
+	}
+
+	for idx, c := range cases {
+		if IsTxnRetryable(c.err) != c.expect {
+			t.Errorf("case %d: is txn retryable of %v should be %v", idx, c.err, c.expect)
+		}
+	}
+}
+
+func TestConvConstraintError(t *testing.T) {
+	dup := ConvConstraintError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'vpc-1' for key 'idx_uk'"})
+	if ef := Error(dup); ef.Code != RecordDuplicated || !IsDuplicated(dup) {
+		t.Errorf("unique key violation should be duplicated error, got: %v", dup)
+	}
+
+	for _, number := range []uint16{1451, 1452} {
+		ref := ConvConstraintError(&mysql.MySQLError{Number: number, Message: "a foreign key constraint fails"})
+		if !IsReferenceViolated(ref) {
+			t.Errorf("mysql error %d should be reference violated error, got: %v", number, ref)
+		}
+
+		// the code is kept after the error is wrapped by the dao.
+		wrapped := fmt.Errorf("insert vpc failed, err: %w", ref)
+		if ef := Error(wrapped); ef.Code != RecordReferenceViolated {
+			t.Errorf("wrapped reference violated error should keep its code, got: %+v", ef)
+		}
+	}
+
+	other := &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+	if converted := ConvConstraintError(other); converted != error(other) {
+		t.Errorf("other mysql error should be returned as it is, got: %v", converted)
+	}
+	if ConvConstraintError(nil) != nil || IsReferenceViolated(errors.New("connection refused")) {
+		t.Errorf("non constraint error should not be converted")
+	}
+}
+
+func TestErrorDetails(t *testing.T) {
+	ef := NewCloudError("tcloud", "InvalidParameterValue", "req-1", "invalid zone")
+
+	// the details are kept after the error is encoded and decoded by the upper service.
+	decoded := Error(errors.New(ef.Error()))
+	if decoded.Code != CloudVendorError || decoded.SubCode != "InvalidParameterValue" || decoded.Details == nil ||
+		decoded.Details.Vendor != "tcloud" || decoded.Details.CloudRequestID != "req-1" {
+		t.Errorf("details are lost, got: %+v", decoded)
+		return
+	}
+
+	resp := decoded.Resp()
+	if resp.SubCode != "InvalidParameterValue" || resp.Details.CloudRequestID != "req-1" {
+		t.Errorf("details are not set to response, got: %+v", resp)
+		return
+	}
+
+	fieldErr := Error(NewFieldErrors(FieldError{Field: "zone", Reason: "is required"}))
+	if fieldErr.Code != InvalidParameter || len(fieldErr.Details.FieldErrors) != 1 ||
+		fieldErr.Message != "zone: is required" {
+		t.Errorf("unexpected field error: %+v", fieldErr)
+		return
+	}
+}
+
+type vendorErr struct{ code string }
+
+func (v *vendorErr) Error() string { return "vendor error " + v.code }
+
+func TestRegisterParser(t *testing.T) {
+	RegisterParser(func(err error) *ErrorF {
+		var verr *vendorErr
+		if !errors.As(err, &verr) {
+			return nil
+		}
+		return &ErrorF{Code: CloudVendorError, Message: verr.Error(), SubCode: verr.code}
+	})
+
+	ef := Error(fmt.Errorf("create cvm failed, err: %w", &vendorErr{code: "LimitExceeded"}))
+	if ef.Code != CloudVendorError || ef.SubCode != "LimitExceeded" {
+		t.Errorf("vendor error is not parsed, got: %+v", ef)
+		return
+	}
+
+	if ef = Error(errors.New("other error")); ef.Code != Unknown {
+		t.Errorf("other error should be unknown, got: %+v", ef)
+		return
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	codes := make(map[int32]struct{})
+	names := make(map[string]struct{})
+	for _, one := range Catalog(constant.Chinese) {
+		if _, exists := codes[one.Code]; exists {
+			t.Errorf("code %d is duplicated", one.Code)
+		}
+		if _, exists := names[one.Name]; exists {
+			t.Errorf("name %s is duplicated", one.Name)
+		}
+		codes[one.Code] = struct{}{}
+		names[one.Name] = struct{}{}
+		if _, exists := enDescriptions[one.Code]; !exists {
+			t.Errorf("code %d has no english description", one.Code)
+		}
+	}
+
+	if en := Catalog(constant.English); en[0].Description != enDescriptions[en[0].Code] {
+		t.Errorf("english catalog should use english description, got: %s", en[0].Description)
+	}
+
+	if info, ok := LookupCode(CloudVendorError); !ok || !info.HasSubCode {
+		t.Errorf("cloud vendor error should have sub code")
+	}
+}
+
+func TestLocalizedError(t *testing.T) {
+	err := NewLocalized(InvalidParameter, i18n.NameTooLong, 60)
+	ef := Error(err)
+	if ef.Message != i18n.Sprintf(i18n.Default, i18n.NameTooLong, 60) {
+		t.Errorf("localized error should be rendered in default language, got: %s", ef.Message)
+		return
+	}
+
+	if msg := ef.Localize(constant.English).Message; msg != "invalid name, length should <= 60" {
+		t.Errorf("localized error should be rendered in english, got: %s", msg)
+		return
+	}
+
+	// the i18n message is kept when the error is wrapped with another code.
+	wrapped := Error(NewFromErr(DecodeRequestFailed, i18n.Errorf(i18n.NameEmpty)))
+	if wrapped.Code != DecodeRequestFailed || wrapped.Localize(constant.English).Message != "invalid name, length "+
+		"should >= 1" {
+
+		t.Errorf("wrapped i18n error should be localized, got: %+v", wrapped.Localize(constant.English))
+		return
+	}
+
+	if plain := Error(New(Unknown, "plain error")); plain.Localize(constant.English).Message != "plain error" {
+		t.Errorf("plain error should not be localized, got: %+v", plain)
+		return
+	}
+}
@@ -20,11 +20,11 @@
 package validator
 
 import (
-	"errors"
 	"fmt"
 	"regexp"
 
 	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/i18n"
 )
 
 var gcpNameRegexp = regexp.MustCompile(`^([a-z0-9][a-z0-9-]*)[a-z0-9]$`)
@@ -42,42 +42,39 @@ func ValidateCvmName(vendor enumor.Vendor, name string) error {
 	switch vendor {
 	case enumor.TCloud:
 		if len(name) == 0 || len(name) > 60 {
-			return errors.New("aws cvm name should 1-60")
+			return i18n.Errorf(i18n.NameLengthOutOfRange, 1, 60)
 		}
 
 	case enumor.Aws:
 		if len(name) == 0 || len(name) > 256 {
-			return errors.New("aws cvm name should 1-256")
+			return i18n.Errorf(i18n.NameLengthOutOfRange, 1, 256)
 		}
 
 	case enumor.HuaWei:
 		if len(name) == 0 || len(name) > 64 {
-			return errors.New("aws cvm name should 1-64")
+			return i18n.Errorf(i18n.NameLengthOutOfRange, 1, 64)
 		}
 
 		if !huaweiCvmNameRegexp.MatchString(name) {
-			return fmt.Errorf("invalid name: %s, only allows to include chinese、english、numbers、underscore (_)"+
-				"、hyphen (-), and must start and end with an chinese、english、numbers", name)
+			return i18n.Errorf(i18n.NameInvalid, name)
 		}
 
 	case enumor.Gcp:
 		if len(name) == 0 || len(name) > 62 {
-			return errors.New("gcp cvm name should 1-62")
+			return i18n.Errorf(i18n.NameLengthOutOfRange, 1, 62)
 		}
 
 		if !gcpNameRegexp.MatchString(name) {
-			return fmt.Errorf("invalid name: %s, only allows include lowercase english、numbers、hyphen (-), and must "+
-				"start and end with an english、numbers", name)
+			return i18n.Errorf(i18n.GcpNameInvalid, name)
 		}
 
 	case enumor.Azure:
 		if len(name) == 0 {
-			return errors.New("aws cvm name should > 0")
+			return i18n.Errorf(i18n.NameEmpty)
 		}
 
 		if !azureCvmNameRegexp.MatchString(name) {
-			return fmt.Errorf("invalid name: %s, only allows include english、numbers、hyphen (-)、point (.), and must "+
-				"start and end with an english、numbers", name)
+			return i18n.Errorf(i18n.AzureCvmNameInvalid, name)
 		}
 
 	default:
@@ -19,20 +19,16 @@
 
 package validator
 
-import (
-	"errors"
-	"fmt"
-)
+import "hcm/pkg/i18n"
 
 // ValidateGcpName 长度63，名称必须以小写字母开头，后面最多可跟 62 个小写字母、数字或连字符，但不能以连字符结尾
 func ValidateGcpName(name string) error {
 	if len(name) == 0 || len(name) > 63 {
-		return errors.New("gcp firewall rule name should 1-63")
+		return i18n.Errorf(i18n.NameLengthOutOfRange, 1, 63)
 	}
 
 	if !gcpNameRegexp.MatchString(name) {
-		return fmt.Errorf("invalid name: %s, only allows include lowercase english、numbers、hyphen (-), and must "+
-			"start and end with an english、numbers", name)
+		return i18n.Errorf(i18n.GcpNameInvalid, name)
 	}
 
 	return nil
@@ -20,9 +20,10 @@
 package validator
 
 import (
-	"errors"
 	"regexp"
 	"unicode/utf8"
+
+	"hcm/pkg/i18n"
 )
 
 const (
@@ -38,7 +39,7 @@ var qualifiedMemoRegexp = regexp.MustCompile("^" + qualifiedMemoFmt + "$")
 func ValidateMemo(memo *string, required bool) error {
 	// check data is nil and required.
 	if required && (memo == nil || len(*memo) == 0) {
-		return errors.New("memo is required, can not be empty")
+		return i18n.Errorf(i18n.MemoRequired)
 	}
 
 	if memo == nil || len(*memo) == 0 {
@@ -47,12 +48,11 @@ func ValidateMemo(memo *string, required bool) error {
 
 	m := *memo
 	if utf8.RuneCountInString(m) > 255 {
-		return errors.New("invalid memo, length should less than 255")
+		return i18n.Errorf(i18n.MemoTooLong, 255)
 	}
 
 	if !qualifiedMemoRegexp.MatchString(m) {
-		return errors.New("invalid memo, only allows include chinese、english、numbers、underscore (_)" +
-			"、hyphen (-)、space, and must start and end with an chinese、english、numbers")
+		return i18n.Errorf(i18n.MemoInvalid)
 	}
 
 	return nil
@@ -20,9 +20,9 @@
 package validator
 
 import (
-	"errors"
-	"fmt"
 	"regexp"
+
+	"hcm/pkg/i18n"
 )
 
 const (
@@ -38,16 +38,15 @@ var qualifiedNameRegexp = regexp.MustCompile("^" + qualifiedNameFmt + "$")
 // ValidateName validate hcm resource name's length and format.
 func ValidateName(name string) error {
 	if len(name) < 1 {
-		return errors.New("invalid name, length should >= 1")
+		return i18n.Errorf(i18n.NameEmpty)
 	}
 
 	if len(name) > 128 {
-		return errors.New("invalid name, length should <= 128")
+		return i18n.Errorf(i18n.NameTooLong, 128)
 	}
 
 	if !qualifiedNameRegexp.MatchString(name) {
-		return fmt.Errorf("invalid name: %s, only allows to include chinese、english、numbers、underscore (_)"+
-			"、hyphen (-), and must start and end with an chinese、english、numbers", name)
+		return i18n.Errorf(i18n.NameInvalid, name)
 	}
 
 	return nil
@@ -20,10 +20,10 @@
 package validator
 
 import (
-	"errors"
-	"fmt"
 	"regexp"
 	"strings"
+
+	"hcm/pkg/i18n"
 )
 
 const (
@@ -37,20 +37,19 @@ var qualifiedSGNameRegexp = regexp.MustCompile("^" + qualifiedSGNameFmt + "$")
 // ValidateSecurityGroupName validate security group name's length and format.
 func ValidateSecurityGroupName(name string) error {
 	if len(name) < 1 {
-		return errors.New("invalid name, length should >= 1")
+		return i18n.Errorf(i18n.NameEmpty)
 	}
 
 	if len(name) > 60 {
-		return errors.New("invalid name, length should <= 60")
+		return i18n.Errorf(i18n.NameTooLong, 60)
 	}
 
 	if strings.HasPrefix(name, "sg-") {
-		return errors.New("name can not start with 'sg-'")
+		return i18n.Errorf(i18n.SecurityGroupNamePrefix)
 	}
 
 	if !qualifiedSGNameRegexp.MatchString(name) {
-		return fmt.Errorf("invalid name: %s, only allows to include low english、numbers、hyphen (-), and must start "+
-			"and end with an low english", name)
+		return i18n.Errorf(i18n.SecurityGroupNameInvalid, name)
 	}
 
 	return nil
@@ -59,7 +58,7 @@ func ValidateSecurityGroupName(name string) error {
 // ValidateSecurityGroupMemo validate security group memo's length and format.
 func ValidateSecurityGroupMemo(memo *string) error {
 	if memo == nil {
-		return errors.New("memo is nil")
+		return i18n.Errorf(i18n.MemoRequired)
 	}
 
 	content := *memo
@@ -68,12 +67,11 @@ func ValidateSecurityGroupMemo(memo *string) error {
 	}
 
 	if len(content) > 100 {
-		return errors.New("invalid memo, length should <= 100")
+		return i18n.Errorf(i18n.MemoTooLong, 100)
 	}
 
 	if !qualifiedMemoRegexp.MatchString(content) {
-		return errors.New("invalid memo, only allows include chinese、english、numbers、underscore (_)" +
-			"、hyphen (-)、space, and must start and end with an chinese、english、numbers")
+		return i18n.Errorf(i18n.MemoInvalid)
 	}
 
 	return nil
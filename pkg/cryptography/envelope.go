@@ -0,0 +1,265 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cryptography
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/TencentBlueKing/gopkg/conv"
+)
+
+const (
+	// dekSize 数据密钥长度，使用AES-256
+	dekSize = 32
+	// maxKeyIDLen 主密钥ID最大长度，密文中以1个字节记录
+	maxKeyIDLen = 255
+	// LegacyKeyID 历史AES Gcm密文对应的主密钥ID，即配置中的AES Gcm密钥
+	LegacyKeyID = "legacy"
+)
+
+// envelopeMagic 信封加密密文前缀，用于与历史AES Gcm密文区分
+var envelopeMagic = []byte("HCMENV1:")
+
+// Envelope 信封加密实现。每个进程生成一个数据密钥(DEK)用于加密数据，DEK由KeyProvider提供的主密钥(KEK)加密后
+// 与密文一起存储，密文格式为:
+//
+//	magic | len(keyID) | keyID | len(wrappedDEK) | wrappedDEK | nonce | ciphertext
+//
+// 解密时如果密文不带magic前缀，则认为是历史AES Gcm密文，使用legacy解密器解密，从而支持平滑迁移。
+// 主密钥轮换时只需调整ActiveKeyID，并保留旧主密钥用于解密历史数据。
+type Envelope struct {
+	provider KeyProvider
+	legacy   Crypto
+
+	// header 当前数据密钥对应的密文头部，包含magic、主密钥ID及被加密的DEK
+	header []byte
+	aead   cipher.AEAD
+
+	// dekCache 缓存已解密的数据密钥，key为主密钥ID+被加密的DEK
+	dekCache sync.Map
+}
+
+var (
+	_ Crypto  = new(Envelope)
+	_ Rotator = new(Envelope)
+)
+
+// NewEnvelope 创建信封加密器，legacy用于解密历史密文，可为nil
+func NewEnvelope(provider KeyProvider, legacy Crypto) (*Envelope, error) {
+	if provider == nil {
+		return nil, errors.New("key provider is required")
+	}
+
+	dek := make([]byte, dekSize)
+	if _, err := io.ReadFull(rand.Reader, dek); err != nil {
+		return nil, fmt.Errorf("generate data key failed, err: %v", err)
+	}
+
+	keyID := provider.ActiveKeyID()
+	if len(keyID) == 0 || len(keyID) > maxKeyIDLen {
+		return nil, fmt.Errorf("invalid active key id %q", keyID)
+	}
+
+	wrapped, err := provider.WrapKey(dek)
+	if err != nil {
+		return nil, fmt.Errorf("wrap data key failed, err: %v", err)
+	}
+
+	if len(wrapped) > 0xFFFF {
+		return nil, errors.New("wrapped data key is too long")
+	}
+
+	aead, err := newGcm(dek)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, len(envelopeMagic)+1+len(keyID)+2+len(wrapped))
+	header = append(header, envelopeMagic...)
+	header = append(header, byte(len(keyID)))
+	header = append(header, keyID...)
+	header = binary.BigEndian.AppendUint16(header, uint16(len(wrapped)))
+	header = append(header, wrapped...)
+
+	e := &Envelope{
+		provider: provider,
+		legacy:   legacy,
+		header:   header,
+		aead:     aead,
+	}
+	e.dekCache.Store(string(header[len(envelopeMagic):]), aead)
+
+	return e, nil
+}
+
+// Encrypt 使用当前数据密钥加密明文
+func (e *Envelope) Encrypt(plaintext []byte) []byte {
+	nonce := make([]byte, e.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		// 系统随机数源不可用时无法保证加密安全，不允许降级
+		panic(fmt.Sprintf("read random nonce failed, err: %v", err))
+	}
+
+	out := make([]byte, 0, len(e.header)+len(nonce)+len(plaintext)+e.aead.Overhead())
+	out = append(out, e.header...)
+	out = append(out, nonce...)
+	return e.aead.Seal(out, nonce, plaintext, nil)
+}
+
+// Decrypt 解密信封密文，非信封密文交由legacy解密器处理
+func (e *Envelope) Decrypt(encryptedText []byte) ([]byte, error) {
+	if !IsEnvelope(encryptedText) {
+		if e.legacy == nil {
+			return nil, errors.New("ciphertext is not envelope encrypted")
+		}
+		return e.legacy.Decrypt(encryptedText)
+	}
+
+	data := encryptedText[len(envelopeMagic):]
+	if len(data) < 1 {
+		return nil, errors.New("invalid envelope ciphertext")
+	}
+
+	keyIDLen := int(data[0])
+	if len(data) < 1+keyIDLen+2 {
+		return nil, errors.New("invalid envelope ciphertext")
+	}
+
+	wrappedLen := int(binary.BigEndian.Uint16(data[1+keyIDLen:]))
+	headerLen := 1 + keyIDLen + 2 + wrappedLen
+	if len(data) < headerLen {
+		return nil, errors.New("invalid envelope ciphertext")
+	}
+
+	aead, err := e.dataKey(data[:headerLen], keyIDLen)
+	if err != nil {
+		return nil, err
+	}
+
+	body := data[headerLen:]
+	if len(body) < aead.NonceSize() {
+		return nil, errors.New("invalid envelope ciphertext")
+	}
+
+	return aead.Open(nil, body[:aead.NonceSize()], body[aead.NonceSize():], nil)
+}
+
+// dataKey 获取密文头部对应的数据密钥，优先从缓存获取
+func (e *Envelope) dataKey(header []byte, keyIDLen int) (cipher.AEAD, error) {
+	if cached, ok := e.dekCache.Load(string(header)); ok {
+		return cached.(cipher.AEAD), nil
+	}
+
+	keyID := string(header[1 : 1+keyIDLen])
+	dek, err := e.provider.UnwrapKey(keyID, header[1+keyIDLen+2:])
+	if err != nil {
+		return nil, fmt.Errorf("unwrap data key by key %s failed, err: %v", keyID, err)
+	}
+
+	aead, err := newGcm(dek)
+	if err != nil {
+		return nil, err
+	}
+	e.dekCache.Store(string(header), aead)
+
+	return aead, nil
+}
+
+// EncryptToString encrypts plaintext to string
+func (e *Envelope) EncryptToString(plaintext []byte) string {
+	return conv.BytesToString(e.Encrypt(plaintext))
+}
+
+// DecryptString decrypts ciphertext string
+func (e *Envelope) DecryptString(encryptedText string) ([]byte, error) {
+	return e.Decrypt(conv.StringToBytes(encryptedText))
+}
+
+// EncryptToBase64 将字符串明文加密后转化为Base64格式的字符串
+func (e *Envelope) EncryptToBase64(plaintext string) string {
+	return base64.StdEncoding.EncodeToString(e.Encrypt(conv.StringToBytes(plaintext)))
+}
+
+// DecryptFromBase64 将Base64格式的密文解密为明文字符串
+func (e *Envelope) DecryptFromBase64(encryptedTextB64 string) (string, error) {
+	encryptedText, err := base64.StdEncoding.DecodeString(encryptedTextB64)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := e.Decrypt(encryptedText)
+	if err != nil {
+		return "", err
+	}
+
+	return conv.BytesToString(plaintext), nil
+}
+
+// NeedRotate 判断Base64格式的密文是否需要使用当前主密钥重新加密，历史AES Gcm密文及非当前主密钥加密的密文均需要轮换
+func (e *Envelope) NeedRotate(encryptedTextB64 string) (bool, error) {
+	keyID, err := KeyIDOf(encryptedTextB64)
+	if err != nil {
+		return false, err
+	}
+
+	return keyID != e.provider.ActiveKeyID(), nil
+}
+
+// Rotate 使用当前主密钥重新加密Base64格式的密文
+func (e *Envelope) Rotate(encryptedTextB64 string) (string, error) {
+	plaintext, err := e.DecryptFromBase64(encryptedTextB64)
+	if err != nil {
+		return "", err
+	}
+
+	return e.EncryptToBase64(plaintext), nil
+}
+
+// KeyIDOf 返回Base64格式密文加密时使用的主密钥ID，历史AES Gcm密文没有主密钥ID，返回LegacyKeyID
+func KeyIDOf(encryptedTextB64 string) (string, error) {
+	encryptedText, err := base64.StdEncoding.DecodeString(encryptedTextB64)
+	if err != nil {
+		return "", err
+	}
+
+	if !IsEnvelope(encryptedText) {
+		return LegacyKeyID, nil
+	}
+
+	data := encryptedText[len(envelopeMagic):]
+	if len(data) < 1 || len(data) < 1+int(data[0]) {
+		return "", errors.New("invalid envelope ciphertext")
+	}
+
+	return string(data[1 : 1+int(data[0])]), nil
+}
+
+// IsEnvelope 判断密文是否为信封加密格式
+func IsEnvelope(encryptedText []byte) bool {
+	return bytes.HasPrefix(encryptedText, envelopeMagic)
+}
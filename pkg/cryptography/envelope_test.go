@@ -0,0 +1,145 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cryptography
+
+import (
+	"testing"
+)
+
+func TestEnvelopeRotate(t *testing.T) {
+	legacy, err := NewAESGcm([]byte("0123456789abcdef"), []byte("0123456789ab"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := map[string]string{
+		"k1": "abcdefghijklmnop",
+		"k2": "abcdefghijklmnopqrstuvwxyz012345",
+	}
+
+	p1, err := NewKeyProvider(LocalKeyProvider, keys, "k1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e1, err := NewEnvelope(p1, legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	legacyText := legacy.EncryptToBase64("legacy-secret")
+	plain, err := e1.DecryptFromBase64(legacyText)
+	if err != nil || plain != "legacy-secret" {
+		t.Fatalf("decrypt legacy ciphertext failed, plain: %s, err: %v", plain, err)
+	}
+
+	k1Text := e1.EncryptToBase64("secret")
+	if k1Text == e1.EncryptToBase64("secret") {
+		t.Fatal("envelope ciphertext should use random nonce")
+	}
+
+	p2, err := NewKeyProvider(LocalKeyProvider, keys, "k2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e2, err := NewEnvelope(p2, legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	need, err := e2.NeedRotate(k1Text)
+	if err != nil || !need {
+		t.Fatalf("k1 ciphertext should need rotate, need: %v, err: %v", need, err)
+	}
+
+	k2Text, err := e2.Rotate(k1Text)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	need, err = e2.NeedRotate(k2Text)
+	if err != nil || need {
+		t.Fatalf("k2 ciphertext should not need rotate, need: %v, err: %v", need, err)
+	}
+
+	plain, err = e2.DecryptFromBase64(k2Text)
+	if err != nil || plain != "secret" {
+		t.Fatalf("decrypt rotated ciphertext failed, plain: %s, err: %v", plain, err)
+	}
+
+	for text, expect := range map[string]string{legacyText: LegacyKeyID, k1Text: "k1", k2Text: "k2"} {
+		keyID, err := KeyIDOf(text)
+		if err != nil || keyID != expect {
+			t.Fatalf("key id of ciphertext should be %s, got: %s, err: %v", expect, keyID, err)
+		}
+	}
+
+	delete(keys, "k1")
+	p3, err := NewKeyProvider(LocalKeyProvider, keys, "k2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e3, err := NewEnvelope(p3, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = e3.DecryptFromBase64(k1Text); err == nil {
+		t.Fatal("decrypt with removed key should fail")
+	}
+}
+
+func TestSmKeyProvider(t *testing.T) {
+	if _, err := NewKeyProvider(SmKeyProvider, map[string]string{"k1": "abcdefghijklmnopqrstuvwxyz012345"},
+		"k1"); err == nil {
+		t.Fatal("sm4 key should be 16 bytes")
+	}
+
+	provider, err := NewKeyProvider(SmKeyProvider, map[string]string{"k1": "abcdefghijklmnop"}, "k1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := NewEnvelope(provider, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plain, err := e.DecryptFromBase64(e.EncryptToBase64("secret"))
+	if err != nil || plain != "secret" {
+		t.Fatalf("decrypt sm envelope ciphertext failed, plain: %s, err: %v", plain, err)
+	}
+
+	local, err := NewKeyProvider(LocalKeyProvider, map[string]string{"k1": "abcdefghijklmnop"}, "k1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wrapped, err := provider.WrapKey([]byte("data-key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = local.UnwrapKey("k1", wrapped); err == nil {
+		t.Fatal("data key wrapped by sm4 should not be unwrapped by aes with the same key")
+	}
+}
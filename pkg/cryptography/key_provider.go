@@ -0,0 +1,193 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cryptography
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/tjfoc/gmsm/sm4"
+)
+
+// KeyProviderKind 密钥提供方类型
+type KeyProviderKind string
+
+const (
+	// LocalKeyProvider 本地配置的AES主密钥
+	LocalKeyProvider KeyProviderKind = "local"
+	// SmKeyProvider 本地配置的国密(SM4)主密钥
+	SmKeyProvider KeyProviderKind = "sm"
+)
+
+// KeyProvider 主密钥(KEK)提供方，负责对数据密钥(DEK)进行加密和解密。
+// 同一个KeyProvider可以持有多个主密钥，加密时总是使用ActiveKeyID对应的主密钥，
+// 解密时根据密文中记录的密钥ID选择主密钥，从而支持主密钥轮换。
+type KeyProvider interface {
+	// ActiveKeyID 返回当前用于加密的主密钥ID
+	ActiveKeyID() string
+	// WrapKey 使用当前主密钥加密数据密钥
+	WrapKey(dek []byte) ([]byte, error)
+	// UnwrapKey 使用指定ID的主密钥解密数据密钥
+	UnwrapKey(keyID string, wrapped []byte) ([]byte, error)
+}
+
+// KeyProviderFactory 根据主密钥集合及当前主密钥ID创建KeyProvider
+type KeyProviderFactory func(keys map[string]string, activeKeyID string) (KeyProvider, error)
+
+var (
+	providerLock      sync.RWMutex
+	providerFactories = map[KeyProviderKind]KeyProviderFactory{
+		LocalKeyProvider: NewLocalKeyProvider,
+		SmKeyProvider:    NewSmKeyProvider,
+	}
+)
+
+// RegisterKeyProvider 注册密钥提供方，用于接入KMS等外部实现
+func RegisterKeyProvider(kind KeyProviderKind, factory KeyProviderFactory) {
+	providerLock.Lock()
+	defer providerLock.Unlock()
+
+	providerFactories[kind] = factory
+}
+
+// NewKeyProvider 根据类型创建密钥提供方
+func NewKeyProvider(kind KeyProviderKind, keys map[string]string, activeKeyID string) (KeyProvider, error) {
+	providerLock.RLock()
+	factory, exist := providerFactories[kind]
+	providerLock.RUnlock()
+
+	if !exist {
+		return nil, fmt.Errorf("key provider %s is not registered", kind)
+	}
+
+	return factory(keys, activeKeyID)
+}
+
+// localKeyProvider 使用本地配置的主密钥加密数据密钥
+type localKeyProvider struct {
+	activeKeyID string
+	keks        map[string]cipher.AEAD
+}
+
+// NewLocalKeyProvider 创建本地AES主密钥提供方，主密钥长度需为16或32字节
+func NewLocalKeyProvider(keys map[string]string, activeKeyID string) (KeyProvider, error) {
+	return newLocalKeyProvider(keys, activeKeyID, newGcm)
+}
+
+// NewSmKeyProvider 创建本地国密主密钥提供方，使用SM4-GCM加密数据密钥，主密钥长度需为16字节
+func NewSmKeyProvider(keys map[string]string, activeKeyID string) (KeyProvider, error) {
+	return newLocalKeyProvider(keys, activeKeyID, newSm4Gcm)
+}
+
+func newLocalKeyProvider(keys map[string]string, activeKeyID string,
+	newAEAD func(key []byte) (cipher.AEAD, error)) (KeyProvider, error) {
+
+	if len(keys) == 0 {
+		return nil, errors.New("local key provider requires at least one key")
+	}
+
+	if _, exist := keys[activeKeyID]; !exist {
+		return nil, fmt.Errorf("active key %s not found", activeKeyID)
+	}
+
+	keks := make(map[string]cipher.AEAD, len(keys))
+	for id, key := range keys {
+		if len(id) == 0 || len(id) > maxKeyIDLen {
+			return nil, fmt.Errorf("invalid key id %q, length should be 1-%d", id, maxKeyIDLen)
+		}
+
+		aead, err := newAEAD([]byte(key))
+		if err != nil {
+			return nil, fmt.Errorf("init key %s failed, err: %v", id, err)
+		}
+		keks[id] = aead
+	}
+
+	return &localKeyProvider{activeKeyID: activeKeyID, keks: keks}, nil
+}
+
+// ActiveKeyID returns current key id used to wrap data keys.
+func (l *localKeyProvider) ActiveKeyID() string {
+	return l.activeKeyID
+}
+
+// WrapKey encrypt data key with active key.
+func (l *localKeyProvider) WrapKey(dek []byte) ([]byte, error) {
+	return sealWithRandomNonce(l.keks[l.activeKeyID], dek)
+}
+
+// UnwrapKey decrypt data key with key of the given id.
+func (l *localKeyProvider) UnwrapKey(keyID string, wrapped []byte) ([]byte, error) {
+	aead, exist := l.keks[keyID]
+	if !exist {
+		return nil, fmt.Errorf("key %s not found", keyID)
+	}
+
+	return openWithNonce(aead, wrapped)
+}
+
+func newGcm(key []byte) (cipher.AEAD, error) {
+	if len(key) != 16 && len(key) != 32 {
+		return nil, errors.New("invalid key, should be 16 or 32 bytes")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func newSm4Gcm(key []byte) (cipher.AEAD, error) {
+	if len(key) != sm4.BlockSize {
+		return nil, fmt.Errorf("invalid key, should be %d bytes", sm4.BlockSize)
+	}
+
+	block, err := sm4.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// sealWithRandomNonce 使用随机nonce加密，输出为 nonce + 密文
+func sealWithRandomNonce(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func openWithNonce(aead cipher.AEAD, data []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	return aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+}
@@ -30,3 +30,9 @@ type Crypto interface {
 	EncryptToBase64(plaintext string) string
 	DecryptFromBase64(encryptedTextB64 string) (string, error)
 }
+
+// Rotator 支持主密钥轮换的加解密器，判断Base64格式的密文是否需要使用当前主密钥重新加密并执行重新加密
+type Rotator interface {
+	NeedRotate(encryptedTextB64 string) (bool, error)
+	Rotate(encryptedTextB64 string) (string, error)
+}
@@ -0,0 +1,196 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package accountgroup account group and account group relation dao.
+package accountgroup
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tableaccountgroup "hcm/pkg/dal/table/account-group"
+	"hcm/pkg/dal/table/utils"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// AccountGroupInterface only used for account group.
+type AccountGroupInterface interface {
+	Create(kt *kit.Kit, model *tableaccountgroup.AccountGroupTable) (string, error)
+	UpdateByID(kt *kit.Kit, id string, model *tableaccountgroup.AccountGroupTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tableaccountgroup.AccountGroupTable], error)
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+}
+
+var _ AccountGroupInterface = new(AccountGroupDao)
+
+// AccountGroupDao account group dao.
+type AccountGroupDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// Create account group.
+func (dao AccountGroupDao) Create(kt *kit.Kit, model *tableaccountgroup.AccountGroupTable) (string, error) {
+	if model == nil {
+		return "", errf.New(errf.InvalidParameter, "model is required")
+	}
+
+	id, err := dao.IDGen.One(kt, table.AccountGroupTable)
+	if err != nil {
+		return "", err
+	}
+	model.ID = id
+	model.TenantID = kt.GetTenantID()
+
+	if err = model.InsertValidate(); err != nil {
+		return "", err
+	}
+
+	sql := tableaccountgroup.AccountGroupColumns.InsertExpr(string(model.TableName()))
+
+	if err = dao.Orm.Do().Insert(kt.Ctx, sql, model); err != nil {
+		if errf.IsDuplicated(err) {
+			return "", err
+		}
+		logs.Errorf("insert %s failed, err: %v, sql: %s, rid: %s", model.TableName(), err, sql, kt.Rid)
+		return "", fmt.Errorf("insert %s failed, err: %w", model.TableName(), err)
+	}
+
+	return id, nil
+}
+
+// UpdateByID update account group by id.
+func (dao AccountGroupDao) UpdateByID(kt *kit.Kit, id string, model *tableaccountgroup.AccountGroupTable) error {
+	if len(id) == 0 {
+		return errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := model.UpdateValidate(); err != nil {
+		return err
+	}
+
+	opts := utils.NewFieldOptions().AddIgnoredFields(types.DefaultIgnoredFields...)
+	if model.ParentID != nil {
+		// 父账号组可以被更新为空，表示调整为顶层账号组
+		opts.AddBlankedFields("parent_id")
+	}
+	if model.Memo != nil {
+		opts.AddBlankedFields("memo")
+	}
+	setExpr, toUpdate, err := utils.RearrangeSQLDataWithOption(model, opts)
+	if err != nil {
+		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, model.TableName(), setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
+
+	toUpdate["id"] = id
+	effected, err := dao.Orm.Do().Update(kt.Ctx, sql, toUpdate)
+	if err != nil {
+		logs.ErrorJson("update account group failed, err: %v, id: %s, rid: %v", err, id, kt.Rid)
+		return err
+	}
+
+	if effected == 0 {
+		return errf.Newf(errf.RecordNotFound, "account group: %s not found", id)
+	}
+
+	return nil
+}
+
+// List account group.
+func (dao AccountGroupDao) List(kt *kit.Kit, opt *types.ListOption) (
+	*types.ListResult[tableaccountgroup.AccountGroupTable], error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(
+		tableaccountgroup.AccountGroupColumns.ColumnTypes())), core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is dao count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AccountGroupTable, whereExpr)
+
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count account group failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[tableaccountgroup.AccountGroupTable]{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tableaccountgroup.AccountGroupColumns.FieldsNamedExpr(opt.Fields),
+		table.AccountGroupTable, whereExpr, pageExpr)
+
+	details := make([]tableaccountgroup.AccountGroupTable, 0)
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AccountGroupTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
+		logs.Errorf("select account group failed, err: %v, sql: %s, rid: %s", err, sql, kt.Rid)
+		return nil, err
+	}
+
+	return &types.ListResult[tableaccountgroup.AccountGroupTable]{Count: count, Details: details}, nil
+}
+
+// DeleteWithTx account group with tx.
+func (dao AccountGroupDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error {
+	if expr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.AccountGroupTable, whereExpr)
+	if _, err = dao.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete account group failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package accountgroup
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tableaccountgroup "hcm/pkg/dal/table/account-group"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// AccountGroupRelInterface only used for account group relation.
+type AccountGroupRelInterface interface {
+	BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, rels []tableaccountgroup.AccountGroupRelTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tableaccountgroup.AccountGroupRelTable], error)
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+}
+
+var _ AccountGroupRelInterface = new(AccountGroupRelDao)
+
+// AccountGroupRelDao account group relation dao.
+type AccountGroupRelDao struct {
+	Orm orm.Interface
+}
+
+// BatchCreateWithTx account group relation with tx.
+func (dao AccountGroupRelDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx,
+	rels []tableaccountgroup.AccountGroupRelTable) error {
+
+	if len(rels) == 0 {
+		return errf.New(errf.InvalidParameter, "account group rels is required")
+	}
+
+	for idx := range rels {
+		rels[idx].TenantID = kt.GetTenantID()
+		if err := rels[idx].InsertValidate(); err != nil {
+			return errf.NewFromErr(errf.InvalidParameter, err)
+		}
+	}
+
+	sql := tableaccountgroup.AccountGroupRelColumns.InsertExpr(string(table.AccountGroupRelTable))
+
+	if err := dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, rels); err != nil {
+		if errf.IsDuplicated(err) {
+			return err
+		}
+		return fmt.Errorf("insert %s failed, err: %w", table.AccountGroupRelTable, err)
+	}
+
+	return nil
+}
+
+// List account group relation.
+func (dao AccountGroupRelDao) List(kt *kit.Kit, opt *types.ListOption) (
+	*types.ListResult[tableaccountgroup.AccountGroupRelTable], error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(
+		tableaccountgroup.AccountGroupRelColumns.ColumnTypes())), core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is dao count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AccountGroupRelTable, whereExpr)
+
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count account group rel failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[tableaccountgroup.AccountGroupRelTable]{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`,
+		tableaccountgroup.AccountGroupRelColumns.FieldsNamedExpr(opt.Fields), table.AccountGroupRelTable,
+		whereExpr, pageExpr)
+
+	details := make([]tableaccountgroup.AccountGroupRelTable, 0)
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AccountGroupRelTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
+		logs.Errorf("select account group rel failed, err: %v, sql: %s, rid: %s", err, sql, kt.Rid)
+		return nil, err
+	}
+
+	return &types.ListResult[tableaccountgroup.AccountGroupRelTable]{Count: count, Details: details}, nil
+}
+
+// DeleteWithTx account group relation with tx.
+func (dao AccountGroupRelDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error {
+	if expr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.AccountGroupRelTable, whereExpr)
+	if _, err = dao.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete account group rel failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package accountgroup
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
+	tableaccountgroup "hcm/pkg/dal/table/account-group"
+	"hcm/pkg/kit"
+)
+
+// fakeOrm records the insert and update statements of account group.
+type fakeOrm struct {
+	orm.Interface
+	orm.DoOrm
+	inserted   *tableaccountgroup.AccountGroupTable
+	sql        string
+	updateArgs map[string]interface{}
+	effected   int64
+}
+
+// Do ...
+func (f *fakeOrm) Do() orm.DoOrm {
+	return f
+}
+
+// Insert ...
+func (f *fakeOrm) Insert(_ context.Context, sql string, args interface{}) error {
+	f.sql = sql
+	f.inserted = args.(*tableaccountgroup.AccountGroupTable)
+	return nil
+}
+
+// Update ...
+func (f *fakeOrm) Update(_ context.Context, sql string, args map[string]interface{}) (int64, error) {
+	f.sql = sql
+	f.updateArgs = args
+	return f.effected, nil
+}
+
+type fakeIDGen struct{}
+
+// Batch ...
+func (fakeIDGen) Batch(_ *kit.Kit, _ table.Name, count int) ([]string, error) {
+	return make([]string, count), nil
+}
+
+// One ...
+func (fakeIDGen) One(_ *kit.Kit, _ table.Name) (string, error) {
+	return "00000001", nil
+}
+
+func TestAccountGroupCreate(t *testing.T) {
+	tools.EnableTenant(true)
+	defer tools.EnableTenant(false)
+
+	fake := new(fakeOrm)
+	dao := AccountGroupDao{Orm: fake, IDGen: fakeIDGen{}}
+	kt := kit.New()
+	kt.TenantID = "tenant1"
+
+	id, err := dao.Create(kt, &tableaccountgroup.AccountGroupTable{Name: "prod", Creator: "admin", Reviser: "admin"})
+	if err != nil {
+		t.Fatalf("create account group failed, err: %v", err)
+	}
+	if id != "00000001" || fake.inserted.ID != id || fake.inserted.TenantID != "tenant1" {
+		t.Errorf("account group should be created with generated id and tenant, got: %+v", fake.inserted)
+	}
+
+	if _, err = dao.Create(kt, &tableaccountgroup.AccountGroupTable{Creator: "admin"}); err == nil {
+		t.Errorf("account group without name should be invalid")
+	}
+}
+
+func TestAccountGroupUpdateByID(t *testing.T) {
+	tools.EnableTenant(true)
+	defer tools.EnableTenant(false)
+
+	fake := &fakeOrm{effected: 1}
+	dao := AccountGroupDao{Orm: fake, IDGen: fakeIDGen{}}
+	kt := kit.New()
+	kt.TenantID = "tenant1"
+
+	// 父账号组更新为空，表示调整为顶层账号组
+	parentID := ""
+	err := dao.UpdateByID(kt, "00000002", &tableaccountgroup.AccountGroupTable{ParentID: &parentID, Reviser: "admin"})
+	if err != nil {
+		t.Fatalf("update account group failed, err: %v", err)
+	}
+	if value, ok := fake.updateArgs["parent_id"].(*string); !ok || *value != "" {
+		t.Errorf("parent id should be updated to blank, args: %v", fake.updateArgs)
+	}
+	if _, exists := fake.updateArgs["memo"]; exists {
+		t.Errorf("memo should not be updated, args: %v", fake.updateArgs)
+	}
+	if !strings.Contains(fake.sql, "tenant_id") || fake.updateArgs["id"] != "00000002" {
+		t.Errorf("only the account group of the tenant should be updated, sql: %s, args: %v", fake.sql,
+			fake.updateArgs)
+	}
+
+	fake.effected = 0
+	err = dao.UpdateByID(kt, "00000003", &tableaccountgroup.AccountGroupTable{Name: "test", Reviser: "admin"})
+	if ef := errf.Error(err); ef == nil || ef.Code != errf.RecordNotFound {
+		t.Errorf("update not exist account group should return not found, err: %v", err)
+	}
+}
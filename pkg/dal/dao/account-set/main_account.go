@@ -69,14 +69,13 @@ func (a MainAccountDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *tableaccou
 		return "", err
 	}
 	model.ID = id
+	model.TenantID = kt.GetTenantID()
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`,
-		model.TableName(), tableaccountset.MainAccountColumns.ColumnExpr(),
-		tableaccountset.MainAccountColumns.ColonNameExpr())
+	sql := tableaccountset.MainAccountColumns.InsertExpr(string(model.TableName()))
 
 	err = a.Orm.Txn(tx).Insert(kt.Ctx, sql, model)
 	if err != nil {
-		return "", fmt.Errorf("insert %s failed, err: %v", model.TableName(), err)
+		return "", fmt.Errorf("insert %s failed, err: %w", model.TableName(), err)
 	}
 
 	// create audit.
@@ -117,7 +116,7 @@ func (a MainAccountDao) Update(kt *kit.Kit, filterExpr *filter.Expression,
 		return err
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -156,7 +155,7 @@ func (ma MainAccountDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListMa
 	if opt == nil {
 		return nil, errf.New(errf.InvalidParameter, "list account options is nil")
 	}
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -182,9 +181,11 @@ func (ma MainAccountDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListMa
 		table.MainAccountTable, whereExpr, pageExpr)
 
 	details := make([]*tableaccountset.MainAccountTable, 0)
-	if err = ma.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.MainAccountTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, ma.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.ListMainAccountDetails{Count: 0, Details: details}, nil
+	return &types.ListMainAccountDetails{Count: count, Details: details}, nil
 }
@@ -67,7 +67,7 @@ func (ra RootAccountDao) ListVendor(kt *kit.Kit, opt *types.ListOption) (*types.
 	if opt.Page == nil || opt.Filter == nil {
 		return nil, errf.New(errf.InvalidParameter, "list root account vendor options page or filter is nil")
 	}
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -121,14 +121,13 @@ func (a RootAccountDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *tableaccou
 		return "", err
 	}
 	model.ID = id
+	model.TenantID = kt.GetTenantID()
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`,
-		model.TableName(), tableaccountset.RootAccountColumns.ColumnExpr(),
-		tableaccountset.RootAccountColumns.ColonNameExpr())
+	sql := tableaccountset.RootAccountColumns.InsertExpr(string(model.TableName()))
 
 	err = a.Orm.Txn(tx).Insert(kt.Ctx, sql, model)
 	if err != nil {
-		return "", fmt.Errorf("insert %s failed, err: %v", model.TableName(), err)
+		return "", fmt.Errorf("insert %s failed, err: %w", model.TableName(), err)
 	}
 
 	// create audit.
@@ -169,7 +168,7 @@ func (a RootAccountDao) Update(kt *kit.Kit, filterExpr *filter.Expression,
 		return err
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -208,7 +207,7 @@ func (ma RootAccountDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListRo
 	if opt == nil {
 		return nil, errf.New(errf.InvalidParameter, "list root account options is nil")
 	}
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -234,9 +233,11 @@ func (ma RootAccountDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListRo
 		table.RootAccountTable, whereExpr, pageExpr)
 
 	details := make([]*tableaccountset.RootAccountTable, 0)
-	if err = ma.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.RootAccountTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, ma.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.ListRootAccountDetails{Count: 0, Details: details}, nil
+	return &types.ListRootAccountDetails{Count: count, Details: details}, nil
 }
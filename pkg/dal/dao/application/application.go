@@ -66,15 +66,13 @@ func (a *ApplicationDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *applicati
 		return "", err
 	}
 	model.ID = id
+	model.TenantID = kt.GetTenantID()
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`,
-		model.TableName(), application.ApplicationColumns.ColumnExpr(),
-		application.ApplicationColumns.ColonNameExpr(),
-	)
+	sql := application.ApplicationColumns.InsertExpr(string(model.TableName()))
 
 	err = a.Orm.Txn(tx).Insert(kt.Ctx, sql, model)
 	if err != nil {
-		return "", fmt.Errorf("insert %s failed, err: %v", model.TableName(), err)
+		return "", fmt.Errorf("insert %s failed, err: %w", model.TableName(), err)
 	}
 	return id, nil
 }
@@ -89,7 +87,7 @@ func (a *ApplicationDao) Update(kt *kit.Kit, filterExpr *filter.Expression, mode
 		return err
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -134,7 +132,7 @@ func (a *ApplicationDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListAp
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -161,9 +159,11 @@ func (a *ApplicationDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListAp
 		table.ApplicationTable, whereExpr, pageExpr)
 
 	details := make([]*application.ApplicationTable, 0)
-	if err = a.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ApplicationTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, a.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.ListApplicationDetails{Count: 0, Details: details}, nil
+	return &types.ListApplicationDetails{Count: count, Details: details}, nil
 }
@@ -67,15 +67,13 @@ func (a *ApprovalProcessDao) CreateWithTx(
 		return "", err
 	}
 	model.ID = id
+	model.TenantID = kt.GetTenantID()
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`,
-		model.TableName(), application.ApprovalProcessColumns.ColumnExpr(),
-		application.ApprovalProcessColumns.ColonNameExpr(),
-	)
+	sql := application.ApprovalProcessColumns.InsertExpr(string(model.TableName()))
 
 	err = a.Orm.Txn(tx).Insert(kt.Ctx, sql, model)
 	if err != nil {
-		return "", fmt.Errorf("insert %s failed, err: %v", model.TableName(), err)
+		return "", fmt.Errorf("insert %s failed, err: %w", model.TableName(), err)
 	}
 	return id, nil
 }
@@ -92,7 +90,7 @@ func (a *ApprovalProcessDao) Update(
 		return err
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -137,7 +135,7 @@ func (a *ApprovalProcessDao) List(kt *kit.Kit, opt *types.ListOption) (*types.Li
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -164,9 +162,11 @@ func (a *ApprovalProcessDao) List(kt *kit.Kit, opt *types.ListOption) (*types.Li
 		table.ApprovalProcessTable, whereExpr, pageExpr)
 
 	details := make([]*application.ApprovalProcessTable, 0)
-	if err = a.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ApprovalProcessTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, a.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.ListApprovalProcessDetails{Count: 0, Details: details}, nil
+	return &types.ListApprovalProcessDetails{Count: count, Details: details}, nil
 }
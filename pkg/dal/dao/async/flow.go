@@ -72,12 +72,11 @@ func (dao *AsyncFlowDao) Create(kt *kit.Kit, tx *sqlx.Tx, model *tableasync.Asyn
 		return "", err
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AsyncFlowTable,
-		tableasync.AsyncFlowColumns.ColumnExpr(), tableasync.AsyncFlowColumns.ColonNameExpr())
+	sql := tableasync.AsyncFlowColumns.InsertExpr(string(table.AsyncFlowTable))
 
 	if err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, model); err != nil {
 		logs.Errorf("insert %s failed, err: %v, sql: %s, rid: %s", table.AsyncFlowTable, err, sql, kt.Rid)
-		return "", fmt.Errorf("insert %s failed, err: %v", table.AsyncFlowTable, err)
+		return "", fmt.Errorf("insert %s failed, err: %w", table.AsyncFlowTable, err)
 	}
 
 	return id, nil
@@ -217,13 +216,12 @@ func (dao *AsyncFlowDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx,
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AsyncFlowTable,
-		tableasync.AsyncFlowColumns.ColumnExpr(), tableasync.AsyncFlowColumns.ColonNameExpr())
+	sql := tableasync.AsyncFlowColumns.InsertExpr(string(table.AsyncFlowTable))
 
 	err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models)
 	if err != nil {
 		logs.Errorf("insert %s failed, err: %v, sql: %s, rid: %s", table.AsyncFlowTable, err, sql, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", table.AsyncFlowTable, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", table.AsyncFlowTable, err)
 	}
 
 	return ids, nil
@@ -270,13 +268,15 @@ func (dao *AsyncFlowDao) ListWithTx(kt *kit.Kit, tx *sqlx.Tx,
 		table.AsyncFlowTable, whereExpr, pageExpr)
 
 	details := make([]tableasync.AsyncFlowTable, 0)
-	if err = dao.Orm.Txn(tx).Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AsyncFlowTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Txn(tx), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		logs.ErrorJson("select async flow failed, err: %v, sql: %s, filter: %v, rid: %s", err, sql,
 			opt.Filter, kt.Rid)
 		return nil, err
 	}
 
-	return &typesasync.ListAsyncFlows{Count: 0, Details: details}, nil
+	return &typesasync.ListAsyncFlows{Count: count, Details: details}, nil
 }
 
 // List async flow.
@@ -318,13 +318,15 @@ func (dao *AsyncFlowDao) List(kt *kit.Kit, opt *types.ListOption) (*typesasync.L
 		table.AsyncFlowTable, whereExpr, pageExpr)
 
 	details := make([]tableasync.AsyncFlowTable, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AsyncFlowTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		logs.ErrorJson("select async flow failed, err: %v, sql: %s, filter: %v, rid: %s", err, sql,
 			opt.Filter, kt.Rid)
 		return nil, err
 	}
 
-	return &typesasync.ListAsyncFlows{Count: 0, Details: details}, nil
+	return &typesasync.ListAsyncFlows{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx async flow with tx.
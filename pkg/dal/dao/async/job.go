@@ -0,0 +1,105 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package daoasync
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+)
+
+// AsyncJob only used for async job view.
+type AsyncJob interface {
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tableasync.AsyncJobTable], error)
+}
+
+var _ AsyncJob = new(AsyncJobDao)
+
+// AsyncJobDao async job dao.
+type AsyncJobDao struct {
+	Orm orm.Interface
+}
+
+// List async job.
+func (dao *AsyncJobDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tableasync.AsyncJobTable],
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list async job options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tableasync.AsyncJobColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is a count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AsyncJobTable, whereExpr)
+
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count async job failed, err: %v, filter: %v, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[tableasync.AsyncJobTable]{Count: count}, nil
+	}
+
+	// the id of view is not unique between kinds, so sort by the newest by default.
+	page := *opt.Page
+	if len(page.Sort) == 0 && len(page.Sorts) == 0 {
+		page.Sorts = []core.SortField{{Field: "created_at", Order: core.Descending}, {Field: "kind"},
+			{Field: "id"}}
+	}
+
+	pageExpr, err := types.PageSQLExpr(&page,
+		types.DefaultPageSQLOption.WithSortFields(tableasync.AsyncJobColumns.ColumnTypes()))
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tableasync.AsyncJobColumns.FieldsNamedExpr(opt.Fields),
+		table.AsyncJobTable, whereExpr, pageExpr)
+
+	details := make([]tableasync.AsyncJobTable, 0)
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AsyncJobTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
+		logs.Errorf("select async job failed, err: %v, sql: %s, rid: %s", err, sql, kt.Rid)
+		return nil, err
+	}
+
+	return &types.ListResult[tableasync.AsyncJobTable]{Count: count, Details: details}, nil
+}
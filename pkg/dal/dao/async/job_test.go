@@ -0,0 +1,76 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package daoasync
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+)
+
+// fakeOrm records the select statement.
+type fakeOrm struct {
+	orm.Interface
+	orm.DoOrm
+	sql string
+}
+
+// Do ...
+func (f *fakeOrm) Do() orm.DoOrm {
+	return f
+}
+
+// Select ...
+func (f *fakeOrm) Select(_ context.Context, _ interface{}, expr string, _ map[string]interface{}) error {
+	f.sql = expr
+	return nil
+}
+
+func TestAsyncJobListSort(t *testing.T) {
+	fake := new(fakeOrm)
+	dao := &AsyncJobDao{Orm: fake}
+
+	// the id of view is not unique between kinds, so the default sort contains kind and id.
+	opt := &types.ListOption{Filter: tools.AllExpression(), Page: core.NewDefaultBasePage()}
+	if _, err := dao.List(kit.New(), opt); err != nil {
+		t.Fatalf("list async job failed, err: %v", err)
+	}
+	if !strings.Contains(fake.sql, "ORDER BY created_at DESC, kind ASC, id ASC") {
+		t.Errorf("async job should be sorted by the newest by default, sql: %s", fake.sql)
+	}
+	if len(opt.Page.Sorts) != 0 {
+		t.Errorf("the default sort should not change the page of option")
+	}
+
+	page := core.NewDefaultBasePage()
+	page.Sort = "updated_at"
+	if _, err := dao.List(kit.New(), &types.ListOption{Filter: tools.AllExpression(), Page: page}); err != nil {
+		t.Fatalf("list async job failed, err: %v", err)
+	}
+	if !strings.Contains(fake.sql, "ORDER BY updated_at") || strings.Contains(fake.sql, "kind ASC") {
+		t.Errorf("the specified sort should be used, sql: %s", fake.sql)
+	}
+}
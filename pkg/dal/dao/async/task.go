@@ -75,12 +75,11 @@ func (dao *AsyncFlowTaskDao) BatchCreate(kt *kit.Kit, models []tableasync.AsyncF
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AsyncFlowTaskTable,
-		tableasync.AsyncFlowTaskColumns.ColumnExpr(), tableasync.AsyncFlowTaskColumns.ColonNameExpr())
+	sql := tableasync.AsyncFlowTaskColumns.InsertExpr(string(table.AsyncFlowTaskTable))
 
 	if err = dao.Orm.Do().BulkInsert(kt.Ctx, sql, models); err != nil {
 		logs.Errorf("insert %s failed, err: %v, sql: %s, rid: %s", table.AsyncFlowTaskTable, err, sql, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", table.AsyncFlowTaskTable, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", table.AsyncFlowTaskTable, err)
 	}
 
 	return ids, nil
@@ -220,13 +219,12 @@ func (dao *AsyncFlowTaskDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx,
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AsyncFlowTaskTable,
-		tableasync.AsyncFlowTaskColumns.ColumnExpr(), tableasync.AsyncFlowTaskColumns.ColonNameExpr())
+	sql := tableasync.AsyncFlowTaskColumns.InsertExpr(string(table.AsyncFlowTaskTable))
 
 	err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models)
 	if err != nil {
 		logs.Errorf("insert %s failed, err: %v, sql: %s, rid: %s", table.AsyncFlowTaskTable, err, sql, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", table.AsyncFlowTaskTable, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", table.AsyncFlowTaskTable, err)
 	}
 
 	return ids, nil
@@ -271,13 +269,15 @@ func (dao *AsyncFlowTaskDao) List(kt *kit.Kit, opt *types.ListOption) (*typesasy
 		table.AsyncFlowTaskTable, whereExpr, pageExpr)
 
 	details := make([]tableasync.AsyncFlowTaskTable, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AsyncFlowTaskTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		logs.ErrorJson("select async flow task failed, err: %v, sql: %s, filter: %v, rid: %s", err, sql,
 			opt.Filter, kt.Rid)
 		return nil, err
 	}
 
-	return &typesasync.ListAsyncFlowTasks{Count: 0, Details: details}, nil
+	return &typesasync.ListAsyncFlowTasks{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx async flow task with tx.
@@ -22,8 +22,10 @@ package audit
 
 import (
 	"fmt"
+	"time"
 
 	"hcm/pkg/api/core"
+	"hcm/pkg/auditsink"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/orm"
@@ -44,6 +46,8 @@ type Interface interface {
 	BatchCreate(kt *kit.Kit, audits []*audit.AuditTable) error
 	BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, audits []*audit.AuditTable) error
 	List(kt *kit.Kit, opt *types.ListOption) (*types.ListAuditDetails, error)
+	DeleteBefore(kt *kit.Kit, before time.Time, limit uint) (int64, error)
+	ListAfter(kt *kit.Kit, after uint64, before time.Time, limit uint) ([]audit.AuditTable, error)
 }
 
 var _ Interface = new(Dao)
@@ -68,36 +72,42 @@ func (d Dao) Create(kt *kit.Kit, one *audit.AuditTable) error {
 // BatchCreate batch create audit.
 func (d Dao) BatchCreate(kt *kit.Kit, audits []*audit.AuditTable) error {
 	for _, one := range audits {
+		one.TenantID = kt.GetTenantID()
 		if err := one.CreateValidate(); err != nil {
 			return err
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AuditTable,
-		audit.AuditColumns.ColumnExpr(), audit.AuditColumns.ColonNameExpr())
+	sql := audit.AuditColumns.InsertExpr(string(table.AuditTable))
 
 	if err := d.Orm.Do().BulkInsert(kt.Ctx, sql, audits); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", table.AuditTable, err, kt.Rid)
-		return fmt.Errorf("insert %s failed, err: %v", table.AuditTable, err)
+		return fmt.Errorf("insert %s failed, err: %w", table.AuditTable, err)
 	}
 
+	auditsink.Publish(audits...)
+
 	return nil
 }
 
 // BatchCreateWithTx batch create audit with tx.
 func (d Dao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, audits []*audit.AuditTable) error {
 	for _, one := range audits {
+		one.TenantID = kt.GetTenantID()
 		if err := one.CreateValidate(); err != nil {
 			return err
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AuditTable,
-		audit.AuditColumns.ColumnExpr(), audit.AuditColumns.ColonNameExpr())
+	sql := audit.AuditColumns.InsertExpr(string(table.AuditTable))
 
 	if err := d.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, audits); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", table.AuditTable, err, kt.Rid)
-		return fmt.Errorf("insert %s failed, err: %v", table.AuditTable, err)
+		return fmt.Errorf("insert %s failed, err: %w", table.AuditTable, err)
+	}
+
+	if auditsink.Enabled() {
+		orm.AfterCommit(tx, func() { auditsink.Publish(audits...) })
 	}
 
 	return nil
@@ -115,7 +125,7 @@ func (d Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListAuditDetails,
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -141,9 +151,47 @@ func (d Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListAuditDetails,
 		table.AuditTable, whereExpr, pageExpr)
 
 	details := make([]audit.AuditTable, 0)
-	if err = d.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AuditTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, d.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ListAuditDetails{Count: count, Details: details}, nil
+}
+
+// ListAfter 按ID升序列出ID大于 after 且创建时间不晚于 before 的审计记录，每次最多 limit 条。
+// 不按租户过滤，仅用于平台级的变更事件投递
+func (d Dao) ListAfter(kt *kit.Kit, after uint64, before time.Time, limit uint) ([]audit.AuditTable, error) {
+	if limit == 0 {
+		return nil, errf.New(errf.InvalidParameter, "limit is required")
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s WHERE id > :after AND created_at <= :before ORDER BY id LIMIT %d`,
+		audit.AuditColumns.FieldsNamedExpr(nil), table.AuditTable, limit)
+
+	details := make([]audit.AuditTable, 0)
+	arg := map[string]interface{}{"after": after, "before": before}
+	if err := d.Orm.Do().Select(kt.Ctx, &details, sql, arg); err != nil {
+		logs.Errorf("list audit after %d failed, err: %v, rid: %s", after, err, kt.Rid)
 		return nil, err
 	}
 
-	return &types.ListAuditDetails{Details: details}, nil
+	return details, nil
+}
+
+// DeleteBefore 删除创建时间早于 before 的审计记录，每次最多删除 limit 条，避免大事务长时间锁表
+func (d Dao) DeleteBefore(kt *kit.Kit, before time.Time, limit uint) (int64, error) {
+	if limit == 0 {
+		return 0, errf.New(errf.InvalidParameter, "limit is required")
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s WHERE created_at < :before ORDER BY id LIMIT %d`, table.AuditTable, limit)
+	deleted, err := d.Orm.Do().Delete(kt.Ctx, sql, map[string]interface{}{"before": before})
+	if err != nil {
+		logs.Errorf("delete expired audit failed, err: %v, before: %v, rid: %s", err, before, kt.Rid)
+		return 0, fmt.Errorf("delete expired audit failed, err: %v", err)
+	}
+
+	return deleted, nil
 }
@@ -0,0 +1,76 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/kit"
+)
+
+// fakeOrm records the delete statement and returns the prepared deleted count.
+type fakeOrm struct {
+	orm.Interface
+	orm.DoOrm
+	deleted int64
+	sql     string
+	args    map[string]interface{}
+}
+
+// Do ...
+func (f *fakeOrm) Do() orm.DoOrm {
+	return f
+}
+
+// Delete ...
+func (f *fakeOrm) Delete(_ context.Context, expr string, args map[string]interface{}) (int64, error) {
+	f.sql = expr
+	f.args = args
+	return f.deleted, nil
+}
+
+func TestDeleteBefore(t *testing.T) {
+	fake := &fakeOrm{deleted: 500}
+	dao := Dao{Orm: fake}
+	before := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	deleted, err := dao.DeleteBefore(kit.New(), before, 1000)
+	if err != nil {
+		t.Fatalf("delete expired audit failed, err: %v", err)
+	}
+	if deleted != 500 {
+		t.Errorf("expect 500 audits deleted, but got %d", deleted)
+	}
+
+	if !strings.Contains(fake.sql, "created_at < :before") || !strings.HasSuffix(fake.sql, "ORDER BY id LIMIT 1000") {
+		t.Errorf("expired audits should be deleted in batch, sql: %s", fake.sql)
+	}
+	if fake.args["before"] != before {
+		t.Errorf("unexpected delete args: %v", fake.args)
+	}
+
+	if _, err = dao.DeleteBefore(kit.New(), before, 0); err == nil {
+		t.Errorf("delete without limit should fail")
+	}
+}
@@ -0,0 +1,449 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package daobackup inventory data logical backup and restore dao.
+package daobackup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"hcm/pkg/cryptography"
+	"hcm/pkg/dal/dao/cloud"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// parentRef 关联表通过父资源表按账号、业务过滤
+type parentRef struct {
+	Column string
+	Table  table.Name
+}
+
+// backupTables 支持备份和恢复的库存数据表，value 不为空的关联表没有账号和业务字段，通过父资源表过滤
+var backupTables = map[table.Name]*parentRef{
+	table.AccountTable:                    nil,
+	table.AccountBizRelTable:              nil,
+	table.VpcTable:                        nil,
+	table.SubnetTable:                     nil,
+	table.SecurityGroupTable:              nil,
+	table.TCloudSecurityGroupRuleTable:    nil,
+	table.AwsSecurityGroupRuleTable:       nil,
+	table.HuaWeiSecurityGroupRuleTable:    nil,
+	table.AzureSecurityGroupRuleTable:     nil,
+	table.GcpFirewallRuleTable:            nil,
+	table.CvmTable:                        nil,
+	table.DiskTable:                       nil,
+	table.EipTable:                        nil,
+	table.NetworkInterfaceTable:           nil,
+	table.RouteTableTable:                 nil,
+	table.TCloudRouteTable:                nil,
+	table.AwsRouteTable:                   nil,
+	table.AzureRouteTable:                 nil,
+	table.HuaWeiRouteTable:                nil,
+	table.GcpRouteTable:                   nil,
+	table.SslCertTable:                    nil,
+	table.ArgumentTemplateTable:           nil,
+	table.LoadBalancerTable:               nil,
+	table.LoadBalancerListenerTable:       nil,
+	table.TCloudLbUrlRuleTable:            nil,
+	table.LoadBalancerTargetGroupTable:    nil,
+	table.LoadBalancerTargetTable:         nil,
+	table.TargetGroupListenerRuleRelTable: nil,
+	table.SecurityGroupCvmTable:           {Column: "cvm_id", Table: table.CvmTable},
+	table.DiskCvmRelTableName:             {Column: "cvm_id", Table: table.CvmTable},
+	table.EipCvmRelTableName:              {Column: "cvm_id", Table: table.CvmTable},
+	table.NetworkInterfaceCvmRelTable:     {Column: "cvm_id", Table: table.CvmTable},
+	table.SecurityGroupCommonRelTable:     {Column: "security_group_id", Table: table.SecurityGroupTable},
+}
+
+// BackupTables 返回支持备份的表，按表名排序
+func BackupTables() []table.Name {
+	names := make([]table.Name, 0, len(backupTables))
+	for name := range backupTables {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+
+	return names
+}
+
+// IsBackupTable 判断表是否支持备份和恢复
+func IsBackupTable(name table.Name) bool {
+	_, exists := backupTables[name]
+	return exists
+}
+
+// ImportMode 导入时主键冲突的处理方式
+type ImportMode string
+
+const (
+	// SkipExisting 跳过已存在的记录
+	SkipExisting ImportMode = "skip"
+	// OverwriteExisting 覆盖已存在的记录
+	OverwriteExisting ImportMode = "overwrite"
+)
+
+// Record 备份数据中的一行记录，导出数据的最后一行为只包含清单的记录
+type Record struct {
+	Table    table.Name             `json:"table,omitempty"`
+	Row      map[string]interface{} `json:"row,omitempty"`
+	Manifest *Manifest              `json:"manifest,omitempty"`
+}
+
+// Manifest 导出数据清单，记录数据所属租户、各表导出的记录数及账号密钥加密使用的主密钥ID，
+// 恢复前需要确认目标环境持有 KeyIDs 中的主密钥，否则恢复后的账号密钥无法解密
+type Manifest struct {
+	TenantID string                `json:"tenant_id,omitempty"`
+	Counts   map[table.Name]uint64 `json:"counts"`
+	KeyIDs   []string              `json:"key_ids"`
+}
+
+// ExportOption 导出选项，表中没有账号、业务字段且不是关联表时，对应的过滤条件不生效
+type ExportOption struct {
+	Tables     []table.Name
+	AccountIDs []string
+	BkBizIDs   []int64
+}
+
+// Interface only used for inventory data backup and restore.
+type Interface interface {
+	// Export 在同一个一致性快照中导出租户下各表数据，handler 按表的顺序逐行处理记录，返回导出数据清单
+	Export(kt *kit.Kit, opt *ExportOption, handler func(record *Record) error) (*Manifest, error)
+	// Import 在一个事务中将记录导入到当前租户下，返回导入的记录数
+	Import(kt *kit.Kit, records []Record, mode ImportMode) (int64, error)
+}
+
+var _ Interface = new(Dao)
+
+// Dao inventory data backup and restore dao.
+type Dao struct {
+	Orm orm.Interface
+	// Cipher 账号密钥的加解密器，导入账号时用于校验密钥可以被解密
+	Cipher cryptography.Crypto
+}
+
+// Export export the tables of the kit's tenant in a consistent snapshot.
+func (d Dao) Export(kt *kit.Kit, opt *ExportOption, handler func(record *Record) error) (*Manifest, error) {
+	manifest := &Manifest{Counts: make(map[table.Name]uint64)}
+	if tools.TenantEnabled() {
+		manifest.TenantID = kt.GetTenantID()
+	}
+
+	keyIDs := make(map[string]struct{})
+	collect := func(record *Record) error {
+		manifest.Counts[record.Table]++
+
+		if record.Table == table.AccountTable {
+			extension, _ := record.Row["extension"].(string)
+			ids, err := cloud.SecretKeyIDs(extension)
+			if err != nil {
+				logs.Errorf("get account %v secret key ids failed, err: %v, rid: %s", record.Row["id"], err, kt.Rid)
+				return err
+			}
+			for _, id := range ids {
+				keyIDs[id] = struct{}{}
+			}
+		}
+
+		return handler(record)
+	}
+
+	_, err := d.Orm.SnapshotTxn(kt, func(txn *sqlx.Tx, _ *orm.TxnOption) (interface{}, error) {
+		for _, name := range opt.Tables {
+			if err := d.exportTable(kt, txn, name, opt, collect); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	manifest.KeyIDs = make([]string, 0, len(keyIDs))
+	for id := range keyIDs {
+		manifest.KeyIDs = append(manifest.KeyIDs, id)
+	}
+	sort.Strings(manifest.KeyIDs)
+
+	return manifest, nil
+}
+
+func (d Dao) exportTable(kt *kit.Kit, txn *sqlx.Tx, name table.Name, opt *ExportOption,
+	handler func(record *Record) error) error {
+
+	columns, err := d.listColumns(kt, d.Orm.Txn(txn), name)
+	if err != nil {
+		return err
+	}
+
+	where, arg := filterExpr(kt, name, columns, opt)
+	sql := fmt.Sprintf("SELECT * FROM %s %s ORDER BY id", name, where)
+
+	return d.Orm.Txn(txn).SelectRows(kt.Ctx, sql, arg, func(rows *sqlx.Rows) error {
+		row := make(map[string]interface{})
+		if err := rows.MapScan(row); err != nil {
+			logs.Errorf("scan %s row failed, err: %v, rid: %s", name, err, kt.Rid)
+			return err
+		}
+
+		for column, value := range row {
+			switch val := value.(type) {
+			case []byte:
+				row[column] = string(val)
+			case time.Time:
+				row[column] = val.Format(time.DateTime)
+			}
+		}
+
+		return handler(&Record{Table: name, Row: row})
+	})
+}
+
+// filterExpr 生成按租户、账号、业务过滤的条件，关联表通过父资源表按账号、业务过滤，没有租户字段的关联表通过父资源表按租户过滤
+func filterExpr(kt *kit.Kit, name table.Name, columns map[string]struct{}, opt *ExportOption) (string,
+	map[string]interface{}) {
+
+	conds := make([]string, 0)
+	arg := make(map[string]interface{})
+
+	_, hasTenant := columns["tenant_id"]
+	if tools.TenantEnabled() {
+		arg["tenant_id"] = kt.GetTenantID()
+		if hasTenant {
+			conds = append(conds, "tenant_id = :tenant_id")
+		}
+	}
+
+	accountCol, bizCol := "account_id", "bk_biz_id"
+	if name == table.AccountTable {
+		accountCol = "id"
+	}
+
+	parent := backupTables[name]
+	if parent != nil {
+		parentConds := make([]string, 0)
+		if tools.TenantEnabled() && !hasTenant {
+			parentConds = append(parentConds, "tenant_id = :tenant_id")
+		}
+		if len(opt.AccountIDs) != 0 {
+			parentConds = append(parentConds, "account_id IN (:account_ids)")
+		}
+		if len(opt.BkBizIDs) != 0 {
+			parentConds = append(parentConds, "bk_biz_id IN (:bk_biz_ids)")
+		}
+		if len(parentConds) != 0 {
+			conds = append(conds, fmt.Sprintf("%s IN (SELECT id FROM %s WHERE %s)", parent.Column, parent.Table,
+				strings.Join(parentConds, " AND ")))
+		}
+	} else {
+		if _, exists := columns[accountCol]; exists && len(opt.AccountIDs) != 0 {
+			conds = append(conds, accountCol+" IN (:account_ids)")
+		}
+		if _, exists := columns[bizCol]; exists && len(opt.BkBizIDs) != 0 {
+			conds = append(conds, bizCol+" IN (:bk_biz_ids)")
+		}
+	}
+
+	if len(conds) == 0 {
+		return "", arg
+	}
+
+	if len(opt.AccountIDs) != 0 {
+		arg["account_ids"] = opt.AccountIDs
+	}
+	if len(opt.BkBizIDs) != 0 {
+		arg["bk_biz_ids"] = opt.BkBizIDs
+	}
+
+	return "WHERE " + strings.Join(conds, " AND "), arg
+}
+
+// Import import the records in one transaction, the columns not exist in the table are ignored, so that
+// the backup of an older schema version can be imported. the id generator of each table is moved forward
+// to the max imported id to avoid generating conflict ids.
+// when multi-tenant is enabled, the records are imported into the kit's tenant, and the records that overwrite
+// or relate to the resources of other tenants are rejected.
+func (d Dao) Import(kt *kit.Kit, records []Record, mode ImportMode) (int64, error) {
+	if len(records) == 0 {
+		return 0, nil
+	}
+
+	names := make([]table.Name, 0)
+	tableRows := make(map[table.Name][]map[string]interface{})
+	for _, record := range records {
+		if _, exists := tableRows[record.Table]; !exists {
+			names = append(names, record.Table)
+		}
+		tableRows[record.Table] = append(tableRows[record.Table], record.Row)
+	}
+
+	stmts := make([]*importStmt, 0, len(names))
+	for _, name := range names {
+		stmt, err := d.buildImportStmt(kt, name, tableRows[name], mode)
+		if err != nil {
+			return 0, err
+		}
+		stmts = append(stmts, stmt)
+	}
+
+	_, err := d.Orm.AutoTxn(kt, func(txn *sqlx.Tx, _ *orm.TxnOption) (interface{}, error) {
+		for _, stmt := range stmts {
+			// 在事务中校验，使同一批次中先导入的父资源可以被关联表引用
+			if err := d.checkTenant(kt, d.Orm.Txn(txn), stmt, mode); err != nil {
+				return nil, err
+			}
+
+			if err := d.Orm.Txn(txn).BulkInsert(kt.Ctx, stmt.sql, stmt.args); err != nil {
+				logs.Errorf("import %s rows failed, err: %v, rid: %s", stmt.table, err, kt.Rid)
+				return nil, err
+			}
+
+			if len(stmt.maxID) == 0 {
+				continue
+			}
+
+			idSql := fmt.Sprintf("UPDATE %s SET max_id = :max_id WHERE resource = :resource AND max_id < :max_id",
+				table.IDGenerator)
+			arg := map[string]interface{}{"max_id": stmt.maxID, "resource": stmt.table}
+			if _, err := d.Orm.Txn(txn).Update(kt.Ctx, idSql, arg); err != nil {
+				logs.Errorf("move %s id generator forward failed, err: %v, rid: %s", stmt.table, err, kt.Rid)
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(records)), nil
+}
+
+// importStmt the bulk insert statement of one table.
+type importStmt struct {
+	table table.Name
+	sql   string
+	args  []map[string]interface{}
+	// maxID the max string id of the imported rows, which is used to move the id generator forward.
+	maxID string
+	// hasTenant whether the table has the tenant_id column.
+	hasTenant bool
+}
+
+func (d Dao) buildImportStmt(kt *kit.Kit, name table.Name, rows []map[string]interface{}, mode ImportMode) (
+	*importStmt, error) {
+
+	columns, err := d.listColumns(kt, d.Orm.Do(), name)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == table.AccountTable {
+		if err = d.verifySecret(rows); err != nil {
+			logs.Errorf("verify imported account secret failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+	}
+
+	_, hasTenant := columns["tenant_id"]
+	forceTenant := tools.TenantEnabled() && hasTenant
+
+	rowColumns := make([]string, 0, len(rows[0]))
+	for column := range rows[0] {
+		if _, exists := columns[column]; exists && !(forceTenant && column == "tenant_id") {
+			rowColumns = append(rowColumns, column)
+		}
+	}
+	if len(rowColumns) == 0 {
+		return nil, fmt.Errorf("no valid column of table %s in rows", name)
+	}
+	// 开启多租户时，记录总是导入到当前租户下，忽略备份数据中的租户
+	if forceTenant {
+		rowColumns = append(rowColumns, "tenant_id")
+	}
+	sort.Strings(rowColumns)
+
+	verb := "INSERT IGNORE INTO"
+	if mode == OverwriteExisting {
+		verb = "REPLACE INTO"
+	}
+
+	stmt := &importStmt{
+		table: name,
+		sql: fmt.Sprintf("%s %s (`%s`) VALUES (:%s)", verb, name, strings.Join(rowColumns, "`, `"),
+			strings.Join(rowColumns, ", :")),
+		args:      make([]map[string]interface{}, 0, len(rows)),
+		hasTenant: hasTenant,
+	}
+	for _, row := range rows {
+		arg := make(map[string]interface{}, len(rowColumns))
+		for _, column := range rowColumns {
+			if forceTenant && column == "tenant_id" {
+				arg[column] = kt.GetTenantID()
+				continue
+			}
+
+			value, exists := row[column]
+			if !exists {
+				return nil, fmt.Errorf("column %s of table %s is missing in some rows", column, name)
+			}
+			arg[column] = value
+		}
+		stmt.args = append(stmt.args, arg)
+
+		if id, ok := row["id"].(string); ok && id > stmt.maxID {
+			stmt.maxID = id
+		}
+	}
+
+	return stmt, nil
+}
+
+type selector interface {
+	Select(ctx context.Context, dest interface{}, expr string, arg map[string]interface{}) error
+}
+
+func (d Dao) listColumns(kt *kit.Kit, db selector, name table.Name) (map[string]struct{}, error) {
+	sql := `SELECT COLUMN_NAME FROM information_schema.columns WHERE TABLE_SCHEMA = DATABASE() ` +
+		`AND TABLE_NAME = :table_name`
+
+	columns := make([]string, 0)
+	if err := db.Select(kt.Ctx, &columns, sql, map[string]interface{}{"table_name": name}); err != nil {
+		logs.Errorf("list %s columns failed, err: %v, rid: %s", name, err, kt.Rid)
+		return nil, err
+	}
+
+	columnMap := make(map[string]struct{}, len(columns))
+	for _, column := range columns {
+		columnMap[column] = struct{}{}
+	}
+
+	return columnMap, nil
+}
@@ -0,0 +1,100 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package daobackup
+
+import (
+	"reflect"
+	"testing"
+
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/kit"
+)
+
+func TestFilterExprTenant(t *testing.T) {
+	tools.EnableTenant(true)
+	defer tools.EnableTenant(false)
+
+	kt := kit.New()
+	kt.TenantID = "t1"
+
+	opt := &ExportOption{AccountIDs: []string{"a1"}}
+	cases := []struct {
+		name    table.Name
+		columns map[string]struct{}
+		where   string
+	}{
+		{
+			name:    table.CvmTable,
+			columns: map[string]struct{}{"id": {}, "account_id": {}, "tenant_id": {}},
+			where:   "WHERE tenant_id = :tenant_id AND account_id IN (:account_ids)",
+		},
+		{
+			name:    table.SecurityGroupCvmTable,
+			columns: map[string]struct{}{"id": {}, "cvm_id": {}, "tenant_id": {}},
+			where: "WHERE tenant_id = :tenant_id AND cvm_id IN (SELECT id FROM cvm WHERE " +
+				"account_id IN (:account_ids))",
+		},
+		{
+			name:    table.NetworkInterfaceCvmRelTable,
+			columns: map[string]struct{}{"id": {}, "cvm_id": {}},
+			where: "WHERE cvm_id IN (SELECT id FROM cvm WHERE tenant_id = :tenant_id AND " +
+				"account_id IN (:account_ids))",
+		},
+	}
+
+	for _, c := range cases {
+		where, arg := filterExpr(kt, c.name, c.columns, opt)
+		if where != c.where {
+			t.Errorf("%s where should be %q, got: %q", c.name, c.where, where)
+		}
+
+		if arg["tenant_id"] != "t1" || !reflect.DeepEqual(arg["account_ids"], []string{"a1"}) {
+			t.Errorf("%s arg is invalid: %v", c.name, arg)
+		}
+	}
+
+	where, arg := filterExpr(kt, table.NetworkInterfaceCvmRelTable, map[string]struct{}{"id": {}},
+		&ExportOption{})
+	if where != "WHERE cvm_id IN (SELECT id FROM cvm WHERE tenant_id = :tenant_id)" || arg["tenant_id"] != "t1" {
+		t.Errorf("rel table without filter should be scoped by parent tenant, where: %q, arg: %v", where, arg)
+	}
+}
+
+func TestFilterExprWithoutTenant(t *testing.T) {
+	where, arg := filterExpr(kit.New(), table.CvmTable, map[string]struct{}{"tenant_id": {}}, &ExportOption{})
+	if where != "" || len(arg) != 0 {
+		t.Errorf("tenant disabled should not filter, where: %q, arg: %v", where, arg)
+	}
+}
+
+func TestMissingValues(t *testing.T) {
+	expected := uniqueValues([]map[string]interface{}{
+		{"cvm_id": "c1"}, {"cvm_id": "c2"}, {"cvm_id": "c1"}, {"cvm_id": nil}, {"cvm_id": ""},
+	}, "cvm_id")
+	if !reflect.DeepEqual(expected, []interface{}{"c1", "c2"}) {
+		t.Fatalf("unique values should be [c1 c2], got: %v", expected)
+	}
+
+	missing := missingValues(expected, []string{"c2"})
+	if !reflect.DeepEqual(missing, []interface{}{"c1"}) {
+		t.Fatalf("missing values should be [c1], got: %v", missing)
+	}
+}
@@ -0,0 +1,151 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package daobackup
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/dal/dao/cloud"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// checkTenant 开启多租户时，校验关联表引用的父资源属于当前租户，覆盖导入时校验不会覆盖其他租户的记录。
+// 表的唯一键都包含租户ID，因此覆盖导入只会因主键与其他租户的记录冲突。
+func (d Dao) checkTenant(kt *kit.Kit, db selector, stmt *importStmt, mode ImportMode) error {
+	if !tools.TenantEnabled() {
+		return nil
+	}
+
+	parent := backupTables[stmt.table]
+	if parent != nil {
+		parentIDs := uniqueValues(stmt.args, parent.Column)
+		sql := fmt.Sprintf("SELECT id FROM %s WHERE id IN (:ids) AND tenant_id = :tenant_id", parent.Table)
+		owned, err := d.selectIDs(kt, db, sql, parentIDs)
+		if err != nil {
+			return err
+		}
+
+		if missing := missingValues(parentIDs, owned); len(missing) != 0 {
+			return fmt.Errorf("%s %v of table %s not belong to tenant %s", parent.Column, missing, stmt.table,
+				kt.GetTenantID())
+		}
+	}
+
+	if mode != OverwriteExisting {
+		return nil
+	}
+
+	ownerCond := "tenant_id = :tenant_id"
+	if !stmt.hasTenant {
+		if parent == nil {
+			return nil
+		}
+		ownerCond = fmt.Sprintf("%s IN (SELECT id FROM %s WHERE tenant_id = :tenant_id)", parent.Column,
+			parent.Table)
+	}
+
+	sql := fmt.Sprintf("SELECT id FROM %s WHERE id IN (:ids) AND NOT (%s)", stmt.table, ownerCond)
+	conflicts, err := d.selectIDs(kt, db, sql, uniqueValues(stmt.args, "id"))
+	if err != nil {
+		return err
+	}
+
+	if len(conflicts) != 0 {
+		return fmt.Errorf("id %v of table %s belong to other tenant, can not be overwritten", conflicts, stmt.table)
+	}
+
+	return nil
+}
+
+func (d Dao) selectIDs(kt *kit.Kit, db selector, sql string, ids []interface{}) ([]string, error) {
+	result := make([]string, 0)
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	arg := map[string]interface{}{"ids": ids, "tenant_id": kt.GetTenantID()}
+	if err := db.Select(kt.Ctx, &result, sql, arg); err != nil {
+		logs.Errorf("select ids failed, err: %v, sql: %s, rid: %s", err, sql, kt.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// uniqueValues 返回各行中指定列不为空的值，去除重复值
+func uniqueValues(rows []map[string]interface{}, column string) []interface{} {
+	values := make([]interface{}, 0, len(rows))
+	exists := make(map[string]struct{}, len(rows))
+	for _, row := range rows {
+		value, ok := row[column]
+		if !ok || value == nil {
+			continue
+		}
+
+		key := fmt.Sprint(value)
+		if _, ok = exists[key]; ok || len(key) == 0 {
+			continue
+		}
+		exists[key] = struct{}{}
+		values = append(values, value)
+	}
+
+	return values
+}
+
+// missingValues 返回 expected 中不在 actual 中的值
+func missingValues(expected []interface{}, actual []string) []interface{} {
+	actualMap := make(map[string]struct{}, len(actual))
+	for _, value := range actual {
+		actualMap[value] = struct{}{}
+	}
+
+	missing := make([]interface{}, 0)
+	for _, value := range expected {
+		if _, ok := actualMap[fmt.Sprint(value)]; !ok {
+			missing = append(missing, value)
+		}
+	}
+
+	return missing
+}
+
+// verifySecret 校验导入账号的密钥可以被当前环境的主密钥解密，避免导入后账号密钥无法使用
+func (d Dao) verifySecret(rows []map[string]interface{}) error {
+	for _, row := range rows {
+		extension, _ := row["extension"].(string)
+		if len(extension) == 0 {
+			continue
+		}
+
+		if d.Cipher == nil {
+			return errors.New("cipher of backup dao is not set, can not import account")
+		}
+
+		if err := cloud.VerifySecret(d.Cipher, extension); err != nil {
+			return fmt.Errorf("account %v %v", row["id"], err)
+		}
+	}
+
+	return nil
+}
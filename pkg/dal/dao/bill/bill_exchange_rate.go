@@ -75,12 +75,11 @@ func (a AccountBillExchangeRateDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, models[0].TableName(),
-		tablebill.AccountBillExchangeRateColumns.ColumnExpr(), tablebill.AccountBillExchangeRateColumns.ColonNameExpr())
+	sql := tablebill.AccountBillExchangeRateColumns.InsertExpr(string(models[0].TableName()))
 
 	if err = a.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", models[0].TableName(), err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", models[0].TableName(), err)
 	}
 
 	return ids, nil
@@ -126,10 +125,12 @@ func (a AccountBillExchangeRateDao) List(kt *kit.Kit, opt *types.ListOption) (
 		table.AccountBillExchangeRateTable, whereExpr, pageExpr)
 
 	details := make([]tablebill.AccountBillExchangeRate, 0)
-	if err = a.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AccountBillExchangeRateTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, a.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
-	return &typesbill.ListAccountBillExchangeRateDetails{Details: details}, nil
+	return &typesbill.ListAccountBillExchangeRateDetails{Count: count, Details: details}, nil
 }
 
 // UpdateByIDWithTx  account bill exchange rate.
@@ -70,19 +70,18 @@ func (a AccountBillAdjustmentItemDao) CreateWithTx(
 
 	for index, model := range models {
 		models[index].ID = ids[index]
+		models[index].TenantID = kt.GetTenantID()
 
 		if err = model.InsertValidate(); err != nil {
 			return nil, err
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, models[0].TableName(),
-		tablebill.AccountBillAdjustmentItemColumns.ColumnExpr(),
-		tablebill.AccountBillAdjustmentItemColumns.ColonNameExpr())
+	sql := tablebill.AccountBillAdjustmentItemColumns.InsertExpr(string(models[0].TableName()))
 
 	if err = a.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", models[0].TableName(), err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", models[0].TableName(), err)
 	}
 
 	return ids, nil
@@ -102,7 +101,7 @@ func (a AccountBillAdjustmentItemDao) List(kt *kit.Kit, opt *types.ListOption) (
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -129,11 +128,13 @@ func (a AccountBillAdjustmentItemDao) List(kt *kit.Kit, opt *types.ListOption) (
 		table.AccountBillAdjustmentItemTable, whereExpr, pageExpr)
 
 	details := make([]tablebill.AccountBillAdjustmentItem, 0)
-	if err = a.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AccountBillAdjustmentItemTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, a.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		logs.Errorf("fail to select bill adjustment item, err: %v ,rid: %s", err, kt.Rid)
 		return nil, err
 	}
-	return &typesbill.ListAccountBillAdjustmentItemDetails{Details: details}, nil
+	return &typesbill.ListAccountBillAdjustmentItemDetails{Count: count, Details: details}, nil
 }
 
 // UpdateByIDWithTx update account bill adjustment item.
@@ -150,7 +151,8 @@ func (a AccountBillAdjustmentItemDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx,
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, table.AccountBillAdjustmentItemTable, setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, table.AccountBillAdjustmentItemTable, setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = id
 	_, err = a.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
@@ -168,7 +170,7 @@ func (a AccountBillAdjustmentItemDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, exp
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
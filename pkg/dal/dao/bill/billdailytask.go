@@ -70,19 +70,17 @@ func (abpDao AccountBillDailyPullTaskDao) BatchCreateWithTx(
 
 	for idx, d := range abPullers {
 		d.ID = ids[idx]
+		d.TenantID = kt.GetTenantID()
 		if err := d.InsertValidate(); err != nil {
 			return nil, err
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`,
-		table.AccountBillDailyPullTaskTable, tablebill.AccountBillDailyPullTaskColumns.ColumnExpr(),
-		tablebill.AccountBillDailyPullTaskColumns.ColonNameExpr(),
-	)
+	sql := tablebill.AccountBillDailyPullTaskColumns.InsertExpr(table.AccountBillDailyPullTaskTable)
 
 	err = abpDao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, abPullers)
 	if err != nil {
-		return nil, fmt.Errorf("insert %s failed, err: %v", table.AccountBillDailyPullTaskTable, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", table.AccountBillDailyPullTaskTable, err)
 	}
 	return ids, nil
 }
@@ -100,7 +98,7 @@ func (abpDao AccountBillDailyPullTaskDao) List(kt *kit.Kit, opt *types.ListOptio
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -126,10 +124,12 @@ func (abpDao AccountBillDailyPullTaskDao) List(kt *kit.Kit, opt *types.ListOptio
 		table.AccountBillDailyPullTaskTable, whereExpr, pageExpr)
 
 	details := make([]tablebill.AccountBillDailyPullTask, 0)
-	if err = abpDao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AccountBillDailyPullTaskTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, abpDao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
-	return &typesbill.ListAccountBillDailyPullTaskDetails{Details: details}, nil
+	return &typesbill.ListAccountBillDailyPullTaskDetails{Count: count, Details: details}, nil
 }
 
 // UpdateByIDWithTx update account bill daily pull task
@@ -156,7 +156,8 @@ func (abpDao AccountBillDailyPullTaskDao) UpdateByIDWithTx(
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, table.AccountBillDailyPullTaskTable, setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, table.AccountBillDailyPullTaskTable, setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = pullerID
 	_, err = abpDao.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
@@ -174,7 +175,7 @@ func (abpDao AccountBillDailyPullTaskDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx,
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -23,8 +23,10 @@ package bill
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
@@ -38,9 +40,13 @@ import (
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 )
 
+// mysqlErrNoSuchTable is the mysql error number of table not exists.
+const mysqlErrNoSuchTable = 1146
+
 // AccountBillItem only used for interface.
 type AccountBillItem interface {
 	CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, commonOpt *typesbill.ItemCommonOpt,
@@ -52,6 +58,8 @@ type AccountBillItem interface {
 		updateData *tablebill.AccountBillItem) error
 
 	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, commonOpt *typesbill.ItemCommonOpt, filterExpr *filter.Expression) error
+	SumCostGroupBy(kt *kit.Kit, commonOpt *typesbill.ItemCommonOpt, opt *typesbill.ItemCostSumOption) (
+		[]typesbill.ItemCostSum, error)
 }
 
 // AccountBillItemDao account bill item dao
@@ -80,13 +88,13 @@ func (a AccountBillItemDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, commonOpt *ty
 
 	for index, model := range models {
 		models[index].ID = ids[index]
+		models[index].TenantID = kt.GetTenantID()
 
 		if err = model.InsertValidate(); err != nil {
 			return nil, err
 		}
 	}
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, tableName,
-		tablebill.AccountBillItemColumns.ColumnExpr(), tablebill.AccountBillItemColumns.ColonNameExpr())
+	sql := tablebill.AccountBillItemColumns.InsertExpr(tableName)
 
 	shardingOpt, err := convertShardingOpt(tableName, commonOpt)
 	if err != nil {
@@ -94,7 +102,7 @@ func (a AccountBillItemDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, commonOpt *ty
 	}
 	if err = a.Orm.TableSharding(shardingOpt).Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
 		logs.Errorf("insert %s failed, err: %v, shardingOpt: %v, rid: %s", tableName, err, shardingOpt, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", tableName, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", tableName, err)
 	}
 
 	return ids, nil
@@ -116,7 +124,7 @@ func (a AccountBillItemDao) List(kt *kit.Kit, commonOpt *typesbill.ItemCommonOpt
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -147,7 +155,10 @@ func (a AccountBillItemDao) List(kt *kit.Kit, commonOpt *typesbill.ItemCommonOpt
 
 	idSql := fmt.Sprintf(`SELECT id FROM %s %s %s`, tableName, whereExpr, pageExpr)
 	preDetails := make([]tablebill.AccountBillItem, 0)
-	if err = a.Orm.TableSharding(shardingOpt).Do().Select(kt.Ctx, &preDetails, idSql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, tableName, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, a.Orm.TableSharding(shardingOpt).Do(), opt.Page.WithCount, &preDetails,
+		countSql, idSql, whereValue)
+	if err != nil {
 		logs.Errorf("fail to select id for bill item, err: %v, table: %s, opt: %+v, rid: %s",
 			err, tableName, opt, kt.Rid)
 		return nil, err
@@ -157,7 +168,7 @@ func (a AccountBillItemDao) List(kt *kit.Kit, commonOpt *typesbill.ItemCommonOpt
 		detailIDs = append(detailIDs, detail.ID)
 	}
 	if len(detailIDs) == 0 {
-		return &typesbill.ListAccountBillItemDetails{Details: make([]tablebill.AccountBillItem, 0)}, nil
+		return &typesbill.ListAccountBillItemDetails{Count: count, Details: make([]tablebill.AccountBillItem, 0)}, nil
 	}
 
 	sql := fmt.Sprintf(`SELECT %s FROM %s WHERE id IN (:ids)`,
@@ -170,7 +181,7 @@ func (a AccountBillItemDao) List(kt *kit.Kit, commonOpt *typesbill.ItemCommonOpt
 			err, shardingOpt.String(), opt, detailIDs, kt.Rid)
 		return nil, err
 	}
-	return &typesbill.ListAccountBillItemDetails{Details: details}, nil
+	return &typesbill.ListAccountBillItemDetails{Count: count, Details: details}, nil
 }
 
 // UpdateByIDWithTx update account bill item.
@@ -195,7 +206,8 @@ func (a AccountBillItemDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, commonOpt
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, tableName, setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, tableName, setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = id
 	_, err = a.Orm.TableSharding(shardingOpt).Txn(tx).Update(kt.Ctx, sql, toUpdate)
@@ -219,7 +231,7 @@ func (a AccountBillItemDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, commonOpt *ty
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -240,6 +252,88 @@ func (a AccountBillItemDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, commonOpt *ty
 	return nil
 }
 
+// billItemRegionPath 各云厂商账单明细扩展字段中地域信息的路径，未配置的云厂商地域为空
+var billItemRegionPath = map[enumor.Vendor]string{
+	enumor.Aws:      "$.product_region_code",
+	enumor.Gcp:      "$.region",
+	enumor.HuaWei:   "$.region",
+	enumor.Zenlayer: "$.city",
+}
+
+// SumCostGroupBy sum account bill item cost group by dimensions and currency.
+// 分表不存在说明该云厂商当月没有账单，返回空结果。
+func (a AccountBillItemDao) SumCostGroupBy(kt *kit.Kit, commonOpt *typesbill.ItemCommonOpt,
+	opt *typesbill.ItemCostSumOption) ([]typesbill.ItemCostSum, error) {
+
+	if err := commonOpt.Validate(); err != nil {
+		return nil, err
+	}
+	if opt == nil || opt.Filter == nil {
+		return nil, errf.New(errf.InvalidParameter, "sum account bill item cost filter is required")
+	}
+
+	exprOpt := filter.NewExprOption(filter.RuleFields(tablebill.AccountBillItemColumns.ColumnTypes()))
+	if err := opt.Filter.Validate(exprOpt); err != nil {
+		return nil, err
+	}
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return nil, err
+	}
+
+	selectExprs := make([]string, 0, len(opt.GroupBy)+1)
+	groupFields := make([]string, 0, len(opt.GroupBy)+1)
+	for _, dim := range opt.GroupBy {
+		var expr string
+		switch dim {
+		case enumor.BillCostDimBiz:
+			expr = "IFNULL(bk_biz_id, 0)"
+		case enumor.BillCostDimMainAccount:
+			expr = "main_account_id"
+		case enumor.BillCostDimResType:
+			expr = "IFNULL(hc_product_code, '')"
+		case enumor.BillCostDimRegion:
+			expr = "''"
+			if path, exists := billItemRegionPath[commonOpt.Vendor]; exists {
+				expr = fmt.Sprintf("IFNULL(JSON_UNQUOTE(JSON_EXTRACT(extension, '%s')), '')", path)
+			}
+		case enumor.BillCostDimVendor:
+			// 分表按云厂商划分，无需再按云厂商分组
+			continue
+		default:
+			return nil, errf.Newf(errf.InvalidParameter, "unsupported bill cost dimension: %s", dim)
+		}
+		selectExprs = append(selectExprs, fmt.Sprintf("%s AS %s", expr, dim))
+		groupFields = append(groupFields, string(dim))
+	}
+	selectExprs = append(selectExprs, "currency")
+	groupFields = append(groupFields, "currency")
+
+	tableName := table.AccountBillItemTable
+	shardingOpt, err := convertShardingOpt(tableName, commonOpt)
+	if err != nil {
+		return nil, err
+	}
+	sql := fmt.Sprintf(`SELECT %s, SUM(cost) AS cost FROM %s %s GROUP BY %s`, strings.Join(selectExprs, ", "),
+		tableName, whereExpr, strings.Join(groupFields, ", "))
+
+	details := make([]typesbill.ItemCostSum, 0)
+	if err = a.Orm.TableSharding(shardingOpt).Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrNoSuchTable {
+			return details, nil
+		}
+		logs.Errorf("sum account bill item cost failed, err: %v, shardingOpt: %v, sql: %s, rid: %s",
+			err, shardingOpt, sql, kt.Rid)
+		return nil, err
+	}
+
+	for idx := range details {
+		details[idx].Vendor = commonOpt.Vendor
+	}
+	return details, nil
+}
+
 func convertShardingOpt(tableName string, commonOpt *typesbill.ItemCommonOpt) (*orm.TableSuffixShardingOpt, error) {
 	if commonOpt == nil {
 		return nil, errors.New("common opt is required")
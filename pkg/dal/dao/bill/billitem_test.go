@@ -0,0 +1,166 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	typesbill "hcm/pkg/dal/dao/types/bill"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/shopspring/decimal"
+)
+
+// fakeCostOrm records the sharding table and the sum sql, and returns the prepared costs or error.
+type fakeCostOrm struct {
+	orm.Interface
+	orm.DoOrm
+	costs      []typesbill.ItemCostSum
+	err        error
+	shardTable string
+	sql        string
+}
+
+// TableSharding ...
+func (f *fakeCostOrm) TableSharding(opts ...orm.TableShardingOpt) orm.Interface {
+	for _, opt := range opts {
+		if opt.Match(table.AccountBillItemTable) {
+			f.shardTable = opt.ReplaceTableName(table.AccountBillItemTable)
+		}
+	}
+	return f
+}
+
+// Do ...
+func (f *fakeCostOrm) Do() orm.DoOrm {
+	return f
+}
+
+// Select ...
+func (f *fakeCostOrm) Select(_ context.Context, dest interface{}, expr string, _ map[string]interface{}) error {
+	f.sql = expr
+	if f.err != nil {
+		return f.err
+	}
+	details := dest.(*[]typesbill.ItemCostSum)
+	*details = append(*details, f.costs...)
+	return nil
+}
+
+func costSumOpt(groupBy ...enumor.BillCostDimension) *typesbill.ItemCostSumOption {
+	return &typesbill.ItemCostSumOption{
+		Filter:  tools.EqualExpression("bill_year", 2024),
+		GroupBy: groupBy,
+	}
+}
+
+func TestSumCostGroupBy(t *testing.T) {
+	fake := &fakeCostOrm{costs: []typesbill.ItemCostSum{
+		{BkBizID: 1, Region: "us-east-1", Currency: enumor.CurrencyUSD,
+			Cost: types.Decimal{Decimal: decimal.NewFromInt(10)}},
+	}}
+	dao := AccountBillItemDao{Orm: fake}
+	commonOpt := &typesbill.ItemCommonOpt{Vendor: enumor.Aws, Year: 2024, Month: 3}
+
+	costs, err := dao.SumCostGroupBy(kit.New(), commonOpt,
+		costSumOpt(enumor.BillCostDimBiz, enumor.BillCostDimVendor, enumor.BillCostDimRegion))
+	if err != nil {
+		t.Fatalf("sum cost group by failed, err: %v", err)
+	}
+
+	if fake.shardTable != "account_bill_item_aws_202403" {
+		t.Errorf("cost should be summed from the month table of vendor, but got: %s", fake.shardTable)
+	}
+
+	for _, expect := range []string{
+		"IFNULL(bk_biz_id, 0) AS bk_biz_id",
+		"JSON_EXTRACT(extension, '$.product_region_code')",
+		"SUM(cost) AS cost",
+		// 分表已按云厂商划分，不应再按云厂商分组
+		"GROUP BY bk_biz_id, region, currency",
+	} {
+		if !strings.Contains(fake.sql, expect) {
+			t.Errorf("sum sql should contain %q, sql: %s", expect, fake.sql)
+		}
+	}
+
+	if len(costs) != 1 || costs[0].Vendor != enumor.Aws || !costs[0].Cost.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("unexpected costs: %+v", costs)
+	}
+}
+
+func TestSumCostGroupByRegionNotSupported(t *testing.T) {
+	fake := &fakeCostOrm{}
+	dao := AccountBillItemDao{Orm: fake}
+	commonOpt := &typesbill.ItemCommonOpt{Vendor: enumor.TCloud, Year: 2024, Month: 3}
+
+	if _, err := dao.SumCostGroupBy(kit.New(), commonOpt, costSumOpt(enumor.BillCostDimRegion)); err != nil {
+		t.Fatalf("sum cost group by failed, err: %v", err)
+	}
+
+	if !strings.Contains(fake.sql, "'' AS region") {
+		t.Errorf("region of vendor without region path should be empty, sql: %s", fake.sql)
+	}
+}
+
+func TestSumCostGroupByTableNotExists(t *testing.T) {
+	fake := &fakeCostOrm{err: &mysql.MySQLError{Number: mysqlErrNoSuchTable, Message: "table doesn't exist"}}
+	dao := AccountBillItemDao{Orm: fake}
+	commonOpt := &typesbill.ItemCommonOpt{Vendor: enumor.Gcp, Year: 2024, Month: 3}
+
+	costs, err := dao.SumCostGroupBy(kit.New(), commonOpt, costSumOpt(enumor.BillCostDimBiz))
+	if err != nil {
+		t.Fatalf("month table not exists should return empty costs, but got err: %v", err)
+	}
+	if len(costs) != 0 {
+		t.Errorf("month table not exists should return empty costs, but got: %+v", costs)
+	}
+
+	fake.err = errors.New("connection refused")
+	if _, err = dao.SumCostGroupBy(kit.New(), commonOpt, costSumOpt(enumor.BillCostDimBiz)); err == nil {
+		t.Errorf("other select error should be returned")
+	}
+}
+
+func TestSumCostGroupByInvalidOption(t *testing.T) {
+	dao := AccountBillItemDao{Orm: &fakeCostOrm{}}
+	commonOpt := &typesbill.ItemCommonOpt{Vendor: enumor.Aws, Year: 2024, Month: 3}
+
+	if _, err := dao.SumCostGroupBy(kit.New(), commonOpt, &typesbill.ItemCostSumOption{}); err == nil {
+		t.Errorf("sum cost without filter should fail")
+	}
+
+	if _, err := dao.SumCostGroupBy(kit.New(), &typesbill.ItemCommonOpt{Vendor: enumor.Aws}, costSumOpt()); err == nil {
+		t.Errorf("sum cost without bill month should fail")
+	}
+
+	if _, err := dao.SumCostGroupBy(kit.New(), commonOpt, costSumOpt("unknown")); err == nil {
+		t.Errorf("sum cost with unsupported dimension should fail")
+	}
+}
@@ -71,6 +71,7 @@ func (abpDao AccountBillMonthPullTaskDao) BatchCreateWithTx(
 
 	for idx, t := range mTasks {
 		t.ID = ids[idx]
+		t.TenantID = kt.GetTenantID()
 	}
 
 	for _, i := range mTasks {
@@ -79,13 +80,10 @@ func (abpDao AccountBillMonthPullTaskDao) BatchCreateWithTx(
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`,
-		table.AccountBillMonthTaskTable, tablebill.AccountBillMonthTaskColumns.ColumnExpr(),
-		tablebill.AccountBillMonthTaskColumns.ColonNameExpr(),
-	)
+	sql := tablebill.AccountBillMonthTaskColumns.InsertExpr(table.AccountBillMonthTaskTable)
 	err = abpDao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, mTasks)
 	if err != nil {
-		return nil, fmt.Errorf("insert %s failed, err: %v", table.AccountBillMonthTaskTable, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", table.AccountBillMonthTaskTable, err)
 	}
 	return ids, nil
 }
@@ -104,7 +102,7 @@ func (abpDao AccountBillMonthPullTaskDao) List(kt *kit.Kit, opt *types.ListOptio
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -130,10 +128,12 @@ func (abpDao AccountBillMonthPullTaskDao) List(kt *kit.Kit, opt *types.ListOptio
 		table.AccountBillMonthTaskTable, whereExpr, pageExpr)
 
 	details := make([]tablebill.AccountBillMonthTask, 0)
-	if err = abpDao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AccountBillMonthTaskTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, abpDao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
-	return &typesbill.ListAccountBillMonthPullTaskDetails{Details: details}, nil
+	return &typesbill.ListAccountBillMonthPullTaskDetails{Count: count, Details: details}, nil
 }
 
 // UpdateByIDWithTx update account bill month task
@@ -150,7 +150,8 @@ func (abpDao AccountBillMonthPullTaskDao) UpdateByIDWithTx(
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, table.AccountBillMonthTaskTable, setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, table.AccountBillMonthTaskTable, setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = pullerID
 	_, err = abpDao.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
@@ -169,7 +170,7 @@ func (abpDao AccountBillMonthPullTaskDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx,
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
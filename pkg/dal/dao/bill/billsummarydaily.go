@@ -70,18 +70,18 @@ func (a AccountBillSummaryDailyDao) CreateWithTx(
 
 	for index, model := range models {
 		models[index].ID = ids[index]
+		models[index].TenantID = kt.GetTenantID()
 
 		if err = model.InsertValidate(); err != nil {
 			return nil, err
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, models[0].TableName(),
-		tablebill.AccountBillSummaryDailyColumns.ColumnExpr(), tablebill.AccountBillSummaryDailyColumns.ColonNameExpr())
+	sql := tablebill.AccountBillSummaryDailyColumns.InsertExpr(string(models[0].TableName()))
 
 	if err = a.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", models[0].TableName(), err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", models[0].TableName(), err)
 	}
 
 	return ids, nil
@@ -100,7 +100,7 @@ func (a AccountBillSummaryDailyDao) List(kt *kit.Kit, opt *types.ListOption) (
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -126,10 +126,12 @@ func (a AccountBillSummaryDailyDao) List(kt *kit.Kit, opt *types.ListOption) (
 		table.AccountBillSummaryDailyTable, whereExpr, pageExpr)
 
 	details := make([]tablebill.AccountBillSummaryDaily, 0)
-	if err = a.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AccountBillSummaryDailyTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, a.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
-	return &typesbill.ListAccountBillSummaryDailyDetails{Details: details}, nil
+	return &typesbill.ListAccountBillSummaryDailyDetails{Count: count, Details: details}, nil
 }
 
 // UpdateByIDWithTx update account bill summary daily.
@@ -146,7 +148,8 @@ func (a AccountBillSummaryDailyDao) UpdateByIDWithTx(
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, table.AccountBillSummaryDailyTable, setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, table.AccountBillSummaryDailyTable, setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = id
 	_, err = a.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
@@ -165,7 +168,7 @@ func (a AccountBillSummaryDailyDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
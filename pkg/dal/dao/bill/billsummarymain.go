@@ -72,17 +72,17 @@ func (a AccountBillSummaryMainDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models
 
 	for index, model := range models {
 		models[index].ID = ids[index]
+		models[index].TenantID = kt.GetTenantID()
 		if err = model.InsertValidate(); err != nil {
 			return nil, err
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, models[0].TableName(),
-		tablebill.AccountBillSummaryMainColumns.ColumnExpr(), tablebill.AccountBillSummaryMainColumns.ColonNameExpr())
+	sql := tablebill.AccountBillSummaryMainColumns.InsertExpr(string(models[0].TableName()))
 
 	if err = a.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", models[0].TableName(), err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", models[0].TableName(), err)
 	}
 
 	return ids, nil
@@ -101,7 +101,7 @@ func (a AccountBillSummaryMainDao) List(kt *kit.Kit, opt *types.ListOption) (
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -127,10 +127,12 @@ func (a AccountBillSummaryMainDao) List(kt *kit.Kit, opt *types.ListOption) (
 		table.AccountBillSummaryMainTable, whereExpr, pageExpr)
 
 	details := make([]tablebill.AccountBillSummaryMain, 0)
-	if err = a.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AccountBillSummaryMainTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, a.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
-	return &typesbill.ListAccountBillSummaryMainDetails{Details: details}, nil
+	return &typesbill.ListAccountBillSummaryMainDetails{Count: count, Details: details}, nil
 }
 
 // UpdateByIDWithTx update account bill summary.
@@ -147,7 +149,8 @@ func (a AccountBillSummaryMainDao) UpdateByIDWithTx(
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, table.AccountBillSummaryMainTable, setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, table.AccountBillSummaryMainTable, setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = billID
 	_, err = a.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
@@ -166,7 +169,7 @@ func (a AccountBillSummaryMainDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -192,7 +195,7 @@ func (a AccountBillSummaryMainDao) ListGroupByBiz(kt *kit.Kit, opt *types.ListOp
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -231,10 +234,12 @@ func (a AccountBillSummaryMainDao) ListGroupByBiz(kt *kit.Kit, opt *types.ListOp
 		table.AccountBillSummaryMainTable, whereExpr, pageExpr)
 
 	details := make([]tablebill.AccountBillSummaryMain, 0)
-	if err = a.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(distinct bk_biz_id) FROM %s %s`, table.AccountBillSummaryMainTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, a.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		logs.Errorf("list account bill summary main group by bk_biz_id failed, err: %v, sql: %s, rid: %s",
 			err, sql, kt.Rid)
 		return nil, err
 	}
-	return &typesbill.ListAccountBillSummaryMainDetails{Details: details}, nil
+	return &typesbill.ListAccountBillSummaryMainDetails{Count: count, Details: details}, nil
 }
@@ -71,18 +71,18 @@ func (a AccountBillSummaryRootDao) CreateWithTx(
 
 	for index, model := range models {
 		models[index].ID = ids[index]
+		models[index].TenantID = kt.GetTenantID()
 
 		if err = model.InsertValidate(); err != nil {
 			return nil, err
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, models[0].TableName(),
-		tablebill.AccountBillSummaryRootColumns.ColumnExpr(), tablebill.AccountBillSummaryRootColumns.ColonNameExpr())
+	sql := tablebill.AccountBillSummaryRootColumns.InsertExpr(string(models[0].TableName()))
 
 	if err = a.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", models[0].TableName(), err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", models[0].TableName(), err)
 	}
 
 	return ids, nil
@@ -101,7 +101,7 @@ func (dao AccountBillSummaryRootDao) ListWithTx(kt *kit.Kit, tx *sqlx.Tx,
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -127,10 +127,12 @@ func (dao AccountBillSummaryRootDao) ListWithTx(kt *kit.Kit, tx *sqlx.Tx,
 		table.AccountBillSummaryRootTable, whereExpr, pageExpr)
 
 	details := make([]tablebill.AccountBillSummaryRoot, 0)
-	if err = dao.Orm.Txn(tx).Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AccountBillSummaryRootTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Txn(tx), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
-	return &typesbill.ListAccountBillSummaryRootDetails{Details: details}, nil
+	return &typesbill.ListAccountBillSummaryRootDetails{Count: count, Details: details}, nil
 }
 
 // List get account bill summary of root account list.
@@ -146,7 +148,7 @@ func (a AccountBillSummaryRootDao) List(kt *kit.Kit, opt *types.ListOption) (
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -172,10 +174,12 @@ func (a AccountBillSummaryRootDao) List(kt *kit.Kit, opt *types.ListOption) (
 		table.AccountBillSummaryRootTable, whereExpr, pageExpr)
 
 	details := make([]tablebill.AccountBillSummaryRoot, 0)
-	if err = a.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AccountBillSummaryRootTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, a.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
-	return &typesbill.ListAccountBillSummaryRootDetails{Details: details}, nil
+	return &typesbill.ListAccountBillSummaryRootDetails{Count: count, Details: details}, nil
 }
 
 // UpdateByIDWithTx update account bill summary of root account.
@@ -192,7 +196,8 @@ func (a AccountBillSummaryRootDao) UpdateByIDWithTx(
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, table.AccountBillSummaryRootTable, setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, table.AccountBillSummaryRootTable, setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = id
 	_, err = a.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
@@ -211,7 +216,7 @@ func (a AccountBillSummaryRootDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
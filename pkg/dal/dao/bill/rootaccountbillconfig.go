@@ -74,18 +74,18 @@ func (a RootAccountBillConfigDao) CreateWithTx(
 
 	for index, model := range models {
 		models[index].ID = ids[index]
+		models[index].TenantID = kt.GetTenantID()
 
 		if err = model.InsertValidate(); err != nil {
 			return nil, err
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, models[0].TableName(),
-		tablebill.RootAccountBillConfigColumns.ColumnExpr(), tablebill.RootAccountBillConfigColumns.ColonNameExpr())
+	sql := tablebill.RootAccountBillConfigColumns.InsertExpr(string(models[0].TableName()))
 
 	if err = a.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", models[0].TableName(), err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", models[0].TableName(), err)
 	}
 
 	return ids, nil
@@ -103,7 +103,7 @@ func (a RootAccountBillConfigDao) Update(kt *kit.Kit, filterExpr *filter.Express
 		return err
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -154,7 +154,7 @@ func (a RootAccountBillConfigDao) List(kt *kit.Kit, opt *types.ListOption) (
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -180,10 +180,12 @@ func (a RootAccountBillConfigDao) List(kt *kit.Kit, opt *types.ListOption) (
 		table.RootAccountBillConfigTable, whereExpr, pageExpr)
 
 	details := make([]tablebill.RootAccountBillConfigTable, 0)
-	if err = a.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.RootAccountBillConfigTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, a.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
-	return &typesbill.ListRootAccountBillConfigDetails{Details: details}, nil
+	return &typesbill.ListRootAccountBillConfigDetails{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx delete root account bill config with tx.
@@ -192,7 +194,7 @@ func (a RootAccountBillConfigDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *f
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
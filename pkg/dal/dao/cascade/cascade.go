@@ -0,0 +1,67 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package cascade defines the parent/child relations of the resources, which is used to cascade delete the
+// child resources when the parent resources are deleted.
+package cascade
+
+import (
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Deleter 子资源的删除接口，子资源的 dao 需实现该接口，以保证删除子资源时的附带逻辑(如记录历史版本)被执行
+type Deleter interface {
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+}
+
+// Child 声明父资源的子资源
+type Child struct {
+	// Table 子资源表
+	Table table.Name
+	// ParentKey 子资源表中关联父资源ID的字段
+	ParentKey string
+	Deleter   Deleter
+}
+
+// DeleteChildrenWithTx 在事务中删除父资源的所有子资源，需在同一个事务中删除父资源前调用
+func DeleteChildrenWithTx(kt *kit.Kit, tx *sqlx.Tx, children []Child, parentIDs []string) error {
+	if len(parentIDs) == 0 {
+		return nil
+	}
+
+	for _, child := range children {
+		for _, ids := range slice.Split(parentIDs, constant.BatchOperationMaxLimit) {
+			if err := child.Deleter.DeleteWithTx(kt, tx, tools.ContainersExpression(child.ParentKey, ids)); err != nil {
+				logs.Errorf("cascade delete %s by %s failed, err: %v, ids: %v, rid: %s", child.Table, child.ParentKey,
+					err, ids, kt.Rid)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cascade
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"hcm/pkg/dal/table"
+	"hcm/pkg/kit"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// recordDeleter records the parent ids of each delete call.
+type recordDeleter struct {
+	name    string
+	calls   *[]string
+	batches [][]string
+	err     error
+}
+
+// DeleteWithTx ...
+func (r *recordDeleter) DeleteWithTx(_ *kit.Kit, _ *sqlx.Tx, expr *filter.Expression) error {
+	rule := expr.Rules[0].(filter.AtomRule)
+	*r.calls = append(*r.calls, r.name+"."+rule.Field)
+	r.batches = append(r.batches, rule.Value.([]string))
+	return r.err
+}
+
+func TestDeleteChildrenWithTx(t *testing.T) {
+	calls := make([]string, 0)
+	rule := &recordDeleter{name: "rule", calls: &calls}
+	rel := &recordDeleter{name: "rel", calls: &calls}
+	children := []Child{
+		{Table: table.TCloudSecurityGroupRuleTable, ParentKey: "security_group_id", Deleter: rule},
+		{Table: table.SecurityGroupCvmTable, ParentKey: "security_group_id", Deleter: rel},
+	}
+
+	parentIDs := make([]string, 0, 150)
+	for idx := 0; idx < 150; idx++ {
+		parentIDs = append(parentIDs, fmt.Sprintf("sg-%d", idx))
+	}
+
+	if err := DeleteChildrenWithTx(kit.New(), nil, children, parentIDs); err != nil {
+		t.Fatalf("cascade delete failed, err: %v", err)
+	}
+
+	// children are deleted in the declared order, and the parent ids are split by the batch limit.
+	expect := "[rule.security_group_id rule.security_group_id rel.security_group_id rel.security_group_id]"
+	if fmt.Sprint(calls) != expect {
+		t.Errorf("unexpected delete calls: %v", calls)
+	}
+	if len(rule.batches[0]) != 100 || len(rule.batches[1]) != 50 || rule.batches[1][49] != "sg-149" {
+		t.Errorf("parent ids should be deleted in batches, got: %d, %d", len(rule.batches[0]), len(rule.batches[1]))
+	}
+}
+
+func TestDeleteChildrenWithTxFailed(t *testing.T) {
+	calls := make([]string, 0)
+	rule := &recordDeleter{name: "rule", calls: &calls, err: errors.New("delete failed")}
+	rel := &recordDeleter{name: "rel", calls: &calls}
+	children := []Child{
+		{Table: table.TCloudSecurityGroupRuleTable, ParentKey: "security_group_id", Deleter: rule},
+		{Table: table.SecurityGroupCvmTable, ParentKey: "security_group_id", Deleter: rel},
+	}
+
+	if err := DeleteChildrenWithTx(kit.New(), nil, children, []string{"sg-1"}); err == nil {
+		t.Fatalf("error of the child deleter should be returned")
+	}
+	if len(calls) != 1 {
+		t.Errorf("cascade delete should stop at the first failure, got: %v", calls)
+	}
+
+	if err := DeleteChildrenWithTx(kit.New(), nil, children, nil); err != nil || len(calls) != 1 {
+		t.Errorf("no child should be deleted without parent, err: %v, calls: %v", err, calls)
+	}
+}
@@ -70,7 +70,8 @@ func (dao BizTypeDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string, mode
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, model.TableName(), setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, model.TableName(), setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = id
 	_, err = dao.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
@@ -93,14 +94,14 @@ func (dao BizTypeDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *tableselecti
 		return "", err
 	}
 	model.ID = id
+	model.TenantID = kt.GetTenantID()
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, model.TableName(), tableselection.BizTypeTableColumns.ColumnExpr(),
-		tableselection.BizTypeTableColumns.ColonNameExpr())
+	sql := tableselection.BizTypeTableColumns.InsertExpr(string(model.TableName()))
 
 	err = dao.Orm.Txn(tx).Insert(kt.Ctx, sql, model)
 	if err != nil {
 		logs.Errorf("insert %s failed, err: %v, sql: %s, model: %+v, rid: %s", err, sql, model, kt.Rid)
-		return "", fmt.Errorf("insert %s failed, err: %v", model.TableName(), err)
+		return "", fmt.Errorf("insert %s failed, err: %w", model.TableName(), err)
 	}
 
 	return id, nil
@@ -117,7 +118,7 @@ func (dao BizTypeDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListResul
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -144,12 +145,14 @@ func (dao BizTypeDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListResul
 		table.CloudSelectionBizTypeTable, whereExpr, pageExpr)
 
 	details := make([]tableselection.BizTypeTable, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.CloudSelectionBizTypeTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		logs.Errorf("select biz type failed, err: %v, sql: %s, rid: %s", err, sql, kt.Rid)
 		return nil, err
 	}
 
-	return &types.ListResult[tableselection.BizTypeTable]{Count: 0, Details: details}, nil
+	return &types.ListResult[tableselection.BizTypeTable]{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx biz type with tx.
@@ -158,7 +161,7 @@ func (dao BizTypeDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
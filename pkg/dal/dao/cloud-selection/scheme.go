@@ -68,7 +68,8 @@ func (dao SchemeDao) UpdateByID(kt *kit.Kit, id string, model *tableselection.Sc
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, model.TableName(), setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, model.TableName(), setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = id
 	_, err = dao.Orm.Do().Update(kt.Ctx, sql, toUpdate)
@@ -91,18 +92,18 @@ func (dao SchemeDao) Create(kt *kit.Kit, model *tableselection.SchemeTable) (str
 		return "", err
 	}
 	model.ID = id
+	model.TenantID = kt.GetTenantID()
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, model.TableName(),
-		tableselection.SchemeTableColumns.ColumnExpr(), tableselection.SchemeTableColumns.ColonNameExpr())
+	sql := tableselection.SchemeTableColumns.InsertExpr(string(model.TableName()))
 
 	err = dao.Orm.Do().Insert(kt.Ctx, sql, model)
 	if err != nil {
-		if em := errf.GetMySQLDuplicated(err); em != nil {
-			return "", errf.New(errf.RecordDuplicated, em.Message)
+		if errf.IsDuplicated(err) {
+			return "", err
 		}
 		logs.Errorf("insert %s failed, err: %v, sql: %s, model: %+v, rid: %s",
 			model.TableName(), err, sql, model, kt.Rid)
-		return "", fmt.Errorf("insert %s failed, err: %v", model.TableName(), err)
+		return "", fmt.Errorf("insert %s failed, err: %w", model.TableName(), err)
 	}
 
 	return id, nil
@@ -119,7 +120,7 @@ func (dao SchemeDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -146,12 +147,14 @@ func (dao SchemeDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult
 		table.CloudSelectionSchemeTable, whereExpr, pageExpr)
 
 	details := make([]tableselection.SchemeTable, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.CloudSelectionSchemeTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		logs.Errorf("select scheme failed, err: %v, sql: %s, rid: %s", err, sql, kt.Rid)
 		return nil, err
 	}
 
-	return &types.ListResult[tableselection.SchemeTable]{Count: 0, Details: details}, nil
+	return &types.ListResult[tableselection.SchemeTable]{Count: count, Details: details}, nil
 }
 
 // Delete scheme with tx.
@@ -160,7 +163,7 @@ func (dao SchemeDao) Delete(kt *kit.Kit, filterExpr *filter.Expression) error {
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
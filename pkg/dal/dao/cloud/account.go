@@ -25,6 +25,7 @@ import (
 	"hcm/pkg/api/core"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/cryptography"
 	"hcm/pkg/dal/dao/audit"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
@@ -49,15 +50,19 @@ type Account interface {
 	List(kt *kit.Kit, opt *types.ListOption) (*types.ListAccountDetails, error)
 	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
 	DeleteValidate(kt *kit.Kit, accountID string) (map[string]uint64, error)
+	DecryptExtension(extension tabletype.JsonField) (tabletype.JsonField, error)
+	MergeExtension(kt *kit.Kit, accountID string, patch interface{}) (tabletype.JsonField, error)
+	RotateSecret(kt *kit.Kit) (uint64, error)
 }
 
 var _ Account = new(AccountDao)
 
-// AccountDao account dao.
+// AccountDao account dao, the secrets in account extension are encrypted by the cipher before stored.
 type AccountDao struct {
-	Orm   orm.Interface
-	IDGen idgenerator.IDGenInterface
-	Audit audit.Interface
+	Orm    orm.Interface
+	IDGen  idgenerator.IDGenInterface
+	Audit  audit.Interface
+	Cipher cryptography.Crypto
 }
 
 // tableNames define table name.
@@ -130,14 +135,19 @@ func (a AccountDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *cloud.AccountT
 		return "", err
 	}
 	model.ID = id
+	model.TenantID = kt.GetTenantID()
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, model.TableName(), cloud.AccountColumns.ColumnExpr(),
-		cloud.AccountColumns.ColonNameExpr())
+	// 账号密钥加密后存储
+	if model.Extension, err = a.encryptExtension(model.Extension); err != nil {
+		return "", err
+	}
 
-	model.TenantID = kt.TenantID
+	sql := cloud.AccountColumns.InsertExpr(string(model.TableName()))
+
+	model.TenantID = kt.GetTenantID()
 	err = a.Orm.Txn(tx).Insert(kt.Ctx, sql, model)
 	if err != nil {
-		return "", fmt.Errorf("insert %s failed, err: %v", model.TableName(), err)
+		return "", fmt.Errorf("insert %s failed, err: %w", model.TableName(), err)
 	}
 
 	// create audit.
@@ -177,7 +187,7 @@ func (a AccountDao) Update(kt *kit.Kit, filterExpr *filter.Expression, model *cl
 		return err
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -227,7 +237,7 @@ func (a AccountDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListAccount
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -245,7 +255,8 @@ func (a AccountDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListAccount
 		return &types.ListAccountDetails{Count: count}, nil
 	}
 
-	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	pageExpr, err := types.PageSQLExpr(opt.Page,
+		types.DefaultPageSQLOption.WithSortFields(cloud.AccountColumns.ColumnTypes()))
 	if err != nil {
 		return nil, err
 	}
@@ -254,11 +265,13 @@ func (a AccountDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListAccount
 		table.AccountTable, whereExpr, pageExpr)
 
 	details := make([]*cloud.AccountTable, 0)
-	if err = a.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AccountTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, a.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.ListAccountDetails{Count: 0, Details: details}, nil
+	return &types.ListAccountDetails{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx account with tx.
@@ -267,7 +280,7 @@ func (a AccountDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.Ex
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -58,12 +58,15 @@ func (a AccountBizRelDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, rels []*cl
 		return errf.New(errf.InvalidParameter, "account_biz_rel is required")
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AccountBizRelTable,
-		cloud.AccountBizRelColumns.ColumnExpr(), cloud.AccountBizRelColumns.ColonNameExpr())
+	for _, rel := range rels {
+		rel.TenantID = kt.GetTenantID()
+	}
+
+	sql := cloud.AccountBizRelColumns.InsertExpr(string(table.AccountBizRelTable))
 
 	err := a.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, rels)
 	if err != nil {
-		return fmt.Errorf("insert %s failed, err: %v", table.AccountBizRelTable, err)
+		return fmt.Errorf("insert %s failed, err: %w", table.AccountBizRelTable, err)
 	}
 
 	return nil
@@ -80,7 +83,7 @@ func (a AccountBizRelDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListA
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -107,11 +110,13 @@ func (a AccountBizRelDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListA
 		table.AccountBizRelTable, whereExpr, pageExpr)
 
 	details := make([]*cloud.AccountBizRelTable, 0)
-	if err = a.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AccountBizRelTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, a.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.ListAccountBizRelDetails{Count: 0, Details: details}, nil
+	return &types.ListAccountBizRelDetails{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx AccountBizRel with tx.
@@ -120,7 +125,7 @@ func (a AccountBizRelDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *fil
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -142,15 +147,20 @@ func (a AccountBizRelDao) ListJoinAccount(kt *kit.Kit, bkBizIDs []int64) (
 		return nil, errf.Newf(errf.InvalidParameter, "bk biz ids is required")
 	}
 
-	sql := fmt.Sprintf(`SELECT %s, %s FROM %s AS rel LEFT JOIN %s AS account ON rel.account_id = account.id 
-	WHERE rel.bk_biz_id in (:bk_biz_ids)`,
-	cloud.AccountColumns.FieldsNamedExprWithout(types.DefaultRelJoinWithoutField),
-	tools.BaseRelJoinSqlBuild("rel", "account", "id", "bk_biz_id"),
-	table.AccountBizRelTable, table.AccountTable,
+	whereExpr, whereValue, err := tools.ContainersExpression("rel.bk_biz_id", bkBizIDs).SQLWhereExpr(
+		tools.TenantJoinSqlWhereOption(kt, "rel"))
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s, %s FROM %s AS rel LEFT JOIN %s AS account ON rel.account_id = account.id %s`,
+		cloud.AccountColumns.FieldsNamedExprWithout(types.DefaultRelJoinWithoutField),
+		tools.BaseRelJoinSqlBuild("rel", "account", "id", "bk_biz_id"),
+		table.AccountBizRelTable, table.AccountTable, whereExpr,
 	)
 
 	details := make([]*types.AccountWithBizID, 0)
-	if err := a.Orm.Do().Select(kt.Ctx, &details, sql, map[string]interface{}{"bk_biz_ids": bkBizIDs}); err != nil {
+	if err = a.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
 		logs.ErrorJson("select account biz rel join account failed, err: %v, sql: (%s), rid: %s", err, sql, kt.Rid)
 		return nil, err
 	}
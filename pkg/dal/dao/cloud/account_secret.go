@@ -0,0 +1,265 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/cryptography"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table/cloud"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/json"
+)
+
+// isSecretField 账号扩展字段中名称包含 secret_key 的字段为密钥，与审计时去除密钥的规则一致
+func isSecretField(field string) bool {
+	return strings.Contains(field, "secret_key")
+}
+
+// convertSecret 对账号扩展字段中不为空的密钥逐个进行转换，返回转换后的扩展字段及是否有密钥被转换
+func convertSecret(extension string, convert func(field, value string) (string, bool, error)) (string, bool,
+	error) {
+
+	if len(extension) == 0 {
+		return extension, false, nil
+	}
+
+	fields := make(map[string]interface{})
+	if err := json.UnmarshalFromString(extension, &fields); err != nil {
+		return "", false, fmt.Errorf("unmarshal account extension failed, err: %v", err)
+	}
+
+	converted := false
+	for field, value := range fields {
+		secret, ok := value.(string)
+		if !ok || len(secret) == 0 || !isSecretField(field) {
+			continue
+		}
+
+		result, changed, err := convert(field, secret)
+		if err != nil {
+			return "", false, fmt.Errorf("convert account extension %s failed, err: %v", field, err)
+		}
+
+		if changed {
+			fields[field] = result
+			converted = true
+		}
+	}
+
+	if !converted {
+		return extension, false, nil
+	}
+
+	result, err := json.MarshalToString(fields)
+	if err != nil {
+		return "", false, fmt.Errorf("marshal account extension failed, err: %v", err)
+	}
+
+	return result, true, nil
+}
+
+// rotateSecret 返回对需要轮换的密钥重新加密的转换方法
+func rotateSecret(rotator cryptography.Rotator) func(field, value string) (string, bool, error) {
+	return func(_, value string) (string, bool, error) {
+		need, err := rotator.NeedRotate(value)
+		if err != nil || !need {
+			return value, false, err
+		}
+
+		rotated, err := rotator.Rotate(value)
+		return rotated, true, err
+	}
+}
+
+// SecretKeyIDs 返回账号扩展字段中各密钥加密时使用的主密钥ID，用于备份时记录恢复数据所需的主密钥
+func SecretKeyIDs(extension string) ([]string, error) {
+	keyIDs := make([]string, 0)
+	_, _, err := convertSecret(extension, func(_, value string) (string, bool, error) {
+		keyID, err := cryptography.KeyIDOf(value)
+		if err != nil {
+			return "", false, err
+		}
+		keyIDs = append(keyIDs, keyID)
+		return value, false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keyIDs, nil
+}
+
+// VerifySecret 校验账号扩展字段中的密钥均可以被 cipher 解密，用于导入其他环境的备份前确认当前环境持有对应的主密钥
+func VerifySecret(cipher cryptography.Crypto, extension string) error {
+	_, _, err := convertSecret(extension, func(_, value string) (string, bool, error) {
+		if _, err := cipher.DecryptFromBase64(value); err != nil {
+			keyID, _ := cryptography.KeyIDOf(value)
+			return "", false, fmt.Errorf("can not decrypt secret encrypted by key %s, err: %v", keyID, err)
+		}
+		return value, false, nil
+	})
+
+	return err
+}
+
+func (a AccountDao) cipher() (cryptography.Crypto, error) {
+	if a.Cipher == nil {
+		return nil, errors.New("cipher of account dao is not set")
+	}
+
+	return a.Cipher, nil
+}
+
+// encryptExtension 加密账号扩展字段中的明文密钥
+func (a AccountDao) encryptExtension(extension tabletype.JsonField) (tabletype.JsonField, error) {
+	cipher, err := a.cipher()
+	if err != nil {
+		return "", err
+	}
+
+	encrypted, _, err := convertSecret(string(extension), func(_, value string) (string, bool, error) {
+		return cipher.EncryptToBase64(value), true, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return tabletype.JsonField(encrypted), nil
+}
+
+// DecryptExtension 解密账号扩展字段中的密钥，历史AesGcm密文与信封密文均可解密，仅用于需要使用密钥访问云上的场景
+func (a AccountDao) DecryptExtension(extension tabletype.JsonField) (tabletype.JsonField, error) {
+	cipher, err := a.cipher()
+	if err != nil {
+		return "", err
+	}
+
+	decrypted, _, err := convertSecret(string(extension), func(_, value string) (string, bool, error) {
+		plaintext, err := cipher.DecryptFromBase64(value)
+		return plaintext, true, err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return tabletype.JsonField(decrypted), nil
+}
+
+// MergeExtension 加密待更新扩展字段中的明文密钥，并与账号当前的扩展字段合并，返回合并后用于更新的扩展字段
+func (a AccountDao) MergeExtension(kt *kit.Kit, accountID string, patch interface{}) (tabletype.JsonField, error) {
+	patchJson, err := json.MarshalToString(patch)
+	if err != nil {
+		return "", fmt.Errorf("marshal account extension failed, err: %v", err)
+	}
+
+	encrypted, err := a.encryptExtension(tabletype.JsonField(patchJson))
+	if err != nil {
+		return "", err
+	}
+
+	opt := &types.ListOption{
+		Filter: tools.EqualExpression("id", accountID),
+		Fields: []string{"id", "extension"},
+		Page:   &core.BasePage{Limit: 1},
+	}
+	result, err := a.List(kt, opt)
+	if err != nil {
+		logs.Errorf("list account failed, err: %v, id: %s, rid: %s", err, accountID, kt.Rid)
+		return "", err
+	}
+
+	if len(result.Details) == 0 {
+		return "", fmt.Errorf("account %s not found", accountID)
+	}
+
+	fields := make(map[string]interface{})
+	if err = json.UnmarshalFromString(string(encrypted), &fields); err != nil {
+		return "", fmt.Errorf("unmarshal account extension failed, err: %v", err)
+	}
+
+	merged, err := json.UpdateMerge(fields, string(result.Details[0].Extension))
+	if err != nil {
+		return "", fmt.Errorf("json UpdateMerge extension failed, err: %v", err)
+	}
+
+	return tabletype.JsonField(merged), nil
+}
+
+// RotateSecret 使用当前主密钥重新加密租户下账号的密钥，历史AesGcm密文及非当前主密钥加密的密文都会被重新加密，
+// 返回重新加密的账号数量。需要开启信封加密。
+func (a AccountDao) RotateSecret(kt *kit.Kit) (uint64, error) {
+	cipher, err := a.cipher()
+	if err != nil {
+		return 0, err
+	}
+
+	rotator, ok := cipher.(cryptography.Rotator)
+	if !ok {
+		return 0, errors.New("crypto envelope is not enabled, can not rotate secret")
+	}
+
+	rotate := rotateSecret(rotator)
+
+	var rotated uint64
+	lastID := ""
+	for {
+		opt := &types.ListOption{
+			Filter: tools.ExpressionAnd(tools.RuleIDGreaterThan(lastID)),
+			Fields: []string{"id", "extension"},
+			Page:   &core.BasePage{Limit: core.DefaultMaxPageLimit, Sort: "id", Order: core.Ascending},
+		}
+		result, err := a.List(kt, opt)
+		if err != nil {
+			logs.Errorf("list account to rotate secret failed, err: %v, rid: %s", err, kt.Rid)
+			return rotated, err
+		}
+
+		for _, one := range result.Details {
+			extension, changed, err := convertSecret(string(one.Extension), rotate)
+			if err != nil {
+				logs.Errorf("rotate account %s secret failed, err: %v, rid: %s", one.ID, err, kt.Rid)
+				return rotated, err
+			}
+
+			if !changed {
+				continue
+			}
+
+			model := &cloud.AccountTable{Extension: tabletype.JsonField(extension), Reviser: kt.User}
+			if err = a.Update(kt, tools.EqualExpression("id", one.ID), model); err != nil {
+				return rotated, err
+			}
+			rotated++
+		}
+
+		if uint(len(result.Details)) < core.DefaultMaxPageLimit {
+			return rotated, nil
+		}
+		lastID = result.Details[len(result.Details)-1].ID
+	}
+}
@@ -0,0 +1,165 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"testing"
+
+	"hcm/pkg/cryptography"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/tools/json"
+)
+
+func newTestCipher(t *testing.T, activeKeyID string) (*cryptography.Envelope, *cryptography.AESGcm) {
+	legacy, err := cryptography.NewAESGcm([]byte("0123456789abcdef"), []byte("0123456789ab"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := map[string]string{"k1": "abcdefghijklmnop", "k2": "ponmlkjihgfedcba"}
+	provider, err := cryptography.NewKeyProvider(cryptography.SmKeyProvider, keys, activeKeyID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	envelope, err := cryptography.NewEnvelope(provider, legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return envelope, legacy
+}
+
+func extensionField(t *testing.T, extension tabletype.JsonField, field string) string {
+	fields := make(map[string]string)
+	if err := json.UnmarshalFromString(string(extension), &fields); err != nil {
+		t.Fatal(err)
+	}
+
+	return fields[field]
+}
+
+func TestAccountDaoEncryptExtension(t *testing.T) {
+	cipher, _ := newTestCipher(t, "k1")
+	dao := AccountDao{Cipher: cipher}
+
+	plain := tabletype.JsonField(`{"cloud_secret_id":"id","cloud_secret_key":"key","cloud_client_secret_key":""}`)
+	encrypted, err := dao.encryptExtension(plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if extensionField(t, encrypted, "cloud_secret_id") != "id" {
+		t.Fatalf("non secret field should not be encrypted, extension: %s", encrypted)
+	}
+
+	if secret := extensionField(t, encrypted, "cloud_secret_key"); secret == "key" || len(secret) == 0 {
+		t.Fatalf("secret key should be encrypted, extension: %s", encrypted)
+	}
+
+	if extensionField(t, encrypted, "cloud_client_secret_key") != "" {
+		t.Fatalf("empty secret key should be kept, extension: %s", encrypted)
+	}
+
+	decrypted, err := dao.DecryptExtension(encrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if extensionField(t, decrypted, "cloud_secret_key") != "key" {
+		t.Fatalf("decrypt secret key failed, extension: %s", decrypted)
+	}
+
+	if _, err = (AccountDao{}).DecryptExtension(encrypted); err == nil {
+		t.Fatal("account dao without cipher should not decrypt secret")
+	}
+}
+
+func TestAccountDaoRotateSecret(t *testing.T) {
+	oldCipher, legacy := newTestCipher(t, "k1")
+	cipher, _ := newTestCipher(t, "k2")
+
+	extension := `{"cloud_service_secret_key":"` + legacy.EncryptToBase64("legacy") + `","cloud_secret_key":"` +
+		oldCipher.EncryptToBase64("old") + `"}`
+
+	rotated, changed, err := convertSecret(extension, rotateSecret(cipher))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !changed {
+		t.Fatal("legacy and old key ciphertext should be rotated")
+	}
+
+	for field, plain := range map[string]string{"cloud_service_secret_key": "legacy", "cloud_secret_key": "old"} {
+		value := extensionField(t, tabletype.JsonField(rotated), field)
+		if need, err := cipher.NeedRotate(value); err != nil || need {
+			t.Fatalf("%s should be encrypted by the active key, need: %v, err: %v", field, need, err)
+		}
+
+		decrypted, err := cipher.DecryptFromBase64(value)
+		if err != nil || decrypted != plain {
+			t.Fatalf("decrypt rotated %s failed, plain: %s, err: %v", field, decrypted, err)
+		}
+	}
+
+	again, changed, err := convertSecret(rotated, rotateSecret(cipher))
+	if err != nil || changed || again != rotated {
+		t.Fatalf("rotated secret should not be rotated again, changed: %v, err: %v", changed, err)
+	}
+}
+
+func TestSecretKeyIDs(t *testing.T) {
+	cipher, legacy := newTestCipher(t, "k1")
+
+	extension := `{"cloud_secret_id":"id","cloud_secret_key":"` + cipher.EncryptToBase64("secret") +
+		`","cloud_main_secret_key":"` + legacy.EncryptToBase64("secret") + `"}`
+
+	keyIDs, err := SecretKeyIDs(extension)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]bool)
+	for _, keyID := range keyIDs {
+		got[keyID] = true
+	}
+	if len(keyIDs) != 2 || !got["k1"] || !got[cryptography.LegacyKeyID] {
+		t.Fatalf("key ids should be k1 and legacy, got: %v", keyIDs)
+	}
+
+	if err = VerifySecret(cipher, extension); err != nil {
+		t.Fatalf("verify secret should pass, err: %v", err)
+	}
+
+	other, err := cryptography.NewKeyProvider(cryptography.SmKeyProvider,
+		map[string]string{"k3": "0123456789abcdef"}, "k3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCipher, err := cryptography.NewEnvelope(other, legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = VerifySecret(otherCipher, extension); err == nil {
+		t.Fatal("verify secret encrypted by unknown key should fail")
+	}
+}
@@ -76,14 +76,14 @@ func (dao Dao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []*tableargstp
 		}
 
 		model.ID = ids[index]
+		model.TenantID = kt.GetTenantID()
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, tableName,
-		tableargstpl.ArgumentTplTableColumns.ColumnExpr(), tableargstpl.ArgumentTplTableColumns.ColonNameExpr())
+	sql := tableargstpl.ArgumentTplTableColumns.InsertExpr(string(tableName))
 
 	if err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", tableName, err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", tableName, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", tableName, err)
 	}
 
 	// create audit.
@@ -125,7 +125,7 @@ func (dao Dao) Update(kt *kit.Kit, expr *filter.Expression, model *tableargstpl.
 		return err
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -173,7 +173,8 @@ func (dao Dao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string,
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, table.ArgumentTemplateTable, setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, table.ArgumentTemplateTable, setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = id
 	_, err = dao.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
@@ -198,7 +199,7 @@ func (dao Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListArgumentTemp
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -224,11 +225,13 @@ func (dao Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListArgumentTemp
 		table.ArgumentTemplateTable, whereExpr, pageExpr)
 
 	details := make([]tableargstpl.ArgumentTemplateTable, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ArgumentTemplateTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.ListArgumentTemplateDetails{Details: details}, nil
+	return &types.ListArgumentTemplateDetails{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx delete argument template.
@@ -237,7 +240,7 @@ func (dao Dao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) e
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
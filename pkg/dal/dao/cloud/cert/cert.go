@@ -74,14 +74,14 @@ func (dao Dao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []*tablecert.S
 		}
 
 		model.ID = ids[index]
+		model.TenantID = kt.GetTenantID()
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, tableName,
-		tablecert.SslCertTableColumns.ColumnExpr(), tablecert.SslCertTableColumns.ColonNameExpr())
+	sql := tablecert.SslCertTableColumns.InsertExpr(string(tableName))
 
 	if err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", tableName, err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", tableName, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", tableName, err)
 	}
 
 	// create audit.
@@ -123,7 +123,7 @@ func (dao Dao) Update(kt *kit.Kit, expr *filter.Expression, model *tablecert.Ssl
 		return err
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -170,7 +170,8 @@ func (dao Dao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string, updateData
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, table.SslCertTable, setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, table.SslCertTable, setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = id
 	_, err = dao.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
@@ -194,7 +195,7 @@ func (dao Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListCertDetails,
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -221,11 +222,13 @@ func (dao Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListCertDetails,
 		table.SslCertTable, whereExpr, pageExpr)
 
 	details := make([]tablecert.SslCertTable, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.SslCertTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.ListCertDetails{Details: details}, nil
+	return &types.ListCertDetails{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx delete ssl cert.
@@ -234,7 +237,7 @@ func (dao Dao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) e
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -110,7 +110,7 @@ func (dao CloudDao) ListResourceIDs(kt *kit.Kit, resType enumor.CloudResourceTyp
 		return nil, errf.New(errf.InvalidParameter, "ids is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -140,7 +140,7 @@ func (dao CloudDao) AssignResourceToBiz(kt *kit.Kit, tx *sqlx.Tx, resType enumor
 		return errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,165 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tablecvm "hcm/pkg/dal/table/cloud/cvm"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CmdbHostRelInterface only used for cvm and cmdb host relation.
+type CmdbHostRelInterface interface {
+	BatchUpsert(kt *kit.Kit, models []tablecvm.CmdbHostRelTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tablecvm.CmdbHostRelTable], error)
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+}
+
+var _ CmdbHostRelInterface = new(CmdbHostRelDao)
+
+// CmdbHostRelDao cvm and cmdb host relation dao.
+type CmdbHostRelDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// BatchUpsert record the cmdb host relations of cvms, one cvm has only one relation. When the relation exists, the
+// previous cmdb host id is kept if the new one is 0 (sync failed), the previous module id is kept if the new one is
+// 0 and the cvm is still in the same biz.
+func (dao CmdbHostRelDao) BatchUpsert(kt *kit.Kit, models []tablecvm.CmdbHostRelTable) error {
+	if len(models) == 0 {
+		return errf.New(errf.InvalidParameter, "models to upsert are required")
+	}
+
+	ids, err := dao.IDGen.Batch(kt, table.CmdbHostRelTable, len(models))
+	if err != nil {
+		return err
+	}
+
+	for index := range models {
+		models[index].ID = ids[index]
+		models[index].TenantID = kt.GetTenantID()
+		models[index].Creator = kt.User
+		models[index].Reviser = kt.User
+
+		if err = models[index].InsertValidate(); err != nil {
+			return err
+		}
+	}
+
+	// bk_module_id should be updated before bk_biz_id, because mysql evaluates the assignments from left to right.
+	sql := fmt.Sprintf(`INSERT INTO %s (%s) VALUES(%s) ON DUPLICATE KEY UPDATE
+		bk_module_id = IF(VALUES(bk_module_id) = 0 AND bk_biz_id = VALUES(bk_biz_id), bk_module_id,
+			VALUES(bk_module_id)),
+		bk_host_id = IF(VALUES(bk_host_id) = 0, bk_host_id, VALUES(bk_host_id)),
+		bk_biz_id = VALUES(bk_biz_id), account_id = VALUES(account_id), cloud_id = VALUES(cloud_id),
+		sync_status = VALUES(sync_status), sync_message = VALUES(sync_message), reviser = VALUES(reviser)`,
+		table.CmdbHostRelTable, tablecvm.CmdbHostRelColumns.ColumnExpr(),
+		tablecvm.CmdbHostRelColumns.ColonNameExpr())
+
+	if err = dao.Orm.Do().BulkInsert(kt.Ctx, sql, models); err != nil {
+		logs.Errorf("upsert %s failed, err: %v, sql: %s, rid: %s", table.CmdbHostRelTable, err, sql, kt.Rid)
+		return fmt.Errorf("upsert %s failed, err: %w", table.CmdbHostRelTable, err)
+	}
+
+	return nil
+}
+
+// List cmdb host relation.
+func (dao CmdbHostRelDao) List(kt *kit.Kit, opt *types.ListOption) (
+	*types.ListResult[tablecvm.CmdbHostRelTable], error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tablecvm.CmdbHostRelColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is dao count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.CmdbHostRelTable, whereExpr)
+
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count cmdb host rel failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[tablecvm.CmdbHostRelTable]{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tablecvm.CmdbHostRelColumns.FieldsNamedExpr(opt.Fields),
+		table.CmdbHostRelTable, whereExpr, pageExpr)
+
+	details := make([]tablecvm.CmdbHostRelTable, 0)
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.CmdbHostRelTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
+		logs.Errorf("select cmdb host rel failed, err: %v, sql: %s, rid: %s", err, sql, kt.Rid)
+		return nil, err
+	}
+
+	return &types.ListResult[tablecvm.CmdbHostRelTable]{Count: count, Details: details}, nil
+}
+
+// DeleteWithTx cmdb host relation with tx.
+func (dao CmdbHostRelDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error {
+	if expr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.CmdbHostRelTable, whereExpr)
+	if _, err = dao.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete cmdb host rel failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/table"
+	tablecvm "hcm/pkg/dal/table/cloud/cvm"
+	"hcm/pkg/kit"
+)
+
+// fakeOrm records the bulk insert statement of cmdb host relation.
+type fakeOrm struct {
+	orm.Interface
+	orm.DoOrm
+	sql      string
+	inserted []tablecvm.CmdbHostRelTable
+}
+
+// Do ...
+func (f *fakeOrm) Do() orm.DoOrm {
+	return f
+}
+
+// BulkInsert ...
+func (f *fakeOrm) BulkInsert(_ context.Context, sql string, args interface{}) error {
+	f.sql = sql
+	f.inserted = args.([]tablecvm.CmdbHostRelTable)
+	return nil
+}
+
+type fakeIDGen struct{}
+
+// Batch ...
+func (fakeIDGen) Batch(_ *kit.Kit, _ table.Name, count int) ([]string, error) {
+	ids := make([]string, count)
+	for idx := range ids {
+		ids[idx] = fmt.Sprintf("%08d", idx+1)
+	}
+	return ids, nil
+}
+
+// One ...
+func (fakeIDGen) One(_ *kit.Kit, _ table.Name) (string, error) {
+	return "00000001", nil
+}
+
+func TestCmdbHostRelBatchUpsert(t *testing.T) {
+	fake := new(fakeOrm)
+	dao := CmdbHostRelDao{Orm: fake, IDGen: fakeIDGen{}}
+	kt := kit.New()
+	kt.User = "admin"
+
+	models := []tablecvm.CmdbHostRelTable{
+		{CvmID: "cvm-1", Vendor: enumor.TCloud, AccountID: "account-1", CloudID: "ins-1", BkBizID: 100,
+			BkHostID: 1, SyncStatus: enumor.CmdbHostSyncSuccess},
+		{CvmID: "cvm-2", Vendor: enumor.TCloud, AccountID: "account-1", CloudID: "ins-2", BkBizID: 100,
+			SyncStatus: enumor.CmdbHostSyncFailed, SyncMessage: "cmdb host not found"},
+	}
+	if err := dao.BatchUpsert(kt, models); err != nil {
+		t.Fatalf("upsert cmdb host rel failed, err: %v", err)
+	}
+
+	if len(fake.inserted) != 2 || fake.inserted[1].ID != "00000002" || fake.inserted[1].Creator != "admin" {
+		t.Errorf("unexpected upserted cmdb host rels: %+v", fake.inserted)
+	}
+
+	// 同步失败时保留之前的cmdb主机ID，模块ID需要在业务ID之前更新
+	if !strings.Contains(fake.sql, "bk_host_id = IF(VALUES(bk_host_id) = 0, bk_host_id, VALUES(bk_host_id))") {
+		t.Errorf("previous cmdb host id should be kept when sync failed, sql: %s", fake.sql)
+	}
+	if strings.Index(fake.sql, "bk_module_id = IF") > strings.Index(fake.sql, "bk_biz_id = VALUES(bk_biz_id)") {
+		t.Errorf("bk_module_id should be updated before bk_biz_id, sql: %s", fake.sql)
+	}
+
+	// 同步成功时必须有cmdb主机ID
+	models = []tablecvm.CmdbHostRelTable{{CvmID: "cvm-1", Vendor: enumor.TCloud, AccountID: "account-1",
+		CloudID: "ins-1", BkBizID: 100, SyncStatus: enumor.CmdbHostSyncSuccess}}
+	if err := dao.BatchUpsert(kt, models); err == nil {
+		t.Errorf("success cmdb host rel without host id should be invalid")
+	}
+}
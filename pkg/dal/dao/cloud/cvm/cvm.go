@@ -29,6 +29,7 @@ import (
 	"hcm/pkg/dal/dao/audit"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	daoreshistory "hcm/pkg/dal/dao/resource-history"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table"
@@ -49,6 +50,7 @@ type Interface interface {
 	UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string, model *tablecvm.Table) error
 	List(kt *kit.Kit, opt *types.ListOption) (*types.ListCvmDetails, error)
 	ListWithTx(kt *kit.Kit, tx *sqlx.Tx, opt *types.ListOption) (*types.ListCvmDetails, error)
+	ListStream(kt *kit.Kit, opt *types.StreamListOption, handler func(one *tablecvm.Table) error) error
 	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
 }
 
@@ -56,9 +58,10 @@ var _ Interface = new(Dao)
 
 // Dao cvm dao.
 type Dao struct {
-	Orm   orm.Interface
-	IDGen idgenerator.IDGenInterface
-	Audit audit.Interface
+	Orm     orm.Interface
+	IDGen   idgenerator.IDGenInterface
+	Audit   audit.Interface
+	History daoreshistory.Interface
 }
 
 // BatchCreateWithTx cvm.
@@ -70,18 +73,23 @@ func (dao Dao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []*tablecvm.Ta
 	}
 	for index, model := range models {
 		model.ID = ids[index]
+		model.TenantID = kt.GetTenantID()
 
 		if err := model.InsertValidate(); err != nil {
 			return nil, err
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.CvmTable,
-		tablecvm.TableColumns.ColumnExpr(), tablecvm.TableColumns.ColonNameExpr())
+	sql := tablecvm.TableColumns.InsertExpr(string(table.CvmTable))
 
 	if err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", table.CvmTable, err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", table.CvmTable, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", table.CvmTable, err)
+	}
+
+	if err = dao.History.RecordWithTx(kt, tx, table.CvmTable, ids); err != nil {
+		logs.Errorf("record cvm history failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
 	}
 
 	// create audit.
@@ -124,7 +132,7 @@ func (dao Dao) Update(kt *kit.Kit, expr *filter.Expression, model *tablecvm.Tabl
 		return err
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -138,6 +146,11 @@ func (dao Dao) Update(kt *kit.Kit, expr *filter.Expression, model *tablecvm.Tabl
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, model.TableName(), setExpr, whereExpr)
 
 	_, err = dao.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := dao.History.ListIDsWithTx(kt, txn, table.CvmTable, whereExpr, whereValue)
+		if err != nil {
+			return nil, err
+		}
+
 		effected, err := dao.Orm.Txn(txn).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 		if err != nil {
 			logs.ErrorJson("update cvm failed, err: %v, filter: %s, rid: %v", err, expr, kt.Rid)
@@ -146,6 +159,12 @@ func (dao Dao) Update(kt *kit.Kit, expr *filter.Expression, model *tablecvm.Tabl
 
 		if effected == 0 {
 			logs.Infof("update cvm, but record not found, sql: %s, rid: %v", sql, kt.Rid)
+			return nil, nil
+		}
+
+		if err = dao.History.RecordWithTx(kt, txn, table.CvmTable, ids); err != nil {
+			logs.Errorf("record cvm history failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
 		}
 
 		return nil, nil
@@ -173,7 +192,8 @@ func (dao Dao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string, model *tabl
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, model.TableName(), setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, model.TableName(), setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = id
 	_, err = dao.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
@@ -182,6 +202,11 @@ func (dao Dao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string, model *tabl
 		return err
 	}
 
+	if err = dao.History.RecordWithTx(kt, tx, table.CvmTable, []string{id}); err != nil {
+		logs.Errorf("record cvm history failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+
 	return nil
 }
 
@@ -195,11 +220,11 @@ func (dao Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListCvmDetails,
 	columnTypes["extension.resource_group_name"] = enumor.String
 	columnTypes["extension.zones"] = enumor.Json
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
-		core.NewDefaultPageOption()); err != nil {
+		core.NewTablePageOption(table.CvmTable)); err != nil {
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -217,7 +242,8 @@ func (dao Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListCvmDetails,
 		return &types.ListCvmDetails{Count: count}, nil
 	}
 
-	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	pageExpr, err := types.PageSQLExpr(opt.Page,
+		types.DefaultPageSQLOption.WithSortFields(tablecvm.TableColumns.ColumnTypes()))
 	if err != nil {
 		return nil, err
 	}
@@ -226,11 +252,48 @@ func (dao Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListCvmDetails,
 		table.CvmTable, whereExpr, pageExpr)
 
 	details := make([]tablecvm.Table, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.CvmTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.ListCvmDetails{Details: details}, nil
+	return &types.ListCvmDetails{Count: count, Details: details}, nil
+}
+
+// ListStream cvm one by one in the order of id, the rows are scanned and handled one by one without
+// loading all of them into memory.
+func (dao Dao) ListStream(kt *kit.Kit, opt *types.StreamListOption, handler func(one *tablecvm.Table) error) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if handler == nil {
+		return errf.New(errf.InvalidParameter, "handler is required")
+	}
+
+	columnTypes := tablecvm.TableColumns.ColumnTypes()
+	columnTypes["extension.resource_group_name"] = enumor.String
+	columnTypes["extension.zones"] = enumor.Json
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes))); err != nil {
+		return err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s ORDER BY id`, tablecvm.TableColumns.FieldsNamedExpr(opt.Fields),
+		table.CvmTable, whereExpr)
+
+	return dao.Orm.Do().SelectRows(kt.Ctx, sql, whereValue, func(rows *sqlx.Rows) error {
+		one := new(tablecvm.Table)
+		if err := rows.StructScan(one); err != nil {
+			return err
+		}
+		return handler(one)
+	})
 }
 
 // ListWithTx cvm with tx.
@@ -243,11 +306,11 @@ func (dao Dao) ListWithTx(kt *kit.Kit, tx *sqlx.Tx, opt *types.ListOption) (*typ
 	columnTypes["extension.resource_group_name"] = enumor.String
 	columnTypes["extension.zones"] = enumor.Json
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
-		core.NewDefaultPageOption()); err != nil {
+		core.NewTablePageOption(table.CvmTable)); err != nil {
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -274,11 +337,13 @@ func (dao Dao) ListWithTx(kt *kit.Kit, tx *sqlx.Tx, opt *types.ListOption) (*typ
 		table.CvmTable, whereExpr, pageExpr)
 
 	details := make([]tablecvm.Table, 0)
-	if err = dao.Orm.Txn(tx).Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.CvmTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Txn(tx), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.ListCvmDetails{Details: details}, nil
+	return &types.ListCvmDetails{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx cvm.
@@ -287,11 +352,16 @@ func (dao Dao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) e
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
 
+	if err = dao.History.CloseByWhereWithTx(kt, tx, table.CvmTable, whereExpr, whereValue); err != nil {
+		logs.Errorf("close cvm history failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.CvmTable, whereExpr)
 	if _, err = dao.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
 		logs.ErrorJson("delete cvm failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
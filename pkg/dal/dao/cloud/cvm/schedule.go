@@ -0,0 +1,295 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	daoquota "hcm/pkg/dal/dao/quota"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tablecvm "hcm/pkg/dal/table/cloud/cvm"
+	"hcm/pkg/dal/table/utils"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+)
+
+// ScheduleInterface only used for cvm schedule and its execution records.
+type ScheduleInterface interface {
+	Create(kt *kit.Kit, model *tablecvm.ScheduleTable) (string, error)
+	UpdateByID(kt *kit.Kit, id string, model *tablecvm.ScheduleTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tablecvm.ScheduleTable], error)
+	Delete(kt *kit.Kit, expr *filter.Expression) error
+	CreateRecord(kt *kit.Kit, model *tablecvm.ScheduleRecordTable) (string, error)
+	ListRecord(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tablecvm.ScheduleRecordTable], error)
+	SumCpuCore(kt *kit.Kit, cvmIDs []string) (int64, error)
+}
+
+var _ ScheduleInterface = new(ScheduleDao)
+
+// ScheduleDao cvm schedule dao.
+type ScheduleDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// Create cvm schedule.
+func (dao ScheduleDao) Create(kt *kit.Kit, model *tablecvm.ScheduleTable) (string, error) {
+	if model == nil {
+		return "", errf.New(errf.InvalidParameter, "model is required")
+	}
+
+	id, err := dao.IDGen.One(kt, table.CvmScheduleTable)
+	if err != nil {
+		return "", err
+	}
+	model.ID = id
+	model.TenantID = kt.GetTenantID()
+
+	if err = model.InsertValidate(); err != nil {
+		return "", err
+	}
+
+	sql := tablecvm.ScheduleColumns.InsertExpr(string(model.TableName()))
+
+	if err = dao.Orm.Do().Insert(kt.Ctx, sql, model); err != nil {
+		if errf.IsDuplicated(err) {
+			return "", err
+		}
+		logs.Errorf("insert %s failed, err: %v, sql: %s, rid: %s", model.TableName(), err, sql, kt.Rid)
+		return "", fmt.Errorf("insert %s failed, err: %w", model.TableName(), err)
+	}
+
+	return id, nil
+}
+
+// UpdateByID update cvm schedule by id.
+func (dao ScheduleDao) UpdateByID(kt *kit.Kit, id string, model *tablecvm.ScheduleTable) error {
+	if len(id) == 0 {
+		return errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := model.UpdateValidate(); err != nil {
+		return err
+	}
+
+	opts := utils.NewFieldOptions().AddIgnoredFields(types.DefaultIgnoredFields...)
+	setExpr, toUpdate, err := utils.RearrangeSQLDataWithOption(model, opts)
+	if err != nil {
+		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, model.TableName(), setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
+
+	toUpdate["id"] = id
+	effected, err := dao.Orm.Do().Update(kt.Ctx, sql, toUpdate)
+	if err != nil {
+		logs.ErrorJson("update cvm schedule failed, err: %v, id: %s, rid: %v", err, id, kt.Rid)
+		return err
+	}
+
+	if effected == 0 {
+		return errf.Newf(errf.RecordNotFound, "cvm schedule: %s not found", id)
+	}
+
+	return nil
+}
+
+// List cvm schedule.
+func (dao ScheduleDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tablecvm.ScheduleTable], error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tablecvm.ScheduleColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is dao count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.CvmScheduleTable, whereExpr)
+
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count cvm schedule failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[tablecvm.ScheduleTable]{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tablecvm.ScheduleColumns.FieldsNamedExpr(opt.Fields),
+		table.CvmScheduleTable, whereExpr, pageExpr)
+
+	details := make([]tablecvm.ScheduleTable, 0)
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.CvmScheduleTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
+		logs.Errorf("select cvm schedule failed, err: %v, sql: %s, rid: %s", err, sql, kt.Rid)
+		return nil, err
+	}
+
+	return &types.ListResult[tablecvm.ScheduleTable]{Count: count, Details: details}, nil
+}
+
+// Delete cvm schedule.
+func (dao ScheduleDao) Delete(kt *kit.Kit, expr *filter.Expression) error {
+	if expr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.CvmScheduleTable, whereExpr)
+	if _, err = dao.Orm.Do().Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete cvm schedule failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// CreateRecord create cvm schedule execution record.
+func (dao ScheduleDao) CreateRecord(kt *kit.Kit, model *tablecvm.ScheduleRecordTable) (string, error) {
+	if model == nil {
+		return "", errf.New(errf.InvalidParameter, "model is required")
+	}
+
+	id, err := dao.IDGen.One(kt, table.CvmScheduleRecordTable)
+	if err != nil {
+		return "", err
+	}
+	model.ID = id
+	model.TenantID = kt.GetTenantID()
+
+	if err = model.InsertValidate(); err != nil {
+		return "", err
+	}
+
+	sql := tablecvm.ScheduleRecordColumns.InsertExpr(string(model.TableName()))
+
+	if err = dao.Orm.Do().Insert(kt.Ctx, sql, model); err != nil {
+		logs.Errorf("insert %s failed, err: %v, sql: %s, rid: %s", model.TableName(), err, sql, kt.Rid)
+		return "", fmt.Errorf("insert %s failed, err: %w", model.TableName(), err)
+	}
+
+	return id, nil
+}
+
+// ListRecord list cvm schedule execution record.
+func (dao ScheduleDao) ListRecord(kt *kit.Kit, opt *types.ListOption) (
+	*types.ListResult[tablecvm.ScheduleRecordTable], error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tablecvm.ScheduleRecordColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is dao count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.CvmScheduleRecordTable, whereExpr)
+
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count cvm schedule record failed, err: %v, filter: %s, rid: %s", err, opt.Filter,
+				kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[tablecvm.ScheduleRecordTable]{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tablecvm.ScheduleRecordColumns.FieldsNamedExpr(opt.Fields),
+		table.CvmScheduleRecordTable, whereExpr, pageExpr)
+
+	details := make([]tablecvm.ScheduleRecordTable, 0)
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.CvmScheduleRecordTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
+		logs.Errorf("select cvm schedule record failed, err: %v, sql: %s, rid: %s", err, sql, kt.Rid)
+		return nil, err
+	}
+
+	return &types.ListResult[tablecvm.ScheduleRecordTable]{Count: count, Details: details}, nil
+}
+
+// SumCpuCore sum vCPU core count of cvms, used to calculate the savings of cvm schedule.
+func (dao ScheduleDao) SumCpuCore(kt *kit.Kit, cvmIDs []string) (int64, error) {
+	if len(cvmIDs) == 0 {
+		return 0, nil
+	}
+
+	whereExpr, whereValue, err := tools.ContainersExpression("id", cvmIDs).SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return 0, err
+	}
+
+	sql := fmt.Sprintf(`SELECT CAST(IFNULL(SUM(%s), 0) AS SIGNED) AS cpu_core FROM %s %s`, daoquota.CvmCpuCoreExpr(),
+		table.CvmTable, whereExpr)
+
+	result := make([]struct {
+		CpuCore int64 `db:"cpu_core"`
+	}, 0)
+	if err = dao.Orm.Do().Select(kt.Ctx, &result, sql, whereValue); err != nil {
+		logs.Errorf("sum cvm cpu core failed, err: %v, sql: %s, rid: %s", err, sql, kt.Rid)
+		return 0, err
+	}
+
+	if len(result) == 0 {
+		return 0, nil
+	}
+
+	return result[0].CpuCore, nil
+}
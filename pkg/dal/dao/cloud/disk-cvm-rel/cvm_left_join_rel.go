@@ -25,6 +25,7 @@ import (
 	"hcm/pkg/api/core"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/dao/types/cloud"
@@ -50,7 +51,7 @@ func (relDao DiskCvmRelDao) ListCvmIDLeftJoinRel(kt *kit.Kit, opt *types.ListOpt
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantJoinSqlWhereOption(kt, "cvm"))
 	if err != nil {
 		logs.Errorf(
 			"gen where expr for list disk cvm rels failed, err: %v, filter: %s, rid: %s",
@@ -101,13 +102,17 @@ func (relDao DiskCvmRelDao) ListCvmIDLeftJoinRel(kt *kit.Kit, opt *types.ListOpt
 	)
 
 	details := make([]cvm.Table, 0)
-	if err := relDao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(
+		`SELECT count(distinct(cvm.id)) FROM %s as cvm left join %s as rel on cvm.id = rel.cvm_id %s`,
+		table.CvmTable, table.DiskCvmRelTableName, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, relDao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		logs.ErrorJson("select cvm left join disk_cvm_rel failed, err: %v, filter: %s, diskID: %s, rid: %s", err,
 			opt.Filter, notEqualDiskID, kt.Rid)
 		return nil, err
 	}
 
-	return &cloud.CvmLeftJoinDiskCvmRelResult{Details: details}, nil
+	return &cloud.CvmLeftJoinDiskCvmRelResult{Count: count, Details: details}, nil
 }
 
 // ListDiskLeftJoinRel ...
@@ -125,7 +130,7 @@ func (relDao *DiskCvmRelDao) ListDiskLeftJoinRel(kt *kit.Kit, opt *types.ListOpt
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantJoinSqlWhereOption(kt, "disk"))
 	if err != nil {
 		logs.Errorf(
 			"gen where expr for list disk cvm rels failed, err: %v, filter: %s, rid: %s",
@@ -173,11 +178,15 @@ func (relDao *DiskCvmRelDao) ListDiskLeftJoinRel(kt *kit.Kit, opt *types.ListOpt
 	)
 
 	details := make([]cloud.DiskLeftJoinDiskCvmRel, 0)
-	if err := relDao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(
+		`SELECT count(distinct(disk.id)) FROM %s as disk left join %s as rel on disk.id = rel.disk_id %s`,
+		table.DiskTable, table.DiskCvmRelTableName, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, relDao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		logs.ErrorJson("select disk left join disk_cvm_rel failed, err: %v, filter: %s, rid: %s", err,
 			opt.Filter, kt.Rid)
 		return nil, err
 	}
 
-	return &cloud.DiskLeftJoinDiskCvmRelResult{Details: details}, nil
+	return &cloud.DiskLeftJoinDiskCvmRelResult{Count: count, Details: details}, nil
 }
@@ -71,15 +71,14 @@ func (relDao DiskCvmRelDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, rels []*
 		return err
 	}
 
-	sql := fmt.Sprintf(
-		`INSERT INTO %s (%s) VALUES(%s)`,
-		table.DiskCvmRelTableName,
-		tablecloud.DiskCvmRelColumns.ColumnExpr(),
-		tablecloud.DiskCvmRelColumns.ColonNameExpr(),
-	)
+	for _, rel := range rels {
+		rel.TenantID = kt.GetTenantID()
+	}
+
+	sql := tablecloud.DiskCvmRelColumns.InsertExpr(string(table.DiskCvmRelTableName))
 	if err := relDao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, rels); err != nil {
 		logs.Errorf("batch create disk cvm rels failed, err: %v, rels: %v, rid: %s", err, rels, kt.Rid)
-		return fmt.Errorf("insert %s failed, err: %v", table.DiskCvmRelTableName, err)
+		return fmt.Errorf("insert %s failed, err: %w", table.DiskCvmRelTableName, err)
 	}
 
 	return nil
@@ -96,7 +95,7 @@ func (relDao DiskCvmRelDao) List(kt *kit.Kit, opt *types.ListOption) (*cloud.Dis
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		logs.Errorf(
 			"gen where expr for list disk cvm rels failed, err: %v, filter: %s, rid: %s",
@@ -137,11 +136,18 @@ func (relDao DiskCvmRelDao) List(kt *kit.Kit, opt *types.ListOption) (*cloud.Dis
 	)
 
 	details := make([]*tablecloud.DiskCvmRelModel, 0)
-	if err = relDao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.DiskCvmRelTableName, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, relDao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		logs.Errorf("list disk cvm rels failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
 		return nil, err
 	}
-	return &cloud.DiskCvmRelListResult{Details: details}, nil
+	result := &cloud.DiskCvmRelListResult{Details: details}
+	if opt.Page.WithCount {
+		result.Count = &count
+	}
+
+	return result, nil
 }
 
 // ListJoinDisk ...
@@ -150,8 +156,14 @@ func (relDao DiskCvmRelDao) ListJoinDisk(kt *kit.Kit, cvmIDs []string) (*cloud.D
 		return nil, errf.Newf(errf.InvalidParameter, "cvm ids is required")
 	}
 
+	whereExpr, whereValue, err := tools.ContainersExpression("rel.cvm_id", cvmIDs).SQLWhereExpr(
+		tools.TenantJoinSqlWhereOption(kt, "rel"))
+	if err != nil {
+		return nil, err
+	}
+
 	sql := fmt.Sprintf(
-		`SELECT %s, %s FROM %s as rel left join %s as disk on rel.disk_id = disk.id where cvm_id in (:cvm_ids)`,
+		`SELECT %s, %s FROM %s as rel left join %s as disk on rel.disk_id = disk.id %s`,
 		tabledisk.DiskColumns.FieldsNamedExprWithout(types.DefaultRelJoinWithoutField),
 		tools.BaseRelJoinSqlBuild(
 			"rel",
@@ -161,10 +173,11 @@ func (relDao DiskCvmRelDao) ListJoinDisk(kt *kit.Kit, cvmIDs []string) (*cloud.D
 		),
 		table.DiskCvmRelTableName,
 		table.DiskTable,
+		whereExpr,
 	)
 
 	details := make([]*cloud.DiskWithCvmID, 0)
-	if err := relDao.Orm.Do().Select(kt.Ctx, &details, sql, map[string]interface{}{"cvm_ids": cvmIDs}); err != nil {
+	if err = relDao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
 		logs.ErrorJson("select disk cvm rels join disk failed, err: %v, sql: (%s), rid: %s", err, sql, kt.Rid)
 		return nil, err
 	}
@@ -178,7 +191,7 @@ func (relDao DiskCvmRelDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *f
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
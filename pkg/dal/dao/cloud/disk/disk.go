@@ -68,9 +68,7 @@ func (diskDao DiskDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, disks []*disk
 		return nil, errf.New(errf.InvalidParameter, "disk model data is required")
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.DiskTable, disk.DiskColumns.ColumnExpr(),
-		disk.DiskColumns.ColonNameExpr(),
-	)
+	sql := disk.DiskColumns.InsertExpr(string(table.DiskTable))
 
 	ids, err := diskDao.IDGen.Batch(kt, table.DiskTable, len(disks))
 	if err != nil {
@@ -79,11 +77,12 @@ func (diskDao DiskDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, disks []*disk
 
 	for idx, d := range disks {
 		d.ID = ids[idx]
+		d.TenantID = kt.GetTenantID()
 	}
 
 	err = diskDao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, disks)
 	if err != nil {
-		return nil, fmt.Errorf("insert %s failed, err: %v", table.DiskTable, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", table.DiskTable, err)
 	}
 
 	// create audit.
@@ -121,7 +120,7 @@ func (diskDao DiskDao) Update(kt *kit.Kit, filterExpr *filter.Expression, update
 		return errf.New(errf.InvalidParameter, "filter expr is nil")
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -165,7 +164,8 @@ func (diskDao DiskDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, diskID string,
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, table.DiskTable, setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, table.DiskTable, setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = diskID
 	_, err = diskDao.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
@@ -188,11 +188,11 @@ func (diskDao DiskDao) List(kt *kit.Kit, opt *types.ListOption) (*cloud.DiskList
 	columnTypes["extension.self_link"] = enumor.String
 	columnTypes["extension.zones"] = enumor.Json
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
-		core.NewDefaultPageOption()); err != nil {
+		core.NewTablePageOption(table.DiskTable)); err != nil {
 		return nil, err
 	}
 
-	whereOpt := tools.DefaultSqlWhereOption
+	whereOpt := tools.TenantSqlWhereOption(kt)
 	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
 	if err != nil {
 		return nil, err
@@ -201,14 +201,17 @@ func (diskDao DiskDao) List(kt *kit.Kit, opt *types.ListOption) (*cloud.DiskList
 	if opt.Page.Count {
 		// this is a count request, then do count operation only.
 		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.DiskTable, whereExpr)
+
 		count, err := diskDao.Orm.Do().Count(kt.Ctx, sql, whereValue)
 		if err != nil {
 			logs.ErrorJson("count disk failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
 			return nil, err
 		}
+
 		return &cloud.DiskListResult{Count: count}, nil
 	}
-	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	pageExpr, err := types.PageSQLExpr(opt.Page,
+		types.DefaultPageSQLOption.WithSortFields(disk.DiskColumns.ColumnTypes()))
 	if err != nil {
 		return nil, err
 	}
@@ -217,13 +220,13 @@ func (diskDao DiskDao) List(kt *kit.Kit, opt *types.ListOption) (*cloud.DiskList
 		whereExpr, pageExpr)
 
 	details := make([]*disk.DiskModel, 0)
-	if err = diskDao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.DiskTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, diskDao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	result := &cloud.DiskListResult{Details: details}
-
-	return result, nil
+	return &cloud.DiskListResult{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx 删除云盘
@@ -232,7 +235,7 @@ func (diskDao DiskDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -256,7 +259,7 @@ func (diskDao DiskDao) Count(kt *kit.Kit, opt *types.CountOption) (*cloud.DiskCo
 	if err := opt.Validate(exprOption); err != nil {
 		return nil, err
 	}
-	whereOpt := tools.DefaultSqlWhereOption
+	whereOpt := tools.TenantSqlWhereOption(kt)
 	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
 	if err != nil {
 		return nil, err
@@ -70,15 +70,14 @@ func (relDao EipCvmRelDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, rels []*t
 		return err
 	}
 
-	sql := fmt.Sprintf(
-		`INSERT INTO %s (%s) VALUES(%s)`,
-		table.EipCvmRelTableName,
-		tablecloud.EipCvmRelColumns.ColumnExpr(),
-		tablecloud.EipCvmRelColumns.ColonNameExpr(),
-	)
+	for _, rel := range rels {
+		rel.TenantID = kt.GetTenantID()
+	}
+
+	sql := tablecloud.EipCvmRelColumns.InsertExpr(string(table.EipCvmRelTableName))
 	if err := relDao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, rels); err != nil {
 		logs.Errorf("batch create eip cvm rels failed, err: %v, rels: %v, rid: %s", err, rels, kt.Rid)
-		return fmt.Errorf("insert %s failed, err: %v", table.EipCvmRelTableName, err)
+		return fmt.Errorf("insert %s failed, err: %w", table.EipCvmRelTableName, err)
 	}
 
 	return nil
@@ -95,7 +94,7 @@ func (relDao EipCvmRelDao) List(kt *kit.Kit, opt *types.ListOption) (*cloud.EipC
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		logs.Errorf(
 			"gen where expr for list eip cvm rels failed, err: %v, filter: %s, rid: %s",
@@ -136,11 +135,18 @@ func (relDao EipCvmRelDao) List(kt *kit.Kit, opt *types.ListOption) (*cloud.EipC
 	)
 
 	details := make([]*tablecloud.EipCvmRelModel, 0)
-	if err = relDao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.EipCvmRelTableName, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, relDao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		logs.Errorf("list eip cvm rels failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
 		return nil, err
 	}
-	return &cloud.EipCvmRelListResult{Details: details}, nil
+	result := &cloud.EipCvmRelListResult{Details: details}
+	if opt.Page.WithCount {
+		result.Count = &count
+	}
+
+	return result, nil
 }
 
 // ListJoinEip ...
@@ -149,8 +155,14 @@ func (relDao EipCvmRelDao) ListJoinEip(kt *kit.Kit, cvmIDs []string) (*cloud.Eip
 		return nil, errf.Newf(errf.InvalidParameter, "cvm ids is required")
 	}
 
+	whereExpr, whereValue, err := tools.ContainersExpression("rel.cvm_id", cvmIDs).SQLWhereExpr(
+		tools.TenantJoinSqlWhereOption(kt, "rel"))
+	if err != nil {
+		return nil, err
+	}
+
 	sql := fmt.Sprintf(
-		`SELECT %s, %s FROM %s as rel left join %s as eip on rel.eip_id = eip.id where cvm_id in (:cvm_ids)`,
+		`SELECT %s, %s FROM %s as rel left join %s as eip on rel.eip_id = eip.id %s`,
 		tableeip.EipColumns.FieldsNamedExprWithout(types.DefaultRelJoinWithoutField),
 		tools.BaseRelJoinSqlBuild(
 			"rel",
@@ -160,10 +172,11 @@ func (relDao EipCvmRelDao) ListJoinEip(kt *kit.Kit, cvmIDs []string) (*cloud.Eip
 		),
 		table.EipCvmRelTableName,
 		table.EipTable,
+		whereExpr,
 	)
 
 	details := make([]*cloud.EipWithCvmID, 0)
-	if err := relDao.Orm.Do().Select(kt.Ctx, &details, sql, map[string]interface{}{"cvm_ids": cvmIDs}); err != nil {
+	if err = relDao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
 		logs.ErrorJson("select eip cvm rels join eip failed, err: %v, sql: (%s), rid: %s", err, sql, kt.Rid)
 		return nil, err
 	}
@@ -177,7 +190,7 @@ func (relDao EipCvmRelDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *fi
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -240,7 +253,7 @@ func (relDao EipCvmRelDao) ListEipLeftJoinRel(kt *kit.Kit, opt *types.ListOption
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantJoinSqlWhereOption(kt, "eip"))
 	if err != nil {
 		logs.Errorf(
 			"gen where expr for list eip cvm rels failed, err: %v, filter: %s, rid: %s",
@@ -288,11 +301,15 @@ func (relDao EipCvmRelDao) ListEipLeftJoinRel(kt *kit.Kit, opt *types.ListOption
 	)
 
 	details := make([]cloud.EipLeftJoinEipCvmRel, 0)
-	if err := relDao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(
+		`SELECT count(distinct(eip.id)) FROM %s as eip left join %s as rel on eip.id = rel.eip_id %s`,
+		table.EipTable, table.EipCvmRelTableName, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, relDao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		logs.ErrorJson("select eip left join eip_cvm_rel failed, err: %v, filter: %s, rid: %s", err,
 			opt.Filter, kt.Rid)
 		return nil, err
 	}
 
-	return &cloud.EipLeftJoinEipCvmRelResult{Details: details}, nil
+	return &cloud.EipLeftJoinEipCvmRelResult{Count: count, Details: details}, nil
 }
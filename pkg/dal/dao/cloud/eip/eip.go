@@ -73,9 +73,7 @@ func (eipDao EipDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, eips []*eip.Eip
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.EipTable, eip.EipColumns.ColumnExpr(),
-		eip.EipColumns.ColonNameExpr(),
-	)
+	sql := eip.EipColumns.InsertExpr(string(table.EipTable))
 
 	ids, err := eipDao.IDGen.Batch(kt, table.EipTable, len(eips))
 	if err != nil {
@@ -84,11 +82,12 @@ func (eipDao EipDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, eips []*eip.Eip
 
 	for idx, d := range eips {
 		d.ID = ids[idx]
+		d.TenantID = kt.GetTenantID()
 	}
 
 	err = eipDao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, eips)
 	if err != nil {
-		return nil, fmt.Errorf("insert %s failed, err: %v", table.EipTable, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", table.EipTable, err)
 	}
 
 	// create audit.
@@ -130,11 +129,11 @@ func (eipDao EipDao) List(kt *kit.Kit, opt *types.ListOption) (*cloud.EipListRes
 	columnTypes["extension.resource_group_name"] = enumor.String
 	columnTypes["extension.zones"] = enumor.Json
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
-		core.NewDefaultPageOption()); err != nil {
+		core.NewTablePageOption(table.EipTable)); err != nil {
 		return nil, err
 	}
 
-	whereOpt := tools.DefaultSqlWhereOption
+	whereOpt := tools.TenantSqlWhereOption(kt)
 	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
 	if err != nil {
 		return nil, err
@@ -143,15 +142,18 @@ func (eipDao EipDao) List(kt *kit.Kit, opt *types.ListOption) (*cloud.EipListRes
 	if opt.Page.Count {
 		// this is a count request, then do count operation only.
 		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.EipTable, whereExpr)
+
 		count, err := eipDao.Orm.Do().Count(kt.Ctx, sql, whereValue)
 		if err != nil {
 			logs.ErrorJson("count eip failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
 			return nil, err
 		}
+
 		return &cloud.EipListResult{Count: &count}, nil
 	}
 
-	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	pageExpr, err := types.PageSQLExpr(opt.Page,
+		types.DefaultPageSQLOption.WithSortFields(eip.EipColumns.ColumnTypes()))
 	if err != nil {
 		return nil, err
 	}
@@ -164,11 +166,17 @@ func (eipDao EipDao) List(kt *kit.Kit, opt *types.ListOption) (*cloud.EipListRes
 		pageExpr,
 	)
 	details := make([]*eip.EipModel, 0)
-	if err = eipDao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.EipTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, eipDao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
 	result := &cloud.EipListResult{Details: details}
+	if opt.Page.WithCount {
+		result.Count = &count
+	}
+
 	return result, nil
 }
 
@@ -185,7 +193,8 @@ func (eipDao EipDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, eipID string, up
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, table.EipTable, setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, table.EipTable, setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = eipID
 	_, err = eipDao.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
@@ -207,7 +216,7 @@ func (eipDao EipDao) Update(kt *kit.Kit, filterExpr *filter.Expression, updateDa
 		return err
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -247,7 +256,7 @@ func (eipDao EipDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.E
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
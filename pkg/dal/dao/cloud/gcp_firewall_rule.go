@@ -69,18 +69,18 @@ func (g GcpFirewallRuleDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, rules []
 	}
 	for index, rule := range rules {
 		rule.ID = ids[index]
+		rule.TenantID = kt.GetTenantID()
 
 		if err := rule.InsertValidate(); err != nil {
 			return nil, err
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.GcpFirewallRuleTable,
-		cloud.GcpFirewallRuleColumns.ColumnExpr(), cloud.GcpFirewallRuleColumns.ColonNameExpr())
+	sql := cloud.GcpFirewallRuleColumns.InsertExpr(table.GcpFirewallRuleTable)
 
 	if err = g.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, rules); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", table.GcpFirewallRuleTable, err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", table.GcpFirewallRuleTable, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", table.GcpFirewallRuleTable, err)
 	}
 
 	audits := make([]*tableaudit.AuditTable, 0, len(rules))
@@ -121,7 +121,7 @@ func (g GcpFirewallRuleDao) Update(kt *kit.Kit, expr *filter.Expression, rule *c
 		return err
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -175,7 +175,8 @@ func (g GcpFirewallRuleDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, rule.TableName(), setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, rule.TableName(), setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = id
 	_, err = g.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
@@ -194,11 +195,11 @@ func (g GcpFirewallRuleDao) List(kt *kit.Kit, opt *types.ListOption) (*types.Lis
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(cloud.GcpFirewallRuleColumns.ColumnTypes())),
-		core.NewDefaultPageOption()); err != nil {
+		core.NewTablePageOption(table.GcpFirewallRuleTable)); err != nil {
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -226,11 +227,13 @@ func (g GcpFirewallRuleDao) List(kt *kit.Kit, opt *types.ListOption) (*types.Lis
 		table.GcpFirewallRuleTable, whereExpr, pageExpr)
 
 	details := make([]cloud.GcpFirewallRuleTable, 0)
-	if err = g.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.GcpFirewallRuleTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, g.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.ListGcpFirewallRuleDetails{Details: details}, nil
+	return &types.ListGcpFirewallRuleDetails{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx rule.
@@ -239,7 +242,7 @@ func (g GcpFirewallRuleDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
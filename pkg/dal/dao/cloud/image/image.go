@@ -74,9 +74,7 @@ func (pImageDao ImageDao) BatchCreateWithTx(
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.ImageTable, image.ImageColumns.ColumnExpr(),
-		image.ImageColumns.ColonNameExpr(),
-	)
+	sql := image.ImageColumns.InsertExpr(string(table.ImageTable))
 
 	ids, err := pImageDao.IDGen.Batch(kt, table.ImageTable, len(images))
 	if err != nil {
@@ -89,7 +87,7 @@ func (pImageDao ImageDao) BatchCreateWithTx(
 
 	err = pImageDao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, images)
 	if err != nil {
-		return nil, fmt.Errorf("insert %s failed, err: %v", table.ImageTable, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", table.ImageTable, err)
 	}
 
 	return ids, nil
@@ -143,12 +141,14 @@ func (pImageDao ImageDao) List(kt *kit.Kit, opt *types.ListOption) (*cloud.Image
 		pageExpr,
 	)
 	details := make([]*image.ImageModel, 0)
-	if err = pImageDao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ImageTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, pImageDao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		logs.Errorf("select image failed, err: %v, sql: %s, values: %v, rid: %s", err, sql, whereValue, kt.Rid)
 		return nil, err
 	}
 
-	result := &cloud.ImageListResult{Details: details}
+	result := &cloud.ImageListResult{Count: count, Details: details}
 	return result, nil
 }
 
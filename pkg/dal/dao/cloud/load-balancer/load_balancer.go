@@ -29,6 +29,7 @@ import (
 	"hcm/pkg/dal/dao/audit"
 	idgen "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	daorestag "hcm/pkg/dal/dao/resource-tag"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	typeslb "hcm/pkg/dal/dao/types/load-balancer"
@@ -59,6 +60,7 @@ type LoadBalancerDao struct {
 	Orm   orm.Interface
 	IDGen idgen.IDGenInterface
 	Audit audit.Interface
+	Tag   daorestag.Interface
 }
 
 // BatchCreateWithTx create load balancer.
@@ -76,14 +78,19 @@ func (dao LoadBalancerDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx,
 			return nil, err
 		}
 		model.ID = ids[index]
+		model.TenantID = kt.GetTenantID()
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, tableName,
-		tablelb.LoadBalancerColumns.ColumnExpr(), tablelb.LoadBalancerColumns.ColonNameExpr())
+	sql := tablelb.LoadBalancerColumns.InsertExpr(string(tableName))
 
 	if err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", tableName, err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", tableName, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", tableName, err)
+	}
+
+	if err = dao.Tag.SyncWithTx(kt, tx, tableName, ids); err != nil {
+		logs.Errorf("sync load balancer tags failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
 	}
 
 	// load balancer create audit.
@@ -125,7 +132,7 @@ func (dao LoadBalancerDao) Update(kt *kit.Kit, expr *filter.Expression, model *t
 		return err
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -149,6 +156,12 @@ func (dao LoadBalancerDao) Update(kt *kit.Kit, expr *filter.Expression, model *t
 			logs.Infof("update load balancer, but record not found, sql: %s, rid: %v", sql, kt.Rid)
 		}
 
+		if model.Tags != nil {
+			if err = dao.syncTagsByWhereWithTx(kt, txn, whereExpr, whereValue); err != nil {
+				return nil, err
+			}
+		}
+
 		return nil, nil
 	})
 	if err != nil {
@@ -176,7 +189,8 @@ func (dao LoadBalancerDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string,
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, model.TableName(), setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, model.TableName(), setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = id
 	_, err = dao.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
@@ -185,9 +199,45 @@ func (dao LoadBalancerDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string,
 		return err
 	}
 
+	// tags is a blanked field, which is always updated.
+	if err = dao.Tag.SyncWithTx(kt, tx, table.LoadBalancerTable, []string{id}); err != nil {
+		logs.Errorf("sync load balancer tags failed, id: %s, err: %v, rid: %v", id, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// syncTagsByWhereWithTx sync the tags of the load balancers which match the where expression.
+func (dao LoadBalancerDao) syncTagsByWhereWithTx(kt *kit.Kit, tx *sqlx.Tx, whereExpr string,
+	whereValue map[string]interface{}) error {
+
+	ids, err := dao.listIDsWithTx(kt, tx, whereExpr, whereValue)
+	if err != nil {
+		return err
+	}
+
+	if err = dao.Tag.SyncWithTx(kt, tx, table.LoadBalancerTable, ids); err != nil {
+		logs.Errorf("sync load balancer tags failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
 	return nil
 }
 
+func (dao LoadBalancerDao) listIDsWithTx(kt *kit.Kit, tx *sqlx.Tx, whereExpr string,
+	whereValue map[string]interface{}) ([]string, error) {
+
+	sql := fmt.Sprintf(`SELECT id FROM %s %s`, table.LoadBalancerTable, whereExpr)
+	ids := make([]string, 0)
+	if err := dao.Orm.Txn(tx).Select(kt.Ctx, &ids, sql, whereValue); err != nil {
+		logs.Errorf("list load balancer ids failed, err: %v, where: %s, rid: %s", err, whereExpr, kt.Rid)
+		return nil, err
+	}
+
+	return ids, nil
+}
+
 // List list load balancer.
 func (dao LoadBalancerDao) List(kt *kit.Kit, opt *types.ListOption) (*typeslb.ListLoadBalancerDetails, error) {
 	if opt == nil {
@@ -197,12 +247,13 @@ func (dao LoadBalancerDao) List(kt *kit.Kit, opt *types.ListOption) (*typeslb.Li
 	columnTypes := tablelb.LoadBalancerColumns.ColumnTypes()
 	columnTypes["tags.*"] = enumor.String
 
-	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes), filter.TagRuleFields()),
 		core.NewDefaultPageOption()); err != nil {
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereOpt := tools.TagSqlWhereOption(kt, table.LoadBalancerTable)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
 	if err != nil {
 		return nil, err
 	}
@@ -229,11 +280,13 @@ func (dao LoadBalancerDao) List(kt *kit.Kit, opt *types.ListOption) (*typeslb.Li
 		table.LoadBalancerTable, whereExpr, pageExpr)
 
 	details := make([]tablelb.LoadBalancerTable, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.LoadBalancerTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &typeslb.ListLoadBalancerDetails{Details: details}, nil
+	return &typeslb.ListLoadBalancerDetails{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx delete load balancer.
@@ -242,11 +295,20 @@ func (dao LoadBalancerDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.E
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return err
+	}
+
+	ids, err := dao.listIDsWithTx(kt, tx, whereExpr, whereValue)
 	if err != nil {
 		return err
 	}
 
+	if err = dao.Tag.DeleteWithTx(kt, tx, table.LoadBalancerTable, ids); err != nil {
+		return err
+	}
+
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.LoadBalancerTable, whereExpr)
 	if _, err = dao.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
 		logs.Errorf("delete load balancer failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
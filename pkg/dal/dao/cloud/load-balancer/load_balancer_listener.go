@@ -78,14 +78,14 @@ func (dao ListenerDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []*tab
 			return nil, err
 		}
 		model.ID = ids[index]
+		model.TenantID = kt.GetTenantID()
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, tableName,
-		tablelb.LoadBalancerListenerColumns.ColumnExpr(), tablelb.LoadBalancerListenerColumns.ColonNameExpr())
+	sql := tablelb.LoadBalancerListenerColumns.InsertExpr(string(tableName))
 
 	if err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", tableName, err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", tableName, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", tableName, err)
 	}
 
 	// listener create audit.
@@ -127,7 +127,7 @@ func (dao ListenerDao) Update(kt *kit.Kit, expr *filter.Expression, model *table
 		return err
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -178,7 +178,8 @@ func (dao ListenerDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string,
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, model.TableName(), setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, model.TableName(), setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = id
 	_, err = dao.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
@@ -201,7 +202,7 @@ func (dao ListenerDao) List(kt *kit.Kit, opt *types.ListOption) (*typeslb.ListLo
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -228,11 +229,13 @@ func (dao ListenerDao) List(kt *kit.Kit, opt *types.ListOption) (*typeslb.ListLo
 		table.LoadBalancerListenerTable, whereExpr, pageExpr)
 
 	details := make([]tablelb.LoadBalancerListenerTable, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.LoadBalancerListenerTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &typeslb.ListLoadBalancerListenerDetails{Details: details}, nil
+	return &typeslb.ListLoadBalancerListenerDetails{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx listener.
@@ -241,7 +244,7 @@ func (dao ListenerDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expre
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
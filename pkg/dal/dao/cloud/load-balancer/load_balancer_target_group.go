@@ -78,16 +78,16 @@ func (dao TargetGroupDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []*
 			return nil, err
 		}
 		model.ID = ids[index]
+		model.TenantID = kt.GetTenantID()
 		if len(model.CloudID) == 0 {
 			model.CloudID = model.ID
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, tableName,
-		tablelb.LoadBalancerTargetGroupColumns.ColumnExpr(), tablelb.LoadBalancerTargetGroupColumns.ColonNameExpr())
+	sql := tablelb.LoadBalancerTargetGroupColumns.InsertExpr(string(tableName))
 	if err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", tableName, err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", tableName, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", tableName, err)
 	}
 
 	// create audit.
@@ -131,7 +131,7 @@ func (dao TargetGroupDao) Update(kt *kit.Kit, expr *filter.Expression,
 		return err
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -179,7 +179,7 @@ func (dao TargetGroupDao) UpdateBatch(kt *kit.Kit, models []*tablelb.LoadBalance
 		for _, model := range models {
 
 			expr := tools.EqualExpression("id", model.ID)
-			whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+			whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 			if err != nil {
 				return nil, err
 			}
@@ -232,7 +232,8 @@ func (dao TargetGroupDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string,
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, model.TableName(), setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, model.TableName(), setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = id
 	_, err = dao.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
@@ -261,7 +262,7 @@ func (dao TargetGroupDao) List(kt *kit.Kit, opt *types.ListOption) (*typeslb.Lis
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -289,11 +290,13 @@ func (dao TargetGroupDao) List(kt *kit.Kit, opt *types.ListOption) (*typeslb.Lis
 		table.LoadBalancerTargetGroupTable, whereExpr, pageExpr)
 
 	details := make([]tablelb.LoadBalancerTargetGroupTable, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.LoadBalancerTargetGroupTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &typeslb.ListLbTargetGroupDetails{Details: details}, nil
+	return &typeslb.ListLbTargetGroupDetails{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx target group.
@@ -302,7 +305,7 @@ func (dao TargetGroupDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Ex
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -74,15 +74,14 @@ func (dao TargetGroupListenerRuleRelDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx
 			return nil, err
 		}
 		model.ID = ids[index]
+		model.TenantID = kt.GetTenantID()
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, tableName,
-		tablelb.TargetGroupListenerRuleRelColumns.ColumnExpr(),
-		tablelb.TargetGroupListenerRuleRelColumns.ColonNameExpr())
+	sql := tablelb.TargetGroupListenerRuleRelColumns.InsertExpr(string(tableName))
 
 	if err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", tableName, err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", tableName, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", tableName, err)
 	}
 
 	return ids, nil
@@ -100,7 +99,7 @@ func (dao TargetGroupListenerRuleRelDao) Update(kt *kit.Kit, expr *filter.Expres
 		return err
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -152,7 +151,8 @@ func (dao TargetGroupListenerRuleRelDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, model.TableName(), setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, model.TableName(), setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = id
 	_, err = dao.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
@@ -178,7 +178,7 @@ func (dao TargetGroupListenerRuleRelDao) List(kt *kit.Kit, opt *types.ListOption
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -206,11 +206,13 @@ func (dao TargetGroupListenerRuleRelDao) List(kt *kit.Kit, opt *types.ListOption
 		table.TargetGroupListenerRuleRelTable, whereExpr, pageExpr)
 
 	details := make([]tablelb.TargetGroupListenerRuleRelTable, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.TargetGroupListenerRuleRelTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &typeslb.ListTargetGroupListenerRuleRelDetails{Details: details}, nil
+	return &typeslb.ListTargetGroupListenerRuleRelDetails{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx target group listener rule rel.
@@ -219,7 +221,7 @@ func (dao TargetGroupListenerRuleRelDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx,
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -69,9 +69,14 @@ func (dao NiCvmRelDao) ListJoinNetworkInterface(kt *kit.Kit, cvmIDs []string, ve
 		return nil, errf.Newf(errf.InvalidParameter, "cvm ids is required")
 	}
 
+	whereExpr, whereValue, err := tools.ExpressionAnd(tools.RuleIn("rel.cvm_id", cvmIDs),
+		tools.RuleEqual("ni.vendor", vendor)).SQLWhereExpr(tools.TenantJoinSqlWhereOption(kt, "rel"))
+	if err != nil {
+		return nil, err
+	}
+
 	sql := fmt.Sprintf(
-		`SELECT %s, %s FROM %s AS rel LEFT JOIN %s AS ni ON rel.network_interface_id = ni.id WHERE 
-		rel.cvm_id IN (:cvm_ids) AND ni.vendor = :vendor`,
+		`SELECT %s, %s FROM %s AS rel LEFT JOIN %s AS ni ON rel.network_interface_id = ni.id %s`,
 		nitable.NetworkInterfaceColumns.FieldsNamedExprWithout(types.DefaultRelJoinWithoutField),
 		tools.BaseRelJoinSqlBuild(
 			"rel",
@@ -81,11 +86,11 @@ func (dao NiCvmRelDao) ListJoinNetworkInterface(kt *kit.Kit, cvmIDs []string, ve
 		),
 		table.NetworkInterfaceCvmRelTable,
 		table.NetworkInterfaceTable,
+		whereExpr,
 	)
 
 	details := make([]*types.NetworkInterfaceWithCvmID, 0)
-	if err := dao.Orm.Do().Select(kt.Ctx, &details, sql,
-		map[string]interface{}{"cvm_ids": cvmIDs, "vendor": vendor}); err != nil {
+	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
 		logs.ErrorJson("select network interface cvm rels join network failed, err: %v, sql: (%s), rid: %s",
 			err, sql, kt.Rid)
 		return nil, err
@@ -138,13 +143,15 @@ func (dao NiCvmRelDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx,
 		return fmt.Errorf("get cvm count not right")
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.NetworkInterfaceCvmRelTable,
-		nicvmreltable.NetworkInterfaceCvmRelColumns.ColumnExpr(),
-		nicvmreltable.NetworkInterfaceCvmRelColumns.ColonNameExpr())
+	for idx := range rels {
+		rels[idx].TenantID = kt.GetTenantID()
+	}
+
+	sql := nicvmreltable.NetworkInterfaceCvmRelColumns.InsertExpr(string(table.NetworkInterfaceCvmRelTable))
 
 	if err := dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, rels); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", table.NetworkInterfaceCvmRelTable, err, kt.Rid)
-		return fmt.Errorf("insert %s failed, err: %v", table.NetworkInterfaceCvmRelTable, err)
+		return fmt.Errorf("insert %s failed, err: %w", table.NetworkInterfaceCvmRelTable, err)
 	}
 
 	return nil
@@ -161,7 +168,7 @@ func (dao NiCvmRelDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListNetw
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -189,12 +196,19 @@ func (dao NiCvmRelDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListNetw
 		table.NetworkInterfaceCvmRelTable, whereExpr, pageExpr)
 
 	details := make([]*nicvmreltable.NetworkInterfaceCvmRelTable, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.NetworkInterfaceCvmRelTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		logs.ErrorJson("select network cvm rels failed, filter: %s, err: %v, rid: %s", opt.Filter, err, kt.Rid)
 		return nil, err
 	}
 
-	return &types.ListNetworkInterfaceCvmRelDetails{Details: details}, nil
+	result := &types.ListNetworkInterfaceCvmRelDetails{Details: details}
+	if opt.Page.WithCount {
+		result.Count = &count
+	}
+
+	return result, nil
 }
 
 // DeleteWithTx delete network interface cvm rel with transaction.
@@ -203,7 +217,7 @@ func (dao NiCvmRelDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expre
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
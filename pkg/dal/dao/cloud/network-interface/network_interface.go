@@ -77,18 +77,18 @@ func (n NetworkInterfaceDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tab
 
 	for index, model := range models {
 		models[index].ID = ids[index]
+		models[index].TenantID = kt.GetTenantID()
 
 		if err := model.InsertValidate(); err != nil {
 			return nil, err
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, models[0].TableName(),
-		tableni.NetworkInterfaceColumns.ColumnExpr(), tableni.NetworkInterfaceColumns.ColonNameExpr())
+	sql := tableni.NetworkInterfaceColumns.InsertExpr(string(models[0].TableName()))
 
 	if err = n.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", models[0].TableName(), err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", models[0].TableName(), err)
 	}
 
 	// create audit.
@@ -132,7 +132,7 @@ func (n NetworkInterfaceDao) Update(kt *kit.Kit, filterExpr *filter.Expression,
 		return err
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -183,7 +183,7 @@ func (n NetworkInterfaceDao) List(kt *kit.Kit, opt *types.ListOption) (*typesni.
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -209,10 +209,12 @@ func (n NetworkInterfaceDao) List(kt *kit.Kit, opt *types.ListOption) (*typesni.
 		table.NetworkInterfaceTable, whereExpr, pageExpr)
 
 	details := make([]tableni.NetworkInterfaceTable, 0)
-	if err = n.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.NetworkInterfaceTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, n.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
-	return &typesni.ListNetworkInterfaceDetails{Details: details}, nil
+	return &typesni.ListNetworkInterfaceDetails{Count: count, Details: details}, nil
 }
 
 // ListAssociate get network interface associate list.
@@ -232,7 +234,7 @@ func (n NetworkInterfaceDao) ListAssociate(kt *kit.Kit, opt *types.ListOption, i
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantJoinSqlWhereOption(kt, "ni"))
 	if err != nil {
 		return nil, err
 	}
@@ -280,11 +282,24 @@ func (n NetworkInterfaceDao) ListAssociate(kt *kit.Kit, opt *types.ListOption, i
 	)
 
 	details := make([]*types.NetworkInterfaceWithCvmID, 0)
-	if err = n.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(
+		`SELECT COUNT(*) FROM %s AS ni %s %s AS rel ON rel.network_interface_id = ni.id %s`,
+		table.NetworkInterfaceTable,
+		joinDirection,
+		table.NetworkInterfaceCvmRelTable,
+		whereExpr,
+	)
+	count, err := orm.SelectPage(kt.Ctx, n.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.ListCvmRelsJoinNetworkInterfaceDetails{Details: details}, nil
+	result := &types.ListCvmRelsJoinNetworkInterfaceDetails{Details: details}
+	if opt.Page.WithCount {
+		result.Count = &count
+	}
+
+	return result, nil
 }
 
 // DeleteWithTx network interface with tx.
@@ -293,7 +308,7 @@ func (n NetworkInterfaceDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
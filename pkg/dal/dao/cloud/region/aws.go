@@ -88,12 +88,11 @@ func (v *awsRegionDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []regi
 		models[idx].ID = ids[idx]
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, models[0].TableName(),
-		region.AwsRegionColumns.ColumnExpr(), region.AwsRegionColumns.ColonNameExpr())
+	sql := region.AwsRegionColumns.InsertExpr(string(models[0].TableName()))
 
 	err = v.orm.Txn(tx).BulkInsert(kt.Ctx, sql, models)
 	if err != nil {
-		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", models[0].TableName(), err)
 	}
 
 	return ids, nil
@@ -194,11 +193,13 @@ func (v *awsRegionDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*fi
 		tableName, whereExpr, pageExpr)
 
 	details := make([]region.AwsRegionTable, 0)
-	if err = v.orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, tableName, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, v.orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &typesRegion.AwsRegionListResult{Details: details}, nil
+	return &typesRegion.AwsRegionListResult{Count: count, Details: details}, nil
 }
 
 // BatchDeleteWithTx batch delete region with transaction.
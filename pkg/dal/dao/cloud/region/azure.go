@@ -127,11 +127,13 @@ func (a AzureRegionDao) List(kt *kit.Kit, opt *types.ListOption) (*typesregion.L
 		table.AzureRegionTable, whereExpr, pageExpr)
 
 	details := make([]*region.AzureRegionTable, 0)
-	if err = a.Orm.Do().Select(kt.Ctx, &details, sql, argMap); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AzureRegionTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, a.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, argMap)
+	if err != nil {
 		return nil, err
 	}
 
-	return &typesregion.ListAzureRegionDetails{Count: 0, Details: details}, nil
+	return &typesregion.ListAzureRegionDetails{Count: count, Details: details}, nil
 }
 
 // CreateWithTx azure region with tx.
@@ -151,12 +153,11 @@ func (a AzureRegionDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []*region.
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, models[0].TableName(),
-		region.AzureRegionColumns.ColumnExpr(), region.AzureRegionColumns.ColonNameExpr())
+	sql := region.AzureRegionColumns.InsertExpr(string(models[0].TableName()))
 
 	if err = a.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", models[0].TableName(), err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", models[0].TableName(), err)
 	}
 
 	return ids, nil
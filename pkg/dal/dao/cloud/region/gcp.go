@@ -88,12 +88,11 @@ func (v *gcpRegionDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []regi
 		models[idx].ID = ids[idx]
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, models[0].TableName(),
-		region.GcpRegionColumns.ColumnExpr(), region.GcpRegionColumns.ColonNameExpr())
+	sql := region.GcpRegionColumns.InsertExpr(string(models[0].TableName()))
 
 	err = v.orm.Txn(tx).BulkInsert(kt.Ctx, sql, models)
 	if err != nil {
-		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", models[0].TableName(), err)
 	}
 
 	return ids, nil
@@ -192,11 +191,13 @@ func (v *gcpRegionDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*fi
 		tableName, whereExpr, pageExpr)
 
 	details := make([]region.GcpRegionTable, 0)
-	if err = v.orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, tableName, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, v.orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &typesRegion.GcpRegionListResult{Details: details}, nil
+	return &typesRegion.GcpRegionListResult{Count: count, Details: details}, nil
 }
 
 // BatchDeleteWithTx batch delete region with transaction.
@@ -127,11 +127,13 @@ func (h HuaWeiRegionDao) List(kt *kit.Kit, opt *types.ListOption) (*typesregion.
 		table.HuaWeiRegionTable, whereExpr, pageExpr)
 
 	details := make([]*region.HuaWeiRegionTable, 0)
-	if err = h.Orm.Do().Select(kt.Ctx, &details, sql, argMap); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.HuaWeiRegionTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, h.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, argMap)
+	if err != nil {
 		return nil, err
 	}
 
-	return &typesregion.ListHuaWeiRegionDetails{Count: 0, Details: details}, nil
+	return &typesregion.ListHuaWeiRegionDetails{Count: count, Details: details}, nil
 }
 
 // CreateWithTx huawei region with tx.
@@ -150,12 +152,11 @@ func (h HuaWeiRegionDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, regions []*regio
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.HuaWeiRegionTable,
-		region.HuaWeiRegionColumns.ColumnExpr(), region.HuaWeiRegionColumns.ColonNameExpr())
+	sql := region.HuaWeiRegionColumns.InsertExpr(string(table.HuaWeiRegionTable))
 
 	if err = h.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, regions); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", table.HuaWeiRegionTable, err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", table.HuaWeiRegionTable, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", table.HuaWeiRegionTable, err)
 	}
 
 	return ids, nil
@@ -88,12 +88,11 @@ func (v *tcloudRegionDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []r
 		models[idx].ID = ids[idx]
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, models[0].TableName(),
-		region.TCloudRegionColumns.ColumnExpr(), region.TCloudRegionColumns.ColonNameExpr())
+	sql := region.TCloudRegionColumns.InsertExpr(string(models[0].TableName()))
 
 	err = v.orm.Txn(tx).BulkInsert(kt.Ctx, sql, models)
 	if err != nil {
-		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", models[0].TableName(), err)
 	}
 
 	return ids, nil
@@ -194,11 +193,13 @@ func (v *tcloudRegionDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...
 		tableName, whereExpr, pageExpr)
 
 	details := make([]region.TCloudRegionTable, 0)
-	if err = v.orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, tableName, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, v.orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &typesRegion.TCloudRegionListResult{Details: details}, nil
+	return &typesRegion.TCloudRegionListResult{Count: count, Details: details}, nil
 }
 
 // BatchDeleteWithTx batch delete region with transaction.
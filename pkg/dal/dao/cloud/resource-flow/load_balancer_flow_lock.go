@@ -64,17 +64,17 @@ type ResourceFlowLockDao struct {
 func (dao ResourceFlowLockDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx,
 	model *tablelb.ResourceFlowLockTable) error {
 
+	model.TenantID = kt.GetTenantID()
 	if err := model.InsertValidate(); err != nil {
 		return err
 	}
 
 	tableName := table.ResourceFlowLockTable
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, tableName,
-		tablelb.ResourceFlowLockColumns.ColumnExpr(), tablelb.ResourceFlowLockColumns.ColonNameExpr())
+	sql := tablelb.ResourceFlowLockColumns.InsertExpr(string(tableName))
 
 	if err := dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, model); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", tableName, err, kt.Rid)
-		return fmt.Errorf("insert %s failed, err: %v", tableName, err)
+		return fmt.Errorf("insert %s failed, err: %w", tableName, err)
 	}
 
 	return nil
@@ -92,7 +92,7 @@ func (dao ResourceFlowLockDao) Update(kt *kit.Kit, expr *filter.Expression,
 		return err
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -151,8 +151,8 @@ func (dao ResourceFlowLockDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, resID,
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s WHERE res_id = :res_id AND res_type = :res_type AND owner = :owner`,
-		model.TableName(), setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s WHERE res_id = :res_id AND res_type = :res_type AND owner = :owner%s`,
+		model.TableName(), setExpr, tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["res_id"] = resID
 	toUpdate["res_type"] = resType
@@ -180,7 +180,7 @@ func (dao ResourceFlowLockDao) List(kt *kit.Kit, opt *types.ListOption) (
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -209,11 +209,13 @@ func (dao ResourceFlowLockDao) List(kt *kit.Kit, opt *types.ListOption) (
 		table.ResourceFlowLockTable, whereExpr, pageExpr)
 
 	details := make([]tablelb.ResourceFlowLockTable, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ResourceFlowLockTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &typeslb.ListResourceFlowLockDetails{Details: details}, nil
+	return &typeslb.ListResourceFlowLockDetails{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx resource flow lock.
@@ -222,7 +224,7 @@ func (dao ResourceFlowLockDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filt
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -74,14 +74,14 @@ func (dao ResourceFlowRelDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models
 			return nil, err
 		}
 		model.ID = ids[index]
+		model.TenantID = kt.GetTenantID()
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, tableName,
-		tablelb.ResourceFlowRelColumns.ColumnExpr(), tablelb.ResourceFlowRelColumns.ColonNameExpr())
+	sql := tablelb.ResourceFlowRelColumns.InsertExpr(string(tableName))
 
 	if err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", tableName, err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", tableName, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", tableName, err)
 	}
 
 	return ids, nil
@@ -99,7 +99,7 @@ func (dao ResourceFlowRelDao) Update(kt *kit.Kit, expr *filter.Expression,
 		return err
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -150,7 +150,8 @@ func (dao ResourceFlowRelDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id stri
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, model.TableName(), setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, model.TableName(), setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = id
 	_, err = dao.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
@@ -175,7 +176,7 @@ func (dao ResourceFlowRelDao) List(kt *kit.Kit, opt *types.ListOption) (
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -203,11 +204,13 @@ func (dao ResourceFlowRelDao) List(kt *kit.Kit, opt *types.ListOption) (
 		table.ResourceFlowRelTable, whereExpr, pageExpr)
 
 	details := make([]tablelb.ResourceFlowRelTable, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ResourceFlowRelTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &typeslb.ListResourceFlowRelDetails{Details: details}, nil
+	return &typeslb.ListResourceFlowRelDetails{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx resource flow rel.
@@ -216,7 +219,7 @@ func (dao ResourceFlowRelDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filte
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
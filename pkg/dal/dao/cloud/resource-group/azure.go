@@ -67,7 +67,7 @@ func (a AzureRGDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expressi
 		return err
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -104,7 +104,7 @@ func (a AzureRGDao) List(kt *kit.Kit, opt *types.ListOption) (*typesregion.ListA
 		return nil, err
 	}
 
-	whereExpr, argMap, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, argMap, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -127,11 +127,13 @@ func (a AzureRGDao) List(kt *kit.Kit, opt *types.ListOption) (*typesregion.ListA
 		table.AzureRGTable, whereExpr, pageExpr)
 
 	details := make([]*resourcegroup.AzureRGTable, 0)
-	if err = a.Orm.Do().Select(kt.Ctx, &details, sql, argMap); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AzureRGTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, a.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, argMap)
+	if err != nil {
 		return nil, err
 	}
 
-	return &typesregion.ListAzureRGDetails{Count: 0, Details: details}, nil
+	return &typesregion.ListAzureRGDetails{Count: count, Details: details}, nil
 }
 
 // CreateWithTx azure region with tx.
@@ -143,6 +145,7 @@ func (a AzureRGDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, regions []*resourcegr
 	}
 	for index := range regions {
 		regions[index].ID = ids[index]
+		regions[index].TenantID = kt.GetTenantID()
 	}
 
 	for _, item := range regions {
@@ -151,12 +154,11 @@ func (a AzureRGDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, regions []*resourcegr
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AzureRGTable,
-		resourcegroup.AzureRGColumns.ColumnExpr(), resourcegroup.AzureRGColumns.ColonNameExpr())
+	sql := resourcegroup.AzureRGColumns.InsertExpr(string(table.AzureRGTable))
 
 	if err = a.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, regions); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", table.AzureRGTable, err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", table.AzureRGTable, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", table.AzureRGTable, err)
 	}
 
 	return ids, nil
@@ -168,7 +170,7 @@ func (a AzureRGDao) DeleteWithTx(kt *kit.Kit, expr *filter.Expression) error {
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, argMap, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, argMap, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -90,14 +90,14 @@ func (r *azureRouteDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []rou
 
 	for idx := range models {
 		models[idx].ID = ids[idx]
+		models[idx].TenantID = kt.GetTenantID()
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, models[0].TableName(),
-		routetable.AzureRouteColumns.ColumnExpr(), routetable.AzureRouteColumns.ColonNameExpr())
+	sql := routetable.AzureRouteColumns.InsertExpr(string(models[0].TableName()))
 
 	err = r.orm.Txn(tx).BulkInsert(kt.Ctx, sql, models)
 	if err != nil {
-		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", models[0].TableName(), err)
 	}
 
 	if err = r.batchCreateAudit(kt, tx, models); err != nil {
@@ -171,7 +171,7 @@ func (r *azureRouteDao) Update(kt *kit.Kit, filterExpr *filter.Expression, model
 		return err
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -215,17 +215,20 @@ func (r *azureRouteDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*f
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(routetable.AzureRouteColumns.ColumnTypes())),
-		core.NewDefaultPageOption()); err != nil {
+		core.NewTablePageOption(table.AzureRouteTable)); err != nil {
 		return nil, err
 	}
 
-	whereOpt := tools.DefaultSqlWhereOption
+	whereOpt := tools.TenantSqlWhereOption(kt)
 	if len(whereOpts) != 0 && whereOpts[0] != nil {
 		err := whereOpts[0].Validate()
 		if err != nil {
 			return nil, err
 		}
-		whereOpt = whereOpts[0]
+		whereOpt, err = tools.WithTenantSqlWhereOption(kt, whereOpts[0])
+		if err != nil {
+			return nil, err
+		}
 	}
 	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
 	if err != nil {
@@ -254,11 +257,13 @@ func (r *azureRouteDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*f
 		table.AzureRouteTable, whereExpr, pageExpr)
 
 	details := make([]routetable.AzureRouteTable, 0)
-	if err = r.orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AzureRouteTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, r.orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.AzureRouteListResult{Details: details}, nil
+	return &types.AzureRouteListResult{Count: count, Details: details}, nil
 }
 
 // BatchDeleteWithTx batch delete azure route with transaction.
@@ -267,7 +272,7 @@ func (r *azureRouteDao) BatchDeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -88,14 +88,14 @@ func (r *gcpRouteDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []route
 
 	for idx := range models {
 		models[idx].ID = ids[idx]
+		models[idx].TenantID = kt.GetTenantID()
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s) VALUES(%s)`, models[0].TableName(),
-		routetable.GcpRouteColumns.ColumnExpr(), routetable.GcpRouteColumns.ColonNameExpr())
+	sql := routetable.GcpRouteColumns.InsertExpr(string(models[0].TableName()))
 
 	err = r.orm.Txn(tx).BulkInsert(kt.Ctx, sql, models)
 	if err != nil {
-		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", models[0].TableName(), err)
 	}
 
 	if err = r.batchCreateAudit(kt, tx, models); err != nil {
@@ -168,17 +168,20 @@ func (r *gcpRouteDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*fil
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(routetable.GcpRouteColumns.ColumnTypes())),
-		core.NewDefaultPageOption()); err != nil {
+		core.NewTablePageOption(table.GcpRouteTable)); err != nil {
 		return nil, err
 	}
 
-	whereOpt := tools.DefaultSqlWhereOption
+	whereOpt := tools.TenantSqlWhereOption(kt)
 	if len(whereOpts) != 0 && whereOpts[0] != nil {
 		err := whereOpts[0].Validate()
 		if err != nil {
 			return nil, err
 		}
-		whereOpt = whereOpts[0]
+		whereOpt, err = tools.WithTenantSqlWhereOption(kt, whereOpts[0])
+		if err != nil {
+			return nil, err
+		}
 	}
 	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
 	if err != nil {
@@ -207,11 +210,13 @@ func (r *gcpRouteDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*fil
 		table.GcpRouteTable, whereExpr, pageExpr)
 
 	details := make([]routetable.GcpRouteTable, 0)
-	if err = r.orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.GcpRouteTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, r.orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.GcpRouteListResult{Details: details}, nil
+	return &types.GcpRouteListResult{Count: count, Details: details}, nil
 }
 
 // BatchDeleteWithTx batch delete gcp route with transaction.
@@ -220,7 +225,7 @@ func (r *gcpRouteDao) BatchDeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *fi
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
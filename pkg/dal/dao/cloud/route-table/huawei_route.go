@@ -90,14 +90,14 @@ func (r *huaweiRouteDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []ro
 
 	for idx := range models {
 		models[idx].ID = ids[idx]
+		models[idx].TenantID = kt.GetTenantID()
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, models[0].TableName(),
-		routetable.HuaWeiRouteColumns.ColumnExpr(), routetable.HuaWeiRouteColumns.ColonNameExpr())
+	sql := routetable.HuaWeiRouteColumns.InsertExpr(string(models[0].TableName()))
 
 	err = r.orm.Txn(tx).BulkInsert(kt.Ctx, sql, models)
 	if err != nil {
-		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", models[0].TableName(), err)
 	}
 
 	if err = r.batchCreateAudit(kt, tx, models); err != nil {
@@ -171,7 +171,7 @@ func (r *huaweiRouteDao) Update(kt *kit.Kit, filterExpr *filter.Expression, mode
 		return err
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -214,17 +214,20 @@ func (r *huaweiRouteDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(routetable.HuaWeiRouteColumns.ColumnTypes())),
-		core.NewDefaultPageOption()); err != nil {
+		core.NewTablePageOption(table.HuaWeiRouteTable)); err != nil {
 		return nil, err
 	}
 
-	whereOpt := tools.DefaultSqlWhereOption
+	whereOpt := tools.TenantSqlWhereOption(kt)
 	if len(whereOpts) != 0 && whereOpts[0] != nil {
 		err := whereOpts[0].Validate()
 		if err != nil {
 			return nil, err
 		}
-		whereOpt = whereOpts[0]
+		whereOpt, err = tools.WithTenantSqlWhereOption(kt, whereOpts[0])
+		if err != nil {
+			return nil, err
+		}
 	}
 	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
 	if err != nil {
@@ -253,11 +256,13 @@ func (r *huaweiRouteDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*
 		table.HuaWeiRouteTable, whereExpr, pageExpr)
 
 	details := make([]routetable.HuaWeiRouteTable, 0)
-	if err = r.orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.HuaWeiRouteTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, r.orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.HuaWeiRouteListResult{Details: details}, nil
+	return &types.HuaWeiRouteListResult{Count: count, Details: details}, nil
 }
 
 // BatchDeleteWithTx batch delete huawei route with transaction.
@@ -266,7 +271,7 @@ func (r *huaweiRouteDao) BatchDeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
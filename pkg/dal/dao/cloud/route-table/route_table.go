@@ -90,14 +90,14 @@ func (r *routeTableDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []rou
 
 	for idx := range models {
 		models[idx].ID = ids[idx]
+		models[idx].TenantID = kt.GetTenantID()
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, models[0].TableName(),
-		routetable.RouteTableColumns.ColumnExpr(), routetable.RouteTableColumns.ColonNameExpr())
+	sql := routetable.RouteTableColumns.InsertExpr(string(models[0].TableName()))
 
 	err = r.orm.Txn(tx).BulkInsert(kt.Ctx, sql, models)
 	if err != nil {
-		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", models[0].TableName(), err)
 	}
 
 	// create audit.
@@ -139,7 +139,7 @@ func (r *routeTableDao) Update(kt *kit.Kit, filterExpr *filter.Expression, model
 		return err
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -188,13 +188,16 @@ func (r *routeTableDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*f
 		return nil, err
 	}
 
-	whereOpt := tools.DefaultSqlWhereOption
+	whereOpt := tools.TenantSqlWhereOption(kt)
 	if len(whereOpts) != 0 && whereOpts[0] != nil {
 		err := whereOpts[0].Validate()
 		if err != nil {
 			return nil, err
 		}
-		whereOpt = whereOpts[0]
+		whereOpt, err = tools.WithTenantSqlWhereOption(kt, whereOpts[0])
+		if err != nil {
+			return nil, err
+		}
 	}
 	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
 	if err != nil {
@@ -223,11 +226,13 @@ func (r *routeTableDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*f
 		table.RouteTableTable, whereExpr, pageExpr)
 
 	details := make([]routetable.RouteTableTable, 0)
-	if err = r.orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.RouteTableTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, r.orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.RouteTableListResult{Details: details}, nil
+	return &types.RouteTableListResult{Count: count, Details: details}, nil
 }
 
 // BatchDeleteWithTx batch delete route table with transaction.
@@ -236,7 +241,7 @@ func (r *routeTableDao) BatchDeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -64,18 +64,22 @@ func (dao Dao) ListJoinSecurityGroup(kt *kit.Kit, resIDs []string, resType enumo
 		return nil, errf.Newf(errf.InvalidParameter, "res ids is required")
 	}
 
+	whereExpr, whereValue, err := tools.ExpressionAnd(tools.RuleIn("rel.res_id", resIDs),
+		tools.RuleEqual("rel.res_type", resType)).SQLWhereExpr(tools.TenantJoinSqlWhereOption(kt, "rel"))
+	if err != nil {
+		return nil, err
+	}
+
 	var withoutFields = []string{"vendor", "reviser", "updated_at"}
 	withoutFields = append(withoutFields, types.DefaultRelJoinWithoutField...)
 	sql := fmt.Sprintf(`SELECT %s, %s, sg.vendor AS vendor,sg.reviser AS reviser,sg.updated_at AS updated_at,
-		rel.res_type,rel.priority FROM %s AS rel LEFT JOIN %s AS sg ON rel.security_group_id = sg.id 
-		WHERE res_id IN (:res_ids) AND res_type = :res_type`,
+		rel.res_type,rel.priority FROM %s AS rel LEFT JOIN %s AS sg ON rel.security_group_id = sg.id %s`,
 		cloud.SecurityGroupColumns.FieldsNamedExprWithout(withoutFields),
 		tools.BaseRelJoinSqlBuild("rel", "sg", "id", "res_id"),
-		table.SecurityGroupCommonRelTable, table.SecurityGroupTable)
+		table.SecurityGroupCommonRelTable, table.SecurityGroupTable, whereExpr)
 
 	details := make([]types.SecurityGroupWithCommonID, 0)
-	updateMap := map[string]interface{}{"res_ids": resIDs, "res_type": resType}
-	if err := dao.Orm.Do().Select(kt.Ctx, &details, sql, updateMap); err != nil {
+	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
 		logs.Errorf("select sg common rels join sg failed, err: %v, sql: (%s), resIDs: %v, resType: %s, rid: %s",
 			err, resIDs, resType, sql, kt.Rid)
 		return nil, err
@@ -117,12 +121,15 @@ func (dao Dao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, rels []cloud.Security
 	}
 
 	tableName := table.SecurityGroupCommonRelTable
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, tableName,
-		cloud.SecurityGroupCommonRelColumns.ColumnExpr(), cloud.SecurityGroupCommonRelColumns.ColonNameExpr())
+	for idx := range rels {
+		rels[idx].TenantID = kt.GetTenantID()
+	}
+
+	sql := cloud.SecurityGroupCommonRelColumns.InsertExpr(string(tableName))
 
 	if err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, rels); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", tableName, err, kt.Rid)
-		return fmt.Errorf("insert %s failed, err: %v", tableName, err)
+		return fmt.Errorf("insert %s failed, err: %w", tableName, err)
 	}
 
 	return nil
@@ -139,7 +146,7 @@ func (dao Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListSecurityGrou
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -166,13 +173,15 @@ func (dao Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListSecurityGrou
 		table.SecurityGroupCommonRelTable, whereExpr, pageExpr)
 
 	details := make([]cloud.SecurityGroupCommonRelTable, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.SecurityGroupCommonRelTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		logs.ErrorJson("select security group common rels failed, err: %v, filter: %s, rid: %s",
 			err, opt.Filter, kt.Rid)
 		return nil, err
 	}
 
-	return &types.ListSecurityGroupCommonRelDetails{Details: details}, nil
+	return &types.ListSecurityGroupCommonRelDetails{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx rels.
@@ -181,7 +190,7 @@ func (dao Dao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) e
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
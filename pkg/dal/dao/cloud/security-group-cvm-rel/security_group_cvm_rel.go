@@ -60,14 +60,19 @@ func (dao Dao) ListJoinSecurityGroup(kt *kit.Kit, cvmIDs []string) (*types.ListS
 		return nil, errf.Newf(errf.InvalidParameter, "cvm ids is required")
 	}
 
-	sql := fmt.Sprintf(`SELECT %s, %s FROM %s as rel left join %s as sg on rel.security_group_id = sg.id 
-	where cvm_id in (:cvm_ids)`,
-	cloud.SecurityGroupColumns.FieldsNamedExprWithout(types.DefaultRelJoinWithoutField),
-	tools.BaseRelJoinSqlBuild("rel", "sg", "id", "cvm_id"),
-	table.SecurityGroupCvmTable, table.SecurityGroupTable)
+	whereExpr, whereValue, err := tools.ContainersExpression("rel.cvm_id", cvmIDs).SQLWhereExpr(
+		tools.TenantJoinSqlWhereOption(kt, "rel"))
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s, %s FROM %s as rel left join %s as sg on rel.security_group_id = sg.id %s`,
+		cloud.SecurityGroupColumns.FieldsNamedExprWithout(types.DefaultRelJoinWithoutField),
+		tools.BaseRelJoinSqlBuild("rel", "sg", "id", "cvm_id"),
+		table.SecurityGroupCvmTable, table.SecurityGroupTable, whereExpr)
 
 	details := make([]types.SecurityGroupWithCvmID, 0)
-	if err := dao.Orm.Do().Select(kt.Ctx, &details, sql, map[string]interface{}{"cvm_ids": cvmIDs}); err != nil {
+	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
 		logs.ErrorJson("select sg cvm rels join sg failed, err: %v, sql: (%s), rid: %s", err, sql, kt.Rid)
 		return nil, err
 	}
@@ -107,12 +112,15 @@ func (dao Dao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, rels []cloud.Security
 		return fmt.Errorf("get cvm count not right")
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.SecurityGroupCvmTable,
-		cloud.SecurityGroupCvmRelColumns.ColumnExpr(), cloud.SecurityGroupCvmRelColumns.ColonNameExpr())
+	for idx := range rels {
+		rels[idx].TenantID = kt.GetTenantID()
+	}
+
+	sql := cloud.SecurityGroupCvmRelColumns.InsertExpr(string(table.SecurityGroupCvmTable))
 
 	if err := dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, rels); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", table.SecurityGroupCvmTable, err, kt.Rid)
-		return fmt.Errorf("insert %s failed, err: %v", table.SecurityGroupCvmTable, err)
+		return fmt.Errorf("insert %s failed, err: %w", table.SecurityGroupCvmTable, err)
 	}
 
 	return nil
@@ -129,7 +137,7 @@ func (dao Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListSecurityGrou
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -156,12 +164,14 @@ func (dao Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListSecurityGrou
 		table.SecurityGroupCvmTable, whereExpr, pageExpr)
 
 	details := make([]cloud.SecurityGroupCvmRelTable, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.SecurityGroupCvmTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		logs.ErrorJson("select security group cvm rels failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
 		return nil, err
 	}
 
-	return &types.ListSecurityGroupCvmRelDetails{Details: details}, nil
+	return &types.ListSecurityGroupCvmRelDetails{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx rels.
@@ -170,7 +180,7 @@ func (dao Dao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) e
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -28,6 +28,7 @@ import (
 	"hcm/pkg/dal/dao/audit"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	daoreshistory "hcm/pkg/dal/dao/resource-history"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table"
@@ -54,9 +55,10 @@ var _ AwsSGRule = new(AwsSGRuleDao)
 
 // AwsSGRuleDao aws security group rule dao.
 type AwsSGRuleDao struct {
-	Orm   orm.Interface
-	IDGen idgenerator.IDGenInterface
-	Audit audit.Interface
+	Orm     orm.Interface
+	IDGen   idgenerator.IDGenInterface
+	Audit   audit.Interface
+	History daoreshistory.Interface
 }
 
 // BatchCreateWithTx rule.
@@ -70,6 +72,7 @@ func (dao *AwsSGRuleDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, rules []*cl
 	}
 	for index := range rules {
 		rules[index].ID = ids[index]
+		rules[index].TenantID = kt.GetTenantID()
 	}
 
 	for _, rule := range rules {
@@ -78,12 +81,16 @@ func (dao *AwsSGRuleDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, rules []*cl
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AwsSecurityGroupRuleTable,
-		cloud.AwsSGRuleColumns.ColumnExpr(), cloud.AwsSGRuleColumns.ColonNameExpr())
+	sql := cloud.AwsSGRuleColumns.InsertExpr(table.AwsSecurityGroupRuleTable)
 
 	if err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, rules); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", table.AwsSecurityGroupRuleTable, err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", table.AwsSecurityGroupRuleTable, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", table.AwsSecurityGroupRuleTable, err)
+	}
+
+	if err = dao.History.RecordWithTx(kt, tx, table.AwsSecurityGroupRuleTable, ids); err != nil {
+		logs.Errorf("record aws security group rule history failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
 	}
 
 	if err = dao.batchCreateAudit(kt, tx, rules); err != nil {
@@ -159,7 +166,7 @@ func (dao *AwsSGRuleDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Exp
 		return err
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -172,6 +179,11 @@ func (dao *AwsSGRuleDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Exp
 
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, rule.TableName(), setExpr, whereExpr)
 
+	ids, err := dao.History.ListIDsWithTx(kt, tx, table.AwsSecurityGroupRuleTable, whereExpr, whereValue)
+	if err != nil {
+		return err
+	}
+
 	effected, err := dao.Orm.Txn(tx).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 	if err != nil {
 		logs.ErrorJson("update aws security group rule failed, err: %v, filter: %s, rid: %v", err, expr, kt.Rid)
@@ -183,6 +195,11 @@ func (dao *AwsSGRuleDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Exp
 		return errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
 	}
 
+	if err = dao.History.RecordWithTx(kt, tx, table.AwsSecurityGroupRuleTable, ids); err != nil {
+		logs.Errorf("record aws security group rule history failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
 	return nil
 }
 
@@ -194,7 +211,7 @@ func (dao *AwsSGRuleDao) List(kt *kit.Kit, opt *types.SGRuleListOption) (*types.
 
 	columnTypes := cloud.AwsSGRuleColumns.ColumnTypes()
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
-		core.NewDefaultPageOption()); err != nil {
+		core.NewTablePageOption(table.AwsSecurityGroupRuleTable)); err != nil {
 		return nil, err
 	}
 
@@ -239,11 +256,13 @@ func (dao *AwsSGRuleDao) List(kt *kit.Kit, opt *types.SGRuleListOption) (*types.
 		table.AwsSecurityGroupRuleTable, whereExpr, pageExpr)
 
 	details := make([]cloud.AwsSecurityGroupRuleTable, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AwsSecurityGroupRuleTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.ListAwsSGRuleDetails{Details: details}, nil
+	return &types.ListAwsSGRuleDetails{Count: count, Details: details}, nil
 }
 
 // Delete rule.
@@ -252,7 +271,7 @@ func (dao *AwsSGRuleDao) Delete(kt *kit.Kit, expr *filter.Expression) error {
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -260,6 +279,12 @@ func (dao *AwsSGRuleDao) Delete(kt *kit.Kit, expr *filter.Expression) error {
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.AwsSecurityGroupRuleTable, whereExpr)
 
 	_, err = dao.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		err = dao.History.CloseByWhereWithTx(kt, txn, table.AwsSecurityGroupRuleTable, whereExpr, whereValue)
+		if err != nil {
+			logs.Errorf("close aws security group rule history failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
 		if _, err = dao.Orm.Txn(txn).Delete(kt.Ctx, sql, whereValue); err != nil {
 			logs.ErrorJson("delete aws security group rule failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
 			return nil, err
@@ -280,13 +305,18 @@ func (dao *AwsSGRuleDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Exp
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
 
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.AwsSecurityGroupRuleTable, whereExpr)
 
+	if err = dao.History.CloseByWhereWithTx(kt, tx, table.AwsSecurityGroupRuleTable, whereExpr, whereValue); err != nil {
+		logs.Errorf("close aws security group rule history failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
 	if _, err = dao.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
 		logs.ErrorJson("delete aws security group rule failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
 		return err
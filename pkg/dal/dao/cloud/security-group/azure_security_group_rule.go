@@ -28,6 +28,7 @@ import (
 	"hcm/pkg/dal/dao/audit"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	daoreshistory "hcm/pkg/dal/dao/resource-history"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table"
@@ -54,9 +55,10 @@ var _ AzureSGRule = new(AzureSGRuleDao)
 
 // AzureSGRuleDao azure security group rule dao.
 type AzureSGRuleDao struct {
-	Orm   orm.Interface
-	IDGen idgenerator.IDGenInterface
-	Audit audit.Interface
+	Orm     orm.Interface
+	IDGen   idgenerator.IDGenInterface
+	Audit   audit.Interface
+	History daoreshistory.Interface
 }
 
 // BatchCreateWithTx rule.
@@ -70,6 +72,7 @@ func (dao *AzureSGRuleDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, rules []*
 	}
 	for index := range rules {
 		rules[index].ID = ids[index]
+		rules[index].TenantID = kt.GetTenantID()
 	}
 
 	for _, rule := range rules {
@@ -78,12 +81,16 @@ func (dao *AzureSGRuleDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, rules []*
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AzureSecurityGroupRuleTable,
-		cloud.AzureSGRuleColumns.ColumnExpr(), cloud.AzureSGRuleColumns.ColonNameExpr())
+	sql := cloud.AzureSGRuleColumns.InsertExpr(table.AzureSecurityGroupRuleTable)
 
 	if err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, rules); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", table.AzureSecurityGroupRuleTable, err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", table.AzureSecurityGroupRuleTable, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", table.AzureSecurityGroupRuleTable, err)
+	}
+
+	if err = dao.History.RecordWithTx(kt, tx, table.AzureSecurityGroupRuleTable, ids); err != nil {
+		logs.Errorf("record azure security group rule history failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
 	}
 
 	if err = dao.batchCreateAudit(kt, tx, rules); err != nil {
@@ -161,7 +168,7 @@ func (dao *AzureSGRuleDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.E
 		return err
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -174,6 +181,11 @@ func (dao *AzureSGRuleDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.E
 
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, rule.TableName(), setExpr, whereExpr)
 
+	ids, err := dao.History.ListIDsWithTx(kt, tx, table.AzureSecurityGroupRuleTable, whereExpr, whereValue)
+	if err != nil {
+		return err
+	}
+
 	effected, err := dao.Orm.Txn(tx).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 	if err != nil {
 		logs.ErrorJson("update azure security group rule failed, err: %v, filter: %s, rid: %v", err, expr, kt.Rid)
@@ -185,6 +197,11 @@ func (dao *AzureSGRuleDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.E
 		return errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
 	}
 
+	if err = dao.History.RecordWithTx(kt, tx, table.AzureSecurityGroupRuleTable, ids); err != nil {
+		logs.Errorf("record azure security group rule history failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
 	return nil
 }
 
@@ -195,7 +212,7 @@ func (dao *AzureSGRuleDao) List(kt *kit.Kit, opt *types.SGRuleListOption) (*type
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(cloud.AzureSGRuleColumns.ColumnTypes())),
-		core.NewDefaultPageOption()); err != nil {
+		core.NewTablePageOption(table.AzureSecurityGroupRuleTable)); err != nil {
 		return nil, err
 	}
 
@@ -240,11 +257,13 @@ func (dao *AzureSGRuleDao) List(kt *kit.Kit, opt *types.SGRuleListOption) (*type
 		table.AzureSecurityGroupRuleTable, whereExpr, pageExpr)
 
 	details := make([]cloud.AzureSecurityGroupRuleTable, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AzureSecurityGroupRuleTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.ListAzureSGRuleDetails{Details: details}, nil
+	return &types.ListAzureSGRuleDetails{Count: count, Details: details}, nil
 }
 
 // Delete rule.
@@ -253,7 +272,7 @@ func (dao *AzureSGRuleDao) Delete(kt *kit.Kit, expr *filter.Expression) error {
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -261,6 +280,12 @@ func (dao *AzureSGRuleDao) Delete(kt *kit.Kit, expr *filter.Expression) error {
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.AzureSecurityGroupRuleTable, whereExpr)
 
 	_, err = dao.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		err = dao.History.CloseByWhereWithTx(kt, txn, table.AzureSecurityGroupRuleTable, whereExpr, whereValue)
+		if err != nil {
+			logs.Errorf("close azure security group rule history failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
 		if _, err = dao.Orm.Txn(txn).Delete(kt.Ctx, sql, whereValue); err != nil {
 			logs.ErrorJson("delete azure security group rule failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
 			return nil, err
@@ -281,13 +306,18 @@ func (dao *AzureSGRuleDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.E
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
 
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.AzureSecurityGroupRuleTable, whereExpr)
 
+	if err = dao.History.CloseByWhereWithTx(kt, tx, table.AzureSecurityGroupRuleTable, whereExpr, whereValue); err != nil {
+		logs.Errorf("close azure security group rule history failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
 	if _, err = dao.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
 		logs.ErrorJson("delete azure security group rule failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
 		return err
@@ -28,6 +28,7 @@ import (
 	"hcm/pkg/dal/dao/audit"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	daoreshistory "hcm/pkg/dal/dao/resource-history"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table"
@@ -54,9 +55,10 @@ var _ HuaWeiSGRule = new(HuaWeiSGRuleDao)
 
 // HuaWeiSGRuleDao huawei security group rule dao.
 type HuaWeiSGRuleDao struct {
-	Orm   orm.Interface
-	IDGen idgenerator.IDGenInterface
-	Audit audit.Interface
+	Orm     orm.Interface
+	IDGen   idgenerator.IDGenInterface
+	Audit   audit.Interface
+	History daoreshistory.Interface
 }
 
 // BatchCreateWithTx rule.
@@ -70,6 +72,7 @@ func (dao *HuaWeiSGRuleDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, rules []
 	}
 	for index := range rules {
 		rules[index].ID = ids[index]
+		rules[index].TenantID = kt.GetTenantID()
 	}
 
 	for _, rule := range rules {
@@ -78,12 +81,16 @@ func (dao *HuaWeiSGRuleDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, rules []
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.HuaWeiSecurityGroupRuleTable,
-		cloud.HuaWeiSGRuleColumns.ColumnExpr(), cloud.HuaWeiSGRuleColumns.ColonNameExpr())
+	sql := cloud.HuaWeiSGRuleColumns.InsertExpr(table.HuaWeiSecurityGroupRuleTable)
 
 	if err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, rules); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", table.HuaWeiSecurityGroupRuleTable, err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", table.HuaWeiSecurityGroupRuleTable, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", table.HuaWeiSecurityGroupRuleTable, err)
+	}
+
+	if err = dao.History.RecordWithTx(kt, tx, table.HuaWeiSecurityGroupRuleTable, ids); err != nil {
+		logs.Errorf("record huawei security group rule history failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
 	}
 
 	if err = dao.batchCreateAudit(kt, tx, rules); err != nil {
@@ -161,7 +168,7 @@ func (dao *HuaWeiSGRuleDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.
 		return err
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -174,6 +181,11 @@ func (dao *HuaWeiSGRuleDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.
 
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, rule.TableName(), setExpr, whereExpr)
 
+	ids, err := dao.History.ListIDsWithTx(kt, tx, table.HuaWeiSecurityGroupRuleTable, whereExpr, whereValue)
+	if err != nil {
+		return err
+	}
+
 	effected, err := dao.Orm.Txn(tx).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 	if err != nil {
 		logs.ErrorJson("update huawei security group rule failed, err: %v, filter: %s, rid: %v", err, expr, kt.Rid)
@@ -185,6 +197,11 @@ func (dao *HuaWeiSGRuleDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.
 		return errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
 	}
 
+	if err = dao.History.RecordWithTx(kt, tx, table.HuaWeiSecurityGroupRuleTable, ids); err != nil {
+		logs.Errorf("record huawei security group rule history failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
 	return nil
 }
 
@@ -195,7 +212,7 @@ func (dao *HuaWeiSGRuleDao) List(kt *kit.Kit, opt *types.SGRuleListOption) (*typ
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(cloud.HuaWeiSGRuleColumns.ColumnTypes())),
-		core.NewDefaultPageOption()); err != nil {
+		core.NewTablePageOption(table.HuaWeiSecurityGroupRuleTable)); err != nil {
 		return nil, err
 	}
 
@@ -240,11 +257,13 @@ func (dao *HuaWeiSGRuleDao) List(kt *kit.Kit, opt *types.SGRuleListOption) (*typ
 		table.HuaWeiSecurityGroupRuleTable, whereExpr, pageExpr)
 
 	details := make([]cloud.HuaWeiSecurityGroupRuleTable, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.HuaWeiSecurityGroupRuleTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.ListHuaWeiSGRuleDetails{Details: details}, nil
+	return &types.ListHuaWeiSGRuleDetails{Count: count, Details: details}, nil
 }
 
 // Delete rule.
@@ -253,7 +272,7 @@ func (dao *HuaWeiSGRuleDao) Delete(kt *kit.Kit, expr *filter.Expression) error {
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -261,6 +280,12 @@ func (dao *HuaWeiSGRuleDao) Delete(kt *kit.Kit, expr *filter.Expression) error {
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.HuaWeiSecurityGroupRuleTable, whereExpr)
 
 	_, err = dao.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		err = dao.History.CloseByWhereWithTx(kt, txn, table.HuaWeiSecurityGroupRuleTable, whereExpr, whereValue)
+		if err != nil {
+			logs.Errorf("close huawei security group rule history failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
 		if _, err = dao.Orm.Txn(txn).Delete(kt.Ctx, sql, whereValue); err != nil {
 			logs.ErrorJson("delete huawei security group rule failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
 			return nil, err
@@ -281,13 +306,18 @@ func (dao *HuaWeiSGRuleDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
 
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.HuaWeiSecurityGroupRuleTable, whereExpr)
 
+	if err = dao.History.CloseByWhereWithTx(kt, tx, table.HuaWeiSecurityGroupRuleTable, whereExpr, whereValue); err != nil {
+		logs.Errorf("close huawei security group rule history failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
 	if _, err = dao.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
 		logs.ErrorJson("delete huawei security group rule failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
 		return err
@@ -26,8 +26,11 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/audit"
+	"hcm/pkg/dal/dao/cascade"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	daoreshistory "hcm/pkg/dal/dao/resource-history"
+	daorestag "hcm/pkg/dal/dao/resource-tag"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table"
@@ -54,9 +57,13 @@ var _ SecurityGroup = new(SecurityGroupDao)
 
 // SecurityGroupDao security group dao.
 type SecurityGroupDao struct {
-	Orm   orm.Interface
-	IDGen idgenerator.IDGenInterface
-	Audit audit.Interface
+	Orm     orm.Interface
+	IDGen   idgenerator.IDGenInterface
+	Audit   audit.Interface
+	History daoreshistory.Interface
+	Tag     daorestag.Interface
+	// Children 安全组的子资源(规则、关联关系)，删除安全组时会在同一个事务中级联删除
+	Children []cascade.Child
 }
 
 // BatchCreateWithTx sg with tx.
@@ -69,18 +76,28 @@ func (s SecurityGroupDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, sgs []*clo
 	}
 	for index, sg := range sgs {
 		sg.ID = ids[index]
+		sg.TenantID = kt.GetTenantID()
 
 		if err := sg.InsertValidate(); err != nil {
 			return nil, err
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.SecurityGroupTable,
-		cloud.SecurityGroupColumns.ColumnExpr(), cloud.SecurityGroupColumns.ColonNameExpr())
+	sql := cloud.SecurityGroupColumns.InsertExpr(string(table.SecurityGroupTable))
 
 	if err = s.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, sgs); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", table.SecurityGroupTable, err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", table.SecurityGroupTable, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", table.SecurityGroupTable, err)
+	}
+
+	if err = s.History.RecordWithTx(kt, tx, table.SecurityGroupTable, ids); err != nil {
+		logs.Errorf("record security group history failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	if err = s.Tag.SyncWithTx(kt, tx, table.SecurityGroupTable, ids); err != nil {
+		logs.Errorf("sync security group tags failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
 	}
 
 	// create audit.
@@ -122,7 +139,7 @@ func (s SecurityGroupDao) Update(kt *kit.Kit, expr *filter.Expression, sg *cloud
 		return err
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -136,6 +153,11 @@ func (s SecurityGroupDao) Update(kt *kit.Kit, expr *filter.Expression, sg *cloud
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, sg.TableName(), setExpr, whereExpr)
 
 	_, err = s.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := s.History.ListIDsWithTx(kt, txn, table.SecurityGroupTable, whereExpr, whereValue)
+		if err != nil {
+			return nil, err
+		}
+
 		effected, err := s.Orm.Txn(txn).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 		if err != nil {
 			logs.ErrorJson("update security group failed, err: %v, filter: %s, rid: %v", err, expr, kt.Rid)
@@ -147,6 +169,18 @@ func (s SecurityGroupDao) Update(kt *kit.Kit, expr *filter.Expression, sg *cloud
 			return nil, errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
 		}
 
+		if err = s.History.RecordWithTx(kt, txn, table.SecurityGroupTable, ids); err != nil {
+			logs.Errorf("record security group history failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		if sg.Tags != nil {
+			if err = s.Tag.SyncWithTx(kt, txn, table.SecurityGroupTable, ids); err != nil {
+				logs.Errorf("sync security group tags failed, err: %v, rid: %s", err, kt.Rid)
+				return nil, err
+			}
+		}
+
 		return nil, nil
 	})
 	if err != nil {
@@ -172,7 +206,8 @@ func (s SecurityGroupDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string,
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, sg.TableName(), setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, sg.TableName(), setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = id
 	_, err = s.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
@@ -181,6 +216,18 @@ func (s SecurityGroupDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string,
 		return err
 	}
 
+	if err = s.History.RecordWithTx(kt, tx, table.SecurityGroupTable, []string{id}); err != nil {
+		logs.Errorf("record security group history failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+
+	if sg.Tags != nil {
+		if err = s.Tag.SyncWithTx(kt, tx, table.SecurityGroupTable, []string{id}); err != nil {
+			logs.Errorf("sync security group tags failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -193,12 +240,12 @@ func (s SecurityGroupDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListS
 	columnTypes := cloud.SecurityGroupColumns.ColumnTypes()
 	columnTypes["extension.resource_group_name"] = enumor.String
 	columnTypes["extension.vpc_id"] = enumor.String
-	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
-		core.NewDefaultPageOption()); err != nil {
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes), filter.TagRuleFields()),
+		core.NewTablePageOption(table.SecurityGroupTable)); err != nil {
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TagSqlWhereOption(kt, table.SecurityGroupTable))
 	if err != nil {
 		return nil, err
 	}
@@ -216,7 +263,8 @@ func (s SecurityGroupDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListS
 		return &types.ListSecurityGroupDetails{Count: count}, nil
 	}
 
-	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	pageExpr, err := types.PageSQLExpr(opt.Page,
+		types.DefaultPageSQLOption.WithSortFields(cloud.SecurityGroupColumns.ColumnTypes()))
 	if err != nil {
 		return nil, err
 	}
@@ -225,25 +273,51 @@ func (s SecurityGroupDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListS
 		table.SecurityGroupTable, whereExpr, pageExpr)
 
 	details := make([]cloud.SecurityGroupTable, 0)
-	if err = s.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.SecurityGroupTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, s.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		logs.ErrorJson("select security group failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
 		return nil, err
 	}
 
-	return &types.ListSecurityGroupDetails{Details: details}, nil
+	return &types.ListSecurityGroupDetails{Count: count, Details: details}, nil
 }
 
-// DeleteWithTx sg with filter.
+// DeleteWithTx sg with filter, the child resources of the security groups are deleted in the same transaction.
 func (s SecurityGroupDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error {
 	if expr == nil {
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
 
+	listSql := fmt.Sprintf(`SELECT id FROM %s %s`, table.SecurityGroupTable, whereExpr)
+	ids := make([]string, 0)
+	if err = s.Orm.Txn(tx).Select(kt.Ctx, &ids, listSql, whereValue); err != nil {
+		logs.ErrorJson("list security group ids failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
+		return err
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err = cascade.DeleteChildrenWithTx(kt, tx, s.Children, ids); err != nil {
+		return err
+	}
+
+	if err = s.Tag.DeleteWithTx(kt, tx, table.SecurityGroupTable, ids); err != nil {
+		return err
+	}
+
+	if err = s.History.CloseByWhereWithTx(kt, tx, table.SecurityGroupTable, whereExpr, whereValue); err != nil {
+		logs.Errorf("close security group history failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.SecurityGroupTable, whereExpr)
 	if _, err = s.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
 		logs.ErrorJson("delete security group failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
@@ -0,0 +1,147 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"hcm/pkg/dal/dao/cascade"
+	"hcm/pkg/dal/dao/orm"
+	daoreshistory "hcm/pkg/dal/dao/resource-history"
+	daorestag "hcm/pkg/dal/dao/resource-tag"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/kit"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// stepRecorder records the steps of the deletion, so that the order can be checked.
+type stepRecorder struct {
+	steps []string
+}
+
+// fakeOrm returns the prepared security group ids, and records the delete statement.
+type fakeOrm struct {
+	orm.Interface
+	orm.DoOrmWithTransaction
+	rec *stepRecorder
+	ids []string
+}
+
+// Txn ...
+func (f *fakeOrm) Txn(_ *sqlx.Tx) orm.DoOrmWithTransaction {
+	return f
+}
+
+// Select ...
+func (f *fakeOrm) Select(_ context.Context, dest interface{}, _ string, _ map[string]interface{}) error {
+	*dest.(*[]string) = append(*dest.(*[]string), f.ids...)
+	return nil
+}
+
+// Delete ...
+func (f *fakeOrm) Delete(_ context.Context, _ string, _ map[string]interface{}) (int64, error) {
+	f.rec.steps = append(f.rec.steps, "security_group")
+	return int64(len(f.ids)), nil
+}
+
+type fakeChild struct {
+	rec  *stepRecorder
+	name string
+}
+
+// DeleteWithTx ...
+func (f fakeChild) DeleteWithTx(_ *kit.Kit, _ *sqlx.Tx, expr *filter.Expression) error {
+	f.rec.steps = append(f.rec.steps, fmt.Sprintf("%s%v", f.name, expr.Rules[0].(filter.AtomRule).Value))
+	return nil
+}
+
+type fakeTag struct {
+	daorestag.Interface
+	rec *stepRecorder
+}
+
+// DeleteWithTx ...
+func (f fakeTag) DeleteWithTx(_ *kit.Kit, _ *sqlx.Tx, _ table.Name, _ []string) error {
+	f.rec.steps = append(f.rec.steps, "tag")
+	return nil
+}
+
+type fakeHistory struct {
+	daoreshistory.Interface
+	rec *stepRecorder
+}
+
+// CloseByWhereWithTx ...
+func (f fakeHistory) CloseByWhereWithTx(_ *kit.Kit, _ *sqlx.Tx, _ table.Name, _ string,
+	_ map[string]interface{}) error {
+
+	f.rec.steps = append(f.rec.steps, "history")
+	return nil
+}
+
+func newTestDao(rec *stepRecorder, ids []string) SecurityGroupDao {
+	return SecurityGroupDao{
+		Orm:     &fakeOrm{rec: rec, ids: ids},
+		History: fakeHistory{rec: rec},
+		Tag:     fakeTag{rec: rec},
+		Children: []cascade.Child{
+			{Table: table.TCloudSecurityGroupRuleTable, ParentKey: "security_group_id",
+				Deleter: fakeChild{rec: rec, name: "rule"}},
+			{Table: table.SecurityGroupCvmTable, ParentKey: "security_group_id",
+				Deleter: fakeChild{rec: rec, name: "cvm_rel"}},
+		},
+	}
+}
+
+func TestDeleteWithTxCascade(t *testing.T) {
+	rec := new(stepRecorder)
+	dao := newTestDao(rec, []string{"sg-1", "sg-2"})
+
+	if err := dao.DeleteWithTx(kit.New(), nil, tools.EqualExpression("vendor", "tcloud")); err != nil {
+		t.Fatalf("delete security group failed, err: %v", err)
+	}
+
+	// the children must be deleted before the security groups in the same transaction.
+	expect := "[rule[sg-1 sg-2] cvm_rel[sg-1 sg-2] tag history security_group]"
+	if fmt.Sprint(rec.steps) != expect {
+		t.Errorf("unexpected delete steps: %v", rec.steps)
+	}
+}
+
+func TestDeleteWithTxNoMatch(t *testing.T) {
+	rec := new(stepRecorder)
+	dao := newTestDao(rec, nil)
+
+	if err := dao.DeleteWithTx(kit.New(), nil, tools.EqualExpression("vendor", "tcloud")); err != nil {
+		t.Fatalf("delete security group failed, err: %v", err)
+	}
+	if len(rec.steps) != 0 {
+		t.Errorf("nothing should be deleted when no security group matches, got: %v", rec.steps)
+	}
+
+	if err := dao.DeleteWithTx(kit.New(), nil, nil); err == nil {
+		t.Errorf("delete without filter should be rejected")
+	}
+}
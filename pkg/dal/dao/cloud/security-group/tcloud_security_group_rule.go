@@ -28,6 +28,7 @@ import (
 	"hcm/pkg/dal/dao/audit"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	daoreshistory "hcm/pkg/dal/dao/resource-history"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table"
@@ -55,9 +56,10 @@ var _ TCloudSGRule = new(TCloudSGRuleDao)
 
 // TCloudSGRuleDao tcloud security group rule dao.
 type TCloudSGRuleDao struct {
-	Orm   orm.Interface
-	IDGen idgenerator.IDGenInterface
-	Audit audit.Interface
+	Orm     orm.Interface
+	IDGen   idgenerator.IDGenInterface
+	Audit   audit.Interface
+	History daoreshistory.Interface
 }
 
 // BatchCreateOrUpdateWithTx rule.
@@ -71,6 +73,7 @@ func (dao *TCloudSGRuleDao) BatchCreateOrUpdateWithTx(kt *kit.Kit, tx *sqlx.Tx,
 	}
 	for index := range rules {
 		rules[index].ID = ids[index]
+		rules[index].TenantID = kt.GetTenantID()
 	}
 
 	for _, rule := range rules {
@@ -79,12 +82,16 @@ func (dao *TCloudSGRuleDao) BatchCreateOrUpdateWithTx(kt *kit.Kit, tx *sqlx.Tx,
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.TCloudSecurityGroupRuleTable,
-		cloud.TCloudSGRuleColumns.ColumnExpr(), cloud.TCloudSGRuleColumns.ColonNameExpr())
+	sql := cloud.TCloudSGRuleColumns.InsertExpr(table.TCloudSecurityGroupRuleTable)
 
 	if err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, rules); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", table.TCloudSecurityGroupRuleTable, err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", table.TCloudSecurityGroupRuleTable, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", table.TCloudSecurityGroupRuleTable, err)
+	}
+
+	if err = dao.History.RecordWithTx(kt, tx, table.TCloudSecurityGroupRuleTable, ids); err != nil {
+		logs.Errorf("record tcloud security group rule history failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
 	}
 
 	if err = dao.batchCreateAudit(kt, tx, rules); err != nil {
@@ -162,7 +169,7 @@ func (dao *TCloudSGRuleDao) Update(kt *kit.Kit, expr *filter.Expression, rule *c
 		return err
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -176,6 +183,11 @@ func (dao *TCloudSGRuleDao) Update(kt *kit.Kit, expr *filter.Expression, rule *c
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, rule.TableName(), setExpr, whereExpr)
 
 	_, err = dao.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := dao.History.ListIDsWithTx(kt, txn, table.TCloudSecurityGroupRuleTable, whereExpr, whereValue)
+		if err != nil {
+			return nil, err
+		}
+
 		effected, err := dao.Orm.Txn(txn).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 		if err != nil {
 			logs.ErrorJson("update tcloud security group rule failed, err: %v, filter: %s, rid: %v", err, expr, kt.Rid)
@@ -187,6 +199,11 @@ func (dao *TCloudSGRuleDao) Update(kt *kit.Kit, expr *filter.Expression, rule *c
 			return nil, errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
 		}
 
+		if err = dao.History.RecordWithTx(kt, txn, table.TCloudSecurityGroupRuleTable, ids); err != nil {
+			logs.Errorf("record tcloud security group rule history failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
 		return nil, nil
 	})
 	if err != nil {
@@ -208,7 +225,7 @@ func (dao *TCloudSGRuleDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.
 		return err
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -221,6 +238,11 @@ func (dao *TCloudSGRuleDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.
 
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, rule.TableName(), setExpr, whereExpr)
 
+	ids, err := dao.History.ListIDsWithTx(kt, tx, table.TCloudSecurityGroupRuleTable, whereExpr, whereValue)
+	if err != nil {
+		return err
+	}
+
 	effected, err := dao.Orm.Txn(tx).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 	if err != nil {
 		logs.ErrorJson("update tcloud security group rule failed, err: %v, filter: %s, rid: %v", err, expr, kt.Rid)
@@ -232,6 +254,11 @@ func (dao *TCloudSGRuleDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.
 		return errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
 	}
 
+	if err = dao.History.RecordWithTx(kt, tx, table.TCloudSecurityGroupRuleTable, ids); err != nil {
+		logs.Errorf("record tcloud security group rule history failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
 	return nil
 }
 
@@ -244,7 +271,7 @@ func (dao *TCloudSGRuleDao) List(kt *kit.Kit, opt *types.SGRuleListOption) (*typ
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(cloud.TCloudSGRuleColumns.ColumnTypes())),
-		core.NewDefaultPageOption()); err != nil {
+		core.NewTablePageOption(table.TCloudSecurityGroupRuleTable)); err != nil {
 		return nil, err
 	}
 
@@ -289,11 +316,13 @@ func (dao *TCloudSGRuleDao) List(kt *kit.Kit, opt *types.SGRuleListOption) (*typ
 		table.TCloudSecurityGroupRuleTable, whereExpr, pageExpr)
 
 	details := make([]cloud.TCloudSecurityGroupRuleTable, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.TCloudSecurityGroupRuleTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.ListTCloudSGRuleDetails{Details: details}, nil
+	return &types.ListTCloudSGRuleDetails{Count: count, Details: details}, nil
 }
 
 // ListExt list ext rules.
@@ -303,11 +332,11 @@ func (dao *TCloudSGRuleDao) ListExt(kt *kit.Kit, opt *types.ListOption) (*types.
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(cloud.TCloudSGRuleColumns.ColumnTypes())),
-		core.NewDefaultPageOption()); err != nil {
+		core.NewTablePageOption(table.TCloudSecurityGroupRuleTable)); err != nil {
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -335,11 +364,13 @@ func (dao *TCloudSGRuleDao) ListExt(kt *kit.Kit, opt *types.ListOption) (*types.
 		table.TCloudSecurityGroupRuleTable, whereExpr, pageExpr)
 
 	details := make([]cloud.TCloudSecurityGroupRuleTable, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.TCloudSecurityGroupRuleTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.ListTCloudSGRuleDetails{Details: details}, nil
+	return &types.ListTCloudSGRuleDetails{Count: count, Details: details}, nil
 }
 
 // Delete rule.
@@ -348,7 +379,7 @@ func (dao *TCloudSGRuleDao) Delete(kt *kit.Kit, expr *filter.Expression) error {
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -356,6 +387,12 @@ func (dao *TCloudSGRuleDao) Delete(kt *kit.Kit, expr *filter.Expression) error {
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.TCloudSecurityGroupRuleTable, whereExpr)
 
 	_, err = dao.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		err = dao.History.CloseByWhereWithTx(kt, txn, table.TCloudSecurityGroupRuleTable, whereExpr, whereValue)
+		if err != nil {
+			logs.Errorf("close tcloud security group rule history failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
 		if _, err = dao.Orm.Txn(txn).Delete(kt.Ctx, sql, whereValue); err != nil {
 			logs.ErrorJson("delete tcloud security group rule failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
 			return nil, err
@@ -376,13 +413,18 @@ func (dao *TCloudSGRuleDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
 
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.TCloudSecurityGroupRuleTable, whereExpr)
 
+	if err = dao.History.CloseByWhereWithTx(kt, tx, table.TCloudSecurityGroupRuleTable, whereExpr, whereValue); err != nil {
+		logs.Errorf("close tcloud security group rule history failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
 	if _, err = dao.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
 		logs.ErrorJson("delete tcloud security group rule failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
 		return err
@@ -76,7 +76,8 @@ func (dao *SubAccountDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string,
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, model.TableName(), setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, model.TableName(), setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = id
 	_, err = dao.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
@@ -124,15 +125,15 @@ func (dao *SubAccountDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx,
 		}
 
 		models[index].ID = ids[index]
+		models[index].TenantID = kt.GetTenantID()
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.SubAccountTable, tablesubaccount.Columns.ColumnExpr(),
-		tablesubaccount.Columns.ColonNameExpr())
+	sql := tablesubaccount.Columns.InsertExpr(string(table.SubAccountTable))
 
 	err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models)
 	if err != nil {
 		logs.Errorf("insert %s failed, err: %v, sql: %s, rid: %s", table.SubAccountTable, err, sql, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", table.SubAccountTable, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", table.SubAccountTable, err)
 	}
 
 	// create audit.
@@ -174,7 +175,7 @@ func (dao *SubAccountDao) Update(kt *kit.Kit, filterExpr *filter.Expression,
 		return err
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -222,7 +223,7 @@ func (dao *SubAccountDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListS
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -249,12 +250,14 @@ func (dao *SubAccountDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListS
 		table.SubAccountTable, whereExpr, pageExpr)
 
 	details := make([]tablesubaccount.Table, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.SubAccountTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		logs.ErrorJson("select sub account failed, err: %v, sql: %s, filter: %v, rid: %s", err, sql, opt.Filter, kt.Rid)
 		return nil, err
 	}
 
-	return &types.ListSubAccountDetails{Count: 0, Details: details}, nil
+	return &types.ListSubAccountDetails{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx account with tx.
@@ -263,7 +266,7 @@ func (dao *SubAccountDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *fil
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
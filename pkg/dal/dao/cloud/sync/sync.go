@@ -73,7 +73,8 @@ func (dao *AccountSyncDetailDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id s
 		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
 	}
 
-	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, model.TableName(), setExpr)
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, model.TableName(), setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
 
 	toUpdate["id"] = id
 	_, err = dao.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
@@ -96,19 +97,19 @@ func (dao *AccountSyncDetailDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx,
 	}
 	for index := range models {
 		models[index].ID = ids[index]
+		models[index].TenantID = kt.GetTenantID()
 
 		if err = models[index].InsertValidate(); err != nil {
 			return nil, err
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AccountSyncDetailTable,
-		tablessync.AccountSyncDetailColumns.ColumnExpr(), tablessync.AccountSyncDetailColumns.ColonNameExpr())
+	sql := tablessync.AccountSyncDetailColumns.InsertExpr(string(table.AccountSyncDetailTable))
 
 	err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models)
 	if err != nil {
 		logs.Errorf("insert %s failed, err: %v, sql: %s, rid: %s", table.AccountSyncDetailTable, err, sql, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", table.AccountSyncDetailTable, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", table.AccountSyncDetailTable, err)
 	}
 
 	return ids, nil
@@ -125,7 +126,7 @@ func (dao *AccountSyncDetailDao) List(kt *kit.Kit, opt *types.ListOption) (*type
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -153,13 +154,15 @@ func (dao *AccountSyncDetailDao) List(kt *kit.Kit, opt *types.ListOption) (*type
 		table.AccountSyncDetailTable, whereExpr, pageExpr)
 
 	details := make([]tablessync.AccountSyncDetailTable, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AccountSyncDetailTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		logs.ErrorJson("select account sync detail failed, err: %v, sql: %s, filter: %v, rid: %s", err, sql,
 			opt.Filter, kt.Rid)
 		return nil, err
 	}
 
-	return &typessync.ListAccountSyncDetails{Count: 0, Details: details}, nil
+	return &typessync.ListAccountSyncDetails{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx account sync detail with tx.
@@ -168,7 +171,7 @@ func (dao *AccountSyncDetailDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterEx
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
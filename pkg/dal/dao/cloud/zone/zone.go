@@ -73,12 +73,11 @@ func (z ZoneDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, zones []*zone.ZoneTable)
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.ZoneTable,
-		zone.ZoneColumns.ColumnExpr(), zone.ZoneColumns.ColonNameExpr())
+	sql := zone.ZoneColumns.InsertExpr(string(table.ZoneTable))
 
 	if err = z.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, zones); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", table.ZoneTable, err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", table.ZoneTable, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", table.ZoneTable, err)
 	}
 
 	return ids, nil
@@ -150,11 +149,13 @@ func (z ZoneDao) List(kt *kit.Kit, opt *types.ListOption) (*typeszone.ListZoneDe
 		table.ZoneTable, whereExpr, pageExpr)
 
 	details := make([]zone.ZoneTable, 0)
-	if err = z.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ZoneTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, z.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &typeszone.ListZoneDetails{Details: details}, nil
+	return &typeszone.ListZoneDetails{Count: count, Details: details}, nil
 }
 
 // Delete delete zone
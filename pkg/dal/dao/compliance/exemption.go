@@ -0,0 +1,178 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package compliance
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tablecompliance "hcm/pkg/dal/table/compliance"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ExemptionInterface only used for compliance exemption.
+type ExemptionInterface interface {
+	Create(kt *kit.Kit, model *tablecompliance.ExemptionTable) (string, error)
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tablecompliance.ExemptionTable], error)
+	DeleteByIDs(kt *kit.Kit, ids []string) error
+}
+
+var _ ExemptionInterface = new(ExemptionDao)
+
+// ExemptionDao compliance exemption dao.
+type ExemptionDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// Create compliance exemption, the existing finding of the rule and resource is marked as exempted at the same time.
+func (dao ExemptionDao) Create(kt *kit.Kit, model *tablecompliance.ExemptionTable) (string, error) {
+	if model == nil {
+		return "", errf.New(errf.InvalidParameter, "model is required")
+	}
+
+	id, err := dao.IDGen.One(kt, table.ComplianceExemptionTable)
+	if err != nil {
+		return "", err
+	}
+	model.ID = id
+	model.TenantID = kt.GetTenantID()
+
+	if err = model.InsertValidate(); err != nil {
+		return "", err
+	}
+
+	_, err = dao.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		sql := tablecompliance.ExemptionColumns.InsertExpr(string(model.TableName()))
+		if err := dao.Orm.Txn(txn).Insert(kt.Ctx, sql, model); err != nil {
+			return nil, err
+		}
+
+		args := map[string]interface{}{"exemption_id": id, "rule": model.Rule, "res_id": model.ResID}
+		updateSql := fmt.Sprintf(`UPDATE %s SET exemption_id = :exemption_id WHERE rule = :rule AND res_id = :res_id%s`,
+			table.ComplianceFindingTable, tools.TenantSqlCondition(kt, "tenant_id", args))
+		if _, err := dao.Orm.Txn(txn).Update(kt.Ctx, updateSql, args); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		if errf.IsDuplicated(err) {
+			return "", err
+		}
+		logs.Errorf("create compliance exemption failed, err: %v, rule: %s, res_id: %s, rid: %s", err, model.Rule,
+			model.ResID, kt.Rid)
+		return "", fmt.Errorf("create compliance exemption failed, err: %w", err)
+	}
+
+	return id, nil
+}
+
+// List compliance exemption.
+func (dao ExemptionDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tablecompliance.ExemptionTable],
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tablecompliance.ExemptionColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is dao count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ComplianceExemptionTable, whereExpr)
+
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count compliance exemption failed, err: %v, filter: %s, rid: %s", err, opt.Filter,
+				kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[tablecompliance.ExemptionTable]{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tablecompliance.ExemptionColumns.FieldsNamedExpr(opt.Fields),
+		table.ComplianceExemptionTable, whereExpr, pageExpr)
+
+	details := make([]tablecompliance.ExemptionTable, 0)
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ComplianceExemptionTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
+		logs.Errorf("select compliance exemption failed, err: %v, sql: %s, rid: %s", err, sql, kt.Rid)
+		return nil, err
+	}
+
+	return &types.ListResult[tablecompliance.ExemptionTable]{Count: count, Details: details}, nil
+}
+
+// DeleteByIDs delete compliance exemption by ids, the findings exempted by them are restored at the same time.
+func (dao ExemptionDao) DeleteByIDs(kt *kit.Kit, ids []string) error {
+	if len(ids) == 0 {
+		return errf.New(errf.InvalidParameter, "ids is required")
+	}
+
+	_, err := dao.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		args := map[string]interface{}{"ids": ids}
+		tenantCond := tools.TenantSqlCondition(kt, "tenant_id", args)
+		sql := fmt.Sprintf(`DELETE FROM %s WHERE id IN (:ids)%s`, table.ComplianceExemptionTable, tenantCond)
+		if _, err := dao.Orm.Txn(txn).Delete(kt.Ctx, sql, args); err != nil {
+			return nil, err
+		}
+
+		updateSql := fmt.Sprintf(`UPDATE %s SET exemption_id = '' WHERE exemption_id IN (:ids)%s`,
+			table.ComplianceFindingTable, tenantCond)
+		if _, err := dao.Orm.Txn(txn).Update(kt.Ctx, updateSql, args); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete compliance exemption failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,319 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package compliance security compliance finding and exemption dao.
+package compliance
+
+import (
+	"fmt"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tablecompliance "hcm/pkg/dal/table/compliance"
+	tabletypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// FindingInterface only used for compliance finding.
+type FindingInterface interface {
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tablecompliance.FindingTable], error)
+	ListCandidates(kt *kit.Kit, rule enumor.ComplianceRule) ([]Candidate, error)
+	SyncDetected(kt *kit.Kit, rule enumor.ComplianceRule, detected []tablecompliance.FindingTable) error
+}
+
+var _ FindingInterface = new(FindingDao)
+
+// FindingDao compliance finding dao.
+type FindingDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// List compliance finding.
+func (dao FindingDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tablecompliance.FindingTable],
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tablecompliance.FindingColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is dao count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ComplianceFindingTable, whereExpr)
+
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count compliance finding failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[tablecompliance.FindingTable]{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tablecompliance.FindingColumns.FieldsNamedExpr(opt.Fields),
+		table.ComplianceFindingTable, whereExpr, pageExpr)
+
+	details := make([]tablecompliance.FindingTable, 0)
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ComplianceFindingTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
+		logs.Errorf("select compliance finding failed, err: %v, sql: %s, rid: %s", err, sql, kt.Rid)
+		return nil, err
+	}
+
+	return &types.ListResult[tablecompliance.FindingTable]{Count: count, Details: details}, nil
+}
+
+// Candidate 根据资源及安全组规则表查询出的待检测资源，安全组每条允许任意来源访问的入站规则对应一条记录，
+// 端口是否命中管理端口由调用方判断
+type Candidate struct {
+	ResID     string        `db:"res_id"`
+	Vendor    enumor.Vendor `db:"vendor"`
+	AccountID string        `db:"account_id"`
+	BkBizID   int64         `db:"bk_biz_id"`
+	Region    string        `db:"region"`
+	ResName   string        `db:"res_name"`
+	// RuleID 安全组规则ID，仅安全组有效
+	RuleID string `db:"rule_id"`
+	// Protocol 安全组规则的协议，各云厂商取值不同，如 ALL、-1、*、空字符串均表示全部协议
+	Protocol string `db:"protocol"`
+	// Port 安全组规则的端口，如 22、20-30、22,3389、ALL
+	Port string `db:"port"`
+	// PortRanges 安全组规则的端口列表，仅微软云有效
+	PortRanges tabletypes.StringArray `db:"port_ranges"`
+}
+
+// sgOpenIngressSqls 各云厂商安全组中允许任意来源访问的入站规则
+var sgOpenIngressSqls = []string{
+	fmt.Sprintf(`SELECT sg.id AS res_id, sg.vendor, sg.account_id, sg.bk_biz_id, sg.region, sg.name AS res_name,
+		r.id AS rule_id, IFNULL(r.protocol, '') AS protocol, IFNULL(r.port, '') AS port, NULL AS port_ranges
+		FROM %s r JOIN %s sg ON sg.id = r.security_group_id WHERE r.type = :ingress AND UPPER(r.action) = 'ACCEPT'
+		AND (r.ipv4_cidr = '0.0.0.0/0' OR r.ipv6_cidr = '::/0')`, table.TCloudSecurityGroupRuleTable,
+		table.SecurityGroupTable),
+	fmt.Sprintf(`SELECT sg.id AS res_id, sg.vendor, sg.account_id, sg.bk_biz_id, sg.region, sg.name AS res_name,
+		r.id AS rule_id, IFNULL(r.protocol, '') AS protocol, CONCAT(r.from_port, '-', r.to_port) AS port,
+		NULL AS port_ranges FROM %s r JOIN %s sg ON sg.id = r.security_group_id WHERE r.type = :ingress
+		AND (r.ipv4_cidr = '0.0.0.0/0' OR r.ipv6_cidr = '::/0')`, table.AwsSecurityGroupRuleTable,
+		table.SecurityGroupTable),
+	fmt.Sprintf(`SELECT sg.id AS res_id, sg.vendor, sg.account_id, sg.bk_biz_id, sg.region, sg.name AS res_name,
+		r.id AS rule_id, r.protocol, r.port, NULL AS port_ranges FROM %s r JOIN %s sg ON sg.id = r.security_group_id
+		WHERE r.type = :ingress AND LOWER(r.action) = 'allow' AND (r.remote_ip_prefix IN ('0.0.0.0/0', '::/0')
+		OR (r.remote_ip_prefix = '' AND r.cloud_remote_group_id = '' AND r.cloud_remote_address_group_id = ''))`,
+		table.HuaWeiSecurityGroupRuleTable, table.SecurityGroupTable),
+	fmt.Sprintf(`SELECT sg.id AS res_id, sg.vendor, sg.account_id, sg.bk_biz_id, sg.region, sg.name AS res_name,
+		r.id AS rule_id, r.protocol, r.destination_port_range AS port, r.destination_port_ranges AS port_ranges
+		FROM %s r JOIN %s sg ON sg.id = r.security_group_id WHERE r.type = :ingress AND LOWER(r.access) = 'allow'
+		AND (r.source_address_prefix IN ('*', '0.0.0.0/0', '::/0', 'Internet', 'Any')
+		OR JSON_OVERLAPS(IFNULL(r.source_address_prefixes, JSON_ARRAY()),
+		JSON_ARRAY('*', '0.0.0.0/0', '::/0', 'Internet', 'Any')))`, table.AzureSecurityGroupRuleTable,
+		table.SecurityGroupTable),
+}
+
+// ListCandidates list compliance finding candidates by rule, resources in recycle bin are excluded.
+func (dao FindingDao) ListCandidates(kt *kit.Kit, rule enumor.ComplianceRule) ([]Candidate, error) {
+	sqls := make([]string, 0)
+	args := map[string]interface{}{"ingress": enumor.Ingress, "recycle_status": enumor.RecycleStatus}
+	tenantField := "tenant_id"
+	switch rule {
+	case enumor.ComplianceSGOpenAdminPort:
+		sqls = sgOpenIngressSqls
+		tenantField = "sg.tenant_id"
+
+	case enumor.ComplianceUnencryptedDisk:
+		// 云上未返回加密信息的硬盘无法判断是否加密，不作为不合规资源
+		sqls = append(sqls, fmt.Sprintf(`SELECT id AS res_id, vendor, account_id, bk_biz_id, region,
+			IFNULL(name, '') AS res_name, '' AS rule_id, '' AS protocol, '' AS port, NULL AS port_ranges FROM %s
+			WHERE recycle_status != :recycle_status AND JSON_EXTRACT(extension, '$.encrypted') = CAST('false' AS JSON)`,
+			table.DiskTable))
+
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "unsupported compliance rule: %s", rule)
+	}
+
+	candidates := make([]Candidate, 0)
+	for _, sql := range sqls {
+		sql += tools.TenantSqlCondition(kt, tenantField, args)
+		list := make([]Candidate, 0)
+		if err := dao.Orm.Do().Select(kt.Ctx, &list, sql, args); err != nil {
+			logs.Errorf("list compliance candidates failed, err: %v, rule: %s, sql: %s, rid: %s", err, rule, sql,
+				kt.Rid)
+			return nil, err
+		}
+		candidates = append(candidates, list...)
+	}
+
+	return candidates, nil
+}
+
+// SyncDetected sync the detected findings of the rule, the findings are marked as exempted by the unexpired
+// exemptions, and findings no longer detected are deleted.
+func (dao FindingDao) SyncDetected(kt *kit.Kit, rule enumor.ComplianceRule,
+	detected []tablecompliance.FindingTable) error {
+
+	if err := rule.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	args := map[string]interface{}{"rule": rule}
+	tenantCond := tools.TenantSqlCondition(kt, "tenant_id", args)
+	existSql := fmt.Sprintf(`SELECT id, res_id FROM %s WHERE rule = :rule%s`, table.ComplianceFindingTable,
+		tenantCond)
+	exists := make([]tablecompliance.FindingTable, 0)
+	if err := dao.Orm.Do().Select(kt.Ctx, &exists, existSql, args); err != nil {
+		logs.Errorf("list exist compliance finding failed, err: %v, rule: %s, rid: %s", err, rule, kt.Rid)
+		return err
+	}
+	existMap := make(map[string]string, len(exists))
+	for _, one := range exists {
+		existMap[one.ResID] = one.ID
+	}
+
+	exemptionSql := fmt.Sprintf(`SELECT id, res_id FROM %s WHERE rule = :rule AND (expired_at IS NULL OR
+		expired_at > NOW())%s`, table.ComplianceExemptionTable, tenantCond)
+	exemptions := make([]tablecompliance.ExemptionTable, 0)
+	if err := dao.Orm.Do().Select(kt.Ctx, &exemptions, exemptionSql, args); err != nil {
+		logs.Errorf("list compliance exemption failed, err: %v, rule: %s, rid: %s", err, rule, kt.Rid)
+		return err
+	}
+	exemptionMap := make(map[string]string, len(exemptions))
+	for _, one := range exemptions {
+		exemptionMap[one.ResID] = one.ID
+	}
+
+	// 时间精度为秒，与数据库保持一致，用于删除本次未检测到的记录
+	detectedAt := time.Now().Truncate(time.Second)
+	toCreate := make([]tablecompliance.FindingTable, 0)
+	toUpdate := make([]tablecompliance.FindingTable, 0)
+	for _, one := range detected {
+		one.Rule = rule
+		one.ExemptionID = exemptionMap[one.ResID]
+		one.DetectedAt = detectedAt
+		one.Reviser = kt.User
+		if id, ok := existMap[one.ResID]; ok {
+			one.ID = id
+			toUpdate = append(toUpdate, one)
+			continue
+		}
+		one.Creator = kt.User
+		toCreate = append(toCreate, one)
+	}
+
+	_, err := dao.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := dao.batchCreateWithTx(kt, txn, toCreate); err != nil {
+			return nil, err
+		}
+
+		updateSql := fmt.Sprintf(`UPDATE %s SET severity = :severity, vendor = :vendor, account_id = :account_id,
+			bk_biz_id = :bk_biz_id, region = :region, res_name = :res_name, detail = :detail,
+			exemption_id = :exemption_id, detected_at = :detected_at, reviser = :reviser WHERE id = :id`,
+			table.ComplianceFindingTable)
+		for _, one := range toUpdate {
+			args := map[string]interface{}{
+				"id":           one.ID,
+				"severity":     one.Severity,
+				"vendor":       one.Vendor,
+				"account_id":   one.AccountID,
+				"bk_biz_id":    one.BkBizID,
+				"region":       one.Region,
+				"res_name":     one.ResName,
+				"detail":       one.Detail,
+				"exemption_id": one.ExemptionID,
+				"detected_at":  one.DetectedAt,
+				"reviser":      one.Reviser,
+			}
+			sql := updateSql + tools.TenantSqlCondition(kt, "tenant_id", args)
+			if _, err := dao.Orm.Txn(txn).Update(kt.Ctx, sql, args); err != nil {
+				logs.Errorf("update compliance finding failed, err: %v, id: %s, rid: %s", err, one.ID, kt.Rid)
+				return nil, err
+			}
+		}
+
+		args := map[string]interface{}{"rule": rule, "detected_at": detectedAt}
+		deleteSql := fmt.Sprintf(`DELETE FROM %s WHERE rule = :rule AND detected_at < :detected_at%s`,
+			table.ComplianceFindingTable, tools.TenantSqlCondition(kt, "tenant_id", args))
+		if _, err := dao.Orm.Txn(txn).Delete(kt.Ctx, deleteSql, args); err != nil {
+			logs.Errorf("delete resolved compliance finding failed, err: %v, rule: %s, rid: %s", err, rule, kt.Rid)
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("sync detected compliance finding failed, err: %v, rule: %s, rid: %s", err, rule, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+func (dao FindingDao) batchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tablecompliance.FindingTable) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	ids, err := dao.IDGen.Batch(kt, table.ComplianceFindingTable, len(models))
+	if err != nil {
+		return err
+	}
+	for idx := range models {
+		models[idx].ID = ids[idx]
+		models[idx].TenantID = kt.GetTenantID()
+		if err = models[idx].InsertValidate(); err != nil {
+			return err
+		}
+	}
+
+	sql := tablecompliance.FindingColumns.InsertExpr(string(table.ComplianceFindingTable))
+	for _, batch := range slice.Split(models, constant.BatchOperationMaxLimit) {
+		if err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, batch); err != nil {
+			logs.Errorf("insert %s failed, err: %v, rid: %s", table.ComplianceFindingTable, err, kt.Rid)
+			return fmt.Errorf("insert %s failed, err: %w", table.ComplianceFindingTable, err)
+		}
+	}
+
+	return nil
+}
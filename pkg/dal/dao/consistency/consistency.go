@@ -0,0 +1,254 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package daoconsistency data consistency check dao.
+package daoconsistency
+
+import (
+	"fmt"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// cloudIDTables 需要检查 (vendor, cloud_id) 唯一性的资源表
+var cloudIDTables = []table.Name{
+	table.VpcTable,
+	table.SubnetTable,
+	table.SecurityGroupTable,
+	table.CvmTable,
+	table.DiskTable,
+	table.EipTable,
+	table.ImageTable,
+	table.RouteTableTable,
+	table.NetworkInterfaceTable,
+}
+
+// accountResTables 需要检查资源与所属账号的 vendor 是否一致的资源表
+var accountResTables = []table.Name{
+	table.VpcTable,
+	table.SubnetTable,
+	table.SecurityGroupTable,
+	table.CvmTable,
+	table.DiskTable,
+	table.EipTable,
+	table.RouteTableTable,
+}
+
+// globalTables 没有租户字段的表，其记录无法归属到租户，仅在默认租户下检查
+var globalTables = map[table.Name]struct{}{
+	table.ImageTable:                  {},
+	table.NetworkInterfaceCvmRelTable: {},
+}
+
+// tenantCondition 返回表的租户过滤条件，skip 为 true 表示当前租户下无需检查该表
+func tenantCondition(kt *kit.Kit, name table.Name, field string, args map[string]interface{}) (string, bool) {
+	if _, exists := globalTables[name]; exists {
+		return "", kt.GetTenantID() != constant.DefaultTenantID
+	}
+
+	return tools.TenantSqlCondition(kt, field, args), false
+}
+
+// Relation 声明关联表中的字段对父资源表的引用关系
+type Relation struct {
+	Table       table.Name `json:"table"`
+	Column      string     `json:"column"`
+	ParentTable table.Name `json:"parent_table"`
+}
+
+// relations 需要检查是否存在孤立记录的关联关系
+var relations = []Relation{
+	{Table: table.SecurityGroupCvmTable, Column: "security_group_id", ParentTable: table.SecurityGroupTable},
+	{Table: table.SecurityGroupCvmTable, Column: "cvm_id", ParentTable: table.CvmTable},
+	{Table: table.SecurityGroupCommonRelTable, Column: "security_group_id", ParentTable: table.SecurityGroupTable},
+	{Table: table.DiskCvmRelTableName, Column: "disk_id", ParentTable: table.DiskTable},
+	{Table: table.DiskCvmRelTableName, Column: "cvm_id", ParentTable: table.CvmTable},
+	{Table: table.EipCvmRelTableName, Column: "eip_id", ParentTable: table.EipTable},
+	{Table: table.EipCvmRelTableName, Column: "cvm_id", ParentTable: table.CvmTable},
+	{Table: table.NetworkInterfaceCvmRelTable, Column: "network_interface_id", ParentTable: table.NetworkInterfaceTable},
+	{Table: table.NetworkInterfaceCvmRelTable, Column: "cvm_id", ParentTable: table.CvmTable},
+	{Table: table.TCloudSecurityGroupRuleTable, Column: "security_group_id", ParentTable: table.SecurityGroupTable},
+	{Table: table.AwsSecurityGroupRuleTable, Column: "security_group_id", ParentTable: table.SecurityGroupTable},
+	{Table: table.HuaWeiSecurityGroupRuleTable, Column: "security_group_id", ParentTable: table.SecurityGroupTable},
+	{Table: table.AzureSecurityGroupRuleTable, Column: "security_group_id", ParentTable: table.SecurityGroupTable},
+}
+
+// DuplicateCloudID 重复的 (vendor, cloud_id) 记录
+type DuplicateCloudID struct {
+	Table   table.Name `db:"-" json:"table"`
+	Vendor  string     `db:"vendor" json:"vendor"`
+	CloudID string     `db:"cloud_id" json:"cloud_id"`
+	Count   uint64     `db:"count" json:"count"`
+	// IDs 重复记录的ID，按创建时间排序，以逗号分隔
+	IDs string `db:"ids" json:"ids"`
+}
+
+// OrphanRelation 引用的父资源已不存在的关联记录
+type OrphanRelation struct {
+	Relation `db:"-" json:",inline"`
+	ID       string `db:"id" json:"id"`
+	RefID    string `db:"ref_id" json:"ref_id"`
+}
+
+// VendorMismatch 资源的 vendor 与所属账号的 vendor 不一致的记录
+type VendorMismatch struct {
+	Table         table.Name `db:"-" json:"table"`
+	ID            string     `db:"id" json:"id"`
+	Vendor        string     `db:"vendor" json:"vendor"`
+	AccountID     string     `db:"account_id" json:"account_id"`
+	AccountVendor string     `db:"account_vendor" json:"account_vendor"`
+}
+
+// Interface only used for data consistency check.
+type Interface interface {
+	// ListDuplicateCloudIDs 查询各资源表中重复的 (vendor, cloud_id) 记录，每张表最多返回 limit 条
+	ListDuplicateCloudIDs(kt *kit.Kit, limit uint) ([]DuplicateCloudID, error)
+	// ListOrphanRelations 查询各关联关系中的孤立记录，每个关联关系最多返回 limit 条
+	ListOrphanRelations(kt *kit.Kit, limit uint) ([]OrphanRelation, error)
+	// DeleteOrphanRelations 删除各关联关系中的孤立记录，返回删除的记录数
+	DeleteOrphanRelations(kt *kit.Kit) (int64, error)
+	// ListVendorMismatches 查询各资源表中 vendor 与所属账号不一致的记录，每张表最多返回 limit 条
+	ListVendorMismatches(kt *kit.Kit, limit uint) ([]VendorMismatch, error)
+}
+
+var _ Interface = new(Dao)
+
+// Dao data consistency check dao.
+type Dao struct {
+	Orm orm.Interface
+}
+
+// ListDuplicateCloudIDs list duplicate (vendor, cloud_id) rows.
+func (d Dao) ListDuplicateCloudIDs(kt *kit.Kit, limit uint) ([]DuplicateCloudID, error) {
+	result := make([]DuplicateCloudID, 0)
+	for _, name := range cloudIDTables {
+		args := make(map[string]interface{})
+		tenantCond, skip := tenantCondition(kt, name, "tenant_id", args)
+		if skip {
+			continue
+		}
+
+		sql := fmt.Sprintf(`SELECT vendor, cloud_id, COUNT(*) AS count, GROUP_CONCAT(id ORDER BY created_at) AS ids `+
+			`FROM %s WHERE 1 = 1%s GROUP BY vendor, cloud_id HAVING COUNT(*) > 1 LIMIT %d`, name, tenantCond, limit)
+
+		details := make([]DuplicateCloudID, 0)
+		if err := d.Orm.Do().Select(kt.Ctx, &details, sql, args); err != nil {
+			logs.Errorf("list %s duplicate cloud id failed, err: %v, rid: %s", name, err, kt.Rid)
+			return nil, err
+		}
+
+		for idx := range details {
+			details[idx].Table = name
+		}
+		result = append(result, details...)
+	}
+
+	return result, nil
+}
+
+// ListOrphanRelations list orphan relation rows.
+func (d Dao) ListOrphanRelations(kt *kit.Kit, limit uint) ([]OrphanRelation, error) {
+	result := make([]OrphanRelation, 0)
+	for _, rel := range relations {
+		args := make(map[string]interface{})
+		tenantCond, skip := tenantCondition(kt, rel.Table, "r.tenant_id", args)
+		if skip {
+			continue
+		}
+
+		sql := fmt.Sprintf(`SELECT r.id AS id, r.%s AS ref_id FROM %s r LEFT JOIN %s p ON r.%s = p.id `+
+			`WHERE p.id IS NULL%s LIMIT %d`, rel.Column, rel.Table, rel.ParentTable, rel.Column, tenantCond, limit)
+
+		details := make([]OrphanRelation, 0)
+		if err := d.Orm.Do().Select(kt.Ctx, &details, sql, args); err != nil {
+			logs.Errorf("list %s orphan relation by %s failed, err: %v, rid: %s", rel.Table, rel.Column, err, kt.Rid)
+			return nil, err
+		}
+
+		for idx := range details {
+			details[idx].Relation = rel
+		}
+		result = append(result, details...)
+	}
+
+	return result, nil
+}
+
+// DeleteOrphanRelations delete orphan relation rows.
+func (d Dao) DeleteOrphanRelations(kt *kit.Kit) (int64, error) {
+	var total int64
+	for _, rel := range relations {
+		args := make(map[string]interface{})
+		tenantCond, skip := tenantCondition(kt, rel.Table, "r.tenant_id", args)
+		if skip {
+			continue
+		}
+
+		sql := fmt.Sprintf(`DELETE r FROM %s r LEFT JOIN %s p ON r.%s = p.id WHERE p.id IS NULL%s`, rel.Table,
+			rel.ParentTable, rel.Column, tenantCond)
+
+		affected, err := d.Orm.Do().Delete(kt.Ctx, sql, args)
+		if err != nil {
+			logs.Errorf("delete %s orphan relation by %s failed, err: %v, rid: %s", rel.Table, rel.Column, err,
+				kt.Rid)
+			return total, err
+		}
+
+		if affected > 0 {
+			logs.Infof("deleted %d orphan relations of %s by %s, rid: %s", affected, rel.Table, rel.Column, kt.Rid)
+		}
+		total += affected
+	}
+
+	return total, nil
+}
+
+// ListVendorMismatches list rows whose vendor mismatch with its account.
+func (d Dao) ListVendorMismatches(kt *kit.Kit, limit uint) ([]VendorMismatch, error) {
+	result := make([]VendorMismatch, 0)
+	for _, name := range accountResTables {
+		args := make(map[string]interface{})
+		tenantCond, skip := tenantCondition(kt, name, "t.tenant_id", args)
+		if skip {
+			continue
+		}
+
+		sql := fmt.Sprintf(`SELECT t.id AS id, t.vendor AS vendor, t.account_id AS account_id, `+
+			`a.vendor AS account_vendor FROM %s t JOIN %s a ON t.account_id = a.id WHERE t.vendor != a.vendor%s `+
+			`LIMIT %d`, name, table.AccountTable, tenantCond, limit)
+
+		details := make([]VendorMismatch, 0)
+		if err := d.Orm.Do().Select(kt.Ctx, &details, sql, args); err != nil {
+			logs.Errorf("list %s vendor mismatch failed, err: %v, rid: %s", name, err, kt.Rid)
+			return nil, err
+		}
+
+		for idx := range details {
+			details[idx].Table = name
+		}
+		result = append(result, details...)
+	}
+
+	return result, nil
+}
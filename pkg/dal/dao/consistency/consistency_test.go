@@ -0,0 +1,114 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package daoconsistency
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+)
+
+// recordOrm records the statements and their args, and returns no rows.
+type recordOrm struct {
+	orm.Interface
+	orm.DoOrm
+	exprs []string
+	args  []map[string]interface{}
+}
+
+// Do ...
+func (r *recordOrm) Do() orm.DoOrm {
+	return r
+}
+
+// Select ...
+func (r *recordOrm) Select(_ context.Context, _ interface{}, expr string, arg map[string]interface{}) error {
+	r.exprs = append(r.exprs, expr)
+	r.args = append(r.args, arg)
+	return nil
+}
+
+// Delete ...
+func (r *recordOrm) Delete(_ context.Context, expr string, arg map[string]interface{}) (int64, error) {
+	r.exprs = append(r.exprs, expr)
+	r.args = append(r.args, arg)
+	return 0, nil
+}
+
+func TestCheckScopedByTenant(t *testing.T) {
+	tools.EnableTenant(true)
+	defer tools.EnableTenant(false)
+
+	kt := kit.New()
+	kt.TenantID = "t1"
+
+	checks := map[string]func(d Dao) error{
+		"duplicate": func(d Dao) error {
+			_, err := d.ListDuplicateCloudIDs(kt, 10)
+			return err
+		},
+		"orphan": func(d Dao) error {
+			_, err := d.ListOrphanRelations(kt, 10)
+			return err
+		},
+		"delete orphan": func(d Dao) error {
+			_, err := d.DeleteOrphanRelations(kt)
+			return err
+		},
+		"vendor mismatch": func(d Dao) error {
+			_, err := d.ListVendorMismatches(kt, 10)
+			return err
+		},
+	}
+
+	for name, check := range checks {
+		record := new(recordOrm)
+		if err := check(Dao{Orm: record}); err != nil {
+			t.Fatalf("%s check failed, err: %v", name, err)
+		}
+
+		if len(record.exprs) == 0 {
+			t.Fatalf("%s check should query the tables", name)
+		}
+		for idx, expr := range record.exprs {
+			if strings.Contains(expr, " image ") || strings.Contains(expr, " network_interface_cvm_rel ") {
+				t.Errorf("%s check should skip the global table for non default tenant, sql: %s", name, expr)
+			}
+			if !strings.Contains(expr, "tenant_id = :tenant_id") || record.args[idx]["tenant_id"] != "t1" {
+				t.Errorf("%s check should be scoped by tenant, sql: %s, args: %v", name, expr, record.args[idx])
+			}
+		}
+	}
+
+	// global tables are checked in the default tenant.
+	kt.TenantID = constant.DefaultTenantID
+	record := new(recordOrm)
+	if _, err := (Dao{Orm: record}).ListDuplicateCloudIDs(kt, 10); err != nil {
+		t.Fatalf("list duplicate cloud ids failed, err: %v", err)
+	}
+	if len(record.exprs) != len(cloudIDTables) {
+		t.Errorf("default tenant should check all the %d tables, got: %d", len(cloudIDTables), len(record.exprs))
+	}
+}
@@ -21,17 +21,23 @@
 package dao
 
 import (
+	"context"
 	"fmt"
+	"io/fs"
 	"strings"
 	"time"
 
 	"hcm/pkg/cc"
+	"hcm/pkg/cryptography"
+	daoaccountgroup "hcm/pkg/dal/dao/account-group"
 	accountset "hcm/pkg/dal/dao/account-set"
 	"hcm/pkg/dal/dao/application"
 	daoasync "hcm/pkg/dal/dao/async"
 	"hcm/pkg/dal/dao/audit"
 	"hcm/pkg/dal/dao/auth"
+	daobackup "hcm/pkg/dal/dao/backup"
 	"hcm/pkg/dal/dao/bill"
+	"hcm/pkg/dal/dao/cascade"
 	"hcm/pkg/dal/dao/cloud"
 	daoselection "hcm/pkg/dal/dao/cloud-selection"
 	argstpl "hcm/pkg/dal/dao/cloud/argument-template"
@@ -56,17 +62,38 @@ import (
 	daosubaccount "hcm/pkg/dal/dao/cloud/sub-account"
 	daosync "hcm/pkg/dal/dao/cloud/sync"
 	"hcm/pkg/dal/dao/cloud/zone"
+	daocompliance "hcm/pkg/dal/dao/compliance"
+	daoconsistency "hcm/pkg/dal/dao/consistency"
+	daodistinct "hcm/pkg/dal/dao/distinct-value"
+	daoevent "hcm/pkg/dal/dao/event"
 	globalconfig "hcm/pkg/dal/dao/global-config"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
+	daoidle "hcm/pkg/dal/dao/idle-resource"
+	daoindex "hcm/pkg/dal/dao/index"
+	daolz "hcm/pkg/dal/dao/landing-zone"
+	daomw "hcm/pkg/dal/dao/maintenance-window"
+	daonaming "hcm/pkg/dal/dao/naming"
+	daonotification "hcm/pkg/dal/dao/notification"
 	"hcm/pkg/dal/dao/orm"
+	daoquota "hcm/pkg/dal/dao/quota"
 	recyclerecord "hcm/pkg/dal/dao/recycle-record"
+	daoreshistory "hcm/pkg/dal/dao/resource-history"
+	daorelation "hcm/pkg/dal/dao/resource-relation"
+	daorestag "hcm/pkg/dal/dao/resource-tag"
+	daosavedfilter "hcm/pkg/dal/dao/saved-filter"
+	daotagpolicy "hcm/pkg/dal/dao/tag-policy"
 	"hcm/pkg/dal/dao/task"
+	daotenant "hcm/pkg/dal/dao/tenant"
 	daouser "hcm/pkg/dal/dao/user"
+	daowebhook "hcm/pkg/dal/dao/webhook"
+	"hcm/pkg/dal/migration"
+	"hcm/pkg/dal/table"
 	"hcm/pkg/kit"
 	"hcm/pkg/metrics"
 
 	_ "github.com/go-sql-driver/mysql" // import mysql drive, used to create conn.
 	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 )
 
 // Set defines all the DAO to be operated.
@@ -90,6 +117,7 @@ type Set interface {
 	AzureRG() resourcegroup.AzureRG
 	AzureRegion() region.AzureRegion
 	Zone() zone.Zone
+	ZoneCapability() zone.ZoneCapability
 	AccountSyncDetail() daosync.AccountSyncDetail
 	TCloudRegion() region.TCloudRegion
 	AwsRegion() region.AwsRegion
@@ -118,11 +146,37 @@ type Set interface {
 	AccountBillSummaryRoot() bill.AccountBillSummaryRoot
 	RootAccountBillConfig() bill.RootAccountBillConfig
 	AccountBillExchangeRate() bill.AccountBillExchangeRate
+	AccountBillBudget() bill.AccountBillBudget
+	AccountBillReconciliation() bill.AccountBillReconciliation
 	AccountBillSyncRecord() bill.AccountBillSyncRecord
 	AsyncFlow() daoasync.AsyncFlow
 	AsyncFlowTask() daoasync.AsyncFlowTask
+	AsyncJob() daoasync.AsyncJob
 	UserCollection() daouser.Interface
+	UserRecentView() daouser.RecentViewInterface
 	CloudSelectionScheme() daoselection.SchemeInterface
+	CvmTemplate() cvm.TemplateInterface
+	CvmSchedule() cvm.ScheduleInterface
+	CmdbHostRel() cvm.CmdbHostRelInterface
+	MaintenanceWindow() daomw.Interface
+	LandingZoneDeployment() daolz.DeploymentInterface
+	EventConsumerOffset() daoevent.ConsumerOffsetInterface
+	WebhookSubscription() daowebhook.SubscriptionInterface
+	WebhookDelivery() daowebhook.DeliveryInterface
+	BizQuota() daoquota.BizQuotaInterface
+	BizResWhitelist() daoquota.BizResWhitelistInterface
+	IdleResource() daoidle.IdleResourceInterface
+	NamingRule() daonaming.NamingRuleInterface
+	NamingViolation() daonaming.NamingViolationInterface
+	TagPolicy() daotagpolicy.TagPolicyInterface
+	TagViolation() daotagpolicy.TagViolationInterface
+	AccountGroup() daoaccountgroup.AccountGroupInterface
+	AccountGroupRel() daoaccountgroup.AccountGroupRelInterface
+	NotificationTemplate() daonotification.TemplateInterface
+	NotificationSubscription() daonotification.SubscriptionInterface
+	SavedFilter() daosavedfilter.Interface
+	ComplianceFinding() daocompliance.FindingInterface
+	ComplianceExemption() daocompliance.ExemptionInterface
 	CloudSelectionBizType() daoselection.BizTypeInterface
 	CloudSelectionIdc() daoselection.IdcInterface
 	ArgsTpl() argstpl.Interface
@@ -141,19 +195,52 @@ type Set interface {
 	TaskDetail() task.Detail
 	TaskManagement() task.Management
 	GlobalConfig() globalconfig.Interface
+	ResourceHistory() daoreshistory.Interface
+	ResourceTag() daorestag.Interface
+	ResourceRelation() daorelation.Interface
+	DistinctValue() daodistinct.Interface
+	Consistency() daoconsistency.Interface
+	Index() daoindex.Interface
+	Backup() daobackup.Interface
+	Tenant() daotenant.Interface
 
 	Txn() *Txn
+	// Ping checks the connectivity of the resource database.
+	Ping(ctx context.Context) error
+}
+
+// Option defines the option of the DAO set.
+type Option func(s *set)
+
+// WithCipher set the cipher used by the account dao to encrypt and decrypt the account secrets.
+func WithCipher(cipher cryptography.Crypto) Option {
+	return func(s *set) {
+		s.cipher = cipher
+	}
 }
 
 // NewDaoSet create the DAO set instance.
-func NewDaoSet(opt cc.DataBase) (Set, error) {
-	db, err := connect(opt.Resource)
+func NewDaoSet(opt cc.DataBase, opts ...Option) (Set, error) {
+	db, err := connect(opt.Resource, "resource")
 	if err != nil {
 		return nil, fmt.Errorf("init sharding failed, err: %v", err)
 	}
 
-	ormInst := orm.InitOrm(db, orm.MetricsRegisterer(metrics.Register()),
-		orm.IngressLimiter(opt.Limiter.QPS, opt.Limiter.Burst), orm.SlowRequestMS(opt.MaxSlowLogLatencyMS))
+	replicas := make([]*sqlx.DB, 0, len(opt.Replicas))
+	for idx, replicaOpt := range opt.Replicas {
+		replica, err := connect(replicaOpt, fmt.Sprintf("replica-%d", idx))
+		if err != nil {
+			return nil, fmt.Errorf("init replica failed, err: %v", err)
+		}
+		replicas = append(replicas, replica)
+	}
+
+	ormOpts := []orm.Option{orm.MetricsRegisterer(metrics.Register()),
+		orm.IngressLimiter(opt.Limiter.QPS, opt.Limiter.Burst), orm.SlowRequestMS(opt.MaxSlowLogLatencyMS),
+		orm.SlowLogWithArgs(opt.SlowLogWithArgs), orm.ReadReplicas(replicas...),
+		orm.WriteOpTimeoutSec(opt.WriteOpTimeoutSec)}
+
+	ormInst := orm.InitOrm(db, ormOpts...)
 
 	idGen := idgenerator.New(db, idgenerator.DefaultMaxRetryCount)
 
@@ -163,12 +250,47 @@ func NewDaoSet(opt cc.DataBase) (Set, error) {
 		db:    db,
 		audit: audit.NewAudit(ormInst),
 	}
+	for _, one := range opts {
+		one(s)
+	}
 
 	return s, nil
 }
 
-// connect to mysql
-func connect(opt cc.ResourceDB) (*sqlx.DB, error) {
+// shardColumns is the columns added to the base tables after their shard tables may have been created,
+// e.g. the account bill item tables sharded by vendor and month.
+var shardColumns = []migration.ShardColumn{
+	{
+		Table:      string(table.AccountBillItemTable),
+		Column:     "tenant_id",
+		Definition: "varchar(64) not null default 'default' comment '租户ID'",
+		Index:      "idx_tenant_id",
+	},
+}
+
+// Migrate apply the pending versioned sql files to the resource database.
+func Migrate(opt cc.DataBase, fsys fs.FS) error {
+	migrations, err := migration.Load(fsys)
+	if err != nil {
+		return err
+	}
+
+	// each sql file contains multiple statements, so multiStatements need to be enabled.
+	db, err := sqlx.Connect("mysql", uri(opt.Resource)+"&multiStatements=true")
+	if err != nil {
+		return fmt.Errorf("connect to mysql for migration failed, err: %v", err)
+	}
+	defer db.Close()
+
+	migrateOpt := migration.Option{
+		LockTimeoutSec: opt.Migration.LockTimeoutSec,
+		ShardColumns:   shardColumns,
+	}
+	return migration.Run(context.Background(), db, migrations, migrateOpt)
+}
+
+// connect to mysql, and register the connection pool stats collector with the given name as db_name label.
+func connect(opt cc.ResourceDB, name string) (*sqlx.DB, error) {
 	db, err := sqlx.Connect("mysql", uri(opt))
 	if err != nil {
 		return nil, fmt.Errorf("connect to mysql failed, err: %v", err)
@@ -178,6 +300,8 @@ func connect(opt cc.ResourceDB) (*sqlx.DB, error) {
 	db.SetMaxIdleConns(int(opt.MaxIdleConn))
 	db.SetConnMaxLifetime(time.Duration(opt.MaxIdleTimeoutMin) * time.Minute)
 
+	metrics.Register().MustRegister(collectors.NewDBStatsCollector(db.DB, name))
+
 	return db, nil
 }
 
@@ -197,10 +321,11 @@ func uri(opt cc.ResourceDB) string {
 }
 
 type set struct {
-	idGen idgenerator.IDGenInterface
-	orm   orm.Interface
-	db    *sqlx.DB
-	audit audit.Interface
+	idGen  idgenerator.IDGenInterface
+	orm    orm.Interface
+	db     *sqlx.DB
+	audit  audit.Interface
+	cipher cryptography.Crypto
 }
 
 // EipCvmRel return EipCvmRel dao.
@@ -265,6 +390,14 @@ func (s *set) Zone() zone.Zone {
 	}
 }
 
+// ZoneCapability return zone capability dao.
+func (s *set) ZoneCapability() zone.ZoneCapability {
+	return &zone.ZoneCapabilityDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
 // AccountSyncDetail return AccountSyncDetail dao.
 func (s *set) AccountSyncDetail() daosync.AccountSyncDetail {
 	return &daosync.AccountSyncDetailDao{
@@ -300,9 +433,10 @@ func (s *set) HuaWeiRegion() region.HuaWeiRegion {
 // Account return account dao.
 func (s *set) Account() cloud.Account {
 	return &cloud.AccountDao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:    s.orm,
+		IDGen:  s.idGen,
+		Audit:  s.audit,
+		Cipher: s.cipher,
 	}
 }
 
@@ -349,9 +483,19 @@ func (s *set) Cloud() cloud.Cloud {
 // SecurityGroup return security group dao.
 func (s *set) SecurityGroup() securitygroup.SecurityGroup {
 	return &securitygroup.SecurityGroupDao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:     s.orm,
+		IDGen:   s.idGen,
+		Audit:   s.audit,
+		History: s.ResourceHistory(),
+		Tag:     s.ResourceTag(),
+		Children: []cascade.Child{
+			{Table: table.TCloudSecurityGroupRuleTable, ParentKey: "security_group_id", Deleter: s.TCloudSGRule()},
+			{Table: table.AwsSecurityGroupRuleTable, ParentKey: "security_group_id", Deleter: s.AwsSGRule()},
+			{Table: table.HuaWeiSecurityGroupRuleTable, ParentKey: "security_group_id", Deleter: s.HuaWeiSGRule()},
+			{Table: table.AzureSecurityGroupRuleTable, ParentKey: "security_group_id", Deleter: s.AzureSGRule()},
+			{Table: table.SecurityGroupCvmTable, ParentKey: "security_group_id", Deleter: s.SGCvmRel()},
+			{Table: table.SecurityGroupCommonRelTable, ParentKey: "security_group_id", Deleter: s.SGCommonRel()},
+		},
 	}
 }
 
@@ -365,9 +509,10 @@ func (s *set) SGCvmRel() sgcvmrel.Interface {
 // TCloudSGRule return tcloud security group rule dao.
 func (s *set) TCloudSGRule() securitygroup.TCloudSGRule {
 	return &securitygroup.TCloudSGRuleDao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:     s.orm,
+		IDGen:   s.idGen,
+		Audit:   s.audit,
+		History: s.ResourceHistory(),
 	}
 }
 
@@ -383,36 +528,40 @@ func (s *set) GcpFirewallRule() cloud.GcpFirewallRule {
 // AwsSGRule return aws security group rule dao.
 func (s *set) AwsSGRule() securitygroup.AwsSGRule {
 	return &securitygroup.AwsSGRuleDao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:     s.orm,
+		IDGen:   s.idGen,
+		Audit:   s.audit,
+		History: s.ResourceHistory(),
 	}
 }
 
 // HuaWeiSGRule return huawei security group rule dao.
 func (s *set) HuaWeiSGRule() securitygroup.HuaWeiSGRule {
 	return &securitygroup.HuaWeiSGRuleDao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:     s.orm,
+		IDGen:   s.idGen,
+		Audit:   s.audit,
+		History: s.ResourceHistory(),
 	}
 }
 
 // AzureSGRule return azure security group rule dao.
 func (s *set) AzureSGRule() securitygroup.AzureSGRule {
 	return &securitygroup.AzureSGRuleDao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:     s.orm,
+		IDGen:   s.idGen,
+		Audit:   s.audit,
+		History: s.ResourceHistory(),
 	}
 }
 
 // Cvm return cvm dao.
 func (s *set) Cvm() cvm.Interface {
 	return &cvm.Dao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:     s.orm,
+		IDGen:   s.idGen,
+		Audit:   s.audit,
+		History: s.ResourceHistory(),
 	}
 }
 
@@ -493,6 +642,11 @@ func (s *set) Txn() *Txn {
 	}
 }
 
+// Ping checks the connectivity of the resource database.
+func (s *set) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
 // AccountBillConfig returns account bill config dao.
 func (s *set) AccountBillConfig() cloudbill.Interface {
 	return &cloudbill.AccountBillConfigDao{
@@ -583,6 +737,22 @@ func (s *set) AccountBillExchangeRate() bill.AccountBillExchangeRate {
 	}
 }
 
+// AccountBillBudget return AccountBillBudget dao
+func (s *set) AccountBillBudget() bill.AccountBillBudget {
+	return &bill.AccountBillBudgetDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// AccountBillReconciliation return AccountBillReconciliation dao
+func (s *set) AccountBillReconciliation() bill.AccountBillReconciliation {
+	return &bill.AccountBillReconciliationDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
 // AccountBillSyncRecord return bill.AccountBillSyncRecord dao
 func (s *set) AccountBillSyncRecord() bill.AccountBillSyncRecord {
 	return &bill.AccountBillSyncRecordDao{
@@ -599,6 +769,14 @@ func (s *set) UserCollection() daouser.Interface {
 	}
 }
 
+// UserRecentView returns user recent view dao.
+func (s *set) UserRecentView() daouser.RecentViewInterface {
+	return &daouser.RecentViewDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
 // AsyncFlow return AsyncFlow dao.
 func (s *set) AsyncFlow() daoasync.AsyncFlow {
 	return &daoasync.AsyncFlowDao{
@@ -615,6 +793,188 @@ func (s *set) AsyncFlowTask() daoasync.AsyncFlowTask {
 	}
 }
 
+// AsyncJob return AsyncJob dao.
+func (s *set) AsyncJob() daoasync.AsyncJob {
+	return &daoasync.AsyncJobDao{
+		Orm: s.orm,
+	}
+}
+
+// CvmTemplate returns cvm template dao.
+func (s *set) CvmTemplate() cvm.TemplateInterface {
+	return &cvm.TemplateDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// CvmSchedule returns cvm schedule dao.
+func (s *set) CvmSchedule() cvm.ScheduleInterface {
+	return &cvm.ScheduleDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// CmdbHostRel returns cvm and cmdb host relation dao.
+func (s *set) CmdbHostRel() cvm.CmdbHostRelInterface {
+	return &cvm.CmdbHostRelDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// MaintenanceWindow returns maintenance window dao.
+func (s *set) MaintenanceWindow() daomw.Interface {
+	return &daomw.Dao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// LandingZoneDeployment returns landing zone deployment dao.
+func (s *set) LandingZoneDeployment() daolz.DeploymentInterface {
+	return &daolz.DeploymentDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// EventConsumerOffset returns event consumer offset dao.
+func (s *set) EventConsumerOffset() daoevent.ConsumerOffsetInterface {
+	return &daoevent.ConsumerOffsetDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// WebhookSubscription returns webhook subscription dao.
+func (s *set) WebhookSubscription() daowebhook.SubscriptionInterface {
+	return &daowebhook.SubscriptionDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// WebhookDelivery returns webhook delivery dao.
+func (s *set) WebhookDelivery() daowebhook.DeliveryInterface {
+	return &daowebhook.DeliveryDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// BizQuota returns biz quota dao.
+func (s *set) BizQuota() daoquota.BizQuotaInterface {
+	return &daoquota.BizQuotaDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// BizResWhitelist returns biz resource whitelist dao.
+func (s *set) BizResWhitelist() daoquota.BizResWhitelistInterface {
+	return &daoquota.BizResWhitelistDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// IdleResource returns idle resource dao.
+func (s *set) IdleResource() daoidle.IdleResourceInterface {
+	return &daoidle.IdleResourceDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// NamingRule returns naming rule dao.
+func (s *set) NamingRule() daonaming.NamingRuleInterface {
+	return &daonaming.NamingRuleDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// NamingViolation returns naming violation dao.
+func (s *set) NamingViolation() daonaming.NamingViolationInterface {
+	return &daonaming.NamingViolationDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// TagPolicy returns tag policy dao.
+func (s *set) TagPolicy() daotagpolicy.TagPolicyInterface {
+	return &daotagpolicy.TagPolicyDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// TagViolation returns tag violation dao.
+func (s *set) TagViolation() daotagpolicy.TagViolationInterface {
+	return &daotagpolicy.TagViolationDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// AccountGroup returns account group dao.
+func (s *set) AccountGroup() daoaccountgroup.AccountGroupInterface {
+	return &daoaccountgroup.AccountGroupDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// AccountGroupRel returns account group relation dao.
+func (s *set) AccountGroupRel() daoaccountgroup.AccountGroupRelInterface {
+	return &daoaccountgroup.AccountGroupRelDao{
+		Orm: s.orm,
+	}
+}
+
+// NotificationTemplate returns notification template dao.
+func (s *set) NotificationTemplate() daonotification.TemplateInterface {
+	return &daonotification.TemplateDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// NotificationSubscription returns notification subscription dao.
+func (s *set) NotificationSubscription() daonotification.SubscriptionInterface {
+	return &daonotification.SubscriptionDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// SavedFilter returns saved filter dao.
+func (s *set) SavedFilter() daosavedfilter.Interface {
+	return &daosavedfilter.Dao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// ComplianceFinding returns compliance finding dao.
+func (s *set) ComplianceFinding() daocompliance.FindingInterface {
+	return &daocompliance.FindingDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// ComplianceExemption returns compliance exemption dao.
+func (s *set) ComplianceExemption() daocompliance.ExemptionInterface {
+	return &daocompliance.ExemptionDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
 // CloudSelectionScheme returns cloud selection scheme dao.
 func (s *set) CloudSelectionScheme() daoselection.SchemeInterface {
 	return &daoselection.SchemeDao{
@@ -663,6 +1023,7 @@ func (s *set) LoadBalancer() loadbalancer.LoadBalancerInterface {
 		Orm:   s.orm,
 		IDGen: s.idGen,
 		Audit: s.audit,
+		Tag:   s.ResourceTag(),
 	}
 }
 
@@ -771,3 +1132,62 @@ func (s *set) GlobalConfig() globalconfig.Interface {
 		IDGen: s.idGen,
 	}
 }
+
+// ResourceHistory return resource history dao.
+func (s *set) ResourceHistory() daoreshistory.Interface {
+	return &daoreshistory.Dao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// ResourceTag return resource tag dao.
+func (s *set) ResourceTag() daorestag.Interface {
+	return &daorestag.Dao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// ResourceRelation return resource relation dao.
+func (s *set) ResourceRelation() daorelation.Interface {
+	return &daorelation.Dao{
+		Orm: s.orm,
+	}
+}
+
+// DistinctValue return distinct value dao.
+func (s *set) DistinctValue() daodistinct.Interface {
+	return &daodistinct.Dao{
+		Orm: s.orm,
+	}
+}
+
+// Consistency return data consistency check dao.
+func (s *set) Consistency() daoconsistency.Interface {
+	return &daoconsistency.Dao{
+		Orm: s.orm,
+	}
+}
+
+// Index return table index management dao.
+func (s *set) Index() daoindex.Interface {
+	return &daoindex.Dao{
+		Orm: s.orm,
+	}
+}
+
+// Backup return inventory data backup and restore dao.
+func (s *set) Backup() daobackup.Interface {
+	return &daobackup.Dao{
+		Orm:    s.orm,
+		Cipher: s.cipher,
+	}
+}
+
+// Tenant return tenant dao.
+func (s *set) Tenant() daotenant.Interface {
+	return &daotenant.Dao{
+		Orm: s.orm,
+	}
+}
@@ -0,0 +1,144 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package daodistinct distinct value dao.
+package daodistinct
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/cloud"
+	tablecvm "hcm/pkg/dal/table/cloud/cvm"
+	"hcm/pkg/dal/table/cloud/disk"
+	"hcm/pkg/dal/table/cloud/eip"
+	"hcm/pkg/dal/table/utils"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+)
+
+// distinctColumns 支持查询去重值的资源表及其字段
+var distinctColumns = map[table.Name]*utils.Columns{
+	table.AccountTable:       cloud.AccountColumns,
+	table.VpcTable:           cloud.VpcColumns,
+	table.SubnetTable:        cloud.SubnetColumns,
+	table.SecurityGroupTable: cloud.SecurityGroupColumns,
+	table.CvmTable:           tablecvm.TableColumns,
+	table.DiskTable:          disk.DiskColumns,
+	table.EipTable:           eip.EipColumns,
+}
+
+// IsSupported 判断资源表是否支持查询去重值
+func IsSupported(resType table.Name) bool {
+	_, exist := distinctColumns[resType]
+	return exist
+}
+
+// ListOption defines options to list distinct values.
+type ListOption struct {
+	Table  table.Name
+	Field  string
+	Filter *filter.Expression
+	// Limit 返回的去重值的最大数量，为0时使用默认值
+	Limit uint
+}
+
+// Interface only used for distinct value.
+type Interface interface {
+	// List 查询资源表中满足条件的字段去重值
+	List(kt *kit.Kit, opt *ListOption) ([]string, error)
+}
+
+var _ Interface = new(Dao)
+
+// Dao distinct value dao.
+type Dao struct {
+	Orm orm.Interface
+}
+
+// List distinct values of the field, the values are sorted in ascending order and null value is ignored.
+func (d Dao) List(kt *kit.Kit, opt *ListOption) ([]string, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list distinct value options is nil")
+	}
+
+	columns, exist := distinctColumns[opt.Table]
+	if !exist {
+		return nil, errf.Newf(errf.InvalidParameter, "table %s does not support list distinct value", opt.Table)
+	}
+
+	columnTypes := columns.ColumnTypes()
+	typ, exist := columnTypes[opt.Field]
+	if !exist || typ == enumor.Json {
+		return nil, errf.Newf(errf.InvalidParameter, "field %s does not support list distinct value", opt.Field)
+	}
+
+	if opt.Filter == nil {
+		return nil, errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	if err := opt.Filter.Validate(filter.NewExprOption(filter.RuleFields(columnTypes))); err != nil {
+		return nil, err
+	}
+
+	limit := opt.Limit
+	if limit == 0 || limit > core.DefaultMaxPageLimit {
+		limit = core.DefaultMaxPageLimit
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(whereExpr) == 0 {
+		whereExpr = fmt.Sprintf("WHERE %s IS NOT NULL", opt.Field)
+	} else {
+		whereExpr = fmt.Sprintf("WHERE (%s) AND %s IS NOT NULL", strings.TrimPrefix(whereExpr, "WHERE "), opt.Field)
+	}
+
+	sql := fmt.Sprintf(`SELECT DISTINCT %s AS value FROM %s %s ORDER BY %s LIMIT %d`, opt.Field, opt.Table,
+		whereExpr, opt.Field, limit)
+
+	rows := make([]distinctValue, 0)
+	if err = d.Orm.Do().Select(kt.Ctx, &rows, sql, whereValue); err != nil {
+		logs.ErrorJson("list %s distinct %s failed, err: %v, filter: %s, rid: %s", opt.Table, opt.Field, err,
+			opt.Filter, kt.Rid)
+		return nil, err
+	}
+
+	values := make([]string, 0, len(rows))
+	for _, one := range rows {
+		values = append(values, one.Value.String)
+	}
+
+	return values, nil
+}
+
+type distinctValue struct {
+	Value sql.NullString `db:"value"`
+}
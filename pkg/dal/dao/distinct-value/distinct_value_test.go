@@ -0,0 +1,126 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package daodistinct
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/kit"
+)
+
+// fakeOrm records the select statement and returns the prepared values.
+type fakeOrm struct {
+	orm.Interface
+	orm.DoOrm
+	values []distinctValue
+	expr   string
+	args   map[string]interface{}
+}
+
+// Do ...
+func (f *fakeOrm) Do() orm.DoOrm {
+	return f
+}
+
+// Select ...
+func (f *fakeOrm) Select(_ context.Context, dest interface{}, expr string, args map[string]interface{}) error {
+	f.expr, f.args = expr, args
+	*dest.(*[]distinctValue) = append(*dest.(*[]distinctValue), f.values...)
+	return nil
+}
+
+func TestList(t *testing.T) {
+	fake := &fakeOrm{values: []distinctValue{
+		{Value: sql.NullString{String: "ap-guangzhou", Valid: true}},
+		{Value: sql.NullString{String: "ap-shanghai", Valid: true}},
+	}}
+	dao := Dao{Orm: fake}
+
+	values, err := dao.List(kit.New(), &ListOption{Table: table.CvmTable, Field: "region",
+		Filter: tools.EqualExpression("vendor", "tcloud")})
+	if err != nil {
+		t.Fatalf("list distinct value failed, err: %v", err)
+	}
+	if strings.Join(values, ",") != "ap-guangzhou,ap-shanghai" {
+		t.Errorf("unexpected distinct values: %v", values)
+	}
+
+	for _, want := range []string{"SELECT DISTINCT region AS value FROM cvm", "AND region IS NOT NULL",
+		"ORDER BY region LIMIT 500"} {
+
+		if !strings.Contains(fake.expr, want) {
+			t.Errorf("sql should contain %q, got: %s", want, fake.expr)
+		}
+	}
+
+	_, err = dao.List(kit.New(), &ListOption{Table: table.CvmTable, Field: "region", Filter: tools.AllExpression(),
+		Limit: 10})
+	if err != nil {
+		t.Fatalf("list distinct value failed, err: %v", err)
+	}
+	if !strings.Contains(fake.expr, "WHERE region IS NOT NULL ORDER BY region LIMIT 10") {
+		t.Errorf("limit should be used and null value should be ignored, got: %s", fake.expr)
+	}
+}
+
+func TestListTenant(t *testing.T) {
+	tools.EnableTenant(true)
+	defer tools.EnableTenant(false)
+
+	fake := new(fakeOrm)
+	dao := Dao{Orm: fake}
+	kt := kit.New()
+	kt.TenantID = "tenant1"
+
+	if _, err := dao.List(kt, &ListOption{Table: table.VpcTable, Field: "region",
+		Filter: tools.AllExpression()}); err != nil {
+
+		t.Fatalf("list distinct value failed, err: %v", err)
+	}
+	if !strings.Contains(fake.expr, "tenant_id") || !strings.Contains(fake.expr, "AND region IS NOT NULL") {
+		t.Errorf("values of other tenants should not be listed, sql: %s", fake.expr)
+	}
+}
+
+func TestListInvalid(t *testing.T) {
+	dao := Dao{Orm: new(fakeOrm)}
+
+	cases := []*ListOption{
+		nil,
+		{Table: table.AuditTable, Field: "res_type", Filter: tools.AllExpression()},
+		{Table: table.CvmTable, Field: "not_exist", Filter: tools.AllExpression()},
+		{Table: table.CvmTable, Field: "extension", Filter: tools.AllExpression()},
+		{Table: table.CvmTable, Field: "region"},
+		{Table: table.CvmTable, Field: "region", Filter: tools.EqualExpression("not_exist", "a")},
+	}
+	for idx, opt := range cases {
+		_, err := dao.List(kit.New(), opt)
+		if ef := errf.Error(err); ef == nil || ef.Code != errf.InvalidParameter {
+			t.Errorf("case %d: invalid option should be rejected, err: %v", idx, err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package daoevent defines the dao of resource change event.
+package daoevent
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tableevent "hcm/pkg/dal/table/event"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+)
+
+// ConsumerOffsetInterface only used for event consumer offset.
+type ConsumerOffsetInterface interface {
+	Upsert(kt *kit.Kit, model *tableevent.ConsumerOffsetTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tableevent.ConsumerOffsetTable], error)
+}
+
+var _ ConsumerOffsetInterface = new(ConsumerOffsetDao)
+
+// ConsumerOffsetDao event consumer offset dao.
+type ConsumerOffsetDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// Upsert commit the consumed offset of the consumer on the topic, the offset is overwritten if the consumer has
+// committed on the topic before, so that the consumer can also rewind to an earlier offset.
+func (dao ConsumerOffsetDao) Upsert(kt *kit.Kit, model *tableevent.ConsumerOffsetTable) error {
+	if model == nil {
+		return errf.New(errf.InvalidParameter, "model is required")
+	}
+
+	id, err := dao.IDGen.One(kt, table.EventConsumerOffsetTable)
+	if err != nil {
+		return err
+	}
+	model.ID = id
+
+	if err = model.InsertValidate(); err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s) VALUES(%s) ON DUPLICATE KEY UPDATE consumed_offset = VALUES(consumed_offset),
+		reviser = VALUES(reviser)`, model.TableName(), tableevent.ConsumerOffsetColumns.ColumnExpr(),
+		tableevent.ConsumerOffsetColumns.ColonNameExpr())
+
+	if err = dao.Orm.Do().Insert(kt.Ctx, sql, model); err != nil {
+		logs.Errorf("upsert %s failed, err: %v, sql: %s, rid: %s", model.TableName(), err, sql, kt.Rid)
+		return fmt.Errorf("upsert %s failed, err: %w", model.TableName(), err)
+	}
+
+	return nil
+}
+
+// List event consumer offset.
+func (dao ConsumerOffsetDao) List(kt *kit.Kit, opt *types.ListOption) (
+	*types.ListResult[tableevent.ConsumerOffsetTable], error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tableevent.ConsumerOffsetColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is a count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.EventConsumerOffsetTable, whereExpr)
+
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count event consumer offset failed, err: %v, filter: %s, rid: %s", err, opt.Filter,
+				kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[tableevent.ConsumerOffsetTable]{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tableevent.ConsumerOffsetColumns.FieldsNamedExpr(opt.Fields),
+		table.EventConsumerOffsetTable, whereExpr, pageExpr)
+
+	details := make([]tableevent.ConsumerOffsetTable, 0)
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.EventConsumerOffsetTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
+		logs.Errorf("select event consumer offset failed, err: %v, sql: %s, rid: %s", err, sql, kt.Rid)
+		return nil, err
+	}
+
+	return &types.ListResult[tableevent.ConsumerOffsetTable]{Count: count, Details: details}, nil
+}
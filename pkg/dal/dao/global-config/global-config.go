@@ -78,12 +78,11 @@ func (d Dao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tablegconf.GlobalCo
 		models[index].ID = ids[index]
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, models[0].TableName(),
-		tablegconf.GlobalConfigTableColumns.ColumnExpr(), tablegconf.GlobalConfigTableColumns.ColonNameExpr())
+	sql := tablegconf.GlobalConfigTableColumns.InsertExpr(string(models[0].TableName()))
 
 	if err = d.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
 		logs.Errorf("insert %s failed, err: %v, rid: %s", models[0].TableName(), err, kt.Rid)
-		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", models[0].TableName(), err)
 	}
 
 	return ids, nil
@@ -171,11 +170,13 @@ func (d Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tablegc
 		table.GlobalConfigTable, whereExpr, pageExpr)
 
 	details := make([]tablegconf.GlobalConfigTable, 0)
-	if err = d.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.GlobalConfigTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, d.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &types.ListResult[tablegconf.GlobalConfigTable]{Count: 0, Details: details}, nil
+	return &types.ListResult[tablegconf.GlobalConfigTable]{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx delete global config with tx.
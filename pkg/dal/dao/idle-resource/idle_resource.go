@@ -0,0 +1,356 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package idleresource idle resource dao.
+package idleresource
+
+import (
+	"fmt"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	daoquota "hcm/pkg/dal/dao/quota"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tableidle "hcm/pkg/dal/table/idle-resource"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+	"hcm/pkg/tools/times"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// IdleResourceInterface only used for idle resource.
+type IdleResourceInterface interface {
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tableidle.IdleResourceTable], error)
+	UpdateStatus(kt *kit.Kit, ids []string, status enumor.IdleResourceStatus) error
+	Delete(kt *kit.Kit, expr *filter.Expression) error
+	ListCandidates(kt *kit.Kit, reason enumor.IdleReason, opt *CandidateOption) ([]IdleCandidate, error)
+	SyncDetected(kt *kit.Kit, reason enumor.IdleReason, detected []tableidle.IdleResourceTable) error
+}
+
+var _ IdleResourceInterface = new(IdleResourceDao)
+
+// IdleResourceDao idle resource dao.
+type IdleResourceDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// List idle resource.
+func (dao IdleResourceDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tableidle.IdleResourceTable],
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tableidle.IdleResourceColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is dao count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.IdleResourceTable, whereExpr)
+
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count idle resource failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[tableidle.IdleResourceTable]{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tableidle.IdleResourceColumns.FieldsNamedExpr(opt.Fields),
+		table.IdleResourceTable, whereExpr, pageExpr)
+
+	details := make([]tableidle.IdleResourceTable, 0)
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.IdleResourceTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
+		logs.Errorf("select idle resource failed, err: %v, sql: %s, rid: %s", err, sql, kt.Rid)
+		return nil, err
+	}
+
+	return &types.ListResult[tableidle.IdleResourceTable]{Count: count, Details: details}, nil
+}
+
+// UpdateStatus update idle resource status by ids.
+func (dao IdleResourceDao) UpdateStatus(kt *kit.Kit, ids []string, status enumor.IdleResourceStatus) error {
+	if len(ids) == 0 {
+		return errf.New(errf.InvalidParameter, "ids is required")
+	}
+
+	if err := status.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	whereExpr, whereValue, err := tools.ContainersExpression("id", ids).SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s SET status = :status, reviser = :reviser %s`, table.IdleResourceTable, whereExpr)
+	toUpdate := tools.MapMerge(map[string]interface{}{"status": status, "reviser": kt.User}, whereValue)
+	if _, err = dao.Orm.Do().Update(kt.Ctx, sql, toUpdate); err != nil {
+		logs.Errorf("update idle resource status failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// Delete idle resource.
+func (dao IdleResourceDao) Delete(kt *kit.Kit, expr *filter.Expression) error {
+	if expr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.IdleResourceTable, whereExpr)
+	if _, err = dao.Orm.Do().Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete idle resource failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// CandidateOption idle resource candidate list option.
+type CandidateOption struct {
+	// StoppedCvmDays 主机处于关机状态且超过该天数未发生变更时视为闲置
+	StoppedCvmDays uint
+}
+
+// IdleCandidate 根据资源及其关联关系表检测出的闲置资源
+type IdleCandidate struct {
+	ResID     string        `db:"res_id"`
+	Vendor    enumor.Vendor `db:"vendor"`
+	AccountID string        `db:"account_id"`
+	BkBizID   int64         `db:"bk_biz_id"`
+	Region    string        `db:"region"`
+	ResName   string        `db:"res_name"`
+	// Size 硬盘为容量(GB)，主机为vCPU核数，其余资源为0
+	Size int64 `db:"size"`
+}
+
+// cvmStoppedStatus 各云厂商主机的关机状态
+var cvmStoppedStatus = []string{"STOPPED", "stopped", "SUSPENDED", "PowerState/stopped", "PowerState/deallocated",
+	"SHUTOFF", "TERMINATED"}
+
+// ListCandidates list idle resource candidates by reason, resources in recycle bin are excluded.
+func (dao IdleResourceDao) ListCandidates(kt *kit.Kit, reason enumor.IdleReason, opt *CandidateOption) (
+	[]IdleCandidate, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "candidate option is required")
+	}
+
+	notRecycling := tools.RuleNotEqual("recycle_status", enumor.RecycleStatus)
+	var expr *filter.Expression
+	var err error
+	var sqlTpl string
+	switch reason {
+	case enumor.IdleUnattachedDisk:
+		expr, err = tools.And(notRecycling, tools.RuleEqual("is_system_disk", false))
+		sqlTpl = fmt.Sprintf(`SELECT id AS res_id, vendor, account_id, bk_biz_id, region, IFNULL(name, '') AS res_name,
+			disk_size AS size FROM %s %%s AND id NOT IN (SELECT disk_id FROM %s)`, table.DiskTable,
+			table.DiskCvmRelTableName)
+
+	case enumor.IdleUnboundEip:
+		expr, err = tools.And(notRecycling)
+		sqlTpl = fmt.Sprintf(`SELECT id AS res_id, vendor, account_id, bk_biz_id, region, IFNULL(name, '') AS res_name,
+			0 AS size FROM %s %%s AND id NOT IN (SELECT eip_id FROM %s)`, table.EipTable, table.EipCvmRelTableName)
+
+	case enumor.IdleStoppedCvm:
+		before := time.Now().Add(-time.Duration(opt.StoppedCvmDays) * 24 * time.Hour)
+		expr, err = tools.And(notRecycling, tools.RuleIn("status", cvmStoppedStatus),
+			tools.RuleLessThanEqual("updated_at", times.ConvStdTimeFormat(before)))
+		sqlTpl = fmt.Sprintf(`SELECT id AS res_id, vendor, account_id, bk_biz_id, region, name AS res_name,
+			CAST(%s AS SIGNED) AS size FROM %s %%s`, daoquota.CvmCpuCoreExpr(), table.CvmTable)
+
+	case enumor.IdleUnusedSecurityGroup:
+		// 默认安全组由云上自动创建且无法删除，不作为闲置资源
+		expr, err = tools.And(tools.RuleNotEqual("name", "default"))
+		sqlTpl = fmt.Sprintf(`SELECT id AS res_id, vendor, account_id, bk_biz_id, region, name AS res_name, 0 AS size
+			FROM %s %%s AND id NOT IN (SELECT security_group_id FROM %s) AND id NOT IN (SELECT security_group_id
+			FROM %s)`, table.SecurityGroupTable, table.SecurityGroupCvmTable, table.SecurityGroupCommonRelTable)
+
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "unsupported idle reason: %s", reason)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(sqlTpl, whereExpr)
+	candidates := make([]IdleCandidate, 0)
+	if err = dao.Orm.Do().Select(kt.Ctx, &candidates, sql, whereValue); err != nil {
+		logs.Errorf("list idle resource candidates failed, err: %v, reason: %s, sql: %s, rid: %s", err, reason, sql,
+			kt.Rid)
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+// SyncDetected sync the detected idle resources of the reason, new resources are created with pending status,
+// existing ones are refreshed with status retained, and resources no longer idle are deleted.
+func (dao IdleResourceDao) SyncDetected(kt *kit.Kit, reason enumor.IdleReason,
+	detected []tableidle.IdleResourceTable) error {
+
+	if err := reason.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	existArgs := map[string]interface{}{"reason": reason}
+	existSql := fmt.Sprintf(`SELECT id, res_id FROM %s WHERE reason = :reason%s`, table.IdleResourceTable,
+		tools.TenantSqlCondition(kt, "tenant_id", existArgs))
+	exists := make([]tableidle.IdleResourceTable, 0)
+	if err := dao.Orm.Do().Select(kt.Ctx, &exists, existSql, existArgs); err != nil {
+		logs.Errorf("list exist idle resource failed, err: %v, reason: %s, rid: %s", err, reason, kt.Rid)
+		return err
+	}
+	existMap := make(map[string]string, len(exists))
+	for _, one := range exists {
+		existMap[one.ResID] = one.ID
+	}
+
+	// 时间精度为秒，与数据库保持一致，用于删除本次未检测到的记录
+	detectedAt := time.Now().Truncate(time.Second)
+	toCreate := make([]tableidle.IdleResourceTable, 0)
+	toUpdate := make([]tableidle.IdleResourceTable, 0)
+	for _, one := range detected {
+		one.Reason = reason
+		one.DetectedAt = detectedAt
+		one.Reviser = kt.User
+		if id, ok := existMap[one.ResID]; ok {
+			one.ID = id
+			toUpdate = append(toUpdate, one)
+			continue
+		}
+		one.Status = enumor.IdleResourcePending
+		one.Creator = kt.User
+		toCreate = append(toCreate, one)
+	}
+
+	_, err := dao.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := dao.batchCreateWithTx(kt, txn, toCreate); err != nil {
+			return nil, err
+		}
+
+		updateSql := fmt.Sprintf(`UPDATE %s SET vendor = :vendor, account_id = :account_id, bk_biz_id = :bk_biz_id,
+			region = :region, res_name = :res_name, detail = :detail, estimated_monthly_cost = :estimated_monthly_cost,
+			currency = :currency, detected_at = :detected_at, reviser = :reviser WHERE id = :id`,
+			table.IdleResourceTable)
+		for _, one := range toUpdate {
+			args := map[string]interface{}{
+				"id":                     one.ID,
+				"vendor":                 one.Vendor,
+				"account_id":             one.AccountID,
+				"bk_biz_id":              one.BkBizID,
+				"region":                 one.Region,
+				"res_name":               one.ResName,
+				"detail":                 one.Detail,
+				"estimated_monthly_cost": one.EstimatedMonthlyCost,
+				"currency":               one.Currency,
+				"detected_at":            one.DetectedAt,
+				"reviser":                one.Reviser,
+			}
+			sql := updateSql + tools.TenantSqlCondition(kt, "tenant_id", args)
+			if _, err := dao.Orm.Txn(txn).Update(kt.Ctx, sql, args); err != nil {
+				logs.Errorf("update idle resource failed, err: %v, id: %s, rid: %s", err, one.ID, kt.Rid)
+				return nil, err
+			}
+		}
+
+		args := map[string]interface{}{"reason": reason, "detected_at": detectedAt}
+		deleteSql := fmt.Sprintf(`DELETE FROM %s WHERE reason = :reason AND detected_at < :detected_at%s`,
+			table.IdleResourceTable, tools.TenantSqlCondition(kt, "tenant_id", args))
+		if _, err := dao.Orm.Txn(txn).Delete(kt.Ctx, deleteSql, args); err != nil {
+			logs.Errorf("delete not idle resource failed, err: %v, reason: %s, rid: %s", err, reason, kt.Rid)
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("sync detected idle resource failed, err: %v, reason: %s, rid: %s", err, reason, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+func (dao IdleResourceDao) batchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tableidle.IdleResourceTable) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	ids, err := dao.IDGen.Batch(kt, table.IdleResourceTable, len(models))
+	if err != nil {
+		return err
+	}
+	for idx := range models {
+		models[idx].ID = ids[idx]
+		models[idx].TenantID = kt.GetTenantID()
+		if err = models[idx].InsertValidate(); err != nil {
+			return err
+		}
+	}
+
+	sql := tableidle.IdleResourceColumns.InsertExpr(string(table.IdleResourceTable))
+	for _, batch := range slice.Split(models, constant.BatchOperationMaxLimit) {
+		if err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, batch); err != nil {
+			logs.Errorf("insert %s failed, err: %v, rid: %s", table.IdleResourceTable, err, kt.Rid)
+			return fmt.Errorf("insert %s failed, err: %w", table.IdleResourceTable, err)
+		}
+	}
+
+	return nil
+}
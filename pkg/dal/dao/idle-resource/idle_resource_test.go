@@ -0,0 +1,189 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package idleresource
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/table"
+	tableidle "hcm/pkg/dal/table/idle-resource"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+)
+
+// fakeOrm returns the prepared exist idle resources, and records the sync statements in order.
+type fakeOrm struct {
+	orm.Interface
+	orm.DoOrm
+	exists     []tableidle.IdleResourceTable
+	selectSql  string
+	inserted   []tableidle.IdleResourceTable
+	updateArgs []map[string]interface{}
+	deleteArgs map[string]interface{}
+	steps      []string
+}
+
+// Do ...
+func (f *fakeOrm) Do() orm.DoOrm {
+	return f
+}
+
+// Txn ...
+func (f *fakeOrm) Txn(_ *sqlx.Tx) orm.DoOrmWithTransaction {
+	return f
+}
+
+// AutoTxn ...
+func (f *fakeOrm) AutoTxn(_ *kit.Kit, run orm.TxnFunc) (interface{}, error) {
+	return run(nil, nil)
+}
+
+// Select ...
+func (f *fakeOrm) Select(_ context.Context, dest interface{}, expr string, _ map[string]interface{}) error {
+	f.selectSql = expr
+	if details, ok := dest.(*[]tableidle.IdleResourceTable); ok {
+		*details = append(*details, f.exists...)
+	}
+	return nil
+}
+
+// BulkInsert ...
+func (f *fakeOrm) BulkInsert(_ context.Context, _ string, args interface{}) error {
+	f.steps = append(f.steps, "insert")
+	f.inserted = append(f.inserted, args.([]tableidle.IdleResourceTable)...)
+	return nil
+}
+
+// Update ...
+func (f *fakeOrm) Update(_ context.Context, _ string, args map[string]interface{}) (int64, error) {
+	f.steps = append(f.steps, "update")
+	f.updateArgs = append(f.updateArgs, args)
+	return 1, nil
+}
+
+// Delete ...
+func (f *fakeOrm) Delete(_ context.Context, _ string, args map[string]interface{}) (int64, error) {
+	f.steps = append(f.steps, "delete")
+	f.deleteArgs = args
+	return 1, nil
+}
+
+type fakeIDGen struct{}
+
+// Batch ...
+func (fakeIDGen) Batch(_ *kit.Kit, _ table.Name, count int) ([]string, error) {
+	ids := make([]string, count)
+	for idx := range ids {
+		ids[idx] = "new" + string(rune('0'+idx))
+	}
+	return ids, nil
+}
+
+// One ...
+func (fakeIDGen) One(_ *kit.Kit, _ table.Name) (string, error) {
+	return "new0", nil
+}
+
+func detectedDisk(resID string) tableidle.IdleResourceTable {
+	return tableidle.IdleResourceTable{
+		ResType:              enumor.DiskCloudResType,
+		ResID:                resID,
+		Vendor:               enumor.TCloud,
+		Detail:               `{"disk_size":100}`,
+		EstimatedMonthlyCost: &types.Decimal{Decimal: decimal.NewFromInt(50)},
+	}
+}
+
+func TestSyncDetected(t *testing.T) {
+	fake := &fakeOrm{exists: []tableidle.IdleResourceTable{{ID: "exist1", ResID: "disk-1"}}}
+	dao := IdleResourceDao{Orm: fake, IDGen: fakeIDGen{}}
+	kt := kit.New()
+	kt.User = "tester"
+
+	detected := []tableidle.IdleResourceTable{detectedDisk("disk-1"), detectedDisk("disk-2")}
+	if err := dao.SyncDetected(kt, enumor.IdleUnattachedDisk, detected); err != nil {
+		t.Fatalf("sync detected idle resource failed, err: %v", err)
+	}
+
+	if strings.Join(fake.steps, ",") != "insert,update,delete" {
+		t.Fatalf("unexpected sync steps: %v", fake.steps)
+	}
+
+	// 新检测到的资源以待处理状态创建
+	if len(fake.inserted) != 1 || fake.inserted[0].ResID != "disk-2" || fake.inserted[0].ID != "new0" ||
+		fake.inserted[0].Status != enumor.IdleResourcePending || fake.inserted[0].Creator != "tester" {
+		t.Errorf("unexpected inserted idle resources: %+v", fake.inserted)
+	}
+
+	// 已存在的资源按原记录更新，不修改处理状态
+	if len(fake.updateArgs) != 1 || fake.updateArgs[0]["id"] != "exist1" {
+		t.Fatalf("unexpected updated idle resources: %+v", fake.updateArgs)
+	}
+	if _, exists := fake.updateArgs[0]["status"]; exists {
+		t.Errorf("status of exist idle resource should be retained")
+	}
+
+	// 本次未检测到的资源按检测时间删除
+	if fake.deleteArgs["reason"] != enumor.IdleUnattachedDisk ||
+		fake.deleteArgs["detected_at"] != fake.inserted[0].DetectedAt {
+		t.Errorf("idle resources not detected this time should be deleted, args: %+v", fake.deleteArgs)
+	}
+}
+
+func TestSyncDetectedInvalidReason(t *testing.T) {
+	dao := IdleResourceDao{Orm: &fakeOrm{}, IDGen: fakeIDGen{}}
+	if err := dao.SyncDetected(kit.New(), "unknown", nil); err == nil {
+		t.Errorf("sync detected with unsupported reason should fail")
+	}
+}
+
+func TestListCandidates(t *testing.T) {
+	cases := map[enumor.IdleReason][]string{
+		enumor.IdleUnattachedDisk: {"FROM disk", "is_system_disk", "NOT IN (SELECT disk_id FROM disk_cvm_rel)"},
+		enumor.IdleUnboundEip:     {"FROM eip", "NOT IN (SELECT eip_id FROM eip_cvm_rel)"},
+		enumor.IdleStoppedCvm:     {"FROM cvm", "status", "updated_at"},
+		enumor.IdleUnusedSecurityGroup: {"FROM security_group", "name",
+			"NOT IN (SELECT security_group_id FROM security_group_cvm_rel)"},
+	}
+	for reason, expects := range cases {
+		fake := &fakeOrm{}
+		dao := IdleResourceDao{Orm: fake}
+		if _, err := dao.ListCandidates(kit.New(), reason, &CandidateOption{StoppedCvmDays: 30}); err != nil {
+			t.Fatalf("list %s candidates failed, err: %v", reason, err)
+		}
+		for _, expect := range expects {
+			if !strings.Contains(fake.selectSql, expect) {
+				t.Errorf("%s candidates sql should contain %q, sql: %s", reason, expect, fake.selectSql)
+			}
+		}
+	}
+
+	dao := IdleResourceDao{Orm: &fakeOrm{}}
+	if _, err := dao.ListCandidates(kit.New(), "unknown", &CandidateOption{}); err == nil {
+		t.Errorf("list candidates with unsupported reason should fail")
+	}
+}
@@ -0,0 +1,142 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package daoindex table index management dao.
+package daoindex
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// PrimaryIndexName 主键索引名
+const PrimaryIndexName = "PRIMARY"
+
+// Index 表的索引信息
+type Index struct {
+	Table table.Name `db:"-" json:"table"`
+	Name  string     `db:"name" json:"name"`
+	// Columns 索引列，按在索引中的顺序以逗号分隔
+	Columns   string `db:"columns" json:"columns"`
+	NonUnique bool   `db:"non_unique" json:"non_unique"`
+}
+
+// ColumnList 返回按顺序排列的索引列
+func (i Index) ColumnList() []string {
+	return strings.Split(i.Columns, ",")
+}
+
+// IsSecondary 判断是否为二级索引，主键和唯一索引承载了数据约束，不属于可管理的二级索引
+func (i Index) IsSecondary() bool {
+	return i.Name != PrimaryIndexName && i.NonUnique
+}
+
+// Interface only used for table index management.
+type Interface interface {
+	// ListIndexes 查询表的所有索引
+	ListIndexes(kt *kit.Kit, name table.Name) ([]Index, error)
+	// ListColumns 查询表的所有字段名
+	ListColumns(kt *kit.Kit, name table.Name) ([]string, error)
+	// CreateIndex 在表上创建二级索引
+	CreateIndex(kt *kit.Kit, name table.Name, index string, columns []string) error
+	// DropIndex 删除表上的二级索引
+	DropIndex(kt *kit.Kit, name table.Name, index string) error
+	// QueryStats 查询采集到的sql执行统计
+	QueryStats() []orm.QueryStat
+	// ResetQueryStats 清空采集到的sql执行统计
+	ResetQueryStats()
+}
+
+var _ Interface = new(Dao)
+
+// Dao table index management dao.
+type Dao struct {
+	Orm orm.Interface
+}
+
+// ListIndexes list indexes of the table.
+func (d Dao) ListIndexes(kt *kit.Kit, name table.Name) ([]Index, error) {
+	sql := `SELECT INDEX_NAME AS name, NON_UNIQUE AS non_unique, ` +
+		`GROUP_CONCAT(COLUMN_NAME ORDER BY SEQ_IN_INDEX) AS columns FROM information_schema.statistics ` +
+		`WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = :table_name GROUP BY INDEX_NAME, NON_UNIQUE`
+
+	indexes := make([]Index, 0)
+	if err := d.Orm.Do().Select(kt.Ctx, &indexes, sql, map[string]interface{}{"table_name": name}); err != nil {
+		logs.Errorf("list %s indexes failed, err: %v, rid: %s", name, err, kt.Rid)
+		return nil, err
+	}
+
+	for idx := range indexes {
+		indexes[idx].Table = name
+	}
+
+	return indexes, nil
+}
+
+// ListColumns list column names of the table.
+func (d Dao) ListColumns(kt *kit.Kit, name table.Name) ([]string, error) {
+	sql := `SELECT COLUMN_NAME FROM information_schema.columns WHERE TABLE_SCHEMA = DATABASE() ` +
+		`AND TABLE_NAME = :table_name ORDER BY ORDINAL_POSITION`
+
+	columns := make([]string, 0)
+	if err := d.Orm.Do().Select(kt.Ctx, &columns, sql, map[string]interface{}{"table_name": name}); err != nil {
+		logs.Errorf("list %s columns failed, err: %v, rid: %s", name, err, kt.Rid)
+		return nil, err
+	}
+
+	return columns, nil
+}
+
+// CreateIndex create secondary index on the table, the table name, index name and columns must be validated
+// by caller, because they can not be passed as sql args.
+func (d Dao) CreateIndex(kt *kit.Kit, name table.Name, index string, columns []string) error {
+	sql := fmt.Sprintf("ALTER TABLE `%s` ADD INDEX `%s` (`%s`)", name, index, strings.Join(columns, "`, `"))
+	if _, err := d.Orm.Do().Exec(kt.Ctx, sql); err != nil {
+		logs.Errorf("create %s index %s failed, err: %v, columns: %v, rid: %s", name, index, err, columns, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// DropIndex drop secondary index of the table, the table name and index name must be validated by caller.
+func (d Dao) DropIndex(kt *kit.Kit, name table.Name, index string) error {
+	sql := fmt.Sprintf("ALTER TABLE `%s` DROP INDEX `%s`", name, index)
+	if _, err := d.Orm.Do().Exec(kt.Ctx, sql); err != nil {
+		logs.Errorf("drop %s index %s failed, err: %v, rid: %s", name, index, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// QueryStats returns the captured query stats.
+func (d Dao) QueryStats() []orm.QueryStat {
+	return d.Orm.QueryStats()
+}
+
+// ResetQueryStats clear the captured query stats.
+func (d Dao) ResetQueryStats() {
+	d.Orm.ResetQueryStats()
+}
@@ -0,0 +1,137 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package naming
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
+	tablenaming "hcm/pkg/dal/table/naming"
+	"hcm/pkg/kit"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// fakeOrm records the delete and insert statements of naming violation sync in order.
+type fakeOrm struct {
+	orm.Interface
+	orm.DoOrm
+	deleteArgs map[string]interface{}
+	inserted   []tablenaming.NamingViolationTable
+	steps      []string
+}
+
+// Txn ...
+func (f *fakeOrm) Txn(_ *sqlx.Tx) orm.DoOrmWithTransaction {
+	return f
+}
+
+// AutoTxn ...
+func (f *fakeOrm) AutoTxn(_ *kit.Kit, run orm.TxnFunc) (interface{}, error) {
+	return run(nil, nil)
+}
+
+// Delete ...
+func (f *fakeOrm) Delete(_ context.Context, _ string, args map[string]interface{}) (int64, error) {
+	f.steps = append(f.steps, "delete")
+	f.deleteArgs = args
+	return 1, nil
+}
+
+// BulkInsert ...
+func (f *fakeOrm) BulkInsert(_ context.Context, _ string, args interface{}) error {
+	f.steps = append(f.steps, "insert")
+	f.inserted = append(f.inserted, args.([]tablenaming.NamingViolationTable)...)
+	return nil
+}
+
+type fakeIDGen struct{}
+
+// Batch ...
+func (fakeIDGen) Batch(_ *kit.Kit, _ table.Name, count int) ([]string, error) {
+	ids := make([]string, count)
+	for idx := range ids {
+		ids[idx] = "v" + string(rune('0'+idx))
+	}
+	return ids, nil
+}
+
+// One ...
+func (fakeIDGen) One(_ *kit.Kit, _ table.Name) (string, error) {
+	return "v0", nil
+}
+
+func TestSyncAccount(t *testing.T) {
+	tools.EnableTenant(true)
+	defer tools.EnableTenant(false)
+
+	fake := new(fakeOrm)
+	dao := NamingViolationDao{Orm: fake, IDGen: fakeIDGen{}}
+	kt := kit.New()
+	kt.TenantID = "tenant1"
+
+	violations := []tablenaming.NamingViolationTable{
+		{ResType: enumor.CvmCloudResType, ResID: "cvm-1", RuleID: "rule1", Reason: "name should start with prod-"},
+		{ResType: enumor.DiskCloudResType, ResID: "disk-1", RuleID: "rule2", Reason: "name should start with prod-"},
+	}
+	if err := dao.SyncAccount(kt, "account1", violations); err != nil {
+		t.Fatalf("sync account naming violation failed, err: %v", err)
+	}
+
+	// 先删除账号下原有的不合规记录，再写入本次检测结果
+	if strings.Join(fake.steps, ",") != "delete,insert" {
+		t.Fatalf("unexpected sync steps: %v", fake.steps)
+	}
+	if fake.deleteArgs["account_id"] != "account1" || fake.deleteArgs["tenant_id"] != "tenant1" {
+		t.Errorf("only the naming violations of the account and tenant should be deleted, args: %v",
+			fake.deleteArgs)
+	}
+
+	if len(fake.inserted) != 2 {
+		t.Fatalf("expect 2 naming violations inserted, but got %d", len(fake.inserted))
+	}
+	for idx, one := range fake.inserted {
+		if one.ID != "v"+string(rune('0'+idx)) || one.AccountID != "account1" || one.TenantID != "tenant1" ||
+			one.DetectedAt.IsZero() {
+			t.Errorf("unexpected inserted naming violation: %+v", one)
+		}
+	}
+}
+
+func TestSyncAccountNoViolation(t *testing.T) {
+	fake := new(fakeOrm)
+	dao := NamingViolationDao{Orm: fake, IDGen: fakeIDGen{}}
+
+	if err := dao.SyncAccount(kit.New(), "account1", nil); err != nil {
+		t.Fatalf("sync account naming violation failed, err: %v", err)
+	}
+	if strings.Join(fake.steps, ",") != "delete" {
+		t.Errorf("account without violation should only delete the previous ones, steps: %v", fake.steps)
+	}
+
+	if err := dao.SyncAccount(kit.New(), "", nil); err == nil {
+		t.Errorf("sync without account id should fail")
+	}
+}
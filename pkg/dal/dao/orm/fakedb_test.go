@@ -0,0 +1,224 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package orm
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func init() {
+	sql.Register("orm_fake", &fakeDriver{})
+}
+
+var fakeDBs sync.Map
+
+// fakeExec is a statement executed by the fake db.
+type fakeExec struct {
+	conn      int
+	inTxn     bool
+	readOnly  bool
+	isolation driver.IsolationLevel
+	query     string
+}
+
+// fakeDB records the statements executed on it, count statements return the number of rows, the others return
+// rows with id and name columns. repeatable read transactions read the snapshot of rows taken when they begin.
+type fakeDB struct {
+	mu        sync.Mutex
+	conns     int
+	execs     []fakeExec
+	rollbacks int
+	rows      [][]driver.Value
+	// afterQuery is called after each query is executed, it's used to simulate concurrent writes.
+	afterQuery func()
+}
+
+// insert a row, it's visible to the queries not in a repeatable read transaction started before.
+func (f *fakeDB) insert(row []driver.Value) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rows = append(f.rows, row)
+}
+
+func (f *fakeDB) snapshot() [][]driver.Value {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([][]driver.Value(nil), f.rows...)
+}
+
+func (f *fakeDB) record(e fakeExec) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.execs = append(f.execs, e)
+}
+
+func (f *fakeDB) executed() []fakeExec {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]fakeExec(nil), f.execs...)
+}
+
+// newFakeDB open a sqlx db on the fake driver with name as its dsn.
+func newFakeDB(t *testing.T, name string) (*sqlx.DB, *fakeDB) {
+	fdb := &fakeDB{rows: [][]driver.Value{{"1", "a"}, {"2", "b"}}}
+	fakeDBs.Store(name, fdb)
+
+	db, err := sqlx.Open("orm_fake", name)
+	if err != nil {
+		t.Fatalf("open fake db failed, err: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+		fakeDBs.Delete(name)
+	})
+
+	return sqlx.NewDb(db.DB, "mysql"), fdb
+}
+
+type fakeDriver struct{}
+
+// Open ...
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	v, ok := fakeDBs.Load(name)
+	if !ok {
+		return nil, errors.New("fake db not found")
+	}
+
+	fdb := v.(*fakeDB)
+	fdb.mu.Lock()
+	fdb.conns++
+	id := fdb.conns
+	fdb.mu.Unlock()
+
+	return &fakeConn{db: fdb, id: id}, nil
+}
+
+type fakeConn struct {
+	db  *fakeDB
+	id  int
+	txn *fakeTx
+}
+
+// Prepare ...
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare is not supported")
+}
+
+// Close ...
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+// Begin ...
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+// BeginTx ...
+func (c *fakeConn) BeginTx(_ context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	c.txn = &fakeTx{conn: c, opts: opts}
+	if opts.Isolation == driver.IsolationLevel(sql.LevelRepeatableRead) {
+		c.txn.snapshot = c.db.snapshot()
+	}
+	return c.txn, nil
+}
+
+// QueryContext ...
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	e := fakeExec{conn: c.id, query: query}
+	if c.txn != nil {
+		e.inTxn = true
+		e.readOnly = c.txn.opts.ReadOnly
+		e.isolation = c.txn.opts.Isolation
+	}
+	c.db.record(e)
+
+	rows := c.db.snapshot()
+	if c.txn != nil && c.txn.snapshot != nil {
+		rows = c.txn.snapshot
+	}
+
+	if c.db.afterQuery != nil {
+		defer c.db.afterQuery()
+	}
+
+	if strings.Contains(strings.ToUpper(query), "COUNT(") {
+		return &fakeRows{columns: []string{"count"}, values: [][]driver.Value{{int64(len(rows))}}}, nil
+	}
+
+	return &fakeRows{columns: []string{"id", "name"}, values: rows}, nil
+}
+
+type fakeTx struct {
+	conn     *fakeConn
+	opts     driver.TxOptions
+	snapshot [][]driver.Value
+}
+
+// Commit ...
+func (t *fakeTx) Commit() error {
+	t.conn.txn = nil
+	return nil
+}
+
+// Rollback ...
+func (t *fakeTx) Rollback() error {
+	t.conn.db.mu.Lock()
+	t.conn.db.rollbacks++
+	t.conn.db.mu.Unlock()
+	t.conn.txn = nil
+	return nil
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+	idx     int
+}
+
+// Columns ...
+func (r *fakeRows) Columns() []string {
+	return r.columns
+}
+
+// Close ...
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+// Next ...
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.idx >= len(r.values) {
+		return io.EOF
+	}
+
+	copy(dest, r.values[r.idx])
+	r.idx++
+	return nil
+}
@@ -42,6 +42,26 @@ func initMetric(register prometheus.Registerer) *metric {
 	}, []string{"cmd"})
 	register.MustRegister(m.cmdLagMS)
 
+	m.tableCmdLagMS = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace:   metrics.Namespace,
+		Subsystem:   metrics.OrmCmdSubSys,
+		Name:        "table_cmd_lag_milliseconds",
+		Help:        "the lags(milliseconds) to exec a ORM command on each table",
+		ConstLabels: labels,
+		Buckets:     []float64{1, 5, 10, 20, 50, 100, 200, 400, 800, 1500, 3000},
+	}, []string{"table", "cmd"})
+	register.MustRegister(m.tableCmdLagMS)
+
+	m.slowCmdCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   metrics.Namespace,
+			Subsystem:   metrics.OrmCmdSubSys,
+			Name:        "total_slow_cmd_count",
+			Help:        "the total count of the slow ORM command on each table",
+			ConstLabels: labels,
+		}, []string{"table", "cmd"})
+	register.MustRegister(m.slowCmdCounter)
+
 	m.errCounter = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace:   metrics.Namespace,
@@ -59,6 +79,12 @@ type metric struct {
 	// cmdLagMS record the cost time to exec an orm command.
 	cmdLagMS *prometheus.HistogramVec
 
+	// tableCmdLagMS record the cost time to exec an orm command on each table.
+	tableCmdLagMS *prometheus.HistogramVec
+
+	// slowCmdCounter record the total count of the slow orm command on each table.
+	slowCmdCounter *prometheus.CounterVec
+
 	// errCounter record the total error count when exec an orm command.
 	errCounter *prometheus.CounterVec
 }
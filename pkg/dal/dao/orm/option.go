@@ -24,6 +24,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/time/rate"
 )
@@ -37,6 +38,13 @@ type options struct {
 	mc *metric
 	// slowRequestMS db slow request time, beyond this time, the db request will be logged. unit: millisecond
 	slowRequestMS time.Duration
+	// slowLogWithArgs defines whether to log the sql args in slow log, args may contain sensitive data,
+	// so it is disabled by default.
+	slowLogWithArgs bool
+	// replicas read-only replica dbs, list and count requests will be routed to them.
+	replicas []*sqlx.DB
+	// writeTimeout the max time of a write request or a transaction, zero means no limit.
+	writeTimeout time.Duration
 }
 
 // Option orm option func defines.
@@ -70,6 +78,29 @@ func SlowRequestMS(ms uint) Option {
 	}
 }
 
+// SlowLogWithArgs set whether to log the sql args in slow log.
+func SlowLogWithArgs(enable bool) Option {
+	return func(opt *options) {
+		opt.slowLogWithArgs = enable
+	}
+}
+
+// ReadReplicas set read-only replica dbs, list and count requests will be routed to these
+// replicas in round-robin, unless the request is forced to read primary.
+func ReadReplicas(replicas ...*sqlx.DB) Option {
+	return func(opt *options) {
+		opt.replicas = append(opt.replicas, replicas...)
+	}
+}
+
+// WriteOpTimeoutSec set the max time of a write request or a transaction, the request is canceled and the
+// transaction is rolled back when it is exceeded.
+func WriteOpTimeoutSec(sec uint) Option {
+	return func(opt *options) {
+		opt.writeTimeout = time.Duration(sec) * time.Second
+	}
+}
+
 // TableShardingOpt defines table name generation options.
 type TableShardingOpt interface {
 	// Match check if table name match this sharding option
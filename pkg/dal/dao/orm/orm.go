@@ -28,6 +28,8 @@ import (
 	"math/rand"
 	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"hcm/pkg/criteria/constant"
@@ -43,7 +45,14 @@ import (
 // DoOrm defines all the orm method.
 type DoOrm interface {
 	Select(ctx context.Context, dest interface{}, expr string, arg map[string]interface{}) error
+	// SelectRows execute the query and iterate the rows with handler one by one, which is used to
+	// process huge amount of data without loading all of them into memory.
+	SelectRows(ctx context.Context, expr string, arg map[string]interface{}, handler RowsHandler) error
 	Count(ctx context.Context, expr string, arg map[string]interface{}) (uint64, error)
+	// SelectWithCount count the total with countExpr and select the page with expr in one read only transaction,
+	// so that both of them are executed on the same connection and snapshot, and the count matches the details.
+	SelectWithCount(ctx context.Context, dest interface{}, countExpr, expr string, arg map[string]interface{}) (
+		uint64, error)
 	Delete(ctx context.Context, expr string, arg map[string]interface{}) (int64, error)
 	Update(ctx context.Context, expr string, arg map[string]interface{}) (int64, error)
 	Exec(ctx context.Context, expr string) (int64, error)
@@ -56,6 +65,10 @@ type DoOrm interface {
 type DoOrmWithTransaction interface {
 	Count(ctx context.Context, expr string, arg map[string]interface{}) (uint64, error)
 	Select(ctx context.Context, dest interface{}, expr string, arg map[string]interface{}) error
+	// SelectWithCount count the total with countExpr and select the page with expr in the transaction.
+	SelectWithCount(ctx context.Context, dest interface{}, countExpr, expr string, arg map[string]interface{}) (
+		uint64, error)
+	SelectRows(ctx context.Context, expr string, arg map[string]interface{}, handler RowsHandler) error
 	Delete(ctx context.Context, expr string, args map[string]interface{}) (int64, error)
 	Update(ctx context.Context, expr string, args map[string]interface{}) (int64, error)
 
@@ -68,8 +81,15 @@ type Interface interface {
 	Do() DoOrm
 	Txn(tx *sqlx.Tx) DoOrmWithTransaction
 	AutoTxn(kt *kit.Kit, run TxnFunc) (interface{}, error)
+	// SnapshotTxn run the read only logics in a repeatable read transaction, all the reads in the
+	// transaction see the same consistent snapshot of the database.
+	SnapshotTxn(kt *kit.Kit, run TxnFunc) (interface{}, error)
 	// TableSharding at least one TableSharding option
 	TableSharding(opts ...TableShardingOpt) Interface
+	// QueryStats returns the captured query stats, sorted by slow count in descending order.
+	QueryStats() []QueryStat
+	// ResetQueryStats clear all the captured query stats.
+	ResetQueryStats()
 }
 
 // InitOrm return orm operations.
@@ -96,11 +116,15 @@ func InitOrm(db *sqlx.DB, opts ...Option) Interface {
 	}
 
 	return &runtimeOrm{
-		db:             db,
-		mc:             ormOpts.mc,
-		ingressLimiter: ormOpts.ingressLimiter,
-		logLimiter:     ormOpts.logLimiter,
-		slowRequestMS:  ormOpts.slowRequestMS,
+		db:              db,
+		mc:              ormOpts.mc,
+		ingressLimiter:  ormOpts.ingressLimiter,
+		logLimiter:      ormOpts.logLimiter,
+		slowRequestMS:   ormOpts.slowRequestMS,
+		slowLogWithArgs: ormOpts.slowLogWithArgs,
+		replicas:        ormOpts.replicas,
+		stats:           newQueryStats(),
+		writeTimeout:    ormOpts.writeTimeout,
 	}
 }
 
@@ -110,13 +134,64 @@ type runtimeOrm struct {
 	logLimiter     *rate.Limiter
 	mc             *metric
 	slowRequestMS  time.Duration
+	// slowLogWithArgs defines whether to log the sql args in slow log.
+	slowLogWithArgs bool
+	// replicas read-only replica dbs, replicaIdx is used to pick replica in round-robin.
+	replicas   []*sqlx.DB
+	replicaIdx uint32
+	// stats captured query stats, used to report slow filters and missing indexes.
+	stats *queryStats
+	// writeTimeout the max time of a write request or a transaction, zero means no limit.
+	writeTimeout time.Duration
 }
 
-func (o *runtimeOrm) logSlowCmd(ctx context.Context, sql string, latency time.Duration) {
+// writeCtx returns the context of the write request or transaction, which is canceled after the write timeout.
+func (o *runtimeOrm) writeCtx(ctx context.Context) (context.Context, context.CancelFunc) {
+	if o.writeTimeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, o.writeTimeout)
+}
+
+// readDB returns the db to execute read requests. if no replica is configured or the request
+// is forced to read primary, the primary db is returned.
+func (o *runtimeOrm) readDB(ctx context.Context) *sqlx.DB {
+	if len(o.replicas) == 0 {
+		return o.db
+	}
+
+	if kit.IsReadPrimary(ctx) {
+		return o.db
+	}
+
+	idx := atomic.AddUint32(&o.replicaIdx, 1)
+	return o.replicas[idx%uint32(len(o.replicas))]
+}
+
+// observeCmd record the latency of the orm command to metrics, and log it if it is a slow command or the
+// request enables sql trace.
+func (o *runtimeOrm) observeCmd(ctx context.Context, cmd string, sql string, arg interface{},
+	latency time.Duration) {
+
+	table := parseTableName(sql)
+	o.mc.cmdLagMS.With(prm.Labels{"cmd": cmd}).Observe(float64(latency.Milliseconds()))
+	o.mc.tableCmdLagMS.With(prm.Labels{"table": table, "cmd": cmd}).Observe(float64(latency.Milliseconds()))
+
+	o.stats.record(cmd, table, sql, latency, o.slowRequestMS)
+	o.logSlowCmd(ctx, cmd, table, sql, arg, latency)
+	traceCmd(ctx, cmd, table, sql, arg, latency)
+}
+
+func (o *runtimeOrm) logSlowCmd(ctx context.Context, cmd, table, sql string, arg interface{},
+	latency time.Duration) {
+
 	if latency < o.slowRequestMS {
 		return
 	}
 
+	o.mc.slowCmdCounter.With(prm.Labels{"table": table, "cmd": cmd}).Inc()
+
 	if !o.logLimiter.Allow() {
 		// if the log rate have already exceeded the limit, then skip the log.
 		// we do this to avoid write lots of log to file and slow down the request.
@@ -124,7 +199,39 @@ func (o *runtimeOrm) logSlowCmd(ctx context.Context, sql string, latency time.Du
 	}
 
 	rid := ctx.Value(constant.RidKey)
-	logs.InfoDepthf(2, "[orm slow log], sql: %s, latency: %d ms, rid: %v", sql, latency.Milliseconds(), rid)
+	// only the named args of the query is logged, the inserted data is too large to be logged.
+	if argMap, ok := arg.(map[string]interface{}); o.slowLogWithArgs && ok && len(argMap) != 0 {
+		logs.InfoDepthf(3, "[orm slow log], table: %s, cmd: %s, sql: %s, args: %+v, latency: %d ms, rid: %v",
+			table, cmd, sql, argMap, latency.Milliseconds(), rid)
+		return
+	}
+
+	logs.InfoDepthf(3, "[orm slow log], table: %s, cmd: %s, sql: %s, latency: %d ms, rid: %v", table, cmd, sql,
+		latency.Milliseconds(), rid)
+}
+
+// traceCmd log the orm command with its args and latency if the request enables sql trace, it is not limited
+// by the log limiter, because the trace is enabled for a single request explicitly.
+func traceCmd(ctx context.Context, cmd, table, sql string, arg interface{}, latency time.Duration) {
+	if trace, ok := ctx.Value(constant.SQLTraceKey).(bool); !ok || !trace {
+		return
+	}
+
+	logs.InfoDepthf(3, "[orm sql trace], table: %s, cmd: %s, sql: %s, args: %+v, latency: %d ms, rid: %v",
+		table, cmd, sql, arg, latency.Milliseconds(), ctx.Value(constant.RidKey))
+}
+
+// tableNameRegexp matches the first table name that the sql operates on.
+var tableNameRegexp = regexp.MustCompile("(?i)\\b(?:FROM|INTO|UPDATE)\\s+(?:`?[a-zA-Z0-9_]+`?\\.)?`?([a-zA-Z0-9_]+)`?")
+
+// parseTableName parse the table name from sql, which is used as metrics label.
+func parseTableName(sql string) string {
+	match := tableNameRegexp.FindStringSubmatch(sql)
+	if len(match) < 2 {
+		return "unknown"
+	}
+
+	return strings.ToLower(match[1])
 }
 
 // tryAccept is used to test if the incoming orm request can be accepted.
@@ -163,9 +270,42 @@ type TxnFunc func(txn *sqlx.Tx, opt *TxnOption) (interface{}, error)
 // transaction in the AutoTxn processes.
 type TxnOption struct{}
 
+var (
+	// afterCommitHooks the hooks to run after the transaction is committed, key is the transaction.
+	afterCommitHooks = make(map[*sqlx.Tx][]func())
+	hookLock         sync.Mutex
+)
+
+// AfterCommit register a hook which runs after the transaction launched by AutoTxn is committed, the hook
+// is discarded if the transaction is rolled back, so that the side effects only happen on persisted data.
+func AfterCommit(txn *sqlx.Tx, hook func()) {
+	hookLock.Lock()
+	afterCommitHooks[txn] = append(afterCommitHooks[txn], hook)
+	hookLock.Unlock()
+}
+
+// popAfterCommitHooks remove and return the hooks of the transaction.
+func popAfterCommitHooks(txn *sqlx.Tx) []func() {
+	hookLock.Lock()
+	hooks := afterCommitHooks[txn]
+	delete(afterCommitHooks, txn)
+	hookLock.Unlock()
+
+	return hooks
+}
+
 // ErrRetryTransaction defines errors that need to retry transaction, like deadlock error in upsert scenario
 var ErrRetryTransaction = errors.New("RETRY TRANSACTION ERROR")
 
+// maxTxnRetryCount is the maximum retry count of the transaction which hits retryable error.
+const maxTxnRetryCount = 3
+
+// isTxnRetryable test if the transaction need to be retried, the mysql deadlock and lock wait timeout error
+// is retried automatically, because bulk sync writes may deadlock against the concurrent user edits.
+func isTxnRetryable(err error) bool {
+	return err == ErrRetryTransaction || errf.IsTxnRetryable(err)
+}
+
 // AutoTxn is a wrapper to do all the transaction operations as follows:
 // 1. auto launch the transaction
 // 2. process the logics, which is a callback run function
@@ -186,8 +326,8 @@ func (o *runtimeOrm) AutoTxn(kit *kit.Kit, run TxnFunc) (interface{}, error) {
 	}
 
 	// if the operation need to retry, retry for at most 3 times, each wait for 50~500ms
-	for retryCount := 1; retryCount <= 3; retryCount++ {
-		logs.Warnf("retry transaction, retry count: %d, rid: %s", retryCount, kit.Rid)
+	for retryCount := 1; retryCount <= maxTxnRetryCount; retryCount++ {
+		logs.Warnf("retry transaction, retry count: %d, err: %v, rid: %s", retryCount, err, kit.Rid)
 		rand.Seed(time.Now().UnixNano())
 		time.Sleep(time.Millisecond * time.Duration(rand.Intn(450)+50))
 
@@ -212,33 +352,60 @@ func (o *runtimeOrm) autoTxn(kit *kit.Kit, run TxnFunc) (bool, interface{}, erro
 		return false, nil, errors.New("transaction function is nil")
 	}
 
-	txn, err := o.db.BeginTxx(kit.Ctx, new(sql.TxOptions))
+	// 事务超时后database/sql会自动回滚事务，事务内后续的sql返回错误
+	txnCtx, cancel := o.writeCtx(kit.Ctx)
+	defer cancel()
+
+	txn, err := o.db.BeginTxx(txnCtx, new(sql.TxOptions))
 	if err != nil {
 		return false, nil, fmt.Errorf("auto txn, but begin txn failed, err: %v", err)
 	}
 
 	result, err := run(txn, new(TxnOption))
 	if err != nil {
+		popAfterCommitHooks(txn)
 		if rollErr := txn.Rollback(); rollErr != nil {
 			logs.ErrorDepthf(1, "run sharding one transaction rollback failed, err: %v, rid: %v", rollErr, kit.Rid)
 			// do not return error. the transaction will be aborted automatically after timeout.
 			// mysql transaction's default timeout is 50s.
 		}
 
-		if err == ErrRetryTransaction {
-			return true, nil, err
-		}
-
-		return false, nil, err
+		return isTxnRetryable(err), nil, err
 	}
 
+	hooks := popAfterCommitHooks(txn)
 	if err := txn.Commit(); err != nil {
-		return false, nil, fmt.Errorf("commit sharding transaction failed, err: %v", err)
+		return errf.IsTxnRetryable(err), nil, fmt.Errorf("commit sharding transaction failed, err: %w", err)
+	}
+
+	for _, hook := range hooks {
+		hook()
 	}
 
 	return false, result, nil
 }
 
+// SnapshotTxn run the read only logics in a consistent snapshot transaction.
+func (o *runtimeOrm) SnapshotTxn(kit *kit.Kit, run TxnFunc) (interface{}, error) {
+	if run == nil {
+		return nil, errors.New("transaction function is nil")
+	}
+
+	txn, err := o.db.BeginTxx(kit.Ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("begin snapshot txn failed, err: %v", err)
+	}
+
+	// the transaction is read only, so it is always rolled back after the logics is done.
+	defer func() {
+		if rollErr := txn.Rollback(); rollErr != nil {
+			logs.Errorf("rollback snapshot transaction failed, err: %v, rid: %s", rollErr, kit.Rid)
+		}
+	}()
+
+	return run(txn, new(TxnOption))
+}
+
 // TableSharding ...
 func (o *runtimeOrm) TableSharding(opts ...TableShardingOpt) Interface {
 	return &tableShardingOrm{
@@ -248,6 +415,16 @@ func (o *runtimeOrm) TableSharding(opts ...TableShardingOpt) Interface {
 
 }
 
+// QueryStats returns the captured query stats.
+func (o *runtimeOrm) QueryStats() []QueryStat {
+	return o.stats.list()
+}
+
+// ResetQueryStats clear all the captured query stats.
+func (o *runtimeOrm) ResetQueryStats() {
+	o.stats.reset()
+}
+
 // tableShardingOrm orm for table sharding, it replaces table name in sql query
 type tableShardingOrm struct {
 	orm               *runtimeOrm
@@ -278,6 +455,23 @@ func (dt *tableShardingDoTxn) Select(ctx context.Context, dest interface{}, expr
 	return dt.doTxn.Select(ctx, dest, replaced, arg)
 }
 
+// SelectWithCount ...
+func (dt *tableShardingDoTxn) SelectWithCount(ctx context.Context, dest interface{}, countExpr, expr string,
+	arg map[string]interface{}) (uint64, error) {
+
+	replacedCount := replaceFromJoinTableName(dt.tableShardingOpts, countExpr)
+	replaced := replaceFromJoinTableName(dt.tableShardingOpts, expr)
+	return dt.doTxn.SelectWithCount(ctx, dest, replacedCount, replaced, arg)
+}
+
+// SelectRows ...
+func (dt *tableShardingDoTxn) SelectRows(ctx context.Context, expr string, arg map[string]interface{},
+	handler RowsHandler) error {
+
+	replaced := replaceFromJoinTableName(dt.tableShardingOpts, expr)
+	return dt.doTxn.SelectRows(ctx, replaced, arg, handler)
+}
+
 // Delete ...
 func (dt *tableShardingDoTxn) Delete(ctx context.Context, expr string, arg map[string]interface{}) (int64, error) {
 	replaced := replaceFromJoinTableName(dt.tableShardingOpts, expr)
@@ -344,6 +538,15 @@ func (ds *tableShardingDo) BulkInsert(ctx context.Context, expr string, args int
 	return ds.do.BulkInsert(ctx, replaced, args)
 }
 
+// SelectRows ...
+func (ds *tableShardingDo) SelectRows(ctx context.Context, expr string, arg map[string]interface{},
+	handler RowsHandler) error {
+
+	replaced := replaceFromJoinTableName(ds.tableShardingOpts, expr)
+
+	return ds.do.SelectRows(ctx, replaced, arg, handler)
+}
+
 // Select ...
 func (ds *tableShardingDo) Select(ctx context.Context, dest interface{}, expr string,
 	arg map[string]interface{}) error {
@@ -353,6 +556,16 @@ func (ds *tableShardingDo) Select(ctx context.Context, dest interface{}, expr st
 	return ds.do.Select(ctx, dest, replaced, arg)
 }
 
+// SelectWithCount ...
+func (ds *tableShardingDo) SelectWithCount(ctx context.Context, dest interface{}, countExpr, expr string,
+	arg map[string]interface{}) (uint64, error) {
+
+	replacedCount := replaceFromJoinTableName(ds.tableShardingOpts, countExpr)
+	replaced := replaceFromJoinTableName(ds.tableShardingOpts, expr)
+
+	return ds.do.SelectWithCount(ctx, dest, replacedCount, replaced, arg)
+}
+
 // Do ...
 func (t tableShardingOrm) Do() DoOrm {
 
@@ -378,6 +591,11 @@ func (t tableShardingOrm) AutoTxn(kt *kit.Kit, run TxnFunc) (interface{}, error)
 	return t.orm.AutoTxn(kt, run)
 }
 
+// SnapshotTxn ...
+func (t tableShardingOrm) SnapshotTxn(kt *kit.Kit, run TxnFunc) (interface{}, error) {
+	return t.orm.SnapshotTxn(kt, run)
+}
+
 // TableSharding ...
 func (t tableShardingOrm) TableSharding(opts ...TableShardingOpt) Interface {
 
@@ -386,6 +604,16 @@ func (t tableShardingOrm) TableSharding(opts ...TableShardingOpt) Interface {
 	return t
 }
 
+// QueryStats ...
+func (t tableShardingOrm) QueryStats() []QueryStat {
+	return t.orm.QueryStats()
+}
+
+// ResetQueryStats ...
+func (t tableShardingOrm) ResetQueryStats() {
+	t.orm.ResetQueryStats()
+}
+
 func replaceUpdateTableName(shardingOpts []TableShardingOpt, origin string) (replaced string) {
 	updateTables := updateTableNameRe.FindAllString(origin, -1)
 	if len(updateTables) == 0 {
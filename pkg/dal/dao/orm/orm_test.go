@@ -167,3 +167,40 @@ func (r *AppendSuffixOpt) ReplaceTableName(old string) string {
 	}
 	return old
 }
+
+func Test_parseTableName(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{sql: "SELECT COUNT(*) FROM cvm WHERE id = :id", want: "cvm"},
+		{sql: "select * from `db1`.`security_group` where vendor = :vendor", want: "security_group"},
+		{sql: "INSERT INTO vpc (id, name) VALUES (:id, :name)", want: "vpc"},
+		{sql: "UPDATE `disk` SET name = :name", want: "disk"},
+		{sql: "DELETE FROM eip WHERE id IN (:ids)", want: "eip"},
+		{sql: "SET SESSION foo = 1", want: "unknown"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, parseTableName(tt.sql), tt.sql)
+	}
+}
+
+func Test_parseWhereColumns(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want []string
+	}{
+		{sql: "SELECT COUNT(*) FROM cvm WHERE id = :id", want: []string{"id"}},
+		{sql: "SELECT * FROM cvm WHERE vendor = :vendor AND `account_id` IN (:ids) ORDER BY id LIMIT 10",
+			want: []string{"account_id", "vendor"}},
+		{sql: "SELECT * FROM sg s WHERE s.name LIKE :name OR s.region != :region GROUP BY s.vendor",
+			want: []string{"name", "region"}},
+		{sql: "UPDATE disk SET name = :name WHERE cloud_id >= :cloud_id", want: []string{"cloud_id"}},
+		{sql: "SELECT * FROM vpc", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, parseWhereColumns(tt.sql), tt.sql)
+	}
+}
@@ -23,8 +23,12 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"time"
 
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+
 	"github.com/jmoiron/sqlx"
 	prm "github.com/prometheus/client_golang/prometheus"
 )
@@ -63,7 +67,8 @@ func (do *do) Select(ctx context.Context, dest interface{}, expr string, arg map
 		return err
 	}
 
-	rows, err := do.db.QueryContext(ctx, do.db.Rebind(query), args...)
+	db := do.ro.readDB(ctx)
+	rows, err := db.QueryContext(ctx, db.Rebind(query), args...)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "select"}).Inc()
 		return err
@@ -74,8 +79,58 @@ func (do *do) Select(ctx context.Context, dest interface{}, expr string, arg map
 		return err
 	}
 
-	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "select"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observeCmd(ctx, "select", expr, arg, time.Since(start))
+
+	return nil
+}
+
+// RowsHandler handle the current row of the rows, the iteration stops if it returns error.
+type RowsHandler func(rows *sqlx.Rows) error
+
+// SelectRows execute the query and iterate the rows with handler one by one.
+func (do *do) SelectRows(ctx context.Context, expr string, arg map[string]interface{}, handler RowsHandler) error {
+	if handler == nil {
+		return errors.New("rows handler is required")
+	}
+
+	if err := do.ro.tryAccept(); err != nil {
+		return err
+	}
+
+	start := time.Now()
+
+	query, args, err := sqlx.Named(expr, arg)
+	if err != nil {
+		do.ro.mc.errCounter.With(prm.Labels{"cmd": "select-rows"}).Inc()
+		return err
+	}
+
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		do.ro.mc.errCounter.With(prm.Labels{"cmd": "select-rows"}).Inc()
+		return err
+	}
+
+	db := do.ro.readDB(ctx)
+	rows, err := db.QueryxContext(ctx, db.Rebind(query), args...)
+	if err != nil {
+		do.ro.mc.errCounter.With(prm.Labels{"cmd": "select-rows"}).Inc()
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = handler(rows); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		do.ro.mc.errCounter.With(prm.Labels{"cmd": "select-rows"}).Inc()
+		return err
+	}
+
+	do.ro.observeCmd(ctx, "select-rows", expr, arg, time.Since(start))
 
 	return nil
 }
@@ -100,7 +155,8 @@ func (do *do) Count(ctx context.Context, expr string, arg map[string]interface{}
 		return 0, err
 	}
 
-	rows, err := do.db.QueryContext(ctx, do.db.Rebind(query), args...)
+	db := do.ro.readDB(ctx)
+	rows, err := db.QueryContext(ctx, db.Rebind(query), args...)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "count"}).Inc()
 		return 0, err
@@ -114,12 +170,52 @@ func (do *do) Count(ctx context.Context, expr string, arg map[string]interface{}
 		}
 	}
 
-	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "count"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observeCmd(ctx, "count", expr, arg, time.Since(start))
 
 	return count, nil
 }
 
+// PageSelector is the orm that selects a page of data, both DoOrm and DoOrmWithTransaction implement it.
+type PageSelector interface {
+	Select(ctx context.Context, dest interface{}, expr string, arg map[string]interface{}) error
+	SelectWithCount(ctx context.Context, dest interface{}, countExpr, expr string, arg map[string]interface{}) (
+		uint64, error)
+}
+
+// SelectPage select a page of data into dest with expr. if withCount is true, the total is counted with countExpr
+// in the same read only snapshot as the page (or in the caller's transaction), so that the total is consistent
+// with the details. otherwise, only the page is selected and the returned count is 0.
+func SelectPage(ctx context.Context, do PageSelector, withCount bool, dest interface{}, countExpr, expr string,
+	arg map[string]interface{}) (uint64, error) {
+
+	if !withCount {
+		return 0, do.Select(ctx, dest, expr, arg)
+	}
+
+	return do.SelectWithCount(ctx, dest, countExpr, expr, arg)
+}
+
+// SelectWithCount count the total and select a page of data in one read only transaction.
+func (do *do) SelectWithCount(ctx context.Context, dest interface{}, countExpr, expr string,
+	arg map[string]interface{}) (uint64, error) {
+
+	// the snapshot of repeatable read transaction is shared by the count and select sql.
+	txn, err := do.ro.readDB(ctx).BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		do.ro.mc.errCounter.With(prm.Labels{"cmd": "select-with-count"}).Inc()
+		return 0, fmt.Errorf("begin read only transaction failed, err: %v", err)
+	}
+
+	// the transaction is read only, so it is always rolled back after the queries are done.
+	defer func() {
+		if rollErr := txn.Rollback(); rollErr != nil {
+			logs.Errorf("rollback read only transaction failed, err: %v", rollErr)
+		}
+	}()
+
+	return (&doTxn{tx: txn, ro: do.ro}).SelectWithCount(ctx, dest, countExpr, expr, arg)
+}
+
 // Delete a collection of data.
 func (do *do) Delete(ctx context.Context, expr string, arg map[string]interface{}) (int64, error) {
 	if err := do.ro.tryAccept(); err != nil {
@@ -140,10 +236,13 @@ func (do *do) Delete(ctx context.Context, expr string, arg map[string]interface{
 		return 0, err
 	}
 
+	ctx, cancel := do.ro.writeCtx(ctx)
+	defer cancel()
+
 	result, err := do.db.ExecContext(ctx, do.db.Rebind(query), args...)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "delete"}).Inc()
-		return 0, err
+		return 0, errf.ConvConstraintError(err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -152,8 +251,7 @@ func (do *do) Delete(ctx context.Context, expr string, arg map[string]interface{
 		return 0, err
 	}
 
-	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "delete"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observeCmd(ctx, "delete", expr, arg, time.Since(start))
 
 	return rowsAffected, nil
 }
@@ -182,10 +280,13 @@ func (do *do) Update(ctx context.Context, expr string, arg map[string]interface{
 		return 0, err
 	}
 
+	ctx, cancel := do.ro.writeCtx(ctx)
+	defer cancel()
+
 	result, err := do.db.ExecContext(ctx, do.db.Rebind(query), args...)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "update"}).Inc()
-		return 0, err
+		return 0, errf.ConvConstraintError(err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -194,8 +295,7 @@ func (do *do) Update(ctx context.Context, expr string, arg map[string]interface{
 		return 0, err
 	}
 
-	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "update"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observeCmd(ctx, "update", expr, arg, time.Since(start))
 
 	return rowsAffected, nil
 }
@@ -208,14 +308,16 @@ func (do *do) Insert(ctx context.Context, expr string, data interface{}) error {
 
 	start := time.Now()
 
+	ctx, cancel := do.ro.writeCtx(ctx)
+	defer cancel()
+
 	_, err := do.db.NamedExecContext(ctx, expr, data)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "insert"}).Inc()
-		return err
+		return errf.ConvConstraintError(err)
 	}
 
-	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "insert"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observeCmd(ctx, "insert", expr, data, time.Since(start))
 
 	return nil
 }
@@ -228,6 +330,9 @@ func (do *do) Exec(ctx context.Context, expr string) (int64, error) {
 
 	start := time.Now()
 
+	ctx, cancel := do.ro.writeCtx(ctx)
+	defer cancel()
+
 	result, err := do.db.ExecContext(ctx, expr)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "exec"}).Inc()
@@ -240,8 +345,7 @@ func (do *do) Exec(ctx context.Context, expr string) (int64, error) {
 		return 0, err
 	}
 
-	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "exec"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observeCmd(ctx, "exec", expr, nil, time.Since(start))
 
 	return effected, nil
 }
@@ -255,14 +359,16 @@ func (do *do) BulkInsert(ctx context.Context, expr string, args interface{}) err
 
 	start := time.Now()
 
+	ctx, cancel := do.ro.writeCtx(ctx)
+	defer cancel()
+
 	_, err := do.db.NamedExecContext(ctx, expr, args)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "bulk-insert"}).Inc()
-		return err
+		return errf.ConvConstraintError(err)
 	}
 
-	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "bulk-insert"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observeCmd(ctx, "bulk-insert", expr, args, time.Since(start))
 
 	return nil
 }
@@ -308,8 +414,7 @@ func (do *doTxn) Count(ctx context.Context, expr string, arg map[string]interfac
 		}
 	}
 
-	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "count"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observeCmd(ctx, "count", expr, arg, time.Since(start))
 
 	return count, nil
 }
@@ -345,8 +450,72 @@ func (do *doTxn) Select(ctx context.Context, dest interface{}, expr string, arg
 		return err
 	}
 
-	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "select"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observeCmd(ctx, "select", expr, arg, time.Since(start))
+
+	return nil
+}
+
+// SelectWithCount count the total and select a page of data in the transaction.
+func (do *doTxn) SelectWithCount(ctx context.Context, dest interface{}, countExpr, expr string,
+	arg map[string]interface{}) (uint64, error) {
+
+	count, err := do.Count(ctx, countExpr, arg)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = do.Select(ctx, dest, expr, arg); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// SelectRows execute the query with transaction and iterate the rows with handler one by one.
+func (do *doTxn) SelectRows(ctx context.Context, expr string, arg map[string]interface{},
+	handler RowsHandler) error {
+
+	if handler == nil {
+		return errors.New("rows handler is required")
+	}
+
+	if err := do.ro.tryAccept(); err != nil {
+		return err
+	}
+
+	start := time.Now()
+
+	query, args, err := sqlx.Named(expr, arg)
+	if err != nil {
+		do.ro.mc.errCounter.With(prm.Labels{"cmd": "select-rows"}).Inc()
+		return err
+	}
+
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		do.ro.mc.errCounter.With(prm.Labels{"cmd": "select-rows"}).Inc()
+		return err
+	}
+
+	rows, err := do.tx.QueryxContext(ctx, do.tx.Rebind(query), args...)
+	if err != nil {
+		do.ro.mc.errCounter.With(prm.Labels{"cmd": "select-rows"}).Inc()
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = handler(rows); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		do.ro.mc.errCounter.With(prm.Labels{"cmd": "select-rows"}).Inc()
+		return err
+	}
+
+	do.ro.observeCmd(ctx, "select-rows", expr, arg, time.Since(start))
 
 	return nil
 }
@@ -374,7 +543,7 @@ func (do *doTxn) Delete(ctx context.Context, expr string, arg map[string]interfa
 	result, err := do.tx.ExecContext(ctx, do.tx.Rebind(query), args...)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "delete"}).Inc()
-		return 0, err
+		return 0, errf.ConvConstraintError(err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -383,8 +552,7 @@ func (do *doTxn) Delete(ctx context.Context, expr string, arg map[string]interfa
 		return 0, err
 	}
 
-	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "delete"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observeCmd(ctx, "delete", expr, arg, time.Since(start))
 
 	return rowsAffected, nil
 }
@@ -404,11 +572,10 @@ func (do *doTxn) Insert(ctx context.Context, expr string, args interface{}) erro
 	_, err := do.tx.NamedExecContext(ctx, expr, args)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "insert"}).Inc()
-		return err
+		return errf.ConvConstraintError(err)
 	}
 
-	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "insert"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observeCmd(ctx, "insert", expr, args, time.Since(start))
 
 	return nil
 }
@@ -425,11 +592,10 @@ func (do *doTxn) BulkInsert(ctx context.Context, expr string, args interface{})
 	_, err := do.tx.NamedExecContext(ctx, expr, args)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "bulk-insert"}).Inc()
-		return err
+		return errf.ConvConstraintError(err)
 	}
 
-	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "bulk-insert"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observeCmd(ctx, "bulk-insert", expr, args, time.Since(start))
 
 	return nil
 }
@@ -461,7 +627,7 @@ func (do *doTxn) Update(ctx context.Context, expr string, arg map[string]interfa
 	result, err := do.tx.ExecContext(ctx, do.tx.Rebind(query), args...)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "update"}).Inc()
-		return 0, err
+		return 0, errf.ConvConstraintError(err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -470,8 +636,7 @@ func (do *doTxn) Update(ctx context.Context, expr string, arg map[string]interfa
 		return 0, err
 	}
 
-	do.ro.logSlowCmd(ctx, expr, time.Since(start))
-	do.ro.mc.cmdLagMS.With(prm.Labels{"cmd": "update"}).Observe(float64(time.Since(start).Milliseconds()))
+	do.ro.observeCmd(ctx, "update", expr, arg, time.Since(start))
 
 	return rowsAffected, nil
 }
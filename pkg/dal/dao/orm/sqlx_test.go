@@ -0,0 +1,152 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package orm
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/kit"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeItem struct {
+	ID   string `db:"id"`
+	Name string `db:"name"`
+}
+
+func TestSelectWithCount(t *testing.T) {
+	primary, primaryDB := newFakeDB(t, "select_with_count_primary")
+	replica, replicaDB := newFakeDB(t, "select_with_count_replica")
+	ro := InitOrm(primary, ReadReplicas(replica), MetricsRegisterer(prometheus.NewRegistry()))
+
+	details := make([]fakeItem, 0)
+	count, err := ro.Do().SelectWithCount(context.Background(), &details, "SELECT COUNT(*) FROM vpc",
+		"SELECT id, name FROM vpc LIMIT 2", map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+	assert.Equal(t, []fakeItem{{ID: "1", Name: "a"}, {ID: "2", Name: "b"}}, details)
+
+	// count and select are executed on the same replica connection in one read only snapshot.
+	assert.Empty(t, primaryDB.executed())
+	execs := replicaDB.executed()
+	assert.Len(t, execs, 2)
+	for _, e := range execs {
+		assert.Equal(t, execs[0].conn, e.conn)
+		assert.True(t, e.inTxn)
+		assert.True(t, e.readOnly)
+		assert.Equal(t, driver.IsolationLevel(sql.LevelRepeatableRead), e.isolation)
+	}
+	assert.Equal(t, 1, replicaDB.rollbacks)
+}
+
+func TestSelectPage(t *testing.T) {
+	primary, primaryDB := newFakeDB(t, "select_page_primary")
+	ro := InitOrm(primary, MetricsRegisterer(prometheus.NewRegistry()))
+
+	// a row is inserted by another request right after each count query, before the page is selected.
+	primaryDB.afterQuery = func() {
+		execs := primaryDB.executed()
+		if strings.Contains(execs[len(execs)-1].query, "COUNT(*)") {
+			primaryDB.insert([]driver.Value{"new", "c"})
+		}
+	}
+
+	// count and details are read from the same snapshot, the row inserted after the count is not selected.
+	details := make([]fakeItem, 0)
+	count, err := SelectPage(context.Background(), ro.Do(), true, &details, "SELECT COUNT(*) FROM vpc",
+		"SELECT id, name FROM vpc", map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+	assert.Len(t, details, int(count))
+
+	// reading them without the snapshot gets a total inconsistent with the details.
+	count, err = ro.Do().Count(context.Background(), "SELECT COUNT(*) FROM vpc", map[string]interface{}{})
+	assert.NoError(t, err)
+	details = make([]fakeItem, 0)
+	err = ro.Do().Select(context.Background(), &details, "SELECT id, name FROM vpc", map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+	assert.Len(t, details, 4)
+
+	// without count, only the page is selected.
+	execs := len(primaryDB.executed())
+	details = make([]fakeItem, 0)
+	count, err = SelectPage(context.Background(), ro.Do(), false, &details, "SELECT COUNT(*) FROM vpc",
+		"SELECT id, name FROM vpc", map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), count)
+	assert.Len(t, details, 4)
+	assert.Len(t, primaryDB.executed(), execs+1)
+}
+
+func TestReadDB(t *testing.T) {
+	primary, _ := newFakeDB(t, "read_db_primary")
+	replica1, _ := newFakeDB(t, "read_db_replica1")
+	replica2, _ := newFakeDB(t, "read_db_replica2")
+
+	// read requests are executed on primary if no replica is configured.
+	ro := InitOrm(primary, MetricsRegisterer(prometheus.NewRegistry())).(*runtimeOrm)
+	assert.Same(t, primary, ro.readDB(context.Background()))
+
+	// read requests are routed to the replicas in round-robin.
+	ro = InitOrm(primary, ReadReplicas(replica1, replica2), MetricsRegisterer(prometheus.NewRegistry())).(*runtimeOrm)
+	first := ro.readDB(context.Background())
+	second := ro.readDB(context.Background())
+	assert.NotSame(t, primary, first)
+	assert.NotSame(t, primary, second)
+	assert.NotSame(t, first, second)
+	assert.Same(t, first, ro.readDB(context.Background()))
+
+	// read requests forced to read primary are executed on primary.
+	kt := kit.New().WithReadPrimary()
+	assert.Same(t, primary, ro.readDB(kt.Ctx))
+	assert.Same(t, second, ro.readDB(context.Background()))
+
+	// the header name used as a plain context key can not force primary reads.
+	ctx := context.WithValue(context.Background(), constant.ReadPrimaryKey, true)
+	assert.NotSame(t, primary, ro.readDB(ctx))
+}
+
+func TestReadPrimary(t *testing.T) {
+	primary, primaryDB := newFakeDB(t, "read_primary_primary")
+	replica, replicaDB := newFakeDB(t, "read_primary_replica")
+	ro := InitOrm(primary, ReadReplicas(replica), MetricsRegisterer(prometheus.NewRegistry()))
+
+	count, err := ro.Do().Count(context.Background(), "SELECT COUNT(*) FROM vpc", map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+	assert.Empty(t, primaryDB.executed())
+	assert.Len(t, replicaDB.executed(), 1)
+
+	details := make([]fakeItem, 0)
+	kt := kit.New().WithReadPrimary()
+	err = ro.Do().Select(kt.Ctx, &details, "SELECT id, name FROM vpc", map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, details, 2)
+	assert.Len(t, primaryDB.executed(), 1)
+	assert.Len(t, replicaDB.executed(), 1)
+}
@@ -0,0 +1,161 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package orm
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxQueryStats the max number of query stats to capture, the stats beyond it are dropped to avoid
+// unlimited memory usage when the sql is generated with inline values.
+const maxQueryStats = 2000
+
+// QueryStat the captured stat of the query with the same table, command and filter columns.
+type QueryStat struct {
+	Table string `json:"table"`
+	Cmd   string `json:"cmd"`
+	// Columns the columns used in where condition, sorted and de-duplicated.
+	Columns        []string `json:"columns"`
+	Count          uint64   `json:"count"`
+	SlowCount      uint64   `json:"slow_count"`
+	TotalLatencyMS int64    `json:"total_latency_ms"`
+	MaxLatencyMS   int64    `json:"max_latency_ms"`
+	// Sample the sql of the latest slow query, or the first query if no query is slow.
+	Sample string `json:"sample"`
+}
+
+// queryStats captures the query stats in memory, which is used to find slow filters and missing indexes.
+type queryStats struct {
+	lock  sync.Mutex
+	stats map[string]*QueryStat
+}
+
+func newQueryStats() *queryStats {
+	return &queryStats{stats: make(map[string]*QueryStat)}
+}
+
+// statCmds the commands whose stats are captured, only the commands with where condition are useful.
+var statCmds = map[string]struct{}{
+	"select":      {},
+	"select-rows": {},
+	"count":       {},
+	"update":      {},
+	"delete":      {},
+}
+
+func (q *queryStats) record(cmd, table, sql string, latency, slow time.Duration) {
+	if _, exists := statCmds[cmd]; !exists {
+		return
+	}
+
+	columns := parseWhereColumns(sql)
+	key := table + "|" + cmd + "|" + strings.Join(columns, ",")
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	stat, exists := q.stats[key]
+	if !exists {
+		if len(q.stats) >= maxQueryStats {
+			return
+		}
+		stat = &QueryStat{Table: table, Cmd: cmd, Columns: columns, Sample: sql}
+		q.stats[key] = stat
+	}
+
+	stat.Count++
+	stat.TotalLatencyMS += latency.Milliseconds()
+	if latency.Milliseconds() > stat.MaxLatencyMS {
+		stat.MaxLatencyMS = latency.Milliseconds()
+	}
+	if latency >= slow {
+		stat.SlowCount++
+		stat.Sample = sql
+	}
+}
+
+// list returns the copy of all the captured stats, sorted by slow count and total latency in descending order.
+func (q *queryStats) list() []QueryStat {
+	q.lock.Lock()
+	result := make([]QueryStat, 0, len(q.stats))
+	for _, stat := range q.stats {
+		one := *stat
+		one.Columns = append([]string(nil), stat.Columns...)
+		result = append(result, one)
+	}
+	q.lock.Unlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].SlowCount != result[j].SlowCount {
+			return result[i].SlowCount > result[j].SlowCount
+		}
+		return result[i].TotalLatencyMS > result[j].TotalLatencyMS
+	})
+
+	return result
+}
+
+func (q *queryStats) reset() {
+	q.lock.Lock()
+	q.stats = make(map[string]*QueryStat)
+	q.lock.Unlock()
+}
+
+var (
+	// whereClauseRegexp matches the where clause of the sql, ends with group by, order by, limit or the end.
+	whereClauseRegexp = regexp.MustCompile(`(?is)\bWHERE\b(.*?)(?:\bGROUP\s+BY\b|\bORDER\s+BY\b|\bLIMIT\b|$)`)
+	// whereColumnRegexp matches the column compared in where clause, the table alias prefix is ignored.
+	whereColumnRegexp = regexp.MustCompile("(?i)(?:[a-z0-9_]+\\.)?`?([a-z_][a-z0-9_]*)`?\\s*" +
+		"(?:=|!=|<>|>=|<=|>|<|\\bNOT\\s+IN\\b|\\bIN\\b|\\bNOT\\s+LIKE\\b|\\bLIKE\\b|\\bIS\\b)")
+)
+
+// sqlKeywords the keywords which may be matched as column by whereColumnRegexp.
+var sqlKeywords = map[string]struct{}{"and": {}, "or": {}, "not": {}, "where": {}, "select": {}, "null": {}}
+
+// parseWhereColumns parse the columns used in the where clause of the sql, named args are not treated as columns.
+func parseWhereColumns(sql string) []string {
+	columnMap := make(map[string]struct{})
+	for _, clause := range whereClauseRegexp.FindAllStringSubmatch(sql, -1) {
+		for _, match := range whereColumnRegexp.FindAllStringSubmatchIndex(clause[1], -1) {
+			// skip named args like :name, they are values but not columns.
+			if match[0] > 0 && clause[1][match[0]-1] == ':' {
+				continue
+			}
+
+			column := strings.ToLower(clause[1][match[2]:match[3]])
+			if _, isKeyword := sqlKeywords[column]; isKeyword {
+				continue
+			}
+			columnMap[column] = struct{}{}
+		}
+	}
+
+	columns := make([]string, 0, len(columnMap))
+	for column := range columnMap {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	return columns
+}
@@ -0,0 +1,264 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package quota biz resource quota dao.
+package quota
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tablequota "hcm/pkg/dal/table/quota"
+	"hcm/pkg/dal/table/utils"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+)
+
+// BizQuotaInterface only used for biz quota.
+type BizQuotaInterface interface {
+	Create(kt *kit.Kit, model *tablequota.BizQuotaTable) (string, error)
+	UpdateByID(kt *kit.Kit, id string, model *tablequota.BizQuotaTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tablequota.BizQuotaTable], error)
+	Delete(kt *kit.Kit, expr *filter.Expression) error
+	ListUsage(kt *kit.Kit, bizIDs []int64) ([]BizResUsage, error)
+}
+
+var _ BizQuotaInterface = new(BizQuotaDao)
+
+// BizQuotaDao biz quota dao.
+type BizQuotaDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// Create biz quota.
+func (dao BizQuotaDao) Create(kt *kit.Kit, model *tablequota.BizQuotaTable) (string, error) {
+	if model == nil {
+		return "", errf.New(errf.InvalidParameter, "model is required")
+	}
+
+	id, err := dao.IDGen.One(kt, table.BizQuotaTable)
+	if err != nil {
+		return "", err
+	}
+	model.ID = id
+	model.TenantID = kt.GetTenantID()
+
+	if err = model.InsertValidate(); err != nil {
+		return "", err
+	}
+
+	sql := tablequota.BizQuotaColumns.InsertExpr(string(model.TableName()))
+
+	if err = dao.Orm.Do().Insert(kt.Ctx, sql, model); err != nil {
+		if errf.IsDuplicated(err) {
+			return "", err
+		}
+		logs.Errorf("insert %s failed, err: %v, sql: %s, rid: %s", model.TableName(), err, sql, kt.Rid)
+		return "", fmt.Errorf("insert %s failed, err: %w", model.TableName(), err)
+	}
+
+	return id, nil
+}
+
+// UpdateByID update biz quota by id.
+func (dao BizQuotaDao) UpdateByID(kt *kit.Kit, id string, model *tablequota.BizQuotaTable) error {
+	if len(id) == 0 {
+		return errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := model.UpdateValidate(); err != nil {
+		return err
+	}
+
+	opts := utils.NewFieldOptions().AddIgnoredFields(types.DefaultIgnoredFields...)
+	setExpr, toUpdate, err := utils.RearrangeSQLDataWithOption(model, opts)
+	if err != nil {
+		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id%s`, model.TableName(), setExpr,
+		tools.TenantSqlCondition(kt, "tenant_id", toUpdate))
+
+	toUpdate["id"] = id
+	effected, err := dao.Orm.Do().Update(kt.Ctx, sql, toUpdate)
+	if err != nil {
+		logs.ErrorJson("update biz quota failed, err: %v, id: %s, rid: %v", err, id, kt.Rid)
+		return err
+	}
+
+	if effected == 0 {
+		return errf.Newf(errf.RecordNotFound, "biz quota: %s not found", id)
+	}
+
+	return nil
+}
+
+// List biz quota.
+func (dao BizQuotaDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tablequota.BizQuotaTable], error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tablequota.BizQuotaColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is dao count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.BizQuotaTable, whereExpr)
+
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count biz quota failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[tablequota.BizQuotaTable]{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tablequota.BizQuotaColumns.FieldsNamedExpr(opt.Fields),
+		table.BizQuotaTable, whereExpr, pageExpr)
+
+	details := make([]tablequota.BizQuotaTable, 0)
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.BizQuotaTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
+		logs.Errorf("select biz quota failed, err: %v, sql: %s, rid: %s", err, sql, kt.Rid)
+		return nil, err
+	}
+
+	return &types.ListResult[tablequota.BizQuotaTable]{Count: count, Details: details}, nil
+}
+
+// Delete biz quota.
+func (dao BizQuotaDao) Delete(kt *kit.Kit, expr *filter.Expression) error {
+	if expr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.BizQuotaTable, whereExpr)
+	if _, err = dao.Orm.Do().Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete biz quota failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// BizResUsage 业务已使用的资源量，根据主机、弹性IP资源表统计
+type BizResUsage struct {
+	BkBizID  int64 `db:"bk_biz_id" json:"bk_biz_id"`
+	CvmCount int64 `db:"cvm_count" json:"cvm_count"`
+	CpuCore  int64 `db:"cpu_core" json:"cpu_core"`
+	EipCount int64 `db:"eip_count" json:"eip_count"`
+}
+
+// cvmCpuCoreExpr 各云厂商主机扩展字段中vCPU核数的表达式，gcp主机未记录核数，不计入统计
+var cvmCpuCoreExpr = map[enumor.Vendor]string{
+	enumor.TCloud: "JSON_EXTRACT(extension, '$.cpu')",
+	enumor.HuaWei: "JSON_UNQUOTE(JSON_EXTRACT(extension, '$.flavor.vcpus'))",
+	enumor.Azure:  "JSON_EXTRACT(extension, '$.hardware_profile.vm_size_properties.vcpus_available')",
+	enumor.Aws: "JSON_EXTRACT(extension, '$.cpu_options.core_count') * " +
+		"JSON_EXTRACT(extension, '$.cpu_options.threads_per_core')",
+}
+
+// CvmCpuCoreExpr return the sql expression to get vCPU core count of a cvm from cvm table by vendor.
+func CvmCpuCoreExpr() string {
+	cases := make([]string, 0, len(cvmCpuCoreExpr))
+	for vendor, expr := range cvmCpuCoreExpr {
+		cases = append(cases, fmt.Sprintf("WHEN '%s' THEN IFNULL(%s, 0)", vendor, expr))
+	}
+	return fmt.Sprintf("CASE vendor %s ELSE 0 END", strings.Join(cases, " "))
+}
+
+// ListUsage list biz resource usage counted from cvm and eip tables, biz without resources is not returned.
+func (dao BizQuotaDao) ListUsage(kt *kit.Kit, bizIDs []int64) ([]BizResUsage, error) {
+	if len(bizIDs) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "biz ids is required")
+	}
+
+	whereExpr, whereValue, err := tools.ContainersExpression("bk_biz_id", bizIDs).
+		SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return nil, err
+	}
+
+	cvmSql := fmt.Sprintf(`SELECT bk_biz_id, COUNT(*) AS cvm_count, CAST(IFNULL(SUM(%s), 0) AS SIGNED) AS cpu_core
+		FROM %s %s GROUP BY bk_biz_id`, CvmCpuCoreExpr(), table.CvmTable, whereExpr)
+
+	cvmUsages := make([]BizResUsage, 0)
+	if err = dao.Orm.Do().Select(kt.Ctx, &cvmUsages, cvmSql, whereValue); err != nil {
+		logs.Errorf("count biz cvm usage failed, err: %v, sql: %s, rid: %s", err, cvmSql, kt.Rid)
+		return nil, err
+	}
+
+	eipSql := fmt.Sprintf(`SELECT bk_biz_id, COUNT(*) AS eip_count FROM %s %s GROUP BY bk_biz_id`, table.EipTable,
+		whereExpr)
+
+	eipUsages := make([]BizResUsage, 0)
+	if err = dao.Orm.Do().Select(kt.Ctx, &eipUsages, eipSql, whereValue); err != nil {
+		logs.Errorf("count biz eip usage failed, err: %v, sql: %s, rid: %s", err, eipSql, kt.Rid)
+		return nil, err
+	}
+
+	usageMap := make(map[int64]*BizResUsage, len(cvmUsages))
+	for i := range cvmUsages {
+		usageMap[cvmUsages[i].BkBizID] = &cvmUsages[i]
+	}
+	for _, one := range eipUsages {
+		if usage, exists := usageMap[one.BkBizID]; exists {
+			usage.EipCount = one.EipCount
+			continue
+		}
+		usageMap[one.BkBizID] = &BizResUsage{BkBizID: one.BkBizID, EipCount: one.EipCount}
+	}
+
+	usages := make([]BizResUsage, 0, len(usageMap))
+	for _, usage := range usageMap {
+		usages = append(usages, *usage)
+	}
+
+	return usages, nil
+}
@@ -89,18 +89,18 @@ func (r *Dao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, records []rr.RecycleRe
 
 	for idx := range records {
 		records[idx].ID = ids[idx]
+		records[idx].TenantID = kt.GetTenantID()
 		records[idx].TaskID = taskID
 		if err = records[idx].InsertValidate(); err != nil {
 			return "", err
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, records[0].TableName(),
-		rr.RecycleRecordColumns.ColumnExpr(), rr.RecycleRecordColumns.ColonNameExpr())
+	sql := rr.RecycleRecordColumns.InsertExpr(string(records[0].TableName()))
 
 	err = r.orm.Txn(tx).BulkInsert(kt.Ctx, sql, records)
 	if err != nil {
-		return "", fmt.Errorf("insert %s failed, err: %v", records[0].TableName(), err)
+		return "", fmt.Errorf("insert %s failed, err: %w", records[0].TableName(), err)
 	}
 
 	return taskID, nil
@@ -116,7 +116,7 @@ func (r *Dao) Update(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.Expression, re
 		return err
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -156,13 +156,16 @@ func (r *Dao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*filter.SQLW
 		return nil, err
 	}
 
-	whereOpt := tools.DefaultSqlWhereOption
+	whereOpt := tools.TenantSqlWhereOption(kt)
 	if len(whereOpts) != 0 && whereOpts[0] != nil {
 		err := whereOpts[0].Validate()
 		if err != nil {
 			return nil, err
 		}
-		whereOpt = whereOpts[0]
+		whereOpt, err = tools.WithTenantSqlWhereOption(kt, whereOpts[0])
+		if err != nil {
+			return nil, err
+		}
 	}
 	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
 	if err != nil {
@@ -191,11 +194,13 @@ func (r *Dao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*filter.SQLW
 		table.RecycleRecordTable, whereExpr, pageExpr)
 
 	details := make([]rr.RecycleRecordTable, 0)
-	if err = r.orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.RecycleRecordTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, r.orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &rrtypes.RecycleRecordListResult{Details: details}, nil
+	return &rrtypes.RecycleRecordListResult{Count: count, Details: details}, nil
 }
 
 // BatchDeleteWithTx batch delete recycle record with transaction.
@@ -204,7 +209,7 @@ func (r *Dao) BatchDeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.Exp
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -225,14 +230,14 @@ func (r *Dao) UpdateResource(kt *kit.Kit, tx *sqlx.Tx, opt *rrtypes.ResourceUpda
 		return errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	sql := fmt.Sprintf(`update %s set recycle_status = :recycle_status where id in (:id)`,
-		tableName)
 	updateData := map[string]interface{}{
 		"recycle_status": opt.Status,
 	}
 	whereValue := map[string]interface{}{
 		"id": opt.IDs,
 	}
+	sql := fmt.Sprintf(`update %s set recycle_status = :recycle_status where id in (:id)%s`,
+		tableName, tools.TenantSqlCondition(kt, "tenant_id", whereValue))
 
 	effected, err := r.orm.Txn(tx).Update(kt.Ctx, sql, tools.MapMerge(updateData, whereValue))
 	if err != nil {
@@ -262,13 +267,12 @@ func (r *Dao) ListResourceInfo(kt *kit.Kit, resType enumor.CloudResourceType, id
 		return nil, errf.New(errf.InvalidParameter, "ids is required")
 	}
 
-	sql := fmt.Sprintf("select vendor, id, cloud_id, name, bk_biz_id, account_id, region from %s where id in (:id)",
-		tableName)
-
 	info := make([]rrtypes.RecycleResourceInfo, 0)
 	args := map[string]interface{}{
 		"id": ids,
 	}
+	sql := fmt.Sprintf("select vendor, id, cloud_id, name, bk_biz_id, account_id, region from %s where id in (:id)%s",
+		tableName, tools.TenantSqlCondition(kt, "tenant_id", args))
 	if err := r.orm.Do().Select(kt.Ctx, &info, sql, args); err != nil {
 		logs.Errorf("list recycle resource info failed, err: %v, type: %s, ids: %v, rid: %s", err, resType, ids, kt.Rid)
 		return nil, err
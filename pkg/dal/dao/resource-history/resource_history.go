@@ -0,0 +1,305 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package daoreshistory resource history dao.
+package daoreshistory
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/cloud"
+	tablecvm "hcm/pkg/dal/table/cloud/cvm"
+	tablereshistory "hcm/pkg/dal/table/resource-history"
+	dtypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// historyColumns 需要记录历史版本的资源表及其字段
+var historyColumns = map[table.Name]*utils.Columns{
+	table.SecurityGroupTable:           cloud.SecurityGroupColumns,
+	table.TCloudSecurityGroupRuleTable: cloud.TCloudSGRuleColumns,
+	table.AwsSecurityGroupRuleTable:    cloud.AwsSGRuleColumns,
+	table.HuaWeiSecurityGroupRuleTable: cloud.HuaWeiSGRuleColumns,
+	table.AzureSecurityGroupRuleTable:  cloud.AzureSGRuleColumns,
+	table.CvmTable:                     tablecvm.TableColumns,
+}
+
+// IsSupported 判断资源表是否支持记录历史版本
+func IsSupported(resType table.Name) bool {
+	_, exist := historyColumns[resType]
+	return exist
+}
+
+// Interface only used for resource history.
+type Interface interface {
+	// RecordWithTx 记录资源的当前数据为新版本，并使资源的上一个版本失效
+	RecordWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, ids []string) error
+	// CloseWithTx 使资源的当前版本失效，用于资源删除时
+	CloseWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, ids []string) error
+	// CloseByWhereWithTx 使满足条件的资源的当前版本失效，用于资源删除时
+	CloseByWhereWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, whereExpr string,
+		whereValue map[string]interface{}) error
+	// ListIDsWithTx 查询满足条件的资源ID，用于资源变更前获取需要记录版本的资源
+	ListIDsWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, whereExpr string,
+		whereValue map[string]interface{}) ([]string, error)
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tablereshistory.ResourceHistoryTable], error)
+	// GetAsOf 查询资源在指定时间点的版本
+	GetAsOf(kt *kit.Kit, resType table.Name, resID string, at time.Time) (
+		*tablereshistory.ResourceHistoryTable, error)
+}
+
+var _ Interface = new(Dao)
+
+// Dao resource history dao.
+type Dao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+type snapshot struct {
+	ResID string           `db:"res_id"`
+	Data  dtypes.JsonField `db:"data"`
+}
+
+type historyRecord struct {
+	ID        string           `db:"id"`
+	TenantID  string           `db:"tenant_id"`
+	ResType   table.Name       `db:"res_type"`
+	ResID     string           `db:"res_id"`
+	Data      dtypes.JsonField `db:"data"`
+	ValidFrom time.Time        `db:"valid_from"`
+	Operator  string           `db:"operator"`
+	Rid       string           `db:"rid"`
+}
+
+// RecordWithTx ...
+func (d Dao) RecordWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	columns, exist := historyColumns[resType]
+	if !exist {
+		return errf.Newf(errf.InvalidParameter, "resource type %s not support history", resType)
+	}
+
+	pairs := make([]string, 0, len(columns.Columns()))
+	for _, column := range columns.Columns() {
+		pairs = append(pairs, fmt.Sprintf("'%s', `%s`", column, column))
+	}
+
+	sql := fmt.Sprintf(`SELECT id AS res_id, JSON_OBJECT(%s) AS data FROM %s WHERE id IN (:ids)`,
+		strings.Join(pairs, ", "), resType)
+
+	snapshots := make([]snapshot, 0, len(ids))
+	if err := d.Orm.Txn(tx).Select(kt.Ctx, &snapshots, sql, map[string]interface{}{"ids": ids}); err != nil {
+		logs.Errorf("select %s snapshot failed, err: %v, ids: %v, rid: %s", resType, err, ids, kt.Rid)
+		return err
+	}
+
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	// 上一个版本的失效时间与新版本的生效时间一致，保证版本的有效时间连续
+	now := time.Now()
+	if err := d.close(kt, tx, resType, ids, now); err != nil {
+		return err
+	}
+
+	historyIDs, err := d.IDGen.Batch(kt, table.ResourceHistoryTable, len(snapshots))
+	if err != nil {
+		return err
+	}
+
+	records := make([]historyRecord, len(snapshots))
+	for idx, one := range snapshots {
+		records[idx] = historyRecord{
+			ID:        historyIDs[idx],
+			TenantID:  kt.GetTenantID(),
+			ResType:   resType,
+			ResID:     one.ResID,
+			Data:      one.Data,
+			ValidFrom: now,
+			Operator:  kt.User,
+			Rid:       kt.Rid,
+		}
+	}
+
+	sql = fmt.Sprintf(`INSERT INTO %s (id, tenant_id, res_type, res_id, data, valid_from, operator, rid)
+	VALUES(:id, :tenant_id, :res_type, :res_id, :data, :valid_from, :operator, :rid)`, table.ResourceHistoryTable)
+	if err = d.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, records); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.ResourceHistoryTable, err, kt.Rid)
+		return fmt.Errorf("insert %s failed, err: %w", table.ResourceHistoryTable, err)
+	}
+
+	return nil
+}
+
+// CloseWithTx ...
+func (d Dao) CloseWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return d.close(kt, tx, resType, ids, time.Now())
+}
+
+func (d Dao) close(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, ids []string, at time.Time) error {
+	args := map[string]interface{}{
+		"valid_to": at,
+		"res_type": resType,
+		"ids":      ids,
+	}
+	sql := fmt.Sprintf(`UPDATE %s SET valid_to = :valid_to WHERE res_type = :res_type AND res_id IN (:ids)
+	AND valid_to IS NULL%s`, table.ResourceHistoryTable, tools.TenantSqlCondition(kt, "tenant_id", args))
+	if _, err := d.Orm.Txn(tx).Update(kt.Ctx, sql, args); err != nil {
+		logs.Errorf("close %s history failed, err: %v, ids: %v, rid: %s", resType, err, ids, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// CloseByWhereWithTx ...
+func (d Dao) CloseByWhereWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, whereExpr string,
+	whereValue map[string]interface{}) error {
+
+	ids, err := d.ListIDsWithTx(kt, tx, resType, whereExpr, whereValue)
+	if err != nil {
+		return err
+	}
+
+	return d.CloseWithTx(kt, tx, resType, ids)
+}
+
+// ListIDsWithTx ...
+func (d Dao) ListIDsWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, whereExpr string,
+	whereValue map[string]interface{}) ([]string, error) {
+
+	sql := fmt.Sprintf(`SELECT id FROM %s %s`, resType, whereExpr)
+
+	ids := make([]string, 0)
+	if err := d.Orm.Txn(tx).Select(kt.Ctx, &ids, sql, whereValue); err != nil {
+		logs.Errorf("list %s ids failed, err: %v, where: %s, rid: %s", resType, err, whereExpr, kt.Rid)
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// List ...
+func (d Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tablereshistory.ResourceHistoryTable],
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list resource history options is nil")
+	}
+
+	if err := opt.Validate(
+		filter.NewExprOption(filter.RuleFields(tablereshistory.ResourceHistoryColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is a count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ResourceHistoryTable, whereExpr)
+
+		count, err := d.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count resource history failed, err: %v, filter: %v, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[tablereshistory.ResourceHistoryTable]{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`,
+		tablereshistory.ResourceHistoryColumns.FieldsNamedExpr(opt.Fields), table.ResourceHistoryTable, whereExpr,
+		pageExpr)
+
+	details := make([]tablereshistory.ResourceHistoryTable, 0)
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ResourceHistoryTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, d.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ListResult[tablereshistory.ResourceHistoryTable]{Count: count, Details: details}, nil
+}
+
+// GetAsOf ...
+func (d Dao) GetAsOf(kt *kit.Kit, resType table.Name, resID string, at time.Time) (
+	*tablereshistory.ResourceHistoryTable, error) {
+
+	if !IsSupported(resType) {
+		return nil, errf.Newf(errf.InvalidParameter, "resource type %s not support history", resType)
+	}
+
+	if len(resID) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "resource id is required")
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s WHERE res_type = :res_type AND res_id = :res_id
+	AND valid_from <= :at AND (valid_to IS NULL OR valid_to > :at) ORDER BY valid_from DESC LIMIT 1`,
+		tablereshistory.ResourceHistoryColumns.NamedExpr(), table.ResourceHistoryTable)
+
+	args := map[string]interface{}{
+		"res_type": resType,
+		"res_id":   resID,
+		"at":       at,
+	}
+	details := make([]tablereshistory.ResourceHistoryTable, 0, 1)
+	if err := d.Orm.Do().Select(kt.Ctx, &details, sql, args); err != nil {
+		logs.Errorf("get %s(%s) history as of %v failed, err: %v, rid: %s", resType, resID, at, err, kt.Rid)
+		return nil, err
+	}
+
+	if len(details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "%s(%s) has no version at %s", resType, resID,
+			at.Format(time.RFC3339))
+	}
+
+	return &details[0], nil
+}
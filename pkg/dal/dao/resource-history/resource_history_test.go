@@ -0,0 +1,198 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package daoreshistory
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
+	tablereshistory "hcm/pkg/dal/table/resource-history"
+	"hcm/pkg/kit"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// fakeOrm returns the prepared snapshots, and records the close and insert statements.
+type fakeOrm struct {
+	orm.Interface
+	orm.DoOrm
+	snapshots  []snapshot
+	histories  []tablereshistory.ResourceHistoryTable
+	selectExpr string
+	closeArgs  []map[string]interface{}
+	inserted   []historyRecord
+	steps      []string
+}
+
+// Do ...
+func (f *fakeOrm) Do() orm.DoOrm {
+	return f
+}
+
+// Txn ...
+func (f *fakeOrm) Txn(_ *sqlx.Tx) orm.DoOrmWithTransaction {
+	return f
+}
+
+// Select ...
+func (f *fakeOrm) Select(_ context.Context, dest interface{}, expr string, _ map[string]interface{}) error {
+	f.selectExpr = expr
+	switch details := dest.(type) {
+	case *[]snapshot:
+		*details = append(*details, f.snapshots...)
+	case *[]tablereshistory.ResourceHistoryTable:
+		*details = append(*details, f.histories...)
+	}
+	return nil
+}
+
+// Update ...
+func (f *fakeOrm) Update(_ context.Context, _ string, arg map[string]interface{}) (int64, error) {
+	f.steps = append(f.steps, "close")
+	f.closeArgs = append(f.closeArgs, arg)
+	return 1, nil
+}
+
+// BulkInsert ...
+func (f *fakeOrm) BulkInsert(_ context.Context, _ string, args interface{}) error {
+	f.steps = append(f.steps, "insert")
+	f.inserted = append(f.inserted, args.([]historyRecord)...)
+	return nil
+}
+
+type fakeIDGen struct{}
+
+// Batch ...
+func (fakeIDGen) Batch(_ *kit.Kit, _ table.Name, count int) ([]string, error) {
+	ids := make([]string, count)
+	for idx := range ids {
+		ids[idx] = "h" + string(rune('0'+idx))
+	}
+	return ids, nil
+}
+
+// One ...
+func (fakeIDGen) One(_ *kit.Kit, _ table.Name) (string, error) {
+	return "h0", nil
+}
+
+func TestRecordWithTx(t *testing.T) {
+	fake := &fakeOrm{snapshots: []snapshot{
+		{ResID: "sg1", Data: `{"id": "sg1", "name": "web"}`},
+		{ResID: "sg2", Data: `{"id": "sg2", "name": "db"}`},
+	}}
+	dao := Dao{Orm: fake, IDGen: fakeIDGen{}}
+	kt := kit.New()
+	kt.User = "tester"
+
+	if err := dao.RecordWithTx(kt, nil, table.SecurityGroupTable, []string{"sg1", "sg2"}); err != nil {
+		t.Fatalf("record history failed, err: %v", err)
+	}
+
+	if !strings.Contains(fake.selectExpr, "JSON_OBJECT(") || !strings.Contains(fake.selectExpr, "`name`") {
+		t.Errorf("snapshot should be taken from all the columns of the resource, sql: %s", fake.selectExpr)
+	}
+
+	// the previous version must be closed before the new version is inserted.
+	if strings.Join(fake.steps, ",") != "close,insert" {
+		t.Fatalf("history should be closed then inserted, got: %v", fake.steps)
+	}
+
+	if len(fake.inserted) != 2 {
+		t.Fatalf("each resource should have a new version, got: %+v", fake.inserted)
+	}
+	closedAt := fake.closeArgs[0]["valid_to"].(time.Time)
+	for _, one := range fake.inserted {
+		if !one.ValidFrom.Equal(closedAt) {
+			t.Errorf("new version should start when the previous one ends, from: %v, to: %v", one.ValidFrom,
+				closedAt)
+		}
+		if one.ResType != table.SecurityGroupTable || one.Operator != "tester" || one.Rid != kt.Rid {
+			t.Errorf("history record is not filled as expected, got: %+v", one)
+		}
+	}
+	if fake.inserted[1].ResID != "sg2" || fake.inserted[1].Data != `{"id": "sg2", "name": "db"}` {
+		t.Errorf("history data should be the snapshot of the resource, got: %+v", fake.inserted[1])
+	}
+}
+
+func TestRecordWithTxSkipped(t *testing.T) {
+	fake := new(fakeOrm)
+	dao := Dao{Orm: fake, IDGen: fakeIDGen{}}
+
+	err := dao.RecordWithTx(kit.New(), nil, table.VpcTable, []string{"vpc1"})
+	if ef := errf.Error(err); ef == nil || ef.Code != errf.InvalidParameter {
+		t.Errorf("unsupported resource type should be rejected, err: %v", err)
+	}
+
+	// resources that are already deleted have no snapshot, and should not close their history.
+	if err = dao.RecordWithTx(kit.New(), nil, table.CvmTable, []string{"cvm1"}); err != nil {
+		t.Fatalf("record history failed, err: %v", err)
+	}
+	if len(fake.steps) != 0 {
+		t.Errorf("no history should be written without snapshot, got: %v", fake.steps)
+	}
+}
+
+func TestGetAsOf(t *testing.T) {
+	fake := new(fakeOrm)
+	dao := Dao{Orm: fake}
+	kt := kit.New()
+
+	_, err := dao.GetAsOf(kt, table.CvmTable, "cvm1", time.Now())
+	if ef := errf.Error(err); ef == nil || ef.Code != errf.RecordNotFound {
+		t.Errorf("resource without version at the time should be not found, err: %v", err)
+	}
+
+	fake.histories = []tablereshistory.ResourceHistoryTable{{ID: "h1", ResID: "cvm1"}}
+	history, err := dao.GetAsOf(kt, table.CvmTable, "cvm1", time.Now())
+	if err != nil {
+		t.Fatalf("get history as of failed, err: %v", err)
+	}
+	if history.ID != "h1" {
+		t.Errorf("unexpected history, got: %+v", history)
+	}
+	if !strings.Contains(fake.selectExpr, "valid_to IS NULL OR valid_to > :at") {
+		t.Errorf("version should be selected by its valid period, sql: %s", fake.selectExpr)
+	}
+}
+
+func TestCloseWithTxTenant(t *testing.T) {
+	tools.EnableTenant(true)
+	defer tools.EnableTenant(false)
+
+	fake := new(fakeOrm)
+	dao := Dao{Orm: fake}
+	kt := kit.New()
+	kt.TenantID = "tenant1"
+
+	if err := dao.CloseWithTx(kt, nil, table.CvmTable, []string{"cvm1"}); err != nil {
+		t.Fatalf("close history failed, err: %v", err)
+	}
+	if len(fake.closeArgs) != 1 || fake.closeArgs[0]["tenant_id"] != "tenant1" {
+		t.Errorf("history of other tenants should not be closed, args: %v", fake.closeArgs)
+	}
+}
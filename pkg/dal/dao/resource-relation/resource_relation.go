@@ -0,0 +1,182 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package daorelation resource relation dao.
+package daorelation
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tablerelation "hcm/pkg/dal/table/resource-relation"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+// Interface only used for resource relation.
+type Interface interface {
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tablerelation.ResourceRelationTable], error)
+	// ListEdges 查询与资源相连的所有边，包括资源作为源和目标的边
+	ListEdges(kt *kit.Kit, resType table.Name, ids []string) ([]tablerelation.ResourceRelationTable, error)
+	// ListNodeInfo 查询关系图中纳管资源节点的云ID和名称
+	ListNodeInfo(kt *kit.Kit, resType table.Name, ids []string) ([]NodeInfo, error)
+}
+
+// nodeInfoTables 支持查询节点信息的资源表，这些表均包含 id、cloud_id、name 字段
+var nodeInfoTables = map[table.Name]struct{}{
+	table.VpcTable:           {},
+	table.SubnetTable:        {},
+	table.RouteTableTable:    {},
+	table.CvmTable:           {},
+	table.SecurityGroupTable: {},
+	table.EipTable:           {},
+	table.DiskTable:          {},
+}
+
+// NodeInfo resource relation node basic info.
+type NodeInfo struct {
+	ID      string `db:"id"`
+	CloudID string `db:"cloud_id"`
+	Name    string `db:"name"`
+}
+
+var _ Interface = new(Dao)
+
+// Dao resource relation dao.
+type Dao struct {
+	Orm orm.Interface
+}
+
+// List ...
+func (d Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tablerelation.ResourceRelationTable],
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list resource relation options is nil")
+	}
+
+	if err := opt.Validate(
+		filter.NewExprOption(filter.RuleFields(tablerelation.ResourceRelationColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is a count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ResourceRelationTable, whereExpr)
+
+		count, err := d.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count resource relation failed, err: %v, filter: %v, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[tablerelation.ResourceRelationTable]{Count: count}, nil
+	}
+
+	// the view has no id column, so sort by the source and destination by default.
+	page := *opt.Page
+	if len(page.Sort) == 0 && len(page.Sorts) == 0 {
+		page.Sorts = []core.SortField{{Field: "src_id"}, {Field: "dst_id"}}
+	}
+
+	pageExpr, err := types.PageSQLExpr(&page,
+		types.DefaultPageSQLOption.WithSortFields(tablerelation.ResourceRelationColumns.ColumnTypes()))
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`,
+		tablerelation.ResourceRelationColumns.FieldsNamedExpr(opt.Fields), table.ResourceRelationTable, whereExpr,
+		pageExpr)
+
+	details := make([]tablerelation.ResourceRelationTable, 0)
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ResourceRelationTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, d.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ListResult[tablerelation.ResourceRelationTable]{Count: count, Details: details}, nil
+}
+
+// ListEdges ...
+func (d Dao) ListEdges(kt *kit.Kit, resType table.Name, ids []string) ([]tablerelation.ResourceRelationTable,
+	error) {
+
+	if len(resType) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "resource type is required")
+	}
+
+	edges := make([]tablerelation.ResourceRelationTable, 0)
+	for _, batch := range slice.Split(ids, constant.BatchOperationMaxLimit) {
+		// query the source and destination respectively, so that the condition can be pushed down to the
+		// underlying tables of the view.
+		for _, side := range []string{"src", "dst"} {
+			sql := fmt.Sprintf(`SELECT %s FROM %s WHERE %s_type = :res_type AND %s_id IN (:ids)`,
+				tablerelation.ResourceRelationColumns.NamedExpr(), table.ResourceRelationTable, side, side)
+
+			list := make([]tablerelation.ResourceRelationTable, 0)
+			args := map[string]interface{}{"res_type": resType, "ids": batch}
+			if err := d.Orm.Do().Select(kt.Ctx, &list, sql, args); err != nil {
+				logs.Errorf("list %s %s edges failed, err: %v, ids: %v, rid: %s", resType, side, err, batch, kt.Rid)
+				return nil, err
+			}
+			edges = append(edges, list...)
+		}
+	}
+
+	return edges, nil
+}
+
+// ListNodeInfo ...
+func (d Dao) ListNodeInfo(kt *kit.Kit, resType table.Name, ids []string) ([]NodeInfo, error) {
+	if _, exists := nodeInfoTables[resType]; !exists {
+		return nil, errf.Newf(errf.InvalidParameter, "resource type %s not support list node info", resType)
+	}
+
+	infos := make([]NodeInfo, 0, len(ids))
+	for _, batch := range slice.Split(ids, constant.BatchOperationMaxLimit) {
+		args := map[string]interface{}{"ids": batch}
+		sql := fmt.Sprintf(`SELECT id, cloud_id, IFNULL(name, '') AS name FROM %s WHERE id IN (:ids)%s`, resType,
+			tools.TenantSqlCondition(kt, "tenant_id", args))
+
+		list := make([]NodeInfo, 0, len(batch))
+		if err := d.Orm.Do().Select(kt.Ctx, &list, sql, args); err != nil {
+			logs.Errorf("list %s node info failed, err: %v, ids: %v, rid: %s", resType, err, batch, kt.Rid)
+			return nil, err
+		}
+		infos = append(infos, list...)
+	}
+
+	return infos, nil
+}
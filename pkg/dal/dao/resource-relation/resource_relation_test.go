@@ -0,0 +1,105 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package daorelation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/table"
+	tablerelation "hcm/pkg/dal/table/resource-relation"
+	"hcm/pkg/kit"
+)
+
+// recordOrm records the statements and their args, and returns one edge for each statement.
+type recordOrm struct {
+	orm.Interface
+	orm.DoOrm
+	exprs []string
+	args  []map[string]interface{}
+}
+
+// Do ...
+func (r *recordOrm) Do() orm.DoOrm {
+	return r
+}
+
+// Select ...
+func (r *recordOrm) Select(_ context.Context, dest interface{}, expr string, arg map[string]interface{}) error {
+	r.exprs = append(r.exprs, expr)
+	r.args = append(r.args, arg)
+	if edges, ok := dest.(*[]tablerelation.ResourceRelationTable); ok {
+		*edges = append(*edges, tablerelation.ResourceRelationTable{SrcID: fmt.Sprintf("edge-%d", len(r.exprs))})
+	}
+	return nil
+}
+
+func TestListEdges(t *testing.T) {
+	record := new(recordOrm)
+	dao := Dao{Orm: record}
+
+	ids := make([]string, 0, 150)
+	for idx := 0; idx < 150; idx++ {
+		ids = append(ids, fmt.Sprintf("vpc-%d", idx))
+	}
+
+	edges, err := dao.ListEdges(kit.New(), table.VpcTable, ids)
+	if err != nil {
+		t.Fatalf("list edges failed, err: %v", err)
+	}
+
+	// the ids are split by the batch limit, and each batch is queried as source and destination.
+	if len(record.exprs) != 4 || len(edges) != 4 {
+		t.Fatalf("edges should be queried in 2 batches for both sides, got: %d", len(record.exprs))
+	}
+	for idx, side := range []string{"src", "dst", "src", "dst"} {
+		if !strings.Contains(record.exprs[idx], side+"_type = :res_type AND "+side+"_id IN (:ids)") {
+			t.Errorf("query %d should be %s side, sql: %s", idx, side, record.exprs[idx])
+		}
+	}
+	if len(record.args[0]["ids"].([]string)) != 100 || len(record.args[2]["ids"].([]string)) != 50 {
+		t.Errorf("unexpected batch size")
+	}
+
+	if _, err = dao.ListEdges(kit.New(), "", ids); err == nil {
+		t.Errorf("resource type should be required")
+	}
+}
+
+func TestListNodeInfo(t *testing.T) {
+	record := new(recordOrm)
+	dao := Dao{Orm: record}
+
+	if _, err := dao.ListNodeInfo(kit.New(), table.CvmTable, []string{"cvm-1"}); err != nil {
+		t.Fatalf("list node info failed, err: %v", err)
+	}
+	if !strings.Contains(record.exprs[0], "SELECT id, cloud_id, IFNULL(name, '') AS name FROM cvm") {
+		t.Errorf("unexpected sql: %s", record.exprs[0])
+	}
+
+	_, err := dao.ListNodeInfo(kit.New(), table.AuditTable, []string{"1"})
+	if ef := errf.Error(err); ef == nil || ef.Code != errf.InvalidParameter {
+		t.Errorf("table without node info should be rejected, err: %v", err)
+	}
+}
@@ -0,0 +1,221 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package daorestag resource tag dao.
+package daorestag
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tablerestag "hcm/pkg/dal/table/resource-tag"
+	dtypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/json"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// supportedTables 支持按标签过滤的资源表，资源表需包含 json 类型的 tags 字段
+var supportedTables = map[table.Name]struct{}{
+	table.SecurityGroupTable: {},
+	table.LoadBalancerTable:  {},
+}
+
+// IsSupported 判断资源表是否支持标签存储
+func IsSupported(resType table.Name) bool {
+	_, exist := supportedTables[resType]
+	return exist
+}
+
+// Interface only used for resource tag.
+type Interface interface {
+	// SyncWithTx 将资源表 tags 字段中的标签同步到标签表，资源的原有标签会被替换，需在资源创建或标签更新后调用
+	SyncWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, ids []string) error
+	// DeleteWithTx 删除资源的所有标签，用于资源删除时
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, ids []string) error
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tablerestag.ResourceTagTable], error)
+}
+
+var _ Interface = new(Dao)
+
+// Dao resource tag dao.
+type Dao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+type resourceTags struct {
+	ID   string           `db:"id"`
+	Tags dtypes.JsonField `db:"tags"`
+}
+
+// SyncWithTx ...
+func (d Dao) SyncWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if !IsSupported(resType) {
+		return errf.Newf(errf.InvalidParameter, "resource type %s not support tag", resType)
+	}
+
+	for _, batch := range slice.Split(ids, constant.BatchOperationMaxLimit) {
+		if err := d.syncWithTx(kt, tx, resType, batch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d Dao) syncWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, ids []string) error {
+	sql := fmt.Sprintf(`SELECT id, tags FROM %s WHERE id IN (:ids)`, resType)
+	resources := make([]resourceTags, 0, len(ids))
+	if err := d.Orm.Txn(tx).Select(kt.Ctx, &resources, sql, map[string]interface{}{"ids": ids}); err != nil {
+		logs.Errorf("select %s tags failed, err: %v, ids: %v, rid: %s", resType, err, ids, kt.Rid)
+		return err
+	}
+
+	if err := d.DeleteWithTx(kt, tx, resType, ids); err != nil {
+		return err
+	}
+
+	tags := make([]*tablerestag.ResourceTagTable, 0)
+	for _, one := range resources {
+		if len(one.Tags) == 0 {
+			continue
+		}
+
+		tagMap := make(core.TagMap)
+		if err := json.UnmarshalFromString(string(one.Tags), &tagMap); err != nil {
+			logs.Errorf("unmarshal %s(%s) tags failed, err: %v, rid: %s", resType, one.ID, err, kt.Rid)
+			return err
+		}
+
+		for key, value := range tagMap {
+			tags = append(tags, &tablerestag.ResourceTagTable{
+				ResType:  resType,
+				ResID:    one.ID,
+				TagKey:   key,
+				TagValue: value,
+			})
+		}
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	tagIDs, err := d.IDGen.Batch(kt, table.ResourceTagTable, len(tags))
+	if err != nil {
+		return err
+	}
+
+	for idx := range tags {
+		tags[idx].ID = tagIDs[idx]
+		tags[idx].TenantID = kt.GetTenantID()
+	}
+
+	sql = fmt.Sprintf(`INSERT INTO %s (id, tenant_id, res_type, res_id, tag_key, tag_value)
+	VALUES(:id, :tenant_id, :res_type, :res_id, :tag_key, :tag_value)`, table.ResourceTagTable)
+	if err = d.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, tags); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.ResourceTagTable, err, kt.Rid)
+		return fmt.Errorf("insert %s failed, err: %w", table.ResourceTagTable, err)
+	}
+
+	return nil
+}
+
+// DeleteWithTx ...
+func (d Dao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	args := map[string]interface{}{
+		"res_type": resType,
+		"ids":      ids,
+	}
+	sql := fmt.Sprintf(`DELETE FROM %s WHERE res_type = :res_type AND res_id IN (:ids)%s`, table.ResourceTagTable,
+		tools.TenantSqlCondition(kt, "tenant_id", args))
+	if _, err := d.Orm.Txn(tx).Delete(kt.Ctx, sql, args); err != nil {
+		logs.Errorf("delete %s tags failed, err: %v, ids: %v, rid: %s", resType, err, ids, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// List ...
+func (d Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tablerestag.ResourceTagTable], error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list resource tag options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tablerestag.ResourceTagColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is a count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ResourceTagTable, whereExpr)
+
+		count, err := d.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count resource tag failed, err: %v, filter: %v, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[tablerestag.ResourceTagTable]{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tablerestag.ResourceTagColumns.FieldsNamedExpr(opt.Fields),
+		table.ResourceTagTable, whereExpr, pageExpr)
+
+	details := make([]tablerestag.ResourceTagTable, 0)
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ResourceTagTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, d.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ListResult[tablerestag.ResourceTagTable]{Count: count, Details: details}, nil
+}
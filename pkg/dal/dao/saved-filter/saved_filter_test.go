@@ -0,0 +1,183 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package savedfilter
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablesavedfilter "hcm/pkg/dal/table/saved-filter"
+	"hcm/pkg/kit"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// predicateRe matches the "column = :name" and "column IN (:name)" predicates of the where expression.
+var predicateRe = regexp.MustCompile(`(\w+) (=|IN) \(?:(\w+)\)?`)
+
+// fakeOrm keeps the saved filter rows in memory, and evaluates the equal and in predicates of the where
+// expression against them, so that the tenant predicate generated by the dao takes effect like in mysql.
+type fakeOrm struct {
+	orm.Interface
+	orm.DoOrm
+	rows []tablesavedfilter.SavedFilterTable
+}
+
+// Do ...
+func (f *fakeOrm) Do() orm.DoOrm {
+	return f
+}
+
+// Txn ...
+func (f *fakeOrm) Txn(_ *sqlx.Tx) orm.DoOrmWithTransaction {
+	return f
+}
+
+func (f *fakeOrm) match(expr string, arg map[string]interface{}, row tablesavedfilter.SavedFilterTable) bool {
+	idx := strings.Index(strings.ToLower(expr), " where ")
+	if idx < 0 {
+		return true
+	}
+
+	columns := map[string]string{"id": row.ID, "tenant_id": row.TenantID}
+	for _, predicate := range predicateRe.FindAllStringSubmatch(expr[idx:], -1) {
+		value, exists := columns[predicate[1]]
+		if !exists {
+			continue
+		}
+
+		switch want := arg[predicate[3]].(type) {
+		case string:
+			if value != want {
+				return false
+			}
+		case []string:
+			found := false
+			for _, one := range want {
+				found = found || value == one
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Select ...
+func (f *fakeOrm) Select(_ context.Context, dest interface{}, expr string, arg map[string]interface{}) error {
+	details := dest.(*[]tablesavedfilter.SavedFilterTable)
+	for _, row := range f.rows {
+		if f.match(expr, arg, row) {
+			*details = append(*details, row)
+		}
+	}
+	return nil
+}
+
+// Update ...
+func (f *fakeOrm) Update(_ context.Context, expr string, arg map[string]interface{}) (int64, error) {
+	var affected int64
+	for idx := range f.rows {
+		if f.match(expr, arg, f.rows[idx]) {
+			f.rows[idx].Name = arg["name"].(string)
+			affected++
+		}
+	}
+	return affected, nil
+}
+
+// Delete ...
+func (f *fakeOrm) Delete(_ context.Context, expr string, arg map[string]interface{}) (int64, error) {
+	remained := make([]tablesavedfilter.SavedFilterTable, 0, len(f.rows))
+	for _, row := range f.rows {
+		if !f.match(expr, arg, row) {
+			remained = append(remained, row)
+		}
+	}
+
+	affected := int64(len(f.rows) - len(remained))
+	f.rows = remained
+	return affected, nil
+}
+
+func newTenantKit(tenantID string) *kit.Kit {
+	kt := kit.New()
+	kt.TenantID = tenantID
+	return kt
+}
+
+func TestCrossTenantRejected(t *testing.T) {
+	tools.EnableTenant(true)
+	defer tools.EnableTenant(false)
+
+	fake := &fakeOrm{rows: []tablesavedfilter.SavedFilterTable{
+		{ID: "f1", Name: "t1 filter", TenantID: "t1"},
+		{ID: "f2", Name: "t2 filter", TenantID: "t2"},
+	}}
+	dao := Dao{Orm: fake}
+	t2 := newTenantKit("t2")
+
+	listOpt := &types.ListOption{Filter: tools.ContainersExpression("id", []string{"f1", "f2"}),
+		Page: core.NewDefaultBasePage()}
+	result, err := dao.List(t2, listOpt)
+	if err != nil {
+		t.Fatalf("list saved filter failed, err: %v", err)
+	}
+	if len(result.Details) != 1 || result.Details[0].ID != "f2" {
+		t.Errorf("tenant t2 should only list its own saved filter, got: %+v", result.Details)
+	}
+
+	err = dao.UpdateByID(t2, "f1", &tablesavedfilter.SavedFilterTable{Name: "hacked", Reviser: "t2 user"})
+	if ef := errf.Error(err); ef == nil || ef.Code != errf.RecordNotFound {
+		t.Errorf("update the other tenant's saved filter should be not found, err: %v", err)
+	}
+	if fake.rows[0].Name != "t1 filter" {
+		t.Errorf("the other tenant's saved filter should not be updated, got: %s", fake.rows[0].Name)
+	}
+
+	if err = dao.DeleteWithTx(t2, nil, tools.EqualExpression("id", "f1")); err != nil {
+		t.Fatalf("delete saved filter failed, err: %v", err)
+	}
+	if len(fake.rows) != 2 {
+		t.Errorf("the other tenant's saved filter should not be deleted, rows: %+v", fake.rows)
+	}
+
+	// the owner tenant can still operate its own saved filter.
+	t1 := newTenantKit("t1")
+	err = dao.UpdateByID(t1, "f1", &tablesavedfilter.SavedFilterTable{Name: "renamed", Reviser: "t1 user"})
+	if err != nil {
+		t.Fatalf("update own saved filter failed, err: %v", err)
+	}
+	if err = dao.DeleteWithTx(t1, nil, tools.EqualExpression("id", "f1")); err != nil {
+		t.Fatalf("delete own saved filter failed, err: %v", err)
+	}
+	if len(fake.rows) != 1 || fake.rows[0].ID != "f2" {
+		t.Errorf("own saved filter should be deleted, rows: %+v", fake.rows)
+	}
+}
@@ -0,0 +1,229 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tagpolicy
+
+import (
+	"fmt"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tabletagpolicy "hcm/pkg/dal/table/tag-policy"
+	tabletypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// TagViolationInterface only used for tag violation.
+type TagViolationInterface interface {
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tabletagpolicy.TagViolationTable], error)
+	ListAccountResource(kt *kit.Kit, accountID string, resType enumor.CloudResourceType) ([]TagResource, error)
+	Summary(kt *kit.Kit, accountIDs []string) ([]TagViolationSummary, error)
+	SyncAccount(kt *kit.Kit, accountID string, violations []tabletagpolicy.TagViolationTable) error
+}
+
+var _ TagViolationInterface = new(TagViolationDao)
+
+// TagViolationDao tag violation dao.
+type TagViolationDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// List tag violation.
+func (dao TagViolationDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tabletagpolicy.TagViolationTable],
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tabletagpolicy.TagViolationColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is dao count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.TagViolationTable, whereExpr)
+
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count tag violation failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[tabletagpolicy.TagViolationTable]{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tabletagpolicy.TagViolationColumns.FieldsNamedExpr(opt.Fields),
+		table.TagViolationTable, whereExpr, pageExpr)
+
+	details := make([]tabletagpolicy.TagViolationTable, 0)
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.TagViolationTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
+		logs.Errorf("select tag violation failed, err: %v, sql: %s, rid: %s", err, sql, kt.Rid)
+		return nil, err
+	}
+
+	return &types.ListResult[tabletagpolicy.TagViolationTable]{Count: count, Details: details}, nil
+}
+
+// TagResource 用于检测标签策略的资源基本信息
+type TagResource struct {
+	ID        string               `db:"id"`
+	Vendor    enumor.Vendor        `db:"vendor"`
+	AccountID string               `db:"account_id"`
+	BkBizID   int64                `db:"bk_biz_id"`
+	Name      string               `db:"name"`
+	Tags      tabletypes.StringMap `db:"tags"`
+}
+
+// ListAccountResource list the tags of account resources of the resource type.
+func (dao TagViolationDao) ListAccountResource(kt *kit.Kit, accountID string, resType enumor.CloudResourceType) (
+	[]TagResource, error) {
+
+	if len(accountID) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "account id is required")
+	}
+
+	if err := enumor.ValidateTagPolicyResType(resType); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	tableName, err := resType.ConvTableName()
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT id, vendor, account_id, bk_biz_id, IFNULL(name, '') AS name, tags FROM %s
+		WHERE account_id = :account_id`, tableName)
+	resources := make([]TagResource, 0)
+	if err = dao.Orm.Do().Select(kt.Ctx, &resources, sql, map[string]interface{}{"account_id": accountID}); err != nil {
+		logs.Errorf("list account resource tags failed, err: %v, account: %s, res_type: %s, rid: %s", err, accountID,
+			resType, kt.Rid)
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// TagViolationSummary 账号下按资源类型及不合规原因统计的不合规资源数量
+type TagViolationSummary struct {
+	AccountID string                    `db:"account_id" json:"account_id"`
+	ResType   enumor.CloudResourceType  `db:"res_type" json:"res_type"`
+	Reason    enumor.TagViolationReason `db:"reason" json:"reason"`
+	ResCount  uint64                    `db:"res_count" json:"res_count"`
+}
+
+// Summary count the distinct resources that violate tag policies, grouped by account, resource type and reason.
+func (dao TagViolationDao) Summary(kt *kit.Kit, accountIDs []string) ([]TagViolationSummary, error) {
+	if len(accountIDs) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "account ids is required")
+	}
+
+	sql := fmt.Sprintf(`SELECT account_id, res_type, reason, COUNT(DISTINCT res_id) AS res_count FROM %s
+		WHERE account_id IN (:account_ids) GROUP BY account_id, res_type, reason`, table.TagViolationTable)
+	summaries := make([]TagViolationSummary, 0)
+	if err := dao.Orm.Do().Select(kt.Ctx, &summaries, sql,
+		map[string]interface{}{"account_ids": accountIDs}); err != nil {
+		logs.Errorf("summary tag violation failed, err: %v, accounts: %v, rid: %s", err, accountIDs, kt.Rid)
+		return nil, err
+	}
+
+	return summaries, nil
+}
+
+// SyncAccount replace the tag violations of the account with the latest detected ones.
+func (dao TagViolationDao) SyncAccount(kt *kit.Kit, accountID string,
+	violations []tabletagpolicy.TagViolationTable) error {
+
+	if len(accountID) == 0 {
+		return errf.New(errf.InvalidParameter, "account id is required")
+	}
+
+	detectedAt := time.Now().Truncate(time.Second)
+	_, err := dao.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		args := map[string]interface{}{"account_id": accountID}
+		deleteSql := fmt.Sprintf(`DELETE FROM %s WHERE account_id = :account_id%s`, table.TagViolationTable,
+			tools.TenantSqlCondition(kt, "tenant_id", args))
+		if _, err := dao.Orm.Txn(txn).Delete(kt.Ctx, deleteSql, args); err != nil {
+			logs.Errorf("delete account tag violation failed, err: %v, account: %s, rid: %s", err, accountID,
+				kt.Rid)
+			return nil, err
+		}
+
+		if len(violations) == 0 {
+			return nil, nil
+		}
+
+		ids, err := dao.IDGen.Batch(kt, table.TagViolationTable, len(violations))
+		if err != nil {
+			return nil, err
+		}
+		for idx := range violations {
+			violations[idx].ID = ids[idx]
+			violations[idx].TenantID = kt.GetTenantID()
+			violations[idx].AccountID = accountID
+			violations[idx].DetectedAt = detectedAt
+			if err = violations[idx].InsertValidate(); err != nil {
+				return nil, err
+			}
+		}
+
+		sql := tabletagpolicy.TagViolationColumns.InsertExpr(string(table.TagViolationTable))
+		for _, batch := range slice.Split(violations, constant.BatchOperationMaxLimit) {
+			if err = dao.Orm.Txn(txn).BulkInsert(kt.Ctx, sql, batch); err != nil {
+				logs.Errorf("insert %s failed, err: %v, rid: %s", table.TagViolationTable, err, kt.Rid)
+				return nil, fmt.Errorf("insert %s failed, err: %w", table.TagViolationTable, err)
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("sync account tag violation failed, err: %v, account: %s, rid: %s", err, accountID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -79,18 +79,18 @@ func (d *DetailDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, details []task.Detail
 
 	for idx := range details {
 		details[idx].ID = ids[idx]
+		details[idx].TenantID = kt.GetTenantID()
 		details[idx].Creator = kt.User
 		if err = details[idx].InsertValidate(); err != nil {
 			return nil, err
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.TaskDetailTable, task.DetailColumns.ColumnExpr(),
-		task.DetailColumns.ColonNameExpr())
+	sql := task.DetailColumns.InsertExpr(table.TaskDetailTable)
 
 	err = d.orm.Txn(tx).BulkInsert(kt.Ctx, sql, details)
 	if err != nil {
-		return nil, fmt.Errorf("insert %s failed, err: %v", table.TaskDetailTable, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", table.TaskDetailTable, err)
 	}
 
 	return ids, nil
@@ -107,7 +107,7 @@ func (d *DetailDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expressi
 		return err
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -148,13 +148,16 @@ func (d *DetailDao) List(kt *kit.Kit, opt *types.ListOption,
 		return nil, err
 	}
 
-	whereOpt := tools.DefaultSqlWhereOption
+	whereOpt := tools.TenantSqlWhereOption(kt)
 	if len(whereOpts) != 0 && whereOpts[0] != nil {
 		err := whereOpts[0].Validate()
 		if err != nil {
 			return nil, err
 		}
-		whereOpt = whereOpts[0]
+		whereOpt, err = tools.WithTenantSqlWhereOption(kt, whereOpts[0])
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if opt.Filter == nil {
@@ -187,11 +190,13 @@ func (d *DetailDao) List(kt *kit.Kit, opt *types.ListOption,
 		whereExpr, pageExpr)
 
 	details := make([]task.DetailTable, 0)
-	if err = d.orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.TaskDetailTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, d.orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &tasktype.ListTaskDetails{Details: details}, nil
+	return &tasktype.ListTaskDetails{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx delete task detail with transaction.
@@ -200,7 +205,7 @@ func (d *DetailDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.Ex
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -79,18 +79,18 @@ func (d *ManagementDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, managements []tas
 
 	for idx := range managements {
 		managements[idx].ID = ids[idx]
+		managements[idx].TenantID = kt.GetTenantID()
 		managements[idx].Creator = kt.User
 		if err = managements[idx].InsertValidate(); err != nil {
 			return nil, err
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.TaskManagementTable,
-		task.ManagementColumns.ColumnExpr(), task.ManagementColumns.ColonNameExpr())
+	sql := task.ManagementColumns.InsertExpr(table.TaskManagementTable)
 
 	err = d.orm.Txn(tx).BulkInsert(kt.Ctx, sql, managements)
 	if err != nil {
-		return nil, fmt.Errorf("insert %s failed, err: %v", table.TaskManagementTable, err)
+		return nil, fmt.Errorf("insert %s failed, err: %w", table.TaskManagementTable, err)
 	}
 
 	return ids, nil
@@ -109,7 +109,7 @@ func (d *ManagementDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expr
 		return err
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -149,13 +149,16 @@ func (d *ManagementDao) List(kt *kit.Kit, opt *types.ListOption,
 		return nil, err
 	}
 
-	whereOpt := tools.DefaultSqlWhereOption
+	whereOpt := tools.TenantSqlWhereOption(kt)
 	if len(whereOpts) != 0 && whereOpts[0] != nil {
 		err := whereOpts[0].Validate()
 		if err != nil {
 			return nil, err
 		}
-		whereOpt = whereOpts[0]
+		whereOpt, err = tools.WithTenantSqlWhereOption(kt, whereOpts[0])
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if opt.Filter == nil {
@@ -188,11 +191,13 @@ func (d *ManagementDao) List(kt *kit.Kit, opt *types.ListOption,
 		table.TaskManagementTable, whereExpr, pageExpr)
 
 	managements := make([]task.ManagementTable, 0)
-	if err = d.orm.Do().Select(kt.Ctx, &managements, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.TaskManagementTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, d.orm.Do(), opt.Page.WithCount, &managements, countSql, sql, whereValue)
+	if err != nil {
 		return nil, err
 	}
 
-	return &tasktype.ListTaskManagements{Details: managements}, nil
+	return &tasktype.ListTaskManagements{Count: count, Details: managements}, nil
 }
 
 // DeleteWithTx delete task management with transaction.
@@ -201,7 +206,7 @@ func (d *ManagementDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filte
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,63 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package daotenant tenant dao.
+package daotenant
+
+import (
+	"fmt"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// Interface only used for tenant.
+type Interface interface {
+	// ListIDs 查询已拥有账号的所有租户ID，未开启多租户时仅返回默认租户
+	ListIDs(kt *kit.Kit) ([]string, error)
+}
+
+var _ Interface = new(Dao)
+
+// Dao tenant dao.
+type Dao struct {
+	Orm orm.Interface
+}
+
+// ListIDs list the tenant ids which own root, main or resource accounts.
+func (d Dao) ListIDs(kt *kit.Kit) ([]string, error) {
+	if !tools.TenantEnabled() {
+		return []string{constant.DefaultTenantID}, nil
+	}
+
+	sql := fmt.Sprintf(`SELECT tenant_id FROM %s UNION SELECT tenant_id FROM %s UNION SELECT tenant_id FROM %s `+
+		`ORDER BY tenant_id`, table.AccountTable, table.MainAccountTable, table.RootAccountTable)
+
+	ids := make([]string, 0)
+	if err := d.Orm.Do().Select(kt.Ctx, &ids, sql, nil); err != nil {
+		logs.Errorf("list tenant ids failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -27,10 +27,11 @@ import "fmt"
 // asIDFieldName: 关联表中映射成id字段的字段名
 // saveIDFieldName: 关联表中字段名不变的字段名
 // e.g: relTableAlias: rel. resTableAlias: sg. asIDFieldName: security_group_id. saveIDFieldName: cvm_id.
-// 生成的SQL: rel.security_group_id as id, rel.cvm_id as cvm_id, sg.creator as creator, sg.created_at as created_at,
-// rel.creator as rel_creator, rel.created_at as rel_created_at
+// 生成的SQL: rel.security_group_id as id, rel.cvm_id as cvm_id, sg.tenant_id as tenant_id, sg.creator as creator,
+// sg.created_at as created_at, rel.creator as rel_creator, rel.created_at as rel_created_at
 func BaseRelJoinSqlBuild(relTableAlias, resTableAlias, asIDFieldName, saveIDFieldName string) string {
-	return fmt.Sprintf(`%s.%s as id, IFNULL(%s.%s,"") as %s, %s.creator as creator, %s.created_at as created_at, 
-IFNULL(%s.creator,"") as rel_creator, %s.created_at as rel_created_at`, resTableAlias, asIDFieldName, relTableAlias,
-		saveIDFieldName, saveIDFieldName, resTableAlias, resTableAlias, relTableAlias, relTableAlias)
+	return fmt.Sprintf(`%s.%s as id, IFNULL(%s.%s,"") as %s, IFNULL(%s.tenant_id,"") as tenant_id, 
+%s.creator as creator, %s.created_at as created_at, IFNULL(%s.creator,"") as rel_creator, 
+%s.created_at as rel_created_at`, resTableAlias, asIDFieldName, relTableAlias, saveIDFieldName, saveIDFieldName,
+		resTableAlias, resTableAlias, resTableAlias, relTableAlias, relTableAlias)
 }
@@ -0,0 +1,140 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"hcm/pkg/dal/table"
+	"hcm/pkg/kit"
+	"hcm/pkg/runtime/filter"
+)
+
+// tenantEnabled defines whether the multi-tenant isolation is enabled.
+var tenantEnabled atomic.Bool
+
+// EnableTenant set whether the multi-tenant isolation is enabled, if enabled,
+// the tenant scoped tables can only be operated with the kit's tenant id.
+func EnableTenant(enabled bool) {
+	tenantEnabled.Store(enabled)
+}
+
+// TenantEnabled returns whether the multi-tenant isolation is enabled.
+func TenantEnabled() bool {
+	return tenantEnabled.Load()
+}
+
+// TenantSqlWhereOption returns the default sql where option crowned with the kit's tenant rule,
+// which is used to operate the tenant scoped tables.
+func TenantSqlWhereOption(kt *kit.Kit) *filter.SQLWhereOption {
+	return tenantSqlWhereOption(kt, "tenant_id")
+}
+
+// TenantJoinSqlWhereOption returns the tenant sql where option used by the join query, the tenant rule
+// is qualified with the table alias, because every joined tenant scoped table has the tenant_id column.
+func TenantJoinSqlWhereOption(kt *kit.Kit, alias string) *filter.SQLWhereOption {
+	return tenantSqlWhereOption(kt, alias+".tenant_id")
+}
+
+func tenantSqlWhereOption(kt *kit.Kit, field string) *filter.SQLWhereOption {
+	if !TenantEnabled() {
+		return DefaultSqlWhereOption
+	}
+
+	return &filter.SQLWhereOption{
+		Priority: DefaultSqlWhereOption.Priority,
+		CrownedOption: &filter.CrownedOption{
+			CrownedOp: filter.And,
+			Rules:     []filter.RuleFactory{RuleEqual(field, kt.GetTenantID())},
+		},
+	}
+}
+
+// TenantSqlCondition returns the tenant condition of the raw sql which is not generated by the filter expression,
+// the condition is prefixed with 'AND' so that it can be appended to the raw sql's where expression directly,
+// and the tenant id is set into the args. an empty condition is returned if the multi-tenant isolation is disabled.
+func TenantSqlCondition(kt *kit.Kit, field string, args map[string]interface{}) string {
+	if !TenantEnabled() {
+		return ""
+	}
+
+	args["tenant_id"] = kt.GetTenantID()
+	return fmt.Sprintf(" AND %s = :tenant_id", field)
+}
+
+// WithTenantSqlWhereOption crown the sql where option with the kit's tenant rule.
+// Note: tenant rule must be operated with 'AND' to the whole expression, so crowned
+// option with 'OR' operator is not supported.
+func WithTenantSqlWhereOption(kt *kit.Kit, opt *filter.SQLWhereOption) (*filter.SQLWhereOption, error) {
+	if opt == nil {
+		return nil, errors.New("sql where option is nil")
+	}
+
+	if !TenantEnabled() {
+		return opt, nil
+	}
+
+	tenantRule := RuleEqual("tenant_id", kt.GetTenantID())
+	if opt.CrownedOption == nil || len(opt.CrownedOption.Rules) == 0 {
+		return &filter.SQLWhereOption{
+			Priority: opt.Priority,
+			CrownedOption: &filter.CrownedOption{
+				CrownedOp: filter.And,
+				Rules:     []filter.RuleFactory{tenantRule},
+			},
+			TagOption: opt.TagOption,
+		}, nil
+	}
+
+	if opt.CrownedOption.CrownedOp != filter.And {
+		return nil, errors.New("tenant rule can not be crowned with non 'and' operator")
+	}
+
+	rules := make([]filter.RuleFactory, 0, len(opt.CrownedOption.Rules)+1)
+	rules = append(rules, opt.CrownedOption.Rules...)
+	rules = append(rules, tenantRule)
+
+	return &filter.SQLWhereOption{
+		Priority: opt.Priority,
+		CrownedOption: &filter.CrownedOption{
+			CrownedOp: filter.And,
+			Rules:     rules,
+		},
+		TagOption: opt.TagOption,
+	}, nil
+}
+
+// TagSqlWhereOption returns the tenant sql where option which supports the tag rules of the resource type,
+// the tag rules are converted to the sub query on the resource tag table.
+func TagSqlWhereOption(kt *kit.Kit, resType table.Name) *filter.SQLWhereOption {
+	return WithTagSqlWhereOption(TenantSqlWhereOption(kt), resType)
+}
+
+// WithTagSqlWhereOption returns a copy of the sql where option which supports the tag rules of the resource type.
+func WithTagSqlWhereOption(opt *filter.SQLWhereOption, resType table.Name) *filter.SQLWhereOption {
+	copied := *opt
+	copied.TagOption = &filter.TagOption{
+		Table:   string(table.ResourceTagTable),
+		ResType: string(resType),
+	}
+	return &copied
+}
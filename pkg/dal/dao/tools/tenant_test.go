@@ -0,0 +1,106 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"hcm/pkg/kit"
+	"hcm/pkg/runtime/filter"
+)
+
+func TestTenantSqlWhereOption(t *testing.T) {
+	kt := kit.New()
+	kt.TenantID = "tenant1"
+
+	EnableTenant(false)
+	if opt := TenantSqlWhereOption(kt); opt != DefaultSqlWhereOption {
+		t.Errorf("tenant disabled should use the default sql where option")
+	}
+	args := make(map[string]interface{})
+	if cond := TenantSqlCondition(kt, "tenant_id", args); cond != "" || len(args) != 0 {
+		t.Errorf("tenant disabled should not generate tenant condition, got: %s, args: %v", cond, args)
+	}
+
+	EnableTenant(true)
+	defer EnableTenant(false)
+
+	where, value, err := EqualExpression("vendor", "tcloud").SQLWhereExpr(TenantSqlWhereOption(kt))
+	if err != nil {
+		t.Fatalf("gen where expr failed, err: %v", err)
+	}
+	if !strings.Contains(where, "tenant_id = :tenant_id_") || !containsValue(value, "tenant1") {
+		t.Errorf("unexpected tenant where expr: %s, value: %v", where, value)
+	}
+
+	where, value, err = ContainersExpression("rel.cvm_id", []string{"cvm1"}).SQLWhereExpr(
+		TenantJoinSqlWhereOption(kt, "rel"))
+	if err != nil {
+		t.Fatalf("gen join where expr failed, err: %v", err)
+	}
+	if !strings.Contains(where, "rel.tenant_id = :rel.tenant_id_") || !containsValue(value, "tenant1") {
+		t.Errorf("unexpected tenant join where expr: %s, value: %v", where, value)
+	}
+
+	cond := TenantSqlCondition(kt, "sg.tenant_id", args)
+	if cond != " AND sg.tenant_id = :tenant_id" || args["tenant_id"] != "tenant1" {
+		t.Errorf("unexpected tenant condition: %s, args: %v", cond, args)
+	}
+}
+
+func TestWithTenantSqlWhereOption(t *testing.T) {
+	EnableTenant(true)
+	defer EnableTenant(false)
+
+	kt := kit.New()
+	opt, err := WithTenantSqlWhereOption(kt, &filter.SQLWhereOption{
+		Priority: filter.Priority{"id"},
+		CrownedOption: &filter.CrownedOption{
+			CrownedOp: filter.And,
+			Rules:     []filter.RuleFactory{RuleEqual("vendor", "aws")},
+		},
+	})
+	if err != nil {
+		t.Fatalf("crown tenant rule failed, err: %v", err)
+	}
+	if len(opt.CrownedOption.Rules) != 2 {
+		t.Errorf("tenant rule should be appended to the crowned rules, got: %d rules", len(opt.CrownedOption.Rules))
+	}
+
+	_, err = WithTenantSqlWhereOption(kt, &filter.SQLWhereOption{
+		Priority: filter.Priority{"id"},
+		CrownedOption: &filter.CrownedOption{
+			CrownedOp: filter.Or,
+			Rules:     []filter.RuleFactory{RuleEqual("vendor", "aws")},
+		},
+	})
+	if err == nil {
+		t.Errorf("tenant rule crowned with 'or' operator should be rejected")
+	}
+}
+
+func containsValue(values map[string]interface{}, want interface{}) bool {
+	for _, value := range values {
+		if value == want {
+			return true
+		}
+	}
+	return false
+}
@@ -23,6 +23,8 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	tablebill "hcm/pkg/dal/table/bill"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/runtime/filter"
 )
 
 // ListAccountBillSummaryMainDetails list account bill config details.
@@ -91,6 +93,18 @@ type ListAccountBillSyncRecordDetails struct {
 	Details []tablebill.AccountBillSyncRecord `json:"details,omitempty"`
 }
 
+// ListAccountBillBudgetDetails list account bill budget details
+type ListAccountBillBudgetDetails struct {
+	Count   uint64                        `json:"count,omitempty"`
+	Details []tablebill.AccountBillBudget `json:"details,omitempty"`
+}
+
+// ListAccountBillReconciliationDetails list account bill reconciliation details
+type ListAccountBillReconciliationDetails struct {
+	Count   uint64                                `json:"count,omitempty"`
+	Details []tablebill.AccountBillReconciliation `json:"details,omitempty"`
+}
+
 // ItemCommonOpt  bill item table partition parameters
 type ItemCommonOpt struct {
 	Vendor enumor.Vendor `json:"vendor" validate:"required"`
@@ -117,3 +131,21 @@ func (p *ItemCommonOpt) Validate() error {
 	}
 	return nil
 }
+
+// ItemCostSumOption sum account bill item cost options.
+type ItemCostSumOption struct {
+	Filter *filter.Expression
+	// GroupBy 汇总维度，结果总是会再按币种区分
+	GroupBy []enumor.BillCostDimension
+}
+
+// ItemCostSum account bill item cost summed by dimensions, fields not in group by are empty.
+type ItemCostSum struct {
+	BkBizID       int64               `db:"bk_biz_id" json:"bk_biz_id"`
+	MainAccountID string              `db:"main_account_id" json:"main_account_id"`
+	Vendor        enumor.Vendor       `db:"vendor" json:"vendor"`
+	Region        string              `db:"region" json:"region"`
+	HcProductCode string              `db:"hc_product_code" json:"hc_product_code"`
+	Currency      enumor.CurrencyCode `db:"currency" json:"currency"`
+	Cost          types.Decimal       `db:"cost" json:"cost"`
+}
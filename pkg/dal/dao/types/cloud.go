@@ -32,6 +32,7 @@ type CloudResourceBasicInfo struct {
 	// these fields are basic info for some resource, needs to be specified explicitly.
 	Region        string `json:"region" db:"region"`
 	RecycleStatus string `json:"recycle_status" db:"recycle_status"`
+	BkCloudID     int64  `json:"bk_cloud_id" db:"bk_cloud_id"`
 }
 
 // CommonBasicInfoFields defines common cloud resource basic info fields.
@@ -22,8 +22,11 @@ package types
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 
 	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 )
 
@@ -35,6 +38,79 @@ type PageSQLOption struct {
 	// 1. If set, then user defined Sort field will be overlapped.
 	// 2. Sort field should always be an indexed field in db.
 	Sort SortOption `json:"sort"`
+	// SortFields is the whitelist of the user defined sort fields, usually it is the table's column
+	// types. if set, the user defined sort fields(BasePage.Sort and BasePage.Sorts) must be in it.
+	SortFields map[string]enumor.ColumnType `json:"-"`
+}
+
+// WithSortFields returns a copy of the page sql option, which uses the fields as the sort fields whitelist.
+func (ps PageSQLOption) WithSortFields(fields map[string]enumor.ColumnType) *PageSQLOption {
+	ps.SortFields = fields
+	return &ps
+}
+
+// sortFieldRegexp defines the valid sort field format.
+var sortFieldRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// validateSortField validate the user defined sort field.
+// Note: sort field is spliced into the sql directly, so only the plain table columns are allowed,
+// the json path fields(e.g. extension.xxx) can not be used to sort even if it is in the whitelist.
+func (ps *PageSQLOption) validateSortField(field string) error {
+	if !sortFieldRegexp.MatchString(field) {
+		return fmt.Errorf("invalid sort field: %s", field)
+	}
+
+	if ps.SortFields == nil {
+		return nil
+	}
+
+	typ, exist := ps.SortFields[field]
+	if !exist || typ == enumor.Json {
+		return fmt.Errorf("sort field %s is not allowed", field)
+	}
+
+	return nil
+}
+
+// orderExpr return the order by expression based on the page options.
+func (ps *PageSQLOption) orderExpr(bp *core.BasePage) (string, error) {
+	if !ps.Sort.ForceOverlap && len(bp.Sorts) != 0 {
+		columns := make([]string, 0, len(bp.Sorts))
+		for _, one := range bp.Sorts {
+			if err := ps.validateSortField(one.Field); err != nil {
+				return "", err
+			}
+			columns = append(columns, fmt.Sprintf("%s %s", one.Field, one.Order.Order()))
+		}
+
+		return fmt.Sprintf("ORDER BY %s", strings.Join(columns, ", ")), nil
+	}
+
+	var sort string
+	if ps.Sort.ForceOverlap {
+		// force overlapped user defined sort field.
+		sort = ps.Sort.Sort
+	} else {
+		if ps.Sort.IfNotPresent && len(bp.Sort) == 0 {
+			// user note defined sort, then use default sort.
+			sort = ps.Sort.Sort
+		} else {
+			// use user defined sort column
+			sort = bp.Sort
+			if len(sort) != 0 && ps.SortFields != nil {
+				if err := ps.validateSortField(sort); err != nil {
+					return "", err
+				}
+			}
+		}
+	}
+	if len(sort) == 0 {
+		// if sort is not set, use the default resource's
+		// identity id as the default sort column.
+		sort = "id"
+	}
+
+	return fmt.Sprintf("ORDER BY %s %s", sort, bp.Order.Order()), nil
 }
 
 // SortOption defines how to set the order column when do the BasePage.SQLExpr
@@ -79,34 +155,19 @@ func PageSQLExpr(bp *core.BasePage, ps *PageSQLOption) (where string, err error)
 		// it means do not need to sort.
 		return "", nil
 	}
-	var sort string
-	if ps.Sort.ForceOverlap {
-		// force overlapped user defined sort field.
-		sort = ps.Sort.Sort
-	} else {
-		if ps.Sort.IfNotPresent && len(bp.Sort) == 0 {
-			// user note defined sort, then use default sort.
-			sort = ps.Sort.Sort
-		} else {
-			// use user defined sort column
-			sort = bp.Sort
-		}
-	}
-	if len(sort) == 0 {
-		// if sort is not set, use the default resource's
-		// identity id as the default sort column.
-		sort = "id"
+	expr, err := ps.orderExpr(bp)
+	if err != nil {
+		return "", err
 	}
-	expr := fmt.Sprintf("ORDER BY %s", sort)
 	if bp.Start == 0 && bp.Limit == 0 {
 		// this is a special scenario, which means query all the resources at once.
-		return fmt.Sprintf("%s %s", expr, bp.Order.Order()), nil
+		return expr, nil
 	}
 	// if Start >=1, then Limit can not be 0.
 	if bp.Limit == 0 {
 		return "", errors.New("page.limit value should >= 1")
 	}
 	// bp.Limit is > 0, already validated upper.
-	expr = fmt.Sprintf("%s %s LIMIT %d OFFSET %d", expr, bp.Order.Order(), bp.Limit, bp.Start)
+	expr = fmt.Sprintf("%s LIMIT %d OFFSET %d", expr, bp.Limit, bp.Start)
 	return expr, nil
 }
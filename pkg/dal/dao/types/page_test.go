@@ -0,0 +1,97 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"testing"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestPageSQLExpr(t *testing.T) {
+	fields := map[string]enumor.ColumnType{"id": enumor.String, "region": enumor.String, "name": enumor.String,
+		"extension": enumor.Json}
+
+	tests := []struct {
+		name    string
+		page    *core.BasePage
+		opt     *PageSQLOption
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "default sort",
+			page: &core.BasePage{Limit: 10},
+			opt:  DefaultPageSQLOption,
+			want: "ORDER BY id ASC LIMIT 10 OFFSET 0",
+		},
+		{
+			name: "user defined sort",
+			page: &core.BasePage{Start: 10, Limit: 10, Sort: "name", Order: core.Descending},
+			opt:  DefaultPageSQLOption.WithSortFields(fields),
+			want: "ORDER BY name DESC LIMIT 10 OFFSET 10",
+		},
+		{
+			name: "multi-column sort",
+			page: &core.BasePage{Limit: 10, Sorts: []core.SortField{{Field: "region"},
+				{Field: "name", Order: core.Descending}}},
+			opt:  DefaultPageSQLOption.WithSortFields(fields),
+			want: "ORDER BY region ASC, name DESC LIMIT 10 OFFSET 0",
+		},
+		{
+			name:    "multi-column sort with field not in whitelist",
+			page:    &core.BasePage{Limit: 10, Sorts: []core.SortField{{Field: "memo"}}},
+			opt:     DefaultPageSQLOption.WithSortFields(fields),
+			wantErr: true,
+		},
+		{
+			name:    "multi-column sort with json field",
+			page:    &core.BasePage{Limit: 10, Sorts: []core.SortField{{Field: "extension"}}},
+			opt:     DefaultPageSQLOption.WithSortFields(fields),
+			wantErr: true,
+		},
+		{
+			name: "sort with json path field in whitelist",
+			page: &core.BasePage{Limit: 10, Sort: "extension.vpc_id"},
+			opt: DefaultPageSQLOption.WithSortFields(map[string]enumor.ColumnType{
+				"extension.vpc_id": enumor.String}),
+			wantErr: true,
+		},
+		{
+			name:    "multi-column sort with invalid field",
+			page:    &core.BasePage{Limit: 10, Sorts: []core.SortField{{Field: "id;drop table cvm"}}},
+			opt:     DefaultPageSQLOption,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := PageSQLExpr(tt.page, tt.opt)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: PageSQLExpr() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("%s: PageSQLExpr() got = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
@@ -68,6 +68,26 @@ func (opt ListOption) Validate(eo *filter.ExprOption, po *core.PageOption) error
 	return nil
 }
 
+// StreamListOption defines options to list resources in stream, the resources are returned one by one
+// in the order of id without paging.
+type StreamListOption struct {
+	Fields []string
+	Filter *filter.Expression
+}
+
+// Validate stream list option.
+func (opt StreamListOption) Validate(eo *filter.ExprOption) error {
+	if opt.Filter == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	if eo == nil {
+		return errf.New(errf.InvalidParameter, "filter expr option is required")
+	}
+
+	return opt.Filter.Validate(eo)
+}
+
 // CountOption defines options to count resources.
 type CountOption struct {
 	Filter  *filter.Expression
@@ -129,5 +149,5 @@ var DefaultIgnoredFields = []string{"id", "creator", "created_at", "tenant_id"}
 // DefaultPageSQLOption define default page sql option.
 var DefaultPageSQLOption = &PageSQLOption{Sort: SortOption{Sort: "id", IfNotPresent: true}}
 
-// DefaultRelJoinWithoutField 因为rel表join时，id、creator、created_at 在两张表中都有，该字段需要手动设置。
-var DefaultRelJoinWithoutField = []string{"id", "creator", "created_at"}
+// DefaultRelJoinWithoutField 因为rel表join时，id、tenant_id、creator、created_at 在两张表中都有，该字段需要手动设置。
+var DefaultRelJoinWithoutField = []string{"id", "tenant_id", "creator", "created_at"}
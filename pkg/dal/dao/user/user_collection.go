@@ -64,14 +64,14 @@ func (dao Dao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *tableuser.UserCollT
 		return "", err
 	}
 	model.ID = id
+	model.TenantID = kt.GetTenantID()
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, model.TableName(), tableuser.UserCollTableColumns.ColumnExpr(),
-		tableuser.UserCollTableColumns.ColonNameExpr())
+	sql := tableuser.UserCollTableColumns.InsertExpr(string(model.TableName()))
 
 	err = dao.Orm.Txn(tx).Insert(kt.Ctx, sql, model)
 	if err != nil {
 		logs.Errorf("insert %s failed, err: %v, sql: %s, model: %+v, rid: %s", err, sql, model, kt.Rid)
-		return "", fmt.Errorf("insert %s failed, err: %v", model.TableName(), err)
+		return "", fmt.Errorf("insert %s failed, err: %w", model.TableName(), err)
 	}
 
 	return id, nil
@@ -88,7 +88,7 @@ func (dao Dao) List(kt *kit.Kit, opt *types.ListOption) (*typesuser.ListUserColl
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return nil, err
 	}
@@ -115,12 +115,14 @@ func (dao Dao) List(kt *kit.Kit, opt *types.ListOption) (*typesuser.ListUserColl
 		table.UserCollectionTable, whereExpr, pageExpr)
 
 	details := make([]tableuser.UserCollTable, 0)
-	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.UserCollectionTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
 		logs.Errorf("select user collection failed, err: %v, sql: %s, rid: %s", err, sql, kt.Rid)
 		return nil, err
 	}
 
-	return &typesuser.ListUserCollectionDetails{Count: 0, Details: details}, nil
+	return &typesuser.ListUserCollectionDetails{Count: count, Details: details}, nil
 }
 
 // DeleteWithTx user collection with tx.
@@ -129,7 +131,7 @@ func (dao Dao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.Express
 		return errf.New(errf.InvalidParameter, "filter expr is required")
 	}
 
-	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,129 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package daouser
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
+	tableuser "hcm/pkg/dal/table/user"
+	"hcm/pkg/kit"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// fakeOrm records the statements of user recent view.
+type fakeOrm struct {
+	orm.Interface
+	orm.DoOrm
+	sql      string
+	inserted *tableuser.UserRecentViewTable
+	args     map[string]interface{}
+}
+
+// Do ...
+func (f *fakeOrm) Do() orm.DoOrm {
+	return f
+}
+
+// Txn ...
+func (f *fakeOrm) Txn(_ *sqlx.Tx) orm.DoOrmWithTransaction {
+	return f
+}
+
+// Insert ...
+func (f *fakeOrm) Insert(_ context.Context, sql string, args interface{}) error {
+	f.sql = sql
+	f.inserted = args.(*tableuser.UserRecentViewTable)
+	return nil
+}
+
+// Delete ...
+func (f *fakeOrm) Delete(_ context.Context, sql string, args map[string]interface{}) (int64, error) {
+	f.sql = sql
+	f.args = args
+	return 1, nil
+}
+
+type fakeIDGen struct{}
+
+// Batch ...
+func (fakeIDGen) Batch(_ *kit.Kit, _ table.Name, count int) ([]string, error) {
+	return make([]string, count), nil
+}
+
+// One ...
+func (fakeIDGen) One(_ *kit.Kit, _ table.Name) (string, error) {
+	return "00000001", nil
+}
+
+func TestRecentViewUpsert(t *testing.T) {
+	tools.EnableTenant(true)
+	defer tools.EnableTenant(false)
+
+	fake := new(fakeOrm)
+	dao := RecentViewDao{Orm: fake, IDGen: fakeIDGen{}}
+	kt := kit.New()
+	kt.TenantID = "tenant1"
+
+	model := &tableuser.UserRecentViewTable{User: "admin", ResType: enumor.CvmCollResType, ResID: "cvm-1"}
+	if err := dao.Upsert(kt, model); err != nil {
+		t.Fatalf("upsert user recent view failed, err: %v", err)
+	}
+
+	// 重复访问同一资源时只刷新访问时间
+	if !strings.HasSuffix(fake.sql, "ON DUPLICATE KEY UPDATE updated_at = now()") {
+		t.Errorf("recent view should be upserted, sql: %s", fake.sql)
+	}
+	if fake.inserted.ID != "00000001" || fake.inserted.TenantID != "tenant1" {
+		t.Errorf("recent view should be recorded with id and tenant, got: %+v", fake.inserted)
+	}
+
+	err := dao.Upsert(kt, &tableuser.UserRecentViewTable{User: "admin", ResType: "unknown", ResID: "cvm-1"})
+	if err == nil {
+		t.Errorf("recent view of unsupported resource type should be invalid")
+	}
+}
+
+func TestRecentViewDeleteWithTx(t *testing.T) {
+	tools.EnableTenant(true)
+	defer tools.EnableTenant(false)
+
+	fake := new(fakeOrm)
+	dao := RecentViewDao{Orm: fake, IDGen: fakeIDGen{}}
+	kt := kit.New()
+	kt.TenantID = "tenant1"
+
+	if err := dao.DeleteWithTx(kt, nil, tools.ContainersExpression("id", []string{"1", "2"})); err != nil {
+		t.Fatalf("delete user recent view failed, err: %v", err)
+	}
+	if !strings.Contains(fake.sql, "tenant_id") {
+		t.Errorf("only the recent views of the tenant should be deleted, sql: %s", fake.sql)
+	}
+
+	if err := dao.DeleteWithTx(kt, nil, nil); err == nil {
+		t.Errorf("delete without filter should be refused")
+	}
+}
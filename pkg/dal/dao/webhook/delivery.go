@@ -0,0 +1,195 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package webhook
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tablewebhook "hcm/pkg/dal/table/webhook"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// DeliveryInterface only used for webhook delivery.
+type DeliveryInterface interface {
+	BatchCreate(kt *kit.Kit, models []tablewebhook.DeliveryTable) error
+	UpdateState(kt *kit.Kit, model *tablewebhook.DeliveryTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tablewebhook.DeliveryTable], error)
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+}
+
+var _ DeliveryInterface = new(DeliveryDao)
+
+// DeliveryDao webhook delivery dao.
+type DeliveryDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// BatchCreate webhook delivery, the event already recorded for the subscription is ignored, so that the events
+// pulled again after a failed offset commit are not delivered twice.
+func (dao DeliveryDao) BatchCreate(kt *kit.Kit, models []tablewebhook.DeliveryTable) error {
+	if len(models) == 0 {
+		return errf.New(errf.InvalidParameter, "models is required")
+	}
+
+	ids, err := dao.IDGen.Batch(kt, table.WebhookDeliveryTable, len(models))
+	if err != nil {
+		return err
+	}
+
+	for index := range models {
+		models[index].ID = ids[index]
+		models[index].TenantID = kt.GetTenantID()
+
+		if err = models[index].InsertValidate(); err != nil {
+			return errf.NewFromErr(errf.InvalidParameter, err)
+		}
+	}
+
+	sql := fmt.Sprintf(`INSERT IGNORE INTO %s (%s) VALUES(%s)`, table.WebhookDeliveryTable,
+		tablewebhook.DeliveryColumns.ColumnExpr(), tablewebhook.DeliveryColumns.ColonNameExpr())
+
+	if err = dao.Orm.Do().BulkInsert(kt.Ctx, sql, models); err != nil {
+		logs.Errorf("insert %s failed, err: %v, sql: %s, rid: %s", table.WebhookDeliveryTable, err, sql, kt.Rid)
+		return fmt.Errorf("insert %s failed, err: %w", table.WebhookDeliveryTable, err)
+	}
+
+	return nil
+}
+
+// UpdateState update the delivery state, attempts and the result of the latest delivery by id.
+func (dao DeliveryDao) UpdateState(kt *kit.Kit, model *tablewebhook.DeliveryTable) error {
+	if model == nil || len(model.ID) == 0 {
+		return errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := model.State.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(model.Reviser) == 0 {
+		return errf.New(errf.InvalidParameter, "reviser is required")
+	}
+
+	args := map[string]interface{}{
+		"id":            model.ID,
+		"state":         model.State,
+		"attempts":      model.Attempts,
+		"status_code":   model.StatusCode,
+		"reason":        model.Reason,
+		"next_retry_at": model.NextRetryAt,
+		"reviser":       model.Reviser,
+	}
+	sql := fmt.Sprintf(`UPDATE %s SET state = :state, attempts = :attempts, status_code = :status_code,
+		reason = :reason, next_retry_at = :next_retry_at, reviser = :reviser WHERE id = :id%s`,
+		table.WebhookDeliveryTable, tools.TenantSqlCondition(kt, "tenant_id", args))
+	effected, err := dao.Orm.Do().Update(kt.Ctx, sql, args)
+	if err != nil {
+		logs.Errorf("update webhook delivery state failed, err: %v, id: %s, rid: %s", err, model.ID, kt.Rid)
+		return err
+	}
+
+	if effected == 0 {
+		return errf.Newf(errf.RecordNotFound, "webhook delivery: %s not found", model.ID)
+	}
+
+	return nil
+}
+
+// List webhook delivery.
+func (dao DeliveryDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tablewebhook.DeliveryTable],
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(
+		tablewebhook.DeliveryColumns.ColumnTypes())), core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is dao count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.WebhookDeliveryTable, whereExpr)
+
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count webhook delivery failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[tablewebhook.DeliveryTable]{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tablewebhook.DeliveryColumns.FieldsNamedExpr(opt.Fields),
+		table.WebhookDeliveryTable, whereExpr, pageExpr)
+
+	details := make([]tablewebhook.DeliveryTable, 0)
+	countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.WebhookDeliveryTable, whereExpr)
+	count, err := orm.SelectPage(kt.Ctx, dao.Orm.Do(), opt.Page.WithCount, &details, countSql, sql, whereValue)
+	if err != nil {
+		logs.Errorf("select webhook delivery failed, err: %v, sql: %s, rid: %s", err, sql, kt.Rid)
+		return nil, err
+	}
+
+	return &types.ListResult[tablewebhook.DeliveryTable]{Count: count, Details: details}, nil
+}
+
+// DeleteWithTx webhook delivery with tx.
+func (dao DeliveryDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error {
+	if expr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TenantSqlWhereOption(kt))
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.WebhookDeliveryTable, whereExpr)
+	if _, err = dao.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete webhook delivery failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
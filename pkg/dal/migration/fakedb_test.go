@@ -0,0 +1,199 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+)
+
+func init() {
+	sql.Register("migration_fake", &fakeDriver{})
+}
+
+var fakeDBs sync.Map
+
+// fakeDB simulates the schema_version and hcm_version tables, and records the migration sql executed on it.
+type fakeDB struct {
+	mu sync.Mutex
+	// sqlVer is the sql_ver of hcm_version view, empty means the view not exists.
+	sqlVer string
+	// versions is the versions recorded in schema_version table.
+	versions []string
+	// applied is the migration sql executed.
+	applied []string
+	// shards is the shard tables which do not have the shard column.
+	shards []string
+	// shardArgs is the args of the shard tables listing query.
+	shardArgs []interface{}
+}
+
+func (f *fakeDB) schemaVersions() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.versions...)
+}
+
+func (f *fakeDB) appliedSQL() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.applied...)
+}
+
+// newFakeDB open a sqlx db on the fake driver with name as its dsn.
+func newFakeDB(t *testing.T, name string, fdb *fakeDB) *sqlx.DB {
+	fakeDBs.Store(name, fdb)
+
+	db, err := sqlx.Open("migration_fake", name)
+	if err != nil {
+		t.Fatalf("open fake db failed, err: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+		fakeDBs.Delete(name)
+	})
+
+	return db
+}
+
+type fakeDriver struct{}
+
+// Open ...
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	v, ok := fakeDBs.Load(name)
+	if !ok {
+		return nil, errors.New("fake db not found")
+	}
+
+	return &fakeConn{db: v.(*fakeDB)}, nil
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+// Prepare ...
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare is not supported")
+}
+
+// Close ...
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+// Begin ...
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transaction is not supported")
+}
+
+// QueryContext ...
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(query, "SELECT GET_LOCK"):
+		return &fakeRows{columns: []string{"locked"}, values: [][]driver.Value{{int64(1)}}}, nil
+
+	case query == "SELECT COUNT(*) FROM schema_version":
+		return &fakeRows{columns: []string{"count"}, values: [][]driver.Value{{int64(len(c.db.versions))}}}, nil
+
+	case query == "SELECT version FROM schema_version":
+		values := make([][]driver.Value, 0, len(c.db.versions))
+		for _, version := range c.db.versions {
+			values = append(values, []driver.Value{version})
+		}
+		return &fakeRows{columns: []string{"version"}, values: values}, nil
+
+	case query == "SELECT sql_ver FROM hcm_version":
+		if c.db.sqlVer == "" {
+			return nil, &mysql.MySQLError{Number: mysqlErrNoSuchTable, Message: "Table 'hcm_version' doesn't exist"}
+		}
+		return &fakeRows{columns: []string{"sql_ver"}, values: [][]driver.Value{{c.db.sqlVer}}}, nil
+
+	case strings.HasPrefix(query, "SELECT TABLE_NAME FROM information_schema.TABLES"):
+		for _, arg := range args {
+			c.db.shardArgs = append(c.db.shardArgs, arg.Value)
+		}
+		values := make([][]driver.Value, 0, len(c.db.shards))
+		for _, shard := range c.db.shards {
+			values = append(values, []driver.Value{shard})
+		}
+		return &fakeRows{columns: []string{"TABLE_NAME"}, values: values}, nil
+	}
+
+	return nil, errors.New("unexpected query: " + query)
+}
+
+// ExecContext ...
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(query, "CREATE TABLE IF NOT EXISTS `schema_version`"),
+		strings.HasPrefix(query, "SELECT RELEASE_LOCK"):
+
+	case strings.HasPrefix(query, "INSERT INTO schema_version"):
+		c.db.versions = append(c.db.versions, args[0].Value.(string))
+
+	default:
+		c.db.applied = append(c.db.applied, query)
+	}
+
+	return driver.RowsAffected(1), nil
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+	idx     int
+}
+
+// Columns ...
+func (r *fakeRows) Columns() []string {
+	return r.columns
+}
+
+// Close ...
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+// Next ...
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.idx >= len(r.values) {
+		return io.EOF
+	}
+
+	copy(dest, r.values[r.idx])
+	r.idx++
+	return nil
+}
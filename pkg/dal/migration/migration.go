@@ -0,0 +1,274 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package migration apply the versioned sql files to database, the applied versions are
+// recorded in schema_version table, so that each sql file is applied only once.
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"hcm/pkg/logs"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	// lockName is the mysql named lock used to make sure only one instance do migration at the same time.
+	lockName = "hcm_schema_migration"
+	// mysqlErrNoSuchTable is the mysql error number of table not exists.
+	mysqlErrNoSuchTable = 1146
+)
+
+// sqlFileRe matches the versioned sql file name, like: 0030_20250108_1100_global_config.sql
+var sqlFileRe = regexp.MustCompile(`^(\d{4})_.*\.sql$`)
+
+// Migration is a versioned sql file.
+type Migration struct {
+	// Version is the sql version, which is the 4 digits prefix of the sql file name.
+	Version string
+	// Name is the sql file name.
+	Name string
+	// SQL is the content of the sql file.
+	SQL string
+}
+
+// Option defines the migration options.
+type Option struct {
+	// LockTimeoutSec is the max seconds to wait for the migration lock.
+	LockTimeoutSec uint
+	// ShardColumns is the columns of the base tables that need to be added to their shard tables.
+	ShardColumns []ShardColumn
+}
+
+// ShardColumn defines a column of the base table that is also required by its shard tables. the shard tables
+// are created from the base table at runtime, so the columns added to the base table by the sql files after
+// a shard table is created are missing in it.
+type ShardColumn struct {
+	// Table is the base table name, its shard tables are named with the base table name and '_' as prefix.
+	Table string
+	// Column is the column name.
+	Column string
+	// Definition is the column definition, like: varchar(64) not null default 'default'.
+	Definition string
+	// Index is the name of the index on the column, empty means no index is needed.
+	Index string
+}
+
+// Load the versioned sql files from the fsys root, sorted by version.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read sql dir failed, err: %v", err)
+	}
+
+	migrations := make([]Migration, 0, len(entries))
+	versions := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		matches := sqlFileRe.FindStringSubmatch(entry.Name())
+		if len(matches) != 2 {
+			continue
+		}
+
+		if exists, ok := versions[matches[1]]; ok {
+			return nil, fmt.Errorf("sql version %s is duplicated, files: %s, %s", matches[1], exists, entry.Name())
+		}
+		versions[matches[1]] = entry.Name()
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("read sql file %s failed, err: %v", entry.Name(), err)
+		}
+
+		migrations = append(migrations, Migration{Version: matches[1], Name: entry.Name(), SQL: string(content)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Run apply the pending migrations to db. the db connection must enable multiStatements,
+// because each sql file contains multiple statements.
+// Note: if schema_version is empty, but the database is already initialized by the migrate.sh
+// script, which means hcm_version view exists, the sql files whose version is not greater than
+// hcm_version's sql_ver are treated as applied.
+func Run(ctx context.Context, db *sqlx.DB, migrations []Migration, opt Option) error {
+	conn, err := db.Connx(ctx)
+	if err != nil {
+		return fmt.Errorf("get migration db connection failed, err: %v", err)
+	}
+	defer conn.Close()
+
+	if err = lock(ctx, conn, opt.LockTimeoutSec); err != nil {
+		return err
+	}
+	defer unlock(conn)
+
+	if err = initSchemaVersion(ctx, conn, migrations); err != nil {
+		return err
+	}
+
+	applied := make([]string, 0)
+	if err = conn.SelectContext(ctx, &applied, "SELECT version FROM schema_version"); err != nil {
+		return fmt.Errorf("list applied schema version failed, err: %v", err)
+	}
+
+	appliedMap := make(map[string]struct{}, len(applied))
+	for _, version := range applied {
+		appliedMap[version] = struct{}{}
+	}
+
+	for _, one := range migrations {
+		if _, exists := appliedMap[one.Version]; exists {
+			continue
+		}
+
+		start := time.Now()
+		if _, err = conn.ExecContext(ctx, one.SQL); err != nil {
+			return fmt.Errorf("apply sql file %s failed, err: %v", one.Name, err)
+		}
+
+		if _, err = conn.ExecContext(ctx, "INSERT INTO schema_version (version, name) VALUES (?, ?)", one.Version,
+			one.Name); err != nil {
+			return fmt.Errorf("record schema version %s failed, err: %v", one.Version, err)
+		}
+
+		logs.Infof("apply sql file %s success, cost: %s", one.Name, time.Since(start))
+	}
+
+	for _, column := range opt.ShardColumns {
+		if err = alignShardColumn(ctx, conn, column); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// alignShardColumn add the column to the shard tables of the base table which do not have it.
+func alignShardColumn(ctx context.Context, conn *sqlx.Conn, column ShardColumn) error {
+	pattern := strings.ReplaceAll(column.Table, "_", `\_`) + `\_%`
+	listSql := "SELECT TABLE_NAME FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() " +
+		"AND TABLE_NAME LIKE ? AND TABLE_NAME NOT IN (SELECT TABLE_NAME FROM information_schema.COLUMNS " +
+		"WHERE TABLE_SCHEMA = DATABASE() AND COLUMN_NAME = ?)"
+
+	shards := make([]string, 0)
+	if err := conn.SelectContext(ctx, &shards, listSql, pattern, column.Column); err != nil {
+		return fmt.Errorf("list %s shard tables without column %s failed, err: %v", column.Table, column.Column, err)
+	}
+
+	for _, shard := range shards {
+		alterSql := fmt.Sprintf("ALTER TABLE `%s` ADD COLUMN `%s` %s", shard, column.Column, column.Definition)
+		if len(column.Index) != 0 {
+			alterSql += fmt.Sprintf(", ADD INDEX `%s` (`%s`)", column.Index, column.Column)
+		}
+
+		if _, err := conn.ExecContext(ctx, alterSql); err != nil {
+			return fmt.Errorf("add column %s to shard table %s failed, err: %v", column.Column, shard, err)
+		}
+
+		logs.Infof("add column %s to shard table %s success", column.Column, shard)
+	}
+
+	return nil
+}
+
+func lock(ctx context.Context, conn *sqlx.Conn, timeoutSec uint) error {
+	var locked sql.NullInt64
+	if err := conn.GetContext(ctx, &locked, "SELECT GET_LOCK(?, ?)", lockName, timeoutSec); err != nil {
+		return fmt.Errorf("get migration lock failed, err: %v", err)
+	}
+
+	if !locked.Valid || locked.Int64 != 1 {
+		return fmt.Errorf("get migration lock timeout after %d seconds", timeoutSec)
+	}
+
+	return nil
+}
+
+func unlock(conn *sqlx.Conn) {
+	if _, err := conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", lockName); err != nil {
+		logs.Errorf("release migration lock failed, err: %v", err)
+	}
+}
+
+// initSchemaVersion create schema_version table if not exists, and record the versions which have already
+// been applied by the migrate.sh script.
+func initSchemaVersion(ctx context.Context, conn *sqlx.Conn, migrations []Migration) error {
+	createSql := "CREATE TABLE IF NOT EXISTS `schema_version` (" +
+		"`version` varchar(16) NOT NULL COMMENT 'sql版本', " +
+		"`name` varchar(255) NOT NULL COMMENT 'sql文件名', " +
+		"`applied_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '执行时间', " +
+		"PRIMARY KEY (`version`)" +
+		") ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE utf8mb4_bin COMMENT = 'sql版本记录表'"
+	if _, err := conn.ExecContext(ctx, createSql); err != nil {
+		return fmt.Errorf("create schema_version table failed, err: %v", err)
+	}
+
+	var count uint64
+	if err := conn.GetContext(ctx, &count, "SELECT COUNT(*) FROM schema_version"); err != nil {
+		return fmt.Errorf("count schema version failed, err: %v", err)
+	}
+
+	if count != 0 {
+		return nil
+	}
+
+	var sqlVer string
+	err := conn.GetContext(ctx, &sqlVer, "SELECT sql_ver FROM hcm_version")
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrNoSuchTable {
+			// database is not initialized, all the migrations need to be applied.
+			return nil
+		}
+
+		return fmt.Errorf("get current sql version failed, err: %v", err)
+	}
+
+	for _, one := range migrations {
+		if one.Version > sqlVer {
+			break
+		}
+
+		if _, err = conn.ExecContext(ctx, "INSERT INTO schema_version (version, name) VALUES (?, ?)", one.Version,
+			one.Name); err != nil {
+			return fmt.Errorf("record baseline schema version %s failed, err: %v", one.Version, err)
+		}
+	}
+
+	logs.Infof("init schema version with current sql version: %s", sqlVer)
+	return nil
+}
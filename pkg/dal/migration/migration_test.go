@@ -0,0 +1,150 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package migration
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+var testMigrations = []Migration{
+	{Version: "0001", Name: "0001_20230101_1000_init.sql", SQL: "create table a (id int);"},
+	{Version: "0002", Name: "0002_20230201_1000_add_b.sql", SQL: "create table b (id int);"},
+	{Version: "0003", Name: "0003_20230301_1000_add_c.sql", SQL: "create table c (id int);"},
+}
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0002_20230201_1000_add_b.sql": {Data: []byte("create table b (id int);")},
+		"0001_20230101_1000_init.sql":  {Data: []byte("create table a (id int);")},
+		"0003_20230301_1000_add_c.sql": {Data: []byte("create table c (id int);")},
+		"migrate.sh":                   {Data: []byte("#!/bin/bash")},
+		"data/0004_data.sql":           {Data: []byte("insert into a values (1);")},
+	}
+
+	migrations, err := Load(fsys)
+	if err != nil {
+		t.Fatalf("load migrations failed, err: %v", err)
+	}
+
+	if !reflect.DeepEqual(migrations, testMigrations) {
+		t.Errorf("load migrations not sorted or filtered, got: %+v", migrations)
+	}
+
+	fsys["0003_20230302_1000_dup.sql"] = &fstest.MapFile{Data: []byte("select 1;")}
+	if _, err = Load(fsys); err == nil {
+		t.Errorf("load duplicated sql version should be failed")
+	}
+}
+
+func TestRunFreshDatabase(t *testing.T) {
+	fdb := new(fakeDB)
+	db := newFakeDB(t, "fresh", fdb)
+
+	if err := Run(context.Background(), db, testMigrations, Option{LockTimeoutSec: 1}); err != nil {
+		t.Fatalf("run migrations failed, err: %v", err)
+	}
+
+	expectApplied := []string{testMigrations[0].SQL, testMigrations[1].SQL, testMigrations[2].SQL}
+	if applied := fdb.appliedSQL(); !reflect.DeepEqual(applied, expectApplied) {
+		t.Errorf("fresh database should apply all migrations, got: %v", applied)
+	}
+
+	if versions := fdb.schemaVersions(); !reflect.DeepEqual(versions, []string{"0001", "0002", "0003"}) {
+		t.Errorf("fresh database should record all versions, got: %v", versions)
+	}
+}
+
+func TestRunBaselineExistingDatabase(t *testing.T) {
+	// database is initialized by migrate.sh to 0002, but schema_version is empty.
+	fdb := &fakeDB{sqlVer: "0002"}
+	db := newFakeDB(t, "baseline", fdb)
+
+	if err := Run(context.Background(), db, testMigrations, Option{LockTimeoutSec: 1}); err != nil {
+		t.Fatalf("run migrations failed, err: %v", err)
+	}
+
+	if applied := fdb.appliedSQL(); !reflect.DeepEqual(applied, []string{testMigrations[2].SQL}) {
+		t.Errorf("baseline database should only apply migrations after sql_ver, got: %v", applied)
+	}
+
+	if versions := fdb.schemaVersions(); !reflect.DeepEqual(versions, []string{"0001", "0002", "0003"}) {
+		t.Errorf("baseline database should record baseline and applied versions, got: %v", versions)
+	}
+}
+
+func TestRunSkipAppliedMigrations(t *testing.T) {
+	// schema_version is not empty, so the hcm_version view should be ignored.
+	fdb := &fakeDB{sqlVer: "0003", versions: []string{"0001", "0002"}}
+	db := newFakeDB(t, "skip", fdb)
+
+	if err := Run(context.Background(), db, testMigrations, Option{LockTimeoutSec: 1}); err != nil {
+		t.Fatalf("run migrations failed, err: %v", err)
+	}
+
+	if applied := fdb.appliedSQL(); !reflect.DeepEqual(applied, []string{testMigrations[2].SQL}) {
+		t.Errorf("applied migrations should be skipped, got: %v", applied)
+	}
+
+	// run again, all migrations are applied, nothing should be executed.
+	if err := Run(context.Background(), db, testMigrations, Option{LockTimeoutSec: 1}); err != nil {
+		t.Fatalf("rerun migrations failed, err: %v", err)
+	}
+
+	if applied := fdb.appliedSQL(); len(applied) != 1 {
+		t.Errorf("rerun migrations should execute nothing, got: %v", applied)
+	}
+
+	if versions := fdb.schemaVersions(); !reflect.DeepEqual(versions, []string{"0001", "0002", "0003"}) {
+		t.Errorf("schema versions not match, got: %v", versions)
+	}
+}
+
+func TestRunAlignShardColumns(t *testing.T) {
+	fdb := &fakeDB{versions: []string{"0001", "0002", "0003"},
+		shards: []string{"account_bill_item_tcloud_202501", "account_bill_item_aws_202502"}}
+	db := newFakeDB(t, "shard", fdb)
+
+	opt := Option{
+		LockTimeoutSec: 1,
+		ShardColumns: []ShardColumn{{Table: "account_bill_item", Column: "tenant_id",
+			Definition: "varchar(64) not null default 'default'", Index: "idx_tenant_id"}},
+	}
+	if err := Run(context.Background(), db, testMigrations, opt); err != nil {
+		t.Fatalf("run migrations failed, err: %v", err)
+	}
+
+	// the underscores of the base table name are escaped, so that only its shard tables are matched.
+	if !reflect.DeepEqual(fdb.shardArgs, []interface{}{`account\_bill\_item\_%`, "tenant_id"}) {
+		t.Errorf("list shard tables args not match, got: %v", fdb.shardArgs)
+	}
+
+	expected := []string{
+		"ALTER TABLE `account_bill_item_tcloud_202501` ADD COLUMN `tenant_id` varchar(64) not null default " +
+			"'default', ADD INDEX `idx_tenant_id` (`tenant_id`)",
+		"ALTER TABLE `account_bill_item_aws_202502` ADD COLUMN `tenant_id` varchar(64) not null default " +
+			"'default', ADD INDEX `idx_tenant_id` (`tenant_id`)",
+	}
+	if applied := fdb.appliedSQL(); !reflect.DeepEqual(applied, expected) {
+		t.Errorf("shard tables should be altered, got: %v", applied)
+	}
+}
@@ -0,0 +1,104 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package accountgroup defines account group and account group relation table.
+package accountgroup
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// AccountGroupColumns defines all the account group table's columns.
+var AccountGroupColumns = utils.MergeColumns(nil, AccountGroupColumnDescriptor)
+
+// AccountGroupColumnDescriptor is account group table column descriptors.
+var AccountGroupColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "parent_id", NamedC: "parent_id", Type: enumor.String},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// AccountGroupTable define account group table.
+type AccountGroupTable struct {
+	ID   string `db:"id" validate:"lte=64" json:"id"`
+	Name string `db:"name" validate:"lte=255" json:"name"`
+	// ParentID 父账号组ID，为空表示顶层账号组
+	ParentID  *string    `db:"parent_id" validate:"omitempty,lte=64" json:"parent_id"`
+	Memo      *string    `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	TenantID  string     `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
+	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time `db:"created_at" validate:"isdefault" json:"created_at"`
+	UpdatedAt types.Time `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName return account group table name.
+func (t AccountGroupTable) TableName() table.Name {
+	return table.AccountGroupTable
+}
+
+// InsertValidate account group table when insert.
+func (t AccountGroupTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate account group table when update.
+func (t AccountGroupTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package accountgroup
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// AccountGroupRelColumns defines all the account group relation table's columns.
+var AccountGroupRelColumns = utils.MergeColumns(utils.InsertWithoutPrimaryID, AccountGroupRelColumnDescriptor)
+
+// AccountGroupRelColumnDescriptor is account group relation table column descriptors.
+var AccountGroupRelColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "group_id", NamedC: "group_id", Type: enumor.String},
+	{Column: "account_type", NamedC: "account_type", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// AccountGroupRelTable 账号组成员关系表
+type AccountGroupRelTable struct {
+	// GroupID 账号组ID
+	GroupID string `db:"group_id" json:"group_id"`
+	// AccountType 成员账号类型
+	AccountType enumor.AccountGroupMemberType `db:"account_type" json:"account_type"`
+	// AccountID 成员账号ID
+	AccountID string `db:"account_id" json:"account_id"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" json:"tenant_id"`
+	// Creator 创建者
+	Creator string `db:"creator" json:"creator"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at"`
+}
+
+// TableName return account group relation table name.
+func (t AccountGroupRelTable) TableName() table.Name {
+	return table.AccountGroupRelTable
+}
+
+// InsertValidate account group relation table when insert.
+func (t AccountGroupRelTable) InsertValidate() error {
+	if len(t.CreatedAt) != 0 {
+		return errors.New("created_at can not set")
+	}
+
+	if len(t.GroupID) == 0 {
+		return errors.New("group id is required")
+	}
+
+	if err := t.AccountType.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.AccountID) == 0 {
+		return errors.New("account id is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
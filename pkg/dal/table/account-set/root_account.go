@@ -45,6 +45,7 @@ var RootAccountColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "dept_id", NamedC: "dept_id", Type: enumor.Numeric},
 	{Column: "memo", NamedC: "memo", Type: enumor.String},
 	{Column: "extension", NamedC: "extension", Type: enumor.Json},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -75,6 +76,8 @@ type RootAccountTable struct {
 	Memo *string `db:"memo" json:"memo"`
 	// Extension 云厂商账号差异扩展字段
 	Extension types.JsonField `db:"extension" json:"extension"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" json:"tenant_id"`
 	// Creator 创建者
 	Creator string `db:"creator" json:"creator"`
 	// Reviser 更新者
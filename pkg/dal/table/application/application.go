@@ -47,6 +47,7 @@ var ApplicationColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "delivery_detail", NamedC: "delivery_detail", Type: enumor.Json},
 	{Column: "memo", NamedC: "memo", Type: enumor.String},
 
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -77,6 +78,8 @@ type ApplicationTable struct {
 	// Memo 备注或申请理由
 	Memo *string `db:"memo" json:"memo" validate:"omitempty,max=255"`
 
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" json:"tenant_id" validate:"max=64"`
 	// Creator 创建者
 	Creator string `db:"creator" json:"creator" validate:"max=64"`
 	// Reviser 更新者
@@ -37,6 +37,7 @@ var ApprovalProcessColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "id", NamedC: "id", Type: enumor.String},
 	{Column: "application_type", NamedC: "application_type", Type: enumor.String},
 	{Column: "service_id", NamedC: "service_id", Type: enumor.Numeric},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -52,6 +53,8 @@ type ApprovalProcessTable struct {
 	ApplicationType string `db:"application_type" json:"application_type" validate:"max=64"`
 	// ServiceID ITSM流程的服务ID
 	ServiceID int64 `db:"service_id" json:"service_id" validate:"min=1"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" json:"tenant_id" validate:"max=64"`
 	// Creator 创建者
 	Creator string `db:"creator" json:"creator" validate:"max=64"`
 	// Reviser 更新者
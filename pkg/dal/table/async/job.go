@@ -0,0 +1,67 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tableasync
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// AsyncJobColumns defines all the async_job view's columns.
+var AsyncJobColumns = utils.MergeColumns(nil, AsyncJobColumnDescriptor)
+
+// AsyncJobColumnDescriptor is async_job's column descriptors.
+var AsyncJobColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "kind", NamedC: "kind", Type: enumor.String},
+	{Column: "category", NamedC: "category", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "state", NamedC: "state", Type: enumor.String},
+	{Column: "reason", NamedC: "reason", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// AsyncJobTable 异步任务，数据来源于视图 async_job，只读。
+// 异步任务流(kind=flow)的ID为 async_flow 的ID，账号资源同步(kind=sync)的ID为账号ID，因此需要通过 kind+id 唯一确定一个任务。
+type AsyncJobTable struct {
+	ID       string                  `db:"id" json:"id"`
+	Kind     enumor.AsyncJobKind     `db:"kind" json:"kind"`
+	Category enumor.AsyncJobCategory `db:"category" json:"category"`
+	// Name 异步任务流为 flow 名称，账号资源同步为 account_sync
+	Name      string           `db:"name" json:"name"`
+	Vendor    enumor.Vendor    `db:"vendor" json:"vendor"`
+	AccountID string           `db:"account_id" json:"account_id"`
+	State     enumor.FlowState `db:"state" json:"state"`
+	Reason    string           `db:"reason" json:"reason"`
+	Creator   string           `db:"creator" json:"creator"`
+	CreatedAt types.Time       `db:"created_at" json:"created_at"`
+	UpdatedAt types.Time       `db:"updated_at" json:"updated_at"`
+}
+
+// TableName return async_job table name.
+func (a AsyncJobTable) TableName() table.Name {
+	return table.AsyncJobTable
+}
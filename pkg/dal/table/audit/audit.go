@@ -52,6 +52,7 @@ var AuditColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "rid", NamedC: "rid", Type: enumor.String},
 	{Column: "app_code", NamedC: "app_code", Type: enumor.String},
 	{Column: "detail", NamedC: "detail", Type: enumor.Json},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
 }
 
@@ -71,6 +72,7 @@ type AuditTable struct {
 	Rid        string                   `db:"rid" json:"rid" validate:"lte=64"`
 	AppCode    string                   `db:"app_code" json:"app_code" validate:"lte=64"`
 	Detail     *BasicDetail             `db:"detail" json:"detail" validate:"-"`
+	TenantID   string                   `db:"tenant_id" json:"tenant_id" validate:"lte=64"`
 	CreatedAt  types.Time               `db:"created_at" json:"created_at"`
 }
 
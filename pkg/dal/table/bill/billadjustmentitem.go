@@ -53,6 +53,7 @@ var AccountBillAdjustmentItemColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "cost", NamedC: "cost", Type: enumor.Numeric},
 	{Column: "rmb_cost", NamedC: "rmb_cost", Type: enumor.Numeric},
 	{Column: "state", NamedC: "state", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
 	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
@@ -93,6 +94,8 @@ type AccountBillAdjustmentItem struct {
 	// State 状态，未确定、已确定
 	State enumor.BillAdjustmentState `db:"state" json:"string"`
 
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" validate:"max=64" json:"tenant_id"`
 	// Creator 创建者
 	Creator string `db:"creator" validate:"max=64" json:"creator"`
 	// CreatedAt 创建时间
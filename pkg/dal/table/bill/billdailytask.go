@@ -55,6 +55,7 @@ var AccountBillDailyPullTaskDescriptor = utils.ColumnDescriptors{
 	{Column: "flow_id", NamedC: "flow_id", Type: enumor.String},
 	{Column: "split_flow_id", NamedC: "split_flow_id", Type: enumor.String},
 	{Column: "daily_summary_flow_id", NamedC: "daily_summary_flow_id", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
 	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
 }
@@ -99,6 +100,8 @@ type AccountBillDailyPullTask struct {
 	SplitFlowID string `db:"split_flow_id" json:"split_flow_id"`
 	// DailySummaryFlowID daily summary flow id
 	DailySummaryFlowID *string `db:"daily_summary_flow_id" json:"daily_summary_flow_id"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" json:"tenant_id"`
 	// CreatedAt 创建时间
 	CreatedAt types.Time `db:"created_at" json:"created_at"`
 	// UpdatedAt 更新时间
@@ -52,6 +52,7 @@ var AccountBillItemColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "res_amount", NamedC: "res_amount", Type: enumor.Numeric},
 	{Column: "res_amount_unit", NamedC: "res_amount_unit", Type: enumor.String},
 	{Column: "extension", NamedC: "extension", Type: enumor.Json},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -94,6 +95,8 @@ type AccountBillItem struct {
 	ResAmountUnit string `db:"res_amount_unit" json:"res_amount_unit,omitempty"`
 	// Extension 云原始字段
 	Extension types.JsonField `db:"extension" json:"extension"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" json:"tenant_id"`
 	// Creator 创建者
 	Creator string `db:"creator" json:"creator"`
 	// Reviser 更新者
@@ -53,6 +53,7 @@ var AccountBillMonthTaskDescriptor = utils.ColumnDescriptors{
 	{Column: "split_flow_id", NamedC: "split_flow_id", Type: enumor.String},
 	{Column: "summary_flow_id", NamedC: "summary_flow_id", Type: enumor.String},
 	{Column: "summary_detail", NamedC: "summary_detail", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -97,6 +98,8 @@ type AccountBillMonthTask struct {
 	SummaryFlowID string `db:"summary_flow_id" json:"summary_flow_id"`
 	// SummaryDetail detail of summary
 	SummaryDetail types.JsonField `db:"summary_detail" json:"summary_detail"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" json:"tenant_id"`
 	// Creator 创建者
 	Creator string `db:"creator" json:"creator"`
 	// Reviser 更新者
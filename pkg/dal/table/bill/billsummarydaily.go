@@ -51,6 +51,7 @@ var AccountBillSummaryDailyColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "currency", NamedC: "currency", Type: enumor.String},
 	{Column: "cost", NamedC: "cost", Type: enumor.Numeric},
 	{Column: "count", NamedC: "count", Type: enumor.Numeric},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -89,6 +90,8 @@ type AccountBillSummaryDaily struct {
 	Cost *types.Decimal `db:"cost" json:"cost"`
 	// Count 账单条数
 	Count int64 `db:"count" json:"count"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" json:"tenant_id"`
 	// Creator 创建者
 	Creator string `db:"creator" json:"creator"`
 	// Reviser 更新者
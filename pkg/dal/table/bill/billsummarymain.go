@@ -61,6 +61,7 @@ var AccountBillSummaryMainColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "adjustment_cost", NamedC: "adjustment_cost", Type: enumor.Numeric},
 	{Column: "adjustment_rmb_cost", NamedC: "adjustment_rmb_cost", Type: enumor.Numeric},
 	{Column: "state", NamedC: "state", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
 	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
 }
@@ -119,6 +120,8 @@ type AccountBillSummaryMain struct {
 	AdjustmentRMBCost *types.Decimal `db:"adjustment_rmb_cost" json:"adjustment_rmb_cost"`
 	// State 状态
 	State enumor.MainBillSummaryState `db:"state" json:"state"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" json:"tenant_id"`
 	// CreatedAt 创建时间
 	CreatedAt types.Time `db:"created_at" json:"created_at"`
 	// UpdatedAt 更新时间
@@ -58,6 +58,7 @@ var AccountBillSummaryRootColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "bk_biz_num", NamedC: "bk_biz_num", Type: enumor.Numeric},
 	{Column: "product_num", NamedC: "product_num", Type: enumor.Numeric},
 	{Column: "state", NamedC: "state", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
 	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
 }
@@ -108,6 +109,8 @@ type AccountBillSummaryRoot struct {
 	ProductNum uint64 `db:"product_num" json:"product_num"`
 	// State 状态
 	State enumor.RootBillSummaryState `db:"state" json:"state"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" json:"tenant_id"`
 	// CreatedAt 创建时间
 	CreatedAt types.Time `db:"created_at" json:"created_at"`
 	// UpdatedAt 更新时间
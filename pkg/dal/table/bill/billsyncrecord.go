@@ -47,6 +47,7 @@ var AccountBillSyncRecordColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "adjustment_flow_id", NamedC: "adjustment_flow_id", Type: enumor.String},
 	{Column: "state", NamedC: "state", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
 	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
@@ -79,6 +80,8 @@ type AccountBillSyncRecord struct {
 	// Operator 操作人
 	Operator string `db:"operator" validate:"max=64" json:"operator"`
 
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" json:"tenant_id"`
 	// Creator 创建者
 	Creator string `db:"creator" json:"creator"`
 	// Reviser 更新者
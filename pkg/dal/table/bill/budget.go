@@ -0,0 +1,148 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// AccountBillBudgetColumns defines account_bill_budget's columns.
+var AccountBillBudgetColumns = utils.MergeColumns(nil, AccountBillBudgetColumnDescriptor)
+
+// AccountBillBudgetColumnDescriptor is account_bill_budget's column descriptors.
+var AccountBillBudgetColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "scope_type", NamedC: "scope_type", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "main_account_id", NamedC: "main_account_id", Type: enumor.String},
+	{Column: "currency", NamedC: "currency", Type: enumor.String},
+	{Column: "amount", NamedC: "amount", Type: enumor.Numeric},
+	{Column: "thresholds", NamedC: "thresholds", Type: enumor.Json},
+	{Column: "notice_users", NamedC: "notice_users", Type: enumor.Json},
+	{Column: "alert_month", NamedC: "alert_month", Type: enumor.Numeric},
+	{Column: "alert_threshold", NamedC: "alert_threshold", Type: enumor.Numeric},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// AccountBillBudget 账单预算表
+type AccountBillBudget struct {
+	// ID 自增ID
+	ID string `db:"id" validate:"lte=64" json:"id"`
+	// Name 预算名称
+	Name string `db:"name" validate:"lte=255" json:"name"`
+	// ScopeType 预算范围类型
+	ScopeType enumor.BudgetScopeType `db:"scope_type" json:"scope_type"`
+	// BkBizID 业务ID，业务预算时有效
+	BkBizID int64 `db:"bk_biz_id" json:"bk_biz_id"`
+	// MainAccountID 二级账号ID，二级账号预算时有效
+	MainAccountID string `db:"main_account_id" validate:"lte=64" json:"main_account_id"`
+	// Currency 币种
+	Currency enumor.CurrencyCode `db:"currency" json:"currency"`
+	// Amount 月度预算金额
+	Amount *types.Decimal `db:"amount" json:"amount"`
+	// Thresholds 告警阈值，预算金额的百分比
+	Thresholds types.Int64Array `db:"thresholds" json:"thresholds"`
+	// NoticeUsers 告警通知人
+	NoticeUsers types.StringArray `db:"notice_users" json:"notice_users"`
+	// AlertMonth 最近一次告警的账期，格式：yyyymm
+	AlertMonth *int `db:"alert_month" json:"alert_month"`
+	// AlertThreshold 最近一次告警账期内已告警的最高阈值
+	AlertThreshold *int64 `db:"alert_threshold" json:"alert_threshold"`
+	// Memo 备注
+	Memo *string `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" json:"tenant_id"`
+	// Creator 创建人
+	Creator string `db:"creator" json:"creator"`
+	// Reviser 修改人
+	Reviser string `db:"reviser" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" json:"updated_at"`
+}
+
+// TableName 返回账单预算表名
+func (b *AccountBillBudget) TableName() table.Name {
+	return table.AccountBillBudgetTable
+}
+
+// InsertValidate validate bill budget on insert
+func (b *AccountBillBudget) InsertValidate() error {
+	if len(b.ID) == 0 {
+		return errors.New("id is required")
+	}
+	if len(b.Name) == 0 {
+		return errors.New("name is required")
+	}
+	if err := b.ScopeType.Validate(); err != nil {
+		return err
+	}
+	if len(b.Currency) == 0 {
+		return errors.New("currency is required")
+	}
+	if b.Amount == nil || !b.Amount.IsPositive() {
+		return errors.New("amount should be positive")
+	}
+	if len(b.Thresholds) == 0 {
+		return errors.New("thresholds is required")
+	}
+	if len(b.NoticeUsers) == 0 {
+		return errors.New("notice users is required")
+	}
+	if len(b.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+	if len(b.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+	return validator.Validate.Struct(b)
+}
+
+// UpdateValidate validate bill budget on update
+func (b *AccountBillBudget) UpdateValidate() error {
+	if len(b.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+	if len(b.Creator) != 0 {
+		return errors.New("creator is not allowed")
+	}
+	if len(b.ScopeType) != 0 {
+		return errors.New("scope type can not update")
+	}
+	if b.Amount != nil && !b.Amount.IsPositive() {
+		return errors.New("amount should be positive")
+	}
+	return validator.Validate.Struct(b)
+}
@@ -0,0 +1,137 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// AccountBillReconciliationColumns defines account_bill_reconciliation's columns.
+var AccountBillReconciliationColumns = utils.MergeColumns(nil, AccountBillReconciliationColumnDescriptor)
+
+// AccountBillReconciliationColumnDescriptor is account_bill_reconciliation's column descriptors.
+var AccountBillReconciliationColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "root_account_id", NamedC: "root_account_id", Type: enumor.String},
+	{Column: "main_account_id", NamedC: "main_account_id", Type: enumor.String},
+	{Column: "bill_year", NamedC: "bill_year", Type: enumor.Numeric},
+	{Column: "bill_month", NamedC: "bill_month", Type: enumor.Numeric},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "res_cloud_id", NamedC: "res_cloud_id", Type: enumor.String},
+	{Column: "hc_product_code", NamedC: "hc_product_code", Type: enumor.String},
+	{Column: "currency", NamedC: "currency", Type: enumor.String},
+	{Column: "cost", NamedC: "cost", Type: enumor.Numeric},
+	{Column: "res_amount", NamedC: "res_amount", Type: enumor.Numeric},
+	{Column: "res_amount_unit", NamedC: "res_amount_unit", Type: enumor.String},
+	{Column: "reason", NamedC: "reason", Type: enumor.String},
+
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// AccountBillReconciliation 账单对账结果表，记录账单中无法与HCM资源对应的费用
+type AccountBillReconciliation struct {
+	// ID 自增ID
+	ID string `db:"id" validate:"lte=64" json:"id"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `db:"vendor" json:"vendor"`
+	// RootAccountID 一级账号ID
+	RootAccountID string `db:"root_account_id" json:"root_account_id"`
+	// MainAccountID 二级账号ID
+	MainAccountID string `db:"main_account_id" json:"main_account_id"`
+	// BillYear 账单年份
+	BillYear int `db:"bill_year" json:"bill_year"`
+	// BillMonth 账单月份
+	BillMonth int `db:"bill_month" json:"bill_month"`
+	// ResType 资源类型
+	ResType enumor.CloudResourceType `db:"res_type" json:"res_type"`
+	// ResCloudID 账单中的云资源ID
+	ResCloudID string `db:"res_cloud_id" validate:"lte=255" json:"res_cloud_id"`
+	// HcProductCode 云服务代号
+	HcProductCode string `db:"hc_product_code" json:"hc_product_code"`
+	// Currency 币种
+	Currency enumor.CurrencyCode `db:"currency" json:"currency"`
+	// Cost 该资源当月费用
+	Cost *types.Decimal `db:"cost" json:"cost"`
+	// ResAmount 该资源当月用量，如主机的计费时长
+	ResAmount *types.Decimal `db:"res_amount" json:"res_amount"`
+	// ResAmountUnit 用量单位
+	ResAmountUnit string `db:"res_amount_unit" json:"res_amount_unit"`
+	// Reason 无法对应的原因
+	Reason enumor.BillReconcileReason `db:"reason" json:"reason"`
+
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" json:"tenant_id"`
+	// Creator 创建人
+	Creator string `db:"creator" json:"creator"`
+	// Reviser 修改人
+	Reviser string `db:"reviser" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" json:"updated_at"`
+}
+
+// TableName 返回账单对账结果表名
+func (r *AccountBillReconciliation) TableName() table.Name {
+	return table.AccountBillReconciliationTable
+}
+
+// InsertValidate validate bill reconciliation on insert
+func (r *AccountBillReconciliation) InsertValidate() error {
+	if len(r.ID) == 0 {
+		return errors.New("id is required")
+	}
+	if len(r.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+	if len(r.MainAccountID) == 0 {
+		return errors.New("main account id is required")
+	}
+	if r.BillYear == 0 || r.BillMonth == 0 {
+		return errors.New("bill year and bill month are required")
+	}
+	if len(r.ResCloudID) == 0 {
+		return errors.New("res cloud id is required")
+	}
+	if r.Cost == nil {
+		return errors.New("cost is required")
+	}
+	if len(r.Reason) == 0 {
+		return errors.New("reason is required")
+	}
+	if len(r.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+	if len(r.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+	return validator.Validate.Struct(r)
+}
@@ -38,6 +38,7 @@ var BizTypeTableColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "biz_type", NamedC: "biz_type", Type: enumor.String},
 	{Column: "cover_ping", NamedC: "cover_ping", Type: enumor.Numeric},
 	{Column: "deployment_architecture", NamedC: "deployment_architecture", Type: enumor.Json},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.String},
@@ -54,6 +55,8 @@ type BizTypeTable struct {
 	CoverPing float64 `db:"cover_ping" json:"cover_ping"`
 	// DeploymentArchitecture 部署方式
 	DeploymentArchitecture types.StringArray `db:"deployment_architecture" json:"deployment_architecture"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" validate:"max=64" json:"tenant_id"`
 	// Creator 创建者
 	Creator string `db:"creator" validate:"max=64" json:"creator"`
 	// Reviser 更新者
@@ -40,6 +40,7 @@ var IdcTableColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
 	{Column: "country", NamedC: "country", Type: enumor.String},
 	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.String},
@@ -60,6 +61,8 @@ type IdcTable struct {
 	Country string `db:"country" json:"country"`
 	// Region 地域
 	Region string `db:"region" json:"region"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" validate:"max=64" json:"tenant_id"`
 	// Creator 创建者
 	Creator string `db:"creator" validate:"max=64" json:"creator"`
 	// Reviser 更新者
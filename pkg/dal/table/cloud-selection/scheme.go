@@ -47,6 +47,7 @@ var SchemeTableColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "cover_rate", NamedC: "cover_rate", Type: enumor.Numeric},
 	{Column: "user_distribution", NamedC: "user_distribution", Type: enumor.Json},
 	{Column: "result_idc_ids", NamedC: "result_idc_ids", Type: enumor.Json},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.String},
@@ -81,6 +82,8 @@ type SchemeTable struct {
 	UserDistribution types.AreaInfos `db:"user_distribution" json:"user_distribution"`
 	// ResultIdcIDs 推荐机房ID列表
 	ResultIdcIDs types.StringArray `db:"result_idc_ids" json:"result_idc_ids"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" validate:"max=64" json:"tenant_id"`
 	// Creator 创建者
 	Creator string `db:"creator" validate:"max=64" json:"creator"`
 	// Reviser 更新者
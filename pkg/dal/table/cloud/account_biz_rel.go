@@ -35,6 +35,7 @@ var AccountBizRelColumns = utils.MergeColumns(utils.InsertWithoutPrimaryID, Acco
 var AccountBizRelColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
 	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
 }
@@ -45,6 +46,8 @@ type AccountBizRelTable struct {
 	BkBizID int64 `db:"bk_biz_id" json:"bk_biz_id"`
 	// AccountID 云账号主键 ID
 	AccountID string `db:"account_id" json:"account_id"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" json:"tenant_id"`
 	// Creator 创建者
 	Creator string `db:"creator" json:"creator"`
 	// CreatedAt 创建时间
@@ -44,6 +44,7 @@ var ArgumentTplTableColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "templates", NamedC: "templates", Type: enumor.Json},
 	{Column: "group_templates", NamedC: "group_templates", Type: enumor.Json},
 	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.String},
@@ -72,6 +73,8 @@ type ArgumentTemplateTable struct {
 	GroupTemplates types.JsonField `db:"group_templates" json:"group_templates"`
 	// Memo 备注
 	Memo *string `db:"memo" json:"memo"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" validate:"max=64" json:"tenant_id"`
 	// Creator 创建者
 	Creator string `db:"creator" validate:"max=64" json:"creator"`
 	// Reviser 更新者
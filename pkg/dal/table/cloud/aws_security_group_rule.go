@@ -50,6 +50,7 @@ var AwsSGRuleColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "cloud_group_owner_id", NamedC: "cloud_group_owner_id", Type: enumor.String},
 	{Column: "security_group_id", NamedC: "security_group_id", Type: enumor.String},
 	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -74,6 +75,7 @@ type AwsSecurityGroupRuleTable struct {
 	SecurityGroupID            string     `db:"security_group_id" json:"security_group_id" validate:"lte=64"`
 	AccountID                  string     `db:"account_id" json:"account_id" validate:"lte=64"`
 	Region                     string     `db:"region" json:"region" validate:"lte=20"`
+	TenantID                   string     `db:"tenant_id" json:"tenant_id" validate:"lte=64"`
 	Creator                    string     `db:"creator" json:"creator" validate:"lte=64"`
 	Reviser                    string     `db:"reviser" json:"reviser" validate:"lte=64"`
 	CreatedAt                  types.Time `db:"created_at" json:"created_at" validate:"excluded_unless"`
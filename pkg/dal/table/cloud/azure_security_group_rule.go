@@ -59,6 +59,7 @@ var AzureSGRuleColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "priority", NamedC: "priority", Type: enumor.Numeric},
 	{Column: "region", NamedC: "region", Type: enumor.String},
 	{Column: "access", NamedC: "access", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -91,6 +92,7 @@ type AzureSecurityGroupRuleTable struct {
 	SourcePortRanges                    types.StringArray `db:"source_port_ranges" json:"source_port_ranges"`
 	Priority                            int32             `db:"priority" json:"priority"`
 	Access                              string            `db:"access" validate:"lte=20" json:"access"`
+	TenantID                            string            `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
 	Creator                             string            `db:"creator" validate:"lte=64" json:"creator"`
 	Reviser                             string            `db:"reviser" validate:"lte=64" json:"reviser"`
 	CreatedAt                           types.Time        `db:"created_at" validate:"excluded_unless" json:"created_at"`
@@ -43,6 +43,7 @@ var AwsBillColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "status", NamedC: "status", Type: enumor.Numeric},
 	{Column: "err_msg", NamedC: "err_msg", Type: enumor.Json},
 	{Column: "extension", NamedC: "extension", Type: enumor.Json},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -67,6 +68,8 @@ type AccountBillConfigTable struct {
 	Status int64 `db:"status" json:"status"`
 	// ErrMsg 错误描述
 	ErrMsg types.JsonField `db:"err_msg" json:"err_msg"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" validate:"max=64" json:"tenant_id"`
 	// Creator 创建者
 	Creator string `db:"creator" validate:"max=64" json:"creator"`
 	// Reviser 更新者
@@ -0,0 +1,128 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// CmdbHostRelColumns defines all the cmdb host rel table's columns.
+var CmdbHostRelColumns = utils.MergeColumns(nil, CmdbHostRelColumnDescriptor)
+
+// CmdbHostRelColumnDescriptor is cmdb host rel table column descriptors.
+var CmdbHostRelColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "cvm_id", NamedC: "cvm_id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "cloud_id", NamedC: "cloud_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "bk_host_id", NamedC: "bk_host_id", Type: enumor.Numeric},
+	{Column: "bk_module_id", NamedC: "bk_module_id", Type: enumor.Numeric},
+	{Column: "sync_status", NamedC: "sync_status", Type: enumor.String},
+	{Column: "sync_message", NamedC: "sync_message", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// CmdbHostRelTable define cvm and cmdb host relation table.
+type CmdbHostRelTable struct {
+	ID        string        `db:"id" validate:"lte=64" json:"id"`
+	CvmID     string        `db:"cvm_id" validate:"lte=64" json:"cvm_id"`
+	Vendor    enumor.Vendor `db:"vendor" validate:"lte=16" json:"vendor"`
+	AccountID string        `db:"account_id" validate:"lte=64" json:"account_id"`
+	CloudID   string        `db:"cloud_id" validate:"lte=255" json:"cloud_id"`
+	// BkBizID 同步到的cmdb业务ID
+	BkBizID int64 `db:"bk_biz_id" json:"bk_biz_id"`
+	// BkHostID cmdb主机ID，同步失败时为0
+	BkHostID int64 `db:"bk_host_id" json:"bk_host_id"`
+	// BkModuleID 转移到的cmdb模块ID，未转移时为0
+	BkModuleID  int64                     `db:"bk_module_id" json:"bk_module_id"`
+	SyncStatus  enumor.CmdbHostSyncStatus `db:"sync_status" validate:"lte=16" json:"sync_status"`
+	SyncMessage string                    `db:"sync_message" validate:"lte=1024" json:"sync_message"`
+	TenantID    string                    `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
+	Creator     string                    `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser     string                    `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt   types.Time                `db:"created_at" validate:"isdefault" json:"created_at"`
+	UpdatedAt   types.Time                `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName return cmdb host rel table name.
+func (t CmdbHostRelTable) TableName() table.Name {
+	return table.CmdbHostRelTable
+}
+
+// InsertValidate cmdb host rel table when insert.
+func (t CmdbHostRelTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.CvmID) == 0 {
+		return errors.New("cvm id is required")
+	}
+
+	if err := t.Vendor.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.AccountID) == 0 {
+		return errors.New("account id is required")
+	}
+
+	if len(t.CloudID) == 0 {
+		return errors.New("cloud id is required")
+	}
+
+	if t.BkBizID <= 0 {
+		return errors.New("bk biz id is required")
+	}
+
+	if err := t.SyncStatus.Validate(); err != nil {
+		return err
+	}
+
+	if t.SyncStatus == enumor.CmdbHostSyncSuccess && t.BkHostID <= 0 {
+		return errors.New("bk host id is required when sync success")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
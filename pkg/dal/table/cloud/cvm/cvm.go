@@ -63,6 +63,9 @@ var TableColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "cloud_created_time", NamedC: "cloud_created_time", Type: enumor.String},
 	{Column: "cloud_launched_time", NamedC: "cloud_launched_time", Type: enumor.String},
 	{Column: "cloud_expired_time", NamedC: "cloud_expired_time", Type: enumor.String},
+	{Column: "owner", NamedC: "owner", Type: enumor.String},
+	{Column: "labels", NamedC: "labels", Type: enumor.Json},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -99,6 +102,9 @@ type Table struct {
 	CloudCreatedTime     string            `db:"cloud_created_time" json:"cloud_created_time"`
 	CloudLaunchedTime    string            `db:"cloud_launched_time" json:"cloud_launched_time"`
 	CloudExpiredTime     string            `db:"cloud_expired_time" json:"cloud_expired_time"`
+	Owner                string            `db:"owner" validate:"lte=64" json:"owner"`
+	Labels               types.StringMap   `db:"labels" json:"labels"`
+	TenantID             string            `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
 	Creator              string            `db:"creator" validate:"lte=64" json:"creator"`
 	Reviser              string            `db:"reviser" validate:"lte=64" json:"reviser"`
 	CreatedAt            types.Time        `db:"created_at" validate:"excluded_unless" json:"created_at"`
@@ -0,0 +1,188 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ScheduleColumns defines all the cvm schedule table's columns.
+var ScheduleColumns = utils.MergeColumns(nil, ScheduleColumnDescriptor)
+
+// ScheduleColumnDescriptor is cvm schedule table column descriptors.
+var ScheduleColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "filter", NamedC: "filter", Type: enumor.Json},
+	{Column: "start_cron", NamedC: "start_cron", Type: enumor.String},
+	{Column: "stop_cron", NamedC: "stop_cron", Type: enumor.String},
+	{Column: "timezone", NamedC: "timezone", Type: enumor.String},
+	{Column: "enabled", NamedC: "enabled", Type: enumor.Boolean},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// ScheduleTable define cvm schedule table.
+type ScheduleTable struct {
+	ID   string `db:"id" validate:"lte=64" json:"id"`
+	Name string `db:"name" validate:"lte=255" json:"name"`
+	// Filter 目标主机过滤条件，结构为 filter.Expression
+	Filter types.JsonField `db:"filter" json:"filter"`
+	// StartCron 开机cron表达式，为空表示不自动开机
+	StartCron *string `db:"start_cron" validate:"omitempty,lte=64" json:"start_cron"`
+	// StopCron 关机cron表达式，为空表示不自动关机
+	StopCron *string `db:"stop_cron" validate:"omitempty,lte=64" json:"stop_cron"`
+	// Timezone cron表达式的时区，如 Asia/Shanghai
+	Timezone  string     `db:"timezone" validate:"lte=64" json:"timezone"`
+	Enabled   *bool      `db:"enabled" json:"enabled"`
+	Memo      *string    `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	TenantID  string     `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
+	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time `db:"created_at" validate:"isdefault" json:"created_at"`
+	UpdatedAt types.Time `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName return cvm schedule table name.
+func (t ScheduleTable) TableName() table.Name {
+	return table.CvmScheduleTable
+}
+
+// InsertValidate cvm schedule table when insert.
+func (t ScheduleTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if len(t.Filter) == 0 {
+		return errors.New("filter is required")
+	}
+
+	if len(t.Timezone) == 0 {
+		return errors.New("timezone is required")
+	}
+
+	if t.Enabled == nil {
+		return errors.New("enabled is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate cvm schedule table when update.
+func (t ScheduleTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// ScheduleRecordColumns defines all the cvm schedule record table's columns.
+var ScheduleRecordColumns = utils.MergeColumns(nil, ScheduleRecordColumnDescriptor)
+
+// ScheduleRecordColumnDescriptor is cvm schedule record table column descriptors.
+var ScheduleRecordColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "schedule_id", NamedC: "schedule_id", Type: enumor.String},
+	{Column: "action", NamedC: "action", Type: enumor.String},
+	{Column: "cvm_ids", NamedC: "cvm_ids", Type: enumor.Json},
+	{Column: "failed_cvm_ids", NamedC: "failed_cvm_ids", Type: enumor.Json},
+	{Column: "cpu_core", NamedC: "cpu_core", Type: enumor.Numeric},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// ScheduleRecordTable define cvm schedule record table.
+type ScheduleRecordTable struct {
+	ID         string                   `db:"id" validate:"lte=64" json:"id"`
+	ScheduleID string                   `db:"schedule_id" validate:"lte=64" json:"schedule_id"`
+	Action     enumor.CvmScheduleAction `db:"action" validate:"lte=16" json:"action"`
+	// CvmIDs 执行成功的主机ID列表
+	CvmIDs types.StringArray `db:"cvm_ids" json:"cvm_ids"`
+	// FailedCvmIDs 执行失败的主机ID列表
+	FailedCvmIDs types.StringArray `db:"failed_cvm_ids" json:"failed_cvm_ids"`
+	// CpuCore 执行成功的主机vCPU核数之和
+	CpuCore   int64      `db:"cpu_core" json:"cpu_core"`
+	TenantID  string     `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
+	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
+	CreatedAt types.Time `db:"created_at" validate:"isdefault" json:"created_at"`
+}
+
+// TableName return cvm schedule record table name.
+func (t ScheduleRecordTable) TableName() table.Name {
+	return table.CvmScheduleRecordTable
+}
+
+// InsertValidate cvm schedule record table when insert.
+func (t ScheduleRecordTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.ScheduleID) == 0 {
+		return errors.New("schedule id is required")
+	}
+
+	if err := t.Action.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
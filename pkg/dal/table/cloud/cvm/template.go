@@ -0,0 +1,157 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// TemplateColumns defines all the cvm template table's columns.
+var TemplateColumns = utils.MergeColumns(nil, TemplateColumnDescriptor)
+
+// TemplateColumnDescriptor is cvm template table column descriptors.
+var TemplateColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "zone", NamedC: "zone", Type: enumor.String},
+	{Column: "cloud_image_id", NamedC: "cloud_image_id", Type: enumor.String},
+	{Column: "instance_type", NamedC: "instance_type", Type: enumor.String},
+	{Column: "cloud_security_group_ids", NamedC: "cloud_security_group_ids", Type: enumor.Json},
+	{Column: "system_disk", NamedC: "system_disk", Type: enumor.Json},
+	{Column: "data_disk", NamedC: "data_disk", Type: enumor.Json},
+	{Column: "user_data", NamedC: "user_data", Type: enumor.String},
+	{Column: "extension", NamedC: "extension", Type: enumor.Json},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// TemplateTable define cvm template table.
+type TemplateTable struct {
+	ID        string        `db:"id" validate:"lte=64" json:"id"`
+	Name      string        `db:"name" validate:"lte=255" json:"name"`
+	Vendor    enumor.Vendor `db:"vendor" validate:"lte=16" json:"vendor"`
+	AccountID string        `db:"account_id" validate:"lte=64" json:"account_id"`
+	Region    string        `db:"region" validate:"lte=255" json:"region"`
+	Zone      string        `db:"zone" validate:"lte=255" json:"zone"`
+	// CloudImageID 云镜像ID
+	CloudImageID string `db:"cloud_image_id" validate:"lte=255" json:"cloud_image_id"`
+	// InstanceType 机型
+	InstanceType string `db:"instance_type" validate:"lte=255" json:"instance_type"`
+	// CloudSecurityGroupIDs 云安全组ID列表
+	CloudSecurityGroupIDs types.StringArray `db:"cloud_security_group_ids" json:"cloud_security_group_ids"`
+	// SystemDisk 系统盘，结构与对应云厂商的主机创建请求中的 system_disk 一致
+	SystemDisk types.JsonField `db:"system_disk" json:"system_disk"`
+	// DataDisk 数据盘，结构与对应云厂商的主机创建请求中的 data_disk 一致
+	DataDisk types.JsonField `db:"data_disk" json:"data_disk"`
+	// UserData 自定义数据，base64编码
+	UserData string `db:"user_data" json:"user_data"`
+	// Extension 云厂商差异化的主机创建参数，如 vpc、子网、计费方式等
+	Extension types.JsonField `db:"extension" json:"extension"`
+	Memo      *string         `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	TenantID  string          `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
+	Creator   string          `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string          `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time      `db:"created_at" validate:"isdefault" json:"created_at"`
+	UpdatedAt types.Time      `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName return cvm template table name.
+func (t TemplateTable) TableName() table.Name {
+	return table.CvmTemplateTable
+}
+
+// InsertValidate cvm template table when insert.
+func (t TemplateTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if err := t.Vendor.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.AccountID) == 0 {
+		return errors.New("account id is required")
+	}
+
+	if len(t.Region) == 0 {
+		return errors.New("region is required")
+	}
+
+	if len(t.CloudImageID) == 0 {
+		return errors.New("cloud image id is required")
+	}
+
+	if len(t.InstanceType) == 0 {
+		return errors.New("instance type is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate cvm template table when update.
+func (t TemplateTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Vendor) != 0 {
+		return errors.New("vendor can not update")
+	}
+
+	if len(t.AccountID) != 0 {
+		return errors.New("account id can not update")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
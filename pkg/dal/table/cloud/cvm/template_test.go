@@ -0,0 +1,81 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+func validTemplate() TemplateTable {
+	return TemplateTable{
+		ID:           "00000001",
+		Name:         "tpl",
+		Vendor:       enumor.TCloud,
+		AccountID:    "account-1",
+		Region:       "ap-guangzhou",
+		CloudImageID: "img-1",
+		InstanceType: "S5.MEDIUM2",
+		Creator:      "admin",
+	}
+}
+
+func TestTemplateInsertValidate(t *testing.T) {
+	if err := validTemplate().InsertValidate(); err != nil {
+		t.Fatalf("insert validate valid template failed, err: %v", err)
+	}
+
+	cases := map[string]func(tpl *TemplateTable){
+		"missing id":             func(tpl *TemplateTable) { tpl.ID = "" },
+		"missing name":           func(tpl *TemplateTable) { tpl.Name = "" },
+		"invalid vendor":         func(tpl *TemplateTable) { tpl.Vendor = "unknown" },
+		"missing account id":     func(tpl *TemplateTable) { tpl.AccountID = "" },
+		"missing region":         func(tpl *TemplateTable) { tpl.Region = "" },
+		"missing cloud image id": func(tpl *TemplateTable) { tpl.CloudImageID = "" },
+		"missing instance type":  func(tpl *TemplateTable) { tpl.InstanceType = "" },
+		"missing creator":        func(tpl *TemplateTable) { tpl.Creator = "" },
+	}
+	for name, mutate := range cases {
+		tpl := validTemplate()
+		mutate(&tpl)
+		if err := tpl.InsertValidate(); err == nil {
+			t.Errorf("%s: expect insert validate failed, but not", name)
+		}
+	}
+}
+
+func TestTemplateUpdateValidate(t *testing.T) {
+	if err := (TemplateTable{Name: "tpl-2", Reviser: "admin"}).UpdateValidate(); err != nil {
+		t.Fatalf("update validate failed, err: %v", err)
+	}
+
+	cases := map[string]TemplateTable{
+		"missing reviser":   {Name: "tpl-2"},
+		"update vendor":     {Vendor: enumor.TCloud, Reviser: "admin"},
+		"update account id": {AccountID: "account-2", Reviser: "admin"},
+		"update creator":    {Creator: "admin", Reviser: "admin"},
+	}
+	for name, tpl := range cases {
+		if err := tpl.UpdateValidate(); err == nil {
+			t.Errorf("%s: expect update validate failed, but not", name)
+		}
+	}
+}
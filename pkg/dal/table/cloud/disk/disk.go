@@ -46,6 +46,7 @@ var DiskColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "is_system_disk", NamedC: "is_system_disk", Type: enumor.Boolean},
 	{Column: "memo", NamedC: "memo", Type: enumor.String},
 	{Column: "extension", NamedC: "extension", Type: enumor.Json},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -84,6 +85,8 @@ type DiskModel struct {
 	Memo *string `db:"memo" json:"memo"`
 	// Extension 云厂商差异扩展字段
 	Extension types.JsonField `db:"extension" json:"extension"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" json:"tenant_id"`
 	// Creator 创建者
 	Creator string `db:"creator" json:"creator"`
 	// Reviser 更新者
@@ -46,6 +46,7 @@ var EipColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "public_ip", NamedC: "public_ip", Type: enumor.String},
 	{Column: "private_ip", NamedC: "private_ip", Type: enumor.String},
 	{Column: "extension", NamedC: "extension", Type: enumor.Json},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -66,6 +67,7 @@ type EipModel struct {
 	PublicIp      string          `db:"public_ip" json:"public_ip"`
 	PrivateIp     string          `db:"private_ip" json:"private_ip"`
 	Extension     types.JsonField `db:"extension" json:"extension" validate:"-"`
+	TenantID      string          `db:"tenant_id" json:"tenant_id"`
 	Creator       string          `db:"creator" json:"creator"`
 	Reviser       string          `db:"reviser" json:"reviser"`
 	CreatedAt     types.Time      `db:"created_at" json:"created_at"`
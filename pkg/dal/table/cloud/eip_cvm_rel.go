@@ -34,6 +34,7 @@ var EipCvmRelColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "id", NamedC: "id", Type: enumor.Numeric},
 	{Column: "eip_id", NamedC: "eip_id", Type: enumor.String},
 	{Column: "cvm_id", NamedC: "cvm_id", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
 }
@@ -43,6 +44,7 @@ type EipCvmRelModel struct {
 	ID        uint64     `db:"id" json:"id"`
 	EipID     string     `db:"eip_id" validate:"required,lte=64" json:"eip_id"`
 	CvmID     string     `db:"cvm_id" validate:"required,lte=64" json:"cvm_id"`
+	TenantID  string     `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
 	Creator   string     `db:"creator" validate:"required,lte=64" json:"creator"`
 	CreatedAt types.Time `db:"created_at" json:"created_at"`
 }
@@ -60,6 +60,7 @@ var GcpFirewallRuleTableColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "log_enable", NamedC: "log_enable", Type: enumor.Boolean},
 	{Column: "disabled", NamedC: "disabled", Type: enumor.Boolean},
 	{Column: "self_link", NamedC: "self_link", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -90,6 +91,7 @@ type GcpFirewallRuleTable struct {
 	LogEnable             bool              `db:"log_enable" json:"log_enable"`
 	Disabled              bool              `db:"disabled" json:"disabled"`
 	SelfLink              string            `db:"self_link" validate:"lte=255" json:"self_link"`
+	TenantID              string            `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
 	Creator               string            `db:"creator" validate:"lte=64" json:"creator"`
 	Reviser               string            `db:"reviser" validate:"lte=64" json:"reviser"`
 	CreatedAt             types.Time        `db:"created_at" validate:"excluded_unless" json:"created_at"`
@@ -51,6 +51,7 @@ var HuaWeiSGRuleColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "port", NamedC: "port", Type: enumor.String},
 	{Column: "region", NamedC: "region", Type: enumor.String},
 	{Column: "priority", NamedC: "priority", Type: enumor.Numeric},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -76,6 +77,7 @@ type HuaWeiSecurityGroupRuleTable struct {
 	CloudRemoteAddressGroupID string     `db:"cloud_remote_address_group_id" validate:"lte=255" json:"cloud_remote_address_group_id"`
 	Port                      string     `db:"port" validate:"lte=255" json:"port"`
 	Priority                  int64      `db:"priority" json:"priority"`
+	TenantID                  string     `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
 	Creator                   string     `db:"creator" validate:"lte=64" json:"creator"`
 	Reviser                   string     `db:"reviser" validate:"lte=64" json:"reviser"`
 	CreatedAt                 types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
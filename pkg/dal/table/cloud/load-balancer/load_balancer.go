@@ -64,6 +64,7 @@ var LoadBalancerColumnsDescriptor = utils.ColumnDescriptors{
 	{Column: "tags", NamedC: "tags", Type: enumor.Json},
 	{Column: "extension", NamedC: "extension", Type: enumor.Json},
 
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -100,6 +101,7 @@ type LoadBalancerTable struct {
 	Tags                 types.StringMap   `db:"tags" json:"tags"`
 	Extension            types.JsonField   `db:"extension" json:"extension"`
 
+	TenantID  string     `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
 	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
 	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
 	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
@@ -38,6 +38,7 @@ var ResourceFlowLockColumnsDescriptor = utils.ColumnDescriptors{
 	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
 	{Column: "owner", NamedC: "owner", Type: enumor.String},
 
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -50,6 +51,7 @@ type ResourceFlowLockTable struct {
 	ResType enumor.CloudResourceType `db:"res_type" validate:"lte=64" json:"res_type"`
 	Owner   string                   `db:"owner" validate:"lte=64" json:"owner"`
 
+	TenantID  string     `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
 	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
 	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
 	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
@@ -41,6 +41,7 @@ var ResourceFlowRelColumnsDescriptor = utils.ColumnDescriptors{
 	{Column: "task_type", NamedC: "task_type", Type: enumor.String},
 	{Column: "status", NamedC: "status", Type: enumor.String},
 
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -56,6 +57,7 @@ type ResourceFlowRelTable struct {
 	TaskType enumor.TaskType          `db:"task_type" validate:"lte=64" json:"task_type"`
 	Status   enumor.ResFlowStatus     `db:"status" validate:"lte=64" json:"status"`
 
+	TenantID  string     `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
 	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
 	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
 	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
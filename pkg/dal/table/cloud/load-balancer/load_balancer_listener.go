@@ -52,6 +52,7 @@ var LoadBalancerListenerColumnsDescriptor = utils.ColumnDescriptors{
 	{Column: "sni_switch", NamedC: "sni_switch", Type: enumor.Numeric},
 	{Column: "extension", NamedC: "extension", Type: enumor.Json},
 
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -78,6 +79,7 @@ type LoadBalancerListenerTable struct {
 	SniSwitch     enumor.SniType      `db:"sni_switch" json:"sni_switch"`
 	Extension     types.JsonField     `db:"extension" json:"extension"`
 
+	TenantID  string     `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
 	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
 	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
 	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
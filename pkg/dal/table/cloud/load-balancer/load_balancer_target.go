@@ -55,6 +55,7 @@ var LoadBalancerTargetColumnsDescriptor = utils.ColumnDescriptors{
 	{Column: "zone", NamedC: "zone", Type: enumor.String},
 	{Column: "memo", NamedC: "memo", Type: enumor.String},
 
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -82,6 +83,7 @@ type LoadBalancerTargetTable struct {
 	Zone               string            `db:"zone" json:"zone"`
 	Memo               *string           `db:"memo" json:"memo"`
 
+	TenantID  string     `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
 	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
 	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
 	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
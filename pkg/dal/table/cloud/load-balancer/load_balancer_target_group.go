@@ -50,6 +50,7 @@ var LoadBalancerTargetGroupColumnsDescriptor = utils.ColumnDescriptors{
 	{Column: "health_check", NamedC: "health_check", Type: enumor.Json},
 	{Column: "extension", NamedC: "extension", Type: enumor.Json},
 	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -74,6 +75,7 @@ type LoadBalancerTargetGroupTable struct {
 	HealthCheck     types.JsonField        `db:"health_check" json:"health_check"`
 	Extension       types.JsonField        `db:"extension" json:"extension"`
 	Memo            *string                `db:"memo" json:"memo"`
+	TenantID        string                 `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
 	Creator         string                 `db:"creator" validate:"lte=64" json:"creator"`
 	Reviser         string                 `db:"reviser" validate:"lte=64" json:"reviser"`
 	CreatedAt       types.Time             `db:"created_at" validate:"excluded_unless" json:"created_at"`
@@ -48,6 +48,7 @@ var TargetGroupListenerRuleRelColumnsDescriptor = utils.ColumnDescriptors{
 	{Column: "binding_status", NamedC: "binding_status", Type: enumor.String},
 	{Column: "detail", NamedC: "detail", Type: enumor.Json},
 
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -74,6 +75,7 @@ type TargetGroupListenerRuleRelTable struct {
 	BindingStatus enumor.BindingStatus `db:"binding_status" validate:"lte=64" json:"binding_status"`
 	Detail        types.JsonField      `db:"detail" json:"detail"`
 
+	TenantID  string     `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
 	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
 	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
 	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
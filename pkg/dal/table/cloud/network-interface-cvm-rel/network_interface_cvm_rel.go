@@ -37,6 +37,7 @@ var NetworkInterfaceCvmRelTableColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "id", NamedC: "id", Type: enumor.Numeric},
 	{Column: "cvm_id", NamedC: "cvm_id", Type: enumor.String},
 	{Column: "network_interface_id", NamedC: "network_interface_id", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
 }
@@ -49,6 +50,8 @@ type NetworkInterfaceCvmRelTable struct {
 	CvmID string `db:"cvm_id" validate:"required,lte=64" json:"cvm_id"`
 	// NetworkInterfaceID 网络接口ID
 	NetworkInterfaceID string `db:"network_interface_id" validate:"required,lte=64" json:"network_interface_id"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
 	// Creator 创建者
 	Creator string `db:"creator" validate:"required,lte=64" json:"creator"`
 	// CreatedAt 创建时间
@@ -39,6 +39,7 @@ var AzureRGTableColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "type", NamedC: "type", Type: enumor.String},
 	{Column: "location", NamedC: "location", Type: enumor.String},
 	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -57,6 +58,8 @@ type AzureRGTable struct {
 	Location string `db:"location"`
 	// AccountID 账号id
 	AccountID string `db:"account_id"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id"`
 	// Creator 创建者
 	Creator string `db:"creator"`
 	// Reviser 更新者
@@ -54,6 +54,7 @@ var AwsRouteColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "cloud_vpc_peering_connection_id", NamedC: "cloud_vpc_peering_connection_id", Type: enumor.String},
 	{Column: "state", NamedC: "state", Type: enumor.String},
 	{Column: "propagated", NamedC: "propagated", Type: enumor.Boolean},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -100,6 +101,8 @@ type AwsRouteTable struct {
 	State string `db:"state" validate:"max=32" json:"state"`
 	// Propagated 是否已传播
 	Propagated *bool `db:"propagated" validate:"-" json:"propagated"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" validate:"max=64" json:"tenant_id"`
 	// Creator 创建者
 	Creator string `db:"creator" validate:"max=64" json:"creator"`
 	// Reviser 更新者
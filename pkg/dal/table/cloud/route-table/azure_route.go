@@ -43,6 +43,7 @@ var AzureRouteColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "next_hop_type", NamedC: "next_hop_type", Type: enumor.String},
 	{Column: "next_hop_ip_address", NamedC: "next_hop_ip_address", Type: enumor.String},
 	{Column: "provisioning_state", NamedC: "provisioning_state", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -69,6 +70,8 @@ type AzureRouteTable struct {
 	NextHopIPAddress *string `db:"next_hop_ip_address" validate:"max=255" json:"next_hop_ip_address,omitempty"`
 	// ProvisioningState 当前供应状态
 	ProvisioningState string `db:"provisioning_state" validate:"max=32" json:"provisioning_state"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" validate:"max=64" json:"tenant_id"`
 	// Creator 创建者
 	Creator string `db:"creator" validate:"max=64" json:"creator"`
 	// Reviser 更新者
@@ -54,6 +54,7 @@ var GcpRouteColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "route_type", NamedC: "route_type", Type: enumor.String},
 	{Column: "tags", NamedC: "tags", Type: enumor.Json},
 	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -102,6 +103,8 @@ type GcpRouteTable struct {
 	Tags types.StringArray `db:"tags" validate:"omitempty" json:"tags,omitempty"`
 	// Memo 备注
 	Memo *string `db:"memo" validate:"omitempty,max=255" json:"memo"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" validate:"max=64" json:"tenant_id"`
 	// Creator 创建者
 	Creator string `db:"creator" validate:"max=64" json:"creator"`
 	// Reviser 更新者
@@ -41,6 +41,7 @@ var HuaWeiRouteColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "destination", NamedC: "destination", Type: enumor.String},
 	{Column: "nexthop", NamedC: "nexthop", Type: enumor.String},
 	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -63,6 +64,8 @@ type HuaWeiRouteTable struct {
 	NextHop string `db:"nexthop" validate:"max=255" json:"nexthop"`
 	// Memo 备注
 	Memo *string `db:"memo" validate:"omitempty,max=255" json:"memo"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" validate:"max=64" json:"tenant_id"`
 	// Creator 创建者
 	Creator string `db:"creator" validate:"max=64" json:"creator"`
 	// Reviser 更新者
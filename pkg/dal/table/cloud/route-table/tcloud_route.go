@@ -46,6 +46,7 @@ var TCloudRouteColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "route_type", NamedC: "route_type", Type: enumor.String},
 	{Column: "published_to_vbc", NamedC: "published_to_vbc", Type: enumor.Boolean},
 	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -78,6 +79,8 @@ type TCloudRouteTable struct {
 	PublishedToVbc *bool `db:"published_to_vbc" validate:"-" json:"published_to_vbc"`
 	// Memo 备注
 	Memo *string `db:"memo" validate:"omitempty,max=255" json:"memo"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" validate:"max=64" json:"tenant_id"`
 	// Creator 创建者
 	Creator string `db:"creator" validate:"max=64" json:"creator"`
 	// Reviser 更新者
@@ -46,6 +46,7 @@ var SecurityGroupColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
 	{Column: "extension", NamedC: "extension", Type: enumor.Json},
 	{Column: "tags", NamedC: "tags", Type: enumor.Json},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -66,6 +67,7 @@ type SecurityGroupTable struct {
 	CloudUpdateTime  string          `db:"cloud_update_time" json:"cloud_update_time"`
 	Extension        types.JsonField `db:"extension" json:"extension"`
 	Tags             types.StringMap `db:"tags" json:"tags"`
+	TenantID         string          `db:"tenant_id" json:"tenant_id" validate:"lte=64"`
 	Creator          string          `db:"creator" json:"creator" validate:"lte=64"`
 	Reviser          string          `db:"reviser" json:"reviser" validate:"lte=64"`
 	CreatedAt        types.Time      `db:"created_at" json:"created_at" validate:"excluded_unless"`
@@ -41,6 +41,7 @@ var SecurityGroupCommonRelColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
 	{Column: "priority", NamedC: "priority", Type: enumor.Numeric},
 	{Column: "security_group_id", NamedC: "security_group_id", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -55,6 +56,7 @@ type SecurityGroupCommonRelTable struct {
 	ResType         enumor.CloudResourceType `db:"res_type" validate:"required,lte=64" json:"res_type"`
 	Priority        int64                    `db:"priority" validate:"min=0,max=65535" json:"priority"`
 	SecurityGroupID string                   `db:"security_group_id" validate:"required,lte=64" json:"security_group_id"`
+	TenantID        string                   `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
 	Creator         string                   `db:"creator" validate:"lte=64" json:"creator"`
 	Reviser         string                   `db:"reviser" validate:"lte=64" json:"reviser"`
 	CreatedAt       types.Time               `db:"created_at" validate:"excluded_unless" json:"created_at"`
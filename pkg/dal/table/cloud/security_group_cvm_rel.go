@@ -35,6 +35,7 @@ var SecurityGroupCvmRelColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "id", NamedC: "id", Type: enumor.Numeric},
 	{Column: "cvm_id", NamedC: "cvm_id", Type: enumor.String},
 	{Column: "security_group_id", NamedC: "security_group_id", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
 }
@@ -44,6 +45,7 @@ type SecurityGroupCvmRelTable struct {
 	ID              uint64     `db:"id" validate:"required" json:"id"`
 	CvmID           string     `db:"cvm_id" validate:"required,lte=64" json:"cvm_id"`
 	SecurityGroupID string     `db:"security_group_id" validate:"required,lte=64" json:"security_group_id"`
+	TenantID        string     `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
 	Creator         string     `db:"creator" validate:"required,lte=64" json:"creator"`
 	CreatedAt       types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
 }
@@ -45,6 +45,7 @@ var ColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "managers", NamedC: "managers", Type: enumor.Json},
 	{Column: "bk_biz_ids", NamedC: "bk_biz_ids", Type: enumor.Json},
 	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -75,6 +76,8 @@ type Table struct {
 	BkBizIDs types.Int64Array `db:"bk_biz_ids" json:"bk_biz_ids"`
 	// Memo 账号信息备注
 	Memo *string `db:"memo" json:"memo"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" json:"tenant_id" validate:"lte=64"`
 	// Creator 创建者
 	Creator string `db:"creator" json:"creator" validate:"lte=64"`
 	// Reviser 更新者
@@ -41,6 +41,7 @@ var AccountSyncDetailColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "res_status", NamedC: "res_status", Type: enumor.String},
 	{Column: "res_end_time", NamedC: "res_end_time", Type: enumor.String},
 	{Column: "res_failed_reason", NamedC: "res_failed_reason", Type: enumor.Json},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -56,6 +57,7 @@ type AccountSyncDetailTable struct {
 	ResStatus       string          `db:"res_status" json:"res_status" validate:"lte=64"`
 	ResEndTime      string          `db:"res_end_time" json:"res_end_time"`
 	ResFailedReason types.JsonField `db:"res_failed_reason" json:"res_failed_reason"`
+	TenantID        string          `db:"tenant_id" json:"tenant_id" validate:"lte=64"`
 	Creator         string          `db:"creator" json:"creator" validate:"lte=64"`
 	Reviser         string          `db:"reviser" json:"reviser" validate:"lte=64"`
 	CreatedAt       types.Time      `db:"created_at" json:"created_at" validate:"excluded_unless"`
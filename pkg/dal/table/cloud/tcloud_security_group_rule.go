@@ -57,6 +57,7 @@ var TCloudSGRuleColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "cloud_address_group_id", NamedC: "cloud_address_group_id", Type: enumor.String},
 	{Column: "region", NamedC: "region", Type: enumor.String},
 	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -88,6 +89,7 @@ type TCloudSecurityGroupRuleTable struct {
 	CloudAddressGroupID        *string    `db:"cloud_address_group_id" validate:"omitempty,lte=255" json:"cloud_address_group_id"`
 	Region                     string     `db:"region" validate:"lte=20" json:"region"`
 	Memo                       *string    `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	TenantID                   string     `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
 	Creator                    string     `db:"creator" validate:"lte=64" json:"creator"`
 	Reviser                    string     `db:"reviser" validate:"lte=64" json:"reviser"`
 	CreatedAt                  types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
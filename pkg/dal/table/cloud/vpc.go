@@ -45,6 +45,7 @@ var VpcColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "extension", NamedC: "extension", Type: enumor.Json},
 	{Column: "bk_cloud_id", NamedC: "bk_cloud_id", Type: enumor.Numeric},
 	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -75,6 +76,8 @@ type VpcTable struct {
 	BkCloudID int64 `db:"bk_cloud_id" validate:"min=-1" json:"bk_cloud_id"`
 	// BkBizID 业务ID
 	BkBizID int64 `db:"bk_biz_id" validate:"min=-1" json:"bk_biz_id"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" validate:"max=64" json:"tenant_id"`
 	// Creator 创建者
 	Creator string `db:"creator" validate:"max=64" json:"creator"`
 	// Reviser 更新者
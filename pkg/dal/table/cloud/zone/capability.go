@@ -0,0 +1,96 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package zone
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ZoneCapabilityColumns defines all the zone capability table's columns.
+var ZoneCapabilityColumns = utils.MergeColumns(nil, ZoneCapabilityColumnDescriptor)
+
+// ZoneCapabilityColumnDescriptor is zone capability's column descriptors.
+var ZoneCapabilityColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "zone", NamedC: "zone", Type: enumor.String},
+	{Column: "instance_families", NamedC: "instance_families", Type: enumor.Json},
+	{Column: "features", NamedC: "features", Type: enumor.Json},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// ZoneCapabilityTable define zone capability table.
+type ZoneCapabilityTable struct {
+	ID     string        `db:"id" json:"id" validate:"lte=64"`
+	Vendor enumor.Vendor `db:"vendor" json:"vendor" validate:"lte=16"`
+	Region string        `db:"region" json:"region" validate:"lte=255"`
+	// Zone 为空表示该能力是地域级别的，对地域下所有可用区生效
+	Zone             string            `db:"zone" json:"zone" validate:"lte=255"`
+	InstanceFamilies types.StringArray `db:"instance_families" json:"instance_families"`
+	Features         types.StringArray `db:"features" json:"features"`
+	Creator          string            `db:"creator" json:"creator" validate:"lte=64"`
+	Reviser          string            `db:"reviser" json:"reviser" validate:"lte=64"`
+	CreatedAt        types.Time        `db:"created_at" json:"created_at" validate:"excluded_unless"`
+	UpdatedAt        types.Time        `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
+}
+
+// TableName return zone capability table name.
+func (t ZoneCapabilityTable) TableName() table.Name {
+	return table.ZoneCapabilityTable
+}
+
+// InsertValidate zone capability table when insert.
+func (t ZoneCapabilityTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+
+	if len(t.Region) == 0 {
+		return errors.New("region is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
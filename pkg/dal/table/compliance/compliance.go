@@ -0,0 +1,185 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package compliance defines security compliance finding and exemption table.
+package compliance
+
+import (
+	"errors"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// FindingColumns defines all the compliance finding table's columns.
+var FindingColumns = utils.MergeColumns(nil, FindingColumnDescriptor)
+
+// FindingColumnDescriptor is compliance finding table column descriptors.
+var FindingColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "rule", NamedC: "rule", Type: enumor.String},
+	{Column: "severity", NamedC: "severity", Type: enumor.String},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "res_name", NamedC: "res_name", Type: enumor.String},
+	{Column: "detail", NamedC: "detail", Type: enumor.Json},
+	{Column: "exemption_id", NamedC: "exemption_id", Type: enumor.String},
+	{Column: "detected_at", NamedC: "detected_at", Type: enumor.Time},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// FindingTable define compliance finding table.
+type FindingTable struct {
+	ID        string                    `db:"id" validate:"lte=64" json:"id"`
+	Rule      enumor.ComplianceRule     `db:"rule" validate:"lte=64" json:"rule"`
+	Severity  enumor.ComplianceSeverity `db:"severity" validate:"lte=16" json:"severity"`
+	ResType   enumor.CloudResourceType  `db:"res_type" validate:"lte=64" json:"res_type"`
+	ResID     string                    `db:"res_id" validate:"lte=64" json:"res_id"`
+	Vendor    enumor.Vendor             `db:"vendor" validate:"lte=16" json:"vendor"`
+	AccountID string                    `db:"account_id" validate:"lte=64" json:"account_id"`
+	BkBizID   int64                     `db:"bk_biz_id" json:"bk_biz_id"`
+	Region    string                    `db:"region" validate:"lte=255" json:"region"`
+	ResName   string                    `db:"res_name" validate:"lte=255" json:"res_name"`
+	// Detail 检测详情，如安全组中违规的规则ID及端口
+	Detail types.JsonField `db:"detail" json:"detail"`
+	// ExemptionID 豁免ID，不为空时该检测结果已被豁免
+	ExemptionID string `db:"exemption_id" validate:"lte=64" json:"exemption_id"`
+	// DetectedAt 最近一次检测到不合规的时间，首次检测时间即CreatedAt
+	DetectedAt time.Time  `db:"detected_at" json:"detected_at"`
+	TenantID   string     `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
+	Creator    string     `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser    string     `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt  types.Time `db:"created_at" validate:"isdefault" json:"created_at"`
+	UpdatedAt  types.Time `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName return compliance finding table name.
+func (t FindingTable) TableName() table.Name {
+	return table.ComplianceFindingTable
+}
+
+// InsertValidate compliance finding table when insert.
+func (t FindingTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.ResType) == 0 || len(t.ResID) == 0 {
+		return errors.New("res_type and res_id are required")
+	}
+
+	if err := t.Rule.Validate(); err != nil {
+		return err
+	}
+
+	if err := t.Severity.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.Detail) == 0 {
+		return errors.New("detail is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
+
+// ExemptionColumns defines all the compliance exemption table's columns.
+var ExemptionColumns = utils.MergeColumns(nil, ExemptionColumnDescriptor)
+
+// ExemptionColumnDescriptor is compliance exemption table column descriptors.
+var ExemptionColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "rule", NamedC: "rule", Type: enumor.String},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "reason", NamedC: "reason", Type: enumor.String},
+	{Column: "expired_at", NamedC: "expired_at", Type: enumor.Time},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// ExemptionTable define compliance exemption table, the finding of the rule and resource is exempted before
+// the exemption expired.
+type ExemptionTable struct {
+	ID      string                `db:"id" validate:"lte=64" json:"id"`
+	Rule    enumor.ComplianceRule `db:"rule" validate:"lte=64" json:"rule"`
+	ResID   string                `db:"res_id" validate:"lte=64" json:"res_id"`
+	BkBizID int64                 `db:"bk_biz_id" json:"bk_biz_id"`
+	Reason  string                `db:"reason" validate:"lte=255" json:"reason"`
+	// ExpiredAt 豁免过期时间，为空时永久有效
+	ExpiredAt *time.Time `db:"expired_at" json:"expired_at"`
+	TenantID  string     `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
+	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time `db:"created_at" validate:"isdefault" json:"created_at"`
+	UpdatedAt types.Time `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName return compliance exemption table name.
+func (t ExemptionTable) TableName() table.Name {
+	return table.ComplianceExemptionTable
+}
+
+// InsertValidate compliance exemption table when insert.
+func (t ExemptionTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if err := t.Rule.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.ResID) == 0 {
+		return errors.New("res_id is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
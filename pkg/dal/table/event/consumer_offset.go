@@ -0,0 +1,93 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package event defines the tables of resource change event.
+package event
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ConsumerOffsetColumns defines all the event consumer offset table's columns.
+var ConsumerOffsetColumns = utils.MergeColumns(nil, ConsumerOffsetColumnDescriptor)
+
+// ConsumerOffsetColumnDescriptor is event consumer offset's column descriptors.
+var ConsumerOffsetColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "consumer", NamedC: "consumer", Type: enumor.String},
+	{Column: "topic", NamedC: "topic", Type: enumor.String},
+	{Column: "consumed_offset", NamedC: "consumed_offset", Type: enumor.Numeric},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// ConsumerOffsetTable define event consumer offset table.
+type ConsumerOffsetTable struct {
+	ID       string                   `db:"id" json:"id" validate:"lte=64"`
+	Consumer string                   `db:"consumer" json:"consumer" validate:"lte=64"`
+	Topic    enumor.AuditResourceType `db:"topic" json:"topic" validate:"lte=50"`
+	// ConsumedOffset 已确认消费的事件位点，下次从该位点之后开始拉取
+	ConsumedOffset uint64     `db:"consumed_offset" json:"consumed_offset"`
+	Creator        string     `db:"creator" json:"creator" validate:"lte=64"`
+	Reviser        string     `db:"reviser" json:"reviser" validate:"lte=64"`
+	CreatedAt      types.Time `db:"created_at" json:"created_at" validate:"excluded_unless"`
+	UpdatedAt      types.Time `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
+}
+
+// TableName return event consumer offset table name.
+func (t ConsumerOffsetTable) TableName() table.Name {
+	return table.EventConsumerOffsetTable
+}
+
+// InsertValidate event consumer offset table when insert.
+func (t ConsumerOffsetTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Consumer) == 0 {
+		return errors.New("consumer is required")
+	}
+
+	if len(t.Topic) == 0 {
+		return errors.New("topic is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package idleresource defines idle resource detection result table.
+package idleresource
+
+import (
+	"errors"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// IdleResourceColumns defines all the idle resource table's columns.
+var IdleResourceColumns = utils.MergeColumns(nil, IdleResourceColumnDescriptor)
+
+// IdleResourceColumnDescriptor is idle resource table column descriptors.
+var IdleResourceColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "res_name", NamedC: "res_name", Type: enumor.String},
+	{Column: "reason", NamedC: "reason", Type: enumor.String},
+	{Column: "detail", NamedC: "detail", Type: enumor.Json},
+	{Column: "estimated_monthly_cost", NamedC: "estimated_monthly_cost", Type: enumor.Numeric},
+	{Column: "currency", NamedC: "currency", Type: enumor.String},
+	{Column: "status", NamedC: "status", Type: enumor.String},
+	{Column: "detected_at", NamedC: "detected_at", Type: enumor.Time},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// IdleResourceTable define idle resource table.
+type IdleResourceTable struct {
+	ID        string                   `db:"id" validate:"lte=64" json:"id"`
+	ResType   enumor.CloudResourceType `db:"res_type" validate:"lte=64" json:"res_type"`
+	ResID     string                   `db:"res_id" validate:"lte=64" json:"res_id"`
+	Vendor    enumor.Vendor            `db:"vendor" validate:"lte=16" json:"vendor"`
+	AccountID string                   `db:"account_id" validate:"lte=64" json:"account_id"`
+	BkBizID   int64                    `db:"bk_biz_id" json:"bk_biz_id"`
+	Region    string                   `db:"region" validate:"lte=255" json:"region"`
+	ResName   string                   `db:"res_name" validate:"lte=255" json:"res_name"`
+	Reason    enumor.IdleReason        `db:"reason" validate:"lte=64" json:"reason"`
+	// Detail 闲置详情，如硬盘容量、主机vCPU核数
+	Detail types.JsonField `db:"detail" json:"detail"`
+	// EstimatedMonthlyCost 根据配置的单价预估的每月费用
+	EstimatedMonthlyCost *types.Decimal            `db:"estimated_monthly_cost" json:"estimated_monthly_cost"`
+	Currency             enumor.CurrencyCode       `db:"currency" validate:"lte=16" json:"currency"`
+	Status               enumor.IdleResourceStatus `db:"status" validate:"lte=32" json:"status"`
+	// DetectedAt 最近一次检测到闲置的时间，首次检测时间即CreatedAt
+	DetectedAt time.Time  `db:"detected_at" json:"detected_at"`
+	TenantID   string     `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
+	Creator    string     `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser    string     `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt  types.Time `db:"created_at" validate:"isdefault" json:"created_at"`
+	UpdatedAt  types.Time `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName return idle resource table name.
+func (t IdleResourceTable) TableName() table.Name {
+	return table.IdleResourceTable
+}
+
+// InsertValidate idle resource table when insert.
+func (t IdleResourceTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.ResType) == 0 || len(t.ResID) == 0 {
+		return errors.New("res_type and res_id are required")
+	}
+
+	if err := t.Reason.Validate(); err != nil {
+		return err
+	}
+
+	if err := t.Status.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.Detail) == 0 {
+		return errors.New("detail is required")
+	}
+
+	if t.EstimatedMonthlyCost == nil {
+		return errors.New("estimated_monthly_cost is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate idle resource table when update.
+func (t IdleResourceTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ResType) != 0 || len(t.ResID) != 0 {
+		return errors.New("res_type and res_id can not update")
+	}
+
+	if len(t.Status) != 0 {
+		if err := t.Status.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
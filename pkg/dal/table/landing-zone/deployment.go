@@ -0,0 +1,151 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package landingzone defines landing zone table.
+package landingzone
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// DeploymentColumns defines all the landing zone deployment table's columns.
+var DeploymentColumns = utils.MergeColumns(nil, DeploymentColumnDescriptor)
+
+// DeploymentColumnDescriptor is landing zone deployment table column descriptors.
+var DeploymentColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "spec", NamedC: "spec", Type: enumor.Json},
+	{Column: "resources", NamedC: "resources", Type: enumor.Json},
+	{Column: "state", NamedC: "state", Type: enumor.String},
+	{Column: "reason", NamedC: "reason", Type: enumor.String},
+	{Column: "flow_id", NamedC: "flow_id", Type: enumor.String},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// DeploymentTable define landing zone deployment table.
+type DeploymentTable struct {
+	ID        string        `db:"id" validate:"lte=64" json:"id"`
+	Name      string        `db:"name" validate:"lte=255" json:"name"`
+	Vendor    enumor.Vendor `db:"vendor" validate:"lte=16" json:"vendor"`
+	AccountID string        `db:"account_id" validate:"lte=64" json:"account_id"`
+	BkBizID   int64         `db:"bk_biz_id" json:"bk_biz_id"`
+	Region    string        `db:"region" validate:"lte=255" json:"region"`
+	// Spec 部署规格，创建后不可修改
+	Spec types.JsonField `db:"spec" json:"spec"`
+	// Resources 部署过程中已创建的资源，回滚时按其删除资源
+	Resources types.JsonField               `db:"resources" json:"resources"`
+	State     enumor.LandingZoneDeployState `db:"state" validate:"lte=32" json:"state"`
+	Reason    *string                       `db:"reason" validate:"omitempty,lte=1024" json:"reason"`
+	FlowID    string                        `db:"flow_id" validate:"lte=64" json:"flow_id"`
+	Memo      *string                       `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	TenantID  string                        `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
+	Creator   string                        `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string                        `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time                    `db:"created_at" validate:"isdefault" json:"created_at"`
+	UpdatedAt types.Time                    `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName return landing zone deployment table name.
+func (t DeploymentTable) TableName() table.Name {
+	return table.LandingZoneDeploymentTable
+}
+
+// InsertValidate landing zone deployment table when insert.
+func (t DeploymentTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if len(t.Vendor) == 0 || len(t.AccountID) == 0 || len(t.Region) == 0 {
+		return errors.New("vendor, account_id and region are required")
+	}
+
+	if t.BkBizID <= 0 {
+		return errors.New("bk_biz_id is invalid")
+	}
+
+	if t.Spec.IsEmpty() {
+		return errors.New("spec is required")
+	}
+
+	if len(t.Resources) == 0 {
+		return errors.New("resources is required")
+	}
+
+	if err := t.State.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate landing zone deployment table when update.
+func (t DeploymentTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Vendor) != 0 || len(t.AccountID) != 0 || t.BkBizID != 0 || len(t.Region) != 0 || len(t.Spec) != 0 {
+		return errors.New("vendor, account_id, bk_biz_id, region and spec can not update")
+	}
+
+	if len(t.State) != 0 {
+		if err := t.State.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
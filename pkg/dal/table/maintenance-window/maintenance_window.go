@@ -0,0 +1,137 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package maintenancewindow defines maintenance window table.
+package maintenancewindow
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// MaintenanceWindowColumns defines all the maintenance window table's columns.
+var MaintenanceWindowColumns = utils.MergeColumns(nil, MaintenanceWindowColumnDescriptor)
+
+// MaintenanceWindowColumnDescriptor is maintenance window table column descriptors.
+var MaintenanceWindowColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "actions", NamedC: "actions", Type: enumor.Json},
+	{Column: "weekdays", NamedC: "weekdays", Type: enumor.Json},
+	{Column: "start_time", NamedC: "start_time", Type: enumor.String},
+	{Column: "end_time", NamedC: "end_time", Type: enumor.String},
+	{Column: "timezone", NamedC: "timezone", Type: enumor.String},
+	{Column: "enabled", NamedC: "enabled", Type: enumor.Boolean},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// MaintenanceWindowTable define maintenance window table.
+type MaintenanceWindowTable struct {
+	ID      string `db:"id" validate:"lte=64" json:"id"`
+	BkBizID int64  `db:"bk_biz_id" json:"bk_biz_id"`
+	Name    string `db:"name" validate:"lte=255" json:"name"`
+	// Actions 受维护窗口限制的操作
+	Actions types.StringArray `db:"actions" json:"actions"`
+	// Weekdays 窗口生效的星期，0表示星期日
+	Weekdays  types.Int64Array `db:"weekdays" json:"weekdays"`
+	StartTime string           `db:"start_time" validate:"lte=8" json:"start_time"`
+	EndTime   string           `db:"end_time" validate:"lte=8" json:"end_time"`
+	Timezone  string           `db:"timezone" validate:"lte=64" json:"timezone"`
+	Enabled   *bool            `db:"enabled" json:"enabled"`
+	Memo      *string          `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	TenantID  string           `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
+	Creator   string           `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string           `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time       `db:"created_at" validate:"isdefault" json:"created_at"`
+	UpdatedAt types.Time       `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName return maintenance window table name.
+func (t MaintenanceWindowTable) TableName() table.Name {
+	return table.MaintenanceWindowTable
+}
+
+// InsertValidate maintenance window table when insert.
+func (t MaintenanceWindowTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if t.BkBizID <= 0 {
+		return errors.New("bk_biz_id is invalid")
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if len(t.Actions) == 0 || len(t.Weekdays) == 0 {
+		return errors.New("actions and weekdays are required")
+	}
+
+	if len(t.StartTime) == 0 || len(t.EndTime) == 0 || len(t.Timezone) == 0 {
+		return errors.New("start_time, end_time and timezone are required")
+	}
+
+	if t.Enabled == nil {
+		return errors.New("enabled is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate maintenance window table when update.
+func (t MaintenanceWindowTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if t.BkBizID != 0 {
+		return errors.New("bk_biz_id can not update")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package naming defines resource naming rule and naming violation table.
+package naming
+
+import (
+	"errors"
+	"regexp"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// NamingRuleColumns defines all the naming rule table's columns.
+var NamingRuleColumns = utils.MergeColumns(nil, NamingRuleColumnDescriptor)
+
+// NamingRuleColumnDescriptor is naming rule table column descriptors.
+var NamingRuleColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "prefix", NamedC: "prefix", Type: enumor.String},
+	{Column: "pattern", NamedC: "pattern", Type: enumor.String},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// NamingRuleTable define naming rule table.
+type NamingRuleTable struct {
+	ID string `db:"id" validate:"lte=64" json:"id"`
+	// BkBizID 业务ID，-1表示未单独配置规则的业务使用的全局默认规则
+	BkBizID int64                    `db:"bk_biz_id" json:"bk_biz_id"`
+	ResType enumor.CloudResourceType `db:"res_type" validate:"lte=64" json:"res_type"`
+	// Prefix 名称前缀
+	Prefix *string `db:"prefix" validate:"omitempty,lte=64" json:"prefix"`
+	// Pattern 名称需匹配的正则表达式
+	Pattern   *string    `db:"pattern" validate:"omitempty,lte=255" json:"pattern"`
+	Memo      *string    `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	TenantID  string     `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
+	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time `db:"created_at" validate:"isdefault" json:"created_at"`
+	UpdatedAt types.Time `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName return naming rule table name.
+func (t NamingRuleTable) TableName() table.Name {
+	return table.NamingRuleTable
+}
+
+// InsertValidate naming rule table when insert.
+func (t NamingRuleTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if t.BkBizID <= 0 && t.BkBizID != constant.UnassignedBiz {
+		return errors.New("bk_biz_id is invalid")
+	}
+
+	if err := enumor.ValidateNamingRuleResType(t.ResType); err != nil {
+		return err
+	}
+
+	if (t.Prefix == nil || len(*t.Prefix) == 0) && (t.Pattern == nil || len(*t.Pattern) == 0) {
+		return errors.New("prefix or pattern is required")
+	}
+
+	if err := validatePattern(t.Pattern); err != nil {
+		return err
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate naming rule table when update.
+func (t NamingRuleTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if t.BkBizID != 0 || len(t.ResType) != 0 {
+		return errors.New("bk_biz_id and res_type can not update")
+	}
+
+	if err := validatePattern(t.Pattern); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+func validatePattern(pattern *string) error {
+	if pattern == nil || len(*pattern) == 0 {
+		return nil
+	}
+
+	if _, err := regexp.Compile(*pattern); err != nil {
+		return errors.New("pattern is not a valid regular expression")
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package naming
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/converter"
+)
+
+func TestNamingRuleInsertValidate(t *testing.T) {
+	valid := func() NamingRuleTable {
+		return NamingRuleTable{
+			ID:      "00000001",
+			BkBizID: constant.UnassignedBiz,
+			ResType: enumor.CvmCloudResType,
+			Prefix:  converter.ValToPtr("prod-"),
+			Creator: "admin",
+		}
+	}
+	if err := valid().InsertValidate(); err != nil {
+		t.Fatalf("insert validate default naming rule failed, err: %v", err)
+	}
+
+	cases := map[string]func(rule *NamingRuleTable){
+		"invalid biz":               func(rule *NamingRuleTable) { rule.BkBizID = 0 },
+		"unsupported res type":      func(rule *NamingRuleTable) { rule.ResType = enumor.RouteTableCloudResType },
+		"neither prefix or pattern": func(rule *NamingRuleTable) { rule.Prefix = converter.ValToPtr("") },
+		"invalid pattern":           func(rule *NamingRuleTable) { rule.Pattern = converter.ValToPtr("([") },
+		"missing creator":           func(rule *NamingRuleTable) { rule.Creator = "" },
+	}
+	for name, mutate := range cases {
+		rule := valid()
+		mutate(&rule)
+		if err := rule.InsertValidate(); err == nil {
+			t.Errorf("%s: expect insert validate failed, but not", name)
+		}
+	}
+}
+
+func TestNamingRuleUpdateValidate(t *testing.T) {
+	if err := (NamingRuleTable{Pattern: converter.ValToPtr(`^a\d+$`), Reviser: "admin"}).UpdateValidate(); err != nil {
+		t.Fatalf("update validate naming rule failed, err: %v", err)
+	}
+
+	cases := map[string]NamingRuleTable{
+		"update biz":      {BkBizID: 2, Reviser: "admin"},
+		"update res type": {ResType: enumor.CvmCloudResType, Reviser: "admin"},
+		"invalid pattern": {Pattern: converter.ValToPtr("(["), Reviser: "admin"},
+		"missing reviser": {Prefix: converter.ValToPtr("prod-")},
+	}
+	for name, rule := range cases {
+		if err := rule.UpdateValidate(); err == nil {
+			t.Errorf("%s: expect update validate failed, but not", name)
+		}
+	}
+}
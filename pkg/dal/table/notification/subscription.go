@@ -0,0 +1,139 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package notification
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// SubscriptionColumns defines all the notification subscription table's columns.
+var SubscriptionColumns = utils.MergeColumns(nil, SubscriptionColumnDescriptor)
+
+// SubscriptionColumnDescriptor is notification subscription table column descriptors.
+var SubscriptionColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "event_type", NamedC: "event_type", Type: enumor.String},
+	{Column: "channels", NamedC: "channels", Type: enumor.Json},
+	{Column: "receivers", NamedC: "receivers", Type: enumor.Json},
+	{Column: "notify_related", NamedC: "notify_related", Type: enumor.Boolean},
+	{Column: "webhook_url", NamedC: "webhook_url", Type: enumor.String},
+	{Column: "template_ids", NamedC: "template_ids", Type: enumor.Json},
+	{Column: "account_ids", NamedC: "account_ids", Type: enumor.Json},
+	{Column: "bk_biz_ids", NamedC: "bk_biz_ids", Type: enumor.Json},
+	{Column: "enabled", NamedC: "enabled", Type: enumor.Boolean},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// SubscriptionTable define notification subscription table.
+type SubscriptionTable struct {
+	ID        string                       `db:"id" validate:"lte=64" json:"id"`
+	Name      string                       `db:"name" validate:"lte=255" json:"name"`
+	EventType enumor.NotificationEventType `db:"event_type" validate:"lte=64" json:"event_type"`
+	Channels  types.StringArray            `db:"channels" json:"channels"`
+	// Receivers 通知接收人，用于邮件、企业微信渠道
+	Receivers types.StringArray `db:"receivers" json:"receivers"`
+	// NotifyRelated 是否同时通知事件相关人员，如单据申请人
+	NotifyRelated *bool  `db:"notify_related" json:"notify_related"`
+	WebhookUrl    string `db:"webhook_url" validate:"omitempty,lte=1024" json:"webhook_url"`
+	// TemplateIDs 使用的通知模板，每个渠道最多一个，未配置的渠道使用默认模板
+	TemplateIDs types.StringArray `db:"template_ids" json:"template_ids"`
+	// AccountIDs 订阅范围，为空表示不限制账号
+	AccountIDs types.StringArray `db:"account_ids" json:"account_ids"`
+	// BkBizIDs 订阅范围，为空表示不限制业务
+	BkBizIDs  types.Int64Array `db:"bk_biz_ids" json:"bk_biz_ids"`
+	Enabled   *bool            `db:"enabled" json:"enabled"`
+	Memo      *string          `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	TenantID  string           `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
+	Creator   string           `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string           `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time       `db:"created_at" validate:"isdefault" json:"created_at"`
+	UpdatedAt types.Time       `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName return notification subscription table name.
+func (t SubscriptionTable) TableName() table.Name {
+	return table.NotificationSubscriptionTable
+}
+
+// InsertValidate notification subscription table when insert.
+func (t SubscriptionTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if err := t.EventType.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.Channels) == 0 {
+		return errors.New("channels is required")
+	}
+
+	if t.Enabled == nil {
+		return errors.New("enabled is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate notification subscription table when update.
+func (t SubscriptionTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.EventType) != 0 {
+		return errors.New("event type can not update")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
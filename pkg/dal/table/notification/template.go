@@ -0,0 +1,127 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package notification defines notification template and subscription table.
+package notification
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// TemplateColumns defines all the notification template table's columns.
+var TemplateColumns = utils.MergeColumns(nil, TemplateColumnDescriptor)
+
+// TemplateColumnDescriptor is notification template table column descriptors.
+var TemplateColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "event_type", NamedC: "event_type", Type: enumor.String},
+	{Column: "channel", NamedC: "channel", Type: enumor.String},
+	{Column: "title", NamedC: "title", Type: enumor.String},
+	{Column: "content", NamedC: "content", Type: enumor.String},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// TemplateTable define notification template table.
+type TemplateTable struct {
+	ID        string                       `db:"id" validate:"lte=64" json:"id"`
+	Name      string                       `db:"name" validate:"lte=255" json:"name"`
+	EventType enumor.NotificationEventType `db:"event_type" validate:"lte=64" json:"event_type"`
+	Channel   enumor.NotificationChannel   `db:"channel" validate:"lte=32" json:"channel"`
+	// Title 标题模板，使用 go text/template 语法，可引用事件数据
+	Title string `db:"title" validate:"lte=255" json:"title"`
+	// Content 内容模板，使用 go text/template 语法，可引用事件数据
+	Content   string     `db:"content" json:"content"`
+	Memo      *string    `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	TenantID  string     `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
+	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time `db:"created_at" validate:"isdefault" json:"created_at"`
+	UpdatedAt types.Time `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName return notification template table name.
+func (t TemplateTable) TableName() table.Name {
+	return table.NotificationTemplateTable
+}
+
+// InsertValidate notification template table when insert.
+func (t TemplateTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if err := t.EventType.Validate(); err != nil {
+		return err
+	}
+
+	if err := t.Channel.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.Content) == 0 {
+		return errors.New("content is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate notification template table when update.
+func (t TemplateTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.EventType) != 0 || len(t.Channel) != 0 {
+		return errors.New("event type and channel can not update")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package quota defines biz resource quota table.
+package quota
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// UnlimitedQuota 配额值为该值时表示不限制
+const UnlimitedQuota int64 = -1
+
+// BizQuotaColumns defines all the biz quota table's columns.
+var BizQuotaColumns = utils.MergeColumns(nil, BizQuotaColumnDescriptor)
+
+// BizQuotaColumnDescriptor is biz quota table column descriptors.
+var BizQuotaColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "max_cvm", NamedC: "max_cvm", Type: enumor.Numeric},
+	{Column: "max_cpu_core", NamedC: "max_cpu_core", Type: enumor.Numeric},
+	{Column: "max_eip", NamedC: "max_eip", Type: enumor.Numeric},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// BizQuotaTable define biz quota table.
+type BizQuotaTable struct {
+	ID      string `db:"id" validate:"lte=64" json:"id"`
+	BkBizID int64  `db:"bk_biz_id" json:"bk_biz_id"`
+	// MaxCvm 主机数量上限，-1表示不限制
+	MaxCvm *int64 `db:"max_cvm" json:"max_cvm"`
+	// MaxCpuCore vCPU核数上限，-1表示不限制
+	MaxCpuCore *int64 `db:"max_cpu_core" json:"max_cpu_core"`
+	// MaxEip 弹性IP数量上限，-1表示不限制
+	MaxEip    *int64     `db:"max_eip" json:"max_eip"`
+	Memo      *string    `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	TenantID  string     `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
+	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time `db:"created_at" validate:"isdefault" json:"created_at"`
+	UpdatedAt types.Time `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName return biz quota table name.
+func (t BizQuotaTable) TableName() table.Name {
+	return table.BizQuotaTable
+}
+
+// InsertValidate biz quota table when insert.
+func (t BizQuotaTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if t.BkBizID <= 0 {
+		return errors.New("bk_biz_id is invalid")
+	}
+
+	if t.MaxCvm == nil || t.MaxCpuCore == nil || t.MaxEip == nil {
+		return errors.New("max_cvm, max_cpu_core and max_eip are required")
+	}
+
+	if err := validateQuotaValues(t.MaxCvm, t.MaxCpuCore, t.MaxEip); err != nil {
+		return err
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate biz quota table when update.
+func (t BizQuotaTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if t.BkBizID != 0 {
+		return errors.New("bk_biz_id can not update")
+	}
+
+	if err := validateQuotaValues(t.MaxCvm, t.MaxCpuCore, t.MaxEip); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+func validateQuotaValues(values ...*int64) error {
+	for _, value := range values {
+		if value != nil && *value < UnlimitedQuota {
+			return errors.New("quota should be -1 (unlimited) or not less than 0")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package quota
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// BizResWhitelistColumns defines all the biz resource whitelist table's columns.
+var BizResWhitelistColumns = utils.MergeColumns(nil, BizResWhitelistColumnDescriptor)
+
+// BizResWhitelistColumnDescriptor is biz resource whitelist table column descriptors.
+var BizResWhitelistColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "regions", NamedC: "regions", Type: enumor.Json},
+	{Column: "instance_families", NamedC: "instance_families", Type: enumor.Json},
+	{Column: "cloud_image_ids", NamedC: "cloud_image_ids", Type: enumor.Json},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// BizResWhitelistTable define biz resource whitelist table, one biz has at most one whitelist of each vendor.
+type BizResWhitelistTable struct {
+	ID      string        `db:"id" validate:"lte=64" json:"id"`
+	BkBizID int64         `db:"bk_biz_id" json:"bk_biz_id"`
+	Vendor  enumor.Vendor `db:"vendor" validate:"lte=16" json:"vendor"`
+	// Regions 允许使用的地域，为空表示不限制
+	Regions types.StringArray `db:"regions" json:"regions"`
+	// InstanceFamilies 允许使用的机型族，为空表示不限制
+	InstanceFamilies types.StringArray `db:"instance_families" json:"instance_families"`
+	// CloudImageIDs 允许使用的云镜像ID，为空表示不限制
+	CloudImageIDs types.StringArray `db:"cloud_image_ids" json:"cloud_image_ids"`
+	Memo          *string           `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	TenantID      string            `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
+	Creator       string            `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser       string            `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt     types.Time        `db:"created_at" validate:"isdefault" json:"created_at"`
+	UpdatedAt     types.Time        `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName return biz resource whitelist table name.
+func (t BizResWhitelistTable) TableName() table.Name {
+	return table.BizResWhitelistTable
+}
+
+// InsertValidate biz resource whitelist table when insert.
+func (t BizResWhitelistTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if t.BkBizID <= 0 {
+		return errors.New("bk_biz_id is invalid")
+	}
+
+	if err := t.Vendor.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate biz resource whitelist table when update.
+func (t BizResWhitelistTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if t.BkBizID != 0 {
+		return errors.New("bk_biz_id can not update")
+	}
+
+	if len(t.Vendor) != 0 {
+		return errors.New("vendor can not update")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
@@ -48,6 +48,7 @@ var RecycleRecordColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "region", NamedC: "region", Type: enumor.String},
 	{Column: "detail", NamedC: "detail", Type: enumor.Json},
 	{Column: "status", NamedC: "status", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -83,6 +84,8 @@ type RecycleRecordTable struct {
 	Detail types.JsonField `db:"detail" json:"detail" validate:"omitempty"`
 	// Detail 回收状态
 	Status string `db:"status" validate:"lte=32" json:"status"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" validate:"max=64" json:"tenant_id"`
 	// Creator 创建者
 	Creator string `db:"creator" validate:"max=64" json:"creator"`
 	// Reviser 更新者
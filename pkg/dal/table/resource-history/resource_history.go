@@ -0,0 +1,72 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tablereshistory resource history table
+package tablereshistory
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ResourceHistoryColumns defines all the resource history table's columns.
+var ResourceHistoryColumns = utils.MergeColumns(nil, ResourceHistoryColumnDescriptors)
+
+// ResourceHistoryColumnDescriptors is resource history table column descriptors.
+var ResourceHistoryColumnDescriptors = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "data", NamedC: "data", Type: enumor.Json},
+	{Column: "valid_from", NamedC: "valid_from", Type: enumor.Time},
+	{Column: "valid_to", NamedC: "valid_to", Type: enumor.Time},
+	{Column: "operator", NamedC: "operator", Type: enumor.String},
+	{Column: "rid", NamedC: "rid", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+}
+
+// ResourceHistoryTable 资源历史版本表，资源每次变更都会记录一个版本，版本在 [valid_from, valid_to) 时间范围内有效，
+// valid_to 为空表示该版本为资源的当前版本。
+type ResourceHistoryTable struct {
+	// ID 历史版本ID
+	ID string `db:"id" json:"id"`
+	// ResType 资源类型，取值为资源所在的表名，如 security_group、cvm 等
+	ResType table.Name `db:"res_type" json:"res_type"`
+	// ResID 资源ID
+	ResID string `db:"res_id" json:"res_id"`
+	// Data 该版本的资源数据
+	Data types.JsonField `db:"data" json:"data"`
+	// ValidFrom 版本生效时间
+	ValidFrom types.Time `db:"valid_from" json:"valid_from"`
+	// ValidTo 版本失效时间
+	ValidTo types.Time `db:"valid_to" json:"valid_to"`
+	// Operator 产生该版本的操作人
+	Operator string `db:"operator" json:"operator"`
+	// Rid 产生该版本的请求ID
+	Rid string `db:"rid" json:"rid"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" json:"tenant_id"`
+}
+
+// TableName return resource history table name.
+func (t ResourceHistoryTable) TableName() table.Name {
+	return table.ResourceHistoryTable
+}
@@ -0,0 +1,59 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tablerelation resource relation table
+package tablerelation
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ResourceRelationColumns defines all the resource relation table's columns.
+var ResourceRelationColumns = utils.MergeColumns(nil, ResourceRelationColumnDescriptors)
+
+// ResourceRelationColumnDescriptors is resource relation table column descriptors.
+var ResourceRelationColumnDescriptors = utils.ColumnDescriptors{
+	{Column: "src_type", NamedC: "src_type", Type: enumor.String},
+	{Column: "src_id", NamedC: "src_id", Type: enumor.String},
+	{Column: "dst_type", NamedC: "dst_type", Type: enumor.String},
+	{Column: "dst_id", NamedC: "dst_id", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+}
+
+// ResourceRelationTable 资源关系，每条记录为资源关系图中的一条边，如 cvm -> disk、subnet -> vpc，
+// 数据来源于视图 resource_relation，只读。
+type ResourceRelationTable struct {
+	// SrcType 源资源类型，取值为资源所在的表名
+	SrcType table.Name `db:"src_type" json:"src_type"`
+	// SrcID 源资源ID
+	SrcID string `db:"src_id" json:"src_id"`
+	// DstType 目标资源类型，取值为资源所在的表名
+	DstType table.Name `db:"dst_type" json:"dst_type"`
+	// DstID 目标资源ID
+	DstID string `db:"dst_id" json:"dst_id"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" json:"tenant_id"`
+}
+
+// TableName return resource relation table name.
+func (t ResourceRelationTable) TableName() table.Name {
+	return table.ResourceRelationTable
+}
@@ -0,0 +1,65 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tablerestag resource tag table
+package tablerestag
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ResourceTagColumns defines all the resource tag table's columns.
+var ResourceTagColumns = utils.MergeColumns(nil, ResourceTagColumnDescriptors)
+
+// ResourceTagColumnDescriptors is resource tag table column descriptors.
+var ResourceTagColumnDescriptors = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "tag_key", NamedC: "tag_key", Type: enumor.String},
+	{Column: "tag_value", NamedC: "tag_value", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// ResourceTagTable 资源标签表，资源的每个标签存储为一行，由资源表的 tags 字段同步而来，用于按标签过滤资源。
+type ResourceTagTable struct {
+	// ID 标签记录ID
+	ID string `db:"id" json:"id"`
+	// ResType 资源类型，取值为资源所在的表名，如 security_group
+	ResType table.Name `db:"res_type" json:"res_type"`
+	// ResID 资源ID
+	ResID string `db:"res_id" json:"res_id"`
+	// TagKey 标签键
+	TagKey string `db:"tag_key" json:"tag_key"`
+	// TagValue 标签值
+	TagValue string `db:"tag_value" json:"tag_value"`
+	// TenantID 租户ID
+	TenantID string `db:"tenant_id" json:"tenant_id"`
+	// CreatedAt 记录创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at"`
+}
+
+// TableName return resource tag table name.
+func (t ResourceTagTable) TableName() table.Name {
+	return table.ResourceTagTable
+}
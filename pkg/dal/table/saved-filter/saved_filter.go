@@ -0,0 +1,136 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package savedfilter defines saved filter table.
+package savedfilter
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// SavedFilterColumns defines all the saved filter table's columns.
+var SavedFilterColumns = utils.MergeColumns(nil, SavedFilterColumnDescriptor)
+
+// SavedFilterColumnDescriptor is saved filter table column descriptors.
+var SavedFilterColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "scope", NamedC: "scope", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "filter", NamedC: "filter", Type: enumor.Json},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// SavedFilterTable define saved filter table.
+type SavedFilterTable struct {
+	ID      string                   `db:"id" validate:"lte=64" json:"id"`
+	Name    string                   `db:"name" validate:"lte=255" json:"name"`
+	ResType enumor.CloudResourceType `db:"res_type" validate:"lte=64" json:"res_type"`
+	Scope   enumor.SavedFilterScope  `db:"scope" validate:"lte=16" json:"scope"`
+	// BkBizID 共享的业务ID，仅本人可见时为-1
+	BkBizID int64 `db:"bk_biz_id" json:"bk_biz_id"`
+	// Filter 过滤条件，为 filter.Expression 的json格式
+	Filter    types.JsonField `db:"filter" json:"filter"`
+	Memo      *string         `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	TenantID  string          `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
+	Creator   string          `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string          `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time      `db:"created_at" validate:"isdefault" json:"created_at"`
+	UpdatedAt types.Time      `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName return saved filter table name.
+func (t SavedFilterTable) TableName() table.Name {
+	return table.SavedFilterTable
+}
+
+// InsertValidate saved filter table when insert.
+func (t SavedFilterTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if len(t.ResType) == 0 {
+		return errors.New("res type is required")
+	}
+
+	if err := t.Scope.Validate(); err != nil {
+		return err
+	}
+
+	if t.Scope == enumor.SavedFilterBizScope && t.BkBizID <= 0 {
+		return errors.New("bk biz id is required when scope is biz")
+	}
+
+	if t.Scope == enumor.SavedFilterUserScope && t.BkBizID != constant.UnassignedBiz {
+		return errors.New("bk biz id should be -1 when scope is user")
+	}
+
+	if t.Filter.IsEmpty() {
+		return errors.New("filter is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate saved filter table when update.
+func (t SavedFilterTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ResType) != 0 {
+		return errors.New("res type can not update")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
@@ -94,6 +94,10 @@ const (
 	ImageTable Name = "image"
 	// ZoneTable is zone table's name.
 	ZoneTable Name = "zone"
+	// ZoneCapabilityTable 可用区能力表
+	ZoneCapabilityTable Name = "zone_capability"
+	// EventConsumerOffsetTable 资源变更事件消费位点表
+	EventConsumerOffsetTable Name = "event_consumer_offset"
 	// CvmTable is cvm table's name.
 	CvmTable Name = "cvm"
 	// RouteTableTable is route table's table name.
@@ -133,6 +137,10 @@ const (
 
 	// UserCollectionTable 用户收藏表
 	UserCollectionTable Name = "user_collection"
+	// UserRecentViewTable 用户最近访问资源表
+	UserRecentViewTable Name = "user_recent_view"
+	// SavedFilterTable 资源列表保存的过滤条件表
+	SavedFilterTable Name = "saved_filter"
 
 	// AsyncFlowTable is async flow table's name.
 	AsyncFlowTable Name = "async_flow"
@@ -198,12 +206,66 @@ const (
 	AccountBillExchangeRateTable = "account_bill_exchange_rate"
 	// AccountBillSyncRecordTable 账单同步记录
 	AccountBillSyncRecordTable = "account_bill_sync_record"
+	// AccountBillBudgetTable 账单预算表
+	AccountBillBudgetTable = "account_bill_budget"
+	// AccountBillReconciliationTable 账单对账结果表
+	AccountBillReconciliationTable = "account_bill_reconciliation"
 	// TaskDetailTable 任务详情表
 	TaskDetailTable = "task_detail"
 	// TaskManagementTable 任务管理表
 	TaskManagementTable = "task_management"
 	//	GlobalConfigTable 全局配置表
 	GlobalConfigTable = "global_config"
+	// ResourceHistoryTable 资源历史版本表
+	ResourceHistoryTable Name = "resource_history"
+	// ResourceTagTable 资源标签表
+	ResourceTagTable Name = "resource_tag"
+	// ResourceRelationTable 资源关系视图，由已有的关联表和资源表合并而成，只读
+	ResourceRelationTable Name = "resource_relation"
+	// CvmTemplateTable 主机模板表
+	CvmTemplateTable Name = "cvm_template"
+	// BizQuotaTable 业务资源配额表
+	BizQuotaTable Name = "biz_quota"
+	// BizResWhitelistTable 业务资源申请白名单表
+	BizResWhitelistTable Name = "biz_res_whitelist"
+	// CvmScheduleTable 主机定时开关机策略表
+	CvmScheduleTable Name = "cvm_schedule"
+	// CvmScheduleRecordTable 主机定时开关机执行记录表
+	CvmScheduleRecordTable Name = "cvm_schedule_record"
+	// CmdbHostRelTable 主机与cmdb主机的映射关系表
+	CmdbHostRelTable Name = "cmdb_host_rel"
+	// MaintenanceWindowTable 业务维护窗口表
+	MaintenanceWindowTable Name = "maintenance_window"
+	// LandingZoneDeploymentTable 网络基线部署表
+	LandingZoneDeploymentTable Name = "landing_zone_deployment"
+	// IdleResourceTable 闲置资源检测结果表
+	IdleResourceTable Name = "idle_resource"
+	// NamingRuleTable 资源命名规则表
+	NamingRuleTable Name = "naming_rule"
+	// NamingViolationTable 命名不合规资源表
+	NamingViolationTable Name = "naming_violation"
+	// TagPolicyTable 标签策略表
+	TagPolicyTable Name = "tag_policy"
+	// TagViolationTable 标签不合规资源表
+	TagViolationTable Name = "tag_violation"
+	// ComplianceFindingTable 安全合规检测结果表
+	ComplianceFindingTable Name = "compliance_finding"
+	// ComplianceExemptionTable 安全合规豁免表
+	ComplianceExemptionTable Name = "compliance_exemption"
+	// AsyncJobTable 异步任务视图，由异步任务流和账号资源同步详情合并而成，只读
+	AsyncJobTable Name = "async_job"
+	// AccountGroupTable 账号组表
+	AccountGroupTable Name = "account_group"
+	// AccountGroupRelTable 账号组成员关系表
+	AccountGroupRelTable Name = "account_group_rel"
+	// NotificationTemplateTable 通知模板表
+	NotificationTemplateTable Name = "notification_template"
+	// NotificationSubscriptionTable 通知订阅表
+	NotificationSubscriptionTable Name = "notification_subscription"
+	// WebhookSubscriptionTable webhook订阅表
+	WebhookSubscriptionTable Name = "webhook_subscription"
+	// WebhookDeliveryTable webhook投递记录表
+	WebhookDeliveryTable Name = "webhook_delivery"
 )
 
 // Validate whether the table name is valid or not.
@@ -253,6 +315,8 @@ var TableMap = map[Name]struct{}{
 	HuaWeiRouteTable:             {},
 	GcpRouteTable:                {},
 	ZoneTable:                    {},
+	ZoneCapabilityTable:          {},
+	EventConsumerOffsetTable:     {},
 	CvmTable:                     {},
 	ApplicationTable:             {},
 	ApprovalProcessTable:         {},
@@ -266,6 +330,8 @@ var TableMap = map[Name]struct{}{
 	EipCvmRelTableName:           {},
 	AccountBillConfigTable:       {},
 	UserCollectionTable:          {},
+	UserRecentViewTable:          {},
+	SavedFilterTable:             {},
 	AccountSyncDetailTable:       {},
 	CloudSelectionSchemeTable:    {},
 	CloudSelectionBizTypeTable:   {},
@@ -291,6 +357,8 @@ var TableMap = map[Name]struct{}{
 	RootAccountBillConfigTable:      {},
 	AccountBillExchangeRateTable:    {},
 	AccountBillSyncRecordTable:      {},
+	AccountBillBudgetTable:          {},
+	AccountBillReconciliationTable:  {},
 	LoadBalancerTable:               {},
 	SecurityGroupCommonRelTable:     {},
 	LoadBalancerListenerTable:       {},
@@ -308,6 +376,35 @@ var TableMap = map[Name]struct{}{
 	TaskDetailTable:     {},
 
 	GlobalConfigTable: {},
+
+	ResourceHistoryTable: {},
+	ResourceTagTable:     {},
+
+	ResourceRelationTable: {},
+
+	CvmTemplateTable:     {},
+	BizQuotaTable:        {},
+	BizResWhitelistTable: {},
+
+	CvmScheduleTable:              {},
+	CvmScheduleRecordTable:        {},
+	CmdbHostRelTable:              {},
+	MaintenanceWindowTable:        {},
+	LandingZoneDeploymentTable:    {},
+	IdleResourceTable:             {},
+	NamingRuleTable:               {},
+	NamingViolationTable:          {},
+	TagPolicyTable:                {},
+	TagViolationTable:             {},
+	ComplianceFindingTable:        {},
+	ComplianceExemptionTable:      {},
+	AsyncJobTable:                 {},
+	AccountGroupTable:             {},
+	AccountGroupRelTable:          {},
+	NotificationTemplateTable:     {},
+	NotificationSubscriptionTable: {},
+	WebhookSubscriptionTable:      {},
+	WebhookDeliveryTable:          {},
 }
 
 // Register 注册表名
@@ -0,0 +1,123 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tagpolicy defines tag policy and tag violation table.
+package tagpolicy
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// TagPolicyColumns defines all the tag policy table's columns.
+var TagPolicyColumns = utils.MergeColumns(nil, TagPolicyColumnDescriptor)
+
+// TagPolicyColumnDescriptor is tag policy table column descriptors.
+var TagPolicyColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "tag_key", NamedC: "tag_key", Type: enumor.String},
+	{Column: "allowed_values", NamedC: "allowed_values", Type: enumor.Json},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// TagPolicyTable define tag policy table.
+type TagPolicyTable struct {
+	ID string `db:"id" validate:"lte=64" json:"id"`
+	// BkBizID 业务ID，-1表示所有业务均需遵循的全局默认策略
+	BkBizID int64                    `db:"bk_biz_id" json:"bk_biz_id"`
+	ResType enumor.CloudResourceType `db:"res_type" validate:"lte=64" json:"res_type"`
+	// TagKey 必填标签键
+	TagKey string `db:"tag_key" validate:"lte=128" json:"tag_key"`
+	// AllowedValues 允许的标签值，为空表示不限制取值
+	AllowedValues types.StringArray `db:"allowed_values" json:"allowed_values"`
+	Memo          *string           `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	TenantID      string            `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
+	Creator       string            `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser       string            `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt     types.Time        `db:"created_at" validate:"isdefault" json:"created_at"`
+	UpdatedAt     types.Time        `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName return tag policy table name.
+func (t TagPolicyTable) TableName() table.Name {
+	return table.TagPolicyTable
+}
+
+// InsertValidate tag policy table when insert.
+func (t TagPolicyTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if t.BkBizID <= 0 && t.BkBizID != constant.UnassignedBiz {
+		return errors.New("bk_biz_id is invalid")
+	}
+
+	if err := enumor.ValidateTagPolicyResType(t.ResType); err != nil {
+		return err
+	}
+
+	if len(t.TagKey) == 0 {
+		return errors.New("tag_key is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate tag policy table when update.
+func (t TagPolicyTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if t.BkBizID != 0 || len(t.ResType) != 0 || len(t.TagKey) != 0 {
+		return errors.New("bk_biz_id, res_type and tag_key can not update")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
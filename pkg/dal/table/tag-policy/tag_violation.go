@@ -0,0 +1,108 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tagpolicy
+
+import (
+	"errors"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// TagViolationColumns defines all the tag violation table's columns.
+var TagViolationColumns = utils.MergeColumns(nil, TagViolationColumnDescriptor)
+
+// TagViolationColumnDescriptor is tag violation table column descriptors.
+var TagViolationColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "res_name", NamedC: "res_name", Type: enumor.String},
+	{Column: "policy_id", NamedC: "policy_id", Type: enumor.String},
+	{Column: "tag_key", NamedC: "tag_key", Type: enumor.String},
+	{Column: "tag_value", NamedC: "tag_value", Type: enumor.String},
+	{Column: "reason", NamedC: "reason", Type: enumor.String},
+	{Column: "detected_at", NamedC: "detected_at", Type: enumor.Time},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// TagViolationTable define tag violation table.
+type TagViolationTable struct {
+	ID        string                   `db:"id" validate:"lte=64" json:"id"`
+	ResType   enumor.CloudResourceType `db:"res_type" validate:"lte=64" json:"res_type"`
+	ResID     string                   `db:"res_id" validate:"lte=64" json:"res_id"`
+	Vendor    enumor.Vendor            `db:"vendor" validate:"lte=16" json:"vendor"`
+	AccountID string                   `db:"account_id" validate:"lte=64" json:"account_id"`
+	BkBizID   int64                    `db:"bk_biz_id" json:"bk_biz_id"`
+	ResName   string                   `db:"res_name" validate:"lte=255" json:"res_name"`
+	// PolicyID 资源不符合的标签策略ID
+	PolicyID string `db:"policy_id" validate:"lte=64" json:"policy_id"`
+	TagKey   string `db:"tag_key" validate:"lte=128" json:"tag_key"`
+	// TagValue 资源当前的标签值，缺少标签时为空
+	TagValue   string                    `db:"tag_value" validate:"lte=255" json:"tag_value"`
+	Reason     enumor.TagViolationReason `db:"reason" validate:"lte=32" json:"reason"`
+	DetectedAt time.Time                 `db:"detected_at" json:"detected_at"`
+	TenantID   string                    `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
+	CreatedAt  types.Time                `db:"created_at" validate:"isdefault" json:"created_at"`
+	UpdatedAt  types.Time                `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName return tag violation table name.
+func (t TagViolationTable) TableName() table.Name {
+	return table.TagViolationTable
+}
+
+// InsertValidate tag violation table when insert.
+func (t TagViolationTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.ResType) == 0 || len(t.ResID) == 0 {
+		return errors.New("res_type and res_id are required")
+	}
+
+	if len(t.AccountID) == 0 {
+		return errors.New("account_id is required")
+	}
+
+	if len(t.PolicyID) == 0 || len(t.TagKey) == 0 {
+		return errors.New("policy_id and tag_key are required")
+	}
+
+	if len(t.Reason) == 0 {
+		return errors.New("reason is required")
+	}
+
+	return nil
+}
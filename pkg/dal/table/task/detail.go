@@ -45,6 +45,7 @@ var DetailColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "state", NamedC: "state", Type: enumor.String},
 	{Column: "reason", NamedC: "reason", Type: enumor.String},
 	{Column: "extension", NamedC: "extension", Type: enumor.Json},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -64,6 +65,7 @@ type DetailTable struct {
 	State            enumor.TaskDetailState `db:"state" validate:"lte=16" json:"state"`
 	Reason           string                 `db:"reason" validate:"lte=1024" json:"reason"`
 	Extension        types.JsonField        `db:"extension" json:"extension"`
+	TenantID         string                 `db:"tenant_id" validate:"max=64" json:"tenant_id"`
 	Creator          string                 `db:"creator" validate:"max=64" json:"creator"`
 	Reviser          string                 `db:"reviser" validate:"max=64" json:"reviser"`
 	CreatedAt        types.Time             `db:"created_at" validate:"isdefault" json:"created_at"`
@@ -44,6 +44,7 @@ var ManagementColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "operations", NamedC: "operations", Type: enumor.Json},
 	{Column: "flow_ids", NamedC: "flow_ids", Type: enumor.Json},
 	{Column: "extension", NamedC: "extension", Type: enumor.Json},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -62,6 +63,7 @@ type ManagementTable struct {
 	Operations types.StringArray             `db:"operations"  json:"operations"`
 	FlowIDs    types.StringArray             `db:"flow_ids"  json:"flow_ids"`
 	Extension  types.JsonField               `db:"extension" json:"extension"`
+	TenantID   string                        `db:"tenant_id" validate:"max=64" json:"tenant_id"`
 	Creator    string                        `db:"creator" validate:"max=64" json:"creator"`
 	Reviser    string                        `db:"reviser" validate:"max=64" json:"reviser"`
 	CreatedAt  types.Time                    `db:"created_at" validate:"isdefault" json:"created_at"`
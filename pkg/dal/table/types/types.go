@@ -174,20 +174,20 @@ func (m StringMap) Value() (driver.Value, error) {
 }
 
 // Scan is used to decode raw message which is read from db into StringMap.
-func (m StringMap) Scan(raw interface{}) error {
+func (m *StringMap) Scan(raw interface{}) error {
 	if m == nil || raw == nil {
 		return nil
 	}
 
 	switch v := raw.(type) {
 	case []byte:
-		if err := json.Unmarshal(v, &m); err != nil {
+		if err := json.Unmarshal(v, m); err != nil {
 			return fmt.Errorf("decode into string map failed, err: %v", err)
 		}
 		return nil
 
 	case string:
-		if err := json.Unmarshal([]byte(v), &m); err != nil {
+		if err := json.Unmarshal([]byte(v), m); err != nil {
 			return fmt.Errorf("decode into string map failed, err: %v", err)
 		}
 		return nil
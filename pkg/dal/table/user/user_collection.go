@@ -38,6 +38,7 @@ var UserCollTableColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "user", NamedC: "user", Type: enumor.String},
 	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
 	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
 }
@@ -48,6 +49,7 @@ type UserCollTable struct {
 	User      string                       `db:"user" json:"user" validate:"lte=64"`
 	ResType   enumor.UserCollectionResType `db:"res_type" json:"res_type" validate:"lte=50"`
 	ResID     string                       `db:"res_id" json:"res_id" validate:"lte=64"`
+	TenantID  string                       `db:"tenant_id" json:"tenant_id" validate:"lte=64"`
 	Creator   string                       `db:"creator" json:"creator" validate:"lte=64"`
 	CreatedAt types.Time                   `db:"created_at" validate:"excluded_unless" json:"created_at"`
 }
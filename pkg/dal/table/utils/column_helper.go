@@ -128,6 +128,19 @@ func (col Columns) ColonNameExpr() string {
 	return col.colonNameExpr
 }
 
+// InsertExpr returns the insert sql of the table with the insert columns,
+// like: "INSERT INTO table (name) VALUES(:spec.name)". the sql is cached by
+// table name, so one table should always be inserted with the same columns.
+func (col Columns) InsertExpr(tableName string) string {
+	if cached, exist := insertExprCache.Load(tableName); exist {
+		return cached.(string)
+	}
+
+	expr := fmt.Sprintf("INSERT INTO %s (%s) VALUES(%s)", tableName, col.columnExpr, col.colonNameExpr)
+	insertExprCache.Store(tableName, expr)
+	return expr
+}
+
 // WithoutColumn remove one or more columns from the 'origin' columns.
 func (col Columns) WithoutColumn(column ...string) map[string]enumor.ColumnType {
 	if len(column) == 0 {
@@ -268,7 +281,8 @@ func MergeColumnDescriptors(prefix string, namedC ...ColumnDescriptors) ColumnDe
 //     is not blank(as is not "ZERO"),
 //  3. If the field is defined in the blank options deliberately, then
 //     update it to blank value as required.
-//  4. see the test case to know the exact data returned.
+//  4. the returned 'expr' fields are sorted, and the expr of the same field set is cached.
+//  5. see the test case to know the exact data returned.
 func RearrangeSQLDataWithOption(data interface{}, opts *FieldOption) (
 	expr string, toUpdate map[string]interface{}, err error,
 ) {
@@ -295,30 +309,23 @@ func RearrangeSQLDataWithOption(data interface{}, opts *FieldOption) (
 		}
 
 		if opts.NeedBlanked(tag) {
-			if isBasicValue(value) && !reflect.ValueOf(value).IsNil() {
-				toUpdate[tag] = value
-				setFields = append(setFields, fmt.Sprintf("%s = :%s", tag, tag))
+			// nil basic pointer value means this field is not set, do not need to be updated.
+			if rv := reflect.ValueOf(value); rv.Kind() == reflect.Ptr && rv.IsNil() && isBasicValue(value) {
 				continue
 			}
 
-			if !isBasicValue(value) {
-				toUpdate[tag] = value
-				setFields = append(setFields, fmt.Sprintf("%s = :%s", tag, tag))
-			}
-
+			toUpdate[tag] = value
+			setFields = append(setFields, tag)
 			continue
 		}
 
 		if !isBlank(reflect.ValueOf(value)) {
 			toUpdate[tag] = value
-			setFields = append(setFields, fmt.Sprintf("%s = :%s", tag, tag))
+			setFields = append(setFields, tag)
 		}
 	}
 
-	setFields = append(setFields, "updated_at = now()")
-	expr = strings.Join(setFields, ", ")
-
-	return "set " + expr, toUpdate, nil
+	return getSetExpr(setFields), toUpdate, nil
 }
 
 // RecursiveGetTaggedFieldValues get all the tagged db kv
@@ -328,65 +335,18 @@ func RearrangeSQLDataWithOption(data interface{}, opts *FieldOption) (
 //  2. use this function carefully, it not supports all the type,
 //     such as array, slice, map is not supported.
 //  3. see the test case to know the output data example.
+//  4. the struct's tagged fields metadata is parsed only once and cached by type.
 func RecursiveGetTaggedFieldValues(v interface{}) (map[string]interface{}, error) {
 	if v == nil {
 		return map[string]interface{}{}, nil
 	}
 
-	value := reflect.ValueOf(v)
-	switch value.Kind() {
-	case reflect.Ptr:
-		if value.IsNil() {
-			return map[string]interface{}{}, nil
-		}
-
-		return RecursiveGetTaggedFieldValues(value.Elem().Interface())
-
-	case reflect.Struct:
-		kv := make(map[string]interface{})
-
-		for i := 0; i < value.NumField(); i++ {
-			name := value.Type().Field(i).Name
-			tag := value.Type().Field(i).Tag.Get("db")
-			if tag == "" {
-				return nil, fmt.Errorf("field: %s do not have a 'db' tag", name)
-			}
-
-			val := value.FieldByName(name).Interface()
-
-			if isBasicValue(val) {
-				kv[tag] = val
-
-				// handle next field.
-				continue
-			}
-
-			// 如果结构体实现了Sql序列化函数（Scan），不再解析嵌套结构体中的字段，将整个结构体当成一个Sql字段
-			_, exist := reflect.TypeOf(val).MethodByName("Scan")
-			if exist {
-				kv[tag] = val
-
-				// handle next field.
-				continue
-			}
-
-			// this is not a basic value, then do get tags again recursively.
-			mapper, err := RecursiveGetTaggedFieldValues(val)
-			if err != nil {
-				return nil, err
-			}
-
-			for k, v := range mapper {
-				kv[k] = v
-			}
-
-		}
-
-		return kv, nil
-
-	default:
-		return nil, fmt.Errorf("unsupported struct db tagged value type: %s", value.Kind())
+	kv := make(map[string]interface{})
+	if err := collectTaggedValues(reflect.ValueOf(v), kv); err != nil {
+		return nil, err
 	}
+
+	return kv, nil
 }
 
 var timeType = reflect.TypeOf(time.Time{})
@@ -201,3 +201,54 @@ func TestRecursiveGetTaggedFieldValues(t *testing.T) {
 	js, _ := json.MarshalIndent(kv, "", "    ")
 	fmt.Printf("kv json: %s\n", js)
 }
+
+func TestRearrangeSQLDataWithOptionCachedExpr(t *testing.T) {
+	c := cases{
+		ID: 20,
+		Embedded: &embedded{
+			Name: "demo",
+			Deep: deepEmbedded{Age: 18},
+		},
+		Iter: "123456789",
+	}
+
+	opts := NewFieldOptions().AddIgnoredFields("id")
+	for i := 0; i < 3; i++ {
+		expr, toUpdate, err := RearrangeSQLDataWithOption(c, opts)
+		if err != nil {
+			t.Errorf("parse data field, err: %v", err)
+			return
+		}
+
+		if expr != "set age = :age, iter = :iter, name = :name, updated_at = now()" {
+			t.Errorf("test cached expr failed, got: %s", expr)
+			return
+		}
+
+		if len(toUpdate) != 3 {
+			t.Errorf("test cached to update failed, got: %v", toUpdate)
+			return
+		}
+	}
+}
+
+func TestColumnsInsertExpr(t *testing.T) {
+	columns := MergeColumns(InsertWithoutPrimaryID, ColumnDescriptors{
+		{Column: "id", NamedC: "id", Type: enumor.Numeric},
+		{Column: "name", NamedC: "spec.name", Type: enumor.String},
+		{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	})
+
+	for i := 0; i < 3; i++ {
+		expr := columns.InsertExpr("test_insert_expr")
+		if expr != "INSERT INTO test_insert_expr (name, created_at) VALUES(:spec.name, now())" {
+			t.Errorf("test cached insert expr failed, got: %s", expr)
+			return
+		}
+	}
+
+	if _, exist := insertExprCache.Load("test_insert_expr"); !exist {
+		t.Errorf("test insert expr cache failed, not cached")
+		return
+	}
+}
@@ -0,0 +1,180 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// fieldKind defines how a 'db' tagged field's value is collected.
+type fieldKind int
+
+const (
+	// basicField is a basic value or a value implements sql Scan, which is treated as one sql field.
+	basicField fieldKind = iota
+	// nestedField is a struct or *struct, whose tagged fields are collected recursively.
+	nestedField
+	// dynamicField is an interface, whose value can only be resolved at runtime.
+	dynamicField
+)
+
+// taggedField defines the cached metadata of a 'db' tagged struct field.
+type taggedField struct {
+	index int
+	tag   string
+	kind  fieldKind
+}
+
+// structMeta defines the cached 'db' tagged fields metadata of a struct type.
+type structMeta struct {
+	fields []taggedField
+	err    error
+}
+
+var (
+	// structMetaCache caches the struct metadata, key is reflect.Type, value is *structMeta.
+	structMetaCache sync.Map
+	// setExprCache caches the update set expression, key is the sorted field set, value is the expression.
+	setExprCache sync.Map
+	// insertExprCache caches the insert sql, key is the table name, value is the sql.
+	insertExprCache sync.Map
+)
+
+// getStructMeta get the struct type's 'db' tagged fields metadata, which only parse the struct tags once.
+func getStructMeta(typ reflect.Type) *structMeta {
+	if cached, exist := structMetaCache.Load(typ); exist {
+		return cached.(*structMeta)
+	}
+
+	meta := &structMeta{fields: make([]taggedField, 0, typ.NumField())}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("db")
+		if tag == "" {
+			meta = &structMeta{err: fmt.Errorf("field: %s do not have a 'db' tag", field.Name)}
+			break
+		}
+
+		meta.fields = append(meta.fields, taggedField{index: i, tag: tag, kind: parseFieldKind(field.Type)})
+	}
+
+	actual, _ := structMetaCache.LoadOrStore(typ, meta)
+	return actual.(*structMeta)
+}
+
+func parseFieldKind(typ reflect.Type) fieldKind {
+	if typ.Kind() == reflect.Interface {
+		return dynamicField
+	}
+
+	if isBasicType(typ) {
+		return basicField
+	}
+
+	// 如果结构体实现了Sql序列化函数（Scan），不再解析嵌套结构体中的字段，将整个结构体当成一个Sql字段
+	if _, exist := typ.MethodByName("Scan"); exist {
+		return basicField
+	}
+
+	return nestedField
+}
+
+func isBasicType(typ reflect.Type) bool {
+	if typ == timeType {
+		return true
+	}
+
+	if typ.Kind() == reflect.Ptr {
+		return isBasicKind(typ.Elem().Kind())
+	}
+
+	return isBasicKind(typ.Kind())
+}
+
+// collectTaggedValues collect all the tagged db kv of the value to kv with the cached struct metadata.
+func collectTaggedValues(value reflect.Value, kv map[string]interface{}) error {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return nil
+		}
+
+		return collectTaggedValues(value.Elem(), kv)
+
+	case reflect.Struct:
+		meta := getStructMeta(value.Type())
+		if meta.err != nil {
+			return meta.err
+		}
+
+		for _, field := range meta.fields {
+			val := value.Field(field.index).Interface()
+
+			switch field.kind {
+			case basicField:
+				kv[field.tag] = val
+
+			case dynamicField:
+				if val == nil || parseFieldKind(reflect.TypeOf(val)) == basicField {
+					kv[field.tag] = val
+					continue
+				}
+
+				if err := collectTaggedValues(reflect.ValueOf(val), kv); err != nil {
+					return err
+				}
+
+			default:
+				if err := collectTaggedValues(value.Field(field.index), kv); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported struct db tagged value type: %s", value.Kind())
+	}
+}
+
+// getSetExpr get the update set expression of the fields, the fields will be sorted, so that the
+// same field set always generates the same expression, and the expression is cached.
+func getSetExpr(fields []string) string {
+	sort.Strings(fields)
+	key := strings.Join(fields, ",")
+	if cached, exist := setExprCache.Load(key); exist {
+		return cached.(string)
+	}
+
+	setFields := make([]string, 0, len(fields)+1)
+	for _, field := range fields {
+		setFields = append(setFields, fmt.Sprintf("%s = :%s", field, field))
+	}
+	setFields = append(setFields, "updated_at = now()")
+	expr := "set " + strings.Join(setFields, ", ")
+
+	setExprCache.Store(key, expr)
+	return expr
+}
@@ -0,0 +1,116 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package webhook
+
+import (
+	"errors"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// DeliveryColumns defines all the webhook delivery table's columns.
+var DeliveryColumns = utils.MergeColumns(nil, DeliveryColumnDescriptor)
+
+// DeliveryColumnDescriptor is webhook delivery table column descriptors.
+var DeliveryColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "subscription_id", NamedC: "subscription_id", Type: enumor.String},
+	{Column: "event_offset", NamedC: "event_offset", Type: enumor.Numeric},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "action", NamedC: "action", Type: enumor.String},
+	{Column: "payload", NamedC: "payload", Type: enumor.Json},
+	{Column: "state", NamedC: "state", Type: enumor.String},
+	{Column: "attempts", NamedC: "attempts", Type: enumor.Numeric},
+	{Column: "status_code", NamedC: "status_code", Type: enumor.Numeric},
+	{Column: "reason", NamedC: "reason", Type: enumor.String},
+	{Column: "next_retry_at", NamedC: "next_retry_at", Type: enumor.Time},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// DeliveryTable define webhook delivery table, each record is an event to be delivered to a subscription.
+type DeliveryTable struct {
+	ID             string                   `db:"id" validate:"lte=64" json:"id"`
+	SubscriptionID string                   `db:"subscription_id" validate:"lte=64" json:"subscription_id"`
+	EventOffset    uint64                   `db:"event_offset" json:"event_offset"`
+	ResType        enumor.AuditResourceType `db:"res_type" validate:"lte=50" json:"res_type"`
+	ResID          string                   `db:"res_id" validate:"lte=64" json:"res_id"`
+	Action         enumor.AuditAction       `db:"action" validate:"lte=20" json:"action"`
+	// Payload 推送的事件内容，重试时原样推送
+	Payload    types.JsonField             `db:"payload" json:"payload"`
+	State      enumor.WebhookDeliveryState `db:"state" validate:"lte=20" json:"state"`
+	Attempts   uint                        `db:"attempts" json:"attempts"`
+	StatusCode int                         `db:"status_code" json:"status_code"`
+	Reason     string                      `db:"reason" validate:"lte=1024" json:"reason"`
+	// NextRetryAt 下次投递时间，投递成功或失败后不再使用
+	NextRetryAt time.Time  `db:"next_retry_at" json:"next_retry_at"`
+	TenantID    string     `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
+	Creator     string     `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser     string     `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt   types.Time `db:"created_at" validate:"isdefault" json:"created_at"`
+	UpdatedAt   types.Time `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName return webhook delivery table name.
+func (t DeliveryTable) TableName() table.Name {
+	return table.WebhookDeliveryTable
+}
+
+// InsertValidate webhook delivery table when insert.
+func (t DeliveryTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.SubscriptionID) == 0 {
+		return errors.New("subscription_id is required")
+	}
+
+	if len(t.ResType) == 0 {
+		return errors.New("res_type is required")
+	}
+
+	if len(t.Payload) == 0 {
+		return errors.New("payload is required")
+	}
+
+	if err := t.State.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
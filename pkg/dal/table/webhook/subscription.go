@@ -0,0 +1,133 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package webhook defines the tables of webhook subscription and delivery.
+package webhook
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// SubscriptionColumns defines all the webhook subscription table's columns.
+var SubscriptionColumns = utils.MergeColumns(nil, SubscriptionColumnDescriptor)
+
+// SubscriptionColumnDescriptor is webhook subscription table column descriptors.
+var SubscriptionColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "url", NamedC: "url", Type: enumor.String},
+	{Column: "secret", NamedC: "secret", Type: enumor.String},
+	{Column: "res_types", NamedC: "res_types", Type: enumor.Json},
+	{Column: "actions", NamedC: "actions", Type: enumor.Json},
+	{Column: "bk_biz_ids", NamedC: "bk_biz_ids", Type: enumor.Json},
+	{Column: "enabled", NamedC: "enabled", Type: enumor.Boolean},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "tenant_id", NamedC: "tenant_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// SubscriptionTable define webhook subscription table.
+type SubscriptionTable struct {
+	ID   string `db:"id" validate:"lte=64" json:"id"`
+	Name string `db:"name" validate:"lte=255" json:"name"`
+	Url  string `db:"url" validate:"lte=1024" json:"url"`
+	// Secret 加密后的签名密钥，投递时解密后用于计算请求体的HMAC签名
+	Secret string `db:"secret" validate:"lte=512" json:"secret"`
+	// ResTypes 订阅的资源类型，即事件topic
+	ResTypes types.StringArray `db:"res_types" json:"res_types"`
+	// Actions 订阅的操作类型，为空表示不限制
+	Actions types.StringArray `db:"actions" json:"actions"`
+	// BkBizIDs 订阅的业务范围，为空表示不限制
+	BkBizIDs  types.Int64Array `db:"bk_biz_ids" json:"bk_biz_ids"`
+	Enabled   *bool            `db:"enabled" json:"enabled"`
+	Memo      *string          `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	TenantID  string           `db:"tenant_id" validate:"lte=64" json:"tenant_id"`
+	Creator   string           `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string           `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time       `db:"created_at" validate:"isdefault" json:"created_at"`
+	UpdatedAt types.Time       `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName return webhook subscription table name.
+func (t SubscriptionTable) TableName() table.Name {
+	return table.WebhookSubscriptionTable
+}
+
+// InsertValidate webhook subscription table when insert.
+func (t SubscriptionTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if len(t.Url) == 0 {
+		return errors.New("url is required")
+	}
+
+	if len(t.Secret) == 0 {
+		return errors.New("secret is required")
+	}
+
+	if len(t.ResTypes) == 0 {
+		return errors.New("res_types is required")
+	}
+
+	if t.Enabled == nil {
+		return errors.New("enabled is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate webhook subscription table when update.
+func (t SubscriptionTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package featureflag evaluates the feature flags of the service, so that risky new behaviors can be rolled out
+// per vendor or per account, and be rolled back without restart when the settings are reloaded from etcd.
+package featureflag
+
+import (
+	"sync/atomic"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+const (
+	// DataServiceGrpc 通过grpc调用data-service的热点接口，需同时开启 dataServiceGrpc 配置，仅支持按云厂商灰度
+	DataServiceGrpc = "data_service_grpc"
+)
+
+// defaults 特性未配置时的开关状态，未在此声明的特性默认关闭
+var defaults = map[string]bool{
+	// grpc传输本身由 dataServiceGrpc 配置控制，特性开关仅用于按云厂商收缩灰度范围
+	DataServiceGrpc: true,
+}
+
+// flag is the evaluated feature flag, vendors and accounts are converted to sets for the per-request lookup.
+type flag struct {
+	enable   bool
+	vendors  map[enumor.Vendor]struct{}
+	accounts map[string]struct{}
+	excludes map[string]struct{}
+}
+
+var flags atomic.Pointer[map[string]flag]
+
+// Init set the feature flags getter, the flags are reloaded once the settings are changed. It also sets the
+// evaluator of kit, so that the flags can be queried by kit.FeatureEnabled.
+func Init(getter func() cc.FeatureFlags) {
+	load(getter())
+	cc.OnChange(func() {
+		load(getter())
+	})
+
+	kit.FeatureEvaluator = func(kt *kit.Kit, name string, vendor enumor.Vendor, accountID string) bool {
+		enabled := Enabled(name, vendor, accountID)
+		logs.V(5).Infof("feature %s is %t for vendor: %s, account: %s, rid: %s", name, enabled, vendor, accountID,
+			kt.Rid)
+		return enabled
+	}
+}
+
+func load(conf cc.FeatureFlags) {
+	loaded := make(map[string]flag, len(conf))
+	for name, one := range conf {
+		f := flag{
+			enable:   one.Enable,
+			vendors:  make(map[enumor.Vendor]struct{}, len(one.Vendors)),
+			accounts: make(map[string]struct{}, len(one.AccountIDs)),
+			excludes: make(map[string]struct{}, len(one.ExcludeAccountIDs)),
+		}
+		for _, vendor := range one.Vendors {
+			f.vendors[enumor.Vendor(vendor)] = struct{}{}
+		}
+		for _, id := range one.AccountIDs {
+			f.accounts[id] = struct{}{}
+		}
+		for _, id := range one.ExcludeAccountIDs {
+			f.excludes[id] = struct{}{}
+		}
+		loaded[name] = f
+	}
+
+	flags.Store(&loaded)
+}
+
+// Enabled returns if the feature is enabled for the vendor and account, pass empty value if the feature is not
+// vendor or account related. Prefer kit.FeatureEnabled when kit is available.
+func Enabled(name string, vendor enumor.Vendor, accountID string) bool {
+	loaded := flags.Load()
+	if loaded == nil {
+		return defaults[name]
+	}
+
+	f, exists := (*loaded)[name]
+	if !exists {
+		return defaults[name]
+	}
+
+	if len(accountID) != 0 {
+		if _, excluded := f.excludes[accountID]; excluded {
+			return false
+		}
+		if _, ok := f.accounts[accountID]; ok {
+			return true
+		}
+	}
+
+	if len(vendor) != 0 {
+		if _, ok := f.vendors[vendor]; ok {
+			return true
+		}
+	}
+
+	return f.enable
+}
@@ -0,0 +1,70 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package featureflag
+
+import (
+	"testing"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnabled(t *testing.T) {
+	flags.Store(nil)
+	assert.True(t, Enabled(DataServiceGrpc, enumor.TCloud, ""), "default of unloaded flag")
+	assert.False(t, Enabled("unknown", enumor.TCloud, "acc"), "unknown flag is disabled")
+
+	load(cc.FeatureFlags{
+		"by_vendor":     {Vendors: []string{string(enumor.TCloud)}, ExcludeAccountIDs: []string{"excluded"}},
+		"by_account":    {AccountIDs: []string{"acc"}},
+		"all":           {Enable: true, ExcludeAccountIDs: []string{"excluded"}},
+		DataServiceGrpc: {Vendors: []string{string(enumor.Aws)}},
+	})
+
+	assert.True(t, Enabled("by_vendor", enumor.TCloud, "acc"))
+	assert.False(t, Enabled("by_vendor", enumor.Aws, "acc"))
+	assert.False(t, Enabled("by_vendor", enumor.TCloud, "excluded"), "excluded account takes precedence")
+
+	assert.True(t, Enabled("by_account", enumor.Aws, "acc"))
+	assert.False(t, Enabled("by_account", enumor.Aws, "other"))
+	assert.False(t, Enabled("by_account", enumor.Aws, ""))
+
+	assert.True(t, Enabled("all", "", ""))
+	assert.False(t, Enabled("all", enumor.TCloud, "excluded"))
+
+	assert.True(t, Enabled(DataServiceGrpc, enumor.Aws, ""))
+	assert.False(t, Enabled(DataServiceGrpc, enumor.TCloud, ""), "configured flag overrides the default")
+	assert.False(t, Enabled("unknown", enumor.TCloud, "acc"))
+}
+
+func TestKitFeatureEnabled(t *testing.T) {
+	kit.FeatureEvaluator = nil
+	kt := kit.New()
+	assert.False(t, kt.FeatureEnabled(DataServiceGrpc, enumor.TCloud, ""), "all features are disabled without evaluator")
+
+	Init(func() cc.FeatureFlags {
+		return cc.FeatureFlags{"by_account": {AccountIDs: []string{"acc"}}}
+	})
+	assert.True(t, kt.FeatureEnabled("by_account", enumor.TCloud, "acc"))
+	assert.False(t, kt.FeatureEnabled("by_account", enumor.TCloud, "other"))
+}
@@ -25,7 +25,9 @@ import (
 	// import pprof.
 	_ "net/http/pprof"
 
+	"hcm/pkg/cc"
 	"hcm/pkg/metrics"
+	"hcm/pkg/rest"
 	"hcm/pkg/runtime/ctl"
 )
 
@@ -40,5 +42,8 @@ func SetCommonHandler(mux *http.ServeMux) http.Handler {
 	// add tools handler
 	mux.HandleFunc("/ctl", ctl.Handler().ServeHTTP)
 
+	// add openapi document handler
+	mux.Handle("/openapi.json", rest.OpenAPIHandler(string(cc.ServiceName())))
+
 	return mux
 }
@@ -0,0 +1,208 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package health provides the liveness and readiness probe handlers, each dependency of the service is checked
+// concurrently and its status is returned in the response, so that kubernetes probes and operators can see which
+// dependency is unavailable.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/shutdown"
+	"hcm/pkg/serviced"
+)
+
+// checkTimeout is the timeout of each dependency check.
+const checkTimeout = 5 * time.Second
+
+// Status is the status of a dependency.
+type Status string
+
+const (
+	// Up the dependency is available.
+	Up Status = "up"
+	// Down the dependency is unavailable.
+	Down Status = "down"
+)
+
+// Checker checks the availability of a dependency.
+type Checker struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// DependencyStatus is the check result of a dependency.
+type DependencyStatus struct {
+	Name      string `json:"name"`
+	Status    Status `json:"status"`
+	Message   string `json:"message,omitempty"`
+	LatencyMS int64  `json:"latency_ms"`
+}
+
+// Report is the check result of all the dependencies, status is up only when all the dependencies are up.
+type Report struct {
+	Status       Status             `json:"status"`
+	Dependencies []DependencyStatus `json:"dependencies"`
+}
+
+// Check checks all the dependencies concurrently.
+func Check(ctx context.Context, checkers ...Checker) *Report {
+	report := &Report{Status: Up, Dependencies: make([]DependencyStatus, len(checkers))}
+
+	wg := sync.WaitGroup{}
+	for idx := range checkers {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+
+			checker := checkers[idx]
+			checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := checker.Check(checkCtx)
+			status := DependencyStatus{Name: checker.Name, Status: Up, LatencyMS: time.Since(start).Milliseconds()}
+			if err != nil {
+				status.Status = Down
+				status.Message = err.Error()
+			}
+			report.Dependencies[idx] = status
+		}(idx)
+	}
+	wg.Wait()
+
+	for _, one := range report.Dependencies {
+		if one.Status == Down {
+			report.Status = Down
+			break
+		}
+	}
+
+	return report
+}
+
+// ServeLiveness checks the dependencies and writes the report for liveness probe. the http status code is 503 only
+// when the service is shutting down, an unavailable dependency makes the instance unready instead of restarting it.
+func ServeLiveness(w http.ResponseWriter, r *http.Request, checkers ...Checker) {
+	serve(w, r, http.StatusOK, checkers...)
+}
+
+// ServeReadiness checks the dependencies and writes the report for readiness probe, the http status code is 503 if
+// any dependency is down or the service is shutting down.
+func ServeReadiness(w http.ResponseWriter, r *http.Request, checkers ...Checker) {
+	serve(w, r, http.StatusServiceUnavailable, checkers...)
+}
+
+func serve(w http.ResponseWriter, r *http.Request, downStatusCode int, checkers ...Checker) {
+	if shutdown.IsShuttingDown() {
+		logs.Errorf("service health check failed, current service is shutting down")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		rest.WriteResp(w, &rest.Response{
+			Code:    errf.UnHealthy,
+			Message: "current service is shutting down",
+			Data:    &Report{Status: Down, Dependencies: make([]DependencyStatus, 0)},
+		})
+		return
+	}
+
+	report := Check(r.Context(), checkers...)
+	if report.Status == Up {
+		rest.WriteResp(w, &rest.Response{Code: errf.OK, Message: "healthy", Data: report})
+		return
+	}
+
+	logs.Errorf("service health check failed, dependencies: %+v", report.Dependencies)
+	w.WriteHeader(downStatusCode)
+	rest.WriteResp(w, &rest.Response{Code: errf.UnHealthy, Message: "unhealthy", Data: report})
+}
+
+// Etcd checks the etcd cluster status.
+func Etcd(config cc.Service) Checker {
+	return Checker{
+		Name: "etcd",
+		Check: func(ctx context.Context) error {
+			return serviced.Healthz(ctx, config)
+		},
+	}
+}
+
+// Registrar is the service instance registered in etcd.
+type Registrar interface {
+	CheckRegistered(ctx context.Context) error
+}
+
+// Registration checks if the service instance registration still exists in etcd.
+func Registration(registrar Registrar) Checker {
+	return Checker{
+		Name: "registration",
+		Check: func(ctx context.Context) error {
+			if registrar == nil {
+				return errors.New("service is not initialized")
+			}
+			return registrar.CheckRegistered(ctx)
+		},
+	}
+}
+
+// Discovery checks if there is any available instance of the dependent service.
+func Discovery(dis serviced.Discover, name cc.Name) Checker {
+	return Checker{
+		Name: string(name),
+		Check: func(_ context.Context) error {
+			servers, err := dis.Discover(name)
+			if err != nil {
+				return err
+			}
+
+			if len(servers) == 0 {
+				return fmt.Errorf("no available %s instance", name)
+			}
+			return nil
+		},
+	}
+}
+
+// Readiness returns the common readiness checkers of the service: etcd status, registration of current instance
+// if the service registers itself, and the available instances of the dependent services.
+func Readiness(config cc.Service, dis serviced.Discover) []Checker {
+	checkers := []Checker{Etcd(config)}
+	if dis == nil {
+		return checkers
+	}
+
+	if registrar, ok := dis.(Registrar); ok {
+		checkers = append(checkers, Registration(registrar))
+	}
+
+	for _, name := range dis.Services() {
+		checkers = append(checkers, Discovery(dis, name))
+	}
+
+	return checkers
+}
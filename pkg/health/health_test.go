@@ -0,0 +1,59 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServe(t *testing.T) {
+	up := Checker{Name: "up", Check: func(context.Context) error { return nil }}
+	down := Checker{Name: "down", Check: func(context.Context) error { return errors.New("connection refused") }}
+
+	rec := httptest.NewRecorder()
+	ServeReadiness(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil), up)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	ServeLiveness(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil), up, down)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	ServeReadiness(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil), up, down)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	resp := struct {
+		Data Report `json:"data"`
+	}{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, Down, resp.Data.Status)
+	assert.Equal(t, []DependencyStatus{
+		{Name: "up", Status: Up, LatencyMS: resp.Data.Dependencies[0].LatencyMS},
+		{Name: "down", Status: Down, Message: "connection refused", LatencyMS: resp.Data.Dependencies[1].LatencyMS},
+	}, resp.Data.Dependencies)
+}
@@ -0,0 +1,144 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package i18n is the message catalog of the user-facing messages, the message is rendered in the language of the
+// request, which is carried in the request header and passed through to the downstream services by kit.
+package i18n
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"hcm/pkg/criteria/constant"
+)
+
+// Default is the language used when the request does not specify a supported language.
+const Default = constant.Chinese
+
+// Messages is the translations of a message, the key is the language, the value is the fmt format of the message.
+type Messages map[constant.Language]string
+
+var catalog = struct {
+	sync.RWMutex
+	messages map[string]Messages
+}{messages: make(map[string]Messages)}
+
+// Register the translations of the message key, it's called in init, so it panics if the key is duplicated or the
+// default language translation is missing.
+func Register(key string, msgs Messages) {
+	catalog.Lock()
+	defer catalog.Unlock()
+
+	if _, exists := catalog.messages[key]; exists {
+		panic(fmt.Sprintf("i18n message %s is registered twice", key))
+	}
+
+	if _, exists := msgs[Default]; !exists {
+		panic(fmt.Sprintf("i18n message %s has no %s translation", key, Default))
+	}
+
+	catalog.messages[key] = msgs
+}
+
+// Sprintf render the message of the key in the language, the default language is used if the language is not
+// translated, and the key itself is returned if the key is not registered.
+func Sprintf(lang constant.Language, key string, args ...interface{}) string {
+	catalog.RLock()
+	msgs, exists := catalog.messages[key]
+	catalog.RUnlock()
+
+	if !exists {
+		if len(args) == 0 {
+			return key
+		}
+		return fmt.Sprintf("%s: %v", key, args)
+	}
+
+	format, exists := msgs[lang]
+	if !exists {
+		format = msgs[Default]
+	}
+
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Error is an error whose message is rendered from the catalog in the language of the request when it's responded.
+type Error struct {
+	Key  string
+	Args []interface{}
+}
+
+// Errorf create an error with the message key and the args of the message format.
+func Errorf(key string, args ...interface{}) error {
+	return &Error{Key: key, Args: args}
+}
+
+// Error implements error, the message is rendered in the default language.
+func (e *Error) Error() string {
+	return e.Localize(Default)
+}
+
+// Localize render the message of the error in the language.
+func (e *Error) Localize(lang constant.Language) string {
+	return Sprintf(lang, e.Key, e.Args...)
+}
+
+// Parse returns the supported language of the value, such as "zh-CN", "zh_cn", "en-US", returns empty if the
+// language is not supported.
+func Parse(value string) constant.Language {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch {
+	case strings.HasPrefix(value, "zh"):
+		return constant.Chinese
+	case strings.HasPrefix(value, "en"):
+		return constant.English
+	default:
+		return ""
+	}
+}
+
+// bkLanguageHeader is the language header set by the blueking api gateway.
+const bkLanguageHeader = "Blueking-Language"
+
+// FromHeader returns the language of the request, the hcm language header passed through by the upstream service
+// takes precedence, then the blueking language header and the Accept-Language header of the browser.
+func FromHeader(header http.Header) constant.Language {
+	for _, key := range []string{constant.LanguageKey, bkLanguageHeader} {
+		if lang := Parse(header.Get(key)); len(lang) != 0 {
+			return lang
+		}
+	}
+
+	// the languages in Accept-Language are in the order of preference in practice, so the q value is ignored.
+	for _, value := range strings.Split(header.Get("Accept-Language"), ",") {
+		if idx := strings.Index(value, ";"); idx >= 0 {
+			value = value[:idx]
+		}
+		if lang := Parse(value); len(lang) != 0 {
+			return lang
+		}
+	}
+
+	return Default
+}
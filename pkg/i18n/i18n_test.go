@@ -0,0 +1,76 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package i18n
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"hcm/pkg/criteria/constant"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromHeader(t *testing.T) {
+	cases := []struct {
+		header http.Header
+		expect constant.Language
+	}{
+		{header: http.Header{}, expect: Default},
+		{header: http.Header{"Accept-Language": []string{"en-US,en;q=0.9,zh-CN;q=0.8"}}, expect: constant.English},
+		{header: http.Header{"Accept-Language": []string{"fr-FR, zh-CN;q=0.8"}}, expect: constant.Chinese},
+		{header: http.Header{"Accept-Language": []string{"fr-FR"}}, expect: Default},
+		{header: http.Header{"Blueking-Language": []string{"en"}, "Accept-Language": []string{"zh-CN"}},
+			expect: constant.English},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expect, FromHeader(c.header), c.header)
+	}
+
+	// the language passed through by the upstream service takes precedence.
+	header := http.Header{"Accept-Language": []string{"zh-CN"}}
+	header.Set(constant.LanguageKey, "en")
+	assert.Equal(t, constant.English, FromHeader(header))
+}
+
+func TestSprintf(t *testing.T) {
+	assert.Equal(t, "名称长度不能超过 60 个字符", Sprintf(constant.Chinese, NameTooLong, 60))
+	assert.Equal(t, "invalid name, length should <= 60", Sprintf(constant.English, NameTooLong, 60))
+	// the default language is used if the language is not supported.
+	assert.Equal(t, Sprintf(Default, NameEmpty), Sprintf("fr", NameEmpty))
+	assert.Equal(t, "not.registered: [1]", Sprintf(constant.English, "not.registered", 1))
+
+	err := Errorf(MemoTooLong, 255)
+	assert.Equal(t, Sprintf(Default, MemoTooLong, 255), err.Error())
+	assert.Equal(t, "invalid memo, length should <= 255", err.(*Error).Localize(constant.English))
+}
+
+func TestMessages(t *testing.T) {
+	verb := regexp.MustCompile(`%[a-z]`)
+	for key, msgs := range messages {
+		zh, en := msgs[constant.Chinese], msgs[constant.English]
+		if !assert.NotEmpty(t, en, "message %s has no english translation", key) {
+			continue
+		}
+		// the translations share the same args, so the verbs must be the same.
+		assert.Equal(t, verb.FindAllString(zh, -1), verb.FindAllString(en, -1), "message %s verbs mismatch", key)
+	}
+}
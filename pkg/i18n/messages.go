@@ -0,0 +1,150 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package i18n
+
+import "hcm/pkg/criteria/constant"
+
+// the keys of the messages, the key is named as "module.message".
+const (
+	// NameEmpty resource name is empty.
+	NameEmpty = "validator.name_empty"
+	// NameTooLong resource name exceeds the max length, args: max length.
+	NameTooLong = "validator.name_too_long"
+	// NameInvalid resource name contains invalid characters, args: name.
+	NameInvalid = "validator.name_invalid"
+	// NameLengthOutOfRange resource name length is out of range, args: min length, max length.
+	NameLengthOutOfRange = "validator.name_length_out_of_range"
+	// GcpNameInvalid gcp resource name contains invalid characters, args: name.
+	GcpNameInvalid = "validator.gcp_name_invalid"
+	// AzureCvmNameInvalid azure cvm name contains invalid characters, args: name.
+	AzureCvmNameInvalid = "validator.azure_cvm_name_invalid"
+	// MemoRequired memo is required.
+	MemoRequired = "validator.memo_required"
+	// MemoTooLong memo exceeds the max length, args: max length.
+	MemoTooLong = "validator.memo_too_long"
+	// MemoInvalid memo contains invalid characters.
+	MemoInvalid = "validator.memo_invalid"
+	// SecurityGroupNamePrefix security group name starts with the reserved prefix.
+	SecurityGroupNamePrefix = "validator.security_group_name_prefix"
+	// SecurityGroupNameInvalid security group name contains invalid characters, args: name.
+	SecurityGroupNameInvalid = "validator.security_group_name_invalid"
+
+	// AsyncJobNotFound async job is not found, args: job kind, job id.
+	AsyncJobNotFound = "task_center.job_not_found"
+	// AsyncJobKindUnsupported async job kind is not supported, args: job kind.
+	AsyncJobKindUnsupported = "task_center.job_kind_unsupported"
+	// AccountSyncingNoRetry account is syncing and can not be retried.
+	AccountSyncingNoRetry = "task_center.account_syncing_no_retry"
+	// FlowRetryStateInvalid only the failed flow can be retried, args: flow state.
+	FlowRetryStateInvalid = "task_center.flow_retry_state_invalid"
+	// FlowNoFailedTask flow has no failed task to retry, args: flow id.
+	FlowNoFailedTask = "task_center.flow_no_failed_task"
+	// FlowCancelStateInvalid flow in the state can not be canceled, args: flow state.
+	FlowCancelStateInvalid = "task_center.flow_cancel_state_invalid"
+	// AsyncJobNotCancelable the kind of async job can not be canceled, args: job kind.
+	AsyncJobNotCancelable = "task_center.job_not_cancelable"
+)
+
+func init() {
+	for key, msgs := range messages {
+		Register(key, msgs)
+	}
+}
+
+var messages = map[string]Messages{
+	NameEmpty: {
+		constant.Chinese: "名称不能为空",
+		constant.English: "invalid name, length should >= 1",
+	},
+	NameTooLong: {
+		constant.Chinese: "名称长度不能超过 %d 个字符",
+		constant.English: "invalid name, length should <= %d",
+	},
+	NameInvalid: {
+		constant.Chinese: "名称 %s 不合法，只允许包含中文、英文、数字、下划线(_)、连字符(-)，且必须以中文、英文、数字开头和结尾",
+		constant.English: "invalid name: %s, only allows to include chinese, english, numbers, underscore (_), " +
+			"hyphen (-), and must start and end with an chinese, english, numbers",
+	},
+	NameLengthOutOfRange: {
+		constant.Chinese: "名称长度必须为 %d-%d 个字符",
+		constant.English: "invalid name, length should be %d-%d",
+	},
+	GcpNameInvalid: {
+		constant.Chinese: "名称 %s 不合法，只允许包含小写英文、数字、连字符(-)，且必须以小写英文、数字开头和结尾",
+		constant.English: "invalid name: %s, only allows include lowercase english, numbers, hyphen (-), and must " +
+			"start and end with an english, numbers",
+	},
+	AzureCvmNameInvalid: {
+		constant.Chinese: "名称 %s 不合法，只允许包含英文、数字、连字符(-)、点(.)，且必须以英文、数字开头和结尾",
+		constant.English: "invalid name: %s, only allows include english, numbers, hyphen (-), point (.), and must " +
+			"start and end with an english, numbers",
+	},
+	MemoRequired: {
+		constant.Chinese: "备注不能为空",
+		constant.English: "memo is required, can not be empty",
+	},
+	MemoTooLong: {
+		constant.Chinese: "备注长度不能超过 %d 个字符",
+		constant.English: "invalid memo, length should <= %d",
+	},
+	MemoInvalid: {
+		constant.Chinese: "备注不合法，只允许包含中文、英文、数字、下划线(_)、连字符(-)、空格，且必须以中文、英文、数字开头和结尾",
+		constant.English: "invalid memo, only allows include chinese, english, numbers, underscore (_), hyphen (-), " +
+			"space, and must start and end with an chinese, english, numbers",
+	},
+	SecurityGroupNamePrefix: {
+		constant.Chinese: "安全组名称不能以 sg- 开头",
+		constant.English: "name can not start with 'sg-'",
+	},
+	SecurityGroupNameInvalid: {
+		constant.Chinese: "名称 %s 不合法，只允许包含小写英文、数字、连字符(-)，且必须以小写英文开头和结尾",
+		constant.English: "invalid name: %s, only allows to include low english, numbers, hyphen (-), and must " +
+			"start and end with an low english",
+	},
+
+	AsyncJobNotFound: {
+		constant.Chinese: "%s 任务 %s 不存在",
+		constant.English: "%s job %s not found",
+	},
+	AsyncJobKindUnsupported: {
+		constant.Chinese: "不支持的任务类型：%s",
+		constant.English: "unsupported async job kind: %s",
+	},
+	AccountSyncingNoRetry: {
+		constant.Chinese: "账号正在同步中，不能重试",
+		constant.English: "account is syncing, can not retry",
+	},
+	FlowRetryStateInvalid: {
+		constant.Chinese: "任务状态为 %s，只有失败的任务可以重试",
+		constant.English: "flow state is %s, only failed flow can be retried",
+	},
+	FlowNoFailedTask: {
+		constant.Chinese: "任务 %s 没有可以重试的失败子任务",
+		constant.English: "flow %s has no failed task to retry",
+	},
+	FlowCancelStateInvalid: {
+		constant.Chinese: "任务状态为 %s，不能终止",
+		constant.English: "flow state is %s, can not be canceled",
+	},
+	AsyncJobNotCancelable: {
+		constant.Chinese: "%s 任务不能终止",
+		constant.English: "%s job can not be canceled",
+	},
+}
@@ -110,4 +110,43 @@ const (
 
 	// TaskManagement defines task management's hcm auth resource type
 	TaskManagement ResourceType = "task_management"
+
+	// CvmTemplate 主机模板
+	CvmTemplate ResourceType = "cvm_template"
+
+	// BizQuota 业务资源配额
+	BizQuota ResourceType = "biz_quota"
+
+	// BizResWhitelist 业务资源申请白名单
+	BizResWhitelist ResourceType = "biz_res_whitelist"
+
+	// LandingZone 网络基线部署
+	LandingZone ResourceType = "landing_zone"
+
+	// CvmSchedule 主机定时开关机策略
+	CvmSchedule ResourceType = "cvm_schedule"
+
+	// IdleResource 闲置资源
+	IdleResource ResourceType = "idle_resource"
+
+	// NamingRule 资源命名规则
+	NamingRule ResourceType = "naming_rule"
+
+	// TagPolicy 标签策略
+	TagPolicy ResourceType = "tag_policy"
+
+	// Compliance 安全合规检测结果及豁免
+	Compliance ResourceType = "compliance"
+
+	// TaskCenter 任务中心，包括异步任务流和账号资源同步任务
+	TaskCenter ResourceType = "task_center"
+
+	// AccountGroup 账号组及其成员
+	AccountGroup ResourceType = "account_group"
+
+	// Notification 通知模板及通知订阅
+	Notification ResourceType = "notification"
+
+	// Webhook webhook订阅及投递记录
+	Webhook ResourceType = "webhook"
 )
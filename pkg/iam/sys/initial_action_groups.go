@@ -97,6 +97,7 @@ func genResManagementActionGroups() []client.ActionGroup {
 						{ID: BizTaskManagementOperate},
 					},
 				},
+				genFineGrainedActionGroup(true),
 			},
 		},
 	}
@@ -193,6 +194,7 @@ func genResourceAccessActionGroups() client.ActionGroup {
 					{ID: OperationRecordFind},
 				},
 			},
+			genFineGrainedActionGroup(false),
 		},
 	}
 }
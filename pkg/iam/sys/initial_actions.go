@@ -96,6 +96,7 @@ func GenerateStaticActions() []client.ResourceAction {
 	resourceActionList = append(resourceActionList, genCloudSelectionActions()...)
 	resourceActionList = append(resourceActionList, genPlatformManageActions()...)
 	resourceActionList = append(resourceActionList, genAccountManageActions()...)
+	resourceActionList = append(resourceActionList, genFineGrainedActions()...)
 
 	return resourceActionList
 }
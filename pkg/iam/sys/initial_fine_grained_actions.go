@@ -0,0 +1,167 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sys
+
+import (
+	"fmt"
+
+	"hcm/pkg/iam/client"
+	"hcm/pkg/iam/meta"
+)
+
+// FineGrainedResType 支持按资源类型、操作类型细粒度授权的资源，新增资源类型时只需在 FineGrainedResTypes 中添加即可，
+// 对应的权限操作、操作分组及鉴权映射都会自动生成并在启动时注册到IAM
+type FineGrainedResType struct {
+	// ResType hcm鉴权资源类型
+	ResType meta.ResourceType
+	// SubResTypes 复用该资源权限的子资源类型，如安全组规则复用安全组的权限
+	SubResTypes []meta.ResourceType
+	Name        string
+	NameEn      string
+}
+
+// FineGrainedResTypes 支持细粒度授权的资源类型
+var FineGrainedResTypes = []FineGrainedResType{
+	{ResType: meta.Cvm, Name: "主机", NameEn: "CVM"},
+	{ResType: meta.Disk, Name: "硬盘", NameEn: "Disk"},
+	{ResType: meta.Vpc, Name: "VPC", NameEn: "VPC"},
+	{ResType: meta.Subnet, Name: "子网", NameEn: "Subnet"},
+	{ResType: meta.SecurityGroup, SubResTypes: []meta.ResourceType{meta.SecurityGroupRule}, Name: "安全组",
+		NameEn: "Security Group"},
+	{ResType: meta.Eip, Name: "弹性IP", NameEn: "EIP"},
+	{ResType: meta.RouteTable, SubResTypes: []meta.ResourceType{meta.Route}, Name: "路由表", NameEn: "Route Table"},
+}
+
+// fineGrainedOperation 细粒度授权的操作类型，与IaaS资源的创建、操作、删除权限一一对应
+type fineGrainedOperation struct {
+	suffix     string
+	name       string
+	nameEn     string
+	actionType client.ActionType
+	// bizAction 对应的业务下IaaS资源权限
+	bizAction client.ActionID
+	// resAction 对应的资源接入下IaaS资源权限
+	resAction client.ActionID
+}
+
+var fineGrainedOperations = []fineGrainedOperation{
+	{suffix: "create", name: "创建", nameEn: "Create", actionType: Create, bizAction: BizIaaSResCreate,
+		resAction: IaaSResCreate},
+	{suffix: "operate", name: "操作", nameEn: "Operate", actionType: Edit, bizAction: BizIaaSResOperate,
+		resAction: IaaSResOperate},
+	{suffix: "delete", name: "删除", nameEn: "Delete", actionType: Delete, bizAction: BizIaaSResDelete,
+		resAction: IaaSResDelete},
+}
+
+// fineGrainedActionMap key为资源类型及对应的IaaS资源权限，value为细粒度权限
+var fineGrainedActionMap = make(map[meta.ResourceType]map[client.ActionID]client.ActionID)
+
+func init() {
+	for _, resType := range FineGrainedResTypes {
+		actionMap := make(map[client.ActionID]client.ActionID)
+		for _, op := range fineGrainedOperations {
+			bizActionID := genFineGrainedActionID(resType.ResType, op, true)
+			resActionID := genFineGrainedActionID(resType.ResType, op, false)
+			actionMap[op.bizAction] = bizActionID
+			actionMap[op.resAction] = resActionID
+			ActionIDNameMap[bizActionID] = fmt.Sprintf("业务-%s%s", resType.Name, op.name)
+			ActionIDNameMap[resActionID] = fmt.Sprintf("资源-%s%s", resType.Name, op.name)
+		}
+
+		fineGrainedActionMap[resType.ResType] = actionMap
+		for _, subResType := range resType.SubResTypes {
+			fineGrainedActionMap[subResType] = actionMap
+		}
+	}
+}
+
+// genFineGrainedActionID 生成细粒度权限ID，业务下的权限以biz_为前缀，如 biz_cvm_create、security_group_delete
+func genFineGrainedActionID(resType meta.ResourceType, op fineGrainedOperation, isBiz bool) client.ActionID {
+	if isBiz {
+		return client.ActionID(fmt.Sprintf("biz_%s_%s", resType, op.suffix))
+	}
+	return client.ActionID(fmt.Sprintf("%s_%s", resType, op.suffix))
+}
+
+// GetFineGrainedAction 根据资源类型及其对应的IaaS资源权限获取细粒度权限，资源不支持细粒度授权时返回false
+func GetFineGrainedAction(resType meta.ResourceType, action client.ActionID) (client.ActionID, bool) {
+	actionMap, exists := fineGrainedActionMap[resType]
+	if !exists {
+		return "", false
+	}
+
+	fineGrainedAction, exists := actionMap[action]
+	return fineGrainedAction, exists
+}
+
+func genFineGrainedActions() []client.ResourceAction {
+	actions := make([]client.ResourceAction, 0)
+	for _, resType := range FineGrainedResTypes {
+		for _, op := range fineGrainedOperations {
+			bizActionID := genFineGrainedActionID(resType.ResType, op, true)
+			actions = append(actions, client.ResourceAction{
+				ID:                   bizActionID,
+				Name:                 ActionIDNameMap[bizActionID],
+				NameEn:               fmt.Sprintf("%s Biz %s", op.nameEn, resType.NameEn),
+				Type:                 op.actionType,
+				RelatedResourceTypes: bizResource,
+				RelatedActions:       []client.ActionID{BizAccess},
+				Version:              1,
+			})
+
+			resActionID := genFineGrainedActionID(resType.ResType, op, false)
+			actions = append(actions, client.ResourceAction{
+				ID:                   resActionID,
+				Name:                 ActionIDNameMap[resActionID],
+				NameEn:               fmt.Sprintf("%s %s", op.nameEn, resType.NameEn),
+				Type:                 op.actionType,
+				RelatedResourceTypes: accountResource,
+				RelatedActions:       []client.ActionID{ResourceFind},
+				Version:              1,
+			})
+		}
+	}
+
+	return actions
+}
+
+// genFineGrainedActionGroup 生成细粒度权限的操作分组，isBiz为true时生成业务下的操作分组
+func genFineGrainedActionGroup(isBiz bool) client.ActionGroup {
+	group := client.ActionGroup{
+		Name:      "IaaS资源细粒度权限",
+		NameEn:    "IaaS Resource Fine-grained Permission",
+		SubGroups: make([]client.ActionGroup, 0, len(FineGrainedResTypes)),
+	}
+
+	for _, resType := range FineGrainedResTypes {
+		actions := make([]client.ActionWithID, 0, len(fineGrainedOperations))
+		for _, op := range fineGrainedOperations {
+			actions = append(actions, client.ActionWithID{ID: genFineGrainedActionID(resType.ResType, op, isBiz)})
+		}
+
+		group.SubGroups = append(group.SubGroups, client.ActionGroup{
+			Name:    resType.Name,
+			NameEn:  resType.NameEn,
+			Actions: actions,
+		})
+	}
+
+	return group
+}
@@ -0,0 +1,87 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sys
+
+import (
+	"testing"
+
+	"hcm/pkg/iam/client"
+	"hcm/pkg/iam/meta"
+)
+
+func TestGetFineGrainedAction(t *testing.T) {
+	cases := []struct {
+		resType meta.ResourceType
+		action  client.ActionID
+		expect  client.ActionID
+		exists  bool
+	}{
+		{resType: meta.Cvm, action: BizIaaSResCreate, expect: "biz_cvm_create", exists: true},
+		{resType: meta.Disk, action: IaaSResDelete, expect: "disk_delete", exists: true},
+		// 子资源复用父资源的细粒度权限
+		{resType: meta.SecurityGroupRule, action: BizIaaSResOperate, expect: "biz_security_group_operate",
+			exists: true},
+		{resType: meta.Cvm, action: BizAccess},
+		{resType: meta.LoadBalancer, action: BizIaaSResCreate},
+	}
+
+	for _, c := range cases {
+		action, exists := GetFineGrainedAction(c.resType, c.action)
+		if action != c.expect || exists != c.exists {
+			t.Errorf("%s %s expect fine-grained action %s(%v), but got %s(%v)", c.resType, c.action, c.expect,
+				c.exists, action, exists)
+		}
+	}
+}
+
+func TestFineGrainedActionsRegistered(t *testing.T) {
+	actionMap := make(map[client.ActionID]struct{})
+	for _, action := range GenerateStaticActions() {
+		if _, exists := actionMap[action.ID]; exists {
+			t.Errorf("action %s is registered more than once", action.ID)
+		}
+		actionMap[action.ID] = struct{}{}
+	}
+
+	// 操作分组中的权限都需要注册到IAM
+	var check func(groups []client.ActionGroup)
+	check = func(groups []client.ActionGroup) {
+		for _, group := range groups {
+			for _, action := range group.Actions {
+				if _, exists := actionMap[action.ID]; !exists {
+					t.Errorf("action %s of group %s is not registered", action.ID, group.Name)
+				}
+			}
+			check(group.SubGroups)
+		}
+	}
+	check(GenerateStaticActionGroups())
+
+	for _, resType := range FineGrainedResTypes {
+		for _, op := range fineGrainedOperations {
+			for _, isBiz := range []bool{true, false} {
+				id := genFineGrainedActionID(resType.ResType, op, isBiz)
+				if _, exists := actionMap[id]; !exists || len(ActionIDNameMap[id]) == 0 {
+					t.Errorf("fine-grained action %s should be registered with name", id)
+				}
+			}
+		}
+	}
+}
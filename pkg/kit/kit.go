@@ -24,15 +24,38 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/i18n"
 	"hcm/pkg/tools/converter"
 	"hcm/pkg/tools/rand"
 	"hcm/pkg/tools/uuid"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// ctxKey 是kit写入context的键类型，不导出以避免其它包或与之同名的请求头字符串键读写到相同的值
+type ctxKey int
+
+const (
+	// readPrimaryCtxKey 标记db读请求强制路由到主库
+	readPrimaryCtxKey ctxKey = iota
 )
 
+// IsReadPrimary 返回context是否设置了db读请求强制路由到主库
+func IsReadPrimary(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+
+	readPrimary, ok := ctx.Value(readPrimaryCtxKey).(bool)
+	return ok && readPrimary
+}
+
 // New initial a kit with rid and context.
 func New() *Kit {
 	rid := uuid.UUID()
@@ -64,6 +87,16 @@ type Kit struct {
 	// 因为来自前端和第三方系统调用的请求均为 ApiCall，所以没必要将该字段暴漏出去，仅同步请求需要设
 	// 置该字段为 BackgroundSync。
 	RequestSource enumor.RequestSourceType
+
+	// ReadPrimary 为true时，db读请求强制路由到主库，用于对数据一致性要求高的场景，如同步删除前的校验。
+	ReadPrimary bool
+
+	// SQLTrace 为true时，记录该请求执行的所有sql语句及其参数、耗时，日志中带有rid，用于排查单个请求的问题。
+	SQLTrace bool
+	// Language 请求的语言，用于选择面向用户的错误信息、通知模板等的语言，通过请求头传递给下游服务
+	Language constant.Language
+	// BizID 请求所属的业务ID，业务下的请求由请求路径解析得到，通过请求头传递给下游服务，用于给创建的云上资源添加业务标签
+	BizID string
 }
 
 // NewSubKit 在当前kit后缀加上6位随机字符串
@@ -98,6 +131,64 @@ func (kt *Kit) WithAsyncSource() *Kit {
 	return newKit
 }
 
+// WithTenant 生成子kit 设置对应的租户ID，用于后台任务按租户处理数据
+func (kt *Kit) WithTenant(tenantID string) *Kit {
+	newKit := kt.NewSubKitWithSuffix(tenantID)
+	newKit.TenantID = tenantID
+	newKit.Ctx = context.WithValue(newKit.Ctx, constant.TenantIDKey, tenantID)
+	return newKit
+}
+
+// WithReadPrimary 生成子kit 设置db读请求强制路由到主库
+func (kt *Kit) WithReadPrimary() *Kit {
+	newKit := converter.ValToPtr(*kt)
+	newKit.ReadPrimary = true
+	newKit.Ctx = context.WithValue(kt.Ctx, readPrimaryCtxKey, true)
+	return newKit
+}
+
+// WithSQLTrace 生成子kit 设置记录该请求执行的所有sql语句
+func (kt *Kit) WithSQLTrace() *Kit {
+	newKit := converter.ValToPtr(*kt)
+	newKit.SQLTrace = true
+	newKit.Ctx = context.WithValue(kt.Ctx, constant.SQLTraceKey, true)
+	return newKit
+}
+
+// WithTimeout 生成子kit 设置请求的超时时间，用于限制调用云上接口等可能长时间无响应的操作，父context的截止时间更早时以父context为准，
+// 超时时间不大于0时不设置超时
+func (kt *Kit) WithTimeout(timeout time.Duration) (*Kit, context.CancelFunc) {
+	newKit := converter.ValToPtr(*kt)
+	if timeout <= 0 {
+		return newKit, func() {}
+	}
+
+	var cancel context.CancelFunc
+	newKit.Ctx, cancel = context.WithTimeout(kt.Ctx, timeout)
+	return newKit, cancel
+}
+
+// FeatureEvaluator 判断特性在指定云厂商、账号下是否开启，由 featureflag 包初始化时设置，未设置时所有特性均关闭
+var FeatureEvaluator func(kt *Kit, name string, vendor enumor.Vendor, accountID string) bool
+
+// FeatureEnabled 判断特性在指定云厂商、账号下是否开启，不区分云厂商或账号时传空值
+func (kt *Kit) FeatureEnabled(name string, vendor enumor.Vendor, accountID string) bool {
+	if FeatureEvaluator == nil {
+		return false
+	}
+
+	return FeatureEvaluator(kt, name, vendor, accountID)
+}
+
+// GetTenantID TenantID为空，返回默认租户ID。
+func (kt *Kit) GetTenantID() string {
+	if len(kt.TenantID) == 0 {
+		return constant.DefaultTenantID
+	}
+
+	return kt.TenantID
+}
+
 // GetRequestSource RequestSource为空，返回 ApiCall 类型。
 func (kt *Kit) GetRequestSource() enumor.RequestSourceType {
 	if len(kt.RequestSource) == 0 {
@@ -150,20 +241,57 @@ func (kt *Kit) Validate() error {
 		return errors.New("app code is required")
 	}
 
-	// TODO add tenant id validation
+	if len(kt.TenantID) != 0 {
+		if err := ValidateTenantID(kt.TenantID); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
-// Header generate header by kit
+// Header generate header by kit, the trace context in kit context is injected as well.
 func (kt *Kit) Header() http.Header {
-	return http.Header{
+	header := http.Header{
 		constant.UserKey:          []string{kt.User},
 		constant.RidKey:           []string{kt.Rid},
 		constant.AppCodeKey:       []string{kt.AppCode},
 		constant.TenantIDKey:      []string{kt.TenantID},
 		constant.RequestSourceKey: []string{string(kt.RequestSource)},
+		constant.ReadPrimaryKey:   []string{strconv.FormatBool(kt.ReadPrimary)},
+		constant.SQLTraceKey:      []string{strconv.FormatBool(kt.SQLTrace)},
 	}
+	if len(kt.Language) != 0 {
+		header.Set(constant.LanguageKey, string(kt.Language))
+	}
+	if len(kt.BizID) != 0 {
+		header.Set(constant.BizIDKey, kt.BizID)
+	}
+
+	if kt.Ctx != nil {
+		if deadline, ok := kt.Ctx.Deadline(); ok {
+			// 传递剩余的超时时间，下游服务超过该时间后停止处理，避免调用方已放弃的请求继续占用下游的处理协程
+			remain := time.Until(deadline).Milliseconds()
+			if remain <= 0 {
+				remain = 1
+			}
+			header.Set(constant.TimeoutKey, strconv.FormatInt(remain, 10))
+		}
+
+		otel.GetTextMapPropagator().Inject(kt.Ctx, propagation.HeaderCarrier(header))
+	}
+
+	return header
+}
+
+// TimeoutFromHeader returns the remaining time of the caller's deadline carried in the request header.
+func TimeoutFromHeader(header http.Header) (time.Duration, bool) {
+	ms, err := strconv.ParseInt(header.Get(constant.TimeoutKey), 10, 64)
+	if err != nil || ms <= 0 {
+		return 0, false
+	}
+
+	return time.Duration(ms) * time.Millisecond, true
 }
 
 // FromHeader http request header to context kit and validate.
@@ -172,6 +300,9 @@ func FromHeader(ctx context.Context, header http.Header) (*Kit, error) {
 		ctx = context.Background()
 	}
 
+	// 从请求头中提取上游的链路追踪上下文，使当前服务的span与上游关联
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+
 	kt := &Kit{
 		Ctx:           ctx,
 		User:          header.Get(constant.UserKey),
@@ -179,12 +310,29 @@ func FromHeader(ctx context.Context, header http.Header) (*Kit, error) {
 		AppCode:       header.Get(constant.AppCodeKey),
 		TenantID:      header.Get(constant.TenantIDKey),
 		RequestSource: enumor.RequestSourceType(header.Get(constant.RequestSourceKey)),
+		Language:      i18n.FromHeader(header),
+		BizID:         header.Get(constant.BizIDKey),
 	}
 
 	if kt.Ctx.Value(constant.RidKey) == nil {
 		kt.Ctx = context.WithValue(kt.Ctx, constant.RidKey, kt.Rid)
 	}
 
+	if kt.Ctx.Value(constant.TenantIDKey) == nil {
+		kt.Ctx = context.WithValue(kt.Ctx, constant.TenantIDKey, kt.GetTenantID())
+	}
+
+	// 该请求头只在服务间传递，外部请求的入口(web-server、api-server)会移除或按应用权限重新设置该请求头
+	if readPrimary, err := strconv.ParseBool(header.Get(constant.ReadPrimaryKey)); err == nil && readPrimary {
+		kt.ReadPrimary = true
+		kt.Ctx = context.WithValue(kt.Ctx, readPrimaryCtxKey, true)
+	}
+
+	if sqlTrace, err := strconv.ParseBool(header.Get(constant.SQLTraceKey)); err == nil && sqlTrace {
+		kt.SQLTrace = true
+		kt.Ctx = context.WithValue(kt.Ctx, constant.SQLTraceKey, true)
+	}
+
 	if err := kt.Validate(); err != nil {
 		return nil, err
 	}
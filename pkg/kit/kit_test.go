@@ -0,0 +1,62 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package kit
+
+import (
+	"context"
+	"testing"
+
+	"hcm/pkg/criteria/constant"
+)
+
+func TestSQLTrace(t *testing.T) {
+	kt := New()
+	kt.User, kt.AppCode = "admin", "hcm"
+	sub := kt.WithSQLTrace()
+	if !sub.SQLTrace || sub.Ctx.Value(constant.SQLTraceKey) != true {
+		t.Errorf("sub kit should enable sql trace, got: %v", sub.SQLTrace)
+	}
+	if kt.SQLTrace || kt.Ctx.Value(constant.SQLTraceKey) != nil {
+		t.Errorf("parent kit should not be changed")
+	}
+
+	// the sql trace is passed to the downstream service by header.
+	header := sub.Header()
+	parsed, err := FromHeader(context.Background(), header)
+	if err != nil {
+		t.Fatalf("parse header failed, err: %v", err)
+	}
+	if !parsed.SQLTrace || parsed.Ctx.Value(constant.SQLTraceKey) != true {
+		t.Errorf("sql trace should be parsed from header")
+	}
+
+	header = kt.Header()
+	if parsed, err = FromHeader(context.Background(), header); err != nil {
+		t.Fatalf("parse header failed, err: %v", err)
+	}
+	if parsed.SQLTrace || parsed.Ctx.Value(constant.SQLTraceKey) != nil {
+		t.Errorf("sql trace should not be enabled without header")
+	}
+
+	header.Set(constant.SQLTraceKey, "invalid")
+	if parsed, err = FromHeader(context.Background(), header); err != nil || parsed.SQLTrace {
+		t.Errorf("invalid sql trace header should be ignored, err: %v", err)
+	}
+}
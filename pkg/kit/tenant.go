@@ -0,0 +1,59 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package kit
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"hcm/pkg/criteria/constant"
+)
+
+// tenantIDRe 租户ID只能包含字母、数字、下划线和中划线，且以字母或数字开头，最大长度与数据表tenant_id字段一致
+var tenantIDRe = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]{0,63}$`)
+
+// ValidateTenantID 校验租户ID的格式
+func ValidateTenantID(tenantID string) error {
+	if !tenantIDRe.MatchString(tenantID) {
+		return fmt.Errorf("tenant id %s is invalid, should only contain letters, numbers, '_' and '-', "+
+			"start with letter or number and the max length is 64", tenantID)
+	}
+
+	return nil
+}
+
+// MatchTenant 返回已认证身份所属的租户ID，身份未声明租户时属于默认租户。请求头中声明的租户ID只能为空或与之一致，
+// 避免调用方通过设置请求头操作其它租户的资源。
+func MatchTenant(claimed, authenticated string) (string, error) {
+	if len(authenticated) == 0 {
+		authenticated = constant.DefaultTenantID
+	}
+
+	if err := ValidateTenantID(authenticated); err != nil {
+		return "", err
+	}
+
+	if len(claimed) != 0 && claimed != authenticated {
+		return "", errors.New("tenant id in request header does not match the authenticated identity")
+	}
+
+	return authenticated, nil
+}
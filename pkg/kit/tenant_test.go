@@ -0,0 +1,113 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package kit
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"hcm/pkg/criteria/constant"
+)
+
+func TestValidateTenantID(t *testing.T) {
+	valid := []string{"default", "tenant-a", "Tenant_01", "0", strings.Repeat("a", 64)}
+	for _, one := range valid {
+		if err := ValidateTenantID(one); err != nil {
+			t.Errorf("tenant id %s should be valid, err: %v", one, err)
+		}
+	}
+
+	invalid := []string{"", "-tenant", "tenant:a", "tenant a", "tenant\n", "../a", strings.Repeat("a", 65)}
+	for _, one := range invalid {
+		if err := ValidateTenantID(one); err == nil {
+			t.Errorf("tenant id %q should be invalid", one)
+		}
+	}
+}
+
+func TestMatchTenant(t *testing.T) {
+	cases := []struct {
+		claimed       string
+		authenticated string
+		expected      string
+		wantErr       bool
+	}{
+		{claimed: "", authenticated: "", expected: constant.DefaultTenantID},
+		{claimed: constant.DefaultTenantID, authenticated: "", expected: constant.DefaultTenantID},
+		{claimed: "", authenticated: "tenant-a", expected: "tenant-a"},
+		{claimed: "tenant-a", authenticated: "tenant-a", expected: "tenant-a"},
+		{claimed: "tenant-b", authenticated: "tenant-a", wantErr: true},
+		{claimed: "tenant-a", authenticated: "", wantErr: true},
+		{claimed: "", authenticated: "tenant:a", wantErr: true},
+	}
+
+	for _, c := range cases {
+		tenantID, err := MatchTenant(c.claimed, c.authenticated)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("match claimed tenant %s with %s should be failed", c.claimed, c.authenticated)
+			}
+			continue
+		}
+
+		if err != nil || tenantID != c.expected {
+			t.Errorf("match claimed tenant %s with %s, got: %s, err: %v, expected: %s", c.claimed,
+				c.authenticated, tenantID, err, c.expected)
+		}
+	}
+}
+
+func TestFromHeaderValidateTenant(t *testing.T) {
+	header := http.Header{}
+	header.Set(constant.UserKey, "admin")
+	header.Set(constant.RidKey, "0123456789abcdef0123")
+	header.Set(constant.AppCodeKey, "hcm")
+
+	header.Set(constant.TenantIDKey, "tenant-a")
+	kt, err := FromHeader(context.Background(), header)
+	if err != nil {
+		t.Fatalf("parse header with valid tenant id failed, err: %v", err)
+	}
+	if kt.GetTenantID() != "tenant-a" {
+		t.Errorf("tenant id not match, got: %s", kt.GetTenantID())
+	}
+
+	header.Set(constant.TenantIDKey, "tenant:a")
+	if _, err = FromHeader(context.Background(), header); err == nil {
+		t.Errorf("parse header with invalid tenant id should be failed")
+	}
+}
+
+func TestWithTenant(t *testing.T) {
+	kt := New()
+	sub := kt.WithTenant("tenant-a")
+
+	if sub.TenantID != "tenant-a" || sub.Ctx.Value(constant.TenantIDKey) != "tenant-a" {
+		t.Errorf("sub kit should carry the tenant, got: %s, ctx: %v", sub.TenantID, sub.Ctx.Value(constant.TenantIDKey))
+	}
+	if values := sub.Header()[constant.TenantIDKey]; len(values) != 1 || values[0] != "tenant-a" {
+		t.Errorf("sub kit header should carry the tenant, got: %v", values)
+	}
+	if len(kt.TenantID) != 0 {
+		t.Errorf("parent kit should not be changed, got: %s", kt.TenantID)
+	}
+}
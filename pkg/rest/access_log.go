@@ -0,0 +1,232 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/logs"
+)
+
+const (
+	defaultSlowThreshold  = 3 * time.Second
+	defaultMaxLogBodySize = 4 << 10
+)
+
+// accessLog is the access log setting of the requests.
+var accessLog = struct {
+	enable bool
+	// samplePercent is the percent of the successful and not slow requests to log.
+	samplePercent int
+	// slowThreshold is the threshold of the slow request, the slow request is logged with the full request context.
+	slowThreshold time.Duration
+	// maxBodySize is the max size of the request body logged for the slow request.
+	maxBodySize int
+}{
+	slowThreshold: defaultSlowThreshold,
+	maxBodySize:   defaultMaxLogBodySize,
+}
+
+// SetAccessLog set the access log setting of the requests.
+func SetAccessLog(opt cc.AccessLog) {
+	accessLog.enable = opt.Enable
+	accessLog.samplePercent = int(opt.SamplePercent)
+	if opt.SlowThresholdMS != 0 {
+		accessLog.slowThreshold = time.Duration(opt.SlowThresholdMS) * time.Millisecond
+	}
+	if opt.MaxBodyKB != 0 {
+		accessLog.maxBodySize = int(opt.MaxBodyKB) << 10
+	}
+}
+
+var defaultSampleRandom = rand.Intn
+
+// sampleRandom returns a random number in [0, n) to decide whether to sample the request, it is replaced in tests.
+var sampleRandom = defaultSampleRandom
+
+// sensitiveHeaders are the headers which contain credentials, they are masked in the access log.
+var sensitiveHeaders = map[string]struct{}{
+	"Authorization": {},
+	"Cookie":        {},
+	http.CanonicalHeaderKey(constant.BKGWAuthKey):     {},
+	http.CanonicalHeaderKey(constant.BKGWJWTTokenKey): {},
+}
+
+// accessRecorder records the status code and size of the response, and captures the request body for the slow
+// request. the nil recorder means the access log is disabled, and all its methods do nothing.
+type accessRecorder struct {
+	http.ResponseWriter
+	start      time.Time
+	statusCode int
+	respSize   int64
+	reqSize    int64
+	body       bytes.Buffer
+	truncated  bool
+}
+
+// newAccessRecorder returns the access recorder of the request if access log is enabled, the response writer of the
+// request is replaced by the recorder.
+func newAccessRecorder(cts *Contexts) *accessRecorder {
+	if !accessLog.enable {
+		return nil
+	}
+
+	a := &accessRecorder{ResponseWriter: cts.resp.ResponseWriter, start: time.Now()}
+	cts.resp.ResponseWriter = a
+	return a
+}
+
+// WriteHeader writes and records the status code.
+func (a *accessRecorder) WriteHeader(statusCode int) {
+	a.statusCode = statusCode
+	a.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write writes and records the size of the response.
+func (a *accessRecorder) Write(b []byte) (int, error) {
+	n, err := a.ResponseWriter.Write(b)
+	a.respSize += int64(n)
+	return n, err
+}
+
+// Flush implements http.Flusher, so that the stream and event stream responses are still flushed.
+func (a *accessRecorder) Flush() {
+	if f, ok := a.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// captureBody captures the request body when it is read by the handler, at most maxBodySize bytes are kept.
+func (a *accessRecorder) captureBody(req *http.Request) {
+	if a == nil || req.Body == nil || req.Body == http.NoBody {
+		return
+	}
+
+	req.Body = struct {
+		io.Reader
+		io.Closer
+	}{Reader: io.TeeReader(req.Body, bodyCapture{a}), Closer: req.Body}
+}
+
+// bodyCapture writes the read request body to the recorder.
+type bodyCapture struct {
+	a *accessRecorder
+}
+
+// Write captures the body until the limit is reached.
+func (b bodyCapture) Write(p []byte) (int, error) {
+	b.a.reqSize += int64(len(p))
+	if remain := accessLog.maxBodySize - b.a.body.Len(); remain < len(p) {
+		b.a.truncated = true
+		b.a.body.Write(p[:max(remain, 0)])
+	} else {
+		b.a.body.Write(p)
+	}
+	return len(p), nil
+}
+
+// log the access log of the request, the failed and slow requests are always logged, the others are sampled.
+func (a *accessRecorder) log(cts *Contexts, alias string) {
+	if a == nil {
+		return
+	}
+
+	cost := time.Since(a.start)
+	req := cts.Request.Request
+	status := a.statusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	var code int32
+	var message string
+	if cts.respErr != nil {
+		code, message = cts.respErr.Code, cts.respErr.Message
+	}
+
+	slow := cost >= accessLog.slowThreshold
+	failed := status >= http.StatusBadRequest || code != 0
+	if !slow && !failed && sampleRandom(100) >= accessLog.samplePercent {
+		return
+	}
+
+	rid, user, appCode := req.Header.Get(constant.RidKey), req.Header.Get(constant.UserKey),
+		req.Header.Get(constant.AppCodeKey)
+	if cts.Kit != nil {
+		rid, user, appCode = cts.Kit.Rid, cts.Kit.User, cts.Kit.AppCode
+	}
+
+	reqSize := req.ContentLength
+	if reqSize < 0 {
+		reqSize = a.reqSize
+	}
+
+	if !slow {
+		logs.Infof("access log, alias: %s, method: %s, path: %s, status: %d, code: %d, duration: %dms, user: %s, "+
+			"app: %s, req_size: %d, resp_size: %d, rid: %s", alias, req.Method, req.URL.Path, status, code,
+			cost.Milliseconds(), user, appCode, reqSize, a.respSize, rid)
+		return
+	}
+
+	logs.Warnf("slow request, alias: %s, method: %s, path: %s, status: %d, code: %d, message: %s, duration: %dms, "+
+		"user: %s, app: %s, req_size: %d, resp_size: %d, remote: %s, query: %s, header: %s, body: %s, rid: %s",
+		alias, req.Method, req.URL.Path, status, code, message, cost.Milliseconds(), user, appCode, reqSize,
+		a.respSize, req.RemoteAddr, req.URL.RawQuery, maskHeader(req.Header), a.loggedBody(), rid)
+}
+
+// loggedBody returns the captured request body, the json body is compacted.
+func (a *accessRecorder) loggedBody() string {
+	body := a.body.String()
+	compact := new(bytes.Buffer)
+	if !a.truncated && json.Compact(compact, a.body.Bytes()) == nil {
+		body = compact.String()
+	}
+
+	if a.truncated {
+		body += "...(truncated)"
+	}
+	return body
+}
+
+// maskHeader returns the json of the request header, the values of the sensitive headers are masked.
+func maskHeader(header http.Header) string {
+	masked := make(map[string]string, len(header))
+	for key, values := range header {
+		if _, ok := sensitiveHeaders[http.CanonicalHeaderKey(key)]; ok {
+			masked[key] = "***"
+			continue
+		}
+		masked[key] = strings.Join(values, ",")
+	}
+
+	byt, err := json.Marshal(masked)
+	if err != nil {
+		return ""
+	}
+	return string(byt)
+}
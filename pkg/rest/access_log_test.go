@@ -0,0 +1,127 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessRecorder(t *testing.T) {
+	defer SetAccessLog(cc.AccessLog{Enable: false, SlowThresholdMS: 3000, MaxBodyKB: 4})
+
+	w := httptest.NewRecorder()
+	cts := &Contexts{resp: restful.NewResponse(w)}
+	SetAccessLog(cc.AccessLog{Enable: false})
+	assert.Nil(t, newAccessRecorder(cts), "access log is disabled")
+
+	SetAccessLog(cc.AccessLog{Enable: true, MaxBodyKB: 1})
+	access := newAccessRecorder(cts)
+	require.NotNil(t, access)
+
+	body := `{"name": "` + strings.Repeat("a", 2000) + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/cvms/create", strings.NewReader(body))
+	access.captureBody(req)
+	read, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(read), "the body read by handler is not changed")
+	assert.Equal(t, int64(len(body)), access.reqSize)
+	assert.True(t, access.truncated)
+	assert.Equal(t, body[:1<<10]+"...(truncated)", access.loggedBody())
+
+	cts.resp.WriteHeader(http.StatusConflict)
+	_, err = cts.resp.ResponseWriter.Write([]byte("conflict"))
+	require.NoError(t, err)
+	_, ok := cts.resp.ResponseWriter.(http.Flusher)
+	assert.True(t, ok, "the stream response can still be flushed")
+	assert.Equal(t, http.StatusConflict, access.statusCode)
+	assert.Equal(t, int64(len("conflict")), access.respSize)
+	assert.Equal(t, "conflict", w.Body.String())
+
+	small := &accessRecorder{}
+	_, _ = bodyCapture{small}.Write([]byte("{\n  \"id\": 1\n}"))
+	assert.Equal(t, `{"id":1}`, small.loggedBody())
+}
+
+func TestMaskHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set(constant.BKGWAuthKey, `{"bk_app_code": "hcm", "bk_app_secret": "secret"}`)
+	header.Set("Cookie", "bk_token=token")
+	header.Set(constant.UserKey, "admin")
+
+	masked := maskHeader(header)
+	assert.NotContains(t, masked, "secret")
+	assert.NotContains(t, masked, "token=")
+	assert.Contains(t, masked, `"X-Bkapi-User-Name":"admin"`)
+}
+
+func TestAccessLogHandler(t *testing.T) {
+	SetAccessLog(cc.AccessLog{Enable: true, SamplePercent: 0})
+	defer SetAccessLog(cc.AccessLog{Enable: false})
+
+	sampled := 0
+	sampleRandom = func(n int) int {
+		sampled++
+		return 0
+	}
+	defer func() { sampleRandom = defaultSampleRandom }()
+
+	h := NewHandler()
+	h.Add("CreateCvm", http.MethodPost, "/cvms/create", func(cts *Contexts) (interface{}, error) {
+		var req map[string]interface{}
+		if err := cts.DecodeInto(&req); err != nil {
+			return nil, err
+		}
+		return req, nil
+	})
+	ws := new(restful.WebService)
+	h.Load(ws)
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	do := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/cvms/create", strings.NewReader(body))
+		req.Header.Set(constant.RidKey, "access-log-test-rid")
+		req.Header.Set(constant.UserKey, "admin")
+		req.Header.Set(constant.AppCodeKey, "hcm")
+		w := httptest.NewRecorder()
+		container.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(`{"name": "cvm"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"cvm"`)
+	assert.Equal(t, 1, sampled, "successful request is sampled")
+
+	w = do(`{"name":`)
+	assert.Contains(t, w.Body.String(), `"code":`)
+	assert.Equal(t, 1, sampled, "failed request is always logged")
+}
@@ -0,0 +1,136 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/uuid"
+)
+
+// gatewayAuthorization is the authorization header value of blueking esb and api gateway requests.
+type gatewayAuthorization struct {
+	AppCode   string `json:"bk_app_code"`
+	AppSecret string `json:"bk_app_secret"`
+}
+
+// AppAuthenticator authenticate the third-party system requests by blueking app code and app secret, and maps
+// the app to its service account, so that other systems can call hcm without impersonating human users.
+type AppAuthenticator struct {
+	apps map[string]cc.AppAccount
+}
+
+// NewAppAuthenticator new app authenticator.
+func NewAppAuthenticator(opt cc.AppAuth) *AppAuthenticator {
+	apps := make(map[string]cc.AppAccount, len(opt.Apps))
+	for _, app := range opt.Apps {
+		apps[app.AppCode] = app
+	}
+
+	return &AppAuthenticator{apps: apps}
+}
+
+// Parse the app code and app secret in authorization header to context kit, whose user is the service account of
+// the app. matched is false when the request does not carry app secret, and should be parsed by other ways.
+func (a *AppAuthenticator) Parse(ctx context.Context, header http.Header, method, path string) (kt *kit.Kit,
+	matched bool, err error) {
+
+	value := header.Get(constant.BKGWAuthKey)
+	if len(value) == 0 {
+		return nil, false, nil
+	}
+
+	auth := new(gatewayAuthorization)
+	if err = json.Unmarshal([]byte(value), auth); err != nil {
+		return nil, false, errf.New(errf.InvalidParameter, "authorization header is invalid")
+	}
+
+	if len(auth.AppCode) == 0 || len(auth.AppSecret) == 0 {
+		return nil, false, nil
+	}
+
+	app, exists := a.apps[auth.AppCode]
+	if !exists || subtle.ConstantTimeCompare([]byte(app.AppSecret), []byte(auth.AppSecret)) != 1 {
+		return nil, true, errf.Newf(errf.PermissionDenied, "app %s is not authorized", auth.AppCode)
+	}
+
+	if !isPathAllowed(app.AllowedPaths, path) {
+		return nil, true, errf.Newf(errf.PermissionDenied, "app %s is not allowed to %s %s", auth.AppCode, method,
+			path)
+	}
+
+	// 应用只能操作其服务账号所属租户的资源，请求头中的租户ID必须与之一致
+	tenantID, err := kit.MatchTenant(header.Get(constant.TenantIDKey), app.TenantID)
+	if err != nil {
+		return nil, true, errf.NewFromErr(errf.PermissionDenied, err)
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	rid := header.Get(constant.RidKey)
+	if len(rid) == 0 {
+		rid = uuid.UUID()
+	}
+
+	kt = &kit.Kit{
+		Ctx:      context.WithValue(ctx, constant.RidKey, rid),
+		User:     app.ServiceAccount,
+		Rid:      rid,
+		AppCode:  app.AppCode,
+		TenantID: tenantID,
+	}
+
+	if err = kt.Validate(); err != nil {
+		return nil, true, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 强制读主库会增加主库的压力，只有授权的应用才能通过请求头开启
+	if readPrimary, _ := strconv.ParseBool(header.Get(constant.ReadPrimaryKey)); readPrimary && app.AllowReadPrimary {
+		kt = kt.WithReadPrimary()
+	}
+
+	return kt, true, nil
+}
+
+// isPathAllowed check if the request path matches any of the allowed path prefixes, empty means no limit.
+func isPathAllowed(allowedPaths []string, path string) bool {
+	if len(allowedPaths) == 0 {
+		return true
+	}
+
+	for _, prefix := range allowedPaths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,144 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+package rest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/kit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppAuthReadPrimary(t *testing.T) {
+	auth := NewAppAuthenticator(cc.AppAuth{Enable: true, Apps: []cc.AppAccount{
+		{AppCode: "allowed", AppSecret: "secret", ServiceAccount: "svc-allowed", AllowReadPrimary: true},
+		{AppCode: "denied", AppSecret: "secret", ServiceAccount: "svc-denied"},
+	}})
+
+	parse := func(appCode string) *kit.Kit {
+		header := http.Header{}
+		header.Set(constant.BKGWAuthKey, `{"bk_app_code": "`+appCode+`", "bk_app_secret": "secret"}`)
+		header.Set(constant.ReadPrimaryKey, "true")
+		kt, matched, err := auth.Parse(context.Background(), header, http.MethodGet, "/api/v1/cloud/vpcs")
+		require.NoError(t, err)
+		require.True(t, matched)
+		return kt
+	}
+
+	// only the apps allowed to read primary can force db reads to primary by the header.
+	kt := parse("allowed")
+	assert.True(t, kt.ReadPrimary)
+	assert.True(t, kit.IsReadPrimary(kt.Ctx))
+
+	kt = parse("denied")
+	assert.False(t, kt.ReadPrimary)
+	assert.False(t, kit.IsReadPrimary(kt.Ctx))
+}
+
+func TestAppAuthTenant(t *testing.T) {
+	auth := NewAppAuthenticator(cc.AppAuth{Enable: true, Apps: []cc.AppAccount{
+		{AppCode: "tenant-app", AppSecret: "secret", ServiceAccount: "svc-tenant", TenantID: "tenant-a"},
+		{AppCode: "default-app", AppSecret: "secret", ServiceAccount: "svc-default"},
+	}})
+
+	parse := func(appCode, tenantID string) (*kit.Kit, error) {
+		header := http.Header{}
+		header.Set(constant.BKGWAuthKey, `{"bk_app_code": "`+appCode+`", "bk_app_secret": "secret"}`)
+		if len(tenantID) != 0 {
+			header.Set(constant.TenantIDKey, tenantID)
+		}
+		kt, matched, err := auth.Parse(context.Background(), header, http.MethodGet, "/api/v1/cloud/vpcs")
+		require.True(t, matched)
+		return kt, err
+	}
+
+	// the tenant of the request is the tenant of the app's service account.
+	kt, err := parse("tenant-app", "")
+	require.NoError(t, err)
+	assert.Equal(t, "tenant-a", kt.TenantID)
+
+	kt, err = parse("tenant-app", "tenant-a")
+	require.NoError(t, err)
+	assert.Equal(t, "tenant-a", kt.TenantID)
+
+	kt, err = parse("default-app", "")
+	require.NoError(t, err)
+	assert.Equal(t, constant.DefaultTenantID, kt.TenantID)
+
+	// the app can not operate other tenants' resources by setting the tenant header.
+	_, err = parse("tenant-app", "tenant-b")
+	assert.Error(t, err)
+
+	_, err = parse("default-app", "tenant-a")
+	assert.Error(t, err)
+}
+
+func TestAppAuthParse(t *testing.T) {
+	auth := NewAppAuthenticator(cc.AppAuth{Enable: true, Apps: []cc.AppAccount{
+		{AppCode: "limited", AppSecret: "secret", ServiceAccount: "svc-limited",
+			AllowedPaths: []string{"/api/v1/cloud/bizs/"}},
+	}})
+
+	parse := func(authorization, path string) (*kit.Kit, bool, error) {
+		header := http.Header{}
+		if len(authorization) != 0 {
+			header.Set(constant.BKGWAuthKey, authorization)
+		}
+		return auth.Parse(context.Background(), header, http.MethodPost, path)
+	}
+
+	// the app is mapped to its service account.
+	kt, matched, err := parse(`{"bk_app_code": "limited", "bk_app_secret": "secret"}`,
+		"/api/v1/cloud/bizs/1/cvms/list")
+	require.NoError(t, err)
+	require.True(t, matched)
+	assert.Equal(t, "svc-limited", kt.User)
+	assert.Equal(t, "limited", kt.AppCode)
+	assert.NotEmpty(t, kt.Rid)
+
+	// requests without app secret are left to the other authentications.
+	_, matched, err = parse("", "/api/v1/cloud/bizs/1/cvms/list")
+	assert.NoError(t, err)
+	assert.False(t, matched)
+
+	_, matched, err = parse(`{"bk_app_code": "limited"}`, "/api/v1/cloud/bizs/1/cvms/list")
+	assert.NoError(t, err)
+	assert.False(t, matched)
+
+	_, matched, err = parse(`{"bk_app_code": "limited", "bk_app_secret": "wrong"}`,
+		"/api/v1/cloud/bizs/1/cvms/list")
+	assert.Error(t, err)
+	assert.True(t, matched)
+
+	_, matched, err = parse(`{"bk_app_code": "unknown", "bk_app_secret": "secret"}`,
+		"/api/v1/cloud/bizs/1/cvms/list")
+	assert.Error(t, err)
+	assert.True(t, matched)
+
+	// the app can only call the apis under its allowed paths.
+	_, matched, err = parse(`{"bk_app_code": "limited", "bk_app_secret": "secret"}`, "/api/v1/cloud/accounts/list")
+	assert.Error(t, err)
+	assert.True(t, matched)
+}
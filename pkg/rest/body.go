@@ -0,0 +1,288 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+)
+
+const (
+	defaultMaxBodySize       int64 = 32 << 20
+	defaultMaxStreamBodySize int64 = 512 << 20
+)
+
+// bodyLimit is the max size of the request body, requests exceed the limit are rejected with 413.
+var bodyLimit = struct {
+	// maxSize is the max body size decoded at once.
+	maxSize int64
+	// maxStreamSize is the max body size decoded by stream or uploaded as file, it is the upper bound of all requests.
+	maxStreamSize int64
+}{
+	maxSize:       defaultMaxBodySize,
+	maxStreamSize: defaultMaxStreamBodySize,
+}
+
+// SetBodyLimit set the max size of the request body.
+func SetBodyLimit(opt cc.BodyLimit) {
+	if opt.MaxSizeMB != 0 {
+		bodyLimit.maxSize = int64(opt.MaxSizeMB) << 20
+	}
+	if opt.MaxStreamSizeMB != 0 {
+		bodyLimit.maxStreamSize = int64(opt.MaxStreamSizeMB) << 20
+	}
+	if bodyLimit.maxStreamSize < bodyLimit.maxSize {
+		bodyLimit.maxStreamSize = bodyLimit.maxSize
+	}
+}
+
+// errBodyTooLarge is returned when the request body exceeds the limit of the decode method.
+var errBodyTooLarge = errors.New("request body too large")
+
+// limitedReader returns errBodyTooLarge once more than limit bytes are read, unlike io.LimitReader which returns
+// io.EOF silently and makes the truncated body look like a malformed json.
+type limitedReader struct {
+	r     io.Reader
+	limit int64
+	read  int64
+}
+
+// Read implements io.Reader.
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.read > l.limit {
+		return 0, errBodyTooLarge
+	}
+
+	// read at most one more byte than the limit to tell if the body exceeds it.
+	if remain := l.limit + 1 - l.read; int64(len(p)) > remain {
+		p = p[:remain]
+	}
+
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.limit {
+		return n, errBodyTooLarge
+	}
+
+	return n, err
+}
+
+func isBodyTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.Is(err, errBodyTooLarge) || errors.As(err, &maxBytesErr)
+}
+
+// limitRequestBody rejects the request whose content length exceeds the stream limit, and limits the body of the
+// request without content length, so that no request can exhaust the memory when the body is read.
+func limitRequestBody(cts *Contexts) error {
+	req := cts.Request.Request
+	if req.Body == nil {
+		return nil
+	}
+
+	if req.ContentLength > bodyLimit.maxStreamSize {
+		return cts.bodyTooLargeErr(bodyLimit.maxStreamSize)
+	}
+
+	req.Body = http.MaxBytesReader(cts.resp.ResponseWriter, req.Body, bodyLimit.maxStreamSize)
+	return nil
+}
+
+// peekBody read the body for request log if the body does not exceed the max size, the read content is put back
+// to the body so that it can be decoded later. ok is false if the body exceeds the max size.
+func peekBody(req *http.Request) (body []byte, ok bool, err error) {
+	if req.ContentLength > bodyLimit.maxSize {
+		return nil, false, nil
+	}
+
+	body, err = io.ReadAll(io.LimitReader(req.Body, bodyLimit.maxSize+1))
+	if err != nil {
+		return nil, false, err
+	}
+
+	if int64(len(body)) > bodyLimit.maxSize {
+		req.Body = struct {
+			io.Reader
+			io.Closer
+		}{Reader: io.MultiReader(bytes.NewReader(body), req.Body), Closer: req.Body}
+		return nil, false, nil
+	}
+
+	req.Body = io.NopCloser(bytes.NewBuffer(body))
+	return body, true, nil
+}
+
+// WithBodyLimit set the max body size of the request decoded at once, it is used by the apis whose body is larger
+// than the default limit, and needs to be called before the body is decoded.
+func (c *Contexts) WithBodyLimit(sizeMB uint) *Contexts {
+	c.maxBodySize = int64(sizeMB) << 20
+	return c
+}
+
+func (c *Contexts) bodyReader(limit int64) io.Reader {
+	return &limitedReader{r: c.Request.Request.Body, limit: limit}
+}
+
+func (c *Contexts) maxBodySizeOrDefault() int64 {
+	if c.maxBodySize > 0 {
+		return c.maxBodySize
+	}
+
+	return bodyLimit.maxSize
+}
+
+func (c *Contexts) bodyTooLargeErr(limit int64) error {
+	c.WithStatusCode(http.StatusRequestEntityTooLarge)
+	return errf.Newf(errf.RequestEntityTooLarge, "request body exceeds the max size %d bytes", limit)
+}
+
+// decodeErr convert the decode error to response error.
+func (c *Contexts) decodeErr(err error, limit int64) error {
+	if isBodyTooLarge(err) {
+		logs.ErrorDepthf(2, "request body exceeds the max size %d bytes, rid: %s", limit, c.Kit.Rid)
+		return c.bodyTooLargeErr(limit)
+	}
+
+	logs.ErrorDepthf(2, "decode request body failed, err: %s, rid: %s", err.Error(), c.Kit.Rid)
+	return errf.NewFromErr(errf.InvalidParameter, err)
+}
+
+// DecodeBatchInto decode the large batch request body by stream, the body is like {"<field>": [item, ...], ...}, items
+// of the field array are decoded and handled one by one without loading the whole array into memory. other fields
+// are decoded into to after the array is handled, they are ignored if to is nil. if field is empty, the body itself
+// is the array. the body is limited by the stream body size instead of the default one.
+func DecodeBatchInto[T any](cts *Contexts, to interface{}, field string, handle func(item *T) error) error {
+	dec := json.NewDecoder(cts.bodyReader(bodyLimit.maxStreamSize))
+
+	if len(field) == 0 {
+		return decodeBatchArray(cts, dec, handle)
+	}
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return cts.decodeErr(err, bodyLimit.maxStreamSize)
+	}
+
+	others := make(map[string]json.RawMessage)
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return cts.decodeErr(err, bodyLimit.maxStreamSize)
+		}
+
+		key, _ := token.(string)
+		if key == field {
+			if err = decodeBatchArray(cts, dec, handle); err != nil {
+				return err
+			}
+			continue
+		}
+
+		raw := json.RawMessage{}
+		if err = dec.Decode(&raw); err != nil {
+			return cts.decodeErr(err, bodyLimit.maxStreamSize)
+		}
+		others[key] = raw
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return cts.decodeErr(err, bodyLimit.maxStreamSize)
+	}
+
+	if to == nil {
+		return nil
+	}
+
+	byt, err := json.Marshal(others)
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(byt, to); err != nil {
+		return cts.decodeErr(err, bodyLimit.maxStreamSize)
+	}
+
+	return nil
+}
+
+func decodeBatchArray[T any](cts *Contexts, dec *json.Decoder, handle func(item *T) error) error {
+	if err := expectDelim(dec, '['); err != nil {
+		return cts.decodeErr(err, bodyLimit.maxStreamSize)
+	}
+
+	for dec.More() {
+		item := new(T)
+		if err := dec.Decode(item); err != nil {
+			return cts.decodeErr(err, bodyLimit.maxStreamSize)
+		}
+
+		if err := handle(item); err != nil {
+			return err
+		}
+	}
+
+	if err := expectDelim(dec, ']'); err != nil {
+		return cts.decodeErr(err, bodyLimit.maxStreamSize)
+	}
+
+	return nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if token != delim {
+		return fmt.Errorf("expect %s, but got %v", delim, token)
+	}
+
+	return nil
+}
+
+// FormFile returns the uploaded file of the multipart form, the form is limited by the stream body size, and the
+// files larger than the default body size are saved to temporary files instead of memory.
+func (c *Contexts) FormFile(name string) (multipart.File, *multipart.FileHeader, error) {
+	req := c.Request.Request
+	if req.MultipartForm == nil {
+		if err := req.ParseMultipartForm(bodyLimit.maxSize); err != nil {
+			if isBodyTooLarge(err) {
+				return nil, nil, c.bodyTooLargeErr(bodyLimit.maxStreamSize)
+			}
+			return nil, nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+	}
+
+	file, header, err := req.FormFile(name)
+	if err != nil {
+		return nil, nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return file, header, nil
+}
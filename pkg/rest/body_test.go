@@ -0,0 +1,125 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBodyLimit(t *testing.T) {
+	SetBodyLimit(cc.BodyLimit{MaxSizeMB: 1, MaxStreamSizeMB: 2})
+	defer SetBodyLimit(cc.BodyLimit{MaxSizeMB: 32, MaxStreamSizeMB: 512})
+
+	type rule struct {
+		Port int `json:"port"`
+	}
+	type batchReq struct {
+		SecurityGroupID string `json:"security_group_id"`
+	}
+
+	h := NewHandler()
+	h.Add("CreateRule", http.MethodPost, "/rules/create", func(cts *Contexts) (interface{}, error) {
+		req := make(map[string]interface{})
+		if err := cts.DecodeInto(&req); err != nil {
+			return nil, err
+		}
+		return len(req), nil
+	})
+	h.Add("ImportRule", http.MethodPost, "/rules/import", func(cts *Contexts) (interface{}, error) {
+		req := new(batchReq)
+		count := 0
+		err := DecodeBatchInto(cts, req, "rules", func(item *rule) error {
+			count++
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"count": count, "security_group_id": req.SecurityGroupID}, nil
+	})
+	ws := new(restful.WebService)
+	h.Load(ws)
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	do := func(path, body string) (int, *Response) {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set(constant.UserKey, "admin")
+		req.Header.Set(constant.AppCodeKey, "hcm")
+		req.Header.Set(constant.RidKey, "body-limit-test-rid")
+		recorder := httptest.NewRecorder()
+		container.ServeHTTP(recorder, req)
+
+		resp := new(Response)
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), resp))
+		return recorder.Code, resp
+	}
+
+	code, resp := do("/rules/create", `{"name":"rule"}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, errf.OK, resp.Code)
+
+	// body larger than the default limit is rejected with 413 by DecodeInto.
+	large := `{"name":"` + strings.Repeat("a", 1<<20) + `"}`
+	code, resp = do("/rules/create", large)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, code)
+	assert.Equal(t, errf.RequestEntityTooLarge, resp.Code)
+
+	// batch body is decoded one by one within the stream limit.
+	rules := make([]string, 0, 100000)
+	for i := 0; i < 100000; i++ {
+		rules = append(rules, `{"port":80}`)
+	}
+	batch := `{"security_group_id":"sg-1","rules":[` + strings.Join(rules, ",") + `]}`
+	require.Greater(t, len(batch), 1<<20)
+	code, resp = do("/rules/import", batch)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, errf.OK, resp.Code)
+	assert.Equal(t, map[string]interface{}{"count": float64(100000), "security_group_id": "sg-1"}, resp.Data)
+
+	// body larger than the stream limit is rejected before it is read.
+	code, resp = do("/rules/import", batch+strings.Repeat(" ", 2<<20))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, code)
+	assert.Equal(t, errf.RequestEntityTooLarge, resp.Code)
+}
+
+func TestLimitedReader(t *testing.T) {
+	r := &limitedReader{r: strings.NewReader("12345"), limit: 5}
+	byt := make([]byte, 10)
+	n, err := r.Read(byt)
+	assert.Equal(t, 5, n)
+	assert.NoError(t, err)
+
+	r = &limitedReader{r: strings.NewReader("123456"), limit: 5}
+	err = json.NewDecoder(r).Decode(new(string))
+	assert.True(t, isBodyTooLarge(err))
+}
@@ -0,0 +1,168 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// Resilience 调用其他服务的容错策略，包括幂等请求的重试、慢请求的对冲以及按服务实例的熔断。
+// 熔断状态保存在 Resilience 中，使用同一个 Resilience 的客户端共享实例的熔断状态。
+type Resilience struct {
+	Retry   RetryPolicy
+	Hedge   HedgePolicy
+	Breaker BreakerPolicy
+
+	lock     sync.Mutex
+	breakers map[string]*hostBreaker
+	now      func() time.Time
+}
+
+// RetryPolicy 幂等请求的重试策略
+type RetryPolicy struct {
+	// MaxAttempts 最多请求次数，包含首次请求，小于等于1时不重试
+	MaxAttempts uint
+	// Backoff 首次重试前的等待时间，之后每次重试翻倍
+	Backoff time.Duration
+	// MaxBackoff 重试等待时间的上限，为0时不限制
+	MaxBackoff time.Duration
+}
+
+// backoff returns the time to wait before the retry, retry starts from 1.
+func (p RetryPolicy) backoff(retry uint) time.Duration {
+	wait := p.Backoff
+	for i := uint(1); i < retry; i++ {
+		wait *= 2
+		if p.MaxBackoff > 0 && wait >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+
+	return wait
+}
+
+// HedgePolicy 幂等请求的对冲策略，请求超过 Delay 未返回时向另一个服务实例发送相同的请求，使用最先返回的结果，
+// 用于避免个别实例响应慢时拖慢整个请求
+type HedgePolicy struct {
+	// Delay 发送对冲请求前的等待时间，为0时不对冲
+	Delay time.Duration
+	// MaxHedged 最多额外发送的对冲请求数
+	MaxHedged uint
+}
+
+// BreakerPolicy 按服务实例的熔断策略，实例连续失败 FailureThreshold 次后熔断，熔断期间不再向该实例发送请求，
+// 熔断 OpenDuration 后允许一个探测请求，探测成功后恢复
+type BreakerPolicy struct {
+	// FailureThreshold 熔断前连续失败的次数，为0时不熔断
+	FailureThreshold uint
+	// OpenDuration 熔断的时间
+	OpenDuration time.Duration
+}
+
+// NewResilience create the resilience policies of the client.
+func NewResilience(retry RetryPolicy, hedge HedgePolicy, breaker BreakerPolicy) *Resilience {
+	return &Resilience{
+		Retry:    retry,
+		Hedge:    hedge,
+		Breaker:  breaker,
+		breakers: make(map[string]*hostBreaker),
+		now:      time.Now,
+	}
+}
+
+// RetryBackoff returns the time to wait before the retry, retry starts from 1.
+func (r *Resilience) RetryBackoff(retry uint) time.Duration {
+	return r.Retry.backoff(retry)
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// hostBreaker is the circuit breaker state of a server instance.
+type hostBreaker struct {
+	state     breakerState
+	failures  uint
+	openUntil time.Time
+}
+
+// Allow returns whether the request can be sent to the host, a probe request is allowed when the open duration of
+// the broken host is passed, and the other requests are rejected until the probe request is reported.
+func (r *Resilience) Allow(host string) bool {
+	if r.Breaker.FailureThreshold == 0 {
+		return true
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	b, exists := r.breakers[host]
+	if !exists {
+		return true
+	}
+
+	if b.state == breakerClosed {
+		return true
+	}
+
+	// 熔断时间已过时允许一个探测请求，探测请求未报告结果(如被取消)时，再过一个熔断时间后允许下一个探测请求
+	now := r.now()
+	if now.Before(b.openUntil) {
+		return false
+	}
+	b.state = breakerHalfOpen
+	b.openUntil = now.Add(r.Breaker.OpenDuration)
+	return true
+}
+
+// Report the result of the request sent to the host.
+func (r *Resilience) Report(host string, success bool) {
+	if r.Breaker.FailureThreshold == 0 {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	b, exists := r.breakers[host]
+	if !exists {
+		if success {
+			return
+		}
+		b = new(hostBreaker)
+		r.breakers[host] = b
+	}
+
+	if success {
+		delete(r.breakers, host)
+		return
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= r.Breaker.FailureThreshold {
+		b.state = breakerOpen
+		b.openUntil = r.now().Add(r.Breaker.OpenDuration)
+	}
+}
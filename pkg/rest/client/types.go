@@ -41,6 +41,10 @@ type Capability struct {
 
 	// MetricOpts metric option.
 	MetricOpts MetricOption
+
+	// Resilience the retry, hedge and circuit breaker policies of the requests, nil means the request is only
+	// retried when the connection of the GET request is reset.
+	Resilience *Resilience
 }
 
 // MetricOption metrics options.
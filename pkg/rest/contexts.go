@@ -43,6 +43,10 @@ type Contexts struct {
 	Request        *restful.Request
 	resp           *restful.Response
 	respStatusCode int
+	// maxBodySize is the max body size decoded at once of this request, default limit is used if it is 0.
+	maxBodySize int64
+	// respErr is the error responded to the request, it is recorded in the access log.
+	respErr *errf.ErrorF
 
 	// request meta info
 	bizID string
@@ -50,8 +54,12 @@ type Contexts struct {
 
 // RequestBody 返回拷贝的body内容
 func (c *Contexts) RequestBody() ([]byte, error) {
-	byt, err := ioutil.ReadAll(c.Request.Request.Body)
+	limit := c.maxBodySizeOrDefault()
+	byt, err := ioutil.ReadAll(c.bodyReader(limit))
 	if err != nil {
+		if isBodyTooLarge(err) {
+			return nil, c.bodyTooLargeErr(limit)
+		}
 		return nil, err
 	}
 
@@ -62,13 +70,11 @@ func (c *Contexts) RequestBody() ([]byte, error) {
 
 // ReDecodeInto 解析Body到结构体中，且把body内容重新写入Body.
 func (c *Contexts) ReDecodeInto(to interface{}) error {
-	byt, err := ioutil.ReadAll(c.Request.Request.Body)
+	byt, err := c.RequestBody()
 	if err != nil {
 		return err
 	}
 
-	c.Request.Request.Body = ioutil.NopCloser(bytes.NewBuffer(byt))
-
 	err = json.Unmarshal(byt, to)
 	if err != nil {
 		logs.ErrorDepthf(1, "decode request body failed, err: %s, rid: %s", err.Error(), c.Kit.Rid)
@@ -79,12 +85,12 @@ func (c *Contexts) ReDecodeInto(to interface{}) error {
 }
 
 // DecodeInto decode request body to a struct, if failed, then return the
-// response with an error
+// response with an error. body exceeds the max size is rejected with 413, use DecodeBatchInto for large batch body.
 func (c *Contexts) DecodeInto(to interface{}) error {
-	err := json.NewDecoder(c.Request.Request.Body).Decode(to)
+	limit := c.maxBodySizeOrDefault()
+	err := json.NewDecoder(c.bodyReader(limit)).Decode(to)
 	if err != nil {
-		logs.ErrorDepthf(1, "decode request body failed, err: %s, rid: %s", err.Error(), c.Kit.Rid)
-		return errf.NewFromErr(errf.InvalidParameter, err)
+		return c.decodeErr(err, limit)
 	}
 
 	return nil
@@ -199,13 +205,22 @@ func (c *Contexts) respFile(resp FileDownloadResp) {
 
 // respEntity response request with a success response.
 func (c *Contexts) respEntity(data interface{}) {
-	if c.respStatusCode != 0 {
-		c.resp.WriteHeader(c.respStatusCode)
+	if streamResp, ok := data.(StreamResp); ok {
+		c.respStream(streamResp)
+		return
+	}
+
+	if eventResp, ok := data.(EventStreamResp); ok {
+		c.respEventStream(eventResp)
+		return
 	}
 
 	c.resp.Header().Set(constant.RidKey, c.Kit.Rid)
 
 	if fileResp, ok := data.(FileDownloadResp); ok {
+		if c.respStatusCode != 0 {
+			c.resp.WriteHeader(c.respStatusCode)
+		}
 		c.respFile(fileResp)
 		return
 	}
@@ -217,7 +232,15 @@ func (c *Contexts) respEntity(data interface{}) {
 		Data:    data,
 	}
 
-	if err := json.NewEncoder(c.resp.ResponseWriter).Encode(resp); err != nil {
+	// the body is encoded before written, so that the large list response can be compressed according to its size.
+	body := new(bytes.Buffer)
+	if err := json.NewEncoder(body).Encode(resp); err != nil {
+		logs.ErrorDepthf(1, "encode response failed, err: %s, rid: %s", err.Error(), c.Kit.Rid)
+		c.respError(errf.NewFromErr(errf.Unknown, err))
+		return
+	}
+
+	if err := c.writeBody(body.Bytes()); err != nil {
 		logs.ErrorDepthf(1, "do response failed, err: %s, rid: %s", err.Error(), c.Kit.Rid)
 		return
 	}
@@ -235,7 +258,8 @@ func (c *Contexts) respError(err error) {
 		c.resp.Header().Set(constant.RidKey, c.Kit.Rid)
 	}
 	c.resp.AddHeader(restful.HEADER_ContentType, restful.MIME_JSON)
-	resp := errf.Error(err).Resp()
+	c.respErr = c.localizeError(err)
+	resp := c.respErr.Resp()
 
 	encodeErr := json.NewEncoder(c.resp.ResponseWriter).Encode(resp)
 	if encodeErr != nil {
@@ -246,6 +270,16 @@ func (c *Contexts) respError(err error) {
 	return
 }
 
+// localizeError parse the error and render its i18n message in the language of the request.
+func (c *Contexts) localizeError(err error) *errf.ErrorF {
+	parsed := errf.Error(err)
+	if c.Kit == nil {
+		return parsed
+	}
+
+	return parsed.Localize(c.Kit.Language)
+}
+
 // respErrorWithEntity response request with error response.
 func (c *Contexts) respErrorWithEntity(data interface{}, err error) {
 	if c.respStatusCode != 0 {
@@ -255,10 +289,13 @@ func (c *Contexts) respErrorWithEntity(data interface{}, err error) {
 	c.resp.Header().Set(constant.RidKey, c.Kit.Rid)
 	c.resp.AddHeader(restful.HEADER_ContentType, restful.MIME_JSON)
 
-	parsedErr := errf.Error(err)
+	parsedErr := c.localizeError(err)
+	c.respErr = parsedErr
 	resp := &Response{
 		Code:    parsedErr.Code,
 		Message: parsedErr.Message,
+		SubCode: parsedErr.SubCode,
+		Details: parsedErr.Details,
 		Data:    data,
 	}
 
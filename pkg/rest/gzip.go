@@ -0,0 +1,97 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	// gzipMinSize is the min size of the response body to be compressed, the small body is not worth the cpu cost.
+	gzipMinSize = 64 << 10
+
+	headerAcceptEncoding  = "Accept-Encoding"
+	headerContentEncoding = "Content-Encoding"
+	headerVary            = "Vary"
+	encodingGzip          = "gzip"
+)
+
+var gzipWriterPool = sync.Pool{
+	New: func() interface{} {
+		// the responses are compressed on the fly, so use the best speed instead of the best compression.
+		w, _ := gzip.NewWriterLevel(nil, gzip.BestSpeed)
+		return w
+	},
+}
+
+// acceptGzip returns if the client accepts gzip encoded response.
+func acceptGzip(req *http.Request) bool {
+	for _, value := range req.Header.Values(headerAcceptEncoding) {
+		for _, encoding := range strings.Split(value, ",") {
+			encoding = strings.TrimSpace(encoding)
+			if idx := strings.Index(encoding, ";"); idx >= 0 {
+				// encoding with q=0 means not acceptable.
+				if strings.TrimSpace(encoding[idx+1:]) == "q=0" {
+					continue
+				}
+				encoding = strings.TrimSpace(encoding[:idx])
+			}
+
+			if encoding == encodingGzip || encoding == "*" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// writeBody writes the response body with the status code, the large body is compressed by gzip if the client
+// accepts it. the response of idempotent request is not compressed, because it is captured and replayed as is.
+func (c *Contexts) writeBody(body []byte) error {
+	_, capturing := c.resp.ResponseWriter.(*captureWriter)
+	if len(body) < gzipMinSize || capturing || !acceptGzip(c.Request.Request) {
+		if c.respStatusCode != 0 {
+			c.resp.WriteHeader(c.respStatusCode)
+		}
+		_, err := c.resp.ResponseWriter.Write(body)
+		return err
+	}
+
+	c.resp.Header().Set(headerContentEncoding, encodingGzip)
+	c.resp.Header().Add(headerVary, headerAcceptEncoding)
+	c.resp.Header().Del("Content-Length")
+	if c.respStatusCode != 0 {
+		c.resp.WriteHeader(c.respStatusCode)
+	}
+
+	gz := gzipWriterPool.Get().(*gzip.Writer)
+	defer gzipWriterPool.Put(gz)
+	gz.Reset(c.resp.ResponseWriter)
+
+	if _, err := gz.Write(body); err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
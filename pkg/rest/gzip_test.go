@@ -0,0 +1,99 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGzipResponse(t *testing.T) {
+	h := NewHandler()
+	h.Add("ListCvm", http.MethodPost, "/cvms/list", func(cts *Contexts) (interface{}, error) {
+		count := 10
+		if cts.Request.QueryParameter("large") == "true" {
+			count = 10000
+		}
+		details := make([]string, 0, count)
+		for i := 0; i < count; i++ {
+			details = append(details, "cvm-detail")
+		}
+		return details, nil
+	})
+	ws := new(restful.WebService)
+	h.Load(ws)
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	do := func(path, acceptEncoding string) (*httptest.ResponseRecorder, []string) {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{}`))
+		req.Header.Set(constant.UserKey, "admin")
+		req.Header.Set(constant.AppCodeKey, "hcm")
+		req.Header.Set(constant.RidKey, "gzip-response-test-rid")
+		if len(acceptEncoding) != 0 {
+			req.Header.Set(headerAcceptEncoding, acceptEncoding)
+		}
+		recorder := httptest.NewRecorder()
+		container.ServeHTTP(recorder, req)
+
+		var body io.Reader = recorder.Body
+		if recorder.Header().Get(headerContentEncoding) == encodingGzip {
+			gz, err := gzip.NewReader(recorder.Body)
+			require.NoError(t, err)
+			body = gz
+		}
+
+		details := make([]string, 0)
+		resp := &Response{Data: &details}
+		require.NoError(t, json.NewDecoder(body).Decode(resp))
+		assert.Equal(t, errf.OK, resp.Code)
+		return recorder, details
+	}
+
+	recorder, details := do("/cvms/list?large=true", "gzip, deflate, br")
+	assert.Equal(t, encodingGzip, recorder.Header().Get(headerContentEncoding))
+	assert.Equal(t, headerAcceptEncoding, recorder.Header().Get(headerVary))
+	assert.Len(t, details, 10000)
+
+	// small response is not compressed.
+	recorder, details = do("/cvms/list", "gzip")
+	assert.Empty(t, recorder.Header().Get(headerContentEncoding))
+	assert.Len(t, details, 10)
+
+	// client does not accept gzip.
+	recorder, details = do("/cvms/list?large=true", "")
+	assert.Empty(t, recorder.Header().Get(headerContentEncoding))
+	assert.Len(t, details, 10000)
+
+	recorder, _ = do("/cvms/list?large=true", "gzip;q=0, identity")
+	assert.Empty(t, recorder.Header().Get(headerContentEncoding))
+}
@@ -21,9 +21,9 @@ package rest
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"runtime/debug"
 	"strings"
@@ -34,9 +34,11 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
+	"hcm/pkg/tracing"
 
 	"github.com/emicklei/go-restful/v3"
-	prm "github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var once sync.Once
@@ -58,6 +60,13 @@ type action struct {
 	Path    string
 	Alias   string
 	Handler func(contexts *Contexts) (reply interface{}, err error)
+	// vendor and resType are the metric labels parsed from the path
+	vendor  string
+	resType string
+	// version is the api version of the action, empty means the action is added without version.
+	version Version
+	// doc is the api document of the action.
+	doc apiDoc
 }
 
 // Handler contains all the restfull http handler actions
@@ -71,8 +80,37 @@ func (r *Handler) Path(path string) {
 	r.rootPath = strings.TrimRight(path, "/")
 }
 
-// Add add a http handler
-func (r *Handler) Add(alias, verb, path string, handler func(cts *Contexts) (interface{}, error)) {
+// Add add a http handler, the returned route is used to describe its api document.
+func (r *Handler) Add(alias, verb, path string, handler func(cts *Contexts) (interface{}, error)) *Route {
+	return &Route{action: r.add("", alias, verb, path, handler)}
+}
+
+// AddVersion add a http handler of the api version, the handler implements the schema of this version, and it
+// is also served in the older versions of the converters by converting their request and response.
+// the returned route is used to describe the api document of this version.
+func (r *Handler) AddVersion(version Version, alias, verb, path string, handler func(cts *Contexts) (interface{},
+	error), converters ...VersionConverter) *Route {
+
+	if len(version) == 0 {
+		panic("add versioned http handler, but got empty version")
+	}
+
+	route := &Route{action: r.add(version, alias, verb, path, handler)}
+	for _, converter := range converters {
+		if len(converter.Version) == 0 || converter.Version == version {
+			panic(fmt.Sprintf("add versioned http handler %s, but got invalid converter version: %s", alias,
+				converter.Version))
+		}
+
+		r.add(converter.Version, alias, verb, path, converter.wrap(handler))
+	}
+
+	return route
+}
+
+func (r *Handler) add(version Version, alias, verb, path string, handler func(cts *Contexts) (interface{},
+	error)) *action {
+
 	switch verb {
 	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodPatch:
 	default:
@@ -87,7 +125,11 @@ func (r *Handler) Add(alias, verb, path string, handler func(cts *Contexts) (int
 		panic("add http handler, but got nil http handler")
 	}
 
-	r.actions = append(r.actions, &action{Verb: verb, Path: path, Alias: alias, Handler: handler})
+	vendor, resType := parseMetricLabels(path)
+	act := &action{Verb: verb, Path: path, Alias: alias, Handler: handler, vendor: vendor, resType: resType,
+		version: version}
+	r.actions = append(r.actions, act)
+	return act
 }
 
 // Load add actions to the restful webservice, and add to the rest container.
@@ -97,28 +139,59 @@ func (r *Handler) Load(ws *restful.WebService) {
 	}
 
 	for _, action := range r.actions {
-		path := action.Path
-		if r.rootPath != "" {
-			path = fmt.Sprintf("%s/%s", r.rootPath, strings.TrimLeft(action.Path, "/"))
+		if len(action.version) != 0 {
+			panic(fmt.Sprintf("action %s is added with version %s, load it with LoadVersions", action.Alias,
+				action.version))
+		}
+		r.loadAction(ws, action)
+	}
+
+	return
+}
+
+// LoadVersions add actions to the restful webservice of their api versions, actions added without version are
+// added to the webservice of Version1.
+func (r *Handler) LoadVersions(wss *WebServices) {
+	if len(r.actions) == 0 {
+		panic("no actions has been added, can not load the handler")
+	}
+
+	for _, action := range r.actions {
+		version := action.version
+		if len(version) == 0 {
+			version = Version1
 		}
 
-		switch action.Verb {
-		case http.MethodPost:
-			ws.Route(ws.POST(path).To(r.wrapperAction(action)))
-		case http.MethodDelete:
-			ws.Route(ws.DELETE(path).To(r.wrapperAction(action)))
-		case http.MethodPut:
-			ws.Route(ws.PUT(path).To(r.wrapperAction(action)))
-		case http.MethodGet:
-			ws.Route(ws.GET(path).To(r.wrapperAction(action)))
-		case http.MethodPatch:
-			ws.Route(ws.PATCH(path).To(r.wrapperAction(action)))
-		default:
-			panic(fmt.Sprintf("add handler to webservice, but got unsupport verb: %s .", action.Verb))
+		ws := wss.Get(version)
+		if ws == nil {
+			panic(fmt.Sprintf("load action %s, but api version %s is not served", action.Alias, version))
 		}
+		r.loadAction(ws, action)
 	}
+}
 
-	return
+func (r *Handler) loadAction(ws *restful.WebService, action *action) {
+	path := action.Path
+	if r.rootPath != "" {
+		path = fmt.Sprintf("%s/%s", r.rootPath, strings.TrimLeft(action.Path, "/"))
+	}
+
+	switch action.Verb {
+	case http.MethodPost:
+		ws.Route(ws.POST(path).To(r.wrapperAction(action)))
+	case http.MethodDelete:
+		ws.Route(ws.DELETE(path).To(r.wrapperAction(action)))
+	case http.MethodPut:
+		ws.Route(ws.PUT(path).To(r.wrapperAction(action)))
+	case http.MethodGet:
+		ws.Route(ws.GET(path).To(r.wrapperAction(action)))
+	case http.MethodPatch:
+		ws.Route(ws.PATCH(path).To(r.wrapperAction(action)))
+	default:
+		panic(fmt.Sprintf("add handler to webservice, but got unsupport verb: %s .", action.Verb))
+	}
+
+	apiDocument.register(strings.TrimRight(ws.RootPath(), "/")+"/"+strings.TrimLeft(path, "/"), action)
 }
 
 func (r *Handler) wrapperAction(action *action) func(req *restful.Request, resp *restful.Response) {
@@ -127,52 +200,105 @@ func (r *Handler) wrapperAction(action *action) func(req *restful.Request, resp
 		cts.Request = req
 		cts.resp = resp
 
+		access := newAccessRecorder(cts)
+		defer access.log(cts, action.Alias)
+
+		defer func() {
+			restMetric.requestCounter.With(action.metricLabels(cts)).Inc()
+		}()
+
 		kt, err := kit.FromHeader(req.Request.Context(), req.Request.Header)
 		if err != nil {
 			rid := req.Request.Header.Get(constant.RidKey)
 			logs.Errorf("invalid request for %s, err: %v, rid: %s", action.Alias, err, rid)
 			cts.WithStatusCode(http.StatusBadRequest)
 			cts.respError(err)
-			restMetric.errCounter.With(prm.Labels{"alias": action.Alias, "biz": cts.bizID}).Inc()
+			restMetric.errCounter.With(action.metricLabels(cts)).Inc()
 			return
 		}
 
+		var idemReq *idempotentRequest
 		defer func() {
 			if fatalErr := recover(); fatalErr != nil {
 				cts.respError(fmt.Errorf("panic err: %v", fatalErr))
+				idemReq.finish(cts, fmt.Errorf("panic err: %v", fatalErr))
 				logs.Errorf("[hcm server panic], err: %v, rid: %s, debug strace: %s", fatalErr, kt.Rid, debug.Stack())
 				logs.CloseLogs()
 			}
 		}()
 
+		// 调用方设置了超时时间时，超时后停止处理请求，避免调用方已放弃的请求继续占用处理协程
+		if timeout, ok := kit.TimeoutFromHeader(req.Request.Header); ok {
+			var cancel context.CancelFunc
+			kt.Ctx, cancel = context.WithTimeout(kt.Ctx, timeout)
+			defer cancel()
+		}
+
+		// 业务下的请求记录所属业务，传递给下游服务
+		if bizID := req.PathParameter("bk_biz_id"); len(bizID) != 0 {
+			kt.BizID = bizID
+		}
 		cts.Kit = kt
 
+		if err = limitRequestBody(cts); err != nil {
+			logs.Errorf("restful request %s body is too large, err: %v, rid: %s", action.Alias, err, cts.Kit.Rid)
+			cts.respError(err)
+			restMetric.errCounter.With(action.metricLabels(cts)).Inc()
+			return
+		}
+
 		// print request log when log level is 4 or request is write request
 		if (bool(logs.V(4)) || (!strings.Contains(req.Request.URL.Path, "/list/") &&
 			!strings.Contains(req.Request.URL.Path, "/find/"))) && req.Request.Body != nil {
 
-			byt, err := ioutil.ReadAll(req.Request.Body)
+			byt, ok, err := peekBody(req.Request)
 			if err != nil {
 				logs.Errorf("restful request %s peek failed, err: %v, rid: %s", action.Alias, err, cts.Kit.Rid)
 
-				cts.WithStatusCode(http.StatusBadRequest)
-				cts.respError(errf.NewFromErr(errf.InvalidParameter, err))
-				restMetric.errCounter.With(prm.Labels{"alias": action.Alias, "biz": cts.bizID}).Inc()
+				if isBodyTooLarge(err) {
+					cts.respError(cts.bodyTooLargeErr(bodyLimit.maxStreamSize))
+				} else {
+					cts.WithStatusCode(http.StatusBadRequest)
+					cts.respError(errf.NewFromErr(errf.InvalidParameter, err))
+				}
+				restMetric.errCounter.With(action.metricLabels(cts)).Inc()
 				return
 			}
 
-			req.Request.Body = ioutil.NopCloser(bytes.NewBuffer(byt))
-
-			compactJson := new(bytes.Buffer)
-			compactBody := string(byt)
-			if err := json.Compact(compactJson, byt); err == nil {
-				compactBody = compactJson.String()
+			if !ok {
+				logs.Infof("%s received restful request, body is too large to print, content length: %d, rid: %s",
+					action.Alias, req.Request.ContentLength, kt.Rid)
+			} else {
+				compactJson := new(bytes.Buffer)
+				compactBody := string(byt)
+				if err := json.Compact(compactJson, byt); err == nil {
+					compactBody = compactJson.String()
+				}
+				logs.Infof("%s received restful request, body: %s, rid: %s", action.Alias, compactBody, kt.Rid)
 			}
-			logs.Infof("%s received restful request, body: %s, rid: %s", action.Alias, compactBody, kt.Rid)
 		}
 
+		access.captureBody(req.Request)
+
+		var replayed bool
+		idemReq, replayed, err = prepareIdempotency(cts)
+		if err != nil {
+			cts.respError(err)
+			restMetric.errCounter.With(action.metricLabels(cts)).Inc()
+			return
+		}
+		if replayed {
+			return
+		}
+
+		ctx, span := tracing.Start(kt.Ctx, action.Alias, trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attribute.String("http.method", action.Verb),
+				attribute.String("http.route", action.Path), attribute.String("rid", kt.Rid)))
+		kt.Ctx = ctx
+
 		start := time.Now()
 		reply, err := action.Handler(cts)
+		tracing.End(span, err)
 		if err != nil {
 			if logs.V(2) {
 				logs.Errorf("do restful request %s failed, err: %v, rid: %s", action.Alias, err, cts.Kit.Rid)
@@ -183,14 +309,16 @@ func (r *Handler) wrapperAction(action *action) func(req *restful.Request, resp
 			} else {
 				cts.respError(err)
 			}
+			idemReq.finish(cts, err)
 
-			restMetric.errCounter.With(prm.Labels{"alias": action.Alias, "biz": cts.bizID}).Inc()
+			restMetric.errCounter.With(action.metricLabels(cts)).Inc()
 			return
 		}
 
 		cts.respEntity(reply)
+		idemReq.finish(cts, nil)
 
-		restMetric.lagMS.With(prm.Labels{"alias": action.Alias, "biz": cts.bizID}).
+		restMetric.lagMS.With(action.metricLabels(cts)).
 			Observe(float64(time.Since(start).Milliseconds()))
 	}
 }
@@ -0,0 +1,392 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sync"
+	"time"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+
+	"github.com/emicklei/go-restful/v3"
+	etcd3 "go.etcd.io/etcd/client/v3"
+)
+
+// idempotencyPendingTTL is the ttl of the reserved key of the request in progress, so that the key is released
+// if the server crashes before the response is saved.
+const idempotencyPendingTTL = 10 * time.Minute
+
+// idempotencyStoreTimeout is the timeout of saving or releasing the key when the request is finished, it does not
+// depend on the request context, which may be canceled or exceeded the deadline at that time.
+const idempotencyStoreTimeout = 5 * time.Second
+
+// idempotency is used to replay the responses of the mutation requests with idempotency key, it is disabled if nil.
+var idempotency *idempotencyOption
+
+type idempotencyOption struct {
+	store IdempotencyStore
+	ttl   time.Duration
+}
+
+// EnableIdempotency enable idempotency key support of the mutation requests, the first response of a key is saved
+// in the store and replayed on retries within the ttl.
+func EnableIdempotency(store IdempotencyStore, ttl time.Duration) {
+	idempotency = &idempotencyOption{store: store, ttl: ttl}
+}
+
+// IdempotentRecord is the record of the request with idempotency key.
+type IdempotentRecord struct {
+	// Hash is the hash of request method, path and body, the key can not be reused by different requests.
+	Hash string `json:"hash"`
+	// Done is true if the request is finished and the response is saved.
+	Done       bool            `json:"done"`
+	StatusCode int             `json:"status_code,omitempty"`
+	Body       json.RawMessage `json:"body,omitempty"`
+}
+
+// IdempotencyStore stores the records of the requests with idempotency key.
+type IdempotencyStore interface {
+	// Reserve the key with the pending record if the key not exists, otherwise return the existing record.
+	Reserve(ctx context.Context, key string, record *IdempotentRecord, ttl time.Duration) (*IdempotentRecord,
+		error)
+	// Save the finished record of the key.
+	Save(ctx context.Context, key string, record *IdempotentRecord, ttl time.Duration) error
+	// Release the key, so that the request can be retried.
+	Release(ctx context.Context, key string) error
+}
+
+// NewEtcdIdempotencyStore create an idempotency store which saves records in etcd under the prefix with lease, so
+// that all replicas of a service share the records.
+func NewEtcdIdempotencyStore(cli *etcd3.Client, prefix string) IdempotencyStore {
+	return &etcdIdempotencyStore{cli: cli, prefix: prefix}
+}
+
+type etcdIdempotencyStore struct {
+	cli    *etcd3.Client
+	prefix string
+}
+
+// Reserve the key in etcd with transaction.
+func (e *etcdIdempotencyStore) Reserve(ctx context.Context, key string, record *IdempotentRecord,
+	ttl time.Duration) (*IdempotentRecord, error) {
+
+	value, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+
+	lease, err := e.cli.Grant(ctx, int64(ttl.Seconds()))
+	if err != nil {
+		return nil, fmt.Errorf("grant idempotency key lease failed, err: %v", err)
+	}
+
+	etcdKey := path.Join(e.prefix, key)
+	resp, err := e.cli.Txn(ctx).
+		If(etcd3.Compare(etcd3.CreateRevision(etcdKey), "=", 0)).
+		Then(etcd3.OpPut(etcdKey, string(value), etcd3.WithLease(lease.ID))).
+		Else(etcd3.OpGet(etcdKey)).
+		Commit()
+	if err != nil {
+		return nil, fmt.Errorf("reserve idempotency key %s failed, err: %v", key, err)
+	}
+
+	if resp.Succeeded {
+		return nil, nil
+	}
+
+	// lease is not used since the key exists.
+	if _, err = e.cli.Revoke(ctx, lease.ID); err != nil {
+		logs.Errorf("revoke unused idempotency key lease failed, err: %v", err)
+	}
+
+	kvs := resp.Responses[0].GetResponseRange().Kvs
+	if len(kvs) == 0 {
+		return nil, fmt.Errorf("idempotency key %s is expired while reserving", key)
+	}
+
+	existing := new(IdempotentRecord)
+	if err = json.Unmarshal(kvs[0].Value, existing); err != nil {
+		return nil, fmt.Errorf("unmarshal idempotency key %s record failed, err: %v", key, err)
+	}
+	return existing, nil
+}
+
+// Save the record of the key to etcd with a new lease.
+func (e *etcdIdempotencyStore) Save(ctx context.Context, key string, record *IdempotentRecord,
+	ttl time.Duration) error {
+
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	lease, err := e.cli.Grant(ctx, int64(ttl.Seconds()))
+	if err != nil {
+		return fmt.Errorf("grant idempotency key lease failed, err: %v", err)
+	}
+
+	if _, err = e.cli.Put(ctx, path.Join(e.prefix, key), string(value), etcd3.WithLease(lease.ID)); err != nil {
+		return fmt.Errorf("save idempotency key %s record failed, err: %v", key, err)
+	}
+
+	return nil
+}
+
+// Release the key from etcd.
+func (e *etcdIdempotencyStore) Release(ctx context.Context, key string) error {
+	if _, err := e.cli.Delete(ctx, path.Join(e.prefix, key)); err != nil {
+		return fmt.Errorf("release idempotency key %s failed, err: %v", key, err)
+	}
+
+	return nil
+}
+
+// NewMemoryIdempotencyStore create an idempotency store which saves records in memory, it is used for single
+// instance deployment.
+func NewMemoryIdempotencyStore() IdempotencyStore {
+	return &memoryIdempotencyStore{records: make(map[string]memoryIdempotentRecord)}
+}
+
+type memoryIdempotentRecord struct {
+	record   IdempotentRecord
+	expireAt time.Time
+}
+
+type memoryIdempotencyStore struct {
+	lock    sync.Mutex
+	records map[string]memoryIdempotentRecord
+}
+
+// Reserve the key in memory.
+func (m *memoryIdempotencyStore) Reserve(_ context.Context, key string, record *IdempotentRecord,
+	ttl time.Duration) (*IdempotentRecord, error) {
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if existing, ok := m.records[key]; ok && time.Now().Before(existing.expireAt) {
+		return &existing.record, nil
+	}
+
+	m.records[key] = memoryIdempotentRecord{record: *record, expireAt: time.Now().Add(ttl)}
+	return nil, nil
+}
+
+// Save the record of the key to memory.
+func (m *memoryIdempotencyStore) Save(_ context.Context, key string, record *IdempotentRecord,
+	ttl time.Duration) error {
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.records[key] = memoryIdempotentRecord{record: *record, expireAt: time.Now().Add(ttl)}
+
+	// clean the expired records, records are few since ttl is limited.
+	now := time.Now()
+	for k, v := range m.records {
+		if now.After(v.expireAt) {
+			delete(m.records, k)
+		}
+	}
+	return nil
+}
+
+// Release the key from memory.
+func (m *memoryIdempotencyStore) Release(_ context.Context, key string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	delete(m.records, key)
+	return nil
+}
+
+// idempotentRequest is the mutation request with idempotency key.
+type idempotentRequest struct {
+	opt  *idempotencyOption
+	key  string
+	hash string
+	// writer captures the response of the request.
+	writer *captureWriter
+}
+
+// newIdempotentRequest returns the idempotent request if idempotency is enabled and the mutation request has
+// idempotency key, the key is scoped by tenant, app code and user. the key is hashed before joined to the scope, so
+// that a key like "../x" can not escape from the scope of the caller.
+func newIdempotentRequest(cts *Contexts) (*idempotentRequest, error) {
+	if idempotency == nil || cts.Request.Request.Method == http.MethodGet {
+		return nil, nil
+	}
+
+	key := cts.Request.Request.Header.Get(constant.IdempotencyKey)
+	if len(key) == 0 {
+		return nil, nil
+	}
+
+	if len(key) > 255 {
+		return nil, errf.New(errf.InvalidParameter, "idempotency key length should <= 255")
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(cts.Request.Request.Method + " " + cts.Request.Request.URL.Path + "\n"))
+	if cts.Request.Request.Body != nil {
+		body, err := io.ReadAll(cts.Request.Request.Body)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+		cts.Request.Request.Body = io.NopCloser(bytes.NewBuffer(body))
+		hash.Write(body)
+	}
+
+	keyHash := sha256.Sum256([]byte(key))
+	kt := cts.Kit
+	return &idempotentRequest{
+		opt:  idempotency,
+		key:  path.Join(kt.GetTenantID(), kt.AppCode, kt.User, hex.EncodeToString(keyHash[:])),
+		hash: hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// prepareIdempotency reserve the idempotency key of the request, and replay the response if the request with the
+// same key is finished. returns replayed true if the response is replayed.
+func prepareIdempotency(cts *Contexts) (*idempotentRequest, bool, error) {
+	req, err := newIdempotentRequest(cts)
+	if err != nil || req == nil {
+		return nil, false, err
+	}
+
+	record, err := req.reserve(cts)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if record != nil {
+		replayIdempotentResponse(cts, record)
+		return nil, true, nil
+	}
+
+	return req, false, nil
+}
+
+// reserve the idempotency key, returns the finished record if the request should be replayed.
+func (r *idempotentRequest) reserve(cts *Contexts) (*IdempotentRecord, error) {
+	pending := &IdempotentRecord{Hash: r.hash}
+	existing, err := r.opt.store.Reserve(cts.Kit.Ctx, r.key, pending, idempotencyPendingTTL)
+	if err != nil {
+		logs.Errorf("reserve idempotency key failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if existing == nil {
+		// capture the response to save it when the request is finished.
+		r.writer = &captureWriter{ResponseWriter: cts.resp.ResponseWriter}
+		cts.resp.ResponseWriter = r.writer
+		return nil, nil
+	}
+
+	if existing.Hash != r.hash {
+		return nil, errf.New(errf.InvalidParameter, "idempotency key is already used by a different request")
+	}
+
+	if !existing.Done {
+		cts.WithStatusCode(http.StatusConflict)
+		return nil, errf.New(errf.IdempotentRequestInProgress, "request with the same idempotency key is in progress")
+	}
+
+	return existing, nil
+}
+
+// finish save the response of the succeeded request, or release the key of the failed request so that it can be
+// retried. it is also called when the handler panics, so that the key is not left in progress.
+func (r *idempotentRequest) finish(cts *Contexts, handleErr error) {
+	if r == nil || r.writer == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), idempotencyStoreTimeout)
+	defer cancel()
+
+	if handleErr != nil || !json.Valid(r.writer.body.Bytes()) {
+		if err := r.opt.store.Release(ctx, r.key); err != nil {
+			logs.Errorf("release idempotency key failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		}
+		return
+	}
+
+	record := &IdempotentRecord{
+		Hash:       r.hash,
+		Done:       true,
+		StatusCode: r.writer.statusCode,
+		Body:       r.writer.body.Bytes(),
+	}
+	if err := r.opt.store.Save(ctx, r.key, record, r.opt.ttl); err != nil {
+		logs.Errorf("save idempotency key record failed, err: %v, rid: %s", err, cts.Kit.Rid)
+	}
+}
+
+// replay the response of the finished request.
+func replayIdempotentResponse(cts *Contexts, record *IdempotentRecord) {
+	cts.resp.Header().Set(constant.RidKey, cts.Kit.Rid)
+	cts.resp.Header().Set(constant.IdempotentReplayedKey, "true")
+	cts.resp.AddHeader(restful.HEADER_ContentType, restful.MIME_JSON)
+	if record.StatusCode != 0 {
+		cts.resp.WriteHeader(record.StatusCode)
+	}
+
+	if _, err := cts.resp.ResponseWriter.Write(record.Body); err != nil {
+		logs.Errorf("replay idempotent response failed, err: %v, rid: %s", err, cts.Kit.Rid)
+	}
+}
+
+// captureWriter writes the response and captures the status code and body of it.
+type captureWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+// WriteHeader writes and captures the status code.
+func (c *captureWriter) WriteHeader(statusCode int) {
+	c.statusCode = statusCode
+	c.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write writes and captures the body.
+func (c *captureWriter) Write(b []byte) (int, error) {
+	c.body.Write(b)
+	return c.ResponseWriter.Write(b)
+}
+
+// Flush flushes the response if the underlying writer supports it, it is used by stream response.
+func (c *captureWriter) Flush() {
+	if f, ok := c.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,144 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotency(t *testing.T) {
+	EnableIdempotency(NewMemoryIdempotencyStore(), time.Minute)
+	defer func() { idempotency = nil }()
+
+	calls := 0
+	h := NewHandler()
+	h.Add("CreateVpc", http.MethodPost, "/vpcs/create", func(cts *Contexts) (interface{}, error) {
+		calls++
+		return map[string]int{"id": calls}, nil
+	})
+	ws := new(restful.WebService)
+	h.Load(ws)
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	do := func(key, body string) (*httptest.ResponseRecorder, *Response) {
+		req := httptest.NewRequest(http.MethodPost, "/vpcs/create", strings.NewReader(body))
+		req.Header.Set(constant.UserKey, "admin")
+		req.Header.Set(constant.AppCodeKey, "hcm")
+		req.Header.Set(constant.RidKey, "idempotency-test-rid")
+		req.Header.Set(constant.IdempotencyKey, key)
+		recorder := httptest.NewRecorder()
+		container.ServeHTTP(recorder, req)
+
+		resp := new(Response)
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), resp))
+		return recorder, resp
+	}
+
+	first, firstResp := do("key-1", `{"name":"vpc"}`)
+	assert.Equal(t, errf.OK, firstResp.Code)
+	assert.Empty(t, first.Header().Get(constant.IdempotentReplayedKey))
+
+	// retry with the same key and body is replayed without executing the handler.
+	retry, _ := do("key-1", `{"name":"vpc"}`)
+	assert.Equal(t, "true", retry.Header().Get(constant.IdempotentReplayedKey))
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, 1, calls)
+
+	// the key can not be reused by a different request.
+	_, conflictResp := do("key-1", `{"name":"vpc-2"}`)
+	assert.Equal(t, errf.InvalidParameter, conflictResp.Code)
+	assert.Equal(t, 1, calls)
+
+	// request with another key is executed.
+	_, otherResp := do("key-2", `{"name":"vpc"}`)
+	assert.Equal(t, errf.OK, otherResp.Code)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyReleaseOnPanic(t *testing.T) {
+	store := NewMemoryIdempotencyStore()
+	EnableIdempotency(store, time.Minute)
+	defer func() { idempotency = nil }()
+
+	calls := 0
+	h := NewHandler()
+	h.Add("CreateVpc", http.MethodPost, "/vpcs/create", func(cts *Contexts) (interface{}, error) {
+		calls++
+		if calls == 1 {
+			panic("create vpc panic")
+		}
+		return map[string]int{"id": calls}, nil
+	})
+	ws := new(restful.WebService)
+	h.Load(ws)
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	do := func() *Response {
+		req := httptest.NewRequest(http.MethodPost, "/vpcs/create", strings.NewReader(`{"name":"vpc"}`))
+		req.Header.Set(constant.UserKey, "admin")
+		req.Header.Set(constant.AppCodeKey, "hcm")
+		req.Header.Set(constant.RidKey, "idempotency-panic-rid")
+		req.Header.Set(constant.IdempotencyKey, "key-1")
+		recorder := httptest.NewRecorder()
+		container.ServeHTTP(recorder, req)
+
+		resp := new(Response)
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), resp))
+		return resp
+	}
+
+	// the key is released after the handler panics, so the retry is executed instead of conflicting.
+	assert.NotEqual(t, errf.OK, do().Code)
+	assert.Equal(t, errf.OK, do().Code)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyKeyScope(t *testing.T) {
+	EnableIdempotency(NewMemoryIdempotencyStore(), time.Minute)
+	defer func() { idempotency = nil }()
+
+	req := httptest.NewRequest(http.MethodPost, "/vpcs/create", strings.NewReader(`{}`))
+	req.Header.Set(constant.IdempotencyKey, "../../other-app/other-user/key")
+	cts := &Contexts{Request: restful.NewRequest(req), Kit: kit.New()}
+	cts.Kit.AppCode = "hcm"
+	cts.Kit.User = "admin"
+
+	idemReq, err := newIdempotentRequest(cts)
+	require.NoError(t, err)
+	// the key can not escape from the scope of the caller.
+	assert.True(t, strings.HasPrefix(idemReq.key, path.Join(cts.Kit.GetTenantID(), "hcm", "admin")+"/"))
+	assert.NotContains(t, idemReq.key, "..")
+}
@@ -20,6 +20,9 @@
 package rest
 
 import (
+	"strings"
+
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/metrics"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -28,6 +31,9 @@ import (
 // restMetric is used to collect restfull metrics.
 var restMetric *metric
 
+// metricLabelNames is the label names of restful metrics, vendor and res_type are parsed from the request path.
+var metricLabelNames = []string{"alias", "biz", "vendor", "res_type"}
+
 func initMetric() {
 	m := new(metric)
 	labels := prometheus.Labels{}
@@ -39,7 +45,7 @@ func initMetric() {
 		Help:        "the lags(milliseconds) to request the restful API",
 		ConstLabels: labels,
 		Buckets:     []float64{0.01, 0.05, 0.1, 0.5, 1, 2, 3, 4, 5, 10, 30, 50, 100},
-	}, []string{"alias", "biz"})
+	}, metricLabelNames)
 	metrics.Register().MustRegister(m.lagMS)
 
 	m.errCounter = prometheus.NewCounterVec(
@@ -49,9 +55,19 @@ func initMetric() {
 			Name:        "total_err_count",
 			Help:        "the total error count to request the restful API",
 			ConstLabels: labels,
-		}, []string{"alias", "biz"})
+		}, metricLabelNames)
 	metrics.Register().MustRegister(m.errCounter)
 
+	m.requestCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   metrics.Namespace,
+			Subsystem:   metrics.RestfulSubSys,
+			Name:        "total_request_count",
+			Help:        "the total request count to request the restful API",
+			ConstLabels: labels,
+		}, metricLabelNames)
+	metrics.Register().MustRegister(m.requestCounter)
+
 	restMetric = m
 }
 
@@ -61,4 +77,45 @@ type metric struct {
 
 	// errCounter record the total error count when request restful API.
 	errCounter *prometheus.CounterVec
+
+	// requestCounter record the total count when request restful API.
+	requestCounter *prometheus.CounterVec
+}
+
+// vendorPathParam is the vendor path parameter, vendor label is resolved from request if path contains it.
+const vendorPathParam = "{vendor}"
+
+// parseMetricLabels parse vendor and resource type labels from the handler path, e.g. vendor is tcloud and resource
+// type is cvms for path /vendors/tcloud/cvms/batch/start, resource type is the first static segment of the path
+// except vendor and biz related segments.
+func parseMetricLabels(path string) (vendor string, resType string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 0; i < len(segments); i++ {
+		switch {
+		case segments[i] == "vendors" && i+1 < len(segments):
+			vendor = segments[i+1]
+			i++
+		case segments[i] == "bizs", strings.HasPrefix(segments[i], "{"):
+		default:
+			if len(resType) == 0 {
+				resType = segments[i]
+			}
+		}
+	}
+
+	return vendor, resType
+}
+
+// metricLabels returns the restful metric labels of the request.
+func (a *action) metricLabels(cts *Contexts) prometheus.Labels {
+	vendor := a.vendor
+	if vendor == vendorPathParam && cts.Request != nil {
+		vendor = cts.Request.PathParameter("vendor")
+		// invalid vendor is collapsed to avoid high cardinality labels
+		if err := enumor.Vendor(vendor).Validate(); err != nil {
+			vendor = "unknown"
+		}
+	}
+
+	return prometheus.Labels{"alias": a.Alias, "biz": cts.bizID, "vendor": vendor, "res_type": a.resType}
 }
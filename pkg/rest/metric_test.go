@@ -0,0 +1,47 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMetricLabels(t *testing.T) {
+	cases := []struct {
+		path    string
+		vendor  string
+		resType string
+	}{
+		{path: "/vendors/tcloud/cvms/batch/start", vendor: "tcloud", resType: "cvms"},
+		{path: "/vendors/{vendor}/eips/associate", vendor: vendorPathParam, resType: "eips"},
+		{path: "/bizs/{bk_biz_id}/security_groups/{id}/rules/list", vendor: "", resType: "security_groups"},
+		{path: "/bizs/{bk_biz_id}/vendors/{vendor}/load_balancers/create", vendor: vendorPathParam,
+			resType: "load_balancers"},
+		{path: "/webhooks/subscriptions/{id}", vendor: "", resType: "webhooks"},
+	}
+
+	for _, c := range cases {
+		vendor, resType := parseMetricLabels(c.path)
+		assert.Equal(t, c.vendor, vendor, c.path)
+		assert.Equal(t, c.resType, resType, c.path)
+	}
+}
@@ -0,0 +1,322 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"hcm/pkg/logs"
+	"hcm/pkg/version"
+)
+
+// Route is used to describe the api document of an action added to the handler, e.g.
+// h.Add("ListAccount", http.MethodPost, "/accounts/list", svc.ListAccount).Reads(proto.AccountListReq{})
+type Route struct {
+	action *action
+}
+
+// apiDoc is the api document of an action.
+type apiDoc struct {
+	summary  string
+	request  reflect.Type
+	response reflect.Type
+}
+
+// Doc set the summary of the action.
+func (r *Route) Doc(summary string) *Route {
+	r.action.doc.summary = summary
+	return r
+}
+
+// Reads set the request body type of the action, sample is a value or pointer of the request struct.
+func (r *Route) Reads(sample interface{}) *Route {
+	r.action.doc.request = reflect.TypeOf(sample)
+	return r
+}
+
+// Writes set the response data type of the action, sample is a value or pointer of the reply.
+func (r *Route) Writes(sample interface{}) *Route {
+	r.action.doc.response = reflect.TypeOf(sample)
+	return r
+}
+
+// apiDocument is the openapi document of all the actions loaded to the web services of this server.
+var apiDocument = &openAPI{operations: make(map[string]map[string]*action)}
+
+// openAPI collects the loaded actions and generates the openapi 3.0 document from them.
+type openAPI struct {
+	lock sync.RWMutex
+	// operations is the path to its method to action map.
+	operations map[string]map[string]*action
+}
+
+var pathParamRe = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?}`)
+
+// register the action served on the full path.
+func (o *openAPI) register(fullPath string, act *action) {
+	// the path parameter with regex like {name:*} is not supported by openapi.
+	fullPath = pathParamRe.ReplaceAllString(fullPath, "{$1}")
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if _, exists := o.operations[fullPath]; !exists {
+		o.operations[fullPath] = make(map[string]*action)
+	}
+	o.operations[fullPath][strings.ToLower(act.Verb)] = act
+}
+
+// build the openapi document.
+func (o *openAPI) build(title string) map[string]interface{} {
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+
+	gen := &schemaGenerator{schemas: make(map[string]interface{}), names: make(map[reflect.Type]string)}
+	operationIDs := make(map[string]int)
+
+	paths := make(map[string]interface{}, len(o.operations))
+	fullPaths := make([]string, 0, len(o.operations))
+	for fullPath := range o.operations {
+		fullPaths = append(fullPaths, fullPath)
+	}
+	sort.Strings(fullPaths)
+
+	for _, fullPath := range fullPaths {
+		item := make(map[string]interface{})
+		for method, act := range o.operations[fullPath] {
+			operationID := act.Alias
+			if len(act.version) != 0 {
+				operationID = fmt.Sprintf("%s_%s", act.Alias, act.version)
+			}
+			// alias is only unique in one service, the same alias may be used by other services.
+			operationIDs[operationID]++
+			if operationIDs[operationID] > 1 {
+				operationID = fmt.Sprintf("%s_%d", operationID, operationIDs[operationID])
+			}
+
+			item[method] = gen.operation(operationID, fullPath, act)
+		}
+		paths[fullPath] = item
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   title,
+			"version": version.VERSION,
+		},
+		"paths":      paths,
+		"components": map[string]interface{}{"schemas": gen.schemas},
+	}
+}
+
+// OpenAPIHandler returns the http handler which serves the openapi document of this server.
+func OpenAPIHandler(title string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(apiDocument.build(title)); err != nil {
+			logs.Errorf("write openapi document failed, err: %v", err)
+		}
+	})
+}
+
+// schemaGenerator generates the json schema of the go types, named struct types are put into the components.
+type schemaGenerator struct {
+	schemas map[string]interface{}
+	names   map[reflect.Type]string
+}
+
+func (g *schemaGenerator) operation(operationID, fullPath string, act *action) map[string]interface{} {
+	op := map[string]interface{}{"operationId": operationID}
+	if len(act.doc.summary) != 0 {
+		op["summary"] = act.doc.summary
+	}
+	if len(act.resType) != 0 {
+		op["tags"] = []string{act.resType}
+	}
+
+	params := make([]interface{}, 0)
+	for _, match := range pathParamRe.FindAllStringSubmatch(fullPath, -1) {
+		params = append(params, map[string]interface{}{
+			"name":     match[1],
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]interface{}{"type": "string"},
+		})
+	}
+	if len(params) != 0 {
+		op["parameters"] = params
+	}
+
+	if act.doc.request != nil && act.Verb != http.MethodGet {
+		op["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content":  jsonContent(g.schema(act.doc.request)),
+		}
+	}
+
+	data := map[string]interface{}{}
+	if act.doc.response != nil {
+		data = g.schema(act.doc.response)
+	}
+	op["responses"] = map[string]interface{}{
+		"200": map[string]interface{}{
+			"description": "the reply of the request, code is 0 if succeeded, otherwise message is the error",
+			"content": jsonContent(map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"code":    map[string]interface{}{"type": "integer", "format": "int32"},
+					"message": map[string]interface{}{"type": "string"},
+					"data":    data,
+				},
+			}),
+		},
+	}
+
+	return op
+}
+
+func jsonContent(schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"application/json": map[string]interface{}{"schema": schema}}
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	schemaNameRe   = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+)
+
+// schema returns the json schema of the type.
+func (g *schemaGenerator) schema(typ reflect.Type) map[string]interface{} {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch typ {
+	case timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case rawMessageType:
+		return map[string]interface{}{}
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16,
+		reflect.Uint32:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": g.schema(typ.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": g.schema(typ.Elem())}
+	case reflect.Struct:
+		if len(typ.Name()) == 0 {
+			return g.structSchema(typ)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + g.structName(typ)}
+	default:
+		// interface, func, etc. can be any value.
+		return map[string]interface{}{}
+	}
+}
+
+// structName returns the component name of the named struct, and generates its schema at the first time.
+func (g *schemaGenerator) structName(typ reflect.Type) string {
+	if name, exists := g.names[typ]; exists {
+		return name
+	}
+
+	// use the package path as prefix to distinguish the types with the same name in different packages.
+	name := schemaNameRe.ReplaceAllString(strings.ReplaceAll(typ.PkgPath(), "/", ".")+"."+typ.Name(), "_")
+	// set the name before generating the schema, so that the recursive type can refer to itself.
+	g.names[typ] = name
+	g.schemas[name] = g.structSchema(typ)
+	return name
+}
+
+func (g *schemaGenerator) structSchema(typ reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	required := make([]string, 0)
+	g.collectFields(typ, properties, &required)
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) != 0 {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	return schema
+}
+
+// collectFields collect the json fields of the struct, the fields of embedded struct without json name are
+// collected into the parent struct as json encoding does.
+func (g *schemaGenerator) collectFields(typ reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && len(name) == 0 {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				g.collectFields(fieldType, properties, required)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if len(name) == 0 {
+			name = field.Name
+		}
+		properties[name] = g.schema(field.Type)
+
+		for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+			if rule == "required" {
+				*required = append(*required, name)
+				break
+			}
+		}
+	}
+}
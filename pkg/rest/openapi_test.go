@@ -0,0 +1,113 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type openAPIPage struct {
+	Count bool   `json:"count"`
+	Start uint32 `json:"start"`
+}
+
+type openAPIListReq struct {
+	Filter interface{}  `json:"filter" validate:"required"`
+	Page   *openAPIPage `json:"page" validate:"required,dive"`
+	Memo   string       `json:"memo,omitempty" validate:"omitempty,max=255"`
+}
+
+type openAPINode struct {
+	openAPIPage `json:",inline"`
+	Name        string            `json:"name"`
+	Labels      map[string]string `json:"labels"`
+	Children    []*openAPINode    `json:"children"`
+	CreatedAt   time.Time         `json:"created_at"`
+	Secret      string            `json:"-"`
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	h := NewHandler()
+	h.Add("ListOpenAPINode", http.MethodPost, "/openapi_nodes/list", func(cts *Contexts) (interface{}, error) {
+		return nil, nil
+	}).Reads(openAPIListReq{}).Writes([]openAPINode{}).Doc("list nodes")
+	h.Add("GetOpenAPINode", http.MethodGet, "/openapi_nodes/{id}/{path:*}", func(cts *Contexts) (interface{},
+		error) {
+		return nil, nil
+	}).Writes(&openAPINode{})
+	h.AddVersion(Version2, "DeleteOpenAPINode", http.MethodDelete, "/openapi_nodes/{id}",
+		func(cts *Contexts) (interface{}, error) {
+			return nil, nil
+		}, VersionConverter{Version: Version1})
+
+	h.LoadVersions(NewWebServices("/api/%s/openapi", Version1, Version2))
+
+	recorder := httptest.NewRecorder()
+	OpenAPIHandler("openapi-test").ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	doc := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &doc))
+	assert.Equal(t, "openapi-test", doc["info"].(map[string]interface{})["title"])
+
+	paths := doc["paths"].(map[string]interface{})
+	list := paths["/api/v1/openapi/openapi_nodes/list"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Equal(t, "ListOpenAPINode", list["operationId"])
+	assert.Equal(t, "list nodes", list["summary"])
+	assert.Equal(t, []interface{}{"openapi_nodes"}, list["tags"])
+
+	reqSchema := list["requestBody"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"].(map[string]interface{})
+	assert.Equal(t, "#/components/schemas/hcm.pkg.rest.openAPIListReq", reqSchema["$ref"])
+
+	data := list["responses"].(map[string]interface{})["200"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"].(map[string]interface{})["properties"].(map[string]interface{})["data"].(map[string]interface{})
+	assert.Equal(t, "array", data["type"])
+	assert.Equal(t, "#/components/schemas/hcm.pkg.rest.openAPINode",
+		data["items"].(map[string]interface{})["$ref"])
+
+	// path parameter with regex is converted to openapi path parameter, get request has no body.
+	get := paths["/api/v1/openapi/openapi_nodes/{id}/{path}"].(map[string]interface{})["get"].(map[string]interface{})
+	assert.Len(t, get["parameters"], 2)
+	assert.Nil(t, get["requestBody"])
+
+	// versioned actions are documented in each version.
+	assert.Equal(t, "DeleteOpenAPINode_v2",
+		paths["/api/v2/openapi/openapi_nodes/{id}"].(map[string]interface{})["delete"].(map[string]interface{})["operationId"])
+	assert.Equal(t, "DeleteOpenAPINode_v1",
+		paths["/api/v1/openapi/openapi_nodes/{id}"].(map[string]interface{})["delete"].(map[string]interface{})["operationId"])
+
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	listReq := schemas["hcm.pkg.rest.openAPIListReq"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"filter", "page"}, listReq["required"])
+
+	node := schemas["hcm.pkg.rest.openAPINode"].(map[string]interface{})["properties"].(map[string]interface{})
+	assert.Contains(t, node, "count", "inline struct fields should be flattened")
+	assert.NotContains(t, node, "Secret")
+	assert.Equal(t, "date-time", node["created_at"].(map[string]interface{})["format"])
+	assert.Equal(t, "#/components/schemas/hcm.pkg.rest.openAPINode",
+		node["children"].(map[string]interface{})["items"].(map[string]interface{})["$ref"])
+	assert.Equal(t, "object", node["labels"].(map[string]interface{})["type"])
+}
@@ -40,8 +40,14 @@ import (
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest/client"
+	"hcm/pkg/tracing"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // VerbType http request verb type
@@ -96,6 +102,9 @@ type Request struct {
 	// contentType http content type
 	contentType ContentType
 
+	// idempotent marks the request can be retried by the resilience policies
+	idempotent bool
+
 	err error
 }
 
@@ -351,6 +360,21 @@ func (r *Request) Do() *Result {
 		return result
 	}
 
+	ctx, span := tracing.Start(r.ctx, string(r.verb)+" "+r.subPath, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("rid", rid)))
+	defer func() {
+		if result.Err == nil && result.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, result.Status)
+		}
+		tracing.End(span, result.Err)
+	}()
+
+	// inject the client span context, so that the server span of the callee is the child of it.
+	if r.headers == nil {
+		r.headers = make(http.Header)
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(r.headers))
+
 	client := r.capability.Client
 	if client == nil {
 		client = http.DefaultClient
@@ -362,11 +386,19 @@ func (r *Request) Do() *Result {
 		return result
 	}
 
+	// ctx is derived from the request context, so the cancellation and deadline of the caller are honoured.
+	contentType := r.prepareBody()
+	if r.capability.Resilience != nil {
+		result = r.doResilient(ctx, client, hosts, contentType, rid)
+		return result
+	}
+
 	maxRetryCycle := 3
 	for try := 0; try < maxRetryCycle; try++ {
 		for index, host := range hosts {
-			result, isComplete := r.doWithHost(client, host, try+index, rid)
+			res, isComplete := r.doWithHost(ctx, client, host, contentType, try+index, rid)
 			if isComplete {
+				result = res
 				return result
 			}
 		}
@@ -376,20 +408,24 @@ func (r *Request) Do() *Result {
 	return result
 }
 
-// doWithHost http request do with specific host.
-func (r *Request) doWithHost(client client.HTTPClient, host string, retries int, rid string) (*Result, bool) {
-	contentType := r.contentType
-
+// prepareBody encode the form data to the body, it returns the content type of the request.
+func (r *Request) prepareBody() ContentType {
 	switch r.contentType {
 	case FormDataContent:
 		r.body = []byte(r.params.Encode())
 		r.params = url.Values{}
+		return FormDataContent
 	default:
-		contentType = JsonContent
+		return JsonContent
 	}
+}
+
+// doWithHost http request do with specific host, ctx is used to cancel the request.
+func (r *Request) doWithHost(ctx context.Context, client client.HTTPClient, host string, contentType ContentType,
+	retries int, rid string) (*Result, bool) {
 
 	url := host + r.WrapURL().String()
-	req, err := r.getRequest(url, contentType)
+	req, err := r.getRequest(ctx, url, contentType)
 	if err != nil {
 		return &Result{Err: err, Rid: rid}, true
 	}
@@ -459,16 +495,12 @@ func (r *Request) doWithHost(client client.HTTPClient, host string, retries int,
 	}, true
 }
 
-func (r *Request) getRequest(url string, contentType ContentType) (*http.Request, error) {
-	req, err := http.NewRequest(string(r.verb), url, bytes.NewReader(r.body))
+func (r *Request) getRequest(ctx context.Context, url string, contentType ContentType) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, string(r.verb), url, bytes.NewReader(r.body))
 	if err != nil {
 		return nil, err
 	}
 
-	if r.ctx != nil {
-		req.WithContext(r.ctx)
-	}
-
 	req.Header = cloneHeader(r.headers)
 	if len(req.Header) == 0 {
 		req.Header = make(http.Header)
@@ -0,0 +1,235 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest/client"
+)
+
+// Idempotent mark the request as idempotent, so that it can be retried and hedged by the resilience policies.
+// GET, HEAD, list and count requests and the requests with idempotency key are idempotent by default.
+func (r *Request) Idempotent() *Request {
+	r.idempotent = true
+	return r
+}
+
+// isIdempotent returns whether the request can be sent again without side effects.
+func (r *Request) isIdempotent() bool {
+	if r.idempotent || r.verb == GET || r.verb == HEAD {
+		return true
+	}
+
+	if len(r.headers.Get(constant.IdempotencyKey)) != 0 {
+		return true
+	}
+
+	// data-service的查询接口使用POST方法，路径以 list、count 结尾，如 /vpcs/list、/cvms/list_with_extension
+	path := r.subPath
+	if len(r.subPathArgs) > 0 {
+		path = fmt.Sprintf(r.subPath, r.subPathArgs...)
+	}
+	for _, seg := range strings.Split(path, "/") {
+		if seg == "list" || seg == "count" || strings.HasPrefix(seg, "list_") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// doResilient send the request with the resilience policies, the idempotent request is retried on the transient
+// failures and hedged when it is slow, and the hosts with circuit breaker open are skipped. retries and hedged
+// requests stop once ctx is canceled or its deadline is exceeded.
+func (r *Request) doResilient(ctx context.Context, cli client.HTTPClient, hosts []string, contentType ContentType,
+	rid string) *Result {
+
+	policy := r.capability.Resilience
+	idempotent := r.isIdempotent()
+
+	attempts := uint(1)
+	if idempotent && policy.Retry.MaxAttempts > 1 {
+		attempts = policy.Retry.MaxAttempts
+	}
+
+	var result *Result
+	for attempt := uint(0); attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if err := waitBackoff(ctx, policy.RetryBackoff(attempt)); err != nil {
+				return &Result{Rid: rid, Err: fmt.Errorf("http request %s %s is not retried, last err: %v, %w",
+					r.verb, r.subPath, result.Err, err)}
+			}
+		}
+
+		// 每次重试从不同的实例开始，避免一直请求同一个异常实例
+		available := availableHosts(policy, hosts, int(attempt))
+		if len(available) == 0 {
+			result = &Result{Rid: rid, Err: fmt.Errorf("all the %d servers are unavailable, circuit breaker is open",
+				len(hosts))}
+			continue
+		}
+
+		var retryable bool
+		if idempotent && policy.Hedge.Delay > 0 && len(available) > 1 {
+			result, retryable = r.hedge(ctx, cli, available, contentType, rid)
+		} else {
+			result, retryable = r.send(ctx, cli, available[0], contentType, rid, idempotent)
+		}
+
+		if !retryable {
+			return result
+		}
+
+		logs.Warnf("http request %s %s failed, attempt: %d/%d, err: %v, status: %s, rid: %s", r.verb, r.subPath,
+			attempt+1, attempts, result.Err, result.Status, rid)
+	}
+
+	return result
+}
+
+// waitBackoff wait for the backoff duration, it returns the error of ctx if ctx is done before that.
+func waitBackoff(ctx context.Context, backoff time.Duration) error {
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// availableHosts returns the hosts which circuit breaker is not open, hosts are rotated by offset.
+func availableHosts(policy *client.Resilience, hosts []string, offset int) []string {
+	available := make([]string, 0, len(hosts))
+	for i := range hosts {
+		host := hosts[(i+offset)%len(hosts)]
+		if policy.Allow(host) {
+			available = append(available, host)
+		}
+	}
+
+	return available
+}
+
+// hedge send the request to the first host, and send the same request to the next host if no reply is received
+// after the hedge delay or the request failed, the first successful reply is used and the others are canceled.
+func (r *Request) hedge(ctx context.Context, cli client.HTTPClient, hosts []string, contentType ContentType,
+	rid string) (*Result, bool) {
+
+	policy := r.capability.Resilience
+	maxSent := min(len(hosts), int(policy.Hedge.MaxHedged)+1)
+
+	// the hedged requests are canceled with the caller, and the others are canceled once a reply is used.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type reply struct {
+		result    *Result
+		retryable bool
+	}
+	replies := make(chan reply, maxSent)
+	sent := 0
+	send := func() {
+		host := hosts[sent]
+		sent++
+		go func() {
+			result, retryable := r.send(ctx, cli, host, contentType, rid, true)
+			replies <- reply{result: result, retryable: retryable}
+		}()
+	}
+
+	send()
+	timer := time.NewTimer(policy.Hedge.Delay)
+	defer timer.Stop()
+
+	var last reply
+	for received := 0; received < sent; {
+		select {
+		case one := <-replies:
+			received++
+			if !one.retryable {
+				return one.result, false
+			}
+			last = one
+			if sent < maxSent {
+				send()
+			}
+
+		case <-timer.C:
+			if sent < maxSent && ctx.Err() == nil {
+				logs.V(3).Infof("http request %s %s is slow, send hedged request to %s, rid: %s", r.verb, r.subPath,
+					hosts[sent], rid)
+				send()
+				timer.Reset(policy.Hedge.Delay)
+			}
+		}
+	}
+
+	return last.result, true
+}
+
+// send the request to the host once, it returns whether the failure is transient and the request can be retried.
+func (r *Request) send(ctx context.Context, cli client.HTTPClient, host string, contentType ContentType, rid string,
+	idempotent bool) (*Result, bool) {
+
+	policy := r.capability.Resilience
+	result, complete := r.doWithHost(ctx, cli, host, contentType, 0, rid)
+	if !complete {
+		policy.Report(host, false)
+		return &Result{Rid: rid, Err: fmt.Errorf("http request to %s is interrupted", host)}, idempotent
+	}
+
+	if result.Err != nil {
+		// 调用方取消、超时或对冲请求被取消不是实例的问题，不计入熔断，也不再重试
+		if ctx.Err() != nil {
+			return result, false
+		}
+
+		policy.Report(host, false)
+		// 连接失败时请求未发送到服务端，非幂等请求也可以重试
+		return result, idempotent || isDialError(result.Err)
+	}
+
+	switch result.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		policy.Report(host, false)
+		return result, idempotent
+	}
+
+	policy.Report(host, true)
+	return result, false
+}
+
+// isDialError returns whether the err is returned when connecting to the server.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,155 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"hcm/pkg/rest/client"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticServers []string
+
+// GetServers returns the static servers.
+func (s staticServers) GetServers() ([]string, error) {
+	return s, nil
+}
+
+func newResilientClient(policy *client.Resilience, hosts ...string) ClientInterface {
+	return NewClient(&client.Capability{Discover: staticServers(hosts), Resilience: policy}, "/api/v1/data")
+}
+
+func TestResilienceRetry(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"result":true}`))
+	}))
+	defer server.Close()
+
+	policy := client.NewResilience(client.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond},
+		client.HedgePolicy{}, client.BreakerPolicy{})
+	cli := newResilientClient(policy, server.URL)
+
+	// list request is idempotent, the unavailable reply is retried
+	result := cli.Post().SubResourcef("/vpcs/list").Body(map[string]string{}).Do()
+	require.NoError(t, result.Err)
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+	assert.EqualValues(t, 2, calls.Load())
+
+	// create request is not idempotent, it is not retried
+	calls.Store(0)
+	result = cli.Post().SubResourcef("/vpcs/batch/create").Body(map[string]string{}).Do()
+	require.NoError(t, result.Err)
+	assert.Equal(t, http.StatusServiceUnavailable, result.StatusCode)
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func TestResilienceBreaker(t *testing.T) {
+	var badCalls, goodCalls atomic.Int32
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		badCalls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer bad.Close()
+	good := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		goodCalls.Add(1)
+		_, _ = w.Write([]byte(`{"result":true}`))
+	}))
+	defer good.Close()
+
+	policy := client.NewResilience(client.RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond},
+		client.HedgePolicy{}, client.BreakerPolicy{FailureThreshold: 2, OpenDuration: time.Minute})
+	cli := newResilientClient(policy, bad.URL, good.URL)
+
+	for i := 0; i < 5; i++ {
+		result := cli.Get().SubResourcef("/vpcs/%d", i).Do()
+		require.NoError(t, result.Err)
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+	}
+
+	// the bad server is skipped after the circuit breaker is open
+	assert.EqualValues(t, 2, badCalls.Load())
+	assert.EqualValues(t, 5, goodCalls.Load())
+	assert.False(t, policy.Allow(bad.URL))
+	assert.True(t, policy.Allow(good.URL))
+}
+
+func TestResilienceHedge(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+		_, _ = w.Write([]byte(`{"result":"slow"}`))
+	}))
+	defer slow.Close()
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"result":"fast"}`))
+	}))
+	defer fast.Close()
+
+	policy := client.NewResilience(client.RetryPolicy{}, client.HedgePolicy{Delay: 20 * time.Millisecond,
+		MaxHedged: 1}, client.BreakerPolicy{FailureThreshold: 1, OpenDuration: time.Minute})
+	cli := newResilientClient(policy, slow.URL, fast.URL)
+
+	start := time.Now()
+	result := cli.Post().SubResourcef("/cvms/list").Body(map[string]string{}).Do()
+	require.NoError(t, result.Err)
+	assert.JSONEq(t, `{"result":"fast"}`, string(result.Body))
+	assert.Less(t, time.Since(start), time.Second)
+
+	// the canceled hedged request does not break the slow server
+	assert.True(t, policy.Allow(slow.URL))
+}
+
+func TestResilienceCancel(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	policy := client.NewResilience(client.RetryPolicy{MaxAttempts: 5, Backoff: time.Second},
+		client.HedgePolicy{}, client.BreakerPolicy{})
+	cli := newResilientClient(policy, server.URL)
+
+	// the retry backoff is interrupted when the deadline of the caller is exceeded
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	result := cli.Post().WithContext(ctx).SubResourcef("/vpcs/list").Body(map[string]string{}).Do()
+	require.ErrorIs(t, result.Err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.EqualValues(t, 1, calls.Load())
+}
@@ -24,14 +24,26 @@ import (
 	"fmt"
 	"net/http"
 
+	"hcm/pkg/criteria/errf"
 	"hcm/pkg/iam/meta"
 	"hcm/pkg/logs"
 )
 
 // BaseResp http response.
 type BaseResp struct {
-	Code    int32  `json:"code"`
-	Message string `json:"message"`
+	Code    int32         `json:"code"`
+	Message string        `json:"message"`
+	SubCode string        `json:"sub_code,omitempty"`
+	Details *errf.Details `json:"details,omitempty"`
+}
+
+// Err returns the error of the response with its sub code and details, returns nil if the response is succeeded.
+func (r *BaseResp) Err() error {
+	if r.Code == errf.OK {
+		return nil
+	}
+
+	return errf.NewWithDetails(r.Code, r.Message, r.SubCode, r.Details)
 }
 
 // Response is a http standard response
@@ -39,6 +51,8 @@ type Response struct {
 	Code        int32               `json:"code"`
 	Message     string              `json:"message"`
 	Permissions *meta.IamPermission `json:"permission,omitempty"`
+	SubCode     string              `json:"sub_code,omitempty"`
+	Details     *errf.Details       `json:"details,omitempty"`
 	Data        interface{}         `json:"data"`
 }
 
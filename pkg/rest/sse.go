@@ -0,0 +1,172 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+)
+
+const (
+	// MIMEEventStream is the content type of server-sent events response.
+	MIMEEventStream = "text/event-stream"
+
+	// EventEnd is the name of the last event of the event stream, its data is the EventEndData.
+	EventEnd = "end"
+
+	// headerLastEventID is the header sent by the reconnecting client with the id of the last received event.
+	headerLastEventID = "Last-Event-ID"
+)
+
+// EventStreamResp define server-sent events resp, the events are pushed to the client as soon as they are
+// sent, so that the client is notified of the changes without polling.
+type EventStreamResp interface {
+	ServeEvents(w *EventWriter) error
+}
+
+// EventStreamFunc is a function which implements EventStreamResp.
+type EventStreamFunc func(w *EventWriter) error
+
+// ServeEvents implements EventStreamResp.
+func (f EventStreamFunc) ServeEvents(w *EventWriter) error {
+	return f(w)
+}
+
+// EventEndData is the data of the end event, it carries the result of the event stream, a stream without
+// end event is broken and the client should reconnect.
+type EventEndData struct {
+	Code    int32  `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// EventWriter write events to the server-sent events response.
+type EventWriter struct {
+	writer      io.Writer
+	flusher     http.Flusher
+	lastEventID string
+}
+
+// Send an event to the client, the data is encoded as json, and the event is flushed immediately.
+// id is used by the client to resume with Last-Event-ID header after reconnected, it is omitted if empty.
+func (w *EventWriter) Send(event, id string, data interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if strings.ContainsAny(event, "\r\n") || strings.ContainsAny(id, "\r\n") {
+		return fmt.Errorf("event name %q or id %q contains line break", event, id)
+	}
+
+	var sb strings.Builder
+	if len(id) != 0 {
+		sb.WriteString("id: " + id + "\n")
+	}
+	if len(event) != 0 {
+		sb.WriteString("event: " + event + "\n")
+	}
+	sb.WriteString("data: ")
+	sb.Write(raw)
+	sb.WriteString("\n\n")
+
+	if _, err = io.WriteString(w.writer, sb.String()); err != nil {
+		return err
+	}
+
+	w.flush()
+	return nil
+}
+
+// KeepAlive write a comment line to keep the connection alive, the proxies may close the idle connection.
+func (w *EventWriter) KeepAlive() error {
+	if _, err := io.WriteString(w.writer, ": keepalive\n\n"); err != nil {
+		return err
+	}
+
+	w.flush()
+	return nil
+}
+
+// LastEventID returns the id of the last event received by the client before reconnected, empty if it is
+// the first connection.
+func (w *EventWriter) LastEventID() string {
+	return w.lastEventID
+}
+
+func (w *EventWriter) flush() {
+	if w.flusher != nil {
+		w.flusher.Flush()
+	}
+}
+
+// end send the end event with the result of the event stream.
+func (w *EventWriter) end(err error) error {
+	data := &EventEndData{Code: errf.OK}
+	if err != nil {
+		parsed := errf.Error(err)
+		data.Code, data.Message = parsed.Code, parsed.Message
+	}
+
+	return w.Send(EventEnd, "", data)
+}
+
+// respEventStream response request with server-sent events.
+func (c *Contexts) respEventStream(resp EventStreamResp) {
+	header := c.resp.Header()
+	header.Set(constant.RidKey, c.Kit.Rid)
+	header.Set("Content-Type", MIMEEventStream)
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	// disable the response buffering of nginx, otherwise the events are delayed until the buffer is full.
+	header.Set("X-Accel-Buffering", "no")
+	c.resp.WriteHeader(http.StatusOK)
+
+	w := &EventWriter{
+		writer:      c.resp.ResponseWriter,
+		lastEventID: c.Request.Request.Header.Get(headerLastEventID),
+	}
+	if f, ok := c.resp.ResponseWriter.(http.Flusher); ok {
+		w.flusher = f
+	}
+	// send the headers to the client right now, so that the client knows the stream is established.
+	w.flush()
+
+	// the status code has been sent, so the error is returned in the end event.
+	err := resp.ServeEvents(w)
+	if err != nil {
+		logs.ErrorDepthf(1, "serve event stream failed, err: %v, rid: %s", err, c.Kit.Rid)
+	}
+
+	// the client is gone, no need to send the end event.
+	if c.Kit.Ctx.Err() != nil {
+		return
+	}
+
+	if endErr := w.end(err); endErr != nil {
+		logs.ErrorDepthf(1, "send event stream end event failed, err: %v, rid: %s", endErr, c.Kit.Rid)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hcm/pkg/criteria/constant"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventStreamResponse(t *testing.T) {
+	h := NewHandler()
+	h.Add("WatchJob", http.MethodGet, "/jobs/{id}/events", func(cts *Contexts) (interface{}, error) {
+		if cts.PathParameter("id").String() == "not-found" {
+			return nil, errors.New("job not found")
+		}
+
+		return EventStreamFunc(func(w *EventWriter) error {
+			if err := w.Send("job", "1", map[string]string{"state": "running", "resume": w.LastEventID()}); err != nil {
+				return err
+			}
+			if err := w.KeepAlive(); err != nil {
+				return err
+			}
+			if cts.PathParameter("id").String() == "broken" {
+				return errors.New("list sub task failed")
+			}
+			return w.Send("sub_task", "2", []string{"task-1"})
+		}), nil
+	})
+	ws := new(restful.WebService)
+	h.Load(ws)
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	do := func(path, lastEventID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set(constant.UserKey, "admin")
+		req.Header.Set(constant.AppCodeKey, "hcm")
+		req.Header.Set(constant.RidKey, "event-stream-test-rid")
+		if len(lastEventID) != 0 {
+			req.Header.Set(headerLastEventID, lastEventID)
+		}
+		recorder := httptest.NewRecorder()
+		container.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	recorder := do("/jobs/job-1/events", "")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, MIMEEventStream, recorder.Header().Get("Content-Type"))
+	assert.Equal(t, "no-cache", recorder.Header().Get("Cache-Control"))
+	assert.True(t, recorder.Flushed)
+	assert.Equal(t, "id: 1\nevent: job\ndata: {\"resume\":\"\",\"state\":\"running\"}\n\n"+
+		": keepalive\n\n"+
+		"id: 2\nevent: sub_task\ndata: [\"task-1\"]\n\n"+
+		"event: end\ndata: {\"code\":0}\n\n", recorder.Body.String())
+
+	recorder = do("/jobs/job-1/events", "1")
+	assert.Contains(t, recorder.Body.String(), `data: {"resume":"1","state":"running"}`)
+
+	// the error after the stream established is returned in the end event.
+	recorder = do("/jobs/broken/events", "")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.NotContains(t, recorder.Body.String(), "event: sub_task")
+	assert.Contains(t, recorder.Body.String(), "event: end\ndata: {\"code\":")
+	assert.Contains(t, recorder.Body.String(), "list sub task failed")
+
+	// the error before the stream established is responded as normal json response.
+	recorder = do("/jobs/not-found/events", "")
+	assert.NotEqual(t, MIMEEventStream, recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Body.String(), "job not found")
+}
@@ -0,0 +1,160 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+)
+
+const (
+	// MIMENDJSON is the content type of newline-delimited json stream response.
+	MIMENDJSON = "application/x-ndjson"
+
+	// streamFlushRows the count of rows to flush the response writer.
+	streamFlushRows = 100
+)
+
+// StreamResp define newline-delimited json stream resp, the rows are written to response one by one
+// as they are scanned, so that huge amount of data can be exported without buffering in memory.
+type StreamResp interface {
+	Stream(w *StreamWriter) error
+}
+
+// StreamFunc is a function which implements StreamResp.
+type StreamFunc func(w *StreamWriter) error
+
+// Stream implements StreamResp.
+func (f StreamFunc) Stream(w *StreamWriter) error {
+	return f(w)
+}
+
+// StreamLine is one line of the stream response, each row is written as a line with data, and the
+// last line with end=true carries the result of the stream, a stream without end line is truncated.
+type StreamLine struct {
+	Data    json.RawMessage `json:"data,omitempty"`
+	End     bool            `json:"end,omitempty"`
+	Code    int32           `json:"code,omitempty"`
+	Message string          `json:"message,omitempty"`
+	Count   uint64          `json:"count,omitempty"`
+}
+
+// StreamWriter write rows to the stream response.
+type StreamWriter struct {
+	encoder *json.Encoder
+	flusher http.Flusher
+	count   uint64
+}
+
+// Write a row to the stream response.
+func (w *StreamWriter) Write(row interface{}) error {
+	data, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+
+	if err = w.encoder.Encode(&StreamLine{Data: data}); err != nil {
+		return err
+	}
+
+	w.count++
+	if w.count%streamFlushRows == 0 {
+		w.flush()
+	}
+
+	return nil
+}
+
+// Count returns the count of rows written.
+func (w *StreamWriter) Count() uint64 {
+	return w.count
+}
+
+func (w *StreamWriter) flush() {
+	if w.flusher != nil {
+		w.flusher.Flush()
+	}
+}
+
+// end write the last line with the result of the stream.
+func (w *StreamWriter) end(err error) error {
+	line := &StreamLine{End: true, Code: errf.OK, Count: w.count}
+	if err != nil {
+		parsed := errf.Error(err)
+		line.Code, line.Message = parsed.Code, parsed.Message
+	}
+
+	defer w.flush()
+	return w.encoder.Encode(line)
+}
+
+// respStream response request with newline-delimited json stream.
+func (c *Contexts) respStream(resp StreamResp) {
+	c.resp.Header().Set(constant.RidKey, c.Kit.Rid)
+	c.resp.AddHeader("Content-Type", MIMENDJSON)
+	c.resp.WriteHeader(http.StatusOK)
+
+	w := &StreamWriter{encoder: json.NewEncoder(c.resp.ResponseWriter)}
+	if f, ok := c.resp.ResponseWriter.(http.Flusher); ok {
+		w.flusher = f
+	}
+
+	// the status code has been sent, so the error is returned in the last line.
+	err := resp.Stream(w)
+	if err != nil {
+		logs.ErrorDepthf(1, "stream response failed, written: %d, err: %v, rid: %s", w.count, err, c.Kit.Rid)
+	}
+
+	if endErr := w.end(err); endErr != nil {
+		logs.ErrorDepthf(1, "write stream end line failed, err: %v, rid: %s", endErr, c.Kit.Rid)
+	}
+}
+
+// DecodeStream decode the newline-delimited json stream response, handler is called with the data of
+// each row, the error in the end line is returned, and the stream without end line is treated as truncated.
+func DecodeStream(r io.Reader, handler func(data json.RawMessage) error) (uint64, error) {
+	decoder := json.NewDecoder(r)
+	for {
+		line := new(StreamLine)
+		if err := decoder.Decode(line); err != nil {
+			if err == io.EOF {
+				return 0, errors.New("stream is truncated without end line")
+			}
+			return 0, err
+		}
+
+		if line.End {
+			if line.Code != errf.OK {
+				return line.Count, errf.New(line.Code, line.Message)
+			}
+			return line.Count, nil
+		}
+
+		if err := handler(line.Data); err != nil {
+			return 0, err
+		}
+	}
+}
@@ -0,0 +1,126 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamResponse(t *testing.T) {
+	h := NewHandler()
+	h.Add("StreamCvm", http.MethodPost, "/cvms/{mode}/stream", func(cts *Contexts) (interface{}, error) {
+		mode := cts.PathParameter("mode").String()
+		if mode == "invalid" {
+			return nil, errf.New(errf.InvalidParameter, "filter expr is required")
+		}
+
+		return StreamFunc(func(w *StreamWriter) error {
+			for idx := 0; idx < 150; idx++ {
+				if mode == "broken" && idx == 120 {
+					return errors.New("scan cvm failed")
+				}
+				if err := w.Write(map[string]string{"id": fmt.Sprintf("cvm-%d", idx)}); err != nil {
+					return err
+				}
+			}
+			return nil
+		}), nil
+	})
+	ws := new(restful.WebService)
+	h.Load(ws)
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	do := func(mode string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/cvms/"+mode+"/stream", strings.NewReader("{}"))
+		req.Header.Set(constant.UserKey, "admin")
+		req.Header.Set(constant.AppCodeKey, "hcm")
+		req.Header.Set(constant.RidKey, "ndjson-stream-test-rid")
+		recorder := httptest.NewRecorder()
+		container.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	recorder := do("all")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, MIMENDJSON, recorder.Header().Get("Content-Type"))
+	assert.True(t, recorder.Flushed)
+
+	ids := make([]string, 0)
+	count, err := DecodeStream(recorder.Body, func(data json.RawMessage) error {
+		row := make(map[string]string)
+		if err := json.Unmarshal(data, &row); err != nil {
+			return err
+		}
+		ids = append(ids, row["id"])
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(150), count)
+	assert.Len(t, ids, 150)
+	assert.Equal(t, "cvm-149", ids[149])
+
+	// the error after the stream established is returned in the end line.
+	recorder = do("broken")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	rows := 0
+	_, err = DecodeStream(recorder.Body, func(_ json.RawMessage) error {
+		rows++
+		return nil
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "scan cvm failed")
+	assert.Equal(t, 120, rows)
+
+	// the error before the stream established is responded as normal json response.
+	recorder = do("invalid")
+	assert.NotEqual(t, MIMENDJSON, recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Body.String(), "filter expr is required")
+}
+
+func TestDecodeStreamTruncated(t *testing.T) {
+	body := `{"data":{"id":"cvm-1"}}` + "\n" + `{"data":{"id":"cvm-2"}}` + "\n"
+
+	rows := 0
+	_, err := DecodeStream(strings.NewReader(body), func(_ json.RawMessage) error {
+		rows++
+		return nil
+	})
+	assert.Error(t, err, "stream without end line should be treated as truncated")
+	assert.Equal(t, 2, rows)
+
+	_, err = DecodeStream(strings.NewReader(body), func(_ json.RawMessage) error {
+		return errors.New("handle row failed")
+	})
+	assert.EqualError(t, err, "handle row failed")
+}
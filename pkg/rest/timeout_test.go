@@ -0,0 +1,83 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/kit"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	var remain time.Duration
+	var hasDeadline bool
+	h := NewHandler()
+	h.Add("CreateCvm", http.MethodPost, "/cvms/create", func(cts *Contexts) (interface{}, error) {
+		var deadline time.Time
+		deadline, hasDeadline = cts.Kit.Ctx.Deadline()
+		remain = time.Until(deadline)
+		return nil, nil
+	})
+	ws := new(restful.WebService)
+	h.Load(ws)
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	do := func(header http.Header) {
+		req := httptest.NewRequest(http.MethodPost, "/cvms/create", strings.NewReader(`{}`))
+		for key := range header {
+			req.Header.Set(key, header.Get(key))
+		}
+		container.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	// the remaining time of the caller's deadline is propagated by the kit header.
+	caller := &kit.Kit{User: "admin", AppCode: "hcm", Rid: "request-timeout-test-rid", Ctx: context.Background()}
+	callerKt, cancel := caller.WithTimeout(3 * time.Second)
+	defer cancel()
+
+	do(callerKt.Header())
+	require.True(t, hasDeadline)
+	assert.True(t, remain > 2*time.Second && remain <= 3*time.Second, "remain: %s", remain)
+
+	// the request is not limited if the caller has no deadline.
+	do(caller.Header())
+	assert.False(t, hasDeadline)
+
+	// the earlier deadline of the parent is respected by the sub kit.
+	subKt, subCancel := callerKt.WithTimeout(time.Hour)
+	defer subCancel()
+	deadline, _ := subKt.Ctx.Deadline()
+	assert.True(t, time.Until(deadline) <= 3*time.Second)
+
+	timeout, ok := kit.TimeoutFromHeader(http.Header{constant.TimeoutKey: []string{"invalid"}})
+	assert.False(t, ok)
+	assert.Zero(t, timeout)
+}
@@ -0,0 +1,134 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+
+	"hcm/pkg/kit"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// Version is the api version, which is the version segment of the url path, e.g. v1 of /api/v1/cloud.
+type Version string
+
+const (
+	// Version1 is the first api version, all handlers added by Handler.Add belong to this version.
+	Version1 Version = "v1"
+	// Version2 is the second api version.
+	Version2 Version = "v2"
+)
+
+// VersionConverter converts the request and response between an older api version and the version the
+// handler implements, so that the handler only need to implement the latest schema.
+type VersionConverter struct {
+	// Version is the older api version served by the converter.
+	Version Version
+	// Request converts the request body of the older version to the handler's, nil means compatible.
+	Request func(kt *kit.Kit, body []byte) ([]byte, error)
+	// Response converts the reply of the handler to the older version's, nil means compatible.
+	Response func(kt *kit.Kit, reply interface{}) (interface{}, error)
+}
+
+// wrap the handler to serve the older version request.
+func (c VersionConverter) wrap(handler func(cts *Contexts) (interface{}, error)) func(cts *Contexts) (
+	interface{}, error) {
+
+	return func(cts *Contexts) (interface{}, error) {
+		if c.Request != nil {
+			body, err := io.ReadAll(cts.Request.Request.Body)
+			if err != nil {
+				return nil, fmt.Errorf("read %s request body failed, err: %v", c.Version, err)
+			}
+
+			body, err = c.Request(cts.Kit, body)
+			if err != nil {
+				return nil, err
+			}
+			cts.Request.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		reply, err := handler(cts)
+		if err != nil || c.Response == nil {
+			return reply, err
+		}
+
+		return c.Response(cts.Kit, reply)
+	}
+}
+
+// WebServices is the web services of all the api versions of a server, the root paths of them only differ
+// in the version segment, e.g. /api/v1/cloud and /api/v2/cloud.
+type WebServices struct {
+	services map[Version]*restful.WebService
+}
+
+// NewWebServices create the web services of the api versions, rootPathFormat is like /api/%s/cloud.
+func NewWebServices(rootPathFormat string, versions ...Version) *WebServices {
+	if len(versions) == 0 {
+		panic("create web services, but got no api version")
+	}
+
+	wss := &WebServices{services: make(map[Version]*restful.WebService, len(versions))}
+	for _, version := range versions {
+		ws := new(restful.WebService)
+		ws.Path(fmt.Sprintf(rootPathFormat, version))
+		wss.services[version] = ws
+	}
+
+	return wss
+}
+
+// Get the web service of the api version, returns nil if the version is not served.
+func (w *WebServices) Get(version Version) *restful.WebService {
+	return w.services[version]
+}
+
+// Each call the fn with the web service of all api versions, it is used to set filters, produces, etc.
+func (w *WebServices) Each(fn func(ws *restful.WebService)) {
+	for _, version := range w.Versions() {
+		fn(w.services[version])
+	}
+}
+
+// Versions returns all the served api versions in order.
+func (w *WebServices) Versions() []Version {
+	versions := make([]Version, 0, len(w.services))
+	for version := range w.services {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+
+	return versions
+}
+
+// Container create a restful container with the web services of all api versions.
+func (w *WebServices) Container() *restful.Container {
+	container := restful.NewContainer()
+	w.Each(func(ws *restful.WebService) {
+		container.Add(ws)
+	})
+
+	return container
+}
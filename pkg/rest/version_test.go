@@ -0,0 +1,108 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionConverter(t *testing.T) {
+	type syncReq struct {
+		ResTypes []string `json:"res_types"`
+	}
+
+	h := NewHandler()
+	h.Add("ListVpc", http.MethodPost, "/vpcs/list", func(cts *Contexts) (interface{}, error) {
+		return "vpcs", nil
+	})
+	h.AddVersion(Version2, "SyncAccount", http.MethodPost, "/accounts/sync", func(cts *Contexts) (interface{},
+		error) {
+
+		req := new(syncReq)
+		if err := cts.DecodeInto(req); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"flow_id": "flow", "res_types": req.ResTypes}, nil
+	}, VersionConverter{
+		Version: Version1,
+		// v1 syncs all resource types and returns no data.
+		Request: func(_ *kit.Kit, _ []byte) ([]byte, error) {
+			return []byte(`{"res_types":["all"]}`), nil
+		},
+		Response: func(_ *kit.Kit, _ interface{}) (interface{}, error) {
+			return nil, nil
+		},
+	})
+
+	wss := NewWebServices("/api/%s/cloud", Version1, Version2)
+	h.LoadVersions(wss)
+	container := wss.Container()
+
+	do := func(path, body string) (int, *Response) {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set(constant.UserKey, "admin")
+		req.Header.Set(constant.AppCodeKey, "hcm")
+		req.Header.Set(constant.RidKey, "version-test-rid")
+		recorder := httptest.NewRecorder()
+		container.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusOK {
+			return recorder.Code, nil
+		}
+
+		resp := new(Response)
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), resp))
+		return recorder.Code, resp
+	}
+
+	_, v2Resp := do("/api/v2/cloud/accounts/sync", `{"res_types":["vpc"]}`)
+	assert.Equal(t, map[string]interface{}{"flow_id": "flow", "res_types": []interface{}{"vpc"}}, v2Resp.Data)
+
+	_, v1Resp := do("/api/v1/cloud/accounts/sync", `{}`)
+	assert.Equal(t, errf.OK, v1Resp.Code)
+	assert.Nil(t, v1Resp.Data)
+
+	// handlers added without version are only served in v1.
+	_, listResp := do("/api/v1/cloud/vpcs/list", `{}`)
+	assert.Equal(t, "vpcs", listResp.Data)
+	code, _ := do("/api/v2/cloud/vpcs/list", `{}`)
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestLoadVersionsNotServed(t *testing.T) {
+	h := NewHandler()
+	h.AddVersion(Version2, "SyncAccount", http.MethodPost, "/accounts/sync", func(cts *Contexts) (interface{},
+		error) {
+		return nil, nil
+	})
+
+	assert.Panics(t, func() { h.LoadVersions(NewWebServices("/api/%s/cloud", Version1)) })
+	assert.Panics(t, func() { h.Load(NewWebServices("/api/%s/cloud", Version2).Get(Version2)) })
+}
@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/rest/discovery"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// NewClient create a grpc client connection to the service, the server addresses are resolved by the service
+// discovery, and the requests are balanced in round-robin.
+func NewClient(name cc.Name, disc discovery.Interface, opt cc.Grpc) (*grpc.ClientConn, error) {
+	size := int(opt.MaxMsgSizeMB) << 20
+	conn, err := grpc.NewClient(fmt.Sprintf("%s:///%s", discoveryScheme, name),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithResolvers(&discoveryBuilder{discovery: disc}),
+		grpc.WithDefaultServiceConfig(`{"loadBalancingConfig": [{"round_robin": {}}]}`),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(size), grpc.MaxCallSendMsgSize(size)),
+		grpc.WithChainUnaryInterceptor(unaryClientInterceptor),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create %s grpc client failed, err: %v", name, err)
+	}
+
+	return conn, nil
+}
+
+func unaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+
+	var trailer metadata.MD
+	if err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Trailer(&trailer))...); err != nil {
+		return fromStatus(err, trailer)
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rpc
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	"hcm/pkg/logs"
+	"hcm/pkg/rest/discovery"
+
+	"google.golang.org/grpc/resolver"
+)
+
+const (
+	// discoveryScheme is the target scheme of the discovery resolver.
+	discoveryScheme = "hcm"
+	// resolveInterval is the interval to refresh the server addresses from the discovery.
+	resolveInterval = 5 * time.Second
+)
+
+// discoveryBuilder builds the resolver which resolves the server addresses by the service discovery.
+type discoveryBuilder struct {
+	discovery discovery.Interface
+}
+
+// Build the discovery resolver.
+func (b *discoveryBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (
+	resolver.Resolver, error) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &discoveryResolver{
+		target:    target.Endpoint(),
+		discovery: b.discovery,
+		cc:        cc,
+		cancel:    cancel,
+	}
+	r.resolve()
+	go r.watch(ctx)
+
+	return r, nil
+}
+
+// Scheme returns the scheme of the discovery resolver.
+func (b *discoveryBuilder) Scheme() string {
+	return discoveryScheme
+}
+
+type discoveryResolver struct {
+	target    string
+	discovery discovery.Interface
+	cc        resolver.ClientConn
+	cancel    context.CancelFunc
+	lock      sync.Mutex
+}
+
+// ResolveNow resolve the server addresses immediately.
+func (r *discoveryResolver) ResolveNow(resolver.ResolveNowOptions) {
+	r.resolve()
+}
+
+// Close stop refreshing the server addresses.
+func (r *discoveryResolver) Close() {
+	r.cancel()
+}
+
+func (r *discoveryResolver) watch(ctx context.Context) {
+	ticker := time.NewTicker(resolveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.resolve()
+		}
+	}
+}
+
+func (r *discoveryResolver) resolve() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	servers, err := r.discovery.GetServers()
+	if err != nil {
+		logs.Errorf("resolve %s grpc servers failed, err: %v", r.target, err)
+		r.cc.ReportError(err)
+		return
+	}
+
+	addresses := make([]resolver.Address, 0, len(servers))
+	for _, server := range servers {
+		u, err := url.Parse(server)
+		if err != nil || len(u.Host) == 0 {
+			logs.Errorf("resolve %s grpc server %s failed, invalid url, err: %v", r.target, server, err)
+			continue
+		}
+		addresses = append(addresses, resolver.Address{Addr: u.Host})
+	}
+
+	if err := r.cc.UpdateState(resolver.State{Addresses: addresses}); err != nil {
+		logs.Errorf("update %s grpc servers %v failed, err: %v", r.target, servers, err)
+	}
+}
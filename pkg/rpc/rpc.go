@@ -0,0 +1,107 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package rpc provides the grpc transport between internal services, the grpc api is served on the same port with
+// the restful api, the request kit is passed by the grpc metadata, and the hcm error code is passed by the trailer.
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// errCodeKey is the trailer key of the hcm error code.
+const errCodeKey = "hcm-error-code"
+
+type kitCtxKey struct{}
+
+// Kit get the request kit from the context of the grpc server handler.
+func Kit(ctx context.Context) (*kit.Kit, error) {
+	kt, ok := ctx.Value(kitCtxKey{}).(*kit.Kit)
+	if !ok {
+		return nil, errors.New("request kit not found in grpc context")
+	}
+
+	return kt, nil
+}
+
+// NewOutgoingContext create the context to call the grpc api with the request header, which is generated by kit.
+func NewOutgoingContext(ctx context.Context, header http.Header) context.Context {
+	md := make(metadata.MD, len(header))
+	for key, values := range header {
+		md[strings.ToLower(key)] = values
+	}
+
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// headerFromIncoming convert the incoming metadata to the request header.
+func headerFromIncoming(ctx context.Context) http.Header {
+	md, _ := metadata.FromIncomingContext(ctx)
+	header := make(http.Header, len(md))
+	for key, values := range md {
+		header[textproto.CanonicalMIMEHeaderKey(key)] = values
+	}
+
+	return header
+}
+
+// toStatus convert the error to grpc status error, and set the hcm error code to the trailer.
+func toStatus(ctx context.Context, err error) error {
+	ef := errf.Error(err)
+	if trailerErr := grpc.SetTrailer(ctx, metadata.Pairs(errCodeKey, strconv.Itoa(int(ef.Code)))); trailerErr != nil {
+		logs.Errorf("set grpc error code trailer failed, err: %v, origin err: %v", trailerErr, err)
+	}
+
+	return status.Error(codes.Unknown, ef.Message)
+}
+
+// fromStatus convert the grpc status error to hcm error with the error code in trailer.
+func fromStatus(err error, trailer metadata.MD) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	errCodes := trailer.Get(errCodeKey)
+	if len(errCodes) == 0 {
+		return fmt.Errorf("grpc call failed, code: %s, err: %s", st.Code(), st.Message())
+	}
+
+	code, parseErr := strconv.ParseInt(errCodes[0], 10, 32)
+	if parseErr != nil {
+		return fmt.Errorf("grpc call failed, invalid error code: %s, err: %s", errCodes[0], st.Message())
+	}
+
+	return errf.New(int32(code), st.Message())
+}
@@ -0,0 +1,101 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hcm/pkg/api/data-service/grpc/pb"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticServers []string
+
+// GetServers ...
+func (s staticServers) GetServers() ([]string, error) {
+	return s, nil
+}
+
+type fakeCvmServer struct {
+	pb.UnimplementedCvmServiceServer
+}
+
+// ListCvmExt return the request id and user of the kit as the cvm id and name.
+func (fakeCvmServer) ListCvmExt(ctx context.Context, req *pb.ListReq) (*pb.CvmListResult, error) {
+	kt, err := Kit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CvmListResult{Count: 1, Details: []*pb.Cvm{{Id: kt.Rid, Name: kt.User, Vendor: req.Vendor}}}, nil
+}
+
+// BatchUpdateCvm always return the invalid parameter error.
+func (fakeCvmServer) BatchUpdateCvm(context.Context, *pb.CvmBatchUpdateReq) (*pb.BatchUpdateResult, error) {
+	return nil, errf.New(errf.InvalidParameter, "cvm id is required")
+}
+
+func TestGrpcTransport(t *testing.T) {
+	opt := cc.Grpc{Enable: true, MaxMsgSizeMB: 16}
+	server := NewServer(opt)
+	pb.RegisterCvmServiceServer(server, fakeCvmServer{})
+
+	restful := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	ts := httptest.NewServer(Handler(server, restful))
+	defer ts.Close()
+
+	// restful requests are still served by the restful handler
+	resp, err := http.Get(ts.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTeapot, resp.StatusCode)
+
+	conn, err := NewClient(cc.DataServiceName, staticServers{ts.URL}, opt)
+	require.NoError(t, err)
+	defer conn.Close()
+	cli := pb.NewCvmServiceClient(conn)
+
+	kt := kit.New()
+	kt.User = "tester"
+	kt.AppCode = "test"
+	ctx := NewOutgoingContext(kt.Ctx, kt.Header())
+
+	result, err := cli.ListCvmExt(ctx, &pb.ListReq{Vendor: "tcloud"})
+	require.NoError(t, err)
+	require.Len(t, result.Details, 1)
+	assert.Equal(t, kt.Rid, result.Details[0].Id)
+	assert.Equal(t, "tester", result.Details[0].Name)
+	assert.Equal(t, "tcloud", result.Details[0].Vendor)
+
+	_, err = cli.BatchUpdateCvm(ctx, &pb.CvmBatchUpdateReq{})
+	require.Error(t, err)
+	ef := errf.Error(err)
+	assert.Equal(t, int32(errf.InvalidParameter), ef.Code)
+	assert.Equal(t, "cvm id is required", ef.Message)
+}
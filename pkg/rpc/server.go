@@ -0,0 +1,98 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strings"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+)
+
+// NewServer create a grpc server.
+func NewServer(opt cc.Grpc) *grpc.Server {
+	size := int(opt.MaxMsgSizeMB) << 20
+	return grpc.NewServer(
+		grpc.MaxRecvMsgSize(size),
+		grpc.MaxSendMsgSize(size),
+		grpc.ChainUnaryInterceptor(unaryServerInterceptor),
+	)
+}
+
+// Handler serve the grpc requests by the grpc server, and the other requests by the restful handler, the http/2
+// requests without tls are supported by h2c, so that grpc and restful api can be served on the same port.
+func Handler(server *grpc.Server, restful http.Handler) http.Handler {
+	return h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			server.ServeHTTP(w, r)
+			return
+		}
+
+		restful.ServeHTTP(w, r)
+	}), &http2.Server{})
+}
+
+func unaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (resp interface{}, err error) {
+
+	header := headerFromIncoming(ctx)
+	kt, err := kit.FromHeader(ctx, header)
+	if err != nil {
+		logs.Errorf("invalid grpc request for %s, err: %v, rid: %s", info.FullMethod, err,
+			header.Get(constant.RidKey))
+		return nil, toStatus(ctx, errf.NewFromErr(errf.InvalidParameter, err))
+	}
+
+	defer func() {
+		if fatalErr := recover(); fatalErr != nil {
+			logs.Errorf("[hcm server panic], err: %v, rid: %s, debug strace: %s", fatalErr, kt.Rid, debug.Stack())
+			resp, err = nil, toStatus(ctx, fmt.Errorf("panic err: %v", fatalErr))
+		}
+	}()
+
+	spanCtx, span := tracing.Start(kt.Ctx, info.FullMethod, trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("rpc.method", info.FullMethod), attribute.String("rid", kt.Rid)))
+	kt.Ctx = spanCtx
+
+	resp, err = handler(context.WithValue(spanCtx, kitCtxKey{}, kt), req)
+	tracing.End(span, err)
+	if err != nil {
+		if logs.V(2) {
+			logs.Errorf("do grpc request %s failed, err: %v, rid: %s", info.FullMethod, err, kt.Rid)
+		}
+		return nil, toStatus(ctx, err)
+	}
+
+	return resp, nil
+}
@@ -565,6 +565,10 @@ func (ar AtomRule) RuleField() string {
 
 // SQLExprAndValue convert this atom rule to a mysql's sub query expression, and field's value.
 func (ar AtomRule) SQLExprAndValue(opt *SQLWhereOption) (string, map[string]interface{}, error) {
+	if IsTagField(ar.Field) {
+		return ar.tagSQLExprAndValue(opt)
+	}
+
 	expr, value, err := ar.Op.Operator().SQLExprAndValue(ar.Field, ar.Value)
 	if err != nil {
 		return "", nil, err
@@ -0,0 +1,98 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+// TagFieldPrefix is the prefix of the tag rule's field, the rest of the field is the tag key,
+// e.g. {"field": "tag.env", "op": "eq", "value": "prod"} matches the resources with tag env=prod.
+// Note: tag key with JSONFieldSeparator is not supported.
+const TagFieldPrefix = "tag" + JSONFieldSeparator
+
+// TagOption defines the options to convert the tag rules to the sql expression, the tag rule is converted to
+// a semi-join sub query on the tag table by resource id, which is optimized by mysql as a join.
+type TagOption struct {
+	// Table is the tag table's name.
+	Table string
+	// ResType is the resource type of the queried resource in tag table.
+	ResType string
+}
+
+// Validate the tag option.
+func (opt *TagOption) Validate() error {
+	if len(opt.Table) == 0 {
+		return errors.New("tag table is required")
+	}
+
+	if len(opt.ResType) == 0 {
+		return errors.New("tag resource type is required")
+	}
+
+	return nil
+}
+
+// TagRuleFields allows the tag rule fields in the expression, it should be set after RuleFields.
+func TagRuleFields() ExprOptionFunc {
+	return func(opt *ExprOption) {
+		fields := make(map[string]enumor.ColumnType, len(opt.RuleFields)+1)
+		for field, typ := range opt.RuleFields {
+			fields[field] = typ
+		}
+		fields[TagFieldPrefix+WildcardPlaceholder] = enumor.String
+		opt.RuleFields = fields
+	}
+}
+
+// IsTagField test if the rule field is a tag field.
+func IsTagField(field string) bool {
+	return strings.HasPrefix(field, TagFieldPrefix) && len(field) > len(TagFieldPrefix)
+}
+
+// tagSQLExprAndValue convert the tag rule to the sql expression, the operator is applied to the tag value.
+func (ar AtomRule) tagSQLExprAndValue(opt *SQLWhereOption) (string, map[string]interface{}, error) {
+	if opt == nil || opt.TagOption == nil {
+		return "", nil, fmt.Errorf("tag field %s is not supported", ar.Field)
+	}
+
+	if err := opt.TagOption.Validate(); err != nil {
+		return "", nil, err
+	}
+
+	valueExpr, value, err := ar.Op.Operator().SQLExprAndValue("tag_value", ar.Value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	resTypePlaceholder := fieldPlaceholderName("res_type")
+	keyPlaceholder := fieldPlaceholderName("tag_key")
+	value[resTypePlaceholder] = opt.TagOption.ResType
+	value[keyPlaceholder] = strings.TrimPrefix(ar.Field, TagFieldPrefix)
+
+	expr := fmt.Sprintf(`id IN (SELECT res_id FROM %s WHERE res_type = %s%s AND tag_key = %s%s AND %s)`,
+		opt.TagOption.Table, SqlPlaceholder, resTypePlaceholder, SqlPlaceholder, keyPlaceholder, valueExpr)
+
+	return expr, value, nil
+}
@@ -0,0 +1,67 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package filter
+
+import (
+	"strings"
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestTagRule(t *testing.T) {
+	expr := &Expression{
+		Op: And,
+		Rules: []RuleFactory{
+			&AtomRule{Field: "name", Op: Equal.Factory(), Value: "sg"},
+			&AtomRule{Field: "tag.env", Op: Equal.Factory(), Value: "prod"},
+		},
+	}
+
+	exprOpt := NewExprOption(RuleFields(map[string]enumor.ColumnType{"name": enumor.String}), TagRuleFields())
+	if err := expr.Validate(exprOpt); err != nil {
+		t.Fatalf("validate tag rule failed, err: %v", err)
+	}
+
+	whereOpt := &SQLWhereOption{Priority: Priority{"id"}}
+	if _, _, err := expr.SQLWhereExpr(whereOpt); err == nil {
+		t.Fatalf("tag rule without tag option should be failed")
+	}
+
+	whereOpt.TagOption = &TagOption{Table: "resource_tag", ResType: "security_group"}
+	where, value, err := expr.SQLWhereExpr(whereOpt)
+	if err != nil {
+		t.Fatalf("generate tag rule sql failed, err: %v", err)
+	}
+
+	if !strings.Contains(where, "id IN (SELECT res_id FROM resource_tag WHERE res_type = :res_type_") {
+		t.Errorf("unexpected tag rule sql: %s", where)
+	}
+
+	values := make(map[string]bool)
+	for _, v := range value {
+		values[v.(string)] = true
+	}
+	for _, v := range []string{"sg", "prod", "env", "security_group"} {
+		if !values[v] {
+			t.Errorf("value %s is not found in %v", v, value)
+		}
+	}
+}
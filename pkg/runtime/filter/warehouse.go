@@ -68,6 +68,9 @@ type SQLWhereOption struct {
 	// field during query.
 	Priority      Priority
 	CrownedOption *CrownedOption
+	// TagOption defines how to convert the tag rules to the SQL expression, tag rules are not
+	// supported if it is not set.
+	TagOption *TagOption
 }
 
 // Validate the options is valid or not
@@ -67,7 +67,7 @@ func Init(disableTGW bool, pkPath string) error {
 func Parse(ctx context.Context, h http.Header) (*kit.Kit, error) {
 	kt, err := parser.Parse(ctx, h)
 	if err == nil {
-		context.WithValue(kt.Ctx, constant.RidKey, kt.Rid)
+		kt.Ctx = context.WithValue(kt.Ctx, constant.RidKey, kt.Rid)
 	}
 	return kt, err
 }
@@ -87,10 +87,11 @@ func (p *defaultParser) Parse(ctx context.Context, header http.Header) (*kit.Kit
 	}
 
 	kt := &kit.Kit{
-		Ctx:     ctx,
-		User:    header.Get(constant.UserKey),
-		Rid:     header.Get(constant.RidKey),
-		AppCode: header.Get(constant.AppCodeKey),
+		Ctx:      ctx,
+		User:     header.Get(constant.UserKey),
+		Rid:      header.Get(constant.RidKey),
+		AppCode:  header.Get(constant.AppCodeKey),
+		TenantID: header.Get(constant.TenantIDKey),
 	}
 
 	if err := kt.Validate(); err != nil {
@@ -122,15 +123,22 @@ func (p *jwtParser) Parse(ctx context.Context, header http.Header) (*kit.Kit, er
 		return nil, err
 	}
 
+	// 租户以网关认证的用户所属租户为准，请求头中的租户ID必须与之一致
+	tenantID, err := kit.MatchTenant(header.Get(constant.TenantIDKey), token.User.TenantID)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.PermissionDenied, err)
+	}
+
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
 	kt := &kit.Kit{
-		Ctx:     ctx,
-		User:    token.User.UserName,
-		AppCode: token.App.AppCode,
-		Rid:     header.Get(constant.RidKey),
+		Ctx:      ctx,
+		User:     token.User.UserName,
+		AppCode:  token.App.AppCode,
+		Rid:      header.Get(constant.RidKey),
+		TenantID: tenantID,
 	}
 
 	if err := kt.Validate(); err != nil {
@@ -160,6 +168,8 @@ type user struct {
 	Version  int64  `json:"version"`
 	UserName string `json:"username"`
 	Verified bool   `json:"verified"`
+	// TenantID is the tenant that the user belongs to, empty means the default tenant.
+	TenantID string `json:"tenant_id"`
 }
 
 // validate user.
@@ -0,0 +1,110 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gwparser
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"testing"
+	"time"
+
+	"hcm/pkg/criteria/constant"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// newTestParser returns a jwt parser and a function to sign the api gateway jwt token of the user's tenant.
+func newTestParser(t *testing.T) (*jwtParser, func(tenantID string) string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate rsa key failed, err: %v", err)
+	}
+
+	pubKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal public key failed, err: %v", err)
+	}
+	parser := &jwtParser{PublicKey: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubKey}))}
+
+	sign := func(tenantID string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, &claims{
+			App:  &app{AppCode: "test-app", Verified: true},
+			User: &user{UserName: "admin", Verified: true, TenantID: tenantID},
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			},
+		})
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatalf("sign jwt token failed, err: %v", err)
+		}
+		return signed
+	}
+
+	return parser, sign
+}
+
+func TestJWTParserTenant(t *testing.T) {
+	parser, sign := newTestParser(t)
+
+	cases := []struct {
+		name        string
+		userTenant  string
+		headerValue string
+		expected    string
+		wantErr     bool
+	}{
+		{name: "default tenant user", expected: constant.DefaultTenantID},
+		{name: "tenant user", userTenant: "tenant-a", expected: "tenant-a"},
+		{name: "header matches user", userTenant: "tenant-a", headerValue: "tenant-a", expected: "tenant-a"},
+		{name: "header of other tenant", userTenant: "tenant-a", headerValue: "tenant-b", wantErr: true},
+		{name: "default user claims other tenant", headerValue: "tenant-b", wantErr: true},
+	}
+
+	for _, c := range cases {
+		header := http.Header{}
+		header.Set(constant.BKGWJWTTokenKey, sign(c.userTenant))
+		header.Set(constant.RidKey, "0123456789abcdef0123")
+		if len(c.headerValue) != 0 {
+			header.Set(constant.TenantIDKey, c.headerValue)
+		}
+
+		kt, err := parser.Parse(context.Background(), header)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("%s: parse should be failed", c.name)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: parse failed, err: %v", c.name, err)
+			continue
+		}
+
+		if kt.TenantID != c.expected {
+			t.Errorf("%s: tenant id not match, got: %s, expected: %s", c.name, kt.TenantID, c.expected)
+		}
+	}
+}
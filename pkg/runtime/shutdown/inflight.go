@@ -0,0 +1,102 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package shutdown
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"hcm/pkg/criteria/constant"
+)
+
+// InFlight tracks the in-flight http requests, so that the shutdown process can report how many requests are
+// being drained and which of them are still unfinished when the drain times out.
+type InFlight struct {
+	lock     sync.Mutex
+	seq      uint64
+	requests map[uint64]inFlightRequest
+}
+
+type inFlightRequest struct {
+	method string
+	path   string
+	rid    string
+	start  time.Time
+}
+
+// NewInFlight new in-flight requests tracker.
+func NewInFlight() *InFlight {
+	return &InFlight{requests: make(map[uint64]inFlightRequest)}
+}
+
+// Wrap returns a handler that tracks the requests handled by next.
+func (f *InFlight) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.lock.Lock()
+		f.seq++
+		id := f.seq
+		f.requests[id] = inFlightRequest{
+			method: r.Method,
+			path:   r.URL.Path,
+			rid:    r.Header.Get(constant.RidKey),
+			start:  time.Now(),
+		}
+		f.lock.Unlock()
+
+		defer func() {
+			f.lock.Lock()
+			delete(f.requests, id)
+			f.lock.Unlock()
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Count returns the count of in-flight requests.
+func (f *InFlight) Count() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return len(f.requests)
+}
+
+// Unfinished returns the description of in-flight requests, sorted by start time.
+func (f *InFlight) Unfinished() []string {
+	f.lock.Lock()
+	list := make([]inFlightRequest, 0, len(f.requests))
+	for _, one := range f.requests {
+		list = append(list, one)
+	}
+	f.lock.Unlock()
+
+	sort.Slice(list, func(i, j int) bool { return list[i].start.Before(list[j].start) })
+
+	result := make([]string, 0, len(list))
+	for _, one := range list {
+		result = append(result, fmt.Sprintf("%s %s, rid: %s, cost: %s", one.method, one.path, one.rid,
+			time.Since(one.start).Truncate(time.Second)))
+	}
+
+	return result
+}
@@ -0,0 +1,63 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package shutdown
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"hcm/pkg/criteria/constant"
+)
+
+func TestInFlight(t *testing.T) {
+	f := NewInFlight()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := f.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/hc/vendors/tcloud/cvms/create", nil)
+	req.Header.Set(constant.RidKey, "rid-1")
+	done := make(chan struct{})
+	go func() {
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		close(done)
+	}()
+
+	<-started
+	if f.Count() != 1 {
+		t.Fatalf("in-flight count should be 1, but got %d", f.Count())
+	}
+
+	unfinished := f.Unfinished()
+	if len(unfinished) != 1 || !strings.Contains(unfinished[0], "rid: rid-1") {
+		t.Fatalf("unexpected unfinished requests: %v", unfinished)
+	}
+
+	close(release)
+	<-done
+	if f.Count() != 0 {
+		t.Fatalf("in-flight count should be 0, but got %d", f.Count())
+	}
+}
@@ -0,0 +1,310 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package scheduler runs periodic jobs exactly once across all replicas of a service. jobs are only executed by the
+// master instance elected by service discovery, and the last run of every job is recorded in a shared store, so that
+// a new master does not run a job again before its next run time after the master changes.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/serviced"
+)
+
+// maxCheckInterval is the max interval to check if a job is due.
+const maxCheckInterval = time.Minute
+
+// Job defines a periodic job.
+type Job struct {
+	// Name 任务名称，同一个调度器中唯一
+	Name string
+	// Interval 任务执行间隔
+	Interval time.Duration
+	// Run 任务执行函数
+	Run func(kt *kit.Kit) error
+}
+
+// Validate Job.
+func (j Job) Validate() error {
+	if len(j.Name) == 0 {
+		return errors.New("job name is required")
+	}
+
+	if j.Interval <= 0 {
+		return fmt.Errorf("job %s interval must be positive", j.Name)
+	}
+
+	if j.Run == nil {
+		return fmt.Errorf("job %s run func is required", j.Name)
+	}
+
+	return nil
+}
+
+// RunState is job run state.
+type RunState string
+
+const (
+	// Running job is running.
+	Running RunState = "running"
+	// Success job run succeeded.
+	Success RunState = "success"
+	// Failed job run failed.
+	Failed RunState = "failed"
+)
+
+// RunRecord is the last run record of a job.
+type RunRecord struct {
+	Name    string    `json:"name"`
+	State   RunState  `json:"state"`
+	Node    string    `json:"node"`
+	Rid     string    `json:"rid"`
+	StartAt time.Time `json:"start_at"`
+	EndAt   time.Time `json:"end_at,omitempty"`
+	Reason  string    `json:"reason,omitempty"`
+}
+
+// JobStatus is job registry info with its last run record.
+type JobStatus struct {
+	Name     string        `json:"name"`
+	Interval time.Duration `json:"interval"`
+	LastRun  *RunRecord    `json:"last_run,omitempty"`
+}
+
+// Scheduler schedules registered jobs on the master instance.
+type Scheduler struct {
+	state serviced.State
+	store Store
+	// node current instance's host name, recorded in run records.
+	node string
+
+	lock    sync.RWMutex
+	jobs    map[string]Job
+	started bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// New create a scheduler.
+func New(state serviced.State, store Store) *Scheduler {
+	node, err := os.Hostname()
+	if err != nil {
+		logs.Errorf("get host name for scheduler failed, err: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		state:  state,
+		store:  store,
+		node:   node,
+		jobs:   make(map[string]Job),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Register register a job, jobs can not be registered after the scheduler is started.
+func (s *Scheduler) Register(job Job) error {
+	if err := job.Validate(); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.started {
+		return fmt.Errorf("scheduler is started, can not register job %s", job.Name)
+	}
+
+	if _, exists := s.jobs[job.Name]; exists {
+		return fmt.Errorf("job %s is already registered", job.Name)
+	}
+
+	s.jobs[job.Name] = job
+	logs.Infof("scheduler job %s registered, interval: %v", job.Name, job.Interval)
+	return nil
+}
+
+// UpdateInterval update the interval of the registered job, it takes effect on the next due check.
+func (s *Scheduler) UpdateInterval(name string, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("job %s interval must be positive", name)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	job, exists := s.jobs[name]
+	if !exists {
+		return fmt.Errorf("job %s is not registered", name)
+	}
+
+	if job.Interval != interval {
+		logs.Infof("scheduler job %s interval is updated from %v to %v", name, job.Interval, interval)
+		job.Interval = interval
+		s.jobs[name] = job
+	}
+	return nil
+}
+
+// Start start to schedule all registered jobs, every job is scheduled in its own goroutine.
+func (s *Scheduler) Start() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.started {
+		return
+	}
+	s.started = true
+
+	for _, job := range s.jobs {
+		go s.loop(job)
+	}
+}
+
+// Stop stop scheduling jobs, running jobs are not interrupted.
+func (s *Scheduler) Stop() {
+	s.cancel()
+}
+
+// Status return all registered jobs with their last run records.
+func (s *Scheduler) Status(kt *kit.Kit) ([]JobStatus, error) {
+	s.lock.RLock()
+	jobs := make([]Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, job)
+	}
+	s.lock.RUnlock()
+
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Name < jobs[j].Name })
+
+	status := make([]JobStatus, 0, len(jobs))
+	for _, job := range jobs {
+		record, err := s.store.Get(kt.Ctx, job.Name)
+		if err != nil {
+			return nil, err
+		}
+		status = append(status, JobStatus{Name: job.Name, Interval: job.Interval, LastRun: record})
+	}
+
+	return status, nil
+}
+
+func (s *Scheduler) loop(job Job) {
+	checkInterval := job.Interval
+	if checkInterval > maxCheckInterval {
+		checkInterval = maxCheckInterval
+	}
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			logs.Infof("scheduler stopped, job %s exit", job.Name)
+			return
+		case <-ticker.C:
+		}
+
+		if !s.state.IsMaster() {
+			continue
+		}
+
+		// 任务间隔可能被更新，每次检查前获取最新的任务
+		s.lock.RLock()
+		job = s.jobs[job.Name]
+		s.lock.RUnlock()
+
+		s.tryRun(job, time.Now())
+	}
+}
+
+// tryRun run the job if it is due, returns if the job is run.
+func (s *Scheduler) tryRun(job Job, now time.Time) bool {
+	kt := core.NewBackendKit()
+
+	last, err := s.store.Get(kt.Ctx, job.Name)
+	if err != nil {
+		logs.Errorf("get scheduler job %s last run record failed, err: %v, rid: %s", job.Name, err, kt.Rid)
+		return false
+	}
+
+	if !isDue(job, last, now) {
+		return false
+	}
+
+	record := &RunRecord{Name: job.Name, State: Running, Node: s.node, Rid: kt.Rid, StartAt: now}
+	if err = s.store.Save(kt.Ctx, record); err != nil {
+		logs.Errorf("save scheduler job %s run record failed, err: %v, rid: %s", job.Name, err, kt.Rid)
+		return false
+	}
+
+	logs.Infof("scheduler job %s start, rid: %s", job.Name, kt.Rid)
+	runErr := runJob(kt, job)
+
+	record.EndAt = time.Now()
+	record.State = Success
+	if runErr != nil {
+		record.State = Failed
+		record.Reason = runErr.Error()
+		logs.Errorf("scheduler job %s failed, err: %v, cost: %v, rid: %s", job.Name, runErr,
+			record.EndAt.Sub(record.StartAt), kt.Rid)
+	} else {
+		logs.Infof("scheduler job %s end, cost: %v, rid: %s", job.Name, record.EndAt.Sub(record.StartAt), kt.Rid)
+	}
+
+	if err = s.store.Save(kt.Ctx, record); err != nil {
+		logs.Errorf("save scheduler job %s run record failed, err: %v, rid: %s", job.Name, err, kt.Rid)
+	}
+
+	return true
+}
+
+// runJob run the job, panic of the job is recovered and returned as error.
+func runJob(kt *kit.Kit, job Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panic: %v", r)
+		}
+	}()
+
+	return job.Run(kt)
+}
+
+// isDue check if the job is due to run, a job is due when it has never run, or the interval since its last start has
+// elapsed, no matter which instance started it.
+func isDue(job Job, last *RunRecord, now time.Time) bool {
+	if last == nil {
+		return true
+	}
+
+	return now.Sub(last.StartAt) >= job.Interval
+}
@@ -0,0 +1,94 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"hcm/pkg/kit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeState struct {
+	master bool
+}
+
+func (f *fakeState) IsMaster() bool {
+	return f.master
+}
+
+func (f *fakeState) DisableMasterSlave(disable bool) {
+	f.master = !disable
+}
+
+func TestRegister(t *testing.T) {
+	s := New(&fakeState{master: true}, NewMemoryStore())
+	job := Job{Name: "test", Interval: time.Minute, Run: func(kt *kit.Kit) error { return nil }}
+
+	assert.NoError(t, s.Register(job))
+	assert.Error(t, s.Register(job), "duplicate job should be rejected")
+	assert.Error(t, s.Register(Job{Name: "no_interval", Run: job.Run}))
+	assert.Error(t, s.Register(Job{Name: "no_run", Interval: time.Minute}))
+
+	s.Start()
+	defer s.Stop()
+	assert.Error(t, s.Register(Job{Name: "after_start", Interval: time.Minute, Run: job.Run}))
+
+	assert.NoError(t, s.UpdateInterval("test", time.Hour))
+	assert.Equal(t, time.Hour, s.jobs["test"].Interval)
+	assert.Error(t, s.UpdateInterval("test", 0))
+	assert.Error(t, s.UpdateInterval("not_exist", time.Hour))
+}
+
+func TestTryRun(t *testing.T) {
+	store := NewMemoryStore()
+	first := New(&fakeState{master: true}, store)
+	first.node = "node-1"
+	second := New(&fakeState{master: true}, store)
+	second.node = "node-2"
+
+	count := 0
+	job := Job{Name: "test", Interval: time.Hour, Run: func(kt *kit.Kit) error {
+		count++
+		return errors.New("run failed")
+	}}
+
+	now := time.Now()
+	assert.True(t, first.tryRun(job, now))
+	// 其他实例成为主节点后，未到下次执行时间时不会重复执行
+	assert.False(t, second.tryRun(job, now.Add(time.Minute)))
+	assert.True(t, second.tryRun(job, now.Add(time.Hour)))
+	assert.Equal(t, 2, count)
+
+	record, err := store.Get(context.Background(), job.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, Failed, record.State)
+	assert.Equal(t, "node-2", record.Node)
+	assert.Equal(t, "run failed", record.Reason)
+}
+
+func TestRunJobPanic(t *testing.T) {
+	job := Job{Name: "panic", Interval: time.Minute, Run: func(kt *kit.Kit) error { panic("boom") }}
+	assert.Error(t, runJob(kit.New(), job))
+}
@@ -0,0 +1,118 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sync"
+
+	etcd3 "go.etcd.io/etcd/client/v3"
+)
+
+// Store stores the last run record of jobs.
+type Store interface {
+	// Get get the last run record of the job, return nil if the job has never run.
+	Get(ctx context.Context, name string) (*RunRecord, error)
+	// Save save the run record of the job.
+	Save(ctx context.Context, record *RunRecord) error
+}
+
+// NewEtcdStore create a store which saves run records in etcd under the prefix, so that all replicas of a service
+// share the run records.
+func NewEtcdStore(cli *etcd3.Client, prefix string) Store {
+	return &etcdStore{cli: cli, prefix: prefix}
+}
+
+type etcdStore struct {
+	cli    *etcd3.Client
+	prefix string
+}
+
+// Get the last run record of the job from etcd.
+func (e *etcdStore) Get(ctx context.Context, name string) (*RunRecord, error) {
+	resp, err := e.cli.Get(ctx, e.key(name))
+	if err != nil {
+		return nil, fmt.Errorf("get job %s run record from etcd failed, err: %v", name, err)
+	}
+
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+
+	record := new(RunRecord)
+	if err = json.Unmarshal(resp.Kvs[0].Value, record); err != nil {
+		return nil, fmt.Errorf("unmarshal job %s run record failed, err: %v", name, err)
+	}
+
+	return record, nil
+}
+
+// Save the run record of the job to etcd.
+func (e *etcdStore) Save(ctx context.Context, record *RunRecord) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	if _, err = e.cli.Put(ctx, e.key(record.Name), string(value)); err != nil {
+		return fmt.Errorf("put job %s run record to etcd failed, err: %v", record.Name, err)
+	}
+
+	return nil
+}
+
+func (e *etcdStore) key(name string) string {
+	return path.Join(e.prefix, name)
+}
+
+// NewMemoryStore create a store which saves run records in memory, it is used for single instance deployment.
+func NewMemoryStore() Store {
+	return &memoryStore{records: make(map[string]RunRecord)}
+}
+
+type memoryStore struct {
+	lock    sync.RWMutex
+	records map[string]RunRecord
+}
+
+// Get the last run record of the job from memory.
+func (m *memoryStore) Get(_ context.Context, name string) (*RunRecord, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	record, exists := m.records[name]
+	if !exists {
+		return nil, nil
+	}
+
+	return &record, nil
+}
+
+// Save the run record of the job to memory.
+func (m *memoryStore) Save(_ context.Context, record *RunRecord) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.records[record.Name] = *record
+	return nil
+}
@@ -44,6 +44,8 @@ type Service interface {
 	Register() error
 	// Deregister the service
 	Deregister() error
+	// CheckRegistered checks if the service is registered and the registration still exists in etcd.
+	CheckRegistered(ctx context.Context) error
 	State
 }
 
@@ -241,6 +243,24 @@ func (s *service) Deregister() error {
 	return nil
 }
 
+// CheckRegistered checks if the service is registered and the registration still exists in etcd.
+func (s *service) CheckRegistered(ctx context.Context) error {
+	if !s.isRegistered() {
+		return errors.New("service is not registered")
+	}
+
+	resp, err := s.cli.Get(ctx, s.key, etcd3.WithCountOnly())
+	if err != nil {
+		return fmt.Errorf("get service registration from etcd failed, err: %v", err)
+	}
+
+	if resp.Count == 0 {
+		return fmt.Errorf("service registration %s not found in etcd", s.key)
+	}
+
+	return nil
+}
+
 // IsMaster test if this service instance is
 // master or not.
 func (s *service) IsMaster() bool {
@@ -0,0 +1,110 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package bkmonitor is the client of BlueKing monitor custom metrics http report service.
+package bkmonitor
+
+import (
+	"fmt"
+	"net/http"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+	"hcm/pkg/rest/client"
+	apigateway "hcm/pkg/thirdparty/api-gateway"
+	"hcm/pkg/tools/ssl"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Client bk monitor client
+type Client interface {
+	ReportMetrics(kt *kit.Kit, data []MetricData) error
+}
+
+// NewClient return a new bk monitor client
+func NewClient(cfg *cc.BkMonitor, reg prometheus.Registerer) (Client, error) {
+	tls := &ssl.TLSConfig{
+		InsecureSkipVerify: cfg.TLS.InsecureSkipVerify,
+		CertFile:           cfg.TLS.CertFile,
+		KeyFile:            cfg.TLS.KeyFile,
+		CAFile:             cfg.TLS.CAFile,
+		Password:           cfg.TLS.Password,
+	}
+	cli, err := client.NewClient(tls)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &client.Capability{
+		Client: cli,
+		Discover: &apigateway.Discovery{
+			Name:    "bkmonitor",
+			Servers: cfg.Endpoints,
+		},
+		MetricOpts: client.MetricOption{Register: reg},
+	}
+	restCli := rest.NewClient(c, "/v2")
+	return &bkMonitor{
+		client:      restCli,
+		dataID:      cfg.DataID,
+		accessToken: cfg.AccessToken,
+	}, nil
+}
+
+type bkMonitor struct {
+	client      rest.ClientInterface
+	dataID      int64
+	accessToken string
+}
+
+// ReportMetrics report custom metrics to the data id of bk monitor.
+func (b *bkMonitor) ReportMetrics(kt *kit.Kit, data []MetricData) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	req := &reportReq{
+		DataID:      b.dataID,
+		AccessToken: b.accessToken,
+		Data:        data,
+	}
+
+	header := http.Header{}
+	header.Set(constant.RidKey, kt.Rid)
+
+	resp := new(reportResp)
+	err := b.client.Post().
+		SubResourcef("/push/").
+		WithContext(kt.Ctx).
+		WithHeaders(header).
+		Body(req).
+		Do().Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != successCode {
+		return fmt.Errorf("report metrics to bk monitor failed, code: %s, msg: %s", resp.Code, resp.Message)
+	}
+
+	return nil
+}
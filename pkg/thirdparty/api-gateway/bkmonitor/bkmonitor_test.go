@@ -0,0 +1,90 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bkmonitor
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/kit"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T, code string, received *reportReq) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v2/push/", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(received))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(reportResp{Code: code, Result: "true", Message: "msg"})
+	}))
+}
+
+func newTestClient(t *testing.T, endpoint string) Client {
+	cfg := &cc.BkMonitor{Endpoints: []string{endpoint}, DataID: 100, AccessToken: "token"}
+	cli, err := NewClient(cfg, prometheus.NewRegistry())
+	assert.NoError(t, err)
+	return cli
+}
+
+func TestReportMetrics(t *testing.T) {
+	received := new(reportReq)
+	server := newTestServer(t, successCode, received)
+	defer server.Close()
+
+	data := []MetricData{{
+		Metrics:   map[string]float64{"hcm_resource_count": 3},
+		Target:    "hcm",
+		Dimension: map[string]string{"vendor": "tcloud"},
+		Timestamp: 1000,
+	}}
+	err := newTestClient(t, server.URL).ReportMetrics(kit.New(), data)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), received.DataID)
+	assert.Equal(t, "token", received.AccessToken)
+	assert.Equal(t, data, received.Data)
+}
+
+func TestReportMetricsFailed(t *testing.T) {
+	server := newTestServer(t, "500", new(reportReq))
+	defer server.Close()
+
+	data := []MetricData{{Metrics: map[string]float64{"hcm_resource_count": 3}, Target: "hcm"}}
+	err := newTestClient(t, server.URL).ReportMetrics(kit.New(), data)
+	assert.Error(t, err)
+}
+
+func TestReportEmptyMetrics(t *testing.T) {
+	requested := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+	}))
+	defer server.Close()
+
+	// empty metrics are not reported.
+	assert.NoError(t, newTestClient(t, server.URL).ReportMetrics(kit.New(), nil))
+	assert.False(t, requested)
+}
@@ -0,0 +1,49 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bkmonitor
+
+// successCode is the code of bk monitor report response when succeeded.
+const successCode = "200"
+
+// MetricData is one group of custom metrics with the same dimensions.
+type MetricData struct {
+	// Metrics metric name -> metric value
+	Metrics map[string]float64 `json:"metrics"`
+	// Target 上报的目标，一般为上报来源的标识
+	Target string `json:"target"`
+	// Dimension 指标的维度
+	Dimension map[string]string `json:"dimension"`
+	// Timestamp 指标的时间，单位毫秒
+	Timestamp int64 `json:"timestamp"`
+}
+
+// reportReq is bk monitor custom metrics report request.
+type reportReq struct {
+	DataID      int64        `json:"data_id"`
+	AccessToken string       `json:"access_token"`
+	Data        []MetricData `json:"data"`
+}
+
+// reportResp is bk monitor custom metrics report response.
+type reportResp struct {
+	Code    string `json:"code"`
+	Result  string `json:"result"`
+	Message string `json:"message"`
+}
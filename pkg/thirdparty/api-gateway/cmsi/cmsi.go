@@ -36,6 +36,7 @@ import (
 // Client cmsi client
 type Client interface {
 	SendMail(kt *kit.Kit, m *CmsiMail) (err error)
+	SendWeixin(kt *kit.Kit, m *CmsiWeixin) (err error)
 }
 
 // NewClient return a new cmsi client
@@ -0,0 +1,59 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cmsi
+
+import (
+	"fmt"
+
+	"hcm/pkg/kit"
+	apigateway "hcm/pkg/thirdparty/api-gateway"
+)
+
+// CmsiWeixin 企业微信消息
+type CmsiWeixin struct {
+	Receiver         string         `json:"receiver,omitempty"`
+	ReceiverUserName string         `json:"receiver__username,omitempty"`
+	Data             CmsiWeixinData `json:"data"`
+}
+
+// CmsiWeixinData 企业微信消息内容
+type CmsiWeixinData struct {
+	Heading string `json:"heading"`
+	Message string `json:"message"`
+}
+
+// SendWeixin 发送企业微信消息
+func (c *cmsi) SendWeixin(kt *kit.Kit, req *CmsiWeixin) error {
+	resp := new(apigateway.BaseResponse)
+	err := c.client.Post().
+		SubResourcef("/send_weixin").
+		WithContext(kt.Ctx).
+		WithHeaders(c.header(kt)).
+		Body(req).
+		Do().Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if !resp.Result || resp.Code != 0 {
+		return fmt.Errorf("send weixin failed, code: %d, msg: %s", resp.Code, resp.Message)
+	}
+	return nil
+}
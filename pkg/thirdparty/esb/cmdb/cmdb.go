@@ -32,6 +32,7 @@ type Client interface {
 	SearchCloudArea(kt *kit.Kit, params *SearchCloudAreaParams) (*SearchCloudAreaResult, error)
 	AddCloudHostToBiz(kt *kit.Kit, params *AddCloudHostToBizParams) (*BatchCreateResult, error)
 	DeleteCloudHostFromBiz(kt *kit.Kit, params *DeleteCloudHostFromBizParams) error
+	TransferHostModule(kt *kit.Kit, params *TransferHostModuleParams) error
 	ListBizHost(kt *kit.Kit, params *ListBizHostParams) (*ListBizHostResult, error)
 	GetBizBriefCacheTopo(kt *kit.Kit, params *GetBizBriefCacheTopoParams) (*GetBizBriefCacheTopoResult, error)
 	FindHostTopoRelation(kt *kit.Kit, params *FindHostTopoRelationParams) (*HostTopoRelationResult, error)
@@ -85,6 +86,13 @@ func (c *cmdb) DeleteCloudHostFromBiz(kt *kit.Kit, params *DeleteCloudHostFromBi
 	return err
 }
 
+// TransferHostModule transfer cmdb hosts to modules in the same biz.
+func (c *cmdb) TransferHostModule(kt *kit.Kit, params *TransferHostModuleParams) error {
+	_, err := types.EsbCall[TransferHostModuleParams, struct{}](c.client, c.config, rest.POST, kt, params,
+		"/cc/transfer_host_module/")
+	return err
+}
+
 // ListBizHost list cmdb host in biz.
 func (c *cmdb) ListBizHost(kt *kit.Kit, params *ListBizHostParams) (*ListBizHostResult, error) {
 
@@ -208,6 +208,15 @@ type DeleteCloudHostFromBizParams struct {
 	HostIDs []int64 `json:"bk_host_ids"`
 }
 
+// TransferHostModuleParams is esb transfer host module parameter.
+type TransferHostModuleParams struct {
+	BizID     int64   `json:"bk_biz_id"`
+	HostIDs   []int64 `json:"bk_host_id"`
+	ModuleIDs []int64 `json:"bk_module_id"`
+	// IsIncrement 为true时在原有模块基础上增加，为false时覆盖原有模块
+	IsIncrement bool `json:"is_increment"`
+}
+
 // esbListBizHostParams is esb list cmdb host in biz parameter.
 type esbListBizHostParams struct {
 	*types.CommParams
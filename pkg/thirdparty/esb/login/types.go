@@ -33,5 +33,7 @@ type IsLoginResp struct {
 	types.BaseResponse `json:",inline"`
 	Data               struct {
 		Username string `json:"bk_username"`
+		// TenantID 用户所属的租户，未开启多租户的登录服务不返回该字段
+		TenantID string `json:"tenant_id"`
 	} `json:"data"`
 }
@@ -168,6 +168,42 @@ func NextAvailableNet(outer net.IPNet, used []net.IPNet, masklen int) (net.IPNet
 
 }
 
+// Remap 将source网段内的IPv4网段平移到target网段内对应的位置，source与target的掩码长度需要一致，
+// 如 source 10.0.0.0/16，target 172.16.0.0/16 时，10.0.1.0/24 映射为 172.16.1.0/24
+func Remap(cidr, source, target string) (string, error) {
+	_, sourceNet, err := net.ParseCIDR(source)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse source cidr: %w", err)
+	}
+
+	_, targetNet, err := net.ParseCIDR(target)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse target cidr: %w", err)
+	}
+
+	sourceOnes, sourceBits := sourceNet.Mask.Size()
+	targetOnes, targetBits := targetNet.Mask.Size()
+	if sourceBits != 32 || targetBits != 32 || sourceOnes != targetOnes {
+		return "", fmt.Errorf("source cidr[%s] and target cidr[%s] should be ipv4 with same mask length", source,
+			target)
+	}
+
+	if err = IsSubnetContained(source, cidr); err != nil {
+		return "", err
+	}
+
+	_, childNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse cidr: %w", err)
+	}
+
+	offset := binary.BigEndian.Uint32(childNet.IP.To4()) - binary.BigEndian.Uint32(sourceNet.IP.To4())
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, binary.BigEndian.Uint32(targetNet.IP.To4())+offset)
+
+	return (&net.IPNet{IP: ip, Mask: childNet.Mask}).String(), nil
+}
+
 // IsIPv4 检查字符串是否包含 IPv4 地址
 func IsIPv4(s string) bool {
 	ip := net.ParseIP(s)
@@ -183,3 +183,34 @@ func TestNextAvailableNetByIPNum(t *testing.T) {
 
 	}
 }
+
+func TestRemap(t *testing.T) {
+	cases := []struct {
+		cidr   string
+		source string
+		target string
+		want   string
+		err    bool
+	}{
+		{"10.0.1.0/24", "10.0.0.0/16", "172.16.0.0/16", "172.16.1.0/24", false},
+		{"10.0.0.0/16", "10.0.0.0/16", "172.16.0.0/16", "172.16.0.0/16", false},
+		{"10.0.255.128/25", "10.0.0.0/16", "10.1.0.0/16", "10.1.255.128/25", false},
+		{"10.1.0.0/24", "10.0.0.0/16", "172.16.0.0/16", "", true},
+		{"10.0.0.0/8", "10.0.0.0/16", "172.16.0.0/16", "", true},
+		{"10.0.1.0/24", "10.0.0.0/16", "172.16.0.0/20", "", true},
+	}
+	for _, c := range cases {
+		t.Run(c.cidr+"->"+c.target, func(t *testing.T) {
+			got, err := Remap(c.cidr, c.source, c.target)
+			if c.err {
+				if err == nil {
+					t.Errorf("except error got %s", got)
+				}
+				return
+			}
+			if err != nil || got != c.want {
+				t.Errorf("except %s got %s, err: %v", c.want, got, err)
+			}
+		})
+	}
+}
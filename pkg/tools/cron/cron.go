@@ -0,0 +1,202 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package cron 解析标准的5段式cron表达式（分 时 日 月 周），计算下一次触发时间
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears 计算下一次触发时间时最多向后查找的年数，超出说明表达式无法触发(如2月30日)
+const maxSearchYears = 5
+
+type bounds struct {
+	min, max uint
+}
+
+var (
+	minuteBounds = bounds{min: 0, max: 59}
+	hourBounds   = bounds{min: 0, max: 23}
+	domBounds    = bounds{min: 1, max: 31}
+	monthBounds  = bounds{min: 1, max: 12}
+	// dowBounds 0和7都表示周日
+	dowBounds = bounds{min: 0, max: 7}
+)
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar, dowStar 日、周字段是否为 *，两者都有限制时满足其一即可，与标准cron一致
+	domStar, dowStar bool
+}
+
+// Parse parse cron expression with 5 fields: minute hour day-of-month month day-of-week, each field supports
+// "*", "a", "a-b", "*/n", "a-b/n" and comma separated list of them.
+func Parse(spec string) (*Schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression should have 5 fields, but got %d: %s", len(fields), spec)
+	}
+
+	var err error
+	s := new(Schedule)
+	if s.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, fmt.Errorf("invalid minute field, err: %v", err)
+	}
+	if s.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, fmt.Errorf("invalid hour field, err: %v", err)
+	}
+	if s.dom, err = parseField(fields[2], domBounds); err != nil {
+		return nil, fmt.Errorf("invalid day of month field, err: %v", err)
+	}
+	if s.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, fmt.Errorf("invalid month field, err: %v", err)
+	}
+	if s.dow, err = parseField(fields[4], dowBounds); err != nil {
+		return nil, fmt.Errorf("invalid day of week field, err: %v", err)
+	}
+	// 7 表示周日，统一转换为 0
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = fields[2] == "*"
+	s.dowStar = fields[4] == "*"
+
+	return s, nil
+}
+
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, expr := range strings.Split(field, ",") {
+		rangeExpr, step := expr, uint64(1)
+		if idx := strings.Index(expr, "/"); idx >= 0 {
+			val, err := strconv.ParseUint(expr[idx+1:], 10, 8)
+			if err != nil || val == 0 {
+				return 0, fmt.Errorf("invalid step: %s", expr)
+			}
+			rangeExpr, step = expr[:idx], val
+		}
+
+		start, end := b.min, b.max
+		switch {
+		case rangeExpr == "*":
+		case strings.Contains(rangeExpr, "-"):
+			parts := strings.SplitN(rangeExpr, "-", 2)
+			var err error
+			if start, err = parseValue(parts[0], b); err != nil {
+				return 0, err
+			}
+			if end, err = parseValue(parts[1], b); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid range: %s", rangeExpr)
+			}
+		default:
+			val, err := parseValue(rangeExpr, b)
+			if err != nil {
+				return 0, err
+			}
+			start = val
+			// a/n 表示从 a 开始到最大值，每隔 n 触发
+			if step == 1 {
+				end = val
+			}
+		}
+
+		for i := start; i <= end; i += uint(step) {
+			bits |= 1 << i
+		}
+	}
+
+	return bits, nil
+}
+
+func parseValue(value string, b bounds) (uint, error) {
+	val, err := strconv.ParseUint(value, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value: %s", value)
+	}
+	if uint(val) < b.min || uint(val) > b.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", val, b.min, b.max)
+	}
+	return uint(val), nil
+}
+
+// Next returns the next activation time after the given time, in the location of the given time.
+// zero time is returned if the schedule can not be activated in the next few years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	yearLimit := t.Year() + maxSearchYears
+
+WRAP:
+	for t.Year() <= yearLimit {
+		for s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			if t.Month() == time.January {
+				continue WRAP
+			}
+		}
+
+		for !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			if t.Day() == 1 {
+				continue WRAP
+			}
+		}
+
+		for s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			if t.Hour() == 0 {
+				continue WRAP
+			}
+		}
+
+		for s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			if t.Minute() == 0 {
+				continue WRAP
+			}
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// Between returns whether the schedule is activated in (from, to].
+func (s *Schedule) Between(from, to time.Time) bool {
+	next := s.Next(from)
+	return !next.IsZero() && !next.After(to)
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
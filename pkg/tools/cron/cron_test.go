@@ -0,0 +1,109 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInvalid(t *testing.T) {
+	specs := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+	}
+	for _, spec := range specs {
+		_, err := Parse(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestNext(t *testing.T) {
+	loc := time.FixedZone("CST", 8*3600)
+	testCases := []struct {
+		spec string
+		from time.Time
+		next time.Time
+	}{
+		{
+			// 工作日晚上9点
+			spec: "0 21 * * 1-5",
+			from: time.Date(2025, 4, 11, 21, 0, 0, 0, loc),
+			next: time.Date(2025, 4, 14, 21, 0, 0, 0, loc),
+		},
+		{
+			spec: "30 8 * * 1-5",
+			from: time.Date(2025, 4, 14, 8, 29, 59, 0, loc),
+			next: time.Date(2025, 4, 14, 8, 30, 0, 0, loc),
+		},
+		{
+			spec: "*/15 * * * *",
+			from: time.Date(2025, 4, 14, 23, 50, 0, 0, loc),
+			next: time.Date(2025, 4, 15, 0, 0, 0, 0, loc),
+		},
+		{
+			// 周日用7表示
+			spec: "0 0 * * 7",
+			from: time.Date(2025, 4, 14, 0, 0, 0, 0, loc),
+			next: time.Date(2025, 4, 20, 0, 0, 0, 0, loc),
+		},
+		{
+			spec: "0 0 29 2 *",
+			from: time.Date(2025, 1, 1, 0, 0, 0, 0, loc),
+			next: time.Date(2028, 2, 29, 0, 0, 0, 0, loc),
+		},
+		{
+			// 日和周都有限制时满足其一即可
+			spec: "0 0 1 * 1",
+			from: time.Date(2025, 4, 14, 0, 0, 0, 0, loc),
+			next: time.Date(2025, 4, 21, 0, 0, 0, 0, loc),
+		},
+		{
+			spec: "0 0 31 2 *",
+			from: time.Date(2025, 1, 1, 0, 0, 0, 0, loc),
+			next: time.Time{},
+		},
+	}
+	for _, testCase := range testCases {
+		s, err := Parse(testCase.spec)
+		assert.NoError(t, err, testCase.spec)
+		assert.True(t, testCase.next.Equal(s.Next(testCase.from)), testCase.spec)
+	}
+}
+
+func TestBetween(t *testing.T) {
+	s, err := Parse("0 21 * * *")
+	assert.NoError(t, err)
+
+	from := time.Date(2025, 4, 14, 20, 55, 0, 0, time.UTC)
+	assert.True(t, s.Between(from, from.Add(5*time.Minute)))
+	assert.False(t, s.Between(from, from.Add(4*time.Minute)))
+	assert.False(t, s.Between(from.Add(5*time.Minute), from.Add(10*time.Minute)))
+}
@@ -0,0 +1,53 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"hcm/pkg/cc"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// newProvider create the tracer provider, which samples root spans by the trace id ratio, the child spans follow
+// the sampling decision of their parents, and the sampled spans are batch exported to the OTLP/HTTP collector.
+func newProvider(opt cc.Tracing, serviceName string) (*sdktrace.TracerProvider, error) {
+	exporter, err := otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(opt.Endpoint),
+		otlptracehttp.WithTimeout(10*time.Second))
+	if err != nil {
+		return nil, err
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opt.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+		sdktrace.WithBatcher(exporter,
+			sdktrace.WithMaxExportBatchSize(int(opt.BatchSize)),
+			// spans are dropped when the queue is full, so that the collector failure does not block requests.
+			sdktrace.WithMaxQueueSize(int(opt.BatchSize)*4),
+			sdktrace.WithBatchTimeout(time.Duration(opt.FlushIntervalSec)*time.Second),
+		),
+	), nil
+}
@@ -0,0 +1,75 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tracing provides opentelemetry distributed tracing, trace context is propagated with the W3C
+// traceparent header, and the sampled spans are exported to the OTLP collector over http.
+package tracing
+
+import (
+	"context"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/logs"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is the instrumentation scope name of hcm spans.
+const instrumentationName = "hcm"
+
+// Init set up the global propagator and tracer provider. trace context is always propagated even if tracing is
+// disabled, so that the trace of a request is not broken by the services which do not export spans.
+func Init(opt cc.Tracing, serviceName string) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	if !opt.Enable {
+		return
+	}
+
+	provider, err := newProvider(opt, serviceName)
+	if err != nil {
+		logs.Errorf("init tracer provider failed, spans will not be exported, err: %v", err)
+		return
+	}
+
+	otel.SetTracerProvider(provider)
+	logs.Infof("tracing is enabled, spans are exported to %s", opt.Endpoint)
+}
+
+// Start a span with the global tracer provider, the span is a child of the span in the context if exists.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+}
+
+// End the span, the span status is set to error if err is not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,106 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"hcm/pkg/cc"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestExport(t *testing.T) {
+	lock := sync.Mutex{}
+	received := new(coltracepb.ExportTraceServiceRequest)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		lock.Lock()
+		defer lock.Unlock()
+		require.NoError(t, proto.Unmarshal(body, received))
+	}))
+	defer srv.Close()
+
+	opt := cc.Tracing{Enable: true, Endpoint: srv.URL, SampleRatio: 1, BatchSize: 512, FlushIntervalSec: 5}
+	provider, err := newProvider(opt, "data-service")
+	require.NoError(t, err)
+	tracer := provider.Tracer(instrumentationName)
+
+	ctx, parent := tracer.Start(context.Background(), "ListVpc", trace.WithSpanKind(trace.SpanKindServer))
+	_, child := tracer.Start(ctx, "POST vpcs/list", trace.WithSpanKind(trace.SpanKindClient))
+	End(child, errors.New("timeout"))
+	End(parent, nil)
+
+	require.NoError(t, provider.Shutdown(context.Background()))
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Len(t, received.ResourceSpans, 1)
+	assert.Equal(t, "service.name", received.ResourceSpans[0].Resource.Attributes[0].Key)
+	assert.Equal(t, "data-service", received.ResourceSpans[0].Resource.Attributes[0].Value.GetStringValue())
+	require.Len(t, received.ResourceSpans[0].ScopeSpans, 1)
+	spans := received.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+
+	// spans are exported in the order they are ended.
+	exportedChild, exportedParent := spans[0], spans[1]
+	assert.Equal(t, exportedParent.TraceId, exportedChild.TraceId)
+	assert.Empty(t, exportedParent.ParentSpanId)
+	assert.Equal(t, exportedParent.SpanId, exportedChild.ParentSpanId)
+	assert.Equal(t, tracepb.Span_SPAN_KIND_SERVER, exportedParent.Kind)
+	assert.Equal(t, tracepb.Status_STATUS_CODE_UNSET, exportedParent.Status.Code)
+	assert.Equal(t, tracepb.Status_STATUS_CODE_ERROR, exportedChild.Status.Code)
+	assert.Equal(t, "timeout", exportedChild.Status.Message)
+	require.Len(t, exportedChild.Events, 1)
+	assert.Equal(t, "exception", exportedChild.Events[0].Name)
+}
+
+func TestSample(t *testing.T) {
+	opt := cc.Tracing{Enable: true, Endpoint: "http://127.0.0.1:4318", SampleRatio: 0, BatchSize: 512,
+		FlushIntervalSec: 5}
+	provider, err := newProvider(opt, "data-service")
+	require.NoError(t, err)
+	defer provider.Shutdown(context.Background())
+	tracer := provider.Tracer(instrumentationName)
+
+	ctx, span := tracer.Start(context.Background(), "ListVpc")
+	assert.False(t, span.IsRecording())
+	assert.True(t, span.SpanContext().IsValid())
+	assert.False(t, span.SpanContext().IsSampled())
+
+	// child span follows the sampling decision of its parent.
+	_, child := tracer.Start(ctx, "POST vpcs/list")
+	assert.False(t, child.IsRecording())
+	assert.Equal(t, span.SpanContext().TraceID(), child.SpanContext().TraceID())
+}
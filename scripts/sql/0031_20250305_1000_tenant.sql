@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0031,HCMVER=v1.7.5
+
+    Notes:
+    1. 账号、VPC、子网、安全组、主机、硬盘、EIP表新增租户ID字段tenant_id，并添加索引
+*/
+
+START TRANSACTION;
+
+-- 1. 账号、VPC、子网、安全组、主机、硬盘、EIP表新增租户ID字段tenant_id，并添加索引
+alter table account
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table vpc
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table subnet
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table security_group
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table cvm
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table disk
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table eip
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0031' as `sql_ver`;
+
+COMMIT;
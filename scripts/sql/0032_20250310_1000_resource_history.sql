@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0032,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加资源历史版本表 resource_history，记录安全组、安全组规则、主机的每个版本
+*/
+
+START TRANSACTION;
+
+--  1. 资源历史版本表
+create table if not exists `resource_history`
+(
+    `id`         varchar(64) not null comment '主键',
+    `res_type`   varchar(64) not null comment '资源类型，取值为资源所在表名',
+    `res_id`     varchar(64) not null comment '资源ID',
+    `data`       json        not null comment '该版本的资源数据',
+    `valid_from` datetime(6) not null comment '版本生效时间',
+    `valid_to`   datetime(6)          default null comment '版本失效时间，为空表示当前版本',
+    `operator`   varchar(64) not null default '' comment '操作人',
+    `rid`        varchar(64) not null default '' comment '请求ID',
+    primary key (`id`),
+    key `idx_res_type_res_id_valid_from` (`res_type`, `res_id`, `valid_from`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='资源历史版本表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('resource_history', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0032' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,51 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0033,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加资源标签表 resource_tag，将资源的标签拆分为键值对存储，用于按标签过滤资源
+*/
+
+START TRANSACTION;
+
+--  1. 资源标签表
+create table if not exists `resource_tag`
+(
+    `id`         varchar(64)  not null comment '主键',
+    `res_type`   varchar(64)  not null comment '资源类型，取值为资源所在表名',
+    `res_id`     varchar(64)  not null comment '资源ID',
+    `tag_key`    varchar(255) not null comment '标签键',
+    `tag_value`  varchar(255) not null default '' comment '标签值',
+    `created_at` timestamp    not null default current_timestamp comment '该记录创建的时间',
+    primary key (`id`),
+    unique key `idx_uk_res_type_res_id_tag_key` (`res_type`, `res_id`, `tag_key`),
+    key `idx_res_type_tag_key_tag_value` (`res_type`, `tag_key`, `tag_value`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='资源标签表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('resource_tag', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0033' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,48 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0034,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加资源关系视图 resource_relation，将主机与硬盘、EIP、安全组，子网与VPC的关系统一为边(src -> dst)，
+       视图基于已有的关联表和资源表，无需额外同步数据
+*/
+
+START TRANSACTION;
+
+--  1. 资源关系视图
+create or replace view `resource_relation`(`src_type`, `src_id`, `dst_type`, `dst_id`) as
+select 'cvm', `cvm_id`, 'disk', `disk_id`
+from `disk_cvm_rel`
+union all
+select 'cvm', `cvm_id`, 'eip', `eip_id`
+from `eip_cvm_rel`
+union all
+select 'cvm', `cvm_id`, 'security_group', `security_group_id`
+from `security_group_cvm_rel`
+union all
+select 'subnet', `id`, 'vpc', `vpc_id`
+from `subnet`
+where `vpc_id` != '';
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0034' as `sql_ver`;
+
+COMMIT;
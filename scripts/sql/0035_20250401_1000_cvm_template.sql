@@ -0,0 +1,63 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0035,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加主机模板表 cvm_template，管理员定义可复用的主机配置，申请主机时可基于模板批量创建
+*/
+
+START TRANSACTION;
+
+--  1. 主机模板表
+create table if not exists `cvm_template`
+(
+    `id`                       varchar(64)   not null comment '主键',
+    `name`                     varchar(255)  not null comment '模板名称',
+    `vendor`                   varchar(16)   not null comment '云厂商',
+    `account_id`               varchar(64)   not null comment '账号ID',
+    `region`                   varchar(255)  not null comment '地域',
+    `zone`                     varchar(255)  not null default '' comment '可用区',
+    `cloud_image_id`           varchar(255)  not null comment '云镜像ID',
+    `instance_type`            varchar(255)  not null comment '机型',
+    `cloud_security_group_ids` json          not null comment '云安全组ID列表',
+    `system_disk`              json          not null comment '系统盘',
+    `data_disk`                json          not null comment '数据盘',
+    `user_data`                text          not null comment '自定义数据，base64编码',
+    `extension`                json          not null comment '云厂商差异化的主机创建参数',
+    `memo`                     varchar(255)  default '' comment '备注',
+    `creator`                  varchar(64)   not null comment '创建者',
+    `reviser`                  varchar(64)   not null comment '更新者',
+    `created_at`               timestamp     not null default current_timestamp comment '该记录创建的时间',
+    `updated_at`               timestamp     not null default current_timestamp on update current_timestamp comment '该记录更新的时间',
+    primary key (`id`),
+    unique key `idx_uk_name` (`name`),
+    key `idx_vendor_region` (`vendor`, `region`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='主机模板表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('cvm_template', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0035' as `sql_ver`;
+
+COMMIT;
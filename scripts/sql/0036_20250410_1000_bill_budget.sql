@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0036,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加账单预算表 account_bill_budget，按业务或二级账号设置月度预算及告警阈值
+*/
+
+START TRANSACTION;
+
+--  1. 账单预算表
+create table if not exists `account_bill_budget`
+(
+    `id`              varchar(64)     not null comment '主键',
+    `name`            varchar(255)    not null comment '预算名称',
+    `scope_type`      varchar(32)     not null comment '预算范围类型(biz:业务、main_account:二级账号)',
+    `bk_biz_id`       bigint(1)       not null default 0 comment '业务ID，业务预算时有效',
+    `main_account_id` varchar(64)     not null default '' comment '二级账号ID，二级账号预算时有效',
+    `currency`        varchar(16)     not null comment '币种',
+    `amount`          decimal(38, 10) not null comment '月度预算金额',
+    `thresholds`      json            not null comment '告警阈值，预算金额的百分比',
+    `notice_users`    json            not null comment '告警通知人',
+    `alert_month`     bigint(1)       not null default 0 comment '最近一次告警的账期，格式：yyyymm',
+    `alert_threshold` bigint(1)       not null default 0 comment '最近一次告警账期内已告警的最高阈值',
+    `memo`            varchar(255)             default '' comment '备注',
+    `creator`         varchar(64)     not null comment '创建者',
+    `reviser`         varchar(64)     not null comment '更新者',
+    `created_at`      timestamp       not null default current_timestamp comment '该记录创建的时间',
+    `updated_at`      timestamp       not null default current_timestamp on update current_timestamp comment '该记录更新的时间',
+    primary key (`id`),
+    unique key `idx_uk_name` (`name`),
+    key `idx_scope` (`scope_type`, `bk_biz_id`, `main_account_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='账单预算表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('account_bill_budget', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0036' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,54 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0037,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加业务资源配额表 biz_quota，限制业务可申请的主机数、vCPU核数及弹性IP数
+*/
+
+START TRANSACTION;
+
+--  1. 业务资源配额表
+create table if not exists `biz_quota`
+(
+    `id`           varchar(64)  not null comment '主键',
+    `bk_biz_id`    bigint(1)    not null comment '业务ID',
+    `max_cvm`      bigint(1)    not null default -1 comment '主机数量上限，-1表示不限制',
+    `max_cpu_core` bigint(1)    not null default -1 comment 'vCPU核数上限，-1表示不限制',
+    `max_eip`      bigint(1)    not null default -1 comment '弹性IP数量上限，-1表示不限制',
+    `memo`         varchar(255)          default '' comment '备注',
+    `creator`      varchar(64)  not null comment '创建者',
+    `reviser`      varchar(64)  not null comment '更新者',
+    `created_at`   timestamp    not null default current_timestamp comment '该记录创建的时间',
+    `updated_at`   timestamp    not null default current_timestamp on update current_timestamp comment '该记录更新的时间',
+    primary key (`id`),
+    unique key `idx_uk_bk_biz_id` (`bk_biz_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='业务资源配额表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('biz_quota', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0037' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,75 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0038,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加主机定时开关机策略表 cvm_schedule
+    2. 添加主机定时开关机执行记录表 cvm_schedule_record
+*/
+
+START TRANSACTION;
+
+--  1. 主机定时开关机策略表
+create table if not exists `cvm_schedule`
+(
+    `id`         varchar(64)  not null comment '主键',
+    `name`       varchar(255) not null comment '策略名称',
+    `filter`     json         not null comment '目标主机过滤条件',
+    `start_cron` varchar(64)  not null default '' comment '开机cron表达式，为空表示不自动开机',
+    `stop_cron`  varchar(64)  not null default '' comment '关机cron表达式，为空表示不自动关机',
+    `timezone`   varchar(64)  not null default 'Asia/Shanghai' comment 'cron表达式的时区',
+    `enabled`    tinyint(1)   not null default 1 comment '是否启用',
+    `memo`       varchar(255)          default '' comment '备注',
+    `creator`    varchar(64)  not null comment '创建者',
+    `reviser`    varchar(64)  not null comment '更新者',
+    `created_at` timestamp    not null default current_timestamp comment '该记录创建的时间',
+    `updated_at` timestamp    not null default current_timestamp on update current_timestamp comment '该记录更新的时间',
+    primary key (`id`),
+    unique key `idx_uk_name` (`name`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='主机定时开关机策略表';
+
+--  2. 主机定时开关机执行记录表
+create table if not exists `cvm_schedule_record`
+(
+    `id`             varchar(64) not null comment '主键',
+    `schedule_id`    varchar(64) not null comment '策略ID',
+    `action`         varchar(16) not null comment '执行动作(start:开机、stop:关机)',
+    `cvm_ids`        json        not null comment '执行成功的主机ID列表',
+    `failed_cvm_ids` json        not null comment '执行失败的主机ID列表',
+    `cpu_core`       bigint(1)   not null default 0 comment '执行成功的主机vCPU核数之和',
+    `creator`        varchar(64) not null comment '创建者',
+    `created_at`     timestamp   not null default current_timestamp comment '该记录创建的时间，即执行时间',
+    primary key (`id`),
+    key `idx_schedule_id_created_at` (`schedule_id`, `created_at`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='主机定时开关机执行记录表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('cvm_schedule', '0'),
+       ('cvm_schedule_record', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0038' as `sql_ver`;
+
+COMMIT;
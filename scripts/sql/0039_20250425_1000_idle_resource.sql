@@ -0,0 +1,63 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0039,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加闲置资源检测结果表 idle_resource
+*/
+
+START TRANSACTION;
+
+--  1. 闲置资源检测结果表
+create table if not exists `idle_resource`
+(
+    `id`                     varchar(64)     not null comment '主键',
+    `res_type`               varchar(64)     not null comment '资源类型(cvm、disk、eip、security_group)',
+    `res_id`                 varchar(64)     not null comment '资源ID',
+    `vendor`                 varchar(16)     not null comment '云厂商',
+    `account_id`             varchar(64)     not null comment '账号ID',
+    `bk_biz_id`              bigint(1)       not null default -1 comment '业务ID',
+    `region`                 varchar(255)    not null default '' comment '地域',
+    `res_name`               varchar(255)    not null default '' comment '资源名称',
+    `reason`                 varchar(64)     not null comment '闲置原因(unattached_disk、unbound_eip、stopped_cvm、unused_security_group)',
+    `detail`                 json            not null comment '闲置详情，如硬盘容量、主机vCPU核数',
+    `estimated_monthly_cost` decimal(38, 10) not null default 0 comment '预估每月费用',
+    `currency`               varchar(16)     not null default '' comment '币种',
+    `status`                 varchar(32)     not null default 'pending' comment '处理状态(pending、ignored、remediating)',
+    `detected_at`            timestamp       not null default current_timestamp comment '最近一次检测到闲置的时间',
+    `creator`                varchar(64)     not null comment '创建者',
+    `reviser`                varchar(64)     not null comment '更新者',
+    `created_at`             timestamp       not null default current_timestamp comment '该记录创建的时间，即首次检测到闲置的时间',
+    `updated_at`             timestamp       not null default current_timestamp on update current_timestamp comment '该记录更新的时间',
+    primary key (`id`),
+    unique key `idx_uk_res_type_res_id` (`res_type`, `res_id`),
+    key `idx_bk_biz_id` (`bk_biz_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='闲置资源检测结果表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('idle_resource', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0039' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,81 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0041,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加标签策略表 tag_policy，按业务及资源类型配置必填标签键及允许的标签值
+    2. 添加标签不合规资源表 tag_violation，记录同步时检测到的缺少标签或标签值不合规的云上资源
+*/
+
+START TRANSACTION;
+
+--  1. 标签策略表
+create table if not exists `tag_policy`
+(
+    `id`             varchar(64)  not null comment '主键',
+    `bk_biz_id`      bigint(1)    not null comment '业务ID，-1表示所有业务均需遵循的全局默认策略',
+    `res_type`       varchar(64)  not null comment '资源类型',
+    `tag_key`        varchar(128) not null comment '必填标签键',
+    `allowed_values` json                  default null comment '允许的标签值，为空表示不限制取值',
+    `memo`           varchar(255)          default '' comment '备注',
+    `creator`        varchar(64)  not null comment '创建者',
+    `reviser`        varchar(64)  not null comment '更新者',
+    `created_at`     timestamp    not null default current_timestamp comment '该记录创建的时间',
+    `updated_at`     timestamp    not null default current_timestamp on update current_timestamp comment '该记录更新的时间',
+    primary key (`id`),
+    unique key `idx_uk_bk_biz_id_res_type_tag_key` (`bk_biz_id`, `res_type`, `tag_key`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='标签策略表';
+
+--  2. 标签不合规资源表
+create table if not exists `tag_violation`
+(
+    `id`          varchar(64)  not null comment '主键',
+    `res_type`    varchar(64)  not null comment '资源类型',
+    `res_id`      varchar(64)  not null comment '资源ID',
+    `vendor`      varchar(16)  not null comment '云厂商',
+    `account_id`  varchar(64)  not null comment '账号ID',
+    `bk_biz_id`   bigint(1)    not null default -1 comment '业务ID',
+    `res_name`    varchar(255)          default '' comment '资源名称',
+    `policy_id`   varchar(64)  not null comment '不符合的标签策略ID',
+    `tag_key`     varchar(128) not null comment '标签键',
+    `tag_value`   varchar(255)          default '' comment '资源当前的标签值',
+    `reason`      varchar(32)  not null comment '不合规原因(missing:缺少标签、mistagged:标签值不合规)',
+    `detected_at` timestamp    not null default current_timestamp comment '检测时间',
+    `created_at`  timestamp    not null default current_timestamp comment '该记录创建的时间',
+    `updated_at`  timestamp    not null default current_timestamp on update current_timestamp comment '该记录更新的时间',
+    primary key (`id`),
+    unique key `idx_uk_res_type_res_id_tag_key` (`res_type`, `res_id`, `tag_key`),
+    key `idx_account_id` (`account_id`),
+    key `idx_bk_biz_id` (`bk_biz_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='标签不合规资源表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('tag_policy', '0'),
+       ('tag_violation', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0041' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,112 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0042,HCMVER=v1.7.5
+
+    Notes:
+    1. 扩展资源关系视图 resource_relation，用于组装VPC网络拓扑:
+       - 新增路由表与VPC、子网与路由表、主机与子网的关系
+       - 新增路由表与NAT网关(nat_gateway)、VPN网关(vpn_gateway)、对等连接(vpc_peering)的关系，这三类资源未纳管，
+         基于路由的下一跳生成，dst_id 为云上ID
+       - 主机与安全组的关系合并 security_group_common_rel 中的主机关联
+*/
+
+START TRANSACTION;
+
+--  1. 资源关系视图
+create or replace view `resource_relation`(`src_type`, `src_id`, `dst_type`, `dst_id`) as
+select 'cvm', `cvm_id`, 'disk', `disk_id`
+from `disk_cvm_rel`
+union all
+select 'cvm', `cvm_id`, 'eip', `eip_id`
+from `eip_cvm_rel`
+union all
+select distinct 'cvm', `cvm_id`, 'security_group', `security_group_id`
+from (select `cvm_id`, `security_group_id`
+      from `security_group_cvm_rel`
+      union
+      select `res_id` as `cvm_id`, `security_group_id`
+      from `security_group_common_rel`
+      where `res_type` = 'cvm') as `sg_rel`
+union all
+select 'subnet', `id`, 'vpc', `vpc_id`
+from `subnet`
+where `vpc_id` != ''
+union all
+select 'route_table', `id`, 'vpc', `vpc_id`
+from `route_table`
+where `vpc_id` != ''
+union all
+select 'subnet', `id`, 'route_table', `route_table_id`
+from `subnet`
+where `route_table_id` is not null
+  and `route_table_id` != ''
+union all
+select 'cvm', `cvm`.`id`, 'subnet', `jt`.`subnet_id`
+from `cvm`,
+     json_table(`cvm`.`subnet_ids`, '$[*]' columns (`subnet_id` varchar(64) path '$')) as `jt`
+where `jt`.`subnet_id` != ''
+union all
+select distinct 'route_table',
+                `route_table_id`,
+                case `gateway_type`
+                    when 'NAT' then 'nat_gateway'
+                    when 'VPN' then 'vpn_gateway'
+                    else 'vpc_peering' end,
+                `cloud_gateway_id`
+from `tcloud_route`
+where `gateway_type` in ('NAT', 'VPN', 'PEERCONNECTION')
+  and `cloud_gateway_id` != ''
+union all
+select distinct 'route_table', `route_table_id`, 'nat_gateway', `cloud_nat_gateway_id`
+from `aws_route`
+where `cloud_nat_gateway_id` != ''
+union all
+select distinct 'route_table', `route_table_id`, 'vpc_peering', `cloud_vpc_peering_connection_id`
+from `aws_route`
+where `cloud_vpc_peering_connection_id` != ''
+union all
+select distinct 'route_table', `route_table_id`, 'vpn_gateway', `cloud_gateway_id`
+from `aws_route`
+where `cloud_gateway_id` like 'vgw-%'
+union all
+select distinct 'route_table',
+                `route_table_id`,
+                case `type`
+                    when 'nat' then 'nat_gateway'
+                    when 'vpn' then 'vpn_gateway'
+                    else 'vpc_peering' end,
+                `nexthop`
+from `huawei_route`
+where `type` in ('nat', 'vpn', 'peering')
+  and `nexthop` != ''
+union all
+select distinct 'route_table', `route_table_id`, 'vpc_peering', `next_hop_peering`
+from `gcp_route`
+where `next_hop_peering` != ''
+union all
+select distinct 'route_table', `route_table_id`, 'vpn_gateway', `next_hop_vpn_tunnel`
+from `gcp_route`
+where `next_hop_vpn_tunnel` != '';
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0042' as `sql_ver`;
+
+COMMIT;
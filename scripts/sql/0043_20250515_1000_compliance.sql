@@ -0,0 +1,84 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0043,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加安全合规检测结果表 compliance_finding
+    2. 添加安全合规豁免表 compliance_exemption
+*/
+
+START TRANSACTION;
+
+--  1. 安全合规检测结果表
+create table if not exists `compliance_finding`
+(
+    `id`           varchar(64)  not null comment '主键',
+    `rule`         varchar(64)  not null comment '检测规则(sg_open_admin_port、unencrypted_disk)',
+    `severity`     varchar(16)  not null comment '风险等级(high、medium、low)',
+    `res_type`     varchar(64)  not null comment '资源类型(security_group、disk)',
+    `res_id`       varchar(64)  not null comment '资源ID',
+    `vendor`       varchar(16)  not null comment '云厂商',
+    `account_id`   varchar(64)  not null comment '账号ID',
+    `bk_biz_id`    bigint(1)    not null default -1 comment '业务ID',
+    `region`       varchar(255) not null default '' comment '地域',
+    `res_name`     varchar(255) not null default '' comment '资源名称',
+    `detail`       json         not null comment '检测详情，如安全组中违规的规则ID及端口',
+    `exemption_id` varchar(64)  not null default '' comment '豁免ID，不为空时该检测结果已被豁免',
+    `detected_at`  timestamp    not null default current_timestamp comment '最近一次检测到不合规的时间',
+    `creator`      varchar(64)  not null comment '创建者',
+    `reviser`      varchar(64)  not null comment '更新者',
+    `created_at`   timestamp    not null default current_timestamp comment '该记录创建的时间，即首次检测到不合规的时间',
+    `updated_at`   timestamp    not null default current_timestamp on update current_timestamp comment '该记录更新的时间',
+    primary key (`id`),
+    unique key `idx_uk_rule_res_id` (`rule`, `res_id`),
+    key `idx_bk_biz_id` (`bk_biz_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='安全合规检测结果表';
+
+--  2. 安全合规豁免表
+create table if not exists `compliance_exemption`
+(
+    `id`         varchar(64)  not null comment '主键',
+    `rule`       varchar(64)  not null comment '检测规则',
+    `res_id`     varchar(64)  not null comment '资源ID',
+    `bk_biz_id`  bigint(1)    not null default -1 comment '资源所属业务ID',
+    `reason`     varchar(255) not null default '' comment '豁免原因',
+    `expired_at` timestamp    null     default null comment '豁免过期时间，为空时永久有效',
+    `creator`    varchar(64)  not null comment '创建者',
+    `reviser`    varchar(64)  not null comment '更新者',
+    `created_at` timestamp    not null default current_timestamp comment '该记录创建的时间',
+    `updated_at` timestamp    not null default current_timestamp on update current_timestamp comment '该记录更新的时间',
+    primary key (`id`),
+    unique key `idx_uk_rule_res_id` (`rule`, `res_id`),
+    key `idx_bk_biz_id` (`bk_biz_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='安全合规豁免表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('compliance_finding', '0'),
+       ('compliance_exemption', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0043' as `sql_ver`;
+
+COMMIT;
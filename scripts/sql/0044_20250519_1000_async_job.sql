@@ -0,0 +1,73 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0044,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加异步任务视图 async_job，合并异步任务流 async_flow 与账号资源同步详情 account_sync_detail，供任务中心查询
+*/
+
+START TRANSACTION;
+
+CREATE OR REPLACE VIEW `async_job` AS
+SELECT `id`,
+       'flow'                                                        AS `kind`,
+       CASE
+           WHEN `name` IN ('start_cvm', 'stop_cvm', 'reboot_cvm') THEN 'power'
+           WHEN `name` LIKE 'load_balancer%' OR `name` LIKE 'tg\_%' OR `name` = 'apply_tg_listener_rule'
+               THEN 'load_balancer'
+           WHEN `name` LIKE 'create%' THEN 'create'
+           WHEN `name` LIKE 'delete%' THEN 'delete'
+           WHEN `name` LIKE 'bill%' THEN 'bill'
+           ELSE 'other'
+           END                                                       AS `category`,
+       `name`,
+       ''                                                            AS `vendor`,
+       ''                                                            AS `account_id`,
+       `state`,
+       IFNULL(JSON_UNQUOTE(JSON_EXTRACT(`reason`, '$.message')), '') AS `reason`,
+       `creator`,
+       `created_at`,
+       `updated_at`
+FROM `async_flow`
+UNION ALL
+SELECT `account_id`                                       AS `id`,
+       'sync'                                             AS `kind`,
+       'sync'                                             AS `category`,
+       'account_sync'                                     AS `name`,
+       MAX(`vendor`)                                      AS `vendor`,
+       `account_id`,
+       CASE
+           WHEN SUM(`res_status` = 'sync_failed') > 0 THEN 'failed'
+           WHEN SUM(`res_status` = 'syncing') > 0 THEN 'running'
+           WHEN SUM(`res_status` = 'not_sync') > 0 THEN 'pending'
+           ELSE 'success'
+           END                                            AS `state`,
+       ''                                                 AS `reason`,
+       MAX(`reviser`)                                     AS `creator`,
+       MIN(`created_at`)                                  AS `created_at`,
+       MAX(`updated_at`)                                  AS `updated_at`
+FROM `account_sync_detail`
+GROUP BY `account_id`;
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0044' as `sql_ver`;
+
+COMMIT;
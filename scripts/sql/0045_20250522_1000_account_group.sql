@@ -0,0 +1,70 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0045,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加账号组表 account_group，支持通过父账号组构建层级，用于在同步、搜索、费用报表中按账号组指定范围
+    2. 添加账号组成员关系表 account_group_rel，成员可以是资源账号或二级账号
+*/
+
+START TRANSACTION;
+
+--  1. 账号组表
+create table if not exists `account_group`
+(
+    `id`         varchar(64)  not null comment '主键',
+    `name`       varchar(255) not null comment '账号组名称',
+    `parent_id`  varchar(64)  not null default '' comment '父账号组ID，为空表示顶层账号组',
+    `memo`       varchar(255)          default '' comment '备注',
+    `creator`    varchar(64)  not null comment '创建者',
+    `reviser`    varchar(64)  not null comment '更新者',
+    `created_at` timestamp    not null default current_timestamp comment '该记录创建的时间',
+    `updated_at` timestamp    not null default current_timestamp on update current_timestamp comment '该记录更新的时间',
+    primary key (`id`),
+    unique key `idx_uk_name` (`name`),
+    key `idx_parent_id` (`parent_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='账号组表';
+
+--  2. 账号组成员关系表
+create table if not exists `account_group_rel`
+(
+    `id`           bigint(1) unsigned not null auto_increment,
+    `group_id`     varchar(64)        not null comment '账号组ID',
+    `account_type` varchar(32)        not null comment '成员账号类型(account:资源账号、main_account:二级账号)',
+    `account_id`   varchar(64)        not null comment '成员账号ID',
+    `creator`      varchar(64)        not null comment '创建者',
+    `created_at`   timestamp          not null default current_timestamp comment '该记录创建的时间',
+    primary key (`id`),
+    unique key `idx_uk_group_id_account_type_account_id` (`group_id`, `account_type`, `account_id`),
+    key `idx_account_id` (`account_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='账号组成员关系表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('account_group', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0045' as `sql_ver`;
+
+COMMIT;
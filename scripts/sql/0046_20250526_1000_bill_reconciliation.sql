@@ -0,0 +1,63 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0046,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加账单对账结果表 account_bill_reconciliation，记录账单中无法与HCM资源对应的费用
+*/
+
+START TRANSACTION;
+
+--  1. 账单对账结果表
+create table if not exists `account_bill_reconciliation`
+(
+    `id`              varchar(64)     not null comment '主键',
+    `vendor`          varchar(16)     not null comment '云厂商',
+    `root_account_id` varchar(64)     not null comment '一级账号ID',
+    `main_account_id` varchar(64)     not null comment '二级账号ID',
+    `bill_year`       bigint(1)       not null comment '账单年份',
+    `bill_month`      tinyint(1)      not null comment '账单月份',
+    `res_type`        varchar(64)     not null comment '资源类型(cvm:主机、disk:硬盘、eip:弹性IP)',
+    `res_cloud_id`    varchar(255)    not null comment '账单中的云资源ID',
+    `hc_product_code` varchar(128)    not null default '' comment '云服务代号',
+    `currency`        varchar(16)     not null comment '币种',
+    `cost`            decimal(38, 10) not null comment '该资源当月费用',
+    `res_amount`      decimal(38, 10) not null default 0 comment '该资源当月用量，如主机的计费时长',
+    `res_amount_unit` varchar(64)     not null default '' comment '用量单位',
+    `reason`          varchar(32)     not null comment '无法对应的原因(unmanaged:HCM中不存在该资源、recycled:资源已回收)',
+    `creator`         varchar(64)     not null comment '创建者',
+    `reviser`         varchar(64)     not null comment '更新者',
+    `created_at`      timestamp       not null default current_timestamp comment '该记录创建的时间',
+    `updated_at`      timestamp       not null default current_timestamp on update current_timestamp comment '该记录更新的时间',
+    primary key (`id`),
+    key `idx_vendor_bill_year_bill_month` (`vendor`, `bill_year`, `bill_month`),
+    key `idx_main_account_id` (`main_account_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='账单对账结果表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('account_bill_reconciliation', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0046' as `sql_ver`;
+
+COMMIT;
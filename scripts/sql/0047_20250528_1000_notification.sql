@@ -0,0 +1,82 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0047,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加通知模板表 notification_template
+    2. 添加通知订阅表 notification_subscription
+*/
+
+START TRANSACTION;
+
+--  1. 通知模板表
+create table if not exists `notification_template`
+(
+    `id`         varchar(64)  not null comment '主键',
+    `name`       varchar(255) not null comment '模板名称',
+    `event_type` varchar(64)  not null comment '事件类型(sync_failed、credential_invalid、budget_alert、application_status)',
+    `channel`    varchar(32)  not null comment '通知渠道(email、wecom、webhook)',
+    `title`      varchar(255) not null comment '通知标题模板',
+    `content`    text         not null comment '通知内容模板',
+    `memo`       varchar(255)          default '' comment '备注',
+    `creator`    varchar(64)  not null comment '创建者',
+    `reviser`    varchar(64)  not null comment '更新者',
+    `created_at` timestamp    not null default current_timestamp comment '该记录创建的时间',
+    `updated_at` timestamp    not null default current_timestamp on update current_timestamp comment '该记录更新的时间',
+    primary key (`id`),
+    key `idx_event_type_channel` (`event_type`, `channel`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='通知模板表';
+
+--  2. 通知订阅表
+create table if not exists `notification_subscription`
+(
+    `id`             varchar(64)   not null comment '主键',
+    `name`           varchar(255)  not null comment '订阅名称',
+    `event_type`     varchar(64)   not null comment '订阅的事件类型',
+    `channels`       json          not null comment '通知渠道列表',
+    `receivers`      json          not null comment '通知接收人列表，用于邮件、企业微信渠道',
+    `notify_related` tinyint(1)    not null default 0 comment '是否同时通知事件相关人员，如单据申请人',
+    `webhook_url`    varchar(1024) not null default '' comment 'webhook地址，用于webhook渠道',
+    `template_ids`   json          not null comment '使用的通知模板ID列表，每个渠道最多一个，未配置的渠道使用默认模板',
+    `account_ids`    json          not null comment '订阅范围：账号ID列表，为空表示不限制',
+    `bk_biz_ids`     json          not null comment '订阅范围：业务ID列表，为空表示不限制',
+    `enabled`        tinyint(1)    not null default 1 comment '是否启用',
+    `memo`           varchar(255)           default '' comment '备注',
+    `creator`        varchar(64)   not null comment '创建者',
+    `reviser`        varchar(64)   not null comment '更新者',
+    `created_at`     timestamp     not null default current_timestamp comment '该记录创建的时间',
+    `updated_at`     timestamp     not null default current_timestamp on update current_timestamp comment '该记录更新的时间',
+    primary key (`id`),
+    key `idx_event_type` (`event_type`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='通知订阅表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('notification_template', '0'),
+       ('notification_subscription', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0047' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,50 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0048,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加用户最近访问资源表 user_recent_view
+*/
+
+START TRANSACTION;
+
+create table if not exists `user_recent_view`
+(
+    `id`         varchar(64) not null comment '主键',
+    `user`       varchar(64) not null comment '用户名',
+    `res_type`   varchar(50) not null comment '资源类型',
+    `res_id`     varchar(64) not null comment '资源ID',
+    `created_at` timestamp   not null default current_timestamp comment '首次访问时间',
+    `updated_at` timestamp   not null default current_timestamp on update current_timestamp comment '最近访问时间',
+    primary key (`id`),
+    unique key `idx_uk_user_res_type_res_id` (`user`, `res_type`, `res_id`),
+    key `idx_user_res_type_updated_at` (`user`, `res_type`, `updated_at`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='用户最近访问资源表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('user_recent_view', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0048' as `sql_ver`;
+
+COMMIT;
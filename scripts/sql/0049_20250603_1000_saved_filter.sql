@@ -0,0 +1,55 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0049,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加资源列表保存的过滤条件表 saved_filter
+*/
+
+START TRANSACTION;
+
+create table if not exists `saved_filter`
+(
+    `id`         varchar(64)  not null comment '主键',
+    `name`       varchar(255) not null comment '名称',
+    `res_type`   varchar(64)  not null comment '资源类型',
+    `scope`      varchar(16)  not null comment '可见范围(user:仅本人,biz:业务内共享)',
+    `bk_biz_id`  bigint       not null default -1 comment '共享的业务ID，仅本人可见时为-1',
+    `filter`     json         not null comment '过滤条件',
+    `memo`       varchar(255)          default '' comment '备注',
+    `creator`    varchar(64)  not null comment '创建者',
+    `reviser`    varchar(64)  not null comment '更新者',
+    `created_at` timestamp    not null default current_timestamp comment '创建时间',
+    `updated_at` timestamp    not null default current_timestamp on update current_timestamp comment '更新时间',
+    primary key (`id`),
+    key `idx_res_type_creator` (`res_type`, `creator`),
+    key `idx_res_type_bk_biz_id` (`res_type`, `bk_biz_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='资源列表保存的过滤条件表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('saved_filter', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0049' as `sql_ver`;
+
+COMMIT;
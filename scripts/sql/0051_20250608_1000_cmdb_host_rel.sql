@@ -0,0 +1,59 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0051,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加主机与cmdb主机的映射关系表 cmdb_host_rel，记录主机同步到cmdb的结果
+*/
+
+START TRANSACTION;
+
+create table if not exists `cmdb_host_rel`
+(
+    `id`           varchar(64)   not null comment '主键',
+    `cvm_id`       varchar(64)   not null comment '主机ID',
+    `vendor`       varchar(16)   not null comment '云厂商',
+    `account_id`   varchar(64)   not null comment '账号ID',
+    `cloud_id`     varchar(255)  not null comment '主机云上ID',
+    `bk_biz_id`    bigint        not null comment '同步到的cmdb业务ID',
+    `bk_host_id`   bigint        not null default 0 comment 'cmdb主机ID，同步失败时为0',
+    `bk_module_id` bigint        not null default 0 comment '转移到的cmdb模块ID，未转移时为0',
+    `sync_status`  varchar(16)   not null comment '同步状态(success:成功,failed:失败)',
+    `sync_message` varchar(1024)          default '' comment '同步失败原因',
+    `creator`      varchar(64)   not null comment '创建者',
+    `reviser`      varchar(64)   not null comment '更新者',
+    `created_at`   timestamp     not null default current_timestamp comment '创建时间',
+    `updated_at`   timestamp     not null default current_timestamp on update current_timestamp comment '更新时间',
+    primary key (`id`),
+    unique key `idx_uk_cvm_id` (`cvm_id`),
+    key `idx_bk_biz_id_bk_host_id` (`bk_biz_id`, `bk_host_id`),
+    key `idx_account_id` (`account_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='主机与cmdb主机的映射关系表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('cmdb_host_rel', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0051' as `sql_ver`;
+
+COMMIT;
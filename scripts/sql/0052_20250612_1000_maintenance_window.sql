@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0052,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加业务维护窗口表 maintenance_window，业务配置维护窗口后，重启、关机等中断业务的操作只能在维护窗口内执行
+*/
+
+START TRANSACTION;
+
+create table if not exists `maintenance_window`
+(
+    `id`         varchar(64)  not null comment '主键',
+    `bk_biz_id`  bigint       not null comment '业务ID',
+    `name`       varchar(255) not null comment '名称',
+    `actions`    json         not null comment '受维护窗口限制的操作',
+    `weekdays`   json         not null comment '窗口生效的星期，0表示星期日',
+    `start_time` varchar(8)   not null comment '窗口开始时间，格式HH:MM',
+    `end_time`   varchar(8)   not null comment '窗口结束时间，格式HH:MM，早于开始时间表示跨天',
+    `timezone`   varchar(64)  not null comment '时区',
+    `enabled`    boolean      not null default true comment '是否启用',
+    `memo`       varchar(255)          default '' comment '备注',
+    `creator`    varchar(64)  not null comment '创建者',
+    `reviser`    varchar(64)  not null comment '更新者',
+    `created_at` timestamp    not null default current_timestamp comment '创建时间',
+    `updated_at` timestamp    not null default current_timestamp on update current_timestamp comment '更新时间',
+    primary key (`id`),
+    key `idx_bk_biz_id` (`bk_biz_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='业务维护窗口表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('maintenance_window', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0052' as `sql_ver`;
+
+COMMIT;
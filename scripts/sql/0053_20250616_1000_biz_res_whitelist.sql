@@ -0,0 +1,55 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0053,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加业务资源申请白名单表 biz_res_whitelist，限制业务申请主机时可使用的地域、机型族及镜像
+*/
+
+START TRANSACTION;
+
+--  1. 业务资源申请白名单表
+create table if not exists `biz_res_whitelist`
+(
+    `id`                varchar(64)  not null comment '主键',
+    `bk_biz_id`         bigint(1)    not null comment '业务ID',
+    `vendor`            varchar(16)  not null comment '云厂商',
+    `regions`           json         not null comment '允许使用的地域列表，为空表示不限制',
+    `instance_families` json         not null comment '允许使用的机型族列表，为空表示不限制',
+    `cloud_image_ids`   json         not null comment '允许使用的云镜像ID列表，为空表示不限制',
+    `memo`              varchar(255)          default '' comment '备注',
+    `creator`           varchar(64)  not null comment '创建者',
+    `reviser`           varchar(64)  not null comment '更新者',
+    `created_at`        timestamp    not null default current_timestamp comment '该记录创建的时间',
+    `updated_at`        timestamp    not null default current_timestamp on update current_timestamp comment '该记录更新的时间',
+    primary key (`id`),
+    unique key `idx_uk_bk_biz_id_vendor` (`bk_biz_id`, `vendor`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='业务资源申请白名单表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('biz_res_whitelist', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0053' as `sql_ver`;
+
+COMMIT;
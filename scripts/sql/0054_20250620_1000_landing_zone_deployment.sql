@@ -0,0 +1,60 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0054,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加网络基线部署表 landing_zone_deployment，记录一次编排创建的VPC、子网、路由表、NAT网关及安全组
+*/
+
+START TRANSACTION;
+
+--  1. 网络基线部署表
+create table if not exists `landing_zone_deployment`
+(
+    `id`         varchar(64)   not null comment '主键',
+    `name`       varchar(255)  not null comment '部署名称',
+    `vendor`     varchar(16)   not null comment '云厂商',
+    `account_id` varchar(64)   not null comment '账号ID',
+    `bk_biz_id`  bigint(1)     not null comment '业务ID',
+    `region`     varchar(255)  not null comment '地域',
+    `spec`       json          not null comment '部署规格',
+    `resources`  json          not null comment '已创建的资源',
+    `state`      varchar(32)   not null comment '部署状态',
+    `reason`     varchar(1024)          default '' comment '失败原因',
+    `flow_id`    varchar(64)            default '' comment '部署任务流ID',
+    `memo`       varchar(255)           default '' comment '备注',
+    `creator`    varchar(64)   not null comment '创建者',
+    `reviser`    varchar(64)   not null comment '更新者',
+    `created_at` timestamp     not null default current_timestamp comment '该记录创建的时间',
+    `updated_at` timestamp     not null default current_timestamp on update current_timestamp comment '该记录更新的时间',
+    primary key (`id`),
+    key `idx_bk_biz_id` (`bk_biz_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='网络基线部署表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('landing_zone_deployment', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0054' as `sql_ver`;
+
+COMMIT;
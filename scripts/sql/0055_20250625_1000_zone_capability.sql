@@ -0,0 +1,54 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0055,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加可用区能力表 zone_capability，记录各云厂商地域/可用区支持的机型族及特性
+*/
+
+START TRANSACTION;
+
+--  1. 可用区能力表
+create table if not exists `zone_capability`
+(
+    `id`                varchar(64)  not null comment '主键',
+    `vendor`            varchar(16)  not null comment '云厂商',
+    `region`            varchar(255) not null comment '地域',
+    `zone`              varchar(255) not null default '' comment '可用区，为空表示地域级别的能力',
+    `instance_families` json         not null comment '支持的机型族',
+    `features`          json         not null comment '支持的特性',
+    `creator`           varchar(64)  not null comment '创建者',
+    `reviser`           varchar(64)  not null comment '更新者',
+    `created_at`        timestamp    not null default current_timestamp comment '该记录创建的时间',
+    `updated_at`        timestamp    not null default current_timestamp on update current_timestamp comment '该记录更新的时间',
+    primary key (`id`),
+    unique key `idx_uk_vendor_region_zone` (`vendor`, `region`, `zone`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='可用区能力表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('zone_capability', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0055' as `sql_ver`;
+
+COMMIT;
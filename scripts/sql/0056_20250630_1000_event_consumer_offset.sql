@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0056,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加资源变更事件消费位点表 event_consumer_offset，记录集成方在各资源类型 topic 上已确认消费的位点
+*/
+
+START TRANSACTION;
+
+--  1. 资源变更事件消费位点表
+create table if not exists `event_consumer_offset`
+(
+    `id`              varchar(64)         not null comment '主键',
+    `consumer`        varchar(64)         not null comment '消费方',
+    `topic`           varchar(50)         not null comment '事件topic，即资源类型',
+    `consumed_offset` bigint(1) unsigned  not null default 0 comment '已确认消费的事件位点',
+    `creator`         varchar(64)         not null comment '创建者',
+    `reviser`         varchar(64)         not null comment '更新者',
+    `created_at`      timestamp           not null default current_timestamp comment '该记录创建的时间',
+    `updated_at`      timestamp           not null default current_timestamp on update current_timestamp comment '该记录更新的时间',
+    primary key (`id`),
+    unique key `idx_uk_consumer_topic` (`consumer`, `topic`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='资源变更事件消费位点表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('event_consumer_offset', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0056' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,84 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0057,HCMVER=v1.7.5
+
+    Notes:
+    1. 添加webhook订阅表 webhook_subscription，外部系统订阅资源变更事件，事件以签名后的http请求推送到订阅地址
+    2. 添加webhook投递记录表 webhook_delivery，记录每个事件的投递状态，投递失败时按退避策略重试
+*/
+
+START TRANSACTION;
+
+--  1. webhook订阅表
+create table if not exists `webhook_subscription`
+(
+    `id`         varchar(64)   not null comment '主键',
+    `name`       varchar(255)  not null comment '订阅名称',
+    `url`        varchar(1024) not null comment '事件推送地址',
+    `secret`     varchar(512)  not null comment '加密后的签名密钥',
+    `res_types`  json          not null comment '订阅的资源类型，即事件topic',
+    `actions`    json          not null comment '订阅的操作类型，为空表示不限制',
+    `bk_biz_ids` json          not null comment '订阅的业务范围，为空表示不限制',
+    `enabled`    tinyint(1)    not null default 1 comment '是否启用',
+    `memo`       varchar(255)           default '' comment '备注',
+    `creator`    varchar(64)   not null comment '创建者',
+    `reviser`    varchar(64)   not null comment '更新者',
+    `created_at` timestamp     not null default current_timestamp comment '该记录创建的时间',
+    `updated_at` timestamp     not null default current_timestamp on update current_timestamp comment '该记录更新的时间',
+    primary key (`id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='webhook订阅表';
+
+--  2. webhook投递记录表
+create table if not exists `webhook_delivery`
+(
+    `id`              varchar(64)         not null comment '主键',
+    `subscription_id` varchar(64)         not null comment 'webhook订阅ID',
+    `event_offset`    bigint(1) unsigned  not null comment '事件位点',
+    `res_type`        varchar(50)         not null comment '资源类型',
+    `res_id`          varchar(64)         not null default '' comment '资源ID',
+    `action`          varchar(20)         not null comment '操作类型',
+    `payload`         json                not null comment '推送的事件内容',
+    `state`           varchar(20)         not null comment '投递状态(pending:待投递 success:成功 failed:失败)',
+    `attempts`        int(1) unsigned     not null default 0 comment '已投递次数',
+    `status_code`     int(1)              not null default 0 comment '最近一次投递的http响应码',
+    `reason`          varchar(1024)       not null default '' comment '最近一次投递失败的原因',
+    `next_retry_at`   timestamp           not null default current_timestamp comment '下次投递时间',
+    `creator`         varchar(64)         not null comment '创建者',
+    `reviser`         varchar(64)         not null comment '更新者',
+    `created_at`      timestamp           not null default current_timestamp comment '该记录创建的时间',
+    `updated_at`      timestamp           not null default current_timestamp on update current_timestamp comment '该记录更新的时间',
+    primary key (`id`),
+    unique key `idx_uk_subscription_id_event_offset` (`subscription_id`, `event_offset`),
+    index `idx_state_next_retry_at` (`state`, `next_retry_at`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='webhook投递记录表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('webhook_subscription', '0'),
+       ('webhook_delivery', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0057' as `sql_ver`;
+
+COMMIT;
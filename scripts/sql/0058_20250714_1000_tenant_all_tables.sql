@@ -0,0 +1,433 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0058,HCMVER=v1.7.5
+
+    Notes:
+    1. 除平台级数据表（ID生成器、异步任务、事件消费位点、地域、可用区、镜像、汇率、全局配置）外，其余业务表新增租户ID字段
+       tenant_id，并添加索引，存量数据归属默认租户
+    2. 资源关系视图 resource_relation 新增租户ID字段 tenant_id
+*/
+
+START TRANSACTION;
+
+--  1. 业务表新增租户ID字段tenant_id，并添加索引
+alter table account_bill_adjustment_item
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table account_bill_budget
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table account_bill_config
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table account_bill_daily_pull_task
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table account_bill_item
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table account_bill_month_task
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table account_bill_reconciliation
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table account_bill_summary_daily
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table account_bill_summary_main
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table account_bill_summary_root
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table account_bill_summary_version
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table account_bill_sync_record
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table account_biz_rel
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table account_group
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table account_group_rel
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table account_sync_detail
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table application
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table approval_process
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table argument_template
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table audit
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table aws_route
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table aws_security_group_rule
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table azure_resource_group
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table azure_route
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table azure_security_group_rule
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table biz_quota
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table biz_res_whitelist
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table cloud_selection_biz_type
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table cloud_selection_idc
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table cloud_selection_scheme
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table cmdb_host_rel
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table compliance_exemption
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table compliance_finding
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table cvm_schedule
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table cvm_schedule_record
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table cvm_template
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table disk_cvm_rel
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table eip_cvm_rel
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table gcp_firewall_rule
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table gcp_route
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table huawei_route
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table huawei_security_group_rule
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table idle_resource
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table landing_zone_deployment
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table load_balancer
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table load_balancer_listener
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table load_balancer_target
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table load_balancer_target_group
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table main_account
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table maintenance_window
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table naming_rule
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table naming_violation
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table network_interface
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table notification_subscription
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table notification_template
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table recycle_record
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table resource_flow_lock
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table resource_flow_rel
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table resource_history
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table resource_tag
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table root_account
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table root_account_bill_config
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table route_table
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table saved_filter
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table security_group_common_rel
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table security_group_cvm_rel
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table ssl_cert
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table sub_account
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table tag_policy
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table tag_violation
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table target_group_listener_rule_rel
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table task_detail
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table task_management
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table tcloud_lb_url_rule
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table tcloud_route
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table tcloud_security_group_rule
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table user_collection
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table user_recent_view
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table webhook_delivery
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+alter table webhook_subscription
+    add column tenant_id varchar(64) not null default 'default' comment '租户ID',
+    add index idx_tenant_id (tenant_id);
+
+--  2. 资源关系视图新增租户ID字段
+create or replace view `resource_relation`(`src_type`, `src_id`, `dst_type`, `dst_id`, `tenant_id`) as
+select 'cvm', `cvm_id`, 'disk', `disk_id`, `tenant_id`
+from `disk_cvm_rel`
+union all
+select 'cvm', `cvm_id`, 'eip', `eip_id`, `tenant_id`
+from `eip_cvm_rel`
+union all
+select distinct 'cvm', `cvm_id`, 'security_group', `security_group_id`, `tenant_id`
+from (select `cvm_id`, `security_group_id`, `tenant_id`
+      from `security_group_cvm_rel`
+      union
+      select `res_id` as `cvm_id`, `security_group_id`, `tenant_id`
+      from `security_group_common_rel`
+      where `res_type` = 'cvm') as `sg_rel`
+union all
+select 'subnet', `id`, 'vpc', `vpc_id`, `tenant_id`
+from `subnet`
+where `vpc_id` != ''
+union all
+select 'route_table', `id`, 'vpc', `vpc_id`, `tenant_id`
+from `route_table`
+where `vpc_id` != ''
+union all
+select 'subnet', `id`, 'route_table', `route_table_id`, `tenant_id`
+from `subnet`
+where `route_table_id` is not null
+  and `route_table_id` != ''
+union all
+select 'cvm', `cvm`.`id`, 'subnet', `jt`.`subnet_id`, `cvm`.`tenant_id`
+from `cvm`,
+     json_table(`cvm`.`subnet_ids`, '$[*]' columns (`subnet_id` varchar(64) path '$')) as `jt`
+where `jt`.`subnet_id` != ''
+union all
+select distinct 'route_table',
+                `route_table_id`,
+                case `gateway_type`
+                    when 'NAT' then 'nat_gateway'
+                    when 'VPN' then 'vpn_gateway'
+                    else 'vpc_peering' end,
+                `cloud_gateway_id`,
+                `tenant_id`
+from `tcloud_route`
+where `gateway_type` in ('NAT', 'VPN', 'PEERCONNECTION')
+  and `cloud_gateway_id` != ''
+union all
+select distinct 'route_table', `route_table_id`, 'nat_gateway', `cloud_nat_gateway_id`, `tenant_id`
+from `aws_route`
+where `cloud_nat_gateway_id` != ''
+union all
+select distinct 'route_table', `route_table_id`, 'vpc_peering', `cloud_vpc_peering_connection_id`, `tenant_id`
+from `aws_route`
+where `cloud_vpc_peering_connection_id` != ''
+union all
+select distinct 'route_table', `route_table_id`, 'vpn_gateway', `cloud_gateway_id`, `tenant_id`
+from `aws_route`
+where `cloud_gateway_id` like 'vgw-%'
+union all
+select distinct 'route_table',
+                `route_table_id`,
+                case `type`
+                    when 'nat' then 'nat_gateway'
+                    when 'vpn' then 'vpn_gateway'
+                    else 'vpc_peering' end,
+                `nexthop`,
+                `tenant_id`
+from `huawei_route`
+where `type` in ('nat', 'vpn', 'peering')
+  and `nexthop` != ''
+union all
+select distinct 'route_table', `route_table_id`, 'vpc_peering', `next_hop_peering`, `tenant_id`
+from `gcp_route`
+where `next_hop_peering` != ''
+union all
+select distinct 'route_table', `route_table_id`, 'vpn_gateway', `next_hop_vpn_tunnel`, `tenant_id`
+from `gcp_route`
+where `next_hop_vpn_tunnel` != '';
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0058' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,331 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0059,HCMVER=v1.7.5
+
+    Notes:
+    1. 租户隔离的业务表的唯一键增加租户ID字段tenant_id，使不同租户可以纳管相同的云资源、使用相同的名称
+    2. 账单明细分表（account_bill_item_{vendor}_{yyyymm}）由data-service在执行sql迁移时补充tenant_id字段，
+       未开启自动迁移的环境需参照account_bill_item表手动为已存在的分表添加该字段
+*/
+
+START TRANSACTION;
+
+-- 1. 租户隔离的业务表的唯一键增加租户ID字段tenant_id
+alter table account
+    drop index `idx_uk_name`,
+    add unique key `idx_uk_name` (tenant_id, name);
+
+alter table account_bill_budget
+    drop index `idx_uk_name`,
+    add unique key `idx_uk_name` (tenant_id, name);
+
+alter table account_bill_config
+    drop index `idx_uk_vendor_account_id`,
+    add unique key `idx_uk_vendor_account_id` (tenant_id, vendor, account_id);
+
+alter table account_bill_daily_pull_task
+    drop index `idx_bill_date_version`,
+    add unique key `idx_bill_date_version` (tenant_id, root_account_id, main_account_id, bill_year, bill_month, bill_day, version_id);
+
+alter table account_bill_month_task
+    drop index `idx_root_account_id_year_month_type`,
+    add unique key `idx_root_account_id_year_month_type` (tenant_id, root_account_id, bill_year, bill_month, type);
+
+alter table account_bill_summary_daily
+    drop index `idx_bill_date_version`,
+    add unique key `idx_bill_date_version` (tenant_id, root_account_id, main_account_id, bill_year, bill_month, bill_day, version_id);
+
+alter table account_bill_summary_main
+    drop index `idx_bill_date`,
+    add unique key `idx_bill_date` (tenant_id, root_account_id, main_account_id, bill_year, bill_month);
+
+alter table account_bill_summary_root
+    drop index `idx_bill_date`,
+    add unique key `idx_bill_date` (tenant_id, root_account_id, bill_year, bill_month);
+
+alter table account_bill_summary_version
+    drop index `idx_bill_date_version`,
+    add unique key `idx_bill_date_version` (tenant_id, first_account_id, second_account_id, bill_year, bill_month, version_id);
+
+alter table account_biz_rel
+    drop index `idx_uk_bk_biz_id_account_id`,
+    add unique key `idx_uk_bk_biz_id_account_id` (tenant_id, bk_biz_id, account_id);
+
+alter table account_group
+    drop index `idx_uk_name`,
+    add unique key `idx_uk_name` (tenant_id, name);
+
+alter table account_group_rel
+    drop index `idx_uk_group_id_account_type_account_id`,
+    add unique key `idx_uk_group_id_account_type_account_id` (tenant_id, group_id, account_type, account_id);
+
+alter table account_sync_detail
+    drop index `idx_uk_vendor_account_id_res_name`,
+    add unique key `idx_uk_vendor_account_id_res_name` (tenant_id, vendor, account_id, res_name);
+
+alter table application
+    drop index `idx_uk_source_sn`,
+    add unique key `idx_uk_source_sn` (tenant_id, source, sn);
+
+alter table approval_process
+    drop index `idx_uk_type`,
+    add unique key `idx_uk_type` (tenant_id, application_type);
+
+alter table argument_template
+    drop index `idx_uk_bk_biz_id_cloud_id`,
+    add unique key `idx_uk_bk_biz_id_cloud_id` (tenant_id, bk_biz_id, cloud_id);
+
+alter table aws_route
+    drop index `idx_uk_route_table_id_destination_cidr_block`,
+    add unique key `idx_uk_route_table_id_destination_cidr_block` (tenant_id, route_table_id, destination_cidr_block);
+
+alter table aws_route
+    drop index `idx_uk_route_table_id_destination_ipv6_cidr_block`,
+    add unique key `idx_uk_route_table_id_destination_ipv6_cidr_block` (tenant_id, route_table_id, destination_ipv6_cidr_block);
+
+alter table aws_route
+    drop index `idx_uk_route_table_id_cloud_dest_prefix_list_id`,
+    add unique key `idx_uk_route_table_id_cloud_dest_prefix_list_id` (tenant_id, route_table_id, cloud_destination_prefix_list_id);
+
+alter table aws_security_group_rule
+    drop index `idx_uk_cloud_id`,
+    add unique key `idx_uk_cloud_id` (tenant_id, cloud_id);
+
+alter table azure_route
+    drop index `idx_cloud_id`,
+    add unique key `idx_cloud_id` (tenant_id, cloud_id);
+
+alter table azure_route
+    drop index `idx_uk_route_table_id_name`,
+    add unique key `idx_uk_route_table_id_name` (tenant_id, route_table_id, name);
+
+alter table azure_route
+    drop index `idx_uk_route_table_id_address_prefix`,
+    add unique key `idx_uk_route_table_id_address_prefix` (tenant_id, route_table_id, address_prefix);
+
+alter table azure_security_group_rule
+    drop index `idx_uk_cloud_id`,
+    add unique key `idx_uk_cloud_id` (tenant_id, cloud_id);
+
+alter table azure_security_group_rule
+    drop index `idx_uk_name_cloud_security_group_id`,
+    add unique key `idx_uk_name_cloud_security_group_id` (tenant_id, name, cloud_security_group_id);
+
+alter table biz_quota
+    drop index `idx_uk_bk_biz_id`,
+    add unique key `idx_uk_bk_biz_id` (tenant_id, bk_biz_id);
+
+alter table biz_res_whitelist
+    drop index `idx_uk_bk_biz_id_vendor`,
+    add unique key `idx_uk_bk_biz_id_vendor` (tenant_id, bk_biz_id, vendor);
+
+alter table cloud_selection_biz_type
+    drop index `idx_uk_biz_type`,
+    add unique key `idx_uk_biz_type` (tenant_id, biz_type);
+
+alter table cloud_selection_idc
+    drop index `idx_uk_bk_biz_id_name`,
+    add unique key `idx_uk_bk_biz_id_name` (tenant_id, bk_biz_id, name);
+
+alter table cloud_selection_scheme
+    drop index `idx_uk_bk_biz_id_name`,
+    add unique key `idx_uk_bk_biz_id_name` (tenant_id, bk_biz_id, name);
+
+alter table cmdb_host_rel
+    drop index `idx_uk_cvm_id`,
+    add unique key `idx_uk_cvm_id` (tenant_id, cvm_id);
+
+alter table compliance_exemption
+    drop index `idx_uk_rule_res_id`,
+    add unique key `idx_uk_rule_res_id` (tenant_id, rule, res_id);
+
+alter table compliance_finding
+    drop index `idx_uk_rule_res_id`,
+    add unique key `idx_uk_rule_res_id` (tenant_id, rule, res_id);
+
+alter table cvm
+    drop index `idx_uk_cloud_id_vendor`,
+    add unique key `idx_uk_cloud_id_vendor` (tenant_id, cloud_id, vendor);
+
+alter table cvm_schedule
+    drop index `idx_uk_name`,
+    add unique key `idx_uk_name` (tenant_id, name);
+
+alter table cvm_template
+    drop index `idx_uk_name`,
+    add unique key `idx_uk_name` (tenant_id, name);
+
+alter table disk_cvm_rel
+    drop index `idx_uk_disk_id_cvm_id`,
+    add unique key `idx_uk_disk_id_cvm_id` (tenant_id, disk_id, cvm_id);
+
+alter table eip
+    drop index `idx_uk_cloud_id_vendor`,
+    add unique key `idx_uk_cloud_id_vendor` (tenant_id, cloud_id, vendor);
+
+alter table eip_cvm_rel
+    drop index `idx_uk_eip_id_cvm_id`,
+    add unique key `idx_uk_eip_id_cvm_id` (tenant_id, eip_id, cvm_id);
+
+alter table gcp_firewall_rule
+    drop index `idx_uk_cloud_id`,
+    add unique key `idx_uk_cloud_id` (tenant_id, cloud_id);
+
+alter table gcp_firewall_rule
+    drop index `idx_uk_account_id_name`,
+    add unique key `idx_uk_account_id_name` (tenant_id, account_id, name);
+
+alter table gcp_route
+    drop index `idx_uk_cloud_id`,
+    add unique key `idx_uk_cloud_id` (tenant_id, cloud_id);
+
+alter table huawei_route
+    drop index `idx_uk_route_table_id_destination`,
+    add unique key `idx_uk_route_table_id_destination` (tenant_id, route_table_id, destination);
+
+alter table huawei_security_group_rule
+    drop index `idx_uk_cloud_id`,
+    add unique key `idx_uk_cloud_id` (tenant_id, cloud_id);
+
+alter table idle_resource
+    drop index `idx_uk_res_type_res_id`,
+    add unique key `idx_uk_res_type_res_id` (tenant_id, res_type, res_id);
+
+alter table load_balancer
+    drop index `idx_uk_cloud_id_vendor_region`,
+    add unique key `idx_uk_cloud_id_vendor_region` (tenant_id, cloud_id, vendor, region);
+
+alter table load_balancer_listener
+    drop index `idx_uk_cloud_id_vendor_region`,
+    add unique key `idx_uk_cloud_id_vendor_region` (tenant_id, cloud_id, vendor, region);
+
+alter table load_balancer_target
+    drop index `idx_uk_cloud_target_group_id_ip_port_target_group_region`,
+    add unique key `idx_uk_cloud_target_group_id_ip_port_target_group_region` (tenant_id, cloud_target_group_id, ip, port, target_group_region);
+
+alter table load_balancer_target_group
+    drop index `idx_uk_cloud_id_vendor_region`,
+    add unique key `idx_uk_cloud_id_vendor_region` (tenant_id, cloud_id, vendor, region);
+
+alter table main_account
+    drop index `idx_uk_cloud_id_vendor`,
+    add unique key `idx_uk_cloud_id_vendor` (tenant_id, cloud_id, vendor);
+
+alter table naming_rule
+    drop index `idx_uk_bk_biz_id_res_type`,
+    add unique key `idx_uk_bk_biz_id_res_type` (tenant_id, bk_biz_id, res_type);
+
+alter table naming_violation
+    drop index `idx_uk_res_type_res_id`,
+    add unique key `idx_uk_res_type_res_id` (tenant_id, res_type, res_id);
+
+alter table network_interface
+    drop index `idx_uk_cloud_id_vendor`,
+    add unique key `idx_uk_cloud_id_vendor` (tenant_id, cloud_id, vendor);
+
+alter table resource_flow_rel
+    drop index `idx_uk_res_id_flow_id`,
+    add unique key `idx_uk_res_id_flow_id` (tenant_id, res_id, res_type, flow_id);
+
+alter table resource_tag
+    drop index `idx_uk_res_type_res_id_tag_key`,
+    add unique key `idx_uk_res_type_res_id_tag_key` (tenant_id, res_type, res_id, tag_key);
+
+alter table root_account
+    drop index `idx_uk_cloud_id_vendor`,
+    add unique key `idx_uk_cloud_id_vendor` (tenant_id, cloud_id, vendor);
+
+alter table root_account_bill_config
+    drop index `idx_uk_vendor_account_id`,
+    add unique key `idx_uk_vendor_account_id` (tenant_id, vendor, root_account_id);
+
+alter table route_table
+    drop index `idx_uk_cloud_id_vendor`,
+    add unique key `idx_uk_cloud_id_vendor` (tenant_id, cloud_id, vendor);
+
+alter table security_group
+    drop index `idx_uk_cloud_id_vendor`,
+    add unique key `idx_uk_cloud_id_vendor` (tenant_id, cloud_id, vendor);
+
+alter table security_group_common_rel
+    drop index `idx_uk_vendor_res_type_res_id_sg_id`,
+    add unique key `idx_uk_vendor_res_type_res_id_sg_id` (tenant_id, vendor, res_type, res_id, security_group_id);
+
+alter table security_group_cvm_rel
+    drop index `idx_uk_security_group_id_cvm_id`,
+    add unique key `idx_uk_security_group_id_cvm_id` (tenant_id, security_group_id, cvm_id);
+
+alter table ssl_cert
+    drop index `idx_uk_bk_biz_id_cloud_id`,
+    add unique key `idx_uk_bk_biz_id_cloud_id` (tenant_id, bk_biz_id, cloud_id);
+
+alter table sub_account
+    drop index `idx_uk_vendor_account_id_cloud_id`,
+    add unique key `idx_uk_vendor_account_id_cloud_id` (tenant_id, vendor, account_id, cloud_id);
+
+alter table subnet
+    drop index `idx_uk_cloud_id_vendor`,
+    add unique key `idx_uk_cloud_id_vendor` (tenant_id, cloud_id, vendor);
+
+alter table tag_policy
+    drop index `idx_uk_bk_biz_id_res_type_tag_key`,
+    add unique key `idx_uk_bk_biz_id_res_type_tag_key` (tenant_id, bk_biz_id, res_type, tag_key);
+
+alter table tag_violation
+    drop index `idx_uk_res_type_res_id_tag_key`,
+    add unique key `idx_uk_res_type_res_id_tag_key` (tenant_id, res_type, res_id, tag_key);
+
+alter table target_group_listener_rule_rel
+    drop index `idx_uk_target_group_id_listener_rule_id_listener_rule_type`,
+    add unique key `idx_uk_target_group_id_listener_rule_id_listener_rule_type` (tenant_id, target_group_id, listener_rule_id, listener_rule_type);
+
+alter table tcloud_lb_url_rule
+    drop index `idx_uk_cloud_id_cloud_lbl_id_region`,
+    add unique key `idx_uk_cloud_id_cloud_lbl_id_region` (tenant_id, cloud_id, cloud_lbl_id, region);
+
+alter table tcloud_route
+    drop index `idx_uk_cloud_route_table_id_cloud_id`,
+    add unique key `idx_uk_cloud_route_table_id_cloud_id` (tenant_id, cloud_route_table_id, cloud_id);
+
+alter table tcloud_security_group_rule
+    drop index `idx_uk_cloud_security_group_id_cloud_policy_index_type`,
+    add unique key `idx_uk_cloud_security_group_id_cloud_policy_index_type` (tenant_id, cloud_security_group_id, cloud_policy_index, type);
+
+alter table user_collection
+    drop index `idx_uk_user_res_type_res_id`,
+    add unique key `idx_uk_user_res_type_res_id` (tenant_id, user, res_type, res_id);
+
+alter table user_recent_view
+    drop index `idx_uk_user_res_type_res_id`,
+    add unique key `idx_uk_user_res_type_res_id` (tenant_id, user, res_type, res_id);
+
+alter table vpc
+    drop index `idx_uk_cloud_id_vendor`,
+    add unique key `idx_uk_cloud_id_vendor` (tenant_id, cloud_id, vendor);
+
+alter table webhook_delivery
+    drop index `idx_uk_subscription_id_event_offset`,
+    add unique key `idx_uk_subscription_id_event_offset` (tenant_id, subscription_id, event_offset);
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.5' as `hcm_ver`, '0059' as `sql_ver`;
+
+COMMIT;